@@ -0,0 +1,65 @@
+package adminstats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/worker/periodic"
+)
+
+const (
+	// DefaultRefreshInterval は全体統計の再集計間隔の既定値。
+	DefaultRefreshInterval = 10 * time.Minute
+	// FetchAttemptRetention はフェッチ結果の保持期間。
+	// 統計は直近 24 時間分のみを使うが、障害調査のため 7 日分を残す。
+	FetchAttemptRetention = 7 * 24 * time.Hour
+)
+
+// RefreshJob は全体統計を定期的に再集計する worker ジョブ。
+// 再集計の前に保持期間を過ぎたフェッチ結果を削除する。
+type RefreshJob struct {
+	stats    repository.AdminStatsRepository
+	attempts repository.FetchAttemptRepository
+	logger   *slog.Logger
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewRefreshJob は RefreshJob を生成する。interval が 0 以下の場合は DefaultRefreshInterval を使う。
+func NewRefreshJob(stats repository.AdminStatsRepository, attempts repository.FetchAttemptRepository, logger *slog.Logger, interval time.Duration) *RefreshJob {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	return &RefreshJob{stats: stats, attempts: attempts, logger: logger, interval: interval, now: time.Now}
+}
+
+// RunOnce は古いフェッチ結果を削除した上で全体統計を再集計する。
+// 削除に失敗しても再集計は行う（統計は直近 24 時間分のみを参照するため結果に影響しない）。
+func (j *RefreshJob) RunOnce(ctx context.Context) error {
+	start := j.now()
+
+	deleted, err := j.attempts.DeleteFetchAttemptsBefore(ctx, start.Add(-FetchAttemptRetention))
+	if err != nil {
+		j.logger.Warn("古いフェッチ結果の削除に失敗しました",
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if err := j.stats.RefreshAdminStats(ctx); err != nil {
+		return fmt.Errorf("全体統計の再集計に失敗: %w", err)
+	}
+
+	j.logger.Info("全体統計を再集計しました",
+		slog.Int64("deleted_fetch_attempts", deleted),
+		slog.Float64("duration_ms", float64(j.now().Sub(start).Milliseconds())),
+	)
+	return nil
+}
+
+// Start は起動直後と interval ごとに RunOnce を実行する。ctx がキャンセルされるまでブロックする。
+func (j *RefreshJob) Start(ctx context.Context) {
+	periodic.Run(ctx, j.logger, "全体統計の集計", j.interval, j.RunOnce)
+}
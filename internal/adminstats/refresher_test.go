@@ -0,0 +1,114 @@
+package adminstats
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockAdminStatsRepo は repository.AdminStatsRepository のモック実装。
+type mockAdminStatsRepo struct {
+	getFn        func(ctx context.Context) (*model.AdminStats, error)
+	refreshErr   error
+	refreshCalls int
+}
+
+func (m *mockAdminStatsRepo) GetAdminStats(ctx context.Context) (*model.AdminStats, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx)
+	}
+	return &model.AdminStats{}, nil
+}
+
+func (m *mockAdminStatsRepo) RefreshAdminStats(_ context.Context) error {
+	m.refreshCalls++
+	return m.refreshErr
+}
+
+// mockFetchAttemptRepo は repository.FetchAttemptRepository のモック実装。
+type mockFetchAttemptRepo struct {
+	deleteBefore time.Time
+	deleteErr    error
+}
+
+func (m *mockFetchAttemptRepo) RecordFetchAttempt(_ context.Context, _ model.FetchAttempt) error {
+	return nil
+}
+
+func (m *mockFetchAttemptRepo) DeleteFetchAttemptsBefore(_ context.Context, before time.Time) (int64, error) {
+	m.deleteBefore = before
+	return 3, m.deleteErr
+}
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+}
+
+func TestRefreshJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 6, 9, 12, 0, 0, 0, time.UTC)
+
+	t.Run("保持期間より古いフェッチ結果を削除してから再集計する", func(t *testing.T) {
+		// Arrange
+		stats := &mockAdminStatsRepo{}
+		attempts := &mockFetchAttemptRepo{}
+		job := NewRefreshJob(stats, attempts, newTestLogger(), 0)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if want := now.Add(-FetchAttemptRetention); !attempts.deleteBefore.Equal(want) {
+			t.Errorf("削除基準 = %v, want %v", attempts.deleteBefore, want)
+		}
+		if stats.refreshCalls != 1 {
+			t.Errorf("RefreshAdminStats calls = %d, want 1", stats.refreshCalls)
+		}
+	})
+
+	t.Run("削除に失敗しても再集計する", func(t *testing.T) {
+		// Arrange
+		stats := &mockAdminStatsRepo{}
+		job := NewRefreshJob(stats, &mockFetchAttemptRepo{deleteErr: errors.New("db error")}, newTestLogger(), 0)
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if stats.refreshCalls != 1 {
+			t.Errorf("RefreshAdminStats calls = %d, want 1", stats.refreshCalls)
+		}
+	})
+
+	t.Run("再集計に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		stats := &mockAdminStatsRepo{refreshErr: errors.New("db error")}
+		job := NewRefreshJob(stats, &mockFetchAttemptRepo{}, newTestLogger(), 0)
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err == nil {
+			t.Error("RunOnce() error = nil, want error")
+		}
+	})
+}
+
+func TestNewRefreshJob_DefaultInterval(t *testing.T) {
+	job := NewRefreshJob(&mockAdminStatsRepo{}, &mockFetchAttemptRepo{}, newTestLogger(), 0)
+	if job.interval != DefaultRefreshInterval {
+		t.Errorf("interval = %v, want %v", job.interval, DefaultRefreshInterval)
+	}
+}
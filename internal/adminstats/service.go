@@ -0,0 +1,33 @@
+// Package adminstats は管理者向けのインスタンス全体統計を提供する。
+//
+// ユーザー数・記事数などの全件集計は重いため、API リクエストごとには行わない。
+// worker の RefreshJob が admin_stats マテリアライズドビューを定期的に再集計し、
+// Service はそのスナップショットを返す。
+package adminstats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service は管理者向け全体統計のサービス層。
+type Service struct {
+	repo repository.AdminStatsRepository
+}
+
+// NewService は Service を生成する。
+func NewService(repo repository.AdminStatsRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetStats は最後に集計した全体統計を返す。
+func (s *Service) GetStats(ctx context.Context) (*model.AdminStats, error) {
+	stats, err := s.repo.GetAdminStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("全体統計の取得に失敗しました: %w", err)
+	}
+	return stats, nil
+}
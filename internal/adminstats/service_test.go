@@ -0,0 +1,58 @@
+package adminstats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestService_GetStats(t *testing.T) {
+	t.Run("リポジトリのスナップショットを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockAdminStatsRepo{
+			getFn: func(context.Context) (*model.AdminStats, error) {
+				return &model.AdminStats{UserCount: 3, FetchAttempts24h: 4, FetchSuccesses24h: 3}, nil
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.GetStats(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("GetStats() error = %v", err)
+		}
+		if got.UserCount != 3 {
+			t.Errorf("UserCount = %d, want 3", got.UserCount)
+		}
+		if rate := got.FetchSuccessRate24h(); rate == nil || *rate != 0.75 {
+			t.Errorf("FetchSuccessRate24h = %v, want 0.75", rate)
+		}
+	})
+
+	t.Run("リポジトリのエラーをラップして返す", func(t *testing.T) {
+		// Arrange
+		repoErr := errors.New("db error")
+		svc := NewService(&mockAdminStatsRepo{
+			getFn: func(context.Context) (*model.AdminStats, error) { return nil, repoErr },
+		})
+
+		// Act
+		_, err := svc.GetStats(context.Background())
+
+		// Assert
+		if !errors.Is(err, repoErr) {
+			t.Errorf("err = %v, want wrapping %v", err, repoErr)
+		}
+	})
+}
+
+func TestAdminStats_FetchSuccessRate24h_NoAttempts(t *testing.T) {
+	stats := &model.AdminStats{}
+	if rate := stats.FetchSuccessRate24h(); rate != nil {
+		t.Errorf("FetchSuccessRate24h = %v, want nil", *rate)
+	}
+}
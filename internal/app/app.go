@@ -2,34 +2,60 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/hitoshi/feedman/internal/adminstats"
+	"github.com/hitoshi/feedman/internal/audit"
 	"github.com/hitoshi/feedman/internal/auth"
+	"github.com/hitoshi/feedman/internal/cache"
 	"github.com/hitoshi/feedman/internal/config"
 	"github.com/hitoshi/feedman/internal/crossfeed"
 	"github.com/hitoshi/feedman/internal/database"
 	"github.com/hitoshi/feedman/internal/feed"
+	"github.com/hitoshi/feedman/internal/feedtitle"
 	"github.com/hitoshi/feedman/internal/handler"
 	"github.com/hitoshi/feedman/internal/hatebu"
+	"github.com/hitoshi/feedman/internal/highlight"
+	"github.com/hitoshi/feedman/internal/importfilter"
+	"github.com/hitoshi/feedman/internal/integration"
 	"github.com/hitoshi/feedman/internal/item"
 	"github.com/hitoshi/feedman/internal/itemsearch"
+	"github.com/hitoshi/feedman/internal/keyword"
 	"github.com/hitoshi/feedman/internal/logger"
+	"github.com/hitoshi/feedman/internal/mail"
 	"github.com/hitoshi/feedman/internal/metrics"
 	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/moderation"
+	"github.com/hitoshi/feedman/internal/notification"
+	"github.com/hitoshi/feedman/internal/profile"
+	"github.com/hitoshi/feedman/internal/readlater"
 	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/retention"
 	"github.com/hitoshi/feedman/internal/security"
+	"github.com/hitoshi/feedman/internal/stats"
 	"github.com/hitoshi/feedman/internal/subscription"
+	"github.com/hitoshi/feedman/internal/summarizer"
+	"github.com/hitoshi/feedman/internal/team"
+	"github.com/hitoshi/feedman/internal/usage"
+	"github.com/hitoshi/feedman/internal/user"
+	"github.com/hitoshi/feedman/internal/usersettings"
 	"github.com/hitoshi/feedman/internal/worker/cleanup"
 	fetchpkg "github.com/hitoshi/feedman/internal/worker/fetch"
+	"github.com/hitoshi/feedman/internal/worker/linkcheck"
 )
 
 // Init はアプリケーションの初期化を行う。
@@ -63,6 +89,11 @@ func Run(w io.Writer, args []string) error {
 		return runHealthcheck(port)
 	}
 
+	// config validate は設定の検証のみを行い、DB には接続しない
+	if cmd == CommandConfigValidate {
+		return runConfigValidate(w, configValidatePath(args))
+	}
+
 	cfg, err := Init(w)
 	if err != nil {
 		return fmt.Errorf("initialization failed: %w", err)
@@ -78,20 +109,79 @@ func Run(w io.Writer, args []string) error {
 	case CommandServe:
 		return runServe(cfg)
 	case CommandWorker:
+		if commandFlag(args, "dry-run") {
+			return runWorkerDryRun(cfg)
+		}
 		return runWorker(cfg)
 	case CommandMigrate:
 		return runMigrate(cfg)
+	case CommandMigrateSessions:
+		return runMigrateSessions(cfg)
+	case CommandSeed:
+		return runSeed(cfg, seedGoogleUserID(args))
+	case CommandBackup:
+		return runBackup(cfg, commandPathArg(args, "out"))
+	case CommandRestore:
+		return runRestore(cfg, commandPathArg(args, "in"))
+	case CommandDoctor:
+		return runDoctor(w, cfg)
 	default:
 		return runServe(cfg)
 	}
 }
 
+// configValidatePath は `config validate [path]` の引数から検証対象の設定ファイルのパスを返す。
+// 省略時は FEEDMAN_CONFIG_FILE の値を返す。
+func configValidatePath(args []string) string {
+	if len(args) >= 3 {
+		return args[2]
+	}
+	return os.Getenv(config.ConfigFileEnv)
+}
+
+// runConfigValidate は path の設定ファイルと環境変数から設定を読み込んで検証し、結果を w に出力する。
+// 問題がある場合はすべてを列挙したうえでエラーを返す（終了コード 1）。
+func runConfigValidate(w io.Writer, path string) error {
+	logger.SetupDefault(w)
+
+	cfg, err := config.LoadFile(path)
+	var verr *config.ValidationError
+	if errors.As(err, &verr) {
+		for _, p := range verr.Problems {
+			fmt.Fprintf(w, "- %s\n", p)
+		}
+		return fmt.Errorf("config validation failed: %d problem(s)", len(verr.Problems))
+	}
+	if err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+
+	source := "environment variables only"
+	if path != "" {
+		source = path + " + environment variables"
+	}
+	fmt.Fprintf(w, "configuration is valid (%s)\n", source)
+	if cfg.DatabaseSchema != "" {
+		fmt.Fprintf(w, "  database: configured (schema=%s)\n", cfg.DatabaseSchema)
+	} else {
+		fmt.Fprintf(w, "  database: configured\n")
+	}
+	fmt.Fprintf(w, "  auth: session_store=%s\n", cfg.SessionStore)
+	fmt.Fprintf(w, "  fetcher: timeout=%s max_size=%d max_concurrent=%d interval=%s max_items=%d\n",
+		cfg.FetchTimeout, cfg.FetchMaxSize, cfg.FetchMaxConcurrent, cfg.FetchInterval, cfg.FetchMaxItems)
+	fmt.Fprintf(w, "  hatebu: ttl=%s batch_interval=%s max_calls_per_cycle=%d\n",
+		cfg.HatebuTTL, cfg.HatebuBatchInterval, cfg.HatebuMaxCallsPerCycle)
+	fmt.Fprintf(w, "  rate_limit: general=%d feed_registration=%d unauth_ip=%d\n",
+		cfg.RateLimitGeneral, cfg.RateLimitFeedReg, cfg.RateLimitUnauthIP)
+	return nil
+}
+
 // runServe はAPIサーバーモードで起動する。
 // DB接続を開き、全依存関係をワイヤリングし、HTTPサーバーを起動する。
 // SIGINTまたはSIGTERMシグナルを受信するとグレースフルシャットダウンを行う。
 func runServe(cfg *config.Config) error {
 	// 1. DB接続
-	db, err := database.Open(cfg.DatabaseURL)
+	db, err := openDatabase(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -106,16 +196,39 @@ func runServe(cfg *config.Config) error {
 	// 2. リポジトリの初期化
 	userRepo := repository.NewPostgresUserRepo(db)
 	identRepo := repository.NewPostgresIdentityRepo(db)
-	sessionRepo := repository.NewPostgresSessionRepo(db)
+	sessionRepo, closeSessionStore, err := newSessionStore(context.Background(), cfg, db)
+	if err != nil {
+		return fmt.Errorf("failed to initialize session store: %w", err)
+	}
+	defer closeSessionStore()
+	slog.Info("session store initialized", slog.String("store", cfg.SessionStore))
 	feedRepo := repository.NewPostgresFeedRepo(db)
 	subRepo := repository.NewPostgresSubscriptionRepo(db)
 	itemRepo := repository.NewPostgresItemRepo(db)
 	itemStateRepo := repository.NewPostgresItemStateRepo(db)
 	userCrossFeedViewRepo := repository.NewPostgresUserCrossFeedViewRepo(db)
+	publicProfileRepo := repository.NewPostgresPublicProfileRepo(db)
+	userSettingsRepo := repository.NewPostgresUserSettingsRepo(db)
+	itemViewRepo := repository.NewPostgresItemViewRepo(db)
+	importFilterRepo := repository.NewPostgresSubscriptionImportFilterRepo(db)
+	subUndoRepo := repository.NewPostgresSubscriptionUndoRepo(db)
+	fetchAttemptRepo := repository.NewPostgresFetchAttemptRepo(db)
+	adminStatsRepo := repository.NewPostgresAdminStatsRepo(db)
+	auditLogRepo := repository.NewPostgresAuditLogRepo(db)
+	teamRepo := repository.NewPostgresTeamRepo(db)
 
 	// 3. セキュリティサービスの初期化
-	ssrfGuard := security.NewSSRFGuard()
-	sanitizer := security.NewContentSanitizer()
+	// フィードの取得には管理者が設定した許可リストの宛先に限りプライベート IP への接続を許す
+	ssrfGuard, err := newFetchSSRFGuard(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SSRF guard: %w", err)
+	}
+	// スター記事の保存先の認証情報の暗号化。READ_LATER_ENCRYPTION_KEY が未設定の場合は nil（連携を無効化）。
+	readLaterBox, err := newReadLaterSecretBox(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize read later encryption: %w", err)
+	}
+	sanitizer := security.SharedContentSanitizer()
 
 	// 4. ドメインサービスの初期化
 	oauthProvider := auth.NewGoogleOAuthProvider(auth.GoogleOAuthConfig{
@@ -123,21 +236,43 @@ func runServe(cfg *config.Config) error {
 		ClientSecret: cfg.GoogleClientSecret,
 		RedirectURL:  cfg.GoogleRedirectURL,
 	})
+	// ログイン成功・失敗・ログアウトを接続元（部分マスク）とともに記録する（閲覧は GET /api/users/me/login-history）。
+	loginEventService := audit.NewLoginEventService(repository.NewPostgresLoginEventRepo(db))
+	authOpts := []auth.ServiceOption{auth.WithLoginEventRecorder(loginEventService)}
+	// マジックリンク認証。SMTP_HOST が未設定の場合は nil（/auth/magic-link を登録しない）。
+	magicLinkSender, err := newMagicLinkSender(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize mail sender: %w", err)
+	}
+	if magicLinkSender != nil {
+		authOpts = append(authOpts, auth.WithMagicLink(
+			repository.NewPostgresLoginTokenRepo(db), magicLinkSender,
+			strings.TrimRight(cfg.BaseURL, "/")+"/auth/magic-link/verify",
+		))
+	}
 	authService := auth.NewService(
 		oauthProvider, userRepo, identRepo, sessionRepo,
 		auth.ServiceConfig{SessionMaxAge: cfg.SessionMaxAge},
+		authOpts...,
 	)
+	var magicLinkService handler.MagicLinkServiceInterface
+	if magicLinkSender != nil {
+		magicLinkService = authService
+	}
 
-	feedDetector := feed.NewFeedDetector(ssrfGuard)
-	faviconFetcher := feed.NewFaviconFetcher(ssrfGuard)
-	feedService := feed.NewFeedService(feedRepo, subRepo, feedDetector, faviconFetcher)
+	// インスタンス管理者が設定するフィードのブロックリスト。フィードの登録・自動検出・フェッチ再開で照合する。
+	blocklistService := moderation.NewService(repository.NewPostgresBlockedDomainRepo(db))
 
-	itemService := item.NewItemService(itemRepo, itemStateRepo)
+	feedDetector := feed.NewFeedDetector(ssrfGuard, feed.WithDetectorBlocklist(blocklistService))
+	faviconFetcher := feed.NewFaviconFetcher(ssrfGuard)
 
 	// 横断新着一覧サービス（Issue #121）。itemRepo の ListNewAcrossFeeds と
 	// userCrossFeedViewRepo の Get / Upsert を利用する。
 	crossFeedService := crossfeed.NewService(itemRepo, userCrossFeedViewRepo)
 
+	// 購読リストの公開プロフィール共有サービス。
+	publicProfileService := profile.NewService(publicProfileRepo)
+
 	// serve 専用の Prometheus registry と Collector を生成する。
 	// Collector は手動フェッチ系のカウンタ（feedman_manual_fetch_total）も保持しており、
 	// subscription.Service.ManualFetch から記録される（Issue #115 Req 8.x）。
@@ -145,14 +280,125 @@ func runServe(cfg *config.Config) error {
 	serveRegistry := prometheus.NewRegistry()
 	serveCollector := metrics.NewCollector(serveRegistry)
 
+	// 購読一覧（未読数を含む）のユーザー単位短期キャッシュ。書き込み系のサービスから
+	// ユーザー単位で無効化し、ヒット率は feedman_cache_requests_total に記録する。
+	subListCache := cache.Instrument[[]subscription.SubscriptionInfo](
+		cache.NewMemory[[]subscription.SubscriptionInfo](subscription.DefaultListCacheTTL),
+		subscription.ListCacheName, serveCollector,
+	)
+	subListInvalidator := subscription.NewListCacheInvalidator(subListCache)
+
+	// 購読・購読解除・購読設定の変更を監査ログに記録する（閲覧は GET /api/audit-logs）。
+	auditService := audit.NewService(auditLogRepo)
+
+	feedService := feed.NewFeedService(
+		feedRepo, subRepo, feedDetector, faviconFetcher,
+		feed.WithCacheInvalidator(subListInvalidator),
+		feed.WithAuditRecorder(auditService),
+		feed.WithBlocklist(blocklistService),
+		feed.WithSSRFValidator(ssrfGuard),
+	)
+
+	// 購読ゼロのユーザー向けの URL 一括登録。検出・登録は非同期に進め、結果は feed_registration_batches に記録する。
+	feedBatchService := handler.NewFeedBatchServiceAdapter(
+		feed.NewBatchRegistrationService(feedService, repository.NewPostgresFeedRegistrationBatchRepo(db), slog.Default()),
+	)
+
+	// 登録前のフィード URL のプリフライト。登録と同じ検出器・ブロックリストで検証し、フィード・購読は作成しない。
+	feedPreflightService := handler.NewFeedPreflightServiceAdapter(
+		feed.NewPreflightService(feedDetector, feed.WithPreflightBlocklist(blocklistService)),
+	)
+
+	// ユーザー設定サービス（積読警告の閾値）。
+	userSettingsService := usersettings.NewService(userSettingsRepo, usersettings.WithCacheInvalidator(subListInvalidator))
+
+	// 記事詳細の閲覧イベントはリクエストと切り離して非同期にバッチ保存する。
+	// シャットダウン時は HTTP リクエストの drain 後に停止し、残りのイベントを保存する。
+	viewRecorder := stats.NewViewRecorder(itemViewRepo, stats.DefaultViewRecorderConfig(), slog.Default())
+	viewRecorderCtx, stopViewRecorder := context.WithCancel(context.Background())
+	viewRecorderDone := make(chan struct{})
+	go func() {
+		viewRecorder.Run(viewRecorderCtx)
+		close(viewRecorderDone)
+	}()
+	// API 利用量も同様にメモリ上で集計し、定期的にカウンタテーブルへ加算する。
+	usageRepo := repository.NewPostgresAPIUsageRepo(db)
+	usageRecorder := usage.NewRecorder(usageRepo, usage.DefaultFlushInterval, slog.Default())
+	usageRecorderCtx, stopUsageRecorder := context.WithCancel(context.Background())
+	usageRecorderDone := make(chan struct{})
+	go func() {
+		usageRecorder.Run(usageRecorderCtx)
+		close(usageRecorderDone)
+	}()
+	// 週次トレンドは worker が記録したスナップショットを返す。
+	statsService := stats.NewService(itemViewRepo,
+		stats.WithWeeklyStatsRepository(repository.NewPostgresWeeklyStatsRepo(db)),
+	)
+
+	// 記事詳細の本文リンクにはユーザー設定（新しいタブで開くか）を反映する。
+	itemOpts := []item.ItemServiceOption{
+		item.WithLinkPreference(userSettingsService),
+		item.WithTimezoneResolver(userSettingsService),
+		item.WithViewRecorder(viewRecorder),
+		item.WithAuthorRepository(itemRepo),
+		item.WithListModTimeRepository(itemRepo),
+		item.WithSubscribedFeedRepository(subRepo),
+	}
+	// 記事詳細の表示時に古いはてブ数を非同期で再取得し、次回の表示に反映する（HATEBU_ON_DEMAND_STALE_AFTER=0 で無効）。
+	// 依頼はベストエフォートのため、シャットダウン時に残った分は破棄して定期バッチに任せる。
+	stopHatebuRefresher := func() {}
+	if cfg.HatebuOnDemandStaleAfter > 0 {
+		hatebuRefresher := hatebu.NewOnDemandRefresher(itemRepo,
+			hatebu.NewClient(&http.Client{Timeout: 10 * time.Second}, slog.Default()),
+			slog.Default(),
+			hatebu.OnDemandConfig{
+				StaleAfter:  cfg.HatebuOnDemandStaleAfter,
+				APIInterval: cfg.HatebuAPIInterval,
+			},
+		)
+		hatebuRefresherCtx, cancelHatebuRefresher := context.WithCancel(context.Background())
+		go hatebuRefresher.Run(hatebuRefresherCtx)
+		stopHatebuRefresher = cancelHatebuRefresher
+		itemOpts = append(itemOpts, item.WithHatebuRefresher(hatebuRefresher))
+	}
+	itemService := item.NewItemService(itemRepo, itemStateRepo, itemOpts...)
+
+	// 記事の要約生成。要約 API が未設定の場合はローカルの抽出型要約器を用い、
+	// 設定されている場合は API の失敗時にローカルの要約器へフォールバックする。
+	var sum summarizer.Summarizer = summarizer.NewLocalSummarizer(0)
+	summaryOpts := []item.SummaryServiceOption{item.WithSummaryRatePerHour(cfg.SummarizerRatePerHour)}
+	if cfg.SummarizerAPIURL != "" {
+		sum = summarizer.NewAPISummarizer(&http.Client{Timeout: cfg.SummarizerTimeout},
+			cfg.SummarizerAPIURL, cfg.SummarizerAPIKey, cfg.SummarizerModel)
+		summaryOpts = append(summaryOpts, item.WithSummaryFallback(summarizer.NewLocalSummarizer(0)))
+	}
+	summaryService := item.NewSummaryService(itemRepo, itemRepo, sum, summaryOpts...)
+
 	// 手動フェッチ用の Fetcher を組み立てる（Issue #115 task 6.1）。
 	// worker 側 (runWorker) と同じ依存配線パターンで、SSRFGuard / Sanitizer / UpsertSvc /
 	// Fetcher を構築する。タイムアウト・最大サイズも自動経路と同一の cfg 値を使う（NFR 1.1）。
+	// 購読単位の取り込みフィルタも自動経路と同じく UPSERT の前段で適用する。
+	importFilterService := importfilter.NewService(importFilterRepo)
 	upsertSvc := item.NewItemUpsertService(itemRepo, sanitizer, item.WithMetrics(serveCollector))
+	feedURLSuggestionRepo := repository.NewPostgresFeedURLSuggestionRepo(db)
+	// 手動フェッチで取り込んだ新着記事も、自動経路と同じく転送キューへ積む。
+	integrationRepo := repository.NewPostgresIntegrationRepo(db)
+	// 管理者がデバッグモードを有効にしたフィードは、手動フェッチでもレスポンスを保存する。
+	feedRawCaptureRepo := repository.NewPostgresFeedRawCaptureRepo(db)
 	fetcher := fetchpkg.NewFetcher(
 		feedRepo, subRepo, upsertSvc, ssrfGuard,
 		slog.Default(), cfg.FetchTimeout, cfg.FetchMaxSize,
 		fetchpkg.WithMetrics(serveCollector),
+		fetchpkg.WithItemFilter(importFilterService),
+		fetchpkg.WithAttemptRecorder(fetchAttemptRepo),
+		fetchpkg.WithMaxItems(cfg.FetchMaxItems),
+		fetchpkg.WithFeedRediscovery(feedDetector, feedURLSuggestionRepo),
+		fetchpkg.WithNewItemNotifier(integrationRepo),
+		fetchpkg.WithSubscriberIntervalPolicy(subRepo, fetchSubscriberIntervalPolicy(cfg)),
+		fetchpkg.WithDormantIntervalPolicy(subRepo, fetchDormantIntervalPolicy(cfg)),
+		fetchpkg.WithRawCaptureStore(feedRawCaptureRepo),
+		fetchpkg.WithBlocklist(blocklistService),
+		fetchpkg.WithTitleChangeRecorder(repository.NewPostgresFeedTitleRepo(db)),
 	)
 
 	// 記事検索ドメインサービス。itemRepo を ItemSearchRepository として、subRepo を
@@ -168,6 +414,16 @@ func runServe(cfg *config.Config) error {
 	subService := subscription.NewService(
 		subRepo, itemStateRepo, feedRepo,
 		fetcher, manualFetchTxBeginner, serveCollector,
+		subscription.WithListCache(subListCache),
+		subscription.WithUndo(subUndoRepo, cfg.UnsubscribeUndoWindow),
+		subscription.WithAuditRecorder(auditService),
+		subscription.WithOrder(repository.NewPostgresSubscriptionOrderRepo(db)),
+		subscription.WithExpiry(repository.NewPostgresSubscriptionExpiryRepo(db)),
+		subscription.WithFeedURLSuggestion(feedURLSuggestionRepo),
+		subscription.WithBlocklist(blocklistService),
+		subscription.WithSSRFValidator(ssrfGuard),
+		subscription.WithListModTime(subRepo),
+		subscription.WithPostSchedule(itemRepo),
 	)
 	userService := newTxUserService(txBeginner, userRepo, sessionRepo, subRepo, itemStateRepo)
 
@@ -175,12 +431,68 @@ func runServe(cfg *config.Config) error {
 	subServiceAdapter := handler.NewSubscriptionServiceAdapter(subService)
 	userServiceAdapter := handler.NewUserServiceAdapter(userService)
 	itemServiceAdapter := handler.NewItemServiceAdapter(itemService)
-	itemStateServiceAdapter := handler.NewItemStateServiceAdapter(itemStateRepo)
+	itemStateServiceAdapter := handler.NewItemStateServiceAdapter(itemStateRepo, subListInvalidator)
+	itemVisitServiceAdapter := handler.NewItemVisitServiceAdapter(item.NewItemVisitService(itemRepo, itemStateRepo), subListInvalidator)
+	syncServiceAdapter := handler.NewSyncServiceAdapter(item.NewSyncService(itemRepo, itemStateRepo), subListInvalidator)
 	itemSearchServiceAdapter := handler.NewItemSearchServiceAdapter(itemSearchService)
 	crossFeedServiceAdapter := handler.NewCrossFeedServiceAdapter(crossFeedService)
+	publicProfileServiceAdapter := handler.NewPublicProfileServiceAdapter(publicProfileService)
+	userSettingsServiceAdapter := handler.NewUserSettingsServiceAdapter(userSettingsService)
+	importFilterServiceAdapter := handler.NewImportFilterServiceAdapter(importFilterService)
+	// 購読単位の最大記事保持数。設定の適用（記事の削除）は worker のクリーンアップジョブが行う。
+	retentionServiceAdapter := handler.NewRetentionServiceAdapter(
+		retention.NewService(repository.NewPostgresSubscriptionRetentionRepo(db)),
+	)
+	// 購読単位の通知ヒント（優先度・ミュート期限）。購読一覧に含めるため更新時に一覧キャッシュを無効化する。
+	notificationServiceAdapter := handler.NewNotificationServiceAdapter(
+		notification.NewService(
+			repository.NewPostgresSubscriptionNotificationRepo(db),
+			notification.WithCacheInvalidator(subListInvalidator),
+		),
+	)
+	// フィードタイトルの自動更新ポリシー。タイトルの反映はフェッチワーカーがポリシーに従って行う。
+	// タイトルと承認待ちタイトルは購読一覧に含めるため更新時に一覧キャッシュを無効化する。
+	feedTitleServiceAdapter := handler.NewFeedTitleServiceAdapter(
+		feedtitle.NewService(
+			repository.NewPostgresFeedTitleRepo(db),
+			feedtitle.WithCacheInvalidator(subListInvalidator),
+		),
+	)
+	// Slack / Discord への新着記事の転送設定。配送はワーカーの DeliveryJob が行う。
+	integrationServiceAdapter := handler.NewIntegrationServiceAdapter(
+		integration.NewService(integrationRepo),
+	)
+	// スター記事の Pocket / Instapaper への自動保存。READ_LATER_ENCRYPTION_KEY が未設定の場合は無効にする。
+	// スター操作（記事状態の更新・オフライン同期）の直後に保存キューへ積み、保存はワーカーの DeliveryJob が行う。
+	var itemStateService handler.ItemStateServiceInterface = itemStateServiceAdapter
+	var syncService handler.SyncServiceInterface = syncServiceAdapter
+	var readLaterService handler.ReadLaterServiceInterface
+	if readLaterBox != nil {
+		readLaterRepo := repository.NewPostgresReadLaterRepo(db)
+		pocket, instapaper := newReadLaterClients(cfg)
+		var opts []readlater.Option
+		if pocket != nil {
+			opts = append(opts, readlater.WithPocket(pocket, strings.TrimRight(cfg.BaseURL, "/")+"/api/read-later/pocket/callback"))
+		}
+		if instapaper != nil {
+			opts = append(opts, readlater.WithInstapaper(instapaper))
+		}
+		readLaterService = handler.NewReadLaterServiceAdapter(readlater.NewService(readLaterRepo, readLaterBox, opts...))
+		itemStateService = handler.NewStarNotifyingItemStateService(itemStateService, readLaterRepo)
+		syncService = handler.NewStarNotifyingSyncService(syncService, readLaterRepo)
+	}
+	// 「何か読む」向けのランダム記事取り出し。itemRepo を RandomItemRepository として使う。
+	randomItemServiceAdapter := handler.NewRandomItemServiceAdapter(crossfeed.NewRandomService(itemRepo))
+	statsServiceAdapter := handler.NewStatsServiceAdapter(statsService)
+	// フィードのパース診断（管理者向け）。フェッチワーカーと同じタイムアウト・最大サイズで取得する。
+	feedDebugServiceAdapter := handler.NewFeedDebugServiceAdapter(
+		fetchpkg.NewDiagnoser(ssrfGuard, cfg.FetchTimeout, cfg.FetchMaxSize),
+	)
+	// 全体統計（管理者向け）。集計は worker が定期的に行い、ここではスナップショットを返すのみ。
+	adminStatsServiceAdapter := handler.NewAdminStatsServiceAdapter(adminstats.NewService(adminStatsRepo))
 
 	// 6. SubscriptionDeleterアダプタの構築
-	subDeleterAdapter := handler.NewSubscriptionDeleterAdapter(subRepo, itemStateRepo)
+	subDeleterAdapter := handler.NewSubscriptionDeleterAdapter(subRepo, itemStateRepo, auditService, subListInvalidator)
 
 	// 7. ルーターの構築
 	rateLimiterCfg := middleware.DefaultRateLimiterConfig()
@@ -200,13 +512,18 @@ func runServe(cfg *config.Config) error {
 	)
 
 	deps := &handler.RouterDeps{
-		HealthChecker:       db,
-		SessionFinder:       sessionRepo,
-		CORSAllowedOrigin:   cfg.CORSAllowedOrigin,
-		RateLimiter:         rateLimiter,
-		UnauthIPRateLimiter: unauthIPRateLimiter,
-		HSTSEnabled:         cfg.HSTSEnabled,
-		Logger:              slog.Default(),
+		HealthChecker:        db,
+		SessionFinder:        sessionRepo,
+		CORSAllowedOrigin:    cfg.CORSAllowedOrigin,
+		CORSAllowedOrigins:   cfg.CORSAllowedOrigins,
+		CORSExtensionOrigins: cfg.CORSExtensionOrigins,
+		RateLimiter:          rateLimiter,
+		UnauthIPRateLimiter:  unauthIPRateLimiter,
+		HSTSEnabled:          cfg.HSTSEnabled,
+		MaxJSONBodyBytes:     cfg.MaxJSONBodyBytes,
+		PageLimits:           handler.PageLimitConfig{Default: cfg.APIPageLimitDefault, Max: cfg.APIPageLimitMax},
+		IdempotencyStore:     repository.NewPostgresIdempotencyKeyRepo(db),
+		Logger:               slog.Default(),
 
 		MetricsHandler:    metrics.SetupMetricsRoute(serveRegistry),
 		MetricsMiddleware: middleware.NewTrustedCIDRMiddleware(cfg.TrustedCIDRs),
@@ -223,7 +540,11 @@ func runServe(cfg *config.Config) error {
 		SubscriptionDeleter: subDeleterAdapter,
 
 		ItemService:      itemServiceAdapter,
-		ItemStateService: itemStateServiceAdapter,
+		ItemStateService: itemStateService,
+		ItemVisitService: itemVisitServiceAdapter,
+		SyncService:      syncService,
+
+		ItemSummaryService: handler.NewItemSummaryServiceAdapter(summaryService),
 
 		ItemSearchService: itemSearchServiceAdapter,
 
@@ -231,6 +552,55 @@ func runServe(cfg *config.Config) error {
 		UserService:         userServiceAdapter,
 
 		CrossFeedService: crossFeedServiceAdapter,
+
+		PublicProfileService: publicProfileServiceAdapter,
+
+		ImportFilterService: importFilterServiceAdapter,
+
+		RetentionService: retentionServiceAdapter,
+
+		NotificationService: notificationServiceAdapter,
+
+		FeedTitleService: feedTitleServiceAdapter,
+
+		IntegrationService: integrationServiceAdapter,
+
+		ReadLaterService: readLaterService,
+
+		FeedBatchService:     feedBatchService,
+		FeedPreflightService: feedPreflightService,
+		MagicLinkService:     magicLinkService,
+
+		RandomItemService: randomItemServiceAdapter,
+
+		UserSettingsService: userSettingsServiceAdapter,
+
+		StatsService: statsServiceAdapter,
+
+		HighlightService: handler.NewHighlightServiceAdapter(highlight.NewService(repository.NewPostgresHighlightRepo(db))),
+		KeywordService:   handler.NewKeywordServiceAdapter(keyword.NewService(repository.NewPostgresFeedKeywordRepo(db))),
+
+		UsageRecorder: usageRecorder,
+		UsageService:  handler.NewUsageServiceAdapter(usage.NewService(usageRepo)),
+
+		// 最終アクティブ日時はユーザーごとに間引いて記録し、休眠から復帰したユーザーの購読フィードを即時取得させる。
+		ActivityTracker: user.NewActivityTracker(userRepo, user.DefaultActivityThrottle, cfg.UserDormantAfter, slog.Default()),
+
+		RelatedFeedService:  handler.NewRelatedFeedServiceAdapter(feedService),
+		AuditLogService:     handler.NewAuditLogServiceAdapter(auditService),
+		LoginHistoryService: handler.NewLoginHistoryServiceAdapter(loginEventService),
+		// アカウント連携の解除時は、その連携で発行したセッションも失効させる。
+		IdentityService: handler.NewIdentityServiceAdapter(auth.NewIdentityService(identRepo, sessionRepo)),
+		TeamService: handler.NewTeamServiceAdapter(
+			team.NewService(teamRepo, subRepo, team.WithCacheInvalidator(subListInvalidator)),
+		),
+
+		FeedDebugService:     feedDebugServiceAdapter,
+		AdminStatsService:    adminStatsServiceAdapter,
+		FeedAdminService:     handler.NewFeedAdminServiceAdapter(feedRepo, feedRawCaptureRepo),
+		WorkerCycleService:   handler.NewWorkerCycleServiceAdapter(repository.NewPostgresWorkerCycleRepo(db)),
+		BlockedDomainService: handler.NewBlockedDomainServiceAdapter(blocklistService),
+		AdminUserIDs:         cfg.AdminUserIDs,
 	}
 
 	router := handler.NewRouter(deps)
@@ -266,8 +636,17 @@ func runServe(cfg *config.Config) error {
 	// グレースフルシャットダウン: 稼働中リクエストの drain 完了後に
 	// RateLimiter のクリーンアップ goroutine を停止する（高々 1 回）。
 	coordinator := newShutdownCoordinator(server, rateLimiter, unauthIPRateLimiter)
-	if err := coordinator.shutdown(ctx); err != nil {
-		return err
+	shutdownErr := coordinator.shutdown(ctx)
+
+	// リクエストの drain 後に閲覧イベント・API 利用量の記録を停止し、未保存の分を保存させる。
+	stopViewRecorder()
+	<-viewRecorderDone
+	stopUsageRecorder()
+	<-usageRecorderDone
+	stopHatebuRefresher()
+
+	if shutdownErr != nil {
+		return shutdownErr
 	}
 
 	slog.Info("API server stopped gracefully")
@@ -279,7 +658,7 @@ func runServe(cfg *config.Config) error {
 // SIGINTまたはSIGTERMシグナルを受信するとシャットダウンする。
 func runWorker(cfg *config.Config) error {
 	// 1. DB接続
-	db, err := database.Open(cfg.DatabaseURL)
+	db, err := openDatabase(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -295,10 +674,23 @@ func runWorker(cfg *config.Config) error {
 	feedRepo := repository.NewPostgresFeedRepo(db)
 	subRepo := repository.NewPostgresSubscriptionRepo(db)
 	itemRepo := repository.NewPostgresItemRepo(db)
+	importFilterRepo := repository.NewPostgresSubscriptionImportFilterRepo(db)
+	fetchAttemptRepo := repository.NewPostgresFetchAttemptRepo(db)
+	adminStatsRepo := repository.NewPostgresAdminStatsRepo(db)
+	workerCycleRepo := repository.NewPostgresWorkerCycleRepo(db)
+	weeklyStatsRepo := repository.NewPostgresWeeklyStatsRepo(db)
+	itemStateRepo := repository.NewPostgresItemStateRepo(db)
+	subUndoRepo := repository.NewPostgresSubscriptionUndoRepo(db)
+	auditLogRepo := repository.NewPostgresAuditLogRepo(db)
+	integrationRepo := repository.NewPostgresIntegrationRepo(db)
 
 	// 3. セキュリティサービスの初期化
-	ssrfGuard := security.NewSSRFGuard()
-	sanitizer := security.NewContentSanitizer()
+	// フィードの取得には管理者が設定した許可リストの宛先に限りプライベート IP への接続を許す
+	ssrfGuard, err := newFetchSSRFGuard(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SSRF guard: %w", err)
+	}
+	sanitizer := security.SharedContentSanitizer()
 
 	// 4. worker 専用の registry と Collector を生成し、各レイヤへ注入する。
 	// フェッチ／UPSERT は worker プロセスで実行されるため、フェッチ系メトリクスは
@@ -307,32 +699,126 @@ func runWorker(cfg *config.Config) error {
 	collector := metrics.NewCollector(workerRegistry)
 
 	// 5. フェッチャーの初期化（WithMetrics で Collector を注入）
+	// 購読単位の取り込みフィルタに一致しない記事は UPSERT の前段で除外する。
 	upsertSvc := item.NewItemUpsertService(itemRepo, sanitizer, item.WithMetrics(collector))
+	blocklistService := moderation.NewService(repository.NewPostgresBlockedDomainRepo(db))
 	fetcher := fetchpkg.NewFetcher(
 		feedRepo, subRepo, upsertSvc, ssrfGuard,
 		slog.Default(), cfg.FetchTimeout, cfg.FetchMaxSize,
 		fetchpkg.WithMetrics(collector),
+		fetchpkg.WithItemFilter(importfilter.NewService(importFilterRepo)),
+		fetchpkg.WithAttemptRecorder(fetchAttemptRepo),
+		fetchpkg.WithMaxItems(cfg.FetchMaxItems),
+		// 404/410 で停止したフィードはサイトから新しいフィード URL を再検出し、張り替えを提案する。
+		// ブロックリストに一致するフィード URL は提案しない。
+		fetchpkg.WithFeedRediscovery(
+			feed.NewFeedDetector(ssrfGuard, feed.WithDetectorBlocklist(blocklistService)),
+			repository.NewPostgresFeedURLSuggestionRepo(db),
+		),
+		// プリフェッチを有効にした購読者がいるフィードは、次回フェッチをその利用時間帯の直前に前倒しする。
+		fetchpkg.WithPrefetch(repository.NewPostgresItemViewRepo(db)),
+		// Slack / Discord 連携が設定された購読の新着記事は配送キューへ積む。
+		fetchpkg.WithNewItemNotifier(integrationRepo),
+		// 購読者の少ないフィードほど次回フェッチまでの間隔を延ばす（上限 12 時間）。
+		fetchpkg.WithSubscriberIntervalPolicy(subRepo, fetchSubscriberIntervalPolicy(cfg)),
+		// 休眠ユーザーのみが購読するフィードは次回フェッチまでの間隔を大幅に延ばす（復帰時は API サーバーが前倒しする）。
+		fetchpkg.WithDormantIntervalPolicy(subRepo, fetchDormantIntervalPolicy(cfg)),
+		// 管理者がデバッグモードを有効にしたフィードは、直近 1 回分のレスポンスを保存する。
+		fetchpkg.WithRawCaptureStore(repository.NewPostgresFeedRawCaptureRepo(db)),
+		// ブロックリストに一致するフィードはフェッチせずに停止する（遡及適用を選ばなかった追加にも効く）。
+		fetchpkg.WithBlocklist(blocklistService),
+		// フィードタイトルが変わったら、タイトルの自動更新ポリシーが initial / manual の購読に反映する。
+		fetchpkg.WithTitleChangeRecorder(repository.NewPostgresFeedTitleRepo(db)),
 	)
 
 	// 6. スケジューラの起動
+	// サイクルごとの結果は worker_cycles に記録し、管理者向け API で直近の履歴を返す。
+	// 同一ホストのフィードは 1 件ずつ FETCH_HOST_INTERVAL の間隔を空けて取得する。
 	scheduler := fetchpkg.NewScheduler(
 		feedRepo, fetcher, slog.Default(), cfg.FetchMaxConcurrent,
+		fetchpkg.WithCycleRecorder(workerCycleRepo),
+		fetchpkg.WithHostMinInterval(cfg.FetchHostInterval),
+		// 1 ホストがサイクル間隔を超えてワーカーを占有しないよう、持ち時間をフェッチ間隔に合わせる
+		fetchpkg.WithHostDeadline(cfg.FetchInterval),
 	)
 
 	// 7. クリーンアップジョブの初期化
 	cleanupJob := cleanup.NewCleanupJob(db, slog.Default())
 
 	// 8. はてなブックマークバッチジョブの初期化
-	hatebuClient := hatebu.NewClient(
-		&http.Client{Timeout: 10 * time.Second},
-		slog.Default(),
+	hatebuBatch := newHatebuBatchJob(cfg, itemRepo)
+
+	// 9. 管理者向け全体統計の集計ジョブの初期化
+	adminStatsJob := adminstats.NewRefreshJob(adminStatsRepo, fetchAttemptRepo, slog.Default(), cfg.AdminStatsRefreshInterval)
+
+	// 10. スター記事のリンク切れチェックジョブの初期化
+	linkCheckConfig := linkcheck.DefaultConfig()
+	linkCheckConfig.Interval = cfg.LinkCheckInterval
+	linkCheckConfig.BatchSize = cfg.LinkCheckBatchSize
+	linkCheckJob := linkcheck.NewJob(itemRepo, ssrfGuard, slog.Default(), linkCheckConfig)
+
+	// 11. 週次統計のスナップショットジョブの初期化
+	weeklySnapshotJob := stats.NewWeeklySnapshotJob(weeklyStatsRepo, slog.Default(), cfg.WeeklyStatsSnapshotInterval)
+
+	// 12. お試し購読の期限切れ解除ジョブの初期化
+	// 解除は API の購読解除と同じく取り消し用スナップショットを残し、監査ログに記録する。
+	// worker は購読一覧キャッシュを持たないため、API 側のキャッシュへの反映は TTL の経過に委ねる。
+	trialUnsubscriber := subscription.NewService(
+		subRepo, itemStateRepo, feedRepo, nil, nil, nil,
+		subscription.WithUndo(subUndoRepo, cfg.UnsubscribeUndoWindow),
+		subscription.WithAuditRecorder(audit.NewService(auditLogRepo)),
+	)
+	trialExpiryJob := subscription.NewTrialExpiryJob(
+		repository.NewPostgresSubscriptionExpiryRepo(db), trialUnsubscriber, slog.Default(), cfg.TrialExpiryInterval,
 	)
-	hatebuBatch := hatebu.NewBatchJob(itemRepo, hatebuClient, slog.Default(), hatebu.BatchConfig{
-		BatchInterval:    cfg.HatebuBatchInterval,
-		APIInterval:      cfg.HatebuAPIInterval,
-		MaxCallsPerCycle: cfg.HatebuMaxCallsPerCycle,
-		HatebuTTL:        cfg.HatebuTTL,
-	})
+
+	// 13. Slack / Discord への新着記事の配送ジョブの初期化
+	// 宛先は連携設定の作成時に各サービスの Webhook に限定済みだが、送信も SSRF 防止付きのクライアントで行う。
+	// 外部サービスへの送信のため、フィード取得用の許可リストは適用しない。
+	integrationDeliveryJob := integration.NewDeliveryJob(integrationRepo, security.NewSSRFGuard(), slog.Default(), integration.DefaultDeliveryConfig())
+
+	// 14. スター記事の Pocket / Instapaper への保存ジョブの初期化
+	// READ_LATER_ENCRYPTION_KEY が未設定の場合は起動しない。送信は SSRF 防止付きのクライアントで行う。
+	readLaterBox, err := newReadLaterSecretBox(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize read later encryption: %w", err)
+	}
+	var readLaterDeliveryJob *readlater.DeliveryJob
+	if readLaterBox != nil {
+		savers := make(map[model.ReadLaterProvider]readlater.Saver)
+		pocket, instapaper := newReadLaterClients(cfg)
+		if pocket != nil {
+			savers[model.ReadLaterProviderPocket] = pocket
+		}
+		if instapaper != nil {
+			savers[model.ReadLaterProviderInstapaper] = instapaper
+		}
+		readLaterDeliveryJob = readlater.NewDeliveryJob(
+			repository.NewPostgresReadLaterRepo(db), readLaterBox, savers, slog.Default(), readlater.DefaultDeliveryConfig(),
+		)
+	}
+
+	// 15. 期限切れセッションの削除ジョブの初期化
+	// PostgreSQL のセッションストアでのみ期限切れの行を削除し、セッション失効をログイン履歴に記録する。
+	// Redis のセッションは TTL で消えるため、失効は記録しない。
+	var sessionExpiryJob *auth.SessionExpiryJob
+	if cfg.SessionStore == config.SessionStorePostgres {
+		sessionExpiryJob = auth.NewSessionExpiryJob(
+			repository.NewPostgresSessionRepo(db),
+			audit.NewLoginEventService(repository.NewPostgresLoginEventRepo(db)),
+			slog.Default(), auth.DefaultSessionExpiryInterval,
+		)
+	}
+
+	// 16. ハイライトの計算ジョブの初期化
+	highlightJob := highlight.NewComputeJob(
+		repository.NewPostgresHighlightRepo(db),
+		model.HighlightWeights{Hatebu: cfg.HighlightWeightHatebu, Star: cfg.HighlightWeightStar, View: cfg.HighlightWeightView},
+		slog.Default(), cfg.HighlightComputeInterval,
+	)
+
+	// 17. 購読フィードの頻出キーワードの計算ジョブの初期化
+	keywordJob := keyword.NewComputeJob(repository.NewPostgresFeedKeywordRepo(db), slog.Default(), cfg.KeywordComputeInterval)
 
 	// グレースフルシャットダウンのためのシグナルハンドリング
 	ctx, cancel := context.WithCancel(context.Background())
@@ -360,6 +846,37 @@ func runWorker(cfg *config.Config) error {
 	// はてなブックマークバッチジョブをバックグラウンドで起動
 	go hatebuBatch.Start(ctx)
 
+	// 全体統計の集計ジョブをバックグラウンドで起動
+	go adminStatsJob.Start(ctx)
+
+	// リンク切れチェックジョブをバックグラウンドで起動
+	go linkCheckJob.Start(ctx)
+
+	// 週次統計のスナップショットジョブをバックグラウンドで起動
+	go weeklySnapshotJob.Start(ctx)
+
+	// ハイライトの計算ジョブをバックグラウンドで起動
+	go highlightJob.Start(ctx)
+
+	// 頻出キーワードの計算ジョブをバックグラウンドで起動
+	go keywordJob.Start(ctx)
+
+	// お試し購読の期限切れ解除ジョブをバックグラウンドで起動
+	go trialExpiryJob.Start(ctx)
+
+	// Slack / Discord への新着記事の配送ジョブをバックグラウンドで起動
+	go integrationDeliveryJob.Start(ctx)
+
+	// スター記事の Pocket / Instapaper への保存ジョブをバックグラウンドで起動
+	if readLaterDeliveryJob != nil {
+		go readLaterDeliveryJob.Start(ctx)
+	}
+
+	// 期限切れセッションの削除ジョブをバックグラウンドで起動
+	if sessionExpiryJob != nil {
+		go sessionExpiryJob.Start(ctx)
+	}
+
 	// クリーンアップジョブを日次でバックグラウンド実行
 	go func() {
 		// 起動直後に1回実行
@@ -393,9 +910,18 @@ func runWorker(cfg *config.Config) error {
 func runMigrate(cfg *config.Config) error {
 	slog.Info("running database migrations",
 		slog.String("database_url", maskDatabaseURL(cfg.DatabaseURL)),
+		slog.String("database_schema", cfg.DatabaseSchema),
 	)
 
-	if err := database.RunMigrations(cfg.DatabaseURL); err != nil {
+	// 専用スキーマを使う場合は、マイグレーションの前にスキーマを作成しておく。
+	if err := database.EnsureSchema(context.Background(), cfg.DatabaseURL, cfg.DatabaseSchema); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	databaseURL, err := database.ConnectionURL(cfg.DatabaseURL, cfg.DatabaseSchema)
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	if err := database.RunMigrations(databaseURL, migrateOptions(cfg)...); err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
@@ -403,6 +929,49 @@ func runMigrate(cfg *config.Config) error {
 	return nil
 }
 
+// migrateOptions はオンライン実行のマイグレーションのタイムアウトとリトライの設定を返す。
+func migrateOptions(cfg *config.Config) []database.MigrateOption {
+	return []database.MigrateOption{
+		database.WithMigrateLockTimeout(cfg.MigrateLockTimeout),
+		database.WithMigrateStatementTimeout(cfg.MigrateStatementTimeout),
+		database.WithMigrateRetry(cfg.MigrateMaxAttempts, cfg.MigrateRetryInterval),
+	}
+}
+
+// seedGoogleUserID は `seed [google_user_id]` の引数からデモユーザーに紐付ける Google のユーザーIDを返す。
+// 省略時は空文字を返す。
+func seedGoogleUserID(args []string) string {
+	if len(args) >= 2 {
+		return args[1]
+	}
+	return ""
+}
+
+// runSeed はローカル開発・デモ用のユーザー・フィード・記事・既読状態を投入する。
+// 冪等なため繰り返し実行してよい。事前に migrate を実行しておくこと。
+func runSeed(cfg *config.Config, googleUserID string) error {
+	slog.Info("seeding demo data",
+		slog.String("database_url", maskDatabaseURL(cfg.DatabaseURL)),
+		slog.Bool("link_google_identity", googleUserID != ""),
+	)
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := database.Seed(ctx, db, database.SeedOptions{GoogleUserID: googleUserID}); err != nil {
+		return fmt.Errorf("seed failed: %w", err)
+	}
+
+	slog.Info("demo data seeded successfully")
+	return nil
+}
+
 // runHealthcheck はヘルスチェックを実行する。
 // distroless環境でのDockerヘルスチェック用サブコマンド。
 // /health エンドポイントにHTTPリクエストを送り、結果を返す。
@@ -423,6 +992,119 @@ func runHealthcheck(port string) error {
 	return nil
 }
 
+// newHatebuBatchJob は設定からはてなブックマークのバッチジョブを組み立てる。
+// worker とそのドライラン（worker --dry-run）で同じ設定を使う。
+func newHatebuBatchJob(cfg *config.Config, repo repository.HatebuItemRepository) *hatebu.BatchJob {
+	client := hatebu.NewClient(
+		&http.Client{Timeout: 10 * time.Second},
+		slog.Default(),
+	)
+	return hatebu.NewBatchJob(repo, client, slog.Default(), hatebu.BatchConfig{
+		BatchInterval:    cfg.HatebuBatchInterval,
+		APIInterval:      cfg.HatebuAPIInterval,
+		MaxCallsPerCycle: cfg.HatebuMaxCallsPerCycle,
+		HatebuTTL:        cfg.HatebuTTL,
+		CountCacheTTL:    cfg.HatebuCountCacheTTL,
+
+		PriorityRecencyWeight:    cfg.HatebuPriorityRecencyWeight,
+		PriorityPopularityWeight: cfg.HatebuPriorityPopularityWeight,
+		PriorityRecencyHalfLife:  cfg.HatebuPriorityRecencyHalfLife,
+	})
+}
+
+// fetchSubscriberIntervalPolicy は設定から購読者数に応じたフェッチ間隔の延長ポリシーを組み立てる。
+// 手動フェッチ（serve）と自動フェッチ（worker）で同じポリシーを使う。
+func fetchSubscriberIntervalPolicy(cfg *config.Config) fetchpkg.SubscriberIntervalPolicy {
+	return fetchpkg.SubscriberIntervalPolicy{
+		FullRateSubscribers: cfg.FetchFullRateSubscribers,
+		MaxExtension:        cfg.FetchMaxIntervalExtension,
+	}
+}
+
+// fetchDormantIntervalPolicy は設定から休眠ユーザーのみが購読するフィードのフェッチ間隔の延長ポリシーを組み立てる。
+// 手動フェッチ（serve）と自動フェッチ（worker）で同じポリシーを使う。
+func fetchDormantIntervalPolicy(cfg *config.Config) fetchpkg.DormantIntervalPolicy {
+	return fetchpkg.DormantIntervalPolicy{
+		DormantAfter: cfg.UserDormantAfter,
+		Interval:     cfg.FetchDormantInterval,
+	}
+}
+
+// newFetchSSRFGuard は設定の許可リスト（FETCH_SSRF_ALLOWLIST）とクライアント証明書を組み込んだ SSRFGuard を生成する。
+// フィードのフェッチ・検出・favicon 取得・リンク切れチェックで共有し、許可された宛先に限りプライベート IP への接続を許す。
+// FETCH_DNS_CACHE_TTL が正の場合は、ホスト名の解決結果（失敗を含む）をプロセス内で共有してキャッシュする。
+func newFetchSSRFGuard(cfg *config.Config) (security.SSRFGuardService, error) {
+	allowlist, err := security.ParseSSRFAllowlist(cfg.FetchSSRFAllowlist)
+	if err != nil {
+		return nil, err
+	}
+	opts := []security.SSRFGuardOption{security.WithAllowlist(allowlist)}
+	if cfg.FetchDNSCacheTTL > 0 {
+		opts = append(opts, security.WithDNSCache(security.NewDNSCache(cfg.FetchDNSCacheTTL, cfg.FetchDNSNegativeTTL)))
+	}
+	if cfg.FetchClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.FetchClientCertFile, cfg.FetchClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load fetch client certificate: %w", err)
+		}
+		opts = append(opts, security.WithAllowlistClientCertificate(cert))
+	}
+	return security.NewSSRFGuard(opts...), nil
+}
+
+// newReadLaterSecretBox は READ_LATER_ENCRYPTION_KEY から「あとで読む」サービスの認証情報を暗号化する SecretBox を生成する。
+// 鍵が未設定の場合は (nil, nil) を返し、連携 API と保存ジョブを無効にする。
+func newReadLaterSecretBox(cfg *config.Config) (*security.SecretBox, error) {
+	if cfg.ReadLaterEncryptionKey == "" {
+		return nil, nil
+	}
+	key, err := security.ParseSecretKey(cfg.ReadLaterEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return security.NewSecretBox(key)
+}
+
+// newMagicLinkSender は SMTP_HOST の SMTP サーバーでマジックリンクのメールを送る Sender を生成する。
+// SMTP_HOST が未設定の場合は (nil, nil) を返し、マジックリンク認証を無効にする。
+func newMagicLinkSender(cfg *config.Config) (*mail.SMTPSender, error) {
+	if cfg.SMTPHost == "" {
+		return nil, nil
+	}
+	return mail.NewSMTPSender(mail.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.MailFrom,
+	})
+}
+
+// newReadLaterClients は consumer の設定された保存先のクライアントを生成する。未設定の保存先は nil を返す。
+// 連携 API（serve）と保存ジョブ（worker）で共有し、いずれも SSRF 防止付きのクライアントで送信する。
+func newReadLaterClients(cfg *config.Config) (*readlater.PocketClient, *readlater.InstapaperClient) {
+	client := security.NewSSRFGuard().NewSafeClient(readlater.DefaultDeliveryConfig().RequestTimeout, 0)
+	var pocket *readlater.PocketClient
+	if cfg.PocketConsumerKey != "" {
+		pocket = readlater.NewPocketClient(cfg.PocketConsumerKey, "", client)
+	}
+	var instapaper *readlater.InstapaperClient
+	if cfg.InstapaperConsumerKey != "" {
+		instapaper = readlater.NewInstapaperClient(cfg.InstapaperConsumerKey, cfg.InstapaperConsumerSecret, "", client)
+	}
+	return pocket, instapaper
+}
+
+// openDatabase は設定に従ってデータベース接続を開く。
+// DATABASE_SCHEMA が指定されている場合は search_path をそのスキーマに向けて接続する。
+func openDatabase(cfg *config.Config) (*sql.DB, error) {
+	databaseURL, err := database.ConnectionURL(cfg.DatabaseURL, cfg.DatabaseSchema)
+	if err != nil {
+		return nil, err
+	}
+	return database.Open(databaseURL)
+}
+
 // maskDatabaseURL はデータベースURLの認証情報をマスクする。
 func maskDatabaseURL(url string) string {
 	if len(url) > 20 {
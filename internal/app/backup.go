@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/hitoshi/feedman/internal/config"
+	"github.com/hitoshi/feedman/internal/database"
+)
+
+// commandPathArg は `backup --out=path` / `restore --in=path` の引数から name で指定されたパスを返す。
+// `--name=path` と `--name path` の両方の書き方を受け付け、未指定の場合は空文字を返す。
+func commandPathArg(args []string, name string) string {
+	flag := "--" + name
+	for i := 1; i < len(args); i++ {
+		if v, ok := strings.CutPrefix(args[i], flag+"="); ok {
+			return v
+		}
+		if args[i] == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// backupSecretTables はダンプに含まれる機密情報を持つテーブル。
+// ログインを引き継げるセッションや、URL 自体が認証情報となる Webhook などを含むため、
+// バックアップ完了時にダンプの取り扱いを警告する際に列挙する。
+var backupSecretTables = []string{
+	"sessions",
+	"integrations",
+	"read_later_connections",
+	"login_tokens",
+	"team_invitations",
+}
+
+// runBackup は全テーブルの論理ダンプを outPath に書き出す。
+// 書き出し中のファイルは一時ファイルとし、完了後にリネームするため、中断しても不完全なダンプは残らない。
+func runBackup(cfg *config.Config, outPath string) error {
+	if outPath == "" {
+		return fmt.Errorf("backup requires --out=<path>")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	databaseURL, err := database.ConnectionURL(cfg.DatabaseURL, cfg.DatabaseSchema)
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	schemaVersion, err := database.SchemaVersion(databaseURL)
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+
+	slog.Info("starting backup",
+		slog.String("database_url", maskDatabaseURL(cfg.DatabaseURL)),
+		slog.String("out", outPath),
+		slog.Uint64("schema_version", uint64(schemaVersion)),
+	)
+
+	db, err := database.Open(databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	tmpPath := outPath + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	if err := database.Backup(ctx, db, f, schemaVersion); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	slog.Info("backup completed successfully", slog.String("out", outPath))
+	slog.Warn("backup file contains secrets such as sessions and webhook URLs; store it with restricted access",
+		slog.String("out", outPath),
+		slog.String("tables", strings.Join(backupSecretTables, ",")),
+	)
+	return nil
+}
+
+// runRestore は inPath の論理ダンプを空のデータベースに復元する。
+// ダンプ取得時のスキーマバージョンまでマイグレーションしてから行を投入し、
+// その後に残りのマイグレーションを適用することで、古いバージョンで取得したダンプも最新のスキーマへ移行できる。
+// 復元先が既にダンプより新しいスキーマの場合は、そのスキーマに存在する列だけを投入する。
+func runRestore(cfg *config.Config, inPath string) error {
+	if inPath == "" {
+		return fmt.Errorf("restore requires --in=<path>")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	f, err := os.Open(inPath)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer f.Close()
+
+	backup, err := database.OpenBackup(f)
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	defer backup.Close()
+	header := backup.Header()
+
+	latest, err := database.LatestMigrationVersion()
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	if header.SchemaVersion > latest {
+		return fmt.Errorf("restore failed: backup schema version %d is newer than this binary (%d)", header.SchemaVersion, latest)
+	}
+
+	slog.Info("starting restore",
+		slog.String("database_url", maskDatabaseURL(cfg.DatabaseURL)),
+		slog.String("in", inPath),
+		slog.Uint64("schema_version", uint64(header.SchemaVersion)),
+		slog.Time("backup_created_at", header.CreatedAt),
+	)
+
+	if err := database.EnsureSchema(ctx, cfg.DatabaseURL, cfg.DatabaseSchema); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	databaseURL, err := database.ConnectionURL(cfg.DatabaseURL, cfg.DatabaseSchema)
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	if err := database.MigrateUpTo(databaseURL, header.SchemaVersion, migrateOptions(cfg)...); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	db, err := database.Open(databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	result, err := backup.Restore(ctx, db)
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	for table, n := range result {
+		slog.Info("restored table", slog.String("table", table), slog.Int("rows", n))
+	}
+
+	if err := database.RunMigrations(databaseURL, migrateOptions(cfg)...); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	slog.Info("restore completed successfully")
+	return nil
+}
@@ -10,9 +10,27 @@ const (
 	CommandWorker Command = "worker"
 	// CommandMigrate はデータベースマイグレーションを実行することを示す。
 	CommandMigrate Command = "migrate"
+	// CommandMigrateSessions は PostgreSQL のセッションを Redis にコピーすることを示す。
+	// SESSION_STORE=redis へ切り替える前に実行する。
+	CommandMigrateSessions Command = "migrate-sessions"
+	// CommandSeed はローカル開発・デモ用のデータを冪等に投入することを示す。
+	// `feedman seed [Google のユーザーID]` で起動する。
+	CommandSeed Command = "seed"
+	// CommandBackup は全テーブルの論理ダンプをファイルに書き出すことを示す。
+	// `feedman backup --out=dump.json.gz` で起動する。
+	CommandBackup Command = "backup"
+	// CommandRestore は論理ダンプを空のデータベースに復元することを示す。
+	// `feedman restore --in=dump.json.gz` で起動する。
+	CommandRestore Command = "restore"
+	// CommandDoctor は DB のスキーマがコード側の期待スキーマと一致しているかを検査して結果を出力することを示す。
+	// `feedman doctor` で起動する。レポートのみで、スキーマは修正しない。
+	CommandDoctor Command = "doctor"
 	// CommandHealthcheck はヘルスチェックを実行することを示す。
 	// distroless環境でのDockerヘルスチェック用。
 	CommandHealthcheck Command = "healthcheck"
+	// CommandConfigValidate は設定（設定ファイルと環境変数）を検証して結果を出力することを示す。
+	// `feedman config validate [設定ファイルのパス]` で起動する。
+	CommandConfigValidate Command = "config validate"
 )
 
 // ParseCommand はコマンドライン引数からサブコマンドを解析する。
@@ -29,9 +47,33 @@ func ParseCommand(args []string) Command {
 		return CommandServe
 	case "migrate":
 		return CommandMigrate
+	case "migrate-sessions":
+		return CommandMigrateSessions
+	case "seed":
+		return CommandSeed
+	case "backup":
+		return CommandBackup
+	case "restore":
+		return CommandRestore
+	case "doctor":
+		return CommandDoctor
 	case "healthcheck":
 		return CommandHealthcheck
+	case "config":
+		// config のサブコマンドは validate のみ
+		return CommandConfigValidate
 	default:
 		return CommandServe
 	}
 }
+
+// commandFlag は `worker --dry-run` のような値を持たないフラグ `--name` が引数に含まれるかを返す。
+func commandFlag(args []string, name string) bool {
+	flag := "--" + name
+	for i := 1; i < len(args); i++ {
+		if args[i] == flag {
+			return true
+		}
+	}
+	return false
+}
@@ -32,6 +32,13 @@ func TestParseCommand_Migrate(t *testing.T) {
 	}
 }
 
+func TestParseCommand_MigrateSessions(t *testing.T) {
+	cmd := ParseCommand([]string{"migrate-sessions"})
+	if cmd != CommandMigrateSessions {
+		t.Errorf("ParseCommand([migrate-sessions]) = %q, want %q", cmd, CommandMigrateSessions)
+	}
+}
+
 func TestParseCommand_UnknownDefaultsToServe(t *testing.T) {
 	cmd := ParseCommand([]string{"unknown"})
 	if cmd != CommandServe {
@@ -54,6 +61,7 @@ func TestCommandString(t *testing.T) {
 		{CommandServe, "serve"},
 		{CommandWorker, "worker"},
 		{CommandMigrate, "migrate"},
+		{CommandMigrateSessions, "migrate-sessions"},
 	}
 
 	for _, tt := range tests {
@@ -62,3 +70,77 @@ func TestCommandString(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCommand_ConfigValidate(t *testing.T) {
+	cmd := ParseCommand([]string{"config", "validate"})
+	if cmd != CommandConfigValidate {
+		t.Errorf("ParseCommand([config validate]) = %q, want %q", cmd, CommandConfigValidate)
+	}
+}
+
+func TestParseCommand_BackupRestore(t *testing.T) {
+	if cmd := ParseCommand([]string{"backup", "--out=dump.json.gz"}); cmd != CommandBackup {
+		t.Errorf("ParseCommand([backup]) = %q, want %q", cmd, CommandBackup)
+	}
+	if cmd := ParseCommand([]string{"restore", "--in=dump.json.gz"}); cmd != CommandRestore {
+		t.Errorf("ParseCommand([restore]) = %q, want %q", cmd, CommandRestore)
+	}
+}
+
+func TestParseCommand_Doctor(t *testing.T) {
+	if cmd := ParseCommand([]string{"doctor"}); cmd != CommandDoctor {
+		t.Errorf("ParseCommand([doctor]) = %q, want %q", cmd, CommandDoctor)
+	}
+}
+
+func TestCommandPathArg(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "イコール区切りのとき値を返す", args: []string{"backup", "--out=dump.json.gz"}, want: "dump.json.gz"},
+		{name: "空白区切りのとき次の引数を返す", args: []string{"backup", "--out", "/tmp/dump.json.gz"}, want: "/tmp/dump.json.gz"},
+		{name: "未指定のとき空文字を返す", args: []string{"backup"}, want: ""},
+		{name: "値が無いとき空文字を返す", args: []string{"backup", "--out"}, want: ""},
+		{name: "別名のフラグは無視する", args: []string{"restore", "--out=dump.json.gz"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := "out"
+			if tt.args[0] == "restore" {
+				name = "in"
+			}
+			if got := commandPathArg(tt.args, name); got != tt.want {
+				t.Errorf("commandPathArg(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Seed(t *testing.T) {
+	cmd := ParseCommand([]string{"seed"})
+	if cmd != CommandSeed {
+		t.Errorf("ParseCommand([seed]) = %q, want %q", cmd, CommandSeed)
+	}
+}
+
+func TestCommandFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{name: "フラグがあるときtrueを返す", args: []string{"worker", "--dry-run"}, want: true},
+		{name: "フラグがないときfalseを返す", args: []string{"worker"}, want: false},
+		{name: "サブコマンド名は対象にしない", args: []string{"--dry-run"}, want: false},
+		{name: "値付きの書き方は対象にしない", args: []string{"worker", "--dry-run=true"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commandFlag(tt.args, "dry-run"); got != tt.want {
+				t.Errorf("commandFlag(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
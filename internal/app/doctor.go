@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/signal"
+	"syscall"
+
+	"github.com/hitoshi/feedman/internal/config"
+	"github.com/hitoshi/feedman/internal/database"
+)
+
+// runDoctor は稼働中の DB がコード側の期待スキーマ（列・インデックス・制約）と一致しているかを検査し、結果を w に出力する。
+// 不一致はすべて列挙したうえでエラーを返す（終了コード 1）。検査のみを行い、スキーマは修正しない。
+func runDoctor(w io.Writer, cfg *config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	databaseURL, err := database.ConnectionURL(cfg.DatabaseURL, cfg.DatabaseSchema)
+	if err != nil {
+		return fmt.Errorf("doctor failed: %w", err)
+	}
+
+	var problems []string
+	problems = append(problems, migrationProblems(databaseURL)...)
+
+	db, err := database.Open(databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	schemaProblems, err := database.CheckSchema(ctx, db, database.ExpectedSchema)
+	if err != nil {
+		return fmt.Errorf("doctor failed: %w", err)
+	}
+	problems = append(problems, schemaProblems...)
+
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintf(w, "- %s\n", p)
+		}
+		return fmt.Errorf("schema check failed: %d problem(s)", len(problems))
+	}
+
+	schema := cfg.DatabaseSchema
+	if schema == "" {
+		schema = "default"
+	}
+	fmt.Fprintf(w, "schema is consistent (schema=%s, tables=%d)\n", schema, len(database.ExpectedSchema))
+	return nil
+}
+
+// migrationProblems は適用済みのマイグレーションが埋め込まれた最新のバージョンと一致しているかを検査する。
+// 途中で失敗した（dirty な）状態や未適用のマイグレーションがある場合は、その内容を問題として返す。
+func migrationProblems(databaseURL string) []string {
+	latest, err := database.LatestMigrationVersion()
+	if err != nil {
+		return []string{fmt.Sprintf("マイグレーションの最新バージョンを取得できません: %v", err)}
+	}
+	current, err := database.SchemaVersion(databaseURL)
+	if err != nil {
+		return []string{fmt.Sprintf("適用済みのマイグレーションのバージョンを取得できません: %v", err)}
+	}
+
+	switch {
+	case current < latest:
+		return []string{fmt.Sprintf("未適用のマイグレーションがあります（適用済み %d / 最新 %d）。feedman migrate を実行してください", current, latest)}
+	case current > latest:
+		return []string{fmt.Sprintf("DB のマイグレーション（%d）がこのバイナリの最新（%d）より新しいバージョンです", current, latest)}
+	}
+	return nil
+}
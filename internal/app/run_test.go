@@ -2,6 +2,9 @@ package app
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -67,3 +70,65 @@ func setTestEnv(t *testing.T) {
 	t.Setenv("SESSION_SECRET", "test-session-secret-32bytes-long!")
 	t.Setenv("BASE_URL", "http://localhost:8080")
 }
+
+// TestRun_ConfigValidate は config validate が DB に接続せずに設定の検証結果を出力することを検証する。
+func TestRun_ConfigValidate(t *testing.T) {
+	t.Run("設定が有効なとき成功し有効である旨を出力する", func(t *testing.T) {
+		// Arrange
+		setTestEnv(t)
+		t.Setenv("FEEDMAN_CONFIG_FILE", "")
+		var buf bytes.Buffer
+
+		// Act
+		err := Run(&buf, []string{"config", "validate"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Run(config validate) error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "configuration is valid") {
+			t.Errorf("output = %q, want validity message", buf.String())
+		}
+	})
+
+	t.Run("必須項目が不足しているとき不足項目をすべて出力してエラーを返す", func(t *testing.T) {
+		// Arrange
+		setTestEnv(t)
+		t.Setenv("FEEDMAN_CONFIG_FILE", "")
+		t.Setenv("DATABASE_URL", "")
+		t.Setenv("SESSION_SECRET", "")
+		var buf bytes.Buffer
+
+		// Act
+		err := Run(&buf, []string{"config", "validate"})
+
+		// Assert
+		if err == nil {
+			t.Fatal("Run(config validate) should return error")
+		}
+		for _, want := range []string{"DATABASE_URL (database.url) is required", "SESSION_SECRET (auth.session_secret) is required"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("output = %q, want %q", buf.String(), want)
+			}
+		}
+	})
+
+	t.Run("引数で設定ファイルを指定したときその内容で検証する", func(t *testing.T) {
+		// Arrange
+		setTestEnv(t)
+		t.Setenv("DATABASE_URL", "")
+		path := filepath.Join(t.TempDir(), "feedman.yaml")
+		if err := os.WriteFile(path, []byte("database:\n  url: postgres://localhost/feedman\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+
+		// Act
+		err := Run(&buf, []string{"config", "validate", path})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Run(config validate %s) error = %v, output = %s", path, err, buf.String())
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hitoshi/feedman/internal/config"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// newSessionStore は cfg.SessionStore に応じたセッションリポジトリを組み立てる。
+// Redis を選択した場合は接続確認（PING）まで行い、返却する close 関数でクライアントを閉じる。
+func newSessionStore(ctx context.Context, cfg *config.Config, db *sql.DB) (repository.SessionRepository, func() error, error) {
+	if cfg.SessionStore != config.SessionStoreRedis {
+		return repository.NewPostgresSessionRepo(db), func() error { return nil }, nil
+	}
+
+	client, err := newRedisClient(ctx, cfg.RedisURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repository.NewRedisSessionRepo(client, cfg.RedisKeyPrefix), client.Close, nil
+}
+
+// newRedisClient は REDIS_URL から Redis クライアントを生成し、接続を確認する。
+func newRedisClient(ctx context.Context, redisURL string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	return client, nil
+}
+
+// activeSessionLister は移行元から失効していないセッションを列挙するインターフェース。
+// *repository.PostgresSessionRepo が実装する。
+type activeSessionLister interface {
+	ListActive(ctx context.Context) ([]*model.Session, error)
+}
+
+// copySessions は src の有効なセッションを dst にコピーし、コピーした件数を返す。
+// 同一 ID は上書きされるため、繰り返し実行しても結果は変わらない。
+func copySessions(ctx context.Context, src activeSessionLister, dst repository.SessionRepository) (int, error) {
+	sessions, err := src.ListActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range sessions {
+		if err := dst.Create(ctx, s); err != nil {
+			return i, fmt.Errorf("failed to copy session %s: %w", s.ID, err)
+		}
+	}
+	return len(sessions), nil
+}
+
+// runMigrateSessions は PostgreSQL の有効なセッションを Redis にコピーする。
+// SESSION_STORE=redis へ切り替える前に実行することで、既存ユーザーのログイン状態を維持する。
+// PostgreSQL 側のセッションは削除しない（切り戻しに備えるため。失効分は従来どおり期限で無効になる）。
+func runMigrateSessions(cfg *config.Config) error {
+	if cfg.RedisURL == "" {
+		return fmt.Errorf("REDIS_URL is required for session migration")
+	}
+
+	ctx := context.Background()
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	client, err := newRedisClient(ctx, cfg.RedisURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	n, err := copySessions(ctx,
+		repository.NewPostgresSessionRepo(db),
+		repository.NewRedisSessionRepo(client, cfg.RedisKeyPrefix),
+	)
+	if err != nil {
+		return fmt.Errorf("session migration failed after %d sessions: %w", n, err)
+	}
+
+	slog.Info("session migration completed", slog.Int("sessions", n))
+	return nil
+}
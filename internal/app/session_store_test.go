@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hitoshi/feedman/internal/config"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// fakeSessionLister は activeSessionLister のテスト用実装。
+type fakeSessionLister struct {
+	sessions []*model.Session
+	err      error
+}
+
+func (f *fakeSessionLister) ListActive(_ context.Context) ([]*model.Session, error) {
+	return f.sessions, f.err
+}
+
+func TestCopySessions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("有効なセッションをRedisにコピーし件数を返す", func(t *testing.T) {
+		// Arrange
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer client.Close()
+		dst := repository.NewRedisSessionRepo(client, "")
+		src := &fakeSessionLister{sessions: []*model.Session{
+			{ID: "sess-1", UserID: "user-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now},
+			{ID: "sess-2", UserID: "user-2", ExpiresAt: now.Add(2 * time.Hour), CreatedAt: now},
+		}}
+
+		// Act
+		n, err := copySessions(ctx, src, dst)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("copySessions returned error: %v", err)
+		}
+		if n != 2 {
+			t.Errorf("copied = %d, want 2", n)
+		}
+		for _, id := range []string{"sess-1", "sess-2"} {
+			if got, _ := dst.FindByID(ctx, id); got == nil {
+				t.Errorf("%s がコピーされていない", id)
+			}
+		}
+	})
+
+	t.Run("移行元の列挙に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		src := &fakeSessionLister{err: errors.New("db error")}
+
+		// Act
+		_, err := copySessions(ctx, src, nil)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestNewSessionStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("postgresのときPostgresSessionRepoを返す", func(t *testing.T) {
+		// Act
+		store, closeFn, err := newSessionStore(ctx, &config.Config{AuthConfig: config.AuthConfig{SessionStore: config.SessionStorePostgres}}, nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("newSessionStore returned error: %v", err)
+		}
+		defer closeFn()
+		if _, ok := store.(*repository.PostgresSessionRepo); !ok {
+			t.Errorf("store = %T, want *repository.PostgresSessionRepo", store)
+		}
+	})
+
+	t.Run("redisのときRedisSessionRepoを返す", func(t *testing.T) {
+		// Arrange
+		mr := miniredis.RunT(t)
+		cfg := &config.Config{AuthConfig: config.AuthConfig{SessionStore: config.SessionStoreRedis, RedisURL: "redis://" + mr.Addr()}}
+
+		// Act
+		store, closeFn, err := newSessionStore(ctx, cfg, nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("newSessionStore returned error: %v", err)
+		}
+		defer closeFn()
+		if _, ok := store.(*repository.RedisSessionRepo); !ok {
+			t.Errorf("store = %T, want *repository.RedisSessionRepo", store)
+		}
+	})
+
+	t.Run("Redisに接続できないときエラーを返す", func(t *testing.T) {
+		// Arrange
+		mr := miniredis.RunT(t)
+		addr := mr.Addr()
+		mr.Close()
+		cfg := &config.Config{AuthConfig: config.AuthConfig{SessionStore: config.SessionStoreRedis, RedisURL: "redis://" + addr}}
+
+		// Act
+		_, _, err := newSessionStore(ctx, cfg, nil)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+// TestNewTxSessionDeleter はセッションストアの実装に応じた退会時の削除アダプタが選ばれることを検証する。
+func TestNewTxSessionDeleter(t *testing.T) {
+	if _, ok := newTxSessionDeleter(repository.NewPostgresSessionRepo(nil)).(*txSessionDeleterAdapter); !ok {
+		t.Error("PostgreSQL 実装のときはトランザクション参加型のアダプタを返すべき")
+	}
+	if _, ok := newTxSessionDeleter(repository.NewRedisSessionRepo(nil, "")).(*storeSessionDeleterAdapter); !ok {
+		t.Error("Redis 実装のときはトランザクション外で削除するアダプタを返すべき")
+	}
+}
@@ -77,6 +77,26 @@ func (a *txSessionDeleterAdapter) DeleteByUserIDTx(ctx context.Context, tx user.
 	return a.repo.DeleteByUserIDExec(ctx, q, userID)
 }
 
+// storeSessionDeleterAdapter は DB 外のセッションストア（Redis 等）を user.TxSessionDeleter に適合させる。
+// 共有トランザクションには参加できないため、呼び出し時点で即座に削除する。
+// 退会がロールバックされた場合もセッションは削除済みとなるが、再ログインで復帰できるため安全側に倒れる。
+type storeSessionDeleterAdapter struct {
+	repo repository.SessionRepository
+}
+
+func (a *storeSessionDeleterAdapter) DeleteByUserIDTx(ctx context.Context, _ user.Tx, userID string) error {
+	return a.repo.DeleteByUserID(ctx, userID)
+}
+
+// newTxSessionDeleter はセッションリポジトリの実装に応じた user.TxSessionDeleter を返す。
+// PostgreSQL 実装は退会トランザクションに参加させ、それ以外はトランザクション外で削除する。
+func newTxSessionDeleter(repo repository.SessionRepository) user.TxSessionDeleter {
+	if pg, ok := repo.(*repository.PostgresSessionRepo); ok {
+		return &txSessionDeleterAdapter{repo: pg}
+	}
+	return &storeSessionDeleterAdapter{repo: repo}
+}
+
 // txUserDeleterAdapter はユーザーリポジトリを user.TxUserDeleter に適合させる。
 type txUserDeleterAdapter struct {
 	repo *repository.PostgresUserRepo
@@ -98,14 +118,14 @@ func (a *txUserDeleterAdapter) DeleteByIDTx(ctx context.Context, tx user.Tx, id
 func newTxUserService(
 	beginner *repository.SQLTxBeginner,
 	userRepo *repository.PostgresUserRepo,
-	sessionRepo *repository.PostgresSessionRepo,
+	sessionRepo repository.SessionRepository,
 	subRepo *repository.PostgresSubscriptionRepo,
 	itemStateRepo *repository.PostgresItemStateRepo,
 ) *user.Service {
 	return user.NewServiceWithTx(
 		&txBeginnerAdapter{beginner: beginner},
 		&txUserDeleterAdapter{repo: userRepo},
-		&txSessionDeleterAdapter{repo: sessionRepo},
+		newTxSessionDeleter(sessionRepo),
 		&txSubscriptionDeleterAdapter{repo: subRepo},
 		&txItemStateDeleterAdapter{repo: itemStateRepo},
 	)
@@ -117,5 +137,6 @@ var (
 	_ user.TxItemStateDeleter    = (*txItemStateDeleterAdapter)(nil)
 	_ user.TxSubscriptionDeleter = (*txSubscriptionDeleterAdapter)(nil)
 	_ user.TxSessionDeleter      = (*txSessionDeleterAdapter)(nil)
+	_ user.TxSessionDeleter      = (*storeSessionDeleterAdapter)(nil)
 	_ user.TxUserDeleter         = (*txUserDeleterAdapter)(nil)
 )
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/signal"
+	"syscall"
+
+	"github.com/hitoshi/feedman/internal/config"
+	"github.com/hitoshi/feedman/internal/dryrun"
+	"github.com/hitoshi/feedman/internal/importfilter"
+	"github.com/hitoshi/feedman/internal/item"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
+	fetchpkg "github.com/hitoshi/feedman/internal/worker/fetch"
+)
+
+// runWorkerDryRun は worker のフェッチとはてなブックマークのバッチを 1 サイクルずつ DB に書き込まずに実行する。
+// `feedman worker --dry-run` で起動する。
+//
+// フェッチ対象の選定・HTTP 取得・パース・同一性判定までは通常の worker と同じに行い、
+// フィード状態・記事・はてブ数の書き込みは dryrun パッケージのリポジトリでログ出力に置き換える。
+// フェッチ結果の記録・URL 再検出の提案・新着記事の配送キュー・レスポンスの保存・サイクル結果の記録は行わない。
+// 書き込まないため next_fetch_at が進まず、繰り返すと同じフィードを取得し続けるので、1 サイクルで終了する。
+func runWorkerDryRun(cfg *config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	rec := dryrun.NewRecorder(slog.Default())
+	feedRepo := dryrun.NewFeedRepository(repository.NewPostgresFeedRepo(db), rec)
+	itemRepo := repository.NewPostgresItemRepo(db)
+	subRepo := repository.NewPostgresSubscriptionRepo(db)
+
+	ssrfGuard, err := newFetchSSRFGuard(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize SSRF guard: %w", err)
+	}
+
+	upsertSvc := item.NewItemUpsertService(dryrun.NewItemRepository(itemRepo, rec), security.SharedContentSanitizer())
+	fetcher := fetchpkg.NewFetcher(
+		feedRepo, subRepo, upsertSvc, ssrfGuard,
+		slog.Default(), cfg.FetchTimeout, cfg.FetchMaxSize,
+		fetchpkg.WithItemFilter(importfilter.NewService(repository.NewPostgresSubscriptionImportFilterRepo(db))),
+		fetchpkg.WithMaxItems(cfg.FetchMaxItems),
+		fetchpkg.WithPrefetch(repository.NewPostgresItemViewRepo(db)),
+		fetchpkg.WithSubscriberIntervalPolicy(subRepo, fetchSubscriberIntervalPolicy(cfg)),
+		fetchpkg.WithDormantIntervalPolicy(subRepo, fetchDormantIntervalPolicy(cfg)),
+	)
+	scheduler := fetchpkg.NewScheduler(
+		feedRepo, fetcher, slog.Default(), cfg.FetchMaxConcurrent,
+		fetchpkg.WithHostMinInterval(cfg.FetchHostInterval),
+	)
+	hatebuBatch := newHatebuBatchJob(cfg, dryrun.NewHatebuItemRepository(itemRepo, rec))
+
+	slog.Info("worker dry run starting: no changes will be written to the database")
+
+	if err := scheduler.RunOnce(ctx); err != nil {
+		return fmt.Errorf("dry run fetch cycle failed: %w", err)
+	}
+	if err := hatebuBatch.RunOnce(ctx); err != nil {
+		return fmt.Errorf("dry run hatebu batch failed: %w", err)
+	}
+
+	slog.Info("worker dry run finished", slog.Int("skipped_writes", len(rec.Writes())))
+	return nil
+}
@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	// loginEventCursorSort はログイン履歴カーソルの並び順識別子（created_at DESC, id DESC）。
+	loginEventCursorSort = "login_events.created_at_desc.id_desc"
+
+	// maskedIPv4PrefixLen / maskedIPv6PrefixLen は保存する IP アドレスのプレフィックス長。
+	// 利用者が自分の接続元（回線・事業者）を見分けられる粒度に留め、端末単位の特定はできないようにする。
+	maskedIPv4PrefixLen = 24
+	maskedIPv6PrefixLen = 48
+
+	// maxMaskedUserAgentBytes は保存する User-Agent の最大バイト数。
+	maxMaskedUserAgentBytes = 256
+)
+
+// LoginEventListResult はログイン履歴の 1 ページ分。
+type LoginEventListResult struct {
+	Events []model.LoginEvent
+	// NextCursor は次ページ取得用のカーソル（pagination の不透明トークン）。末尾ページでは空文字。
+	NextCursor string
+	HasMore    bool
+}
+
+// LoginEventService はログインイベントのサービス層。
+// IP アドレスと User-Agent は保存前に部分マスクし、生の値は保存しない。
+type LoginEventService struct {
+	repo repository.LoginEventRepository
+	now  func() time.Time
+}
+
+// NewLoginEventService は LoginEventService を生成する。
+func NewLoginEventService(repo repository.LoginEventRepository) *LoginEventService {
+	return &LoginEventService{repo: repo, now: time.Now}
+}
+
+// RecordLoginEvent はログインイベントを 1 件記録する。
+// event.IPAddress（host:port 形式も可）と event.UserAgent は部分マスクしてから保存する。
+// CreatedAt がゼロ値の場合は現在時刻を用いる。
+// ログインイベントは補助的な記録のため、保存に失敗しても呼び出し元の操作は失敗させずにログへ残す。
+func (s *LoginEventService) RecordLoginEvent(ctx context.Context, event model.LoginEvent) {
+	event.IPAddress = maskIPAddress(event.IPAddress)
+	event.UserAgent = maskUserAgent(event.UserAgent)
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = s.now()
+	}
+	if err := s.repo.Create(ctx, &event); err != nil {
+		slog.Warn("ログインイベントの記録に失敗しました",
+			"user_id", event.UserID,
+			"event", string(event.Event),
+			"error", err,
+		)
+	}
+}
+
+// List は当該ユーザーのログイン履歴を新しい順に返す。
+// cursor は前ページの NextCursor（空なら先頭ページ）、limit は 1〜model.MaxLoginHistoryLimit にクランプし、
+// 0 以下の場合は model.DefaultLoginHistoryLimit を用いる。不正なカーソルは INVALID_FILTER を返す。
+func (s *LoginEventService) List(ctx context.Context, userID, cursor string, limit int) (*LoginEventListResult, error) {
+	cursorCreatedAt, cursorID, err := parseSortedCursor(loginEventCursorSort, cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = model.DefaultLoginHistoryLimit
+	}
+	if limit > model.MaxLoginHistoryLimit {
+		limit = model.MaxLoginHistoryLimit
+	}
+
+	// 次ページの有無を判定するため 1 件多く取得する
+	events, err := s.repo.ListByUser(ctx, userID, cursorCreatedAt, cursorID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("ログイン履歴の取得に失敗しました: %w", err)
+	}
+
+	result := &LoginEventListResult{Events: events}
+	if len(events) > limit {
+		result.Events = events[:limit]
+		result.HasMore = true
+		last := result.Events[limit-1]
+		result.NextCursor = pagination.Encode(loginEventCursorSort, pagination.Cursor{Time: last.CreatedAt, ID: last.ID})
+	}
+	if result.Events == nil {
+		result.Events = []model.LoginEvent{}
+	}
+	return result, nil
+}
+
+// maskIPAddress は接続元アドレス（host:port 形式または IP）をネットワーク部のみの CIDR 表記に丸める
+// （例: "203.0.113.45:52100" -> "203.0.113.0/24"、"2001:db8:1:2::1" -> "2001:db8:1::/48"）。
+// IP として解釈できない場合は復元可能な値を残さないよう空文字を返す。
+func maskIPAddress(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	if v4 := ip.To4(); v4 != nil {
+		network := &net.IPNet{IP: v4.Mask(net.CIDRMask(maskedIPv4PrefixLen, 32)), Mask: net.CIDRMask(maskedIPv4PrefixLen, 32)}
+		return network.String()
+	}
+	network := &net.IPNet{IP: ip.Mask(net.CIDRMask(maskedIPv6PrefixLen, 128)), Mask: net.CIDRMask(maskedIPv6PrefixLen, 128)}
+	return network.String()
+}
+
+// maskUserAgent は User-Agent から端末の特定につながる情報を伏せる。
+// 括弧内のコメント（OS・端末名・ビルド番号など）は "(*)" に置き換え、製品トークンのバージョンは
+// メジャーバージョンのみを残す（例: "Mozilla/5.0 (X11; Linux x86_64) Chrome/126.0.6478.61" ->
+// "Mozilla/5 (*) Chrome/126"）。結果は maxMaskedUserAgentBytes バイトまでに切り詰める。
+func maskUserAgent(ua string) string {
+	var b strings.Builder
+	depth := 0
+	for _, token := range strings.Fields(ua) {
+		opens := strings.Count(token, "(")
+		closes := strings.Count(token, ")")
+		if depth > 0 || opens > 0 {
+			if depth == 0 {
+				writeUserAgentToken(&b, "(*)")
+			}
+			depth += opens - closes
+			if depth < 0 {
+				depth = 0
+			}
+			continue
+		}
+		name, version, found := strings.Cut(token, "/")
+		if found {
+			major, _, _ := strings.Cut(version, ".")
+			token = name + "/" + major
+		}
+		writeUserAgentToken(&b, token)
+	}
+	return truncateUTF8(b.String(), maxMaskedUserAgentBytes)
+}
+
+// writeUserAgentToken は b にトークンを空白区切りで追記する。
+func writeUserAgentToken(b *strings.Builder, token string) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(token)
+}
+
+// truncateUTF8 は s を UTF-8 の文字境界を保ったまま max バイト以内に切り詰める。
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[:max]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,269 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockLoginEventRepo は repository.LoginEventRepository のモック実装。
+type mockLoginEventRepo struct {
+	createFn     func(ctx context.Context, event *model.LoginEvent) error
+	listByUserFn func(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.LoginEvent, error)
+}
+
+func (m *mockLoginEventRepo) Create(ctx context.Context, event *model.LoginEvent) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, event)
+	}
+	return nil
+}
+
+func (m *mockLoginEventRepo) ListByUser(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.LoginEvent, error) {
+	if m.listByUserFn != nil {
+		return m.listByUserFn(ctx, userID, cursorCreatedAt, cursorID, limit)
+	}
+	return nil, nil
+}
+
+func TestLoginEventService_RecordLoginEvent(t *testing.T) {
+	now := time.Date(2026, 7, 4, 9, 0, 0, 0, time.UTC)
+
+	t.Run("IPとUser-Agentを部分マスクし現在時刻で保存する", func(t *testing.T) {
+		// Arrange
+		var got *model.LoginEvent
+		repo := &mockLoginEventRepo{
+			createFn: func(_ context.Context, event *model.LoginEvent) error {
+				got = event
+				return nil
+			},
+		}
+		svc := NewLoginEventService(repo)
+		svc.now = func() time.Time { return now }
+
+		// Act
+		svc.RecordLoginEvent(context.Background(), model.LoginEvent{
+			UserID:    "user-1",
+			Event:     model.LoginEventSuccess,
+			IPAddress: "203.0.113.45:52100",
+			UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.6478.61 Safari/537.36",
+		})
+
+		// Assert
+		if got == nil {
+			t.Fatal("Create should be called")
+		}
+		if got.UserID != "user-1" || got.Event != model.LoginEventSuccess || !got.CreatedAt.Equal(now) {
+			t.Errorf("event = %+v", got)
+		}
+		if got.IPAddress != "203.0.113.0/24" {
+			t.Errorf("IPAddress = %q, want %q", got.IPAddress, "203.0.113.0/24")
+		}
+		if want := "Mozilla/5 (*) AppleWebKit/537 (*) Chrome/126 Safari/537"; got.UserAgent != want {
+			t.Errorf("UserAgent = %q, want %q", got.UserAgent, want)
+		}
+	})
+
+	t.Run("記録時刻が指定されているときその時刻で保存する", func(t *testing.T) {
+		// Arrange
+		expiresAt := now.Add(-2 * time.Hour)
+		var got *model.LoginEvent
+		repo := &mockLoginEventRepo{
+			createFn: func(_ context.Context, event *model.LoginEvent) error {
+				got = event
+				return nil
+			},
+		}
+		svc := NewLoginEventService(repo)
+		svc.now = func() time.Time { return now }
+
+		// Act
+		svc.RecordLoginEvent(context.Background(), model.LoginEvent{UserID: "user-1", Event: model.LoginEventSessionExpired, CreatedAt: expiresAt})
+
+		// Assert
+		if got == nil || !got.CreatedAt.Equal(expiresAt) {
+			t.Errorf("event = %+v, want created_at %v", got, expiresAt)
+		}
+	})
+
+	t.Run("保存に失敗してもパニックせずに戻る", func(t *testing.T) {
+		// Arrange
+		repo := &mockLoginEventRepo{
+			createFn: func(_ context.Context, _ *model.LoginEvent) error {
+				return errors.New("db error")
+			},
+		}
+		svc := NewLoginEventService(repo)
+
+		// Act & Assert（エラーは呼び出し元に返さない）
+		svc.RecordLoginEvent(context.Background(), model.LoginEvent{UserID: "user-1", Event: model.LoginEventLogout})
+	})
+}
+
+func TestLoginEventService_List(t *testing.T) {
+	base := time.Date(2026, 7, 4, 9, 0, 0, 0, time.UTC)
+	newEvents := func(n int) []model.LoginEvent {
+		events := make([]model.LoginEvent, n)
+		for i := range events {
+			events[i] = model.LoginEvent{
+				ID:        fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1),
+				UserID:    "user-1",
+				Event:     model.LoginEventSuccess,
+				CreatedAt: base.Add(-time.Duration(i) * time.Minute),
+			}
+		}
+		return events
+	}
+
+	t.Run("limitより多く存在するとき次ページのカーソルを返す", func(t *testing.T) {
+		// Arrange
+		var gotLimit int
+		repo := &mockLoginEventRepo{
+			listByUserFn: func(_ context.Context, _ string, _ time.Time, _ string, limit int) ([]model.LoginEvent, error) {
+				gotLimit = limit
+				return newEvents(3), nil
+			},
+		}
+		svc := NewLoginEventService(repo)
+
+		// Act
+		result, err := svc.List(context.Background(), "user-1", "", 2)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotLimit != 3 {
+			t.Errorf("repo limit = %d, want 3", gotLimit)
+		}
+		if len(result.Events) != 2 || !result.HasMore || result.NextCursor == "" {
+			t.Errorf("result = %+v", result)
+		}
+	})
+
+	t.Run("次ページのカーソルを渡したとき最後の要素より古いものを取得する", func(t *testing.T) {
+		// Arrange
+		var gotCreatedAt time.Time
+		var gotID string
+		repo := &mockLoginEventRepo{
+			listByUserFn: func(_ context.Context, _ string, cursorCreatedAt time.Time, cursorID string, _ int) ([]model.LoginEvent, error) {
+				gotCreatedAt, gotID = cursorCreatedAt, cursorID
+				return newEvents(3), nil
+			},
+		}
+		svc := NewLoginEventService(repo)
+		firstPage, err := svc.List(context.Background(), "user-1", "", 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Act
+		_, err = svc.List(context.Background(), "user-1", firstPage.NextCursor, 2)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		last := firstPage.Events[1]
+		if !gotCreatedAt.Equal(last.CreatedAt) || gotID != last.ID {
+			t.Errorf("cursor = (%v, %q), want (%v, %q)", gotCreatedAt, gotID, last.CreatedAt, last.ID)
+		}
+	})
+
+	t.Run("監査ログのカーソルを渡したときINVALID_FILTERを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewLoginEventService(&mockLoginEventRepo{})
+		auditCursor := formatCursor(base, "00000000-0000-0000-0000-000000000001")
+
+		// Act
+		_, err := svc.List(context.Background(), "user-1", auditCursor, 0)
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidFilter {
+			t.Errorf("err = %v, want INVALID_FILTER", err)
+		}
+	})
+
+	t.Run("履歴が無いとき空配列を返す", func(t *testing.T) {
+		// Arrange
+		var gotLimit int
+		repo := &mockLoginEventRepo{
+			listByUserFn: func(_ context.Context, _ string, _ time.Time, _ string, limit int) ([]model.LoginEvent, error) {
+				gotLimit = limit
+				return nil, nil
+			},
+		}
+		svc := NewLoginEventService(repo)
+
+		// Act
+		result, err := svc.List(context.Background(), "user-1", "", 0)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Events == nil || len(result.Events) != 0 || result.HasMore {
+			t.Errorf("result = %+v", result)
+		}
+		if gotLimit != model.DefaultLoginHistoryLimit+1 {
+			t.Errorf("repo limit = %d, want %d", gotLimit, model.DefaultLoginHistoryLimit+1)
+		}
+	})
+}
+
+func TestMaskIPAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		addr string
+		want string
+	}{
+		{name: "IPv4のhost:port形式のとき/24に丸める", addr: "203.0.113.45:52100", want: "203.0.113.0/24"},
+		{name: "ポートの無いIPv4のとき/24に丸める", addr: "198.51.100.7", want: "198.51.100.0/24"},
+		{name: "IPv6のhost:port形式のとき/48に丸める", addr: "[2001:db8:1:2::1]:443", want: "2001:db8:1::/48"},
+		{name: "IPv4射影IPv6のときIPv4として丸める", addr: "::ffff:192.0.2.10", want: "192.0.2.0/24"},
+		{name: "IPとして解釈できないとき空文字を返す", addr: "example.com:80", want: ""},
+		{name: "空文字のとき空文字を返す", addr: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskIPAddress(tt.addr); got != tt.want {
+				t.Errorf("maskIPAddress(%q) = %q, want %q", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskUserAgent(t *testing.T) {
+	t.Run("括弧内を伏せてメジャーバージョンのみを残す", func(t *testing.T) {
+		got := maskUserAgent("Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) Version/17.5 Mobile/15E148 Safari/604.1")
+		if want := "Mozilla/5 (*) Version/17 Mobile/15E148 Safari/604"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("入れ子の括弧もまとめて伏せる", func(t *testing.T) {
+		got := maskUserAgent("curl/8.7.1 (x86_64 (linux) build) extra/1.2")
+		if want := "curl/8 (*) extra/1"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("長すぎるとき上限バイト数で切り詰める", func(t *testing.T) {
+		got := maskUserAgent(strings.Repeat("あ", 200))
+		if len(got) > maxMaskedUserAgentBytes || !strings.HasPrefix(got, "あ") {
+			t.Errorf("len = %d, want <= %d", len(got), maxMaskedUserAgentBytes)
+		}
+	})
+
+	t.Run("空文字のとき空文字を返す", func(t *testing.T) {
+		if got := maskUserAgent(""); got != "" {
+			t.Errorf("got %q, want empty", got)
+		}
+	})
+}
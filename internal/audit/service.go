@@ -0,0 +1,122 @@
+// Package audit はユーザー操作の監査ログ（購読・購読解除・購読設定の変更など）と
+// ログインイベント（ログイン成功・失敗・ログアウト・セッション失効）の記録と閲覧を提供する。
+//
+// 記録は各ドメインサービスから Record を呼ぶフックとして行い、保存の失敗は元の操作を失敗させない
+// （ログに残して握りつぶす）。閲覧は (created_at, id) の複合カーソルによる新しい順のページングで行う。
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// ListResult は監査ログ一覧の 1 ページ分。
+type ListResult struct {
+	Logs []model.AuditLog
+	// NextCursor は次ページ取得用のカーソル（pagination の不透明トークン）。末尾ページでは空文字。
+	NextCursor string
+	HasMore    bool
+}
+
+// Service は監査ログのサービス層。
+type Service struct {
+	repo repository.AuditLogRepository
+	now  func() time.Time
+}
+
+// NewService は Service を生成する。
+func NewService(repo repository.AuditLogRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Record は監査ログを 1 件記録する。
+// 監査ログは補助的な記録のため、保存に失敗しても呼び出し元の操作は失敗させずにログへ残す。
+func (s *Service) Record(ctx context.Context, userID string, action model.AuditAction, target string, payload map[string]any) {
+	log := &model.AuditLog{
+		UserID:    userID,
+		Action:    action,
+		Target:    target,
+		Payload:   payload,
+		CreatedAt: s.now(),
+	}
+	if err := s.repo.Create(ctx, log); err != nil {
+		slog.Warn("監査ログの記録に失敗しました",
+			"user_id", userID,
+			"action", string(action),
+			"target", target,
+			"error", err,
+		)
+	}
+}
+
+// List は当該ユーザーの監査ログを新しい順に返す。
+// cursor は前ページの NextCursor（空なら先頭ページ）、limit は 1〜model.MaxAuditLogLimit にクランプし、
+// 0 以下の場合は model.DefaultAuditLogLimit を用いる。不正なカーソルは INVALID_FILTER を返す。
+func (s *Service) List(ctx context.Context, userID, cursor string, limit int) (*ListResult, error) {
+	cursorCreatedAt, cursorID, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = model.DefaultAuditLogLimit
+	}
+	if limit > model.MaxAuditLogLimit {
+		limit = model.MaxAuditLogLimit
+	}
+
+	// 次ページの有無を判定するため 1 件多く取得する
+	logs, err := s.repo.ListByUser(ctx, userID, cursorCreatedAt, cursorID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("監査ログの取得に失敗しました: %w", err)
+	}
+
+	result := &ListResult{Logs: logs}
+	if len(logs) > limit {
+		result.Logs = logs[:limit]
+		result.HasMore = true
+		last := result.Logs[limit-1]
+		result.NextCursor = formatCursor(last.CreatedAt, last.ID)
+	}
+	if result.Logs == nil {
+		result.Logs = []model.AuditLog{}
+	}
+	return result, nil
+}
+
+// auditCursorSort は監査ログ一覧カーソルの並び順識別子（created_at DESC, id DESC）。
+const auditCursorSort = "audit_logs.created_at_desc.id_desc"
+
+// parseCursor は pagination の不透明カーソル（移行期間中は旧形式の `<RFC3339Nano>:<id>` も可）を
+// (created_at, id) に分解する。空文字列の場合はゼロ値を返し、先頭ページの取得を意味する。
+func parseCursor(cursor string) (time.Time, string, error) {
+	return parseSortedCursor(auditCursorSort, cursor)
+}
+
+// parseSortedCursor は並び順識別子 sort のカーソルを (created_at, id) に分解する。
+// 監査ログとログイン履歴で (created_at, id) の複合カーソルの扱いを共有する。
+func parseSortedCursor(sort, cursor string) (time.Time, string, error) {
+	if cursor == "" {
+		return time.Time{}, "", nil
+	}
+	c, err := pagination.Decode(sort, cursor)
+	if err != nil {
+		return time.Time{}, "", model.NewInvalidFilterError("invalid cursor: " + cursor)
+	}
+	// id は DB で uuid として比較するため、形式不正は DB エラーではなく入力エラーとして返す
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return time.Time{}, "", model.NewInvalidFilterError("invalid cursor: " + cursor)
+	}
+	return c.Time, c.ID, nil
+}
+
+// formatCursor は created_at と id から不透明カーソルを組み立てる。
+func formatCursor(createdAt time.Time, id string) string {
+	return pagination.Encode(auditCursorSort, pagination.Cursor{Time: createdAt, ID: id})
+}
@@ -0,0 +1,206 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockAuditLogRepo は repository.AuditLogRepository のモック実装。
+type mockAuditLogRepo struct {
+	createFn     func(ctx context.Context, log *model.AuditLog) error
+	listByUserFn func(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.AuditLog, error)
+	createCalls  int
+}
+
+func (m *mockAuditLogRepo) Create(ctx context.Context, log *model.AuditLog) error {
+	m.createCalls++
+	if m.createFn != nil {
+		return m.createFn(ctx, log)
+	}
+	return nil
+}
+
+func (m *mockAuditLogRepo) ListByUser(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.AuditLog, error) {
+	if m.listByUserFn != nil {
+		return m.listByUserFn(ctx, userID, cursorCreatedAt, cursorID, limit)
+	}
+	return nil, nil
+}
+
+func TestService_Record(t *testing.T) {
+	now := time.Date(2026, 6, 14, 12, 0, 0, 0, time.UTC)
+
+	t.Run("操作内容と現在時刻を保存する", func(t *testing.T) {
+		// Arrange
+		var got *model.AuditLog
+		repo := &mockAuditLogRepo{
+			createFn: func(_ context.Context, log *model.AuditLog) error {
+				got = log
+				return nil
+			},
+		}
+		svc := NewService(repo)
+		svc.now = func() time.Time { return now }
+
+		// Act
+		svc.Record(context.Background(), "user-1", model.AuditActionSubscriptionDelete, "feed-1", map[string]any{"subscription_id": "sub-1"})
+
+		// Assert
+		if got == nil {
+			t.Fatal("Create should be called")
+		}
+		if got.UserID != "user-1" || got.Action != model.AuditActionSubscriptionDelete || got.Target != "feed-1" ||
+			!got.CreatedAt.Equal(now) || got.Payload["subscription_id"] != "sub-1" {
+			t.Errorf("log = %+v", got)
+		}
+	})
+
+	t.Run("保存に失敗しても呼び出し元へ伝播しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockAuditLogRepo{
+			createFn: func(context.Context, *model.AuditLog) error { return errors.New("db down") },
+		}
+		svc := NewService(repo)
+
+		// Act / Assert（panic せずに戻ること）
+		svc.Record(context.Background(), "user-1", model.AuditActionSubscriptionCreate, "feed-1", nil)
+		if repo.createCalls != 1 {
+			t.Errorf("createCalls = %d, want 1", repo.createCalls)
+		}
+	})
+}
+
+func TestService_List(t *testing.T) {
+	base := time.Date(2026, 6, 14, 12, 0, 0, 0, time.UTC)
+	makeLogs := func(n int) []model.AuditLog {
+		logs := make([]model.AuditLog, n)
+		for i := range logs {
+			logs[i] = model.AuditLog{
+				ID:        fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1),
+				UserID:    "user-1",
+				Action:    model.AuditActionSubscriptionCreate,
+				CreatedAt: base.Add(-time.Duration(i) * time.Minute),
+			}
+		}
+		return logs
+	}
+
+	t.Run("limitより多く存在するとき次ページのカーソルを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockAuditLogRepo{
+			listByUserFn: func(_ context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.AuditLog, error) {
+				if userID != "user-1" || !cursorCreatedAt.IsZero() || cursorID != "" {
+					t.Errorf("args = (%q, %v, %q)", userID, cursorCreatedAt, cursorID)
+				}
+				if limit != 3 {
+					t.Errorf("limit = %d, want 3（判定用に 1 件多く取得する）", limit)
+				}
+				return makeLogs(3), nil
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		result, err := svc.List(context.Background(), "user-1", "", 2)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(result.Logs) != 2 || !result.HasMore {
+			t.Fatalf("result = %+v", result)
+		}
+		want := formatCursor(result.Logs[1].CreatedAt, result.Logs[1].ID)
+		if result.NextCursor != want {
+			t.Errorf("NextCursor = %q, want %q", result.NextCursor, want)
+		}
+	})
+
+	t.Run("カーソルを指定したとき分解した値でリポジトリを呼ぶ", func(t *testing.T) {
+		// Arrange
+		cursorAt := base.Add(-time.Hour)
+		cursorID := "00000000-0000-0000-0000-000000000009"
+		repo := &mockAuditLogRepo{
+			listByUserFn: func(_ context.Context, _ string, gotAt time.Time, gotID string, _ int) ([]model.AuditLog, error) {
+				if !gotAt.Equal(cursorAt) || gotID != cursorID {
+					t.Errorf("cursor = (%v, %q), want (%v, %q)", gotAt, gotID, cursorAt, cursorID)
+				}
+				return makeLogs(1), nil
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		result, err := svc.List(context.Background(), "user-1", formatCursor(cursorAt, cursorID), 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(result.Logs) != 1 || result.HasMore || result.NextCursor != "" {
+			t.Errorf("result = %+v", result)
+		}
+	})
+
+	t.Run("limitが未指定や上限超過のとき既定値と上限に丸め結果が無くても空スライスを返す", func(t *testing.T) {
+		tests := []struct {
+			limit int
+			want  int
+		}{
+			{0, model.DefaultAuditLogLimit + 1},
+			{model.MaxAuditLogLimit + 100, model.MaxAuditLogLimit + 1},
+		}
+		for _, tt := range tests {
+			// Arrange
+			var gotLimit int
+			repo := &mockAuditLogRepo{
+				listByUserFn: func(_ context.Context, _ string, _ time.Time, _ string, limit int) ([]model.AuditLog, error) {
+					gotLimit = limit
+					return nil, nil
+				},
+			}
+			svc := NewService(repo)
+
+			// Act
+			result, err := svc.List(context.Background(), "user-1", "", tt.limit)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("List returned error: %v", err)
+			}
+			if gotLimit != tt.want {
+				t.Errorf("limit %d: repo limit = %d, want %d", tt.limit, gotLimit, tt.want)
+			}
+			if result.Logs == nil || len(result.Logs) != 0 {
+				t.Errorf("Logs = %#v, want empty slice", result.Logs)
+			}
+		}
+	})
+
+	t.Run("カーソルが不正なとき400 INVALID_FILTERを返す", func(t *testing.T) {
+		for _, cursor := range []string{"garbage", "2026-06-14T12:00:00Z:", "not-a-time:00000000-0000-0000-0000-000000000001", "2026-06-14T12:00:00Z:not-a-uuid"} {
+			// Arrange
+			repo := &mockAuditLogRepo{
+				listByUserFn: func(context.Context, string, time.Time, string, int) ([]model.AuditLog, error) {
+					t.Error("不正なカーソルでリポジトリを呼ぶべきでない")
+					return nil, nil
+				},
+			}
+			svc := NewService(repo)
+
+			// Act
+			_, err := svc.List(context.Background(), "user-1", cursor, 10)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidFilter {
+				t.Errorf("cursor %q: err = %v, want INVALID_FILTER", cursor, err)
+			}
+		}
+	})
+}
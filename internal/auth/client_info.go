@@ -0,0 +1,26 @@
+package auth
+
+import "context"
+
+// ClientInfo はログインイベントに記録する接続元の情報。
+// 値は記録側（LoginEventRecorder）で部分マスクされるため、ここでは生の値を渡す。
+type ClientInfo struct {
+	// RemoteAddr は接続元アドレス（r.RemoteAddr の host:port 形式）。
+	RemoteAddr string
+	UserAgent  string
+}
+
+// clientInfoContextKey はリクエストコンテキストに ClientInfo を格納するためのキー。
+type clientInfoContextKey struct{}
+
+// ContextWithClientInfo はコンテキストに接続元の情報を注入する。
+// HandleCallback / Logout の呼び出し元（HTTP ハンドラー）が設定する。
+func ContextWithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey{}, info)
+}
+
+// clientInfoFromContext はコンテキストから接続元の情報を取り出す。未設定の場合はゼロ値を返す。
+func clientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoContextKey{}).(ClientInfo)
+	return info
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// IdentityService はユーザー自身による identity（外部 IdP 連携）の一覧・解除を提供する。
+type IdentityService struct {
+	identRepo   repository.IdentityManagementRepository
+	sessionRepo repository.SessionRepository
+}
+
+// NewIdentityService は IdentityService を生成する。
+func NewIdentityService(identRepo repository.IdentityManagementRepository, sessionRepo repository.SessionRepository) *IdentityService {
+	return &IdentityService{identRepo: identRepo, sessionRepo: sessionRepo}
+}
+
+// ListIdentities はユーザーの identity を連携日時の昇順で返す。
+func (s *IdentityService) ListIdentities(ctx context.Context, userID string) ([]*model.Identity, error) {
+	identities, err := s.identRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+	return identities, nil
+}
+
+// UnlinkIdentity はユーザーの identity を 1 件解除し、その identity でのログインで発行したセッションを失効させる。
+// 発行元の identity が記録されていないセッションも、解除した identity で発行した可能性があるため失効させる。
+//
+// 存在しない・他ユーザーの identity は IDENTITY_NOT_FOUND、最後の 1 つの identity は
+// ログインできなくなるため LAST_IDENTITY を返す。
+func (s *IdentityService) UnlinkIdentity(ctx context.Context, userID, identityID string) error {
+	if _, err := uuid.Parse(identityID); err != nil {
+		return model.NewIdentityNotFoundError(identityID)
+	}
+
+	if err := s.identRepo.DeleteForUser(ctx, userID, identityID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrIdentityNotFound):
+			return model.NewIdentityNotFoundError(identityID)
+		case errors.Is(err, repository.ErrLastIdentity):
+			return model.NewLastIdentityError()
+		}
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+
+	// identity は解除済みのため、失効に失敗した場合はエラーを返して再ログインを促す
+	// （解除した identity ではもうログインできないため、残ったセッションは期限切れで消える）。
+	if err := s.sessionRepo.DeleteByIdentityID(ctx, userID, identityID); err != nil {
+		return fmt.Errorf("failed to revoke identity sessions: %w", err)
+	}
+
+	slog.Info("identity unlinked",
+		slog.String("user_id", userID),
+		slog.String("identity_id", identityID),
+	)
+	return nil
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockIdentityManagementRepo は IdentityManagementRepository のモック。
+type mockIdentityManagementRepo struct {
+	identities  []*model.Identity
+	deleteErr   error
+	deletedIDs  []string
+	deleteCalls int
+}
+
+func (m *mockIdentityManagementRepo) ListByUserID(ctx context.Context, userID string) ([]*model.Identity, error) {
+	return m.identities, nil
+}
+
+func (m *mockIdentityManagementRepo) DeleteForUser(ctx context.Context, userID, identityID string) error {
+	m.deleteCalls++
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deletedIDs = append(m.deletedIDs, identityID)
+	return nil
+}
+
+var _ repository.IdentityManagementRepository = (*mockIdentityManagementRepo)(nil)
+
+func TestIdentityService_UnlinkIdentity(t *testing.T) {
+	const identityID = "11111111-1111-1111-1111-111111111111"
+	ctx := context.Background()
+
+	t.Run("identityを削除しその identity で発行したセッションを失効させる", func(t *testing.T) {
+		// Arrange
+		identRepo := &mockIdentityManagementRepo{}
+		var revokedUser, revokedIdentity string
+		sessionRepo := &mockSessionRepo{
+			deleteByIdentityIDFn: func(_ context.Context, userID, identityID string) error {
+				revokedUser, revokedIdentity = userID, identityID
+				return nil
+			},
+		}
+		svc := NewIdentityService(identRepo, sessionRepo)
+
+		// Act
+		err := svc.UnlinkIdentity(ctx, "user-1", identityID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("UnlinkIdentity() error = %v", err)
+		}
+		if len(identRepo.deletedIDs) != 1 || identRepo.deletedIDs[0] != identityID {
+			t.Errorf("deleted = %v, want [%s]", identRepo.deletedIDs, identityID)
+		}
+		if revokedUser != "user-1" || revokedIdentity != identityID {
+			t.Errorf("revoked = (%q, %q), want (user-1, %s)", revokedUser, revokedIdentity, identityID)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		identityID string
+		deleteErr  error
+		wantCode   string
+	}{
+		{name: "最後のidentityのときLAST_IDENTITYを返す", identityID: identityID, deleteErr: repository.ErrLastIdentity, wantCode: model.ErrCodeLastIdentity},
+		{name: "存在しないidentityのときIDENTITY_NOT_FOUNDを返す", identityID: identityID, deleteErr: repository.ErrIdentityNotFound, wantCode: model.ErrCodeIdentityNotFound},
+		{name: "UUIDでないIDのときIDENTITY_NOT_FOUNDを返す", identityID: "not-a-uuid", wantCode: model.ErrCodeIdentityNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			identRepo := &mockIdentityManagementRepo{deleteErr: tt.deleteErr}
+			sessionRepo := &mockSessionRepo{
+				deleteByIdentityIDFn: func(context.Context, string, string) error {
+					t.Error("DeleteByIdentityID should not be called")
+					return nil
+				},
+			}
+			svc := NewIdentityService(identRepo, sessionRepo)
+
+			// Act
+			err := svc.UnlinkIdentity(ctx, "user-1", tt.identityID)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+				t.Errorf("err = %v, want %s", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	feedmanmail "github.com/hitoshi/feedman/internal/mail"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// MagicLinkProvider はマジックリンクでログインしたユーザーの identities.provider の値。
+// provider_user_id には正規化したメールアドレスを保存する。
+const MagicLinkProvider = "email"
+
+// loginTokenBytes はマジックリンクのトークンの生成に使う乱数のバイト数（256bit）。
+const loginTokenBytes = 32
+
+// magicLinkSubject はマジックリンクのメールの件名。
+const magicLinkSubject = "Feedman ログインリンク"
+
+// WithMagicLink はメールアドレスに一回限りのログインリンクを送るマジックリンク認証を有効にする。
+// verifyURL はリンク先（GET /auth/magic-link/verify の絶対 URL）で、token クエリを付けて送る。
+func WithMagicLink(loginTokens repository.LoginTokenRepository, mailer feedmanmail.Sender, verifyURL string) ServiceOption {
+	return func(s *Service) {
+		s.loginTokens = loginTokens
+		s.mailer = mailer
+		s.magicLinkURL = verifyURL
+	}
+}
+
+// RequestMagicLink は email にログインリンクを送る。
+// メールアドレスの形式が不正な場合は INVALID_EMAIL を返す。アカウントの有無を推測されないよう、
+// 未登録のメールアドレスにも送信し、送信の上限（model.MaxLoginTokensPerWindow）に達した場合も
+// エラーにせず送信を省略する。
+func (s *Service) RequestMagicLink(ctx context.Context, email string) error {
+	if s.loginTokens == nil || s.mailer == nil {
+		return fmt.Errorf("magic link authentication is not configured")
+	}
+	email, ok := normalizeEmail(email)
+	if !ok {
+		return model.NewInvalidEmailError()
+	}
+
+	now := time.Now()
+	// 期限切れのトークンは使えないため、発行のついでに掃除する。失敗しても発行は続行する。
+	if _, err := s.loginTokens.DeleteExpired(ctx, now); err != nil {
+		slog.Warn("failed to delete expired login tokens", slog.String("error", err.Error()))
+	}
+
+	count, err := s.loginTokens.CountCreatedSince(ctx, email, now.Add(-model.LoginTokenTTL))
+	if err != nil {
+		return fmt.Errorf("failed to count login tokens: %w", err)
+	}
+	if count >= model.MaxLoginTokensPerWindow {
+		slog.Warn("magic link request rate limited", slog.String("email", maskEmail(email)))
+		return nil
+	}
+
+	token, err := generateLoginToken()
+	if err != nil {
+		return err
+	}
+	if err := s.loginTokens.Create(ctx, &model.LoginToken{
+		TokenHash: hashLoginToken(token),
+		Email:     email,
+		ExpiresAt: now.Add(model.LoginTokenTTL),
+		CreatedAt: now,
+	}); err != nil {
+		return fmt.Errorf("failed to save login token: %w", err)
+	}
+
+	link := s.magicLinkURL + "?" + url.Values{"token": {token}}.Encode()
+	if err := s.mailer.Send(ctx, feedmanmail.Message{
+		To:      email,
+		Subject: magicLinkSubject,
+		Body:    magicLinkBody(link),
+	}); err != nil {
+		return fmt.Errorf("failed to send magic link: %w", err)
+	}
+
+	slog.Info("magic link sent", slog.String("email", maskEmail(email)))
+	return nil
+}
+
+// VerifyMagicLink はログインリンクのトークンを使用済みにしてセッションを発行する。
+// トークンが存在しない・期限切れ・使用済みの場合は INVALID_LOGIN_TOKEN を返す。
+// メールアドレスが未登録の場合は OAuth の初回ログインと同様にユーザーを作成する。
+// 既存の OAuth アカウントとはメールアドレスが一致しても自動では紐づけない。
+func (s *Service) VerifyMagicLink(ctx context.Context, token string) (*model.Session, error) {
+	if s.loginTokens == nil {
+		return nil, fmt.Errorf("magic link authentication is not configured")
+	}
+	if token == "" {
+		s.recordLoginEvent(ctx, "", model.LoginEventFailure, loginFailureMagicLink)
+		return nil, model.NewInvalidLoginTokenError()
+	}
+
+	loginToken, err := s.loginTokens.Consume(ctx, hashLoginToken(token), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume login token: %w", err)
+	}
+	if loginToken == nil {
+		s.recordLoginEvent(ctx, "", model.LoginEventFailure, loginFailureMagicLink)
+		return nil, model.NewInvalidLoginTokenError()
+	}
+
+	name, _, _ := strings.Cut(loginToken.Email, "@")
+	userID, identityID, err := s.findOrCreateUser(ctx, &OAuthUserInfo{
+		ProviderUserID: loginToken.Email,
+		Email:          loginToken.Email,
+		Name:           name,
+		Provider:       MagicLinkProvider,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.createSession(ctx, userID, identityID)
+	if err != nil {
+		s.recordLoginEvent(ctx, userID, model.LoginEventFailure, loginFailureSessionCreate)
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	s.recordLoginEvent(ctx, userID, model.LoginEventSuccess, "")
+	return session, nil
+}
+
+// normalizeEmail は前後の空白を除いて小文字化したメールアドレスを返す。
+// 表示名付き（"Name <a@example.com>"）やアドレスとして解釈できない値は受け付けない。
+func normalizeEmail(email string) (string, bool) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || len(email) > 255 {
+		return "", false
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", false
+	}
+	return email, true
+}
+
+// generateLoginToken は crypto/rand の 256bit 乱数を URL に載せられる hex 表現で返す。
+func generateLoginToken() (string, error) {
+	b := make([]byte, loginTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate login token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashLoginToken はトークンを login_tokens.token_hash に保存する SHA-256 の hex 表現に変換する。
+func hashLoginToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// magicLinkBody はマジックリンクのメールの本文を組み立てる。
+func magicLinkBody(link string) string {
+	minutes := int(model.LoginTokenTTL / time.Minute)
+	return fmt.Sprintf(`Feedman にログインするには、次のリンクを開いてください。
+
+%s
+
+このリンクは %d 分間、1 回だけ使えます。
+心当たりのない場合は、このメールを破棄してください。
+`, link, minutes)
+}
@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/mail"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// fakeLoginTokenRepo はトークンをメモリに保持する LoginTokenRepository。
+type fakeLoginTokenRepo struct {
+	tokens map[string]*model.LoginToken
+	// countFn は CountCreatedSince の振る舞い。nil の場合は保持しているトークンを数える。
+	countFn func(email string, since time.Time) (int, error)
+}
+
+func newFakeLoginTokenRepo() *fakeLoginTokenRepo {
+	return &fakeLoginTokenRepo{tokens: make(map[string]*model.LoginToken)}
+}
+
+func (f *fakeLoginTokenRepo) Create(_ context.Context, token *model.LoginToken) error {
+	saved := *token
+	f.tokens[token.TokenHash] = &saved
+	return nil
+}
+
+func (f *fakeLoginTokenRepo) CountCreatedSince(_ context.Context, email string, since time.Time) (int, error) {
+	if f.countFn != nil {
+		return f.countFn(email, since)
+	}
+	count := 0
+	for _, t := range f.tokens {
+		if t.Email == email && !t.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeLoginTokenRepo) Consume(_ context.Context, tokenHash string, now time.Time) (*model.LoginToken, error) {
+	t, ok := f.tokens[tokenHash]
+	if !ok || t.UsedAt != nil || !t.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	t.UsedAt = &now
+	consumed := *t
+	return &consumed, nil
+}
+
+func (f *fakeLoginTokenRepo) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for hash, t := range f.tokens {
+		if !t.ExpiresAt.After(before) {
+			delete(f.tokens, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+var _ repository.LoginTokenRepository = (*fakeLoginTokenRepo)(nil)
+
+// fakeMailSender は送信したメールを記録する mail.Sender。
+type fakeMailSender struct {
+	sent []mail.Message
+	err  error
+}
+
+func (f *fakeMailSender) Send(_ context.Context, msg mail.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+const testMagicLinkURL = "https://feedman.example.com/auth/magic-link/verify"
+
+// tokenFromMail はメール本文のログインリンクからトークンを取り出す。
+func tokenFromMail(t *testing.T, msg mail.Message) string {
+	t.Helper()
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if strings.HasPrefix(line, testMagicLinkURL+"?") {
+			u, err := url.Parse(line)
+			if err != nil {
+				t.Fatalf("failed to parse link: %v", err)
+			}
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("link not found in body: %q", msg.Body)
+	return ""
+}
+
+func TestService_RequestMagicLink(t *testing.T) {
+	t.Run("正規化したメールアドレスにログインリンクを送りトークンのハッシュだけを保存する", func(t *testing.T) {
+		// Arrange
+		repo := newFakeLoginTokenRepo()
+		sender := &fakeMailSender{}
+		svc := NewService(nil, &mockUserRepo{}, &mockIdentityRepo{}, &mockSessionRepo{}, ServiceConfig{},
+			WithMagicLink(repo, sender, testMagicLinkURL))
+
+		// Act
+		err := svc.RequestMagicLink(context.Background(), "  User@Example.COM ")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(sender.sent) != 1 || sender.sent[0].To != "user@example.com" {
+			t.Fatalf("sent = %+v", sender.sent)
+		}
+		token := tokenFromMail(t, sender.sent[0])
+		if len(token) != 64 {
+			t.Errorf("token length = %d, want 64", len(token))
+		}
+		saved, ok := repo.tokens[hashLoginToken(token)]
+		if !ok {
+			t.Fatal("token hash is not saved")
+		}
+		if _, raw := repo.tokens[token]; raw {
+			t.Error("raw token must not be saved")
+		}
+		if saved.Email != "user@example.com" || saved.ExpiresAt.Sub(saved.CreatedAt) != model.LoginTokenTTL {
+			t.Errorf("saved = %+v", saved)
+		}
+	})
+
+	t.Run("メールアドレスの形式が不正なとき INVALID_EMAIL を返す", func(t *testing.T) {
+		for _, email := range []string{"", "user", "User <user@example.com>", "a@b.c, d@e.f"} {
+			// Arrange
+			sender := &fakeMailSender{}
+			svc := NewService(nil, &mockUserRepo{}, &mockIdentityRepo{}, &mockSessionRepo{}, ServiceConfig{},
+				WithMagicLink(newFakeLoginTokenRepo(), sender, testMagicLinkURL))
+
+			// Act
+			err := svc.RequestMagicLink(context.Background(), email)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidEmail {
+				t.Errorf("email %q: error = %v, want INVALID_EMAIL", email, err)
+			}
+			if len(sender.sent) != 0 {
+				t.Errorf("email %q: sent = %+v", email, sender.sent)
+			}
+		}
+	})
+
+	t.Run("送信の上限に達したときエラーにせず送信を省略する", func(t *testing.T) {
+		// Arrange
+		repo := newFakeLoginTokenRepo()
+		repo.countFn = func(_ string, _ time.Time) (int, error) { return model.MaxLoginTokensPerWindow, nil }
+		sender := &fakeMailSender{}
+		svc := NewService(nil, &mockUserRepo{}, &mockIdentityRepo{}, &mockSessionRepo{}, ServiceConfig{},
+			WithMagicLink(repo, sender, testMagicLinkURL))
+
+		// Act
+		err := svc.RequestMagicLink(context.Background(), "user@example.com")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(sender.sent) != 0 || len(repo.tokens) != 0 {
+			t.Errorf("sent = %+v, tokens = %d", sender.sent, len(repo.tokens))
+		}
+	})
+
+	t.Run("メールの送信に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(nil, &mockUserRepo{}, &mockIdentityRepo{}, &mockSessionRepo{}, ServiceConfig{},
+			WithMagicLink(newFakeLoginTokenRepo(), &fakeMailSender{err: errors.New("smtp down")}, testMagicLinkURL))
+
+		// Act
+		err := svc.RequestMagicLink(context.Background(), "user@example.com")
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "smtp down") {
+			t.Errorf("error = %v", err)
+		}
+	})
+}
+
+func TestService_VerifyMagicLink(t *testing.T) {
+	// requestToken はログインリンクを送信させ、メールに載ったトークンを返す。
+	requestToken := func(t *testing.T, svc *Service, sender *fakeMailSender) string {
+		t.Helper()
+		if err := svc.RequestMagicLink(context.Background(), "user@example.com"); err != nil {
+			t.Fatalf("RequestMagicLink() error = %v", err)
+		}
+		return tokenFromMail(t, sender.sent[len(sender.sent)-1])
+	}
+
+	t.Run("未登録のメールアドレスのときユーザーを作成してセッションを発行する", func(t *testing.T) {
+		// Arrange
+		var createdUser *model.User
+		var createdIdentity *model.Identity
+		userRepo := &mockUserRepo{
+			createWithIdentityFn: func(_ context.Context, user *model.User, identity *model.Identity) error {
+				createdUser, createdIdentity = user, identity
+				return nil
+			},
+		}
+		sender := &fakeMailSender{}
+		recorder := &mockLoginEventRecorder{}
+		svc := NewService(nil, userRepo, &mockIdentityRepo{}, &mockSessionRepo{}, ServiceConfig{SessionMaxAge: 3600},
+			WithMagicLink(newFakeLoginTokenRepo(), sender, testMagicLinkURL), WithLoginEventRecorder(recorder))
+		token := requestToken(t, svc, sender)
+
+		// Act
+		session, err := svc.VerifyMagicLink(context.Background(), token)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if createdUser == nil || createdUser.Email != "user@example.com" || createdUser.Name != "user" {
+			t.Fatalf("created user = %+v", createdUser)
+		}
+		if createdIdentity.Provider != MagicLinkProvider || createdIdentity.ProviderUserID != "user@example.com" {
+			t.Errorf("created identity = %+v", createdIdentity)
+		}
+		if session.UserID != createdUser.ID || session.IdentityID != createdIdentity.ID {
+			t.Errorf("session = %+v", session)
+		}
+		if len(recorder.events) != 1 || recorder.events[0].Event != model.LoginEventSuccess {
+			t.Errorf("events = %+v", recorder.events)
+		}
+	})
+
+	t.Run("登録済みのメールアドレスのとき既存ユーザーでログインする", func(t *testing.T) {
+		// Arrange
+		identRepo := &mockIdentityRepo{
+			findByProviderFn: func(_ context.Context, provider, providerUserID string) (*model.Identity, error) {
+				if provider == MagicLinkProvider && providerUserID == "user@example.com" {
+					return &model.Identity{ID: "ident-1", UserID: "user-1"}, nil
+				}
+				return nil, nil
+			},
+		}
+		sender := &fakeMailSender{}
+		svc := NewService(nil, &mockUserRepo{}, identRepo, &mockSessionRepo{}, ServiceConfig{SessionMaxAge: 3600},
+			WithMagicLink(newFakeLoginTokenRepo(), sender, testMagicLinkURL))
+		token := requestToken(t, svc, sender)
+
+		// Act
+		session, err := svc.VerifyMagicLink(context.Background(), token)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if session.UserID != "user-1" || session.IdentityID != "ident-1" {
+			t.Errorf("session = %+v", session)
+		}
+	})
+
+	t.Run("使用済みのトークンのとき INVALID_LOGIN_TOKEN を返しログイン失敗を記録する", func(t *testing.T) {
+		// Arrange
+		sender := &fakeMailSender{}
+		recorder := &mockLoginEventRecorder{}
+		svc := NewService(nil, &mockUserRepo{}, &mockIdentityRepo{}, &mockSessionRepo{}, ServiceConfig{SessionMaxAge: 3600},
+			WithMagicLink(newFakeLoginTokenRepo(), sender, testMagicLinkURL), WithLoginEventRecorder(recorder))
+		token := requestToken(t, svc, sender)
+		if _, err := svc.VerifyMagicLink(context.Background(), token); err != nil {
+			t.Fatalf("first VerifyMagicLink() error = %v", err)
+		}
+
+		// Act
+		_, err := svc.VerifyMagicLink(context.Background(), token)
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidLoginToken {
+			t.Fatalf("error = %v, want INVALID_LOGIN_TOKEN", err)
+		}
+		last := recorder.events[len(recorder.events)-1]
+		if last.Event != model.LoginEventFailure || last.Reason != loginFailureMagicLink {
+			t.Errorf("event = %+v", last)
+		}
+	})
+
+	t.Run("期限切れのトークンのとき INVALID_LOGIN_TOKEN を返す", func(t *testing.T) {
+		// Arrange
+		repo := newFakeLoginTokenRepo()
+		expired := time.Now().Add(-time.Minute)
+		_ = repo.Create(context.Background(), &model.LoginToken{
+			TokenHash: hashLoginToken("expired-token"),
+			Email:     "user@example.com",
+			ExpiresAt: expired,
+			CreatedAt: expired.Add(-model.LoginTokenTTL),
+		})
+		svc := NewService(nil, &mockUserRepo{}, &mockIdentityRepo{}, &mockSessionRepo{}, ServiceConfig{},
+			WithMagicLink(repo, &fakeMailSender{}, testMagicLinkURL))
+
+		// Act
+		_, err := svc.VerifyMagicLink(context.Background(), "expired-token")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidLoginToken {
+			t.Errorf("error = %v, want INVALID_LOGIN_TOKEN", err)
+		}
+	})
+}
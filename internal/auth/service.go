@@ -11,10 +11,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hitoshi/feedman/internal/mail"
 	"github.com/hitoshi/feedman/internal/model"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
+// sessionIDBytes はセッションIDの生成に使う乱数のバイト数（256bit）。
+const sessionIDBytes = 32
+
 // OAuthUserInfo はOAuthプロバイダーから取得したユーザー情報を表す。
 type OAuthUserInfo struct {
 	ProviderUserID string
@@ -32,6 +36,21 @@ type OAuthProvider interface {
 	ExchangeCode(ctx context.Context, code string) (*OAuthUserInfo, error)
 }
 
+// ログイン失敗の理由として login_events.reason に記録する値。
+const (
+	loginFailureOAuthExchange  = "oauth_exchange_failed"
+	loginFailureIdentityLookup = "identity_lookup_failed"
+	loginFailureUserCreation   = "user_creation_failed"
+	loginFailureSessionCreate  = "session_creation_failed"
+	loginFailureMagicLink      = "magic_link_invalid"
+)
+
+// LoginEventRecorder はログインイベントの記録先。audit.LoginEventService が実装する。
+// 記録は補助的なものとし、保存の失敗は呼び出し元に返さない。
+type LoginEventRecorder interface {
+	RecordLoginEvent(ctx context.Context, event model.LoginEvent)
+}
+
 // ServiceConfig は認証サービスの設定。
 type ServiceConfig struct {
 	SessionMaxAge int // セッション有効期間（秒）
@@ -44,6 +63,23 @@ type Service struct {
 	identRepo   repository.IdentityRepository
 	sessionRepo repository.SessionRepository
 	config      ServiceConfig
+	// loginEvents はログインイベントの記録先。nil の場合は記録しない。
+	loginEvents LoginEventRecorder
+	// loginTokens / mailer / magicLinkURL はマジックリンク認証の設定。WithMagicLink で設定する。
+	loginTokens  repository.LoginTokenRepository
+	mailer       mail.Sender
+	magicLinkURL string
+}
+
+// ServiceOption は Service の任意設定。
+type ServiceOption func(*Service)
+
+// WithLoginEventRecorder はログイン成功・失敗・ログアウトを recorder に記録する。
+// 接続元の情報は ContextWithClientInfo で呼び出し元が渡す。
+func WithLoginEventRecorder(recorder LoginEventRecorder) ServiceOption {
+	return func(s *Service) {
+		s.loginEvents = recorder
+	}
 }
 
 // NewService はServiceを生成する。
@@ -53,14 +89,19 @@ func NewService(
 	identRepo repository.IdentityRepository,
 	sessionRepo repository.SessionRepository,
 	config ServiceConfig,
+	opts ...ServiceOption,
 ) *Service {
-	return &Service{
+	s := &Service{
 		oauth:       oauth,
 		userRepo:    userRepo,
 		identRepo:   identRepo,
 		sessionRepo: sessionRepo,
 		config:      config,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetLoginURL はOAuth認証URLを生成する。
@@ -71,87 +112,178 @@ func (s *Service) GetLoginURL(state string) string {
 // HandleCallback はOAuthコールバックを処理し、セッションを発行する。
 // 未登録ユーザーの場合はusersレコードとidentitiesレコードを同時に自動作成する。
 // 登録済みユーザーの場合はidentitiesテーブルで既存ユーザーを特定しログインする。
+// 成功・失敗はログインイベントとして記録する（ユーザーを特定できない失敗は user_id なしで記録する）。
 func (s *Service) HandleCallback(ctx context.Context, code string) (*model.Session, error) {
 	// 1. 認可コードをトークンに交換し、ユーザー情報を取得
 	userInfo, err := s.oauth.ExchangeCode(ctx, code)
 	if err != nil {
+		s.recordLoginEvent(ctx, "", model.LoginEventFailure, loginFailureOAuthExchange)
 		return nil, fmt.Errorf("failed to exchange oauth code: %w", err)
 	}
 
-	// 2. identitiesテーブルで既存ユーザーを検索
-	identity, err := s.identRepo.FindByProviderAndProviderUserID(ctx, userInfo.Provider, userInfo.ProviderUserID)
+	// 2. identities テーブルで既存ユーザーを特定し、未登録の場合は作成
+	userID, identityID, err := s.findOrCreateUser(ctx, userInfo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find identity: %w", err)
+		return nil, err
 	}
 
-	var userID string
+	// 3. セッションを発行（連携解除時に失効できるよう、ログインに用いた identity を記録する）
+	session, err := s.createSession(ctx, userID, identityID)
+	if err != nil {
+		s.recordLoginEvent(ctx, userID, model.LoginEventFailure, loginFailureSessionCreate)
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	s.recordLoginEvent(ctx, userID, model.LoginEventSuccess, "")
+	return session, nil
+}
+
+// findOrCreateUser は identities テーブルで userInfo のユーザーを特定し、未登録の場合は
+// users レコードと identities レコードを同時に作成する。ユーザーIDと identity のIDを返す。
+// 失敗はユーザーを特定できないログイン失敗として記録する。
+func (s *Service) findOrCreateUser(ctx context.Context, userInfo *OAuthUserInfo) (userID, identityID string, err error) {
+	identity, err := s.identRepo.FindByProviderAndProviderUserID(ctx, userInfo.Provider, userInfo.ProviderUserID)
+	if err != nil {
+		s.recordLoginEvent(ctx, "", model.LoginEventFailure, loginFailureIdentityLookup)
+		return "", "", fmt.Errorf("failed to find identity: %w", err)
+	}
 
 	if identity != nil {
-		// 3a. 既存ユーザー: identityからユーザーIDを取得
-		userID = identity.UserID
+		// 既存ユーザー: identityからユーザーIDを取得
 		slog.Info("existing user logged in",
-			slog.String("user_id", userID),
+			slog.String("user_id", identity.UserID),
 			slog.String("provider", userInfo.Provider),
 		)
-	} else {
-		// 3b. 新規ユーザー: usersレコードとidentitiesレコードを同時に作成
-		newUserID := uuid.New().String()
-		newIdentityID := uuid.New().String()
-		now := time.Now()
-
-		newUser := &model.User{
-			ID:        newUserID,
-			Email:     userInfo.Email,
-			Name:      userInfo.Name,
-			CreatedAt: now,
-			UpdatedAt: now,
-		}
+		return identity.UserID, identity.ID, nil
+	}
 
-		newIdentity := &model.Identity{
-			ID:             newIdentityID,
-			UserID:         newUserID,
-			Provider:       userInfo.Provider,
-			ProviderUserID: userInfo.ProviderUserID,
-			CreatedAt:      now,
-		}
+	// 新規ユーザー: usersレコードとidentitiesレコードを同時に作成
+	newUserID := uuid.New().String()
+	newIdentityID := uuid.New().String()
+	now := time.Now()
 
-		if err := s.userRepo.CreateWithIdentity(ctx, newUser, newIdentity); err != nil {
-			return nil, fmt.Errorf("failed to create user and identity: %w", err)
-		}
+	newUser := &model.User{
+		ID:        newUserID,
+		Email:     userInfo.Email,
+		Name:      userInfo.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
 
-		userID = newUserID
-		// PII（メールアドレス平文）をログに残さないため、email はマスク値で出力する。
-		// 後方互換のため user_id / provider のキー名・出力有無は変更しない。
-		slog.Info("new user created",
-			slog.String("user_id", userID),
-			slog.String("email", maskEmail(userInfo.Email)),
-			slog.String("provider", userInfo.Provider),
-		)
+	newIdentity := &model.Identity{
+		ID:             newIdentityID,
+		UserID:         newUserID,
+		Provider:       userInfo.Provider,
+		ProviderUserID: userInfo.ProviderUserID,
+		CreatedAt:      now,
 	}
 
-	// 4. セッションを発行
-	session, err := s.createSession(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+	if err := s.userRepo.CreateWithIdentity(ctx, newUser, newIdentity); err != nil {
+		s.recordLoginEvent(ctx, "", model.LoginEventFailure, loginFailureUserCreation)
+		return "", "", fmt.Errorf("failed to create user and identity: %w", err)
 	}
 
-	return session, nil
+	// PII（メールアドレス平文）をログに残さないため、email はマスク値で出力する。
+	// 後方互換のため user_id / provider のキー名・出力有無は変更しない。
+	slog.Info("new user created",
+		slog.String("user_id", newUserID),
+		slog.String("email", maskEmail(userInfo.Email)),
+		slog.String("provider", userInfo.Provider),
+	)
+	return newUserID, newIdentityID, nil
 }
 
 // Logout はセッションを破棄する。
+// ログインイベントを記録する場合は、破棄前にセッションの所有者を特定してログアウトを記録する
+// （存在しない・期限切れのセッションでは記録しない）。
 func (s *Service) Logout(ctx context.Context, sessionID string) error {
 	if sessionID == "" {
 		return fmt.Errorf("session ID is required")
 	}
 
+	var userID string
+	if s.loginEvents != nil {
+		// 所有者の特定は記録のためだけに行うため、失敗してもログアウト自体は続行する。
+		session, err := s.sessionRepo.FindByID(ctx, sessionID)
+		if err != nil {
+			slog.Warn("failed to find session for logout event",
+				slog.String("session_id_hash", hashSessionIDForLog(sessionID)),
+				slog.String("error", err.Error()),
+			)
+		} else if session != nil {
+			userID = session.UserID
+		}
+	}
+
 	if err := s.sessionRepo.DeleteByID(ctx, sessionID); err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
 
+	if userID != "" {
+		s.recordLoginEvent(ctx, userID, model.LoginEventLogout, "")
+	}
+
 	slog.Info("user logged out", slog.String("session_id_hash", hashSessionIDForLog(sessionID)))
 	return nil
 }
 
+// RotateSession はセッションIDを再生成（ローテーション）する。
+// 旧セッションと同じユーザーの新しいセッションを発行し、旧セッションを即時に削除する。
+// 旧セッションが存在しない（期限切れ・削除済み）場合は nil を返す。
+// 旧セッションの削除に失敗した場合は、新旧 2 つのセッションが有効なまま残らないよう
+// 新しいセッションを破棄してエラーを返す。
+func (s *Service) RotateSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("session ID is required")
+	}
+
+	current, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+	if current == nil {
+		return nil, nil
+	}
+
+	session, err := s.createSession(ctx, current.UserID, current.IdentityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := s.sessionRepo.DeleteByID(ctx, sessionID); err != nil {
+		if cleanupErr := s.sessionRepo.DeleteByID(ctx, session.ID); cleanupErr != nil {
+			slog.Error("failed to discard rotated session",
+				slog.String("session_id_hash", hashSessionIDForLog(session.ID)),
+				slog.String("error", cleanupErr.Error()),
+			)
+		}
+		return nil, fmt.Errorf("failed to revoke old session: %w", err)
+	}
+
+	slog.Info("session rotated",
+		slog.String("user_id", current.UserID),
+		slog.String("old_session_id_hash", hashSessionIDForLog(sessionID)),
+		slog.String("session_id_hash", hashSessionIDForLog(session.ID)),
+	)
+	return session, nil
+}
+
+// recordLoginEvent はコンテキストの接続元情報を添えてログインイベントを記録する。
+// 記録先が未設定の場合は何もしない。
+func (s *Service) recordLoginEvent(ctx context.Context, userID string, eventType model.LoginEventType, reason string) {
+	if s.loginEvents == nil {
+		return
+	}
+	client := clientInfoFromContext(ctx)
+	s.loginEvents.RecordLoginEvent(ctx, model.LoginEvent{
+		UserID:    userID,
+		Event:     eventType,
+		Reason:    reason,
+		IPAddress: client.RemoteAddr,
+		UserAgent: client.UserAgent,
+	})
+}
+
 // hashSessionIDForLog はセッションIDをログ出力用の復元不能な短縮値に変換する。
 // SHA-256 ハッシュの hex 表現の先頭 8 文字を返す純粋関数であり、副作用を持たず
 // error も返さない。空文字を含む任意の入力に対してパニックせず安全に値を返す。
@@ -186,18 +318,19 @@ func (s *Service) GetCurrentUser(ctx context.Context, sessionID string) (*model.
 	return user, nil
 }
 
-// createSession はセッションを作成し永続化する。
-func (s *Service) createSession(ctx context.Context, userID string) (*model.Session, error) {
+// createSession はセッションを作成し永続化する。identityID はログインに用いた identity（不明な場合は空文字）。
+func (s *Service) createSession(ctx context.Context, userID, identityID string) (*model.Session, error) {
 	sessionID, err := generateSessionID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate session ID: %w", err)
 	}
 
 	session := &model.Session{
-		ID:        sessionID,
-		UserID:    userID,
-		ExpiresAt: time.Now().Add(time.Duration(s.config.SessionMaxAge) * time.Second),
-		CreatedAt: time.Now(),
+		ID:         sessionID,
+		UserID:     userID,
+		IdentityID: identityID,
+		ExpiresAt:  time.Now().Add(time.Duration(s.config.SessionMaxAge) * time.Second),
+		CreatedAt:  time.Now(),
 	}
 
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
@@ -208,8 +341,9 @@ func (s *Service) createSession(ctx context.Context, userID string) (*model.Sess
 }
 
 // generateSessionID は暗号的に安全なセッションIDを生成する。
+// crypto/rand の 256bit 乱数を hex 表現（64 文字）で返す。
 func generateSessionID() (string, error) {
-	b := make([]byte, 32)
+	b := make([]byte, sessionIDBytes)
 	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
@@ -52,6 +52,8 @@ type mockSessionRepo struct {
 	findByIDFn       func(ctx context.Context, id string) (*model.Session, error)
 	deleteByIDFn     func(ctx context.Context, id string) error
 	deleteByUserIDFn func(ctx context.Context, userID string) error
+	// deleteByIdentityIDFn は DeleteByIdentityID の振る舞い。nil の場合は何もしない。
+	deleteByIdentityIDFn func(ctx context.Context, userID, identityID string) error
 }
 
 func (m *mockSessionRepo) Create(ctx context.Context, session *model.Session) error {
@@ -82,6 +84,13 @@ func (m *mockSessionRepo) DeleteByUserID(ctx context.Context, userID string) err
 	return nil
 }
 
+func (m *mockSessionRepo) DeleteByIdentityID(ctx context.Context, userID, identityID string) error {
+	if m.deleteByIdentityIDFn != nil {
+		return m.deleteByIdentityIDFn(ctx, userID, identityID)
+	}
+	return nil
+}
+
 type mockOAuthProvider struct {
 	getLoginURLFn  func(state string) string
 	exchangeCodeFn func(ctx context.Context, code string) (*OAuthUserInfo, error)
@@ -215,6 +224,10 @@ func TestHandleCallback_NewUser_CreatesUserAndIdentityAndSession(t *testing.T) {
 	if createdSession.UserID != createdUser.ID {
 		t.Errorf("session userID = %q, want %q", createdSession.UserID, createdUser.ID)
 	}
+	// 連携解除時に失効できるよう、ログインに用いた identity が記録されること
+	if createdSession.IdentityID != createdIdentity.ID {
+		t.Errorf("session identityID = %q, want %q", createdSession.IdentityID, createdIdentity.ID)
+	}
 	if createdSession.ExpiresAt.Before(time.Now()) {
 		t.Error("session should not be expired")
 	}
@@ -290,6 +303,9 @@ func TestHandleCallback_ExistingUser_LogsInAndCreatesSession(t *testing.T) {
 	if createdSession.UserID != existingUserID {
 		t.Errorf("session userID = %q, want %q", createdSession.UserID, existingUserID)
 	}
+	if createdSession.IdentityID != "identity-id-1" {
+		t.Errorf("session identityID = %q, want %q", createdSession.IdentityID, "identity-id-1")
+	}
 }
 
 func TestHandleCallback_IssuesDistinctSessionIDPerLogin(t *testing.T) {
@@ -434,6 +450,119 @@ func TestLogout_EmptySessionID_ReturnsError(t *testing.T) {
 	}
 }
 
+func TestRotateSession_IssuesNewSessionAndRevokesOld(t *testing.T) {
+	ctx := context.Background()
+
+	var created *model.Session
+	var deleted []string
+	sessionRepo := &mockSessionRepo{
+		findByIDFn: func(ctx context.Context, id string) (*model.Session, error) {
+			return &model.Session{ID: id, UserID: "user-rotate"}, nil
+		},
+		createFn: func(ctx context.Context, session *model.Session) error {
+			created = session
+			return nil
+		},
+		deleteByIDFn: func(ctx context.Context, id string) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+	}
+
+	svc := NewService(nil, nil, nil, sessionRepo, ServiceConfig{SessionMaxAge: 86400})
+
+	session, err := svc.RotateSession(ctx, "old-session-id")
+	if err != nil {
+		t.Fatalf("RotateSession() error = %v", err)
+	}
+
+	if session == nil || created == nil || session.ID != created.ID {
+		t.Fatalf("session = %+v, want saved session %+v", session, created)
+	}
+	if session.ID == "old-session-id" {
+		t.Error("rotated session ID must differ from old one")
+	}
+	if session.UserID != "user-rotate" {
+		t.Errorf("UserID = %q, want %q", session.UserID, "user-rotate")
+	}
+	if len(deleted) != 1 || deleted[0] != "old-session-id" {
+		t.Errorf("deleted = %v, want [old-session-id]", deleted)
+	}
+}
+
+func TestRotateSession_SessionNotFound_ReturnsNil(t *testing.T) {
+	ctx := context.Background()
+
+	createCalled := false
+	sessionRepo := &mockSessionRepo{
+		createFn: func(ctx context.Context, session *model.Session) error {
+			createCalled = true
+			return nil
+		},
+	}
+
+	svc := NewService(nil, nil, nil, sessionRepo, ServiceConfig{SessionMaxAge: 86400})
+
+	session, err := svc.RotateSession(ctx, "expired-session-id")
+	if err != nil || session != nil {
+		t.Fatalf("RotateSession() = (%+v, %v), want (nil, nil)", session, err)
+	}
+	if createCalled {
+		t.Error("expected no new session for missing session")
+	}
+}
+
+func TestRotateSession_RevokeFails_DiscardsNewSessionAndReturnsError(t *testing.T) {
+	ctx := context.Background()
+
+	var newID string
+	var deleted []string
+	sessionRepo := &mockSessionRepo{
+		findByIDFn: func(ctx context.Context, id string) (*model.Session, error) {
+			return &model.Session{ID: id, UserID: "user-rotate"}, nil
+		},
+		createFn: func(ctx context.Context, session *model.Session) error {
+			newID = session.ID
+			return nil
+		},
+		deleteByIDFn: func(ctx context.Context, id string) error {
+			deleted = append(deleted, id)
+			if id == "old-session-id" {
+				return errors.New("db error")
+			}
+			return nil
+		},
+	}
+
+	svc := NewService(nil, nil, nil, sessionRepo, ServiceConfig{SessionMaxAge: 86400})
+
+	session, err := svc.RotateSession(ctx, "old-session-id")
+	if err == nil || session != nil {
+		t.Fatalf("RotateSession() = (%+v, %v), want error", session, err)
+	}
+	if len(deleted) != 2 || deleted[1] != newID {
+		t.Errorf("deleted = %v, want new session %q to be discarded", deleted, newID)
+	}
+}
+
+func TestRotateSession_EmptySessionID_ReturnsError(t *testing.T) {
+	svc := NewService(nil, nil, nil, nil, ServiceConfig{SessionMaxAge: 86400})
+
+	if _, err := svc.RotateSession(context.Background(), ""); err == nil {
+		t.Fatal("expected error for empty session ID")
+	}
+}
+
+func TestGenerateSessionID_Returns256BitHex(t *testing.T) {
+	id, err := generateSessionID()
+	if err != nil {
+		t.Fatalf("generateSessionID() error = %v", err)
+	}
+	if len(id) != 64 {
+		t.Errorf("len(id) = %d, want 64 (256bit hex)", len(id))
+	}
+}
+
 func TestHashSessionIDForLog_SameInput_ReturnsSameValue(t *testing.T) {
 	// Req 2.1: 同一のセッション ID から短縮値を生成すると常に同一の短縮値を返す
 	// Arrange
@@ -563,3 +692,175 @@ func TestGetCurrentUser_EmptySessionID_ReturnsError(t *testing.T) {
 		t.Fatal("expected error for empty session ID")
 	}
 }
+
+// mockLoginEventRecorder は LoginEventRecorder のモック実装。
+type mockLoginEventRecorder struct {
+	events []model.LoginEvent
+}
+
+func (m *mockLoginEventRecorder) RecordLoginEvent(_ context.Context, event model.LoginEvent) {
+	m.events = append(m.events, event)
+}
+
+func TestService_LoginEvents(t *testing.T) {
+	client := ClientInfo{RemoteAddr: "203.0.113.45:52100", UserAgent: "test-agent/1.0"}
+	existingUser := &mockIdentityRepo{
+		findByProviderFn: func(_ context.Context, _, _ string) (*model.Identity, error) {
+			return &model.Identity{UserID: "user-1"}, nil
+		},
+	}
+	validProvider := &mockOAuthProvider{
+		exchangeCodeFn: func(_ context.Context, _ string) (*OAuthUserInfo, error) {
+			return &OAuthUserInfo{ProviderUserID: "g-1", Provider: "google"}, nil
+		},
+	}
+
+	t.Run("ログインに成功したとき接続元の情報とともにlogin_successを記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockLoginEventRecorder{}
+		svc := NewService(validProvider, &mockUserRepo{}, existingUser, &mockSessionRepo{}, ServiceConfig{SessionMaxAge: 3600},
+			WithLoginEventRecorder(recorder))
+
+		// Act
+		_, err := svc.HandleCallback(ContextWithClientInfo(context.Background(), client), "code")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(recorder.events) != 1 {
+			t.Fatalf("events = %+v, want 1 event", recorder.events)
+		}
+		got := recorder.events[0]
+		if got.UserID != "user-1" || got.Event != model.LoginEventSuccess || got.Reason != "" ||
+			got.IPAddress != client.RemoteAddr || got.UserAgent != client.UserAgent {
+			t.Errorf("event = %+v", got)
+		}
+	})
+
+	t.Run("認可コードの交換に失敗したときユーザーなしでlogin_failureを記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockLoginEventRecorder{}
+		provider := &mockOAuthProvider{
+			exchangeCodeFn: func(_ context.Context, _ string) (*OAuthUserInfo, error) {
+				return nil, errors.New("invalid_grant")
+			},
+		}
+		svc := NewService(provider, &mockUserRepo{}, existingUser, &mockSessionRepo{}, ServiceConfig{},
+			WithLoginEventRecorder(recorder))
+
+		// Act
+		_, err := svc.HandleCallback(ContextWithClientInfo(context.Background(), client), "bad-code")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if len(recorder.events) != 1 {
+			t.Fatalf("events = %+v, want 1 event", recorder.events)
+		}
+		got := recorder.events[0]
+		if got.UserID != "" || got.Event != model.LoginEventFailure || got.Reason != loginFailureOAuthExchange || got.IPAddress != client.RemoteAddr {
+			t.Errorf("event = %+v", got)
+		}
+	})
+
+	t.Run("セッションの発行に失敗したときユーザー付きでlogin_failureを記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockLoginEventRecorder{}
+		sessionRepo := &mockSessionRepo{
+			createFn: func(_ context.Context, _ *model.Session) error {
+				return errors.New("db error")
+			},
+		}
+		svc := NewService(validProvider, &mockUserRepo{}, existingUser, sessionRepo, ServiceConfig{},
+			WithLoginEventRecorder(recorder))
+
+		// Act
+		_, err := svc.HandleCallback(context.Background(), "code")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if len(recorder.events) != 1 || recorder.events[0].UserID != "user-1" ||
+			recorder.events[0].Event != model.LoginEventFailure || recorder.events[0].Reason != loginFailureSessionCreate {
+			t.Errorf("events = %+v", recorder.events)
+		}
+	})
+
+	t.Run("ログアウトしたときセッションの所有者でlogoutを記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockLoginEventRecorder{}
+		deleted := false
+		sessionRepo := &mockSessionRepo{
+			findByIDFn: func(_ context.Context, id string) (*model.Session, error) {
+				return &model.Session{ID: id, UserID: "user-1"}, nil
+			},
+			deleteByIDFn: func(_ context.Context, _ string) error {
+				deleted = true
+				return nil
+			},
+		}
+		svc := NewService(nil, nil, nil, sessionRepo, ServiceConfig{}, WithLoginEventRecorder(recorder))
+
+		// Act
+		err := svc.Logout(ContextWithClientInfo(context.Background(), client), "session-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !deleted {
+			t.Error("session should be deleted")
+		}
+		if len(recorder.events) != 1 || recorder.events[0].UserID != "user-1" ||
+			recorder.events[0].Event != model.LoginEventLogout || recorder.events[0].UserAgent != client.UserAgent {
+			t.Errorf("events = %+v", recorder.events)
+		}
+	})
+
+	t.Run("存在しないセッションのログアウトでは記録しない", func(t *testing.T) {
+		// Arrange
+		recorder := &mockLoginEventRecorder{}
+		svc := NewService(nil, nil, nil, &mockSessionRepo{}, ServiceConfig{}, WithLoginEventRecorder(recorder))
+
+		// Act
+		err := svc.Logout(context.Background(), "expired-session")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(recorder.events) != 0 {
+			t.Errorf("events = %+v, want none", recorder.events)
+		}
+	})
+
+	t.Run("所有者の特定に失敗してもログアウトする", func(t *testing.T) {
+		// Arrange
+		recorder := &mockLoginEventRecorder{}
+		deleted := false
+		sessionRepo := &mockSessionRepo{
+			findByIDFn: func(_ context.Context, _ string) (*model.Session, error) {
+				return nil, errors.New("db error")
+			},
+			deleteByIDFn: func(_ context.Context, _ string) error {
+				deleted = true
+				return nil
+			},
+		}
+		svc := NewService(nil, nil, nil, sessionRepo, ServiceConfig{}, WithLoginEventRecorder(recorder))
+
+		// Act
+		err := svc.Logout(context.Background(), "session-1")
+
+		// Assert
+		if err != nil || !deleted {
+			t.Errorf("err = %v, deleted = %v, want nil / true", err, deleted)
+		}
+		if len(recorder.events) != 0 {
+			t.Errorf("events = %+v, want none", recorder.events)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/worker/periodic"
+)
+
+const (
+	// DefaultSessionExpiryInterval は期限切れセッションのスキャン間隔の既定値。
+	DefaultSessionExpiryInterval = time.Hour
+	// sessionExpiryBatchSize は 1 回の削除で処理する期限切れセッションの最大件数。
+	sessionExpiryBatchSize = 500
+)
+
+// SessionExpiryJob は有効期限を過ぎたセッションを削除し、セッション失効のログインイベントを記録する worker ジョブ。
+// PostgreSQL のセッションストアでのみ用いる（Redis のセッションは TTL で消えるため失効を検出しない）。
+// 失効の記録時刻はスキャン時刻ではなくセッションの有効期限とする。
+type SessionExpiryJob struct {
+	repo     repository.ExpiredSessionRepository
+	recorder LoginEventRecorder
+	logger   *slog.Logger
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewSessionExpiryJob は SessionExpiryJob を生成する。interval が 0 以下の場合は DefaultSessionExpiryInterval を使う。
+func NewSessionExpiryJob(repo repository.ExpiredSessionRepository, recorder LoginEventRecorder, logger *slog.Logger, interval time.Duration) *SessionExpiryJob {
+	if interval <= 0 {
+		interval = DefaultSessionExpiryInterval
+	}
+	return &SessionExpiryJob{repo: repo, recorder: recorder, logger: logger, interval: interval, now: time.Now}
+}
+
+// RunOnce は期限切れのセッションをすべて削除し、1 件ごとにセッション失効を記録する。
+func (j *SessionExpiryJob) RunOnce(ctx context.Context) error {
+	now := j.now()
+	expired := 0
+	for {
+		sessions, err := j.repo.DeleteExpired(ctx, now, sessionExpiryBatchSize)
+		if err != nil {
+			return fmt.Errorf("期限切れセッションの削除に失敗: %w", err)
+		}
+		for _, session := range sessions {
+			j.recorder.RecordLoginEvent(ctx, model.LoginEvent{
+				UserID:    session.UserID,
+				Event:     model.LoginEventSessionExpired,
+				CreatedAt: session.ExpiresAt,
+			})
+		}
+		expired += len(sessions)
+
+		if len(sessions) < sessionExpiryBatchSize {
+			break
+		}
+	}
+
+	if expired > 0 {
+		j.logger.Info("期限切れのセッションを削除しました",
+			slog.Int("expired", expired),
+		)
+	}
+	return nil
+}
+
+// Start は起動直後と interval ごとに RunOnce を実行する。ctx がキャンセルされるまでブロックする。
+func (j *SessionExpiryJob) Start(ctx context.Context) {
+	periodic.Run(ctx, j.logger, "期限切れセッションの削除", j.interval, j.RunOnce)
+}
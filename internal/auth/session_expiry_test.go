@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockExpiredSessionRepo は repository.ExpiredSessionRepository のモック実装。
+type mockExpiredSessionRepo struct {
+	deleteExpiredFn func(ctx context.Context, now time.Time, limit int) ([]*model.Session, error)
+	calls           int
+}
+
+func (m *mockExpiredSessionRepo) DeleteExpired(ctx context.Context, now time.Time, limit int) ([]*model.Session, error) {
+	m.calls++
+	if m.deleteExpiredFn != nil {
+		return m.deleteExpiredFn(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+var _ repository.ExpiredSessionRepository = (*mockExpiredSessionRepo)(nil)
+
+func TestSessionExpiryJob_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 7, 4, 9, 0, 0, 0, time.UTC)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("期限切れのセッションごとに有効期限の時刻でsession_expiredを記録する", func(t *testing.T) {
+		// Arrange
+		expiresAt := now.Add(-30 * time.Minute)
+		var gotNow time.Time
+		repo := &mockExpiredSessionRepo{
+			deleteExpiredFn: func(_ context.Context, at time.Time, _ int) ([]*model.Session, error) {
+				gotNow = at
+				return []*model.Session{
+					{ID: "s-1", UserID: "user-1", ExpiresAt: expiresAt},
+					{ID: "s-2", UserID: "user-2", ExpiresAt: expiresAt},
+				}, nil
+			},
+		}
+		recorder := &mockLoginEventRecorder{}
+		job := NewSessionExpiryJob(repo, recorder, logger, 0)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if !gotNow.Equal(now) {
+			t.Errorf("基準時刻 = %v, want %v", gotNow, now)
+		}
+		if len(recorder.events) != 2 {
+			t.Fatalf("events = %+v, want 2 events", recorder.events)
+		}
+		for i, want := range []string{"user-1", "user-2"} {
+			got := recorder.events[i]
+			if got.UserID != want || got.Event != model.LoginEventSessionExpired || !got.CreatedAt.Equal(expiresAt) {
+				t.Errorf("events[%d] = %+v", i, got)
+			}
+		}
+	})
+
+	t.Run("1バッチ分の件数があるとき続きを削除する", func(t *testing.T) {
+		// Arrange
+		repo := &mockExpiredSessionRepo{}
+		repo.deleteExpiredFn = func(_ context.Context, _ time.Time, limit int) ([]*model.Session, error) {
+			if repo.calls > 1 {
+				return []*model.Session{{ID: "s-last", UserID: "user-1"}}, nil
+			}
+			sessions := make([]*model.Session, limit)
+			for i := range sessions {
+				sessions[i] = &model.Session{ID: "s", UserID: "user-1"}
+			}
+			return sessions, nil
+		}
+		recorder := &mockLoginEventRecorder{}
+		job := NewSessionExpiryJob(repo, recorder, logger, 0)
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if repo.calls != 2 || len(recorder.events) != sessionExpiryBatchSize+1 {
+			t.Errorf("calls = %d, events = %d, want 2 / %d", repo.calls, len(recorder.events), sessionExpiryBatchSize+1)
+		}
+	})
+
+	t.Run("削除に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockExpiredSessionRepo{
+			deleteExpiredFn: func(_ context.Context, _ time.Time, _ int) ([]*model.Session, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		recorder := &mockLoginEventRecorder{}
+		job := NewSessionExpiryJob(repo, recorder, logger, 0)
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err == nil {
+			t.Error("expected error")
+		}
+		if len(recorder.events) != 0 {
+			t.Errorf("events = %+v, want none", recorder.events)
+		}
+	})
+}
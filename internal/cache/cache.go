@@ -0,0 +1,33 @@
+// Package cache はサービス層で用いる短期キャッシュの抽象と実装を提供する。
+//
+// キャッシュは Cache インターフェース越しに利用し、既定の in-memory 実装（Memory）を
+// 将来 Redis 等の外部ストアに差し替えられるようにする。ヒット率は Instrument で包んだ
+// キャッシュが Recorder（Prometheus コレクタ）へ記録する。
+package cache
+
+import "context"
+
+// Cache はキー単位で値を保持する短期キャッシュのインターフェース。
+// 実装は複数 goroutine から安全に呼び出せなければならない。
+// 取得・保存の失敗はキャッシュミスとして扱い、呼び出し元には返さない（キャッシュは最適化に過ぎないため）。
+type Cache[V any] interface {
+	// Get はキーに対応する有効な値を返す。存在しない・期限切れの場合は ok = false。
+	Get(ctx context.Context, key string) (value V, ok bool)
+	// Set はキーに値を保存する。有効期限は実装の TTL に従う。
+	Set(ctx context.Context, key string, value V)
+	// Delete はキーに対応する値を無効化する。存在しない場合は何もしない。
+	Delete(ctx context.Context, key string)
+}
+
+// UserInvalidator はユーザー単位のキャッシュを無効化するインターフェース。
+// 書き込み系のサービスが、自身の管轄外のキャッシュ（購読一覧など）を無効化するために使う。
+type UserInvalidator interface {
+	InvalidateUser(ctx context.Context, userID string)
+}
+
+// Recorder はキャッシュのヒット・ミスを記録するインターフェース。
+// metrics.Collector が実装する。
+type Recorder interface {
+	RecordCacheHit(cache string)
+	RecordCacheMiss(cache string)
+}
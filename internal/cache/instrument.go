@@ -0,0 +1,41 @@
+package cache
+
+import "context"
+
+// instrumented は Get のヒット・ミスを Recorder に記録する Cache デコレータ。
+type instrumented[V any] struct {
+	inner    Cache[V]
+	name     string
+	recorder Recorder
+}
+
+// Instrument は c を包み、Get のヒット・ミスを name ラベル付きで recorder に記録する Cache を返す。
+// 実装（in-memory / Redis 等）に依存せずヒット率を計測するためのデコレータ。
+// recorder が nil の場合は c をそのまま返す。
+func Instrument[V any](c Cache[V], name string, recorder Recorder) Cache[V] {
+	if recorder == nil {
+		return c
+	}
+	return &instrumented[V]{inner: c, name: name, recorder: recorder}
+}
+
+// Get は内側のキャッシュから値を取得し、結果をヒット・ミスとして記録する。
+func (c *instrumented[V]) Get(ctx context.Context, key string) (V, bool) {
+	v, ok := c.inner.Get(ctx, key)
+	if ok {
+		c.recorder.RecordCacheHit(c.name)
+	} else {
+		c.recorder.RecordCacheMiss(c.name)
+	}
+	return v, ok
+}
+
+// Set は内側のキャッシュに値を保存する。
+func (c *instrumented[V]) Set(ctx context.Context, key string, value V) {
+	c.inner.Set(ctx, key, value)
+}
+
+// Delete は内側のキャッシュの値を無効化する。
+func (c *instrumented[V]) Delete(ctx context.Context, key string) {
+	c.inner.Delete(ctx, key)
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// mockRecorder は Recorder のモック実装。
+type mockRecorder struct {
+	hits   map[string]int
+	misses map[string]int
+}
+
+func newMockRecorder() *mockRecorder {
+	return &mockRecorder{hits: map[string]int{}, misses: map[string]int{}}
+}
+
+func (r *mockRecorder) RecordCacheHit(cache string)  { r.hits[cache]++ }
+func (r *mockRecorder) RecordCacheMiss(cache string) { r.misses[cache]++ }
+
+func TestInstrument(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Getのヒットとミスをキャッシュ名付きで記録する", func(t *testing.T) {
+		// Arrange
+		rec := newMockRecorder()
+		c := Instrument[string](NewMemory[string](time.Minute), "subscriptions", rec)
+		c.Set(ctx, "k", "v")
+
+		// Act
+		c.Get(ctx, "k")
+		c.Get(ctx, "k")
+		c.Get(ctx, "missing")
+
+		// Assert
+		if rec.hits["subscriptions"] != 2 || rec.misses["subscriptions"] != 1 {
+			t.Errorf("hits/misses = %d/%d, want 2/1", rec.hits["subscriptions"], rec.misses["subscriptions"])
+		}
+	})
+
+	t.Run("Deleteが内側のキャッシュに委譲される", func(t *testing.T) {
+		// Arrange
+		inner := NewMemory[string](time.Minute)
+		c := Instrument[string](inner, "subscriptions", newMockRecorder())
+		c.Set(ctx, "k", "v")
+
+		// Act
+		c.Delete(ctx, "k")
+
+		// Assert
+		if _, ok := inner.Get(ctx, "k"); ok {
+			t.Error("Delete が内側のキャッシュに反映されるべき")
+		}
+	})
+
+	t.Run("recorderがnilのとき元のキャッシュをそのまま返す", func(t *testing.T) {
+		// Arrange
+		inner := NewMemory[string](time.Minute)
+
+		// Act
+		c := Instrument[string](inner, "subscriptions", nil)
+
+		// Assert
+		if c != Cache[string](inner) {
+			t.Error("recorder が nil のときは元のキャッシュを返すべき")
+		}
+	})
+}
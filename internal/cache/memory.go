@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryEntry は Memory が保持する値と有効期限。
+type memoryEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Memory はプロセス内メモリに値を保持する TTL 付きキャッシュ。
+// 期限切れエントリは Get 時に破棄し、加えて Set 時に TTL 間隔で一括掃除する
+// （アクセスの途絶えたキーが残り続けないようにするため）。
+//
+// 複数プロセス間では共有されないため、別プロセス（worker 等）の書き込みは TTL 経過まで反映されない。
+type Memory[V any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]memoryEntry[V]
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewMemory は TTL を指定して Memory を生成する。
+func NewMemory[V any](ttl time.Duration) *Memory[V] {
+	return &Memory[V]{
+		ttl:     ttl,
+		entries: make(map[string]memoryEntry[V]),
+		now:     time.Now,
+	}
+}
+
+// Get はキーに対応する有効な値を返す。
+func (m *Memory[V]) Get(_ context.Context, key string) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !m.now().Before(e.expiresAt) {
+		delete(m.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set はキーに値を保存する。
+func (m *Memory[V]) Set(_ context.Context, key string, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweepLocked(now)
+	m.entries[key] = memoryEntry[V]{value: value, expiresAt: now.Add(m.ttl)}
+}
+
+// Delete はキーに対応する値を無効化する。
+func (m *Memory[V]) Delete(_ context.Context, key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+}
+
+// sweepLocked は前回の掃除から TTL 以上経過していれば期限切れエントリを一括削除する。
+// 呼び出し元で mu を保持していること。
+func (m *Memory[V]) sweepLocked(now time.Time) {
+	if now.Sub(m.lastSweep) < m.ttl {
+		return
+	}
+	for k, e := range m.entries {
+		if !now.Before(e.expiresAt) {
+			delete(m.entries, k)
+		}
+	}
+	m.lastSweep = now
+}
+
+// compile-time interface check
+var _ Cache[int] = (*Memory[int])(nil)
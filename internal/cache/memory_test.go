@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestMemory(ttl time.Duration, now *time.Time) *Memory[string] {
+	m := NewMemory[string](ttl)
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func TestMemory_GetSet(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("保存した値をTTL内に取得できる", func(t *testing.T) {
+		// Arrange
+		m := newTestMemory(30*time.Second, &now)
+		m.Set(ctx, "k", "v")
+
+		// Act
+		got, ok := m.Get(ctx, "k")
+
+		// Assert
+		if !ok || got != "v" {
+			t.Errorf("Get() = (%q, %v), want (\"v\", true)", got, ok)
+		}
+	})
+
+	t.Run("未保存のキーのときミスになる", func(t *testing.T) {
+		// Arrange
+		m := newTestMemory(30*time.Second, &now)
+
+		// Act
+		_, ok := m.Get(ctx, "missing")
+
+		// Assert
+		if ok {
+			t.Error("未保存のキーはミスになるべき")
+		}
+	})
+
+	t.Run("TTLを経過したときミスになりエントリが破棄される", func(t *testing.T) {
+		// Arrange
+		current := now
+		m := newTestMemory(30*time.Second, &current)
+		m.Set(ctx, "k", "v")
+		current = now.Add(30 * time.Second)
+
+		// Act
+		_, ok := m.Get(ctx, "k")
+
+		// Assert
+		if ok {
+			t.Error("TTL 経過後はミスになるべき")
+		}
+		if len(m.entries) != 0 {
+			t.Errorf("entries = %d, want 0", len(m.entries))
+		}
+	})
+
+	t.Run("Deleteしたときミスになる", func(t *testing.T) {
+		// Arrange
+		m := newTestMemory(30*time.Second, &now)
+		m.Set(ctx, "k", "v")
+
+		// Act
+		m.Delete(ctx, "k")
+
+		// Assert
+		if _, ok := m.Get(ctx, "k"); ok {
+			t.Error("Delete 後はミスになるべき")
+		}
+	})
+}
+
+func TestMemory_SetSweepsExpiredEntries(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	current := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newTestMemory(30*time.Second, &current)
+	m.Set(ctx, "old-1", "v")
+	m.Set(ctx, "old-2", "v")
+	current = current.Add(time.Minute)
+
+	// Act
+	m.Set(ctx, "new", "v")
+
+	// Assert
+	if len(m.entries) != 1 {
+		t.Errorf("entries = %d, want 1（期限切れエントリは掃除されるべき）", len(m.entries))
+	}
+}
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"fmt"
 	"log/slog"
 	"os"
 	"strconv"
@@ -10,42 +9,46 @@ import (
 )
 
 // Config はアプリケーション全体の設定を保持する。
-// 環境変数から起動時に1回読み込み、イミュータブルとして扱う。
+// 既定値・設定ファイル（YAML）・環境変数の順に重ねて起動時に1回読み込み、イミュータブルとして扱う。
+// サブシステムごとの設定は埋め込みの構造体に分けており、各フィールドは従来どおり Config から直接参照できる。
 type Config struct {
-	// Database
-	DatabaseURL string
+	DatabaseConfig
+	AuthConfig
+	FetcherConfig
+	RateLimitConfig
+	HatebuConfig
+	SummarizerConfig
+	ReadLaterConfig
+	HighlightConfig
+	MailConfig
 
-	// OAuth
-	GoogleClientID     string
-	GoogleClientSecret string
-	GoogleRedirectURL  string
+	// Logging
+	LogRetentionDays int
 
-	// Session
-	SessionSecret string
-	SessionMaxAge int
+	// AdminStatsRefreshInterval は管理者向け全体統計（GET /api/admin/stats）を worker が再集計する間隔。
+	// ADMIN_STATS_REFRESH_INTERVAL から読み込む。既定値は 10 分。
+	AdminStatsRefreshInterval time.Duration
 
-	// Fetch
-	FetchTimeout       time.Duration
-	FetchMaxSize       int64
-	FetchMaxConcurrent int
-	FetchInterval      time.Duration
+	// WeeklyStatsSnapshotInterval は週次統計（GET /api/stats/weekly）のスナップショットを worker が記録する間隔。
+	// WEEKLY_STATS_SNAPSHOT_INTERVAL から読み込む。既定値は 6 時間。
+	WeeklyStatsSnapshotInterval time.Duration
 
-	// Rate Limit
-	RateLimitGeneral int
-	RateLimitFeedReg int
-	// RateLimitUnauthIP は未認証エンドポイント（/auth/google/login・/auth/google/callback・
-	// /health）に適用する IP 単位レート制限の閾値（req/min/IP）。
-	// RATE_LIMIT_UNAUTH_IP から読み込む。既定値は 30。不正値時は既定値にフォールバックする。
-	RateLimitUnauthIP int
+	// TrialExpiryInterval は期限を過ぎたお試し購読を worker が自動解除する間隔。
+	// TRIAL_EXPIRY_INTERVAL から読み込む。既定値は 10 分。
+	TrialExpiryInterval time.Duration
 
-	// Hatebu
-	HatebuTTL              time.Duration
-	HatebuBatchInterval    time.Duration
-	HatebuAPIInterval      time.Duration
-	HatebuMaxCallsPerCycle int
+	// LinkCheck
+	// LinkCheckInterval はスター記事のリンク切れチェックを worker が実行する間隔。
+	// LINK_CHECK_INTERVAL から読み込む。既定値は 24 時間。
+	LinkCheckInterval time.Duration
+	// LinkCheckBatchSize は 1 回のリンク切れチェックで確認する最大記事数。
+	// LINK_CHECK_BATCH_SIZE から読み込む。既定値は 50。
+	LinkCheckBatchSize int
 
-	// Logging
-	LogRetentionDays int
+	// Subscription
+	// UnsubscribeUndoWindow は購読解除を取り消せる猶予期間。UNSUBSCRIBE_UNDO_WINDOW から読み込む。
+	// 既定値は 2 分。30 秒〜10 分の範囲外の値は既定値にフォールバックする。
+	UnsubscribeUndoWindow time.Duration
 
 	// Server
 	ServerPort string
@@ -57,11 +60,26 @@ type Config struct {
 
 	// CORS
 	CORSAllowedOrigin string
+	// CORSAllowedOrigins は全エンドポイントで CORSAllowedOrigin に加えて許可するオリジン。
+	// "https://*.example.com" のワイルドカードでサブドメインを一括で許可できる。
+	// CORS_ALLOWED_ORIGINS（カンマ区切り）から読み込む。未設定時は空スライス。
+	CORSAllowedOrigins []string
+	// CORSExtensionOrigins はフィード登録（POST /api/feeds）に限り追加で許可するオリジン
+	// （例: chrome-extension://<id>）。CORS_EXTENSION_ORIGINS（カンマ区切り）から読み込む。未設定時は空スライス。
+	CORSExtensionOrigins []string
 
 	// Security
 	// HSTSEnabled は HSTS（Strict-Transport-Security）ヘッダーの出力可否を制御する。
 	// 既定値は false（HSTS 非出力 = 本機能導入前と等価）。
 	HSTSEnabled bool
+	// MaxJSONBodyBytes は API のリクエストボディ上限バイト数。MAX_JSON_BODY_BYTES から読み込む。
+	// 既定値は 1MB（1048576）。上限を超えるリクエストには 413 を返す。
+	MaxJSONBodyBytes int64
+	// APIPageLimitDefault は一覧 API で limit 未指定時に返す件数。API_PAGE_LIMIT_DEFAULT から読み込む。既定値は 50。
+	APIPageLimitDefault int
+	// APIPageLimitMax は一覧 API で指定できる limit の上限。API_PAGE_LIMIT_MAX から読み込む。既定値は 200。
+	// これを超える limit の指定は上限に丸め、重いクエリを防ぐ。
+	APIPageLimitMax int
 
 	// Metrics
 	// TrustedCIDRs は /metrics エンドポイントへのアクセスを許可する信頼ネットワーク範囲（CIDR 表記）。
@@ -71,73 +89,337 @@ type Config struct {
 	// MetricsPort は worker プロセスがメトリクスを公開する listener のポート。
 	// METRICS_PORT から読み込む。既定値は "9090"。
 	MetricsPort string
+
+	// Admin
+	// AdminUserIDs は管理者限定エンドポイント（/api/debug/* など）へのアクセスを許可するユーザーID。
+	// ADMIN_USER_IDS（カンマ区切り）から読み込む。未設定時は空スライスで、管理者限定エンドポイントは全て 403 になる。
+	AdminUserIDs []string
 }
 
-// Load は環境変数からConfigを読み込む。
-// 必須環境変数が未設定の場合はエラーを返す。
-func Load() (*Config, error) {
-	cfg := &Config{}
+// DatabaseConfig はデータベース接続の設定。
+type DatabaseConfig struct {
+	// DatabaseURL は PostgreSQL の接続文字列。DATABASE_URL（database.url）から読み込む。必須。
+	DatabaseURL string
+	// DatabaseSchema は feedman のテーブルを配置する PostgreSQL のスキーマ名。DATABASE_SCHEMA（database.schema）から読み込む。
+	// 共有の PostgreSQL に同居させる場合に指定する。未設定時は接続ユーザーの既定の search_path（通常は public）を使う。
+	DatabaseSchema string
+	// MigrateLockTimeout はオンライン実行のマイグレーション（CREATE INDEX CONCURRENTLY 等）がロックの取得を待つ時間。
+	// MIGRATE_LOCK_TIMEOUT（database.migrate_lock_timeout）から読み込む。既定値は 5 秒。0 で制限しない。
+	MigrateLockTimeout time.Duration
+	// MigrateStatementTimeout はオンライン実行のマイグレーションの 1 文あたりの実行時間の上限。
+	// MIGRATE_STATEMENT_TIMEOUT（database.migrate_statement_timeout）から読み込む。既定値は 0（制限しない）。
+	MigrateStatementTimeout time.Duration
+	// MigrateMaxAttempts はオンライン実行のマイグレーションがロック待ちのタイムアウト等で失敗したときの試行回数。
+	// MIGRATE_MAX_ATTEMPTS（database.migrate_max_attempts）から読み込む。既定値は 3。
+	MigrateMaxAttempts int
+	// MigrateRetryInterval はオンライン実行のマイグレーションをリトライするまでの待ち時間。
+	// MIGRATE_RETRY_INTERVAL（database.migrate_retry_interval）から読み込む。既定値は 10 秒。
+	MigrateRetryInterval time.Duration
+}
 
-	// Required fields
-	var missing []string
+// AuthConfig は Google OAuth とセッションの設定。
+type AuthConfig struct {
+	// OAuth（いずれも必須）
+	GoogleClientID     string
+	GoogleClientSecret string
+	GoogleRedirectURL  string
 
-	cfg.DatabaseURL = os.Getenv("DATABASE_URL")
-	if cfg.DatabaseURL == "" {
-		missing = append(missing, "DATABASE_URL")
-	}
+	// Session
+	SessionSecret string
+	SessionMaxAge int
+	// SessionStore はセッションの保存先（"postgres" または "redis"）。
+	// SESSION_STORE から読み込む。既定値は "postgres"。
+	SessionStore string
+	// RedisURL は SessionStore が "redis" のときの接続先（redis://[:password@]host:port/db 形式）。
+	// REDIS_URL から読み込む。SessionStore が "redis" の場合は必須。
+	RedisURL string
+	// RedisKeyPrefix は Redis に保存するキーの接頭辞。REDIS_KEY_PREFIX から読み込む。既定値は "feedman:"。
+	RedisKeyPrefix string
+}
 
-	cfg.GoogleClientID = os.Getenv("GOOGLE_CLIENT_ID")
-	if cfg.GoogleClientID == "" {
-		missing = append(missing, "GOOGLE_CLIENT_ID")
-	}
+// FetcherConfig はフィードフェッチの設定。
+type FetcherConfig struct {
+	FetchTimeout       time.Duration
+	FetchMaxSize       int64
+	FetchMaxConcurrent int
+	FetchInterval      time.Duration
+	// FetchHostInterval は同一ホストへのフェッチの最小間隔。同一ホストへの同時接続は常に 1 本に制限する。
+	// FETCH_HOST_INTERVAL から読み込む。既定値は 5 秒。0 で間隔を空けない。
+	FetchHostInterval time.Duration
+	// FetchMaxItems は 1 回のフェッチで取り込む記事数の上限。超過時は公開日時の新しい順に採用する。
+	// FETCH_MAX_ITEMS から読み込む。既定値は 500。
+	FetchMaxItems int
+	// FetchFullRateSubscribers はフェッチ間隔を延長しない購読者数。これより購読者の少ないフィードほど間隔を延ばす。
+	// FETCH_FULL_RATE_SUBSCRIBERS から読み込む。既定値は 50。
+	FetchFullRateSubscribers int
+	// FetchMaxIntervalExtension は購読者 1 人のフィードに掛けるフェッチ間隔の倍率（延長後も 12 時間が上限）。
+	// FETCH_MAX_INTERVAL_EXTENSION から読み込む。既定値は 1（延長しない）。
+	FetchMaxIntervalExtension float64
+	// UserDormantAfter は最終アクティブ日時からこの期間が過ぎたユーザーを休眠中とみなす期間。
+	// USER_DORMANT_AFTER から読み込む。既定値は 90 日（2160h）。0 で休眠を判定しない。
+	UserDormantAfter time.Duration
+	// FetchDormantInterval は休眠ユーザーのみが購読するフィードのフェッチ間隔。
+	// FETCH_DORMANT_INTERVAL から読み込む。既定値は 24 時間。0 で延長しない。
+	FetchDormantInterval time.Duration
+	// FetchSSRFAllowlist は SSRF 対策の例外としてプライベート IP へのフェッチを許可する宛先
+	// （ホスト名・"*.example.com"・CIDR・IP アドレス）。FETCH_SSRF_ALLOWLIST（カンマ区切り）から読み込む。
+	// 未設定時は空スライスで、プライベート IP へのフェッチをすべて拒否する。
+	FetchSSRFAllowlist []string
+	// FetchClientCertFile / FetchClientKeyFile は許可リストのホスト名へのフェッチで提示する
+	// TLS クライアント証明書と秘密鍵（PEM）のパス。FETCH_CLIENT_CERT_FILE / FETCH_CLIENT_KEY_FILE から読み込む。
+	// 両方を指定した場合のみ提示する。
+	FetchClientCertFile string
+	FetchClientKeyFile  string
+	// FetchDNSCacheTTL はフェッチ・検出・favicon 取得でホスト名の解決結果を再利用する期間。
+	// FETCH_DNS_CACHE_TTL から読み込む。既定値は 5 分。0 で DNS キャッシュを使わない。
+	FetchDNSCacheTTL time.Duration
+	// FetchDNSNegativeTTL は解決に失敗したホストの問い合わせを控える期間（ネガティブキャッシュ）。
+	// FETCH_DNS_NEGATIVE_TTL から読み込む。既定値は 3 分。0 で失敗を保持しない。
+	FetchDNSNegativeTTL time.Duration
+}
 
-	cfg.GoogleClientSecret = os.Getenv("GOOGLE_CLIENT_SECRET")
-	if cfg.GoogleClientSecret == "" {
-		missing = append(missing, "GOOGLE_CLIENT_SECRET")
-	}
+// RateLimitConfig は API のレート制限の設定。
+type RateLimitConfig struct {
+	RateLimitGeneral int
+	RateLimitFeedReg int
+	// RateLimitUnauthIP は未認証エンドポイント（/auth/google/login・/auth/google/callback・
+	// /health）に適用する IP 単位レート制限の閾値（req/min/IP）。
+	// RATE_LIMIT_UNAUTH_IP から読み込む。既定値は 30。不正値時は既定値にフォールバックする。
+	RateLimitUnauthIP int
+}
 
-	cfg.GoogleRedirectURL = os.Getenv("GOOGLE_REDIRECT_URL")
-	if cfg.GoogleRedirectURL == "" {
-		missing = append(missing, "GOOGLE_REDIRECT_URL")
-	}
+// HatebuConfig ははてなブックマーク数取得の設定。
+type HatebuConfig struct {
+	HatebuTTL              time.Duration
+	HatebuBatchInterval    time.Duration
+	HatebuAPIInterval      time.Duration
+	HatebuMaxCallsPerCycle int
+	// HatebuCountCacheTTL は URL 単位のはてブ数キャッシュの有効期間。
+	// HATEBU_COUNT_CACHE_TTL から読み込む。既定値は 6 時間。0 でキャッシュを無効化する。
+	HatebuCountCacheTTL time.Duration
+	// HatebuPriorityRecencyWeight / HatebuPriorityPopularityWeight は取得対象の選定で
+	// 新しい記事・はてブ数の多い記事を優先する重み。HATEBU_PRIORITY_RECENCY_WEIGHT /
+	// HATEBU_PRIORITY_POPULARITY_WEIGHT から読み込む。既定値は 1.0 / 0.2。
+	HatebuPriorityRecencyWeight    float64
+	HatebuPriorityPopularityWeight float64
+	// HatebuPriorityRecencyHalfLife は新しさの優先度が半減するまでの経過時間。
+	// HATEBU_PRIORITY_RECENCY_HALF_LIFE から読み込む。既定値は 24 時間。
+	HatebuPriorityRecencyHalfLife time.Duration
+	// HatebuOnDemandStaleAfter は記事詳細の表示時にはてブ数をオンデマンドで再取得する鮮度の閾値。
+	// HATEBU_ON_DEMAND_STALE_AFTER から読み込む。既定値は 1 時間。0 でオンデマンド更新を無効化する。
+	HatebuOnDemandStaleAfter time.Duration
+}
 
-	cfg.SessionSecret = os.Getenv("SESSION_SECRET")
-	if cfg.SessionSecret == "" {
-		missing = append(missing, "SESSION_SECRET")
-	}
+// SummarizerConfig は記事の要約生成（POST /api/items/{id}/summarize）の設定。
+type SummarizerConfig struct {
+	// SummarizerAPIURL は要約に用いる OpenAI 互換の Chat Completions API の URL。SUMMARIZER_API_URL から読み込む。
+	// 未設定時は外部 API を使わず、本文の先頭の文を抜き出すローカルの要約器で要約する。
+	SummarizerAPIURL string
+	// SummarizerAPIKey は要約 API の API キー。SUMMARIZER_API_KEY から読み込む。
+	SummarizerAPIKey string
+	// SummarizerModel は要約 API に指定するモデル名。SUMMARIZER_MODEL から読み込む。SUMMARIZER_API_URL 設定時は必須。
+	SummarizerModel string
+	// SummarizerTimeout は要約 API の 1 回の呼び出しのタイムアウト。SUMMARIZER_TIMEOUT から読み込む。既定値は 30 秒。
+	SummarizerTimeout time.Duration
+	// SummarizerRatePerHour はユーザーあたりの要約生成の上限回数（1 時間あたり）。
+	// SUMMARIZER_RATE_PER_HOUR から読み込む。既定値は 20。生成済みの要約の取得は数えない。
+	SummarizerRatePerHour int
+}
+
+// ReadLaterConfig はスター記事の「あとで読む」サービス（Pocket / Instapaper）への自動保存の設定。
+// ReadLaterEncryptionKey が未設定の場合は機能自体を無効にし、連携 API も登録しない。
+type ReadLaterConfig struct {
+	// ReadLaterEncryptionKey は連携先の認証情報を暗号化する鍵（base64 の 32 バイト）。
+	// READ_LATER_ENCRYPTION_KEY から読み込む。変更すると保存済みの連携は使えなくなり、連携し直しが必要になる。
+	ReadLaterEncryptionKey string
+	// PocketConsumerKey は Pocket のアプリケーションの consumer key。POCKET_CONSUMER_KEY から読み込む。
+	// 未設定の場合は Pocket と連携できない。
+	PocketConsumerKey string
+	// InstapaperConsumerKey / InstapaperConsumerSecret は Instapaper Full API の OAuth consumer。
+	// INSTAPAPER_CONSUMER_KEY / INSTAPAPER_CONSUMER_SECRET から読み込む。未設定の場合は Instapaper と連携できない。
+	InstapaperConsumerKey    string
+	InstapaperConsumerSecret string
+}
+
+// HighlightConfig はデイリー / ウィークリーのハイライト（GET /api/highlights）の設定。
+type HighlightConfig struct {
+	// HighlightWeightHatebu / HighlightWeightStar / HighlightWeightView はスコアの重み。
+	// スコアは重み·ln(1+はてブ数) + 重み·ln(1+スター数) + 重み·ln(1+閲覧数) で計算する。
+	// HIGHLIGHT_WEIGHT_HATEBU / HIGHLIGHT_WEIGHT_STAR / HIGHLIGHT_WEIGHT_VIEW から読み込む。既定値は 1.0 / 2.0 / 1.0。
+	HighlightWeightHatebu float64
+	HighlightWeightStar   float64
+	HighlightWeightView   float64
+	// HighlightComputeInterval は worker がハイライトのスコアを計算し直す間隔。
+	// HIGHLIGHT_COMPUTE_INTERVAL から読み込む。既定値は 24 時間。
+	HighlightComputeInterval time.Duration
+
+	// KeywordComputeInterval は worker が購読フィードの頻出キーワードを計算し直す間隔。
+	// KEYWORD_COMPUTE_INTERVAL から読み込む。既定値は 24 時間。
+	KeywordComputeInterval time.Duration
+}
+
+// MailConfig はマジックリンク認証のメールを送る SMTP サーバーの設定。
+// SMTPHost が未設定の場合はマジックリンク認証を無効にし、/auth/magic-link も登録しない。
+type MailConfig struct {
+	// SMTPHost / SMTPPort は SMTP サーバーの接続先。SMTP_HOST / SMTP_PORT から読み込む。SMTPPort の既定値は 587。
+	SMTPHost string
+	SMTPPort int
+	// SMTPUsername / SMTPPassword は SMTP 認証（PLAIN）の資格情報。SMTP_USERNAME / SMTP_PASSWORD から読み込む。
+	// SMTPUsername が未設定の場合は認証せずに送信する。
+	SMTPUsername string
+	SMTPPassword string
+	// MailFrom はメールの送信元アドレス。MAIL_FROM から読み込む。SMTPHost を設定する場合は必須。
+	MailFrom string
+}
+
+// セッションストアの種別。
+const (
+	SessionStorePostgres = "postgres"
+	SessionStoreRedis    = "redis"
+)
+
+// ConfigFileEnv は設定ファイル（YAML）のパスを指定する環境変数名。
+const ConfigFileEnv = "FEEDMAN_CONFIG_FILE"
+
+// Load は設定を読み込む。
+// FEEDMAN_CONFIG_FILE が指定されていればその設定ファイルを読み込んだうえで環境変数を優先して重ねる。
+// 必須項目の未設定や不正な値がある場合はエラーを返す。
+func Load() (*Config, error) {
+	return LoadFile(os.Getenv(ConfigFileEnv))
+}
 
-	cfg.BaseURL = os.Getenv("BASE_URL")
-	if cfg.BaseURL == "" {
-		missing = append(missing, "BASE_URL")
+// LoadFile は path の設定ファイル（YAML）と環境変数から設定を読み込む。
+// path が空の場合は環境変数のみを使う。同じ項目は環境変数の値を優先し、どちらにもない項目は既定値を採用する。
+// 読み込み後に Validate で検証し、問題があればすべてまとめたエラーを返す。
+func LoadFile(path string) (*Config, error) {
+	src := envSource
+	if path != "" {
+		values, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		src = &source{file: values}
 	}
 
-	if len(missing) > 0 {
-		return nil, fmt.Errorf("required environment variables are not set: %v", missing)
+	cfg := src.load()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
+	return cfg, nil
+}
+
+// load は src から各項目を読み込む。未設定の項目は既定値を採用する。
+func (src *source) load() *Config {
+	cfg := &Config{}
+
+	// Required fields（未設定の検出は Validate が行う）
+	cfg.DatabaseURL = src.lookup("DATABASE_URL")
+	cfg.DatabaseSchema = src.lookup("DATABASE_SCHEMA")
+	cfg.MigrateLockTimeout = src.getDuration("MIGRATE_LOCK_TIMEOUT", 5*time.Second)
+	cfg.MigrateStatementTimeout = src.getDuration("MIGRATE_STATEMENT_TIMEOUT", 0)
+	cfg.MigrateMaxAttempts = src.getInt("MIGRATE_MAX_ATTEMPTS", 3)
+	cfg.MigrateRetryInterval = src.getDuration("MIGRATE_RETRY_INTERVAL", 10*time.Second)
+	cfg.GoogleClientID = src.lookup("GOOGLE_CLIENT_ID")
+	cfg.GoogleClientSecret = src.lookup("GOOGLE_CLIENT_SECRET")
+	cfg.GoogleRedirectURL = src.lookup("GOOGLE_REDIRECT_URL")
+	cfg.SessionSecret = src.lookup("SESSION_SECRET")
+	cfg.BaseURL = src.lookup("BASE_URL")
 
 	// Optional fields with defaults
-	cfg.SessionMaxAge = getEnvInt("SESSION_MAX_AGE", 86400)
-	cfg.FetchTimeout = getEnvDuration("FETCH_TIMEOUT", 10*time.Second)
-	cfg.FetchMaxSize = getEnvInt64("FETCH_MAX_SIZE", 5242880)
-	cfg.FetchMaxConcurrent = getEnvInt("FETCH_MAX_CONCURRENT", 10)
-	cfg.FetchInterval = getEnvDuration("FETCH_INTERVAL", 5*time.Minute)
-	cfg.RateLimitGeneral = getEnvInt("RATE_LIMIT_GENERAL", 120)
-	cfg.RateLimitFeedReg = getEnvInt("RATE_LIMIT_FEED_REG", 10)
-	cfg.RateLimitUnauthIP = getEnvInt("RATE_LIMIT_UNAUTH_IP", 30)
-	cfg.HatebuTTL = getEnvDuration("HATEBU_TTL", 24*time.Hour)
-	cfg.HatebuBatchInterval = getEnvDuration("HATEBU_BATCH_INTERVAL", 10*time.Minute)
-	cfg.HatebuAPIInterval = getEnvDuration("HATEBU_API_INTERVAL", 5*time.Second)
-	cfg.HatebuMaxCallsPerCycle = getEnvInt("HATEBU_MAX_CALLS_PER_CYCLE", 100)
-	cfg.LogRetentionDays = getEnvInt("LOG_RETENTION_DAYS", 14)
-	cfg.ServerPort = getEnvString("SERVER_PORT", "8080")
+	cfg.SessionMaxAge = src.getInt("SESSION_MAX_AGE", 86400)
+	cfg.FetchTimeout = src.getDuration("FETCH_TIMEOUT", 10*time.Second)
+	cfg.FetchMaxSize = src.getInt64("FETCH_MAX_SIZE", 5242880)
+	cfg.FetchMaxConcurrent = src.getInt("FETCH_MAX_CONCURRENT", 10)
+	cfg.FetchInterval = src.getDuration("FETCH_INTERVAL", 5*time.Minute)
+	cfg.FetchHostInterval = src.getDuration("FETCH_HOST_INTERVAL", 5*time.Second)
+	cfg.FetchMaxItems = src.getInt("FETCH_MAX_ITEMS", 500)
+	cfg.FetchFullRateSubscribers = src.getInt("FETCH_FULL_RATE_SUBSCRIBERS", 50)
+	cfg.FetchMaxIntervalExtension = src.getFloat64("FETCH_MAX_INTERVAL_EXTENSION", 1.0)
+	cfg.UserDormantAfter = src.getDuration("USER_DORMANT_AFTER", 90*24*time.Hour)
+	cfg.FetchDormantInterval = src.getDuration("FETCH_DORMANT_INTERVAL", 24*time.Hour)
+	cfg.FetchSSRFAllowlist = parseCommaSeparated(src.lookup("FETCH_SSRF_ALLOWLIST"))
+	cfg.FetchClientCertFile = src.lookup("FETCH_CLIENT_CERT_FILE")
+	cfg.FetchClientKeyFile = src.lookup("FETCH_CLIENT_KEY_FILE")
+	cfg.FetchDNSCacheTTL = src.getDuration("FETCH_DNS_CACHE_TTL", 5*time.Minute)
+	cfg.FetchDNSNegativeTTL = src.getDuration("FETCH_DNS_NEGATIVE_TTL", 3*time.Minute)
+	cfg.RateLimitGeneral = src.getInt("RATE_LIMIT_GENERAL", 120)
+	cfg.RateLimitFeedReg = src.getInt("RATE_LIMIT_FEED_REG", 10)
+	cfg.RateLimitUnauthIP = src.getInt("RATE_LIMIT_UNAUTH_IP", 30)
+	cfg.HatebuTTL = src.getDuration("HATEBU_TTL", 24*time.Hour)
+	cfg.HatebuBatchInterval = src.getDuration("HATEBU_BATCH_INTERVAL", 10*time.Minute)
+	cfg.HatebuAPIInterval = src.getDuration("HATEBU_API_INTERVAL", 5*time.Second)
+	cfg.HatebuMaxCallsPerCycle = src.getInt("HATEBU_MAX_CALLS_PER_CYCLE", 100)
+	cfg.HatebuCountCacheTTL = src.getDuration("HATEBU_COUNT_CACHE_TTL", 6*time.Hour)
+	cfg.HatebuPriorityRecencyWeight = src.getFloat64("HATEBU_PRIORITY_RECENCY_WEIGHT", 1.0)
+	cfg.HatebuPriorityPopularityWeight = src.getFloat64("HATEBU_PRIORITY_POPULARITY_WEIGHT", 0.2)
+	cfg.HatebuPriorityRecencyHalfLife = src.getDuration("HATEBU_PRIORITY_RECENCY_HALF_LIFE", 24*time.Hour)
+	cfg.HatebuOnDemandStaleAfter = src.getDuration("HATEBU_ON_DEMAND_STALE_AFTER", time.Hour)
+	cfg.SummarizerAPIURL = src.lookup("SUMMARIZER_API_URL")
+	cfg.SummarizerAPIKey = src.lookup("SUMMARIZER_API_KEY")
+	cfg.SummarizerModel = src.lookup("SUMMARIZER_MODEL")
+	cfg.SummarizerTimeout = src.getDuration("SUMMARIZER_TIMEOUT", 30*time.Second)
+	cfg.SummarizerRatePerHour = src.getInt("SUMMARIZER_RATE_PER_HOUR", 20)
+	cfg.ReadLaterEncryptionKey = src.lookup("READ_LATER_ENCRYPTION_KEY")
+	cfg.PocketConsumerKey = src.lookup("POCKET_CONSUMER_KEY")
+	cfg.InstapaperConsumerKey = src.lookup("INSTAPAPER_CONSUMER_KEY")
+	cfg.InstapaperConsumerSecret = src.lookup("INSTAPAPER_CONSUMER_SECRET")
+	cfg.HighlightWeightHatebu = src.getFloat64("HIGHLIGHT_WEIGHT_HATEBU", 1.0)
+	cfg.HighlightWeightStar = src.getFloat64("HIGHLIGHT_WEIGHT_STAR", 2.0)
+	cfg.HighlightWeightView = src.getFloat64("HIGHLIGHT_WEIGHT_VIEW", 1.0)
+	cfg.HighlightComputeInterval = src.getDuration("HIGHLIGHT_COMPUTE_INTERVAL", 24*time.Hour)
+	cfg.KeywordComputeInterval = src.getDuration("KEYWORD_COMPUTE_INTERVAL", 24*time.Hour)
+	cfg.SMTPHost = src.lookup("SMTP_HOST")
+	cfg.SMTPPort = src.getInt("SMTP_PORT", 587)
+	cfg.SMTPUsername = src.lookup("SMTP_USERNAME")
+	cfg.SMTPPassword = src.lookup("SMTP_PASSWORD")
+	cfg.MailFrom = src.lookup("MAIL_FROM")
+	cfg.LogRetentionDays = src.getInt("LOG_RETENTION_DAYS", 14)
+	cfg.UnsubscribeUndoWindow = src.loadUnsubscribeUndoWindow()
+	cfg.ServerPort = src.getString("SERVER_PORT", "8080")
 	cfg.CookieSecure = strings.HasPrefix(cfg.BaseURL, "https://")
-	cfg.CookieDomain = getEnvString("COOKIE_DOMAIN", "")
-	cfg.CORSAllowedOrigin = getEnvString("CORS_ALLOWED_ORIGIN", "http://localhost:3000")
-	cfg.HSTSEnabled = getEnvBool("HSTS_ENABLED", false)
-	cfg.TrustedCIDRs = parseCommaSeparated(os.Getenv("METRICS_TRUSTED_CIDRS"))
-	cfg.MetricsPort = getEnvString("METRICS_PORT", "9090")
+	cfg.CookieDomain = src.getString("COOKIE_DOMAIN", "")
+	cfg.CORSAllowedOrigin = src.getString("CORS_ALLOWED_ORIGIN", "http://localhost:3000")
+	cfg.CORSAllowedOrigins = parseCommaSeparated(src.lookup("CORS_ALLOWED_ORIGINS"))
+	cfg.CORSExtensionOrigins = parseCommaSeparated(src.lookup("CORS_EXTENSION_ORIGINS"))
+	cfg.HSTSEnabled = src.getBool("HSTS_ENABLED", false)
+	cfg.MaxJSONBodyBytes = src.getInt64("MAX_JSON_BODY_BYTES", 1048576)
+	cfg.APIPageLimitDefault = src.getInt("API_PAGE_LIMIT_DEFAULT", 50)
+	cfg.APIPageLimitMax = src.getInt("API_PAGE_LIMIT_MAX", 200)
+	cfg.TrustedCIDRs = parseCommaSeparated(src.lookup("METRICS_TRUSTED_CIDRS"))
+	cfg.MetricsPort = src.getString("METRICS_PORT", "9090")
+	cfg.AdminUserIDs = parseCommaSeparated(src.lookup("ADMIN_USER_IDS"))
+	cfg.AdminStatsRefreshInterval = src.getDuration("ADMIN_STATS_REFRESH_INTERVAL", 10*time.Minute)
+	cfg.WeeklyStatsSnapshotInterval = src.getDuration("WEEKLY_STATS_SNAPSHOT_INTERVAL", 6*time.Hour)
+	cfg.TrialExpiryInterval = src.getDuration("TRIAL_EXPIRY_INTERVAL", 10*time.Minute)
+	cfg.LinkCheckInterval = src.getDuration("LINK_CHECK_INTERVAL", 24*time.Hour)
+	cfg.LinkCheckBatchSize = src.getInt("LINK_CHECK_BATCH_SIZE", 50)
+	cfg.SessionStore = strings.ToLower(src.getString("SESSION_STORE", SessionStorePostgres))
+	cfg.RedisURL = src.lookup("REDIS_URL")
+	cfg.RedisKeyPrefix = src.getString("REDIS_KEY_PREFIX", "feedman:")
 
-	return cfg, nil
+	return cfg
+}
+
+// 購読解除の取り消し猶予期間の既定値と許容範囲。
+const (
+	defaultUnsubscribeUndoWindow = 2 * time.Minute
+	minUnsubscribeUndoWindow     = 30 * time.Second
+	maxUnsubscribeUndoWindow     = 10 * time.Minute
+)
+
+// loadUnsubscribeUndoWindow は UNSUBSCRIBE_UNDO_WINDOW を読み込む。
+// 許容範囲（30 秒〜10 分）外の値は警告を出して既定値を採用する。
+func (src *source) loadUnsubscribeUndoWindow() time.Duration {
+	d := src.getDuration("UNSUBSCRIBE_UNDO_WINDOW", defaultUnsubscribeUndoWindow)
+	if d < minUnsubscribeUndoWindow || d > maxUnsubscribeUndoWindow {
+		slog.Warn("設定値が許容範囲外のためデフォルト値を採用します",
+			slog.String("key", "UNSUBSCRIBE_UNDO_WINDOW"),
+			slog.Duration("value", d),
+			slog.Duration("default", defaultUnsubscribeUndoWindow),
+		)
+		return defaultUnsubscribeUndoWindow
+	}
+	return d
 }
 
 // parseCommaSeparated はカンマ区切りの文字列を要素スライスに分解する。
@@ -157,24 +439,41 @@ func parseCommaSeparated(v string) []string {
 	return out
 }
 
-func getEnvString(key, defaultVal string) string {
+// source は設定値の読み込み元。環境変数を優先し、未設定の項目は設定ファイルの値を参照する。
+type source struct {
+	// file は設定ファイルの値を対応する環境変数名をキーとして保持する。
+	file map[string]string
+}
+
+// envSource は環境変数のみを読み込み元とする source。
+var envSource = &source{}
+
+// lookup は key の設定値を返す。環境変数が空の場合は設定ファイルの値を返す。
+func (src *source) lookup(key string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
 	}
+	return src.file[key]
+}
+
+func (src *source) getString(key, defaultVal string) string {
+	if v := src.lookup(key); v != "" {
+		return v
+	}
 	return defaultVal
 }
 
-// getEnvBool は環境変数を bool として読み込む。
+// getBool は設定値を bool として読み込む。
 // 未設定（空文字）の場合は defaultVal を返す。
 // 不正値（strconv.ParseBool が受け付けない値）の場合は Warn ログを出力し defaultVal を返して起動を継続する。
-func getEnvBool(key string, defaultVal bool) bool {
-	v := os.Getenv(key)
+func (src *source) getBool(key string, defaultVal bool) bool {
+	v := src.lookup(key)
 	if v == "" {
 		return defaultVal
 	}
 	b, err := strconv.ParseBool(v)
 	if err != nil {
-		slog.Warn("環境変数のパースに失敗したためデフォルト値を採用します",
+		slog.Warn("設定値のパースに失敗したためデフォルト値を採用します",
 			slog.String("key", key),
 			slog.String("value", v),
 			slog.Bool("default", defaultVal),
@@ -184,14 +483,14 @@ func getEnvBool(key string, defaultVal bool) bool {
 	return b
 }
 
-func getEnvInt(key string, defaultVal int) int {
-	v := os.Getenv(key)
+func (src *source) getInt(key string, defaultVal int) int {
+	v := src.lookup(key)
 	if v == "" {
 		return defaultVal
 	}
 	i, err := strconv.Atoi(v)
 	if err != nil {
-		slog.Warn("環境変数のパースに失敗したためデフォルト値を採用します",
+		slog.Warn("設定値のパースに失敗したためデフォルト値を採用します",
 			slog.String("key", key),
 			slog.String("value", v),
 			slog.Int("default", defaultVal),
@@ -201,14 +500,14 @@ func getEnvInt(key string, defaultVal int) int {
 	return i
 }
 
-func getEnvInt64(key string, defaultVal int64) int64 {
-	v := os.Getenv(key)
+func (src *source) getInt64(key string, defaultVal int64) int64 {
+	v := src.lookup(key)
 	if v == "" {
 		return defaultVal
 	}
 	i, err := strconv.ParseInt(v, 10, 64)
 	if err != nil {
-		slog.Warn("環境変数のパースに失敗したためデフォルト値を採用します",
+		slog.Warn("設定値のパースに失敗したためデフォルト値を採用します",
 			slog.String("key", key),
 			slog.String("value", v),
 			slog.Int64("default", defaultVal),
@@ -218,14 +517,31 @@ func getEnvInt64(key string, defaultVal int64) int64 {
 	return i
 }
 
-func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
-	v := os.Getenv(key)
+func (src *source) getFloat64(key string, defaultVal float64) float64 {
+	v := src.lookup(key)
+	if v == "" {
+		return defaultVal
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		slog.Warn("設定値のパースに失敗したためデフォルト値を採用します",
+			slog.String("key", key),
+			slog.String("value", v),
+			slog.Float64("default", defaultVal),
+		)
+		return defaultVal
+	}
+	return f
+}
+
+func (src *source) getDuration(key string, defaultVal time.Duration) time.Duration {
+	v := src.lookup(key)
 	if v == "" {
 		return defaultVal
 	}
 	d, err := time.ParseDuration(v)
 	if err != nil {
-		slog.Warn("環境変数のパースに失敗したためデフォルト値を採用します",
+		slog.Warn("設定値のパースに失敗したためデフォルト値を採用します",
 			slog.String("key", key),
 			slog.String("value", v),
 			slog.Duration("default", defaultVal),
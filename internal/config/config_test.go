@@ -3,6 +3,8 @@ package config
 import (
 	"context"
 	"log/slog"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -124,6 +126,27 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if cfg.FetchMaxSize != 5242880 {
 		t.Errorf("FetchMaxSize = %d, want %d", cfg.FetchMaxSize, 5242880)
 	}
+	if cfg.FetchMaxItems != 500 {
+		t.Errorf("FetchMaxItems = %d, want %d", cfg.FetchMaxItems, 500)
+	}
+	if cfg.FetchFullRateSubscribers != 50 {
+		t.Errorf("FetchFullRateSubscribers = %d, want %d", cfg.FetchFullRateSubscribers, 50)
+	}
+	if cfg.FetchMaxIntervalExtension != 1.0 {
+		t.Errorf("FetchMaxIntervalExtension = %v, want %v", cfg.FetchMaxIntervalExtension, 1.0)
+	}
+	if cfg.UserDormantAfter != 90*24*time.Hour {
+		t.Errorf("UserDormantAfter = %v, want %v", cfg.UserDormantAfter, 90*24*time.Hour)
+	}
+	if cfg.FetchDormantInterval != 24*time.Hour {
+		t.Errorf("FetchDormantInterval = %v, want %v", cfg.FetchDormantInterval, 24*time.Hour)
+	}
+	if cfg.FetchDNSCacheTTL != 5*time.Minute {
+		t.Errorf("FetchDNSCacheTTL = %v, want %v", cfg.FetchDNSCacheTTL, 5*time.Minute)
+	}
+	if cfg.FetchDNSNegativeTTL != 3*time.Minute {
+		t.Errorf("FetchDNSNegativeTTL = %v, want %v", cfg.FetchDNSNegativeTTL, 3*time.Minute)
+	}
 	if cfg.FetchMaxConcurrent != 10 {
 		t.Errorf("FetchMaxConcurrent = %d, want %d", cfg.FetchMaxConcurrent, 10)
 	}
@@ -156,6 +179,44 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if cfg.HatebuMaxCallsPerCycle != 100 {
 		t.Errorf("HatebuMaxCallsPerCycle = %d, want %d", cfg.HatebuMaxCallsPerCycle, 100)
 	}
+	if cfg.HatebuCountCacheTTL != 6*time.Hour {
+		t.Errorf("HatebuCountCacheTTL = %v, want %v", cfg.HatebuCountCacheTTL, 6*time.Hour)
+	}
+	if cfg.HatebuPriorityRecencyWeight != 1.0 {
+		t.Errorf("HatebuPriorityRecencyWeight = %v, want %v", cfg.HatebuPriorityRecencyWeight, 1.0)
+	}
+	if cfg.HatebuPriorityPopularityWeight != 0.2 {
+		t.Errorf("HatebuPriorityPopularityWeight = %v, want %v", cfg.HatebuPriorityPopularityWeight, 0.2)
+	}
+	if cfg.HatebuPriorityRecencyHalfLife != 24*time.Hour {
+		t.Errorf("HatebuPriorityRecencyHalfLife = %v, want %v", cfg.HatebuPriorityRecencyHalfLife, 24*time.Hour)
+	}
+	if cfg.HatebuOnDemandStaleAfter != time.Hour {
+		t.Errorf("HatebuOnDemandStaleAfter = %v, want %v", cfg.HatebuOnDemandStaleAfter, time.Hour)
+	}
+	if cfg.HighlightWeightHatebu != 1.0 || cfg.HighlightWeightStar != 2.0 || cfg.HighlightWeightView != 1.0 {
+		t.Errorf("HighlightWeight = (%v, %v, %v), want (1, 2, 1)", cfg.HighlightWeightHatebu, cfg.HighlightWeightStar, cfg.HighlightWeightView)
+	}
+	if cfg.HighlightComputeInterval != 24*time.Hour {
+		t.Errorf("HighlightComputeInterval = %v, want %v", cfg.HighlightComputeInterval, 24*time.Hour)
+	}
+	if cfg.KeywordComputeInterval != 24*time.Hour {
+		t.Errorf("KeywordComputeInterval = %v, want %v", cfg.KeywordComputeInterval, 24*time.Hour)
+	}
+	if cfg.SMTPHost != "" {
+		t.Errorf("SMTPHost = %q, want empty", cfg.SMTPHost)
+	}
+	if cfg.SMTPPort != 587 {
+		t.Errorf("SMTPPort = %d, want 587", cfg.SMTPPort)
+	}
+
+	// Migration defaults
+	if cfg.MigrateLockTimeout != 5*time.Second || cfg.MigrateStatementTimeout != 0 {
+		t.Errorf("MigrateTimeout = (%v, %v), want (5s, 0s)", cfg.MigrateLockTimeout, cfg.MigrateStatementTimeout)
+	}
+	if cfg.MigrateMaxAttempts != 3 || cfg.MigrateRetryInterval != 10*time.Second {
+		t.Errorf("MigrateRetry = (%d, %v), want (3, 10s)", cfg.MigrateMaxAttempts, cfg.MigrateRetryInterval)
+	}
 
 	// Log retention defaults
 	if cfg.LogRetentionDays != 14 {
@@ -171,6 +232,12 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if cfg.HSTSEnabled != false {
 		t.Errorf("HSTSEnabled = %v, want %v (default)", cfg.HSTSEnabled, false)
 	}
+	if cfg.MaxJSONBodyBytes != 1048576 {
+		t.Errorf("MaxJSONBodyBytes = %d, want %d (default)", cfg.MaxJSONBodyBytes, 1048576)
+	}
+	if cfg.APIPageLimitDefault != 50 || cfg.APIPageLimitMax != 200 {
+		t.Errorf("APIPageLimit = (%d, %d), want (50, 200) (default)", cfg.APIPageLimitDefault, cfg.APIPageLimitMax)
+	}
 
 	// Metrics defaults: 未設定時 MetricsPort は "9090"、TrustedCIDRs は空。
 	if cfg.MetricsPort != "9090" {
@@ -179,6 +246,380 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if len(cfg.TrustedCIDRs) != 0 {
 		t.Errorf("TrustedCIDRs = %v, want empty (default)", cfg.TrustedCIDRs)
 	}
+
+	// Admin defaults: 未設定時 AdminUserIDs は空（管理者限定エンドポイントは全て拒否）。
+	if len(cfg.AdminUserIDs) != 0 {
+		t.Errorf("AdminUserIDs = %v, want empty (default)", cfg.AdminUserIDs)
+	}
+}
+
+// TestLoad_CORSExtensionOrigins は CORS_EXTENSION_ORIGINS のカンマ区切りパースを検証する。
+func TestLoad_CORSExtensionOrigins(t *testing.T) {
+	// Arrange
+	setRequiredEnvVars(t)
+	t.Setenv("CORS_EXTENSION_ORIGINS", "chrome-extension://abc, moz-extension://def")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []string{"chrome-extension://abc", "moz-extension://def"}
+	if len(cfg.CORSExtensionOrigins) != len(want) {
+		t.Fatalf("CORSExtensionOrigins = %v, want %v", cfg.CORSExtensionOrigins, want)
+	}
+	for i, w := range want {
+		if cfg.CORSExtensionOrigins[i] != w {
+			t.Errorf("CORSExtensionOrigins[%d] = %q, want %q", i, cfg.CORSExtensionOrigins[i], w)
+		}
+	}
+}
+
+// TestLoad_CORSAllowedOrigins は CORS_ALLOWED_ORIGINS のカンマ区切りパースを検証する。
+func TestLoad_CORSAllowedOrigins(t *testing.T) {
+	// Arrange
+	setRequiredEnvVars(t)
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://staging.example.com, https://*.preview.example.com,")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := strings.Join(cfg.CORSAllowedOrigins, ","); got != "https://staging.example.com,https://*.preview.example.com" {
+		t.Errorf("CORSAllowedOrigins = %q", got)
+	}
+}
+
+// TestLoad_DatabaseSchema は DATABASE_SCHEMA の読み込みとスキーマ名の検証を検証する。
+func TestLoad_DatabaseSchema(t *testing.T) {
+	t.Run("小文字の識別子のとき読み込む", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("DATABASE_SCHEMA", "feedman")
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.DatabaseSchema != "feedman" {
+			t.Errorf("DatabaseSchema = %q, want %q", cfg.DatabaseSchema, "feedman")
+		}
+	})
+
+	t.Run("未設定のとき空文字になる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.DatabaseSchema != "" {
+			t.Errorf("DatabaseSchema = %q, want empty", cfg.DatabaseSchema)
+		}
+	})
+
+	for _, schema := range []string{"Feedman", "feed-man", "1feedman", "feedman;drop"} {
+		t.Run(schema+"のとき検証エラーになる", func(t *testing.T) {
+			// Arrange
+			setRequiredEnvVars(t)
+			t.Setenv("DATABASE_SCHEMA", schema)
+
+			// Act
+			_, err := Load()
+
+			// Assert
+			if err == nil || !strings.Contains(err.Error(), "DATABASE_SCHEMA") {
+				t.Errorf("Load() error = %v, want DATABASE_SCHEMA validation error", err)
+			}
+		})
+	}
+}
+
+// TestLoad_Summarizer は要約 API の設定の読み込みと検証を検証する。
+func TestLoad_Summarizer(t *testing.T) {
+	t.Run("未設定のときローカルの要約器向けの既定値になる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.SummarizerAPIURL != "" {
+			t.Errorf("SummarizerAPIURL = %q, want empty", cfg.SummarizerAPIURL)
+		}
+		if cfg.SummarizerTimeout != 30*time.Second {
+			t.Errorf("SummarizerTimeout = %v, want %v", cfg.SummarizerTimeout, 30*time.Second)
+		}
+		if cfg.SummarizerRatePerHour != 20 {
+			t.Errorf("SummarizerRatePerHour = %d, want %d", cfg.SummarizerRatePerHour, 20)
+		}
+	})
+
+	t.Run("API の URL とモデルを指定したとき読み込む", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SUMMARIZER_API_URL", "https://llm.example.com/v1/chat/completions")
+		t.Setenv("SUMMARIZER_MODEL", "summary-model")
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.SummarizerModel != "summary-model" {
+			t.Errorf("SummarizerModel = %q, want %q", cfg.SummarizerModel, "summary-model")
+		}
+	})
+
+	t.Run("API の URL を指定しモデルが未設定のとき検証エラーになる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SUMMARIZER_API_URL", "https://llm.example.com/v1/chat/completions")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "SUMMARIZER_MODEL is required") {
+			t.Errorf("Load() error = %v, want SUMMARIZER_MODEL validation error", err)
+		}
+	})
+
+	t.Run("API の URL が http(s) でないとき検証エラーになる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SUMMARIZER_API_URL", "llm.example.com")
+		t.Setenv("SUMMARIZER_MODEL", "summary-model")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "SUMMARIZER_API_URL") {
+			t.Errorf("Load() error = %v, want SUMMARIZER_API_URL validation error", err)
+		}
+	})
+}
+
+// TestLoad_ReadLater は「あとで読む」サービス連携の設定の読み込みと検証を確認する。
+func TestLoad_ReadLater(t *testing.T) {
+	t.Run("暗号鍵と consumer を指定したとき読み込む", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("READ_LATER_ENCRYPTION_KEY", "QkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkJCQkI=")
+		t.Setenv("POCKET_CONSUMER_KEY", "pocket-key")
+		t.Setenv("INSTAPAPER_CONSUMER_KEY", "ip-key")
+		t.Setenv("INSTAPAPER_CONSUMER_SECRET", "ip-secret")
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.PocketConsumerKey != "pocket-key" || cfg.InstapaperConsumerSecret != "ip-secret" {
+			t.Errorf("ReadLaterConfig = %+v", cfg.ReadLaterConfig)
+		}
+	})
+
+	t.Run("暗号鍵が32バイトでないとき検証エラーになる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("READ_LATER_ENCRYPTION_KEY", "c2hvcnQ=")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "READ_LATER_ENCRYPTION_KEY") {
+			t.Errorf("Load() error = %v, want READ_LATER_ENCRYPTION_KEY validation error", err)
+		}
+	})
+
+	t.Run("Instapaper の consumer secret が未設定のとき検証エラーになる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("INSTAPAPER_CONSUMER_KEY", "ip-key")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "INSTAPAPER_CONSUMER_SECRET") {
+			t.Errorf("Load() error = %v, want INSTAPAPER_CONSUMER_SECRET validation error", err)
+		}
+	})
+}
+
+// TestLoad_Mail はマジックリンク認証のメール送信設定の読み込みと検証を確認する。
+func TestLoad_Mail(t *testing.T) {
+	t.Run("SMTP サーバーと送信元を指定したとき読み込む", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+		t.Setenv("SMTP_PORT", "2525")
+		t.Setenv("SMTP_USERNAME", "mailer")
+		t.Setenv("SMTP_PASSWORD", "secret")
+		t.Setenv("MAIL_FROM", "Feedman <noreply@example.com>")
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		want := MailConfig{
+			SMTPHost:     "smtp.example.com",
+			SMTPPort:     2525,
+			SMTPUsername: "mailer",
+			SMTPPassword: "secret",
+			MailFrom:     "Feedman <noreply@example.com>",
+		}
+		if cfg.MailConfig != want {
+			t.Errorf("MailConfig = %+v, want %+v", cfg.MailConfig, want)
+		}
+	})
+
+	t.Run("SMTP_HOST を指定して MAIL_FROM が未設定のとき検証エラーになる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "MAIL_FROM") {
+			t.Errorf("Load() error = %v, want MAIL_FROM validation error", err)
+		}
+	})
+
+	t.Run("MAIL_FROM がメールアドレスでないとき検証エラーになる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+		t.Setenv("MAIL_FROM", "noreply")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "MAIL_FROM") {
+			t.Errorf("Load() error = %v, want MAIL_FROM validation error", err)
+		}
+	})
+}
+
+// TestLoad_AdminUserIDs は ADMIN_USER_IDS のカンマ区切りパースを検証する。
+func TestLoad_AdminUserIDs(t *testing.T) {
+	// Arrange
+	setRequiredEnvVars(t)
+	t.Setenv("ADMIN_USER_IDS", " admin-1 ,,admin-2")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []string{"admin-1", "admin-2"}
+	if len(cfg.AdminUserIDs) != len(want) {
+		t.Fatalf("AdminUserIDs = %v, want %v", cfg.AdminUserIDs, want)
+	}
+	for i, w := range want {
+		if cfg.AdminUserIDs[i] != w {
+			t.Errorf("AdminUserIDs[%d] = %q, want %q", i, cfg.AdminUserIDs[i], w)
+		}
+	}
+}
+
+// TestLoad_SessionStore は SESSION_STORE / REDIS_URL の読み込みと検証を検証する。
+func TestLoad_SessionStore(t *testing.T) {
+	t.Run("未設定のときpostgresになる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.SessionStore != SessionStorePostgres {
+			t.Errorf("SessionStore = %q, want %q", cfg.SessionStore, SessionStorePostgres)
+		}
+		if cfg.RedisKeyPrefix != "feedman:" {
+			t.Errorf("RedisKeyPrefix = %q, want %q", cfg.RedisKeyPrefix, "feedman:")
+		}
+	})
+
+	t.Run("redisとREDIS_URLを指定したとき読み込まれる", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SESSION_STORE", "Redis")
+		t.Setenv("REDIS_URL", "redis://localhost:6379/0")
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if cfg.SessionStore != SessionStoreRedis || cfg.RedisURL != "redis://localhost:6379/0" {
+			t.Errorf("SessionStore/RedisURL = %q/%q", cfg.SessionStore, cfg.RedisURL)
+		}
+	})
+
+	t.Run("redisでREDIS_URLが未設定のときエラーを返す", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SESSION_STORE", "redis")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("未知の値のときエラーを返す", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		t.Setenv("SESSION_STORE", "memcached")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
 }
 
 // TestLoad_MetricsTrustedCIDRs は METRICS_TRUSTED_CIDRS のカンマ区切りパースを検証する。
@@ -375,6 +816,13 @@ func TestLoad_CustomValues(t *testing.T) {
 	t.Setenv("SESSION_MAX_AGE", "3600")
 	t.Setenv("FETCH_TIMEOUT", "30s")
 	t.Setenv("FETCH_MAX_SIZE", "10485760")
+	t.Setenv("FETCH_MAX_ITEMS", "1000")
+	t.Setenv("FETCH_FULL_RATE_SUBSCRIBERS", "10")
+	t.Setenv("FETCH_MAX_INTERVAL_EXTENSION", "1.5")
+	t.Setenv("USER_DORMANT_AFTER", "720h")
+	t.Setenv("FETCH_DORMANT_INTERVAL", "48h")
+	t.Setenv("FETCH_DNS_CACHE_TTL", "10m")
+	t.Setenv("FETCH_DNS_NEGATIVE_TTL", "0")
 	t.Setenv("FETCH_MAX_CONCURRENT", "5")
 	t.Setenv("FETCH_INTERVAL", "10m")
 	t.Setenv("RATE_LIMIT_GENERAL", "60")
@@ -384,7 +832,24 @@ func TestLoad_CustomValues(t *testing.T) {
 	t.Setenv("HATEBU_BATCH_INTERVAL", "20m")
 	t.Setenv("HATEBU_API_INTERVAL", "10s")
 	t.Setenv("HATEBU_MAX_CALLS_PER_CYCLE", "50")
+	t.Setenv("HATEBU_COUNT_CACHE_TTL", "3h")
+	t.Setenv("HATEBU_PRIORITY_RECENCY_WEIGHT", "0.5")
+	t.Setenv("HATEBU_PRIORITY_POPULARITY_WEIGHT", "1.5")
+	t.Setenv("HATEBU_PRIORITY_RECENCY_HALF_LIFE", "12h")
+	t.Setenv("HATEBU_ON_DEMAND_STALE_AFTER", "30m")
+	t.Setenv("HIGHLIGHT_WEIGHT_HATEBU", "0.5")
+	t.Setenv("HIGHLIGHT_WEIGHT_STAR", "3")
+	t.Setenv("HIGHLIGHT_WEIGHT_VIEW", "0")
+	t.Setenv("HIGHLIGHT_COMPUTE_INTERVAL", "6h")
+	t.Setenv("KEYWORD_COMPUTE_INTERVAL", "12h")
+	t.Setenv("MIGRATE_LOCK_TIMEOUT", "2s")
+	t.Setenv("MIGRATE_STATEMENT_TIMEOUT", "1h")
+	t.Setenv("MIGRATE_MAX_ATTEMPTS", "5")
+	t.Setenv("MIGRATE_RETRY_INTERVAL", "30s")
 	t.Setenv("SERVER_PORT", "3000")
+	t.Setenv("API_PAGE_LIMIT_DEFAULT", "20")
+	t.Setenv("API_PAGE_LIMIT_MAX", "150")
+	t.Setenv("FETCH_SSRF_ALLOWLIST", "rss.intranet.example.com, 10.1.2.0/24")
 
 	cfg, err := Load()
 	if err != nil {
@@ -400,6 +865,27 @@ func TestLoad_CustomValues(t *testing.T) {
 	if cfg.FetchMaxSize != 10485760 {
 		t.Errorf("FetchMaxSize = %d, want %d", cfg.FetchMaxSize, 10485760)
 	}
+	if cfg.FetchMaxItems != 1000 {
+		t.Errorf("FetchMaxItems = %d, want %d", cfg.FetchMaxItems, 1000)
+	}
+	if cfg.FetchFullRateSubscribers != 10 {
+		t.Errorf("FetchFullRateSubscribers = %d, want %d", cfg.FetchFullRateSubscribers, 10)
+	}
+	if cfg.FetchMaxIntervalExtension != 1.5 {
+		t.Errorf("FetchMaxIntervalExtension = %v, want %v", cfg.FetchMaxIntervalExtension, 1.5)
+	}
+	if cfg.UserDormantAfter != 720*time.Hour {
+		t.Errorf("UserDormantAfter = %v, want %v", cfg.UserDormantAfter, 720*time.Hour)
+	}
+	if cfg.FetchDormantInterval != 48*time.Hour {
+		t.Errorf("FetchDormantInterval = %v, want %v", cfg.FetchDormantInterval, 48*time.Hour)
+	}
+	if cfg.FetchDNSCacheTTL != 10*time.Minute {
+		t.Errorf("FetchDNSCacheTTL = %v, want %v", cfg.FetchDNSCacheTTL, 10*time.Minute)
+	}
+	if cfg.FetchDNSNegativeTTL != 0 {
+		t.Errorf("FetchDNSNegativeTTL = %v, want 0", cfg.FetchDNSNegativeTTL)
+	}
 	if cfg.FetchMaxConcurrent != 5 {
 		t.Errorf("FetchMaxConcurrent = %d, want %d", cfg.FetchMaxConcurrent, 5)
 	}
@@ -428,9 +914,42 @@ func TestLoad_CustomValues(t *testing.T) {
 	if cfg.HatebuMaxCallsPerCycle != 50 {
 		t.Errorf("HatebuMaxCallsPerCycle = %d, want %d", cfg.HatebuMaxCallsPerCycle, 50)
 	}
+	if cfg.HatebuCountCacheTTL != 3*time.Hour {
+		t.Errorf("HatebuCountCacheTTL = %v, want %v", cfg.HatebuCountCacheTTL, 3*time.Hour)
+	}
+	if cfg.HatebuPriorityRecencyWeight != 0.5 || cfg.HatebuPriorityPopularityWeight != 1.5 {
+		t.Errorf("Hatebu priority weights = (%v, %v), want (0.5, 1.5)", cfg.HatebuPriorityRecencyWeight, cfg.HatebuPriorityPopularityWeight)
+	}
+	if cfg.HatebuPriorityRecencyHalfLife != 12*time.Hour {
+		t.Errorf("HatebuPriorityRecencyHalfLife = %v, want %v", cfg.HatebuPriorityRecencyHalfLife, 12*time.Hour)
+	}
+	if cfg.HatebuOnDemandStaleAfter != 30*time.Minute {
+		t.Errorf("HatebuOnDemandStaleAfter = %v, want %v", cfg.HatebuOnDemandStaleAfter, 30*time.Minute)
+	}
+	if cfg.HighlightWeightHatebu != 0.5 || cfg.HighlightWeightStar != 3 || cfg.HighlightWeightView != 0 {
+		t.Errorf("HighlightWeight = (%v, %v, %v), want (0.5, 3, 0)", cfg.HighlightWeightHatebu, cfg.HighlightWeightStar, cfg.HighlightWeightView)
+	}
+	if cfg.HighlightComputeInterval != 6*time.Hour {
+		t.Errorf("HighlightComputeInterval = %v, want %v", cfg.HighlightComputeInterval, 6*time.Hour)
+	}
+	if cfg.KeywordComputeInterval != 12*time.Hour {
+		t.Errorf("KeywordComputeInterval = %v, want %v", cfg.KeywordComputeInterval, 12*time.Hour)
+	}
+	if cfg.MigrateLockTimeout != 2*time.Second || cfg.MigrateStatementTimeout != time.Hour {
+		t.Errorf("MigrateTimeout = (%v, %v), want (2s, 1h)", cfg.MigrateLockTimeout, cfg.MigrateStatementTimeout)
+	}
+	if cfg.MigrateMaxAttempts != 5 || cfg.MigrateRetryInterval != 30*time.Second {
+		t.Errorf("MigrateRetry = (%d, %v), want (5, 30s)", cfg.MigrateMaxAttempts, cfg.MigrateRetryInterval)
+	}
 	if cfg.ServerPort != "3000" {
 		t.Errorf("ServerPort = %q, want %q", cfg.ServerPort, "3000")
 	}
+	if cfg.APIPageLimitDefault != 20 || cfg.APIPageLimitMax != 150 {
+		t.Errorf("APIPageLimit = (%d, %d), want (20, 150)", cfg.APIPageLimitDefault, cfg.APIPageLimitMax)
+	}
+	if want := []string{"rss.intranet.example.com", "10.1.2.0/24"}; !reflect.DeepEqual(cfg.FetchSSRFAllowlist, want) {
+		t.Errorf("FetchSSRFAllowlist = %v, want %v", cfg.FetchSSRFAllowlist, want)
+	}
 }
 
 func TestLoad_MissingDatabaseURL_ReturnsError(t *testing.T) {
@@ -508,7 +1027,39 @@ func TestLoad_InvalidRateLimitUnauthIP_FallsBackToDefault(t *testing.T) {
 	}
 }
 
-// TestGetEnvInt は getEnvInt のパース失敗時警告ログ・フォールバック・正常系を検証する。
+// TestLoad_UnsubscribeUndoWindow は購読解除の取り消し猶予期間の読み込みと範囲外のフォールバックを検証する。
+func TestLoad_UnsubscribeUndoWindow(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"未設定のとき既定値2分", "", 2 * time.Minute},
+		{"範囲内のとき指定値", "45s", 45 * time.Second},
+		{"下限未満のとき既定値", "10s", 2 * time.Minute},
+		{"上限超過のとき既定値", "1h", 2 * time.Minute},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			setRequiredEnvVars(t)
+			t.Setenv("UNSUBSCRIBE_UNDO_WINDOW", tc.value)
+
+			// Act
+			cfg, err := Load()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.UnsubscribeUndoWindow != tc.want {
+				t.Errorf("UnsubscribeUndoWindow = %v, want %v", cfg.UnsubscribeUndoWindow, tc.want)
+			}
+		})
+	}
+}
+
+// TestGetEnvInt は source.getInt のパース失敗時警告ログ・フォールバック・正常系を検証する。
 // Requirement 1 (1.1/1.2/1.3) と Requirement 4 (4.1/4.2/4.3/4.4) に対応。
 func TestGetEnvInt(t *testing.T) {
 	const key = "TEST_GET_ENV_INT"
@@ -520,7 +1071,7 @@ func TestGetEnvInt(t *testing.T) {
 		t.Setenv(key, "not-an-int")
 
 		// Act
-		got := getEnvInt(key, defaultVal)
+		got := envSource.getInt(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
@@ -537,7 +1088,7 @@ func TestGetEnvInt(t *testing.T) {
 		t.Setenv(key, "not-an-int")
 
 		// Act
-		getEnvInt(key, defaultVal)
+		envSource.getInt(key, defaultVal)
 
 		// Assert
 		recs := h.warnRecords()
@@ -556,7 +1107,7 @@ func TestGetEnvInt(t *testing.T) {
 		t.Setenv(key, "100")
 
 		// Act
-		got := getEnvInt(key, defaultVal)
+		got := envSource.getInt(key, defaultVal)
 
 		// Assert
 		if got != 100 {
@@ -573,7 +1124,7 @@ func TestGetEnvInt(t *testing.T) {
 		t.Setenv(key, "")
 
 		// Act
-		got := getEnvInt(key, defaultVal)
+		got := envSource.getInt(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
@@ -585,7 +1136,7 @@ func TestGetEnvInt(t *testing.T) {
 	})
 }
 
-// TestGetEnvInt64 は getEnvInt64 のパース失敗時警告ログ・フォールバック・正常系を検証する。
+// TestGetEnvInt64 は source.getInt64 のパース失敗時警告ログ・フォールバック・正常系を検証する。
 // Requirement 2 (2.1/2.2/2.3) と Requirement 4 に対応。
 func TestGetEnvInt64(t *testing.T) {
 	const key = "TEST_GET_ENV_INT64"
@@ -597,7 +1148,7 @@ func TestGetEnvInt64(t *testing.T) {
 		t.Setenv(key, "12.5")
 
 		// Act
-		got := getEnvInt64(key, defaultVal)
+		got := envSource.getInt64(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
@@ -614,7 +1165,7 @@ func TestGetEnvInt64(t *testing.T) {
 		t.Setenv(key, "12.5")
 
 		// Act
-		getEnvInt64(key, defaultVal)
+		envSource.getInt64(key, defaultVal)
 
 		// Assert
 		recs := h.warnRecords()
@@ -633,7 +1184,7 @@ func TestGetEnvInt64(t *testing.T) {
 		t.Setenv(key, "10485760")
 
 		// Act
-		got := getEnvInt64(key, defaultVal)
+		got := envSource.getInt64(key, defaultVal)
 
 		// Assert
 		if got != 10485760 {
@@ -650,7 +1201,7 @@ func TestGetEnvInt64(t *testing.T) {
 		t.Setenv(key, "")
 
 		// Act
-		got := getEnvInt64(key, defaultVal)
+		got := envSource.getInt64(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
@@ -662,7 +1213,7 @@ func TestGetEnvInt64(t *testing.T) {
 	})
 }
 
-// TestGetEnvDuration は getEnvDuration のパース失敗時警告ログ・フォールバック・正常系を検証する。
+// TestGetEnvDuration は source.getDuration のパース失敗時警告ログ・フォールバック・正常系を検証する。
 // Requirement 3 (3.1/3.2/3.3) と Requirement 4 に対応。
 func TestGetEnvDuration(t *testing.T) {
 	const key = "TEST_GET_ENV_DURATION"
@@ -674,7 +1225,7 @@ func TestGetEnvDuration(t *testing.T) {
 		t.Setenv(key, "10sec")
 
 		// Act
-		got := getEnvDuration(key, defaultVal)
+		got := envSource.getDuration(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
@@ -691,7 +1242,7 @@ func TestGetEnvDuration(t *testing.T) {
 		t.Setenv(key, "10sec")
 
 		// Act
-		getEnvDuration(key, defaultVal)
+		envSource.getDuration(key, defaultVal)
 
 		// Assert
 		recs := h.warnRecords()
@@ -710,7 +1261,7 @@ func TestGetEnvDuration(t *testing.T) {
 		t.Setenv(key, "30s")
 
 		// Act
-		got := getEnvDuration(key, defaultVal)
+		got := envSource.getDuration(key, defaultVal)
 
 		// Assert
 		if got != 30*time.Second {
@@ -727,7 +1278,7 @@ func TestGetEnvDuration(t *testing.T) {
 		t.Setenv(key, "")
 
 		// Act
-		got := getEnvDuration(key, defaultVal)
+		got := envSource.getDuration(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
@@ -739,7 +1290,7 @@ func TestGetEnvDuration(t *testing.T) {
 	})
 }
 
-// TestGetEnvBool は getEnvBool のパース失敗時警告ログ・フォールバック・正常系を検証する。
+// TestGetEnvBool は source.getBool のパース失敗時警告ログ・フォールバック・正常系を検証する。
 // Requirement 3.3（未指定・不正値時は既定値採用で起動継続）に対応。
 func TestGetEnvBool(t *testing.T) {
 	const key = "TEST_GET_ENV_BOOL"
@@ -751,7 +1302,7 @@ func TestGetEnvBool(t *testing.T) {
 		t.Setenv(key, "yesnt")
 
 		// Act
-		got := getEnvBool(key, defaultVal)
+		got := envSource.getBool(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
@@ -768,7 +1319,7 @@ func TestGetEnvBool(t *testing.T) {
 		t.Setenv(key, "yesnt")
 
 		// Act
-		getEnvBool(key, defaultVal)
+		envSource.getBool(key, defaultVal)
 
 		// Assert
 		recs := h.warnRecords()
@@ -787,7 +1338,7 @@ func TestGetEnvBool(t *testing.T) {
 		t.Setenv(key, "true")
 
 		// Act
-		got := getEnvBool(key, defaultVal)
+		got := envSource.getBool(key, defaultVal)
 
 		// Assert
 		if got != true {
@@ -804,7 +1355,7 @@ func TestGetEnvBool(t *testing.T) {
 		t.Setenv(key, "")
 
 		// Act
-		got := getEnvBool(key, defaultVal)
+		got := envSource.getBool(key, defaultVal)
 
 		// Assert
 		if got != defaultVal {
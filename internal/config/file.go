@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileKeys は設定ファイル（YAML）の階層キーと、同じ項目を表す環境変数名の対応。
+// 設定ファイルの値は環境変数が未設定の項目にだけ使われる。
+var fileKeys = map[string]string{
+	"database.url":                       "DATABASE_URL",
+	"database.schema":                    "DATABASE_SCHEMA",
+	"database.migrate_lock_timeout":      "MIGRATE_LOCK_TIMEOUT",
+	"database.migrate_statement_timeout": "MIGRATE_STATEMENT_TIMEOUT",
+	"database.migrate_max_attempts":      "MIGRATE_MAX_ATTEMPTS",
+	"database.migrate_retry_interval":    "MIGRATE_RETRY_INTERVAL",
+
+	"auth.google_client_id":     "GOOGLE_CLIENT_ID",
+	"auth.google_client_secret": "GOOGLE_CLIENT_SECRET",
+	"auth.google_redirect_url":  "GOOGLE_REDIRECT_URL",
+	"auth.session_secret":       "SESSION_SECRET",
+	"auth.session_max_age":      "SESSION_MAX_AGE",
+	"auth.session_store":        "SESSION_STORE",
+	"auth.redis_url":            "REDIS_URL",
+	"auth.redis_key_prefix":     "REDIS_KEY_PREFIX",
+
+	"fetch.timeout":                "FETCH_TIMEOUT",
+	"fetch.max_size":               "FETCH_MAX_SIZE",
+	"fetch.max_concurrent":         "FETCH_MAX_CONCURRENT",
+	"fetch.interval":               "FETCH_INTERVAL",
+	"fetch.host_interval":          "FETCH_HOST_INTERVAL",
+	"fetch.max_items":              "FETCH_MAX_ITEMS",
+	"fetch.full_rate_subscribers":  "FETCH_FULL_RATE_SUBSCRIBERS",
+	"fetch.max_interval_extension": "FETCH_MAX_INTERVAL_EXTENSION",
+	"fetch.ssrf_allowlist":         "FETCH_SSRF_ALLOWLIST",
+	"fetch.client_cert_file":       "FETCH_CLIENT_CERT_FILE",
+	"fetch.client_key_file":        "FETCH_CLIENT_KEY_FILE",
+	"fetch.dns_cache_ttl":          "FETCH_DNS_CACHE_TTL",
+	"fetch.dns_negative_ttl":       "FETCH_DNS_NEGATIVE_TTL",
+
+	"rate_limit.general":           "RATE_LIMIT_GENERAL",
+	"rate_limit.feed_registration": "RATE_LIMIT_FEED_REG",
+	"rate_limit.unauth_ip":         "RATE_LIMIT_UNAUTH_IP",
+
+	"hatebu.ttl":                        "HATEBU_TTL",
+	"hatebu.batch_interval":             "HATEBU_BATCH_INTERVAL",
+	"hatebu.api_interval":               "HATEBU_API_INTERVAL",
+	"hatebu.max_calls_per_cycle":        "HATEBU_MAX_CALLS_PER_CYCLE",
+	"hatebu.count_cache_ttl":            "HATEBU_COUNT_CACHE_TTL",
+	"hatebu.priority_recency_weight":    "HATEBU_PRIORITY_RECENCY_WEIGHT",
+	"hatebu.priority_popularity_weight": "HATEBU_PRIORITY_POPULARITY_WEIGHT",
+	"hatebu.priority_recency_half_life": "HATEBU_PRIORITY_RECENCY_HALF_LIFE",
+	"hatebu.on_demand_stale_after":      "HATEBU_ON_DEMAND_STALE_AFTER",
+
+	"summarizer.api_url":       "SUMMARIZER_API_URL",
+	"summarizer.api_key":       "SUMMARIZER_API_KEY",
+	"summarizer.model":         "SUMMARIZER_MODEL",
+	"summarizer.timeout":       "SUMMARIZER_TIMEOUT",
+	"summarizer.rate_per_hour": "SUMMARIZER_RATE_PER_HOUR",
+
+	"read_later.encryption_key":             "READ_LATER_ENCRYPTION_KEY",
+	"read_later.pocket_consumer_key":        "POCKET_CONSUMER_KEY",
+	"read_later.instapaper_consumer_key":    "INSTAPAPER_CONSUMER_KEY",
+	"read_later.instapaper_consumer_secret": "INSTAPAPER_CONSUMER_SECRET",
+
+	"mail.smtp_host":     "SMTP_HOST",
+	"mail.smtp_port":     "SMTP_PORT",
+	"mail.smtp_username": "SMTP_USERNAME",
+	"mail.smtp_password": "SMTP_PASSWORD",
+	"mail.from":          "MAIL_FROM",
+
+	"highlight.weight_hatebu":    "HIGHLIGHT_WEIGHT_HATEBU",
+	"highlight.weight_star":      "HIGHLIGHT_WEIGHT_STAR",
+	"highlight.weight_view":      "HIGHLIGHT_WEIGHT_VIEW",
+	"highlight.compute_interval": "HIGHLIGHT_COMPUTE_INTERVAL",
+	"keyword.compute_interval":   "KEYWORD_COMPUTE_INTERVAL",
+
+	"server.port":                   "SERVER_PORT",
+	"server.base_url":               "BASE_URL",
+	"server.cookie_domain":          "COOKIE_DOMAIN",
+	"server.cors_allowed_origin":    "CORS_ALLOWED_ORIGIN",
+	"server.cors_allowed_origins":   "CORS_ALLOWED_ORIGINS",
+	"server.cors_extension_origins": "CORS_EXTENSION_ORIGINS",
+	"server.hsts_enabled":           "HSTS_ENABLED",
+	"server.max_json_body_bytes":    "MAX_JSON_BODY_BYTES",
+	"server.page_limit_default":     "API_PAGE_LIMIT_DEFAULT",
+	"server.page_limit_max":         "API_PAGE_LIMIT_MAX",
+
+	"metrics.port":          "METRICS_PORT",
+	"metrics.trusted_cidrs": "METRICS_TRUSTED_CIDRS",
+
+	"admin.user_ids": "ADMIN_USER_IDS",
+
+	"log.retention_days": "LOG_RETENTION_DAYS",
+
+	"subscription.unsubscribe_undo_window": "UNSUBSCRIBE_UNDO_WINDOW",
+
+	"worker.admin_stats_refresh_interval":   "ADMIN_STATS_REFRESH_INTERVAL",
+	"worker.weekly_stats_snapshot_interval": "WEEKLY_STATS_SNAPSHOT_INTERVAL",
+	"worker.trial_expiry_interval":          "TRIAL_EXPIRY_INTERVAL",
+	"worker.link_check_interval":            "LINK_CHECK_INTERVAL",
+	"worker.link_check_batch_size":          "LINK_CHECK_BATCH_SIZE",
+}
+
+// fileKeyFor は環境変数名 env に対応する設定ファイルのキーを返す。対応がない場合は空文字列を返す。
+func fileKeyFor(env string) string {
+	for k, v := range fileKeys {
+		if v == env {
+			return k
+		}
+	}
+	return ""
+}
+
+// readConfigFile は path の設定ファイル（YAML）を読み込み、値を対応する環境変数名をキーとして返す。
+// 未知のキーは綴り間違いに気づけるようエラーとする。リストはカンマ区切りの文字列に変換する。
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return parseConfigFile(path, data)
+}
+
+// parseConfigFile は YAML の内容 data を環境変数名をキーとした値に展開する。
+func parseConfigFile(path string, data []byte) (map[string]string, error) {
+	var root map[string]interface{}
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	flat := make(map[string]string)
+	if err := flattenConfig("", root, flat); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(flat))
+	var unknown []string
+	for key, v := range flat {
+		env, ok := fileKeys[key]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		values[env] = v
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("invalid config file %s: unknown keys: %s", path, strings.Join(unknown, ", "))
+	}
+	return values, nil
+}
+
+// flattenConfig は入れ子のマップを "section.key" 形式のキーに平坦化して out に格納する。
+func flattenConfig(prefix string, node map[string]interface{}, out map[string]string) error {
+	for k, v := range node {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case nil:
+			// 値のないキーは未設定として扱う
+		case map[string]interface{}:
+			if err := flattenConfig(key, val, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, 0, len(val))
+			for _, item := range val {
+				if _, ok := item.(map[string]interface{}); ok {
+					return fmt.Errorf("%s: list items must be scalar values", key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(items, ",")
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile はテスト用の設定ファイルを一時ディレクトリに書き出してパスを返す。
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "feedman.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("設定ファイルの書き出しに失敗: %v", err)
+	}
+	return path
+}
+
+// clearRequiredEnvVars は必須項目の環境変数を空にし、設定ファイルの値が使われる状態にする。
+func clearRequiredEnvVars(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"DATABASE_URL", "GOOGLE_CLIENT_ID", "GOOGLE_CLIENT_SECRET", "GOOGLE_REDIRECT_URL", "SESSION_SECRET", "BASE_URL"} {
+		t.Setenv(key, "")
+	}
+}
+
+const fullConfigFile = `
+database:
+  url: postgres://file@localhost:5432/feedman
+auth:
+  google_client_id: file-client-id
+  google_client_secret: file-client-secret
+  google_redirect_url: https://feedman.example.com/auth/google/callback
+  session_secret: file-session-secret
+fetch:
+  timeout: 20s
+  max_concurrent: 4
+  max_items: 200
+rate_limit:
+  general: 60
+hatebu:
+  ttl: 12h
+server:
+  base_url: https://feedman.example.com
+  cors_extension_origins:
+    - chrome-extension://abc
+    - moz-extension://def
+admin:
+  user_ids: [u1, u2]
+`
+
+func TestLoadFile(t *testing.T) {
+	t.Run("設定ファイルの値を読み込み未指定の項目は既定値になる", func(t *testing.T) {
+		// Arrange
+		clearRequiredEnvVars(t)
+		path := writeConfigFile(t, fullConfigFile)
+
+		// Act
+		cfg, err := LoadFile(path)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("LoadFile() error = %v", err)
+		}
+		if cfg.DatabaseURL != "postgres://file@localhost:5432/feedman" {
+			t.Errorf("DatabaseURL = %q", cfg.DatabaseURL)
+		}
+		if cfg.FetchTimeout != 20*time.Second || cfg.FetchMaxConcurrent != 4 || cfg.FetchMaxItems != 200 {
+			t.Errorf("FetcherConfig = %+v", cfg.FetcherConfig)
+		}
+		if cfg.FetchInterval != 5*time.Minute {
+			t.Errorf("FetchInterval = %v, want default %v", cfg.FetchInterval, 5*time.Minute)
+		}
+		if cfg.RateLimitGeneral != 60 || cfg.HatebuTTL != 12*time.Hour {
+			t.Errorf("RateLimitGeneral/HatebuTTL = %d/%v", cfg.RateLimitGeneral, cfg.HatebuTTL)
+		}
+		if !cfg.CookieSecure {
+			t.Error("https の BASE_URL を設定ファイルで指定したとき CookieSecure は true になるべき")
+		}
+		if got := strings.Join(cfg.CORSExtensionOrigins, ","); got != "chrome-extension://abc,moz-extension://def" {
+			t.Errorf("CORSExtensionOrigins = %q", got)
+		}
+		if got := strings.Join(cfg.AdminUserIDs, ","); got != "u1,u2" {
+			t.Errorf("AdminUserIDs = %q", got)
+		}
+	})
+
+	t.Run("環境変数が設定されているとき設定ファイルより優先される", func(t *testing.T) {
+		// Arrange
+		clearRequiredEnvVars(t)
+		t.Setenv("DATABASE_URL", "postgres://env@localhost:5432/feedman")
+		t.Setenv("FETCH_MAX_ITEMS", "50")
+		path := writeConfigFile(t, fullConfigFile)
+
+		// Act
+		cfg, err := LoadFile(path)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("LoadFile() error = %v", err)
+		}
+		if cfg.DatabaseURL != "postgres://env@localhost:5432/feedman" {
+			t.Errorf("DatabaseURL = %q, want env value", cfg.DatabaseURL)
+		}
+		if cfg.FetchMaxItems != 50 {
+			t.Errorf("FetchMaxItems = %d, want 50", cfg.FetchMaxItems)
+		}
+	})
+
+	t.Run("FEEDMAN_CONFIG_FILEを指定したときLoadが設定ファイルを読み込む", func(t *testing.T) {
+		// Arrange
+		clearRequiredEnvVars(t)
+		t.Setenv(ConfigFileEnv, writeConfigFile(t, fullConfigFile))
+
+		// Act
+		cfg, err := Load()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.GoogleClientID != "file-client-id" {
+			t.Errorf("GoogleClientID = %q", cfg.GoogleClientID)
+		}
+	})
+
+	t.Run("未知のキーがあるときエラーを返す", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		path := writeConfigFile(t, "fetch:\n  timout: 10s\nunknown_section:\n  key: v\n")
+
+		// Act
+		_, err := LoadFile(path)
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "fetch.timout") || !strings.Contains(err.Error(), "unknown_section.key") {
+			t.Errorf("LoadFile() error = %v, want unknown keys error", err)
+		}
+	})
+
+	t.Run("YAMLとして不正なときエラーを返す", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+		path := writeConfigFile(t, "fetch: [unclosed\n")
+
+		// Act
+		_, err := LoadFile(path)
+
+		// Assert
+		if err == nil {
+			t.Fatal("LoadFile() error = nil, want parse error")
+		}
+	})
+
+	t.Run("設定ファイルが存在しないときエラーを返す", func(t *testing.T) {
+		// Arrange
+		setRequiredEnvVars(t)
+
+		// Act
+		_, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		// Assert
+		if err == nil {
+			t.Fatal("LoadFile() error = nil, want read error")
+		}
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("必須項目と不正な値をすべて列挙する", func(t *testing.T) {
+		// Arrange
+		clearRequiredEnvVars(t)
+		t.Setenv("GOOGLE_CLIENT_ID", "id")
+		t.Setenv("GOOGLE_CLIENT_SECRET", "secret")
+		t.Setenv("GOOGLE_REDIRECT_URL", "http://localhost:8080/auth/google/callback")
+		t.Setenv("BASE_URL", "localhost:8080")
+		t.Setenv("FETCH_MAX_CONCURRENT", "0")
+		t.Setenv("RATE_LIMIT_GENERAL", "-1")
+		t.Setenv("FETCH_MAX_INTERVAL_EXTENSION", "0.5")
+		t.Setenv("API_PAGE_LIMIT_DEFAULT", "500")
+		t.Setenv("FETCH_CLIENT_CERT_FILE", "/etc/feedman/client.crt")
+
+		// Act
+		_, err := Load()
+
+		// Assert
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Load() error = %v, want *ValidationError", err)
+		}
+		want := []string{
+			"DATABASE_URL (database.url) is required",
+			"SESSION_SECRET (auth.session_secret) is required",
+			"FETCH_MAX_CONCURRENT must be positive",
+			"RATE_LIMIT_GENERAL must be positive",
+			"FETCH_MAX_INTERVAL_EXTENSION must be at least 1",
+			"API_PAGE_LIMIT_DEFAULT must not exceed API_PAGE_LIMIT_MAX",
+			"FETCH_CLIENT_CERT_FILE and FETCH_CLIENT_KEY_FILE must be set together",
+			"BASE_URL must be an absolute http(s) URL",
+		}
+		for _, w := range want {
+			if !strings.Contains(verr.Error(), w) {
+				t.Errorf("error = %q, want to contain %q", verr.Error(), w)
+			}
+		}
+		if len(verr.Problems) != len(want) {
+			t.Errorf("problems = %d (%v), want %d", len(verr.Problems), verr.Problems, len(want))
+		}
+	})
+}
+
+func TestFileKeys_MapToDistinctEnvVars(t *testing.T) {
+	seen := make(map[string]string)
+	for key, env := range fileKeys {
+		if prev, ok := seen[env]; ok {
+			t.Errorf("%s は %s と %s の両方に対応している", env, prev, key)
+		}
+		seen[env] = key
+	}
+}
+
+// TestSampleConfigFile はリポジトリ同梱の feedman.sample.yaml が未知のキーを含まず読み込めることを検証する。
+func TestSampleConfigFile(t *testing.T) {
+	values, err := readConfigFile(filepath.Join("..", "..", "feedman.sample.yaml"))
+	if err != nil {
+		t.Fatalf("readConfigFile() error = %v", err)
+	}
+	if values["FETCH_MAX_ITEMS"] != "500" {
+		t.Errorf("FETCH_MAX_ITEMS = %q, want %q", values["FETCH_MAX_ITEMS"], "500")
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// ValidationError は設定の検証で見つかった問題をまとめたエラー。
+// 起動時に不足している設定を一度に把握できるよう、最初の問題で打ち切らずにすべて列挙する。
+type ValidationError struct {
+	Problems []string
+}
+
+// Error は error インターフェースを実装する。
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// problems は検証で見つかった問題を蓄積する。
+type problems []string
+
+// required は value が空のとき、環境変数名 env（と対応する設定ファイルのキー）を添えて未設定を記録する。
+func (p *problems) required(env, value string) {
+	if value != "" {
+		return
+	}
+	if key := fileKeyFor(env); key != "" {
+		*p = append(*p, fmt.Sprintf("%s (%s) is required", env, key))
+		return
+	}
+	*p = append(*p, fmt.Sprintf("%s is required", env))
+}
+
+// positive は value が 0 以下のとき問題を記録する。
+func positive[T ~int | ~int64](p *problems, env string, value T) {
+	if value <= 0 {
+		*p = append(*p, fmt.Sprintf("%s must be positive (got %v)", env, value))
+	}
+}
+
+// nonNegative は value が負のとき問題を記録する。
+func nonNegative[T ~int | ~int64 | ~float64](p *problems, env string, value T) {
+	if value < 0 {
+		*p = append(*p, fmt.Sprintf("%s must not be negative (got %v)", env, value))
+	}
+}
+
+// Validate は設定全体を検証し、問題があれば *ValidationError を返す。
+func (c *Config) Validate() error {
+	var p problems
+	c.DatabaseConfig.validate(&p)
+	c.AuthConfig.validate(&p)
+	c.FetcherConfig.validate(&p)
+	c.RateLimitConfig.validate(&p)
+	c.HatebuConfig.validate(&p)
+	c.SummarizerConfig.validate(&p)
+	c.ReadLaterConfig.validate(&p)
+	c.HighlightConfig.validate(&p)
+	c.MailConfig.validate(&p)
+
+	positive(&p, "API_PAGE_LIMIT_DEFAULT", c.APIPageLimitDefault)
+	positive(&p, "API_PAGE_LIMIT_MAX", c.APIPageLimitMax)
+	if c.APIPageLimitDefault > c.APIPageLimitMax {
+		p = append(p, fmt.Sprintf("API_PAGE_LIMIT_DEFAULT must not exceed API_PAGE_LIMIT_MAX (got %d > %d)", c.APIPageLimitDefault, c.APIPageLimitMax))
+	}
+
+	p.required("BASE_URL", c.BaseURL)
+	if c.BaseURL != "" {
+		if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			p = append(p, fmt.Sprintf("BASE_URL must be an absolute http(s) URL (got %q)", c.BaseURL))
+		}
+	}
+
+	if len(p) > 0 {
+		return &ValidationError{Problems: p}
+	}
+	return nil
+}
+
+// databaseSchemaPattern は DATABASE_SCHEMA に指定できるスキーマ名。
+// search_path ではクォートしない識別子が小文字に畳まれるため、小文字・数字・アンダースコアの 63 文字以内に限る。
+var databaseSchemaPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+func (c DatabaseConfig) validate(p *problems) {
+	p.required("DATABASE_URL", c.DatabaseURL)
+	if c.DatabaseSchema != "" && !databaseSchemaPattern.MatchString(c.DatabaseSchema) {
+		*p = append(*p, fmt.Sprintf("DATABASE_SCHEMA must be a lowercase identifier (a-z, 0-9, _; up to 63 chars) (got %q)", c.DatabaseSchema))
+	}
+	nonNegative(p, "MIGRATE_LOCK_TIMEOUT", c.MigrateLockTimeout)
+	nonNegative(p, "MIGRATE_STATEMENT_TIMEOUT", c.MigrateStatementTimeout)
+	positive(p, "MIGRATE_MAX_ATTEMPTS", c.MigrateMaxAttempts)
+	nonNegative(p, "MIGRATE_RETRY_INTERVAL", c.MigrateRetryInterval)
+}
+
+func (c AuthConfig) validate(p *problems) {
+	p.required("GOOGLE_CLIENT_ID", c.GoogleClientID)
+	p.required("GOOGLE_CLIENT_SECRET", c.GoogleClientSecret)
+	p.required("GOOGLE_REDIRECT_URL", c.GoogleRedirectURL)
+	p.required("SESSION_SECRET", c.SessionSecret)
+	positive(p, "SESSION_MAX_AGE", c.SessionMaxAge)
+
+	switch c.SessionStore {
+	case SessionStorePostgres:
+	case SessionStoreRedis:
+		if c.RedisURL == "" {
+			*p = append(*p, fmt.Sprintf("REDIS_URL is required when SESSION_STORE=%s", SessionStoreRedis))
+		}
+	default:
+		*p = append(*p, fmt.Sprintf("unsupported SESSION_STORE: %q (expected %q or %q)", c.SessionStore, SessionStorePostgres, SessionStoreRedis))
+	}
+}
+
+func (c FetcherConfig) validate(p *problems) {
+	positive(p, "FETCH_TIMEOUT", c.FetchTimeout)
+	positive(p, "FETCH_MAX_SIZE", c.FetchMaxSize)
+	positive(p, "FETCH_MAX_CONCURRENT", c.FetchMaxConcurrent)
+	positive(p, "FETCH_INTERVAL", c.FetchInterval)
+	nonNegative(p, "FETCH_HOST_INTERVAL", c.FetchHostInterval)
+	positive(p, "FETCH_MAX_ITEMS", c.FetchMaxItems)
+	positive(p, "FETCH_FULL_RATE_SUBSCRIBERS", c.FetchFullRateSubscribers)
+	if c.FetchMaxIntervalExtension < 1 {
+		*p = append(*p, fmt.Sprintf("FETCH_MAX_INTERVAL_EXTENSION must be at least 1 (got %v)", c.FetchMaxIntervalExtension))
+	}
+	nonNegative(p, "USER_DORMANT_AFTER", c.UserDormantAfter)
+	nonNegative(p, "FETCH_DORMANT_INTERVAL", c.FetchDormantInterval)
+	nonNegative(p, "FETCH_DNS_CACHE_TTL", c.FetchDNSCacheTTL)
+	nonNegative(p, "FETCH_DNS_NEGATIVE_TTL", c.FetchDNSNegativeTTL)
+	if (c.FetchClientCertFile == "") != (c.FetchClientKeyFile == "") {
+		*p = append(*p, "FETCH_CLIENT_CERT_FILE and FETCH_CLIENT_KEY_FILE must be set together")
+	}
+}
+
+func (c RateLimitConfig) validate(p *problems) {
+	positive(p, "RATE_LIMIT_GENERAL", c.RateLimitGeneral)
+	positive(p, "RATE_LIMIT_FEED_REG", c.RateLimitFeedReg)
+	positive(p, "RATE_LIMIT_UNAUTH_IP", c.RateLimitUnauthIP)
+}
+
+func (c HatebuConfig) validate(p *problems) {
+	positive(p, "HATEBU_TTL", c.HatebuTTL)
+	positive(p, "HATEBU_BATCH_INTERVAL", c.HatebuBatchInterval)
+	nonNegative(p, "HATEBU_API_INTERVAL", c.HatebuAPIInterval)
+	positive(p, "HATEBU_MAX_CALLS_PER_CYCLE", c.HatebuMaxCallsPerCycle)
+	nonNegative(p, "HATEBU_COUNT_CACHE_TTL", c.HatebuCountCacheTTL)
+	nonNegative(p, "HATEBU_PRIORITY_RECENCY_WEIGHT", c.HatebuPriorityRecencyWeight)
+	nonNegative(p, "HATEBU_PRIORITY_POPULARITY_WEIGHT", c.HatebuPriorityPopularityWeight)
+	positive(p, "HATEBU_PRIORITY_RECENCY_HALF_LIFE", c.HatebuPriorityRecencyHalfLife)
+	nonNegative(p, "HATEBU_ON_DEMAND_STALE_AFTER", c.HatebuOnDemandStaleAfter)
+}
+
+func (c SummarizerConfig) validate(p *problems) {
+	positive(p, "SUMMARIZER_TIMEOUT", c.SummarizerTimeout)
+	positive(p, "SUMMARIZER_RATE_PER_HOUR", c.SummarizerRatePerHour)
+	if c.SummarizerAPIURL == "" {
+		return
+	}
+	if u, err := url.Parse(c.SummarizerAPIURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		*p = append(*p, fmt.Sprintf("SUMMARIZER_API_URL must be an absolute http(s) URL (got %q)", c.SummarizerAPIURL))
+	}
+	if c.SummarizerModel == "" {
+		*p = append(*p, "SUMMARIZER_MODEL is required when SUMMARIZER_API_URL is set")
+	}
+}
+
+func (c ReadLaterConfig) validate(p *problems) {
+	if c.ReadLaterEncryptionKey != "" {
+		if _, err := security.ParseSecretKey(c.ReadLaterEncryptionKey); err != nil {
+			*p = append(*p, fmt.Sprintf("READ_LATER_ENCRYPTION_KEY is invalid: %v", err))
+		}
+	}
+	if (c.InstapaperConsumerKey == "") != (c.InstapaperConsumerSecret == "") {
+		*p = append(*p, "INSTAPAPER_CONSUMER_KEY and INSTAPAPER_CONSUMER_SECRET must be set together")
+	}
+}
+
+func (c HighlightConfig) validate(p *problems) {
+	nonNegative(p, "HIGHLIGHT_WEIGHT_HATEBU", c.HighlightWeightHatebu)
+	nonNegative(p, "HIGHLIGHT_WEIGHT_STAR", c.HighlightWeightStar)
+	nonNegative(p, "HIGHLIGHT_WEIGHT_VIEW", c.HighlightWeightView)
+	positive(p, "HIGHLIGHT_COMPUTE_INTERVAL", c.HighlightComputeInterval)
+	positive(p, "KEYWORD_COMPUTE_INTERVAL", c.KeywordComputeInterval)
+}
+
+func (c MailConfig) validate(p *problems) {
+	if c.SMTPHost == "" {
+		return
+	}
+	positive(p, "SMTP_PORT", c.SMTPPort)
+	if c.MailFrom == "" {
+		*p = append(*p, "MAIL_FROM is required when SMTP_HOST is set")
+	} else if _, err := mail.ParseAddress(c.MailFrom); err != nil {
+		*p = append(*p, fmt.Sprintf("MAIL_FROM must be a valid email address (got %q)", c.MailFrom))
+	}
+}
@@ -0,0 +1,69 @@
+package crossfeed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// MaxRandomPickCount は PickRandomItems で一度に取り出せる記事数の上限。
+// これを超える指定は上限値にクランプする。
+const MaxRandomPickCount = 20
+
+// randomPickFilters は PickRandomItems が受け付けるフィルタ値のセット。
+var randomPickFilters = map[model.ItemFilter]bool{
+	model.ItemFilterAll:     true,
+	model.ItemFilterUnread:  true,
+	model.ItemFilterStarred: true,
+}
+
+// RandomService は積読解消向けに購読中フィードの記事をランダムに取り出すサービス。
+type RandomService struct {
+	repo repository.RandomItemRepository
+	// pivotFn はテスト容易性のため起点 UUID の生成を差し替え可能にする内部 hook。
+	// 通常運用では nil（uuid.New が使われる）。
+	pivotFn func() string
+}
+
+// NewRandomService は RandomService の新しいインスタンスを生成する。
+func NewRandomService(repo repository.RandomItemRepository) *RandomService {
+	return &RandomService{repo: repo}
+}
+
+// pivot はランダム抽出の起点となる UUID を返す。
+func (s *RandomService) pivot() string {
+	if s.pivotFn != nil {
+		return s.pivotFn()
+	}
+	return uuid.New().String()
+}
+
+// PickRandomItems は filter に合致する記事を最大 count 件ランダムに返す。
+// 無効なフィルタ値は model.NewInvalidFilterError を返す。count が 0 以下の場合は 1 件、
+// MaxRandomPickCount を超える場合は上限値として扱う。該当記事が無い場合は空スライスを返す。
+func (s *RandomService) PickRandomItems(ctx context.Context, userID string, filter model.ItemFilter, count int) ([]CrossFeedItemSummary, error) {
+	if !randomPickFilters[filter] {
+		return nil, model.NewInvalidFilterError(string(filter))
+	}
+	if count <= 0 {
+		count = 1
+	}
+	if count > MaxRandomPickCount {
+		count = MaxRandomPickCount
+	}
+
+	rows, err := s.repo.PickRandomItems(ctx, userID, filter, s.pivot(), count)
+	if err != nil {
+		return nil, fmt.Errorf("ランダム記事の取得に失敗しました: %w", err)
+	}
+
+	summaries := make([]CrossFeedItemSummary, len(rows))
+	for i, row := range rows {
+		summaries[i] = toCrossFeedItemSummary(row)
+	}
+	return summaries, nil
+}
@@ -0,0 +1,180 @@
+package crossfeed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockRandomItemRepo は RandomItemRepository のモック。
+type mockRandomItemRepo struct {
+	pickRandomItemsFn func(ctx context.Context, userID string, filter model.ItemFilter, pivotID string, limit int) ([]repository.CrossFeedItem, error)
+
+	// 呼び出し記録
+	lastUserID  string
+	lastFilter  model.ItemFilter
+	lastPivotID string
+	lastLimit   int
+	callCount   int
+}
+
+func (m *mockRandomItemRepo) PickRandomItems(ctx context.Context, userID string, filter model.ItemFilter, pivotID string, limit int) ([]repository.CrossFeedItem, error) {
+	m.lastUserID = userID
+	m.lastFilter = filter
+	m.lastPivotID = pivotID
+	m.lastLimit = limit
+	m.callCount++
+	if m.pickRandomItemsFn != nil {
+		return m.pickRandomItemsFn(ctx, userID, filter, pivotID, limit)
+	}
+	return nil, nil
+}
+
+func TestRandomService_PickRandomItems(t *testing.T) {
+	ctx := context.Background()
+	publishedAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("有効なフィルタのとき起点 UUID と件数をリポジトリに渡しサマリを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockRandomItemRepo{
+			pickRandomItemsFn: func(_ context.Context, _ string, _ model.ItemFilter, _ string, _ int) ([]repository.CrossFeedItem, error) {
+				row := newRowAt("item-1", "feed-1", "Feed 1", publishedAt)
+				row.FaviconData = []byte{0x01}
+				row.FaviconMime = "image/png"
+				return []repository.CrossFeedItem{row, newRowAt("item-2", "feed-2", "Feed 2", publishedAt)}, nil
+			},
+		}
+		svc := NewRandomService(repo)
+		svc.pivotFn = func() string { return "00000000-0000-0000-0000-000000000001" }
+
+		// Act
+		got, err := svc.PickRandomItems(ctx, "user-1", model.ItemFilterUnread, 2)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.lastUserID != "user-1" || repo.lastFilter != model.ItemFilterUnread || repo.lastLimit != 2 {
+			t.Errorf("repo args = (%q, %q, %d), want (user-1, unread, 2)", repo.lastUserID, repo.lastFilter, repo.lastLimit)
+		}
+		if repo.lastPivotID != "00000000-0000-0000-0000-000000000001" {
+			t.Errorf("pivotID = %q, want pivotFn の戻り値", repo.lastPivotID)
+		}
+		if len(got) != 2 || got[0].ID != "item-1" || got[1].ID != "item-2" {
+			t.Fatalf("got = %+v, want item-1, item-2", got)
+		}
+		if got[0].FeedFaviconURL == nil || *got[0].FeedFaviconURL != "data:image/png;base64,AQ==" {
+			t.Errorf("FeedFaviconURL = %v, want data URL", got[0].FeedFaviconURL)
+		}
+		if got[1].FeedFaviconURL != nil {
+			t.Errorf("favicon 未設定の FeedFaviconURL = %v, want nil", *got[1].FeedFaviconURL)
+		}
+	})
+
+	t.Run("pivotFn 未設定のとき UUID 形式の起点を生成する", func(t *testing.T) {
+		// Arrange
+		repo := &mockRandomItemRepo{}
+		svc := NewRandomService(repo)
+
+		// Act
+		_, err := svc.PickRandomItems(ctx, "user-1", model.ItemFilterAll, 1)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(repo.lastPivotID) != 36 {
+			t.Errorf("pivotID = %q, want UUID 形式", repo.lastPivotID)
+		}
+	})
+
+	t.Run("count が 0 以下のとき 1 件として扱う", func(t *testing.T) {
+		// Arrange
+		repo := &mockRandomItemRepo{}
+		svc := NewRandomService(repo)
+
+		// Act
+		_, err := svc.PickRandomItems(ctx, "user-1", model.ItemFilterUnread, 0)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.lastLimit != 1 {
+			t.Errorf("limit = %d, want 1", repo.lastLimit)
+		}
+	})
+
+	t.Run("count が上限を超えるとき上限値にクランプする", func(t *testing.T) {
+		// Arrange
+		repo := &mockRandomItemRepo{}
+		svc := NewRandomService(repo)
+
+		// Act
+		_, err := svc.PickRandomItems(ctx, "user-1", model.ItemFilterStarred, MaxRandomPickCount+1)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.lastLimit != MaxRandomPickCount {
+			t.Errorf("limit = %d, want %d", repo.lastLimit, MaxRandomPickCount)
+		}
+	})
+
+	t.Run("該当記事が無いとき空スライスを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewRandomService(&mockRandomItemRepo{})
+
+		// Act
+		got, err := svc.PickRandomItems(ctx, "user-1", model.ItemFilterUnread, 1)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil || len(got) != 0 {
+			t.Errorf("got = %#v, want 空スライス", got)
+		}
+	})
+
+	t.Run("無効なフィルタのとき INVALID_FILTER を返しリポジトリを呼ばない", func(t *testing.T) {
+		// Arrange
+		repo := &mockRandomItemRepo{}
+		svc := NewRandomService(repo)
+
+		// Act
+		_, err := svc.PickRandomItems(ctx, "user-1", model.ItemFilter("bogus"), 1)
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidFilter {
+			t.Fatalf("err = %v, want INVALID_FILTER", err)
+		}
+		if repo.callCount != 0 {
+			t.Errorf("repo callCount = %d, want 0", repo.callCount)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返すときエラーを伝播する", func(t *testing.T) {
+		// Arrange
+		repoErr := errors.New("db down")
+		svc := NewRandomService(&mockRandomItemRepo{
+			pickRandomItemsFn: func(_ context.Context, _ string, _ model.ItemFilter, _ string, _ int) ([]repository.CrossFeedItem, error) {
+				return nil, repoErr
+			},
+		})
+
+		// Act
+		_, err := svc.PickRandomItems(ctx, "user-1", model.ItemFilterUnread, 1)
+
+		// Assert
+		if !errors.Is(err, repoErr) {
+			t.Errorf("err = %v, want wrapped repoErr", err)
+		}
+	})
+}
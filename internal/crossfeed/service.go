@@ -8,11 +8,11 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/hitoshi/feedman/internal/item"
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
@@ -129,7 +129,7 @@ func (s *Service) ListNewItems(
 		summaries[i] = toCrossFeedItemSummary(row)
 	}
 
-	// (5) NextCursor 組み立て: <published_at(RFC3339Nano)>:<item_id>
+	// (5) NextCursor 組み立て: (published_at, item_id) を内包する不透明カーソル
 	var nextCursor string
 	if hasMore && len(summaries) > 0 {
 		last := summaries[len(summaries)-1]
@@ -171,36 +171,28 @@ func (s *Service) resolveSinceTime(ctx context.Context, userID string, overrideS
 	return s.now().Add(-defaultFallbackWindow), nil
 }
 
-// parseCrossFeedCursor は `<RFC3339Nano>:<itemID>` 形式の複合カーソルを分解する。
+// crossFeedCursorSort は横断新着一覧カーソルの並び順識別子（published_at DESC, id DESC）。
+const crossFeedCursorSort = "cross_feed_items.published_at_desc.id_desc"
+
+// parseCrossFeedCursor は pagination の不透明カーソル（移行期間中は旧形式の
+// `<RFC3339Nano>:<itemID>` も可）を (published_at, item_id) に分解する。
 // 空文字列の場合は (ゼロ値, "", nil) を返し、呼び出し側で「先頭ページ取得」を意味する。
-// 不正形式は model.NewInvalidFilterError を返す（既存エラーコード INVALID_FILTER の再利用）。
+// 不正形式・tiebreaker の item_id を欠くカーソルは model.NewInvalidFilterError を返す
+// （既存エラーコード INVALID_FILTER の再利用）。
 func parseCrossFeedCursor(cursorStr string) (time.Time, string, error) {
 	if cursorStr == "" {
 		return time.Time{}, "", nil
 	}
-	// RFC3339Nano は ":" を含むため、末尾の ":" で分割する
-	idx := strings.LastIndex(cursorStr, ":")
-	if idx <= 0 || idx == len(cursorStr)-1 {
+	cursor, err := pagination.Decode(crossFeedCursorSort, cursorStr)
+	if err != nil || cursor.ID == "" {
 		return time.Time{}, "", model.NewInvalidFilterError("invalid cursor: " + cursorStr)
 	}
-	publishedAtStr := cursorStr[:idx]
-	itemID := cursorStr[idx+1:]
-
-	publishedAt, err := time.Parse(time.RFC3339Nano, publishedAtStr)
-	if err != nil {
-		// RFC3339 でも parse を試みる（fallback、横断 API のカーソル規約を緩める）
-		publishedAt, err = time.Parse(time.RFC3339, publishedAtStr)
-		if err != nil {
-			return time.Time{}, "", model.NewInvalidFilterError("invalid cursor: " + cursorStr)
-		}
-	}
-	return publishedAt, itemID, nil
+	return cursor.Time, cursor.ID, nil
 }
 
-// formatCrossFeedCursor は published_at と item_id から `<RFC3339Nano>:<itemID>` 形式の
-// 複合カーソルを組み立てる。
+// formatCrossFeedCursor は published_at と item_id から複合カーソルを組み立てる。
 func formatCrossFeedCursor(publishedAt time.Time, itemID string) string {
-	return publishedAt.Format(time.RFC3339Nano) + ":" + itemID
+	return pagination.Encode(crossFeedCursorSort, pagination.Cursor{Time: publishedAt, ID: itemID})
 }
 
 // toCrossFeedItemSummary は repository.CrossFeedItem を CrossFeedItemSummary に変換する。
@@ -213,16 +205,17 @@ func toCrossFeedItemSummary(row repository.CrossFeedItem) CrossFeedItemSummary {
 	}
 	summary := CrossFeedItemSummary{
 		ItemSummary: item.ItemSummary{
-			ID:              row.ID,
-			FeedID:          row.FeedID,
-			Title:           row.Title,
-			Link:            row.Link,
-			Summary:         row.Summary,
-			PublishedAt:     pubAt,
-			IsDateEstimated: row.IsDateEstimated,
-			IsRead:          row.IsRead,
-			IsStarred:       row.IsStarred,
-			HatebuCount:     row.HatebuCount,
+			ID:                 row.ID,
+			FeedID:             row.FeedID,
+			Title:              row.Title,
+			Link:               row.Link,
+			Summary:            row.Summary,
+			PublishedAt:        pubAt,
+			IsDateEstimated:    row.IsDateEstimated,
+			IsRead:             row.IsRead,
+			IsStarred:          row.IsStarred,
+			HatebuCount:        row.HatebuCount,
+			ReadingTimeMinutes: row.ReadingTimeMinutes,
 		},
 		FeedTitle: row.FeedTitle,
 	}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
@@ -60,14 +61,17 @@ func (m *mockItemRepo) FindByFeedAndLink(_ context.Context, _, _ string) (*model
 func (m *mockItemRepo) FindByContentHash(_ context.Context, _, _ string) (*model.Item, error) {
 	return nil, nil
 }
-func (m *mockItemRepo) ListByFeed(_ context.Context, _, _ string, _ model.ItemFilter, _ time.Time, _ int) ([]model.ItemWithState, error) {
+func (m *mockItemRepo) ListByFeed(_ context.Context, _, _ string, _ model.ItemConditions, _ string, _ time.Time, _ string, _ int) ([]model.ItemWithState, error) {
 	return nil, nil
 }
-func (m *mockItemRepo) ListStarredByUser(_ context.Context, _ string, _ time.Time, _ int) ([]repository.StarredItemRow, error) {
+func (m *mockItemRepo) ListByFeeds(_ context.Context, _ []string, _ string, _ model.ItemConditions, _ string, _ time.Time, _ string, _ int) ([]model.ItemWithState, error) {
 	return nil, nil
 }
-func (m *mockItemRepo) Create(_ context.Context, _ *model.Item) error                  { return nil }
-func (m *mockItemRepo) Update(_ context.Context, _ *model.Item) error                  { return nil }
+func (m *mockItemRepo) ListStarredByUser(_ context.Context, _ string, _ int, _ time.Time, _ string, _ int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
+	return nil, nil
+}
+func (m *mockItemRepo) Create(_ context.Context, _ *model.Item) error { return nil }
+func (m *mockItemRepo) Update(_ context.Context, _ *model.Item) error { return nil }
 func (m *mockItemRepo) FindExistingForUpsert(_ context.Context, _ string, _, _, _ []string) (*repository.ExistingItems, error) {
 	return nil, nil
 }
@@ -219,7 +223,7 @@ func TestListNewItems(t *testing.T) {
 		}
 	})
 
-	t.Run("limit+1 件取得で HasMore=true / NextCursor が <RFC3339Nano>:<itemID> 形式で組み立てられること", func(t *testing.T) {
+	t.Run("limit+1 件取得で HasMore=true / NextCursor が (published_at, id) を内包する不透明カーソルで組み立てられること", func(t *testing.T) {
 		// Arrange
 		lastSeen := time.Date(2026, 5, 27, 10, 0, 0, 0, time.UTC)
 		// limit=2 を要求 → repo は limit+1=3 件を返し HasMore=true / 3 件目は cursor 算出にのみ使い表示から除外
@@ -259,10 +263,13 @@ func TestListNewItems(t *testing.T) {
 		if len(result.Items) != 2 {
 			t.Fatalf("Items count should be limit=2 (HasMore 判定用の 1 件は切り詰める): got %d", len(result.Items))
 		}
-		// NextCursor は表示最後尾（item-2 / pa2）の (RFC3339Nano, id)
-		wantCursor := pa2.Format(time.RFC3339Nano) + ":item-2"
-		if result.NextCursor != wantCursor {
-			t.Errorf("NextCursor mismatch: got %q, want %q", result.NextCursor, wantCursor)
+		// NextCursor は表示最後尾（item-2 / pa2）の (published_at, id) を指す
+		gotPA, gotID, err := parseCrossFeedCursor(result.NextCursor)
+		if err != nil {
+			t.Fatalf("NextCursor %q could not be parsed: %v", result.NextCursor, err)
+		}
+		if !gotPA.Equal(pa2) || gotID != "item-2" {
+			t.Errorf("NextCursor mismatch: got (%v, %q), want (%v, %q)", gotPA, gotID, pa2, "item-2")
 		}
 	})
 
@@ -373,7 +380,40 @@ func TestListNewItems(t *testing.T) {
 		}
 	})
 
-	t.Run("有効な cursor が <RFC3339Nano>:<itemID> 形式で repo に渡されること", func(t *testing.T) {
+	t.Run("不透明カーソルの (published_at, id) が repo に渡されること", func(t *testing.T) {
+		// Arrange
+		lastSeen := time.Date(2026, 5, 27, 10, 0, 0, 0, time.UTC)
+		cursorPA := time.Date(2026, 5, 28, 11, 0, 0, 123456789, time.UTC)
+		cursorID := "550e8400-e29b-41d4-a716-446655440000"
+
+		itemRepo := &mockItemRepo{}
+		viewRepo := &mockUserCrossFeedViewRepo{
+			getFn: func(_ context.Context, _ string) (*model.UserCrossFeedView, error) {
+				return &model.UserCrossFeedView{LastSeenAt: lastSeen}, nil
+			},
+		}
+		s := NewService(itemRepo, viewRepo)
+
+		// Act
+		_, err := s.ListNewItems(ctx, userID, formatCrossFeedCursor(cursorPA, cursorID), 50, nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !itemRepo.lastCursorPublishedAt.Equal(cursorPA) {
+			t.Errorf("cursorPublishedAt mismatch: got %v, want %v", itemRepo.lastCursorPublishedAt, cursorPA)
+		}
+		if itemRepo.lastCursorItemID != cursorID {
+			t.Errorf("cursorItemID mismatch: got %q, want %q", itemRepo.lastCursorItemID, cursorID)
+		}
+	})
+
+	t.Run("旧形式 <RFC3339Nano>:<itemID> の cursor も移行期間中は repo に渡されること", func(t *testing.T) {
+		if !time.Now().Before(pagination.LegacyFormatSunset) {
+			t.Skip("旧形式カーソルの受理期限を過ぎている")
+		}
+
 		// Arrange
 		lastSeen := time.Date(2026, 5, 27, 10, 0, 0, 0, time.UTC)
 		cursorPA := time.Date(2026, 5, 28, 11, 0, 0, 123456789, time.UTC)
@@ -415,10 +455,11 @@ func TestListNewItems(t *testing.T) {
 
 		// 不正形式の cursor パターンを複数検証
 		invalidCursors := []string{
-			"not-a-cursor",                // ":" を含まない
-			":item-id-only",               // 先頭が ":"
-			"2026-05-28T12:00:00Z:",       // 末尾が ":" で itemID が空
-			"invalid-time:item-id",        // published_at が parse 不能
+			"not-a-cursor",          // ":" を含まない
+			":item-id-only",         // 先頭が ":"
+			"2026-05-28T12:00:00Z:", // 末尾が ":" で itemID が空
+			"invalid-time:item-id",  // published_at が parse 不能
+			"2026-05-28T12:00:00Z",  // tiebreaker の itemID を欠く
 		}
 
 		for _, c := range invalidCursors {
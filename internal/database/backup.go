@@ -0,0 +1,426 @@
+package database
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// BackupFormat はバックアップファイルの先頭行（ヘッダー）に埋め込む形式名。
+	BackupFormat = "feedman-backup"
+	// BackupFormatVersion はバックアップファイルの形式のバージョン。
+	// 行の構造を変更した場合に上げ、読み込み側は未知のバージョンを拒否する。
+	BackupFormatVersion = 1
+)
+
+// ErrBackupTargetNotEmpty は復元先のテーブルに既に行があることを表す。
+var ErrBackupTargetNotEmpty = errors.New("restore target database is not empty")
+
+// BackupHeader はバックアップファイルの先頭行に書き出すメタデータ。
+type BackupHeader struct {
+	Format        string `json:"format"`
+	FormatVersion int    `json:"format_version"`
+	// SchemaVersion はバックアップ取得時点のマイグレーションのバージョン。
+	// 復元時はこのバージョンまでマイグレーションしてから行を投入し、その後に最新まで適用する。
+	SchemaVersion uint      `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// Tables は書き出したテーブルの一覧。外部キーの参照先が先に来る順に並ぶ。
+	Tables []string `json:"tables"`
+}
+
+// backupRecord はバックアップファイルの 2 行目以降の 1 行で、テーブルの 1 行を表す。
+// Row は row_to_json で得た列名をキーとする JSON オブジェクト。
+type backupRecord struct {
+	Table string          `json:"table"`
+	Row   json.RawMessage `json:"row"`
+}
+
+// Backup は現在のスキーマの全テーブル（マイグレーション管理テーブルを除く）を論理ダンプとして w に書き出す。
+// 形式は gzip 圧縮した JSON Lines で、先頭行が BackupHeader、以降が 1 行 1 レコードとなる。
+// 全テーブルを 1 つの REPEATABLE READ の読み取り専用トランザクションで読むため、整合した時点のデータになる。
+// 行はクエリ結果から 1 行ずつ書き出すため、データ量に関わらずメモリ使用量は一定に保たれる。
+func Backup(ctx context.Context, db *sql.DB, w io.Writer, schemaVersion uint) error {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin backup transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tables, err := backupTables(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	header := BackupHeader{
+		Format:        BackupFormat,
+		FormatVersion: BackupFormatVersion,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		Tables:        tables,
+	}
+	if err := enc.Encode(header); err != nil {
+		return fmt.Errorf("failed to write backup header: %w", err)
+	}
+
+	for _, table := range tables {
+		if err := backupTable(ctx, tx, enc, table); err != nil {
+			return err
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish backup: %w", err)
+	}
+	return nil
+}
+
+// backupTable は table の全行を enc に書き出す。
+func backupTable(ctx context.Context, tx *sql.Tx, enc *json.Encoder, table string) error {
+	rows, err := tx.QueryContext(ctx, `SELECT row_to_json(t)::text FROM `+pq.QuoteIdentifier(table)+` t`)
+	if err != nil {
+		return fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("failed to scan row of table %s: %w", table, err)
+		}
+		if err := enc.Encode(backupRecord{Table: table, Row: row}); err != nil {
+			return fmt.Errorf("failed to write row of table %s: %w", table, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+	return nil
+}
+
+// backupTables は現在のスキーマのテーブルを、外部キーの参照先が参照元より先に来る順に返す。
+// 依存関係の無いテーブル同士は名前順とし、出力を決定的にする。
+func backupTables(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT table_name FROM information_schema.tables
+		 WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' AND table_name <> 'schema_migrations'
+		 ORDER BY table_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	rows, err = tx.QueryContext(ctx,
+		`SELECT c.conrelid::regclass::text, c.confrelid::regclass::text
+		 FROM pg_constraint c
+		 JOIN pg_namespace n ON n.oid = c.connamespace
+		 WHERE c.contype = 'f' AND n.nspname = current_schema()`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+	defer rows.Close()
+	deps := make(map[string][]string)
+	for rows.Next() {
+		var from, to string
+		if err := rows.Scan(&from, &to); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+		deps[unqualifiedTableName(from)] = append(deps[unqualifiedTableName(from)], unqualifiedTableName(to))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+
+	return sortTablesByDependency(tables, deps), nil
+}
+
+// unqualifiedTableName は regclass のテキスト表現からスキーマ修飾と引用符を取り除く。
+func unqualifiedTableName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.Trim(name, `"`)
+}
+
+// sortTablesByDependency は deps（テーブル → 参照先テーブル）に従い、参照先が先に来るよう tables を並べる。
+// 自己参照や循環は無視し、tables に無い参照先も無視する。
+func sortTablesByDependency(tables []string, deps map[string][]string) []string {
+	known := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		known[t] = true
+	}
+	sorted := make([]string, 0, len(tables))
+	visited := make(map[string]bool, len(tables))
+	var visit func(table string)
+	visit = func(table string) {
+		if visited[table] {
+			return
+		}
+		visited[table] = true
+		refs := append([]string(nil), deps[table]...)
+		sort.Strings(refs)
+		for _, ref := range refs {
+			if known[ref] {
+				visit(ref)
+			}
+		}
+		sorted = append(sorted, table)
+	}
+	for _, t := range tables {
+		visit(t)
+	}
+	return sorted
+}
+
+// BackupReader はバックアップファイルを先頭から順に読み出す。
+type BackupReader struct {
+	gz     *gzip.Reader
+	dec    *json.Decoder
+	header BackupHeader
+}
+
+// OpenBackup はバックアップファイルを開き、ヘッダーを検証して読み込む。
+// 形式名・形式のバージョンが一致しない場合はエラーを返す。
+func OpenBackup(r io.Reader) (*BackupReader, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	dec := json.NewDecoder(gz)
+
+	var header BackupHeader
+	if err := dec.Decode(&header); err != nil {
+		gz.Close()
+		return nil, fmt.Errorf("failed to read backup header: %w", err)
+	}
+	if header.Format != BackupFormat {
+		gz.Close()
+		return nil, fmt.Errorf("not a feedman backup (format=%q)", header.Format)
+	}
+	if header.FormatVersion != BackupFormatVersion {
+		gz.Close()
+		return nil, fmt.Errorf("unsupported backup format version %d (supported: %d)", header.FormatVersion, BackupFormatVersion)
+	}
+	return &BackupReader{gz: gz, dec: dec, header: header}, nil
+}
+
+// Header はバックアップファイルのヘッダーを返す。
+func (b *BackupReader) Header() BackupHeader {
+	return b.header
+}
+
+// Close はバックアップファイルの読み込みを終了する。元の io.Reader は閉じない。
+func (b *BackupReader) Close() error {
+	return b.gz.Close()
+}
+
+// next は次のレコードを返す。末尾に達した場合は io.EOF を返す。
+func (b *BackupReader) next() (backupRecord, error) {
+	var rec backupRecord
+	if err := b.dec.Decode(&rec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return rec, io.EOF
+		}
+		return rec, fmt.Errorf("failed to read backup record: %w", err)
+	}
+	if rec.Table == "" || len(rec.Row) == 0 {
+		return rec, errors.New("invalid backup record: table or row is missing")
+	}
+	return rec, nil
+}
+
+// RestoreResult は復元したテーブルごとの行数。
+type RestoreResult map[string]int
+
+// Restore はバックアップの全行を db に投入する。全行を 1 つのトランザクションで投入し、失敗時は何も残さない。
+// 復元先のテーブルはすべて空であること（空でない場合は ErrBackupTargetNotEmpty）。
+// 復元先のスキーマがバックアップ取得時より新しい場合に備え、各行は復元先に存在する列だけを投入し、
+// バックアップに無い列は列の既定値とする。投入後は BIGSERIAL のシーケンスを投入済みの最大値に合わせる。
+func (b *BackupReader) Restore(ctx context.Context, db *sql.DB) (RestoreResult, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin restore transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tables, err := backupTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]map[string]bool, len(tables))
+	for _, table := range tables {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+pq.QuoteIdentifier(table)+`)`).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check table %s: %w", table, err)
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: table %s has rows", ErrBackupTargetNotEmpty, table)
+		}
+		cols, err := insertableColumns(ctx, tx, table)
+		if err != nil {
+			return nil, err
+		}
+		columns[table] = cols
+	}
+
+	result := make(RestoreResult)
+	stmts := make(map[string]*sql.Stmt)
+	defer func() {
+		for _, stmt := range stmts {
+			stmt.Close()
+		}
+	}()
+	for {
+		rec, err := b.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		cols, ok := columns[rec.Table]
+		if !ok {
+			// 新しいスキーマで削除されたテーブルの行は投入しない
+			continue
+		}
+
+		var row map[string]json.RawMessage
+		if err := json.Unmarshal(rec.Row, &row); err != nil {
+			return nil, fmt.Errorf("invalid backup row of table %s: %w", rec.Table, err)
+		}
+		var names []string
+		for name := range row {
+			if cols[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		key := rec.Table + "\x00" + strings.Join(names, ",")
+		stmt, ok := stmts[key]
+		if !ok {
+			stmt, err = tx.PrepareContext(ctx, restoreInsertQuery(rec.Table, names))
+			if err != nil {
+				return nil, fmt.Errorf("failed to prepare restore of table %s: %w", rec.Table, err)
+			}
+			stmts[key] = stmt
+		}
+		if _, err := stmt.ExecContext(ctx, string(rec.Row)); err != nil {
+			return nil, fmt.Errorf("failed to restore row of table %s: %w", rec.Table, err)
+		}
+		result[rec.Table]++
+	}
+
+	if err := resetSequences(ctx, tx); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return result, nil
+}
+
+// restoreInsertQuery は JSON の 1 行（$1）から names の列だけを table に投入する INSERT 文を返す。
+// 型の変換は json_populate_record に任せる。
+func restoreInsertQuery(table string, names []string) string {
+	quotedTable := pq.QuoteIdentifier(table)
+	if len(names) == 0 {
+		return `INSERT INTO ` + quotedTable + ` DEFAULT VALUES`
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = pq.QuoteIdentifier(name)
+	}
+	list := strings.Join(quoted, ", ")
+	return `INSERT INTO ` + quotedTable + ` (` + list + `) SELECT ` + list +
+		` FROM json_populate_record(NULL::` + quotedTable + `, $1::json)`
+}
+
+// insertableColumns は table の列のうち INSERT で値を指定できる列（生成列以外）を返す。
+func insertableColumns(ctx context.Context, tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT column_name FROM information_schema.columns
+		 WHERE table_schema = current_schema() AND table_name = $1 AND is_generated = 'NEVER'`,
+		table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns of table %s: %w", table, err)
+	}
+	defer rows.Close()
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan column of table %s: %w", table, err)
+		}
+		cols[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list columns of table %s: %w", table, err)
+	}
+	return cols, nil
+}
+
+// resetSequences は列の既定値に使われているシーケンスを、投入済みの最大値に合わせる。
+// 復元後の INSERT で BIGSERIAL の ID が既存の行と衝突しないようにする。
+func resetSequences(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT table_name, column_name, pg_get_serial_sequence(quote_ident(table_name), column_name)
+		 FROM information_schema.columns
+		 WHERE table_schema = current_schema() AND column_default LIKE 'nextval(%'`)
+	if err != nil {
+		return fmt.Errorf("failed to list sequences: %w", err)
+	}
+	type serialColumn struct{ table, column, sequence string }
+	var serials []serialColumn
+	for rows.Next() {
+		var s serialColumn
+		var sequence sql.NullString
+		if err := rows.Scan(&s.table, &s.column, &sequence); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan sequence: %w", err)
+		}
+		if sequence.Valid {
+			s.sequence = sequence.String
+			serials = append(serials, s)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list sequences: %w", err)
+	}
+
+	for _, s := range serials {
+		column := pq.QuoteIdentifier(s.column)
+		if _, err := tx.ExecContext(ctx,
+			`SELECT setval($1, COALESCE((SELECT MAX(`+column+`) FROM `+pq.QuoteIdentifier(s.table)+`), 0) + 1, false)`,
+			s.sequence,
+		); err != nil {
+			return fmt.Errorf("failed to reset sequence %s: %w", s.sequence, err)
+		}
+	}
+	return nil
+}
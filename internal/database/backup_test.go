@@ -0,0 +1,179 @@
+package database
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// gzipLines は lines を改行区切りで連結し gzip 圧縮したバックアップファイルの内容を返す。
+func gzipLines(t *testing.T, lines ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(strings.Join(lines, "\n"))); err != nil {
+		t.Fatalf("gzip write error: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSortTablesByDependency(t *testing.T) {
+	tables := []string{"feeds", "item_states", "items", "subscriptions", "users"}
+	deps := map[string][]string{
+		"item_states":   {"users", "items"},
+		"items":         {"feeds"},
+		"subscriptions": {"users", "feeds", "subscriptions"},
+		"feeds":         {"unknown"},
+	}
+
+	got := sortTablesByDependency(tables, deps)
+
+	want := []string{"feeds", "items", "users", "item_states", "subscriptions"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortTablesByDependency() = %v, want %v", got, want)
+	}
+}
+
+func TestOpenBackup(t *testing.T) {
+	t.Run("ヘッダーとレコードを順に読み出す", func(t *testing.T) {
+		// Arrange
+		data := gzipLines(t,
+			`{"format":"feedman-backup","format_version":1,"schema_version":20260716120000,"created_at":"2026-07-16T12:00:00Z","tables":["users"]}`,
+			`{"table":"users","row":{"id":"u1","email":"a@example.com"}}`,
+			`{"table":"users","row":{"id":"u2","email":"b@example.com"}}`,
+		)
+
+		// Act
+		b, err := OpenBackup(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("OpenBackup() error = %v", err)
+		}
+		defer b.Close()
+
+		// Assert
+		if got := b.Header().SchemaVersion; got != 20260716120000 {
+			t.Errorf("SchemaVersion = %d, want 20260716120000", got)
+		}
+		var ids []string
+		for {
+			rec, err := b.next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("next() error = %v", err)
+			}
+			if rec.Table != "users" {
+				t.Errorf("Table = %q, want users", rec.Table)
+			}
+			ids = append(ids, string(rec.Row))
+		}
+		if len(ids) != 2 {
+			t.Errorf("records = %d, want 2", len(ids))
+		}
+	})
+
+	tests := []struct {
+		name string
+		data func(t *testing.T) []byte
+	}{
+		{name: "gzipでないときエラーを返す", data: func(t *testing.T) []byte { return []byte(`{"format":"feedman-backup"}`) }},
+		{name: "形式名が異なるときエラーを返す", data: func(t *testing.T) []byte {
+			return gzipLines(t, `{"format":"other","format_version":1}`)
+		}},
+		{name: "未知の形式バージョンのときエラーを返す", data: func(t *testing.T) []byte {
+			return gzipLines(t, `{"format":"feedman-backup","format_version":99}`)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := OpenBackup(bytes.NewReader(tt.data(t))); err == nil {
+				t.Error("OpenBackup() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestRestoreInsertQuery(t *testing.T) {
+	got := restoreInsertQuery("items", []string{"feed_id", "id"})
+	want := `INSERT INTO "items" ("feed_id", "id") SELECT "feed_id", "id" FROM json_populate_record(NULL::"items", $1::json)`
+	if got != want {
+		t.Errorf("restoreInsertQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestBackupRestore_RoundTrip(t *testing.T) {
+	db, dbURL := setupTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	if err := RunMigrations(dbURL); err != nil {
+		t.Fatalf("マイグレーション実行に失敗: %v", err)
+	}
+	if err := Seed(ctx, db, SeedOptions{}); err != nil {
+		t.Fatalf("デモデータの投入に失敗: %v", err)
+	}
+	var wantItems int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM items`).Scan(&wantItems); err != nil {
+		t.Fatalf("記事数の取得に失敗: %v", err)
+	}
+	version, err := SchemaVersion(dbURL)
+	if err != nil {
+		t.Fatalf("スキーマバージョンの取得に失敗: %v", err)
+	}
+
+	// Act: バックアップ後に空のデータベースへ復元する
+	var buf bytes.Buffer
+	if err := Backup(ctx, db, &buf, version); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	db2, _ := setupTestDB(t)
+	defer db2.Close()
+	if err := MigrateUpTo(dbURL, version); err != nil {
+		t.Fatalf("MigrateUpTo() error = %v", err)
+	}
+	b, err := OpenBackup(&buf)
+	if err != nil {
+		t.Fatalf("OpenBackup() error = %v", err)
+	}
+	defer b.Close()
+	result, err := b.Restore(ctx, db2)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if result["items"] != wantItems {
+		t.Errorf("restored items = %d, want %d", result["items"], wantItems)
+	}
+	var gotItems int
+	if err := db2.QueryRow(`SELECT COUNT(*) FROM items`).Scan(&gotItems); err != nil {
+		t.Fatalf("記事数の取得に失敗: %v", err)
+	}
+	if gotItems != wantItems {
+		t.Errorf("items = %d, want %d", gotItems, wantItems)
+	}
+
+	t.Run("空でないデータベースへの復元を拒否する", func(t *testing.T) {
+		var again bytes.Buffer
+		if err := Backup(ctx, db2, &again, version); err != nil {
+			t.Fatalf("Backup() error = %v", err)
+		}
+		b, err := OpenBackup(&again)
+		if err != nil {
+			t.Fatalf("OpenBackup() error = %v", err)
+		}
+		defer b.Close()
+		if _, err := b.Restore(ctx, db2); !errors.Is(err, ErrBackupTargetNotEmpty) {
+			t.Errorf("Restore() error = %v, want ErrBackupTargetNotEmpty", err)
+		}
+	})
+}
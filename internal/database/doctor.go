@@ -0,0 +1,299 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExpectedTable はテーブルに期待する列・制約・インデックスを表す。
+// doctor コマンドが稼働中の DB と突き合わせる期待スキーマの単位。
+type ExpectedTable struct {
+	// Name はテーブル名。
+	Name string
+	// Columns は列名から information_schema.columns.data_type への対応。
+	Columns map[string]string
+	// NotNull は NOT NULL 制約を期待する列。
+	NotNull []string
+	// PrimaryKey はプライマリキーの列（複合キーの場合は構成するすべての列）。
+	PrimaryKey []string
+	// Unique はユニーク制約（またはユニークインデックス）を期待する列の組み合わせ。
+	Unique [][]string
+	// ForeignKeys は期待する外部キー制約。
+	ForeignKeys []ExpectedForeignKey
+	// Indexes はインデックス定義に含まれることを期待する列。
+	Indexes []string
+	// PartialIndexes は期待する部分インデックス。
+	PartialIndexes []ExpectedPartialIndex
+}
+
+// ExpectedForeignKey は期待する外部キー制約を表す。
+type ExpectedForeignKey struct {
+	Column     string
+	RefTable   string
+	RefColumn  string
+	DeleteRule string
+}
+
+// ExpectedPartialIndex は期待する部分インデックスを表す。
+// Columns をインデックス定義に含み、WHERE 句が WhereColumn を参照するインデックスがあれば一致とみなす。
+type ExpectedPartialIndex struct {
+	Columns     []string
+	WhereColumn string
+	Unique      bool
+}
+
+// CheckSchema は db の現在のスキーマ（search_path の先頭）が expected と一致しているかを検査し、
+// 不一致を人が読める形で列挙して返す。一致している場合は空のスライスを返す。
+// 検査のみを行い、スキーマは変更しない。クエリの実行に失敗した場合はエラーを返す。
+func CheckSchema(ctx context.Context, db *sql.DB, expected []ExpectedTable) ([]string, error) {
+	var problems []string
+	for _, table := range expected {
+		p, err := checkTable(ctx, db, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check table %s: %w", table.Name, err)
+		}
+		problems = append(problems, p...)
+	}
+	return problems, nil
+}
+
+// checkTable は 1 テーブル分の列・制約・インデックスを検査する。
+// テーブル自体が存在しない場合は、それ以降の検査を省いて 1 件の不一致として返す。
+func checkTable(ctx context.Context, db *sql.DB, table ExpectedTable) ([]string, error) {
+	columns, nullable, err := loadColumns(ctx, db, table.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return []string{fmt.Sprintf("%s テーブルが存在しません", table.Name)}, nil
+	}
+
+	var problems []string
+	for _, col := range sortedKeys(table.Columns) {
+		want := table.Columns[col]
+		got, ok := columns[col]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s.%s カラムが存在しません", table.Name, col))
+			continue
+		}
+		if got != want {
+			problems = append(problems, fmt.Sprintf("%s.%s のデータ型が不正: got %q, want %q", table.Name, col, got, want))
+		}
+	}
+	for _, col := range table.NotNull {
+		if isNullable, ok := nullable[col]; ok && isNullable != "NO" {
+			problems = append(problems, fmt.Sprintf("%s.%s にNOT NULL制約が設定されていません", table.Name, col))
+		}
+	}
+
+	for _, col := range table.PrimaryKey {
+		ok, err := hasPrimaryKey(ctx, db, table.Name, col)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s.%s にプライマリキーが設定されていません", table.Name, col))
+		}
+	}
+	for _, cols := range table.Unique {
+		ok, err := hasUniqueConstraint(ctx, db, table.Name, cols)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s テーブルに %v のユニーク制約が設定されていません", table.Name, cols))
+		}
+	}
+	for _, fk := range table.ForeignKeys {
+		ok, err := hasForeignKey(ctx, db, table.Name, fk)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s.%s -> %s.%s の外部キー制約（ON DELETE %s）が設定されていません",
+				table.Name, fk.Column, fk.RefTable, fk.RefColumn, fk.DeleteRule))
+		}
+	}
+	for _, col := range table.Indexes {
+		ok, err := hasIndex(ctx, db, table.Name, []string{col}, "", false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s.%s にインデックスが設定されていません", table.Name, col))
+		}
+	}
+	for _, idx := range table.PartialIndexes {
+		ok, err := hasIndex(ctx, db, table.Name, idx.Columns, idx.WhereColumn, idx.Unique)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			kind := "部分インデックス"
+			if idx.Unique {
+				kind = "部分ユニークインデックス"
+			}
+			problems = append(problems, fmt.Sprintf("%s テーブルに %v の%s（WHERE %s）が設定されていません",
+				table.Name, idx.Columns, kind, idx.WhereColumn))
+		}
+	}
+	return problems, nil
+}
+
+// loadColumns は table の列名からデータ型・NULL 許容（information_schema の is_nullable）への対応を返す。
+func loadColumns(ctx context.Context, db *sql.DB, table string) (map[string]string, map[string]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT column_name, data_type, is_nullable FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+	`, table)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+	defer rows.Close()
+
+	types := make(map[string]string)
+	nullable := make(map[string]string)
+	for rows.Next() {
+		var name, dtype, isNullable string
+		if err := rows.Scan(&name, &dtype, &isNullable); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		types[name] = dtype
+		nullable[name] = isNullable
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate columns: %w", err)
+	}
+	return types, nullable, nil
+}
+
+// hasPrimaryKey は table の column がプライマリキー（複合キーの一部を含む）に含まれるかを返す。
+func hasPrimaryKey(ctx context.Context, db *sql.DB, table, column string) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `
+		SELECT count(*) FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON tc.constraint_name = kcu.constraint_name
+			AND tc.table_schema = kcu.table_schema
+		WHERE tc.constraint_type = 'PRIMARY KEY'
+			AND tc.table_schema = current_schema()
+			AND tc.table_name = $1
+			AND kcu.column_name = $2
+	`, table, column).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to query primary key: %w", err)
+	}
+	return count > 0, nil
+}
+
+// hasUniqueConstraint は table に columns の組み合わせ（順序も一致）のユニーク制約または
+// ユニークインデックスがあるかを返す。
+func hasUniqueConstraint(ctx context.Context, db *sql.DB, table string, columns []string) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `
+		SELECT count(*) FROM (
+			SELECT i.relname
+			FROM pg_index ix
+			JOIN pg_class t ON t.oid = ix.indrelid
+			JOIN pg_class i ON i.oid = ix.indexrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			WHERE t.relname = $1
+				AND n.nspname = current_schema()
+				AND ix.indisunique = true
+				AND ix.indisprimary = false
+				AND (
+					SELECT array_agg(a.attname::text ORDER BY array_position(ix.indkey, a.attnum))
+					FROM pg_attribute a
+					WHERE a.attrelid = t.oid AND a.attnum = ANY(ix.indkey)
+				) = $2::text[]
+		) sub
+	`, table, "{"+strings.Join(columns, ",")+"}").Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to query unique constraint: %w", err)
+	}
+	return count > 0, nil
+}
+
+// hasForeignKey は table に fk の外部キー制約（削除時の動作を含む）があるかを返す。
+func hasForeignKey(ctx context.Context, db *sql.DB, table string, fk ExpectedForeignKey) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `
+		SELECT count(*) FROM information_schema.referential_constraints rc
+		JOIN information_schema.key_column_usage kcu
+			ON rc.constraint_name = kcu.constraint_name
+			AND rc.constraint_schema = kcu.constraint_schema
+		JOIN information_schema.constraint_column_usage ccu
+			ON rc.unique_constraint_name = ccu.constraint_name
+			AND rc.unique_constraint_schema = ccu.constraint_schema
+		WHERE kcu.table_schema = current_schema()
+			AND kcu.table_name = $1
+			AND kcu.column_name = $2
+			AND ccu.table_name = $3
+			AND ccu.column_name = $4
+			AND rc.delete_rule = $5
+	`, table, fk.Column, fk.RefTable, fk.RefColumn, fk.DeleteRule).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to query foreign key: %w", err)
+	}
+	return count > 0, nil
+}
+
+// hasIndex は table に columns をすべて定義に含むインデックスがあるかを返す。
+// whereColumn を指定した場合は WHERE 句がその列を参照する部分インデックスに、
+// unique が true の場合はユニークインデックスに限る。
+func hasIndex(ctx context.Context, db *sql.DB, table string, columns []string, whereColumn string, unique bool) (bool, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT indexdef FROM pg_indexes
+		WHERE schemaname = current_schema() AND tablename = $1
+	`, table)
+	if err != nil {
+		return false, fmt.Errorf("failed to query indexes: %w", err)
+	}
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		var def string
+		if err := rows.Scan(&def); err != nil {
+			return false, fmt.Errorf("failed to scan index: %w", err)
+		}
+		if !found && indexDefMatches(def, columns, whereColumn, unique) {
+			found = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("failed to iterate indexes: %w", err)
+	}
+	return found, nil
+}
+
+// indexDefMatches は pg_indexes.indexdef の定義文が条件を満たすかを返す。
+// 判定は migrate_test.go のアサーションと同じく定義文の部分一致で行う。
+func indexDefMatches(def string, columns []string, whereColumn string, unique bool) bool {
+	if unique && !strings.Contains(def, "UNIQUE") {
+		return false
+	}
+	body, where, hasWhere := strings.Cut(def, " WHERE ")
+	for _, col := range columns {
+		if !strings.Contains(body, col) {
+			return false
+		}
+	}
+	if whereColumn == "" {
+		return true
+	}
+	return hasWhere && strings.Contains(where, whereColumn)
+}
+
+// sortedKeys は m のキーを辞書順で返す。不一致の一覧を実行ごとに同じ順序で出力するために使う。
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIndexDefMatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		def         string
+		columns     []string
+		whereColumn string
+		unique      bool
+		want        bool
+	}{
+		{
+			name:    "列を含むインデックスのとき一致する",
+			def:     "CREATE INDEX idx_sessions_user_id ON public.sessions USING btree (user_id)",
+			columns: []string{"user_id"},
+			want:    true,
+		},
+		{
+			name:    "列を含まないインデックスのとき一致しない",
+			def:     "CREATE INDEX idx_sessions_expires_at ON public.sessions USING btree (expires_at)",
+			columns: []string{"user_id"},
+			want:    false,
+		},
+		{
+			name:        "WHERE 句が列を参照する部分インデックスのとき一致する",
+			def:         "CREATE INDEX idx_feeds_next_fetch_at_active ON public.feeds USING btree (next_fetch_at) WHERE ((fetch_status)::text = 'active'::text)",
+			columns:     []string{"next_fetch_at"},
+			whereColumn: "fetch_status",
+			want:        true,
+		},
+		{
+			name:        "WHERE 句が無いインデックスのとき部分インデックスとして一致しない",
+			def:         "CREATE INDEX idx_feeds_next_fetch_at ON public.feeds USING btree (next_fetch_at, fetch_status)",
+			columns:     []string{"next_fetch_at"},
+			whereColumn: "fetch_status",
+			want:        false,
+		},
+		{
+			name:        "ユニークでないインデックスのときユニークインデックスとして一致しない",
+			def:         "CREATE INDEX idx_items_feed_guid ON public.items USING btree (feed_id, guid_or_id) WHERE (guid_or_id IS NOT NULL)",
+			columns:     []string{"feed_id", "guid_or_id"},
+			whereColumn: "guid_or_id",
+			unique:      true,
+			want:        false,
+		},
+		{
+			name:        "部分ユニークインデックスのとき一致する",
+			def:         "CREATE UNIQUE INDEX idx_items_feed_guid ON public.items USING btree (feed_id, guid_or_id) WHERE (guid_or_id IS NOT NULL)",
+			columns:     []string{"feed_id", "guid_or_id"},
+			whereColumn: "guid_or_id",
+			unique:      true,
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := indexDefMatches(tt.def, tt.columns, tt.whereColumn, tt.unique); got != tt.want {
+				t.Errorf("indexDefMatches(%q) = %v, want %v", tt.def, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheckSchema_MigratedDatabase はマイグレーション適用直後の DB が期待スキーマと一致することを検証する。
+func TestCheckSchema_MigratedDatabase(t *testing.T) {
+	db, dbURL := setupTestDB(t)
+	defer db.Close()
+
+	if err := RunMigrations(dbURL); err != nil {
+		t.Fatalf("マイグレーション実行に失敗: %v", err)
+	}
+
+	problems, err := CheckSchema(context.Background(), db, ExpectedSchema)
+	if err != nil {
+		t.Fatalf("CheckSchema に失敗: %v", err)
+	}
+	for _, p := range problems {
+		t.Errorf("期待スキーマとの不一致: %s", p)
+	}
+}
+
+// TestCheckSchema_ReportsMismatch はインデックスの欠落や列の型の相違を不一致として列挙することを検証する。
+func TestCheckSchema_ReportsMismatch(t *testing.T) {
+	db, dbURL := setupTestDB(t)
+	defer db.Close()
+
+	if err := RunMigrations(dbURL); err != nil {
+		t.Fatalf("マイグレーション実行に失敗: %v", err)
+	}
+	if _, err := db.Exec(`DROP INDEX idx_sessions_expires_at`); err != nil {
+		t.Fatalf("インデックスの削除に失敗: %v", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE user_settings ALTER COLUMN theme TYPE text`); err != nil {
+		t.Fatalf("列の型の変更に失敗: %v", err)
+	}
+
+	problems, err := CheckSchema(context.Background(), db, ExpectedSchema)
+	if err != nil {
+		t.Fatalf("CheckSchema に失敗: %v", err)
+	}
+
+	joined := strings.Join(problems, "\n")
+	for _, want := range []string{
+		"sessions.expires_at にインデックスが設定されていません",
+		`user_settings.theme のデータ型が不正: got "text", want "character varying"`,
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("不一致の一覧に %q が含まれていません: %v", want, problems)
+		}
+	}
+	if len(problems) != 2 {
+		t.Errorf("不一致の件数 = %d, want 2: %v", len(problems), problems)
+	}
+}
+
+// TestCheckSchema_MissingTable はテーブルが存在しないとき 1 件の不一致として報告することを検証する。
+func TestCheckSchema_MissingTable(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	problems, err := CheckSchema(context.Background(), db, ExpectedSchema[:1])
+	if err != nil {
+		t.Fatalf("CheckSchema に失敗: %v", err)
+	}
+	if len(problems) != 1 || problems[0] != "users テーブルが存在しません" {
+		t.Errorf("problems = %v, want [users テーブルが存在しません]", problems)
+	}
+}
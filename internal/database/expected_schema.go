@@ -0,0 +1,677 @@
+package database
+
+// PostgreSQL の information_schema.columns.data_type での型名。
+const (
+	typeUUID        = "uuid"
+	typeVarchar     = "character varying"
+	typeChar        = "character"
+	typeText        = "text"
+	typeTimestamptz = "timestamp with time zone"
+	typeDate        = "date"
+	typeBoolean     = "boolean"
+	typeSmallint    = "smallint"
+	typeInteger     = "integer"
+	typeBigint      = "bigint"
+	typeDouble      = "double precision"
+	typeBytea       = "bytea"
+	typeJSONB       = "jsonb"
+	typeArray       = "ARRAY"
+)
+
+// cascadeTo は参照先の id 列への ON DELETE CASCADE の外部キーを返す。
+func cascadeTo(column, refTable string) ExpectedForeignKey {
+	return ExpectedForeignKey{Column: column, RefTable: refTable, RefColumn: "id", DeleteRule: "CASCADE"}
+}
+
+// setNullTo は参照先の id 列への ON DELETE SET NULL の外部キーを返す。
+func setNullTo(column, refTable string) ExpectedForeignKey {
+	return ExpectedForeignKey{Column: column, RefTable: refTable, RefColumn: "id", DeleteRule: "SET NULL"}
+}
+
+// ExpectedSchema はマイグレーション適用後の全テーブルの期待スキーマ。
+// doctor コマンドと migrate_test.go のテーブル検証がともにこの定義を参照する。
+// マイグレーションでテーブル・列・制約・インデックスを追加・変更した場合はここも更新すること
+// （テーブルの追加漏れは migrate_test.go の TestExpectedSchema_CoversAllTables で検出する）。
+var ExpectedSchema = []ExpectedTable{
+	{
+		Name: "users",
+		Columns: map[string]string{
+			"id":                       typeUUID,
+			"email":                    typeVarchar,
+			"name":                     typeVarchar,
+			"created_at":               typeTimestamptz,
+			"updated_at":               typeTimestamptz,
+			"subscriptions_changed_at": typeTimestamptz,
+			"last_active_at":           typeTimestamptz,
+		},
+		NotNull:    []string{"id", "email", "name", "created_at", "updated_at", "subscriptions_changed_at", "last_active_at"},
+		PrimaryKey: []string{"id"},
+	},
+	{
+		Name: "identities",
+		Columns: map[string]string{
+			"id":               typeUUID,
+			"user_id":          typeUUID,
+			"provider":         typeVarchar,
+			"provider_user_id": typeVarchar,
+			"created_at":       typeTimestamptz,
+		},
+		NotNull:     []string{"id", "user_id", "provider", "provider_user_id", "created_at"},
+		PrimaryKey:  []string{"id"},
+		Unique:      [][]string{{"provider", "provider_user_id"}},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+		Indexes:     []string{"user_id"},
+	},
+	{
+		Name: "feeds",
+		Columns: map[string]string{
+			"id":                       typeUUID,
+			"feed_url":                 typeText,
+			"site_url":                 typeText,
+			"title":                    typeVarchar,
+			"favicon_data":             typeBytea,
+			"favicon_mime":             typeVarchar,
+			"etag":                     typeVarchar,
+			"last_modified":            typeVarchar,
+			"fetch_status":             typeVarchar,
+			"consecutive_errors":       typeInteger,
+			"error_message":            typeText,
+			"error_kind":               typeVarchar,
+			"error_detail":             typeJSONB,
+			"http_version":             typeText,
+			"next_fetch_at":            typeTimestamptz,
+			"last_successful_fetch_at": typeTimestamptz,
+			"language":                 typeVarchar,
+			"description":              typeText,
+			"last_published_at":        typeTimestamptz,
+			"ignore_conditional_get":   typeBoolean,
+			"capture_raw_response":     typeBoolean,
+			"suggested_feed_url":       typeText,
+			"copyright":                typeText,
+			"ttl_minutes":              typeInteger,
+			"robots":                   typeText,
+			"created_at":               typeTimestamptz,
+			"updated_at":               typeTimestamptz,
+		},
+		NotNull: []string{
+			"id", "feed_url", "title", "fetch_status", "consecutive_errors", "next_fetch_at",
+			"ignore_conditional_get", "capture_raw_response", "created_at", "updated_at",
+		},
+		PrimaryKey: []string{"id"},
+		Unique:     [][]string{{"feed_url"}},
+		// サイトのホスト（feed_host(COALESCE(NULLIF(site_url, ''), feed_url))）の式インデックス
+		Indexes: []string{"site_url"},
+		PartialIndexes: []ExpectedPartialIndex{
+			{Columns: []string{"next_fetch_at"}, WhereColumn: "fetch_status"},
+			{Columns: []string{"error_kind"}, WhereColumn: "error_kind"},
+		},
+	},
+	{
+		Name: "items",
+		Columns: map[string]string{
+			"id":                   typeUUID,
+			"feed_id":              typeUUID,
+			"guid_or_id":           typeVarchar,
+			"link":                 typeText,
+			"link_status":          typeText,
+			"link_checked_at":      typeTimestamptz,
+			"title":                typeVarchar,
+			"content":              typeText,
+			"content_text":         typeText,
+			"summary":              typeText,
+			"generated_summary":    typeText,
+			"generated_summary_at": typeTimestamptz,
+			"author":               typeVarchar,
+			"series_key":           typeText,
+			"thumbnail_url":        typeText,
+			"reading_time_minutes": typeInteger,
+			"published_at":         typeTimestamptz,
+			"is_date_estimated":    typeBoolean,
+			"fetched_at":           typeTimestamptz,
+			"content_hash":         typeVarchar,
+			"hatebu_count":         typeInteger,
+			"hatebu_fetched_at":    typeTimestamptz,
+			"created_at":           typeTimestamptz,
+			"updated_at":           typeTimestamptz,
+		},
+		NotNull: []string{
+			"id", "feed_id", "title", "content_text", "thumbnail_url", "reading_time_minutes",
+			"is_date_estimated", "fetched_at", "hatebu_count", "created_at", "updated_at",
+		},
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("feed_id", "feeds")},
+		Indexes:     []string{"feed_id", "published_at", "link", "content_hash", "title"},
+		PartialIndexes: []ExpectedPartialIndex{
+			{Columns: []string{"feed_id", "guid_or_id"}, WhereColumn: "guid_or_id", Unique: true},
+			{Columns: []string{"hatebu_fetched_at"}, WhereColumn: "hatebu_fetched_at"},
+			{Columns: []string{"content"}, WhereColumn: "content"},
+			{Columns: []string{"feed_id", "author"}, WhereColumn: "author"},
+			{Columns: []string{"feed_id", "series_key"}, WhereColumn: "series_key"},
+		},
+	},
+	{
+		Name: "subscriptions",
+		Columns: map[string]string{
+			"id":                          typeUUID,
+			"user_id":                     typeUUID,
+			"feed_id":                     typeUUID,
+			"team_id":                     typeUUID,
+			"fetch_interval_minutes":      typeInteger,
+			"is_public":                   typeBoolean,
+			"is_pinned":                   typeBoolean,
+			"sort_order":                  typeInteger,
+			"import_filter_authors":       typeArray,
+			"import_filter_title_pattern": typeText,
+			"retention_override":          typeInteger,
+			"priority":                    typeText,
+			"mute_until":                  typeTimestamptz,
+			"expires_at":                  typeTimestamptz,
+			"title_update_policy":         typeText,
+			"display_title":               typeText,
+			"pending_title":               typeText,
+			"created_at":                  typeTimestamptz,
+			"updated_at":                  typeTimestamptz,
+		},
+		NotNull: []string{
+			"id", "user_id", "feed_id", "fetch_interval_minutes", "is_public", "is_pinned", "sort_order",
+			"import_filter_authors", "import_filter_title_pattern", "priority", "title_update_policy",
+			"created_at", "updated_at",
+		},
+		PrimaryKey: []string{"id"},
+		Unique:     [][]string{{"user_id", "feed_id"}},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("user_id", "users"),
+			cascadeTo("feed_id", "feeds"),
+			setNullTo("team_id", "teams"),
+		},
+		Indexes: []string{"user_id", "feed_id"},
+		PartialIndexes: []ExpectedPartialIndex{
+			{Columns: []string{"team_id"}, WhereColumn: "team_id"},
+			{Columns: []string{"expires_at"}, WhereColumn: "expires_at"},
+		},
+	},
+	{
+		Name: "item_states",
+		Columns: map[string]string{
+			"id":                 typeUUID,
+			"user_id":            typeUUID,
+			"item_id":            typeUUID,
+			"is_read":            typeBoolean,
+			"is_starred":         typeBoolean,
+			"is_hidden":          typeBoolean,
+			"rating":             typeSmallint,
+			"read_at":            typeTimestamptz,
+			"starred_at":         typeTimestamptz,
+			"hidden_at":          typeTimestamptz,
+			"read_changed_at":    typeTimestamptz,
+			"starred_changed_at": typeTimestamptz,
+			"last_visited_at":    typeTimestamptz,
+			"created_at":         typeTimestamptz,
+			"updated_at":         typeTimestamptz,
+		},
+		NotNull:    []string{"id", "user_id", "item_id", "is_read", "is_starred", "is_hidden", "rating", "created_at", "updated_at"},
+		PrimaryKey: []string{"id"},
+		Unique:     [][]string{{"user_id", "item_id"}},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("user_id", "users"),
+			cascadeTo("item_id", "items"),
+		},
+		Indexes: []string{"updated_at"},
+		PartialIndexes: []ExpectedPartialIndex{
+			{Columns: []string{"user_id", "is_read"}, WhereColumn: "is_read"},
+			{Columns: []string{"user_id", "is_starred"}, WhereColumn: "is_starred"},
+			{Columns: []string{"user_id", "rating"}, WhereColumn: "rating"},
+			{Columns: []string{"item_id"}, WhereColumn: "is_starred"},
+		},
+	},
+	{
+		Name: "user_settings",
+		Columns: map[string]string{
+			"id":                       typeUUID,
+			"user_id":                  typeUUID,
+			"theme":                    typeVarchar,
+			"public_profile":           typeBoolean,
+			"public_slug":              typeVarchar,
+			"unread_warning_threshold": typeInteger,
+			"open_links_in_new_tab":    typeBoolean,
+			"prefetch_enabled":         typeBoolean,
+			"timezone":                 typeText,
+			"updated_at":               typeTimestamptz,
+		},
+		NotNull:     []string{"id", "user_id", "theme", "public_profile", "open_links_in_new_tab", "prefetch_enabled", "updated_at"},
+		PrimaryKey:  []string{"id"},
+		Unique:      [][]string{{"user_id"}},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+		PartialIndexes: []ExpectedPartialIndex{
+			{Columns: []string{"public_slug"}, WhereColumn: "public_slug", Unique: true},
+		},
+	},
+	{
+		Name: "sessions",
+		Columns: map[string]string{
+			"id":          typeVarchar,
+			"user_id":     typeUUID,
+			"identity_id": typeUUID,
+			"data":        typeBytea,
+			"expires_at":  typeTimestamptz,
+			"created_at":  typeTimestamptz,
+		},
+		NotNull:     []string{"id", "user_id", "data", "expires_at", "created_at"},
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+		Indexes:     []string{"expires_at", "user_id"},
+	},
+	{
+		Name: "user_cross_feed_views",
+		Columns: map[string]string{
+			"user_id":      typeUUID,
+			"last_seen_at": typeTimestamptz,
+			"updated_at":   typeTimestamptz,
+		},
+		NotNull:     []string{"user_id", "last_seen_at", "updated_at"},
+		PrimaryKey:  []string{"user_id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+	},
+	{
+		Name: "item_views",
+		Columns: map[string]string{
+			"id":        typeBigint,
+			"user_id":   typeUUID,
+			"item_id":   typeUUID,
+			"feed_id":   typeUUID,
+			"viewed_at": typeTimestamptz,
+		},
+		NotNull:    []string{"id", "user_id", "item_id", "feed_id", "viewed_at"},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("user_id", "users"),
+			cascadeTo("item_id", "items"),
+			cascadeTo("feed_id", "feeds"),
+		},
+		Indexes: []string{"viewed_at", "item_id"},
+	},
+	{
+		Name: "subscription_undos",
+		Columns: map[string]string{
+			"subscription_id": typeUUID,
+			"user_id":         typeUUID,
+			"feed_id":         typeUUID,
+			"subscription":    typeJSONB,
+			"item_states":     typeJSONB,
+			"expires_at":      typeTimestamptz,
+			"created_at":      typeTimestamptz,
+		},
+		NotNull:    []string{"subscription_id", "user_id", "feed_id", "subscription", "item_states", "expires_at", "created_at"},
+		PrimaryKey: []string{"subscription_id"},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("user_id", "users"),
+			cascadeTo("feed_id", "feeds"),
+		},
+		Indexes: []string{"expires_at"},
+	},
+	{
+		Name: "fetch_attempts",
+		Columns: map[string]string{
+			"id":           typeBigint,
+			"feed_id":      typeUUID,
+			"succeeded":    typeBoolean,
+			"duration_ms":  typeInteger,
+			"attempted_at": typeTimestamptz,
+		},
+		NotNull:     []string{"id", "feed_id", "succeeded", "duration_ms", "attempted_at"},
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("feed_id", "feeds")},
+		Indexes:     []string{"attempted_at", "feed_id"},
+	},
+	{
+		Name: "audit_logs",
+		Columns: map[string]string{
+			"id":         typeUUID,
+			"user_id":    typeUUID,
+			"action":     typeText,
+			"target":     typeText,
+			"payload":    typeJSONB,
+			"created_at": typeTimestamptz,
+		},
+		NotNull:     []string{"id", "user_id", "action", "target", "payload", "created_at"},
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+		Indexes:     []string{"user_id"},
+	},
+	{
+		Name: "teams",
+		Columns: map[string]string{
+			"id":         typeUUID,
+			"name":       typeText,
+			"created_at": typeTimestamptz,
+			"updated_at": typeTimestamptz,
+		},
+		NotNull:    []string{"id", "name", "created_at", "updated_at"},
+		PrimaryKey: []string{"id"},
+	},
+	{
+		Name: "team_members",
+		Columns: map[string]string{
+			"team_id":   typeUUID,
+			"user_id":   typeUUID,
+			"role":      typeText,
+			"joined_at": typeTimestamptz,
+		},
+		NotNull:    []string{"team_id", "user_id", "role", "joined_at"},
+		PrimaryKey: []string{"team_id", "user_id"},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("team_id", "teams"),
+			cascadeTo("user_id", "users"),
+		},
+		Indexes: []string{"user_id"},
+	},
+	{
+		Name: "team_feeds",
+		Columns: map[string]string{
+			"team_id":    typeUUID,
+			"feed_id":    typeUUID,
+			"added_by":   typeUUID,
+			"created_at": typeTimestamptz,
+		},
+		NotNull:    []string{"team_id", "feed_id", "created_at"},
+		PrimaryKey: []string{"team_id", "feed_id"},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("team_id", "teams"),
+			cascadeTo("feed_id", "feeds"),
+			setNullTo("added_by", "users"),
+		},
+	},
+	{
+		Name: "team_invitations",
+		Columns: map[string]string{
+			"id":          typeUUID,
+			"team_id":     typeUUID,
+			"token_hash":  typeText,
+			"invited_by":  typeUUID,
+			"expires_at":  typeTimestamptz,
+			"accepted_by": typeUUID,
+			"accepted_at": typeTimestamptz,
+			"created_at":  typeTimestamptz,
+		},
+		NotNull:    []string{"id", "team_id", "token_hash", "expires_at", "created_at"},
+		PrimaryKey: []string{"id"},
+		Unique:     [][]string{{"token_hash"}},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("team_id", "teams"),
+			setNullTo("invited_by", "users"),
+			setNullTo("accepted_by", "users"),
+		},
+		Indexes: []string{"team_id"},
+	},
+	{
+		Name: "worker_cycles",
+		Columns: map[string]string{
+			"id":              typeBigint,
+			"started_at":      typeTimestamptz,
+			"finished_at":     typeTimestamptz,
+			"feed_count":      typeInteger,
+			"succeeded_count": typeInteger,
+			"failed_count":    typeInteger,
+			"items_inserted":  typeInteger,
+			"items_updated":   typeInteger,
+			"duration_ms":     typeInteger,
+		},
+		NotNull: []string{
+			"id", "started_at", "finished_at", "feed_count", "succeeded_count", "failed_count",
+			"items_inserted", "items_updated", "duration_ms",
+		},
+		PrimaryKey: []string{"id"},
+		Indexes:    []string{"started_at"},
+	},
+	{
+		Name: "idempotency_keys",
+		Columns: map[string]string{
+			"user_id":       typeUUID,
+			"key":           typeText,
+			"request_hash":  typeText,
+			"status_code":   typeInteger,
+			"content_type":  typeText,
+			"response_body": typeBytea,
+			"created_at":    typeTimestamptz,
+			"expires_at":    typeTimestamptz,
+		},
+		NotNull:     []string{"user_id", "key", "request_hash", "created_at", "expires_at"},
+		PrimaryKey:  []string{"user_id", "key"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+	},
+	{
+		Name: "weekly_subscription_stats",
+		Columns: map[string]string{
+			"user_id":      typeUUID,
+			"feed_id":      typeUUID,
+			"week_start":   typeTimestamptz,
+			"new_items":    typeInteger,
+			"read_items":   typeInteger,
+			"hidden_items": typeInteger,
+			"created_at":   typeTimestamptz,
+		},
+		NotNull:    []string{"user_id", "feed_id", "week_start", "new_items", "read_items", "hidden_items", "created_at"},
+		PrimaryKey: []string{"user_id", "week_start", "feed_id"},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("user_id", "users"),
+			cascadeTo("feed_id", "feeds"),
+		},
+		Indexes: []string{"week_start"},
+	},
+	{
+		Name: "integrations",
+		Columns: map[string]string{
+			"id":                typeUUID,
+			"user_id":           typeUUID,
+			"subscription_id":   typeUUID,
+			"kind":              typeText,
+			"webhook_url":       typeText,
+			"message_template":  typeText,
+			"enabled":           typeBoolean,
+			"last_error":        typeText,
+			"last_delivered_at": typeTimestamptz,
+			"created_at":        typeTimestamptz,
+			"updated_at":        typeTimestamptz,
+		},
+		NotNull: []string{
+			"id", "user_id", "subscription_id", "kind", "webhook_url", "message_template", "enabled",
+			"created_at", "updated_at",
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("user_id", "users"),
+			cascadeTo("subscription_id", "subscriptions"),
+		},
+		Indexes: []string{"user_id", "subscription_id"},
+	},
+	{
+		Name: "integration_deliveries",
+		Columns: map[string]string{
+			"id":              typeBigint,
+			"integration_id":  typeUUID,
+			"item_id":         typeUUID,
+			"status":          typeText,
+			"attempts":        typeInteger,
+			"next_attempt_at": typeTimestamptz,
+			"last_error":      typeText,
+			"sent_at":         typeTimestamptz,
+			"created_at":      typeTimestamptz,
+		},
+		NotNull:    []string{"id", "integration_id", "item_id", "status", "attempts", "next_attempt_at", "created_at"},
+		PrimaryKey: []string{"id"},
+		Unique:     [][]string{{"integration_id", "item_id"}},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("integration_id", "integrations"),
+			cascadeTo("item_id", "items"),
+		},
+		Indexes: []string{"item_id"},
+		PartialIndexes: []ExpectedPartialIndex{
+			{Columns: []string{"next_attempt_at"}, WhereColumn: "status"},
+		},
+	},
+	{
+		Name: "blocked_domains",
+		Columns: map[string]string{
+			"id":         typeUUID,
+			"pattern":    typeText,
+			"kind":       typeText,
+			"reason":     typeText,
+			"created_by": typeUUID,
+			"created_at": typeTimestamptz,
+		},
+		NotNull:     []string{"id", "pattern", "kind", "reason", "created_at"},
+		PrimaryKey:  []string{"id"},
+		Unique:      [][]string{{"pattern"}},
+		ForeignKeys: []ExpectedForeignKey{setNullTo("created_by", "users")},
+	},
+	{
+		Name: "api_usage_daily",
+		Columns: map[string]string{
+			"user_id":       typeUUID,
+			"usage_date":    typeDate,
+			"client":        typeText,
+			"request_count": typeBigint,
+			"error_count":   typeBigint,
+		},
+		NotNull:     []string{"user_id", "usage_date", "client", "request_count", "error_count"},
+		PrimaryKey:  []string{"user_id", "usage_date", "client"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+		Indexes:     []string{"usage_date"},
+	},
+	{
+		Name: "login_events",
+		Columns: map[string]string{
+			"id":         typeUUID,
+			"user_id":    typeUUID,
+			"event":      typeText,
+			"reason":     typeText,
+			"ip_address": typeText,
+			"user_agent": typeText,
+			"created_at": typeTimestamptz,
+		},
+		NotNull:     []string{"id", "event", "reason", "ip_address", "user_agent", "created_at"},
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+		Indexes:     []string{"user_id"},
+	},
+	{
+		Name: "feed_raw_captures",
+		Columns: map[string]string{
+			"feed_id":        typeUUID,
+			"fetched_at":     typeTimestamptz,
+			"request_url":    typeText,
+			"status_code":    typeInteger,
+			"proto":          typeText,
+			"headers":        typeJSONB,
+			"body":           typeBytea,
+			"body_truncated": typeBoolean,
+		},
+		NotNull:     []string{"feed_id", "fetched_at", "request_url", "status_code", "proto", "headers", "body", "body_truncated"},
+		PrimaryKey:  []string{"feed_id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("feed_id", "feeds")},
+	},
+	{
+		Name: "read_later_connections",
+		Columns: map[string]string{
+			"id":            typeUUID,
+			"user_id":       typeUUID,
+			"provider":      typeText,
+			"account_name":  typeText,
+			"credentials":   typeBytea,
+			"enabled":       typeBoolean,
+			"last_error":    typeText,
+			"last_error_at": typeTimestamptz,
+			"last_saved_at": typeTimestamptz,
+			"created_at":    typeTimestamptz,
+			"updated_at":    typeTimestamptz,
+		},
+		NotNull:     []string{"id", "user_id", "provider", "account_name", "credentials", "enabled", "created_at", "updated_at"},
+		PrimaryKey:  []string{"id"},
+		Unique:      [][]string{{"user_id", "provider"}},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+	},
+	{
+		Name: "read_later_deliveries",
+		Columns: map[string]string{
+			"id":              typeBigint,
+			"connection_id":   typeUUID,
+			"item_id":         typeUUID,
+			"status":          typeText,
+			"attempts":        typeInteger,
+			"next_attempt_at": typeTimestamptz,
+			"last_error":      typeText,
+			"sent_at":         typeTimestamptz,
+			"created_at":      typeTimestamptz,
+		},
+		NotNull:    []string{"id", "connection_id", "item_id", "status", "attempts", "next_attempt_at", "created_at"},
+		PrimaryKey: []string{"id"},
+		Unique:     [][]string{{"connection_id", "item_id"}},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("connection_id", "read_later_connections"),
+			cascadeTo("item_id", "items"),
+		},
+		Indexes: []string{"item_id"},
+		PartialIndexes: []ExpectedPartialIndex{
+			{Columns: []string{"next_attempt_at"}, WhereColumn: "status"},
+		},
+	},
+	{
+		Name: "feed_registration_batches",
+		Columns: map[string]string{
+			"id":           typeUUID,
+			"user_id":      typeUUID,
+			"entries":      typeJSONB,
+			"created_at":   typeTimestamptz,
+			"completed_at": typeTimestamptz,
+		},
+		NotNull:     []string{"id", "user_id", "entries", "created_at"},
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("user_id", "users")},
+		Indexes:     []string{"user_id"},
+	},
+	{
+		Name: "item_highlights",
+		Columns: map[string]string{
+			"period":       typeVarchar,
+			"item_id":      typeUUID,
+			"feed_id":      typeUUID,
+			"score":        typeDouble,
+			"hatebu_count": typeInteger,
+			"star_count":   typeInteger,
+			"view_count":   typeInteger,
+			"computed_at":  typeTimestamptz,
+		},
+		NotNull:    []string{"period", "item_id", "feed_id", "score", "hatebu_count", "star_count", "view_count", "computed_at"},
+		PrimaryKey: []string{"period", "item_id"},
+		ForeignKeys: []ExpectedForeignKey{
+			cascadeTo("item_id", "items"),
+			cascadeTo("feed_id", "feeds"),
+		},
+		Indexes: []string{"score"},
+	},
+	{
+		Name: "feed_keywords",
+		Columns: map[string]string{
+			"feed_id":     typeUUID,
+			"keyword":     typeText,
+			"count":       typeInteger,
+			"computed_at": typeTimestamptz,
+		},
+		NotNull:     []string{"feed_id", "keyword", "count", "computed_at"},
+		PrimaryKey:  []string{"feed_id", "keyword"},
+		ForeignKeys: []ExpectedForeignKey{cascadeTo("feed_id", "feeds")},
+	},
+	{
+		Name: "login_tokens",
+		Columns: map[string]string{
+			"token_hash": typeChar,
+			"email":      typeVarchar,
+			"expires_at": typeTimestamptz,
+			"used_at":    typeTimestamptz,
+			"created_at": typeTimestamptz,
+		},
+		NotNull:    []string{"token_hash", "email", "expires_at", "created_at"},
+		PrimaryKey: []string{"token_hash"},
+		Indexes:    []string{"email", "expires_at"},
+	},
+}
@@ -3,6 +3,7 @@ package database
 
 import (
 	"embed"
+	"errors"
 	"fmt"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -15,6 +16,8 @@ var migrationsFS embed.FS
 
 // NewMigrator はマイグレーション実行用のmigrateインスタンスを生成する。
 // databaseURLはPostgreSQLの接続URLを指定する。
+// 返したインスタンスはオンライン実行のマイグレーション（onlineMigrationMarker）を区別しないため、
+// 適用には RunMigrations / MigrateUpTo を使うこと。
 func NewMigrator(databaseURL string) (*migrate.Migrate, error) {
 	source, err := iofs.New(migrationsFS, "migrations")
 	if err != nil {
@@ -31,16 +34,51 @@ func NewMigrator(databaseURL string) (*migrate.Migrate, error) {
 
 // RunMigrations はすべてのマイグレーションを適用する。
 // すでに最新の場合はエラーなしで返る。
-func RunMigrations(databaseURL string) error {
-	m, err := NewMigrator(databaseURL)
+// オンライン実行のマイグレーションにはロック待ちのタイムアウトとリトライを適用する（opts で変更できる）。
+func RunMigrations(databaseURL string, opts ...MigrateOption) error {
+	latest, err := LatestMigrationVersion()
 	if err != nil {
 		return err
 	}
+	if err := migrateTo(databaseURL, latest, newMigrateOptions(opts)); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return nil
+}
+
+// SchemaVersion は適用済みのマイグレーションのバージョンを返す。
+// マイグレーションが未適用の場合は 0 を返す。途中で失敗した（dirty な）状態の場合はエラーを返す。
+func SchemaVersion(databaseURL string) (uint, error) {
+	m, err := NewMigrator(databaseURL)
+	if err != nil {
+		return 0, err
+	}
 	defer m.Close()
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("failed to run migrations: %w", err)
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, nil
 	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("schema version %d is dirty; fix the failed migration first", version)
+	}
+	return version, nil
+}
 
-	return nil
+// LatestMigrationVersion は埋め込まれたマイグレーションの最新のバージョンを返す。
+func LatestMigrationVersion() (uint, error) {
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return 0, err
+	}
+	return migrations[len(migrations)-1].version, nil
+}
+
+// MigrateUpTo は version までのマイグレーションを適用する。
+// すでに version 以降まで適用済みの場合は何もしない（ダウンマイグレーションは行わない）。
+func MigrateUpTo(databaseURL string, version uint, opts ...MigrateOption) error {
+	return migrateTo(databaseURL, version, newMigrateOptions(opts))
 }
@@ -0,0 +1,305 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/lib/pq"
+)
+
+// onlineMigrationMarker は up.sql の先頭行に書くと、そのマイグレーションをオンライン実行する目印。
+//
+// golang-migrate はマイグレーションファイル全体を 1 回の Exec で実行するため、複数の文は暗黙のトランザクションに入り、
+// CREATE INDEX CONCURRENTLY のようにトランザクション内で実行できない文を含められない。
+// オンライン実行のマイグレーションは文ごとにトランザクション外で実行し、ロック待ちのタイムアウトとリトライを適用する。
+// 途中で失敗しても再実行できるよう、各文は冪等に書くこと（CONCURRENTLY の作成に失敗すると INVALID なインデックスが残るため、
+// 作成の前に DROP INDEX CONCURRENTLY IF EXISTS を置く）。
+// 目印は up.sql にだけ効く。down.sql に書いても通常どおり 1 回の Exec で実行されるため、書かないこと。
+const onlineMigrationMarker = "-- migrate:online"
+
+// オンライン実行のマイグレーションの既定値。
+const (
+	// DefaultMigrateLockTimeout はロックの取得を待つ時間の既定値。
+	// 長時間のトランザクションの後ろでロックを待ち続け、後続のクエリを巻き込んで詰まらせないよう短くする。
+	DefaultMigrateLockTimeout = 5 * time.Second
+	// DefaultMigrateMaxAttempts は 1 つのマイグレーションを試行する回数の既定値。
+	DefaultMigrateMaxAttempts = 3
+	// DefaultMigrateRetryInterval はリトライまでの待ち時間の既定値。
+	DefaultMigrateRetryInterval = 10 * time.Second
+)
+
+// migrateOptions はオンライン実行のマイグレーションの設定。
+type migrateOptions struct {
+	lockTimeout      time.Duration
+	statementTimeout time.Duration
+	maxAttempts      int
+	retryInterval    time.Duration
+	logger           *slog.Logger
+	sleep            func(time.Duration)
+}
+
+// MigrateOption は RunMigrations / MigrateUpTo のオプション。
+type MigrateOption func(*migrateOptions)
+
+// WithMigrateLockTimeout はオンライン実行のマイグレーションでロックの取得を待つ時間を設定する（lock_timeout）。
+// 0 以下の場合は待ち時間を制限しない。
+func WithMigrateLockTimeout(d time.Duration) MigrateOption {
+	return func(o *migrateOptions) { o.lockTimeout = d }
+}
+
+// WithMigrateStatementTimeout はオンライン実行のマイグレーションの 1 文あたりの実行時間の上限を設定する（statement_timeout）。
+// 0 以下の場合は上限を設けない（既定）。大きなテーブルへのインデックス作成は時間がかかるため、上限は余裕を持たせること。
+func WithMigrateStatementTimeout(d time.Duration) MigrateOption {
+	return func(o *migrateOptions) { o.statementTimeout = d }
+}
+
+// WithMigrateRetry はオンライン実行のマイグレーションがタイムアウト等で失敗したときの試行回数とリトライの間隔を設定する。
+// maxAttempts が 1 未満の場合は 1 回だけ試行する。
+func WithMigrateRetry(maxAttempts int, interval time.Duration) MigrateOption {
+	return func(o *migrateOptions) {
+		o.maxAttempts = max(maxAttempts, 1)
+		o.retryInterval = interval
+	}
+}
+
+// WithMigrateLogger はリトライ等を記録するロガーを設定する。未指定時は slog.Default を使う。
+func WithMigrateLogger(logger *slog.Logger) MigrateOption {
+	return func(o *migrateOptions) { o.logger = logger }
+}
+
+func newMigrateOptions(opts []MigrateOption) migrateOptions {
+	o := migrateOptions{
+		lockTimeout:   DefaultMigrateLockTimeout,
+		maxAttempts:   DefaultMigrateMaxAttempts,
+		retryInterval: DefaultMigrateRetryInterval,
+		logger:        slog.Default(),
+		sleep:         time.Sleep,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// migrationStep は 1 回の migrate.Migrate で適用するマイグレーションの範囲。
+// 通常のマイグレーションは連続する分をまとめて適用し、オンライン実行のマイグレーションは 1 件ずつ適用する。
+type migrationStep struct {
+	// from は適用前のバージョン（未適用の場合は -1）。失敗時に dirty を解消して戻すバージョン。
+	from int
+	// to は適用後のバージョン。
+	to     uint
+	online bool
+}
+
+// embeddedMigration は埋め込まれたマイグレーション 1 件。
+type embeddedMigration struct {
+	version uint
+	online  bool
+}
+
+// embeddedMigrations は埋め込まれたマイグレーションをバージョンの昇順で返す。
+func embeddedMigrations() ([]embeddedMigration, error) {
+	src, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration source: %w", err)
+	}
+	defer src.Close()
+
+	var migrations []embeddedMigration
+	version, err := src.First()
+	for err == nil {
+		online, readErr := isOnlineMigration(src, version)
+		if readErr != nil {
+			return nil, readErr
+		}
+		migrations = append(migrations, embeddedMigration{version: version, online: online})
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	if len(migrations) == 0 {
+		return nil, errors.New("failed to read migrations: no migration found")
+	}
+	return migrations, nil
+}
+
+// isOnlineMigration は version の up.sql の先頭行が onlineMigrationMarker かを返す。
+func isOnlineMigration(src source.Driver, version uint) (bool, error) {
+	r, _, err := src.ReadUp(version)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read migration %d: %w", version, err)
+	}
+	defer r.Close()
+	return hasOnlineMarker(r)
+}
+
+// hasOnlineMarker は r の先頭行が onlineMigrationMarker かを返す。
+func hasOnlineMarker(r io.Reader) (bool, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read migration: %w", err)
+	}
+	return strings.TrimSpace(line) == onlineMigrationMarker, nil
+}
+
+// planMigrations は current（未適用の場合は -1）から target までに適用するステップを返す。
+func planMigrations(migrations []embeddedMigration, current int, target uint) []migrationStep {
+	var steps []migrationStep
+	from := current
+	for i, mig := range migrations {
+		if int(mig.version) <= current {
+			continue
+		}
+		if mig.version > target {
+			break
+		}
+		// 次が通常のマイグレーションで target 以内なら、まとめて適用する
+		if !mig.online && i+1 < len(migrations) && !migrations[i+1].online && migrations[i+1].version <= target {
+			continue
+		}
+		steps = append(steps, migrationStep{from: from, to: mig.version, online: mig.online})
+		from = int(mig.version)
+	}
+	return steps
+}
+
+// onlineMigrationURL はオンライン実行のマイグレーション用の接続 URL を返す。
+// 文ごとに分けて実行し（x-multi-statement）、lock_timeout / statement_timeout を接続の実行時パラメータとして設定する。
+func onlineMigrationURL(databaseURL string, o migrateOptions) (string, error) {
+	u, err := url.Parse(databaseURL)
+	if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		return "", errors.New("online migrations require a postgres:// database url")
+	}
+	q := u.Query()
+	q.Set("x-multi-statement", "true")
+	q.Set("lock_timeout", strconv.FormatInt(max(o.lockTimeout, 0).Milliseconds(), 10))
+	q.Set("statement_timeout", strconv.FormatInt(max(o.statementTimeout, 0).Milliseconds(), 10))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// isRetryableMigrationError はマイグレーションの失敗がリトライで解消しうるかを返す。
+// ロック待ちのタイムアウト・文のキャンセル（statement_timeout を含む）・デッドロック・シリアライズの失敗が対象。
+func isRetryableMigrationError(err error) bool {
+	var dbErr migratedb.Error
+	if errors.As(err, &dbErr) && dbErr.OrigErr != nil {
+		err = dbErr.OrigErr
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "55P03", // lock_not_available
+		"57014", // query_canceled
+		"40P01", // deadlock_detected
+		"40001": // serialization_failure
+		return true
+	}
+	return false
+}
+
+// migrateTo は target までの未適用のマイグレーションを順に適用する。
+// 通常のマイグレーションはファイルごとにトランザクション内で、オンライン実行のマイグレーションは文ごとにトランザクション外で実行する。
+func migrateTo(databaseURL string, target uint, o migrateOptions) error {
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return err
+	}
+	current, err := SchemaVersion(databaseURL)
+	if err != nil {
+		return err
+	}
+	from := int(current)
+	if current == 0 {
+		from = -1
+	}
+
+	steps := planMigrations(migrations, from, target)
+	if len(steps) > 0 && steps[len(steps)-1].to != target {
+		return fmt.Errorf("migration version %d does not exist", target)
+	}
+
+	var normal, online *migrate.Migrate
+	defer func() {
+		for _, m := range []*migrate.Migrate{normal, online} {
+			if m != nil {
+				m.Close()
+			}
+		}
+	}()
+
+	for _, step := range steps {
+		if !step.online {
+			if normal == nil {
+				if normal, err = NewMigrator(databaseURL); err != nil {
+					return err
+				}
+			}
+			if err := normal.Migrate(step.to); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+				return fmt.Errorf("failed to migrate to version %d: %w", step.to, err)
+			}
+			continue
+		}
+
+		if online == nil {
+			onlineURL, err := onlineMigrationURL(databaseURL, o)
+			if err != nil {
+				return err
+			}
+			if online, err = NewMigrator(onlineURL); err != nil {
+				return err
+			}
+		}
+		if err := runOnlineMigration(online, step, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runOnlineMigration はオンライン実行のマイグレーション 1 件を適用する。
+// 失敗した場合は dirty になったバージョンを適用前に戻し、リトライ可能なエラーであれば間隔を空けて再試行する。
+// 試行回数を使い切った場合も適用前に戻すため、原因を取り除いて migrate を再実行すれば続きから適用できる。
+func runOnlineMigration(m *migrate.Migrate, step migrationStep, o migrateOptions) error {
+	for attempt := 1; ; attempt++ {
+		err := m.Migrate(step.to)
+		if err == nil || errors.Is(err, migrate.ErrNoChange) {
+			return nil
+		}
+		if forceErr := m.Force(step.from); forceErr != nil {
+			return fmt.Errorf("failed to run online migration %d: %w (and failed to reset version: %v)", step.to, err, forceErr)
+		}
+		if !isRetryableMigrationError(err) || attempt >= o.maxAttempts {
+			return fmt.Errorf("failed to run online migration %d after %d attempt(s): %w", step.to, attempt, err)
+		}
+
+		o.logger.Warn("online migration failed; retrying",
+			slog.Uint64("version", uint64(step.to)),
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", o.retryInterval),
+			slog.String("error", err.Error()),
+		)
+		o.sleep(o.retryInterval)
+	}
+}
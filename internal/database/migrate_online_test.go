@@ -0,0 +1,223 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	"github.com/lib/pq"
+)
+
+func TestHasOnlineMarker(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want bool
+	}{
+		{"先頭行が目印のときオンライン実行と判定する", "-- migrate:online\nCREATE INDEX CONCURRENTLY idx ON items (feed_id);\n", true},
+		{"目印の前後の空白を無視するとき", "  -- migrate:online  \r\nDROP INDEX CONCURRENTLY IF EXISTS idx;", true},
+		{"目印のみで改行がないとき", "-- migrate:online", true},
+		{"目印が先頭行にないとき通常のマイグレーションと判定する", "-- コメント\n-- migrate:online\nCREATE TABLE t (id int);", false},
+		{"目印がないとき", "CREATE TABLE t (id int);", false},
+		{"空のとき", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := hasOnlineMarker(strings.NewReader(tt.sql))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("hasOnlineMarker() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("hasOnlineMarker() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	// Act
+	migrations, err := embeddedMigrations()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("embeddedMigrations() error = %v", err)
+	}
+	var online []uint
+	for i, mig := range migrations {
+		if i > 0 && mig.version <= migrations[i-1].version {
+			t.Fatalf("バージョンが昇順ではありません: %d → %d", migrations[i-1].version, mig.version)
+		}
+		if mig.online {
+			online = append(online, mig.version)
+		}
+	}
+	if len(online) == 0 || online[0] != 20260721120000 {
+		t.Errorf("オンライン実行のマイグレーション = %v, want 20260721120000 を含む", online)
+	}
+}
+
+func TestEmbeddedMigrations_DownHasNoOnlineMarker(t *testing.T) {
+	// Arrange
+	paths, err := fs.Glob(migrationsFS, "migrations/*.down.sql")
+	if err != nil {
+		t.Fatalf("fs.Glob() error = %v", err)
+	}
+
+	for _, path := range paths {
+		// Act
+		f, err := migrationsFS.Open(path)
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", path, err)
+		}
+		online, err := hasOnlineMarker(f)
+		f.Close()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("hasOnlineMarker(%s) error = %v", path, err)
+		}
+		if online {
+			t.Errorf("%s の先頭行に %q がありますが、down.sql はオンライン実行に対応しません", path, onlineMigrationMarker)
+		}
+	}
+}
+
+func TestPlanMigrations(t *testing.T) {
+	migrations := []embeddedMigration{
+		{version: 1}, {version: 2}, {version: 3, online: true}, {version: 4}, {version: 5}, {version: 6, online: true},
+	}
+
+	tests := []struct {
+		name    string
+		current int
+		target  uint
+		want    []migrationStep
+	}{
+		{
+			name:    "未適用のとき通常のマイグレーションをまとめオンライン実行を1件ずつ分けるとき",
+			current: -1,
+			target:  6,
+			want: []migrationStep{
+				{from: -1, to: 2}, {from: 2, to: 3, online: true}, {from: 3, to: 5}, {from: 5, to: 6, online: true},
+			},
+		},
+		{
+			name:    "途中まで適用済みのとき続きから計画するとき",
+			current: 3,
+			target:  6,
+			want:    []migrationStep{{from: 3, to: 5}, {from: 5, to: 6, online: true}},
+		},
+		{
+			name:    "target までで止めるとき",
+			current: -1,
+			target:  4,
+			want:    []migrationStep{{from: -1, to: 2}, {from: 2, to: 3, online: true}, {from: 3, to: 4}},
+		},
+		{
+			name:    "最新まで適用済みのとき空を返す",
+			current: 6,
+			target:  6,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := planMigrations(migrations, tt.current, tt.target)
+
+			// Assert
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("planMigrations() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnlineMigrationURL(t *testing.T) {
+	t.Run("文ごとの実行とタイムアウトを接続パラメータに加えるとき", func(t *testing.T) {
+		// Arrange
+		o := newMigrateOptions([]MigrateOption{
+			WithMigrateLockTimeout(3 * time.Second),
+			WithMigrateStatementTimeout(30 * time.Minute),
+		})
+
+		// Act
+		got, err := onlineMigrationURL("postgres://u:p@localhost:5432/db?sslmode=disable&search_path=feedman%2Cpublic", o)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("onlineMigrationURL() error = %v", err)
+		}
+		u, err := url.Parse(got)
+		if err != nil {
+			t.Fatalf("URL の解析に失敗: %v", err)
+		}
+		q := u.Query()
+		if q.Get("x-multi-statement") != "true" || q.Get("lock_timeout") != "3000" || q.Get("statement_timeout") != "1800000" {
+			t.Errorf("query = %v", q)
+		}
+		if q.Get("sslmode") != "disable" || q.Get("search_path") != "feedman,public" {
+			t.Errorf("既存のパラメータが失われています: %v", q)
+		}
+	})
+
+	t.Run("既定では文の実行時間を制限しないとき", func(t *testing.T) {
+		// Act
+		got, err := onlineMigrationURL("postgres://localhost/db", newMigrateOptions(nil))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("onlineMigrationURL() error = %v", err)
+		}
+		u, _ := url.Parse(got)
+		if u.Query().Get("statement_timeout") != "0" || u.Query().Get("lock_timeout") != "5000" {
+			t.Errorf("query = %v", u.Query())
+		}
+	})
+
+	t.Run("key=value 形式の接続文字列のときエラーを返す", func(t *testing.T) {
+		// Act
+		_, err := onlineMigrationURL("host=localhost dbname=feedman", newMigrateOptions(nil))
+
+		// Assert
+		if err == nil {
+			t.Error("onlineMigrationURL() error = nil, want error")
+		}
+	})
+}
+
+func TestIsRetryableMigrationError(t *testing.T) {
+	wrap := func(err error) error {
+		return migratedb.Error{OrigErr: err, Err: "migration failed", Query: []byte("CREATE INDEX CONCURRENTLY ...")}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"ロック待ちのタイムアウトのとき", wrap(&pq.Error{Code: "55P03"}), true},
+		{"statement_timeout によるキャンセルのとき", wrap(&pq.Error{Code: "57014"}), true},
+		{"デッドロックのとき", wrap(&pq.Error{Code: "40P01"}), true},
+		{"コンテキストの期限切れのとき", wrap(context.DeadlineExceeded), true},
+		{"構文エラーのときリトライしない", wrap(&pq.Error{Code: "42601"}), false},
+		{"重複キーのときリトライしない", wrap(&pq.Error{Code: "23505"}), false},
+		{"データベース以外のエラーのときリトライしない", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableMigrationError(tt.err); got != tt.want {
+				t.Errorf("isRetryableMigrationError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+-- 公開プロフィール共有向けのカラムとインデックスを削除する
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS is_public;
+
+DROP INDEX IF EXISTS idx_user_settings_public_slug;
+ALTER TABLE user_settings DROP COLUMN IF EXISTS public_slug;
+ALTER TABLE user_settings DROP COLUMN IF EXISTS public_profile;
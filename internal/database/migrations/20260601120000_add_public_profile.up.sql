@@ -0,0 +1,9 @@
+-- 購読リストの公開プロフィール共有向けに、公開フラグ・公開スラッグと購読ごとの公開可否を追加する
+-- user_settings.public_profile: プロフィール全体の公開可否（既定 false = 非公開）
+-- user_settings.public_slug: 公開 URL（/public/{slug}/subscriptions）に用いる一意なスラッグ
+-- subscriptions.is_public: 個別購読の公開可否（既定 false = 明示的に公開した購読のみ表示）
+ALTER TABLE user_settings ADD COLUMN public_profile BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE user_settings ADD COLUMN public_slug VARCHAR(64) NULL;
+CREATE UNIQUE INDEX idx_user_settings_public_slug ON user_settings(public_slug) WHERE public_slug IS NOT NULL;
+
+ALTER TABLE subscriptions ADD COLUMN is_public BOOLEAN NOT NULL DEFAULT false;
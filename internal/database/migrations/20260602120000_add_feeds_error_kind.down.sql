@@ -0,0 +1,3 @@
+-- feeds テーブルから error_kind カラムと集計用インデックスを削除する
+DROP INDEX IF EXISTS idx_feeds_error_kind;
+ALTER TABLE feeds DROP COLUMN IF EXISTS error_kind;
@@ -0,0 +1,8 @@
+-- feeds テーブルに error_kind カラムを追加する
+-- 用途: フェッチ失敗の原因分類（dns / tls / timeout / too_large / parse / http_4xx / http_5xx / ssrf / other）
+-- 分類別のバックオフポリシー適用と、原因別の集計（ヘルスダッシュボード）に用いる
+-- 既存行はバックフィルしない (NULL = エラーなし、または分類導入前のエラー)
+ALTER TABLE feeds ADD COLUMN error_kind VARCHAR(20) NULL;
+
+-- 原因別集計用の部分インデックス（エラー中のフィードのみ）
+CREATE INDEX idx_feeds_error_kind ON feeds(error_kind) WHERE error_kind IS NOT NULL;
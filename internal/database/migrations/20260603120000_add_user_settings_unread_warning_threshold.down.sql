@@ -0,0 +1,2 @@
+-- 積読警告の閾値カラムを削除する
+ALTER TABLE user_settings DROP COLUMN IF EXISTS unread_warning_threshold;
@@ -0,0 +1,4 @@
+-- 積読警告（too_many_unread）の閾値をユーザー設定に追加する
+-- NULL は既定値（500 件）を用い、0 は警告を無効にする
+ALTER TABLE user_settings ADD COLUMN unread_warning_threshold INTEGER NULL
+    CHECK (unread_warning_threshold >= 0);
@@ -0,0 +1,2 @@
+-- リンクの開き方の設定カラムを削除する
+ALTER TABLE user_settings DROP COLUMN IF EXISTS open_links_in_new_tab;
@@ -0,0 +1,3 @@
+-- 記事本文のリンクを新しいタブで開くかのユーザー設定を追加する
+-- 既定 true（本機能導入前の挙動 = target="_blank" と等価）
+ALTER TABLE user_settings ADD COLUMN open_links_in_new_tab BOOLEAN NOT NULL DEFAULT true;
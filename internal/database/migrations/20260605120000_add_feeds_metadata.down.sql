@@ -0,0 +1,4 @@
+-- feeds テーブルから language / description / last_published_at カラムを削除する
+ALTER TABLE feeds DROP COLUMN IF EXISTS last_published_at;
+ALTER TABLE feeds DROP COLUMN IF EXISTS description;
+ALTER TABLE feeds DROP COLUMN IF EXISTS language;
@@ -0,0 +1,8 @@
+-- feeds テーブルに language / description / last_published_at カラムを追加する
+-- 用途: フィードの言語・説明文・最終投稿日時を購読一覧とフィード詳細で返し、
+--       ディスカバリーや購読整理（更新の止まったフィードの判別）の判断材料にする
+-- フェッチ成功時に channel 情報と記事の公開日時から更新される
+-- 既存行はバックフィルしない (NULL = 次回フェッチ成功まで不明)
+ALTER TABLE feeds ADD COLUMN language VARCHAR(35) NULL;
+ALTER TABLE feeds ADD COLUMN description TEXT NULL;
+ALTER TABLE feeds ADD COLUMN last_published_at TIMESTAMPTZ NULL;
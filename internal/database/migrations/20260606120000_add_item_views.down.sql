@@ -0,0 +1,2 @@
+-- item_views テーブルを削除する
+DROP TABLE IF EXISTS item_views;
@@ -0,0 +1,16 @@
+-- item_views テーブルを追加する
+-- 用途: 記事詳細の閲覧イベントを記録し、「よく読むフィード」ランキング（GET /api/stats/top-feeds）を集計する
+-- feed_id は集計用に記事から非正規化して保持する
+-- 記事・ユーザーの削除に追従して CASCADE 削除される（記事の保持期間がそのまま閲覧履歴の保持期間となる）
+CREATE TABLE item_views (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- ユーザー単位の期間集計用
+CREATE INDEX idx_item_views_user_viewed_at ON item_views(user_id, viewed_at);
+-- 記事削除（クリーンアップジョブ）時の CASCADE 削除用
+CREATE INDEX idx_item_views_item_id ON item_views(item_id);
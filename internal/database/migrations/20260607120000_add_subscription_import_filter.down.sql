@@ -0,0 +1,3 @@
+-- subscriptions から記事取り込みフィルタの列を削除する
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS import_filter_title_pattern;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS import_filter_authors;
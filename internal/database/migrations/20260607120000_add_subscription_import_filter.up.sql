@@ -0,0 +1,5 @@
+-- 購読単位の記事取り込みフィルタ（ホワイトリスト型）を追加する
+-- subscriptions.import_filter_authors: 取り込む記事の著者（いずれかに一致した記事のみ取り込む。空配列は著者で絞り込まない）
+-- subscriptions.import_filter_title_pattern: 取り込む記事タイトルの正規表現（空文字はタイトルで絞り込まない）
+ALTER TABLE subscriptions ADD COLUMN import_filter_authors TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE subscriptions ADD COLUMN import_filter_title_pattern TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- subscription_undos テーブルを削除する
+DROP TABLE IF EXISTS subscription_undos;
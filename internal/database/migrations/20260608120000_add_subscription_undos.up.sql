@@ -0,0 +1,15 @@
+-- 購読解除の取り消し（Undo）向けに、解除した購読と記事状態のスナップショットを猶予期間だけ保持する
+-- subscription: 解除時点の subscriptions 行（to_jsonb）。復元時は jsonb_populate_record で同じ行を戻す
+-- item_states: 解除時点の当該フィードの記事状態（to_jsonb の配列）
+-- expires_at: 取り消し期限。期限切れの行は次回の購読解除時にまとめて削除する
+CREATE TABLE subscription_undos (
+    subscription_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    subscription JSONB NOT NULL,
+    item_states JSONB NOT NULL DEFAULT '[]'::jsonb,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_subscription_undos_expires_at ON subscription_undos(expires_at);
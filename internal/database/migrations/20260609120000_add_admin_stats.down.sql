@@ -0,0 +1,3 @@
+-- admin_stats マテリアライズドビューと fetch_attempts テーブルを削除する
+DROP MATERIALIZED VIEW IF EXISTS admin_stats;
+DROP TABLE IF EXISTS fetch_attempts;
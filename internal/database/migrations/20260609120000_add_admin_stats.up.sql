@@ -0,0 +1,43 @@
+-- fetch_attempts テーブルを追加する
+-- 用途: フェッチ 1 回ごとの成否と所要時間を記録し、管理者向け統計（GET /api/admin/stats）の
+--       直近 24 時間のフェッチ成功率・平均フェッチ時間を集計する
+-- 保持期間を過ぎた行は統計の定期集計ジョブが削除する
+CREATE TABLE fetch_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    succeeded BOOLEAN NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- 期間集計・保持期間超過分の削除用
+CREATE INDEX idx_fetch_attempts_attempted_at ON fetch_attempts(attempted_at);
+-- フィード削除時の CASCADE 削除用
+CREATE INDEX idx_fetch_attempts_feed_id ON fetch_attempts(feed_id);
+
+-- admin_stats マテリアライズドビューを追加する
+-- 用途: 全件 COUNT など重い集計を API リクエストごとに行わないよう、worker が定期的に
+--       REFRESH MATERIALIZED VIEW CONCURRENTLY で更新した 1 行のスナップショットを返す
+-- DB サイズは pg_database_size による概算
+CREATE MATERIALIZED VIEW admin_stats AS
+SELECT
+    1 AS id,
+    (SELECT COUNT(*) FROM users) AS user_count,
+    (SELECT COUNT(*) FROM feeds) AS feed_count,
+    (SELECT COUNT(*) FROM items) AS item_count,
+    recent.attempts AS fetch_attempts_24h,
+    recent.successes AS fetch_successes_24h,
+    recent.avg_duration_ms AS avg_fetch_duration_ms_24h,
+    pg_database_size(current_database()) AS database_size_bytes,
+    now() AS refreshed_at
+FROM (
+    SELECT
+        COUNT(*) AS attempts,
+        COUNT(*) FILTER (WHERE succeeded) AS successes,
+        COALESCE(AVG(duration_ms), 0)::double precision AS avg_duration_ms
+    FROM fetch_attempts
+    WHERE attempted_at > now() - interval '24 hours'
+) AS recent;
+
+-- REFRESH ... CONCURRENTLY には一意インデックスが必要
+CREATE UNIQUE INDEX idx_admin_stats_id ON admin_stats(id);
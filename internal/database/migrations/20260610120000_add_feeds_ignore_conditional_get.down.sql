@@ -0,0 +1,2 @@
+-- feeds テーブルから ignore_conditional_get カラムを削除する
+ALTER TABLE feeds DROP COLUMN IF EXISTS ignore_conditional_get;
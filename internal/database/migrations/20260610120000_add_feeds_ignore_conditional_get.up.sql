@@ -0,0 +1,5 @@
+-- feeds テーブルに ignore_conditional_get カラムを追加する
+-- 用途: ETag / Last-Modified を不正確に返し 304 ばかり応答するサーバー向けに、
+--       フィード単位で条件付き GET（If-None-Match / If-Modified-Since）の送信を止める
+-- 既定値は false（従来どおり条件付き GET を行う）
+ALTER TABLE feeds ADD COLUMN ignore_conditional_get BOOLEAN NOT NULL DEFAULT false;
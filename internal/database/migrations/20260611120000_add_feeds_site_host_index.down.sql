@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_feeds_site_host;
+DROP FUNCTION IF EXISTS feed_host(TEXT);
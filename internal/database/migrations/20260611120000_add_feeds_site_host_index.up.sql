@@ -0,0 +1,13 @@
+-- 同一ホストのフィード（カテゴリ別 RSS 等）を検索するための関数と式インデックス。
+-- feed_host は URL からホスト名を小文字で取り出し、先頭の "www." を除いて返す（ポート・認証情報は除外）。
+-- Go 側（feed.siteHost）の正規化と一致させること。
+CREATE OR REPLACE FUNCTION feed_host(url TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE AS $$
+    SELECT regexp_replace(
+        substring(lower(url) from '^[a-z][a-z0-9+.-]*://(?:[^@/?#]*@)?([^/:?#]+)'),
+        '^www\.', ''
+    )
+$$;
+
+-- site_url が未設定のフィードは feed_url のホストで判定する。
+CREATE INDEX idx_feeds_site_host ON feeds (feed_host(COALESCE(NULLIF(site_url, ''), feed_url)));
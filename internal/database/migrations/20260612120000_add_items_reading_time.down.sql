@@ -0,0 +1,2 @@
+-- items テーブルから reading_time_minutes カラムを削除する
+ALTER TABLE items DROP COLUMN IF EXISTS reading_time_minutes;
@@ -0,0 +1,5 @@
+-- items テーブルに reading_time_minutes カラムを追加する
+-- 用途: 記事一覧・詳細で「3 分で読める」のような読了時間を表示する
+--       値は UpsertItems 時に本文テキストの文字数（日本語）/ 単語数（英語）から算出する
+-- 既存記事は 0（未算出）とし、次回の取得で本文が更新された際に再計算される
+ALTER TABLE items ADD COLUMN reading_time_minutes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- item_states テーブルから last_visited_at カラムを削除する
+ALTER TABLE item_states DROP COLUMN IF EXISTS last_visited_at;
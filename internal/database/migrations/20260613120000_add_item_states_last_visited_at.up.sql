@@ -0,0 +1,4 @@
+-- item_states テーブルに last_visited_at カラムを追加する
+-- 用途: GET /api/items/:id/visit で元記事へリダイレクトした時刻（最終訪問日時）を記録する
+-- 未訪問の場合は NULL
+ALTER TABLE item_states ADD COLUMN last_visited_at TIMESTAMPTZ;
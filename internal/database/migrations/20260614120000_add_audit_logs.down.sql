@@ -0,0 +1,2 @@
+-- audit_logs テーブルを削除する
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,15 @@
+-- audit_logs テーブルを追加する
+-- 用途: 購読・購読解除・購読設定の変更といったユーザー操作の履歴を記録し、GET /api/audit-logs で閲覧する
+-- target は操作対象（購読操作ではフィードID）、payload は操作時点の補足情報（購読ID・変更前後の値など）
+-- ユーザーの削除に追従して CASCADE 削除される
+CREATE TABLE audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    target TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- ユーザー単位の新しい順ページング用（(created_at, id) の複合カーソル）
+CREATE INDEX idx_audit_logs_user_created_at ON audit_logs(user_id, created_at DESC, id DESC);
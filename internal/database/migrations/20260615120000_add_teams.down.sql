@@ -0,0 +1,7 @@
+-- チーム共有のテーブルと subscriptions.team_id を削除する
+DROP INDEX IF EXISTS idx_subscriptions_team_id;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS team_id;
+DROP TABLE IF EXISTS team_invitations;
+DROP TABLE IF EXISTS team_feeds;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
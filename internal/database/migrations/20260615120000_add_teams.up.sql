@@ -0,0 +1,50 @@
+-- チーム（家族・チーム）で購読リストを共有するためのテーブルを追加する
+-- teams: チーム本体
+-- team_members: チームの所属メンバー（role は owner / member）。ユーザー削除に追従して CASCADE 削除される
+-- team_feeds: チーム所有の購読フィード。メンバー全員の購読（subscriptions.team_id 付き）として展開される
+-- team_invitations: 招待トークン（SHA-256 ハッシュのみ保存）。1 回限り・期限付き
+CREATE TABLE teams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE team_members (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+-- 自分の所属チーム一覧用
+CREATE INDEX idx_team_members_user_id ON team_members(user_id);
+
+CREATE TABLE team_feeds (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    added_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, feed_id)
+);
+
+CREATE TABLE team_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_team_invitations_team_id ON team_invitations(team_id);
+
+-- チーム購読から展開された購読にはチームIDを持たせる。
+-- 既読・スターは item_states（ユーザー単位）のため、メンバーごとに分離される。
+-- チームが削除された場合は個人の購読として残す。
+ALTER TABLE subscriptions ADD COLUMN team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_subscriptions_team_id ON subscriptions(team_id) WHERE team_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- feeds テーブルからフェッチ失敗の詳細と HTTP バージョンを削除する
+ALTER TABLE feeds DROP COLUMN IF EXISTS http_version;
+ALTER TABLE feeds DROP COLUMN IF EXISTS error_detail;
@@ -0,0 +1,7 @@
+-- feeds テーブルにフェッチ失敗の詳細と直近の HTTP バージョンを追加する
+-- error_detail: 直近のフェッチ失敗の構造化詳細（TLS エラー種別・証明書の有効期限・HTTP ステータス等）。
+--               成功時は NULL に戻す。error_message だけでは原因が分からない失敗（証明書切れ等）の表示に使う
+-- http_version: 直近に応答を受信したときのプロトコル（HTTP/1.1・HTTP/2.0 等）。応答を受信できなかった
+--               フェッチでは更新しない
+ALTER TABLE feeds ADD COLUMN error_detail JSONB;
+ALTER TABLE feeds ADD COLUMN http_version TEXT;
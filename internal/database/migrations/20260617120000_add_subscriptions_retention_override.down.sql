@@ -0,0 +1,2 @@
+-- subscriptions テーブルから retention_override カラムを削除する
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS retention_override;
@@ -0,0 +1,6 @@
+-- subscriptions テーブルに購読単位の最大記事保持数（retention_override）を追加する
+-- NULL は上書きなし（全体の保持期間に従う）。値がある場合はフィードの新しい記事からこの件数だけを保持する
+-- 記事はフィード単位で共有されるため、クリーンアップジョブは全購読者が上書きを設定している
+-- フィードにのみ適用し、保持数は購読者間の最大値を採用する
+ALTER TABLE subscriptions ADD COLUMN retention_override INTEGER NULL
+    CHECK (retention_override IS NULL OR retention_override > 0);
@@ -0,0 +1,3 @@
+-- subscriptions テーブルから is_pinned と sort_order カラムを削除する
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS is_pinned;
@@ -0,0 +1,5 @@
+-- subscriptions テーブルにピン留め（is_pinned）とサイドバーの並び順（sort_order）を追加する
+-- 購読一覧は is_pinned（ピン留めを先頭）→ sort_order（昇順）→ フィードタイトルの順で返す
+-- 既存の購読はピン留めなし・並び順 0 となり、従来どおりタイトル順に並ぶ
+ALTER TABLE subscriptions ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE subscriptions ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;
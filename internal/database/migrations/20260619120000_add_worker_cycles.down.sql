@@ -0,0 +1,2 @@
+-- worker_cycles テーブルを削除する
+DROP TABLE IF EXISTS worker_cycles;
@@ -0,0 +1,18 @@
+-- worker_cycles テーブルを追加する
+-- 用途: フェッチスケジューラの 1 サイクルごとの結果（対象フィード数・成功/失敗数・新規/更新記事数・所要時間）を
+--       記録し、管理者向け API（GET /api/admin/worker-cycles）で直近の取り込み状況を確認する
+-- 保持期間を過ぎた行はスケジューラがサイクル記録時に削除する
+CREATE TABLE worker_cycles (
+    id BIGSERIAL PRIMARY KEY,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    feed_count INTEGER NOT NULL,
+    succeeded_count INTEGER NOT NULL,
+    failed_count INTEGER NOT NULL,
+    items_inserted INTEGER NOT NULL,
+    items_updated INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL
+);
+
+-- 直近の履歴取得・保持期間超過分の削除用
+CREATE INDEX idx_worker_cycles_started_at ON worker_cycles(started_at);
@@ -0,0 +1,4 @@
+-- items からリンク切れチェックのカラムを削除する
+ALTER TABLE items
+    DROP COLUMN IF EXISTS link_checked_at,
+    DROP COLUMN IF EXISTS link_status;
@@ -0,0 +1,9 @@
+-- items にリンク切れチェックの結果を記録するカラムを追加する
+-- 用途: スター記事の元記事 URL を低頻度ワーカーで定期チェックし、
+--       スター一覧の「リンク切れ」フィルタ（GET /api/feeds/starred/items?link_status=broken）に用いる
+-- link_status: NULL=未判定, 'ok'=到達可能, 'not_found'=404/410, 'domain_unresolvable'=ドメイン名を解決できない
+-- link_checked_at: 最後にチェックした日時。一時的な失敗（タイムアウト・5xx 等）でも更新し、再チェック間隔の起点とする
+ALTER TABLE items
+    ADD COLUMN link_status TEXT
+        CHECK (link_status IN ('ok', 'not_found', 'domain_unresolvable')),
+    ADD COLUMN link_checked_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+-- 著者別フィルタ用のインデックスを削除する（正規化済みの author の値は元に戻さない）
+DROP INDEX IF EXISTS idx_items_feed_author;
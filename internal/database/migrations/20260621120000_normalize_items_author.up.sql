@@ -0,0 +1,11 @@
+-- items.author を正規化し、フィード内の著者別フィルタ・著者一覧集計用のインデックスを追加する
+-- 用途: GET /api/feeds/:id/items?author= の絞り込みと GET /api/feeds/:id/authors の集計
+-- 新規・更新記事はアプリケーション側（item.normalizeAuthor）で正規化して保存する。
+-- 既存行はここで NFKC 正規化・前後空白の除去・連続空白の集約のみを行い、空になった値は NULL にする
+-- （"email (Name)" 形式の名前抽出は次回の記事更新時に適用される）。
+UPDATE items
+SET author = NULLIF(btrim(regexp_replace(normalize(author, NFKC), '\s+', ' ', 'g')), '')
+WHERE author IS NOT NULL
+  AND author IS DISTINCT FROM NULLIF(btrim(regexp_replace(normalize(author, NFKC), '\s+', ' ', 'g')), '');
+
+CREATE INDEX idx_items_feed_author ON items(feed_id, author) WHERE author IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Idempotency-Key の冪等性レイヤー用テーブルを削除する
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,17 @@
+-- 書き込み系 API の Idempotency-Key ヘッダによる冪等性レイヤー用テーブル
+-- 用途: モバイル回線等でのリトライで同じリクエストが二重実行されないよう、
+--       ユーザー・キー単位で最初のレスポンスを保存し、期限（既定 24 時間）内の再送にはそれを再生する
+-- request_hash: メソッド・パス・ボディのハッシュ。同じキーで別内容のリクエストが来た場合の検知に用いる
+-- status_code: NULL=処理中（レスポンス未確定）。確定後にレスポンスのステータス・Content-Type・ボディを保存する
+-- 期限切れの行は同じユーザーが次にキーを予約する際にまとめて削除する
+CREATE TABLE idempotency_keys (
+    user_id       UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key           TEXT        NOT NULL,
+    request_hash  TEXT        NOT NULL,
+    status_code   INTEGER,
+    content_type  TEXT,
+    response_body BYTEA,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
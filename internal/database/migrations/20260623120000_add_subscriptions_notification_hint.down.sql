@@ -0,0 +1,4 @@
+-- subscriptions から通知ヒントのカラムを削除する
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS mute_until,
+    DROP COLUMN IF EXISTS priority;
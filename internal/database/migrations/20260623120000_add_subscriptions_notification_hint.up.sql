@@ -0,0 +1,8 @@
+-- subscriptions にクライアント向けの通知ヒント（優先度・ミュート期限）を追加する
+-- 用途: クライアントが通知音や表示の重み付けに使う。一覧 API と（将来の）プッシュ通知 payload に含める
+-- priority: 'high' / 'normal'（既定）/ 'low'
+-- mute_until: この日時まで新着通知イベントを生成しない。NULL=ミュートしていない
+ALTER TABLE subscriptions
+    ADD COLUMN priority TEXT NOT NULL DEFAULT 'normal'
+        CHECK (priority IN ('high', 'normal', 'low')),
+    ADD COLUMN mute_until TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+-- 週次統計スナップショット用テーブルを削除する
+DROP TABLE IF EXISTS weekly_subscription_stats;
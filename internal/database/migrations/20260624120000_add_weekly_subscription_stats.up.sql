@@ -0,0 +1,18 @@
+-- weekly_subscription_stats: ユーザー×フィード単位の週次統計スナップショット
+-- 用途: GET /api/stats/weekly で新着数・未読消化数の週ごとの推移を返す
+-- week_start: 集計週の開始時刻（月曜 00:00 UTC）。集計範囲は [week_start, week_start + 7 日)
+-- new_items: 週内に取り込まれたフィードの記事数（items.created_at 基準）
+-- read_items: 週内にユーザーが既読にした当該フィードの記事数（item_states.read_at 基準）
+-- worker が週の終了後に 1 回だけ記録し、1 年を過ぎたスナップショットは worker が削除する
+CREATE TABLE weekly_subscription_stats (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    week_start TIMESTAMPTZ NOT NULL,
+    new_items INTEGER NOT NULL DEFAULT 0,
+    read_items INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, week_start, feed_id)
+);
+
+-- 保持期間を過ぎたスナップショットの削除用
+CREATE INDEX idx_weekly_subscription_stats_week_start ON weekly_subscription_stats(week_start);
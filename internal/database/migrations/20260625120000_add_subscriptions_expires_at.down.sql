@@ -0,0 +1,4 @@
+-- subscriptions からお試し購読の期限を削除する
+DROP INDEX IF EXISTS idx_subscriptions_expires_at;
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,8 @@
+-- subscriptions にお試し購読（期限付き購読）の期限を追加する
+-- expires_at: この日時を過ぎるとワーカーが自動で購読を解除する。NULL=通常の購読
+ALTER TABLE subscriptions
+    ADD COLUMN expires_at TIMESTAMPTZ;
+
+-- 期限切れ購読の定期スキャン用（お試し購読のみを対象にする部分インデックス）
+CREATE INDEX idx_subscriptions_expires_at ON subscriptions (expires_at)
+    WHERE expires_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- feeds から URL 張り替えの提案を削除する
+ALTER TABLE feeds
+    DROP COLUMN IF EXISTS suggested_feed_url;
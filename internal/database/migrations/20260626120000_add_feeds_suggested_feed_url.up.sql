@@ -0,0 +1,5 @@
+-- feeds に URL 張り替えの提案を追加する
+-- suggested_feed_url: 404/410 でフェッチを停止したとき、サイトから再検出した新しいフィード URL。
+-- NULL=提案なし。フェッチ停止中のみ購読一覧に提示し、適用すると feed_url に置き換えて NULL に戻す
+ALTER TABLE feeds
+    ADD COLUMN suggested_feed_url TEXT;
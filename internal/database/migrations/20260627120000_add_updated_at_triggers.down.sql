@@ -0,0 +1,4 @@
+-- feeds / items の updated_at 自動更新トリガーを削除する
+DROP TRIGGER IF EXISTS items_set_updated_at ON items;
+DROP TRIGGER IF EXISTS feeds_set_updated_at ON feeds;
+DROP FUNCTION IF EXISTS set_updated_at();
@@ -0,0 +1,20 @@
+-- feeds / items の updated_at を PostgreSQL トリガーで自動更新する
+-- set_updated_at: BEFORE UPDATE で行の内容が変わったときだけ updated_at を now() にする。
+-- 値の変わらない UPDATE では updated_at を据え置き、差分検出で変更なしの行を拾わないようにする。
+-- アプリ側で updated_at を指定しても上書きされる（設定漏れ・時刻ずれの防止）
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW IS DISTINCT FROM OLD THEN
+        NEW.updated_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER feeds_set_updated_at
+    BEFORE UPDATE ON feeds
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER items_set_updated_at
+    BEFORE UPDATE ON items
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
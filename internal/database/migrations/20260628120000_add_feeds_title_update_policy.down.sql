@@ -0,0 +1,4 @@
+-- feeds からフィードタイトル自動更新のポリシーと承認待ちタイトルを削除する
+ALTER TABLE feeds
+    DROP COLUMN IF EXISTS pending_title,
+    DROP COLUMN IF EXISTS title_update_policy;
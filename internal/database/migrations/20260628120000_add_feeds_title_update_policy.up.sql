@@ -0,0 +1,9 @@
+-- feeds にフィードタイトル自動更新のポリシーと承認待ちタイトルを追加する
+-- title_update_policy: フェッチ時に取得したタイトルの反映方法。
+--   always=毎回上書きする（既定・従来の挙動）、initial=タイトル未設定のときのみ反映する、
+--   manual=変更を検知したら pending_title に保留し、購読者の承認で反映する
+-- pending_title: manual のとき検知した承認待ちの新しいタイトル。NULL=承認待ちなし
+ALTER TABLE feeds
+    ADD COLUMN title_update_policy TEXT NOT NULL DEFAULT 'always'
+        CHECK (title_update_policy IN ('always', 'initial', 'manual')),
+    ADD COLUMN pending_title TEXT;
@@ -0,0 +1,3 @@
+-- items テーブルから series_key カラムとインデックスを削除する
+DROP INDEX IF EXISTS idx_items_feed_series_key;
+ALTER TABLE items DROP COLUMN IF EXISTS series_key;
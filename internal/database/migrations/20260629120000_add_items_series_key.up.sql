@@ -0,0 +1,8 @@
+-- items テーブルに series_key カラムを追加する
+-- 用途: 「第 N 回」「Part N」等の連番を除いたタイトルを連載（シリーズ）のキーとして保持し、
+--       記事一覧（?group_by=series）で同じ連載の記事を折りたたんで表示する
+-- 値は UpsertItems 時にタイトルからヒューリスティクスで検出する。連載と判定できない記事は NULL
+-- 既存記事は NULL（未検出）とし、次回の取得で記事が更新された際に検出される
+ALTER TABLE items ADD COLUMN series_key TEXT;
+
+CREATE INDEX idx_items_feed_series_key ON items (feed_id, series_key) WHERE series_key IS NOT NULL;
@@ -0,0 +1,2 @@
+-- プリフェッチのオプトイン設定カラムを削除する
+ALTER TABLE user_settings DROP COLUMN IF EXISTS prefetch_enabled;
@@ -0,0 +1,3 @@
+-- 利用時間帯に合わせたプリフェッチ（next_fetch_at をよく読む時間帯の直前に寄せる）のオプトイン設定を追加する
+-- 既定 false（本機能導入前の挙動 = 購読のフェッチ間隔どおりにフェッチする）
+ALTER TABLE user_settings ADD COLUMN prefetch_enabled BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+-- Slack / Discord 連携の設定と配送キューを削除する
+DROP TABLE IF EXISTS integration_deliveries;
+DROP TABLE IF EXISTS integrations;
@@ -0,0 +1,48 @@
+-- 購読フィードの新着記事を Slack / Discord の Webhook に転送する連携設定と配送キューを追加する
+-- integrations: 連携設定（対象購読・宛先 Webhook URL・メッセージテンプレート）
+--   購読の解除・ユーザーの削除に追従して CASCADE 削除される
+--   last_error / last_delivered_at は配送ワーカーが記録する直近の配送結果
+-- integration_deliveries: 新着記事 1 件 × 連携 1 件の配送キュー
+--   status は pending（未配送・再試行待ち）/ sent（配送済み）/ failed（再試行上限・恒久的な失敗）
+--   (integration_id, item_id) の一意制約で同じ記事を二重に配送しない
+--   記事の削除（クリーンアップジョブ）に追従して CASCADE 削除される
+CREATE TABLE integrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('slack', 'discord')),
+    webhook_url TEXT NOT NULL,
+    message_template TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_error TEXT,
+    last_delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- 自分の連携一覧用
+CREATE INDEX idx_integrations_user_id ON integrations(user_id);
+-- フェッチ後の新着記事のキュー投入（購読 → 連携の逆引き）用
+CREATE INDEX idx_integrations_subscription_id ON integrations(subscription_id);
+
+CREATE TRIGGER integrations_set_updated_at
+    BEFORE UPDATE ON integrations
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE integration_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    integration_id UUID NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (integration_id, item_id)
+);
+
+-- 配送ワーカーが配送期限の来た pending を古い順に取り出す用
+CREATE INDEX idx_integration_deliveries_due ON integration_deliveries(next_attempt_at) WHERE status = 'pending';
+-- 記事削除（クリーンアップジョブ）時の CASCADE 削除用
+CREATE INDEX idx_integration_deliveries_item_id ON integration_deliveries(item_id);
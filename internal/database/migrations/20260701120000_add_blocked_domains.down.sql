@@ -0,0 +1,2 @@
+-- モデレーション用ブロックリストを削除する
+DROP TABLE IF EXISTS blocked_domains;
@@ -0,0 +1,12 @@
+-- インスタンス全体でフィードの登録をブロックするドメイン・URL（モデレーション用ブロックリスト）を追加する
+-- kind が domain の項目は pattern のホストとそのサブドメインに一致し、url の項目はフィード URL と完全一致する
+-- pattern は正規化（小文字化・末尾ドットの除去など）済みの値を保存し、重複を一意制約で防ぐ
+-- created_by は追加した管理者。ユーザーの削除後も項目は残す
+CREATE TABLE blocked_domains (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pattern TEXT NOT NULL UNIQUE,
+    kind TEXT NOT NULL CHECK (kind IN ('domain', 'url')),
+    reason TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
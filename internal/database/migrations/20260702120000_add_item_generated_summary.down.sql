@@ -0,0 +1,3 @@
+-- items テーブルから生成要約のカラムを削除する
+ALTER TABLE items DROP COLUMN IF EXISTS generated_summary_at;
+ALTER TABLE items DROP COLUMN IF EXISTS generated_summary;
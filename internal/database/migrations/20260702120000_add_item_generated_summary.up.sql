@@ -0,0 +1,6 @@
+-- items テーブルに生成要約のカラムを追加する
+-- 用途: POST /api/items/{id}/summarize で要約器（外部 API またはローカルの抽出型要約）が生成した要約を保持し、
+--       記事一覧・記事詳細に generated_summary として表示する
+-- 本文（content_hash）が変わった記事は取り込み時に NULL に戻し、次回の要求で再生成する
+ALTER TABLE items ADD COLUMN generated_summary TEXT;
+ALTER TABLE items ADD COLUMN generated_summary_at TIMESTAMPTZ;
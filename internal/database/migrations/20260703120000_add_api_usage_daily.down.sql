@@ -0,0 +1,2 @@
+-- ユーザー×日×クライアント単位の API 利用量カウンタを削除する
+DROP TABLE IF EXISTS api_usage_daily;
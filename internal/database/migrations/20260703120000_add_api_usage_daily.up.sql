@@ -0,0 +1,14 @@
+-- ユーザー×日×クライアント単位の API 利用量カウンタを追加する
+-- usage_date は UTC の日付。client は User-Agent から分類したクライアント名（browser / unknown / 製品名）
+-- API サーバーがメモリ上で集計したリクエスト数・エラー数（ステータス 400 以上）を定期的に加算する
+CREATE TABLE api_usage_daily (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    usage_date DATE NOT NULL,
+    client TEXT NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+    error_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, usage_date, client)
+);
+
+-- 保持期間を過ぎた行の削除に使う
+CREATE INDEX idx_api_usage_daily_usage_date ON api_usage_daily (usage_date);
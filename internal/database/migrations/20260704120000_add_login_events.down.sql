@@ -0,0 +1,2 @@
+-- ログインイベントの記録を削除する
+DROP TABLE IF EXISTS login_events;
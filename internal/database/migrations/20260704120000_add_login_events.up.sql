@@ -0,0 +1,18 @@
+-- login_events テーブルを追加する
+-- 用途: 不正アクセス調査のため、ログイン成功・失敗・ログアウト・セッション失効を記録し、
+--       GET /api/users/me/login-history で本人が閲覧する
+-- ip_address / user_agent は部分マスク済みの値のみを保存する（生の値は保存しない）
+-- user_id はユーザーを特定できないログイン失敗（認可コードの交換失敗など）では NULL とする
+-- ユーザーの削除に追従して CASCADE 削除される
+CREATE TABLE login_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    event TEXT NOT NULL CHECK (event IN ('login_success', 'login_failure', 'logout', 'session_expired')),
+    reason TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- ユーザー単位の新しい順ページング用（(created_at, id) の複合カーソル）
+CREATE INDEX idx_login_events_user_created_at ON login_events(user_id, created_at DESC, id DESC);
@@ -0,0 +1,3 @@
+-- 記事一覧の日付グルーピング（?group_dates=true）に用いるユーザーのタイムゾーン（IANA 名。例: Asia/Tokyo）を追加する
+-- 既定 NULL（未設定 = UTC として扱う）
+ALTER TABLE user_settings ADD COLUMN timezone TEXT NULL;
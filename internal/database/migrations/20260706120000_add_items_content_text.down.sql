@@ -0,0 +1,2 @@
+-- items テーブルから content_text カラムを削除する
+ALTER TABLE items DROP COLUMN IF EXISTS content_text;
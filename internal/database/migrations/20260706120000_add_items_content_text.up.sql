@@ -0,0 +1,6 @@
+-- items テーブルに content_text カラムを追加する
+-- 用途: サニタイズ済み HTML 本文からタグを除去したプレーンテキスト（先頭 2000 文字）を保持し、
+--       記事一覧の抜粋（excerpt）表示や、検索・要約のソースに使う
+--       値は UpsertItems 時に本文（本文が空ならサマリー）から生成する
+-- 既存記事は空文字（未生成）とし、次回の取得で記事が更新された際に生成される
+ALTER TABLE items ADD COLUMN content_text TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- 購読一覧・記事一覧の条件付き GET 用のトリガー・カラム・インデックスを削除する
+DROP INDEX IF EXISTS idx_item_states_user_updated_at;
+DROP TRIGGER IF EXISTS subscriptions_touch_changed_at ON subscriptions;
+DROP FUNCTION IF EXISTS touch_subscriptions_changed_at();
+ALTER TABLE users DROP COLUMN IF EXISTS subscriptions_changed_at;
+DROP TRIGGER IF EXISTS item_states_set_updated_at ON item_states;
+DROP TRIGGER IF EXISTS subscriptions_set_updated_at ON subscriptions;
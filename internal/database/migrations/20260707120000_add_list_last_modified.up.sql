@@ -0,0 +1,31 @@
+-- 購読一覧・記事一覧 API の条件付き GET（Last-Modified / If-Modified-Since）の判定に使う更新日時を整備する
+-- subscriptions / item_states は updated_at をトリガーで自動更新し、アプリ側の設定漏れで 304 を返さないようにする
+CREATE TRIGGER subscriptions_set_updated_at
+    BEFORE UPDATE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER item_states_set_updated_at
+    BEFORE UPDATE ON item_states
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- 購読の追加・解除は行の updated_at に残らない（解除は行が消え、復元は元の updated_at で戻る）ため、
+-- ユーザー単位の最終変更日時を users に持ち、subscriptions への INSERT / DELETE で更新する
+ALTER TABLE users ADD COLUMN subscriptions_changed_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE OR REPLACE FUNCTION touch_subscriptions_changed_at() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE users SET subscriptions_changed_at = now() WHERE id = OLD.user_id;
+    ELSE
+        UPDATE users SET subscriptions_changed_at = now() WHERE id = NEW.user_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER subscriptions_touch_changed_at
+    AFTER INSERT OR DELETE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION touch_subscriptions_changed_at();
+
+-- ユーザーの記事状態の最終更新日時を求めるためのインデックス
+CREATE INDEX idx_item_states_user_updated_at ON item_states(user_id, updated_at DESC);
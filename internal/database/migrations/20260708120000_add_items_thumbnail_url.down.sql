@@ -0,0 +1,2 @@
+-- items テーブルから thumbnail_url カラムを削除する
+ALTER TABLE items DROP COLUMN IF EXISTS thumbnail_url;
@@ -0,0 +1,4 @@
+-- items テーブルに記事のサムネイル画像の URL を追加する
+-- フィードの media:thumbnail / 画像の enclosure、無ければ本文の最初の画像を取り込み時に検証して保存する（https のみ）
+-- 既存の記事は次回の取り込みで記事が更新されたときに設定される
+ALTER TABLE items ADD COLUMN thumbnail_url TEXT NOT NULL DEFAULT '';
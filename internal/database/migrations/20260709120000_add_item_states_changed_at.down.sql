@@ -0,0 +1,5 @@
+-- item_states から既読・スターの最終変更日時を削除する
+DROP TRIGGER IF EXISTS item_states_set_changed_at ON item_states;
+DROP FUNCTION IF EXISTS set_item_state_changed_at();
+ALTER TABLE item_states DROP COLUMN IF EXISTS starred_changed_at;
+ALTER TABLE item_states DROP COLUMN IF EXISTS read_changed_at;
@@ -0,0 +1,26 @@
+-- item_states に既読・スターそれぞれの最終変更日時を追加する
+-- オフライン同期（POST /api/sync/operations）で、操作の時刻とサーバー側の最終変更日時を比べて
+-- 新しい方を採用する（last-write-wins）ために使う。オフライン同期では操作の時刻（クライアント時刻）を保存する
+-- 既存の行は NULL のままとし、NULL の場合は既読・スター済みなら updated_at、そうでなければ未変更とみなす
+ALTER TABLE item_states ADD COLUMN read_changed_at TIMESTAMPTZ;
+ALTER TABLE item_states ADD COLUMN starred_changed_at TIMESTAMPTZ;
+
+-- オンラインの操作（状態更新 API・訪問・既読 beacon 等）で値が変わった場合は変更日時を now() にする。
+-- アプリが変更日時を明示した UPDATE（オフライン同期）では上書きしない
+CREATE OR REPLACE FUNCTION set_item_state_changed_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW.is_read IS DISTINCT FROM OLD.is_read
+        AND NEW.read_changed_at IS NOT DISTINCT FROM OLD.read_changed_at THEN
+        NEW.read_changed_at := now();
+    END IF;
+    IF NEW.is_starred IS DISTINCT FROM OLD.is_starred
+        AND NEW.starred_changed_at IS NOT DISTINCT FROM OLD.starred_changed_at THEN
+        NEW.starred_changed_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER item_states_set_changed_at
+    BEFORE UPDATE ON item_states
+    FOR EACH ROW EXECUTE FUNCTION set_item_state_changed_at();
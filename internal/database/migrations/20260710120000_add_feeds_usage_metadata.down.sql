@@ -0,0 +1,5 @@
+-- feeds からフィードが示す利用条件のメタ情報を削除する
+ALTER TABLE feeds
+    DROP COLUMN IF EXISTS robots,
+    DROP COLUMN IF EXISTS ttl_minutes,
+    DROP COLUMN IF EXISTS copyright;
@@ -0,0 +1,9 @@
+-- feeds にフィードが示す利用条件のメタ情報を追加する
+-- copyright: 著作権・利用条件の表記（RSS の <copyright> / Atom の <rights>）。NULL=表記なし
+-- ttl_minutes: フィードが示すキャッシュ有効期間（RSS の <ttl>、分）。次回フェッチまでの間隔の下限として扱う。NULL=指定なし
+-- robots: ロボット向け指示（X-Robots-Tag ヘッダー / channel の xhtml:meta name="robots"）を
+--   小文字・カンマ区切りに正規化したもの（例: 'noindex, noarchive'）。NULL=指定なし
+ALTER TABLE feeds
+    ADD COLUMN copyright TEXT,
+    ADD COLUMN ttl_minutes INTEGER CHECK (ttl_minutes > 0),
+    ADD COLUMN robots TEXT;
@@ -0,0 +1,3 @@
+-- フィードの記事一覧のインデックスを (feed_id, published_at DESC) に戻す
+CREATE INDEX IF NOT EXISTS idx_items_feed_published_at ON items(feed_id, published_at DESC);
+DROP INDEX IF EXISTS idx_items_feed_published_at_id;
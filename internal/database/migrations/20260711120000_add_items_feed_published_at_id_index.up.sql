@@ -0,0 +1,5 @@
+-- フィードの記事一覧の (published_at, id) 複合カーソルによるページング用のインデックスに置き換える
+-- published_at が同一の記事を id で一意に並べるため、id を末尾に加える。
+-- 既存の idx_items_feed_published_at (feed_id, published_at DESC) は新しいインデックスの先頭列と重複するため削除する
+CREATE INDEX idx_items_feed_published_at_id ON items(feed_id, published_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_items_feed_published_at;
@@ -0,0 +1,8 @@
+-- weekly_subscription_stats から非表示にした記事数を削除する
+ALTER TABLE weekly_subscription_stats
+    DROP COLUMN IF EXISTS hidden_items;
+
+-- item_states から記事の非表示状態を削除する
+ALTER TABLE item_states
+    DROP COLUMN IF EXISTS hidden_at,
+    DROP COLUMN IF EXISTS is_hidden;
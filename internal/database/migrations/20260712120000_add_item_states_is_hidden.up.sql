@@ -0,0 +1,12 @@
+-- item_states に記事の非表示（ミュート）状態を追加する
+-- is_hidden: ユーザーが興味のない記事として一覧から非表示にしたか。既読（is_read）とは独立した状態で、
+--   非表示にしても既読にはならない。記事一覧・横断一覧・未読数からは既定で除外する
+-- hidden_at: 非表示にした日時。非表示でない場合は NULL
+ALTER TABLE item_states
+    ADD COLUMN is_hidden BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN hidden_at TIMESTAMPTZ;
+
+-- weekly_subscription_stats に週内に非表示にした記事数を追加する
+-- hidden_items: 週内にユーザーが非表示にした当該フィードの記事数（item_states.hidden_at 基準。既読数には含めない）
+ALTER TABLE weekly_subscription_stats
+    ADD COLUMN hidden_items INTEGER NOT NULL DEFAULT 0;
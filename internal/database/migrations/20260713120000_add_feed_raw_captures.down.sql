@@ -0,0 +1,5 @@
+-- フェッチレスポンスの保存テーブルを削除する
+DROP TABLE IF EXISTS feed_raw_captures;
+
+-- feeds テーブルから capture_raw_response カラムを削除する
+ALTER TABLE feeds DROP COLUMN IF EXISTS capture_raw_response;
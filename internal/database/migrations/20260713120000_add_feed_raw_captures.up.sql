@@ -0,0 +1,20 @@
+-- feeds テーブルに capture_raw_response カラムを追加する
+-- 用途: 取り込み不具合の再現調査のため、管理者がフィード単位で直近のフェッチレスポンスの保存を有効にする
+-- 既定値は false（保存しない）
+ALTER TABLE feeds ADD COLUMN capture_raw_response BOOLEAN NOT NULL DEFAULT false;
+
+-- フィードごとの直近 1 回分のフェッチレスポンス（デバッグ用）を保存する
+-- フェッチのたびに上書きし、capture_raw_response を無効にしたときに削除する
+-- status_code / proto: レスポンスのステータスコードと HTTP バージョン（例: HTTP/1.1）
+-- headers: レスポンスヘッダー（ヘッダー名 → 値の配列の JSON。Set-Cookie は保存しない）
+-- body: レスポンスボディの先頭（最大 256KB）。body_truncated は上限で切り詰めたかどうか
+CREATE TABLE feed_raw_captures (
+    feed_id UUID PRIMARY KEY REFERENCES feeds(id) ON DELETE CASCADE,
+    fetched_at TIMESTAMPTZ NOT NULL,
+    request_url TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    proto TEXT NOT NULL DEFAULT '',
+    headers JSONB NOT NULL DEFAULT '{}',
+    body BYTEA NOT NULL,
+    body_truncated BOOLEAN NOT NULL DEFAULT false
+);
@@ -0,0 +1,3 @@
+-- users から最終アクティブ日時を削除する
+ALTER TABLE users
+    DROP COLUMN IF EXISTS last_active_at;
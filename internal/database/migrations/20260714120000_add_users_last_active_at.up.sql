@@ -0,0 +1,6 @@
+-- users に最終アクティブ日時を追加する
+-- last_active_at: 認証済み API を最後に利用した日時。API サーバーがユーザーごとに一定間隔で間引いて更新する。
+--   一定期間アクティブでないユーザーのみが購読するフィードは worker がフェッチ間隔を大幅に延長する。
+--   既存ユーザーは追加時点でアクティブとみなし、直後に休眠扱いにならないよう NOW() で埋める
+ALTER TABLE users
+    ADD COLUMN last_active_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
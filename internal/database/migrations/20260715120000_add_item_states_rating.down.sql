@@ -0,0 +1,19 @@
+-- スターの最終変更日時の更新条件を評価追加前に戻す
+CREATE OR REPLACE FUNCTION set_item_state_changed_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW.is_read IS DISTINCT FROM OLD.is_read
+        AND NEW.read_changed_at IS NOT DISTINCT FROM OLD.read_changed_at THEN
+        NEW.read_changed_at := now();
+    END IF;
+    IF NEW.is_starred IS DISTINCT FROM OLD.is_starred
+        AND NEW.starred_changed_at IS NOT DISTINCT FROM OLD.starred_changed_at THEN
+        NEW.starred_changed_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- item_states から評価を削除する（スター状態は is_starred に残る）
+DROP INDEX IF EXISTS idx_item_states_user_rating;
+ALTER TABLE item_states
+    DROP COLUMN IF EXISTS rating;
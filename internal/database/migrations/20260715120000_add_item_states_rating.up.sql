@@ -0,0 +1,28 @@
+-- item_states に 1〜5 段階の評価を追加する
+-- rating: ユーザーが付けた評価（1〜5）。0 は未評価。
+--   既存のスター（is_starred）との互換のため rating >= 1 の記事をスター付きとみなし、
+--   アプリは is_starred = (rating >= 1) を保って両方を更新する。評価なしでスターを付けた記事は rating = 1 とする
+ALTER TABLE item_states
+    ADD COLUMN rating SMALLINT NOT NULL DEFAULT 0
+        CONSTRAINT item_states_rating_range CHECK (rating BETWEEN 0 AND 5);
+
+-- 既存のスター付き記事は評価 1 とみなす
+UPDATE item_states SET rating = 1 WHERE is_starred;
+
+-- 評価別の絞り込み・並び替え（スター記事一覧の評価順）に使う
+CREATE INDEX idx_item_states_user_rating ON item_states(user_id, rating) WHERE rating > 0;
+
+-- スターを付けたまま評価だけを変えた場合もスターの最終変更日時を更新し、オフライン同期の比較対象にする
+CREATE OR REPLACE FUNCTION set_item_state_changed_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW.is_read IS DISTINCT FROM OLD.is_read
+        AND NEW.read_changed_at IS NOT DISTINCT FROM OLD.read_changed_at THEN
+        NEW.read_changed_at := now();
+    END IF;
+    IF (NEW.is_starred IS DISTINCT FROM OLD.is_starred OR NEW.rating IS DISTINCT FROM OLD.rating)
+        AND NEW.starred_changed_at IS NOT DISTINCT FROM OLD.starred_changed_at THEN
+        NEW.starred_changed_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
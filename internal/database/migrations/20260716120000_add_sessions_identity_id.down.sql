@@ -0,0 +1,3 @@
+-- sessions からログインに用いた identity を削除する
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS identity_id;
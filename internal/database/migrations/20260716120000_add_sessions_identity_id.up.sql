@@ -0,0 +1,8 @@
+-- sessions にログインに用いた identity を記録する
+-- identity_id: セッション発行時のログインに用いた identity。連携解除（DELETE /api/users/me/identities/{id}）時に
+--   その identity で発行したセッションを失効させるために使う。本列の追加前に発行したセッションは NULL
+--   （どの identity で発行したか不明なため、連携解除時はいずれの identity の解除でも失効対象とする）。
+--   identity の削除はアプリがセッションの失効と合わせて行うため、外部キーは張らない
+--   （Redis セッションストアと同じく、セッションストアから identities を参照しない）。
+ALTER TABLE sessions
+    ADD COLUMN identity_id UUID;
@@ -0,0 +1,3 @@
+-- 「あとで読む」サービス連携と保存キューを削除する
+DROP TABLE IF EXISTS read_later_deliveries;
+DROP TABLE IF EXISTS read_later_connections;
@@ -0,0 +1,45 @@
+-- スター記事を Pocket / Instapaper に自動保存する「あとで読む」サービス連携と保存キューを追加する
+-- read_later_connections: ユーザー × 保存先サービスの連携（1 ユーザー 1 サービスにつき 1 件）
+--   credentials は OAuth のアクセストークン等を AES-GCM で暗号化した値（平文は保存しない）
+--   連携先で認証が無効になった場合、保存ワーカーは enabled を false にして last_error に理由を記録する
+--   ユーザーの削除に追従して CASCADE 削除される
+-- read_later_deliveries: スターを付けた記事 1 件 × 連携 1 件の保存キュー
+--   status は pending（未保存・再試行待ち）/ sent（保存済み）/ failed（再試行上限・恒久的な失敗）
+--   (connection_id, item_id) の一意制約で、スターを付け直しても同じ記事を二重に保存しない
+--   記事の削除（クリーンアップジョブ）に追従して CASCADE 削除される
+CREATE TABLE read_later_connections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL CHECK (provider IN ('pocket', 'instapaper')),
+    account_name TEXT NOT NULL DEFAULT '',
+    credentials BYTEA NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_error TEXT,
+    last_error_at TIMESTAMPTZ,
+    last_saved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, provider)
+);
+
+CREATE TRIGGER read_later_connections_set_updated_at
+    BEFORE UPDATE ON read_later_connections
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE read_later_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    connection_id UUID NOT NULL REFERENCES read_later_connections(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (connection_id, item_id)
+);
+
+-- 保存ワーカーが保存期限の来た pending を古い順に取り出す用
+CREATE INDEX idx_read_later_deliveries_due ON read_later_deliveries(next_attempt_at) WHERE status = 'pending';
+-- 記事削除（クリーンアップジョブ）時の CASCADE 削除用
+CREATE INDEX idx_read_later_deliveries_item_id ON read_later_deliveries(item_id);
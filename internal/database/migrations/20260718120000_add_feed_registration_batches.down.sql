@@ -0,0 +1,2 @@
+-- URL の一括登録の進捗テーブルを削除する
+DROP TABLE IF EXISTS feed_registration_batches;
@@ -0,0 +1,16 @@
+-- URL の一括登録（POST /api/feeds/batch）の進捗と URL ごとの結果を保持する
+-- entries は URL ごとの結果の JSON 配列（リクエストの URL と同じ順）。API サーバーが処理しながら 1 件ずつ書き換え、
+--   クライアントは GET /api/feeds/batch/{id} をポーリングして結果を受け取る
+-- completed_at はすべての URL の処理を終えた日時（処理中は NULL）
+-- 照会期間（24 時間）を過ぎた行は、同じユーザーが次に一括登録を開始したときに削除する
+-- ユーザーの削除に追従して CASCADE 削除される
+CREATE TABLE feed_registration_batches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entries JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ
+);
+
+-- 照会期間を過ぎた行のユーザー単位の削除用
+CREATE INDEX idx_feed_registration_batches_user_created ON feed_registration_batches(user_id, created_at);
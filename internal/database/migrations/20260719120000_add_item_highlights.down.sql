@@ -0,0 +1,2 @@
+-- ハイライトの事前計算テーブルを削除する
+DROP TABLE IF EXISTS item_highlights;
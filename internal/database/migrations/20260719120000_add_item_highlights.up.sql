@@ -0,0 +1,19 @@
+-- ハイライト（GET /api/highlights）の事前計算結果を保持する
+-- worker が集計期間（day: 直近 24 時間 / week: 直近 7 日間）ごとに 1 日 1 回計算し直し、期間ごとに全行を置き換える
+-- score ははてブ数・スター数・閲覧数の対数に重みを掛けた合計。hatebu_count / star_count / view_count は計算に用いた値
+-- 閲覧ユーザーの上位 10 件が必ず含まれるよう、フィードごとにスコアの上位 10 件を保存する
+-- 記事・フィードの削除に追従して CASCADE 削除される
+CREATE TABLE item_highlights (
+    period VARCHAR(10) NOT NULL,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    hatebu_count INTEGER NOT NULL,
+    star_count INTEGER NOT NULL,
+    view_count INTEGER NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (period, item_id)
+);
+
+-- 購読フィードに絞ったスコア順の取得用
+CREATE INDEX idx_item_highlights_period_feed_score ON item_highlights(period, feed_id, score DESC);
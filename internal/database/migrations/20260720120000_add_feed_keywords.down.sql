@@ -0,0 +1,2 @@
+-- 記事傾向サマリーの事前計算テーブルを削除する
+DROP TABLE IF EXISTS feed_keywords;
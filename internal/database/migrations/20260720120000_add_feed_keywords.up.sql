@@ -0,0 +1,11 @@
+-- 購読フィードの記事傾向サマリー（GET /api/subscriptions/{id}/keywords）の事前計算結果を保持する
+-- worker が 1 日 1 回、購読者のいるフィードごとに直近 30 日の記事のタイトルと本文テキスト（content_text）から
+-- 語の出現回数を数え、上位 10 件でフィードの全行を置き換える
+-- フィードの削除に追従して CASCADE 削除される
+CREATE TABLE feed_keywords (
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    keyword TEXT NOT NULL,
+    count INTEGER NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (feed_id, keyword)
+);
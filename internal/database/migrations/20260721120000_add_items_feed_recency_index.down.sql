@@ -0,0 +1,3 @@
+-- down.sql はオンライン実行に対応しないため、golang-migrate がそのまま 1 回の Exec で実行する。
+-- 1 文だけであればトランザクションブロックに入らないため、CONCURRENTLY で削除できる（複数の文を書かないこと）。
+DROP INDEX CONCURRENTLY IF EXISTS idx_items_feed_recency;
@@ -0,0 +1,5 @@
+-- migrate:online
+-- フィードごとの直近の記事（COALESCE(published_at, created_at) による期間の絞り込み）を引く集計向けのインデックス。
+-- items が大きくても書き込みを止めないよう CONCURRENTLY で作成する。作成に失敗すると INVALID なインデックスが残るため、先に削除してから作り直す。
+DROP INDEX CONCURRENTLY IF EXISTS idx_items_feed_recency;
+CREATE INDEX CONCURRENTLY idx_items_feed_recency ON items (feed_id, (COALESCE(published_at, created_at)));
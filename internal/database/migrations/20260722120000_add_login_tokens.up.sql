@@ -0,0 +1,14 @@
+-- マジックリンクログイン（POST /auth/magic-link・GET /auth/magic-link/verify）の一回限りのトークンを保持する
+-- トークンの平文は保存せず、SHA-256 の hex 表現（token_hash）のみを保存する
+-- 有効期限は発行から 15 分。検証に成功した時点で used_at を記録し、以降は使えない
+-- 期限切れのトークンは次にリンクを発行したときに削除する
+CREATE TABLE login_tokens (
+    token_hash CHAR(64) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_tokens_email_created_at ON login_tokens (email, created_at);
+CREATE INDEX idx_login_tokens_expires_at ON login_tokens (expires_at);
@@ -0,0 +1,11 @@
+-- フィードタイトル自動更新のポリシーと承認待ちタイトルを subscriptions から feeds へ戻す
+-- 購読者ごとの設定はフィード単位に集約できないため、feeds のポリシーは既定（always）に戻す
+ALTER TABLE feeds
+    ADD COLUMN title_update_policy TEXT NOT NULL DEFAULT 'always'
+        CHECK (title_update_policy IN ('always', 'initial', 'manual')),
+    ADD COLUMN pending_title TEXT;
+
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS pending_title,
+    DROP COLUMN IF EXISTS display_title,
+    DROP COLUMN IF EXISTS title_update_policy;
@@ -0,0 +1,24 @@
+-- フィードタイトル自動更新のポリシーと承認待ちタイトルを feeds から subscriptions へ移す
+-- feeds は購読者間で共有されるため、1 人の購読者の設定が同じフィードの全購読者に及んでいた
+-- title_update_policy: 購読一覧に表示するタイトルの追従方法。
+--   always=フェッチで取得した最新のタイトルを表示する（既定）、initial=ポリシーを設定した時点のタイトルを表示し続ける、
+--   manual=フィードのタイトルが変わったら pending_title に保留し、購読者の承認で表示を切り替える
+-- display_title: initial / manual のときに表示するタイトル。NULL=フィードのタイトル（feeds.title）を表示する
+-- pending_title: manual のとき検知した承認待ちの新しいタイトル。NULL=承認待ちなし
+-- feeds.title はポリシーによらずフェッチのたびに更新する
+ALTER TABLE subscriptions
+    ADD COLUMN title_update_policy TEXT NOT NULL DEFAULT 'always'
+        CHECK (title_update_policy IN ('always', 'initial', 'manual')),
+    ADD COLUMN display_title TEXT,
+    ADD COLUMN pending_title TEXT;
+
+UPDATE subscriptions s
+SET title_update_policy = f.title_update_policy,
+    display_title = CASE WHEN f.title_update_policy = 'always' THEN NULL ELSE NULLIF(f.title, '') END,
+    pending_title = CASE WHEN f.title_update_policy = 'manual' THEN f.pending_title END
+FROM feeds f
+WHERE f.id = s.feed_id AND f.title_update_policy <> 'always';
+
+ALTER TABLE feeds
+    DROP COLUMN IF EXISTS pending_title,
+    DROP COLUMN IF EXISTS title_update_policy;
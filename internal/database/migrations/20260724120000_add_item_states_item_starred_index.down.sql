@@ -0,0 +1,2 @@
+-- item_states のスター集計用インデックスを削除する
+DROP INDEX IF EXISTS idx_item_states_item_starred;
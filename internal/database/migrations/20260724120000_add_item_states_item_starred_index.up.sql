@@ -0,0 +1,5 @@
+-- migrate:online
+-- ハイライトの事前計算で、期間内の記事ごとにスターを付けているユーザー数を数えるためのインデックス。
+-- item_states が大きくても書き込みを止めないよう CONCURRENTLY で作成する。作成に失敗すると INVALID なインデックスが残るため、先に削除してから作り直す。
+DROP INDEX CONCURRENTLY IF EXISTS idx_item_states_item_starred;
+CREATE INDEX CONCURRENTLY idx_item_states_item_starred ON item_states (item_id) WHERE is_starred = true;
@@ -0,0 +1,2 @@
+-- 消した ETag / Last-Modified は次回のフェッチで再取得されるため、戻す処理はない。
+SELECT 1;
@@ -0,0 +1,10 @@
+-- copyright / ttl_minutes / robots（20260710120000）の追加前から取得しているフィードは、
+-- 条件付き GET が 304 を返し続ける間は本文をパースしないため利用条件のメタ情報が空のまま残る。
+-- 取得済みでメタ情報がすべて空のフィードの ETag / Last-Modified を消し、次回のフェッチで本文を取得して埋める。
+-- 本当に利用条件の指定がないフィードも対象になるが、条件付き GET を外すのは次回の 1 回だけ。
+UPDATE feeds
+SET etag = NULL, last_modified = NULL
+WHERE last_successful_fetch_at IS NOT NULL
+  AND copyright IS NULL
+  AND ttl_minutes IS NULL
+  AND robots IS NULL;
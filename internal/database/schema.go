@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ConnectionURL は schema を search_path に設定した接続文字列を返す。
+// schema が空の場合は databaseURL をそのまま返す（接続ユーザーの既定の search_path を使う）。
+//
+// repository のクエリはスキーマ修飾しないため、search_path の先頭を schema にすることで
+// テーブルの作成・参照先を専用スキーマに向ける。pgcrypto / pg_trgm など public に
+// インストール済みの拡張を引き続き参照できるよう、public を後ろに残す。
+// URL 形式（postgres://）と key=value 形式のどちらの接続文字列にも対応する。
+func ConnectionURL(databaseURL, schema string) (string, error) {
+	if schema == "" {
+		return databaseURL, nil
+	}
+	searchPath := schema + ",public"
+
+	if strings.HasPrefix(databaseURL, "postgres://") || strings.HasPrefix(databaseURL, "postgresql://") {
+		u, err := url.Parse(databaseURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse database url: %w", err)
+		}
+		q := u.Query()
+		q.Set("search_path", searchPath)
+		u.RawQuery = q.Encode()
+		return u.String(), nil
+	}
+	return strings.TrimSpace(databaseURL + " search_path=" + searchPath), nil
+}
+
+// EnsureSchema は schema が存在しなければ作成する。schema が空の場合は何もしない。
+// golang-migrate は CURRENT_SCHEMA() をマイグレーション管理テーブルの配置先とし、
+// 存在しないスキーマは search_path から読み飛ばされるため、マイグレーションの前に呼び出すこと。
+func EnsureSchema(ctx context.Context, databaseURL, schema string) error {
+	if schema == "" {
+		return nil
+	}
+
+	db, err := Open(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, "CREATE SCHEMA IF NOT EXISTS "+pq.QuoteIdentifier(schema)); err != nil {
+		return fmt.Errorf("failed to create schema %q: %w", schema, err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeedProviderDemo はデモユーザーに常に紐付ける identity の provider 名。
+// 実在の IdP と衝突しない値とし、デモデータの識別に使う。
+const SeedProviderDemo = "demo"
+
+// seedNamespace はデモデータの ID を決定的に導出するための名前空間 UUID。
+// 同じ名前からは常に同じ ID が得られるため、シーダーを繰り返し実行しても行が増えない。
+var seedNamespace = uuid.MustParse("6f1d2c3e-8a4b-4f5e-9c7d-0e1f2a3b4c5d")
+
+// SeedOptions はシーダーの挙動を指定する。
+type SeedOptions struct {
+	// GoogleUserID が空でない場合、デモユーザーに provider=google の identity を追加で紐付ける。
+	// 指定した Google アカウントでログインするとデモユーザーとしてデータを閲覧できる。
+	// すでに同じ Google アカウントが別ユーザーに紐付いている場合は何もしない。
+	GoogleUserID string
+}
+
+// seedUser はデモユーザーの定義。
+var seedUser = struct {
+	email string
+	name  string
+}{
+	email: "demo@feedman.example",
+	name:  "Demo User",
+}
+
+// seedFeed はデモフィードとその記事の定義。
+type seedFeed struct {
+	feedURL string
+	siteURL string
+	title   string
+	items   []seedItem
+}
+
+// seedItem はデモ記事と、デモユーザーにとっての既読・スター状態の定義。
+type seedItem struct {
+	guid    string
+	title   string
+	summary string
+	author  string
+	// age は公開日時を最初の投入時刻からどれだけ遡らせるか。
+	age     time.Duration
+	read    bool
+	starred bool
+}
+
+// seedFeeds は投入するデモフィードの一覧。
+// フィード URL は予約ドメイン（.example）とし、ワーカーが取得しないよう停止状態で投入する。
+var seedFeeds = []seedFeed{
+	{
+		feedURL: "https://tech.feedman.example/feed.xml",
+		siteURL: "https://tech.feedman.example/",
+		title:   "Demo Tech Blog",
+		items: []seedItem{
+			{guid: "tech-1", title: "Go 1.25 の新機能まとめ", summary: "最新リリースで追加された機能を紹介します。", author: "Alice", age: 2 * time.Hour},
+			{guid: "tech-2", title: "PostgreSQL の部分インデックス入門", summary: "WHERE 句付きインデックスの使いどころ。", author: "Bob", age: 26 * time.Hour, starred: true},
+			{guid: "tech-3", title: "RSS と Atom の違い", summary: "フィード形式の歴史と仕様の差異。", author: "Alice", age: 3 * 24 * time.Hour, read: true},
+			{guid: "tech-4", title: "コンテナイメージを小さく保つコツ", summary: "distroless とマルチステージビルド。", author: "Carol", age: 5 * 24 * time.Hour, read: true, starred: true},
+		},
+	},
+	{
+		feedURL: "https://news.feedman.example/rss",
+		siteURL: "https://news.feedman.example/",
+		title:   "Demo News",
+		items: []seedItem{
+			{guid: "news-1", title: "本日のトップニュース", summary: "今日の主な出来事をまとめました。", author: "編集部", age: 30 * time.Minute},
+			{guid: "news-2", title: "週末の天気予報", summary: "全国的に晴れの見込みです。", author: "編集部", age: 8 * time.Hour},
+			{guid: "news-3", title: "新しい図書館がオープン", summary: "駅前に市立図書館が開館しました。", author: "編集部", age: 2 * 24 * time.Hour, read: true},
+		},
+	},
+	{
+		feedURL: "https://cooking.feedman.example/atom.xml",
+		siteURL: "https://cooking.feedman.example/",
+		title:   "Demo Cooking",
+		items: []seedItem{
+			{guid: "cooking-1", title: "10 分でできる親子丼", summary: "忙しい日の定番レシピ。", author: "Dave", age: 12 * time.Hour},
+			{guid: "cooking-2", title: "ぬか漬けの始め方", summary: "ぬか床の作り方と手入れ。", author: "Dave", age: 4 * 24 * time.Hour, starred: true},
+		},
+	},
+}
+
+// seedID は kind と name から決定的な UUID を導出する。
+func seedID(kind, name string) uuid.UUID {
+	return uuid.NewSHA1(seedNamespace, []byte(kind+":"+name))
+}
+
+// Seed はローカル開発とデモ用のユーザー・フィード・記事・購読・既読状態を投入する。
+// すべての行は決定的な ID と ON CONFLICT DO NOTHING で投入するため冪等に実行でき、
+// 既存の行（デモユーザーが変更した既読状態など）は上書きしない。
+// マイグレーション適用済みのデータベースに対して実行すること。
+func Seed(ctx context.Context, db *sql.DB, opts SeedOptions) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin seed transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	userID := seedID("user", seedUser.email)
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO users (id, email, name) VALUES ($1, $2, $3)
+		 ON CONFLICT (id) DO NOTHING`,
+		userID, seedUser.email, seedUser.name,
+	); err != nil {
+		return fmt.Errorf("failed to seed user: %w", err)
+	}
+
+	identities := [][2]string{{SeedProviderDemo, seedUser.email}}
+	if opts.GoogleUserID != "" {
+		identities = append(identities, [2]string{"google", opts.GoogleUserID})
+	}
+	for _, ident := range identities {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO identities (id, user_id, provider, provider_user_id) VALUES ($1, $2, $3, $4)
+			 ON CONFLICT DO NOTHING`,
+			seedID("identity", ident[0]+":"+ident[1]), userID, ident[0], ident[1],
+		); err != nil {
+			return fmt.Errorf("failed to seed identity (provider=%s): %w", ident[0], err)
+		}
+	}
+
+	for _, f := range seedFeeds {
+		if err := seedOneFeed(ctx, tx, userID, f, now); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit seed transaction: %w", err)
+	}
+	return nil
+}
+
+// seedOneFeed は1件のデモフィードと、その購読・記事・既読状態を投入する。
+func seedOneFeed(ctx context.Context, tx *sql.Tx, userID uuid.UUID, f seedFeed, now time.Time) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO feeds (id, feed_url, site_url, title, fetch_status) VALUES ($1, $2, $3, $4, 'stopped')
+		 ON CONFLICT DO NOTHING`,
+		seedID("feed", f.feedURL), f.feedURL, f.siteURL, f.title,
+	); err != nil {
+		return fmt.Errorf("failed to seed feed %s: %w", f.feedURL, err)
+	}
+
+	// 同じ URL のフィードが別 ID で既に登録されている場合はそちらを使う
+	var feedID uuid.UUID
+	if err := tx.QueryRowContext(ctx,
+		`SELECT id FROM feeds WHERE feed_url = $1`, f.feedURL,
+	).Scan(&feedID); err != nil {
+		return fmt.Errorf("failed to look up seeded feed %s: %w", f.feedURL, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO subscriptions (id, user_id, feed_id) VALUES ($1, $2, $3)
+		 ON CONFLICT DO NOTHING`,
+		seedID("subscription", f.feedURL), userID, feedID,
+	); err != nil {
+		return fmt.Errorf("failed to seed subscription for %s: %w", f.feedURL, err)
+	}
+
+	for _, it := range f.items {
+		link := f.siteURL + it.guid
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO items (id, feed_id, guid_or_id, link, title, summary, content, author, published_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8)
+			 ON CONFLICT DO NOTHING`,
+			seedID("item", f.feedURL+"#"+it.guid), feedID, it.guid, link, it.title, it.summary, it.author, now.Add(-it.age),
+		); err != nil {
+			return fmt.Errorf("failed to seed item %s: %w", link, err)
+		}
+
+		if !it.read && !it.starred {
+			continue
+		}
+		var itemID uuid.UUID
+		if err := tx.QueryRowContext(ctx,
+			`SELECT id FROM items WHERE feed_id = $1 AND guid_or_id = $2`, feedID, it.guid,
+		).Scan(&itemID); err != nil {
+			return fmt.Errorf("failed to look up seeded item %s: %w", link, err)
+		}
+		// スター付きは評価 1 以上として扱う互換規則に合わせ、スター付きの記事は評価 1 で投入する
+		rating := 0
+		if it.starred {
+			rating = 1
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO item_states (id, user_id, item_id, is_read, is_starred, rating) VALUES ($1, $2, $3, $4, $5, $6)
+			 ON CONFLICT DO NOTHING`,
+			seedID("item_state", f.feedURL+"#"+it.guid), userID, itemID, it.read, it.starred, rating,
+		); err != nil {
+			return fmt.Errorf("failed to seed item state for %s: %w", link, err)
+		}
+	}
+
+	return nil
+}
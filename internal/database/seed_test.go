@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSeed_Idempotent(t *testing.T) {
+	db, dbURL := setupTestDB(t)
+	defer db.Close()
+
+	if err := RunMigrations(dbURL); err != nil {
+		t.Fatalf("マイグレーション実行に失敗: %v", err)
+	}
+
+	counts := func() map[string]int {
+		t.Helper()
+		queries := map[string]string{
+			"users":         "SELECT COUNT(*) FROM users",
+			"identities":    "SELECT COUNT(*) FROM identities",
+			"feeds":         "SELECT COUNT(*) FROM feeds",
+			"subscriptions": "SELECT COUNT(*) FROM subscriptions",
+			"items":         "SELECT COUNT(*) FROM items",
+			"item_states":   "SELECT COUNT(*) FROM item_states",
+		}
+		got := make(map[string]int, len(queries))
+		for table, q := range queries {
+			var n int
+			if err := db.QueryRow(q).Scan(&n); err != nil {
+				t.Fatalf("%s の件数取得に失敗: %v", table, err)
+			}
+			got[table] = n
+		}
+		return got
+	}
+
+	t.Run("初回実行のときデモデータが投入される", func(t *testing.T) {
+		// Act
+		if err := Seed(context.Background(), db, SeedOptions{}); err != nil {
+			t.Fatalf("Seed に失敗: %v", err)
+		}
+
+		// Assert
+		wantItems := 0
+		for _, f := range seedFeeds {
+			wantItems += len(f.items)
+		}
+		got := counts()
+		if got["users"] != 1 {
+			t.Errorf("users = %d, want 1", got["users"])
+		}
+		if got["feeds"] != len(seedFeeds) || got["subscriptions"] != len(seedFeeds) {
+			t.Errorf("feeds = %d, subscriptions = %d, want %d", got["feeds"], got["subscriptions"], len(seedFeeds))
+		}
+		if got["items"] != wantItems {
+			t.Errorf("items = %d, want %d", got["items"], wantItems)
+		}
+		if got["item_states"] == 0 {
+			t.Error("item_states が投入されていません")
+		}
+		var mismatched int
+		if err := db.QueryRow("SELECT COUNT(*) FROM item_states WHERE is_starred <> (rating >= 1)").Scan(&mismatched); err != nil {
+			t.Fatalf("スターと評価の整合の確認に失敗: %v", err)
+		}
+		if mismatched != 0 {
+			t.Errorf("is_starred と rating が一致しない item_states = %d, want 0", mismatched)
+		}
+	})
+
+	t.Run("再実行のとき行が増えず既存の既読状態も上書きされない", func(t *testing.T) {
+		// Arrange: デモユーザーが全記事を未読に戻した状態を作る
+		if _, err := db.Exec("UPDATE item_states SET is_read = false"); err != nil {
+			t.Fatalf("item_states の更新に失敗: %v", err)
+		}
+		before := counts()
+
+		// Act
+		if err := Seed(context.Background(), db, SeedOptions{}); err != nil {
+			t.Fatalf("2回目の Seed に失敗: %v", err)
+		}
+
+		// Assert
+		after := counts()
+		for table, n := range before {
+			if after[table] != n {
+				t.Errorf("%s = %d, want %d（冪等でない）", table, after[table], n)
+			}
+		}
+		var read int
+		if err := db.QueryRow("SELECT COUNT(*) FROM item_states WHERE is_read").Scan(&read); err != nil {
+			t.Fatalf("既読件数の取得に失敗: %v", err)
+		}
+		if read != 0 {
+			t.Errorf("既読件数 = %d, want 0（既存の状態が上書きされた）", read)
+		}
+	})
+
+	t.Run("GoogleUserIDを指定したときgoogleのidentityが追加で紐付く", func(t *testing.T) {
+		// Act
+		if err := Seed(context.Background(), db, SeedOptions{GoogleUserID: "google-sub-123"}); err != nil {
+			t.Fatalf("Seed に失敗: %v", err)
+		}
+
+		// Assert
+		var userID string
+		err := db.QueryRow(
+			"SELECT user_id FROM identities WHERE provider = 'google' AND provider_user_id = $1",
+			"google-sub-123",
+		).Scan(&userID)
+		if err != nil {
+			t.Fatalf("google identity の取得に失敗: %v", err)
+		}
+		if userID != seedID("user", seedUser.email).String() {
+			t.Errorf("user_id = %s, want デモユーザー", userID)
+		}
+	})
+}
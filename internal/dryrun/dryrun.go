@@ -0,0 +1,177 @@
+// Package dryrun は worker のドライランモード（`feedman worker --dry-run`）で使う、
+// 書き込みを DB に反映しないリポジトリを提供する。
+//
+// 各リポジトリは読み取りを元のリポジトリへ委譲し、書き込みは実行せずに Recorder へ記録してログに出力する。
+// Recorder は記録した書き込みを保持するため、フェッチ・はてブバッチの RunOnce のテストで
+// 「何を書き込もうとしたか」を検証する用途にも使える。
+package dryrun
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// 記録する書き込みの操作名。
+const (
+	OpCreateFeed                  = "create_feed"
+	OpUpdateFeed                  = "update_feed"
+	OpUpdateFavicon               = "update_favicon"
+	OpUpdateFetchState            = "update_fetch_state"
+	OpUpdateLastSuccessfulFetchAt = "update_last_successful_fetch_at"
+	OpCreateItem                  = "create_item"
+	OpUpdateItem                  = "update_item"
+	OpUpdateHatebuCount           = "update_hatebu_count"
+)
+
+// Write は省略した書き込み 1 件。
+type Write struct {
+	// Op は操作名（Op* 定数）。
+	Op string
+	// Target は書き込み対象の ID（フィード ID・記事 ID）。
+	Target string
+	// Attrs は書き込もうとした値の要約。ログにもそのまま出力する。
+	Attrs []slog.Attr
+}
+
+// Recorder は省略した書き込みをログに出力して保持する。複数のゴルーチンから同時に使ってよい。
+type Recorder struct {
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	writes []Write
+}
+
+// NewRecorder は Recorder を生成する。
+func NewRecorder(logger *slog.Logger) *Recorder {
+	return &Recorder{logger: logger}
+}
+
+// Writes は記録した書き込みを記録順に返す。
+func (r *Recorder) Writes() []Write {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Write(nil), r.writes...)
+}
+
+// record は書き込みを記録し、ログに出力する。
+func (r *Recorder) record(op, target string, attrs ...slog.Attr) {
+	r.mu.Lock()
+	r.writes = append(r.writes, Write{Op: op, Target: target, Attrs: attrs})
+	r.mu.Unlock()
+
+	args := []any{slog.String("op", op), slog.String("target", target)}
+	for _, a := range attrs {
+		args = append(args, a)
+	}
+	r.logger.Info("ドライランのため書き込みを省略しました", args...)
+}
+
+// FeedRepository は書き込みを Recorder への記録に置き換えた repository.FeedRepository。
+type FeedRepository struct {
+	repository.FeedRepository
+	rec *Recorder
+}
+
+// NewFeedRepository は repo の読み取りを委譲する FeedRepository を生成する。
+func NewFeedRepository(repo repository.FeedRepository, rec *Recorder) *FeedRepository {
+	return &FeedRepository{FeedRepository: repo, rec: rec}
+}
+
+// Create はフィードを作成せずに記録する。
+func (r *FeedRepository) Create(_ context.Context, feed *model.Feed) error {
+	r.rec.record(OpCreateFeed, feed.ID, slog.String("feed_url", feed.FeedURL))
+	return nil
+}
+
+// Update はフィードを更新せずに記録する。
+func (r *FeedRepository) Update(_ context.Context, feed *model.Feed) error {
+	r.rec.record(OpUpdateFeed, feed.ID, slog.String("title", feed.Title))
+	return nil
+}
+
+// UpdateFavicon は favicon を更新せずに記録する。
+func (r *FeedRepository) UpdateFavicon(_ context.Context, feedID string, faviconData []byte, faviconMime string) error {
+	r.rec.record(OpUpdateFavicon, feedID, slog.Int("size", len(faviconData)), slog.String("mime", faviconMime))
+	return nil
+}
+
+// UpdateFetchState はフェッチ状態を更新せずに記録する。
+func (r *FeedRepository) UpdateFetchState(_ context.Context, feed *model.Feed) error {
+	r.rec.record(OpUpdateFetchState, feed.ID,
+		slog.String("fetch_status", string(feed.FetchStatus)),
+		slog.Int("consecutive_errors", feed.ConsecutiveErrors),
+		slog.String("error_message", feed.ErrorMessage),
+		slog.Time("next_fetch_at", feed.NextFetchAt),
+	)
+	return nil
+}
+
+// UpdateLastSuccessfulFetchAt は最終成功日時を更新せずに記録する。
+func (r *FeedRepository) UpdateLastSuccessfulFetchAt(_ context.Context, feedID string, at time.Time) error {
+	r.rec.record(OpUpdateLastSuccessfulFetchAt, feedID, slog.Time("at", at))
+	return nil
+}
+
+// ItemRepository は書き込みを Recorder への記録に置き換えた repository.ItemRepository。
+type ItemRepository struct {
+	repository.ItemRepository
+	rec *Recorder
+}
+
+// NewItemRepository は repo の読み取りを委譲する ItemRepository を生成する。
+func NewItemRepository(repo repository.ItemRepository, rec *Recorder) *ItemRepository {
+	return &ItemRepository{ItemRepository: repo, rec: rec}
+}
+
+// Create は記事を作成せずに記録する。
+func (r *ItemRepository) Create(_ context.Context, item *model.Item) error {
+	r.recordItem(OpCreateItem, item)
+	return nil
+}
+
+// Update は記事を更新せずに記録する。
+func (r *ItemRepository) Update(_ context.Context, item *model.Item) error {
+	r.recordItem(OpUpdateItem, item)
+	return nil
+}
+
+// BulkUpsert は記事を作成・更新せずに 1 件ずつ記録する。
+func (r *ItemRepository) BulkUpsert(_ context.Context, toCreate, toUpdate []*model.Item) error {
+	for _, item := range toCreate {
+		r.recordItem(OpCreateItem, item)
+	}
+	for _, item := range toUpdate {
+		r.recordItem(OpUpdateItem, item)
+	}
+	return nil
+}
+
+func (r *ItemRepository) recordItem(op string, item *model.Item) {
+	r.rec.record(op, item.ID,
+		slog.String("feed_id", item.FeedID),
+		slog.String("title", item.Title),
+		slog.String("link", item.Link),
+	)
+}
+
+// HatebuItemRepository は書き込みを Recorder への記録に置き換えた repository.HatebuItemRepository。
+type HatebuItemRepository struct {
+	repository.HatebuItemRepository
+	rec *Recorder
+}
+
+// NewHatebuItemRepository は repo の読み取りを委譲する HatebuItemRepository を生成する。
+func NewHatebuItemRepository(repo repository.HatebuItemRepository, rec *Recorder) *HatebuItemRepository {
+	return &HatebuItemRepository{HatebuItemRepository: repo, rec: rec}
+}
+
+// UpdateHatebuCount ははてなブックマーク数を更新せずに記録する。
+func (r *HatebuItemRepository) UpdateHatebuCount(_ context.Context, itemID string, count int, fetchedAt time.Time) error {
+	r.rec.record(OpUpdateHatebuCount, itemID, slog.Int("count", count), slog.Time("fetched_at", fetchedAt))
+	return nil
+}
@@ -0,0 +1,121 @@
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// stubFeedRepo は FindByID のみを実装する FeedRepository。書き込みが委譲されるとパニックする。
+type stubFeedRepo struct {
+	repository.FeedRepository
+}
+
+func (stubFeedRepo) FindByID(_ context.Context, id string) (*model.Feed, error) {
+	return &model.Feed{ID: id}, nil
+}
+
+// stubHatebuRepo は ListNeedingHatebuFetch のみを実装する HatebuItemRepository。
+type stubHatebuRepo struct {
+	repository.HatebuItemRepository
+}
+
+func (stubHatebuRepo) ListNeedingHatebuFetch(context.Context, int, model.HatebuFetchPriority) ([]*model.Item, error) {
+	return []*model.Item{{ID: "item-1"}}, nil
+}
+
+func TestFeedRepository(t *testing.T) {
+	t.Run("読み取りは元のリポジトリに委譲するとき", func(t *testing.T) {
+		// Arrange
+		repo := NewFeedRepository(stubFeedRepo{}, NewRecorder(slog.New(slog.DiscardHandler)))
+
+		// Act
+		feed, err := repo.FindByID(context.Background(), "feed-1")
+
+		// Assert
+		if err != nil || feed == nil || feed.ID != "feed-1" {
+			t.Errorf("FindByID() = %+v, %v", feed, err)
+		}
+	})
+
+	t.Run("書き込みは委譲せず記録してログに出力するとき", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		rec := NewRecorder(slog.New(slog.NewTextHandler(&buf, nil)))
+		repo := NewFeedRepository(stubFeedRepo{}, rec)
+		at := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		err1 := repo.UpdateFetchState(context.Background(), &model.Feed{ID: "feed-1", FetchStatus: model.FetchStatusActive})
+		err2 := repo.UpdateLastSuccessfulFetchAt(context.Background(), "feed-1", at)
+
+		// Assert
+		if err1 != nil || err2 != nil {
+			t.Fatalf("errors = %v, %v", err1, err2)
+		}
+		writes := rec.Writes()
+		if len(writes) != 2 || writes[0].Op != OpUpdateFetchState || writes[1].Op != OpUpdateLastSuccessfulFetchAt {
+			t.Fatalf("writes = %+v", writes)
+		}
+		if writes[0].Target != "feed-1" {
+			t.Errorf("target = %q, want feed-1", writes[0].Target)
+		}
+		if !strings.Contains(buf.String(), "op=update_fetch_state") || !strings.Contains(buf.String(), "fetch_status=active") {
+			t.Errorf("log = %s", buf.String())
+		}
+	})
+}
+
+func TestItemRepository_BulkUpsert(t *testing.T) {
+	// Arrange
+	rec := NewRecorder(slog.New(slog.DiscardHandler))
+	repo := NewItemRepository(nil, rec)
+
+	// Act
+	err := repo.BulkUpsert(context.Background(),
+		[]*model.Item{{ID: "new-1"}, {ID: "new-2"}},
+		[]*model.Item{{ID: "old-1"}},
+	)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("BulkUpsert() error = %v", err)
+	}
+	writes := rec.Writes()
+	want := []Write{{Op: OpCreateItem, Target: "new-1"}, {Op: OpCreateItem, Target: "new-2"}, {Op: OpUpdateItem, Target: "old-1"}}
+	if len(writes) != len(want) {
+		t.Fatalf("writes = %+v", writes)
+	}
+	for i, w := range want {
+		if writes[i].Op != w.Op || writes[i].Target != w.Target {
+			t.Errorf("writes[%d] = %s %s, want %s %s", i, writes[i].Op, writes[i].Target, w.Op, w.Target)
+		}
+	}
+}
+
+func TestHatebuItemRepository(t *testing.T) {
+	// Arrange
+	rec := NewRecorder(slog.New(slog.DiscardHandler))
+	repo := NewHatebuItemRepository(stubHatebuRepo{}, rec)
+
+	// Act
+	items, listErr := repo.ListNeedingHatebuFetch(context.Background(), 10, model.HatebuFetchPriority{})
+	updateErr := repo.UpdateHatebuCount(context.Background(), "item-1", 42, time.Now())
+
+	// Assert
+	if listErr != nil || len(items) != 1 {
+		t.Errorf("ListNeedingHatebuFetch() = %v, %v", items, listErr)
+	}
+	if updateErr != nil {
+		t.Errorf("UpdateHatebuCount() error = %v", updateErr)
+	}
+	if writes := rec.Writes(); len(writes) != 1 || writes[0].Op != OpUpdateHatebuCount || writes[0].Target != "item-1" {
+		t.Errorf("writes = %+v", writes)
+	}
+}
@@ -0,0 +1,36 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// AuditRecorder は購読操作を監査ログに記録するインターフェース。
+// audit.Service が実装する。記録の失敗は実装側で処理し、フィード登録を失敗させない。
+type AuditRecorder interface {
+	Record(ctx context.Context, userID string, action model.AuditAction, target string, payload map[string]any)
+}
+
+// WithAuditRecorder はフィード登録による購読作成を監査ログに記録する recorder を設定する。
+func WithAuditRecorder(r AuditRecorder) FeedServiceOption {
+	return func(s *FeedService) {
+		s.auditRecorder = r
+	}
+}
+
+// recordSubscriptionCreated は AuditRecorder が設定されていれば購読作成を監査ログに記録する。
+func (s *FeedService) recordSubscriptionCreated(ctx context.Context, feed *model.Feed, sub *model.Subscription) {
+	if s.auditRecorder == nil {
+		return
+	}
+	details := map[string]any{
+		"subscription_id": sub.ID,
+		"feed_url":        feed.FeedURL,
+	}
+	if sub.ExpiresAt != nil {
+		details["expires_at"] = sub.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	s.auditRecorder.Record(ctx, sub.UserID, model.AuditActionSubscriptionCreate, feed.ID, details)
+}
@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockAuditRecorder は AuditRecorder のモック実装。
+type mockAuditRecorder struct {
+	actions  []model.AuditAction
+	userIDs  []string
+	targets  []string
+	payloads []map[string]any
+}
+
+func (m *mockAuditRecorder) Record(_ context.Context, userID string, action model.AuditAction, target string, payload map[string]any) {
+	m.actions = append(m.actions, action)
+	m.userIDs = append(m.userIDs, userID)
+	m.targets = append(m.targets, target)
+	m.payloads = append(m.payloads, payload)
+}
+
+func TestFeedService_RegisterFeed_AuditRecorder(t *testing.T) {
+	t.Run("購読を作成したときフィードIDを対象として記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		svc := NewFeedService(newMockFeedRepo(), newMockSubRepo(),
+			&mockDetector{feedURL: "https://example.com/feed.xml"}, &mockFaviconFetcher{},
+			WithAuditRecorder(recorder),
+		)
+
+		// Act
+		feed, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
+		svc.faviconWG.Wait()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RegisterFeed returned error: %v", err)
+		}
+		if len(recorder.actions) != 1 {
+			t.Fatalf("records = %d, want 1", len(recorder.actions))
+		}
+		if recorder.actions[0] != model.AuditActionSubscriptionCreate {
+			t.Errorf("action = %q, want %q", recorder.actions[0], model.AuditActionSubscriptionCreate)
+		}
+		if recorder.userIDs[0] != "user-1" || recorder.targets[0] != feed.ID {
+			t.Errorf("userID, target = %q, %q", recorder.userIDs[0], recorder.targets[0])
+		}
+		payload := recorder.payloads[0]
+		if payload["subscription_id"] != sub.ID || payload["feed_url"] != "https://example.com/feed.xml" {
+			t.Errorf("payload = %v", payload)
+		}
+	})
+
+	t.Run("重複購読で失敗したとき記録しない", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		feedRepo := newMockFeedRepo()
+		existing := &model.Feed{ID: "feed-1", FeedURL: "https://example.com/feed.xml"}
+		feedRepo.feeds[existing.ID] = existing
+		feedRepo.feedByURL[existing.FeedURL] = existing
+		subRepo := newMockSubRepo()
+		subRepo.subs["sub-1"] = &model.Subscription{ID: "sub-1", UserID: "user-1", FeedID: "feed-1"}
+		svc := NewFeedService(feedRepo, subRepo,
+			&mockDetector{feedURL: "https://example.com/feed.xml"}, &mockFaviconFetcher{},
+			WithAuditRecorder(recorder),
+		)
+
+		// Act
+		_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected duplicate subscription error")
+		}
+		if len(recorder.actions) != 0 {
+			t.Errorf("records = %v, want none", recorder.actions)
+		}
+	})
+}
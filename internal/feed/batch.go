@@ -0,0 +1,264 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	// defaultBatchConcurrency は一括登録でフィード検出を同時に実行する URL 数。
+	defaultBatchConcurrency = 4
+	// batchProcessingTimeout は一括登録 1 回分の処理の上限時間。
+	// これを過ぎても完了していない一括登録は、プロセスの停止等で中断したものとみなす。
+	batchProcessingTimeout = 5 * time.Minute
+)
+
+// BatchRegistrationService は購読ゼロのユーザー向けに、貼り付けた複数の URL をまとめて登録する。
+// Start は URL を pending で記録して即座に戻り、検出・登録はバックグラウンドで進める。
+// 処理結果は URL ごとに feed_registration_batches へ書き込み、Get でポーリングして確認する。
+// 1 件ずつの登録と同じく FeedService の検証・検出・購読作成を用いる。
+type BatchRegistrationService struct {
+	feeds       *FeedService
+	repo        repository.FeedRegistrationBatchRepository
+	logger      *slog.Logger
+	now         func() time.Time
+	concurrency int
+
+	// subscribeMu は購読上限の確認と購読作成を直列化し、同時に処理中の一括登録が上限を超えて購読しないようにする。
+	subscribeMu sync.Mutex
+
+	// wg はバックグラウンドの一括登録処理の完了を追跡する。テストから完了を待つために用いる。
+	wg sync.WaitGroup
+}
+
+// NewBatchRegistrationService は BatchRegistrationService を生成する。
+func NewBatchRegistrationService(feeds *FeedService, repo repository.FeedRegistrationBatchRepository, logger *slog.Logger) *BatchRegistrationService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &BatchRegistrationService{
+		feeds:       feeds,
+		repo:        repo,
+		logger:      logger,
+		now:         time.Now,
+		concurrency: defaultBatchConcurrency,
+	}
+}
+
+// Start は URL の一括登録を開始し、全件が pending の一括登録を返す。
+// 前後の空白を除いて空の URL は無視し、残りが 0 件または MaxBatchFeedURLs 件を超える場合は INVALID_BATCH_FEED_URLS を返す。
+// 検出・登録はリクエストから切り離した context で非同期に実行する。
+func (s *BatchRegistrationService) Start(ctx context.Context, userID string, urls []string) (*model.FeedRegistrationBatch, error) {
+	var entries []model.FeedRegistrationBatchEntry
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		entries = append(entries, model.FeedRegistrationBatchEntry{URL: u, Status: model.FeedRegistrationPending})
+	}
+	if len(entries) == 0 {
+		return nil, model.NewInvalidBatchFeedURLsError("URL を 1 件以上指定してください")
+	}
+	if len(entries) > model.MaxBatchFeedURLs {
+		return nil, model.NewInvalidBatchFeedURLsError(fmt.Sprintf("一度に登録できる URL は %d 件までです", model.MaxBatchFeedURLs))
+	}
+
+	batch := &model.FeedRegistrationBatch{UserID: userID, Entries: entries}
+	if err := s.repo.Create(ctx, batch, s.now().Add(-model.FeedRegistrationBatchRetention)); err != nil {
+		return nil, fmt.Errorf("一括登録の作成に失敗しました: %w", err)
+	}
+
+	// 処理中に書き換えるため、呼び出し元へ返す batch とは別のコピーを渡す
+	work := *batch
+	work.Entries = append([]model.FeedRegistrationBatchEntry(nil), batch.Entries...)
+
+	bgCtx := context.WithoutCancel(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		timeoutCtx, cancel := context.WithTimeout(bgCtx, batchProcessingTimeout)
+		defer cancel()
+		s.process(timeoutCtx, &work)
+	}()
+
+	return batch, nil
+}
+
+// Get は当該ユーザーの一括登録を返す。存在しない場合は FEED_REGISTRATION_BATCH_NOT_FOUND を返す。
+// 処理の上限時間を過ぎても完了していない一括登録は、未処理の URL を failed として返す。
+func (s *BatchRegistrationService) Get(ctx context.Context, userID, batchID string) (*model.FeedRegistrationBatch, error) {
+	batch, err := s.repo.FindByUser(ctx, userID, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("一括登録の取得に失敗しました: %w", err)
+	}
+	if batch == nil {
+		return nil, model.NewFeedRegistrationBatchNotFoundError(batchID)
+	}
+
+	if batch.CompletedAt == nil && s.now().Sub(batch.CreatedAt) > batchProcessingTimeout {
+		for i := range batch.Entries {
+			if batch.Entries[i].Status == model.FeedRegistrationPending {
+				batch.Entries[i].Status = model.FeedRegistrationFailed
+				batch.Entries[i].Error = internalBatchError()
+			}
+		}
+		completedAt := batch.CreatedAt.Add(batchProcessingTimeout)
+		batch.CompletedAt = &completedAt
+	}
+	return batch, nil
+}
+
+// Wait は進行中のバックグラウンドの一括登録処理の完了を待つ。
+// 本番フローでは呼ばれず、非同期完了を検証したいテストからのみ利用する。
+func (s *BatchRegistrationService) Wait() {
+	s.wg.Wait()
+}
+
+// detection は URL 1 件分のフィード検出結果。
+type detection struct {
+	feedURL string
+	err     error
+}
+
+// process は一括登録の各 URL を検出・登録し、URL ごとの結果を記録する。
+// 検出は concurrency 件ずつ並行して行い、検出できなかった URL はその時点で結果を記録する。
+// 購読の作成はリクエストの URL の順に行うため、購読上限に達した場合は後ろの URL が limit_exceeded になる。
+func (s *BatchRegistrationService) process(ctx context.Context, batch *model.FeedRegistrationBatch) {
+	defer func() {
+		if err := s.repo.Complete(ctx, batch.ID, s.now()); err != nil {
+			s.logger.Error("一括登録の完了の記録に失敗しました",
+				slog.String("batch_id", batch.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+
+	// すでに上限に達している場合は検出せずに全件を limit_exceeded とする
+	if err := s.feeds.checkSubscriptionLimit(ctx, batch.UserID); err != nil {
+		for i := range batch.Entries {
+			s.updateEntry(ctx, batch, i, s.entryFromError(batch.Entries[i].URL, err))
+		}
+		return
+	}
+
+	detections := make([]detection, len(batch.Entries))
+	sem := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+	for i := range batch.Entries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			inputURL := batch.Entries[i].URL
+			feedURL, err := s.detect(ctx, inputURL)
+			detections[i] = detection{feedURL: feedURL, err: err}
+			if err != nil {
+				s.updateEntry(ctx, batch, i, s.entryFromError(inputURL, err))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, d := range detections {
+		if d.err != nil {
+			continue
+		}
+		s.updateEntry(ctx, batch, i, s.register(ctx, batch.UserID, batch.Entries[i].URL, d.feedURL))
+	}
+}
+
+// detect は入力 URL を検証してフィード URL を検出する。
+func (s *BatchRegistrationService) detect(ctx context.Context, inputURL string) (string, error) {
+	if err := s.feeds.checkInputURL(ctx, inputURL); err != nil {
+		return "", err
+	}
+	return s.feeds.detectFeedURL(ctx, inputURL)
+}
+
+// register は購読上限を確認したうえで検出済みのフィードを購読し、その結果を返す。
+func (s *BatchRegistrationService) register(ctx context.Context, userID, inputURL, feedURL string) model.FeedRegistrationBatchEntry {
+	s.subscribeMu.Lock()
+	defer s.subscribeMu.Unlock()
+
+	if err := s.feeds.checkSubscriptionLimit(ctx, userID); err != nil {
+		return s.entryFromError(inputURL, err)
+	}
+	feed, _, err := s.feeds.subscribe(ctx, userID, inputURL, feedURL, model.RegisterFeedOptions{})
+	if err != nil {
+		entry := s.entryFromError(inputURL, err)
+		if entry.Status == model.FeedRegistrationDuplicate {
+			// 購読済みのフィードは ID を返し、クライアントが購読一覧の該当フィードを示せるようにする
+			if existing, findErr := s.feeds.feedRepo.FindByFeedURL(ctx, feedURL); findErr == nil && existing != nil {
+				entry.FeedID = existing.ID
+			}
+		}
+		return entry
+	}
+	return model.FeedRegistrationBatchEntry{URL: inputURL, Status: model.FeedRegistrationRegistered, FeedID: feed.ID}
+}
+
+// updateEntry は index 番目の URL の結果を記録する。記録に失敗しても残りの URL の処理は続ける。
+func (s *BatchRegistrationService) updateEntry(ctx context.Context, batch *model.FeedRegistrationBatch, index int, entry model.FeedRegistrationBatchEntry) {
+	if err := s.repo.UpdateEntry(ctx, batch.ID, index, entry); err != nil {
+		s.logger.Error("一括登録の結果の記録に失敗しました",
+			slog.String("batch_id", batch.ID),
+			slog.Int("index", index),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// entryFromError は登録時のエラーを URL 1 件分の結果に変換する。
+// APIError 以外のエラーは詳細をログに残し、INTERNAL_ERROR として返す。
+func (s *BatchRegistrationService) entryFromError(inputURL string, err error) model.FeedRegistrationBatchEntry {
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) {
+		s.logger.Error("一括登録でフィードの登録に失敗しました",
+			slog.String("url", inputURL),
+			slog.String("error", err.Error()),
+		)
+		return model.FeedRegistrationBatchEntry{URL: inputURL, Status: model.FeedRegistrationFailed, Error: internalBatchError()}
+	}
+	return model.FeedRegistrationBatchEntry{URL: inputURL, Status: registrationStatusOf(apiErr.Code), Error: apiErr}
+}
+
+// registrationStatusOf はエラーコードに対応する一括登録の結果を返す。
+func registrationStatusOf(code string) model.FeedRegistrationStatus {
+	switch code {
+	case model.ErrCodeDuplicateSubscription:
+		return model.FeedRegistrationDuplicate
+	case model.ErrCodeSubscriptionLimit:
+		return model.FeedRegistrationLimitExceeded
+	case model.ErrCodeFeedNotDetected,
+		model.ErrCodeInvalidURL,
+		model.ErrCodeInvalidURLScheme,
+		model.ErrCodeSSRFBlocked,
+		model.ErrCodeFetchFailed,
+		model.ErrCodeParseFailed,
+		model.ErrCodeFeedRedirectLoop:
+		return model.FeedRegistrationNotDetected
+	default:
+		return model.FeedRegistrationFailed
+	}
+}
+
+// internalBatchError は内部エラーで登録できなかった URL に付与するエラーを返す。
+func internalBatchError() *model.APIError {
+	return &model.APIError{
+		Code:     model.ErrCodeInternal,
+		Message:  "内部エラーが発生しました。",
+		Category: "system",
+		Action:   "しばらく待ってから再度お試しください。",
+	}
+}
@@ -0,0 +1,294 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockBatchRepo はテスト用の FeedRegistrationBatchRepository モック。
+// 検出は並行して実行されるため、mu で全フィールドを保護する。
+type mockBatchRepo struct {
+	mu            sync.Mutex
+	batches       map[string]*model.FeedRegistrationBatch
+	expiredBefore time.Time
+	createErr     error
+}
+
+func newMockBatchRepo() *mockBatchRepo {
+	return &mockBatchRepo{batches: make(map[string]*model.FeedRegistrationBatch)}
+}
+
+func (m *mockBatchRepo) Create(_ context.Context, batch *model.FeedRegistrationBatch, expiredBefore time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.createErr != nil {
+		return m.createErr
+	}
+	batch.ID = fmt.Sprintf("batch-%d", len(m.batches)+1)
+	batch.CreatedAt = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	stored := *batch
+	stored.Entries = append([]model.FeedRegistrationBatchEntry(nil), batch.Entries...)
+	m.batches[batch.ID] = &stored
+	m.expiredBefore = expiredBefore
+	return nil
+}
+
+func (m *mockBatchRepo) UpdateEntry(_ context.Context, batchID string, index int, entry model.FeedRegistrationBatchEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches[batchID].Entries[index] = entry
+	return nil
+}
+
+func (m *mockBatchRepo) Complete(_ context.Context, batchID string, completedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches[batchID].CompletedAt = &completedAt
+	return nil
+}
+
+func (m *mockBatchRepo) FindByUser(_ context.Context, userID, batchID string) (*model.FeedRegistrationBatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.batches[batchID]
+	if !ok || b.UserID != userID {
+		return nil, nil
+	}
+	found := *b
+	found.Entries = append([]model.FeedRegistrationBatchEntry(nil), b.Entries...)
+	return &found, nil
+}
+
+// urlDetector は入力 URL ごとに検出結果を返す Detector モック。未登録の URL は FEED_NOT_DETECTED を返す。
+type urlDetector map[string]string
+
+func (d urlDetector) DetectFeedURL(_ context.Context, inputURL string) (string, error) {
+	if feedURL, ok := d[inputURL]; ok {
+		return feedURL, nil
+	}
+	return "", model.NewFeedNotDetectedError(inputURL)
+}
+
+func newTestBatchService(feedRepo *mockFeedRepo, subRepo *mockSubRepo, detector Detector, repo *mockBatchRepo) *BatchRegistrationService {
+	feeds := NewFeedService(feedRepo, subRepo, detector, nil)
+	var buf bytes.Buffer
+	return NewBatchRegistrationService(feeds, repo, slog.New(slog.NewTextHandler(&buf, nil)))
+}
+
+func TestBatchRegistrationService_Start_ValidatesURLs(t *testing.T) {
+	tooMany := make([]string, model.MaxBatchFeedURLs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	tests := []struct {
+		name string
+		urls []string
+	}{
+		{name: "URL が空のとき", urls: nil},
+		{name: "空白のみの URL しかないとき", urls: []string{"", "  "}},
+		{name: "上限を超えるとき", urls: tooMany},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockBatchRepo()
+			svc := newTestBatchService(newMockFeedRepo(), newMockSubRepo(), urlDetector{}, repo)
+
+			_, err := svc.Start(context.Background(), "user-1", tt.urls)
+
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidBatchFeedURLs {
+				t.Fatalf("err = %v, want INVALID_BATCH_FEED_URLS", err)
+			}
+			if len(repo.batches) != 0 {
+				t.Error("不正な指定のとき一括登録を作成してはならない")
+			}
+		})
+	}
+}
+
+func TestBatchRegistrationService_Start_RegistersEachURL(t *testing.T) {
+	feedRepo := newMockFeedRepo()
+	subRepo := newMockSubRepo()
+	repo := newMockBatchRepo()
+	detector := urlDetector{
+		"https://a.example.com":  "https://a.example.com/feed.xml",
+		"https://a2.example.com": "https://a.example.com/feed.xml",
+		"https://b.example.com":  "https://b.example.com/rss",
+	}
+	svc := newTestBatchService(feedRepo, subRepo, detector, repo)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	batch, err := svc.Start(context.Background(), "user-1", []string{
+		" https://a.example.com ",
+		"",
+		"https://unknown.example.com",
+		"https://a2.example.com",
+		"ftp://b.example.com",
+		"https://b.example.com",
+	})
+	if err != nil {
+		t.Fatalf("Start に失敗: %v", err)
+	}
+	if len(batch.Entries) != 5 {
+		t.Fatalf("Entries = %d 件, want 空行を除いた 5 件", len(batch.Entries))
+	}
+	for i, e := range batch.Entries {
+		if e.Status != model.FeedRegistrationPending {
+			t.Errorf("Entries[%d].Status = %q, want pending", i, e.Status)
+		}
+	}
+	if want := now.Add(-model.FeedRegistrationBatchRetention); !repo.expiredBefore.Equal(want) {
+		t.Errorf("expiredBefore = %v, want %v", repo.expiredBefore, want)
+	}
+
+	svc.Wait()
+
+	got, err := svc.Get(context.Background(), "user-1", batch.ID)
+	if err != nil {
+		t.Fatalf("Get に失敗: %v", err)
+	}
+	if got.CompletedAt == nil {
+		t.Error("処理後も CompletedAt が設定されていない")
+	}
+	feedA := feedRepo.feedByURL["https://a.example.com/feed.xml"]
+	feedB := feedRepo.feedByURL["https://b.example.com/rss"]
+	if feedA == nil || feedB == nil {
+		t.Fatal("検出したフィードが保存されていない")
+	}
+	want := []struct {
+		url    string
+		status model.FeedRegistrationStatus
+		feedID string
+		code   string
+	}{
+		{url: "https://a.example.com", status: model.FeedRegistrationRegistered, feedID: feedA.ID},
+		{url: "https://unknown.example.com", status: model.FeedRegistrationNotDetected, code: model.ErrCodeFeedNotDetected},
+		{url: "https://a2.example.com", status: model.FeedRegistrationDuplicate, feedID: feedA.ID, code: model.ErrCodeDuplicateSubscription},
+		{url: "ftp://b.example.com", status: model.FeedRegistrationNotDetected, code: model.ErrCodeInvalidURLScheme},
+		{url: "https://b.example.com", status: model.FeedRegistrationRegistered, feedID: feedB.ID},
+	}
+	for i, w := range want {
+		e := got.Entries[i]
+		if e.URL != w.url || e.Status != w.status || e.FeedID != w.feedID {
+			t.Errorf("Entries[%d] = {%q %q %q}, want {%q %q %q}", i, e.URL, e.Status, e.FeedID, w.url, w.status, w.feedID)
+		}
+		gotCode := ""
+		if e.Error != nil {
+			gotCode = e.Error.Code
+		}
+		if gotCode != w.code {
+			t.Errorf("Entries[%d].Error.Code = %q, want %q", i, gotCode, w.code)
+		}
+	}
+	if subRepo.countByUser["user-1"] != 2 {
+		t.Errorf("購読数 = %d, want 2", subRepo.countByUser["user-1"])
+	}
+}
+
+func TestBatchRegistrationService_Start_StopsAtSubscriptionLimit(t *testing.T) {
+	feedRepo := newMockFeedRepo()
+	subRepo := newMockSubRepo()
+	subRepo.countByUser["user-1"] = model.MaxSubscriptionsPerUser - 1
+	repo := newMockBatchRepo()
+	detector := urlDetector{
+		"https://a.example.com": "https://a.example.com/feed.xml",
+		"https://b.example.com": "https://b.example.com/feed.xml",
+	}
+	svc := newTestBatchService(feedRepo, subRepo, detector, repo)
+
+	batch, err := svc.Start(context.Background(), "user-1", []string{"https://a.example.com", "https://b.example.com"})
+	if err != nil {
+		t.Fatalf("Start に失敗: %v", err)
+	}
+	svc.Wait()
+
+	got, _ := svc.Get(context.Background(), "user-1", batch.ID)
+	if got.Entries[0].Status != model.FeedRegistrationRegistered {
+		t.Errorf("Entries[0].Status = %q, want registered", got.Entries[0].Status)
+	}
+	if got.Entries[1].Status != model.FeedRegistrationLimitExceeded {
+		t.Errorf("Entries[1].Status = %q, want limit_exceeded", got.Entries[1].Status)
+	}
+}
+
+func TestBatchRegistrationService_Start_AlreadyAtLimit(t *testing.T) {
+	subRepo := newMockSubRepo()
+	subRepo.countByUser["user-1"] = model.MaxSubscriptionsPerUser
+	repo := newMockBatchRepo()
+	svc := newTestBatchService(newMockFeedRepo(), subRepo, urlDetector{}, repo)
+
+	batch, err := svc.Start(context.Background(), "user-1", []string{"https://a.example.com", "https://b.example.com"})
+	if err != nil {
+		t.Fatalf("Start に失敗: %v", err)
+	}
+	svc.Wait()
+
+	got, _ := svc.Get(context.Background(), "user-1", batch.ID)
+	for i, e := range got.Entries {
+		if e.Status != model.FeedRegistrationLimitExceeded {
+			t.Errorf("Entries[%d].Status = %q, want limit_exceeded", i, e.Status)
+		}
+	}
+}
+
+func TestBatchRegistrationService_Get(t *testing.T) {
+	t.Run("他のユーザーの一括登録のとき FEED_REGISTRATION_BATCH_NOT_FOUND を返す", func(t *testing.T) {
+		// Arrange
+		repo := newMockBatchRepo()
+		repo.batches["batch-1"] = &model.FeedRegistrationBatch{ID: "batch-1", UserID: "user-2"}
+		svc := newTestBatchService(newMockFeedRepo(), newMockSubRepo(), urlDetector{}, repo)
+
+		// Act
+		_, err := svc.Get(context.Background(), "user-1", "batch-1")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeFeedRegistrationBatchNotFound {
+			t.Errorf("err = %v, want FEED_REGISTRATION_BATCH_NOT_FOUND", err)
+		}
+	})
+
+	t.Run("上限時間を過ぎても完了していないとき未処理の URL を failed として返す", func(t *testing.T) {
+		// Arrange
+		createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		repo := newMockBatchRepo()
+		repo.batches["batch-1"] = &model.FeedRegistrationBatch{
+			ID:     "batch-1",
+			UserID: "user-1",
+			Entries: []model.FeedRegistrationBatchEntry{
+				{URL: "https://a.example.com", Status: model.FeedRegistrationRegistered, FeedID: "feed-1"},
+				{URL: "https://b.example.com", Status: model.FeedRegistrationPending},
+			},
+			CreatedAt: createdAt,
+		}
+		svc := newTestBatchService(newMockFeedRepo(), newMockSubRepo(), urlDetector{}, repo)
+		svc.now = func() time.Time { return createdAt.Add(batchProcessingTimeout + time.Minute) }
+
+		// Act
+		got, err := svc.Get(context.Background(), "user-1", "batch-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Get に失敗: %v", err)
+		}
+		if got.CompletedAt == nil {
+			t.Error("CompletedAt が設定されていない")
+		}
+		if got.Entries[0].Status != model.FeedRegistrationRegistered {
+			t.Errorf("処理済みの結果が変わっている: %q", got.Entries[0].Status)
+		}
+		if got.Entries[1].Status != model.FeedRegistrationFailed || got.Entries[1].Error == nil {
+			t.Errorf("Entries[1] = %+v, want failed", got.Entries[1])
+		}
+	})
+}
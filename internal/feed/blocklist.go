@@ -0,0 +1,36 @@
+package feed
+
+import "context"
+
+// BlocklistChecker はインスタンスのブロックリスト（モデレーション）との照合のインターフェース。
+// moderation.Service が実装し、一致した場合は FEED_BLOCKED の APIError を返す。
+type BlocklistChecker interface {
+	CheckURL(ctx context.Context, rawURL string) error
+}
+
+// WithBlocklist はフィード登録時に入力 URL と検出したフィード URL をブロックリストと照合する checker を設定する。
+// 検出器（FeedDetector）でも照合するが、検出器を差し替えた場合も登録を確実に拒否できるよう登録側でも照合する。
+func WithBlocklist(checker BlocklistChecker) FeedServiceOption {
+	return func(s *FeedService) {
+		s.blocklist = checker
+	}
+}
+
+// DetectorOption は NewFeedDetector の任意設定を表す functional option。
+type DetectorOption func(*FeedDetector)
+
+// WithDetectorBlocklist はフィード検出時に入力 URL と検出したフィード URL をブロックリストと照合する checker を設定する。
+// 入力 URL が一致する場合は HTTP リクエストを送らずに拒否する。
+func WithDetectorBlocklist(checker BlocklistChecker) DetectorOption {
+	return func(d *FeedDetector) {
+		d.blocklist = checker
+	}
+}
+
+// checkBlocklist は checker が設定されていれば rawURL をブロックリストと照合する。
+func checkBlocklist(ctx context.Context, checker BlocklistChecker, rawURL string) error {
+	if checker == nil {
+		return nil
+	}
+	return checker.CheckURL(ctx, rawURL)
+}
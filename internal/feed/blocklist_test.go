@@ -0,0 +1,188 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockBlocklist は BlocklistChecker のモック実装。blockedHost をホストに含む URL を FEED_BLOCKED で拒否する。
+type mockBlocklist struct {
+	blockedHost string
+	checked     []string
+}
+
+func (m *mockBlocklist) CheckURL(_ context.Context, rawURL string) error {
+	m.checked = append(m.checked, rawURL)
+	if strings.Contains(rawURL, "://"+m.blockedHost) {
+		return model.NewFeedBlockedError(rawURL)
+	}
+	return nil
+}
+
+func assertFeedBlocked(t *testing.T, err error) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeFeedBlocked {
+		t.Fatalf("err = %v, want %s", err, model.ErrCodeFeedBlocked)
+	}
+}
+
+func TestFeedService_RegisterFeed_Blocklist(t *testing.T) {
+	t.Run("入力URLがブロック対象のとき検出も保存もせずFEED_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		feedRepo := newMockFeedRepo()
+		subRepo := newMockSubRepo()
+		svc := NewFeedService(feedRepo, subRepo, &mockDetector{feedURL: "https://ok.example/feed.xml"}, &mockFaviconFetcher{},
+			WithBlocklist(&mockBlocklist{blockedHost: "spam.example"}))
+
+		// Act
+		_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://spam.example/", model.RegisterFeedOptions{})
+
+		// Assert
+		assertFeedBlocked(t, err)
+		if feedRepo.createCalls != 0 || subRepo.createCalls != 0 {
+			t.Errorf("フィード・購読は作成されるべきではない (feed=%d, sub=%d)", feedRepo.createCalls, subRepo.createCalls)
+		}
+	})
+
+	t.Run("検出したフィードURLがブロック対象のときFEED_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		feedRepo := newMockFeedRepo()
+		svc := NewFeedService(feedRepo, newMockSubRepo(), &mockDetector{feedURL: "https://spam.example/feed.xml"}, &mockFaviconFetcher{},
+			WithBlocklist(&mockBlocklist{blockedHost: "spam.example"}))
+
+		// Act
+		_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://ok.example/", model.RegisterFeedOptions{})
+
+		// Assert
+		assertFeedBlocked(t, err)
+		if feedRepo.createCalls != 0 {
+			t.Errorf("feedRepo.Create should not be called, got %d", feedRepo.createCalls)
+		}
+	})
+
+	t.Run("ブロック対象でないとき通常どおり登録する", func(t *testing.T) {
+		// Arrange
+		svc := NewFeedService(newMockFeedRepo(), newMockSubRepo(), &mockDetector{feedURL: "https://ok.example/feed.xml"}, &mockFaviconFetcher{},
+			WithBlocklist(&mockBlocklist{blockedHost: "spam.example"}))
+
+		// Act
+		_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://ok.example/", model.RegisterFeedOptions{})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RegisterFeed returned error: %v", err)
+		}
+	})
+}
+
+func TestFeedService_UpdateFeedURL_Validation(t *testing.T) {
+	newService := func(guard *mockSSRFGuard) (*FeedService, *mockFeedRepo) {
+		feedRepo := newMockFeedRepo()
+		feedRepo.feeds["feed-1"] = &model.Feed{ID: "feed-1", FeedURL: "https://ok.example/feed.xml", FetchStatus: model.FetchStatusActive}
+		subRepo := newMockSubRepo()
+		subRepo.subs["sub-1"] = &model.Subscription{ID: "sub-1", UserID: "user-1", FeedID: "feed-1"}
+		svc := NewFeedService(feedRepo, subRepo, &mockDetector{}, &mockFaviconFetcher{},
+			WithBlocklist(&mockBlocklist{blockedHost: "spam.example"}), WithSSRFValidator(guard))
+		return svc, feedRepo
+	}
+
+	tests := []struct {
+		name     string
+		newURL   string
+		blockAll bool
+		wantCode string
+	}{
+		{"http/https以外のスキームのときINVALID_URL_SCHEMEを返す", "file:///etc/passwd", false, model.ErrCodeInvalidURLScheme},
+		{"ブロック対象のURLのときFEED_BLOCKEDを返す", "https://spam.example/feed.xml", false, model.ErrCodeFeedBlocked},
+		{"SSRF検証に失敗したときSSRF_BLOCKEDを返す", "http://127.0.0.1/feed.xml", true, model.ErrCodeSSRFBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			svc, feedRepo := newService(&mockSSRFGuard{blockAll: tt.blockAll})
+
+			// Act
+			_, err := svc.UpdateFeedURL(context.Background(), "user-1", "feed-1", tt.newURL)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tt.wantCode {
+				t.Fatalf("err = %v, want %s", err, tt.wantCode)
+			}
+			if feedRepo.updateCalls != 0 {
+				t.Errorf("feedRepo.Update should not be called, got %d", feedRepo.updateCalls)
+			}
+		})
+	}
+
+	t.Run("検証を通過したとき新しいURLに更新する", func(t *testing.T) {
+		// Arrange
+		guard := &mockSSRFGuard{}
+		svc, feedRepo := newService(guard)
+
+		// Act
+		feed, err := svc.UpdateFeedURL(context.Background(), "user-1", "feed-1", "https://ok.example/new.xml")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("UpdateFeedURL returned error: %v", err)
+		}
+		if feed.FeedURL != "https://ok.example/new.xml" || feedRepo.updateCalls != 1 {
+			t.Errorf("FeedURL = %q, updateCalls = %d", feed.FeedURL, feedRepo.updateCalls)
+		}
+		if guard.validateURLCalls() != 1 {
+			t.Errorf("validateURL calls = %d, want 1", guard.validateURLCalls())
+		}
+	})
+}
+
+func TestDetectFeedURL_Blocklist(t *testing.T) {
+	t.Run("入力URLがブロック対象のときリクエストを送らずFEED_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		var requests atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Header().Set("Content-Type", "application/rss+xml")
+			w.Write([]byte(`<rss version="2.0"><channel></channel></rss>`))
+		}))
+		defer server.Close()
+		guard := &mockSSRFGuard{}
+		d := NewFeedDetector(guard, WithDetectorBlocklist(&mockBlocklist{blockedHost: strings.TrimPrefix(server.URL, "http://")}))
+
+		// Act
+		_, err := d.DetectFeedURL(context.Background(), server.URL+"/feed.xml")
+
+		// Assert
+		assertFeedBlocked(t, err)
+		if requests.Load() != 0 {
+			t.Errorf("ブロック対象にはリクエストを送るべきではない (requests=%d)", requests.Load())
+		}
+		if guard.validateURLCallCount.Load() != 0 {
+			t.Errorf("SSRF 検証より前に拒否されるべき (validateURL=%d)", guard.validateURLCallCount.Load())
+		}
+	})
+
+	t.Run("HTMLから検出したフィードURLがブロック対象のときFEED_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><head><link rel="alternate" type="application/rss+xml" href="https://spam.example/feed.xml"></head></html>`))
+		}))
+		defer server.Close()
+		d := NewFeedDetector(&mockSSRFGuard{}, WithDetectorBlocklist(&mockBlocklist{blockedHost: "spam.example"}))
+
+		// Act
+		_, err := d.DetectFeedURL(context.Background(), server.URL)
+
+		// Assert
+		assertFeedBlocked(t, err)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/security"
 	"golang.org/x/net/html"
 )
 
@@ -26,6 +27,26 @@ const (
 	FeedTypeAtom FeedType = "atom"
 )
 
+// DetectionSource は入力 URL の応答の種類を表す。
+type DetectionSource string
+
+const (
+	// DetectionSourceFeed は入力 URL 自体が RSS/Atom フィードだった。
+	DetectionSourceFeed DetectionSource = "feed"
+	// DetectionSourceHTML は入力 URL が HTML ページだった（フィードは head のリンクから検出する）。
+	DetectionSourceHTML DetectionSource = "html"
+	// DetectionSourceOther は入力 URL がフィードでも HTML でもなかった。
+	DetectionSourceOther DetectionSource = "other"
+)
+
+// Detection はフィード検出の結果を表す。
+// FeedURL / FeedType は検出に成功した場合のみ設定する。FeedType は判別できない場合は空。
+type Detection struct {
+	Source   DetectionSource
+	FeedURL  string
+	FeedType FeedType
+}
+
 // FeedCandidate はHTMLから検出されたフィード候補を表す。
 type FeedCandidate struct {
 	URL      string
@@ -53,25 +74,32 @@ type FeedDetector struct {
 	// コンストラクタで一度だけ生成し、生成後は read-only なフィールド参照となるため、
 	// 複数 goroutine からの同時アクセスでもデータ競合は発生しない（NFR 2.1）。
 	httpClient *http.Client
+	// blocklist はインスタンスのブロックリストとの照合先。未設定時は nil（照合しない）。
+	blocklist BlocklistChecker
 }
 
 // NewFeedDetector はFeedDetectorの新しいインスタンスを生成する。
 // HTTPクライアントはここで一度だけ生成し、以降のリクエストで使い回す
 // （コネクションプールを共有して無駄な TCP/TLS ハンドシェイクを抑制する）。
-func NewFeedDetector(ssrfGuard SSRFValidator) *FeedDetector {
-	return &FeedDetector{
+func NewFeedDetector(ssrfGuard SSRFValidator, opts ...DetectorOption) *FeedDetector {
+	d := &FeedDetector{
 		ssrfGuard:  ssrfGuard,
 		httpClient: newDetectorHTTPClient(ssrfGuard),
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 // newDetectorHTTPClient はフィード検出用のHTTPクライアントを生成する。
 // SSRFGuardが設定されている場合はSSRF防止付きクライアントを返す。
+// いずれの場合もリダイレクトは security.CheckRedirect により循環・上限超過で打ち切る。
 func newDetectorHTTPClient(ssrfGuard SSRFValidator) *http.Client {
 	if ssrfGuard != nil {
 		return ssrfGuard.NewSafeClient(detectorTimeout, detectorMaxResponseSize)
 	}
-	return &http.Client{Timeout: detectorTimeout}
+	return &http.Client{Timeout: detectorTimeout, CheckRedirect: security.CheckRedirect}
 }
 
 // feedContentTypes はフィードとして認識するContent-Typeのリスト。
@@ -120,6 +148,26 @@ func (d *FeedDetector) IsDirectFeed(contentType string, body []byte) bool {
 	return isRSSOrAtomXML(body)
 }
 
+// directFeedType は IsDirectFeed と判定したレスポンスのフィードの種類を返す。判別できない場合は空を返す。
+func directFeedType(contentType string, body []byte) FeedType {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch strings.ToLower(mediaType) {
+	case "application/rss+xml":
+		return FeedTypeRSS
+	case "application/atom+xml":
+		return FeedTypeAtom
+	}
+
+	prefix := strings.ToLower(string(body[:min(len(body), 4096)]))
+	switch {
+	case strings.Contains(prefix, "<rss"), strings.Contains(prefix, "<rdf:rdf"):
+		return FeedTypeRSS
+	case strings.Contains(prefix, "<feed"):
+		return FeedTypeAtom
+	}
+	return ""
+}
+
 // isRSSOrAtomXML はXMLボディの先頭部分を解析してRSS/Atomフィードかを判定する。
 func isRSSOrAtomXML(body []byte) bool {
 	// 先頭4KBを検査（XMLプロローグ + ルート要素が含まれるのに十分）
@@ -239,6 +287,24 @@ func (d *FeedDetector) ParseFeedLinksFromHTML(htmlBody []byte, baseURL string) [
 	}
 }
 
+// excludeSelfReferences は candidates から pageURLs のいずれかと同じリソースを指す候補を除いて返す。
+func excludeSelfReferences(candidates []FeedCandidate, pageURLs ...string) []FeedCandidate {
+	result := make([]FeedCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		self := false
+		for _, page := range pageURLs {
+			if security.SameResourceURL(c.URL, page) {
+				self = true
+				break
+			}
+		}
+		if !self {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
 // resolveURL は相対URLをベースURLを基準に絶対URLに解決する。
 func resolveURL(base *url.URL, rawRef string) string {
 	ref, err := url.Parse(rawRef)
@@ -301,16 +367,36 @@ func extractHost(rawURL string) string {
 // 3. Content-Typeとボディからフィードかどうかを判定
 // 4. HTMLの場合はheadタグからフィードリンクを検出し、優先順位で選択
 // 5. フィード未検出の場合はエラー（原因カテゴリ + 対処方法）を返す
+// リダイレクトの循環・上限超過や、HTML ページが自分自身しかフィードとして参照していない（自己参照）場合は
+// FEED_REDIRECT_LOOP を返す。
+// ブロックリストが設定されている場合は、入力 URL（リクエスト前）と検出したフィード URL を照合する。
 func (d *FeedDetector) DetectFeedURL(ctx context.Context, inputURL string) (string, error) {
-	// 空URLチェック
-	if inputURL == "" {
-		return "", model.NewInvalidURLError("URLが入力されていません")
+	detection, err := d.Detect(ctx, inputURL)
+	if err != nil {
+		return "", err
+	}
+	return detection.FeedURL, nil
+}
+
+// Detect は DetectFeedURL と同じ手順でフィードを検出し、入力 URL の応答の種類とフィードの種類を合わせて返す。
+// 応答を取得できた後に検出に失敗した場合は、エラーとともに Source のみを設定した Detection を返す
+// （HTML だがフィードのリンクがない、フィードでも HTML でもない、等を区別できるようにする）。
+// それより前（URL の検証・ブロックリスト・SSRF 検証・取得）で失敗した場合の Detection は nil。
+func (d *FeedDetector) Detect(ctx context.Context, inputURL string) (*Detection, error) {
+	// 空URL・スキームのチェック（非 HTTP スキームは SSRF 検証より前に専用エラーで拒否する）
+	if err := validateURLScheme(inputURL); err != nil {
+		return nil, err
+	}
+
+	// ブロックリストの照合（ブロック対象のサイトにはリクエストを送らない）
+	if err := checkBlocklist(ctx, d.blocklist, inputURL); err != nil {
+		return nil, err
 	}
 
 	// SSRF検証
 	if d.ssrfGuard != nil {
 		if err := d.ssrfGuard.ValidateURL(inputURL); err != nil {
-			return "", model.NewSSRFBlockedError()
+			return nil, model.NewSSRFBlockedError()
 		}
 	}
 
@@ -318,50 +404,86 @@ func (d *FeedDetector) DetectFeedURL(ctx context.Context, inputURL string) (stri
 	client := d.getHTTPClient()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, inputURL, nil)
 	if err != nil {
-		return "", model.NewInvalidURLError(err.Error())
+		return nil, model.NewInvalidURLError(err.Error())
 	}
 	req.Header.Set("User-Agent", "Feedman/1.0 RSS Reader")
 	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml, text/html, */*")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", model.NewFetchFailedError(err.Error())
+		if security.IsRedirectError(err) {
+			return nil, model.NewFeedRedirectLoopError(err.Error())
+		}
+		return nil, model.NewFetchFailedError(err.Error())
 	}
 	defer resp.Body.Close()
 
 	// レスポンスボディを読み込み（最大5MB）
 	body, err := io.ReadAll(io.LimitReader(resp.Body, detectorMaxResponseSize))
 	if err != nil {
-		return "", model.NewFetchFailedError(fmt.Sprintf("レスポンスの読み取りに失敗: %v", err))
+		return nil, model.NewFetchFailedError(fmt.Sprintf("レスポンスの読み取りに失敗: %v", err))
 	}
 
 	contentType := resp.Header.Get("Content-Type")
 
 	// フィード直接判定
 	if d.IsDirectFeed(contentType, body) {
-		return inputURL, nil
+		return &Detection{Source: DetectionSourceFeed, FeedURL: inputURL, FeedType: directFeedType(contentType, body)}, nil
 	}
 
 	// HTMLの場合: headタグからフィードリンクを検出
 	mediaType, _, _ := mime.ParseMediaType(contentType)
 	if !strings.Contains(strings.ToLower(mediaType), "html") {
 		// HTMLでもフィードでもない場合
-		return "", model.NewFeedNotDetectedError(inputURL)
+		return &Detection{Source: DetectionSourceOther}, model.NewFeedNotDetectedError(inputURL)
 	}
+	detection := &Detection{Source: DetectionSourceHTML}
 
 	// HTMLからフィードリンクを検出
 	candidates := d.ParseFeedLinksFromHTML(body, inputURL)
 	if len(candidates) == 0 {
-		return "", model.NewFeedNotDetectedError(inputURL)
+		return detection, model.NewFeedNotDetectedError(inputURL)
+	}
+
+	// 自分自身（入力 URL またはリダイレクト後のページ）を指す候補は HTML に戻るだけなので除く
+	candidates = excludeSelfReferences(candidates, inputURL, resp.Request.URL.String())
+	if len(candidates) == 0 {
+		return detection, model.NewFeedRedirectLoopError("ページが自分自身をフィードとして参照しています")
 	}
 
 	// 優先順位に従って最適なフィードを選択
 	best := d.SelectBestFeed(candidates, inputURL)
 	if best == nil {
-		return "", model.NewFeedNotDetectedError(inputURL)
+		return detection, model.NewFeedNotDetectedError(inputURL)
 	}
 
-	return best.URL, nil
+	// HTML から検出したフィードは別ドメインを指すことがあるため、改めてブロックリストと照合する
+	if err := checkBlocklist(ctx, d.blocklist, best.URL); err != nil {
+		return detection, err
+	}
+
+	detection.FeedURL = best.URL
+	detection.FeedType = best.FeedType
+	return detection, nil
+}
+
+// validateURLScheme はフィード登録の入力 URL が http または https であることを検証する。
+// 空の場合は INVALID_URL、file:// 等のローカルファイルや ftp:// 等の非 HTTP スキーム、
+// スキームのない入力は INVALID_URL_SCHEME を返す。
+func validateURLScheme(inputURL string) error {
+	if strings.TrimSpace(inputURL) == "" {
+		return model.NewInvalidURLError("URLが入力されていません")
+	}
+	u, err := url.Parse(strings.TrimSpace(inputURL))
+	if err != nil {
+		return model.NewInvalidURLError(err.Error())
+	}
+	switch scheme := strings.ToLower(u.Scheme); scheme {
+	case "http", "https":
+		return nil
+	default:
+		return model.NewInvalidURLSchemeError(scheme)
+	}
 }
 
 // getHTTPClient はコンストラクタで生成済みの再利用HTTPクライアントを返す。
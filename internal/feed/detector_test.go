@@ -450,6 +450,45 @@ func TestDetectFeedURL_EmptyURL(t *testing.T) {
 	}
 }
 
+// TestDetectFeedURL_InvalidScheme は http / https 以外のスキームを SSRF 検証より前に専用エラーで拒否することをテストする。
+func TestDetectFeedURL_InvalidScheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		inputURL   string
+		wantScheme string
+	}{
+		{name: "file://のときINVALID_URL_SCHEMEを返す", inputURL: "file:///etc/passwd", wantScheme: "file"},
+		{name: "ftp://のときINVALID_URL_SCHEMEを返す", inputURL: "ftp://example.com/feed.xml", wantScheme: "ftp"},
+		{name: "大文字のスキームも小文字で判定する", inputURL: "FTP://example.com/feed.xml", wantScheme: "ftp"},
+		{name: "スキームのないパスのときINVALID_URL_SCHEMEを返す", inputURL: "/home/user/feed.xml", wantScheme: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: SSRF 検証まで到達すると SSRF_BLOCKED になるガード
+			d := NewFeedDetector(&mockSSRFGuard{blockAll: true})
+
+			// Act
+			_, err := d.DetectFeedURL(context.Background(), tt.inputURL)
+
+			// Assert
+			apiErr, ok := err.(*model.APIError)
+			if !ok {
+				t.Fatalf("APIError型が期待されるが、%T が返された", err)
+			}
+			if apiErr.Code != model.ErrCodeInvalidURLScheme {
+				t.Errorf("Code = %s, want %s", apiErr.Code, model.ErrCodeInvalidURLScheme)
+			}
+			if apiErr.Category != "validation" || apiErr.Action == "" {
+				t.Errorf("Category/Action = %q/%q, want validation と対処方法", apiErr.Category, apiErr.Action)
+			}
+			if apiErr.Details["scheme"] != tt.wantScheme {
+				t.Errorf("Details[scheme] = %v, want %q", apiErr.Details["scheme"], tt.wantScheme)
+			}
+		})
+	}
+}
+
 // TestDetectFeedURL_XMLContentTypeWithRSSBody はContent-Type text/xmlでRSSボディの場合にフィードとして検出するテスト。
 func TestDetectFeedURL_XMLContentTypeWithRSSBody(t *testing.T) {
 	rssXML := `<?xml version="1.0" encoding="UTF-8"?>
@@ -785,3 +824,82 @@ func (m *mockSSRFGuard) newSafeClientCalls() int64 {
 func (m *mockSSRFGuard) validateURLCalls() int64 {
 	return m.validateURLCallCount.Load()
 }
+
+// --- リダイレクトの循環・自己参照のテスト ---
+
+// TestDetectFeedURL_RedirectLoop はリダイレクトが循環するURLで即座に FEED_REDIRECT_LOOP を返すことをテストする。
+func TestDetectFeedURL_RedirectLoop(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/feed" {
+			http.Redirect(w, r, "/feed/", http.StatusMovedPermanently)
+			return
+		}
+		http.Redirect(w, r, "/feed", http.StatusMovedPermanently)
+	}))
+	defer server.Close()
+
+	d := NewFeedDetector(nil)
+
+	_, err := d.DetectFeedURL(context.Background(), server.URL+"/feed")
+
+	apiErr, ok := err.(*model.APIError)
+	if !ok || apiErr.Code != model.ErrCodeFeedRedirectLoop {
+		t.Fatalf("err = %v, want FEED_REDIRECT_LOOP", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+// TestDetectFeedURL_SelfReferencingFeedLink はHTMLページが自分自身をフィードとして参照する場合のテスト。
+func TestDetectFeedURL_SelfReferencingFeedLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		links    string
+		wantPath string
+		wantCode string
+	}{
+		{
+			name:     "自分自身しか参照していないときFEED_REDIRECT_LOOPを返す",
+			links:    `<link rel="alternate" type="application/rss+xml" href="/blog">`,
+			wantCode: model.ErrCodeFeedRedirectLoop,
+		},
+		{
+			name: "自分自身以外の候補があるときその候補を返す",
+			links: `<link rel="alternate" type="application/atom+xml" href="/blog#feed">` +
+				`<link rel="alternate" type="application/rss+xml" href="/blog/rss.xml">`,
+			wantPath: "/blog/rss.xml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				fmt.Fprintf(w, `<html><head>%s</head><body></body></html>`, tt.links)
+			}))
+			defer server.Close()
+			d := NewFeedDetector(&mockSSRFGuard{})
+
+			// Act
+			feedURL, err := d.DetectFeedURL(context.Background(), server.URL+"/blog")
+
+			// Assert
+			if tt.wantCode != "" {
+				apiErr, ok := err.(*model.APIError)
+				if !ok || apiErr.Code != tt.wantCode {
+					t.Errorf("err = %v, want %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DetectFeedURL returned error: %v", err)
+			}
+			if feedURL != server.URL+tt.wantPath {
+				t.Errorf("feedURL = %s, want %s%s", feedURL, server.URL, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// Prober はフィード URL のプリフライトに用いるフィード検出のインターフェース。FeedDetector が実装する。
+type Prober interface {
+	Detect(ctx context.Context, inputURL string) (*Detection, error)
+}
+
+// PreflightService はフィード URL を登録せずに検証する（プリフライト）。
+// 登録（FeedService.RegisterFeed）と同じ検出・ブロックリスト・SSRF 検証を行うが、フィード・購読は作成しない。
+type PreflightService struct {
+	prober Prober
+	// blocklist はインスタンスのブロックリストとの照合先。未設定時は nil（照合しない）。
+	blocklist BlocklistChecker
+}
+
+// PreflightOption は NewPreflightService の任意設定を表す functional option。
+type PreflightOption func(*PreflightService)
+
+// WithPreflightBlocklist は入力 URL と検出したフィード URL をブロックリストと照合する checker を設定する。
+// 登録側（WithBlocklist）と同じく、検出器を差し替えた場合も登録と同じ判定になるよう照合する。
+func WithPreflightBlocklist(checker BlocklistChecker) PreflightOption {
+	return func(s *PreflightService) {
+		s.blocklist = checker
+	}
+}
+
+// NewPreflightService は PreflightService を生成する。
+func NewPreflightService(prober Prober, opts ...PreflightOption) *PreflightService {
+	s := &PreflightService{prober: prober}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Validate は inputURL を登録できる見込みかを検証する。
+// 到達できない・SSRF やブロックリストの対象・フィードを検出できない等の理由（APIError）はエラーではなく
+// 結果の Error に設定して返す。APIError 以外のエラー（ブロックリストの照会の失敗等）はそのまま返す。
+// 購読数の上限・購読済みかは検証しない（登録時に判定する）。
+func (s *PreflightService) Validate(ctx context.Context, inputURL string) (*model.FeedPreflight, error) {
+	inputURL = strings.TrimSpace(inputURL)
+	result := &model.FeedPreflight{URL: inputURL}
+
+	detection, err := s.detect(ctx, inputURL)
+	if detection != nil {
+		result.SourceType = string(detection.Source)
+	}
+	if err != nil {
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) {
+			return nil, err
+		}
+		result.Error = apiErr
+		return result, nil
+	}
+
+	result.Valid = true
+	result.FeedURL = detection.FeedURL
+	result.FeedType = string(detection.FeedType)
+	return result, nil
+}
+
+// detect は登録と同じ順で入力 URL を検証してフィードを検出し、検出したフィード URL をブロックリストと照合する。
+func (s *PreflightService) detect(ctx context.Context, inputURL string) (*Detection, error) {
+	if err := validateURLScheme(inputURL); err != nil {
+		return nil, err
+	}
+	if err := checkBlocklist(ctx, s.blocklist, inputURL); err != nil {
+		return nil, err
+	}
+
+	detection, err := s.prober.Detect(ctx, inputURL)
+	if err != nil {
+		return detection, err
+	}
+	if err := checkBlocklist(ctx, s.blocklist, detection.FeedURL); err != nil {
+		return detection, err
+	}
+	return detection, nil
+}
@@ -0,0 +1,196 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// errorProber は Detect が常に err を返す Prober。
+type errorProber struct{ err error }
+
+func (p errorProber) Detect(context.Context, string) (*Detection, error) { return nil, p.err }
+
+// newPreflightTestServer は常に contentType と body を返すテスト用サーバーを起動する。
+func newPreflightTestServer(t *testing.T, contentType, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func assertPreflightError(t *testing.T, result *model.FeedPreflight, code string) {
+	t.Helper()
+	if result.Valid || result.Error == nil || result.Error.Code != code {
+		t.Fatalf("result = %+v, want invalid with %s", result, code)
+	}
+	if result.FeedURL != "" || result.FeedType != "" {
+		t.Errorf("FeedURL = %q, FeedType = %q, want empty", result.FeedURL, result.FeedType)
+	}
+}
+
+func TestPreflightService_Validate(t *testing.T) {
+	t.Run("URLがフィードのときフィードとして検出し種類を返す", func(t *testing.T) {
+		// Arrange
+		server := newPreflightTestServer(t, "application/atom+xml",
+			`<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"><title>t</title></feed>`)
+		svc := NewPreflightService(NewFeedDetector(&mockSSRFGuard{}))
+
+		// Act
+		result, err := svc.Validate(context.Background(), " "+server.URL+"/atom.xml ")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if !result.Valid || result.Error != nil {
+			t.Fatalf("result = %+v, want valid", result)
+		}
+		if result.URL != server.URL+"/atom.xml" || result.FeedURL != server.URL+"/atom.xml" {
+			t.Errorf("URL = %q, FeedURL = %q", result.URL, result.FeedURL)
+		}
+		if result.SourceType != "feed" || result.FeedType != "atom" {
+			t.Errorf("SourceType = %q, FeedType = %q, want feed, atom", result.SourceType, result.FeedType)
+		}
+	})
+
+	t.Run("URLがHTMLのときheadのリンクから検出したフィードを返す", func(t *testing.T) {
+		// Arrange
+		server := newPreflightTestServer(t, "text/html; charset=utf-8",
+			`<html><head><link rel="alternate" type="application/rss+xml" href="/rss.xml"></head></html>`)
+		svc := NewPreflightService(NewFeedDetector(&mockSSRFGuard{}))
+
+		// Act
+		result, err := svc.Validate(context.Background(), server.URL+"/blog")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if !result.Valid || result.SourceType != "html" || result.FeedURL != server.URL+"/rss.xml" || result.FeedType != "rss" {
+			t.Errorf("result = %+v", result)
+		}
+	})
+
+	t.Run("HTMLにフィードのリンクがないときHTMLであることとFEED_NOT_DETECTEDを返す", func(t *testing.T) {
+		// Arrange
+		server := newPreflightTestServer(t, "text/html", `<html><head><title>no feed</title></head></html>`)
+		svc := NewPreflightService(NewFeedDetector(&mockSSRFGuard{}))
+
+		// Act
+		result, err := svc.Validate(context.Background(), server.URL)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		assertPreflightError(t, result, model.ErrCodeFeedNotDetected)
+		if result.SourceType != "html" {
+			t.Errorf("SourceType = %q, want html", result.SourceType)
+		}
+	})
+
+	t.Run("フィードでもHTMLでもないときotherとFEED_NOT_DETECTEDを返す", func(t *testing.T) {
+		// Arrange
+		server := newPreflightTestServer(t, "image/png", "png")
+		svc := NewPreflightService(NewFeedDetector(&mockSSRFGuard{}))
+
+		// Act
+		result, err := svc.Validate(context.Background(), server.URL+"/logo.png")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		assertPreflightError(t, result, model.ErrCodeFeedNotDetected)
+		if result.SourceType != "other" {
+			t.Errorf("SourceType = %q, want other", result.SourceType)
+		}
+	})
+
+	t.Run("SSRFの対象のときリクエストを送らずSSRF_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewPreflightService(NewFeedDetector(&mockSSRFGuard{blockAll: true}))
+
+		// Act
+		result, err := svc.Validate(context.Background(), "http://192.168.1.1/feed.xml")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		assertPreflightError(t, result, model.ErrCodeSSRFBlocked)
+		if result.SourceType != "" {
+			t.Errorf("SourceType = %q, want empty", result.SourceType)
+		}
+	})
+
+	t.Run("入力URLがブロック対象のとき検出せずFEED_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		prober := errorProber{err: errors.New("Detect は呼ばれないはず")}
+		svc := NewPreflightService(prober, WithPreflightBlocklist(&mockBlocklist{blockedHost: "spam.example"}))
+
+		// Act
+		result, err := svc.Validate(context.Background(), "https://spam.example/feed")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		assertPreflightError(t, result, model.ErrCodeFeedBlocked)
+	})
+
+	t.Run("HTMLから検出したフィードがブロック対象のときFEED_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		server := newPreflightTestServer(t, "text/html",
+			`<html><head><link rel="alternate" type="application/rss+xml" href="https://spam.example/rss"></head></html>`)
+		svc := NewPreflightService(NewFeedDetector(&mockSSRFGuard{}), WithPreflightBlocklist(&mockBlocklist{blockedHost: "spam.example"}))
+
+		// Act
+		result, err := svc.Validate(context.Background(), server.URL)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		assertPreflightError(t, result, model.ErrCodeFeedBlocked)
+		if result.SourceType != "html" {
+			t.Errorf("SourceType = %q, want html", result.SourceType)
+		}
+	})
+
+	t.Run("スキームがhttpでないときINVALID_URL_SCHEMEを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewPreflightService(errorProber{err: errors.New("Detect は呼ばれないはず")})
+
+		// Act
+		result, err := svc.Validate(context.Background(), "file:///etc/passwd")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		assertPreflightError(t, result, model.ErrCodeInvalidURLScheme)
+	})
+
+	t.Run("APIError以外のエラーのときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewPreflightService(errorProber{err: errors.New("db down")})
+
+		// Act
+		_, err := svc.Validate(context.Background(), "https://example.com/feed")
+
+		// Assert
+		if err == nil {
+			t.Fatal("Validate() error = nil, want error")
+		}
+	})
+}
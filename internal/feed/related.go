@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// maxRelatedFeeds は関連フィード一覧で返す最大件数。
+const maxRelatedFeeds = 50
+
+// RelatedFeed は同一ホストの別フィードと、リクエストユーザーの購読状況。
+type RelatedFeed struct {
+	Feed *model.Feed
+	// SubscriptionID はリクエストユーザーの購読ID。未購読の場合は空文字。
+	SubscriptionID string
+}
+
+// Subscribed はリクエストユーザーが当該フィードを購読しているかを返す。
+func (r RelatedFeed) Subscribed() bool {
+	return r.SubscriptionID != ""
+}
+
+// ListRelatedFeeds は指定フィードと同じサイト（ホスト）を持つ別フィードを購読状況付きで返す。
+// カテゴリ別 RSS など、同じサイトが提供する他のフィードを見つけるために使う。
+// 対象は自分が購読しているフィードと、公開プロフィールで公開されている購読のフィードに限る
+// （他ユーザーが非公開で購読しているフィードの URL を漏らさないため）。
+// 認可: リクエストユーザーが当該フィードを購読している場合のみ取得可能。
+// 購読していない場合は IDOR を避けるため FEED_NOT_FOUND を返す。
+func (s *FeedService) ListRelatedFeeds(ctx context.Context, userID, feedID string) ([]RelatedFeed, error) {
+	sub, err := s.subRepo.FindByUserAndFeed(ctx, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("購読の確認に失敗しました: %w", err)
+	}
+	if sub == nil {
+		return nil, &model.APIError{
+			Code:     model.ErrCodeFeedNotFound,
+			Message:  "指定されたフィードが見つかりません。",
+			Category: "feed",
+			Action:   "フィードIDを確認してください。",
+		}
+	}
+
+	base, err := s.feedRepo.FindByID(ctx, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("フィードの取得に失敗しました: %w", err)
+	}
+	if base == nil {
+		return nil, &model.APIError{
+			Code:     model.ErrCodeFeedNotFound,
+			Message:  "指定されたフィードが見つかりません。",
+			Category: "feed",
+			Action:   "フィードIDを確認してください。",
+		}
+	}
+
+	host := siteHost(base)
+	if host == "" {
+		return []RelatedFeed{}, nil
+	}
+	feeds, err := s.feedRepo.ListBySiteHost(ctx, userID, host, base.ID, maxRelatedFeeds)
+	if err != nil {
+		return nil, fmt.Errorf("関連フィードの取得に失敗しました: %w", err)
+	}
+	if len(feeds) == 0 {
+		return []RelatedFeed{}, nil
+	}
+
+	subs, err := s.subRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("購読一覧の取得に失敗しました: %w", err)
+	}
+	subIDByFeed := make(map[string]string, len(subs))
+	for _, sub := range subs {
+		subIDByFeed[sub.FeedID] = sub.ID
+	}
+
+	related := make([]RelatedFeed, 0, len(feeds))
+	for _, f := range feeds {
+		related = append(related, RelatedFeed{Feed: f, SubscriptionID: subIDByFeed[f.ID]})
+	}
+	return related, nil
+}
+
+// siteHost はフィードのサイトのホスト名を小文字・先頭の "www." を除いた形で返す。
+// site_url が未設定の場合は feed_url から求める。DB 側の feed_host 関数と同じ正規化を行う。
+func siteHost(feed *model.Feed) string {
+	raw := feed.SiteURL
+	if raw == "" {
+		raw = feed.FeedURL
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
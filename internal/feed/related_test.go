@@ -0,0 +1,117 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestFeedService_ListRelatedFeeds(t *testing.T) {
+	newFixture := func() (*mockFeedRepo, *mockSubRepo) {
+		feedRepo := newMockFeedRepo()
+		feedRepo.feeds["feed-1"] = &model.Feed{
+			ID:      "feed-1",
+			FeedURL: "https://example.com/category/tech/feed.xml",
+			SiteURL: "https://www.Example.com/",
+		}
+		subRepo := newMockSubRepo()
+		subRepo.subs["sub-1"] = &model.Subscription{ID: "sub-1", UserID: "user-1", FeedID: "feed-1"}
+		return feedRepo, subRepo
+	}
+
+	t.Run("同じホストの別フィードを購読状況付きで返す", func(t *testing.T) {
+		// Arrange
+		feedRepo, subRepo := newFixture()
+		subRepo.subs["sub-2"] = &model.Subscription{ID: "sub-2", UserID: "user-1", FeedID: "feed-2"}
+		var gotUser, gotHost, gotExclude string
+		feedRepo.listBySiteHostFn = func(_ context.Context, userID, host, excludeFeedID string, _ int) ([]*model.Feed, error) {
+			gotUser, gotHost, gotExclude = userID, host, excludeFeedID
+			return []*model.Feed{
+				{ID: "feed-2", FeedURL: "https://example.com/category/life/feed.xml"},
+				{ID: "feed-3", FeedURL: "https://example.com/category/news/feed.xml"},
+			}, nil
+		}
+		svc := NewFeedService(feedRepo, subRepo, &mockDetector{}, &mockFaviconFetcher{})
+
+		// Act
+		related, err := svc.ListRelatedFeeds(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotUser != "user-1" || gotHost != "example.com" || gotExclude != "feed-1" {
+			t.Errorf("ListBySiteHost called with (%q, %q, %q), want (\"user-1\", \"example.com\", \"feed-1\")", gotUser, gotHost, gotExclude)
+		}
+		if len(related) != 2 {
+			t.Fatalf("len(related) = %d, want 2", len(related))
+		}
+		if !related[0].Subscribed() || related[0].SubscriptionID != "sub-2" {
+			t.Errorf("related[0] = %+v, want subscribed sub-2", related[0])
+		}
+		if related[1].Subscribed() {
+			t.Errorf("related[1] = %+v, want not subscribed", related[1])
+		}
+	})
+
+	t.Run("site_urlが未設定のときfeed_urlのホストで検索する", func(t *testing.T) {
+		// Arrange
+		feedRepo, subRepo := newFixture()
+		feedRepo.feeds["feed-1"].SiteURL = ""
+		feedRepo.feeds["feed-1"].FeedURL = "https://blog.example.org:8443/rss"
+		var gotHost string
+		feedRepo.listBySiteHostFn = func(_ context.Context, _, host, _ string, _ int) ([]*model.Feed, error) {
+			gotHost = host
+			return nil, nil
+		}
+		svc := NewFeedService(feedRepo, subRepo, &mockDetector{}, &mockFaviconFetcher{})
+
+		// Act
+		related, err := svc.ListRelatedFeeds(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotHost != "blog.example.org" {
+			t.Errorf("host = %q, want %q", gotHost, "blog.example.org")
+		}
+		if related == nil || len(related) != 0 {
+			t.Errorf("related = %v, want empty slice", related)
+		}
+	})
+
+	t.Run("フィードを購読していないときFEED_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		feedRepo, subRepo := newFixture()
+		svc := NewFeedService(feedRepo, subRepo, &mockDetector{}, &mockFaviconFetcher{})
+
+		// Act
+		_, err := svc.ListRelatedFeeds(context.Background(), "user-2", "feed-1")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeFeedNotFound {
+			t.Errorf("err = %v, want FEED_NOT_FOUND", err)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		feedRepo, subRepo := newFixture()
+		feedRepo.listBySiteHostFn = func(context.Context, string, string, string, int) ([]*model.Feed, error) {
+			return nil, errors.New("db error")
+		}
+		svc := NewFeedService(feedRepo, subRepo, &mockDetector{}, &mockFaviconFetcher{})
+
+		// Act
+		_, err := svc.ListRelatedFeeds(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
@@ -10,13 +10,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hitoshi/feedman/internal/cache"
 	"github.com/hitoshi/feedman/internal/model"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
-// maxSubscriptionsPerUser はユーザーあたりの購読上限。
-const maxSubscriptionsPerUser = 100
-
 // defaultFetchIntervalMinutes は新規購読のデフォルトフェッチ間隔（分）。
 const defaultFetchIntervalMinutes = 60
 
@@ -41,44 +39,128 @@ type FeedService struct {
 	detector       Detector
 	faviconFetcher FaviconFetcherService
 
+	// cacheInvalidator は購読一覧キャッシュの無効化先。未設定時は nil。
+	cacheInvalidator cache.UserInvalidator
+
+	// auditRecorder は購読作成の監査ログ記録先。未設定時は nil。
+	auditRecorder AuditRecorder
+
+	// blocklist はインスタンスのブロックリストとの照合先。未設定時は nil（照合しない）。
+	blocklist BlocklistChecker
+
+	// ssrfGuard はフィード URL の変更時に新しい URL を検証する SSRF ガード。未設定時は nil（検証しない）。
+	ssrfGuard SSRFValidator
+
 	// faviconWG はバックグラウンドの favicon 取得 goroutine の完了を追跡する。
 	// テストから非同期完了を待つために用いる（本番では Wait を呼ばないため挙動に影響しない）。
 	faviconWG sync.WaitGroup
 }
 
+// FeedServiceOption は NewFeedService の任意設定を表す functional option。
+type FeedServiceOption func(*FeedService)
+
+// WithCacheInvalidator は購読の追加やフィード URL の変更時に、当該ユーザーの
+// 購読一覧キャッシュを無効化する invalidator を設定する。
+func WithCacheInvalidator(inv cache.UserInvalidator) FeedServiceOption {
+	return func(s *FeedService) {
+		s.cacheInvalidator = inv
+	}
+}
+
+// WithSSRFValidator はフィード URL の変更（UpdateFeedURL）で新しい URL を SSRF 検証する guard を設定する。
+// 登録時の SSRF 検証は検出器（FeedDetector）が行うが、URL の変更は検出器を通らないためサービス側で検証する。
+func WithSSRFValidator(guard SSRFValidator) FeedServiceOption {
+	return func(s *FeedService) {
+		s.ssrfGuard = guard
+	}
+}
+
 // NewFeedService はFeedServiceの新しいインスタンスを生成する。
 func NewFeedService(
 	feedRepo repository.FeedRepository,
 	subRepo repository.SubscriptionRepository,
 	detector Detector,
 	faviconFetcher FaviconFetcherService,
+	opts ...FeedServiceOption,
 ) *FeedService {
-	return &FeedService{
+	s := &FeedService{
 		feedRepo:       feedRepo,
 		subRepo:        subRepo,
 		detector:       detector,
 		faviconFetcher: faviconFetcher,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// invalidateUserCache は invalidator が設定されていれば当該ユーザーのキャッシュを無効化する。
+func (s *FeedService) invalidateUserCache(ctx context.Context, userID string) {
+	if s.cacheInvalidator != nil {
+		s.cacheInvalidator.InvalidateUser(ctx, userID)
+	}
 }
 
 // RegisterFeed はURLからフィードを検出し登録する。
 // フロー: 購読上限チェック → フィード検出 → フィード保存（重複チェック） → 購読作成 → favicon取得
-func (s *FeedService) RegisterFeed(ctx context.Context, userID string, inputURL string) (*model.Feed, *model.Subscription, error) {
+// ブロックリストが設定されている場合は、入力 URL と検出したフィード URL のいずれかが一致すると FEED_BLOCKED を返す。
+// opts.Trial が true の場合は model.TrialSubscriptionDuration 後に期限切れとなるお試し購読を作成する。
+func (s *FeedService) RegisterFeed(ctx context.Context, userID string, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
+	// 0. URL スキームの検証（file:// や ftp:// は購読数の確認や検出より前に拒否する）
+	if err := s.checkInputURL(ctx, inputURL); err != nil {
+		return nil, nil, err
+	}
+
 	// 1. 購読上限チェック
+	if err := s.checkSubscriptionLimit(ctx, userID); err != nil {
+		return nil, nil, err
+	}
+
+	// 2. フィードURL検出
+	feedURL, err := s.detectFeedURL(ctx, inputURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.subscribe(ctx, userID, inputURL, feedURL, opts)
+}
+
+// checkInputURL は入力 URL のスキームとブロックリストを検証する。
+func (s *FeedService) checkInputURL(ctx context.Context, inputURL string) error {
+	if err := validateURLScheme(inputURL); err != nil {
+		return err
+	}
+	return checkBlocklist(ctx, s.blocklist, inputURL)
+}
+
+// checkSubscriptionLimit はユーザーの購読数が上限に達していれば SUBSCRIPTION_LIMIT を返す。
+func (s *FeedService) checkSubscriptionLimit(ctx context.Context, userID string) error {
 	count, err := s.subRepo.CountByUserID(ctx, userID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("購読数の確認に失敗しました: %w", err)
+		return fmt.Errorf("購読数の確認に失敗しました: %w", err)
 	}
-	if count >= maxSubscriptionsPerUser {
-		return nil, nil, model.NewSubscriptionLimitError()
+	if count >= model.MaxSubscriptionsPerUser {
+		return model.NewSubscriptionLimitError()
 	}
+	return nil
+}
 
-	// 2. フィードURL検出
+// detectFeedURL は入力 URL からフィード URL を検出し、検出したフィード URL をブロックリストと照合する。
+func (s *FeedService) detectFeedURL(ctx context.Context, inputURL string) (string, error) {
 	feedURL, err := s.detector.DetectFeedURL(ctx, inputURL)
 	if err != nil {
-		return nil, nil, err
+		return "", err
 	}
+	if err := checkBlocklist(ctx, s.blocklist, feedURL); err != nil {
+		return "", err
+	}
+	return feedURL, nil
+}
 
+// subscribe は検出済みのフィード URL を購読する。
+// フロー: フィード保存（重複チェック） → 購読作成 → favicon取得。購読上限は呼び出し元で確認する。
+func (s *FeedService) subscribe(ctx context.Context, userID, inputURL, feedURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 	// 3. 既存フィードの重複チェック（feed_urlで検索）
 	existingFeed, err := s.feedRepo.FindByFeedURL(ctx, feedURL)
 	if err != nil {
@@ -125,13 +207,20 @@ func (s *FeedService) RegisterFeed(ctx context.Context, userID string, inputURL
 		UserID:               userID,
 		FeedID:               feed.ID,
 		FetchIntervalMinutes: defaultFetchIntervalMinutes,
+		Priority:             model.DefaultNotificationPriority,
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}
+	if opts.Trial {
+		expiresAt := now.Add(model.TrialSubscriptionDuration)
+		sub.ExpiresAt = &expiresAt
+	}
 
 	if err := s.subRepo.Create(ctx, sub); err != nil {
 		return nil, nil, fmt.Errorf("購読の作成に失敗しました: %w", err)
 	}
+	s.invalidateUserCache(ctx, userID)
+	s.recordSubscriptionCreated(ctx, feed, sub)
 
 	// 5. favicon取得（非同期）。
 	// リクエストスコープの ctx から切り離した独立 context で実行し、
@@ -203,6 +292,8 @@ func (s *FeedService) GetFeed(ctx context.Context, userID, feedID string) (*mode
 // UpdateFeedURL はフィードURLを更新する。
 // 認可: リクエストユーザーが当該フィードを購読している場合のみ更新可能。
 // 購読していない場合は IDOR を避けるため FEED_NOT_FOUND を返す。
+// 新しい URL は登録時と同じくスキーム・ブロックリスト・SSRF を検証し、
+// 不正な場合は INVALID_URL / INVALID_URL_SCHEME / FEED_BLOCKED / SSRF_BLOCKED を返す。
 func (s *FeedService) UpdateFeedURL(ctx context.Context, userID, feedID, newURL string) (*model.Feed, error) {
 	sub, err := s.subRepo.FindByUserAndFeed(ctx, userID, feedID)
 	if err != nil {
@@ -210,7 +301,7 @@ func (s *FeedService) UpdateFeedURL(ctx context.Context, userID, feedID, newURL
 	}
 	if sub == nil {
 		return nil, &model.APIError{
-			Code:     "FEED_NOT_FOUND",
+			Code:     model.ErrCodeFeedNotFound,
 			Message:  "指定されたフィードが見つかりません。",
 			Category: "feed",
 			Action:   "フィードIDを確認してください。",
@@ -223,19 +314,29 @@ func (s *FeedService) UpdateFeedURL(ctx context.Context, userID, feedID, newURL
 	}
 	if feed == nil {
 		return nil, &model.APIError{
-			Code:     "FEED_NOT_FOUND",
+			Code:     model.ErrCodeFeedNotFound,
 			Message:  "指定されたフィードが見つかりません。",
 			Category: "feed",
 			Action:   "フィードIDを確認してください。",
 		}
 	}
 
+	if err := s.checkInputURL(ctx, newURL); err != nil {
+		return nil, err
+	}
+	if s.ssrfGuard != nil {
+		if err := s.ssrfGuard.ValidateURL(newURL); err != nil {
+			return nil, model.NewSSRFBlockedError()
+		}
+	}
+
 	feed.FeedURL = newURL
 	feed.UpdatedAt = time.Now()
 
 	if err := s.feedRepo.Update(ctx, feed); err != nil {
 		return nil, fmt.Errorf("フィードURLの更新に失敗しました: %w", err)
 	}
+	s.invalidateUserCache(ctx, userID)
 
 	return feed, nil
 }
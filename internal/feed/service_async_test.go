@@ -6,6 +6,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
 )
 
 // --- 非同期 favicon 取得テスト用の制御可能なモック ---
@@ -91,7 +93,7 @@ func TestRegisterFeed_ReturnsBeforeFaviconCompletes(t *testing.T) {
 	var regErr error
 	start := time.Now()
 	go func() {
-		f, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+		f, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 		feed, regErr = f, err
 		close(done)
 	}()
@@ -138,7 +140,7 @@ func TestRegisterFeed_FaviconSavedAsynchronously(t *testing.T) {
 	svc, feedRepo := newRegisterTestService(fetcher)
 
 	// Act
-	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("RegisterFeed returned error: %v", err)
 	}
@@ -169,7 +171,7 @@ func TestRegisterFeed_SucceedsWhenFaviconFetchErrors(t *testing.T) {
 	svc, feedRepo := newRegisterTestService(fetcher)
 
 	// Act
-	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 
 	// Assert: 登録は成功
 	if err != nil {
@@ -198,7 +200,7 @@ func TestRegisterFeed_SucceedsWhenFaviconNotFound(t *testing.T) {
 	svc, feedRepo := newRegisterTestService(fetcher)
 
 	// Act
-	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("favicon 未検出でも RegisterFeed は成功すべき: %v", err)
 	}
@@ -230,7 +232,7 @@ func TestRegisterFeed_FaviconContinuesAfterRequestCtxCanceled(t *testing.T) {
 	reqCtx, cancel := context.WithCancel(context.Background())
 
 	// Act: 登録（favicon は非同期で起動される）
-	feed, _, err := svc.RegisterFeed(reqCtx, "user-1", "https://example.com")
+	feed, _, err := svc.RegisterFeed(reqCtx, "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		close(fetcher.block)
 		svc.waitFaviconFetch()
@@ -291,7 +293,7 @@ func TestStartFaviconFetch_AppliesTimeoutDeadline(t *testing.T) {
 	svc := NewFeedService(feedRepo, subRepo, detector, observed)
 
 	// Act
-	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("RegisterFeed returned error: %v", err)
 	}
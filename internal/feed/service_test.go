@@ -3,6 +3,7 @@ package feed
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -22,6 +23,8 @@ type mockFeedRepo struct {
 	feedByURL   map[string]*model.Feed
 	createCalls int
 	updateCalls int
+	// listBySiteHostFn が設定されていれば ListBySiteHost はそれを呼ぶ。
+	listBySiteHostFn func(ctx context.Context, userID, host, excludeFeedID string, limit int) ([]*model.Feed, error)
 	// mu は faviconCall への並行アクセス（バックグラウンドgoroutineからの書き込み）を保護する。
 	mu          sync.Mutex
 	faviconCall struct {
@@ -105,6 +108,13 @@ func (m *mockFeedRepo) UpdateLastSuccessfulFetchAt(_ context.Context, _ string,
 	return nil
 }
 
+func (m *mockFeedRepo) ListBySiteHost(ctx context.Context, userID, host, excludeFeedID string, limit int) ([]*model.Feed, error) {
+	if m.listBySiteHostFn != nil {
+		return m.listBySiteHostFn(ctx, userID, host, excludeFeedID, limit)
+	}
+	return nil, nil
+}
+
 // mockSubRepo はテスト用のSubscriptionRepositoryモック。
 type mockSubRepo struct {
 	subs        map[string]*model.Subscription
@@ -233,7 +243,7 @@ func TestFeedService_RegisterFeed_NewFeed(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	feed, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	feed, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("RegisterFeed returned error: %v", err)
 	}
@@ -260,6 +270,65 @@ func TestFeedService_RegisterFeed_NewFeed(t *testing.T) {
 	}
 }
 
+// TestFeedService_RegisterFeed_InvalidScheme は非 HTTP スキームの URL を検出や保存より前に拒否することをテストする。
+func TestFeedService_RegisterFeed_InvalidScheme(t *testing.T) {
+	feedRepo := newMockFeedRepo()
+	subRepo := newMockSubRepo()
+	detector := &mockDetector{feedURL: "https://example.com/feed.xml"}
+	svc := NewFeedService(feedRepo, subRepo, detector, &mockFaviconFetcher{})
+
+	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "file:///tmp/feed.xml", model.RegisterFeedOptions{})
+
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidURLScheme {
+		t.Fatalf("err = %v, want %s", err, model.ErrCodeInvalidURLScheme)
+	}
+	if feedRepo.createCalls != 0 || subRepo.createCalls != 0 {
+		t.Errorf("フィード・購読は作成されるべきではない (feed=%d, sub=%d)", feedRepo.createCalls, subRepo.createCalls)
+	}
+}
+
+// TestFeedService_RegisterFeed_Trial はお試し購読の期限設定を検証する。
+func TestFeedService_RegisterFeed_Trial(t *testing.T) {
+	t.Run("trialを指定したとき作成から1週間後の期限付きで購読を作成する", func(t *testing.T) {
+		// Arrange
+		svc := NewFeedService(newMockFeedRepo(), newMockSubRepo(), &mockDetector{feedURL: "https://example.com/feed.xml"}, &mockFaviconFetcher{})
+
+		// Act
+		_, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{Trial: true})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RegisterFeed returned error: %v", err)
+		}
+		if sub.ExpiresAt == nil {
+			t.Fatal("sub.ExpiresAt should be set for trial subscription")
+		}
+		if got := sub.ExpiresAt.Sub(sub.CreatedAt); got != model.TrialSubscriptionDuration {
+			t.Errorf("ExpiresAt - CreatedAt = %v, want %v", got, model.TrialSubscriptionDuration)
+		}
+		if !sub.IsTrial() {
+			t.Error("IsTrial() = false, want true")
+		}
+	})
+
+	t.Run("trialを指定しないとき期限なしの購読を作成する", func(t *testing.T) {
+		// Arrange
+		svc := NewFeedService(newMockFeedRepo(), newMockSubRepo(), &mockDetector{feedURL: "https://example.com/feed.xml"}, &mockFaviconFetcher{})
+
+		// Act
+		_, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RegisterFeed returned error: %v", err)
+		}
+		if sub.ExpiresAt != nil {
+			t.Errorf("sub.ExpiresAt = %v, want nil", sub.ExpiresAt)
+		}
+	})
+}
+
 // TestFeedService_RegisterFeed_ExistingFeed は既存フィードへの購読が正常に動作することをテストする。
 func TestFeedService_RegisterFeed_ExistingFeed(t *testing.T) {
 	feedRepo := newMockFeedRepo()
@@ -278,7 +347,7 @@ func TestFeedService_RegisterFeed_ExistingFeed(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	feed, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	feed, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("RegisterFeed returned error: %v", err)
 	}
@@ -316,7 +385,7 @@ func TestFeedService_RegisterFeed_DuplicateSubscription(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err == nil {
 		t.Fatal("重複購読はエラーを返すべき")
 	}
@@ -340,7 +409,7 @@ func TestFeedService_RegisterFeed_SubscriptionLimit(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err == nil {
 		t.Fatal("購読上限到達時はエラーを返すべき")
 	}
@@ -365,7 +434,7 @@ func TestFeedService_RegisterFeed_DetectionFails(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err == nil {
 		t.Fatal("フィード検出失敗時はエラーを返すべき")
 	}
@@ -384,7 +453,7 @@ func TestFeedService_RegisterFeed_FaviconFetchFailure(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("favicon取得失敗でもRegisterFeedは成功すべき: %v", err)
 	}
@@ -417,7 +486,7 @@ func TestFeedService_RegisterFeed_FaviconSavedOnSuccess(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	feed, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("RegisterFeed returned error: %v", err)
 	}
@@ -604,7 +673,7 @@ func TestFeedService_RegisterFeed_SubscriptionLimitBoundary(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("購読数99の場合はまだ登録可能であるべき: %v", err)
 	}
@@ -619,7 +688,7 @@ func TestFeedService_RegisterFeed_DefaultFetchInterval(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	_, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	_, sub, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("RegisterFeed returned error: %v", err)
 	}
@@ -668,7 +737,7 @@ func TestFeedService_RegisterFeed_Integration_WithHTTPServer(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detectorAdapter, faviconFetcher)
 
-	feed, sub, err := svc.RegisterFeed(context.Background(), "user-1", server.URL+"/")
+	feed, sub, err := svc.RegisterFeed(context.Background(), "user-1", server.URL+"/", model.RegisterFeedOptions{})
 	if err != nil {
 		t.Fatalf("RegisterFeed returned error: %v", err)
 	}
@@ -741,7 +810,7 @@ func TestFeedService_RegisterFeed_SubscriptionLimitExact100(t *testing.T) {
 
 	svc := NewFeedService(feedRepo, subRepo, detector, faviconFetcher)
 
-	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com")
+	_, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{})
 	if err == nil {
 		t.Fatal("購読数100の場合は拒否されるべき")
 	}
@@ -753,3 +822,29 @@ func TestFeedService_RegisterFeed_SubscriptionLimitExact100(t *testing.T) {
 		t.Errorf("エラーコード = %q, want %q", apiErr.Code, model.ErrCodeSubscriptionLimit)
 	}
 }
+
+// mockInvalidator は cache.UserInvalidator のモック。
+type mockInvalidator struct {
+	invalidated []string
+}
+
+func (m *mockInvalidator) InvalidateUser(_ context.Context, userID string) {
+	m.invalidated = append(m.invalidated, userID)
+}
+
+// TestFeedService_RegisterFeed_InvalidatesCache は購読作成後に購読一覧キャッシュが無効化されることをテストする。
+func TestFeedService_RegisterFeed_InvalidatesCache(t *testing.T) {
+	inv := &mockInvalidator{}
+	svc := NewFeedService(newMockFeedRepo(), newMockSubRepo(),
+		&mockDetector{feedURL: "https://example.com/feed.xml"}, &mockFaviconFetcher{},
+		WithCacheInvalidator(inv))
+
+	if _, _, err := svc.RegisterFeed(context.Background(), "user-1", "https://example.com", model.RegisterFeedOptions{}); err != nil {
+		t.Fatalf("RegisterFeed returned error: %v", err)
+	}
+	svc.waitFaviconFetch()
+
+	if len(inv.invalidated) != 1 || inv.invalidated[0] != "user-1" {
+		t.Errorf("invalidated = %v, want [user-1]", inv.invalidated)
+	}
+}
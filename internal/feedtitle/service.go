@@ -0,0 +1,125 @@
+// Package feedtitle はフィードタイトルの自動更新ポリシー（always / initial / manual）と
+// 承認待ちタイトルの承認・破棄を提供する。
+//
+// ポリシーと承認待ちタイトルは購読ごとに保持し、ある購読者の設定が同じフィードの他の購読者に及ぶことはない。
+// フェッチ時のタイトル変更の反映はワーカー（fetch パッケージ）が行い、本パッケージは
+// 購読者によるポリシーの変更と、manual のときに保留された承認待ちタイトルの承認・破棄を扱う。
+package feedtitle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service はフィードタイトルの自動更新ポリシーのサービス層。
+type Service struct {
+	repo repository.FeedTitleRepository
+	// cacheInvalidator は購読一覧キャッシュの無効化先。未設定時は nil。
+	cacheInvalidator cache.UserInvalidator
+}
+
+// Option は NewService の任意設定を表す functional option。
+type Option func(*Service)
+
+// WithCacheInvalidator は設定の更新時に当該ユーザーの購読一覧キャッシュ
+// （フィードタイトル・ポリシー・承認待ちタイトルを含む）を無効化する invalidator を設定する。
+func WithCacheInvalidator(inv cache.UserInvalidator) Option {
+	return func(s *Service) {
+		s.cacheInvalidator = inv
+	}
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.FeedTitleRepository, opts ...Option) *Service {
+	s := &Service{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetSetting はフィードのタイトル・自動更新ポリシー・承認待ちタイトルを返す。
+// 対象フィードが存在しない、または購読していない場合は FEED_NOT_FOUND を返す。
+func (s *Service) GetSetting(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error) {
+	setting, err := s.repo.GetFeedTitleSetting(ctx, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("フィードタイトル設定の取得に失敗しました: %w", err)
+	}
+	if setting == nil {
+		return nil, feedNotFoundError()
+	}
+	return setting, nil
+}
+
+// UpdatePolicy はフィードタイトルの自動更新ポリシーを検証して更新する。
+// manual 以外に変更した場合、承認待ちタイトルは破棄する。
+func (s *Service) UpdatePolicy(ctx context.Context, userID, feedID string, policy model.TitleUpdatePolicy) (*model.FeedTitleSetting, error) {
+	if !policy.Valid() {
+		return nil, model.NewInvalidTitleUpdatePolicyError(string(policy))
+	}
+	setting, err := s.repo.UpdateTitleUpdatePolicy(ctx, userID, feedID, policy)
+	if err != nil {
+		return nil, fmt.Errorf("フィードタイトル自動更新ポリシーの更新に失敗しました: %w", err)
+	}
+	if setting == nil {
+		return nil, feedNotFoundError()
+	}
+	s.invalidate(ctx, userID)
+	return setting, nil
+}
+
+// ApprovePendingTitle は承認待ちタイトルを購読一覧に表示するタイトルに反映する。
+// 承認待ちタイトルがない場合は PENDING_TITLE_NOT_FOUND を返す。
+func (s *Service) ApprovePendingTitle(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error) {
+	current, err := s.GetSetting(ctx, userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	if current.PendingTitle == "" {
+		return nil, model.NewPendingTitleNotFoundError()
+	}
+	setting, err := s.repo.ApplyPendingTitle(ctx, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("承認待ちタイトルの反映に失敗しました: %w", err)
+	}
+	if setting == nil {
+		return nil, feedNotFoundError()
+	}
+	s.invalidate(ctx, userID)
+	return setting, nil
+}
+
+// DismissPendingTitle は承認待ちタイトルを破棄し、現在のタイトルを維持する。
+// 承認待ちタイトルがない場合も成功として現在の設定を返す。
+func (s *Service) DismissPendingTitle(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error) {
+	setting, err := s.repo.DismissPendingTitle(ctx, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("承認待ちタイトルの破棄に失敗しました: %w", err)
+	}
+	if setting == nil {
+		return nil, feedNotFoundError()
+	}
+	s.invalidate(ctx, userID)
+	return setting, nil
+}
+
+// invalidate は当該ユーザーの購読一覧キャッシュを無効化する。
+func (s *Service) invalidate(ctx context.Context, userID string) {
+	if s.cacheInvalidator != nil {
+		s.cacheInvalidator.InvalidateUser(ctx, userID)
+	}
+}
+
+// feedNotFoundError は購読していないフィードへの操作に返すエラー。IDOR を避けるため存在しない場合と区別しない。
+func feedNotFoundError() *model.APIError {
+	return &model.APIError{
+		Code:     model.ErrCodeFeedNotFound,
+		Message:  "指定されたフィードが見つかりません。",
+		Category: "feed",
+		Action:   "フィードIDを確認してください。",
+	}
+}
@@ -0,0 +1,258 @@
+package feedtitle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// --- テスト用モック ---
+
+// mockFeedTitleRepo は FeedTitleRepository のモック。
+type mockFeedTitleRepo struct {
+	setting      *model.FeedTitleSetting
+	err          error
+	updateCalls  int
+	applyCalls   int
+	dismissCalls int
+	savedPolicy  model.TitleUpdatePolicy
+}
+
+func (m *mockFeedTitleRepo) GetFeedTitleSetting(_ context.Context, _, _ string) (*model.FeedTitleSetting, error) {
+	return m.setting, m.err
+}
+
+func (m *mockFeedTitleRepo) UpdateTitleUpdatePolicy(_ context.Context, _, _ string, policy model.TitleUpdatePolicy) (*model.FeedTitleSetting, error) {
+	m.updateCalls++
+	m.savedPolicy = policy
+	if m.err != nil || m.setting == nil {
+		return nil, m.err
+	}
+	s := *m.setting
+	s.Policy = policy
+	if policy != model.TitleUpdatePolicyManual {
+		s.PendingTitle = ""
+	}
+	return &s, nil
+}
+
+func (m *mockFeedTitleRepo) ApplyPendingTitle(_ context.Context, _, _ string) (*model.FeedTitleSetting, error) {
+	m.applyCalls++
+	if m.err != nil || m.setting == nil {
+		return nil, m.err
+	}
+	s := *m.setting
+	if s.PendingTitle != "" {
+		s.Title = s.PendingTitle
+	}
+	s.PendingTitle = ""
+	return &s, nil
+}
+
+func (m *mockFeedTitleRepo) DismissPendingTitle(_ context.Context, _, _ string) (*model.FeedTitleSetting, error) {
+	m.dismissCalls++
+	if m.err != nil || m.setting == nil {
+		return nil, m.err
+	}
+	s := *m.setting
+	s.PendingTitle = ""
+	return &s, nil
+}
+
+var _ repository.FeedTitleRepository = (*mockFeedTitleRepo)(nil)
+
+// mockInvalidator は cache.UserInvalidator のモック。
+type mockInvalidator struct {
+	invalidated []string
+}
+
+func (m *mockInvalidator) InvalidateUser(_ context.Context, userID string) {
+	m.invalidated = append(m.invalidated, userID)
+}
+
+// assertAPIErrorCode は err が指定コードの APIError であることを検証する。
+func assertAPIErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Fatalf("err = %v, want APIError code %s", err, code)
+	}
+}
+
+func TestService_GetSetting(t *testing.T) {
+	t.Run("購読しているとき設定を返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockFeedTitleRepo{setting: &model.FeedTitleSetting{Title: "Blog", Policy: model.TitleUpdatePolicyManual, PendingTitle: "New Blog"}}
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.GetSetting(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Title != "Blog" || got.Policy != model.TitleUpdatePolicyManual || got.PendingTitle != "New Blog" {
+			t.Errorf("setting = %+v", got)
+		}
+	})
+
+	t.Run("購読していないときFEED_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockFeedTitleRepo{})
+
+		// Act
+		_, err := svc.GetSetting(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeFeedNotFound)
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockFeedTitleRepo{err: errors.New("db down")})
+
+		// Act
+		_, err := svc.GetSetting(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestService_UpdatePolicy(t *testing.T) {
+	t.Run("有効なポリシーのとき更新してキャッシュを無効化する", func(t *testing.T) {
+		// Arrange
+		repo := &mockFeedTitleRepo{setting: &model.FeedTitleSetting{Title: "Blog", Policy: model.TitleUpdatePolicyAlways}}
+		inv := &mockInvalidator{}
+		svc := NewService(repo, WithCacheInvalidator(inv))
+
+		// Act
+		got, err := svc.UpdatePolicy(context.Background(), "user-1", "feed-1", model.TitleUpdatePolicyInitial)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Policy != model.TitleUpdatePolicyInitial || repo.savedPolicy != model.TitleUpdatePolicyInitial {
+			t.Errorf("policy = %q, saved = %q", got.Policy, repo.savedPolicy)
+		}
+		if len(inv.invalidated) != 1 || inv.invalidated[0] != "user-1" {
+			t.Errorf("invalidated = %v, want [user-1]", inv.invalidated)
+		}
+	})
+
+	t.Run("不正なポリシーのときINVALID_TITLE_UPDATE_POLICYを返し更新しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockFeedTitleRepo{setting: &model.FeedTitleSetting{Title: "Blog"}}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.UpdatePolicy(context.Background(), "user-1", "feed-1", model.TitleUpdatePolicy("sometimes"))
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeInvalidTitleUpdatePolicy)
+		if repo.updateCalls != 0 {
+			t.Errorf("updateCalls = %d, want 0", repo.updateCalls)
+		}
+	})
+
+	t.Run("購読していないときFEED_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		inv := &mockInvalidator{}
+		svc := NewService(&mockFeedTitleRepo{}, WithCacheInvalidator(inv))
+
+		// Act
+		_, err := svc.UpdatePolicy(context.Background(), "user-1", "feed-1", model.TitleUpdatePolicyManual)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeFeedNotFound)
+		if len(inv.invalidated) != 0 {
+			t.Errorf("invalidated = %v, want none", inv.invalidated)
+		}
+	})
+}
+
+func TestService_ApprovePendingTitle(t *testing.T) {
+	t.Run("承認待ちタイトルがあるときタイトルに反映する", func(t *testing.T) {
+		// Arrange
+		repo := &mockFeedTitleRepo{setting: &model.FeedTitleSetting{Title: "Blog", Policy: model.TitleUpdatePolicyManual, PendingTitle: "New Blog"}}
+		inv := &mockInvalidator{}
+		svc := NewService(repo, WithCacheInvalidator(inv))
+
+		// Act
+		got, err := svc.ApprovePendingTitle(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Title != "New Blog" || got.PendingTitle != "" {
+			t.Errorf("setting = %+v", got)
+		}
+		if len(inv.invalidated) != 1 {
+			t.Errorf("invalidated = %v, want [user-1]", inv.invalidated)
+		}
+	})
+
+	t.Run("承認待ちタイトルがないときPENDING_TITLE_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockFeedTitleRepo{setting: &model.FeedTitleSetting{Title: "Blog", Policy: model.TitleUpdatePolicyManual}}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.ApprovePendingTitle(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodePendingTitleNotFound)
+		if repo.applyCalls != 0 {
+			t.Errorf("applyCalls = %d, want 0", repo.applyCalls)
+		}
+	})
+
+	t.Run("購読していないときFEED_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockFeedTitleRepo{})
+
+		// Act
+		_, err := svc.ApprovePendingTitle(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeFeedNotFound)
+	})
+}
+
+func TestService_DismissPendingTitle(t *testing.T) {
+	t.Run("承認待ちタイトルを破棄し現在のタイトルを維持する", func(t *testing.T) {
+		// Arrange
+		repo := &mockFeedTitleRepo{setting: &model.FeedTitleSetting{Title: "Blog", Policy: model.TitleUpdatePolicyManual, PendingTitle: "New Blog"}}
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.DismissPendingTitle(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Title != "Blog" || got.PendingTitle != "" {
+			t.Errorf("setting = %+v", got)
+		}
+	})
+
+	t.Run("購読していないときFEED_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockFeedTitleRepo{})
+
+		// Act
+		_, err := svc.DismissPendingTitle(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeFeedNotFound)
+	})
+}
@@ -0,0 +1,405 @@
+// Package handler の admin_handler.go は、インスタンス運用者向けの HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント（いずれも管理者限定）:
+//   - GET /api/admin/stats : ユーザー数・フィード数・記事数・直近 24h のフェッチ成功率などの全体統計
+//   - PUT /api/admin/feeds/{id}/conditional-get : フィード単位の条件付き GET 無効化の切り替え
+//   - PUT /api/admin/feeds/{id}/raw-capture : フィード単位のフェッチレスポンス保存（デバッグモード）の切り替え
+//   - GET /api/admin/feeds/{id}/raw-capture : 保存した直近のフェッチレスポンスのダウンロード
+//   - GET /api/admin/worker-cycles : フェッチワーカーの直近のサイクル結果
+//   - GET /api/admin/blocked-domains : フィードのブロックリスト（ドメイン・URL）の一覧
+//   - POST /api/admin/blocked-domains : ブロックリストへの追加（既存フィードへの遡及適用を選択可）
+//   - DELETE /api/admin/blocked-domains/{id} : ブロックリストからの削除
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// AdminStatsServiceInterface は管理者向け統計ハンドラが必要とするサービスインターフェース。
+type AdminStatsServiceInterface interface {
+	// GetStats は最後に集計した全体統計を返す。
+	GetStats(ctx context.Context) (*adminStatsResponse, error)
+}
+
+// AdminHandler はインスタンス運用者向けエンドポイントの HTTP ハンドラ。
+type AdminHandler struct {
+	service AdminStatsServiceInterface
+}
+
+// NewAdminHandler は AdminHandler を生成する。
+func NewAdminHandler(service AdminStatsServiceInterface) *AdminHandler {
+	return &AdminHandler{service: service}
+}
+
+// adminFetchStatsResponse は直近 24 時間のフェッチ統計。
+type adminFetchStatsResponse struct {
+	Attempts  int64 `json:"attempts"`
+	Successes int64 `json:"successes"`
+	// SuccessRate は 0〜1 の成功率。期間内にフェッチが無い場合は null。
+	SuccessRate   *float64 `json:"success_rate"`
+	AvgDurationMs float64  `json:"avg_duration_ms"`
+}
+
+// adminStatsResponse は GET /api/admin/stats のレスポンス。
+type adminStatsResponse struct {
+	UserCount         int64                   `json:"user_count"`
+	FeedCount         int64                   `json:"feed_count"`
+	ItemCount         int64                   `json:"item_count"`
+	Fetch24h          adminFetchStatsResponse `json:"fetch_24h"`
+	DatabaseSizeBytes int64                   `json:"database_size_bytes"`
+	RefreshedAt       time.Time               `json:"refreshed_at"`
+}
+
+// Stats はインスタンス全体の統計を返す。
+// GET /api/admin/stats
+//
+// 値は worker が定期的に再集計したスナップショットで、集計時刻を refreshed_at で返す。
+// 管理者判定はルーター側の NewAdminOnlyMiddleware で行うため、本ハンドラでは扱わない。
+func (h *AdminHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetStats(r.Context())
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// FeedAdminServiceInterface は管理者向けフィード設定ハンドラが必要とするサービスインターフェース。
+type FeedAdminServiceInterface interface {
+	// SetIgnoreConditionalGet はフィードの条件付き GET 無効化フラグを更新する。
+	// 対象フィードが存在しない場合は nil を返す。
+	SetIgnoreConditionalGet(ctx context.Context, feedID string, ignore bool) (*feedConditionalGetResponse, error)
+	// SetRawCapture はフィードのフェッチレスポンス保存の有効・無効を更新する。無効にした場合は保存済みのレスポンスを削除する。
+	// 対象フィードが存在しない場合は nil を返す。
+	SetRawCapture(ctx context.Context, feedID string, enabled bool) (*feedRawCaptureSettingResponse, error)
+	// GetRawCapture はフィードの直近のフェッチレスポンスを返す。保存されていない場合は FEED_RAW_CAPTURE_NOT_FOUND を返す。
+	GetRawCapture(ctx context.Context, feedID string) (*model.FeedRawCapture, error)
+}
+
+// FeedAdminHandler は管理者向けフィード設定の HTTP ハンドラ。
+type FeedAdminHandler struct {
+	service FeedAdminServiceInterface
+}
+
+// NewFeedAdminHandler は FeedAdminHandler を生成する。
+func NewFeedAdminHandler(service FeedAdminServiceInterface) *FeedAdminHandler {
+	return &FeedAdminHandler{service: service}
+}
+
+// feedConditionalGetRequest は条件付き GET 設定の更新リクエストのボディ。
+// 未指定と false を区別するためポインタで受け取る。
+type feedConditionalGetRequest struct {
+	IgnoreConditionalGet *bool `json:"ignore_conditional_get"`
+}
+
+// feedConditionalGetResponse は条件付き GET 設定の API レスポンス。
+type feedConditionalGetResponse struct {
+	FeedID               string `json:"feed_id"`
+	IgnoreConditionalGet bool   `json:"ignore_conditional_get"`
+}
+
+// UpdateConditionalGet はフィードの条件付き GET（If-None-Match / If-Modified-Since）の送信可否を切り替える。
+// PUT /api/admin/feeds/{id}/conditional-get
+//
+// ETag を不正確に返し 304 ばかり応答するサーバー向けの運用操作で、次回のフェッチから反映される。
+func (h *FeedAdminHandler) UpdateConditionalGet(w http.ResponseWriter, r *http.Request) {
+	var req feedConditionalGetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.IgnoreConditionalGet == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "ignore_conditional_get を真偽値で指定してください。",
+		})
+		return
+	}
+
+	feedID := chi.URLParam(r, "id")
+	resp, err := h.service.SetIgnoreConditionalGet(r.Context(), feedID, *req.IgnoreConditionalGet)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if resp == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeFeedNotFound,
+			Message:  "指定されたフィードが見つかりません。",
+			Category: "feed",
+			Action:   "フィードIDを確認してください。",
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// feedRawCaptureSettingRequest はフェッチレスポンス保存設定の更新リクエストのボディ。
+// 未指定と false を区別するためポインタで受け取る。
+type feedRawCaptureSettingRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// feedRawCaptureSettingResponse はフェッチレスポンス保存設定の API レスポンス。
+type feedRawCaptureSettingResponse struct {
+	FeedID  string `json:"feed_id"`
+	Enabled bool   `json:"enabled"`
+}
+
+// UpdateRawCapture はフィードの直近のフェッチレスポンスの保存（デバッグモード）を切り替える。
+// PUT /api/admin/feeds/{id}/raw-capture
+//
+// 有効にすると次回以降のフェッチでレスポンスのヘッダーとボディ先頭 256KB を 1 回分だけ上書き保存する。
+// 無効にすると保存済みのレスポンスも削除する。
+func (h *FeedAdminHandler) UpdateRawCapture(w http.ResponseWriter, r *http.Request) {
+	var req feedRawCaptureSettingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "enabled を真偽値で指定してください。",
+		})
+		return
+	}
+
+	feedID := chi.URLParam(r, "id")
+	resp, err := h.service.SetRawCapture(r.Context(), feedID, *req.Enabled)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if resp == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeFeedNotFound,
+			Message:  "指定されたフィードが見つかりません。",
+			Category: "feed",
+			Action:   "フィードIDを確認してください。",
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// DownloadRawCapture は保存した直近のフェッチレスポンスをダウンロードさせる。
+// GET /api/admin/feeds/{id}/raw-capture
+//
+// 本文はステータス行・レスポンスヘッダー・空行・ボディ先頭をつなげた HTTP メッセージ形式
+// （curl -i の出力と同じ並び）で返す。取得した URL・日時・ボディの切り詰め有無は
+// X-Raw-Capture-* レスポンスヘッダーで返す。
+func (h *FeedAdminHandler) DownloadRawCapture(w http.ResponseWriter, r *http.Request) {
+	feedID := chi.URLParam(r, "id")
+	capture, err := h.service.GetRawCapture(r.Context(), feedID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="feed-%s-%s.http"`,
+		feedID, capture.FetchedAt.UTC().Format("20060102T150405Z")))
+	w.Header().Set("X-Raw-Capture-URL", capture.RequestURL)
+	w.Header().Set("X-Raw-Capture-Fetched-At", capture.FetchedAt.UTC().Format(time.RFC3339))
+	w.Header().Set("X-Raw-Capture-Truncated", strconv.FormatBool(capture.BodyTruncated))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(formatRawCapture(capture))
+}
+
+// formatRawCapture は保存したフェッチレスポンスを HTTP メッセージ形式のバイト列に整形する。
+func formatRawCapture(capture *model.FeedRawCapture) []byte {
+	var buf bytes.Buffer
+	proto := capture.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	fmt.Fprintf(&buf, "%s %d %s\r\n", proto, capture.StatusCode, http.StatusText(capture.StatusCode))
+	_ = capture.Header.Write(&buf)
+	buf.WriteString("\r\n")
+	buf.Write(capture.Body)
+	return buf.Bytes()
+}
+
+// WorkerCycleServiceInterface はフェッチサイクル履歴ハンドラが必要とするサービスインターフェース。
+type WorkerCycleServiceInterface interface {
+	// ListRecentCycles は開始時刻の新しい順に最大 limit 件のサイクル結果を返す。
+	ListRecentCycles(ctx context.Context, limit int) ([]workerCycleResponse, error)
+}
+
+// WorkerCycleHandler はフェッチサイクル履歴の HTTP ハンドラ。
+type WorkerCycleHandler struct {
+	service WorkerCycleServiceInterface
+}
+
+// NewWorkerCycleHandler は WorkerCycleHandler を生成する。
+func NewWorkerCycleHandler(service WorkerCycleServiceInterface) *WorkerCycleHandler {
+	return &WorkerCycleHandler{service: service}
+}
+
+// workerCycleResponse はフェッチサイクル 1 回分の結果。
+type workerCycleResponse struct {
+	ID             int64     `json:"id"`
+	StartedAt      time.Time `json:"started_at"`
+	FinishedAt     time.Time `json:"finished_at"`
+	FeedCount      int       `json:"feed_count"`
+	SucceededCount int       `json:"succeeded_count"`
+	FailedCount    int       `json:"failed_count"`
+	ItemsInserted  int       `json:"items_inserted"`
+	ItemsUpdated   int       `json:"items_updated"`
+	DurationMs     int64     `json:"duration_ms"`
+}
+
+// workerCycleListResponse は GET /api/admin/worker-cycles のレスポンス。
+type workerCycleListResponse struct {
+	Cycles []workerCycleResponse `json:"cycles"`
+}
+
+// ListCycles はフェッチワーカーの直近のサイクル結果を新しい順に返す。
+// GET /api/admin/worker-cycles?limit=N
+//
+// limit は任意（既定 50、上限 200 でクランプ。いずれも設定で変更可）。形式不正・非正値は 400 INVALID_REQUEST。
+// サイクル結果は worker が 7 日間保持する。
+func (h *WorkerCycleHandler) ListCycles(w http.ResponseWriter, r *http.Request) {
+	limit, err := parsePageLimit(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	cycles, err := h.service.ListRecentCycles(r.Context(), limit)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if cycles == nil {
+		cycles = []workerCycleResponse{}
+	}
+
+	WriteJSON(w, http.StatusOK, workerCycleListResponse{Cycles: cycles})
+}
+
+// BlockedDomainServiceInterface はブロックリスト管理ハンドラが必要とするサービスインターフェース。
+type BlockedDomainServiceInterface interface {
+	// ListBlockedDomains はブロックリストの全項目を追加日時の昇順で返す。
+	ListBlockedDomains(ctx context.Context) ([]blockedDomainResponse, error)
+	// AddBlockedDomain はブロックリストに項目を追加し、遡及適用で停止したフィード数とともに返す。
+	AddBlockedDomain(ctx context.Context, adminUserID string, req blockedDomainRequest) (*blockedDomainCreateResponse, error)
+	// DeleteBlockedDomain はブロックリストの項目を削除する。存在しない場合は BLOCKED_DOMAIN_NOT_FOUND を返す。
+	DeleteBlockedDomain(ctx context.Context, id string) error
+}
+
+// BlockedDomainHandler はフィードのブロックリスト管理の HTTP ハンドラ。
+type BlockedDomainHandler struct {
+	service BlockedDomainServiceInterface
+}
+
+// NewBlockedDomainHandler は BlockedDomainHandler を生成する。
+func NewBlockedDomainHandler(service BlockedDomainServiceInterface) *BlockedDomainHandler {
+	return &BlockedDomainHandler{service: service}
+}
+
+// blockedDomainRequest は POST /api/admin/blocked-domains のリクエストボディ。
+// pattern は "example.com" のようなドメイン（サブドメインも対象）、または http(s):// で始まるフィード URL。
+type blockedDomainRequest struct {
+	Pattern         string `json:"pattern"`
+	Reason          string `json:"reason"`
+	ApplyToExisting bool   `json:"apply_to_existing"`
+}
+
+// blockedDomainResponse はブロックリストの項目 1 件。
+type blockedDomainResponse struct {
+	ID        string    `json:"id"`
+	Pattern   string    `json:"pattern"`
+	Kind      string    `json:"kind"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// blockedDomainListResponse は GET /api/admin/blocked-domains のレスポンス。
+type blockedDomainListResponse struct {
+	BlockedDomains []blockedDomainResponse `json:"blocked_domains"`
+}
+
+// blockedDomainCreateResponse は POST /api/admin/blocked-domains のレスポンス。
+// stopped_feed_count は遡及適用でフェッチを停止したフィード数（apply_to_existing が false の場合は 0）。
+type blockedDomainCreateResponse struct {
+	blockedDomainResponse
+	StoppedFeedCount int `json:"stopped_feed_count"`
+}
+
+// ListBlockedDomains はブロックリストの一覧を返す。
+// GET /api/admin/blocked-domains
+func (h *BlockedDomainHandler) ListBlockedDomains(w http.ResponseWriter, r *http.Request) {
+	blocked, err := h.service.ListBlockedDomains(r.Context())
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if blocked == nil {
+		blocked = []blockedDomainResponse{}
+	}
+
+	WriteJSON(w, http.StatusOK, blockedDomainListResponse{BlockedDomains: blocked})
+}
+
+// AddBlockedDomain はブロックリストに項目を追加する。
+// POST /api/admin/blocked-domains
+//
+// 追加後のフィード登録・自動検出で一致する URL は FEED_BLOCKED で拒否される。
+// apply_to_existing が true の場合は一致する既存フィードのフェッチも停止する。
+// 同じ pattern を再度追加した場合は理由を更新し、既存の項目を返す。
+func (h *BlockedDomainHandler) AddBlockedDomain(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req blockedDomainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.AddBlockedDomain(r.Context(), userID, req)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, resp)
+}
+
+// DeleteBlockedDomain はブロックリストから項目を削除する。
+// DELETE /api/admin/blocked-domains/{id}
+//
+// 遡及適用で停止したフィードは自動では再開しない（購読者のフェッチ再開操作で再開できるようになる）。
+func (h *BlockedDomainHandler) DeleteBlockedDomain(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteBlockedDomain(r.Context(), chi.URLParam(r, "id")); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,945 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockAdminStatsService は AdminStatsServiceInterface のモック実装。
+type mockAdminStatsService struct {
+	getStatsFn    func(ctx context.Context) (*adminStatsResponse, error)
+	getStatsCalls int
+}
+
+func (m *mockAdminStatsService) GetStats(ctx context.Context) (*adminStatsResponse, error) {
+	m.getStatsCalls++
+	if m.getStatsFn != nil {
+		return m.getStatsFn(ctx)
+	}
+	return &adminStatsResponse{}, nil
+}
+
+// mockFeedAdminService は FeedAdminServiceInterface のモック実装。
+type mockFeedAdminService struct {
+	setIgnoreConditionalGetFn    func(ctx context.Context, feedID string, ignore bool) (*feedConditionalGetResponse, error)
+	setIgnoreConditionalGetCalls int
+	setRawCaptureFn              func(ctx context.Context, feedID string, enabled bool) (*feedRawCaptureSettingResponse, error)
+	setRawCaptureCalls           int
+	getRawCaptureFn              func(ctx context.Context, feedID string) (*model.FeedRawCapture, error)
+}
+
+func (m *mockFeedAdminService) SetIgnoreConditionalGet(ctx context.Context, feedID string, ignore bool) (*feedConditionalGetResponse, error) {
+	m.setIgnoreConditionalGetCalls++
+	if m.setIgnoreConditionalGetFn != nil {
+		return m.setIgnoreConditionalGetFn(ctx, feedID, ignore)
+	}
+	return &feedConditionalGetResponse{FeedID: feedID, IgnoreConditionalGet: ignore}, nil
+}
+
+func (m *mockFeedAdminService) SetRawCapture(ctx context.Context, feedID string, enabled bool) (*feedRawCaptureSettingResponse, error) {
+	m.setRawCaptureCalls++
+	if m.setRawCaptureFn != nil {
+		return m.setRawCaptureFn(ctx, feedID, enabled)
+	}
+	return &feedRawCaptureSettingResponse{FeedID: feedID, Enabled: enabled}, nil
+}
+
+func (m *mockFeedAdminService) GetRawCapture(ctx context.Context, feedID string) (*model.FeedRawCapture, error) {
+	if m.getRawCaptureFn != nil {
+		return m.getRawCaptureFn(ctx, feedID)
+	}
+	return nil, model.NewFeedRawCaptureNotFoundError(feedID)
+}
+
+// --- GET /api/admin/stats テスト ---
+
+func TestAdminHandler_Stats(t *testing.T) {
+	t.Run("全体統計を返す", func(t *testing.T) {
+		// Arrange
+		rate := 0.9
+		refreshedAt := time.Date(2026, 6, 9, 12, 0, 0, 0, time.UTC)
+		svc := &mockAdminStatsService{
+			getStatsFn: func(context.Context) (*adminStatsResponse, error) {
+				return &adminStatsResponse{
+					UserCount:         12,
+					FeedCount:         34,
+					ItemCount:         5678,
+					Fetch24h:          adminFetchStatsResponse{Attempts: 10, Successes: 9, SuccessRate: &rate, AvgDurationMs: 250.5},
+					DatabaseSizeBytes: 1 << 20,
+					RefreshedAt:       refreshedAt,
+				}, nil
+			},
+		}
+		h := NewAdminHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Stats(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["user_count"] != float64(12) || body["item_count"] != float64(5678) || body["database_size_bytes"] != float64(1<<20) {
+			t.Errorf("body = %v", body)
+		}
+		fetch, ok := body["fetch_24h"].(map[string]interface{})
+		if !ok || fetch["success_rate"] != 0.9 || fetch["avg_duration_ms"] != 250.5 {
+			t.Errorf("fetch_24h = %v", body["fetch_24h"])
+		}
+		if body["refreshed_at"] != "2026-06-09T12:00:00Z" {
+			t.Errorf("refreshed_at = %v", body["refreshed_at"])
+		}
+	})
+
+	t.Run("期間内にフェッチが無いときsuccess_rateはnullを返す", func(t *testing.T) {
+		// Arrange
+		h := NewAdminHandler(&mockAdminStatsService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Stats(w, req)
+
+		// Assert
+		var body struct {
+			Fetch24h map[string]interface{} `json:"fetch_24h"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if v, ok := body.Fetch24h["success_rate"]; !ok || v != nil {
+			t.Errorf("success_rate = %v (present=%v), want null", v, ok)
+		}
+	})
+
+	t.Run("サービスがエラーを返したとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockAdminStatsService{
+			getStatsFn: func(context.Context) (*adminStatsResponse, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		h := NewAdminHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Stats(w, req)
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
+
+// --- PUT /api/admin/feeds/{id}/conditional-get テスト ---
+
+func TestFeedAdminHandler_UpdateConditionalGet(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/feeds/feed-1/conditional-get", strings.NewReader(body))
+		return withChiURLParam(withUserID(req, "admin-1"), "id", "feed-1")
+	}
+
+	t.Run("フラグを更新し更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		var gotFeedID string
+		var gotIgnore bool
+		svc := &mockFeedAdminService{
+			setIgnoreConditionalGetFn: func(_ context.Context, feedID string, ignore bool) (*feedConditionalGetResponse, error) {
+				gotFeedID, gotIgnore = feedID, ignore
+				return &feedConditionalGetResponse{FeedID: feedID, IgnoreConditionalGet: ignore}, nil
+			},
+		}
+		h := NewFeedAdminHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateConditionalGet(w, newRequest(`{"ignore_conditional_get": true}`))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotFeedID != "feed-1" || !gotIgnore {
+			t.Errorf("service called with (%q, %v), want (\"feed-1\", true)", gotFeedID, gotIgnore)
+		}
+		var body feedConditionalGetResponse
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body.FeedID != "feed-1" || !body.IgnoreConditionalGet {
+			t.Errorf("body = %+v", body)
+		}
+	})
+
+	t.Run("ignore_conditional_getが未指定のとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedAdminService{}
+		h := NewFeedAdminHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateConditionalGet(w, newRequest(`{}`))
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.setIgnoreConditionalGetCalls != 0 {
+			t.Errorf("SetIgnoreConditionalGet calls = %d, want 0", svc.setIgnoreConditionalGetCalls)
+		}
+	})
+
+	t.Run("不正なJSONのとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedAdminHandler(&mockFeedAdminService{})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateConditionalGet(w, newRequest(`{invalid`))
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("フィードが存在しないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedAdminService{
+			setIgnoreConditionalGetFn: func(context.Context, string, bool) (*feedConditionalGetResponse, error) {
+				return nil, nil
+			},
+		}
+		h := NewFeedAdminHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateConditionalGet(w, newRequest(`{"ignore_conditional_get": false}`))
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeFeedNotFound {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeFeedNotFound)
+		}
+	})
+}
+
+// --- PUT /api/admin/feeds/{id}/raw-capture テスト ---
+
+func TestFeedAdminHandler_UpdateRawCapture(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/feeds/feed-1/raw-capture", strings.NewReader(body))
+		return withChiURLParam(withUserID(req, "admin-1"), "id", "feed-1")
+	}
+
+	t.Run("設定を更新し更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		var gotFeedID string
+		var gotEnabled bool
+		svc := &mockFeedAdminService{
+			setRawCaptureFn: func(_ context.Context, feedID string, enabled bool) (*feedRawCaptureSettingResponse, error) {
+				gotFeedID, gotEnabled = feedID, enabled
+				return &feedRawCaptureSettingResponse{FeedID: feedID, Enabled: enabled}, nil
+			},
+		}
+		h := NewFeedAdminHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateRawCapture(w, newRequest(`{"enabled": true}`))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotFeedID != "feed-1" || !gotEnabled {
+			t.Errorf("service called with (%q, %v), want (\"feed-1\", true)", gotFeedID, gotEnabled)
+		}
+		var body feedRawCaptureSettingResponse
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body.FeedID != "feed-1" || !body.Enabled {
+			t.Errorf("body = %+v", body)
+		}
+	})
+
+	t.Run("enabledが未指定のとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedAdminService{}
+		h := NewFeedAdminHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateRawCapture(w, newRequest(`{}`))
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.setRawCaptureCalls != 0 {
+			t.Errorf("SetRawCapture calls = %d, want 0", svc.setRawCaptureCalls)
+		}
+	})
+
+	t.Run("フィードが存在しないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedAdminService{
+			setRawCaptureFn: func(context.Context, string, bool) (*feedRawCaptureSettingResponse, error) {
+				return nil, nil
+			},
+		}
+		h := NewFeedAdminHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateRawCapture(w, newRequest(`{"enabled": false}`))
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeFeedNotFound {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeFeedNotFound)
+		}
+	})
+}
+
+// --- GET /api/admin/feeds/{id}/raw-capture テスト ---
+
+func TestFeedAdminHandler_DownloadRawCapture(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/feeds/feed-1/raw-capture", nil)
+		return withChiURLParam(withUserID(req, "admin-1"), "id", "feed-1")
+	}
+
+	t.Run("保存したレスポンスをHTTPメッセージ形式で返す", func(t *testing.T) {
+		// Arrange
+		fetchedAt := time.Date(2026, 7, 13, 9, 30, 0, 0, time.UTC)
+		svc := &mockFeedAdminService{
+			getRawCaptureFn: func(_ context.Context, feedID string) (*model.FeedRawCapture, error) {
+				return &model.FeedRawCapture{
+					FeedID:        feedID,
+					FetchedAt:     fetchedAt,
+					RequestURL:    "https://example.com/feed.xml",
+					StatusCode:    http.StatusOK,
+					Proto:         "HTTP/1.1",
+					Header:        http.Header{"Content-Type": {"application/rss+xml"}},
+					Body:          []byte("<rss></rss>"),
+					BodyTruncated: true,
+				}, nil
+			},
+		}
+		h := NewFeedAdminHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DownloadRawCapture(w, newRequest())
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/octet-stream" {
+			t.Errorf("Content-Type = %q, want application/octet-stream", got)
+		}
+		if got, want := w.Header().Get("Content-Disposition"), `attachment; filename="feed-feed-1-20260713T093000Z.http"`; got != want {
+			t.Errorf("Content-Disposition = %q, want %q", got, want)
+		}
+		if got := w.Header().Get("X-Raw-Capture-URL"); got != "https://example.com/feed.xml" {
+			t.Errorf("X-Raw-Capture-URL = %q", got)
+		}
+		if got := w.Header().Get("X-Raw-Capture-Truncated"); got != "true" {
+			t.Errorf("X-Raw-Capture-Truncated = %q, want true", got)
+		}
+		want := "HTTP/1.1 200 OK\r\nContent-Type: application/rss+xml\r\n\r\n<rss></rss>"
+		if got := w.Body.String(); got != want {
+			t.Errorf("body = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("保存されていないとき404を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedAdminHandler(&mockFeedAdminService{})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DownloadRawCapture(w, newRequest())
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeFeedRawCaptureNotFound {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeFeedRawCaptureNotFound)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_AdminRoutes は全体統計ルートが管理者のみに開放されていることを検証する。
+func TestNewRouter_AdminRoutes(t *testing.T) {
+	newRouter := func(svc AdminStatsServiceInterface, admins []string) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+			AdminUserIDs:        admins,
+		}
+		if svc != nil {
+			deps.AdminStatsService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler, withSession bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil)
+		if withSession {
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("管理者のとき200を返しサービスが呼ばれる", func(t *testing.T) {
+		// Arrange
+		svc := &mockAdminStatsService{}
+		router := newRouter(svc, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.getStatsCalls != 1 {
+			t.Errorf("GetStats calls = %d, want 1", svc.getStatsCalls)
+		}
+	})
+
+	t.Run("管理者でないとき403を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockAdminStatsService{}
+		router := newRouter(svc, []string{"admin-1"})
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		if svc.getStatsCalls != 0 {
+			t.Errorf("GetStats calls = %d, want 0", svc.getStatsCalls)
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newRouter(&mockAdminStatsService{}, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router, false)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("AdminStatsService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+// TestNewRouter_FeedAdminRoutes はフィード設定ルートが管理者のみに開放されていることを検証する。
+func TestNewRouter_FeedAdminRoutes(t *testing.T) {
+	newRouter := func(svc FeedAdminServiceInterface, admins []string) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+			AdminUserIDs:        admins,
+		}
+		if svc != nil {
+			deps.FeedAdminService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/feeds/feed-1/conditional-get", strings.NewReader(`{"ignore_conditional_get": true}`))
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("管理者のとき200を返しサービスが呼ばれる", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedAdminService{}
+		router := newRouter(svc, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.setIgnoreConditionalGetCalls != 1 {
+			t.Errorf("SetIgnoreConditionalGet calls = %d, want 1", svc.setIgnoreConditionalGetCalls)
+		}
+	})
+
+	t.Run("管理者でないとき403を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedAdminService{}
+		router := newRouter(svc, []string{"admin-1"})
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		if svc.setIgnoreConditionalGetCalls != 0 {
+			t.Errorf("SetIgnoreConditionalGet calls = %d, want 0", svc.setIgnoreConditionalGetCalls)
+		}
+	})
+
+	t.Run("FeedAdminService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+// mockWorkerCycleService は WorkerCycleServiceInterface のモック実装。
+type mockWorkerCycleService struct {
+	listFn    func(ctx context.Context, limit int) ([]workerCycleResponse, error)
+	listCalls int
+	lastLimit int
+}
+
+func (m *mockWorkerCycleService) ListRecentCycles(ctx context.Context, limit int) ([]workerCycleResponse, error) {
+	m.listCalls++
+	m.lastLimit = limit
+	if m.listFn != nil {
+		return m.listFn(ctx, limit)
+	}
+	return nil, nil
+}
+
+// --- GET /api/admin/worker-cycles テスト ---
+
+func TestWorkerCycleHandler_ListCycles(t *testing.T) {
+	t.Run("limit未指定のとき既定件数で取得しサイクル結果を返す", func(t *testing.T) {
+		// Arrange
+		startedAt := time.Date(2026, 6, 19, 12, 0, 0, 0, time.UTC)
+		svc := &mockWorkerCycleService{
+			listFn: func(context.Context, int) ([]workerCycleResponse, error) {
+				return []workerCycleResponse{{
+					ID: 1, StartedAt: startedAt, FinishedAt: startedAt.Add(1500 * time.Millisecond),
+					FeedCount: 3, SucceededCount: 2, FailedCount: 1, ItemsInserted: 5, ItemsUpdated: 1, DurationMs: 1500,
+				}}, nil
+			},
+		}
+		h := NewWorkerCycleHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListCycles(w, httptest.NewRequest(http.MethodGet, "/api/admin/worker-cycles", nil))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.lastLimit != DefaultPageLimit {
+			t.Errorf("limit = %d, want %d", svc.lastLimit, DefaultPageLimit)
+		}
+		want := `{"cycles":[{"id":1,"started_at":"2026-06-19T12:00:00Z","finished_at":"2026-06-19T12:00:01.5Z",` +
+			`"feed_count":3,"succeeded_count":2,"failed_count":1,"items_inserted":5,"items_updated":1,"duration_ms":1500}]}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("履歴が無いときcyclesを空配列で返す", func(t *testing.T) {
+		// Arrange
+		h := NewWorkerCycleHandler(&mockWorkerCycleService{})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListCycles(w, httptest.NewRequest(http.MethodGet, "/api/admin/worker-cycles", nil))
+
+		// Assert
+		if got := strings.TrimSpace(w.Body.String()); got != `{"cycles":[]}` {
+			t.Errorf("body = %s, want {\"cycles\":[]}", got)
+		}
+	})
+
+	t.Run("limitが上限を超えるとき上限値にクランプする", func(t *testing.T) {
+		// Arrange
+		svc := &mockWorkerCycleService{}
+		h := NewWorkerCycleHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListCycles(w, httptest.NewRequest(http.MethodGet, "/api/admin/worker-cycles?limit=1000", nil))
+
+		// Assert
+		if svc.lastLimit != DefaultMaxPageLimit {
+			t.Errorf("limit = %d, want %d", svc.lastLimit, DefaultMaxPageLimit)
+		}
+	})
+
+	t.Run("limitが不正なとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockWorkerCycleService{}
+		h := NewWorkerCycleHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListCycles(w, httptest.NewRequest(http.MethodGet, "/api/admin/worker-cycles?limit=0", nil))
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.listCalls != 0 {
+			t.Errorf("ListRecentCycles calls = %d, want 0", svc.listCalls)
+		}
+	})
+
+	t.Run("サービスがエラーを返すとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockWorkerCycleService{
+			listFn: func(context.Context, int) ([]workerCycleResponse, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		h := NewWorkerCycleHandler(svc)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListCycles(w, httptest.NewRequest(http.MethodGet, "/api/admin/worker-cycles", nil))
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
+
+func TestNewRouter_WorkerCycleRoutes(t *testing.T) {
+	newRouter := func(svc WorkerCycleServiceInterface, admins []string) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+			AdminUserIDs:        admins,
+		}
+		if svc != nil {
+			deps.WorkerCycleService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/worker-cycles", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("管理者のとき200を返しサービスが呼ばれる", func(t *testing.T) {
+		// Arrange
+		svc := &mockWorkerCycleService{}
+		router := newRouter(svc, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.listCalls != 1 {
+			t.Errorf("ListRecentCycles calls = %d, want 1", svc.listCalls)
+		}
+	})
+
+	t.Run("管理者でないとき403を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockWorkerCycleService{}
+		router := newRouter(svc, []string{"admin-1"})
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		if svc.listCalls != 0 {
+			t.Errorf("ListRecentCycles calls = %d, want 0", svc.listCalls)
+		}
+	})
+
+	t.Run("WorkerCycleService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+// mockBlockedDomainService は BlockedDomainServiceInterface のモック実装。
+type mockBlockedDomainService struct {
+	listFn    func(ctx context.Context) ([]blockedDomainResponse, error)
+	addFn     func(ctx context.Context, adminUserID string, req blockedDomainRequest) (*blockedDomainCreateResponse, error)
+	deleteFn  func(ctx context.Context, id string) error
+	addCalls  int
+	lastAdmin string
+}
+
+func (m *mockBlockedDomainService) ListBlockedDomains(ctx context.Context) ([]blockedDomainResponse, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockBlockedDomainService) AddBlockedDomain(ctx context.Context, adminUserID string, req blockedDomainRequest) (*blockedDomainCreateResponse, error) {
+	m.addCalls++
+	m.lastAdmin = adminUserID
+	if m.addFn != nil {
+		return m.addFn(ctx, adminUserID, req)
+	}
+	return &blockedDomainCreateResponse{}, nil
+}
+
+func (m *mockBlockedDomainService) DeleteBlockedDomain(ctx context.Context, id string) error {
+	if m.deleteFn != nil {
+		return m.deleteFn(ctx, id)
+	}
+	return nil
+}
+
+// --- /api/admin/blocked-domains テスト ---
+
+func TestBlockedDomainHandler_ListBlockedDomains(t *testing.T) {
+	t.Run("項目が無いときblocked_domainsを空配列で返す", func(t *testing.T) {
+		// Arrange
+		h := NewBlockedDomainHandler(&mockBlockedDomainService{})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListBlockedDomains(w, httptest.NewRequest(http.MethodGet, "/api/admin/blocked-domains", nil))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got := strings.TrimSpace(w.Body.String()); got != `{"blocked_domains":[]}` {
+			t.Errorf("body = %s, want {\"blocked_domains\":[]}", got)
+		}
+	})
+}
+
+func TestBlockedDomainHandler_AddBlockedDomain(t *testing.T) {
+	t.Run("追加に成功したとき201と停止したフィード数を返す", func(t *testing.T) {
+		// Arrange
+		createdAt := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+		svc := &mockBlockedDomainService{
+			addFn: func(_ context.Context, _ string, req blockedDomainRequest) (*blockedDomainCreateResponse, error) {
+				if req.Pattern != "spam.example" || !req.ApplyToExisting {
+					t.Errorf("req = %+v, want pattern spam.example with apply_to_existing", req)
+				}
+				return &blockedDomainCreateResponse{
+					blockedDomainResponse: blockedDomainResponse{ID: "blocked-1", Pattern: "spam.example", Kind: "domain", Reason: "spam", CreatedAt: createdAt},
+					StoppedFeedCount:      2,
+				}, nil
+			},
+		}
+		h := NewBlockedDomainHandler(svc)
+		body := `{"pattern":"spam.example","reason":"spam","apply_to_existing":true}`
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/admin/blocked-domains", strings.NewReader(body)), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.AddBlockedDomain(w, req)
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		if svc.lastAdmin != "admin-1" {
+			t.Errorf("adminUserID = %q, want admin-1", svc.lastAdmin)
+		}
+		want := `{"id":"blocked-1","pattern":"spam.example","kind":"domain","reason":"spam","created_at":"2026-07-01T12:00:00Z","stopped_feed_count":2}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("JSONが不正なとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockBlockedDomainService{}
+		h := NewBlockedDomainHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/admin/blocked-domains", strings.NewReader("{")), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.AddBlockedDomain(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.addCalls != 0 {
+			t.Errorf("AddBlockedDomain calls = %d, want 0", svc.addCalls)
+		}
+	})
+
+	t.Run("patternが不正なとき400 INVALID_BLOCKED_DOMAINを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockBlockedDomainService{
+			addFn: func(context.Context, string, blockedDomainRequest) (*blockedDomainCreateResponse, error) {
+				return nil, model.NewInvalidBlockedDomainError("ドメインの形式が不正です")
+			},
+		}
+		h := NewBlockedDomainHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/admin/blocked-domains", strings.NewReader(`{"pattern":"a b"}`)), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.AddBlockedDomain(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodeInvalidBlockedDomain) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodeInvalidBlockedDomain)
+		}
+	})
+}
+
+func TestBlockedDomainHandler_DeleteBlockedDomain(t *testing.T) {
+	t.Run("削除に成功したとき204を返す", func(t *testing.T) {
+		// Arrange
+		var gotID string
+		h := NewBlockedDomainHandler(&mockBlockedDomainService{
+			deleteFn: func(_ context.Context, id string) error {
+				gotID = id
+				return nil
+			},
+		})
+		req := withChiURLParam(httptest.NewRequest(http.MethodDelete, "/api/admin/blocked-domains/blocked-1", nil), "id", "blocked-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DeleteBlockedDomain(w, req)
+
+		// Assert
+		if w.Code != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if gotID != "blocked-1" {
+			t.Errorf("id = %q, want blocked-1", gotID)
+		}
+	})
+
+	t.Run("項目が存在しないとき404を返す", func(t *testing.T) {
+		// Arrange
+		h := NewBlockedDomainHandler(&mockBlockedDomainService{
+			deleteFn: func(_ context.Context, id string) error {
+				return model.NewBlockedDomainNotFoundError(id)
+			},
+		})
+		req := withChiURLParam(httptest.NewRequest(http.MethodDelete, "/api/admin/blocked-domains/missing", nil), "id", "missing")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DeleteBlockedDomain(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// Package handler の audit_log_handler.go は、監査ログ（購読操作の変更履歴）の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/audit-logs?cursor=...&limit=50 : 自分の購読・購読解除・設定変更などの履歴（新しい順）
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// AuditLogServiceInterface は監査ログハンドラが必要とするサービスインターフェース。
+type AuditLogServiceInterface interface {
+	// ListAuditLogs はユーザーの監査ログを新しい順に返す。
+	// cursor は前ページの next_cursor（空なら先頭ページ）、limit が 0 の場合は既定件数とする。
+	// 不正なカーソルの場合は INVALID_FILTER を返す。
+	ListAuditLogs(ctx context.Context, userID, cursor string, limit int) (*auditLogListResponse, error)
+}
+
+// AuditLogHandler は監査ログの HTTP ハンドラ。
+type AuditLogHandler struct {
+	service AuditLogServiceInterface
+}
+
+// NewAuditLogHandler は AuditLogHandler を生成する。
+func NewAuditLogHandler(service AuditLogServiceInterface) *AuditLogHandler {
+	return &AuditLogHandler{service: service}
+}
+
+// auditLogResponse は監査ログ 1 件。
+type auditLogResponse struct {
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	// Target は操作対象の ID（購読操作ではフィードID）。
+	Target    string         `json:"target"`
+	Payload   map[string]any `json:"payload"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// auditLogListResponse は GET /api/audit-logs のレスポンス。
+type auditLogListResponse struct {
+	Logs       []auditLogResponse `json:"logs"`
+	NextCursor *string            `json:"next_cursor"`
+	HasMore    bool               `json:"has_more"`
+}
+
+// ListAuditLogs は自分の監査ログを新しい順に返す。
+// GET /api/audit-logs?cursor=...&limit=50
+//
+// limit が不正な場合は 400 INVALID_REQUEST、cursor が不正な場合は 400 INVALID_FILTER を返す。
+func (h *AuditLogHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	q := r.URL.Query()
+	limit, err := parsePageLimit(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	resp, err := h.service.ListAuditLogs(r.Context(), userID, q.Get("cursor"), limit)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockAuditLogService は AuditLogServiceInterface のモック実装。
+type mockAuditLogService struct {
+	listAuditLogsFn    func(ctx context.Context, userID, cursor string, limit int) (*auditLogListResponse, error)
+	listAuditLogsCalls int
+}
+
+func (m *mockAuditLogService) ListAuditLogs(ctx context.Context, userID, cursor string, limit int) (*auditLogListResponse, error) {
+	m.listAuditLogsCalls++
+	if m.listAuditLogsFn != nil {
+		return m.listAuditLogsFn(ctx, userID, cursor, limit)
+	}
+	return &auditLogListResponse{Logs: []auditLogResponse{}}, nil
+}
+
+// --- GET /api/audit-logs テスト ---
+
+func TestAuditLogHandler_ListAuditLogs(t *testing.T) {
+	t.Run("cursorとlimitを指定したとき監査ログと次ページのカーソルを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockAuditLogService{
+			listAuditLogsFn: func(_ context.Context, userID, cursor string, limit int) (*auditLogListResponse, error) {
+				if userID != "user-1" || cursor != "c1" || limit != 2 {
+					t.Errorf("args = (%q, %q, %d), want (user-1, c1, 2)", userID, cursor, limit)
+				}
+				return &auditLogListResponse{
+					Logs: []auditLogResponse{
+						{
+							ID:        "log-1",
+							Action:    string(model.AuditActionSubscriptionUpdateSettings),
+							Target:    "feed-1",
+							Payload:   map[string]any{"subscription_id": "sub-1", "fetch_interval_minutes": 120},
+							CreatedAt: time.Date(2026, 6, 14, 12, 0, 0, 0, time.UTC),
+						},
+					},
+					NextCursor: nullableString("c2"),
+					HasMore:    true,
+				}, nil
+			},
+		}
+		h := NewAuditLogHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/audit-logs?cursor=c1&limit=2", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuditLogs(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["next_cursor"] != "c2" || body["has_more"] != true {
+			t.Errorf("body = %v", body)
+		}
+		logs, ok := body["logs"].([]interface{})
+		if !ok || len(logs) != 1 {
+			t.Fatalf("logs = %v, want 1 element", body["logs"])
+		}
+		log := logs[0].(map[string]interface{})
+		if log["action"] != "subscription.update_settings" || log["target"] != "feed-1" || log["created_at"] != "2026-06-14T12:00:00Z" {
+			t.Errorf("log = %v", log)
+		}
+		payload := log["payload"].(map[string]interface{})
+		if payload["fetch_interval_minutes"] != float64(120) {
+			t.Errorf("payload = %v", payload)
+		}
+	})
+
+	t.Run("末尾ページのときnext_cursorをnullで返す", func(t *testing.T) {
+		// Arrange
+		h := NewAuditLogHandler(&mockAuditLogService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/audit-logs", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuditLogs(w, req)
+
+		// Assert
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if v, ok := body["next_cursor"]; !ok || v != nil {
+			t.Errorf("next_cursor should be null: %v", body)
+		}
+		if logs, ok := body["logs"].([]interface{}); !ok || len(logs) != 0 {
+			t.Errorf("logs = %v, want empty array", body["logs"])
+		}
+	})
+
+	t.Run("limitが不正なとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		for _, limit := range []string{"abc", "0", "-1"} {
+			// Arrange
+			svc := &mockAuditLogService{}
+			h := NewAuditLogHandler(svc)
+			req := withUserID(httptest.NewRequest(http.MethodGet, "/api/audit-logs?limit="+limit, nil), "user-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.ListAuditLogs(w, req)
+
+			// Assert
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("limit=%q: status = %d, want %d", limit, w.Code, http.StatusBadRequest)
+			}
+			if svc.listAuditLogsCalls != 0 {
+				t.Errorf("limit=%q: ListAuditLogs calls = %d, want 0", limit, svc.listAuditLogsCalls)
+			}
+		}
+	})
+
+	t.Run("cursorが不正なとき400 INVALID_FILTERを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockAuditLogService{
+			listAuditLogsFn: func(_ context.Context, _, cursor string, _ int) (*auditLogListResponse, error) {
+				return nil, model.NewInvalidFilterError("invalid cursor: " + cursor)
+			},
+		}
+		h := NewAuditLogHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/audit-logs?cursor=broken", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuditLogs(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidFilter {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidFilter)
+		}
+	})
+
+	t.Run("ユーザーIDがないとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewAuditLogHandler(&mockAuditLogService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/audit-logs", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuditLogs(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_AuditLogRoutes は監査ログルートが認証必須で、AuditLogService 未配線時は登録されないことを検証する。
+func TestNewRouter_AuditLogRoutes(t *testing.T) {
+	newRouter := func(svc AuditLogServiceInterface) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+		}
+		if svc != nil {
+			deps.AuditLogService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler, withSession bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/audit-logs?limit=10", nil)
+		if withSession {
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("セッションありのとき200を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockAuditLogService{}
+		router := newRouter(svc)
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.listAuditLogsCalls != 1 {
+			t.Errorf("ListAuditLogs calls = %d, want 1", svc.listAuditLogsCalls)
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newRouter(&mockAuditLogService{})
+
+		// Act
+		w := doRequest(router, false)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("AuditLogService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil)
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
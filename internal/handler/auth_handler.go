@@ -5,16 +5,20 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 
+	"github.com/hitoshi/feedman/internal/auth"
 	"github.com/hitoshi/feedman/internal/model"
 )
 
 const (
 	sessionCookieName = "session_id"
 	oauthStateCookie  = "oauth_state"
+
+	// oauthStateBytes は OAuth state の生成に使う乱数のバイト数（256bit）。
+	oauthStateBytes = 32
 )
 
 // AuthServiceInterface は認証ハンドラーが必要とするサービスインターフェース。
@@ -23,6 +27,9 @@ type AuthServiceInterface interface {
 	HandleCallback(ctx context.Context, code string) (*model.Session, error)
 	Logout(ctx context.Context, sessionID string) error
 	GetCurrentUser(ctx context.Context, sessionID string) (*model.User, error)
+	// RotateSession はセッションIDを再生成し、旧IDを即時に失効させる。
+	// 旧セッションが存在しない場合は nil を返す。
+	RotateSession(ctx context.Context, sessionID string) (*model.Session, error)
 }
 
 // AuthHandlerConfig は認証ハンドラーの設定。
@@ -52,8 +59,7 @@ func NewAuthHandler(service AuthServiceInterface, config AuthHandlerConfig) *Aut
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	state, err := generateState()
 	if err != nil {
-		slog.Error("failed to generate oauth state", slog.String("error", err.Error()))
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+		WriteError(w, fmt.Errorf("failed to generate oauth state: %w", err))
 		return
 	}
 
@@ -82,7 +88,12 @@ func (h *AuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 		slog.Warn("oauth state mismatch",
 			slog.String("query_state", state),
 		)
-		http.Error(w, "invalid state parameter", http.StatusBadRequest)
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "state パラメータが不正です。",
+			Category: "auth",
+			Action:   "もう一度ログインをやり直してください。",
+		})
 		return
 	}
 
@@ -100,21 +111,32 @@ func (h *AuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 	// 2. 認可コードの取得
 	code := r.URL.Query().Get("code")
 	if code == "" {
-		http.Error(w, "missing authorization code", http.StatusBadRequest)
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "認可コードが指定されていません。",
+			Category: "auth",
+			Action:   "もう一度ログインをやり直してください。",
+		})
 		return
 	}
 
-	// 3. 認証処理
-	session, err := h.service.HandleCallback(r.Context(), code)
+	// 3. 認証処理（成功・失敗は接続元の情報とともにログインイベントとして記録される）
+	session, err := h.service.HandleCallback(loginEventContext(r), code)
 	if err != nil {
-		slog.Error("oauth callback failed", slog.String("error", err.Error()))
-		http.Error(w, "authentication failed", http.StatusInternalServerError)
+		WriteError(w, fmt.Errorf("oauth callback failed: %w", err))
 		return
 	}
 
-	// 4. セッション固定攻撃対策: 旧 session_id を無効化して識別子を旋回する。
+	// 4. セッションCookieを発行してフロントエンドにリダイレクト
+	h.completeLogin(w, r, session)
+}
+
+// completeLogin はログインで発行したセッションの Cookie を設定し、フロントエンドにリダイレクトする。
+// OAuth コールバックとマジックリンクの検証で共通の後処理。
+func (h *AuthHandler) completeLogin(w http.ResponseWriter, r *http.Request, session *model.Session) {
+	// 1. セッション固定攻撃対策: 旧 session_id を無効化して識別子を旋回する。
 	//    ログイン前から存在する session_id Cookie に対応する保存済みセッションを破棄し、
-	//    手順 5 で発行する新しい session_id のみが有効になるようにする。
+	//    手順 2 で発行する新しい session_id のみが有効になるようにする。
 	//    旧セッションが存在しない（Cookie 不在・期限切れ・削除済み）場合や無効化に
 	//    失敗した場合でも、ログイン自体はエラーにせず継続する。
 	if oldCookie, cookieErr := r.Cookie(sessionCookieName); cookieErr == nil &&
@@ -127,19 +149,15 @@ func (h *AuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// 5. セッションCookieを設定（HTTP Only）
-	http.SetCookie(w, &http.Cookie{
-		Name:     sessionCookieName,
-		Value:    session.ID,
-		Path:     "/",
-		Domain:   h.config.CookieDomain,
-		MaxAge:   h.config.SessionMaxAge,
-		HttpOnly: true,
-		Secure:   h.config.CookieSecure,
-		SameSite: http.SameSiteLaxMode,
-	})
+	// 2. セッションCookieを設定（HTTP Only）
+	h.setSessionCookie(w, session.ID)
 
-	// 6. フロントエンドにリダイレクト
+	// 3. フロントエンドにリダイレクト。
+	//    /subscribe からログインに誘導された場合は購読確認画面へ戻す。
+	if target := h.popPendingSubscribeURL(w, r); target != "" {
+		http.Redirect(w, r, subscribeConfirmURL(h.config.BaseURL, target), http.StatusTemporaryRedirect)
+		return
+	}
 	http.Redirect(w, r, h.config.BaseURL, http.StatusTemporaryRedirect)
 }
 
@@ -150,7 +168,7 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	cookie, err := r.Cookie(sessionCookieName)
 	if err == nil && cookie.Value != "" {
 		// セッションをDBから削除
-		if logoutErr := h.service.Logout(r.Context(), cookie.Value); logoutErr != nil {
+		if logoutErr := h.service.Logout(loginEventContext(r), cookie.Value); logoutErr != nil {
 			slog.Error("failed to logout", slog.String("error", logoutErr.Error()))
 			// ログアウト失敗してもCookieはクリアする
 		}
@@ -171,24 +189,89 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, h.config.BaseURL, http.StatusTemporaryRedirect)
 }
 
+// loginEventContext はログインイベントに記録する接続元の情報（接続元アドレス・User-Agent）を
+// リクエストコンテキストに添える。X-Forwarded-For 等のヘッダーは信頼せず r.RemoteAddr を用いる。
+func loginEventContext(r *http.Request) context.Context {
+	return auth.ContextWithClientInfo(r.Context(), auth.ClientInfo{
+		RemoteAddr: r.RemoteAddr,
+		UserAgent:  r.UserAgent(),
+	})
+}
+
+// RotateSession はセッションIDを再生成する（任意ローテーション）。
+// POST /auth/rotate-session
+//
+// 同じユーザーの新しいセッションを発行して Cookie を差し替え、旧セッションIDは即時に失効させる。
+// 旧IDの失効に失敗した場合は Cookie を差し替えずにエラーを返す。
+func (h *AuthHandler) RotateSession(w http.ResponseWriter, r *http.Request) {
+	unauthorized := &model.APIError{
+		Code:     model.ErrCodeUnauthorized,
+		Message:  "認証が必要です。",
+		Category: "auth",
+		Action:   "ログインしてください。",
+	}
+
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		WriteError(w, unauthorized)
+		return
+	}
+
+	session, err := h.service.RotateSession(r.Context(), cookie.Value)
+	if err != nil {
+		WriteError(w, fmt.Errorf("session rotation failed: %w", err))
+		return
+	}
+	if session == nil {
+		WriteError(w, unauthorized)
+		return
+	}
+
+	h.setSessionCookie(w, session.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setSessionCookie はセッションCookie（HTTP Only）を設定する。
+func (h *AuthHandler) setSessionCookie(w http.ResponseWriter, sessionID string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		Domain:   h.config.CookieDomain,
+		MaxAge:   h.config.SessionMaxAge,
+		HttpOnly: true,
+		Secure:   h.config.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
 // Me は現在のログインユーザー情報を返す。
 // GET /auth/me
 func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	cookie, err := r.Cookie(sessionCookieName)
 	if err != nil || cookie.Value == "" {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
 		return
 	}
 
 	user, err := h.service.GetCurrentUser(r.Context(), cookie.Value)
 	if err != nil {
 		slog.Error("failed to get current user", slog.String("error", err.Error()))
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"id":    user.ID,
 		"email": user.Email,
 		"name":  user.Name,
@@ -196,8 +279,9 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 }
 
 // generateState はCSRF対策用のランダムなstate値を生成する。
+// crypto/rand の 256bit 乱数を hex 表現（64 文字）で返す。
 func generateState() (string, error) {
-	b := make([]byte, 16)
+	b := make([]byte, oauthStateBytes)
 	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
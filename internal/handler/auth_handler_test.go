@@ -18,6 +18,7 @@ type mockAuthService struct {
 	handleCallbackFn func(ctx context.Context, code string) (*model.Session, error)
 	logoutFn         func(ctx context.Context, sessionID string) error
 	getCurrentUserFn func(ctx context.Context, sessionID string) (*model.User, error)
+	rotateSessionFn  func(ctx context.Context, sessionID string) (*model.Session, error)
 }
 
 func (m *mockAuthService) GetLoginURL(state string) string {
@@ -48,6 +49,13 @@ func (m *mockAuthService) GetCurrentUser(ctx context.Context, sessionID string)
 	return nil, nil
 }
 
+func (m *mockAuthService) RotateSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	if m.rotateSessionFn != nil {
+		return m.rotateSessionFn(ctx, sessionID)
+	}
+	return nil, nil
+}
+
 // --- テスト ---
 
 func TestAuthHandler_Login_RedirectsToOAuthURL(t *testing.T) {
@@ -453,6 +461,132 @@ func TestAuthHandler_Logout_Success_ClearsCookieAndRedirects(t *testing.T) {
 	}
 }
 
+func TestAuthHandler_Login_StateIs256BitHex(t *testing.T) {
+	var gotState string
+	svc := &mockAuthService{
+		getLoginURLFn: func(state string) string {
+			gotState = state
+			return "https://accounts.google.com/o/oauth2/auth"
+		},
+	}
+	h := NewAuthHandler(svc, AuthHandlerConfig{BaseURL: "http://localhost:3000"})
+
+	h.Login(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/auth/google/login", nil))
+
+	if len(gotState) != 64 {
+		t.Errorf("len(state) = %d, want 64 (256bit hex)", len(gotState))
+	}
+}
+
+func TestAuthHandler_RotateSession_Success_ReplacesCookie(t *testing.T) {
+	var rotatedID string
+	svc := &mockAuthService{
+		rotateSessionFn: func(ctx context.Context, sessionID string) (*model.Session, error) {
+			rotatedID = sessionID
+			return &model.Session{ID: "rotated-session-id", UserID: "user-id-123"}, nil
+		},
+	}
+	h := NewAuthHandler(svc, AuthHandlerConfig{
+		BaseURL:       "http://localhost:3000",
+		SessionMaxAge: 86400,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/rotate-session", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "current-session-id"})
+	w := httptest.NewRecorder()
+
+	h.RotateSession(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	if rotatedID != "current-session-id" {
+		t.Errorf("rotated session ID = %q, want %q", rotatedID, "current-session-id")
+	}
+
+	var sessionCookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == "session_id" {
+			sessionCookie = c
+			break
+		}
+	}
+	if sessionCookie == nil {
+		t.Fatal("expected session_id cookie to be set")
+	}
+	if sessionCookie.Value != "rotated-session-id" {
+		t.Errorf("session cookie = %q, want %q", sessionCookie.Value, "rotated-session-id")
+	}
+	if !sessionCookie.HttpOnly || sessionCookie.MaxAge != 86400 {
+		t.Errorf("cookie attributes = HttpOnly:%v MaxAge:%d, want HttpOnly:true MaxAge:86400", sessionCookie.HttpOnly, sessionCookie.MaxAge)
+	}
+}
+
+func TestAuthHandler_RotateSession_NoSession_ReturnsUnauthorized(t *testing.T) {
+	called := false
+	svc := &mockAuthService{
+		rotateSessionFn: func(ctx context.Context, sessionID string) (*model.Session, error) {
+			called = true
+			return nil, nil
+		},
+	}
+	h := NewAuthHandler(svc, AuthHandlerConfig{BaseURL: "http://localhost:3000"})
+
+	w := httptest.NewRecorder()
+	h.RotateSession(w, httptest.NewRequest(http.MethodPost, "/auth/rotate-session", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	if called {
+		t.Error("expected RotateSession not to be called without cookie")
+	}
+}
+
+func TestAuthHandler_RotateSession_ExpiredSession_ReturnsUnauthorized(t *testing.T) {
+	h := NewAuthHandler(&mockAuthService{}, AuthHandlerConfig{BaseURL: "http://localhost:3000"})
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/rotate-session", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "expired-session-id"})
+	w := httptest.NewRecorder()
+
+	h.RotateSession(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "session_id" {
+			t.Errorf("unexpected session_id cookie: %q", c.Value)
+		}
+	}
+}
+
+func TestAuthHandler_RotateSession_ServiceError_DoesNotReplaceCookie(t *testing.T) {
+	svc := &mockAuthService{
+		rotateSessionFn: func(ctx context.Context, sessionID string) (*model.Session, error) {
+			return nil, errors.New("failed to revoke old session")
+		},
+	}
+	h := NewAuthHandler(svc, AuthHandlerConfig{BaseURL: "http://localhost:3000"})
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/rotate-session", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "current-session-id"})
+	w := httptest.NewRecorder()
+
+	h.RotateSession(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "session_id" {
+			t.Errorf("unexpected session_id cookie: %q", c.Value)
+		}
+	}
+}
+
 func TestAuthHandler_Logout_NoSession_StillRedirects(t *testing.T) {
 	h := NewAuthHandler(&mockAuthService{}, AuthHandlerConfig{
 		BaseURL: "http://localhost:3000",
@@ -495,8 +629,8 @@ func TestAuthHandler_Me_Authenticated_ReturnsUserJSON(t *testing.T) {
 	}
 
 	contentType := resp.Header.Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", contentType, "application/json; charset=utf-8")
 	}
 }
 
@@ -525,3 +659,44 @@ func containsStr(s, substr string) bool {
 	}
 	return false
 }
+
+// TestAuthHandler_ErrorResponses_UseUnifiedFormat は認証ハンドラのエラーが
+// WriteError 経由の統一 JSON フォーマットで返ることを検証する。
+func TestAuthHandler_ErrorResponses_UseUnifiedFormat(t *testing.T) {
+	h := NewAuthHandler(&mockAuthService{}, AuthHandlerConfig{BaseURL: "http://localhost:3000"})
+
+	t.Run("Meでセッションがないとき401 UNAUTHORIZEDを返す", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/auth/me", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Me(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+		if got := parseAPIErrorResponse(t, w); got["code"] != model.ErrCodeUnauthorized {
+			t.Errorf("code = %q, want %q", got["code"], model.ErrCodeUnauthorized)
+		}
+	})
+
+	t.Run("Callbackでstateが一致しないとき400 INVALID_REQUESTを返す", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/auth/google/callback?code=c&state=s", nil)
+		req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "other"})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Callback(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w); got["code"] != model.ErrCodeInvalidRequest {
+			t.Errorf("code = %q, want %q", got["code"], model.ErrCodeInvalidRequest)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+)
+
+// checkNotModified は一覧 API の条件付き GET（Last-Modified / If-Modified-Since）を処理する。
+// modTime を秒単位に切り捨てて Last-Modified ヘッダーに設定し、If-Modified-Since 以前であれば
+// 304 Not Modified を書き込んで true を返す。呼び出し元は true のとき本文を書き込まずに戻ること。
+// modTime がゼロ値（最終更新日時を判定できない）の場合は何もせず false を返す。
+// If-None-Match が指定されたリクエストでは RFC 9110 に従い If-Modified-Since を評価しない。
+func checkNotModified(w http.ResponseWriter, r *http.Request, modTime time.Time) bool {
+	if modTime.IsZero() {
+		return false
+	}
+	// HTTP 日付は秒精度のため、比較も秒単位で行う
+	modTime = modTime.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", modTime.Format(http.TimeFormat))
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if r.Header.Get("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || modTime.After(since) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
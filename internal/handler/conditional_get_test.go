@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckNotModified(t *testing.T) {
+	modTime := time.Date(2026, 7, 7, 12, 0, 0, 500_000_000, time.UTC)
+	lastModified := "Tue, 07 Jul 2026 12:00:00 GMT"
+
+	tests := []struct {
+		name             string
+		method           string
+		modTime          time.Time
+		headers          map[string]string
+		wantNotModified  bool
+		wantLastModified string
+	}{
+		{
+			name:             "If-Modified-Since が無いとき Last-Modified を付与して 304 にしない",
+			method:           http.MethodGet,
+			modTime:          modTime,
+			wantLastModified: lastModified,
+		},
+		{
+			name:             "If-Modified-Since が最終更新日時と同じ秒のとき 304 を返す",
+			method:           http.MethodGet,
+			modTime:          modTime,
+			headers:          map[string]string{"If-Modified-Since": lastModified},
+			wantNotModified:  true,
+			wantLastModified: lastModified,
+		},
+		{
+			name:             "If-Modified-Since が最終更新日時より後のとき 304 を返す",
+			method:           http.MethodGet,
+			modTime:          modTime,
+			headers:          map[string]string{"If-Modified-Since": "Tue, 07 Jul 2026 13:00:00 GMT"},
+			wantNotModified:  true,
+			wantLastModified: lastModified,
+		},
+		{
+			name:             "If-Modified-Since より後に更新されていたとき 304 にしない",
+			method:           http.MethodGet,
+			modTime:          modTime,
+			headers:          map[string]string{"If-Modified-Since": "Tue, 07 Jul 2026 11:59:59 GMT"},
+			wantLastModified: lastModified,
+		},
+		{
+			name:             "If-Modified-Since が日付として解釈できないとき 304 にしない",
+			method:           http.MethodGet,
+			modTime:          modTime,
+			headers:          map[string]string{"If-Modified-Since": "yesterday"},
+			wantLastModified: lastModified,
+		},
+		{
+			name:    "If-None-Match があるとき If-Modified-Since を評価しない",
+			method:  http.MethodGet,
+			modTime: modTime,
+			headers: map[string]string{
+				"If-Modified-Since": lastModified,
+				"If-None-Match":     `"abc"`,
+			},
+			wantLastModified: lastModified,
+		},
+		{
+			name:             "GET・HEAD 以外のとき 304 にしない",
+			method:           http.MethodPost,
+			modTime:          modTime,
+			headers:          map[string]string{"If-Modified-Since": lastModified},
+			wantLastModified: lastModified,
+		},
+		{
+			name:    "最終更新日時がゼロ値のとき Last-Modified を付与しない",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-Modified-Since": lastModified},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(tt.method, "/api/subscriptions", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			got := checkNotModified(w, req, tt.modTime)
+
+			// Assert
+			if got != tt.wantNotModified {
+				t.Errorf("checkNotModified = %v, want %v", got, tt.wantNotModified)
+			}
+			if tt.wantNotModified && w.Code != http.StatusNotModified {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+			}
+			if lm := w.Header().Get("Last-Modified"); lm != tt.wantLastModified {
+				t.Errorf("Last-Modified = %q, want %q", lm, tt.wantLastModified)
+			}
+		})
+	}
+}
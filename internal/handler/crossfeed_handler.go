@@ -11,19 +11,13 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hitoshi/feedman/internal/middleware"
 	"github.com/hitoshi/feedman/internal/model"
 )
 
-// maxCrossFeedLimit は GET /api/items/cross-feed の limit クエリパラメータの上限値
-// （NFR 1.3 / design.md「API Contract」節）。これを超える指定はクランプする。
-const maxCrossFeedLimit = 200
-
 // CrossFeedServiceInterface は横断新着ハンドラが必要とするサービスインターフェース。
 //
 // 戻り値は handler 内部レスポンス型（*crossFeedListResult）にすることで、サービス層と
@@ -76,17 +70,19 @@ type crossFeedItemResponse struct {
 	IsRead          bool      `json:"is_read"`
 	IsStarred       bool      `json:"is_starred"`
 	HatebuCount     int       `json:"hatebu_count"`
+	// ReadingTimeMinutes は本文から推定した読了時間（分）。本文が無い場合は 0。
+	ReadingTimeMinutes int `json:"reading_time_minutes"`
 }
 
 // crossFeedListResult は GET /api/items/cross-feed のレスポンス。
 //
-// next_cursor は次ページ取得用のカーソル文字列（`<RFC3339Nano>:<uuid>` 形式）。
-// 末尾ページ・空結果のときは空文字となる（omitempty で省略）。
+// next_cursor は次ページ取得用のカーソル文字列（pagination の不透明トークン）。
+// 末尾ページ・空結果のときは null となる。
 // since_time は当該レスポンスで採用した新着判定基準時刻であり、クライアントが
 // session-level baseline として保持する（Req 4.7）。
 type crossFeedListResult struct {
 	Items      []crossFeedItemResponse `json:"items"`
-	NextCursor string                  `json:"next_cursor,omitempty"`
+	NextCursor *string                 `json:"next_cursor"`
 	HasMore    bool                    `json:"has_more"`
 	SinceTime  time.Time               `json:"since_time"`
 }
@@ -94,13 +90,16 @@ type crossFeedListResult struct {
 // ListItems は GET /api/items/cross-feed のハンドラ。
 //
 // クエリパラメータ:
-//   - cursor : ページネーション用カーソル（任意、`<RFC3339Nano>:<uuid>` 形式）。
+//   - cursor : ページネーション用カーソル（任意、前回レスポンスの next_cursor）。
 //     形式不正は service 層が model.NewInvalidFilterError を返し 400 にマップ
-//   - limit  : 1 ページあたり件数（任意、既定 50、上限 200 でクランプ）。形式不正は 400
+//   - limit  : 1 ページあたり件数（任意、既定 50、上限 200 でクランプ。いずれも設定で変更可）。形式不正は 400
 //   - since  : 新着判定基準時刻の override（任意、RFC3339 形式）。指定時はサーバ側
 //     user_cross_feed_views.last_seen_at を参照せず、当該値を基準に新着抽出する
 //     （Req 4.7 / session-level baseline）。形式不正は 400 INVALID_REQUEST
 //
+// Accept: application/x-ndjson を指定すると、cursor 以降の全記事を 1 行 1 記事の NDJSON で
+// ストリーミングする（next_cursor / has_more / since_time は出力しない）。
+//
 // エラーレスポンス:
 //   - 401 UNAUTHORIZED   : セッションなし（middleware が早期返却）
 //   - 400 INVALID_REQUEST: limit または since の形式不正
@@ -109,8 +108,8 @@ type crossFeedListResult struct {
 func (h *CrossFeedHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -120,26 +119,13 @@ func (h *CrossFeedHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 
 	q := r.URL.Query()
 	cursor := q.Get("cursor")
-	limitStr := q.Get("limit")
 	sinceStr := q.Get("since")
 
 	// limit のパース（未指定は既定値 / 形式不正・非正値は 400 / 上限を超える指定はクランプ）
-	limit := defaultItemsPerPage
-	if limitStr != "" {
-		n, parseErr := strconv.Atoi(limitStr)
-		if parseErr != nil || n <= 0 {
-			middleware.WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{
-				Code:     "INVALID_REQUEST",
-				Message:  "limit の形式が不正です。",
-				Category: "validation",
-				Action:   "1 以上の整数を指定してください。",
-			})
-			return
-		}
-		if n > maxCrossFeedLimit {
-			n = maxCrossFeedLimit
-		}
-		limit = n
+	limit, err := parsePageLimit(r)
+	if err != nil {
+		WriteError(w, err)
+		return
 	}
 
 	// since のパース（Req 4.7）。指定時のみ overrideSince に渡し、形式不正は 400 を返す。
@@ -148,8 +134,8 @@ func (h *CrossFeedHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	if sinceStr != "" {
 		t, parseErr := time.Parse(time.RFC3339, sinceStr)
 		if parseErr != nil {
-			middleware.WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{
-				Code:     "INVALID_REQUEST",
+			WriteError(w, &model.APIError{
+				Code:     model.ErrCodeInvalidRequest,
 				Message:  "since の形式が不正です。",
 				Category: "validation",
 				Action:   "RFC3339 形式の日時を指定してください（例: 2026-05-27T12:34:56Z）。",
@@ -159,9 +145,14 @@ func (h *CrossFeedHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 		overrideSince = &t
 	}
 
+	if wantsNDJSON(r) {
+		h.streamItems(w, r, userID, cursor, limit, overrideSince)
+		return
+	}
+
 	result, err := h.service.ListNewItems(r.Context(), userID, cursor, limit, overrideSince)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
@@ -170,8 +161,28 @@ func (h *CrossFeedHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 		result.Items = []crossFeedItemResponse{}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	WriteJSON(w, http.StatusOK, result)
+}
+
+// streamItems は横断新着一覧を NDJSON で返す（Accept: application/x-ndjson 指定時）。
+// cursor の位置から末尾までの全記事を limit 件ずつ取得しながら 1 行 1 記事で書き込む。
+// ページ間で新着判定基準がずれないよう、2 ページ目以降は先頭ページで採用した since_time を override として渡す。
+func (h *CrossFeedHandler) streamItems(w http.ResponseWriter, r *http.Request, userID, cursor string, limit int, overrideSince *time.Time) {
+	streamNDJSONPages(w, r, cursor, func(cursor string) ([]crossFeedItemResponse, string, error) {
+		result, err := h.service.ListNewItems(r.Context(), userID, cursor, limit, overrideSince)
+		if err != nil {
+			return nil, "", err
+		}
+		if overrideSince == nil {
+			since := result.SinceTime
+			overrideSince = &since
+		}
+		var next string
+		if result.NextCursor != nil {
+			next = *result.NextCursor
+		}
+		return result.Items, next, nil
+	})
 }
 
 // TouchLastSeen は PUT /api/users/me/cross-feed-last-seen のハンドラ。
@@ -179,8 +190,8 @@ func (h *CrossFeedHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 func (h *CrossFeedHandler) TouchLastSeen(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -189,7 +200,7 @@ func (h *CrossFeedHandler) TouchLastSeen(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.service.TouchLastSeen(r.Context(), userID); err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
@@ -86,8 +86,8 @@ func TestCrossFeedHandler_ListItems_Success(t *testing.T) {
 			if userID != "user-123" {
 				t.Errorf("userID = %q, want %q", userID, "user-123")
 			}
-			if limit != defaultItemsPerPage {
-				t.Errorf("limit = %d, want %d (default)", limit, defaultItemsPerPage)
+			if limit != DefaultPageLimit {
+				t.Errorf("limit = %d, want %d (default)", limit, DefaultPageLimit)
 			}
 			if overrideSince != nil {
 				t.Errorf("overrideSince = %v, want nil (no since query)", overrideSince)
@@ -117,7 +117,7 @@ func TestCrossFeedHandler_ListItems_Success(t *testing.T) {
 						PublishedAt:    now.Add(-time.Hour),
 					},
 				},
-				NextCursor: now.Add(-time.Hour).Format(time.RFC3339Nano) + ":item-2",
+				NextCursor: nullableString(now.Add(-time.Hour).Format(time.RFC3339Nano) + ":item-2"),
 				HasMore:    true,
 				SinceTime:  since,
 			}, nil
@@ -138,8 +138,8 @@ func TestCrossFeedHandler_ListItems_Success(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
-	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json; charset=utf-8")
 	}
 
 	var result map[string]interface{}
@@ -270,17 +270,17 @@ func TestCrossFeedHandler_ListItems_InvalidSince_ReturnsBadRequest(t *testing.T)
 }
 
 // TestCrossFeedHandler_ListItems_WithLimit はクエリパラメータ limit が Service に伝搬し、
-// 上限値（200）を超える指定がクランプされることを検証する（NFR 1.3）。
+// 上限値（100）を超える指定がクランプされることを検証する（NFR 1.3）。
 func TestCrossFeedHandler_ListItems_WithLimit(t *testing.T) {
 	cases := []struct {
 		name      string
 		limitStr  string
 		wantLimit int
 	}{
-		{name: "未指定時は既定値 50", limitStr: "", wantLimit: defaultItemsPerPage},
-		{name: "100 を指定すると 100", limitStr: "100", wantLimit: 100},
-		{name: "200 を超える指定は 200 にクランプ", limitStr: "500", wantLimit: maxCrossFeedLimit},
-		{name: "200 ちょうどはそのまま", limitStr: "200", wantLimit: 200},
+		{name: "未指定時は既定値 50", limitStr: "", wantLimit: DefaultPageLimit},
+		{name: "50 を指定すると 50", limitStr: "50", wantLimit: 50},
+		{name: "200 を超える指定は 200 にクランプ", limitStr: "500", wantLimit: DefaultMaxPageLimit},
+		{name: "100 ちょうどはそのまま", limitStr: "100", wantLimit: 100},
 	}
 
 	for _, tc := range cases {
@@ -711,3 +711,48 @@ func minimalRouterDepsForCrossFeed(state *integrationState) *RouterDeps {
 	}
 }
 
+// TestCrossFeedHandler_ListItems_NDJSON は Accept: application/x-ndjson のとき全ページの記事を
+// 1 行ずつ返し、2 ページ目以降は先頭ページの since_time を基準に取得することを検証する。
+func TestCrossFeedHandler_ListItems_NDJSON(t *testing.T) {
+	// Arrange
+	since := time.Date(2026, 7, 16, 0, 0, 0, 0, time.UTC)
+	var gotSince []*time.Time
+	svc := &mockCrossFeedService{
+		listNewItemsFn: func(ctx context.Context, userID, cursorStr string, limit int, overrideSince *time.Time) (*crossFeedListResult, error) {
+			gotSince = append(gotSince, overrideSince)
+			if cursorStr == "" {
+				return &crossFeedListResult{
+					Items:      []crossFeedItemResponse{{ID: "item-1", FeedID: "feed-A"}},
+					NextCursor: nullableString("c1"),
+					HasMore:    true,
+					SinceTime:  since,
+				}, nil
+			}
+			return &crossFeedListResult{Items: []crossFeedItemResponse{{ID: "item-2", FeedID: "feed-B"}}, SinceTime: since}, nil
+		},
+	}
+	h := NewCrossFeedHandler(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/items/cross-feed", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	req = withUserID(req, "user-123")
+	w := httptest.NewRecorder()
+
+	// Act
+	h.ListItems(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != NDJSONContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, NDJSONContentType)
+	}
+	lines := decodeNDJSONLines(t, w.Body.String())
+	if len(lines) != 2 || lines[0]["id"] != "item-1" || lines[1]["id"] != "item-2" {
+		t.Errorf("lines = %v, want item-1 and item-2", lines)
+	}
+	if len(gotSince) != 2 || gotSince[0] != nil || gotSince[1] == nil || !gotSince[1].Equal(since) {
+		t.Errorf("overrideSince = %v, want [nil %v]", gotSince, since)
+	}
+}
@@ -0,0 +1,88 @@
+// Package handler の debug_handler.go は、管理者向けの調査用 HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント（いずれも管理者限定）:
+//   - POST /api/debug/parse-feed : URL または生 XML を渡してフィードのパース結果を診断する
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// maxDebugParseRequestBytes はパース診断リクエストボディの上限バイト数（ルーターで適用する）。
+// 生 XML を JSON 文字列として受け取るため、フェッチ上限（既定 5MB）にエスケープ分の余裕を持たせる。
+const maxDebugParseRequestBytes int64 = 16 << 20
+
+// FeedDebugServiceInterface はデバッグハンドラが必要とするサービスインターフェース。
+type FeedDebugServiceInterface interface {
+	// ParseFeed は URL または生 XML のどちらか一方からフィードをパースし、診断結果を返す。
+	// DB には一切書き込まない。
+	ParseFeed(ctx context.Context, rawURL, rawXML string) (*feedDiagnosisResponse, error)
+}
+
+// DebugHandler は管理者向け調査用エンドポイントの HTTP ハンドラ。
+type DebugHandler struct {
+	service FeedDebugServiceInterface
+}
+
+// NewDebugHandler は DebugHandler を生成する。
+func NewDebugHandler(service FeedDebugServiceInterface) *DebugHandler {
+	return &DebugHandler{service: service}
+}
+
+// parseFeedRequest はパース診断リクエストのボディ。url と xml はどちらか一方のみ指定する。
+type parseFeedRequest struct {
+	URL string `json:"url"`
+	XML string `json:"xml"`
+}
+
+// feedDiagnosisResponse はパース診断のAPIレスポンス。
+type feedDiagnosisResponse struct {
+	FeedType    string                  `json:"feed_type"`
+	FeedVersion string                  `json:"feed_version"`
+	Title       string                  `json:"title"`
+	SiteURL     string                  `json:"site_url"`
+	ItemCount   int                     `json:"item_count"`
+	Items       []diagnosedItemResponse `json:"items"`
+	Warnings    []string                `json:"warnings"`
+}
+
+// diagnosedItemResponse はパース診断で検出された記事 1 件のAPIレスポンス。
+type diagnosedItemResponse struct {
+	GuidOrID        string     `json:"guid_or_id"`
+	Title           string     `json:"title"`
+	Link            string     `json:"link"`
+	Author          string     `json:"author"`
+	PublishedAt     *time.Time `json:"published_at"`
+	IsDateEstimated bool       `json:"is_date_estimated"`
+	Warnings        []string   `json:"warnings"`
+}
+
+// ParseFeed はフィードのパース結果を診断して返す。
+// POST /api/debug/parse-feed
+//
+// 管理者判定とボディサイズの上限はルーター側のミドルウェアで行うため、本ハンドラでは扱わない。
+func (h *DebugHandler) ParseFeed(w http.ResponseWriter, r *http.Request) {
+	var req parseFeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	result, err := h.service.ParseFeed(r.Context(), req.URL, req.XML)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockFeedDebugService は FeedDebugServiceInterface のモック実装。
+type mockFeedDebugService struct {
+	parseFeedFn    func(ctx context.Context, rawURL, rawXML string) (*feedDiagnosisResponse, error)
+	parseFeedCalls int
+}
+
+func (m *mockFeedDebugService) ParseFeed(ctx context.Context, rawURL, rawXML string) (*feedDiagnosisResponse, error) {
+	m.parseFeedCalls++
+	if m.parseFeedFn != nil {
+		return m.parseFeedFn(ctx, rawURL, rawXML)
+	}
+	return &feedDiagnosisResponse{Items: []diagnosedItemResponse{}, Warnings: []string{}}, nil
+}
+
+// --- POST /api/debug/parse-feed テスト ---
+
+func TestDebugHandler_ParseFeed(t *testing.T) {
+	t.Run("xmlを指定したとき診断結果を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedDebugService{
+			parseFeedFn: func(_ context.Context, rawURL, rawXML string) (*feedDiagnosisResponse, error) {
+				if rawURL != "" || rawXML != "<rss/>" {
+					t.Errorf("args = (%q, %q), want (\"\", \"<rss/>\")", rawURL, rawXML)
+				}
+				return &feedDiagnosisResponse{
+					FeedType:  "rss",
+					Title:     "Diag Feed",
+					ItemCount: 1,
+					Items: []diagnosedItemResponse{
+						{GuidOrID: "guid-1", Title: "Article 1", IsDateEstimated: true, Warnings: []string{"日付がないため取り込み時刻で推定されます"}},
+					},
+					Warnings: []string{},
+				}, nil
+			},
+		}
+		h := NewDebugHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/debug/parse-feed", strings.NewReader(`{"xml":"<rss/>"}`)), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ParseFeed(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["feed_type"] != "rss" || body["title"] != "Diag Feed" || body["item_count"] != float64(1) {
+			t.Errorf("body = %v", body)
+		}
+		items, ok := body["items"].([]interface{})
+		if !ok || len(items) != 1 {
+			t.Fatalf("items = %v, want 1 element", body["items"])
+		}
+		item := items[0].(map[string]interface{})
+		if item["guid_or_id"] != "guid-1" || item["is_date_estimated"] != true || item["published_at"] != nil {
+			t.Errorf("item = %v", item)
+		}
+	})
+
+	t.Run("不正なJSONのとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedDebugService{}
+		h := NewDebugHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/debug/parse-feed", strings.NewReader(`{`)), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ParseFeed(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidRequest {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidRequest)
+		}
+		if svc.parseFeedCalls != 0 {
+			t.Errorf("ParseFeed calls = %d, want 0", svc.parseFeedCalls)
+		}
+	})
+
+	t.Run("入力が不正なとき400 INVALID_DEBUG_PARSE_INPUTを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedDebugService{
+			parseFeedFn: func(_ context.Context, _, _ string) (*feedDiagnosisResponse, error) {
+				return nil, model.NewInvalidDebugParseInputError("url または xml が指定されていません")
+			},
+		}
+		h := NewDebugHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/debug/parse-feed", strings.NewReader(`{}`)), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ParseFeed(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidDebugParseInput {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidDebugParseInput)
+		}
+	})
+
+	t.Run("パースに失敗したとき422 PARSE_FAILEDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedDebugService{
+			parseFeedFn: func(_ context.Context, _, _ string) (*feedDiagnosisResponse, error) {
+				return nil, model.NewParseFailedError()
+			},
+		}
+		h := NewDebugHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/debug/parse-feed", strings.NewReader(`{"xml":"<html/>"}`)), "admin-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ParseFeed(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_DebugRoutes はパース診断ルートが管理者のみに開放されていることを検証する。
+func TestNewRouter_DebugRoutes(t *testing.T) {
+	newRouter := func(svc FeedDebugServiceInterface, admins []string) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+			AdminUserIDs:        admins,
+		}
+		if svc != nil {
+			deps.FeedDebugService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler, withSession bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/debug/parse-feed", strings.NewReader(`{"xml":"<rss/>"}`))
+		if withSession {
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("管理者のとき200を返しサービスが呼ばれる", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedDebugService{}
+		router := newRouter(svc, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.parseFeedCalls != 1 {
+			t.Errorf("ParseFeed calls = %d, want 1", svc.parseFeedCalls)
+		}
+	})
+
+	t.Run("管理者でないとき403を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedDebugService{}
+		router := newRouter(svc, []string{"admin-1"})
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		if svc.parseFeedCalls != 0 {
+			t.Errorf("ParseFeed calls = %d, want 0", svc.parseFeedCalls)
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newRouter(&mockFeedDebugService{}, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router, false)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("FeedDebugService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil, []string{"user-test-1"})
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
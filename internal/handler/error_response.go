@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// apiErrorStatusCodes は APIError のエラーコードから HTTP ステータスコードへの対応表。
+// ハンドラは個別にステータスを決めず、WriteError 経由でこの表を参照する。
+// 新しいエラーコードを追加した場合は本表にも登録すること（未登録のコードは 500 になる）。
+var apiErrorStatusCodes = map[string]int{
+	// 認証・リクエスト形式
+	model.ErrCodeUnauthorized:   http.StatusUnauthorized,
+	model.ErrCodeInvalidRequest: http.StatusBadRequest,
+	model.ErrCodeForbidden:      http.StatusForbidden,
+	model.ErrCodeInternal:       http.StatusInternalServerError,
+
+	// フィード登録・取得
+	model.ErrCodeFeedNotDetected:       http.StatusUnprocessableEntity,
+	model.ErrCodeInvalidURL:            http.StatusBadRequest,
+	model.ErrCodeInvalidURLScheme:      http.StatusBadRequest,
+	model.ErrCodeSSRFBlocked:           http.StatusForbidden,
+	model.ErrCodeFetchFailed:           http.StatusBadGateway,
+	model.ErrCodeParseFailed:           http.StatusUnprocessableEntity,
+	model.ErrCodeFeedRedirectLoop:      http.StatusUnprocessableEntity,
+	model.ErrCodeSubscriptionLimit:     http.StatusConflict,
+	model.ErrCodeDuplicateSubscription: http.StatusConflict,
+	model.ErrCodeFeedNotSubscribed:     http.StatusForbidden,
+
+	// 未検出
+	model.ErrCodeFeedNotFound:         http.StatusNotFound,
+	model.ErrCodeSubscriptionNotFound: http.StatusNotFound,
+	model.ErrCodeItemNotFound:         http.StatusNotFound,
+	model.ErrCodeUserNotFound:         http.StatusNotFound,
+	model.ErrCodeProfileNotFound:      http.StatusNotFound,
+
+	// 入力検証
+	model.ErrCodeInvalidFilter:                 http.StatusBadRequest,
+	model.ErrCodeInvalidFetchInterval:          http.StatusBadRequest,
+	model.ErrCodeInvalidSearchQuery:            http.StatusBadRequest,
+	model.ErrCodeInvalidProfileSlug:            http.StatusBadRequest,
+	model.ErrCodeInvalidUnreadWarningThreshold: http.StatusBadRequest,
+	model.ErrCodeInvalidDebugParseInput:        http.StatusBadRequest,
+	model.ErrCodeInvalidStatsPeriod:            http.StatusBadRequest,
+	model.ErrCodeInvalidImportFilter:           http.StatusBadRequest,
+	model.ErrCodeInvalidTimezone:               http.StatusBadRequest,
+
+	// 状態の衝突
+	model.ErrCodeFeedNotStopped:   http.StatusConflict,
+	model.ErrCodeProfileSlugTaken: http.StatusConflict,
+	// 行ロック競合（自動ワーカーまたは別手動フェッチが進行中）。
+	// 既存ドメイン衝突系（FEED_NOT_STOPPED / SUBSCRIPTION_LIMIT / DUPLICATE_SUBSCRIPTION）と
+	// 同じ 409 Conflict にマップする（Issue #115 Req 3.2 / design.md 既存慣習との整合）。
+	model.ErrCodeFeedFetchInProgress: http.StatusConflict,
+	// 10 分クールダウン中の手動フェッチ拒否。HTTP 429 Too Many Requests にマップする
+	// （Issue #115 Req 2.1）。レスポンスボディの Details.retry_after_seconds に残り秒数を含める。
+	model.ErrCodeFeedCooldown: http.StatusTooManyRequests,
+	// 購読解除の取り消し期限切れ。スナップショットは既に破棄されており復元できない。
+	model.ErrCodeSubscriptionRestoreExpired: http.StatusGone,
+	// リクエストボディの上限超過。通常は BodyLimit ミドルウェアが返すが、
+	// ハンドラが上限超過を検知して返した場合も同じ 413 にそろえる。
+	model.ErrCodePayloadTooLarge: http.StatusRequestEntityTooLarge,
+	// 元記事リンクの無い記事への訪問。リダイレクト先となるリソースが存在しないため 404 にする。
+	model.ErrCodeItemLinkUnavailable: http.StatusNotFound,
+	// チーム共有。未所属のチームは存在を明かさないよう未検出と同じ 404 にする。
+	// 招待トークンは使用済み・期限切れを区別せず 404 とし、上限・重複・オーナー脱退は 409 とする。
+	model.ErrCodeTeamNotFound:          http.StatusNotFound,
+	model.ErrCodeInvalidTeamName:       http.StatusBadRequest,
+	model.ErrCodeTeamInvitationInvalid: http.StatusNotFound,
+	model.ErrCodeTeamMemberLimit:       http.StatusConflict,
+	model.ErrCodeAlreadyTeamMember:     http.StatusConflict,
+	model.ErrCodeTeamOwnerCannotLeave:  http.StatusConflict,
+	model.ErrCodeDuplicateTeamFeed:     http.StatusConflict,
+	// 購読単位の最大記事保持数
+	model.ErrCodeInvalidRetentionOverride: http.StatusBadRequest,
+	// 購読単位の通知ヒント設定
+	model.ErrCodeInvalidNotificationSetting: http.StatusBadRequest,
+	// 購読の並び順の一括更新
+	model.ErrCodeInvalidSubscriptionOrder: http.StatusBadRequest,
+	// Idempotency-Key による冪等性レイヤー
+	model.ErrCodeInvalidIdempotencyKey:  http.StatusBadRequest,
+	model.ErrCodeIdempotencyKeyInUse:    http.StatusConflict,
+	model.ErrCodeIdempotencyKeyMismatch: http.StatusUnprocessableEntity,
+	// フィード URL の張り替え提案。提案がない場合は 404、張り替え先が別フィードで登録済みの場合は 409 とする。
+	model.ErrCodeFeedURLSuggestionNotFound: http.StatusNotFound,
+	model.ErrCodeFeedURLTaken:              http.StatusConflict,
+	// フィードタイトルの自動更新ポリシー
+	model.ErrCodeInvalidTitleUpdatePolicy: http.StatusBadRequest,
+	model.ErrCodePendingTitleNotFound:     http.StatusNotFound,
+	// Slack / Discord への新着記事の転送設定
+	model.ErrCodeIntegrationNotFound: http.StatusNotFound,
+	model.ErrCodeInvalidIntegration:  http.StatusBadRequest,
+	model.ErrCodeIntegrationLimit:    http.StatusConflict,
+	// あとで読むサービス連携。連携先での認証失敗は入力（アカウント情報・認可）の問題として 422、
+	// 連携先の障害は上流のエラーとして 502 にする。
+	model.ErrCodeReadLaterNotConnected:    http.StatusNotFound,
+	model.ErrCodeInvalidReadLaterProvider: http.StatusBadRequest,
+	model.ErrCodeReadLaterAuthFailed:      http.StatusUnprocessableEntity,
+	model.ErrCodeReadLaterUnavailable:     http.StatusBadGateway,
+	// フィードのブロックリスト（モデレーション）
+	model.ErrCodeFeedBlocked:           http.StatusForbidden,
+	model.ErrCodeInvalidBlockedDomain:  http.StatusBadRequest,
+	model.ErrCodeBlockedDomainNotFound: http.StatusNotFound,
+	// 記事の要約生成。要約器がいずれも失敗した場合は一時的な障害として 503 とする。
+	model.ErrCodeItemNotSummarizable:  http.StatusUnprocessableEntity,
+	model.ErrCodeSummarizeRateLimited: http.StatusTooManyRequests,
+	model.ErrCodeSummaryUnavailable:   http.StatusServiceUnavailable,
+	// オフライン同期の操作列
+	model.ErrCodeInvalidSyncOperations: http.StatusBadRequest,
+	// 管理者向けのフェッチレスポンスのダウンロード
+	model.ErrCodeFeedRawCaptureNotFound: http.StatusNotFound,
+	// アカウント連携の解除。最後の 1 つの解除はロックアウト防止のため 409 とする。
+	model.ErrCodeIdentityNotFound: http.StatusNotFound,
+	model.ErrCodeLastIdentity:     http.StatusConflict,
+	// フィードの一括登録
+	model.ErrCodeInvalidBatchFeedURLs:          http.StatusBadRequest,
+	model.ErrCodeFeedRegistrationBatchNotFound: http.StatusNotFound,
+	model.ErrCodeInvalidHighlightPeriod:        http.StatusBadRequest,
+	// マジックリンクログイン。無効なリンクは存在しない・期限切れ・使用済みを区別せず 400 とする。
+	model.ErrCodeInvalidEmail:      http.StatusBadRequest,
+	model.ErrCodeInvalidLoginToken: http.StatusBadRequest,
+}
+
+// WriteError はエラーを統一フォーマットのエラーレスポンスとして書き込む。
+// APIError（ラップされたものを含む）は対応表のステータスコードで返し、
+// それ以外のエラーは詳細をログに記録した上で 500 INTERNAL_ERROR を返す。
+func WriteError(w http.ResponseWriter, err error) {
+	var apiErr *model.APIError
+	if errors.As(err, &apiErr) {
+		statusCode := mapAPIErrorToHTTPStatus(apiErr)
+		if statusCode == http.StatusInternalServerError && apiErr.Code != model.ErrCodeInternal {
+			// 対応表への登録漏れを検知できるようにログに残す
+			slog.Warn("unmapped api error code", slog.String("code", apiErr.Code))
+		}
+		middleware.WriteErrorResponse(w, statusCode, apiErr)
+		return
+	}
+
+	// APIError以外のエラーは内部サーバーエラーとして扱う
+	slog.Error("internal server error", slog.String("error", err.Error()))
+	middleware.WriteInternalServerError(w)
+}
+
+// mapAPIErrorToHTTPStatus はAPIErrorコードからHTTPステータスコードにマッピングする。
+// 対応表に存在しないコードは 500 にフォールバックする。
+func mapAPIErrorToHTTPStatus(apiErr *model.APIError) int {
+	if statusCode, ok := apiErrorStatusCodes[apiErr.Code]; ok {
+		return statusCode
+	}
+	return http.StatusInternalServerError
+}
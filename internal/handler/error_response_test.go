@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- mapAPIErrorToHTTPStatus（エラーコード→HTTPステータスマッピング）のテスト ---
+
+// TestMapAPIErrorToHTTPStatus_KnownCodes は既知のエラーコードがそれぞれ対応する
+// HTTPステータスへマッピングされる正常系を検証する（要件 1.2）。
+func TestMapAPIErrorToHTTPStatus_KnownCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		wantStatus int
+	}{
+		{"FEED_NOT_DETECTED のとき 422", model.ErrCodeFeedNotDetected, http.StatusUnprocessableEntity},
+		{"INVALID_URL のとき 400", model.ErrCodeInvalidURL, http.StatusBadRequest},
+		{"INVALID_URL_SCHEME のとき 400", model.ErrCodeInvalidURLScheme, http.StatusBadRequest},
+		{"SSRF_BLOCKED のとき 403", model.ErrCodeSSRFBlocked, http.StatusForbidden},
+		{"FETCH_FAILED のとき 502", model.ErrCodeFetchFailed, http.StatusBadGateway},
+		{"PARSE_FAILED のとき 422", model.ErrCodeParseFailed, http.StatusUnprocessableEntity},
+		{"SUBSCRIPTION_LIMIT のとき 409", model.ErrCodeSubscriptionLimit, http.StatusConflict},
+		{"DUPLICATE_SUBSCRIPTION のとき 409", model.ErrCodeDuplicateSubscription, http.StatusConflict},
+		{"FEED_NOT_FOUND のとき 404", model.ErrCodeFeedNotFound, http.StatusNotFound},
+		{"SUBSCRIPTION_NOT_FOUND のとき 404", model.ErrCodeSubscriptionNotFound, http.StatusNotFound},
+		{"ITEM_NOT_FOUND のとき 404", model.ErrCodeItemNotFound, http.StatusNotFound},
+		{"INVALID_FILTER のとき 400", model.ErrCodeInvalidFilter, http.StatusBadRequest},
+		{"INVALID_FETCH_INTERVAL のとき 400", model.ErrCodeInvalidFetchInterval, http.StatusBadRequest},
+		{"FEED_NOT_STOPPED のとき 409", model.ErrCodeFeedNotStopped, http.StatusConflict},
+		{"FEED_FETCH_IN_PROGRESS のとき 409", model.ErrCodeFeedFetchInProgress, http.StatusConflict},
+		{"FEED_COOLDOWN のとき 429", model.ErrCodeFeedCooldown, http.StatusTooManyRequests},
+		{"USER_NOT_FOUND のとき 404", model.ErrCodeUserNotFound, http.StatusNotFound},
+		{"INVALID_SEARCH_QUERY のとき 400", model.ErrCodeInvalidSearchQuery, http.StatusBadRequest},
+		{"FEED_NOT_SUBSCRIBED のとき 403", model.ErrCodeFeedNotSubscribed, http.StatusForbidden},
+		{"UNAUTHORIZED のとき 401", model.ErrCodeUnauthorized, http.StatusUnauthorized},
+		{"INVALID_REQUEST のとき 400", model.ErrCodeInvalidRequest, http.StatusBadRequest},
+		{"INTERNAL_ERROR のとき 500", model.ErrCodeInternal, http.StatusInternalServerError},
+		{"PROFILE_NOT_FOUND のとき 404", model.ErrCodeProfileNotFound, http.StatusNotFound},
+		{"INVALID_PROFILE_SLUG のとき 400", model.ErrCodeInvalidProfileSlug, http.StatusBadRequest},
+		{"PROFILE_SLUG_TAKEN のとき 409", model.ErrCodeProfileSlugTaken, http.StatusConflict},
+		{"INVALID_UNREAD_WARNING_THRESHOLD のとき 400", model.ErrCodeInvalidUnreadWarningThreshold, http.StatusBadRequest},
+		{"INVALID_TIMEZONE のとき 400", model.ErrCodeInvalidTimezone, http.StatusBadRequest},
+		{"FEED_URL_SUGGESTION_NOT_FOUND のとき 404", model.ErrCodeFeedURLSuggestionNotFound, http.StatusNotFound},
+		{"FEED_URL_TAKEN のとき 409", model.ErrCodeFeedURLTaken, http.StatusConflict},
+		{"INVALID_TITLE_UPDATE_POLICY のとき 400", model.ErrCodeInvalidTitleUpdatePolicy, http.StatusBadRequest},
+		{"PENDING_TITLE_NOT_FOUND のとき 404", model.ErrCodePendingTitleNotFound, http.StatusNotFound},
+		{"INTEGRATION_NOT_FOUND のとき 404", model.ErrCodeIntegrationNotFound, http.StatusNotFound},
+		{"INVALID_INTEGRATION のとき 400", model.ErrCodeInvalidIntegration, http.StatusBadRequest},
+		{"INTEGRATION_LIMIT のとき 409", model.ErrCodeIntegrationLimit, http.StatusConflict},
+		{"READ_LATER_NOT_CONNECTED のとき 404", model.ErrCodeReadLaterNotConnected, http.StatusNotFound},
+		{"INVALID_READ_LATER_PROVIDER のとき 400", model.ErrCodeInvalidReadLaterProvider, http.StatusBadRequest},
+		{"READ_LATER_AUTH_FAILED のとき 422", model.ErrCodeReadLaterAuthFailed, http.StatusUnprocessableEntity},
+		{"READ_LATER_UNAVAILABLE のとき 502", model.ErrCodeReadLaterUnavailable, http.StatusBadGateway},
+		{"FEED_BLOCKED のとき 403", model.ErrCodeFeedBlocked, http.StatusForbidden},
+		{"INVALID_BLOCKED_DOMAIN のとき 400", model.ErrCodeInvalidBlockedDomain, http.StatusBadRequest},
+		{"BLOCKED_DOMAIN_NOT_FOUND のとき 404", model.ErrCodeBlockedDomainNotFound, http.StatusNotFound},
+		{"ITEM_NOT_SUMMARIZABLE のとき 422", model.ErrCodeItemNotSummarizable, http.StatusUnprocessableEntity},
+		{"SUMMARIZE_RATE_LIMITED のとき 429", model.ErrCodeSummarizeRateLimited, http.StatusTooManyRequests},
+		{"SUMMARY_UNAVAILABLE のとき 503", model.ErrCodeSummaryUnavailable, http.StatusServiceUnavailable},
+		{"INVALID_SYNC_OPERATIONS のとき 400", model.ErrCodeInvalidSyncOperations, http.StatusBadRequest},
+		{"FEED_RAW_CAPTURE_NOT_FOUND のとき 404", model.ErrCodeFeedRawCaptureNotFound, http.StatusNotFound},
+		{"FEED_REDIRECT_LOOP のとき 422", model.ErrCodeFeedRedirectLoop, http.StatusUnprocessableEntity},
+		{"IDENTITY_NOT_FOUND のとき 404", model.ErrCodeIdentityNotFound, http.StatusNotFound},
+		{"LAST_IDENTITY のとき 409", model.ErrCodeLastIdentity, http.StatusConflict},
+		{"INVALID_BATCH_FEED_URLS のとき 400", model.ErrCodeInvalidBatchFeedURLs, http.StatusBadRequest},
+		{"FEED_REGISTRATION_BATCH_NOT_FOUND のとき 404", model.ErrCodeFeedRegistrationBatchNotFound, http.StatusNotFound},
+		{"INVALID_HIGHLIGHT_PERIOD のとき 400", model.ErrCodeInvalidHighlightPeriod, http.StatusBadRequest},
+		{"INVALID_EMAIL のとき 400", model.ErrCodeInvalidEmail, http.StatusBadRequest},
+		{"INVALID_LOGIN_TOKEN のとき 400", model.ErrCodeInvalidLoginToken, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			apiErr := &model.APIError{Code: tt.code}
+
+			// Act
+			got := mapAPIErrorToHTTPStatus(apiErr)
+
+			// Assert
+			if got != tt.wantStatus {
+				t.Errorf("mapAPIErrorToHTTPStatus(code=%q) = %d, want %d", tt.code, got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// TestMapAPIErrorToHTTPStatus_UnknownCode_ReturnsInternalServerError は未マップの
+// エラーコードが default 分岐で HTTP 500（Internal Server Error）にフォールバックする
+// ことを検証する（要件 1.1）。
+func TestMapAPIErrorToHTTPStatus_UnknownCode_ReturnsInternalServerError(t *testing.T) {
+	// Arrange: マッピング表に存在しない未知のエラーコード。
+	apiErr := &model.APIError{Code: "SOME_UNMAPPED_ERROR_CODE"}
+
+	// Act
+	got := mapAPIErrorToHTTPStatus(apiErr)
+
+	// Assert
+	if got != http.StatusInternalServerError {
+		t.Errorf("未知コードの default 分岐 = %d, want %d", got, http.StatusInternalServerError)
+	}
+}
+
+// TestMapAPIErrorToHTTPStatus_EmptyCode_ReturnsInternalServerError は空のエラーコード
+// （境界値）も default 分岐で 500 にフォールバックすることを検証する（要件 1.1）。
+func TestMapAPIErrorToHTTPStatus_EmptyCode_ReturnsInternalServerError(t *testing.T) {
+	// Arrange: 空文字コードはどの case にも一致しない。
+	apiErr := &model.APIError{Code: ""}
+
+	// Act
+	got := mapAPIErrorToHTTPStatus(apiErr)
+
+	// Assert
+	if got != http.StatusInternalServerError {
+		t.Errorf("空コードの default 分岐 = %d, want %d", got, http.StatusInternalServerError)
+	}
+}
+
+// --- WriteError（共通エラーレスポンス）のテスト ---
+
+func TestWriteError(t *testing.T) {
+	t.Run("APIErrorのとき対応表のステータスとエラーボディを返す", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+
+		// Act
+		WriteError(w, model.NewSubscriptionNotFoundError("sub-1"))
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		got := parseAPIErrorResponse(t, w)
+		if got["code"] != model.ErrCodeSubscriptionNotFound {
+			t.Errorf("code = %q, want %q", got["code"], model.ErrCodeSubscriptionNotFound)
+		}
+	})
+
+	t.Run("ラップされたAPIErrorのとき元のコードで返す", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		err := fmt.Errorf("購読設定の更新に失敗しました: %w", model.NewInvalidFetchIntervalError(31))
+
+		// Act
+		WriteError(w, err)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w); got["code"] != model.ErrCodeInvalidFetchInterval {
+			t.Errorf("code = %q, want %q", got["code"], model.ErrCodeInvalidFetchInterval)
+		}
+	})
+
+	t.Run("APIErrorのDetailsをレスポンスに含める", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		apiErr := &model.APIError{
+			Code:    model.ErrCodeFeedCooldown,
+			Message: "cooldown",
+			Details: map[string]any{"retry_after_seconds": 120},
+		}
+
+		// Act
+		WriteError(w, apiErr)
+
+		// Assert
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+		}
+		var body struct {
+			Details map[string]any `json:"details"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body.Details["retry_after_seconds"] != float64(120) {
+			t.Errorf("details = %v, want retry_after_seconds=120", body.Details)
+		}
+	})
+
+	t.Run("APIError以外のエラーのとき500を返し詳細をログに記録する", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		prev := slog.Default()
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+		defer slog.SetDefault(prev)
+		w := httptest.NewRecorder()
+
+		// Act
+		WriteError(w, errors.New("database connection failed"))
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+		if got := parseAPIErrorResponse(t, w); got["code"] != model.ErrCodeInternal {
+			t.Errorf("code = %q, want %q", got["code"], model.ErrCodeInternal)
+		}
+		if strings.Contains(w.Body.String(), "database connection failed") {
+			t.Error("内部エラーの詳細がレスポンスに漏れている")
+		}
+		if !strings.Contains(buf.String(), "database connection failed") {
+			t.Errorf("内部エラーの詳細がログに記録されていない: %s", buf.String())
+		}
+	})
+
+	t.Run("対応表に未登録のコードのとき500を返し警告ログを出す", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		prev := slog.Default()
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+		defer slog.SetDefault(prev)
+		w := httptest.NewRecorder()
+
+		// Act
+		WriteError(w, &model.APIError{Code: "SOME_UNMAPPED_ERROR_CODE"})
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+		if !strings.Contains(buf.String(), "SOME_UNMAPPED_ERROR_CODE") {
+			t.Errorf("未登録コードの警告ログが出力されていない: %s", buf.String())
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Package handler の feed_batch_handler.go は、貼り付けた複数の URL をまとめてフィード登録する HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - POST /api/feeds/batch           : URL の配列（最大 20 件）の一括登録を開始する（202 Accepted）
+//   - GET  /api/feeds/batch/{batchId} : 一括登録の進捗と URL ごとの結果を返す（ポーリング用）
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// FeedBatchServiceInterface はフィード一括登録ハンドラが必要とするサービスインターフェース。
+type FeedBatchServiceInterface interface {
+	// StartBatch は URL の一括登録を開始し、全件が pending の一括登録を返す。
+	// URL が空か上限を超える場合は INVALID_BATCH_FEED_URLS を返す。
+	StartBatch(ctx context.Context, userID string, urls []string) (*feedBatchResponse, error)
+	// GetBatch は当該ユーザーの一括登録を返す。存在しない場合は FEED_REGISTRATION_BATCH_NOT_FOUND を返す。
+	GetBatch(ctx context.Context, userID, batchID string) (*feedBatchResponse, error)
+}
+
+// FeedBatchHandler はフィード一括登録の HTTP ハンドラ。
+type FeedBatchHandler struct {
+	service FeedBatchServiceInterface
+}
+
+// NewFeedBatchHandler は FeedBatchHandler を生成する。
+func NewFeedBatchHandler(service FeedBatchServiceInterface) *FeedBatchHandler {
+	return &FeedBatchHandler{service: service}
+}
+
+// feedBatchRequest はフィード一括登録のリクエスト。
+type feedBatchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+// feedBatchResponse はフィード一括登録のレスポンス。
+// status は processing（処理中）/ completed（全件処理済み）。results はリクエストの urls（空行を除く）と同じ順に並ぶ。
+type feedBatchResponse struct {
+	ID          string                    `json:"id"`
+	Status      string                    `json:"status"`
+	Results     []feedBatchResultResponse `json:"results"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	CompletedAt *time.Time                `json:"completed_at"`
+}
+
+// feedBatchResultResponse は URL 1 件分の登録結果。
+// status は pending / registered / duplicate / not_detected / limit_exceeded / failed。
+// feed_id は registered / duplicate の場合、error は not_detected / limit_exceeded / failed の場合のみ設定する。
+type feedBatchResultResponse struct {
+	Index  int                   `json:"index"`
+	URL    string                `json:"url"`
+	Status string                `json:"status"`
+	FeedID string                `json:"feed_id,omitempty"`
+	Error  *feedBatchResultError `json:"error"`
+}
+
+// feedBatchResultError は登録できなかった URL のエラー。
+type feedBatchResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// StartBatch は URL の一括登録を開始する。
+// POST /api/feeds/batch
+//
+// 検出・登録はバックグラウンドで進めるため、受け付けた時点の（全件 pending の）一括登録を 202 で返す。
+// クライアントは GET /api/feeds/batch/{batchId} を status が completed になるまでポーリングする。
+func (h *FeedBatchHandler) StartBatch(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req feedBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.StartBatch(r.Context(), userID, req.URLs)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusAccepted, resp)
+}
+
+// GetBatch は一括登録の進捗と URL ごとの結果を返す。
+// GET /api/feeds/batch/{batchId}
+func (h *FeedBatchHandler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.GetBatch(r.Context(), userID, chi.URLParam(r, "batchId"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// feedBatchRegistrationCost は一括登録のリクエストが登録を試みる URL 数（空行を除く）を返す。
+// 一括登録のレート制限（FeedBatchRegistrationMiddleware）で URL 1 件を 1 件として数えるために使う。
+// 読み出したボディはハンドラが改めて読めるように戻す。解析できないボディや件数が上限の範囲外のリクエストは
+// 1 件として数え、ハンドラの 400 に任せる。
+func feedBatchRegistrationCost(r *http.Request) int {
+	if r.Body == nil || r.Body == http.NoBody {
+		return 1
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if err != nil {
+		return 1
+	}
+
+	var req feedBatchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 1
+	}
+	n := 0
+	for _, u := range req.URLs {
+		if strings.TrimSpace(u) != "" {
+			n++
+		}
+	}
+	if n == 0 || n > model.MaxBatchFeedURLs {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockFeedBatchService は FeedBatchServiceInterface のモック実装。
+type mockFeedBatchService struct {
+	startFn func(ctx context.Context, userID string, urls []string) (*feedBatchResponse, error)
+	getFn   func(ctx context.Context, userID, batchID string) (*feedBatchResponse, error)
+}
+
+func (m *mockFeedBatchService) StartBatch(ctx context.Context, userID string, urls []string) (*feedBatchResponse, error) {
+	return m.startFn(ctx, userID, urls)
+}
+
+func (m *mockFeedBatchService) GetBatch(ctx context.Context, userID, batchID string) (*feedBatchResponse, error) {
+	return m.getFn(ctx, userID, batchID)
+}
+
+func TestFeedBatchHandler_StartBatch(t *testing.T) {
+	t.Run("URL の配列をサービスに渡し受け付けた一括登録を202で返すとき", func(t *testing.T) {
+		// Arrange
+		var gotUserID string
+		var gotURLs []string
+		svc := &mockFeedBatchService{
+			startFn: func(_ context.Context, userID string, urls []string) (*feedBatchResponse, error) {
+				gotUserID, gotURLs = userID, urls
+				return &feedBatchResponse{
+					ID:     "batch-1",
+					Status: "processing",
+					Results: []feedBatchResultResponse{
+						{Index: 0, URL: urls[0], Status: "pending"},
+						{Index: 1, URL: urls[1], Status: "pending"},
+					},
+					CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+				}, nil
+			},
+		}
+		h := NewFeedBatchHandler(svc)
+		body := `{"urls":["https://a.example.com","https://b.example.com"]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/batch", strings.NewReader(body))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.StartBatch(w, req)
+
+		// Assert
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusAccepted)
+		}
+		if gotUserID != "user-1" || len(gotURLs) != 2 || gotURLs[1] != "https://b.example.com" {
+			t.Errorf("userID = %q, urls = %v", gotUserID, gotURLs)
+		}
+		var resp struct {
+			ID          string  `json:"id"`
+			Status      string  `json:"status"`
+			CompletedAt *string `json:"completed_at"`
+			Results     []struct {
+				Index  int    `json:"index"`
+				Status string `json:"status"`
+			} `json:"results"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("レスポンスの解析に失敗: %v", err)
+		}
+		if resp.ID != "batch-1" || resp.Status != "processing" || resp.CompletedAt != nil {
+			t.Errorf("resp = %+v", resp)
+		}
+		if len(resp.Results) != 2 || resp.Results[1].Index != 1 || resp.Results[1].Status != "pending" {
+			t.Errorf("results = %+v", resp.Results)
+		}
+	})
+
+	t.Run("URL の指定が不正なとき400を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedBatchService{
+			startFn: func(context.Context, string, []string) (*feedBatchResponse, error) {
+				return nil, model.NewInvalidBatchFeedURLsError("URL を 1 件以上指定してください")
+			},
+		}
+		h := NewFeedBatchHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/batch", strings.NewReader(`{"urls":[]}`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.StartBatch(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w)["code"]; got != model.ErrCodeInvalidBatchFeedURLs {
+			t.Errorf("code = %q, want %q", got, model.ErrCodeInvalidBatchFeedURLs)
+		}
+	})
+
+	t.Run("リクエストボディが不正なJSONのとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedBatchHandler(&mockFeedBatchService{})
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/batch", strings.NewReader(`{`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.StartBatch(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedBatchHandler(&mockFeedBatchService{})
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/batch", strings.NewReader(`{"urls":["https://a.example.com"]}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.StartBatch(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestFeedBatchHandler_GetBatch(t *testing.T) {
+	t.Run("一括登録の結果を200で返すとき", func(t *testing.T) {
+		// Arrange
+		var gotBatchID string
+		completedAt := time.Date(2026, 10, 1, 12, 0, 5, 0, time.UTC)
+		svc := &mockFeedBatchService{
+			getFn: func(_ context.Context, _ string, batchID string) (*feedBatchResponse, error) {
+				gotBatchID = batchID
+				return &feedBatchResponse{
+					ID:     batchID,
+					Status: "completed",
+					Results: []feedBatchResultResponse{
+						{Index: 0, URL: "https://a.example.com", Status: "registered", FeedID: "feed-1"},
+						{Index: 1, URL: "https://x.example.com", Status: "not_detected", Error: &feedBatchResultError{Code: model.ErrCodeFeedNotDetected, Message: "検出できませんでした"}},
+					},
+					CompletedAt: &completedAt,
+				}, nil
+			},
+		}
+		h := NewFeedBatchHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/batch/batch-1", nil)
+		req = withUserID(req, "user-1")
+		req = withChiURLParam(req, "batchId", "batch-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetBatch(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotBatchID != "batch-1" {
+			t.Errorf("batchID = %q, want %q", gotBatchID, "batch-1")
+		}
+		var resp struct {
+			Status  string `json:"status"`
+			Results []struct {
+				Status string `json:"status"`
+				FeedID string `json:"feed_id"`
+				Error  *struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			} `json:"results"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("レスポンスの解析に失敗: %v", err)
+		}
+		if resp.Status != "completed" || len(resp.Results) != 2 {
+			t.Fatalf("resp = %+v", resp)
+		}
+		if resp.Results[0].FeedID != "feed-1" || resp.Results[0].Error != nil {
+			t.Errorf("results[0] = %+v", resp.Results[0])
+		}
+		if resp.Results[1].Error == nil || resp.Results[1].Error.Code != model.ErrCodeFeedNotDetected {
+			t.Errorf("results[1] = %+v", resp.Results[1])
+		}
+	})
+
+	t.Run("一括登録が存在しないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedBatchService{
+			getFn: func(_ context.Context, _ string, batchID string) (*feedBatchResponse, error) {
+				return nil, model.NewFeedRegistrationBatchNotFoundError(batchID)
+			},
+		}
+		h := NewFeedBatchHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/batch/unknown", nil)
+		req = withUserID(req, "user-1")
+		req = withChiURLParam(req, "batchId", "unknown")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetBatch(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if got := parseAPIErrorResponse(t, w)["code"]; got != model.ErrCodeFeedRegistrationBatchNotFound {
+			t.Errorf("code = %q, want %q", got, model.ErrCodeFeedRegistrationBatchNotFound)
+		}
+	})
+}
+
+func TestFeedBatchRegistrationCost(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"空行を除いた URL の件数を返すとき", `{"urls":["https://a.example.com"," ","https://b.example.com","https://c.example.com"]}`, 3},
+		{"URL が空のとき1件として数える", `{"urls":[]}`, 1},
+		{"件数が上限を超えるとき1件として数える", `{"urls":[` + strings.Repeat(`"https://a.example.com",`, model.MaxBatchFeedURLs) + `"https://b.example.com"]}`, 1},
+		{"JSON として解析できないとき1件として数える", `{`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodPost, "/api/feeds/batch", strings.NewReader(tt.body))
+
+			// Act
+			got := feedBatchRegistrationCost(req)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("feedBatchRegistrationCost() = %d, want %d", got, tt.want)
+			}
+			rest, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(rest) != tt.body {
+				t.Errorf("body after cost = %q, want %q", rest, tt.body)
+			}
+		})
+	}
+}
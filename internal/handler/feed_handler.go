@@ -3,9 +3,8 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hitoshi/feedman/internal/middleware"
@@ -15,7 +14,8 @@ import (
 // FeedServiceInterface はフィードハンドラーが必要とするサービスインターフェース。
 type FeedServiceInterface interface {
 	// RegisterFeed はURLからフィードを検出し登録する。
-	RegisterFeed(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error)
+	// opts.Trial が true の場合はお試し購読（期限付き購読）として作成する。
+	RegisterFeed(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error)
 	// GetFeed はフィード情報を取得する。userID は認可チェック用。
 	GetFeed(ctx context.Context, userID, feedID string) (*model.Feed, error)
 	// UpdateFeedURL はフィードURLを更新する。userID は認可チェック用。
@@ -45,8 +45,10 @@ func NewFeedHandler(service FeedServiceInterface, deleter SubscriptionDeleter) *
 }
 
 // registerFeedRequest はフィード登録リクエストのボディ。
+// trial が true の場合は 1 週間の期限付きのお試し購読として作成する。
 type registerFeedRequest struct {
-	URL string `json:"url"`
+	URL   string `json:"url"`
+	Trial bool   `json:"trial"`
 }
 
 // updateFeedURLRequest はフィードURL更新リクエストのボディ。
@@ -55,12 +57,55 @@ type updateFeedURLRequest struct {
 }
 
 // feedResponse はフィード情報のAPIレスポンス。
+// copyright / ttl_minutes / robots / noindex はフィードが示す利用条件で、クライアントが購読前後に
+// 転載・公開に関する注意を表示するために使う（未取得・指定なしの場合は空文字 / null / false）。
+// 値はフェッチ時に保存するため、新規フィードの登録レスポンスでは未取得になる。
 type feedResponse struct {
-	ID          string `json:"id"`
-	FeedURL     string `json:"feed_url"`
-	SiteURL     string `json:"site_url"`
-	Title       string `json:"title"`
-	FetchStatus string `json:"fetch_status"`
+	ID              string     `json:"id"`
+	FeedURL         string     `json:"feed_url"`
+	SiteURL         string     `json:"site_url"`
+	Title           string     `json:"title"`
+	FetchStatus     string     `json:"fetch_status"`
+	Language        string     `json:"language"`
+	Description     string     `json:"description"`
+	LastPublishedAt *time.Time `json:"last_published_at"`
+	Copyright       string     `json:"copyright"`
+	TTLMinutes      *int       `json:"ttl_minutes"`
+	Robots          string     `json:"robots"`
+	NoIndex         bool       `json:"noindex"`
+}
+
+// registerFeedResponse はフィード登録のAPIレスポンス。
+// お試し購読として作成した場合は subscription_expires_at に購読の期限を含める。
+type registerFeedResponse struct {
+	feedResponse
+	SubscriptionExpiresAt *time.Time `json:"subscription_expires_at,omitempty"`
+}
+
+// feedHealthResponse はフィードのフェッチ状態（ヘルス）のAPIレスポンス。
+// 値がない項目は null を返す（エラーなしの場合の error_kind / error_message / error_detail 等）。
+type feedHealthResponse struct {
+	FeedID                string                   `json:"feed_id"`
+	FetchStatus           string                   `json:"fetch_status"`
+	ConsecutiveErrors     int                      `json:"consecutive_errors"`
+	ErrorKind             *string                  `json:"error_kind"`
+	ErrorMessage          *string                  `json:"error_message"`
+	ErrorDetail           *feedErrorDetailResponse `json:"error_detail"`
+	HTTPVersion           *string                  `json:"http_version"`
+	LastSuccessfulFetchAt *time.Time               `json:"last_successful_fetch_at"`
+	NextFetchAt           time.Time                `json:"next_fetch_at"`
+}
+
+// feedErrorDetailResponse は直近のフェッチ失敗の詳細。
+// TLS 失敗では tls_error と証明書情報、HTTP エラー応答では http_status が入る。
+type feedErrorDetailResponse struct {
+	HTTPStatus    *int       `json:"http_status"`
+	TLSError      *string    `json:"tls_error"`
+	CertSubject   *string    `json:"cert_subject"`
+	CertIssuer    *string    `json:"cert_issuer"`
+	CertDNSNames  []string   `json:"cert_dns_names"`
+	CertNotBefore *time.Time `json:"cert_not_before"`
+	CertNotAfter  *time.Time `json:"cert_not_after"`
 }
 
 // RegisterFeed はフィード登録を処理する。
@@ -68,8 +113,8 @@ type feedResponse struct {
 func (h *FeedHandler) RegisterFeed(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -79,8 +124,8 @@ func (h *FeedHandler) RegisterFeed(w http.ResponseWriter, r *http.Request) {
 
 	var req registerFeedRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		middleware.WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{
-			Code:     "INVALID_REQUEST",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
 			Message:  "リクエストボディの解析に失敗しました。",
 			Category: "validation",
 			Action:   "正しいJSON形式でリクエストしてください。",
@@ -89,19 +134,20 @@ func (h *FeedHandler) RegisterFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.URL == "" {
-		middleware.WriteErrorResponse(w, http.StatusBadRequest, model.NewInvalidURLError("URLが空です"))
+		WriteError(w, model.NewInvalidURLError("URLが空です"))
 		return
 	}
 
-	feed, _, err := h.service.RegisterFeed(r.Context(), userID, req.URL)
+	feed, sub, err := h.service.RegisterFeed(r.Context(), userID, req.URL, model.RegisterFeedOptions{Trial: req.Trial})
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(toFeedResponse(feed))
+	WriteJSON(w, http.StatusCreated, registerFeedResponse{
+		feedResponse:          toFeedResponse(feed),
+		SubscriptionExpiresAt: subscriptionExpiresAt(sub),
+	})
 }
 
 // GetFeed はフィード詳細を取得する。
@@ -109,8 +155,8 @@ func (h *FeedHandler) RegisterFeed(w http.ResponseWriter, r *http.Request) {
 func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -122,13 +168,13 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 
 	feed, err := h.service.GetFeed(r.Context(), userID, feedID)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
 	if feed == nil {
-		middleware.WriteErrorResponse(w, http.StatusNotFound, &model.APIError{
-			Code:     "FEED_NOT_FOUND",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeFeedNotFound,
 			Message:  "指定されたフィードが見つかりません。",
 			Category: "feed",
 			Action:   "フィードIDを確認してください。",
@@ -136,8 +182,44 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(toFeedResponse(feed))
+	WriteJSON(w, http.StatusOK, toFeedResponse(feed))
+}
+
+// GetFeedHealth はフィードのフェッチ状態と直近の失敗詳細を取得する。
+// GET /api/feeds/:id/health
+//
+// 購読していないフィードは GetFeed と同様に 404 FEED_NOT_FOUND を返す。
+func (h *FeedHandler) GetFeedHealth(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	feedID := chi.URLParam(r, "id")
+
+	feed, err := h.service.GetFeed(r.Context(), userID, feedID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if feed == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeFeedNotFound,
+			Message:  "指定されたフィードが見つかりません。",
+			Category: "feed",
+			Action:   "フィードIDを確認してください。",
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toFeedHealthResponse(feed))
 }
 
 // UpdateFeedURL はフィードURLを更新する。
@@ -145,8 +227,8 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 func (h *FeedHandler) UpdateFeedURL(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -158,8 +240,8 @@ func (h *FeedHandler) UpdateFeedURL(w http.ResponseWriter, r *http.Request) {
 
 	var req updateFeedURLRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		middleware.WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{
-			Code:     "INVALID_REQUEST",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
 			Message:  "リクエストボディの解析に失敗しました。",
 			Category: "validation",
 			Action:   "正しいJSON形式でリクエストしてください。",
@@ -168,18 +250,17 @@ func (h *FeedHandler) UpdateFeedURL(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.FeedURL == "" {
-		middleware.WriteErrorResponse(w, http.StatusBadRequest, model.NewInvalidURLError("フィードURLが空です"))
+		WriteError(w, model.NewInvalidURLError("フィードURLが空です"))
 		return
 	}
 
 	feed, err := h.service.UpdateFeedURL(r.Context(), userID, feedID, req.FeedURL)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(toFeedResponse(feed))
+	WriteJSON(w, http.StatusOK, toFeedResponse(feed))
 }
 
 // DeleteFeed はフィードの購読を解除する。
@@ -187,8 +268,8 @@ func (h *FeedHandler) UpdateFeedURL(w http.ResponseWriter, r *http.Request) {
 func (h *FeedHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -199,7 +280,7 @@ func (h *FeedHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
 	feedID := chi.URLParam(r, "id")
 
 	if err := h.deleter.DeleteByUserAndFeed(r.Context(), userID, feedID); err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
@@ -225,6 +306,7 @@ func SetupFeedRoutes(service FeedServiceInterface, deleter SubscriptionDeleter,
 			r.Get("/", h.GetFeed)
 			r.Patch("/", h.UpdateFeedURL)
 			r.Delete("/", h.DeleteFeed)
+			r.Get("/health", h.GetFeedHealth)
 		})
 	})
 
@@ -236,70 +318,55 @@ func SetupFeedRoutes(service FeedServiceInterface, deleter SubscriptionDeleter,
 // toFeedResponse はmodel.FeedからAPIレスポンスに変換する。
 func toFeedResponse(feed *model.Feed) feedResponse {
 	return feedResponse{
-		ID:          feed.ID,
-		FeedURL:     feed.FeedURL,
-		SiteURL:     feed.SiteURL,
-		Title:       feed.Title,
-		FetchStatus: string(feed.FetchStatus),
+		ID:              feed.ID,
+		FeedURL:         feed.FeedURL,
+		SiteURL:         feed.SiteURL,
+		Title:           feed.Title,
+		FetchStatus:     string(feed.FetchStatus),
+		Language:        feed.Language,
+		Description:     feed.Description,
+		LastPublishedAt: feed.LastPublishedAt,
+		Copyright:       feed.Copyright,
+		TTLMinutes:      nullablePositiveInt(feed.TTLMinutes),
+		Robots:          feed.Robots,
+		NoIndex:         feed.NoIndex(),
 	}
 }
 
-// handleServiceError はサービス層から返されたエラーを適切なHTTPステータスコードに変換する。
-func handleServiceError(w http.ResponseWriter, err error) {
-	var apiErr *model.APIError
-	if errors.As(err, &apiErr) {
-		statusCode := mapAPIErrorToHTTPStatus(apiErr)
-		middleware.WriteErrorResponse(w, statusCode, apiErr)
-		return
+// subscriptionExpiresAt は購読の期限を返す。sub が nil または通常の購読の場合は nil。
+func subscriptionExpiresAt(sub *model.Subscription) *time.Time {
+	if sub == nil {
+		return nil
 	}
-
-	// APIError以外のエラーは内部サーバーエラーとして扱う
-	slog.Error("internal server error", slog.String("error", err.Error()))
-	middleware.WriteErrorResponse(w, http.StatusInternalServerError, &model.APIError{
-		Code:     "INTERNAL_ERROR",
-		Message:  "内部エラーが発生しました。",
-		Category: "system",
-		Action:   "しばらく待ってから再度お試しください。",
-	})
+	return sub.ExpiresAt
 }
 
-// mapAPIErrorToHTTPStatus はAPIErrorコードからHTTPステータスコードにマッピングする。
-func mapAPIErrorToHTTPStatus(apiErr *model.APIError) int {
-	switch apiErr.Code {
-	case model.ErrCodeFeedNotDetected:
-		return http.StatusUnprocessableEntity
-	case model.ErrCodeInvalidURL:
-		return http.StatusBadRequest
-	case model.ErrCodeSSRFBlocked:
-		return http.StatusForbidden
-	case model.ErrCodeFetchFailed:
-		return http.StatusBadGateway
-	case model.ErrCodeParseFailed:
-		return http.StatusUnprocessableEntity
-	case model.ErrCodeSubscriptionLimit:
-		return http.StatusConflict
-	case "DUPLICATE_SUBSCRIPTION":
-		return http.StatusConflict
-	case "FEED_NOT_FOUND", model.ErrCodeSubscriptionNotFound, model.ErrCodeItemNotFound:
-		return http.StatusNotFound
-	case model.ErrCodeInvalidFilter, model.ErrCodeInvalidFetchInterval, model.ErrCodeInvalidSearchQuery:
-		return http.StatusBadRequest
-	case model.ErrCodeFeedNotStopped:
-		return http.StatusConflict
-	case model.ErrCodeFeedFetchInProgress:
-		// 行ロック競合（自動ワーカーまたは別手動フェッチが進行中）。
-		// 既存ドメイン衝突系（FEED_NOT_STOPPED / SUBSCRIPTION_LIMIT / DUPLICATE_SUBSCRIPTION）と
-		// 同じ 409 Conflict にマップする（Issue #115 Req 3.2 / design.md 既存慣習との整合）。
-		return http.StatusConflict
-	case model.ErrCodeFeedCooldown:
-		// 10 分クールダウン中の手動フェッチ拒否。HTTP 429 Too Many Requests にマップする
-		// （Issue #115 Req 2.1）。レスポンスボディの Details.retry_after_seconds に残り秒数を含める。
-		return http.StatusTooManyRequests
-	case model.ErrCodeUserNotFound:
-		return http.StatusNotFound
-	case model.ErrCodeFeedNotSubscribed:
-		return http.StatusForbidden
-	default:
-		return http.StatusInternalServerError
+// toFeedHealthResponse はmodel.Feedからフィードヘルスのレスポンスに変換する。
+func toFeedHealthResponse(feed *model.Feed) feedHealthResponse {
+	resp := feedHealthResponse{
+		FeedID:                feed.ID,
+		FetchStatus:           string(feed.FetchStatus),
+		ConsecutiveErrors:     feed.ConsecutiveErrors,
+		ErrorKind:             nullableString(string(feed.ErrorKind)),
+		ErrorMessage:          nullableString(feed.ErrorMessage),
+		HTTPVersion:           nullableString(feed.HTTPVersion),
+		LastSuccessfulFetchAt: feed.LastSuccessfulFetchAt,
+		NextFetchAt:           feed.NextFetchAt,
+	}
+	if d := feed.ErrorDetail; d != nil {
+		detail := &feedErrorDetailResponse{
+			TLSError:      nullableString(string(d.TLSError)),
+			CertSubject:   nullableString(d.CertSubject),
+			CertIssuer:    nullableString(d.CertIssuer),
+			CertDNSNames:  d.CertDNSNames,
+			CertNotBefore: d.CertNotBefore,
+			CertNotAfter:  d.CertNotAfter,
+		}
+		if d.HTTPStatus != 0 {
+			status := d.HTTPStatus
+			detail.HTTPStatus = &status
+		}
+		resp.ErrorDetail = detail
 	}
+	return resp
 }
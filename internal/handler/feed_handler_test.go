@@ -7,7 +7,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hitoshi/feedman/internal/middleware"
@@ -18,14 +20,14 @@ import (
 
 // mockFeedService はFeedServiceInterfaceのモック実装。
 type mockFeedService struct {
-	registerFeedFn  func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error)
+	registerFeedFn  func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error)
 	getFeedFn       func(ctx context.Context, userID, feedID string) (*model.Feed, error)
 	updateFeedURLFn func(ctx context.Context, userID, feedID, newURL string) (*model.Feed, error)
 }
 
-func (m *mockFeedService) RegisterFeed(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+func (m *mockFeedService) RegisterFeed(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 	if m.registerFeedFn != nil {
-		return m.registerFeedFn(ctx, userID, inputURL)
+		return m.registerFeedFn(ctx, userID, inputURL, opts)
 	}
 	return nil, nil, nil
 }
@@ -86,7 +88,7 @@ func parseAPIErrorResponse(t *testing.T, w *httptest.ResponseRecorder) map[strin
 
 func TestFeedHandler_RegisterFeed_Success(t *testing.T) {
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			if userID != "user-123" {
 				t.Errorf("userID = %q, want %q", userID, "user-123")
 			}
@@ -122,8 +124,8 @@ func TestFeedHandler_RegisterFeed_Success(t *testing.T) {
 	}
 
 	contentType := resp.Header.Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", contentType, "application/json; charset=utf-8")
 	}
 
 	var result map[string]interface{}
@@ -139,6 +141,146 @@ func TestFeedHandler_RegisterFeed_Success(t *testing.T) {
 	}
 }
 
+// TestFeedHandler_RegisterFeed_UsageMetadata はフィードの利用条件のメタ情報が登録レスポンスに含まれることを検証する。
+func TestFeedHandler_RegisterFeed_UsageMetadata(t *testing.T) {
+	t.Run("copyright・ttl・robotsがあるときレスポンスに含めnoindexを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedService{
+			registerFeedFn: func(context.Context, string, string, model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
+				return &model.Feed{
+					ID:         "feed-id-1",
+					FeedURL:    "https://example.com/feed.xml",
+					Copyright:  "無断転載禁止",
+					TTLMinutes: 120,
+					Robots:     "noindex, noarchive",
+				}, &model.Subscription{ID: "sub-id-1"}, nil
+			},
+		}
+		h := NewFeedHandler(svc, &mockSubscriptionDeleter{})
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds", bytes.NewBufferString(`{"url": "https://example.com/feed.xml"}`))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.RegisterFeed(w, req)
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result["copyright"] != "無断転載禁止" {
+			t.Errorf("copyright = %v, want %q", result["copyright"], "無断転載禁止")
+		}
+		if result["ttl_minutes"] != float64(120) {
+			t.Errorf("ttl_minutes = %v, want 120", result["ttl_minutes"])
+		}
+		if result["robots"] != "noindex, noarchive" || result["noindex"] != true {
+			t.Errorf("robots/noindex = %v/%v, want %q/true", result["robots"], result["noindex"], "noindex, noarchive")
+		}
+	})
+
+	t.Run("利用条件の指定がないときttl_minutesはnullでnoindexはfalseを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedService{
+			registerFeedFn: func(context.Context, string, string, model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
+				return &model.Feed{ID: "feed-id-1", FeedURL: "https://example.com/feed.xml"}, &model.Subscription{ID: "sub-id-1"}, nil
+			},
+		}
+		h := NewFeedHandler(svc, &mockSubscriptionDeleter{})
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds", bytes.NewBufferString(`{"url": "https://example.com/feed.xml"}`))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.RegisterFeed(w, req)
+
+		// Assert
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if v, ok := result["ttl_minutes"]; !ok || v != nil {
+			t.Errorf("ttl_minutes = %v (present=%v), want null", v, ok)
+		}
+		if result["copyright"] != "" || result["noindex"] != false {
+			t.Errorf("copyright/noindex = %v/%v, want empty/false", result["copyright"], result["noindex"])
+		}
+	})
+}
+
+// TestFeedHandler_RegisterFeed_Trial はお試し購読の指定がサービスに渡り、期限がレスポンスに含まれることを検証する。
+func TestFeedHandler_RegisterFeed_Trial(t *testing.T) {
+	expiresAt := time.Date(2026, 6, 8, 9, 0, 0, 0, time.UTC)
+
+	t.Run("trialがtrueのときお試し購読として登録しsubscription_expires_atを返す", func(t *testing.T) {
+		// Arrange
+		var gotOpts model.RegisterFeedOptions
+		svc := &mockFeedService{
+			registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
+				gotOpts = opts
+				return &model.Feed{ID: "feed-id-1", FeedURL: inputURL}, &model.Subscription{ID: "sub-id-1", ExpiresAt: &expiresAt}, nil
+			},
+		}
+		h := NewFeedHandler(svc, &mockSubscriptionDeleter{})
+		body := `{"url": "https://example.com/feed.xml", "trial": true}`
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds", bytes.NewBufferString(body))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.RegisterFeed(w, req)
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		if !gotOpts.Trial {
+			t.Error("opts.Trial = false, want true")
+		}
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result["subscription_expires_at"] != "2026-06-08T09:00:00Z" {
+			t.Errorf("subscription_expires_at = %v, want %q", result["subscription_expires_at"], "2026-06-08T09:00:00Z")
+		}
+		if result["id"] != "feed-id-1" {
+			t.Errorf("id = %v, want %q", result["id"], "feed-id-1")
+		}
+	})
+
+	t.Run("trialを省略したとき通常の購読として登録しsubscription_expires_atを含めない", func(t *testing.T) {
+		// Arrange
+		var gotOpts model.RegisterFeedOptions
+		svc := &mockFeedService{
+			registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
+				gotOpts = opts
+				return &model.Feed{ID: "feed-id-1", FeedURL: inputURL}, &model.Subscription{ID: "sub-id-1"}, nil
+			},
+		}
+		h := NewFeedHandler(svc, &mockSubscriptionDeleter{})
+		body := `{"url": "https://example.com/feed.xml"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds", bytes.NewBufferString(body))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.RegisterFeed(w, req)
+
+		// Assert
+		if gotOpts.Trial {
+			t.Error("opts.Trial = true, want false")
+		}
+		if strings.Contains(w.Body.String(), "subscription_expires_at") {
+			t.Errorf("body should not contain subscription_expires_at: %s", w.Body.String())
+		}
+	})
+}
+
 func TestFeedHandler_RegisterFeed_EmptyURL_ReturnsBadRequest(t *testing.T) {
 	h := NewFeedHandler(&mockFeedService{}, &mockSubscriptionDeleter{})
 
@@ -186,7 +328,7 @@ func TestFeedHandler_RegisterFeed_InvalidJSON_ReturnsBadRequest(t *testing.T) {
 
 func TestFeedHandler_RegisterFeed_SubscriptionLimit_ReturnsConflict(t *testing.T) {
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			return nil, nil, model.NewSubscriptionLimitError()
 		},
 	}
@@ -214,7 +356,7 @@ func TestFeedHandler_RegisterFeed_SubscriptionLimit_ReturnsConflict(t *testing.T
 
 func TestFeedHandler_RegisterFeed_FeedNotDetected_ReturnsUnprocessableEntity(t *testing.T) {
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			return nil, nil, model.NewFeedNotDetectedError("https://example.com")
 		},
 	}
@@ -242,7 +384,7 @@ func TestFeedHandler_RegisterFeed_FeedNotDetected_ReturnsUnprocessableEntity(t *
 
 func TestFeedHandler_RegisterFeed_DuplicateSubscription_ReturnsConflict(t *testing.T) {
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			return nil, nil, model.NewDuplicateSubscriptionError()
 		},
 	}
@@ -265,7 +407,7 @@ func TestFeedHandler_RegisterFeed_DuplicateSubscription_ReturnsConflict(t *testi
 
 func TestFeedHandler_RegisterFeed_InternalError_ReturnsInternalServerError(t *testing.T) {
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			return nil, nil, errors.New("database connection failed")
 		},
 	}
@@ -314,11 +456,15 @@ func TestFeedHandler_GetFeed_Success(t *testing.T) {
 			if feedID != "feed-id-1" {
 				t.Errorf("feedID = %q, want %q", feedID, "feed-id-1")
 			}
+			lastPublishedAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
 			return &model.Feed{
-				ID:      "feed-id-1",
-				FeedURL: "https://example.com/feed.xml",
-				SiteURL: "https://example.com",
-				Title:   "Example Feed",
+				ID:              "feed-id-1",
+				FeedURL:         "https://example.com/feed.xml",
+				SiteURL:         "https://example.com",
+				Title:           "Example Feed",
+				Language:        "ja",
+				Description:     "Example のブログ",
+				LastPublishedAt: &lastPublishedAt,
 			}, nil
 		},
 	}
@@ -338,8 +484,8 @@ func TestFeedHandler_GetFeed_Success(t *testing.T) {
 	}
 
 	contentType := resp.Header.Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", contentType, "application/json; charset=utf-8")
 	}
 
 	var result map[string]interface{}
@@ -350,6 +496,12 @@ func TestFeedHandler_GetFeed_Success(t *testing.T) {
 	if result["id"] != "feed-id-1" {
 		t.Errorf("id = %v, want %q", result["id"], "feed-id-1")
 	}
+	if result["language"] != "ja" || result["description"] != "Example のブログ" {
+		t.Errorf("language/description = %v/%v, want %q/%q", result["language"], result["description"], "ja", "Example のブログ")
+	}
+	if result["last_published_at"] != "2025-01-01T09:00:00Z" {
+		t.Errorf("last_published_at = %v, want %q", result["last_published_at"], "2025-01-01T09:00:00Z")
+	}
 }
 
 func TestFeedHandler_GetFeed_NotFound(t *testing.T) {
@@ -379,6 +531,144 @@ func TestFeedHandler_GetFeed_NotFound(t *testing.T) {
 	}
 }
 
+func TestFeedHandler_GetFeedHealth(t *testing.T) {
+	t.Run("TLS失敗のフィードのときエラー詳細を返す", func(t *testing.T) {
+		// Arrange
+		notAfter := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+		svc := &mockFeedService{
+			getFeedFn: func(ctx context.Context, userID, feedID string) (*model.Feed, error) {
+				return &model.Feed{
+					ID:                feedID,
+					FetchStatus:       model.FetchStatusActive,
+					ConsecutiveErrors: 3,
+					ErrorKind:         model.FetchErrorKindTLS,
+					ErrorMessage:      "HTTPリクエスト失敗: x509: certificate has expired",
+					ErrorDetail: &model.FetchErrorDetail{
+						TLSError:     model.TLSErrorCertExpired,
+						CertSubject:  "feed.example.com",
+						CertNotAfter: &notAfter,
+					},
+					HTTPVersion: "HTTP/2.0",
+					NextFetchAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+				}, nil
+			},
+		}
+		h := NewFeedHandler(svc, &mockSubscriptionDeleter{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-id-1/health", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "feed-id-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetFeedHealth(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var result struct {
+			FeedID            string  `json:"feed_id"`
+			ConsecutiveErrors int     `json:"consecutive_errors"`
+			ErrorKind         *string `json:"error_kind"`
+			HTTPVersion       *string `json:"http_version"`
+			ErrorDetail       *struct {
+				HTTPStatus   *int    `json:"http_status"`
+				TLSError     *string `json:"tls_error"`
+				CertSubject  *string `json:"cert_subject"`
+				CertNotAfter *string `json:"cert_not_after"`
+			} `json:"error_detail"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result.FeedID != "feed-id-1" || result.ConsecutiveErrors != 3 {
+			t.Errorf("feed_id/consecutive_errors = %q/%d, want feed-id-1/3", result.FeedID, result.ConsecutiveErrors)
+		}
+		if result.ErrorKind == nil || *result.ErrorKind != "tls" {
+			t.Errorf("error_kind = %v, want tls", result.ErrorKind)
+		}
+		if result.HTTPVersion == nil || *result.HTTPVersion != "HTTP/2.0" {
+			t.Errorf("http_version = %v, want HTTP/2.0", result.HTTPVersion)
+		}
+		d := result.ErrorDetail
+		if d == nil {
+			t.Fatal("error_detail = null, want detail")
+		}
+		if d.TLSError == nil || *d.TLSError != "certificate_expired" {
+			t.Errorf("tls_error = %v, want certificate_expired", d.TLSError)
+		}
+		if d.CertNotAfter == nil || *d.CertNotAfter != "2026-01-31T23:59:59Z" {
+			t.Errorf("cert_not_after = %v, want 2026-01-31T23:59:59Z", d.CertNotAfter)
+		}
+		if d.HTTPStatus != nil {
+			t.Errorf("http_status = %v, want null", *d.HTTPStatus)
+		}
+	})
+
+	t.Run("エラーのないフィードのときエラー項目をnullで返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedService{
+			getFeedFn: func(ctx context.Context, userID, feedID string) (*model.Feed, error) {
+				return &model.Feed{ID: feedID, FetchStatus: model.FetchStatusActive}, nil
+			},
+		}
+		h := NewFeedHandler(svc, &mockSubscriptionDeleter{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-id-1/health", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "feed-id-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetFeedHealth(w, req)
+
+		// Assert
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		for _, key := range []string{"error_kind", "error_message", "error_detail", "http_version", "last_successful_fetch_at"} {
+			if v, ok := result[key]; !ok || v != nil {
+				t.Errorf("%s = %v (present=%v), want null", key, v, ok)
+			}
+		}
+	})
+
+	t.Run("購読していないフィードのとき404を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedHandler(&mockFeedService{}, &mockSubscriptionDeleter{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/other/health", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "other")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetFeedHealth(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if errResp := parseAPIErrorResponse(t, w); errResp["code"] != model.ErrCodeFeedNotFound {
+			t.Errorf("code = %q, want %q", errResp["code"], model.ErrCodeFeedNotFound)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedHandler(&mockFeedService{}, &mockSubscriptionDeleter{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-id-1/health", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetFeedHealth(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
 func TestFeedHandler_GetFeed_ServiceError_ReturnsInternalServerError(t *testing.T) {
 	svc := &mockFeedService{
 		getFeedFn: func(ctx context.Context, userID, feedID string) (*model.Feed, error) {
@@ -727,7 +1017,7 @@ func TestFeedHandler_DeleteFeed_InternalError_ReturnsInternalServerError(t *test
 
 func TestFeedHandler_ErrorResponse_ContainsAllFields(t *testing.T) {
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			return nil, nil, model.NewSubscriptionLimitError()
 		},
 	}
@@ -775,24 +1065,24 @@ func TestFeedHandler_ErrorResponse_ExactJSONBody(t *testing.T) {
 	if resp.StatusCode != http.StatusUnauthorized {
 		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
 	}
-	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json; charset=utf-8")
 	}
 
-	// json.Encoder は末尾に改行を付与する。フィールド順序は struct 定義順（code/message/category/action）。
-	wantBody := `{"code":"UNAUTHORIZED","message":"認証が必要です。","category":"auth","action":"ログインしてください。"}` + "\n"
+	// json.Encoder は末尾に改行を付与する。フィールド順序は struct 定義順（code/message/category/action/docs_url）。
+	wantBody := `{"code":"UNAUTHORIZED","message":"認証が必要です。","category":"auth","action":"ログインしてください。","docs_url":"https://github.com/hitoshiichikawa/feedman/blob/main/docs/errors.md#unauthorized"}` + "\n"
 	if gotBody := w.Body.String(); gotBody != wantBody {
 		t.Errorf("body = %q, want %q", gotBody, wantBody)
 	}
 }
 
-// TestFeedHandler_handleServiceError_InternalError_ExactJSONBody は API エラーでない
-// 内部エラーを handleServiceError が処理したとき、500 INTERNAL_ERROR の JSON ボディが
+// TestFeedHandler_WriteError_InternalError_ExactJSONBody は API エラーでない
+// 内部エラーを WriteError が処理したとき、500 INTERNAL_ERROR の JSON ボディが
 // 固定値と完全一致することを検証する（issue #26 の差分等価担保）。
-func TestFeedHandler_handleServiceError_InternalError_ExactJSONBody(t *testing.T) {
+func TestFeedHandler_WriteError_InternalError_ExactJSONBody(t *testing.T) {
 	// Arrange: サービス層が APIError でない素のエラーを返す。
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			return nil, nil, errors.New("database connection failed")
 		},
 	}
@@ -812,11 +1102,11 @@ func TestFeedHandler_handleServiceError_InternalError_ExactJSONBody(t *testing.T
 	if resp.StatusCode != http.StatusInternalServerError {
 		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
 	}
-	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json; charset=utf-8")
 	}
 
-	wantBody := `{"code":"INTERNAL_ERROR","message":"内部エラーが発生しました。","category":"system","action":"しばらく待ってから再度お試しください。"}` + "\n"
+	wantBody := `{"code":"INTERNAL_ERROR","message":"内部エラーが発生しました。","category":"system","action":"しばらく待ってから再度お試しください。","docs_url":"https://github.com/hitoshiichikawa/feedman/blob/main/docs/errors.md#internal_error"}` + "\n"
 	if gotBody := w.Body.String(); gotBody != wantBody {
 		t.Errorf("body = %q, want %q", gotBody, wantBody)
 	}
@@ -826,7 +1116,7 @@ func TestFeedHandler_handleServiceError_InternalError_ExactJSONBody(t *testing.T
 
 func TestSetupFeedRoutes_RegisterEndpoint(t *testing.T) {
 	svc := &mockFeedService{
-		registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+		registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 			return &model.Feed{
 				ID:      "feed-1",
 				FeedURL: "https://example.com/feed.xml",
@@ -928,83 +1218,6 @@ func TestSetupFeedRoutes_DeleteEndpoint(t *testing.T) {
 	}
 }
 
-// --- mapAPIErrorToHTTPStatus（エラーコード→HTTPステータスマッピング）のテスト ---
-
-// TestMapAPIErrorToHTTPStatus_KnownCodes は既知のエラーコードがそれぞれ対応する
-// HTTPステータスへマッピングされる正常系を検証する（要件 1.2）。
-func TestMapAPIErrorToHTTPStatus_KnownCodes(t *testing.T) {
-	tests := []struct {
-		name       string
-		code       string
-		wantStatus int
-	}{
-		{"FEED_NOT_DETECTED のとき 422", model.ErrCodeFeedNotDetected, http.StatusUnprocessableEntity},
-		{"INVALID_URL のとき 400", model.ErrCodeInvalidURL, http.StatusBadRequest},
-		{"SSRF_BLOCKED のとき 403", model.ErrCodeSSRFBlocked, http.StatusForbidden},
-		{"FETCH_FAILED のとき 502", model.ErrCodeFetchFailed, http.StatusBadGateway},
-		{"PARSE_FAILED のとき 422", model.ErrCodeParseFailed, http.StatusUnprocessableEntity},
-		{"SUBSCRIPTION_LIMIT のとき 409", model.ErrCodeSubscriptionLimit, http.StatusConflict},
-		{"DUPLICATE_SUBSCRIPTION のとき 409", "DUPLICATE_SUBSCRIPTION", http.StatusConflict},
-		{"FEED_NOT_FOUND のとき 404", "FEED_NOT_FOUND", http.StatusNotFound},
-		{"SUBSCRIPTION_NOT_FOUND のとき 404", model.ErrCodeSubscriptionNotFound, http.StatusNotFound},
-		{"ITEM_NOT_FOUND のとき 404", model.ErrCodeItemNotFound, http.StatusNotFound},
-		{"INVALID_FILTER のとき 400", model.ErrCodeInvalidFilter, http.StatusBadRequest},
-		{"INVALID_FETCH_INTERVAL のとき 400", model.ErrCodeInvalidFetchInterval, http.StatusBadRequest},
-		{"FEED_NOT_STOPPED のとき 409", model.ErrCodeFeedNotStopped, http.StatusConflict},
-		{"FEED_FETCH_IN_PROGRESS のとき 409", model.ErrCodeFeedFetchInProgress, http.StatusConflict},
-		{"FEED_COOLDOWN のとき 429", model.ErrCodeFeedCooldown, http.StatusTooManyRequests},
-		{"USER_NOT_FOUND のとき 404", model.ErrCodeUserNotFound, http.StatusNotFound},
-		{"INVALID_SEARCH_QUERY のとき 400", model.ErrCodeInvalidSearchQuery, http.StatusBadRequest},
-		{"FEED_NOT_SUBSCRIBED のとき 403", model.ErrCodeFeedNotSubscribed, http.StatusForbidden},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Arrange
-			apiErr := &model.APIError{Code: tt.code}
-
-			// Act
-			got := mapAPIErrorToHTTPStatus(apiErr)
-
-			// Assert
-			if got != tt.wantStatus {
-				t.Errorf("mapAPIErrorToHTTPStatus(code=%q) = %d, want %d", tt.code, got, tt.wantStatus)
-			}
-		})
-	}
-}
-
-// TestMapAPIErrorToHTTPStatus_UnknownCode_ReturnsInternalServerError は未マップの
-// エラーコードが default 分岐で HTTP 500（Internal Server Error）にフォールバックする
-// ことを検証する（要件 1.1）。
-func TestMapAPIErrorToHTTPStatus_UnknownCode_ReturnsInternalServerError(t *testing.T) {
-	// Arrange: マッピング表に存在しない未知のエラーコード。
-	apiErr := &model.APIError{Code: "SOME_UNMAPPED_ERROR_CODE"}
-
-	// Act
-	got := mapAPIErrorToHTTPStatus(apiErr)
-
-	// Assert
-	if got != http.StatusInternalServerError {
-		t.Errorf("未知コードの default 分岐 = %d, want %d", got, http.StatusInternalServerError)
-	}
-}
-
-// TestMapAPIErrorToHTTPStatus_EmptyCode_ReturnsInternalServerError は空のエラーコード
-// （境界値）も default 分岐で 500 にフォールバックすることを検証する（要件 1.1）。
-func TestMapAPIErrorToHTTPStatus_EmptyCode_ReturnsInternalServerError(t *testing.T) {
-	// Arrange: 空文字コードはどの case にも一致しない。
-	apiErr := &model.APIError{Code: ""}
-
-	// Act
-	got := mapAPIErrorToHTTPStatus(apiErr)
-
-	// Assert
-	if got != http.StatusInternalServerError {
-		t.Errorf("空コードの default 分岐 = %d, want %d", got, http.StatusInternalServerError)
-	}
-}
-
 func TestSetupFeedRoutes_UnknownRoute_Returns404Or405(t *testing.T) {
 	router := SetupFeedRoutes(&mockFeedService{}, &mockSubscriptionDeleter{}, nil)
 
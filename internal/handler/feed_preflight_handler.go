@@ -0,0 +1,94 @@
+// Package handler の feed_preflight_handler.go は、フィード URL を登録せずに検証するプリフライトの HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - POST /api/feeds/validate : URL の到達性・SSRF / ブロック対象か・フィードか HTML かを検証する（登録はしない）
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// FeedPreflightServiceInterface はフィード URL のプリフライトハンドラが必要とするサービスインターフェース。
+type FeedPreflightServiceInterface interface {
+	// ValidateFeedURL は URL を登録できる見込みかを検証する。
+	// 登録できない理由はエラーではなくレスポンスの error に設定する。
+	ValidateFeedURL(ctx context.Context, url string) (*feedPreflightResponse, error)
+}
+
+// FeedPreflightHandler はフィード URL のプリフライトの HTTP ハンドラ。
+type FeedPreflightHandler struct {
+	service FeedPreflightServiceInterface
+}
+
+// NewFeedPreflightHandler は FeedPreflightHandler を生成する。
+func NewFeedPreflightHandler(service FeedPreflightServiceInterface) *FeedPreflightHandler {
+	return &FeedPreflightHandler{service: service}
+}
+
+// feedPreflightRequest はフィード URL のプリフライトのリクエスト。
+type feedPreflightRequest struct {
+	URL string `json:"url"`
+}
+
+// feedPreflightResponse はフィード URL のプリフライトの結果。
+// source_type は URL の応答の種類（feed / html / other）で、取得できなかった場合は空。
+// feed_url / feed_type は valid が true の場合、error は valid が false の場合のみ設定する。
+type feedPreflightResponse struct {
+	URL        string                    `json:"url"`
+	Valid      bool                      `json:"valid"`
+	SourceType string                    `json:"source_type"`
+	FeedURL    string                    `json:"feed_url,omitempty"`
+	FeedType   string                    `json:"feed_type,omitempty"`
+	Error      *feedPreflightResultError `json:"error"`
+}
+
+// feedPreflightResultError は登録できない理由。code は登録 API が返すエラーコードと同じ。
+type feedPreflightResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidateFeedURL はフィード URL を登録せずに検証する。
+// POST /api/feeds/validate
+//
+// 登録（POST /api/feeds）と同じ検出・SSRF 検証・ブロックリストの照合を行い、登録できない場合も 200 で理由を返す。
+// 購読数の上限・購読済みかは検証しない。レート制限は登録 API と共有する。
+func (h *FeedPreflightHandler) ValidateFeedURL(w http.ResponseWriter, r *http.Request) {
+	if _, err := middleware.UserIDFromContext(r.Context()); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req feedPreflightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	if req.URL == "" {
+		WriteError(w, model.NewInvalidURLError("URLが空です"))
+		return
+	}
+
+	resp, err := h.service.ValidateFeedURL(r.Context(), req.URL)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockFeedPreflightService は FeedPreflightServiceInterface のモック実装。
+type mockFeedPreflightService struct {
+	validateFn func(ctx context.Context, url string) (*feedPreflightResponse, error)
+}
+
+func (m *mockFeedPreflightService) ValidateFeedURL(ctx context.Context, url string) (*feedPreflightResponse, error) {
+	return m.validateFn(ctx, url)
+}
+
+func TestFeedPreflightHandler_ValidateFeedURL(t *testing.T) {
+	t.Run("検証結果を200で返すとき", func(t *testing.T) {
+		// Arrange
+		var gotURL string
+		svc := &mockFeedPreflightService{
+			validateFn: func(_ context.Context, url string) (*feedPreflightResponse, error) {
+				gotURL = url
+				return &feedPreflightResponse{URL: url, Valid: true, SourceType: "html", FeedURL: "https://example.com/rss", FeedType: "rss"}, nil
+			},
+		}
+		h := NewFeedPreflightHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/validate", strings.NewReader(`{"url":"https://example.com/"}`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ValidateFeedURL(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotURL != "https://example.com/" {
+			t.Errorf("url = %q", gotURL)
+		}
+		var resp map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("レスポンスの解析に失敗: %v", err)
+		}
+		if resp["valid"] != true || resp["source_type"] != "html" || resp["feed_url"] != "https://example.com/rss" || resp["feed_type"] != "rss" {
+			t.Errorf("resp = %v", resp)
+		}
+		if v, ok := resp["error"]; !ok || v != nil {
+			t.Errorf("error = %v, want null", v)
+		}
+	})
+
+	t.Run("登録できないURLのとき理由のエラーコードを200で返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedPreflightService{
+			validateFn: func(_ context.Context, url string) (*feedPreflightResponse, error) {
+				return &feedPreflightResponse{
+					URL:   url,
+					Error: &feedPreflightResultError{Code: model.ErrCodeSSRFBlocked, Message: "blocked"},
+				}, nil
+			},
+		}
+		h := NewFeedPreflightHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/validate", strings.NewReader(`{"url":"http://10.0.0.1/"}`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ValidateFeedURL(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var resp struct {
+			Valid      bool    `json:"valid"`
+			SourceType string  `json:"source_type"`
+			FeedURL    *string `json:"feed_url"`
+			Error      *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("レスポンスの解析に失敗: %v", err)
+		}
+		if resp.Valid || resp.Error == nil || resp.Error.Code != model.ErrCodeSSRFBlocked || resp.FeedURL != nil {
+			t.Errorf("resp = %+v", resp)
+		}
+	})
+
+	t.Run("URLが空のとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedPreflightHandler(&mockFeedPreflightService{})
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/validate", strings.NewReader(`{"url":""}`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ValidateFeedURL(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w)["code"]; got != model.ErrCodeInvalidURL {
+			t.Errorf("code = %q, want %q", got, model.ErrCodeInvalidURL)
+		}
+	})
+
+	t.Run("ボディがJSONでないとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedPreflightHandler(&mockFeedPreflightService{})
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/validate", strings.NewReader(`{`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ValidateFeedURL(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("サービスが失敗したとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedPreflightService{
+			validateFn: func(context.Context, string) (*feedPreflightResponse, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		h := NewFeedPreflightHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/validate", strings.NewReader(`{"url":"https://example.com/"}`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ValidateFeedURL(w, req)
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedPreflightHandler(&mockFeedPreflightService{})
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/validate", strings.NewReader(`{"url":"https://example.com/"}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ValidateFeedURL(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// Package handler の feed_title_handler.go は、フィードタイトルの自動更新ポリシー
+// （always / initial / manual）と承認待ちタイトルの HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET  /api/feeds/{id}/title-policy   : タイトル・自動更新ポリシー・承認待ちタイトルの取得
+//   - PUT  /api/feeds/{id}/title-policy   : 自動更新ポリシーの更新
+//   - POST /api/feeds/{id}/title/approve  : 承認待ちタイトルをタイトルに反映
+//   - POST /api/feeds/{id}/title/dismiss  : 承認待ちタイトルを破棄
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// FeedTitleServiceInterface はフィードタイトル自動更新ポリシーのハンドラが必要とするサービスインターフェース。
+type FeedTitleServiceInterface interface {
+	// GetFeedTitleSetting は当該ユーザーが購読するフィードのタイトル設定を返す。
+	GetFeedTitleSetting(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error)
+	// UpdateTitleUpdatePolicy は自動更新ポリシーを更新し、更新後の設定を返す。
+	UpdateTitleUpdatePolicy(ctx context.Context, userID, feedID, policy string) (*feedTitleSettingResponse, error)
+	// ApprovePendingTitle は承認待ちタイトルを反映し、反映後の設定を返す。
+	ApprovePendingTitle(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error)
+	// DismissPendingTitle は承認待ちタイトルを破棄し、破棄後の設定を返す。
+	DismissPendingTitle(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error)
+}
+
+// FeedTitleHandler はフィードタイトル自動更新ポリシーの HTTP ハンドラ。
+type FeedTitleHandler struct {
+	service FeedTitleServiceInterface
+}
+
+// NewFeedTitleHandler は FeedTitleHandler を生成する。
+func NewFeedTitleHandler(service FeedTitleServiceInterface) *FeedTitleHandler {
+	return &FeedTitleHandler{service: service}
+}
+
+// feedTitleSettingResponse はフィードタイトル設定のAPIレスポンス。承認待ちタイトルがない場合の pending_title は null。
+type feedTitleSettingResponse struct {
+	Title        string  `json:"title"`
+	Policy       string  `json:"policy"`
+	PendingTitle *string `json:"pending_title"`
+}
+
+// feedTitlePolicyRequest は自動更新ポリシー更新リクエストのボディ。
+type feedTitlePolicyRequest struct {
+	Policy string `json:"policy"`
+}
+
+// GetFeedTitleSetting はフィードのタイトル設定を返す。
+// GET /api/feeds/{id}/title-policy
+func (h *FeedTitleHandler) GetFeedTitleSetting(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	setting, err := h.service.GetFeedTitleSetting(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// UpdateTitleUpdatePolicy はフィードタイトルの自動更新ポリシーを更新する。
+// PUT /api/feeds/{id}/title-policy
+//
+// フィードは購読者間で共有されるため、ポリシーは同じフィードの全購読者に反映される。
+func (h *FeedTitleHandler) UpdateTitleUpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req feedTitlePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.UpdateTitleUpdatePolicy(r.Context(), userID, chi.URLParam(r, "id"), req.Policy)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// ApprovePendingTitle は承認待ちタイトルをフィードのタイトルに反映する。
+// POST /api/feeds/{id}/title/approve
+func (h *FeedTitleHandler) ApprovePendingTitle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	setting, err := h.service.ApprovePendingTitle(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// DismissPendingTitle は承認待ちタイトルを破棄する。
+// POST /api/feeds/{id}/title/dismiss
+func (h *FeedTitleHandler) DismissPendingTitle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	setting, err := h.service.DismissPendingTitle(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// userID はコンテキストからユーザーIDを取り出す。未認証の場合は 401 を書き込み false を返す。
+func (h *FeedTitleHandler) userID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return "", false
+	}
+	return userID, true
+}
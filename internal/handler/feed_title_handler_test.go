@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockFeedTitleService は FeedTitleServiceInterface のモック実装。
+type mockFeedTitleService struct {
+	getFn       func(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error)
+	updateFn    func(ctx context.Context, userID, feedID, policy string) (*feedTitleSettingResponse, error)
+	approveFn   func(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error)
+	dismissFn   func(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error)
+	updateCalls int
+}
+
+func (m *mockFeedTitleService) GetFeedTitleSetting(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx, userID, feedID)
+	}
+	return &feedTitleSettingResponse{Title: "Blog", Policy: "always"}, nil
+}
+
+func (m *mockFeedTitleService) UpdateTitleUpdatePolicy(ctx context.Context, userID, feedID, policy string) (*feedTitleSettingResponse, error) {
+	m.updateCalls++
+	if m.updateFn != nil {
+		return m.updateFn(ctx, userID, feedID, policy)
+	}
+	return &feedTitleSettingResponse{Title: "Blog", Policy: policy}, nil
+}
+
+func (m *mockFeedTitleService) ApprovePendingTitle(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error) {
+	if m.approveFn != nil {
+		return m.approveFn(ctx, userID, feedID)
+	}
+	return &feedTitleSettingResponse{Title: "New Blog", Policy: "manual"}, nil
+}
+
+func (m *mockFeedTitleService) DismissPendingTitle(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error) {
+	if m.dismissFn != nil {
+		return m.dismissFn(ctx, userID, feedID)
+	}
+	return &feedTitleSettingResponse{Title: "Blog", Policy: "manual"}, nil
+}
+
+// --- GET /api/feeds/{id}/title-policy テスト ---
+
+func TestFeedTitleHandler_GetFeedTitleSetting(t *testing.T) {
+	t.Run("承認待ちタイトルがあるときpending_titleを含めて返す", func(t *testing.T) {
+		// Arrange
+		pending := "New Blog"
+		svc := &mockFeedTitleService{
+			getFn: func(_ context.Context, userID, feedID string) (*feedTitleSettingResponse, error) {
+				if userID != "user-1" || feedID != "feed-1" {
+					t.Errorf("args = (%q, %q), want (user-1, feed-1)", userID, feedID)
+				}
+				return &feedTitleSettingResponse{Title: "Blog", Policy: "manual", PendingTitle: &pending}, nil
+			},
+		}
+		h := NewFeedTitleHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetFeedTitleSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		want := `{"title":"Blog","policy":"manual","pending_title":"New Blog"}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("承認待ちタイトルがないときpending_titleをnullで返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedTitleHandler(&mockFeedTitleService{})
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetFeedTitleSetting(w, req)
+
+		// Assert
+		want := `{"title":"Blog","policy":"always","pending_title":null}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedTitleHandler(&mockFeedTitleService{})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetFeedTitleSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- PUT /api/feeds/{id}/title-policy テスト ---
+
+func TestFeedTitleHandler_UpdateTitleUpdatePolicy(t *testing.T) {
+	t.Run("ポリシーを指定したとき更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedTitleHandler(&mockFeedTitleService{})
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"policy":"initial"}`)), "user-1")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateTitleUpdatePolicy(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		want := `{"title":"Blog","policy":"initial","pending_title":null}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("不正なポリシーのとき400 INVALID_TITLE_UPDATE_POLICYを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedTitleService{
+			updateFn: func(_ context.Context, _, _, policy string) (*feedTitleSettingResponse, error) {
+				return nil, model.NewInvalidTitleUpdatePolicyError(policy)
+			},
+		}
+		h := NewFeedTitleHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"policy":"sometimes"}`)), "user-1")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateTitleUpdatePolicy(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodeInvalidTitleUpdatePolicy) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodeInvalidTitleUpdatePolicy)
+		}
+	})
+
+	t.Run("JSONが不正なとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedTitleService{}
+		h := NewFeedTitleHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{`)), "user-1")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateTitleUpdatePolicy(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("updateCalls = %d, want 0", svc.updateCalls)
+		}
+	})
+}
+
+// --- POST /api/feeds/{id}/title/approve, /dismiss テスト ---
+
+func TestFeedTitleHandler_ApprovePendingTitle(t *testing.T) {
+	t.Run("承認待ちタイトルがあるとき反映後の設定を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedTitleHandler(&mockFeedTitleService{})
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/", nil), "user-1"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApprovePendingTitle(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		want := `{"title":"New Blog","policy":"manual","pending_title":null}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("承認待ちタイトルがないとき404 PENDING_TITLE_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockFeedTitleService{
+			approveFn: func(context.Context, string, string) (*feedTitleSettingResponse, error) {
+				return nil, model.NewPendingTitleNotFoundError()
+			},
+		}
+		h := NewFeedTitleHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/", nil), "user-1"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApprovePendingTitle(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodePendingTitleNotFound) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodePendingTitleNotFound)
+		}
+	})
+}
+
+func TestFeedTitleHandler_DismissPendingTitle(t *testing.T) {
+	t.Run("破棄したとき現在のタイトルを返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedTitleHandler(&mockFeedTitleService{})
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/", nil), "user-1"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DismissPendingTitle(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		want := `{"title":"Blog","policy":"manual","pending_title":null}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewFeedTitleHandler(&mockFeedTitleService{})
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DismissPendingTitle(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+// Package handler の highlight_handler.go は、デイリー / ウィークリーのハイライトの HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/highlights?period=week : 購読フィードからスコアの高い記事 10 件（period は day / week）
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// HighlightServiceInterface はハイライトハンドラが必要とするサービスインターフェース。
+type HighlightServiceInterface interface {
+	// ListHighlights は period（day / week、空なら week）のハイライトを返す。
+	// period が不正な場合は INVALID_HIGHLIGHT_PERIOD を返す。
+	ListHighlights(ctx context.Context, userID, period string) (*highlightsResponse, error)
+}
+
+// HighlightHandler はハイライトの HTTP ハンドラ。
+type HighlightHandler struct {
+	service HighlightServiceInterface
+}
+
+// NewHighlightHandler は HighlightHandler を生成する。
+func NewHighlightHandler(service HighlightServiceInterface) *HighlightHandler {
+	return &HighlightHandler{service: service}
+}
+
+// highlightItemResponse はハイライトの記事 1 件。
+type highlightItemResponse struct {
+	ID          string     `json:"id"`
+	FeedID      string     `json:"feed_id"`
+	FeedTitle   string     `json:"feed_title"`
+	Title       string     `json:"title"`
+	Link        string     `json:"link"`
+	PublishedAt *time.Time `json:"published_at"`
+	Score       float64    `json:"score"`
+	HatebuCount int        `json:"hatebu_count"`
+	StarCount   int        `json:"star_count"`
+	ViewCount   int        `json:"view_count"`
+	IsRead      bool       `json:"is_read"`
+	IsStarred   bool       `json:"is_starred"`
+}
+
+// highlightsResponse は GET /api/highlights のレスポンス。
+// computed_at はスコアを計算した日時で、ハイライトが 0 件の場合は null。
+type highlightsResponse struct {
+	Period     string                  `json:"period"`
+	ComputedAt *time.Time              `json:"computed_at"`
+	Items      []highlightItemResponse `json:"items"`
+}
+
+// ListHighlights はデイリー / ウィークリーのハイライトを返す。
+// GET /api/highlights?period=week
+//
+// スコアは worker が定期的に事前計算した値を用いるため、直近の反応は次回の計算まで反映されない。
+// period が不正な場合は 400 INVALID_HIGHLIGHT_PERIOD を返す。
+func (h *HighlightHandler) ListHighlights(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.ListHighlights(r.Context(), userID, r.URL.Query().Get("period"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockHighlightService は HighlightServiceInterface のモック実装。
+type mockHighlightService struct {
+	listFn func(ctx context.Context, userID, period string) (*highlightsResponse, error)
+}
+
+func (m *mockHighlightService) ListHighlights(ctx context.Context, userID, period string) (*highlightsResponse, error) {
+	return m.listFn(ctx, userID, period)
+}
+
+func TestHighlightHandler_ListHighlights(t *testing.T) {
+	t.Run("期間をサービスに渡しハイライトを200で返すとき", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotPeriod string
+		computedAt := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+		svc := &mockHighlightService{
+			listFn: func(_ context.Context, userID, period string) (*highlightsResponse, error) {
+				gotUserID, gotPeriod = userID, period
+				return &highlightsResponse{
+					Period:     "day",
+					ComputedAt: &computedAt,
+					Items: []highlightItemResponse{
+						{ID: "item-1", FeedID: "feed-1", Title: "記事", Score: 3.5, HatebuCount: 20, StarCount: 1},
+					},
+				}, nil
+			},
+		}
+		h := NewHighlightHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/highlights?period=day", nil)
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListHighlights(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotUserID != "user-1" || gotPeriod != "day" {
+			t.Errorf("userID = %q, period = %q", gotUserID, gotPeriod)
+		}
+		var resp struct {
+			Period     string  `json:"period"`
+			ComputedAt *string `json:"computed_at"`
+			Items      []struct {
+				ID          string  `json:"id"`
+				Score       float64 `json:"score"`
+				HatebuCount int     `json:"hatebu_count"`
+			} `json:"items"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("レスポンスの解析に失敗: %v", err)
+		}
+		if resp.Period != "day" || resp.ComputedAt == nil || len(resp.Items) != 1 {
+			t.Fatalf("resp = %+v", resp)
+		}
+		if resp.Items[0].ID != "item-1" || resp.Items[0].Score != 3.5 || resp.Items[0].HatebuCount != 20 {
+			t.Errorf("items[0] = %+v", resp.Items[0])
+		}
+	})
+
+	t.Run("期間の指定が不正なとき400を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockHighlightService{
+			listFn: func(_ context.Context, _, period string) (*highlightsResponse, error) {
+				return nil, model.NewInvalidHighlightPeriodError(period)
+			},
+		}
+		h := NewHighlightHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/highlights?period=month", nil)
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListHighlights(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w)["code"]; got != model.ErrCodeInvalidHighlightPeriod {
+			t.Errorf("code = %q, want %q", got, model.ErrCodeInvalidHighlightPeriod)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewHighlightHandler(&mockHighlightService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/highlights", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListHighlights(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
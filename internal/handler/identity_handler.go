@@ -0,0 +1,95 @@
+// Package handler の identity_handler.go は、アカウント連携（identity）の管理の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET    /api/users/me/identities      : 自分のアカウント連携（外部 IdP との紐付け）の一覧
+//   - DELETE /api/users/me/identities/{id} : アカウント連携の解除（その連携で発行したセッションも失効する）
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// IdentityServiceInterface はアカウント連携ハンドラが必要とするサービスインターフェース。
+type IdentityServiceInterface interface {
+	// ListIdentities はユーザーのアカウント連携を連携日時の昇順で返す。
+	ListIdentities(ctx context.Context, userID string) (*identityListResponse, error)
+	// UnlinkIdentity はユーザーのアカウント連携を 1 件解除し、その連携で発行したセッションを失効させる。
+	// 存在しない・他ユーザーの連携は IDENTITY_NOT_FOUND、最後の 1 つの連携は LAST_IDENTITY を返す。
+	UnlinkIdentity(ctx context.Context, userID, identityID string) error
+}
+
+// IdentityHandler はアカウント連携の HTTP ハンドラ。
+type IdentityHandler struct {
+	service IdentityServiceInterface
+}
+
+// NewIdentityHandler は IdentityHandler を生成する。
+func NewIdentityHandler(service IdentityServiceInterface) *IdentityHandler {
+	return &IdentityHandler{service: service}
+}
+
+// identityResponse はアカウント連携 1 件。プロバイダ側のユーザー ID は返さない。
+type identityResponse struct {
+	ID        string    `json:"id"`
+	Provider  string    `json:"provider"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// identityListResponse は GET /api/users/me/identities のレスポンス。
+type identityListResponse struct {
+	Identities []identityResponse `json:"identities"`
+}
+
+// ListIdentities は自分のアカウント連携の一覧を返す。
+// GET /api/users/me/identities
+func (h *IdentityHandler) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.ListIdentities(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// UnlinkIdentity は自分のアカウント連携を解除する。
+// DELETE /api/users/me/identities/{id}
+//
+// 解除した連携でのログインで発行したセッションは失効するため、その連携でログイン中の場合は
+// 以降のリクエストが 401 になる。最後の 1 つの連携は 409 LAST_IDENTITY で拒否する。
+func (h *IdentityHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	if err := h.service.UnlinkIdentity(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
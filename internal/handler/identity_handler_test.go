@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockIdentityService は IdentityServiceInterface のモック実装。
+type mockIdentityService struct {
+	listIdentitiesFn func(ctx context.Context, userID string) (*identityListResponse, error)
+	unlinkIdentityFn func(ctx context.Context, userID, identityID string) error
+}
+
+func (m *mockIdentityService) ListIdentities(ctx context.Context, userID string) (*identityListResponse, error) {
+	if m.listIdentitiesFn != nil {
+		return m.listIdentitiesFn(ctx, userID)
+	}
+	return &identityListResponse{Identities: []identityResponse{}}, nil
+}
+
+func (m *mockIdentityService) UnlinkIdentity(ctx context.Context, userID, identityID string) error {
+	if m.unlinkIdentityFn != nil {
+		return m.unlinkIdentityFn(ctx, userID, identityID)
+	}
+	return nil
+}
+
+// --- GET /api/users/me/identities テスト ---
+
+func TestIdentityHandler_ListIdentities(t *testing.T) {
+	t.Run("自分のアカウント連携の一覧を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockIdentityService{
+			listIdentitiesFn: func(_ context.Context, userID string) (*identityListResponse, error) {
+				if userID != "user-1" {
+					t.Errorf("userID = %q, want user-1", userID)
+				}
+				return &identityListResponse{Identities: []identityResponse{
+					{ID: "ident-1", Provider: "google", CreatedAt: time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)},
+					{ID: "ident-2", Provider: "github", CreatedAt: time.Date(2026, 7, 2, 9, 0, 0, 0, time.UTC)},
+				}}, nil
+			},
+		}
+		h := NewIdentityHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/identities", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListIdentities(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body struct {
+			Identities []map[string]interface{} `json:"identities"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Identities) != 2 {
+			t.Fatalf("identities = %v, want 2 elements", body.Identities)
+		}
+		first := body.Identities[0]
+		if first["id"] != "ident-1" || first["provider"] != "google" || first["created_at"] != "2026-07-01T09:00:00Z" {
+			t.Errorf("identities[0] = %v", first)
+		}
+	})
+
+	t.Run("ユーザーIDがないとき401を返す", func(t *testing.T) {
+		h := NewIdentityHandler(&mockIdentityService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/identities", nil)
+		w := httptest.NewRecorder()
+
+		h.ListIdentities(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- DELETE /api/users/me/identities/{id} テスト ---
+
+func TestIdentityHandler_UnlinkIdentity(t *testing.T) {
+	t.Run("連携を解除したとき204を返す", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotIdentityID string
+		svc := &mockIdentityService{
+			unlinkIdentityFn: func(_ context.Context, userID, identityID string) error {
+				gotUserID, gotIdentityID = userID, identityID
+				return nil
+			},
+		}
+		h := NewIdentityHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodDelete, "/api/users/me/identities/ident-2", nil), "user-1")
+		req = withChiURLParam(req, "id", "ident-2")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UnlinkIdentity(w, req)
+
+		// Assert
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if gotUserID != "user-1" || gotIdentityID != "ident-2" {
+			t.Errorf("args = (%q, %q), want (user-1, ident-2)", gotUserID, gotIdentityID)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "最後の連携のとき409 LAST_IDENTITYを返す", err: model.NewLastIdentityError(), wantStatus: http.StatusConflict, wantCode: model.ErrCodeLastIdentity},
+		{name: "存在しない連携のとき404 IDENTITY_NOT_FOUNDを返す", err: model.NewIdentityNotFoundError("ident-x"), wantStatus: http.StatusNotFound, wantCode: model.ErrCodeIdentityNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			svc := &mockIdentityService{
+				unlinkIdentityFn: func(context.Context, string, string) error { return tt.err },
+			}
+			h := NewIdentityHandler(svc)
+			req := withUserID(httptest.NewRequest(http.MethodDelete, "/api/users/me/identities/ident-x", nil), "user-1")
+			req = withChiURLParam(req, "id", "ident-x")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.UnlinkIdentity(w, req)
+
+			// Assert
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if resp := parseAPIErrorResponse(t, w); resp["code"] != tt.wantCode {
+				t.Errorf("code = %q, want %q", resp["code"], tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package handler の import_filter_handler.go は、購読単位の記事取り込みフィルタの
+// HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/subscriptions/{id}/import-filter : 取り込みフィルタの取得
+//   - PUT /api/subscriptions/{id}/import-filter : 取り込みフィルタの更新（空指定で解除）
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// ImportFilterServiceInterface は取り込みフィルタハンドラが必要とするサービスインターフェース。
+type ImportFilterServiceInterface interface {
+	// GetImportFilter は当該ユーザーの購読の取り込みフィルタを返す。
+	GetImportFilter(ctx context.Context, userID, subscriptionID string) (*importFilterResponse, error)
+	// UpdateImportFilter は当該ユーザーの購読の取り込みフィルタを更新し、正規化後の設定を返す。
+	UpdateImportFilter(ctx context.Context, userID, subscriptionID string, authors []string, titlePattern string) (*importFilterResponse, error)
+}
+
+// ImportFilterHandler は記事取り込みフィルタの HTTP ハンドラ。
+type ImportFilterHandler struct {
+	service ImportFilterServiceInterface
+}
+
+// NewImportFilterHandler は ImportFilterHandler を生成する。
+func NewImportFilterHandler(service ImportFilterServiceInterface) *ImportFilterHandler {
+	return &ImportFilterHandler{service: service}
+}
+
+// importFilterResponse は取り込みフィルタのAPIレスポンス。
+type importFilterResponse struct {
+	Authors      []string `json:"authors"`
+	TitlePattern string   `json:"title_pattern"`
+}
+
+// importFilterRequest は取り込みフィルタ更新リクエストのボディ。
+type importFilterRequest struct {
+	Authors      []string `json:"authors"`
+	TitlePattern string   `json:"title_pattern"`
+}
+
+// GetImportFilter は購読の取り込みフィルタを返す。
+// GET /api/subscriptions/{id}/import-filter
+func (h *ImportFilterHandler) GetImportFilter(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	filter, err := h.service.GetImportFilter(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, filter)
+}
+
+// UpdateImportFilter は購読の取り込みフィルタを更新する。
+// PUT /api/subscriptions/{id}/import-filter
+//
+// 変更は以降のフェッチから適用され、保存済みの記事は削除しない。
+func (h *ImportFilterHandler) UpdateImportFilter(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req importFilterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	filter, err := h.service.UpdateImportFilter(r.Context(), userID, chi.URLParam(r, "id"), req.Authors, req.TitlePattern)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, filter)
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockImportFilterService は ImportFilterServiceInterface のモック実装。
+type mockImportFilterService struct {
+	getFn       func(ctx context.Context, userID, subscriptionID string) (*importFilterResponse, error)
+	updateFn    func(ctx context.Context, userID, subscriptionID string, authors []string, titlePattern string) (*importFilterResponse, error)
+	updateCalls int
+}
+
+func (m *mockImportFilterService) GetImportFilter(ctx context.Context, userID, subscriptionID string) (*importFilterResponse, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx, userID, subscriptionID)
+	}
+	return &importFilterResponse{Authors: []string{}}, nil
+}
+
+func (m *mockImportFilterService) UpdateImportFilter(ctx context.Context, userID, subscriptionID string, authors []string, titlePattern string) (*importFilterResponse, error) {
+	m.updateCalls++
+	if m.updateFn != nil {
+		return m.updateFn(ctx, userID, subscriptionID, authors, titlePattern)
+	}
+	return &importFilterResponse{Authors: authors, TitlePattern: titlePattern}, nil
+}
+
+// --- GET /api/subscriptions/{id}/import-filter テスト ---
+
+func TestImportFilterHandler_GetImportFilter(t *testing.T) {
+	t.Run("設定済みのとき著者とタイトル正規表現を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockImportFilterService{
+			getFn: func(_ context.Context, userID, subscriptionID string) (*importFilterResponse, error) {
+				if userID != "user-1" || subscriptionID != "sub-1" {
+					t.Errorf("args = (%q, %q), want (user-1, sub-1)", userID, subscriptionID)
+				}
+				return &importFilterResponse{Authors: []string{"Alice"}, TitlePattern: "^Go"}, nil
+			},
+		}
+		h := NewImportFilterHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-1/import-filter", nil), "user-1")
+		req = withChiURLParam(req, "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetImportFilter(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body importFilterResponse
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Authors) != 1 || body.Authors[0] != "Alice" || body.TitlePattern != "^Go" {
+			t.Errorf("body = %+v", body)
+		}
+	})
+
+	t.Run("購読が見つからないとき404 SUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockImportFilterService{
+			getFn: func(_ context.Context, _, subscriptionID string) (*importFilterResponse, error) {
+				return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		h := NewImportFilterHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1"), "id", "sub-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetImportFilter(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+// --- PUT /api/subscriptions/{id}/import-filter テスト ---
+
+func TestImportFilterHandler_UpdateImportFilter(t *testing.T) {
+	t.Run("正常なリクエストのとき更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockImportFilterService{
+			updateFn: func(_ context.Context, _, _ string, authors []string, titlePattern string) (*importFilterResponse, error) {
+				if len(authors) != 2 || titlePattern != "Go" {
+					t.Errorf("args = (%v, %q), want ([Alice Bob], Go)", authors, titlePattern)
+				}
+				return &importFilterResponse{Authors: authors, TitlePattern: titlePattern}, nil
+			},
+		}
+		h := NewImportFilterHandler(svc)
+		body := `{"authors":["Alice","Bob"],"title_pattern":"Go"}`
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body)), "user-1")
+		req = withChiURLParam(req, "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateImportFilter(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.updateCalls != 1 {
+			t.Errorf("UpdateImportFilter calls = %d, want 1", svc.updateCalls)
+		}
+	})
+
+	t.Run("不正なJSONのとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockImportFilterService{}
+		h := NewImportFilterHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{`)), "user-1"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateImportFilter(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("UpdateImportFilter calls = %d, want 0", svc.updateCalls)
+		}
+	})
+
+	t.Run("フィルタが不正なとき400 INVALID_IMPORT_FILTERを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockImportFilterService{
+			updateFn: func(_ context.Context, _, _ string, _ []string, _ string) (*importFilterResponse, error) {
+				return nil, model.NewInvalidImportFilterError("タイトルの正規表現が不正です")
+			},
+		}
+		h := NewImportFilterHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"title_pattern":"("}`)), "user-1"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateImportFilter(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidImportFilter {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidImportFilter)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_ImportFilterRoutes は取り込みフィルタのルートが ImportFilterService 配線時のみ登録されることを検証する。
+func TestNewRouter_ImportFilterRoutes(t *testing.T) {
+	newRouter := func(svc ImportFilterServiceInterface) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+		}
+		if svc != nil {
+			deps.ImportFilterService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-1/import-filter", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("ImportFilterService を配線したとき200を返す", func(t *testing.T) {
+		// Act
+		w := doRequest(newRouter(&mockImportFilterService{}))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("ImportFilterService が nil のときルートを登録しない", func(t *testing.T) {
+		// Act
+		w := doRequest(newRouter(nil))
+
+		// Assert
+		if w.Code != http.StatusNotFound && w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("status = %d, want 404 or 405", w.Code)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+// Package handler の integration_handler.go は、購読フィードの新着記事を Slack / Discord に転送する
+// 連携設定の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET    /api/integrations      : 連携設定の一覧
+//   - POST   /api/integrations      : 連携設定の作成（対象購読・宛先 Webhook URL・メッセージテンプレート）
+//   - GET    /api/integrations/{id} : 連携設定の取得
+//   - PUT    /api/integrations/{id} : 連携設定の更新（webhook_url を省略すると現在の URL を維持）
+//   - DELETE /api/integrations/{id} : 連携設定の削除（未配送の記事も破棄）
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// IntegrationServiceInterface は連携設定ハンドラが必要とするサービスインターフェース。
+// 存在しない・他ユーザーの連携設定への操作は INTEGRATION_NOT_FOUND を返す。
+type IntegrationServiceInterface interface {
+	ListIntegrations(ctx context.Context, userID string) ([]integrationResponse, error)
+	GetIntegration(ctx context.Context, userID, integrationID string) (*integrationResponse, error)
+	CreateIntegration(ctx context.Context, userID string, req integrationRequest) (*integrationResponse, error)
+	UpdateIntegration(ctx context.Context, userID, integrationID string, req integrationRequest) (*integrationResponse, error)
+	DeleteIntegration(ctx context.Context, userID, integrationID string) error
+}
+
+// IntegrationHandler は連携設定の HTTP ハンドラ。
+type IntegrationHandler struct {
+	service IntegrationServiceInterface
+}
+
+// NewIntegrationHandler は IntegrationHandler を生成する。
+func NewIntegrationHandler(service IntegrationServiceInterface) *IntegrationHandler {
+	return &IntegrationHandler{service: service}
+}
+
+// integrationResponse は連携設定 1 件。
+// webhook_url は秘密部分を伏せて返す（Webhook URL を知っていれば誰でも投稿できるため）。
+type integrationResponse struct {
+	ID              string     `json:"id"`
+	SubscriptionID  string     `json:"subscription_id"`
+	FeedTitle       string     `json:"feed_title"`
+	Kind            string     `json:"kind"`
+	WebhookURL      string     `json:"webhook_url"`
+	MessageTemplate string     `json:"message_template"`
+	Enabled         bool       `json:"enabled"`
+	LastError       *string    `json:"last_error"`
+	LastDeliveredAt *time.Time `json:"last_delivered_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// integrationListResponse は GET /api/integrations のレスポンス。
+type integrationListResponse struct {
+	Integrations []integrationResponse `json:"integrations"`
+}
+
+// integrationRequest は POST / PUT /api/integrations のリクエストボディ。
+// subscription_id は作成時のみ参照する。enabled の省略時は true とする。
+type integrationRequest struct {
+	SubscriptionID  string `json:"subscription_id"`
+	Kind            string `json:"kind"`
+	WebhookURL      string `json:"webhook_url"`
+	MessageTemplate string `json:"message_template"`
+	Enabled         *bool  `json:"enabled"`
+}
+
+// ListIntegrations は自分の連携設定の一覧を返す。
+// GET /api/integrations
+func (h *IntegrationHandler) ListIntegrations(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	integrations, err := h.service.ListIntegrations(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if integrations == nil {
+		integrations = []integrationResponse{}
+	}
+
+	WriteJSON(w, http.StatusOK, integrationListResponse{Integrations: integrations})
+}
+
+// CreateIntegration は自分の購読に連携設定を作成する。
+// POST /api/integrations
+func (h *IntegrationHandler) CreateIntegration(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req integrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.SubscriptionID == "" {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "subscription_id を含む正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	// 種類・Webhook URL・テンプレートの検証はサービス層に集約済み。
+	integration, err := h.service.CreateIntegration(r.Context(), userID, req)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, integration)
+}
+
+// GetIntegration は自分の連携設定を返す。
+// GET /api/integrations/{id}
+func (h *IntegrationHandler) GetIntegration(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	integration, err := h.service.GetIntegration(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, integration)
+}
+
+// UpdateIntegration は自分の連携設定を更新する。
+// PUT /api/integrations/{id}
+func (h *IntegrationHandler) UpdateIntegration(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req integrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	integration, err := h.service.UpdateIntegration(r.Context(), userID, chi.URLParam(r, "id"), req)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, integration)
+}
+
+// DeleteIntegration は自分の連携設定を削除する。
+// DELETE /api/integrations/{id}
+func (h *IntegrationHandler) DeleteIntegration(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	if err := h.service.DeleteIntegration(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
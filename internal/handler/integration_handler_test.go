@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockIntegrationService は IntegrationServiceInterface のモック実装。
+type mockIntegrationService struct {
+	listFn      func(ctx context.Context, userID string) ([]integrationResponse, error)
+	createFn    func(ctx context.Context, userID string, req integrationRequest) (*integrationResponse, error)
+	deleteFn    func(ctx context.Context, userID, integrationID string) error
+	createCalls int
+}
+
+func (m *mockIntegrationService) ListIntegrations(ctx context.Context, userID string) ([]integrationResponse, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockIntegrationService) GetIntegration(_ context.Context, _, integrationID string) (*integrationResponse, error) {
+	return &integrationResponse{ID: integrationID, Kind: "slack"}, nil
+}
+
+func (m *mockIntegrationService) CreateIntegration(ctx context.Context, userID string, req integrationRequest) (*integrationResponse, error) {
+	m.createCalls++
+	if m.createFn != nil {
+		return m.createFn(ctx, userID, req)
+	}
+	return &integrationResponse{ID: "int-1", SubscriptionID: req.SubscriptionID, Kind: req.Kind}, nil
+}
+
+func (m *mockIntegrationService) UpdateIntegration(_ context.Context, _, integrationID string, req integrationRequest) (*integrationResponse, error) {
+	return &integrationResponse{ID: integrationID, Kind: req.Kind}, nil
+}
+
+func (m *mockIntegrationService) DeleteIntegration(ctx context.Context, userID, integrationID string) error {
+	if m.deleteFn != nil {
+		return m.deleteFn(ctx, userID, integrationID)
+	}
+	return nil
+}
+
+// --- GET /api/integrations テスト ---
+
+func TestIntegrationHandler_ListIntegrations(t *testing.T) {
+	t.Run("連携設定がないとき空配列を返す", func(t *testing.T) {
+		// Arrange
+		h := NewIntegrationHandler(&mockIntegrationService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListIntegrations(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got, want := strings.TrimSpace(w.Body.String()), `{"integrations":[]}`; got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewIntegrationHandler(&mockIntegrationService{})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListIntegrations(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- POST /api/integrations テスト ---
+
+func TestIntegrationHandler_CreateIntegration(t *testing.T) {
+	t.Run("正しいリクエストのとき201で作成した連携設定を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockIntegrationService{}
+		h := NewIntegrationHandler(svc)
+		body := `{"subscription_id":"sub-1","kind":"discord","webhook_url":"https://discord.com/api/webhooks/1/x"}`
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.CreateIntegration(w, req)
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		var got integrationResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.SubscriptionID != "sub-1" || got.Kind != "discord" {
+			t.Errorf("got = %+v, want subscription_id=sub-1 kind=discord", got)
+		}
+	})
+
+	t.Run("subscription_idがないとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockIntegrationService{}
+		h := NewIntegrationHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"kind":"slack"}`)), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.CreateIntegration(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodeInvalidRequest) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodeInvalidRequest)
+		}
+		if svc.createCalls != 0 {
+			t.Errorf("createCalls = %d, want 0", svc.createCalls)
+		}
+	})
+
+	t.Run("不正な連携設定のとき400 INVALID_INTEGRATIONを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockIntegrationService{
+			createFn: func(_ context.Context, _ string, _ integrationRequest) (*integrationResponse, error) {
+				return nil, model.NewInvalidIntegrationError("webhook_url は https の Webhook URL を指定してください")
+			},
+		}
+		h := NewIntegrationHandler(svc)
+		body := `{"subscription_id":"sub-1","kind":"slack","webhook_url":"https://example.com/"}`
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.CreateIntegration(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodeInvalidIntegration) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodeInvalidIntegration)
+		}
+	})
+}
+
+// --- DELETE /api/integrations/{id} テスト ---
+
+func TestIntegrationHandler_DeleteIntegration(t *testing.T) {
+	t.Run("削除できたとき204を返す", func(t *testing.T) {
+		// Arrange
+		var gotID string
+		svc := &mockIntegrationService{
+			deleteFn: func(_ context.Context, _, integrationID string) error {
+				gotID = integrationID
+				return nil
+			},
+		}
+		h := NewIntegrationHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodDelete, "/", nil), "user-1"), "id", "int-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DeleteIntegration(w, req)
+
+		// Assert
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if gotID != "int-1" {
+			t.Errorf("integrationID = %q, want int-1", gotID)
+		}
+	})
+
+	t.Run("存在しないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockIntegrationService{
+			deleteFn: func(_ context.Context, _, integrationID string) error {
+				return model.NewIntegrationNotFoundError(integrationID)
+			},
+		}
+		h := NewIntegrationHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodDelete, "/", nil), "user-1"), "id", "int-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.DeleteIntegration(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -96,7 +96,7 @@ func createIntegrationRouter(state *integrationState) http.Handler {
 		},
 		AuthConfig: AuthHandlerConfig{BaseURL: "http://localhost:3000", SessionMaxAge: 86400},
 		FeedService: &mockFeedService{
-			registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+			registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 				f := &model.Feed{
 					ID:          "feed-integration-1",
 					FeedURL:     inputURL,
@@ -147,7 +147,7 @@ func createIntegrationRouter(state *integrationState) http.Handler {
 			},
 		},
 		ItemService: &mockItemService{
-			listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+			listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 				return &itemListResult{
 					Items: []itemSummaryResponse{
 						{
@@ -176,7 +176,7 @@ func createIntegrationRouter(state *integrationState) http.Handler {
 			// 到達すること、および user_id によるフィルタが期待通り行われることを
 			// 統合的に検証できる。state.items が空の既存テストでは空一覧が返るため、
 			// 既存テストの挙動は変化しない（後方互換）。
-			listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error) {
+			listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
 				state.lastStarredUserID = userID
 				state.lastStarredCursor = cursor
 				state.lastStarredLimit = limit
@@ -275,13 +275,13 @@ func createIntegrationRouter(state *integrationState) http.Handler {
 
 				return &starredItemListResult{
 					Items:      items,
-					NextCursor: nextCursor,
+					NextCursor: nullableString(nextCursor),
 					HasMore:    hasMore,
 				}, nil
 			},
 		},
 		ItemStateService: &mockItemStateService{
-			updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+			updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 				key := userID + ":" + itemID
 				is := &model.ItemState{UserID: userID, ItemID: itemID}
 				if isRead != nil {
@@ -855,8 +855,8 @@ func TestIntegration_ListStarredItems_OnlyOwnStarredItems(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
-	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json; charset=utf-8")
 	}
 
 	var body map[string]interface{}
@@ -1085,8 +1085,7 @@ func TestIntegration_ListStarredItems_Unauthorized_Returns401(t *testing.T) {
 	}
 
 	// 応答ボディに items を含めない（Requirement 4.6）。session middleware の 401 は
-	// plain text "unauthorized" を返す実装のため、ここでは JSON パースを期待せず、
-	// 応答に "items" 文字列が含まれないことで担保する。
+	// 統一エラーフォーマットのため、応答に "items" 文字列が含まれないことで担保する。
 	bodyBytes := w.Body.Bytes()
 	if bytes.Contains(bodyBytes, []byte(`"items"`)) {
 		t.Errorf("expected no items field in 401 response, got body: %s", string(bodyBytes))
@@ -1194,8 +1193,8 @@ func TestIntegration_ListStarredItems_CursorPropagation(t *testing.T) {
 	if state.lastStarredCursor != cursorValue {
 		t.Errorf("cursor propagated to service = %q, want %q", state.lastStarredCursor, cursorValue)
 	}
-	if state.lastStarredLimit != defaultItemsPerPage {
+	if state.lastStarredLimit != DefaultPageLimit {
 		t.Errorf("limit propagated to service = %d, want %d (default)",
-			state.lastStarredLimit, defaultItemsPerPage)
+			state.lastStarredLimit, DefaultPageLimit)
 	}
 }
@@ -3,7 +3,11 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -11,26 +15,42 @@ import (
 	"github.com/hitoshi/feedman/internal/model"
 )
 
-// defaultItemsPerPage は記事一覧の1回の取得件数（デフォルト）。
-const defaultItemsPerPage = 50
+// starredLinkStatusBroken はスター記事一覧をリンク切れ記事に絞り込む link_status クエリパラメータの値。
+const starredLinkStatusBroken = "broken"
+
+// starredSortRating はスター記事一覧を評価の高い順に並べる sort クエリパラメータの値。
+const starredSortRating = "rating"
 
 // ItemServiceInterface は記事ハンドラーが必要とするサービスインターフェース。
 type ItemServiceInterface interface {
 	// ListItems はフィードの記事一覧をフィルタ・ページネーション付きで返す。
-	ListItems(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error)
-	// GetItem は記事詳細を返す。
-	GetItem(ctx context.Context, userID, itemID string) (*itemDetailResponse, error)
+	// author が空でない場合は著者名で絞り込む。
+	ListItems(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error)
+	// ListItemGroups はフィードの記事一覧をページ内で連載（series_key）ごとに折りたたんで返す。
+	ListItemGroups(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemGroupListResult, error)
+	// ListItemsByFeeds は複数フィードの記事を 1 つの一覧として返す。
+	// 購読していないフィードを含む場合は FEED_NOT_FOUND、指定数の上限超過・形式不正は INVALID_FILTER を返す。
+	ListItemsByFeeds(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error)
+	// ListAuthors はフィード内の著者一覧を記事数付きで返す。
+	ListAuthors(ctx context.Context, feedID string) (*feedAuthorListResponse, error)
+	// GetItem は記事詳細を返す。textOnly が true の場合は本文・要約から画像・埋め込み・動画を除去する。
+	GetItem(ctx context.Context, userID, itemID string, textOnly bool) (*itemDetailResponse, error)
 	// ListStarredItems はユーザーの全フィード横断スター記事一覧を返す。
 	// cursorStr が空文字列の場合は先頭ページを返す。
 	// 不正な cursorStr は model.APIError（INVALID_FILTER）を返す（Requirement 4.5 / 4.8）。
-	ListStarredItems(ctx context.Context, userID, cursorStr string, limit int) (*starredItemListResult, error)
+	ListStarredItems(ctx context.Context, userID, cursorStr string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error)
+	// DateBoundaries はユーザーのタイムゾーンでの今日・昨日・今週の開始日を返す（group_dates=true 用）。
+	DateBoundaries(ctx context.Context, userID string) (*itemDateBoundariesResponse, error)
+	// ListModTime はフィードの記事一覧の最終更新日時を返す（Last-Modified / If-Modified-Since 用）。
+	// 判定できない場合はゼロ値を返す。
+	ListModTime(ctx context.Context, userID, feedID string) (time.Time, error)
 }
 
 // ItemStateServiceInterface は記事状態管理サービスのインターフェース。
 type ItemStateServiceInterface interface {
-	// UpdateState は記事の既読・スター状態を冪等に更新する。
+	// UpdateState は記事の既読・スター・非表示状態と評価を冪等に更新する。
 	// nilフィールドは変更しない部分更新を行う。
-	UpdateState(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error)
+	UpdateState(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error)
 }
 
 // ItemHandler は記事管理のHTTPハンドラー。
@@ -60,33 +80,103 @@ type itemSummaryResponse struct {
 	IsDateEstimated bool      `json:"is_date_estimated"`
 	IsRead          bool      `json:"is_read"`
 	IsStarred       bool      `json:"is_starred"`
-	HatebuCount     int       `json:"hatebu_count"`
+	// IsHidden は記事が非表示（ミュート）にされているか。include_hidden=true で取得した非表示の記事でのみ true を返す。
+	IsHidden bool `json:"is_hidden,omitempty"`
+	// Rating は記事の評価（1〜5）。未評価の記事では省略する。評価 1 以上の記事は is_starred も true になる。
+	Rating      int `json:"rating,omitempty"`
+	HatebuCount int `json:"hatebu_count"`
+	// ReadingTimeMinutes は本文から推定した読了時間（分）。本文が無い場合は 0。
+	ReadingTimeMinutes int `json:"reading_time_minutes"`
+	// Excerpt は本文のプレーンテキストの先頭（最大 200 文字）。記事一覧でのみ返し、未生成の記事では省略する。
+	Excerpt string `json:"excerpt,omitempty"`
+	// ThumbnailURL はサムネイル画像の URL（https のみ）。記事一覧でのみ返し、画像の無い記事では省略する。
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// GeneratedSummary は要約器が生成した要約（プレーンテキスト）。未生成の場合は null。
+	GeneratedSummary *string `json:"generated_summary"`
+	// DateGroup は公開日時のユーザーのタイムゾーンでの日付（YYYY-MM-DD）。group_dates=true 指定時のみ返す。
+	DateGroup string `json:"date_group,omitempty"`
 }
 
 // itemListResult は記事一覧のレスポンス。
 type itemListResult struct {
 	Items      []itemSummaryResponse `json:"items"`
-	NextCursor string                `json:"next_cursor,omitempty"`
+	NextCursor *string               `json:"next_cursor"`
 	HasMore    bool                  `json:"has_more"`
+	// DateBoundaries は日付見出しの境界。group_dates=true 指定時のみ返す。
+	DateBoundaries *itemDateBoundariesResponse `json:"date_boundaries,omitempty"`
+}
+
+// itemDateBoundariesResponse は記事一覧を「今日 / 昨日 / 今週 / それ以前」で見出し分けするための日付境界。
+// 日付はいずれもユーザーのタイムゾーンでの YYYY-MM-DD で、各記事の date_group と文字列比較できる。
+// 週は月曜始まり。
+type itemDateBoundariesResponse struct {
+	Timezone  string `json:"timezone"`
+	Today     string `json:"today"`
+	Yesterday string `json:"yesterday"`
+	WeekStart string `json:"week_start"`
+
+	location *time.Location
+}
+
+// groupKey は公開日時 t のユーザーのタイムゾーンでの日付を返す。
+func (b *itemDateBoundariesResponse) groupKey(t time.Time) string {
+	return t.In(b.location).Format(time.DateOnly)
+}
+
+// annotate は記事サマリーに日付グループキーを付与する。
+func (b *itemDateBoundariesResponse) annotate(items []itemSummaryResponse) {
+	for i := range items {
+		items[i].DateGroup = b.groupKey(items[i].PublishedAt)
+	}
+}
+
+// itemGroupResponse は記事一覧を連載で折りたたんだ 1 グループのレスポンス。
+// 連載ではない記事は series_key が null の 1 件だけのグループになる。
+type itemGroupResponse struct {
+	SeriesKey *string               `json:"series_key"`
+	Items     []itemSummaryResponse `json:"items"`
+}
+
+// itemGroupListResult は group_by=series 指定時の記事一覧のレスポンス。
+// ページングは通常の記事一覧と同じく記事単位で、グループ化は取得したページ内で行う。
+type itemGroupListResult struct {
+	Groups     []itemGroupResponse `json:"groups"`
+	NextCursor *string             `json:"next_cursor"`
+	HasMore    bool                `json:"has_more"`
+	// DateBoundaries は日付見出しの境界。group_dates=true 指定時のみ返す。
+	DateBoundaries *itemDateBoundariesResponse `json:"date_boundaries,omitempty"`
 }
 
 // starredItemSummaryResponse は全フィード横断スター記事一覧の記事サマリーレスポンス。
 // 既存 itemSummaryResponse の全フィールドに加え、フィードタイトルを併記する
 // （Requirement 2.4 / 4.10）。フィードタイトルはフロントエンドで「どのフィードの記事か」を
 // 表示するためのもので、既存単一フィード API の応答スキーマは一切変更しない（NFR 3.1）。
+// link_status はリンク切れチェックの判定結果で、未判定の記事では null となる。
 type starredItemSummaryResponse struct {
 	itemSummaryResponse
-	FeedTitle string `json:"feed_title"`
+	FeedTitle  string  `json:"feed_title"`
+	LinkStatus *string `json:"link_status"`
 }
 
 // starredItemListResult は全フィード横断スター記事一覧のレスポンス。
 // 形状は itemListResult と同形だが、Items の各要素が feed_title を含む点が異なる。
 type starredItemListResult struct {
 	Items      []starredItemSummaryResponse `json:"items"`
-	NextCursor string                       `json:"next_cursor,omitempty"`
+	NextCursor *string                      `json:"next_cursor"`
 	HasMore    bool                         `json:"has_more"`
 }
 
+// feedAuthorResponse はフィード内の著者 1 名分のレスポンス。
+type feedAuthorResponse struct {
+	Author    string `json:"author"`
+	ItemCount int    `json:"item_count"`
+}
+
+// feedAuthorListResponse はフィード内の著者一覧のレスポンス。
+type feedAuthorListResponse struct {
+	Authors []feedAuthorResponse `json:"authors"`
+}
+
 // itemDetailResponse は記事詳細のレスポンス。
 type itemDetailResponse struct {
 	itemSummaryResponse
@@ -99,6 +189,9 @@ type itemDetailResponse struct {
 type itemStateRequest struct {
 	IsRead    *bool `json:"is_read,omitempty"`
 	IsStarred *bool `json:"is_starred,omitempty"`
+	IsHidden  *bool `json:"is_hidden,omitempty"`
+	// Rating は記事の評価（0〜5）。0 は評価の解除（スター解除）を表す。
+	Rating *int `json:"rating,omitempty"`
 }
 
 // itemStateResponse は記事状態のレスポンス。
@@ -106,15 +199,27 @@ type itemStateResponse struct {
 	ItemID    string `json:"item_id"`
 	IsRead    bool   `json:"is_read"`
 	IsStarred bool   `json:"is_starred"`
+	IsHidden  bool   `json:"is_hidden"`
+	Rating    int    `json:"rating"`
 }
 
 // ListItems はフィードの記事一覧を取得する。
-// GET /api/feeds/:id/items?cursor=xxx&filter=all|unread|starred
+// GET /api/feeds/:id/items?cursor=xxx&limit=50&filter=all|unread|starred&unread=true|false&starred=true|false&author=xxx
+// limit は一覧 API 共通の parsePageLimit で解釈する（未指定は既定値、上限を超える指定は上限に丸める）。
+// unread・starred は組み合わせて指定でき、filter（互換用の単一値指定）とも AND で結合する。
+// author を指定すると、GET /api/feeds/:id/authors が返す著者名で絞り込む。
+// group_by=series を指定すると、ページ内の記事を連載ごとに折りたたんだ groups を返す。
+// group_dates=true を指定すると、各記事にユーザーのタイムゾーンでの日付（date_group）を付与し、
+// 今日・昨日・今週の開始日（date_boundaries）を併せて返す。
+// 応答にはフィードの記事と記事状態の最終更新日時を Last-Modified として付与し、
+// If-Modified-Since 以降に変更が無ければ 304 Not Modified を返す。
+// group_dates=true の応答は日付の変わり目やタイムゾーンの変更で記事が変わらなくても内容が変わるため、
+// 条件付き GET の対象外とする（Last-Modified を付与せず、常に本文を返す）。
 func (h *ItemHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -124,39 +229,318 @@ func (h *ItemHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 
 	feedID := chi.URLParam(r, "id")
 	cursor := r.URL.Query().Get("cursor")
-	filterStr := r.URL.Query().Get("filter")
+	author := r.URL.Query().Get("author")
+
+	conds, err := parseItemConditions(r.URL.Query())
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	limit, err := parsePageLimit(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	groupDates, err := parseGroupDates(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if !groupDates {
+		if modTime, err := h.service.ListModTime(r.Context(), userID, feedID); err != nil {
+			// 最終更新日時は条件付き GET のためだけに使うため、取得に失敗しても一覧は返す
+			slog.Warn("記事一覧の最終更新日時の取得に失敗しました",
+				slog.String("feed_id", feedID),
+				slog.String("error", err.Error()),
+			)
+		} else if checkNotModified(w, r, modTime) {
+			return
+		}
+	}
+
+	switch groupBy := r.URL.Query().Get("group_by"); groupBy {
+	case "":
+	case itemGroupBySeries:
+		result, err := h.service.ListItemGroups(r.Context(), userID, feedID, conds, author, cursor, limit)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		if groupDates {
+			if result.DateBoundaries, err = h.service.DateBoundaries(r.Context(), userID); err != nil {
+				WriteError(w, err)
+				return
+			}
+			for _, g := range result.Groups {
+				result.DateBoundaries.annotate(g.Items)
+			}
+		}
+		WriteJSON(w, http.StatusOK, result)
+		return
+	default:
+		WriteError(w, model.NewInvalidFilterError("group_by="+groupBy))
+		return
+	}
+
+	result, err := h.service.ListItems(r.Context(), userID, feedID, conds, author, cursor, limit)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if groupDates {
+		if result.DateBoundaries, err = h.service.DateBoundaries(r.Context(), userID); err != nil {
+			WriteError(w, err)
+			return
+		}
+		result.DateBoundaries.annotate(result.Items)
+	}
+
+	WriteJSON(w, http.StatusOK, result)
+}
+
+// ListItemsByFeeds は複数フィードを指定した横断の記事一覧を取得する。
+// GET /api/items?feed_ids=a,b,c&cursor=xxx&limit=50&filter=...&unread=...&starred=...&author=xxx&group_dates=true
+// feed_ids はカンマ区切りで item.MaxListFeedIDs 件まで指定でき、すべて購読中のフィードである必要がある。
+// 絞り込み・ページング・group_dates は GET /api/feeds/:id/items と同じ。
+// group_by と条件付き GET（Last-Modified）はフィード単位の一覧のみが対応する。
+// Accept: application/x-ndjson を指定すると、cursor 以降の全記事を 1 行 1 記事の NDJSON でストリーミングする。
+func (h *ItemHandler) ListItemsByFeeds(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	q := r.URL.Query()
+	if groupBy := q.Get("group_by"); groupBy != "" {
+		WriteError(w, model.NewInvalidFilterError("group_by="+groupBy))
+		return
+	}
+
+	conds, err := parseItemConditions(q)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	limit, err := parsePageLimit(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	groupDates, err := parseGroupDates(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	feedIDs := strings.Split(q.Get("feed_ids"), ",")
+	if wantsNDJSON(r) {
+		h.streamItemsByFeeds(w, r, userID, feedIDs, conds, limit, groupDates)
+		return
+	}
+
+	result, err := h.service.ListItemsByFeeds(r.Context(), userID, feedIDs, conds, q.Get("author"), q.Get("cursor"), limit)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if groupDates {
+		if result.DateBoundaries, err = h.service.DateBoundaries(r.Context(), userID); err != nil {
+			WriteError(w, err)
+			return
+		}
+		result.DateBoundaries.annotate(result.Items)
+	}
+
+	WriteJSON(w, http.StatusOK, result)
+}
+
+// streamItemsByFeeds は横断の記事一覧を NDJSON で返す（Accept: application/x-ndjson 指定時）。
+// cursor の位置から末尾までの全記事を、limit 件ずつ取得しながら 1 行 1 記事で書き込む。
+// group_dates=true の場合は各記事に date_group を付与する（date_boundaries は出力しない）。
+func (h *ItemHandler) streamItemsByFeeds(w http.ResponseWriter, r *http.Request, userID string, feedIDs []string, conds model.ItemConditions, limit int, groupDates bool) {
+	var boundaries *itemDateBoundariesResponse
+	if groupDates {
+		var err error
+		if boundaries, err = h.service.DateBoundaries(r.Context(), userID); err != nil {
+			WriteError(w, err)
+			return
+		}
+	}
 
-	// デフォルトフィルタは "all"
-	filter := model.ItemFilterAll
-	if filterStr != "" {
-		filter = model.ItemFilter(filterStr)
+	q := r.URL.Query()
+	streamNDJSONPages(w, r, q.Get("cursor"), func(cursor string) ([]itemSummaryResponse, string, error) {
+		result, err := h.service.ListItemsByFeeds(r.Context(), userID, feedIDs, conds, q.Get("author"), cursor, limit)
+		if err != nil {
+			return nil, "", err
+		}
+		if boundaries != nil {
+			boundaries.annotate(result.Items)
+		}
+		var next string
+		if result.NextCursor != nil {
+			next = *result.NextCursor
+		}
+		return result.Items, next, nil
+	})
+}
+
+// parseGroupDates は記事一覧の group_dates クエリパラメータを解釈する。未指定は false。
+func parseGroupDates(r *http.Request) (bool, error) {
+	raw := r.URL.Query().Get("group_dates")
+	if raw == "" {
+		return false, nil
+	}
+	groupDates, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, model.NewInvalidFilterError("group_dates=" + raw)
 	}
+	return groupDates, nil
+}
 
-	result, err := h.service.ListItems(r.Context(), userID, feedID, filter, cursor, defaultItemsPerPage)
+// parseTextOnly は記事詳細の text_only クエリパラメータを解釈する。未指定は false。
+func parseTextOnly(r *http.Request) (bool, error) {
+	raw := r.URL.Query().Get("text_only")
+	if raw == "" {
+		return false, nil
+	}
+	textOnly, err := strconv.ParseBool(raw)
 	if err != nil {
-		handleServiceError(w, err)
+		return false, model.NewInvalidFilterError("text_only=" + raw)
+	}
+	return textOnly, nil
+}
+
+// itemGroupBySeries は記事一覧を連載ごとに折りたたむ group_by の値。
+const itemGroupBySeries = "series"
+
+// itemConditionParams は記事一覧の絞り込みに使うブール型クエリパラメータと、対応する条件の格納先。
+var itemConditionParams = []struct {
+	name  string
+	field func(*model.ItemConditions) **bool
+}{
+	{"unread", func(c *model.ItemConditions) **bool { return &c.Unread }},
+	{"starred", func(c *model.ItemConditions) **bool { return &c.Starred }},
+}
+
+// parseItemConditions は記事一覧のクエリパラメータから絞り込み条件を組み立てる。
+// filter 未指定は all として扱う。filter が未知の値、ブール値として解釈できないパラメータ、
+// filter とブール値パラメータの矛盾（filter=unread&unread=false 等）は INVALID_FILTER とする。
+// 非表示の記事は include_hidden=true の指定時のみ含める。
+// min_rating（1〜5）を指定すると、その評価以上の記事に絞り込む。範囲外の値や starred=false との併用は INVALID_FILTER とする。
+func parseItemConditions(q url.Values) (model.ItemConditions, error) {
+	filter := model.ItemFilter(q.Get("filter"))
+	conds, ok := filter.Conditions()
+	if !ok {
+		return model.ItemConditions{}, model.NewInvalidFilterError(string(filter))
+	}
+
+	var flags model.ItemConditions
+	for _, p := range itemConditionParams {
+		raw := q.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return model.ItemConditions{}, model.NewInvalidFilterError(p.name + "=" + raw)
+		}
+		*p.field(&flags) = &v
+	}
+
+	merged, ok := conds.Merge(flags)
+	if !ok {
+		return model.ItemConditions{}, model.NewInvalidFilterError(q.Encode())
+	}
+	if raw := q.Get("include_hidden"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return model.ItemConditions{}, model.NewInvalidFilterError("include_hidden=" + raw)
+		}
+		merged.IncludeHidden = v
+	}
+	if raw := q.Get("min_rating"); raw != "" {
+		minRating, err := parseMinRating(raw)
+		if err != nil {
+			return model.ItemConditions{}, err
+		}
+		if merged.Starred != nil && !*merged.Starred {
+			return model.ItemConditions{}, model.NewInvalidFilterError(q.Encode())
+		}
+		merged.MinRating = minRating
+	}
+	return merged, nil
+}
+
+// parseMinRating は min_rating クエリパラメータを解釈する。1〜MaxItemRating 以外は INVALID_FILTER とする。
+func parseMinRating(raw string) (int, error) {
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 1 || v > model.MaxItemRating {
+		return 0, model.NewInvalidFilterError("min_rating=" + raw)
+	}
+	return v, nil
+}
+
+// ListAuthors はフィード内の著者一覧と著者ごとの記事数を取得する。
+// GET /api/feeds/:id/authors
+// 記事数の多い順に返し、著者名の無い記事は集計に含まない。
+func (h *ItemHandler) ListAuthors(w http.ResponseWriter, r *http.Request) {
+	if _, err := middleware.UserIDFromContext(r.Context()); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	feedID := chi.URLParam(r, "id")
+
+	result, err := h.service.ListAuthors(r.Context(), feedID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	// 著者がいない場合でも JSON で `"authors": []` を返す
+	if result.Authors == nil {
+		result.Authors = []feedAuthorResponse{}
+	}
+
+	WriteJSON(w, http.StatusOK, result)
 }
 
 // ListStarredItems はユーザーの全フィード横断スター記事一覧を取得する。
-// GET /api/feeds/starred/items?cursor=xxx
+// GET /api/feeds/starred/items?cursor=xxx&limit=50&link_status=broken&min_rating=3&sort=rating
 //
 // 認証必須（UserIDFromContext 失敗で 401 / Requirement 4.6）。
 // cursor クエリパラメータが指定された場合は当該時刻より前の続きページを返し、
 // 指定がない場合は先頭ページを返す（Requirement 4.4 / 4.5）。
-// 不正カーソルは service 層が model.NewInvalidFilterError を返し、handleServiceError で
+// 不正カーソルは service 層が model.NewInvalidFilterError を返し、WriteError で
 // 400 にマップされる（Requirement 4.8）。
 // 応答スキーマは既存 ListItems と同形（items / next_cursor / has_more）に加え、
 // 各記事行に feed_title を併記する（Requirement 4.3 / 4.10 / NFR 3.1）。
+// link_status=broken を指定するとリンク切れと判定された記事のみに絞り込む。
+// それ以外の値は 400 INVALID_REQUEST を返す。
+// min_rating（1〜5）を指定するとその評価以上の記事に絞り込み、範囲外の値は 400 INVALID_FILTER を返す。
+// sort=rating を指定すると評価の高い順（同評価は公開日時の新しい順）に並べる。
+// sort の既定値は published_at で、それ以外の値は 400 INVALID_REQUEST を返す。
 func (h *ItemHandler) ListStarredItems(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -166,9 +550,53 @@ func (h *ItemHandler) ListStarredItems(w http.ResponseWriter, r *http.Request) {
 
 	cursor := r.URL.Query().Get("cursor")
 
-	result, err := h.service.ListStarredItems(r.Context(), userID, cursor, defaultItemsPerPage)
+	limit, err := parsePageLimit(r)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
+		return
+	}
+
+	var filter model.StarredItemFilter
+	switch r.URL.Query().Get("link_status") {
+	case "":
+	case starredLinkStatusBroken:
+		filter.BrokenLinkOnly = true
+	default:
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "link_status パラメータが不正です。",
+			Category: "validation",
+			Action:   "link_status には broken を指定してください。",
+		})
+		return
+	}
+
+	if raw := r.URL.Query().Get("min_rating"); raw != "" {
+		minRating, err := parseMinRating(raw)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		filter.MinRating = minRating
+	}
+
+	switch r.URL.Query().Get("sort") {
+	case "", "published_at":
+	case starredSortRating:
+		filter.SortByRating = true
+	default:
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "sort パラメータが不正です。",
+			Category: "validation",
+			Action:   "sort には published_at または rating を指定してください。",
+		})
+		return
+	}
+
+	result, err := h.service.ListStarredItems(r.Context(), userID, cursor, limit, filter)
+	if err != nil {
+		WriteError(w, err)
 		return
 	}
 
@@ -178,17 +606,19 @@ func (h *ItemHandler) ListStarredItems(w http.ResponseWriter, r *http.Request) {
 		result.Items = []starredItemSummaryResponse{}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	WriteJSON(w, http.StatusOK, result)
 }
 
 // GetItem は記事詳細を取得する。
-// GET /api/items/:id
+// GET /api/items/:id?text_only=true
+//
+// text_only=true の場合は本文・要約から img / iframe / video を除去したテキストオンリーの版を返す（データセーバー）。
+// text_only の値が真偽値として解釈できない場合は 400 INVALID_FILTER を返す。
 func (h *ItemHandler) GetItem(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -198,28 +628,33 @@ func (h *ItemHandler) GetItem(w http.ResponseWriter, r *http.Request) {
 
 	itemID := chi.URLParam(r, "id")
 
-	detail, err := h.service.GetItem(r.Context(), userID, itemID)
+	textOnly, err := parseTextOnly(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	detail, err := h.service.GetItem(r.Context(), userID, itemID, textOnly)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
 	if detail == nil {
-		middleware.WriteErrorResponse(w, http.StatusNotFound, model.NewItemNotFoundError(itemID))
+		WriteError(w, model.NewItemNotFoundError(itemID))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(detail)
+	WriteJSON(w, http.StatusOK, detail)
 }
 
-// UpdateItemState は記事の既読・スター状態を更新する。
+// UpdateItemState は記事の既読・スター・非表示状態を更新する。
 // PUT /api/items/:id/state
 func (h *ItemHandler) UpdateItemState(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -231,8 +666,8 @@ func (h *ItemHandler) UpdateItemState(w http.ResponseWriter, r *http.Request) {
 
 	var req itemStateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		middleware.WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{
-			Code:     "INVALID_REQUEST",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
 			Message:  "リクエストボディの解析に失敗しました。",
 			Category: "validation",
 			Action:   "正しいJSON形式でリクエストしてください。",
@@ -240,31 +675,84 @@ func (h *ItemHandler) UpdateItemState(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// is_readとis_starredの両方がnilの場合はバリデーションエラー
-	if req.IsRead == nil && req.IsStarred == nil {
-		middleware.WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{
-			Code:     "INVALID_REQUEST",
-			Message:  "is_readまたはis_starredのいずれかを指定してください。",
+	// is_read・is_starred・is_hidden・ratingがすべてnilの場合はバリデーションエラー
+	if req.IsRead == nil && req.IsStarred == nil && req.IsHidden == nil && req.Rating == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "is_read・is_starred・is_hidden・ratingのいずれかを指定してください。",
 			Category: "validation",
 			Action:   "更新するフィールドを指定してください。",
 		})
 		return
 	}
 
-	state, err := h.stateService.UpdateState(r.Context(), userID, itemID, req.IsRead, req.IsStarred)
+	if req.Rating != nil {
+		if *req.Rating < 0 || *req.Rating > model.MaxItemRating {
+			WriteError(w, &model.APIError{
+				Code:     model.ErrCodeInvalidRequest,
+				Message:  "ratingは0〜5の整数で指定してください。",
+				Category: "validation",
+				Action:   "評価を解除する場合は0を指定してください。",
+			})
+			return
+		}
+		// 評価 1 以上はスター扱いのため、is_starred と食い違う組み合わせは受け付けない
+		if req.IsStarred != nil && *req.IsStarred != (*req.Rating > 0) {
+			WriteError(w, &model.APIError{
+				Code:     model.ErrCodeInvalidRequest,
+				Message:  "is_starredとratingの指定が矛盾しています。",
+				Category: "validation",
+				Action:   "ratingのみを指定するか、is_starredと整合する値を指定してください。",
+			})
+			return
+		}
+	}
+
+	state, err := h.stateService.UpdateState(r.Context(), userID, itemID, req.IsRead, req.IsStarred, req.IsHidden, req.Rating)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(itemStateResponse{
+	WriteJSON(w, http.StatusOK, itemStateResponse{
 		ItemID:    state.ItemID,
 		IsRead:    state.IsRead,
 		IsStarred: state.IsStarred,
+		IsHidden:  state.IsHidden,
+		Rating:    state.Rating,
 	})
 }
 
+// ReadBeacon はページ離脱時の navigator.sendBeacon から記事を既読にする。
+// POST /api/items/:id/read-beacon
+//
+// ボディは読まず、UpdateItemState と同じ stateService で is_read=true を記録する。
+// sendBeacon は応答を参照できないため、記録の成否によらず 204 を返し、失敗はログにのみ残す。
+// JSON ボディを伴わないため、ルーターで同一オリジン限定のミドルウェアを掛けて CSRF を防ぐ。
+func (h *ItemHandler) ReadBeacon(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	itemID := chi.URLParam(r, "id")
+	isRead := true
+	if _, err := h.stateService.UpdateState(r.Context(), userID, itemID, &isRead, nil, nil, nil); err != nil {
+		slog.Warn("既読 beacon の記録に失敗しました",
+			slog.String("item_id", itemID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // SetupItemRoutes は記事管理関連のルーティングを設定したchi.Routerを返す。
 func SetupItemRoutes(service ItemServiceInterface, stateService ItemStateServiceInterface) http.Handler {
 	r := chi.NewRouter()
@@ -275,10 +763,14 @@ func SetupItemRoutes(service ItemServiceInterface, stateService ItemStateService
 		r.Get("/", h.ListItems)
 	})
 
+	// GET /api/feeds/:id/authors - フィード内の著者一覧
+	r.Get("/api/feeds/{id}/authors", h.ListAuthors)
+
 	// /api/items/:id 以下のルーティング
 	r.Route("/api/items/{id}", func(r chi.Router) {
 		r.Get("/", h.GetItem)
 		r.Put("/state", h.UpdateItemState)
+		r.Post("/read-beacon", h.ReadBeacon)
 	})
 
 	return r
@@ -7,6 +7,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,40 +20,85 @@ import (
 
 // mockItemService はItemServiceInterfaceのモック実装。
 type mockItemService struct {
-	listItemsFn        func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error)
+	listItemsFn        func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error)
 	getItemFn          func(ctx context.Context, userID, itemID string) (*itemDetailResponse, error)
-	listStarredItemsFn func(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error)
+	listStarredItemsFn func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error)
+	listAuthorsFn      func(ctx context.Context, feedID string) (*feedAuthorListResponse, error)
+	listItemGroupsFn   func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemGroupListResult, error)
+	dateBoundariesFn   func(ctx context.Context, userID string) (*itemDateBoundariesResponse, error)
+	dateBoundaryCalls  int
+	listModTimeFn      func(ctx context.Context, userID, feedID string) (time.Time, error)
+	listByFeedsFn      func(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error)
+	// gotTextOnly は直近の GetItem に渡された textOnly。
+	gotTextOnly bool
 }
 
-func (m *mockItemService) ListItems(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+func (m *mockItemService) ListItemsByFeeds(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+	if m.listByFeedsFn != nil {
+		return m.listByFeedsFn(ctx, userID, feedIDs, conds, author, cursor, limit)
+	}
+	return &itemListResult{}, nil
+}
+
+func (m *mockItemService) ListItems(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 	if m.listItemsFn != nil {
-		return m.listItemsFn(ctx, userID, feedID, filter, cursor, limit)
+		return m.listItemsFn(ctx, userID, feedID, conds, author, cursor, limit)
 	}
 	return &itemListResult{}, nil
 }
 
-func (m *mockItemService) GetItem(ctx context.Context, userID, itemID string) (*itemDetailResponse, error) {
+func (m *mockItemService) ListItemGroups(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemGroupListResult, error) {
+	if m.listItemGroupsFn != nil {
+		return m.listItemGroupsFn(ctx, userID, feedID, conds, author, cursor, limit)
+	}
+	return &itemGroupListResult{Groups: []itemGroupResponse{}}, nil
+}
+
+func (m *mockItemService) GetItem(ctx context.Context, userID, itemID string, textOnly bool) (*itemDetailResponse, error) {
+	m.gotTextOnly = textOnly
 	if m.getItemFn != nil {
 		return m.getItemFn(ctx, userID, itemID)
 	}
 	return nil, nil
 }
 
-func (m *mockItemService) ListStarredItems(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error) {
+func (m *mockItemService) ListStarredItems(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
 	if m.listStarredItemsFn != nil {
-		return m.listStarredItemsFn(ctx, userID, cursor, limit)
+		return m.listStarredItemsFn(ctx, userID, cursor, limit, filter)
 	}
 	return &starredItemListResult{}, nil
 }
 
+func (m *mockItemService) ListAuthors(ctx context.Context, feedID string) (*feedAuthorListResponse, error) {
+	if m.listAuthorsFn != nil {
+		return m.listAuthorsFn(ctx, feedID)
+	}
+	return &feedAuthorListResponse{}, nil
+}
+
+func (m *mockItemService) DateBoundaries(ctx context.Context, userID string) (*itemDateBoundariesResponse, error) {
+	m.dateBoundaryCalls++
+	if m.dateBoundariesFn != nil {
+		return m.dateBoundariesFn(ctx, userID)
+	}
+	return &itemDateBoundariesResponse{Timezone: "UTC", location: time.UTC}, nil
+}
+
+func (m *mockItemService) ListModTime(ctx context.Context, userID, feedID string) (time.Time, error) {
+	if m.listModTimeFn != nil {
+		return m.listModTimeFn(ctx, userID, feedID)
+	}
+	return time.Time{}, nil
+}
+
 // mockItemStateService はItemStateServiceInterfaceのモック実装。
 type mockItemStateService struct {
-	updateStateFn func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error)
+	updateStateFn func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error)
 }
 
-func (m *mockItemStateService) UpdateState(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+func (m *mockItemStateService) UpdateState(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 	if m.updateStateFn != nil {
-		return m.updateStateFn(ctx, userID, itemID, isRead, isStarred)
+		return m.updateStateFn(ctx, userID, itemID, isRead, isStarred, isHidden, rating)
 	}
 	return nil, nil
 }
@@ -60,15 +108,15 @@ func (m *mockItemStateService) UpdateState(ctx context.Context, userID, itemID s
 func TestItemHandler_ListItems_Success(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Second)
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 			if userID != "user-123" {
 				t.Errorf("userID = %q, want %q", userID, "user-123")
 			}
 			if feedID != "feed-1" {
 				t.Errorf("feedID = %q, want %q", feedID, "feed-1")
 			}
-			if filter != model.ItemFilterAll {
-				t.Errorf("filter = %q, want %q", filter, model.ItemFilterAll)
+			if conds != (model.ItemConditions{}) {
+				t.Errorf("conds = %s, want none", condsString(conds))
 			}
 			if limit != 50 {
 				t.Errorf("limit = %d, want %d", limit, 50)
@@ -87,7 +135,7 @@ func TestItemHandler_ListItems_Success(t *testing.T) {
 						HatebuCount:     10,
 					},
 				},
-				NextCursor: now.Format(time.RFC3339Nano),
+				NextCursor: nullableString(now.Format(time.RFC3339Nano)),
 				HasMore:    true,
 			}, nil
 		},
@@ -108,8 +156,8 @@ func TestItemHandler_ListItems_Success(t *testing.T) {
 	}
 
 	contentType := resp.Header.Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", contentType, "application/json; charset=utf-8")
 	}
 
 	var result map[string]interface{}
@@ -154,7 +202,7 @@ func TestItemHandler_ListItems_IncludesSummary(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			svc := &mockItemService{
-				listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+				listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 					return &itemListResult{
 						Items: []itemSummaryResponse{
 							{
@@ -211,20 +259,21 @@ func TestItemHandler_ListItems_IncludesSummary(t *testing.T) {
 func TestItemHandler_ListItems_PreservesExistingFields(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Second)
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 			return &itemListResult{
 				Items: []itemSummaryResponse{
 					{
-						ID:              "item-1",
-						FeedID:          "feed-1",
-						Title:           "テスト記事",
-						Link:            "https://example.com/1",
-						Summary:         "概要",
-						PublishedAt:     now,
-						IsDateEstimated: true,
-						IsRead:          true,
-						IsStarred:       true,
-						HatebuCount:     7,
+						ID:                 "item-1",
+						FeedID:             "feed-1",
+						Title:              "テスト記事",
+						Link:               "https://example.com/1",
+						Summary:            "概要",
+						PublishedAt:        now,
+						IsDateEstimated:    true,
+						IsRead:             true,
+						IsStarred:          true,
+						HatebuCount:        7,
+						ReadingTimeMinutes: 3,
 					},
 				},
 			}, nil
@@ -252,14 +301,15 @@ func TestItemHandler_ListItems_PreservesExistingFields(t *testing.T) {
 	item := result.Items[0]
 	// 既存フィールドが全て存在し変更されていないこと
 	wantFields := map[string]interface{}{
-		"id":                "item-1",
-		"feed_id":           "feed-1",
-		"title":             "テスト記事",
-		"link":              "https://example.com/1",
-		"is_date_estimated": true,
-		"is_read":           true,
-		"is_starred":        true,
-		"hatebu_count":      float64(7),
+		"id":                   "item-1",
+		"feed_id":              "feed-1",
+		"title":                "テスト記事",
+		"link":                 "https://example.com/1",
+		"is_date_estimated":    true,
+		"is_read":              true,
+		"is_starred":           true,
+		"hatebu_count":         float64(7),
+		"reading_time_minutes": float64(3),
 	}
 	for k, want := range wantFields {
 		if got := item[k]; got != want {
@@ -272,10 +322,10 @@ func TestItemHandler_ListItems_PreservesExistingFields(t *testing.T) {
 }
 
 func TestItemHandler_ListItems_WithUnreadFilter(t *testing.T) {
-	receivedFilter := model.ItemFilterAll
+	var received model.ItemConditions
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
-			receivedFilter = filter
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+			received = conds
 			return &itemListResult{Items: []itemSummaryResponse{}}, nil
 		},
 	}
@@ -292,16 +342,16 @@ func TestItemHandler_ListItems_WithUnreadFilter(t *testing.T) {
 	if w.Result().StatusCode != http.StatusOK {
 		t.Errorf("status = %d, want %d", w.Result().StatusCode, http.StatusOK)
 	}
-	if receivedFilter != model.ItemFilterUnread {
-		t.Errorf("filter = %q, want %q", receivedFilter, model.ItemFilterUnread)
+	if got := condsString(received); got != "unread=true starred=-" {
+		t.Errorf("conds = %s, want %s", got, "unread=true starred=-")
 	}
 }
 
 func TestItemHandler_ListItems_WithStarredFilter(t *testing.T) {
-	receivedFilter := model.ItemFilterAll
+	var received model.ItemConditions
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
-			receivedFilter = filter
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+			received = conds
 			return &itemListResult{Items: []itemSummaryResponse{}}, nil
 		},
 	}
@@ -318,37 +368,202 @@ func TestItemHandler_ListItems_WithStarredFilter(t *testing.T) {
 	if w.Result().StatusCode != http.StatusOK {
 		t.Errorf("status = %d, want %d", w.Result().StatusCode, http.StatusOK)
 	}
-	if receivedFilter != model.ItemFilterStarred {
-		t.Errorf("filter = %q, want %q", receivedFilter, model.ItemFilterStarred)
+	if got := condsString(received); got != "unread=- starred=true" {
+		t.Errorf("conds = %s, want %s", got, "unread=- starred=true")
 	}
 }
 
 func TestItemHandler_ListItems_InvalidFilter_ReturnsBadRequest(t *testing.T) {
-	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
-			return nil, model.NewInvalidFilterError("invalid")
-		},
+	for _, query := range []string{
+		"filter=invalid",
+		"unread=maybe",
+		"filter=unread&unread=false",
+		"include_hidden=maybe",
+	} {
+		t.Run(query+"のとき400を返しサービスを呼ばない", func(t *testing.T) {
+			// Arrange
+			called := false
+			svc := &mockItemService{
+				listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+					called = true
+					return &itemListResult{}, nil
+				},
+			}
+			h := NewItemHandler(svc, &mockItemStateService{})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?"+query, nil)
+			req = withUserID(req, "user-123")
+			req = withChiURLParam(req, "id", "feed-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.ListItems(w, req)
+
+			// Assert
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Result().StatusCode, http.StatusBadRequest)
+			}
+			if !strings.Contains(w.Body.String(), string(model.ErrCodeInvalidFilter)) {
+				t.Errorf("body = %s, want %s", w.Body.String(), model.ErrCodeInvalidFilter)
+			}
+			if called {
+				t.Error("不正な絞り込み条件でサービスが呼ばれるべきではない")
+			}
+		})
 	}
+}
 
-	h := NewItemHandler(svc, &mockItemStateService{})
+func TestItemHandler_ListItems_CombinedConditions(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "unread=true&starred=true", want: "unread=true starred=true"},
+		{query: "unread=false", want: "unread=false starred=-"},
+		{query: "filter=unread&starred=true", want: "unread=true starred=true"},
+		{query: "filter=starred&starred=1", want: "unread=- starred=true"},
+		{query: "filter=all&starred=false", want: "unread=- starred=false"},
+	}
 
-	req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?filter=invalid", nil)
-	req = withUserID(req, "user-123")
-	req = withChiURLParam(req, "id", "feed-1")
-	w := httptest.NewRecorder()
+	for _, tt := range tests {
+		t.Run(tt.query+"のとき条件をANDで組み合わせてサービスに渡す", func(t *testing.T) {
+			// Arrange
+			var received model.ItemConditions
+			svc := &mockItemService{
+				listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+					received = conds
+					return &itemListResult{Items: []itemSummaryResponse{}}, nil
+				},
+			}
+			h := NewItemHandler(svc, &mockItemStateService{})
 
-	h.ListItems(w, req)
+			req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?"+tt.query, nil)
+			req = withUserID(req, "user-123")
+			req = withChiURLParam(req, "id", "feed-1")
+			w := httptest.NewRecorder()
 
-	resp := w.Result()
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			// Act
+			h.ListItems(w, req)
+
+			// Assert
+			if w.Result().StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Result().StatusCode, http.StatusOK)
+			}
+			if got := condsString(received); got != tt.want {
+				t.Errorf("conds = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemHandler_ListItems_IncludeHidden(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{name: "include_hidden未指定のとき非表示の記事を除外する条件を渡す", query: "", want: false},
+		{name: "include_hidden=trueのとき非表示の記事を含める条件を渡す", query: "include_hidden=true", want: true},
+		{name: "他の条件と併用したとき両方の条件を渡す", query: "unread=true&include_hidden=1", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var received model.ItemConditions
+			svc := &mockItemService{
+				listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+					received = conds
+					return &itemListResult{Items: []itemSummaryResponse{}}, nil
+				},
+			}
+			h := NewItemHandler(svc, &mockItemStateService{})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?"+tt.query, nil)
+			req = withUserID(req, "user-123")
+			req = withChiURLParam(req, "id", "feed-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.ListItems(w, req)
+
+			// Assert
+			if w.Result().StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Result().StatusCode, http.StatusOK)
+			}
+			if received.IncludeHidden != tt.want {
+				t.Errorf("IncludeHidden = %v, want %v", received.IncludeHidden, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemHandler_ListItems_MinRating(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		want       int
+	}{
+		{name: "min_ratingを指定したとき評価で絞り込む条件を渡す", query: "min_rating=4", wantStatus: http.StatusOK, want: 4},
+		{name: "starred=trueと併用したとき両方の条件を渡す", query: "starred=true&min_rating=2", wantStatus: http.StatusOK, want: 2},
+		{name: "min_ratingが範囲外のとき400を返す", query: "min_rating=6", wantStatus: http.StatusBadRequest},
+		{name: "min_ratingが数値でないとき400を返す", query: "min_rating=high", wantStatus: http.StatusBadRequest},
+		{name: "starred=falseと併用したとき400を返す", query: "starred=false&min_rating=1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var received model.ItemConditions
+			svc := &mockItemService{
+				listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+					received = conds
+					return &itemListResult{Items: []itemSummaryResponse{}}, nil
+				},
+			}
+			h := NewItemHandler(svc, &mockItemStateService{})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?"+tt.query, nil)
+			req = withUserID(req, "user-123")
+			req = withChiURLParam(req, "id", "feed-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.ListItems(w, req)
+
+			// Assert
+			if w.Result().StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Result().StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if errResp := parseAPIErrorResponse(t, w); errResp["code"] != model.ErrCodeInvalidFilter {
+					t.Errorf("code = %q, want %q", errResp["code"], model.ErrCodeInvalidFilter)
+				}
+				return
+			}
+			if received.MinRating != tt.want {
+				t.Errorf("MinRating = %d, want %d", received.MinRating, tt.want)
+			}
+		})
 	}
 }
 
+// condsString は絞り込み条件を比較しやすい文字列にする（未指定は "-"）。
+func condsString(c model.ItemConditions) string {
+	f := func(b *bool) string {
+		if b == nil {
+			return "-"
+		}
+		return strconv.FormatBool(*b)
+	}
+	return "unread=" + f(c.Unread) + " starred=" + f(c.Starred)
+}
+
 func TestItemHandler_ListItems_WithCursor(t *testing.T) {
 	receivedCursor := ""
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 			receivedCursor = cursor
 			return &itemListResult{Items: []itemSummaryResponse{}}, nil
 		},
@@ -389,10 +604,10 @@ func TestItemHandler_ListItems_NoUserID_ReturnsUnauthorized(t *testing.T) {
 }
 
 func TestItemHandler_ListItems_DefaultFilterIsAll(t *testing.T) {
-	receivedFilter := model.ItemFilter("")
+	received := model.ItemConditions{Unread: new(bool)}
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
-			receivedFilter = filter
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+			received = conds
 			return &itemListResult{Items: []itemSummaryResponse{}}, nil
 		},
 	}
@@ -407,14 +622,526 @@ func TestItemHandler_ListItems_DefaultFilterIsAll(t *testing.T) {
 
 	h.ListItems(w, req)
 
-	if receivedFilter != model.ItemFilterAll {
-		t.Errorf("default filter = %q, want %q", receivedFilter, model.ItemFilterAll)
+	if received != (model.ItemConditions{}) {
+		t.Errorf("default conds = %s, want none", condsString(received))
 	}
 }
 
+func TestItemHandler_ListItems_AuthorFilter(t *testing.T) {
+	t.Run("author を指定したときサービスに著者名が渡る", func(t *testing.T) {
+		// Arrange
+		var receivedAuthor string
+		svc := &mockItemService{
+			listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+				receivedAuthor = author
+				return &itemListResult{Items: []itemSummaryResponse{}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?author="+url.QueryEscape("山田 太郎"), nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if receivedAuthor != "山田 太郎" {
+			t.Errorf("author = %q, want %q", receivedAuthor, "山田 太郎")
+		}
+	})
+
+	t.Run("author を指定しないとき空文字列が渡る", func(t *testing.T) {
+		// Arrange
+		receivedAuthor := "unset"
+		svc := &mockItemService{
+			listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+				receivedAuthor = author
+				return &itemListResult{Items: []itemSummaryResponse{}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if receivedAuthor != "" {
+			t.Errorf("author = %q, want empty", receivedAuthor)
+		}
+	})
+}
+
+// --- GET /api/feeds/:id/authors テスト ---
+
+func TestItemHandler_ListAuthors(t *testing.T) {
+	t.Run("著者一覧と記事数を返す", func(t *testing.T) {
+		// Arrange
+		var receivedFeedID string
+		svc := &mockItemService{
+			listAuthorsFn: func(ctx context.Context, feedID string) (*feedAuthorListResponse, error) {
+				receivedFeedID = feedID
+				return &feedAuthorListResponse{Authors: []feedAuthorResponse{
+					{Author: "山田 太郎", ItemCount: 3},
+					{Author: "John Doe", ItemCount: 1},
+				}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/authors", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuthors(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if receivedFeedID != "feed-1" {
+			t.Errorf("feedID = %q, want %q", receivedFeedID, "feed-1")
+		}
+		var body struct {
+			Authors []struct {
+				Author    string `json:"author"`
+				ItemCount int    `json:"item_count"`
+			} `json:"authors"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Authors) != 2 {
+			t.Fatalf("len(authors) = %d, want 2", len(body.Authors))
+		}
+		if body.Authors[0].Author != "山田 太郎" || body.Authors[0].ItemCount != 3 {
+			t.Errorf("authors[0] = %+v, want {山田 太郎 3}", body.Authors[0])
+		}
+	})
+
+	t.Run("著者がいないとき空配列を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listAuthorsFn: func(ctx context.Context, feedID string) (*feedAuthorListResponse, error) {
+				return &feedAuthorListResponse{}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/authors", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuthors(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got := strings.TrimSpace(w.Body.String()); got != `{"authors":[]}` {
+			t.Errorf("body = %s, want {\"authors\":[]}", got)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewItemHandler(&mockItemService{}, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/authors", nil)
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuthors(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("サービスがエラーを返したとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listAuthorsFn: func(ctx context.Context, feedID string) (*feedAuthorListResponse, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/authors", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListAuthors(w, req)
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
+
+func TestItemHandler_ListItems_GroupBySeries(t *testing.T) {
+	t.Run("group_by=seriesのとき連載ごとに折りたたんだgroupsを返す", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 6, 29, 12, 0, 0, 0, time.UTC)
+		seriesKey := "go 入門"
+		var receivedAuthor string
+		svc := &mockItemService{
+			listItemsFn: func(context.Context, string, string, model.ItemConditions, string, string, int) (*itemListResult, error) {
+				t.Error("group_by=series のとき ListItems を呼んではならない")
+				return &itemListResult{}, nil
+			},
+			listItemGroupsFn: func(_ context.Context, _, feedID string, _ model.ItemConditions, author, _ string, _ int) (*itemGroupListResult, error) {
+				receivedAuthor = author
+				return &itemGroupListResult{
+					Groups: []itemGroupResponse{
+						{SeriesKey: &seriesKey, Items: []itemSummaryResponse{
+							{ID: "item-2", FeedID: feedID, Title: "Go 入門 第2回", PublishedAt: now},
+							{ID: "item-1", FeedID: feedID, Title: "Go 入門 第1回", PublishedAt: now.Add(-time.Hour)},
+						}},
+						{Items: []itemSummaryResponse{{ID: "item-3", FeedID: feedID, Title: "お知らせ", PublishedAt: now.Add(-2 * time.Hour)}}},
+					},
+				}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?group_by=series&author=alice", nil)
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if receivedAuthor != "alice" {
+			t.Errorf("author = %q, want %q", receivedAuthor, "alice")
+		}
+		var body struct {
+			Groups []struct {
+				SeriesKey *string `json:"series_key"`
+				Items     []struct {
+					ID string `json:"id"`
+				} `json:"items"`
+			} `json:"groups"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Groups) != 2 {
+			t.Fatalf("len(groups) = %d, want 2", len(body.Groups))
+		}
+		if body.Groups[0].SeriesKey == nil || *body.Groups[0].SeriesKey != seriesKey || len(body.Groups[0].Items) != 2 {
+			t.Errorf("groups[0] = %+v, want series %q with 2 items", body.Groups[0], seriesKey)
+		}
+		if body.Groups[1].SeriesKey != nil {
+			t.Errorf("groups[1].series_key = %q, want null", *body.Groups[1].SeriesKey)
+		}
+	})
+
+	t.Run("未知のgroup_byのとき400 INVALID_FILTERを返す", func(t *testing.T) {
+		// Arrange
+		h := NewItemHandler(&mockItemService{}, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?group_by=author", nil)
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodeInvalidFilter) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodeInvalidFilter)
+		}
+	})
+}
+
+func TestItemHandler_ListItems_GroupDates(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	boundaries := func(context.Context, string) (*itemDateBoundariesResponse, error) {
+		return &itemDateBoundariesResponse{
+			Timezone:  "Asia/Tokyo",
+			Today:     "2026-07-06",
+			Yesterday: "2026-07-05",
+			WeekStart: "2026-07-06",
+			location:  tokyo,
+		}, nil
+	}
+
+	t.Run("group_dates=trueのときユーザーのタイムゾーンでの日付と境界を付与する", func(t *testing.T) {
+		// Arrange
+		var gotUserID string
+		svc := &mockItemService{
+			listItemsFn: func(context.Context, string, string, model.ItemConditions, string, string, int) (*itemListResult, error) {
+				return &itemListResult{Items: []itemSummaryResponse{
+					// UTC では 7/5 だが JST では 7/6
+					{ID: "item-1", PublishedAt: time.Date(2026, 7, 5, 15, 30, 0, 0, time.UTC)},
+					{ID: "item-2", PublishedAt: time.Date(2026, 7, 5, 14, 59, 0, 0, time.UTC)},
+				}}, nil
+			},
+			dateBoundariesFn: func(ctx context.Context, userID string) (*itemDateBoundariesResponse, error) {
+				gotUserID = userID
+				return boundaries(ctx, userID)
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?group_dates=true", nil)
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotUserID != "user-123" {
+			t.Errorf("userID = %q, want %q", gotUserID, "user-123")
+		}
+		var body struct {
+			Items []struct {
+				ID        string `json:"id"`
+				DateGroup string `json:"date_group"`
+			} `json:"items"`
+			DateBoundaries map[string]string `json:"date_boundaries"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Items) != 2 || body.Items[0].DateGroup != "2026-07-06" || body.Items[1].DateGroup != "2026-07-05" {
+			t.Errorf("items = %+v", body.Items)
+		}
+		want := map[string]string{"timezone": "Asia/Tokyo", "today": "2026-07-06", "yesterday": "2026-07-05", "week_start": "2026-07-06"}
+		for k, v := range want {
+			if body.DateBoundaries[k] != v {
+				t.Errorf("date_boundaries[%s] = %q, want %q", k, body.DateBoundaries[k], v)
+			}
+		}
+	})
+
+	t.Run("group_byと併用したときグループ内の記事にも日付を付与する", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listItemGroupsFn: func(context.Context, string, string, model.ItemConditions, string, string, int) (*itemGroupListResult, error) {
+				return &itemGroupListResult{Groups: []itemGroupResponse{
+					{Items: []itemSummaryResponse{{ID: "item-1", PublishedAt: time.Date(2026, 7, 5, 15, 30, 0, 0, time.UTC)}}},
+				}}, nil
+			},
+			dateBoundariesFn: boundaries,
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?group_by=series&group_dates=1", nil)
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if !strings.Contains(w.Body.String(), `"date_group":"2026-07-06"`) || !strings.Contains(w.Body.String(), `"date_boundaries":{`) {
+			t.Errorf("body = %s", w.Body.String())
+		}
+	})
+
+	t.Run("group_dates未指定のとき日付を付与せず境界も求めない", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listItemsFn: func(context.Context, string, string, model.ItemConditions, string, string, int) (*itemListResult, error) {
+				return &itemListResult{Items: []itemSummaryResponse{{ID: "item-1", PublishedAt: time.Now()}}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items", nil)
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if svc.dateBoundaryCalls != 0 {
+			t.Errorf("DateBoundaries calls = %d, want 0", svc.dateBoundaryCalls)
+		}
+		if strings.Contains(w.Body.String(), "date_group") || strings.Contains(w.Body.String(), "date_boundaries") {
+			t.Errorf("body = %s, want no date fields", w.Body.String())
+		}
+	})
+
+	t.Run("group_datesがブール値でないとき400 INVALID_FILTERを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?group_dates=yes", nil)
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodeInvalidFilter) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodeInvalidFilter)
+		}
+	})
+}
+
+func TestItemHandler_ListItemsByFeeds(t *testing.T) {
+	t.Run("feed_idsをカンマで分割して条件とともにサービスに渡す", func(t *testing.T) {
+		// Arrange
+		var gotFeedIDs []string
+		var gotConds model.ItemConditions
+		svc := &mockItemService{
+			listByFeedsFn: func(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+				if userID != "user-123" {
+					t.Errorf("userID = %q, want %q", userID, "user-123")
+				}
+				gotFeedIDs, gotConds = feedIDs, conds
+				return &itemListResult{Items: []itemSummaryResponse{{ID: "item-1", FeedID: "feed-b"}}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/items?feed_ids=feed-a,feed-b&unread=true", nil)
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItemsByFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if len(gotFeedIDs) != 2 || gotFeedIDs[0] != "feed-a" || gotFeedIDs[1] != "feed-b" {
+			t.Errorf("feedIDs = %v, want [feed-a feed-b]", gotFeedIDs)
+		}
+		if gotConds.Unread == nil || !*gotConds.Unread {
+			t.Errorf("conds = %s, want unread=true", condsString(gotConds))
+		}
+	})
+
+	t.Run("NDJSONを受け付けるとき全ページの記事を1行ずつ返す", func(t *testing.T) {
+		// Arrange
+		var cursors []string
+		svc := &mockItemService{
+			listByFeedsFn: func(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+				cursors = append(cursors, cursor)
+				if cursor == "" {
+					return &itemListResult{Items: []itemSummaryResponse{{ID: "item-1"}}, NextCursor: nullableString("c1"), HasMore: true}, nil
+				}
+				return &itemListResult{Items: []itemSummaryResponse{{ID: "item-2"}}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items?feed_ids=feed-a,feed-b", nil), "user-123")
+		req.Header.Set("Accept", "application/x-ndjson")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItemsByFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != NDJSONContentType {
+			t.Errorf("Content-Type = %q, want %q", ct, NDJSONContentType)
+		}
+		if len(cursors) != 2 || cursors[1] != "c1" {
+			t.Errorf("cursors = %q, want [\"\" \"c1\"]", cursors)
+		}
+		lines := decodeNDJSONLines(t, w.Body.String())
+		if len(lines) != 2 || lines[0]["id"] != "item-1" || lines[1]["id"] != "item-2" {
+			t.Errorf("lines = %v, want item-1 and item-2", lines)
+		}
+	})
+
+	t.Run("購読していないフィードを含むとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listByFeedsFn: func(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+				return nil, &model.APIError{Code: model.ErrCodeFeedNotFound, Message: "not found"}
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items?feed_ids=feed-a,feed-x", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItemsByFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("group_byを指定したとき400を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listByFeedsFn: func(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+				t.Error("ListItemsByFeeds should not be called")
+				return nil, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items?feed_ids=feed-a&group_by=series", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItemsByFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("セッションがないとき401を返す", func(t *testing.T) {
+		h := NewItemHandler(&mockItemService{}, &mockItemStateService{})
+		w := httptest.NewRecorder()
+
+		h.ListItemsByFeeds(w, httptest.NewRequest(http.MethodGet, "/api/items?feed_ids=feed-a", nil))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
 func TestItemHandler_ListItems_EmptyResult(t *testing.T) {
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 			return &itemListResult{
 				Items:   []itemSummaryResponse{},
 				HasMore: false,
@@ -469,15 +1196,16 @@ func TestItemHandler_GetItem_Success(t *testing.T) {
 			}
 			return &itemDetailResponse{
 				itemSummaryResponse: itemSummaryResponse{
-					ID:              "item-1",
-					FeedID:          "feed-1",
-					Title:           "テスト記事",
-					Link:            "https://example.com/article",
-					PublishedAt:     now,
-					IsDateEstimated: false,
-					IsRead:          true,
-					IsStarred:       false,
-					HatebuCount:     42,
+					ID:                 "item-1",
+					FeedID:             "feed-1",
+					Title:              "テスト記事",
+					Link:               "https://example.com/article",
+					PublishedAt:        now,
+					IsDateEstimated:    false,
+					IsRead:             true,
+					IsStarred:          false,
+					HatebuCount:        42,
+					ReadingTimeMinutes: 5,
 				},
 				Content: "<p>サニタイズ済みコンテンツ</p>",
 				Summary: "記事のサマリー",
@@ -501,8 +1229,8 @@ func TestItemHandler_GetItem_Success(t *testing.T) {
 	}
 
 	contentType := resp.Header.Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", contentType, "application/json; charset=utf-8")
 	}
 
 	var result map[string]interface{}
@@ -519,6 +1247,9 @@ func TestItemHandler_GetItem_Success(t *testing.T) {
 	if result["link"] != "https://example.com/article" {
 		t.Errorf("link = %v, want %q", result["link"], "https://example.com/article")
 	}
+	if result["reading_time_minutes"] != float64(5) {
+		t.Errorf("reading_time_minutes = %v, want 5", result["reading_time_minutes"])
+	}
 	if result["author"] != "著者名" {
 		t.Errorf("author = %v, want %q", result["author"], "著者名")
 	}
@@ -567,6 +1298,53 @@ func TestItemHandler_GetItem_NoUserID_ReturnsUnauthorized(t *testing.T) {
 	}
 }
 
+func TestItemHandler_GetItem_TextOnly(t *testing.T) {
+	t.Run("text_only=trueのときテキストオンリーの記事詳細をサービスに要求する", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			getItemFn: func(ctx context.Context, userID, itemID string) (*itemDetailResponse, error) {
+				return &itemDetailResponse{itemSummaryResponse: itemSummaryResponse{ID: itemID}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-1?text_only=true", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetItem(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if !svc.gotTextOnly {
+			t.Error("textOnly = false, want true")
+		}
+	})
+
+	t.Run("text_onlyが真偽値でないとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := NewItemHandler(&mockItemService{}, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-1?text_only=maybe", nil)
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetItem(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w)["code"]; got != model.ErrCodeInvalidFilter {
+			t.Errorf("code = %q, want %q", got, model.ErrCodeInvalidFilter)
+		}
+	})
+}
+
 func TestItemHandler_GetItem_ServiceError_ReturnsInternalServerError(t *testing.T) {
 	svc := &mockItemService{
 		getItemFn: func(ctx context.Context, userID, itemID string) (*itemDetailResponse, error) {
@@ -593,7 +1371,7 @@ func TestItemHandler_GetItem_ServiceError_ReturnsInternalServerError(t *testing.
 
 func TestItemHandler_UpdateItemState_SetRead_Success(t *testing.T) {
 	stateSvc := &mockItemStateService{
-		updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 			if userID != "user-123" {
 				t.Errorf("userID = %q, want %q", userID, "user-123")
 			}
@@ -646,7 +1424,7 @@ func TestItemHandler_UpdateItemState_SetRead_Success(t *testing.T) {
 
 func TestItemHandler_UpdateItemState_SetStarred_Success(t *testing.T) {
 	stateSvc := &mockItemStateService{
-		updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 			if isStarred == nil || !*isStarred {
 				t.Error("expected isStarred to be true")
 			}
@@ -690,7 +1468,7 @@ func TestItemHandler_UpdateItemState_SetStarred_Success(t *testing.T) {
 
 func TestItemHandler_UpdateItemState_BothFields_Success(t *testing.T) {
 	stateSvc := &mockItemStateService{
-		updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 			if isRead == nil || !*isRead {
 				t.Error("expected isRead to be true")
 			}
@@ -723,6 +1501,133 @@ func TestItemHandler_UpdateItemState_BothFields_Success(t *testing.T) {
 	}
 }
 
+func TestItemHandler_UpdateItemState_SetHidden_Success(t *testing.T) {
+	// Arrange
+	stateSvc := &mockItemStateService{
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
+			if isHidden == nil || !*isHidden {
+				t.Error("expected isHidden to be true")
+			}
+			if isRead != nil || isStarred != nil {
+				t.Error("expected isRead and isStarred to be nil (not specified)")
+			}
+			return &model.ItemState{
+				ItemID:   "item-1",
+				UserID:   "user-123",
+				IsHidden: true,
+			}, nil
+		},
+	}
+
+	h := NewItemHandler(&mockItemService{}, stateSvc)
+
+	body := `{"is_hidden": true}`
+	req := httptest.NewRequest(http.MethodPut, "/api/items/item-1/state", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = withUserID(req, "user-123")
+	req = withChiURLParam(req, "id", "item-1")
+	w := httptest.NewRecorder()
+
+	// Act
+	h.UpdateItemState(w, req)
+
+	// Assert
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// 非表示は既読とは独立した状態として返す
+	if result["is_hidden"] != true {
+		t.Errorf("is_hidden = %v, want true", result["is_hidden"])
+	}
+	if result["is_read"] != false {
+		t.Errorf("is_read = %v, want false", result["is_read"])
+	}
+}
+
+func TestItemHandler_UpdateItemState_Rating(t *testing.T) {
+	t.Run("ratingを指定したとき評価を更新してスター状態と併せて返す", func(t *testing.T) {
+		// Arrange
+		var receivedRating *int
+		stateSvc := &mockItemStateService{
+			updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
+				receivedRating = rating
+				return &model.ItemState{ItemID: "item-1", UserID: "user-123", IsStarred: true, Rating: 4}, nil
+			},
+		}
+		h := NewItemHandler(&mockItemService{}, stateSvc)
+		req := httptest.NewRequest(http.MethodPut, "/api/items/item-1/state", bytes.NewBufferString(`{"rating": 4}`))
+		req = withUserID(req, "user-123")
+		req = withChiURLParam(req, "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateItemState(w, req)
+
+		// Assert
+		if w.Result().StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Result().StatusCode, http.StatusOK)
+		}
+		if receivedRating == nil || *receivedRating != 4 {
+			t.Errorf("rating = %v, want 4", receivedRating)
+		}
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result["rating"] != float64(4) || result["is_starred"] != true {
+			t.Errorf("rating = %v, is_starred = %v, want 4, true", result["rating"], result["is_starred"])
+		}
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "ratingが範囲外のとき400を返す", body: `{"rating": 6}`},
+		{name: "ratingが負のとき400を返す", body: `{"rating": -1}`},
+		{name: "is_starred=falseとrating1以上を併せて指定したとき400を返す", body: `{"is_starred": false, "rating": 3}`},
+		{name: "is_starred=trueとrating0を併せて指定したとき400を返す", body: `{"is_starred": true, "rating": 0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			called := false
+			stateSvc := &mockItemStateService{
+				updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
+					called = true
+					return &model.ItemState{}, nil
+				},
+			}
+			h := NewItemHandler(&mockItemService{}, stateSvc)
+			req := httptest.NewRequest(http.MethodPut, "/api/items/item-1/state", bytes.NewBufferString(tt.body))
+			req = withUserID(req, "user-123")
+			req = withChiURLParam(req, "id", "item-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.UpdateItemState(w, req)
+
+			// Assert
+			if w.Result().StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Result().StatusCode, http.StatusBadRequest)
+			}
+			if errResp := parseAPIErrorResponse(t, w); errResp["code"] != model.ErrCodeInvalidRequest {
+				t.Errorf("code = %q, want %q", errResp["code"], model.ErrCodeInvalidRequest)
+			}
+			if called {
+				t.Error("不正な rating で service が呼ばれた")
+			}
+		})
+	}
+}
+
 func TestItemHandler_UpdateItemState_EmptyBody_ReturnsBadRequest(t *testing.T) {
 	h := NewItemHandler(&mockItemService{}, &mockItemStateService{})
 
@@ -780,7 +1685,7 @@ func TestItemHandler_UpdateItemState_NoUserID_ReturnsUnauthorized(t *testing.T)
 
 func TestItemHandler_UpdateItemState_ItemNotFound_ReturnsNotFound(t *testing.T) {
 	stateSvc := &mockItemStateService{
-		updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 			return nil, model.NewItemNotFoundError(itemID)
 		},
 	}
@@ -806,7 +1711,7 @@ func TestItemHandler_UpdateItemState_Idempotent(t *testing.T) {
 	// 同じ状態を2回設定しても同じ結果が返されることを検証（冪等性）
 	callCount := 0
 	stateSvc := &mockItemStateService{
-		updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 			callCount++
 			return &model.ItemState{
 				ItemID:    "item-1",
@@ -848,11 +1753,79 @@ func TestItemHandler_UpdateItemState_Idempotent(t *testing.T) {
 	}
 }
 
+// --- POST /api/items/:id/read-beacon テスト ---
+
+func TestItemHandler_ReadBeacon_MarksReadAndReturnsNoContent(t *testing.T) {
+	var gotItemID string
+	var gotIsRead, gotIsStarred *bool
+	stateSvc := &mockItemStateService{
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
+			gotItemID, gotIsRead, gotIsStarred = itemID, isRead, isStarred
+			return &model.ItemState{ItemID: itemID, UserID: userID, IsRead: true}, nil
+		},
+	}
+
+	h := NewItemHandler(&mockItemService{}, stateSvc)
+
+	// sendBeacon はボディなし（または text/plain）で送られる
+	req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/read-beacon", nil)
+	req = withUserID(req, "user-123")
+	req = withChiURLParam(req, "id", "item-1")
+	w := httptest.NewRecorder()
+
+	h.ReadBeacon(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("body = %q, want empty", w.Body.String())
+	}
+	if gotItemID != "item-1" || gotIsRead == nil || !*gotIsRead || gotIsStarred != nil {
+		t.Errorf("UpdateState args = (%q, %v, %v), want (item-1, true, nil)", gotItemID, gotIsRead, gotIsStarred)
+	}
+}
+
+func TestItemHandler_ReadBeacon_ServiceError_StillReturnsNoContent(t *testing.T) {
+	stateSvc := &mockItemStateService{
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
+			return nil, model.NewItemNotFoundError(itemID)
+		},
+	}
+
+	h := NewItemHandler(&mockItemService{}, stateSvc)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/items/item-x/read-beacon", nil)
+	req = withUserID(req, "user-123")
+	req = withChiURLParam(req, "id", "item-x")
+	w := httptest.NewRecorder()
+
+	h.ReadBeacon(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestItemHandler_ReadBeacon_NoUserID_ReturnsUnauthorized(t *testing.T) {
+	h := NewItemHandler(&mockItemService{}, &mockItemStateService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/read-beacon", nil)
+	req = withChiURLParam(req, "id", "item-1")
+	w := httptest.NewRecorder()
+
+	h.ReadBeacon(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
 // --- ルーティングテスト ---
 
 func TestSetupItemRoutes_ListItemsEndpoint(t *testing.T) {
 	svc := &mockItemService{
-		listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+		listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 			return &itemListResult{Items: []itemSummaryResponse{}}, nil
 		},
 	}
@@ -903,7 +1876,7 @@ func TestSetupItemRoutes_GetItemEndpoint(t *testing.T) {
 
 func TestSetupItemRoutes_UpdateStateEndpoint(t *testing.T) {
 	stateSvc := &mockItemStateService{
-		updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+		updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 			return &model.ItemState{
 				ItemID:    itemID,
 				UserID:    userID,
@@ -968,7 +1941,7 @@ func TestItemHandler_ListStarredItems_Success(t *testing.T) {
 	// Arrange
 	now := time.Now().UTC().Truncate(time.Second)
 	svc := &mockItemService{
-		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error) {
+		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
 			if userID != "user-123" {
 				t.Errorf("userID = %q, want %q", userID, "user-123")
 			}
@@ -1004,7 +1977,7 @@ func TestItemHandler_ListStarredItems_Success(t *testing.T) {
 						FeedTitle: "Feed Two",
 					},
 				},
-				NextCursor: now.Add(-time.Hour).Format(time.RFC3339Nano),
+				NextCursor: nullableString(now.Add(-time.Hour).Format(time.RFC3339Nano)),
 				HasMore:    true,
 			}, nil
 		},
@@ -1024,8 +1997,8 @@ func TestItemHandler_ListStarredItems_Success(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
-	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", ct, "application/json; charset=utf-8")
 	}
 
 	var result map[string]interface{}
@@ -1079,7 +2052,7 @@ func TestItemHandler_ListStarredItems_WithCursor(t *testing.T) {
 	// Arrange
 	receivedCursor := ""
 	svc := &mockItemService{
-		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error) {
+		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
 			receivedCursor = cursor
 			return &starredItemListResult{Items: []starredItemSummaryResponse{}}, nil
 		},
@@ -1104,13 +2077,115 @@ func TestItemHandler_ListStarredItems_WithCursor(t *testing.T) {
 	}
 }
 
+// TestItemHandler_ListStarredItems_LinkStatusFilter は link_status クエリパラメータが
+// リンク切れ絞り込みとして service 層に伝搬し、不正値は 400 になることを検証する。
+func TestItemHandler_ListStarredItems_LinkStatusFilter(t *testing.T) {
+	t.Run("link_status=broken のときリンク切れのみに絞り込んで取得する", func(t *testing.T) {
+		// Arrange
+		var receivedBrokenLinkOnly bool
+		svc := &mockItemService{
+			listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
+				receivedBrokenLinkOnly = filter.BrokenLinkOnly
+				return &starredItemListResult{Items: []starredItemSummaryResponse{}}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/starred/items?link_status=broken", nil)
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListStarredItems(w, req)
+
+		// Assert
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Result().StatusCode, http.StatusOK)
+		}
+		if !receivedBrokenLinkOnly {
+			t.Error("brokenLinkOnly = false, want true")
+		}
+	})
+
+	t.Run("link_status が不正な値のとき 400 INVALID_REQUEST を返す", func(t *testing.T) {
+		// Arrange
+		called := false
+		svc := &mockItemService{
+			listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
+				called = true
+				return &starredItemListResult{}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/starred/items?link_status=ok", nil)
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListStarredItems(w, req)
+
+		// Assert
+		if w.Result().StatusCode != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Result().StatusCode, http.StatusBadRequest)
+		}
+		if errResp := parseAPIErrorResponse(t, w); errResp["code"] != model.ErrCodeInvalidRequest {
+			t.Errorf("code = %q, want %q", errResp["code"], model.ErrCodeInvalidRequest)
+		}
+		if called {
+			t.Error("不正な link_status で service が呼ばれた")
+		}
+	})
+}
+
+func TestItemHandler_ListStarredItems_RatingFilterAndSort(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantFilter model.StarredItemFilter
+	}{
+		{name: "min_ratingを指定したとき評価で絞り込む", query: "min_rating=3", wantStatus: http.StatusOK, wantFilter: model.StarredItemFilter{MinRating: 3}},
+		{name: "sort=ratingのとき評価順で取得する", query: "sort=rating", wantStatus: http.StatusOK, wantFilter: model.StarredItemFilter{SortByRating: true}},
+		{name: "sort=published_atのとき公開日時順で取得する", query: "sort=published_at&link_status=broken", wantStatus: http.StatusOK, wantFilter: model.StarredItemFilter{BrokenLinkOnly: true}},
+		{name: "min_ratingが範囲外のとき400を返す", query: "min_rating=0", wantStatus: http.StatusBadRequest},
+		{name: "sortが不正な値のとき400を返す", query: "sort=title", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var received model.StarredItemFilter
+			svc := &mockItemService{
+				listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
+					received = filter
+					return &starredItemListResult{Items: []starredItemSummaryResponse{}}, nil
+				},
+			}
+			h := NewItemHandler(svc, &mockItemStateService{})
+			req := httptest.NewRequest(http.MethodGet, "/api/feeds/starred/items?"+tt.query, nil)
+			req = withUserID(req, "user-123")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.ListStarredItems(w, req)
+
+			// Assert
+			if w.Result().StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Result().StatusCode, tt.wantStatus)
+			}
+			if received != tt.wantFilter {
+				t.Errorf("filter = %+v, want %+v", received, tt.wantFilter)
+			}
+		})
+	}
+}
+
 // TestItemHandler_ListStarredItems_InvalidCursor_ReturnsBadRequest は service 層が
 // model.NewInvalidFilterError を返したときに 400 にマップされることを検証する
 // （Requirement 4.8）。
 func TestItemHandler_ListStarredItems_InvalidCursor_ReturnsBadRequest(t *testing.T) {
 	// Arrange
 	svc := &mockItemService{
-		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error) {
+		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
 			return nil, model.NewInvalidFilterError("無効なカーソル値: " + cursor)
 		},
 	}
@@ -1141,7 +2216,7 @@ func TestItemHandler_ListStarredItems_InvalidCursor_ReturnsBadRequest(t *testing
 func TestItemHandler_ListStarredItems_EmptyResult(t *testing.T) {
 	// Arrange
 	svc := &mockItemService{
-		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error) {
+		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
 			return &starredItemListResult{
 				Items:   []starredItemSummaryResponse{},
 				HasMore: false,
@@ -1182,9 +2257,9 @@ func TestItemHandler_ListStarredItems_EmptyResult(t *testing.T) {
 		t.Error("expected has_more=false in response")
 	}
 
-	// next_cursor は has_more=false のとき omit される（omitempty）
-	if v, ok := result["next_cursor"]; ok && v != "" {
-		t.Errorf("expected next_cursor to be absent or empty, got %v", v)
+	// next_cursor は has_more=false のとき null になる
+	if v, ok := result["next_cursor"]; !ok || v != nil {
+		t.Errorf("expected next_cursor to be null, got %v", v)
 	}
 }
 
@@ -1195,7 +2270,7 @@ func TestItemHandler_ListStarredItems_EmptyResult(t *testing.T) {
 func TestItemHandler_ListStarredItems_EmptyResult_NilItems(t *testing.T) {
 	// Arrange
 	svc := &mockItemService{
-		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int) (*starredItemListResult, error) {
+		listStarredItemsFn: func(ctx context.Context, userID, cursor string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
 			return &starredItemListResult{Items: nil, HasMore: false}, nil
 		},
 	}
@@ -1220,3 +2295,119 @@ func TestItemHandler_ListStarredItems_EmptyResult_NilItems(t *testing.T) {
 		t.Errorf("expected items=[] in JSON, got %s", string(bodyBytes))
 	}
 }
+
+func TestItemHandler_ListItems_IfModifiedSince(t *testing.T) {
+	modTime := time.Date(2026, 7, 7, 12, 0, 0, 0, time.UTC)
+
+	t.Run("If-Modified-Since以降に変更が無いとき記事一覧を取得せず304を返す", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotFeedID string
+		listCalled := false
+		svc := &mockItemService{
+			listModTimeFn: func(ctx context.Context, userID, feedID string) (time.Time, error) {
+				gotUserID, gotFeedID = userID, feedID
+				return modTime, nil
+			},
+			listItemsFn: func(context.Context, string, string, model.ItemConditions, string, string, int) (*itemListResult, error) {
+				listCalled = true
+				return &itemListResult{}, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items", nil)
+		req.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotModified {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNotModified)
+		}
+		if listCalled {
+			t.Error("304 のとき ListItems を呼ばないこと")
+		}
+		if gotUserID != "user-123" || gotFeedID != "feed-1" {
+			t.Errorf("ListModTime(userID=%q, feedID=%q), want (user-123, feed-1)", gotUserID, gotFeedID)
+		}
+	})
+
+	t.Run("If-Modified-Sinceより後に変更があるときLast-Modified付きで記事一覧を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listModTimeFn: func(context.Context, string, string) (time.Time, error) {
+				return modTime, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items", nil)
+		req.Header.Set("If-Modified-Since", modTime.Add(-time.Second).Format(http.TimeFormat))
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if lm := w.Header().Get("Last-Modified"); lm != modTime.Format(http.TimeFormat) {
+			t.Errorf("Last-Modified = %q, want %q", lm, modTime.Format(http.TimeFormat))
+		}
+	})
+
+	t.Run("group_dates=trueのときIf-Modified-Sinceを評価せずLast-Modifiedなしで記事一覧を返す", func(t *testing.T) {
+		// Arrange
+		modTimeCalled := false
+		svc := &mockItemService{
+			listModTimeFn: func(context.Context, string, string) (time.Time, error) {
+				modTimeCalled = true
+				return modTime, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?group_dates=true", nil)
+		req.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if modTimeCalled {
+			t.Error("group_dates=true のとき ListModTime を呼ばないこと")
+		}
+		if lm := w.Header().Get("Last-Modified"); lm != "" {
+			t.Errorf("Last-Modified = %q, want empty", lm)
+		}
+	})
+
+	t.Run("不正な絞り込み条件のときIf-Modified-Sinceに関わらずINVALID_FILTERを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemService{
+			listModTimeFn: func(context.Context, string, string) (time.Time, error) {
+				return modTime, nil
+			},
+		}
+		h := NewItemHandler(svc, &mockItemStateService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/items?unread=maybe", nil)
+		req.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
@@ -2,10 +2,8 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,13 +12,6 @@ import (
 	"github.com/hitoshi/feedman/internal/model"
 )
 
-// 検索リクエストの limit パラメータの既定値と上限値。
-//
-// design.md「API Contract」節に従い、limit クエリ未指定時は defaultItemsPerPage を使い、
-// maxSearchLimit を超える指定はクランプする。サービス層（`itemsearch.SearchService`）も
-// 同じクランプを防御的に行うため、handler 層の値はそのまま下層に伝搬しても安全である。
-const maxSearchLimit = 200
-
 // ItemSearchServiceInterface は記事検索ハンドラが必要とするサービスインターフェース。
 //
 // 戻り値は handler 内部レスポンス型（`*itemSearchResponse`）にすることで、サービス層と
@@ -55,12 +46,12 @@ func NewItemSearchHandler(service ItemSearchServiceInterface) *ItemSearchHandler
 //
 // favicon_url は data URL 形式（`data:<mime>;base64,...`）。Service 層から渡された
 // 生バイト + MIME を Adapter 層で組み立てた結果が入る。欠落時は nil を入れ、JSON では
-// `omitempty` でフィールドごと省略する（既存 subscription レスポンスと同じ流儀）。
+// null となる（既存 subscription レスポンスと同じ流儀）。
 type itemSearchHitResponse struct {
 	ID              string    `json:"id"`
 	FeedID          string    `json:"feed_id"`
 	FeedTitle       string    `json:"feed_title"`
-	FaviconURL      *string   `json:"favicon_url,omitempty"`
+	FaviconURL      *string   `json:"favicon_url"`
 	Title           string    `json:"title"`
 	Link            string    `json:"link"`
 	Summary         string    `json:"summary"`
@@ -73,13 +64,13 @@ type itemSearchHitResponse struct {
 
 // itemSearchResponse は GET /api/items/search のレスポンス。
 //
-// next_cursor は次ページ取得用のカーソル文字列（`<RFC3339Nano>|<id>` 形式）。
-// 末尾ページ・空結果のときは空文字となる（`omitempty` で省略）。has_more は次ページの
+// next_cursor は次ページ取得用のカーソル文字列（pagination の不透明トークン）。
+// 末尾ページ・空結果のときは null となる。has_more は次ページの
 // 存在を示し、cursor を発行できない場合（末尾項目の PublishedAt がゼロ値等）でも
-// true を返しうるため、UI 側は next_cursor の空判定だけでなく has_more も参照する。
+// true を返しうるため、UI 側は next_cursor の null 判定だけでなく has_more も参照する。
 type itemSearchResponse struct {
 	Items      []itemSearchHitResponse `json:"items"`
-	NextCursor string                  `json:"next_cursor,omitempty"`
+	NextCursor *string                 `json:"next_cursor"`
 	HasMore    bool                    `json:"has_more"`
 }
 
@@ -89,9 +80,9 @@ type itemSearchResponse struct {
 //   - q      : 検索キーワード（必須に近いが、空クエリは 200 OK で空配列を返す。Req 1.5）
 //   - feed_id: フィード内検索のスコープ指定（任意、UUID 形式）。
 //     形式不正は 400 INVALID_SEARCH_QUERY、未購読は 403 FEED_NOT_SUBSCRIBED。
-//   - cursor : ページネーションのカーソル（任意、`<RFC3339Nano>|<uuid>` 形式）。
+//   - cursor : ページネーションのカーソル（任意、前回レスポンスの next_cursor）。
 //     形式不正は 400 INVALID_SEARCH_QUERY（サービス層で判定）。
-//   - limit  : 1 ページあたり件数（任意、既定 50、上限 200 でクランプ）。
+//   - limit  : 1 ページあたり件数（任意、既定 50、上限 200 でクランプ。いずれも設定で変更可）。
 //
 // エラーレスポンス:
 //   - 401 UNAUTHORIZED        : セッションなし
@@ -104,8 +95,8 @@ type itemSearchResponse struct {
 func (h *ItemSearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -117,7 +108,6 @@ func (h *ItemSearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	rawQuery := q.Get("q")
 	feedIDStr := q.Get("feed_id")
 	cursor := q.Get("cursor")
-	limitStr := q.Get("limit")
 
 	// feed_id の UUID パース（空文字 = 横断検索）
 	var feedIDPtr *string
@@ -125,10 +115,7 @@ func (h *ItemSearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	searchType := "global"
 	if feedIDStr != "" {
 		if _, parseErr := uuid.Parse(feedIDStr); parseErr != nil {
-			middleware.WriteErrorResponse(
-				w, http.StatusBadRequest,
-				model.NewInvalidSearchQueryError("feed_id の形式が不正です"),
-			)
+			WriteError(w, model.NewInvalidSearchQueryError("feed_id の形式が不正です"))
 			return
 		}
 		feedIDPtr = &feedIDStr
@@ -136,21 +123,12 @@ func (h *ItemSearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 		searchType = "feed"
 	}
 
-	// limit のパース（未指定は既定値 / 形式不正は 400 / 上限を超える指定はクランプ）
-	limit := defaultItemsPerPage
-	if limitStr != "" {
-		n, parseErr := strconv.Atoi(limitStr)
-		if parseErr != nil || n <= 0 {
-			middleware.WriteErrorResponse(
-				w, http.StatusBadRequest,
-				model.NewInvalidSearchQueryError("limit の形式が不正です"),
-			)
-			return
-		}
-		if n > maxSearchLimit {
-			n = maxSearchLimit
-		}
-		limit = n
+	// limit のパース（未指定は既定値 / 形式不正は 400 / 上限を超える指定はクランプ）。
+	// サービス層（`itemsearch.SearchService`）も独自の上限で防御的にクランプする。
+	limit, err := parsePageLimit(r)
+	if err != nil {
+		WriteError(w, model.NewInvalidSearchQueryError("limit の形式が不正です"))
+		return
 	}
 
 	// NFR 3.1: 検索リクエストの認証主体・検索種別・検索範囲・クエリ長・スコープ feed_id を
@@ -165,7 +143,7 @@ func (h *ItemSearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.service.Search(r.Context(), userID, rawQuery, feedIDPtr, cursor, limit)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
@@ -174,6 +152,5 @@ func (h *ItemSearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 		result.Items = []itemSearchHitResponse{}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	WriteJSON(w, http.StatusOK, result)
 }
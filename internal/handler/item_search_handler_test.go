@@ -80,8 +80,8 @@ func TestItemSearchHandler_Search_Global_Success(t *testing.T) {
 			if feedID != nil {
 				t.Errorf("feedID = %v, want nil (global search)", *feedID)
 			}
-			if limit != defaultItemsPerPage {
-				t.Errorf("limit = %d, want %d", limit, defaultItemsPerPage)
+			if limit != DefaultPageLimit {
+				t.Errorf("limit = %d, want %d", limit, DefaultPageLimit)
 			}
 			return &itemSearchResponse{
 				Items: []itemSearchHitResponse{
@@ -96,7 +96,7 @@ func TestItemSearchHandler_Search_Global_Success(t *testing.T) {
 						PublishedAt: now,
 					},
 				},
-				NextCursor: now.Format(time.RFC3339Nano) + "|item-1",
+				NextCursor: nullableString(now.Format(time.RFC3339Nano) + "|item-1"),
 				HasMore:    true,
 			}, nil
 		},
@@ -115,8 +115,8 @@ func TestItemSearchHandler_Search_Global_Success(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
-	if got := resp.Header.Get("Content-Type"); got != "application/json" {
-		t.Errorf("Content-Type = %q, want application/json", got)
+	if got := resp.Header.Get("Content-Type"); got != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want application/json; charset=utf-8", got)
 	}
 
 	var body map[string]interface{}
@@ -368,17 +368,17 @@ func TestItemSearchHandler_Search_ServiceError_ReturnsInternalServerError(t *tes
 // --- limit のクランプ確認 ---
 
 // TestItemSearchHandler_Search_LimitClamp は limit クエリパラメータが上限値を超えた
-// ときに maxSearchLimit にクランプされることを検証する。
+// ときに DefaultMaxPageLimit にクランプされることを検証する。
 func TestItemSearchHandler_Search_LimitClamp(t *testing.T) {
 	cases := []struct {
 		name      string
 		limitStr  string
 		wantLimit int
 	}{
-		{"unspecified -> default", "", defaultItemsPerPage},
+		{"unspecified -> default", "", DefaultPageLimit},
 		{"valid -> as-is", "25", 25},
-		{"at max -> as-is", "200", maxSearchLimit},
-		{"over max -> clamped", "500", maxSearchLimit},
+		{"at max -> as-is", "200", DefaultMaxPageLimit},
+		{"over max -> clamped", "500", DefaultMaxPageLimit},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
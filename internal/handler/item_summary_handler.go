@@ -0,0 +1,67 @@
+// Package handler の item_summary_handler.go は、記事の要約をオンデマンドで生成する HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - POST /api/items/{id}/summarize : 記事の要約を生成して返す（生成済みの場合は保存済みの要約を返す）
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// ItemSummaryServiceInterface は記事要約ハンドラが必要とするサービスインターフェース。
+type ItemSummaryServiceInterface interface {
+	// Summarize は記事の要約を返す。保存済みの要約が無い場合は要約器で生成する。
+	// 記事が無い場合は ITEM_NOT_FOUND、回数制限を超えた場合は SUMMARIZE_RATE_LIMITED、
+	// 要約を生成できない場合は SUMMARY_UNAVAILABLE を返す。
+	Summarize(ctx context.Context, userID, itemID string) (*itemSummarizeResponse, error)
+}
+
+// ItemSummaryHandler は記事要約の HTTP ハンドラ。
+type ItemSummaryHandler struct {
+	service ItemSummaryServiceInterface
+}
+
+// NewItemSummaryHandler は ItemSummaryHandler を生成する。
+func NewItemSummaryHandler(service ItemSummaryServiceInterface) *ItemSummaryHandler {
+	return &ItemSummaryHandler{service: service}
+}
+
+// itemSummarizeResponse は POST /api/items/{id}/summarize のレスポンス。
+// cached は保存済みの要約を返した場合、fallback は要約 API が失敗してローカルの要約器で生成した場合に true
+// （フォールバックの要約は保存せず、記事一覧の generated_summary には反映されない）。
+type itemSummarizeResponse struct {
+	ItemID      string    `json:"item_id"`
+	Summary     string    `json:"summary"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Cached      bool      `json:"cached"`
+	Fallback    bool      `json:"fallback"`
+}
+
+// Summarize は記事の要約を生成して返す。
+// POST /api/items/{id}/summarize
+func (h *ItemSummaryHandler) Summarize(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.Summarize(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
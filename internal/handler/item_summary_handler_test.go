@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockItemSummaryService は ItemSummaryServiceInterface のモック実装。
+type mockItemSummaryService struct {
+	summarizeFn func(ctx context.Context, userID, itemID string) (*itemSummarizeResponse, error)
+}
+
+func (m *mockItemSummaryService) Summarize(ctx context.Context, userID, itemID string) (*itemSummarizeResponse, error) {
+	return m.summarizeFn(ctx, userID, itemID)
+}
+
+func TestItemSummaryHandler_Summarize(t *testing.T) {
+	t.Run("要約を生成できたとき200で要約を返す", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotItemID string
+		generatedAt := time.Date(2026, 7, 2, 12, 0, 0, 0, time.UTC)
+		svc := &mockItemSummaryService{
+			summarizeFn: func(_ context.Context, userID, itemID string) (*itemSummarizeResponse, error) {
+				gotUserID, gotItemID = userID, itemID
+				return &itemSummarizeResponse{ItemID: itemID, Summary: "要約です。", GeneratedAt: generatedAt}, nil
+			},
+		}
+		h := NewItemSummaryHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/summarize", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Summarize(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["item_id"] != "item-1" || body["summary"] != "要約です。" || body["cached"] != false || body["fallback"] != false {
+			t.Errorf("body = %v", body)
+		}
+		if gotUserID != "user-1" || gotItemID != "item-1" {
+			t.Errorf("service called with (%q, %q)", gotUserID, gotItemID)
+		}
+	})
+
+	t.Run("回数制限を超えたとき429を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemSummaryService{
+			summarizeFn: func(context.Context, string, string) (*itemSummarizeResponse, error) {
+				return nil, model.NewSummarizeRateLimitedError(120)
+			},
+		}
+		h := NewItemSummaryHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/summarize", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Summarize(w, req)
+
+		// Assert
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+		}
+	})
+
+	t.Run("未認証のとき401を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemSummaryService{
+			summarizeFn: func(context.Context, string, string) (*itemSummarizeResponse, error) {
+				t.Error("service should not be called")
+				return nil, nil
+			},
+		}
+		h := NewItemSummaryHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/summarize", nil)
+		req = withChiURLParam(req, "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Summarize(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
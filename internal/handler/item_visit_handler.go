@@ -0,0 +1,59 @@
+// Package handler の item_visit_handler.go は、元記事ページへの訪問（リダイレクト）の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/items/{id}/visit : 記事を既読化・訪問記録した上で元記事へ 302 リダイレクト
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// ItemVisitServiceInterface は記事訪問ハンドラが必要とするサービスインターフェース。
+type ItemVisitServiceInterface interface {
+	// Visit は記事を既読にして最終訪問日時を記録し、元記事の URL を返す。
+	// 記事が無い場合は ITEM_NOT_FOUND、元記事のリンクが無い場合は ITEM_LINK_UNAVAILABLE を返す。
+	Visit(ctx context.Context, userID, itemID string) (string, error)
+}
+
+// ItemVisitHandler は記事訪問の HTTP ハンドラ。
+type ItemVisitHandler struct {
+	service ItemVisitServiceInterface
+}
+
+// NewItemVisitHandler は ItemVisitHandler を生成する。
+func NewItemVisitHandler(service ItemVisitServiceInterface) *ItemVisitHandler {
+	return &ItemVisitHandler{service: service}
+}
+
+// Visit は記事を既読化・訪問記録し、元記事へ 302 リダイレクトする。
+// フロントエンドはこの URL をリンク先にするだけで既読処理が完結する。
+// GET /api/items/{id}/visit
+func (h *ItemVisitHandler) Visit(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	itemID := chi.URLParam(r, "id")
+
+	link, err := h.service.Visit(r.Context(), userID, itemID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	// リダイレクトがキャッシュされると 2 回目以降の訪問でサーバーを経由せず記録されないため、保存を禁止する
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, link, http.StatusFound)
+}
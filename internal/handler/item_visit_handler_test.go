@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockItemVisitService は ItemVisitServiceInterface のモック実装。
+type mockItemVisitService struct {
+	visitFn    func(ctx context.Context, userID, itemID string) (string, error)
+	visitCalls int
+}
+
+func (m *mockItemVisitService) Visit(ctx context.Context, userID, itemID string) (string, error) {
+	m.visitCalls++
+	if m.visitFn != nil {
+		return m.visitFn(ctx, userID, itemID)
+	}
+	return "https://example.com/article", nil
+}
+
+func TestItemVisitHandler_Visit(t *testing.T) {
+	t.Run("訪問に成功したとき元記事へ302リダイレクトする", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotItemID string
+		svc := &mockItemVisitService{
+			visitFn: func(_ context.Context, userID, itemID string) (string, error) {
+				gotUserID, gotItemID = userID, itemID
+				return "https://example.com/article?id=1", nil
+			},
+		}
+		h := NewItemVisitHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-1/visit", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Visit(w, req)
+
+		// Assert
+		if w.Code != http.StatusFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+		}
+		if got := w.Header().Get("Location"); got != "https://example.com/article?id=1" {
+			t.Errorf("Location = %q, want %q", got, "https://example.com/article?id=1")
+		}
+		if got := w.Header().Get("Cache-Control"); got != "no-store" {
+			t.Errorf("Cache-Control = %q, want %q", got, "no-store")
+		}
+		if gotUserID != "user-1" || gotItemID != "item-1" {
+			t.Errorf("service called with (%q, %q)", gotUserID, gotItemID)
+		}
+	})
+
+	t.Run("記事が見つからないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemVisitService{
+			visitFn: func(context.Context, string, string) (string, error) {
+				return "", model.NewItemNotFoundError("item-x")
+			},
+		}
+		h := NewItemVisitHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-x/visit", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "item-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Visit(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if w.Header().Get("Location") != "" {
+			t.Errorf("Location should be empty, got %q", w.Header().Get("Location"))
+		}
+	})
+
+	t.Run("元記事のリンクが無いとき404とITEM_LINK_UNAVAILABLEを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemVisitService{
+			visitFn: func(context.Context, string, string) (string, error) {
+				return "", model.NewItemLinkUnavailableError("item-1")
+			},
+		}
+		h := NewItemVisitHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-1/visit", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Visit(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		body := parseAPIErrorResponse(t, w)
+		if body["code"] != model.ErrCodeItemLinkUnavailable {
+			t.Errorf("code = %q, want %q", body["code"], model.ErrCodeItemLinkUnavailable)
+		}
+	})
+
+	t.Run("サービスが予期しないエラーを返したとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemVisitService{
+			visitFn: func(context.Context, string, string) (string, error) {
+				return "", errors.New("db error")
+			},
+		}
+		h := NewItemVisitHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-1/visit", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "item-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Visit(w, req)
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+
+	t.Run("未認証のとき401を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemVisitService{}
+		h := NewItemVisitHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-1/visit", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Visit(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+		if svc.visitCalls != 0 {
+			t.Errorf("Visit calls = %d, want 0", svc.visitCalls)
+		}
+	})
+}
+
+// TestNewRouter_ItemVisitRoutes は記事訪問ルートの登録を検証する。
+func TestNewRouter_ItemVisitRoutes(t *testing.T) {
+	newRouter := func(svc ItemVisitServiceInterface) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+		}
+		if svc != nil {
+			deps.ItemVisitService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler, withSession bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/items/item-1/visit", nil)
+		if withSession {
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("セッションありのとき元記事へリダイレクトする", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotItemID string
+		router := newRouter(&mockItemVisitService{
+			visitFn: func(_ context.Context, userID, itemID string) (string, error) {
+				gotUserID, gotItemID = userID, itemID
+				return "https://example.com/article", nil
+			},
+		})
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusFound)
+		}
+		if gotUserID != "user-test-1" || gotItemID != "item-1" {
+			t.Errorf("service called with (%q, %q)", gotUserID, gotItemID)
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockItemVisitService{}
+		router := newRouter(svc)
+
+		// Act
+		w := doRequest(router, false)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+		if svc.visitCalls != 0 {
+			t.Errorf("Visit calls = %d, want 0", svc.visitCalls)
+		}
+	})
+
+	t.Run("ItemVisitService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil)
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
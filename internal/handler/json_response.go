@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+)
+
+// WriteJSON は成功レスポンスを統一フォーマットの JSON で書き込む。
+// 日時は RFC3339 UTC、配列は null ではなく []、値のない任意項目は null に揃える。
+// ハンドラは json.NewEncoder を直接使わず本関数を経由すること。
+func WriteJSON(w http.ResponseWriter, statusCode int, v any) {
+	middleware.WriteJSON(w, statusCode, v)
+}
+
+// nullableString は空文字を nil に変換する。
+// 値がないことを省略ではなく null で表す任意項目（next_cursor 等）に使う。
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nullablePositiveInt は 0 以下の値を nil に変換する。
+// 指定がないことを 0 ではなく null で表す任意項目（ttl_minutes 等）に使う。
+func nullablePositiveInt(n int) *int {
+	if n <= 0 {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// updateGolden が指定された場合はゴールデンファイルを現在の出力で上書きする。
+// go test ./internal/handler -run TestWriteJSON_Golden -update
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// goldenJST はタイムゾーン付き日時が UTC に正規化されることを確認するための固定ゾーン。
+var goldenJST = time.FixedZone("JST", 9*60*60)
+
+// TestWriteJSON_Golden は代表的なレスポンス型のシリアライズ結果を
+// testdata/golden 配下のゴールデンファイルと突き合わせる。
+// 日時形式・null の扱い・フィールド名が意図せず変わった場合に検出する。
+func TestWriteJSON_Golden(t *testing.T) {
+	publishedAt := time.Date(2026, 6, 1, 9, 30, 0, 123456000, goldenJST)
+	createdAt := time.Date(2026, 5, 31, 18, 0, 0, 0, goldenJST)
+	faviconURL := "data:image/png;base64,AAAA"
+	errorKind := "http_4xx"
+	errorMessage := "HTTP 404"
+	suggestedFeedURL := "https://example.com/new-feed.xml"
+	pendingFeedTitle := "Example Feed (Renamed)"
+
+	tests := []struct {
+		name   string
+		status int
+		value  any
+	}{
+		{
+			name:   "subscription_list",
+			status: http.StatusOK,
+			value: []subscriptionResponse{
+				{
+					ID:                    "sub-1",
+					UserID:                "user-1",
+					FeedID:                "feed-1",
+					FeedTitle:             "Example Feed",
+					FeedURL:               "https://example.com/feed.xml",
+					FaviconURL:            &faviconURL,
+					FetchIntervalMinutes:  60,
+					FeedStatus:            "stopped",
+					ErrorMessage:          &errorMessage,
+					ErrorKind:             &errorKind,
+					FeedLastPublishedAt:   &publishedAt,
+					Priority:              "high",
+					MuteUntil:             &publishedAt,
+					ExpiresAt:             &publishedAt,
+					SuggestedFeedURL:      &suggestedFeedURL,
+					FeedTitleUpdatePolicy: "manual",
+					PendingFeedTitle:      &pendingFeedTitle,
+					CreatedAt:             createdAt,
+				},
+				{
+					ID:                    "sub-2",
+					UserID:                "user-1",
+					FeedID:                "feed-2",
+					FeedTitle:             "No Favicon",
+					FeedURL:               "https://example.org/rss",
+					FetchIntervalMinutes:  30,
+					FeedStatus:            "active",
+					Priority:              "normal",
+					FeedTitleUpdatePolicy: "always",
+					CreatedAt:             createdAt,
+				},
+			},
+		},
+		{
+			name:   "item_list_last_page",
+			status: http.StatusOK,
+			value: itemListResult{
+				Items: []itemSummaryResponse{
+					{
+						ID:                 "item-1",
+						FeedID:             "feed-1",
+						Title:              "記事タイトル",
+						Link:               "https://example.com/posts/1?a=1&b=2",
+						Summary:            "<p>概要</p>",
+						PublishedAt:        publishedAt,
+						HatebuCount:        3,
+						ReadingTimeMinutes: 2,
+					},
+				},
+			},
+		},
+		{
+			name:   "item_list_empty",
+			status: http.StatusOK,
+			value:  itemListResult{},
+		},
+		{
+			name:   "audit_log_list",
+			status: http.StatusOK,
+			value: auditLogListResponse{
+				Logs: []auditLogResponse{
+					{ID: "log-2", Action: "subscription.delete", Target: "feed-1", CreatedAt: publishedAt},
+					{ID: "log-1", Action: "subscription.create", Target: "feed-1", Payload: map[string]any{"feed_url": "https://example.com/feed.xml"}, CreatedAt: createdAt},
+				},
+				NextCursor: nullableString("cursor-1"),
+				HasMore:    true,
+			},
+		},
+		{
+			name:   "team_detail",
+			status: http.StatusOK,
+			value: teamDetailResponse{
+				teamResponse: teamResponse{ID: "team-1", Name: "開発チーム", Role: "owner", CreatedAt: createdAt},
+				Members: []teamMemberResponse{
+					{UserID: "user-1", Name: "Owner", Role: "owner", JoinedAt: createdAt},
+				},
+			},
+		},
+		{
+			name:   "team_created",
+			status: http.StatusCreated,
+			value:  &teamResponse{ID: "team-1", Name: "開発チーム", Role: "owner", CreatedAt: createdAt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			rec := httptest.NewRecorder()
+
+			// Act
+			WriteJSON(rec, tt.status, tt.value)
+
+			// Assert
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+				t.Errorf("Content-Type = %q, want %q", ct, "application/json; charset=utf-8")
+			}
+
+			goldenPath := filepath.Join("testdata", "golden", tt.name+".json")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+					t.Fatalf("failed to create golden dir: %v", err)
+				}
+				if err := os.WriteFile(goldenPath, rec.Body.Bytes(), 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to create): %v", err)
+			}
+			if got := rec.Body.String(); got != string(want) {
+				t.Errorf("body mismatch with %s\ngot:\n%s\nwant:\n%s", goldenPath, got, want)
+			}
+		})
+	}
+}
+
+func TestNullableString(t *testing.T) {
+	t.Run("空文字のときnilを返す", func(t *testing.T) {
+		if got := nullableString(""); got != nil {
+			t.Errorf("nullableString(\"\") = %v, want nil", *got)
+		}
+	})
+
+	t.Run("値があるときそのポインタを返す", func(t *testing.T) {
+		got := nullableString("c1")
+		if got == nil || *got != "c1" {
+			t.Errorf("nullableString(\"c1\") = %v, want c1", got)
+		}
+	})
+}
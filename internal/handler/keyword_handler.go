@@ -0,0 +1,70 @@
+// Package handler の keyword_handler.go は、購読フィードの記事傾向サマリー（頻出キーワード）の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/subscriptions/{id}/keywords : 購読フィードの直近 30 日の頻出キーワード上位 10 件
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// KeywordServiceInterface は記事傾向サマリーハンドラが必要とするサービスインターフェース。
+type KeywordServiceInterface interface {
+	// GetSubscriptionKeywords は当該ユーザーの購読のフィードの頻出キーワードを返す。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+	GetSubscriptionKeywords(ctx context.Context, userID, subscriptionID string) (*subscriptionKeywordsResponse, error)
+}
+
+// KeywordHandler は記事傾向サマリーの HTTP ハンドラ。
+type KeywordHandler struct {
+	service KeywordServiceInterface
+}
+
+// NewKeywordHandler は KeywordHandler を生成する。
+func NewKeywordHandler(service KeywordServiceInterface) *KeywordHandler {
+	return &KeywordHandler{service: service}
+}
+
+// keywordResponse は頻出キーワード 1 件。count は直近 30 日の記事での出現回数。
+type keywordResponse struct {
+	Keyword string `json:"keyword"`
+	Count   int    `json:"count"`
+}
+
+// subscriptionKeywordsResponse は GET /api/subscriptions/{id}/keywords のレスポンス。
+// computed_at はキーワードを計算した日時で、未計算またはキーワードが 0 件の場合は null。
+type subscriptionKeywordsResponse struct {
+	FeedID     string            `json:"feed_id"`
+	ComputedAt *time.Time        `json:"computed_at"`
+	Keywords   []keywordResponse `json:"keywords"`
+}
+
+// GetSubscriptionKeywords は購読フィードの頻出キーワードを返す。
+// GET /api/subscriptions/{id}/keywords
+//
+// キーワードは worker が日次で事前計算した値を用いるため、直近の記事は次回の計算まで反映されない。
+func (h *KeywordHandler) GetSubscriptionKeywords(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.GetSubscriptionKeywords(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockKeywordService は KeywordServiceInterface のモック実装。
+type mockKeywordService struct {
+	getFn func(ctx context.Context, userID, subscriptionID string) (*subscriptionKeywordsResponse, error)
+}
+
+func (m *mockKeywordService) GetSubscriptionKeywords(ctx context.Context, userID, subscriptionID string) (*subscriptionKeywordsResponse, error) {
+	return m.getFn(ctx, userID, subscriptionID)
+}
+
+func TestKeywordHandler_GetSubscriptionKeywords(t *testing.T) {
+	t.Run("購読IDをサービスに渡し頻出キーワードを200で返すとき", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotSubID string
+		computedAt := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+		svc := &mockKeywordService{
+			getFn: func(_ context.Context, userID, subscriptionID string) (*subscriptionKeywordsResponse, error) {
+				gotUserID, gotSubID = userID, subscriptionID
+				return &subscriptionKeywordsResponse{
+					FeedID:     "feed-1",
+					ComputedAt: &computedAt,
+					Keywords:   []keywordResponse{{Keyword: "go", Count: 12}, {Keyword: "機械", Count: 5}},
+				}, nil
+			},
+		}
+		h := NewKeywordHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-1/keywords", nil)
+		req = withUserID(req, "user-1")
+		req = withChiURLParam(req, "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetSubscriptionKeywords(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotUserID != "user-1" || gotSubID != "sub-1" {
+			t.Errorf("userID = %q, subscriptionID = %q", gotUserID, gotSubID)
+		}
+		var resp struct {
+			FeedID     string  `json:"feed_id"`
+			ComputedAt *string `json:"computed_at"`
+			Keywords   []struct {
+				Keyword string `json:"keyword"`
+				Count   int    `json:"count"`
+			} `json:"keywords"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("レスポンスの解析に失敗: %v", err)
+		}
+		if resp.FeedID != "feed-1" || resp.ComputedAt == nil || len(resp.Keywords) != 2 {
+			t.Fatalf("resp = %+v", resp)
+		}
+		if resp.Keywords[0].Keyword != "go" || resp.Keywords[0].Count != 12 {
+			t.Errorf("keywords[0] = %+v", resp.Keywords[0])
+		}
+	})
+
+	t.Run("購読が見つからないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockKeywordService{
+			getFn: func(_ context.Context, _, subscriptionID string) (*subscriptionKeywordsResponse, error) {
+				return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		h := NewKeywordHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-x/keywords", nil)
+		req = withUserID(req, "user-1")
+		req = withChiURLParam(req, "id", "sub-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetSubscriptionKeywords(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if got := parseAPIErrorResponse(t, w)["code"]; got != model.ErrCodeSubscriptionNotFound {
+			t.Errorf("code = %q, want %q", got, model.ErrCodeSubscriptionNotFound)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewKeywordHandler(&mockKeywordService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-1/keywords", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetSubscriptionKeywords(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
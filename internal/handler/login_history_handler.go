@@ -0,0 +1,84 @@
+// Package handler の login_history_handler.go は、ログイン履歴の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/users/me/login-history?cursor=...&limit=50 : 自分のログイン成功・失敗・ログアウト・セッション失効（新しい順）
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// LoginHistoryServiceInterface はログイン履歴ハンドラが必要とするサービスインターフェース。
+type LoginHistoryServiceInterface interface {
+	// ListLoginHistory はユーザーのログインイベントを新しい順に返す。
+	// cursor は前ページの next_cursor（空なら先頭ページ）、limit が 0 の場合は既定件数とする。
+	// 不正なカーソルの場合は INVALID_FILTER を返す。
+	ListLoginHistory(ctx context.Context, userID, cursor string, limit int) (*loginHistoryListResponse, error)
+}
+
+// LoginHistoryHandler はログイン履歴の HTTP ハンドラ。
+type LoginHistoryHandler struct {
+	service LoginHistoryServiceInterface
+}
+
+// NewLoginHistoryHandler は LoginHistoryHandler を生成する。
+func NewLoginHistoryHandler(service LoginHistoryServiceInterface) *LoginHistoryHandler {
+	return &LoginHistoryHandler{service: service}
+}
+
+// loginEventResponse はログインイベント 1 件。
+type loginEventResponse struct {
+	ID    string `json:"id"`
+	Event string `json:"event"`
+	// Reason はログイン失敗の理由。それ以外のイベントでは null。
+	Reason *string `json:"reason"`
+	// IPAddress は接続元 IP の部分マスク値（例: "203.0.113.0/24"）。記録が無い場合は null。
+	IPAddress *string `json:"ip_address"`
+	// UserAgent は User-Agent の部分マスク値。記録が無い場合は null。
+	UserAgent *string   `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// loginHistoryListResponse は GET /api/users/me/login-history のレスポンス。
+type loginHistoryListResponse struct {
+	Events     []loginEventResponse `json:"events"`
+	NextCursor *string              `json:"next_cursor"`
+	HasMore    bool                 `json:"has_more"`
+}
+
+// ListLoginHistory は自分のログイン履歴を新しい順に返す。
+// GET /api/users/me/login-history?cursor=...&limit=50
+//
+// limit が不正な場合は 400 INVALID_REQUEST、cursor が不正な場合は 400 INVALID_FILTER を返す。
+func (h *LoginHistoryHandler) ListLoginHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	q := r.URL.Query()
+	limit, err := parsePageLimit(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	resp, err := h.service.ListLoginHistory(r.Context(), userID, q.Get("cursor"), limit)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockLoginHistoryService は LoginHistoryServiceInterface のモック実装。
+type mockLoginHistoryService struct {
+	listLoginHistoryFn    func(ctx context.Context, userID, cursor string, limit int) (*loginHistoryListResponse, error)
+	listLoginHistoryCalls int
+}
+
+func (m *mockLoginHistoryService) ListLoginHistory(ctx context.Context, userID, cursor string, limit int) (*loginHistoryListResponse, error) {
+	m.listLoginHistoryCalls++
+	if m.listLoginHistoryFn != nil {
+		return m.listLoginHistoryFn(ctx, userID, cursor, limit)
+	}
+	return &loginHistoryListResponse{Events: []loginEventResponse{}}, nil
+}
+
+// --- GET /api/users/me/login-history テスト ---
+
+func TestLoginHistoryHandler_ListLoginHistory(t *testing.T) {
+	t.Run("cursorとlimitを指定したときマスク済みの履歴と次ページのカーソルを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockLoginHistoryService{
+			listLoginHistoryFn: func(_ context.Context, userID, cursor string, limit int) (*loginHistoryListResponse, error) {
+				if userID != "user-1" || cursor != "c1" || limit != 2 {
+					t.Errorf("args = (%q, %q, %d), want (user-1, c1, 2)", userID, cursor, limit)
+				}
+				return &loginHistoryListResponse{
+					Events: []loginEventResponse{
+						{
+							ID:        "ev-1",
+							Event:     string(model.LoginEventFailure),
+							Reason:    nullableString("oauth_exchange_failed"),
+							IPAddress: nullableString("203.0.113.0/24"),
+							UserAgent: nullableString("Mozilla/5 (*) Firefox/127"),
+							CreatedAt: time.Date(2026, 7, 4, 9, 0, 0, 0, time.UTC),
+						},
+						{
+							ID:        "ev-2",
+							Event:     string(model.LoginEventSessionExpired),
+							CreatedAt: time.Date(2026, 7, 4, 8, 0, 0, 0, time.UTC),
+						},
+					},
+					NextCursor: nullableString("c2"),
+					HasMore:    true,
+				}, nil
+			},
+		}
+		h := NewLoginHistoryHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/login-history?cursor=c1&limit=2", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListLoginHistory(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["next_cursor"] != "c2" || body["has_more"] != true {
+			t.Errorf("body = %v", body)
+		}
+		events, ok := body["events"].([]interface{})
+		if !ok || len(events) != 2 {
+			t.Fatalf("events = %v, want 2 elements", body["events"])
+		}
+		failure := events[0].(map[string]interface{})
+		if failure["event"] != "login_failure" || failure["reason"] != "oauth_exchange_failed" ||
+			failure["ip_address"] != "203.0.113.0/24" || failure["user_agent"] != "Mozilla/5 (*) Firefox/127" ||
+			failure["created_at"] != "2026-07-04T09:00:00Z" {
+			t.Errorf("events[0] = %v", failure)
+		}
+		expired := events[1].(map[string]interface{})
+		if expired["event"] != "session_expired" || expired["reason"] != nil || expired["ip_address"] != nil || expired["user_agent"] != nil {
+			t.Errorf("events[1] = %v", expired)
+		}
+	})
+
+	t.Run("limitが不正なとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		for _, limit := range []string{"abc", "0", "-1"} {
+			// Arrange
+			svc := &mockLoginHistoryService{}
+			h := NewLoginHistoryHandler(svc)
+			req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/login-history?limit="+limit, nil), "user-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.ListLoginHistory(w, req)
+
+			// Assert
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("limit=%q: status = %d, want %d", limit, w.Code, http.StatusBadRequest)
+			}
+			if svc.listLoginHistoryCalls != 0 {
+				t.Errorf("limit=%q: ListLoginHistory calls = %d, want 0", limit, svc.listLoginHistoryCalls)
+			}
+		}
+	})
+
+	t.Run("cursorが不正なとき400 INVALID_FILTERを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockLoginHistoryService{
+			listLoginHistoryFn: func(_ context.Context, _, cursor string, _ int) (*loginHistoryListResponse, error) {
+				return nil, model.NewInvalidFilterError("invalid cursor: " + cursor)
+			},
+		}
+		h := NewLoginHistoryHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/login-history?cursor=broken", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListLoginHistory(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidFilter {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidFilter)
+		}
+	})
+
+	t.Run("ユーザーIDがないとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewLoginHistoryHandler(&mockLoginHistoryService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/login-history", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListLoginHistory(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_LoginHistoryRoutes はログイン履歴ルートが認証必須で、LoginHistoryService 未配線時は登録されないことを検証する。
+func TestNewRouter_LoginHistoryRoutes(t *testing.T) {
+	newRouter := func(svc LoginHistoryServiceInterface) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+		}
+		if svc != nil {
+			deps.LoginHistoryService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler, withSession bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/login-history", nil)
+		if withSession {
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("セッションありのとき自分の履歴を返す", func(t *testing.T) {
+		// Arrange
+		var gotUserID string
+		svc := &mockLoginHistoryService{
+			listLoginHistoryFn: func(_ context.Context, userID, _ string, _ int) (*loginHistoryListResponse, error) {
+				gotUserID = userID
+				return &loginHistoryListResponse{Events: []loginEventResponse{}}, nil
+			},
+		}
+		router := newRouter(svc)
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotUserID != "user-test-1" {
+			t.Errorf("userID = %q, want %q", gotUserID, "user-test-1")
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newRouter(&mockLoginHistoryService{})
+
+		// Act
+		w := doRequest(router, false)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("LoginHistoryService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil)
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+// Package handler の magic_link_handler.go は、メールアドレスに一回限りのログインリンクを送る
+// マジックリンク認証の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - POST /auth/magic-link        : メールアドレスにログインリンクを送る
+//   - GET  /auth/magic-link/verify : ログインリンクのトークンを検証してセッションを発行する
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// MagicLinkServiceInterface はマジックリンク認証ハンドラーが必要とするサービスインターフェース。
+// auth.Service が実装する。
+type MagicLinkServiceInterface interface {
+	// RequestMagicLink は email にログインリンクを送る。形式が不正な場合は INVALID_EMAIL を返す。
+	RequestMagicLink(ctx context.Context, email string) error
+	// VerifyMagicLink はトークンを使用済みにしてセッションを発行する。
+	// トークンが無効な場合は INVALID_LOGIN_TOKEN を返す。
+	VerifyMagicLink(ctx context.Context, token string) (*model.Session, error)
+}
+
+// MagicLinkHandler はマジックリンク認証の HTTP ハンドラー。
+// セッション発行後の Cookie 設定・リダイレクトは AuthHandler と共通にする。
+type MagicLinkHandler struct {
+	service MagicLinkServiceInterface
+	auth    *AuthHandler
+}
+
+// NewMagicLinkHandler は MagicLinkHandler を生成する。
+func NewMagicLinkHandler(service MagicLinkServiceInterface, authHandler *AuthHandler) *MagicLinkHandler {
+	return &MagicLinkHandler{service: service, auth: authHandler}
+}
+
+// magicLinkRequest はログインリンクの送信依頼。
+type magicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// Request はメールアドレスにログインリンクを送る。
+// POST /auth/magic-link
+//
+// アカウントの有無を推測されないよう、未登録のメールアドレスや送信の上限に達した場合も 202 を返す。
+func (h *MagicLinkHandler) Request(w http.ResponseWriter, r *http.Request) {
+	var req magicLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	if err := h.service.RequestMagicLink(r.Context(), req.Email); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, map[string]string{"status": "accepted"})
+}
+
+// Verify はログインリンクのトークンを検証し、セッションを発行してフロントエンドにリダイレクトする。
+// GET /auth/magic-link/verify?token=...
+func (h *MagicLinkHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	// 成功・失敗は接続元の情報とともにログインイベントとして記録される。
+	session, err := h.service.VerifyMagicLink(loginEventContext(r), r.URL.Query().Get("token"))
+	if err != nil {
+		WriteError(w, fmt.Errorf("magic link verification failed: %w", err))
+		return
+	}
+
+	h.auth.completeLogin(w, r, session)
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+type mockMagicLinkService struct {
+	requestFn func(ctx context.Context, email string) error
+	verifyFn  func(ctx context.Context, token string) (*model.Session, error)
+}
+
+func (m *mockMagicLinkService) RequestMagicLink(ctx context.Context, email string) error {
+	if m.requestFn != nil {
+		return m.requestFn(ctx, email)
+	}
+	return nil
+}
+
+func (m *mockMagicLinkService) VerifyMagicLink(ctx context.Context, token string) (*model.Session, error) {
+	if m.verifyFn != nil {
+		return m.verifyFn(ctx, token)
+	}
+	return nil, nil
+}
+
+var _ MagicLinkServiceInterface = (*mockMagicLinkService)(nil)
+
+func newTestMagicLinkHandler(svc MagicLinkServiceInterface, authSvc *mockAuthService) *MagicLinkHandler {
+	authHandler := NewAuthHandler(authSvc, AuthHandlerConfig{
+		BaseURL:       "http://localhost:3000",
+		SessionMaxAge: 86400,
+	})
+	return NewMagicLinkHandler(svc, authHandler)
+}
+
+func TestMagicLinkHandler_Request(t *testing.T) {
+	t.Run("メールアドレスを受け付けたとき202を返す", func(t *testing.T) {
+		// Arrange
+		var gotEmail string
+		svc := &mockMagicLinkService{
+			requestFn: func(_ context.Context, email string) error {
+				gotEmail = email
+				return nil
+			},
+		}
+		h := newTestMagicLinkHandler(svc, &mockAuthService{})
+		req := httptest.NewRequest(http.MethodPost, "/auth/magic-link", strings.NewReader(`{"email":"user@example.com"}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Request(w, req)
+
+		// Assert
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusAccepted)
+		}
+		if gotEmail != "user@example.com" {
+			t.Errorf("email = %q", gotEmail)
+		}
+	})
+
+	t.Run("メールアドレスの形式が不正なとき400 INVALID_EMAILを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockMagicLinkService{
+			requestFn: func(_ context.Context, _ string) error {
+				return model.NewInvalidEmailError()
+			},
+		}
+		h := newTestMagicLinkHandler(svc, &mockAuthService{})
+		req := httptest.NewRequest(http.MethodPost, "/auth/magic-link", strings.NewReader(`{"email":"user"}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Request(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidEmail {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidEmail)
+		}
+	})
+
+	t.Run("リクエストボディが不正なとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := newTestMagicLinkHandler(&mockMagicLinkService{}, &mockAuthService{})
+		req := httptest.NewRequest(http.MethodPost, "/auth/magic-link", strings.NewReader(`{`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Request(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestMagicLinkHandler_Verify(t *testing.T) {
+	t.Run("トークンが有効なときセッションCookieを設定し旧セッションを失効させてリダイレクトする", func(t *testing.T) {
+		// Arrange
+		var gotToken, revoked string
+		svc := &mockMagicLinkService{
+			verifyFn: func(_ context.Context, token string) (*model.Session, error) {
+				gotToken = token
+				return &model.Session{ID: "new-session-id", UserID: "user-1"}, nil
+			},
+		}
+		authSvc := &mockAuthService{
+			logoutFn: func(_ context.Context, sessionID string) error {
+				revoked = sessionID
+				return nil
+			},
+		}
+		h := newTestMagicLinkHandler(svc, authSvc)
+		req := httptest.NewRequest(http.MethodGet, "/auth/magic-link/verify?token=abc", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "old-session-id"})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Verify(w, req)
+
+		// Assert
+		resp := w.Result()
+		if resp.StatusCode != http.StatusTemporaryRedirect || resp.Header.Get("Location") != "http://localhost:3000" {
+			t.Fatalf("status = %d, Location = %q", resp.StatusCode, resp.Header.Get("Location"))
+		}
+		if gotToken != "abc" {
+			t.Errorf("token = %q, want %q", gotToken, "abc")
+		}
+		if revoked != "old-session-id" {
+			t.Errorf("revoked = %q, want %q", revoked, "old-session-id")
+		}
+		var sessionCookie *http.Cookie
+		for _, c := range resp.Cookies() {
+			if c.Name == "session_id" {
+				sessionCookie = c
+			}
+		}
+		if sessionCookie == nil || sessionCookie.Value != "new-session-id" || !sessionCookie.HttpOnly {
+			t.Errorf("session cookie = %+v", sessionCookie)
+		}
+	})
+
+	t.Run("トークンが無効なとき400 INVALID_LOGIN_TOKENを返しCookieを設定しない", func(t *testing.T) {
+		// Arrange
+		svc := &mockMagicLinkService{
+			verifyFn: func(_ context.Context, _ string) (*model.Session, error) {
+				return nil, model.NewInvalidLoginTokenError()
+			},
+		}
+		h := newTestMagicLinkHandler(svc, &mockAuthService{})
+		req := httptest.NewRequest(http.MethodGet, "/auth/magic-link/verify?token=used", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Verify(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidLoginToken {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidLoginToken)
+		}
+		if len(w.Result().Cookies()) != 0 {
+			t.Errorf("cookies = %+v, want none", w.Result().Cookies())
+		}
+	})
+
+	t.Run("サービスが失敗したとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockMagicLinkService{
+			verifyFn: func(_ context.Context, _ string) (*model.Session, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		h := newTestMagicLinkHandler(svc, &mockAuthService{})
+		req := httptest.NewRequest(http.MethodGet, "/auth/magic-link/verify?token=abc", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Verify(w, req)
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
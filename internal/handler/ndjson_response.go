@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// NDJSONContentType は NDJSON（改行区切り JSON）レスポンスの Content-Type。
+const NDJSONContentType = "application/x-ndjson"
+
+// wantsNDJSON はリクエストの Accept ヘッダーが NDJSON を受け付けるかを返す。
+// application/x-ndjson が q=0 以外で明示されている場合のみ true とし、*/* などのワイルドカードは対象にしない。
+func wantsNDJSON(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mediaType != NDJSONContentType {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q <= 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// ndjsonErrorLine はストリーミング開始後に発生したエラーを表す行。
+// ステータスコードは送信済みのため、クライアントは error キーを持つ行で途中終了を検知する。
+type ndjsonErrorLine struct {
+	Error middleware.ErrorResponseBody `json:"error"`
+}
+
+// ndjsonWriter は 1 行 1 オブジェクトの NDJSON をレスポンスへ逐次書き込む。
+// ヘッダーは最初の行を書き込むときに送るため、書き込み前であれば WriteError で通常のエラーレスポンスを返せる。
+// 各行は WriteJSON と同じ統一ルール（UTC の日時・nil スライスを [] など）でシリアライズする。
+type ndjsonWriter struct {
+	w       http.ResponseWriter
+	enc     *json.Encoder
+	started bool
+}
+
+// newNDJSONWriter は w に書き込む ndjsonWriter を生成する。
+func newNDJSONWriter(w http.ResponseWriter) *ndjsonWriter {
+	return &ndjsonWriter{w: w, enc: middleware.NewJSONEncoder(w)}
+}
+
+// start はまだ送っていなければ 200 と NDJSON の Content-Type を送る。
+func (nw *ndjsonWriter) start() {
+	if nw.started {
+		return
+	}
+	nw.started = true
+	nw.w.Header().Set("Content-Type", NDJSONContentType)
+	// リバースプロキシでのバッファリングを抑止し、行を受け取り次第クライアントへ届ける
+	nw.w.Header().Set("X-Accel-Buffering", "no")
+	nw.w.WriteHeader(http.StatusOK)
+}
+
+// Write は v を 1 行として書き込む。クライアントの切断などで書き込めない場合はエラーを返す。
+func (nw *ndjsonWriter) Write(v any) error {
+	nw.start()
+	return nw.enc.Encode(middleware.NormalizeJSON(v))
+}
+
+// Flush は書き込み済みの行をクライアントへ送り出す。ResponseWriter が http.Flusher でなければ何もしない。
+func (nw *ndjsonWriter) Flush() {
+	if err := http.NewResponseController(nw.w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.Debug("NDJSONレスポンスのフラッシュに失敗しました", slog.String("error", err.Error()))
+	}
+}
+
+// WriteError は err をレスポンスに書き込む。
+// まだ 1 行も書き込んでいなければ通常のエラーレスポンス（WriteError）を返し、
+// ストリーミング開始後であれば error キーを持つ行を書き込む。
+func (nw *ndjsonWriter) WriteError(err error) {
+	if !nw.started {
+		WriteError(nw.w, err)
+		return
+	}
+
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) {
+		slog.Error("internal server error", slog.String("error", err.Error()))
+		apiErr = &model.APIError{
+			Code:     model.ErrCodeInternal,
+			Message:  "内部エラーが発生しました。",
+			Category: "system",
+			Action:   "しばらく待ってから再度お試しください。",
+		}
+	}
+	nw.enc.Encode(ndjsonErrorLine{Error: middleware.ErrorResponseBody{
+		Code:      apiErr.Code,
+		Message:   apiErr.Message,
+		Category:  apiErr.Category,
+		Action:    apiErr.Action,
+		Details:   apiErr.Details,
+		RequestID: nw.w.Header().Get(middleware.RequestIDHeader),
+		DocsURL:   model.ErrorDocsURL(apiErr.Code),
+	}})
+	nw.Flush()
+}
+
+// streamNDJSONPages はカーソルページングの一覧を先頭から末尾まで辿り、記事などの要素を 1 行ずつ書き込む。
+// fetch は cursor のページを取得し、そのページの要素と次ページのカーソル（末尾なら空文字）を返す。
+// ページを 1 つずつ取得して書き込み・フラッシュするため、件数にかかわらずメモリ使用量は 1 ページ分で済む。
+// クライアントが切断した場合は以降のページを取得せずに終了する。
+func streamNDJSONPages[T any](w http.ResponseWriter, r *http.Request, cursor string, fetch func(cursor string) ([]T, string, error)) {
+	nw := newNDJSONWriter(w)
+	for {
+		items, next, err := fetch(cursor)
+		if err != nil {
+			nw.WriteError(err)
+			return
+		}
+		for _, item := range items {
+			if err := nw.Write(item); err != nil {
+				slog.Debug("NDJSONレスポンスの書き込みを中断しました", slog.String("error", err.Error()))
+				return
+			}
+		}
+		// 空の一覧でもヘッダーを送って 200 の空レスポンスにする
+		nw.start()
+		nw.Flush()
+		if next == "" || next == cursor || r.Context().Err() != nil {
+			return
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// decodeNDJSONLines は NDJSON のレスポンスボディを 1 行ずつ map にデコードして返す。
+func decodeNDJSONLines(t *testing.T, body string) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	sc := bufio.NewScanner(strings.NewReader(body))
+	for sc.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &line); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", sc.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestWantsNDJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept []string
+		want   bool
+	}{
+		{name: "NDJSONを指定したときtrueを返す", accept: []string{"application/x-ndjson"}, want: true},
+		{name: "複数のメディアタイプの中にNDJSONがあるときtrueを返す", accept: []string{"application/json;q=0.5, application/x-ndjson"}, want: true},
+		{name: "複数のAcceptヘッダーの中にNDJSONがあるときtrueを返す", accept: []string{"application/json", "application/x-ndjson;q=0.9"}, want: true},
+		{name: "q=0のときfalseを返す", accept: []string{"application/x-ndjson;q=0"}, want: false},
+		{name: "ワイルドカードのときfalseを返す", accept: []string{"*/*"}, want: false},
+		{name: "JSONのときfalseを返す", accept: []string{"application/json"}, want: false},
+		{name: "未指定のときfalseを返す", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+			for _, v := range tt.accept {
+				req.Header.Add("Accept", v)
+			}
+
+			if got := wantsNDJSON(req); got != tt.want {
+				t.Errorf("wantsNDJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamNDJSONPages(t *testing.T) {
+	t.Run("全ページを辿って要素を1行ずつ書き込む", func(t *testing.T) {
+		// Arrange
+		jst := time.FixedZone("JST", 9*60*60)
+		pages := map[string]struct {
+			items []itemSummaryResponse
+			next  string
+		}{
+			"":   {items: []itemSummaryResponse{{ID: "item-1", PublishedAt: time.Date(2026, 7, 1, 9, 0, 0, 0, jst)}, {ID: "item-2"}}, next: "c1"},
+			"c1": {items: []itemSummaryResponse{{ID: "item-3"}}},
+		}
+		var cursors []string
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		streamNDJSONPages(w, req, "", func(cursor string) ([]itemSummaryResponse, string, error) {
+			cursors = append(cursors, cursor)
+			return pages[cursor].items, pages[cursor].next, nil
+		})
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != NDJSONContentType {
+			t.Errorf("Content-Type = %q, want %q", ct, NDJSONContentType)
+		}
+		if strings.Join(cursors, ",") != ",c1" {
+			t.Errorf("cursors = %q, want [\"\" \"c1\"]", cursors)
+		}
+		lines := decodeNDJSONLines(t, w.Body.String())
+		if len(lines) != 3 {
+			t.Fatalf("lines = %d, want 3", len(lines))
+		}
+		for i, want := range []string{"item-1", "item-2", "item-3"} {
+			if lines[i]["id"] != want {
+				t.Errorf("lines[%d].id = %v, want %s", i, lines[i]["id"], want)
+			}
+		}
+		if got := lines[0]["published_at"]; got != "2026-07-01T00:00:00Z" {
+			t.Errorf("published_at = %v, want UTC", got)
+		}
+	})
+
+	t.Run("要素が無いとき200の空レスポンスを返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		w := httptest.NewRecorder()
+
+		streamNDJSONPages(w, req, "", func(cursor string) ([]itemSummaryResponse, string, error) {
+			return nil, "", nil
+		})
+
+		if w.Code != http.StatusOK || w.Body.Len() != 0 {
+			t.Errorf("status = %d, body = %q, want 200 and empty body", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != NDJSONContentType {
+			t.Errorf("Content-Type = %q, want %q", ct, NDJSONContentType)
+		}
+	})
+
+	t.Run("先頭ページの取得に失敗したとき通常のエラーレスポンスを返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		w := httptest.NewRecorder()
+
+		streamNDJSONPages(w, req, "", func(cursor string) ([]itemSummaryResponse, string, error) {
+			return nil, "", model.NewInvalidFilterError("cursor")
+		})
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w); got["code"] != model.ErrCodeInvalidFilter {
+			t.Errorf("code = %q, want %q", got["code"], model.ErrCodeInvalidFilter)
+		}
+	})
+
+	t.Run("途中のページの取得に失敗したときerror行を書き込んで終了する", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/api/items", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		streamNDJSONPages(w, req, "", func(cursor string) ([]itemSummaryResponse, string, error) {
+			if cursor == "" {
+				return []itemSummaryResponse{{ID: "item-1"}}, "c1", nil
+			}
+			return nil, "", errors.New("db down")
+		})
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		lines := decodeNDJSONLines(t, w.Body.String())
+		if len(lines) != 2 {
+			t.Fatalf("lines = %d, want 2", len(lines))
+		}
+		errLine, ok := lines[1]["error"].(map[string]any)
+		if !ok || errLine["code"] != model.ErrCodeInternal {
+			t.Errorf("last line = %v, want error line with %s", lines[1], model.ErrCodeInternal)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Package handler の notification_handler.go は、購読単位の通知ヒント設定（priority / mute_until）の
+// HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/subscriptions/{id}/notification : 通知ヒント設定の取得
+//   - PUT /api/subscriptions/{id}/notification : 通知ヒント設定の更新（mute_until に null 指定でミュート解除）
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// NotificationServiceInterface は通知ヒント設定ハンドラが必要とするサービスインターフェース。
+type NotificationServiceInterface interface {
+	// GetNotificationSetting は当該ユーザーの購読の通知ヒント設定を返す。
+	GetNotificationSetting(ctx context.Context, userID, subscriptionID string) (*notificationSettingResponse, error)
+	// UpdateNotificationSetting は当該ユーザーの購読の通知ヒント設定を更新し、更新後の設定を返す。
+	UpdateNotificationSetting(ctx context.Context, userID, subscriptionID, priority string, muteUntil *time.Time) (*notificationSettingResponse, error)
+}
+
+// NotificationHandler は通知ヒント設定の HTTP ハンドラ。
+type NotificationHandler struct {
+	service NotificationServiceInterface
+}
+
+// NewNotificationHandler は NotificationHandler を生成する。
+func NewNotificationHandler(service NotificationServiceInterface) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// notificationSettingResponse は通知ヒント設定のAPIレスポンス。ミュートしていない場合の mute_until は null。
+type notificationSettingResponse struct {
+	Priority  string     `json:"priority"`
+	MuteUntil *time.Time `json:"mute_until"`
+}
+
+// notificationSettingRequest は通知ヒント設定更新リクエストのボディ。
+// priority を省略した場合は normal として扱う。
+type notificationSettingRequest struct {
+	Priority  string     `json:"priority"`
+	MuteUntil *time.Time `json:"mute_until"`
+}
+
+// GetNotificationSetting は購読の通知ヒント設定を返す。
+// GET /api/subscriptions/{id}/notification
+func (h *NotificationHandler) GetNotificationSetting(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.GetNotificationSetting(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// UpdateNotificationSetting は購読の通知ヒント設定を更新する。
+// PUT /api/subscriptions/{id}/notification
+//
+// mute_until までの間は当該購読の新着通知イベントを生成しない。
+func (h *NotificationHandler) UpdateNotificationSetting(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req notificationSettingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.UpdateNotificationSetting(r.Context(), userID, chi.URLParam(r, "id"), req.Priority, req.MuteUntil)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
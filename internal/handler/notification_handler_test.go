@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockNotificationService は NotificationServiceInterface のモック実装。
+type mockNotificationService struct {
+	getFn       func(ctx context.Context, userID, subscriptionID string) (*notificationSettingResponse, error)
+	updateFn    func(ctx context.Context, userID, subscriptionID, priority string, muteUntil *time.Time) (*notificationSettingResponse, error)
+	updateCalls int
+}
+
+func (m *mockNotificationService) GetNotificationSetting(ctx context.Context, userID, subscriptionID string) (*notificationSettingResponse, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx, userID, subscriptionID)
+	}
+	return &notificationSettingResponse{Priority: "normal"}, nil
+}
+
+func (m *mockNotificationService) UpdateNotificationSetting(ctx context.Context, userID, subscriptionID, priority string, muteUntil *time.Time) (*notificationSettingResponse, error) {
+	m.updateCalls++
+	if m.updateFn != nil {
+		return m.updateFn(ctx, userID, subscriptionID, priority, muteUntil)
+	}
+	return &notificationSettingResponse{Priority: priority, MuteUntil: muteUntil}, nil
+}
+
+// --- GET /api/subscriptions/{id}/notification テスト ---
+
+func TestNotificationHandler_GetNotificationSetting(t *testing.T) {
+	t.Run("ミュートしていないときmute_untilをnullで返す", func(t *testing.T) {
+		// Arrange
+		h := NewNotificationHandler(&mockNotificationService{})
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetNotificationSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		want := `{"priority":"normal","mute_until":null}`
+		if got := strings.TrimSpace(w.Body.String()); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("購読が見つからないとき404 SUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockNotificationService{
+			getFn: func(_ context.Context, _, subscriptionID string) (*notificationSettingResponse, error) {
+				return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		h := NewNotificationHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1"), "id", "sub-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetNotificationSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewNotificationHandler(&mockNotificationService{})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetNotificationSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- PUT /api/subscriptions/{id}/notification テスト ---
+
+func TestNotificationHandler_UpdateNotificationSetting(t *testing.T) {
+	t.Run("優先度とミュート期限を指定したとき更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		want := time.Date(2026, 6, 24, 9, 0, 0, 0, time.UTC)
+		svc := &mockNotificationService{
+			updateFn: func(_ context.Context, userID, subscriptionID, priority string, muteUntil *time.Time) (*notificationSettingResponse, error) {
+				if userID != "user-1" || subscriptionID != "sub-1" || priority != "high" || muteUntil == nil || !muteUntil.Equal(want) {
+					t.Errorf("args = (%q, %q, %q, %v), want (user-1, sub-1, high, %v)", userID, subscriptionID, priority, muteUntil, want)
+				}
+				return &notificationSettingResponse{Priority: priority, MuteUntil: muteUntil}, nil
+			},
+		}
+		h := NewNotificationHandler(svc)
+		body := `{"priority":"high","mute_until":"2026-06-24T09:00:00Z"}`
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body)), "user-1")
+		req = withChiURLParam(req, "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateNotificationSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got := strings.TrimSpace(w.Body.String()); got != body {
+			t.Errorf("body = %s, want %s", got, body)
+		}
+	})
+
+	t.Run("不正な設定のとき400 INVALID_NOTIFICATION_SETTINGを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockNotificationService{
+			updateFn: func(context.Context, string, string, string, *time.Time) (*notificationSettingResponse, error) {
+				return nil, model.NewInvalidNotificationSettingError("未知の優先度")
+			},
+		}
+		h := NewNotificationHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"priority":"urgent"}`)), "user-1")
+		req = withChiURLParam(req, "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateNotificationSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodeInvalidNotificationSetting) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodeInvalidNotificationSetting)
+		}
+	})
+
+	t.Run("JSONが不正なとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockNotificationService{}
+		h := NewNotificationHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{`)), "user-1")
+		req = withChiURLParam(req, "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateNotificationSetting(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("updateCalls = %d, want 0", svc.updateCalls)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+const (
+	// DefaultPageLimit は一覧 API で limit が未指定のときの取得件数の既定値。
+	DefaultPageLimit = 50
+	// DefaultMaxPageLimit は一覧 API で指定できる limit の上限の既定値。
+	DefaultMaxPageLimit = 200
+)
+
+// PageLimitConfig は一覧 API の limit クエリパラメータの既定値と上限値。
+// 0 以下の項目は DefaultPageLimit / DefaultMaxPageLimit を用いる。
+type PageLimitConfig struct {
+	// Default は limit 未指定時の取得件数。
+	Default int
+	// Max は limit の上限。これを超える指定は上限に丸める。
+	Max int
+}
+
+// normalize は未設定の項目を既定値で補い、既定値が上限を超えないように揃えた設定を返す。
+func (c PageLimitConfig) normalize() PageLimitConfig {
+	if c.Max <= 0 {
+		c.Max = DefaultMaxPageLimit
+	}
+	if c.Default <= 0 {
+		c.Default = DefaultPageLimit
+	}
+	if c.Default > c.Max {
+		c.Default = c.Max
+	}
+	return c
+}
+
+// pageLimitContextKey はリクエストコンテキストに PageLimitConfig を格納するキー。
+type pageLimitContextKey struct{}
+
+// newPageLimitMiddleware は後続のハンドラーが parsePageLimit で参照する limit の設定を
+// リクエストコンテキストに載せるミドルウェアを返す。
+func newPageLimitMiddleware(cfg PageLimitConfig) func(http.Handler) http.Handler {
+	cfg = cfg.normalize()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), pageLimitContextKey{}, cfg)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// pageLimitConfigFromContext はコンテキストの limit の設定を返す。
+// ミドルウェアを経由しない場合（ハンドラー単体のテスト等）は既定値を返す。
+func pageLimitConfigFromContext(ctx context.Context) PageLimitConfig {
+	if cfg, ok := ctx.Value(pageLimitContextKey{}).(PageLimitConfig); ok {
+		return cfg
+	}
+	return PageLimitConfig{}.normalize()
+}
+
+// parsePageLimit は一覧 API の limit クエリパラメータを解釈する。
+// 未指定の場合は設定の既定値を、上限を超える指定は上限を返す。
+// 整数として解釈できない値や 0 以下の値は 400 INVALID_REQUEST とする。
+func parsePageLimit(r *http.Request) (int, error) {
+	cfg := pageLimitConfigFromContext(r.Context())
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return cfg.Default, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "limit の形式が不正です。",
+			Category: "validation",
+			Action:   "1 以上の整数を指定してください。",
+		}
+	}
+	if n > cfg.Max {
+		n = cfg.Max
+	}
+	return n, nil
+}
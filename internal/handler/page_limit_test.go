@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestParsePageLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *PageLimitConfig
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{name: "未指定のとき既定値を返す", query: "", want: DefaultPageLimit},
+		{name: "上限以内のときそのまま返す", query: "limit=20", want: 20},
+		{name: "上限を超えるとき上限に丸める", query: "limit=10000", want: DefaultMaxPageLimit},
+		{name: "設定した既定値と上限を使う", cfg: &PageLimitConfig{Default: 20, Max: 30}, query: "limit=31", want: 30},
+		{name: "設定した既定値を未指定時に使う", cfg: &PageLimitConfig{Default: 20, Max: 30}, query: "", want: 20},
+		{name: "既定値が上限を超える設定のとき上限を既定値にする", cfg: &PageLimitConfig{Default: 80, Max: 40}, query: "", want: 40},
+		{name: "0のとき400を返す", query: "limit=0", wantErr: true},
+		{name: "負の値のとき400を返す", query: "limit=-1", wantErr: true},
+		{name: "整数でないとき400を返す", query: "limit=all", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var got int
+			var gotErr error
+			var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, gotErr = parsePageLimit(r)
+			})
+			if tt.cfg != nil {
+				h = newPageLimitMiddleware(*tt.cfg)(h)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/api/items?"+tt.query, nil)
+
+			// Act
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			// Assert
+			if tt.wantErr {
+				apiErr, ok := gotErr.(*model.APIError)
+				if !ok || apiErr.Code != model.ErrCodeInvalidRequest {
+					t.Errorf("err = %v, want INVALID_REQUEST", gotErr)
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatalf("unexpected error: %v", gotErr)
+			}
+			if got != tt.want {
+				t.Errorf("limit = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Package handler の profile_handler.go は、購読リストの公開プロフィール共有の
+// HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /public/{slug}/subscriptions      : 公開購読一覧（認証不要）
+//   - GET /api/users/me/public-profile      : 自分の公開設定の取得
+//   - PUT /api/users/me/public-profile      : 公開フラグ・スラッグの更新
+//   - PUT /api/subscriptions/{id}/visibility : 個別購読の公開/非公開の更新
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PublicProfileServiceInterface は公開プロフィールハンドラが必要とするサービスインターフェース。
+type PublicProfileServiceInterface interface {
+	// GetProfile は当該ユーザーの公開設定を返す。
+	GetProfile(ctx context.Context, userID string) (*publicProfileResponse, error)
+	// UpdateProfile は当該ユーザーの公開フラグとスラッグを更新する。
+	UpdateProfile(ctx context.Context, userID string, enabled bool, slug string) (*publicProfileResponse, error)
+	// SetSubscriptionVisibility は当該ユーザーの購読の公開可否を更新する。
+	SetSubscriptionVisibility(ctx context.Context, userID, subscriptionID string, isPublic bool) error
+	// ListPublicSubscriptions はスラッグで指定された公開プロフィールの公開購読一覧を返す。
+	ListPublicSubscriptions(ctx context.Context, slug string) ([]publicSubscriptionResponse, error)
+}
+
+// PublicProfileHandler は公開プロフィールの HTTP ハンドラ。
+type PublicProfileHandler struct {
+	service PublicProfileServiceInterface
+}
+
+// NewPublicProfileHandler は PublicProfileHandler を生成する。
+func NewPublicProfileHandler(service PublicProfileServiceInterface) *PublicProfileHandler {
+	return &PublicProfileHandler{service: service}
+}
+
+// publicProfileResponse は公開設定のAPIレスポンス。
+type publicProfileResponse struct {
+	Enabled bool   `json:"enabled"`
+	Slug    string `json:"slug"`
+}
+
+// publicProfileRequest は公開設定更新リクエストのボディ。
+type publicProfileRequest struct {
+	Enabled bool   `json:"enabled"`
+	Slug    string `json:"slug"`
+}
+
+// publicSubscriptionResponse は公開購読 1 件分のAPIレスポンス。
+// 未認証の閲覧者に返すため、タイトルとサイト URL のみを含める。
+type publicSubscriptionResponse struct {
+	Title   string `json:"title"`
+	SiteURL string `json:"site_url"`
+}
+
+// subscriptionVisibilityRequest は購読の公開可否更新リクエストのボディ。
+type subscriptionVisibilityRequest struct {
+	IsPublic bool `json:"is_public"`
+}
+
+// ListPublicSubscriptions は公開プロフィールの購読一覧を返す。
+// GET /public/{slug}/subscriptions
+func (h *PublicProfileHandler) ListPublicSubscriptions(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	subs, err := h.service.ListPublicSubscriptions(r.Context(), slug)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if subs == nil {
+		subs = []publicSubscriptionResponse{}
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"subscriptions": subs,
+	})
+}
+
+// GetProfile は自分の公開設定を返す。
+// GET /api/users/me/public-profile
+func (h *PublicProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	profile, err := h.service.GetProfile(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, profile)
+}
+
+// UpdateProfile は自分の公開フラグとスラッグを更新する。
+// PUT /api/users/me/public-profile
+func (h *PublicProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req publicProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	// スラッグの形式検証・重複検出はサービス層に集約済み。
+	profile, err := h.service.UpdateProfile(r.Context(), userID, req.Enabled, req.Slug)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, profile)
+}
+
+// UpdateSubscriptionVisibility は購読の公開可否を更新する。
+// PUT /api/subscriptions/{id}/visibility
+func (h *PublicProfileHandler) UpdateSubscriptionVisibility(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "id")
+
+	var req subscriptionVisibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	if err := h.service.SetSubscriptionVisibility(r.Context(), userID, subscriptionID, req.IsPublic); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,398 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockPublicProfileService は PublicProfileServiceInterface のモック実装。
+type mockPublicProfileService struct {
+	getProfileFn        func(ctx context.Context, userID string) (*publicProfileResponse, error)
+	updateProfileFn     func(ctx context.Context, userID string, enabled bool, slug string) (*publicProfileResponse, error)
+	setVisibilityFn     func(ctx context.Context, userID, subscriptionID string, isPublic bool) error
+	listPublicSubsFn    func(ctx context.Context, slug string) ([]publicSubscriptionResponse, error)
+	listPublicSubsCalls int
+}
+
+func (m *mockPublicProfileService) GetProfile(ctx context.Context, userID string) (*publicProfileResponse, error) {
+	if m.getProfileFn != nil {
+		return m.getProfileFn(ctx, userID)
+	}
+	return &publicProfileResponse{}, nil
+}
+
+func (m *mockPublicProfileService) UpdateProfile(ctx context.Context, userID string, enabled bool, slug string) (*publicProfileResponse, error) {
+	if m.updateProfileFn != nil {
+		return m.updateProfileFn(ctx, userID, enabled, slug)
+	}
+	return &publicProfileResponse{Enabled: enabled, Slug: slug}, nil
+}
+
+func (m *mockPublicProfileService) SetSubscriptionVisibility(ctx context.Context, userID, subscriptionID string, isPublic bool) error {
+	if m.setVisibilityFn != nil {
+		return m.setVisibilityFn(ctx, userID, subscriptionID, isPublic)
+	}
+	return nil
+}
+
+func (m *mockPublicProfileService) ListPublicSubscriptions(ctx context.Context, slug string) ([]publicSubscriptionResponse, error) {
+	m.listPublicSubsCalls++
+	if m.listPublicSubsFn != nil {
+		return m.listPublicSubsFn(ctx, slug)
+	}
+	return nil, nil
+}
+
+// --- GET /public/{slug}/subscriptions テスト ---
+
+func TestPublicProfileHandler_ListPublicSubscriptions(t *testing.T) {
+	t.Run("公開プロフィールが存在するときタイトルとsite_urlのみを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockPublicProfileService{
+			listPublicSubsFn: func(_ context.Context, slug string) ([]publicSubscriptionResponse, error) {
+				if slug != "alice" {
+					t.Errorf("slug = %q, want %q", slug, "alice")
+				}
+				return []publicSubscriptionResponse{{Title: "Go Blog", SiteURL: "https://go.dev/blog"}}, nil
+			},
+		}
+		h := NewPublicProfileHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/public/alice/subscriptions", nil)
+		req = withChiURLParam(req, "slug", "alice")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListPublicSubscriptions(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body struct {
+			Subscriptions []map[string]interface{} `json:"subscriptions"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Subscriptions) != 1 {
+			t.Fatalf("len(subscriptions) = %d, want 1", len(body.Subscriptions))
+		}
+		got := body.Subscriptions[0]
+		if got["title"] != "Go Blog" || got["site_url"] != "https://go.dev/blog" {
+			t.Errorf("subscription = %v", got)
+		}
+		if len(got) != 2 {
+			t.Errorf("公開レスポンスは title と site_url のみを含むべき: %v", got)
+		}
+	})
+
+	t.Run("公開購読が0件のとき空配列を返す", func(t *testing.T) {
+		// Arrange
+		h := NewPublicProfileHandler(&mockPublicProfileService{})
+		req := withChiURLParam(httptest.NewRequest(http.MethodGet, "/public/alice/subscriptions", nil), "slug", "alice")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListPublicSubscriptions(w, req)
+
+		// Assert
+		if !strings.Contains(w.Body.String(), `"subscriptions":[]`) {
+			t.Errorf("body = %s, want empty subscriptions array", w.Body.String())
+		}
+	})
+
+	t.Run("プロフィールが非公開のとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockPublicProfileService{
+			listPublicSubsFn: func(_ context.Context, slug string) ([]publicSubscriptionResponse, error) {
+				return nil, model.NewProfileNotFoundError(slug)
+			},
+		}
+		h := NewPublicProfileHandler(svc)
+		req := withChiURLParam(httptest.NewRequest(http.MethodGet, "/public/bob/subscriptions", nil), "slug", "bob")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListPublicSubscriptions(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeProfileNotFound {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeProfileNotFound)
+		}
+	})
+}
+
+// --- /api/users/me/public-profile テスト ---
+
+func TestPublicProfileHandler_GetProfile(t *testing.T) {
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewPublicProfileHandler(&mockPublicProfileService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/public-profile", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetProfile(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("認証済みのとき公開設定を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockPublicProfileService{
+			getProfileFn: func(_ context.Context, userID string) (*publicProfileResponse, error) {
+				if userID != "user-123" {
+					t.Errorf("userID = %q, want %q", userID, "user-123")
+				}
+				return &publicProfileResponse{Enabled: true, Slug: "alice"}, nil
+			},
+		}
+		h := NewPublicProfileHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/public-profile", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetProfile(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var got publicProfileResponse
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !got.Enabled || got.Slug != "alice" {
+			t.Errorf("got %+v", got)
+		}
+	})
+}
+
+func TestPublicProfileHandler_UpdateProfile(t *testing.T) {
+	t.Run("正常なリクエストのときサービスに値を渡し更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockPublicProfileService{
+			updateProfileFn: func(_ context.Context, userID string, enabled bool, slug string) (*publicProfileResponse, error) {
+				if userID != "user-123" || !enabled || slug != "alice" {
+					t.Errorf("called with (%q, %v, %q)", userID, enabled, slug)
+				}
+				return &publicProfileResponse{Enabled: enabled, Slug: slug}, nil
+			},
+		}
+		h := NewPublicProfileHandler(svc)
+		req := httptest.NewRequest(http.MethodPut, "/api/users/me/public-profile", strings.NewReader(`{"enabled":true,"slug":"alice"}`))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateProfile(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("ボディが不正なJSONのとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := NewPublicProfileHandler(&mockPublicProfileService{})
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/public-profile", strings.NewReader(`{`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateProfile(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "スラッグが形式不正のとき400を返す", err: model.NewInvalidProfileSlugError("a"), wantStatus: http.StatusBadRequest},
+		{name: "スラッグが重複したとき409を返す", err: model.NewProfileSlugTakenError("alice"), wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			svc := &mockPublicProfileService{
+				updateProfileFn: func(_ context.Context, _ string, _ bool, _ string) (*publicProfileResponse, error) {
+					return nil, tt.err
+				},
+			}
+			h := NewPublicProfileHandler(svc)
+			req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/public-profile", strings.NewReader(`{"enabled":true,"slug":"alice"}`)), "user-123")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.UpdateProfile(w, req)
+
+			// Assert
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// --- PUT /api/subscriptions/{id}/visibility テスト ---
+
+func TestPublicProfileHandler_UpdateSubscriptionVisibility(t *testing.T) {
+	t.Run("正常なリクエストのとき204を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockPublicProfileService{
+			setVisibilityFn: func(_ context.Context, userID, subscriptionID string, isPublic bool) error {
+				if userID != "user-123" || subscriptionID != "sub-1" || !isPublic {
+					t.Errorf("called with (%q, %q, %v)", userID, subscriptionID, isPublic)
+				}
+				return nil
+			},
+		}
+		h := NewPublicProfileHandler(svc)
+		req := httptest.NewRequest(http.MethodPut, "/api/subscriptions/sub-1/visibility", strings.NewReader(`{"is_public":true}`))
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateSubscriptionVisibility(w, req)
+
+		// Assert
+		if w.Code != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+	})
+
+	t.Run("購読が存在しないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockPublicProfileService{
+			setVisibilityFn: func(_ context.Context, _, subscriptionID string, _ bool) error {
+				return model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		h := NewPublicProfileHandler(svc)
+		req := httptest.NewRequest(http.MethodPut, "/api/subscriptions/sub-x/visibility", strings.NewReader(`{"is_public":false}`))
+		req = withChiURLParam(withUserID(req, "user-123"), "id", "sub-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateSubscriptionVisibility(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_PublicProfileRoutes は公開プロフィールのルートが登録され、
+// 公開購読一覧のみセッションなしで到達できることを検証する。
+func TestNewRouter_PublicProfileRoutes(t *testing.T) {
+	newRouter := func(svc PublicProfileServiceInterface) http.Handler {
+		return NewRouter(&RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:    "http://localhost:3000",
+			RateLimiter:          middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:          &mockAuthService{},
+			FeedService:          &mockFeedService{},
+			ItemService:          &mockItemService{},
+			SubscriptionService:  &mockSubscriptionService{},
+			UserService:          &mockUserService{},
+			PublicProfileService: svc,
+		})
+	}
+
+	t.Run("セッションなしで公開購読一覧に到達できるとき200を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockPublicProfileService{}
+		router := newRouter(svc)
+		req := httptest.NewRequest(http.MethodGet, "/public/alice/subscriptions", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.listPublicSubsCalls != 1 {
+			t.Errorf("ListPublicSubscriptions calls = %d, want 1", svc.listPublicSubsCalls)
+		}
+	})
+
+	t.Run("セッションなしで設定更新にアクセスしたとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newRouter(&mockPublicProfileService{})
+		req := httptest.NewRequest(http.MethodPut, "/api/users/me/public-profile", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("認証済みのとき設定系ルートが登録されている", func(t *testing.T) {
+		router := newRouter(&mockPublicProfileService{})
+		routes := []struct{ method, path, body string }{
+			{http.MethodGet, "/api/users/me/public-profile", ""},
+			{http.MethodPut, "/api/users/me/public-profile", `{"enabled":false}`},
+			{http.MethodPut, "/api/subscriptions/sub-1/visibility", `{"is_public":true}`},
+		}
+		for _, rt := range routes {
+			req := httptest.NewRequest(rt.method, rt.path, strings.NewReader(rt.body))
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code == http.StatusNotFound || w.Code == http.StatusMethodNotAllowed {
+				t.Errorf("%s %s status = %d, route not registered", rt.method, rt.path, w.Code)
+			}
+		}
+	})
+
+	t.Run("PublicProfileService が nil のとき公開ルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil)
+		req := httptest.NewRequest(http.MethodGet, "/public/alice/subscriptions", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Package handler の random_item_handler.go は、積読解消向けの「何か読む」エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/items/random : 購読中フィードの記事をランダムに取り出す（filter / count）
+//
+// 認証必須グループ配下に登録される。
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// RandomItemServiceInterface はランダム記事ハンドラが必要とするサービスインターフェース。
+// 実装は RandomItemServiceAdapter（service_adapter.go）が担当する。
+type RandomItemServiceInterface interface {
+	// PickRandomItems は filter に合致する記事を最大 count 件ランダムに返す。
+	PickRandomItems(ctx context.Context, userID string, filter model.ItemFilter, count int) ([]crossFeedItemResponse, error)
+}
+
+// RandomItemHandler はランダム記事取り出しの HTTP ハンドラ。
+type RandomItemHandler struct {
+	service RandomItemServiceInterface
+}
+
+// NewRandomItemHandler は RandomItemHandler を生成する。
+func NewRandomItemHandler(service RandomItemServiceInterface) *RandomItemHandler {
+	return &RandomItemHandler{service: service}
+}
+
+// randomItemListResponse は GET /api/items/random のレスポンス。
+// 記事 1 件の形状は横断新着一覧（crossFeedItemResponse）と同一で、発信元フィードの
+// タイトルと favicon を併記する。該当記事が無い場合は `"items": []` を返す。
+type randomItemListResponse struct {
+	Items []crossFeedItemResponse `json:"items"`
+}
+
+// PickItems は GET /api/items/random のハンドラ。
+//
+// クエリパラメータ:
+//   - filter : 抽出対象（任意、unread / all / starred、既定 unread）。無効値は 400 INVALID_FILTER
+//   - count  : 取り出す件数（任意、既定 1、上限を超える指定はクランプ）。形式不正は 400
+//
+// 呼び出しのたびに結果が変わるため Cache-Control: no-store を付与する。
+func (h *RandomItemHandler) PickItems(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	q := r.URL.Query()
+	filter := model.ItemFilterUnread
+	if f := q.Get("filter"); f != "" {
+		filter = model.ItemFilter(f)
+	}
+
+	count := 1
+	if countStr := q.Get("count"); countStr != "" {
+		n, parseErr := strconv.Atoi(countStr)
+		if parseErr != nil || n <= 0 {
+			WriteError(w, &model.APIError{
+				Code:     model.ErrCodeInvalidRequest,
+				Message:  "count の形式が不正です。",
+				Category: "validation",
+				Action:   "1 以上の整数を指定してください。",
+			})
+			return
+		}
+		count = n
+	}
+
+	items, err := h.service.PickRandomItems(r.Context(), userID, filter, count)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if items == nil {
+		items = []crossFeedItemResponse{}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	WriteJSON(w, http.StatusOK, randomItemListResponse{Items: items})
+}
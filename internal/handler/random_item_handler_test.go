@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockRandomItemService は RandomItemServiceInterface のモック実装。
+type mockRandomItemService struct {
+	pickFn func(ctx context.Context, userID string, filter model.ItemFilter, count int) ([]crossFeedItemResponse, error)
+
+	// 呼び出し記録
+	lastFilter model.ItemFilter
+	lastCount  int
+	callCount  int
+}
+
+func (m *mockRandomItemService) PickRandomItems(ctx context.Context, userID string, filter model.ItemFilter, count int) ([]crossFeedItemResponse, error) {
+	m.lastFilter = filter
+	m.lastCount = count
+	m.callCount++
+	if m.pickFn != nil {
+		return m.pickFn(ctx, userID, filter, count)
+	}
+	return nil, nil
+}
+
+// --- GET /api/items/random テスト ---
+
+func TestRandomItemHandler_PickItems(t *testing.T) {
+	t.Run("パラメータ未指定のときunreadで1件を要求し記事を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockRandomItemService{
+			pickFn: func(_ context.Context, _ string, _ model.ItemFilter, _ int) ([]crossFeedItemResponse, error) {
+				return []crossFeedItemResponse{{ID: "item-1", FeedID: "feed-1", FeedTitle: "Feed 1"}}, nil
+			},
+		}
+		h := NewRandomItemHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items/random", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.PickItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.lastFilter != model.ItemFilterUnread || svc.lastCount != 1 {
+			t.Errorf("service args = (%q, %d), want (unread, 1)", svc.lastFilter, svc.lastCount)
+		}
+		if got := w.Header().Get("Cache-Control"); got != "no-store" {
+			t.Errorf("Cache-Control = %q, want no-store", got)
+		}
+		var resp randomItemListResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("レスポンスのデコードに失敗: %v", err)
+		}
+		if len(resp.Items) != 1 || resp.Items[0].ID != "item-1" || resp.Items[0].FeedTitle != "Feed 1" {
+			t.Errorf("items = %+v, want item-1", resp.Items)
+		}
+	})
+
+	t.Run("filterとcountを指定したときサービスにそのまま渡す", func(t *testing.T) {
+		// Arrange
+		svc := &mockRandomItemService{}
+		h := NewRandomItemHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items/random?filter=starred&count=5", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.PickItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.lastFilter != model.ItemFilterStarred || svc.lastCount != 5 {
+			t.Errorf("service args = (%q, %d), want (starred, 5)", svc.lastFilter, svc.lastCount)
+		}
+	})
+
+	t.Run("該当記事が無いときitemsを空配列で返す", func(t *testing.T) {
+		// Arrange
+		h := NewRandomItemHandler(&mockRandomItemService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items/random", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.PickItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got := w.Body.String(); got != "{\"items\":[]}\n" {
+			t.Errorf("body = %q, want {\"items\":[]}", got)
+		}
+	})
+
+	t.Run("countが不正なとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		for _, count := range []string{"abc", "0", "-1"} {
+			// Arrange
+			svc := &mockRandomItemService{}
+			h := NewRandomItemHandler(svc)
+			req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items/random?count="+count, nil), "user-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.PickItems(w, req)
+
+			// Assert
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("count=%s: status = %d, want %d", count, w.Code, http.StatusBadRequest)
+			}
+			if apiErr := parseAPIErrorResponse(t, w); apiErr["code"] != model.ErrCodeInvalidRequest {
+				t.Errorf("count=%s: code = %q, want %q", count, apiErr["code"], model.ErrCodeInvalidRequest)
+			}
+			if svc.callCount != 0 {
+				t.Errorf("count=%s: service callCount = %d, want 0", count, svc.callCount)
+			}
+		}
+	})
+
+	t.Run("サービスがINVALID_FILTERを返すとき400を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockRandomItemService{
+			pickFn: func(_ context.Context, _ string, filter model.ItemFilter, _ int) ([]crossFeedItemResponse, error) {
+				return nil, model.NewInvalidFilterError(string(filter))
+			},
+		}
+		h := NewRandomItemHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/items/random?filter=bogus", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.PickItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if apiErr := parseAPIErrorResponse(t, w); apiErr["code"] != model.ErrCodeInvalidFilter {
+			t.Errorf("code = %q, want %q", apiErr["code"], model.ErrCodeInvalidFilter)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewRandomItemHandler(&mockRandomItemService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/items/random", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.PickItems(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// TestNewRouter_RandomItemRoute は /api/items/random が {id} に吸われず RandomItemHandler に届くことを検証する。
+func TestNewRouter_RandomItemRoute(t *testing.T) {
+	// Arrange
+	svc := &mockRandomItemService{}
+	deps := &RouterDeps{
+		SessionFinder: &mockSessionFinderForRouter{
+			sessions: map[string]*model.Session{
+				"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+			},
+		},
+		CORSAllowedOrigin:   "http://localhost:3000",
+		RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+		AuthService:         &mockAuthService{},
+		FeedService:         &mockFeedService{},
+		ItemService:         &mockItemService{},
+		SubscriptionService: &mockSubscriptionService{},
+		UserService:         &mockUserService{},
+		RandomItemService:   svc,
+	}
+	router := NewRouter(deps)
+	req := httptest.NewRequest(http.MethodGet, "/api/items/random?count=3", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if svc.callCount != 1 || svc.lastCount != 3 {
+		t.Errorf("service callCount = %d, lastCount = %d, want 1, 3", svc.callCount, svc.lastCount)
+	}
+}
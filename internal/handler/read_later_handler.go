@@ -0,0 +1,267 @@
+// Package handler の read_later_handler.go は、スターを付けた記事を Pocket / Instapaper に自動保存する
+// 「あとで読む」サービス連携の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET    /api/read-later                  : 連携できる保存先と連携の一覧（直近の保存エラーを含む）
+//   - POST   /api/read-later/pocket/authorize : Pocket の認可を開始し、利用者を誘導する認可画面の URL を返す
+//   - GET    /api/read-later/pocket/callback  : Pocket の認可画面からの戻り先。連携を保存してフロントエンドへリダイレクトする
+//   - POST   /api/read-later/instapaper       : Instapaper のユーザー名・パスワードで連携する（パスワードは保存しない）
+//   - PUT    /api/read-later/{provider}       : 連携の有効・無効の切り替え
+//   - DELETE /api/read-later/{provider}       : 連携の解除
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// ReadLaterServiceInterface は「あとで読む」サービス連携ハンドラが必要とするサービスインターフェース。
+// 連携していない保存先への操作は READ_LATER_NOT_CONNECTED、未知の保存先は INVALID_READ_LATER_PROVIDER を返す。
+type ReadLaterServiceInterface interface {
+	ListConnections(ctx context.Context, userID string) (*readLaterListResponse, error)
+	StartPocketAuthorization(ctx context.Context, userID string) (string, error)
+	CompletePocketAuthorization(ctx context.Context, userID, state string) error
+	ConnectInstapaper(ctx context.Context, userID, username, password string) (*readLaterConnectionResponse, error)
+	SetEnabled(ctx context.Context, userID, provider string, enabled bool) (*readLaterConnectionResponse, error)
+	Disconnect(ctx context.Context, userID, provider string) error
+}
+
+// ReadLaterHandler は「あとで読む」サービス連携の HTTP ハンドラ。
+type ReadLaterHandler struct {
+	service ReadLaterServiceInterface
+	// baseURL は Pocket の認可後に利用者を戻すフロントエンドの URL。
+	baseURL string
+}
+
+// NewReadLaterHandler は ReadLaterHandler を生成する。
+func NewReadLaterHandler(service ReadLaterServiceInterface, baseURL string) *ReadLaterHandler {
+	return &ReadLaterHandler{service: service, baseURL: baseURL}
+}
+
+// readLaterConnectionResponse は連携 1 件。認証情報は返さない。
+// last_error は直近の保存失敗の理由で、連携先で認証が無効になった場合は enabled が false になる。
+type readLaterConnectionResponse struct {
+	Provider    string     `json:"provider"`
+	AccountName string     `json:"account_name"`
+	Enabled     bool       `json:"enabled"`
+	LastError   *string    `json:"last_error"`
+	LastErrorAt *time.Time `json:"last_error_at"`
+	LastSavedAt *time.Time `json:"last_saved_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// readLaterListResponse は GET /api/read-later のレスポンス。
+type readLaterListResponse struct {
+	AvailableProviders []string                      `json:"available_providers"`
+	Connections        []readLaterConnectionResponse `json:"connections"`
+}
+
+// pocketAuthorizeResponse は POST /api/read-later/pocket/authorize のレスポンス。
+type pocketAuthorizeResponse struct {
+	AuthorizeURL string `json:"authorize_url"`
+}
+
+// instapaperConnectRequest は POST /api/read-later/instapaper のリクエストボディ。
+type instapaperConnectRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// readLaterUpdateRequest は PUT /api/read-later/{provider} のリクエストボディ。
+type readLaterUpdateRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// ListConnections は連携できる保存先と自分の連携の一覧を返す。
+// GET /api/read-later
+func (h *ReadLaterHandler) ListConnections(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.ListConnections(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	if resp.AvailableProviders == nil {
+		resp.AvailableProviders = []string{}
+	}
+	if resp.Connections == nil {
+		resp.Connections = []readLaterConnectionResponse{}
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// AuthorizePocket は Pocket の認可を開始し、利用者を誘導する認可画面の URL を返す。
+// POST /api/read-later/pocket/authorize
+func (h *ReadLaterHandler) AuthorizePocket(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	authorizeURL, err := h.service.StartPocketAuthorization(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, pocketAuthorizeResponse{AuthorizeURL: authorizeURL})
+}
+
+// PocketCallback は Pocket の認可画面から戻った利用者の連携を保存し、フロントエンドへリダイレクトする。
+// 結果はクエリ（read_later=pocket&result=connected、失敗時は result=error&code=<エラーコード>）で伝える。
+// GET /api/read-later/pocket/callback?state=...
+func (h *ReadLaterHandler) PocketCallback(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		h.redirectPocketResult(w, r, model.ErrCodeUnauthorized)
+		return
+	}
+
+	if err := h.service.CompletePocketAuthorization(r.Context(), userID, r.URL.Query().Get("state")); err != nil {
+		code := model.ErrCodeInternal
+		var apiErr *model.APIError
+		if errors.As(err, &apiErr) {
+			code = apiErr.Code
+		} else {
+			// リダイレクトでは INTERNAL_ERROR しか伝わらないため、WriteError と同様に原因をログに残す
+			slog.Error("pocket authorization failed",
+				slog.String("user_id", userID),
+				slog.String("error", err.Error()),
+			)
+		}
+		h.redirectPocketResult(w, r, code)
+		return
+	}
+	h.redirectPocketResult(w, r, "")
+}
+
+// redirectPocketResult は Pocket の認可の結果をクエリに載せてフロントエンドへリダイレクトする。
+// errCode が空の場合は成功とする。
+func (h *ReadLaterHandler) redirectPocketResult(w http.ResponseWriter, r *http.Request, errCode string) {
+	q := url.Values{}
+	q.Set("read_later", string(model.ReadLaterProviderPocket))
+	if errCode == "" {
+		q.Set("result", "connected")
+	} else {
+		q.Set("result", "error")
+		q.Set("code", errCode)
+	}
+	http.Redirect(w, r, strings.TrimRight(h.baseURL, "/")+"/?"+q.Encode(), http.StatusFound)
+}
+
+// ConnectInstapaper は Instapaper のユーザー名・パスワードで連携する。
+// POST /api/read-later/instapaper
+func (h *ReadLaterHandler) ConnectInstapaper(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req instapaperConnectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "username と password を含む正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	conn, err := h.service.ConnectInstapaper(r.Context(), userID, req.Username, req.Password)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, conn)
+}
+
+// UpdateConnection は連携の有効・無効を切り替える。
+// PUT /api/read-later/{provider}
+func (h *ReadLaterHandler) UpdateConnection(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req readLaterUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "enabled を含む正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	conn, err := h.service.SetEnabled(r.Context(), userID, chi.URLParam(r, "provider"), *req.Enabled)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, conn)
+}
+
+// DeleteConnection は連携を解除する。保存済みの認証情報と未保存の記事も削除する。
+// DELETE /api/read-later/{provider}
+func (h *ReadLaterHandler) DeleteConnection(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	if err := h.service.Disconnect(r.Context(), userID, chi.URLParam(r, "provider")); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockReadLaterService は ReadLaterServiceInterface のモック実装。
+type mockReadLaterService struct {
+	listFn       func(ctx context.Context, userID string) (*readLaterListResponse, error)
+	completeFn   func(ctx context.Context, userID, state string) error
+	setEnabledFn func(ctx context.Context, userID, provider string, enabled bool) (*readLaterConnectionResponse, error)
+	disconnectFn func(ctx context.Context, userID, provider string) error
+	gotPassword  string
+}
+
+func (m *mockReadLaterService) ListConnections(ctx context.Context, userID string) (*readLaterListResponse, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, userID)
+	}
+	return &readLaterListResponse{}, nil
+}
+
+func (m *mockReadLaterService) StartPocketAuthorization(_ context.Context, _ string) (string, error) {
+	return "https://getpocket.com/auth/authorize?request_token=req", nil
+}
+
+func (m *mockReadLaterService) CompletePocketAuthorization(ctx context.Context, userID, state string) error {
+	if m.completeFn != nil {
+		return m.completeFn(ctx, userID, state)
+	}
+	return nil
+}
+
+func (m *mockReadLaterService) ConnectInstapaper(_ context.Context, _, username, password string) (*readLaterConnectionResponse, error) {
+	m.gotPassword = password
+	return &readLaterConnectionResponse{Provider: "instapaper", AccountName: username, Enabled: true}, nil
+}
+
+func (m *mockReadLaterService) SetEnabled(ctx context.Context, userID, provider string, enabled bool) (*readLaterConnectionResponse, error) {
+	if m.setEnabledFn != nil {
+		return m.setEnabledFn(ctx, userID, provider, enabled)
+	}
+	return &readLaterConnectionResponse{Provider: provider, Enabled: enabled}, nil
+}
+
+func (m *mockReadLaterService) Disconnect(ctx context.Context, userID, provider string) error {
+	if m.disconnectFn != nil {
+		return m.disconnectFn(ctx, userID, provider)
+	}
+	return nil
+}
+
+// --- GET /api/read-later テスト ---
+
+func TestReadLaterHandler_ListConnections(t *testing.T) {
+	t.Run("連携がないとき空配列を返す", func(t *testing.T) {
+		// Arrange
+		h := NewReadLaterHandler(&mockReadLaterService{}, "https://feedman.example.com")
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListConnections(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got, want := strings.TrimSpace(w.Body.String()), `{"available_providers":[],"connections":[]}`; got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		h := NewReadLaterHandler(&mockReadLaterService{}, "https://feedman.example.com")
+		w := httptest.NewRecorder()
+
+		h.ListConnections(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- POST /api/read-later/pocket/authorize テスト ---
+
+func TestReadLaterHandler_AuthorizePocket(t *testing.T) {
+	t.Run("認可画面のURLを返す", func(t *testing.T) {
+		// Arrange
+		h := NewReadLaterHandler(&mockReadLaterService{}, "https://feedman.example.com")
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.AuthorizePocket(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var got pocketAuthorizeResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !strings.HasPrefix(got.AuthorizeURL, "https://getpocket.com/auth/authorize") {
+			t.Errorf("authorize_url = %q", got.AuthorizeURL)
+		}
+	})
+}
+
+// --- GET /api/read-later/pocket/callback テスト ---
+
+func TestReadLaterHandler_PocketCallback(t *testing.T) {
+	t.Run("連携に成功したときフロントエンドへconnectedでリダイレクトする", func(t *testing.T) {
+		// Arrange
+		var gotState string
+		svc := &mockReadLaterService{completeFn: func(_ context.Context, _, state string) error {
+			gotState = state
+			return nil
+		}}
+		h := NewReadLaterHandler(svc, "https://feedman.example.com/")
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/?state=abc", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.PocketCallback(w, req)
+
+		// Assert
+		if w.Code != http.StatusFound {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusFound)
+		}
+		if got, want := w.Header().Get("Location"), "https://feedman.example.com/?read_later=pocket&result=connected"; got != want {
+			t.Errorf("Location = %q, want %q", got, want)
+		}
+		if gotState != "abc" {
+			t.Errorf("state = %q, want abc", gotState)
+		}
+	})
+
+	t.Run("認可に失敗したときエラーコードを付けてリダイレクトする", func(t *testing.T) {
+		// Arrange
+		svc := &mockReadLaterService{completeFn: func(context.Context, string, string) error {
+			return model.NewReadLaterAuthFailedError("拒否されました")
+		}}
+		h := NewReadLaterHandler(svc, "https://feedman.example.com")
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/?state=abc", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.PocketCallback(w, req)
+
+		// Assert
+		if got, want := w.Header().Get("Location"), "https://feedman.example.com/?code=READ_LATER_AUTH_FAILED&read_later=pocket&result=error"; got != want {
+			t.Errorf("Location = %q, want %q", got, want)
+		}
+	})
+}
+
+// --- POST /api/read-later/instapaper テスト ---
+
+func TestReadLaterHandler_ConnectInstapaper(t *testing.T) {
+	t.Run("連携を返しパスワードをレスポンスに含めない", func(t *testing.T) {
+		// Arrange
+		svc := &mockReadLaterService{}
+		h := NewReadLaterHandler(svc, "https://feedman.example.com")
+		body := `{"username":"alice","password":"pw-secret"}`
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ConnectInstapaper(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.gotPassword != "pw-secret" {
+			t.Errorf("password = %q, want pw-secret", svc.gotPassword)
+		}
+		if strings.Contains(w.Body.String(), "pw-secret") {
+			t.Errorf("body contains password: %s", w.Body.String())
+		}
+	})
+}
+
+// --- PUT /api/read-later/{provider} テスト ---
+
+func TestReadLaterHandler_UpdateConnection(t *testing.T) {
+	t.Run("enabledを指定したとき更新後の連携を返す", func(t *testing.T) {
+		// Arrange
+		h := NewReadLaterHandler(&mockReadLaterService{}, "https://feedman.example.com")
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"enabled":false}`))
+		req = withChiURLParam(withUserID(req, "user-1"), "provider", "pocket")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateConnection(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var got readLaterConnectionResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.Provider != "pocket" || got.Enabled {
+			t.Errorf("got = %+v, want pocket disabled", got)
+		}
+	})
+
+	t.Run("enabledがないとき400 INVALID_REQUESTを返す", func(t *testing.T) {
+		h := NewReadLaterHandler(&mockReadLaterService{}, "https://feedman.example.com")
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{}`))
+		req = withChiURLParam(withUserID(req, "user-1"), "provider", "pocket")
+		w := httptest.NewRecorder()
+
+		h.UpdateConnection(w, req)
+
+		if got := parseAPIErrorResponse(t, w); w.Code != http.StatusBadRequest || got["code"] != model.ErrCodeInvalidRequest {
+			t.Errorf("status = %d, code = %q, want 400 %s", w.Code, got["code"], model.ErrCodeInvalidRequest)
+		}
+	})
+
+	t.Run("連携していないとき404 READ_LATER_NOT_CONNECTEDを返す", func(t *testing.T) {
+		svc := &mockReadLaterService{setEnabledFn: func(context.Context, string, string, bool) (*readLaterConnectionResponse, error) {
+			return nil, model.NewReadLaterNotConnectedError(model.ReadLaterProviderInstapaper)
+		}}
+		h := NewReadLaterHandler(svc, "https://feedman.example.com")
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"enabled":true}`))
+		req = withChiURLParam(withUserID(req, "user-1"), "provider", "instapaper")
+		w := httptest.NewRecorder()
+
+		h.UpdateConnection(w, req)
+
+		if got := parseAPIErrorResponse(t, w); w.Code != http.StatusNotFound || got["code"] != model.ErrCodeReadLaterNotConnected {
+			t.Errorf("status = %d, code = %q, want 404 %s", w.Code, got["code"], model.ErrCodeReadLaterNotConnected)
+		}
+	})
+}
+
+// --- DELETE /api/read-later/{provider} テスト ---
+
+func TestReadLaterHandler_DeleteConnection(t *testing.T) {
+	t.Run("連携を解除したとき204を返す", func(t *testing.T) {
+		var gotProvider string
+		svc := &mockReadLaterService{disconnectFn: func(_ context.Context, _, provider string) error {
+			gotProvider = provider
+			return nil
+		}}
+		h := NewReadLaterHandler(svc, "https://feedman.example.com")
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodDelete, "/", nil), "user-1"), "provider", "pocket")
+		w := httptest.NewRecorder()
+
+		h.DeleteConnection(w, req)
+
+		if w.Code != http.StatusNoContent || gotProvider != "pocket" {
+			t.Errorf("status = %d, provider = %q, want 204 pocket", w.Code, gotProvider)
+		}
+	})
+
+	t.Run("サービスが失敗したとき500を返す", func(t *testing.T) {
+		svc := &mockReadLaterService{disconnectFn: func(context.Context, string, string) error {
+			return errors.New("db down")
+		}}
+		h := NewReadLaterHandler(svc, "https://feedman.example.com")
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodDelete, "/", nil), "user-1"), "provider", "pocket")
+		w := httptest.NewRecorder()
+
+		h.DeleteConnection(w, req)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Package handler の related_feed_handler.go は、同一サイトの関連フィードの HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/feeds/{id}/related : 同じホストの別フィード（カテゴリ別 RSS 等）と自分の購読状況
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// RelatedFeedServiceInterface は関連フィードハンドラが必要とするサービスインターフェース。
+type RelatedFeedServiceInterface interface {
+	// ListRelatedFeeds は指定フィードと同じホストの別フィードを購読状況付きで返す。
+	// リクエストユーザーが指定フィードを購読していない場合は FEED_NOT_FOUND を返す。
+	ListRelatedFeeds(ctx context.Context, userID, feedID string) (*relatedFeedsResponse, error)
+}
+
+// RelatedFeedHandler は関連フィードの HTTP ハンドラ。
+type RelatedFeedHandler struct {
+	service RelatedFeedServiceInterface
+}
+
+// NewRelatedFeedHandler は RelatedFeedHandler を生成する。
+func NewRelatedFeedHandler(service RelatedFeedServiceInterface) *RelatedFeedHandler {
+	return &RelatedFeedHandler{service: service}
+}
+
+// relatedFeedResponse は関連フィード 1 件。
+type relatedFeedResponse struct {
+	FeedID          string     `json:"feed_id"`
+	Title           string     `json:"title"`
+	FeedURL         string     `json:"feed_url"`
+	SiteURL         string     `json:"site_url"`
+	FetchStatus     string     `json:"fetch_status"`
+	LastPublishedAt *time.Time `json:"last_published_at"`
+	Subscribed      bool       `json:"subscribed"`
+	// SubscriptionID は購読済みの場合の購読ID。未購読の場合は null。
+	SubscriptionID *string `json:"subscription_id"`
+}
+
+// relatedFeedsResponse は GET /api/feeds/{id}/related のレスポンス。
+type relatedFeedsResponse struct {
+	Feeds []relatedFeedResponse `json:"feeds"`
+}
+
+// ListRelatedFeeds は同じホストの別フィードを購読状況付きで返す。
+// GET /api/feeds/{id}/related
+func (h *RelatedFeedHandler) ListRelatedFeeds(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	feedID := chi.URLParam(r, "id")
+
+	resp, err := h.service.ListRelatedFeeds(r.Context(), userID, feedID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
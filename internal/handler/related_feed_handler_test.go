@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockRelatedFeedService は RelatedFeedServiceInterface のモック実装。
+type mockRelatedFeedService struct {
+	listRelatedFeedsFn func(ctx context.Context, userID, feedID string) (*relatedFeedsResponse, error)
+}
+
+func (m *mockRelatedFeedService) ListRelatedFeeds(ctx context.Context, userID, feedID string) (*relatedFeedsResponse, error) {
+	if m.listRelatedFeedsFn != nil {
+		return m.listRelatedFeedsFn(ctx, userID, feedID)
+	}
+	return &relatedFeedsResponse{Feeds: []relatedFeedResponse{}}, nil
+}
+
+func TestRelatedFeedHandler_ListRelatedFeeds(t *testing.T) {
+	t.Run("関連フィードと購読状況を返す", func(t *testing.T) {
+		// Arrange
+		subID := "sub-2"
+		var gotUserID, gotFeedID string
+		svc := &mockRelatedFeedService{
+			listRelatedFeedsFn: func(_ context.Context, userID, feedID string) (*relatedFeedsResponse, error) {
+				gotUserID, gotFeedID = userID, feedID
+				return &relatedFeedsResponse{Feeds: []relatedFeedResponse{
+					{FeedID: "feed-2", Title: "Tech", Subscribed: true, SubscriptionID: &subID},
+					{FeedID: "feed-3", Title: "Life"},
+				}}, nil
+			},
+		}
+		h := NewRelatedFeedHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/related", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "feed-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListRelatedFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotUserID != "user-1" || gotFeedID != "feed-1" {
+			t.Errorf("service called with (%q, %q)", gotUserID, gotFeedID)
+		}
+		var body struct {
+			Feeds []map[string]interface{} `json:"feeds"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(body.Feeds) != 2 {
+			t.Fatalf("len(feeds) = %d, want 2", len(body.Feeds))
+		}
+		if body.Feeds[0]["subscribed"] != true || body.Feeds[0]["subscription_id"] != "sub-2" {
+			t.Errorf("feeds[0] = %v", body.Feeds[0])
+		}
+		if body.Feeds[1]["subscribed"] != false || body.Feeds[1]["subscription_id"] != nil {
+			t.Errorf("feeds[1] = %v", body.Feeds[1])
+		}
+	})
+
+	t.Run("フィードが見つからないとき404を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockRelatedFeedService{
+			listRelatedFeedsFn: func(context.Context, string, string) (*relatedFeedsResponse, error) {
+				return nil, &model.APIError{Code: model.ErrCodeFeedNotFound, Message: "not found"}
+			},
+		}
+		h := NewRelatedFeedHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-x/related", nil)
+		req = withChiURLParam(withUserID(req, "user-1"), "id", "feed-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListRelatedFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewRelatedFeedHandler(&mockRelatedFeedService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/related", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListRelatedFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// TestNewRouter_RelatedFeedRoutes は関連フィードルートの登録を検証する。
+func TestNewRouter_RelatedFeedRoutes(t *testing.T) {
+	newRouter := func(svc RelatedFeedServiceInterface) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+		}
+		if svc != nil {
+			deps.RelatedFeedService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds/feed-1/related", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("セッションありのときURLのフィードIDでサービスが呼ばれる", func(t *testing.T) {
+		// Arrange
+		var gotFeedID string
+		router := newRouter(&mockRelatedFeedService{
+			listRelatedFeedsFn: func(_ context.Context, _, feedID string) (*relatedFeedsResponse, error) {
+				gotFeedID = feedID
+				return &relatedFeedsResponse{Feeds: []relatedFeedResponse{}}, nil
+			},
+		})
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotFeedID != "feed-1" {
+			t.Errorf("feedID = %q, want %q", gotFeedID, "feed-1")
+		}
+	})
+
+	t.Run("RelatedFeedService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil)
+
+		// Act
+		w := doRequest(router)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
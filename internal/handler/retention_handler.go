@@ -0,0 +1,105 @@
+// Package handler の retention_handler.go は、購読単位の最大記事保持数（retention_override）の
+// HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/subscriptions/{id}/retention : 最大記事保持数の取得
+//   - PUT /api/subscriptions/{id}/retention : 最大記事保持数の更新（null 指定で解除）
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// RetentionServiceInterface は最大記事保持数ハンドラが必要とするサービスインターフェース。
+type RetentionServiceInterface interface {
+	// GetRetentionOverride は当該ユーザーの購読の最大記事保持数を返す。
+	GetRetentionOverride(ctx context.Context, userID, subscriptionID string) (*retentionOverrideResponse, error)
+	// UpdateRetentionOverride は当該ユーザーの購読の最大記事保持数を更新し、更新後の設定を返す。
+	UpdateRetentionOverride(ctx context.Context, userID, subscriptionID string, maxItems *int) (*retentionOverrideResponse, error)
+}
+
+// RetentionHandler は最大記事保持数の HTTP ハンドラ。
+type RetentionHandler struct {
+	service RetentionServiceInterface
+}
+
+// NewRetentionHandler は RetentionHandler を生成する。
+func NewRetentionHandler(service RetentionServiceInterface) *RetentionHandler {
+	return &RetentionHandler{service: service}
+}
+
+// retentionOverrideResponse は最大記事保持数のAPIレスポンス。未設定時の max_items は null。
+type retentionOverrideResponse struct {
+	MaxItems *int `json:"max_items"`
+}
+
+// retentionOverrideRequest は最大記事保持数更新リクエストのボディ。
+type retentionOverrideRequest struct {
+	MaxItems *int `json:"max_items"`
+}
+
+// GetRetentionOverride は購読の最大記事保持数を返す。
+// GET /api/subscriptions/{id}/retention
+func (h *RetentionHandler) GetRetentionOverride(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	override, err := h.service.GetRetentionOverride(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, override)
+}
+
+// UpdateRetentionOverride は購読の最大記事保持数を更新する。
+// PUT /api/subscriptions/{id}/retention
+//
+// 設定は次回のクリーンアップから適用される。フィードの全購読者が設定している場合にのみ
+// 件数による削除が行われ、保持数は購読者間の最大値が採用される。
+func (h *RetentionHandler) UpdateRetentionOverride(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req retentionOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	override, err := h.service.UpdateRetentionOverride(r.Context(), userID, chi.URLParam(r, "id"), req.MaxItems)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, override)
+}
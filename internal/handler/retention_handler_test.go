@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockRetentionService は RetentionServiceInterface のモック実装。
+type mockRetentionService struct {
+	getFn       func(ctx context.Context, userID, subscriptionID string) (*retentionOverrideResponse, error)
+	updateFn    func(ctx context.Context, userID, subscriptionID string, maxItems *int) (*retentionOverrideResponse, error)
+	updateCalls int
+}
+
+func (m *mockRetentionService) GetRetentionOverride(ctx context.Context, userID, subscriptionID string) (*retentionOverrideResponse, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx, userID, subscriptionID)
+	}
+	return &retentionOverrideResponse{}, nil
+}
+
+func (m *mockRetentionService) UpdateRetentionOverride(ctx context.Context, userID, subscriptionID string, maxItems *int) (*retentionOverrideResponse, error) {
+	m.updateCalls++
+	if m.updateFn != nil {
+		return m.updateFn(ctx, userID, subscriptionID, maxItems)
+	}
+	return &retentionOverrideResponse{MaxItems: maxItems}, nil
+}
+
+// --- GET /api/subscriptions/{id}/retention テスト ---
+
+func TestRetentionHandler_GetRetentionOverride(t *testing.T) {
+	t.Run("未設定のときmax_itemsをnullで返す", func(t *testing.T) {
+		// Arrange
+		h := NewRetentionHandler(&mockRetentionService{})
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetRetentionOverride(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got := strings.TrimSpace(w.Body.String()); got != `{"max_items":null}` {
+			t.Errorf("body = %s, want {\"max_items\":null}", got)
+		}
+	})
+
+	t.Run("購読が見つからないとき404 SUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockRetentionService{
+			getFn: func(_ context.Context, _, subscriptionID string) (*retentionOverrideResponse, error) {
+				return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		h := NewRetentionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/", nil), "user-1"), "id", "sub-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetRetentionOverride(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewRetentionHandler(&mockRetentionService{})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetRetentionOverride(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- PUT /api/subscriptions/{id}/retention テスト ---
+
+func TestRetentionHandler_UpdateRetentionOverride(t *testing.T) {
+	t.Run("保持数を指定したとき更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockRetentionService{
+			updateFn: func(_ context.Context, userID, subscriptionID string, maxItems *int) (*retentionOverrideResponse, error) {
+				if userID != "user-1" || subscriptionID != "sub-1" || maxItems == nil || *maxItems != 200 {
+					t.Errorf("args = (%q, %q, %v), want (user-1, sub-1, 200)", userID, subscriptionID, maxItems)
+				}
+				return &retentionOverrideResponse{MaxItems: maxItems}, nil
+			},
+		}
+		h := NewRetentionHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"max_items":200}`)), "user-1")
+		req = withChiURLParam(req, "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateRetentionOverride(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if got := strings.TrimSpace(w.Body.String()); got != `{"max_items":200}` {
+			t.Errorf("body = %s, want {\"max_items\":200}", got)
+		}
+	})
+
+	t.Run("nullを指定したとき上書きを解除する", func(t *testing.T) {
+		// Arrange
+		svc := &mockRetentionService{
+			updateFn: func(_ context.Context, _, _ string, maxItems *int) (*retentionOverrideResponse, error) {
+				if maxItems != nil {
+					t.Errorf("maxItems = %v, want nil", *maxItems)
+				}
+				return &retentionOverrideResponse{}, nil
+			},
+		}
+		h := NewRetentionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"max_items":null}`)), "user-1"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateRetentionOverride(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("不正なJSONのとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockRetentionService{}
+		h := NewRetentionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{`)), "user-1"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateRetentionOverride(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("UpdateRetentionOverride calls = %d, want 0", svc.updateCalls)
+		}
+	})
+
+	t.Run("保持数が範囲外のとき400 INVALID_RETENTION_OVERRIDEを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockRetentionService{
+			updateFn: func(_ context.Context, _, _ string, maxItems *int) (*retentionOverrideResponse, error) {
+				return nil, model.NewInvalidRetentionOverrideError(*maxItems)
+			},
+		}
+		h := NewRetentionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"max_items":1}`)), "user-1"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateRetentionOverride(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidRetentionOverride {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidRetentionOverride)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_RetentionRoutes は最大記事保持数のルートが RetentionService 配線時のみ登録されることを検証する。
+func TestNewRouter_RetentionRoutes(t *testing.T) {
+	newRouter := func(svc RetentionServiceInterface) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+		}
+		if svc != nil {
+			deps.RetentionService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-1/retention", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("RetentionService を配線したとき200を返す", func(t *testing.T) {
+		// Act
+		w := doRequest(newRouter(&mockRetentionService{}))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("RetentionService が nil のときルートを登録しない", func(t *testing.T) {
+		// Act
+		w := doRequest(newRouter(nil))
+
+		// Assert
+		if w.Code != http.StatusNotFound && w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("status = %d, want 404 or 405", w.Code)
+		}
+	})
+}
@@ -2,12 +2,12 @@ package handler
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
 )
 
 // SetupAuthRoutes は認証関連のルーティングを設定したchi.Routerを返す。
@@ -23,6 +23,7 @@ func SetupAuthRoutes(service AuthServiceInterface, config AuthHandlerConfig) htt
 		// セッション管理
 		r.Post("/logout", h.Logout)
 		r.Get("/me", h.Me)
+		r.Post("/rotate-session", h.RotateSession)
 	})
 
 	return r
@@ -43,6 +44,14 @@ type RouterDeps struct {
 	CORSAllowedOrigin string
 	RateLimiter       *middleware.RateLimiter
 
+	// CORSAllowedOrigins は全エンドポイントで CORSAllowedOrigin に加えて許可するオリジン
+	// （"https://*.example.com" のワイルドカードを含む）。空の場合は CORSAllowedOrigin のみを許可する。
+	CORSAllowedOrigins []string
+
+	// CORSExtensionOrigins はフィード登録（POST /api/feeds）に限り追加で許可する
+	// ブラウザ拡張等のオリジン。空の場合は CORSAllowedOrigin のみを許可する（後方互換）。
+	CORSExtensionOrigins []string
+
 	// UnauthIPRateLimiter は未認証エンドポイント（/auth/google/login・
 	// /auth/google/callback・/health）に適用する IP 単位レート制限。
 	// nil の場合は IP レート制限を適用せず、既存ルーティングを完全に不変に保つ（後方互換）。
@@ -52,6 +61,15 @@ type RouterDeps struct {
 	// false（既定）の場合は HTTPS 配信でも HSTS を付与しない。
 	HSTSEnabled bool
 
+	// MaxJSONBodyBytes は全ルートに適用するリクエストボディの上限バイト数。
+	// 0 以下の場合は middleware.DefaultMaxJSONBodyBytes（1MB）を使う。
+	// パース診断等の大きなボディを受け取るルートは r.With(middleware.NewBodyLimitMiddleware(...)) で個別に引き上げる。
+	MaxJSONBodyBytes int64
+
+	// PageLimits は一覧 API の limit クエリパラメータの既定値と上限値。
+	// 0 以下の項目は DefaultPageLimit（50）/ DefaultMaxPageLimit（200）を使う。
+	PageLimits PageLimitConfig
+
 	// アクセスログ出力に使用する構造化ロガー。
 	// nil の場合は slog.Default() にフォールバックする（後方互換）。
 	Logger *slog.Logger
@@ -87,17 +105,129 @@ type RouterDeps struct {
 
 	// 横断新着一覧（Issue #121）
 	CrossFeedService CrossFeedServiceInterface
+
+	// 購読リストの公開プロフィール共有（任意）。
+	// nil の場合は公開プロフィール関連ルートを登録しない（後方互換）。
+	PublicProfileService PublicProfileServiceInterface
+
+	// 購読単位の記事取り込みフィルタ（任意）。
+	// nil の場合は /api/subscriptions/{id}/import-filter を登録しない（後方互換）。
+	ImportFilterService ImportFilterServiceInterface
+
+	// 購読単位の最大記事保持数（任意）。
+	// nil の場合は /api/subscriptions/{id}/retention を登録しない（後方互換）。
+	RetentionService RetentionServiceInterface
+
+	// 購読単位の通知ヒント設定（任意）。
+	// nil の場合は /api/subscriptions/{id}/notification を登録しない（後方互換）。
+	NotificationService NotificationServiceInterface
+
+	// フィードタイトルの自動更新ポリシー（任意）。
+	// nil の場合は /api/feeds/{id}/title-policy と /api/feeds/{id}/title/* を登録しない（後方互換）。
+	FeedTitleService FeedTitleServiceInterface
+	// Slack / Discord への新着記事の転送設定（任意）。
+	// nil の場合は /api/integrations/* を登録しない（後方互換）。
+	IntegrationService IntegrationServiceInterface
+	// スター記事の Pocket / Instapaper への自動保存の連携設定（任意）。
+	// nil の場合は /api/read-later/* を登録しない（後方互換）。
+	ReadLaterService ReadLaterServiceInterface
+	// 貼り付けた複数の URL のフィード一括登録（任意）。
+	// nil の場合は /api/feeds/batch を登録しない（後方互換）。
+	FeedBatchService FeedBatchServiceInterface
+	// 登録前のフィード URL のプリフライト（任意）。
+	// nil の場合は /api/feeds/validate を登録しない（後方互換）。
+	FeedPreflightService FeedPreflightServiceInterface
+	// メールアドレスに一回限りのログインリンクを送るマジックリンク認証（任意）。
+	// nil の場合は /auth/magic-link を登録しない（後方互換）。
+	MagicLinkService MagicLinkServiceInterface
+
+	// 積読解消向けのランダム記事取り出し（任意）。
+	// nil の場合は /api/items/random を登録しない（後方互換）。
+	RandomItemService RandomItemServiceInterface
+
+	// ユーザー設定（積読警告の閾値など。任意）。
+	// nil の場合はユーザー設定関連ルートを登録しない（後方互換）。
+	UserSettingsService UserSettingsServiceInterface
+
+	// 閲覧統計（よく読むフィードのランキング。任意）。
+	// nil の場合は /api/stats/* を登録しない（後方互換）。
+	StatsService StatsServiceInterface
+
+	// デイリー / ウィークリーのハイライト（任意）。
+	// nil の場合は /api/highlights を登録しない（後方互換）。
+	HighlightService HighlightServiceInterface
+
+	// 購読フィードの記事傾向サマリー（任意）。
+	// nil の場合は /api/subscriptions/{id}/keywords を登録しない（後方互換）。
+	KeywordService KeywordServiceInterface
+
+	// API 利用量（任意）。UsageRecorder は認証必須ルートの利用量の記録先で、
+	// nil の場合は記録しない。UsageService が nil の場合は /api/usage を登録しない（後方互換）。
+	UsageRecorder middleware.UsageRecorder
+	UsageService  UsageServiceInterface
+
+	// 最終アクティブ日時の記録先（任意）。nil の場合は記録しない（後方互換）。
+	ActivityTracker middleware.ActivityTracker
+
+	// 管理者向け調査用 API（フィードのパース診断。任意）。
+	// nil の場合は /api/debug/* を登録しない（後方互換）。
+	FeedDebugService FeedDebugServiceInterface
+	// 管理者向け全体統計（任意）。
+	// nil の場合は /api/admin/* を登録しない（後方互換）。
+	AdminStatsService AdminStatsServiceInterface
+	// 同一ホストの関連フィード（任意）。
+	// nil の場合は /api/feeds/{id}/related を登録しない（後方互換）。
+	RelatedFeedService RelatedFeedServiceInterface
+	// 元記事への訪問（既読化 + リダイレクト。任意）。
+	// nil の場合は /api/items/{id}/visit を登録しない（後方互換）。
+	ItemVisitService ItemVisitServiceInterface
+	// オフライン中の既読・スター操作の同期（任意）。
+	// nil の場合は /api/sync/operations を登録しない（後方互換）。
+	SyncService SyncServiceInterface
+	// 記事の要約のオンデマンド生成（任意）。
+	// nil の場合は /api/items/{id}/summarize を登録しない（後方互換）。
+	ItemSummaryService ItemSummaryServiceInterface
+	// 監査ログの閲覧（購読操作の変更履歴。任意）。
+	// nil の場合は /api/audit-logs を登録しない（後方互換）。
+	AuditLogService AuditLogServiceInterface
+	// ログイン履歴の閲覧（ログイン成功・失敗・ログアウト・セッション失効。任意）。
+	// nil の場合は /api/users/me/login-history を登録しない（後方互換）。
+	LoginHistoryService LoginHistoryServiceInterface
+	// アカウント連携（identity）の一覧・解除（任意）。
+	// nil の場合は /api/users/me/identities を登録しない（後方互換）。
+	IdentityService IdentityServiceInterface
+	// チームでの購読リスト共有（任意）。
+	// nil の場合は /api/teams/* を登録しない（後方互換）。
+	TeamService TeamServiceInterface
+	// 管理者向けフィード設定（条件付き GET の無効化など。任意）。
+	// nil の場合は /api/admin/feeds/* を登録しない（後方互換）。
+	FeedAdminService FeedAdminServiceInterface
+	// 管理者向けフェッチサイクル履歴（任意）。
+	// nil の場合は /api/admin/worker-cycles を登録しない（後方互換）。
+	WorkerCycleService WorkerCycleServiceInterface
+	// 管理者向けフィードのブロックリスト（モデレーション。任意）。
+	// nil の場合は /api/admin/blocked-domains を登録しない（後方互換）。
+	BlockedDomainService BlockedDomainServiceInterface
+	// IdempotencyStore は書き込み系 API の Idempotency-Key によるレスポンス再生の保存先（任意）。
+	// nil の場合は Idempotency-Key ヘッダを解釈しない（後方互換）。
+	IdempotencyStore middleware.IdempotencyStore
+	// AdminUserIDs は管理者限定エンドポイントへのアクセスを許可するユーザーID。
+	// 空の場合は管理者限定エンドポイントへのリクエストを全て 403 で拒否する（安全側）。
+	AdminUserIDs []string
 }
 
 // NewRouter は全APIエンドポイントのルーティングとミドルウェアチェーンを構成したchi.Routerを返す。
 //
 // ミドルウェアスタックの実行順序:
 //   - 全ルート共通（最上位）: Recovery → SecurityHeaders → CORS
-//   - 認証不要ルート（/health, /auth/*）: 上記共通 → Logging
+//   - 認証不要ルート（/health, /auth/*, /public/*）: 上記共通 → Logging
 //   - うち /health・/auth/google/login・/auth/google/callback の 3 ルートのみ
 //     Logging の内側に IP 単位レート制限（UnauthIPRateLimiter）を重ねる。
 //     /auth/logout・/auth/me には適用しない（セッションを持つため）。
-//   - 認証必須ルート（/api/*）: 上記共通 → Session → RateLimit(General) → Logging
+//   - 認証必須ルート（/api/*）: 上記共通 → Session → Activity → Usage → RateLimit(General) → Logging → Idempotency
+//     （Activity は deps.ActivityTracker が非 nil のときのみ。
+//     Usage は deps.UsageRecorder が非 nil のときのみ。レート制限で拒否したリクエストもエラーとして数える。
+//     Idempotency は deps.IdempotencyStore が非 nil のときのみ。書き込み系リクエストのみが対象）
 //
 // Logging を Session の内側（後ろ）に置くことで、認証済みリクエストの user_id を
 // アクセスログに含められる。/health・/auth/* は Session を通らないため user_id は付与されない。
@@ -107,14 +237,31 @@ type RouterDeps struct {
 func NewRouter(deps *RouterDeps) http.Handler {
 	r := chi.NewRouter()
 
-	// panic recovery を最上位に適用
+	// リクエスト ID を最上位で採番し、panic を含む全エラー応答とアクセスログに載せる
+	r.Use(middleware.NewRequestIDMiddleware())
+
+	// panic recovery を適用
 	r.Use(middleware.NewRecoveryMiddleware())
 
 	// セキュリティヘッダーを適用（全ルートに効く）
 	r.Use(middleware.NewSecurityHeadersMiddleware(deps.HSTSEnabled))
 
 	// CORS ミドルウェアを適用（全ルートに効く）
-	r.Use(middleware.NewCORSMiddleware(deps.CORSAllowedOrigin))
+	// CORSAllowedOrigins が指定された場合は一致したオリジンを、CORSExtensionOrigins が指定された場合は
+	// フィード登録に限り拡張オリジンも許可する。
+	r.Use(middleware.NewCORSMiddleware(deps.CORSAllowedOrigin,
+		middleware.WithAllowedOrigins(deps.CORSAllowedOrigins),
+		middleware.WithExtensionOrigins(deps.CORSExtensionOrigins)))
+
+	// リクエストボディの上限を適用する（全ルートに効く）。上限超過は 413 PAYLOAD_TOO_LARGE。
+	maxJSONBodyBytes := deps.MaxJSONBodyBytes
+	if maxJSONBodyBytes <= 0 {
+		maxJSONBodyBytes = middleware.DefaultMaxJSONBodyBytes
+	}
+	r.Use(middleware.NewBodyLimitMiddleware(maxJSONBodyBytes))
+
+	// 一覧 API の limit の既定値・上限をコンテキストに載せる（全ルートに効く）。上限超過の指定は上限に丸める。
+	r.Use(newPageLimitMiddleware(deps.PageLimits))
 
 	// アクセスログ用ロガー。未指定時はアプリ標準ロガー（slog.Default）にフォールバック。
 	logger := deps.Logger
@@ -137,6 +284,179 @@ func NewRouter(deps *RouterDeps) http.Handler {
 		crossFeedHandler = NewCrossFeedHandler(deps.CrossFeedService)
 	}
 
+	// PublicProfileService が nil の場合は PublicProfileHandler を生成しない（後方互換）。
+	var publicProfileHandler *PublicProfileHandler
+	if deps.PublicProfileService != nil {
+		publicProfileHandler = NewPublicProfileHandler(deps.PublicProfileService)
+	}
+
+	// ImportFilterService が nil の場合は ImportFilterHandler を生成しない（後方互換）。
+	var importFilterHandler *ImportFilterHandler
+	if deps.ImportFilterService != nil {
+		importFilterHandler = NewImportFilterHandler(deps.ImportFilterService)
+	}
+
+	// RetentionService が nil の場合は RetentionHandler を生成しない（後方互換）。
+	var retentionHandler *RetentionHandler
+	if deps.RetentionService != nil {
+		retentionHandler = NewRetentionHandler(deps.RetentionService)
+	}
+
+	// NotificationService が nil の場合は NotificationHandler を生成しない（後方互換）。
+	var notificationHandler *NotificationHandler
+	if deps.NotificationService != nil {
+		notificationHandler = NewNotificationHandler(deps.NotificationService)
+	}
+
+	// FeedTitleService が nil の場合は FeedTitleHandler を生成しない（後方互換）。
+	var feedTitleHandler *FeedTitleHandler
+	if deps.FeedTitleService != nil {
+		feedTitleHandler = NewFeedTitleHandler(deps.FeedTitleService)
+	}
+
+	// RandomItemService が nil の場合は RandomItemHandler を生成しない（後方互換）。
+	var randomItemHandler *RandomItemHandler
+	if deps.RandomItemService != nil {
+		randomItemHandler = NewRandomItemHandler(deps.RandomItemService)
+	}
+
+	// RelatedFeedService が nil の場合は RelatedFeedHandler を生成しない（後方互換）。
+	var relatedFeedHandler *RelatedFeedHandler
+	if deps.RelatedFeedService != nil {
+		relatedFeedHandler = NewRelatedFeedHandler(deps.RelatedFeedService)
+	}
+
+	// ItemVisitService が nil の場合は ItemVisitHandler を生成しない（後方互換）。
+	var itemVisitHandler *ItemVisitHandler
+	if deps.ItemVisitService != nil {
+		itemVisitHandler = NewItemVisitHandler(deps.ItemVisitService)
+	}
+
+	// SyncService が nil の場合は SyncHandler を生成しない（後方互換）。
+	var syncHandler *SyncHandler
+	if deps.SyncService != nil {
+		syncHandler = NewSyncHandler(deps.SyncService)
+	}
+
+	// ItemSummaryService が nil の場合は ItemSummaryHandler を生成しない（後方互換）。
+	var itemSummaryHandler *ItemSummaryHandler
+	if deps.ItemSummaryService != nil {
+		itemSummaryHandler = NewItemSummaryHandler(deps.ItemSummaryService)
+	}
+
+	// AuditLogService が nil の場合は AuditLogHandler を生成しない（後方互換）。
+	var auditLogHandler *AuditLogHandler
+	if deps.AuditLogService != nil {
+		auditLogHandler = NewAuditLogHandler(deps.AuditLogService)
+	}
+
+	// LoginHistoryService が nil の場合は LoginHistoryHandler を生成しない（後方互換）。
+	var loginHistoryHandler *LoginHistoryHandler
+	if deps.LoginHistoryService != nil {
+		loginHistoryHandler = NewLoginHistoryHandler(deps.LoginHistoryService)
+	}
+	// IdentityService が nil の場合は IdentityHandler を生成しない（後方互換）。
+	var identityHandler *IdentityHandler
+	if deps.IdentityService != nil {
+		identityHandler = NewIdentityHandler(deps.IdentityService)
+	}
+
+	// IntegrationService が nil の場合は IntegrationHandler を生成しない（後方互換）。
+	var integrationHandler *IntegrationHandler
+	if deps.IntegrationService != nil {
+		integrationHandler = NewIntegrationHandler(deps.IntegrationService)
+	}
+
+	// ReadLaterService が nil の場合は ReadLaterHandler を生成しない（後方互換）。
+	var readLaterHandler *ReadLaterHandler
+	if deps.ReadLaterService != nil {
+		readLaterHandler = NewReadLaterHandler(deps.ReadLaterService, deps.AuthConfig.BaseURL)
+	}
+
+	// FeedBatchService が nil の場合は FeedBatchHandler を生成しない（後方互換）。
+	var feedBatchHandler *FeedBatchHandler
+	if deps.FeedBatchService != nil {
+		feedBatchHandler = NewFeedBatchHandler(deps.FeedBatchService)
+	}
+
+	// FeedPreflightService が nil の場合は FeedPreflightHandler を生成しない（後方互換）。
+	var feedPreflightHandler *FeedPreflightHandler
+	if deps.FeedPreflightService != nil {
+		feedPreflightHandler = NewFeedPreflightHandler(deps.FeedPreflightService)
+	}
+
+	// MagicLinkService が nil の場合は MagicLinkHandler を生成しない（後方互換）。
+	var magicLinkHandler *MagicLinkHandler
+	if deps.MagicLinkService != nil {
+		magicLinkHandler = NewMagicLinkHandler(deps.MagicLinkService, authHandler)
+	}
+
+	// TeamService が nil の場合は TeamHandler を生成しない（後方互換）。
+	var teamHandler *TeamHandler
+	if deps.TeamService != nil {
+		teamHandler = NewTeamHandler(deps.TeamService)
+	}
+
+	// UserSettingsService が nil の場合は UserSettingsHandler を生成しない（後方互換）。
+	var userSettingsHandler *UserSettingsHandler
+	if deps.UserSettingsService != nil {
+		userSettingsHandler = NewUserSettingsHandler(deps.UserSettingsService)
+	}
+
+	// StatsService が nil の場合は StatsHandler を生成しない（後方互換）。
+	var statsHandler *StatsHandler
+	if deps.StatsService != nil {
+		statsHandler = NewStatsHandler(deps.StatsService)
+	}
+
+	// HighlightService が nil の場合は HighlightHandler を生成しない（後方互換）。
+	var highlightHandler *HighlightHandler
+	if deps.HighlightService != nil {
+		highlightHandler = NewHighlightHandler(deps.HighlightService)
+	}
+
+	// KeywordService が nil の場合は KeywordHandler を生成しない（後方互換）。
+	var keywordHandler *KeywordHandler
+	if deps.KeywordService != nil {
+		keywordHandler = NewKeywordHandler(deps.KeywordService)
+	}
+
+	// UsageService が nil の場合は UsageHandler を生成しない（後方互換）。
+	var usageHandler *UsageHandler
+	if deps.UsageService != nil {
+		usageHandler = NewUsageHandler(deps.UsageService)
+	}
+
+	// FeedDebugService が nil の場合は DebugHandler を生成しない（後方互換）。
+	var debugHandler *DebugHandler
+	if deps.FeedDebugService != nil {
+		debugHandler = NewDebugHandler(deps.FeedDebugService)
+	}
+
+	// AdminStatsService が nil の場合は AdminHandler を生成しない（後方互換）。
+	var adminHandler *AdminHandler
+	if deps.AdminStatsService != nil {
+		adminHandler = NewAdminHandler(deps.AdminStatsService)
+	}
+
+	// FeedAdminService が nil の場合は FeedAdminHandler を生成しない（後方互換）。
+	var feedAdminHandler *FeedAdminHandler
+	if deps.FeedAdminService != nil {
+		feedAdminHandler = NewFeedAdminHandler(deps.FeedAdminService)
+	}
+
+	// WorkerCycleService が nil の場合は WorkerCycleHandler を生成しない（後方互換）。
+	var workerCycleHandler *WorkerCycleHandler
+	if deps.WorkerCycleService != nil {
+		workerCycleHandler = NewWorkerCycleHandler(deps.WorkerCycleService)
+	}
+
+	// BlockedDomainService が nil の場合は BlockedDomainHandler を生成しない（後方互換）。
+	var blockedDomainHandler *BlockedDomainHandler
+	if deps.BlockedDomainService != nil {
+		blockedDomainHandler = NewBlockedDomainHandler(deps.BlockedDomainService)
+	}
+
 	// 未認証エンドポイント向け IP 単位レート制限ミドルウェア。
 	// UnauthIPRateLimiter が nil の場合は素通し（制限なし）として扱い、既存ルーティングを
 	// 完全に不変に保つ（後方互換）。login・callback・health の 3 ルートにのみ適用し、
@@ -157,9 +477,7 @@ func NewRouter(deps *RouterDeps) http.Handler {
 			}
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(httpStatus)
-		json.NewEncoder(w).Encode(map[string]string{"status": status})
+		WriteJSON(w, httpStatus, map[string]string{"status": status})
 	}
 
 	// --- 認証不要のルート ---
@@ -175,11 +493,24 @@ func NewRouter(deps *RouterDeps) http.Handler {
 			// OAuth フローの入口は IP 単位レート制限を適用する（OAuth フラッディング対策）。
 			r.With(unauthIPMW).Get("/google/login", authHandler.Login)
 			r.With(unauthIPMW).Get("/google/callback", authHandler.Callback)
-			// logout・me はセッションを持つ実質認証エンドポイントのため IP 制限の対象外。
+			// logout・me・rotate-session はセッションを持つ実質認証エンドポイントのため IP 制限の対象外。
 			r.Post("/logout", authHandler.Logout)
 			r.Get("/me", authHandler.Me)
+			// セッションIDの任意ローテーション（セッション固定攻撃対策）。
+			r.Post("/rotate-session", authHandler.RotateSession)
+
+			// マジックリンク認証（任意）。送信依頼はメール送信の踏み台にされうるため、
+			// OAuth フローの入口と同様に IP 単位レート制限を適用する。
+			if magicLinkHandler != nil {
+				r.With(unauthIPMW).Post("/magic-link", magicLinkHandler.Request)
+				r.With(unauthIPMW).Get("/magic-link/verify", magicLinkHandler.Verify)
+			}
 		})
 
+		// ブラウザ拡張・ブックマークレットからの購読追加の入口。セッション有無で誘導先を切り替えるため
+		// Session ミドルウェアは通さない。外部サイトから叩かれうるため IP 単位レート制限を適用する。
+		r.With(unauthIPMW).Get("/subscribe", authHandler.Subscribe)
+
 		// メトリクス公開エンドポイント（任意）。
 		// MetricsHandler が非 nil のときのみ登録し、前段に MetricsMiddleware（信頼 CIDR 制限）を
 		// 重ねる。MetricsHandler が nil の場合は登録せず既存ルーティングを完全に不変に保つ（後方互換）。
@@ -191,21 +522,51 @@ func NewRouter(deps *RouterDeps) http.Handler {
 			}
 			r.With(mw).Handle("/metrics", deps.MetricsHandler)
 		}
+
+		// 公開プロフィールの購読一覧（認証不要）。スラッグの総当たりを抑止するため
+		// IP 単位レート制限を適用する。PublicProfileService 未配線の deps では登録しない。
+		if publicProfileHandler != nil {
+			r.With(unauthIPMW).Get("/public/{slug}/subscriptions", publicProfileHandler.ListPublicSubscriptions)
+		}
 	})
 
 	// --- 認証が必要なルート ---
-	// ミドルウェアスタック: Session → RateLimit(General) → Logging
+	// ミドルウェアスタック: Session → Activity → Usage → RateLimit(General) → Logging
 	// Logging を Session の後ろに置くことで user_id をログに含める。
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.NewSessionMiddleware(deps.SessionFinder))
+		if deps.ActivityTracker != nil {
+			r.Use(middleware.NewActivityMiddleware(deps.ActivityTracker))
+		}
+		if deps.UsageRecorder != nil {
+			r.Use(middleware.NewUsageMiddleware(deps.UsageRecorder))
+		}
 		r.Use(deps.RateLimiter.GeneralMiddleware())
 		r.Use(logging)
+		// Idempotency-Key 付きの再送には最初のレスポンスを再生する。再送もレート制限とアクセスログの対象とする。
+		if deps.IdempotencyStore != nil {
+			r.Use(middleware.NewIdempotencyMiddleware(deps.IdempotencyStore, model.IdempotencyKeyTTL))
+		}
 
 		// フィード管理
 		r.Route("/api/feeds", func(r chi.Router) {
 			// POST /api/feeds - フィード登録（登録専用レート制限を追加）
 			r.With(deps.RateLimiter.FeedRegistrationMiddleware()).Post("/", feedHandler.RegisterFeed)
 
+			// POST /api/feeds/batch - URL の一括登録の開始、GET /api/feeds/batch/{batchId} - 進捗と結果の取得。
+			// FeedBatchService が未配線の deps では登録しない。静的セグメント `batch` は `{id}` より優先される。
+			// 登録のレート制限は POST /api/feeds と同じバケットから URL の件数分を消費する。
+			if feedBatchHandler != nil {
+				r.With(deps.RateLimiter.FeedBatchRegistrationMiddleware(feedBatchRegistrationCost)).Post("/batch", feedBatchHandler.StartBatch)
+				r.Get("/batch/{batchId}", feedBatchHandler.GetBatch)
+			}
+
+			// POST /api/feeds/validate - 登録せずにフィード URL を検証する（プリフライト）。
+			// 検出のために外部へリクエストを送るため、登録と同じレート制限（ユーザーごとのバケット）を共有する。
+			if feedPreflightHandler != nil {
+				r.With(deps.RateLimiter.FeedRegistrationMiddleware()).Post("/validate", feedPreflightHandler.ValidateFeedURL)
+			}
+
 			// GET /api/feeds/starred/items - 全フィード横断スター記事一覧（Issue #117）
 			// chi v5 のトライ木は静的セグメント `starred` を動的パラメータ `{id}` より優先するため、
 			// 登録順を問わず `/api/feeds/{id}/items` と衝突しない。可読性のため `/{id}` ブロックの
@@ -217,11 +578,33 @@ func NewRouter(deps *RouterDeps) http.Handler {
 				r.Patch("/", feedHandler.UpdateFeedURL)
 				r.Delete("/", feedHandler.DeleteFeed)
 
+				// GET /api/feeds/{id}/health - フェッチ状態と直近の失敗詳細（TLS エラー種別・証明書期限等）
+				r.Get("/health", feedHandler.GetFeedHealth)
+
 				// GET /api/feeds/{id}/items - フィードごとの記事一覧
 				r.Get("/items", itemHandler.ListItems)
+
+				// GET /api/feeds/{id}/authors - フィード内の著者一覧と記事数
+				r.Get("/authors", itemHandler.ListAuthors)
+
+				// GET /api/feeds/{id}/related - 同じホストの関連フィード
+				if relatedFeedHandler != nil {
+					r.Get("/related", relatedFeedHandler.ListRelatedFeeds)
+				}
+
+				// フィードタイトルの自動更新ポリシーと承認待ちタイトルの承認・破棄
+				if feedTitleHandler != nil {
+					r.Get("/title-policy", feedTitleHandler.GetFeedTitleSetting)
+					r.Put("/title-policy", feedTitleHandler.UpdateTitleUpdatePolicy)
+					r.Post("/title/approve", feedTitleHandler.ApprovePendingTitle)
+					r.Post("/title/dismiss", feedTitleHandler.DismissPendingTitle)
+				}
 			})
 		})
 
+		// GET /api/items?feed_ids=a,b,c - 複数フィードを指定した横断の記事一覧
+		r.Get("/api/items", itemHandler.ListItemsByFeeds)
+
 		// 記事検索（/api/items/{id} よりも前に登録する必要がある。
 		// chi は static segment `/search` を `{id}` よりも優先するが、明示的に
 		// 先に登録することで `search` が `{id}` の捕捉に吸われる可能性を確実に排除する）。
@@ -235,23 +618,78 @@ func NewRouter(deps *RouterDeps) http.Handler {
 			r.Get("/api/items/cross-feed", crossFeedHandler.ListItems)
 		}
 
+		// ランダム記事取り出し（「何か読む」）。cross-feed と同じく /api/items/{id} よりも前に登録する。
+		if randomItemHandler != nil {
+			r.Get("/api/items/random", randomItemHandler.PickItems)
+		}
+
 		// 記事管理
 		r.Route("/api/items/{id}", func(r chi.Router) {
 			r.Get("/", itemHandler.GetItem)
 			r.Put("/state", itemHandler.UpdateItemState)
+			// POST /api/items/{id}/read-beacon - ページ離脱時の sendBeacon による既読化。
+			// JSON ボディを伴わないため同一オリジン（CORS の許可オリジン）からのリクエストに限定する。
+			r.With(middleware.NewSameOriginMiddleware(deps.CORSAllowedOrigin, deps.CORSAllowedOrigins)).
+				Post("/read-beacon", itemHandler.ReadBeacon)
+			// GET /api/items/{id}/visit - 既読化して元記事へリダイレクト
+			if itemVisitHandler != nil {
+				r.Get("/visit", itemVisitHandler.Visit)
+			}
+			// POST /api/items/{id}/summarize - 記事の要約のオンデマンド生成
+			if itemSummaryHandler != nil {
+				r.Post("/summarize", itemSummaryHandler.Summarize)
+			}
 		})
 
+		// オフライン中の既読・スター操作の同期。SyncService が未配線の deps では登録しない。
+		if syncHandler != nil {
+			r.Post("/api/sync/operations", syncHandler.ApplyOperations)
+		}
+
 		// 購読管理
 		r.Route("/api/subscriptions", func(r chi.Router) {
 			r.Get("/", subHandler.ListSubscriptions)
+			// ピン留め・サイドバー並び順の一括更新
+			r.Put("/order", subHandler.UpdateOrder)
 
 			r.Route("/{id}", func(r chi.Router) {
+				// 購読詳細（推定次回更新時刻を含む）
+				r.Get("/", subHandler.GetSubscription)
 				r.Delete("/", subHandler.Unsubscribe)
 				r.Put("/settings", subHandler.UpdateSettings)
 				r.Post("/resume", subHandler.ResumeFetch)
 				// Issue #115: 手動フェッチ API（同期）。
 				// 認証ミドルウェア + General レート制限はグループ単位で適用済み（NFR 2.1, 2.2）。
 				r.Post("/fetch", subHandler.ManualFetch)
+				// 購読解除の取り消し（猶予期間内のみ）
+				r.Post("/restore", subHandler.Restore)
+				// お試し購読を通常の購読にする
+				r.Post("/keep", subHandler.KeepSubscription)
+				// 404/410 で停止したフィードの URL を再検出した提案に張り替えてフェッチを再開する
+				r.Post("/apply-suggested-url", subHandler.ApplySuggestedFeedURL)
+				// 個別購読の公開/非公開設定（公開プロフィール共有）
+				if publicProfileHandler != nil {
+					r.Put("/visibility", publicProfileHandler.UpdateSubscriptionVisibility)
+				}
+				// 購読単位の記事取り込みフィルタ
+				if importFilterHandler != nil {
+					r.Get("/import-filter", importFilterHandler.GetImportFilter)
+					r.Put("/import-filter", importFilterHandler.UpdateImportFilter)
+				}
+				// 購読単位の最大記事保持数
+				if retentionHandler != nil {
+					r.Get("/retention", retentionHandler.GetRetentionOverride)
+					r.Put("/retention", retentionHandler.UpdateRetentionOverride)
+				}
+				// 購読単位の通知ヒント設定
+				if notificationHandler != nil {
+					r.Get("/notification", notificationHandler.GetNotificationSetting)
+					r.Put("/notification", notificationHandler.UpdateNotificationSetting)
+				}
+				// 購読フィードの記事傾向サマリー（頻出キーワード）
+				if keywordHandler != nil {
+					r.Get("/keywords", keywordHandler.GetSubscriptionKeywords)
+				}
 			})
 		})
 
@@ -263,7 +701,125 @@ func NewRouter(deps *RouterDeps) http.Handler {
 			if crossFeedHandler != nil {
 				r.Put("/me/cross-feed-last-seen", crossFeedHandler.TouchLastSeen)
 			}
+			// 公開プロフィール設定の取得・更新。PublicProfileService 未配線の deps では登録しない。
+			if publicProfileHandler != nil {
+				r.Get("/me/public-profile", publicProfileHandler.GetProfile)
+				r.Put("/me/public-profile", publicProfileHandler.UpdateProfile)
+			}
+			// 積読警告・リンクの開き方・プリフェッチ・タイムゾーンの設定の取得・更新。UserSettingsService 未配線の deps では登録しない。
+			if userSettingsHandler != nil {
+				r.Get("/me/unread-warning", userSettingsHandler.GetUnreadWarning)
+				r.Put("/me/unread-warning", userSettingsHandler.UpdateUnreadWarning)
+				r.Get("/me/link-behavior", userSettingsHandler.GetLinkBehavior)
+				r.Put("/me/link-behavior", userSettingsHandler.UpdateLinkBehavior)
+				r.Get("/me/prefetch", userSettingsHandler.GetPrefetch)
+				r.Put("/me/prefetch", userSettingsHandler.UpdatePrefetch)
+				r.Get("/me/timezone", userSettingsHandler.GetTimezone)
+				r.Put("/me/timezone", userSettingsHandler.UpdateTimezone)
+			}
+			// 自分のログイン履歴。LoginHistoryService 未配線の deps では登録しない。
+			if loginHistoryHandler != nil {
+				r.Get("/me/login-history", loginHistoryHandler.ListLoginHistory)
+			}
+			// アカウント連携の一覧・解除。IdentityService 未配線の deps では登録しない。
+			if identityHandler != nil {
+				r.Get("/me/identities", identityHandler.ListIdentities)
+				r.Delete("/me/identities/{id}", identityHandler.UnlinkIdentity)
+			}
 		})
+
+		// 閲覧統計。StatsService が未配線の deps では登録しない。
+		if statsHandler != nil {
+			r.Get("/api/stats/top-feeds", statsHandler.TopFeeds)
+			r.Get("/api/stats/weekly", statsHandler.WeeklyTrend)
+		}
+
+		// ハイライト。HighlightService が未配線の deps では登録しない。
+		if highlightHandler != nil {
+			r.Get("/api/highlights", highlightHandler.ListHighlights)
+		}
+
+		// API 利用量。UsageService が未配線の deps では登録しない。
+		if usageHandler != nil {
+			r.Get("/api/usage", usageHandler.GetUsage)
+		}
+
+		// 監査ログの閲覧。AuditLogService が未配線の deps では登録しない。
+		if auditLogHandler != nil {
+			r.Get("/api/audit-logs", auditLogHandler.ListAuditLogs)
+		}
+
+		// チームでの購読リスト共有。TeamService が未配線の deps では登録しない。
+		if teamHandler != nil {
+			r.Route("/api/teams", func(r chi.Router) {
+				r.Get("/", teamHandler.ListTeams)
+				r.Post("/", teamHandler.CreateTeam)
+				r.Post("/invitations/accept", teamHandler.AcceptInvitation)
+				r.Route("/{id}", func(r chi.Router) {
+					r.Get("/", teamHandler.GetTeam)
+					r.Post("/invitations", teamHandler.CreateInvitation)
+					r.Post("/feeds", teamHandler.AddFeed)
+					r.Delete("/feeds/{feedId}", teamHandler.RemoveFeed)
+					r.Delete("/members/me", teamHandler.LeaveTeam)
+				})
+			})
+		}
+
+		// Slack / Discord への新着記事の転送設定。IntegrationService が未配線の deps では登録しない。
+		if integrationHandler != nil {
+			r.Route("/api/integrations", func(r chi.Router) {
+				r.Get("/", integrationHandler.ListIntegrations)
+				r.Post("/", integrationHandler.CreateIntegration)
+				r.Get("/{id}", integrationHandler.GetIntegration)
+				r.Put("/{id}", integrationHandler.UpdateIntegration)
+				r.Delete("/{id}", integrationHandler.DeleteIntegration)
+			})
+		}
+
+		// Pocket / Instapaper へのスター記事の自動保存の連携設定。ReadLaterService が未配線の deps では登録しない。
+		if readLaterHandler != nil {
+			r.Route("/api/read-later", func(r chi.Router) {
+				r.Get("/", readLaterHandler.ListConnections)
+				r.Post("/pocket/authorize", readLaterHandler.AuthorizePocket)
+				r.Get("/pocket/callback", readLaterHandler.PocketCallback)
+				r.Post("/instapaper", readLaterHandler.ConnectInstapaper)
+				r.Put("/{provider}", readLaterHandler.UpdateConnection)
+				r.Delete("/{provider}", readLaterHandler.DeleteConnection)
+			})
+		}
+
+		// 管理者向け調査用 API。Session の内側で管理者判定を行い、一般ユーザーには 403 を返す。
+		// FeedDebugService が未配線の deps では登録しない。
+		if debugHandler != nil {
+			r.Route("/api/debug", func(r chi.Router) {
+				r.Use(middleware.NewAdminOnlyMiddleware(deps.AdminUserIDs))
+				// 生 XML を受け取るため全体の上限より引き上げる
+				r.With(middleware.NewBodyLimitMiddleware(maxDebugParseRequestBytes)).Post("/parse-feed", debugHandler.ParseFeed)
+			})
+		}
+
+		// 管理者向け運用 API。各サービスが未配線の deps では該当ルートを登録しない。
+		if adminHandler != nil || feedAdminHandler != nil || workerCycleHandler != nil || blockedDomainHandler != nil {
+			r.Route("/api/admin", func(r chi.Router) {
+				r.Use(middleware.NewAdminOnlyMiddleware(deps.AdminUserIDs))
+				if adminHandler != nil {
+					r.Get("/stats", adminHandler.Stats)
+				}
+				if feedAdminHandler != nil {
+					r.Put("/feeds/{id}/conditional-get", feedAdminHandler.UpdateConditionalGet)
+					r.Put("/feeds/{id}/raw-capture", feedAdminHandler.UpdateRawCapture)
+					r.Get("/feeds/{id}/raw-capture", feedAdminHandler.DownloadRawCapture)
+				}
+				if workerCycleHandler != nil {
+					r.Get("/worker-cycles", workerCycleHandler.ListCycles)
+				}
+				if blockedDomainHandler != nil {
+					r.Get("/blocked-domains", blockedDomainHandler.ListBlockedDomains)
+					r.Post("/blocked-domains", blockedDomainHandler.AddBlockedDomain)
+					r.Delete("/blocked-domains/{id}", blockedDomainHandler.DeleteBlockedDomain)
+				}
+			})
+		}
 	})
 
 	return r
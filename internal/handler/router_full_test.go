@@ -50,7 +50,7 @@ func createTestRouter() (http.Handler, *mockSessionFinderForRouter) {
 		},
 		AuthConfig:          AuthHandlerConfig{BaseURL: "http://localhost:3000", SessionMaxAge: 86400},
 		FeedService: &mockFeedService{
-			registerFeedFn: func(ctx context.Context, userID, inputURL string) (*model.Feed, *model.Subscription, error) {
+			registerFeedFn: func(ctx context.Context, userID, inputURL string, opts model.RegisterFeedOptions) (*model.Feed, *model.Subscription, error) {
 				return &model.Feed{
 					ID:      "feed-test-1",
 					FeedURL: inputURL,
@@ -85,7 +85,7 @@ func createTestRouter() (http.Handler, *mockSessionFinderForRouter) {
 			},
 		},
 		ItemService: &mockItemService{
-			listItemsFn: func(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
+			listItemsFn: func(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
 				return &itemListResult{Items: []itemSummaryResponse{}, HasMore: false}, nil
 			},
 			getItemFn: func(ctx context.Context, userID, itemID string) (*itemDetailResponse, error) {
@@ -99,7 +99,7 @@ func createTestRouter() (http.Handler, *mockSessionFinderForRouter) {
 			},
 		},
 		ItemStateService: &mockItemStateService{
-			updateStateFn: func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+			updateStateFn: func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 				return &model.ItemState{UserID: userID, ItemID: itemID}, nil
 			},
 		},
@@ -329,3 +329,60 @@ func TestNewRouter_UserRoutes_WithdrawEndpoint(t *testing.T) {
 		t.Errorf("DELETE /api/users/me status = %d, want %d", w.Result().StatusCode, http.StatusNoContent)
 	}
 }
+
+// TestNewRouter_BodyLimit はリクエストボディの上限超過が 413 の統一フォーマットで返ることを検証する。
+func TestNewRouter_BodyLimit(t *testing.T) {
+	t.Run("既定の上限を超えるボディのとき413を返す", func(t *testing.T) {
+		// Arrange
+		router, _ := createTestRouter()
+		body := `{"url": "` + strings.Repeat("a", int(middleware.DefaultMaxJSONBodyBytes)) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+		}
+		if !strings.Contains(w.Body.String(), model.ErrCodePayloadTooLarge) {
+			t.Errorf("body = %s, want code %s", w.Body.String(), model.ErrCodePayloadTooLarge)
+		}
+	})
+
+	t.Run("パース診断ルートは既定の上限を超えるボディを受け付ける", func(t *testing.T) {
+		// Arrange
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+			FeedDebugService:    &mockFeedDebugService{},
+			AdminUserIDs:        []string{"user-test-1"},
+		}
+		router := NewRouter(deps)
+		body := `{"xml": "` + strings.Repeat("a", 2<<20) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/debug/parse-feed", strings.NewReader(body))
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+}
@@ -6,13 +6,33 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hitoshi/feedman/internal/adminstats"
+	"github.com/hitoshi/feedman/internal/audit"
+	"github.com/hitoshi/feedman/internal/auth"
+	"github.com/hitoshi/feedman/internal/cache"
 	"github.com/hitoshi/feedman/internal/crossfeed"
+	"github.com/hitoshi/feedman/internal/feed"
+	"github.com/hitoshi/feedman/internal/feedtitle"
+	"github.com/hitoshi/feedman/internal/highlight"
+	"github.com/hitoshi/feedman/internal/importfilter"
+	"github.com/hitoshi/feedman/internal/integration"
 	"github.com/hitoshi/feedman/internal/item"
 	"github.com/hitoshi/feedman/internal/itemsearch"
+	"github.com/hitoshi/feedman/internal/keyword"
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/moderation"
+	"github.com/hitoshi/feedman/internal/notification"
+	"github.com/hitoshi/feedman/internal/profile"
+	"github.com/hitoshi/feedman/internal/readlater"
 	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/retention"
+	"github.com/hitoshi/feedman/internal/stats"
 	"github.com/hitoshi/feedman/internal/subscription"
+	"github.com/hitoshi/feedman/internal/team"
+	"github.com/hitoshi/feedman/internal/usage"
 	"github.com/hitoshi/feedman/internal/user"
+	"github.com/hitoshi/feedman/internal/usersettings"
+	fetchpkg "github.com/hitoshi/feedman/internal/worker/fetch"
 )
 
 // SubscriptionServiceAdapter は subscription.Service を SubscriptionServiceInterface に適合させるアダプタ。
@@ -39,6 +59,23 @@ func (a *SubscriptionServiceAdapter) ListSubscriptions(ctx context.Context, user
 	return results, nil
 }
 
+// GetSubscription は購読 1 件を推定次回更新時刻付きの handler レスポンス型で返す。
+func (a *SubscriptionServiceAdapter) GetSubscription(ctx context.Context, userID, subscriptionID string) (*subscriptionDetailResponse, error) {
+	info, err := a.svc.GetSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return &subscriptionDetailResponse{
+		subscriptionResponse: toSubscriptionResponse(*info),
+		EstimatedNextPostAt:  info.EstimatedNextPostAt,
+	}, nil
+}
+
+// ListModTime はユーザーの購読一覧の最終更新日時を返す。
+func (a *SubscriptionServiceAdapter) ListModTime(ctx context.Context, userID string) (time.Time, error) {
+	return a.svc.ListModTime(ctx, userID)
+}
+
 // UpdateSettings は購読のフェッチ間隔を更新しhandlerレスポンス型で返す。
 func (a *SubscriptionServiceAdapter) UpdateSettings(ctx context.Context, userID, subscriptionID string, minutes int) (*subscriptionResponse, error) {
 	info, err := a.svc.UpdateSettings(ctx, userID, subscriptionID, minutes)
@@ -75,20 +112,77 @@ func (a *SubscriptionServiceAdapter) ManualFetch(ctx context.Context, userID, su
 	return &resp, nil
 }
 
+// Restore は解除した購読を復元し、復元後の購読情報を handler レスポンス型で返す。
+func (a *SubscriptionServiceAdapter) Restore(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+	info, err := a.svc.Restore(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toSubscriptionResponse(*info)
+	return &resp, nil
+}
+
+// KeepSubscription はお試し購読を通常の購読にし、更新後の購読情報を handler レスポンス型で返す。
+func (a *SubscriptionServiceAdapter) KeepSubscription(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+	info, err := a.svc.KeepSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toSubscriptionResponse(*info)
+	return &resp, nil
+}
+
+// ApplySuggestedFeedURL はフィード URL の張り替え提案を適用し、更新後の購読情報を handler レスポンス型で返す。
+func (a *SubscriptionServiceAdapter) ApplySuggestedFeedURL(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+	info, err := a.svc.ApplySuggestedFeedURL(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toSubscriptionResponse(*info)
+	return &resp, nil
+}
+
+// UpdateOrder は購読のピン留めと並び順を一括更新し、更新後の購読一覧を handler レスポンス型で返す。
+func (a *SubscriptionServiceAdapter) UpdateOrder(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) ([]subscriptionResponse, error) {
+	infos, err := a.svc.UpdateOrder(ctx, userID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]subscriptionResponse, len(infos))
+	for i, info := range infos {
+		results[i] = toSubscriptionResponse(info)
+	}
+	return results, nil
+}
+
 // toSubscriptionResponse はドメインのSubscriptionInfoをhandlerのレスポンス型に変換する。
 func toSubscriptionResponse(info subscription.SubscriptionInfo) subscriptionResponse {
 	return subscriptionResponse{
-		ID:                   info.ID,
-		UserID:               info.UserID,
-		FeedID:               info.FeedID,
-		FeedTitle:            info.FeedTitle,
-		FeedURL:              info.FeedURL,
-		FaviconURL:           info.FaviconURL,
-		FetchIntervalMinutes: info.FetchIntervalMinutes,
-		FeedStatus:           info.FeedStatus,
-		ErrorMessage:         info.ErrorMessage,
-		UnreadCount:          info.UnreadCount,
-		CreatedAt:            info.CreatedAt,
+		ID:                    info.ID,
+		UserID:                info.UserID,
+		FeedID:                info.FeedID,
+		FeedTitle:             info.FeedTitle,
+		FeedURL:               info.FeedURL,
+		FaviconURL:            info.FaviconURL,
+		FetchIntervalMinutes:  info.FetchIntervalMinutes,
+		FeedStatus:            info.FeedStatus,
+		ErrorMessage:          info.ErrorMessage,
+		ErrorKind:             info.ErrorKind,
+		UnreadCount:           info.UnreadCount,
+		TooManyUnread:         info.TooManyUnread,
+		FeedLanguage:          info.FeedLanguage,
+		FeedDescription:       info.FeedDescription,
+		FeedLastPublishedAt:   info.FeedLastPublishedAt,
+		IsPinned:              info.IsPinned,
+		SortOrder:             info.SortOrder,
+		Priority:              string(info.Priority),
+		MuteUntil:             info.MuteUntil,
+		ExpiresAt:             info.ExpiresAt,
+		SuggestedFeedURL:      info.SuggestedFeedURL,
+		FeedTitleUpdatePolicy: string(info.FeedTitleUpdatePolicy),
+		PendingFeedTitle:      info.PendingFeedTitle,
+		CreatedAt:             info.CreatedAt,
 	}
 }
 
@@ -118,40 +212,106 @@ func NewItemServiceAdapter(svc *item.ItemService) ItemServiceInterface {
 }
 
 // ListItems はフィードの記事一覧を返す。
-func (a *ItemServiceAdapterFromDomain) ListItems(ctx context.Context, userID, feedID string, filter model.ItemFilter, cursor string, limit int) (*itemListResult, error) {
-	result, err := a.svc.ListItems(ctx, userID, feedID, filter, cursor, limit)
+func (a *ItemServiceAdapterFromDomain) ListItems(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+	result, err := a.svc.ListItems(ctx, userID, feedID, conds, author, cursor, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make([]itemSummaryResponse, len(result.Items))
-	for i, it := range result.Items {
-		items[i] = itemSummaryResponse{
-			ID:              it.ID,
-			FeedID:          it.FeedID,
-			Title:           it.Title,
-			Link:            it.Link,
-			Summary:         it.Summary,
-			PublishedAt:     it.PublishedAt,
-			IsDateEstimated: it.IsDateEstimated,
-			IsRead:          it.IsRead,
-			IsStarred:       it.IsStarred,
-			HatebuCount:     it.HatebuCount,
-		}
+	return &itemListResult{
+		Items:      toItemSummaryResponses(result.Items),
+		NextCursor: nullableString(result.NextCursor),
+		HasMore:    result.HasMore,
+	}, nil
+}
+
+// ListItemsByFeeds は複数フィードの記事一覧を返す。
+func (a *ItemServiceAdapterFromDomain) ListItemsByFeeds(ctx context.Context, userID string, feedIDs []string, conds model.ItemConditions, author, cursor string, limit int) (*itemListResult, error) {
+	result, err := a.svc.ListItemsByFeeds(ctx, userID, feedIDs, conds, author, cursor, limit)
+	if err != nil {
+		return nil, err
 	}
 
 	return &itemListResult{
-		Items:      items,
-		NextCursor: result.NextCursor,
+		Items:      toItemSummaryResponses(result.Items),
+		NextCursor: nullableString(result.NextCursor),
+		HasMore:    result.HasMore,
+	}, nil
+}
+
+// ListItemGroups はフィードの記事一覧を連載ごとに折りたたんだ handler のレスポンス型で返す。
+func (a *ItemServiceAdapterFromDomain) ListItemGroups(ctx context.Context, userID, feedID string, conds model.ItemConditions, author, cursor string, limit int) (*itemGroupListResult, error) {
+	result, err := a.svc.ListItemGroups(ctx, userID, feedID, conds, author, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]itemGroupResponse, len(result.Groups))
+	for i, g := range result.Groups {
+		groups[i] = itemGroupResponse{
+			SeriesKey: nullableString(g.SeriesKey),
+			Items:     toItemSummaryResponses(g.Items),
+		}
+	}
+
+	return &itemGroupListResult{
+		Groups:     groups,
+		NextCursor: nullableString(result.NextCursor),
 		HasMore:    result.HasMore,
 	}, nil
 }
 
+// DateBoundaries はユーザーのタイムゾーンでの日付境界を handler のレスポンス型で返す。
+func (a *ItemServiceAdapterFromDomain) DateBoundaries(ctx context.Context, userID string) (*itemDateBoundariesResponse, error) {
+	b, err := a.svc.DateBoundaries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &itemDateBoundariesResponse{
+		Timezone:  b.Location.String(),
+		Today:     b.GroupKey(b.Today),
+		Yesterday: b.GroupKey(b.Yesterday),
+		WeekStart: b.GroupKey(b.WeekStart),
+		location:  b.Location,
+	}, nil
+}
+
+// ListModTime はフィードの記事一覧の最終更新日時を返す。
+func (a *ItemServiceAdapterFromDomain) ListModTime(ctx context.Context, userID, feedID string) (time.Time, error) {
+	return a.svc.ListModTime(ctx, userID, feedID)
+}
+
+// toItemSummaryResponses はドメインの記事サマリーを handler のレスポンス型に変換する。
+func toItemSummaryResponses(summaries []item.ItemSummary) []itemSummaryResponse {
+	items := make([]itemSummaryResponse, len(summaries))
+	for i, it := range summaries {
+		items[i] = itemSummaryResponse{
+			ID:                 it.ID,
+			FeedID:             it.FeedID,
+			Title:              it.Title,
+			Link:               it.Link,
+			Summary:            it.Summary,
+			PublishedAt:        it.PublishedAt,
+			IsDateEstimated:    it.IsDateEstimated,
+			IsRead:             it.IsRead,
+			IsStarred:          it.IsStarred,
+			IsHidden:           it.IsHidden,
+			Rating:             it.Rating,
+			HatebuCount:        it.HatebuCount,
+			ReadingTimeMinutes: it.ReadingTimeMinutes,
+			Excerpt:            it.Excerpt,
+			ThumbnailURL:       it.ThumbnailURL,
+			GeneratedSummary:   nullableString(it.GeneratedSummary),
+		}
+	}
+	return items
+}
+
 // ListStarredItems は全フィード横断スター記事一覧を handler のレスポンス型で返す。
 // ドメイン層 *item.StarredItemListResult を handler 層 *starredItemListResult に変換する。
 // 各記事行に feed_title を併記する（Requirement 2.4 / 4.10）。
-func (a *ItemServiceAdapterFromDomain) ListStarredItems(ctx context.Context, userID, cursorStr string, limit int) (*starredItemListResult, error) {
-	result, err := a.svc.ListStarredItems(ctx, userID, cursorStr, limit)
+func (a *ItemServiceAdapterFromDomain) ListStarredItems(ctx context.Context, userID, cursorStr string, limit int, filter model.StarredItemFilter) (*starredItemListResult, error) {
+	result, err := a.svc.ListStarredItems(ctx, userID, cursorStr, limit, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -160,31 +320,52 @@ func (a *ItemServiceAdapterFromDomain) ListStarredItems(ctx context.Context, use
 	for i, it := range result.Items {
 		items[i] = starredItemSummaryResponse{
 			itemSummaryResponse: itemSummaryResponse{
-				ID:              it.ID,
-				FeedID:          it.FeedID,
-				Title:           it.Title,
-				Link:            it.Link,
-				Summary:         it.Summary,
-				PublishedAt:     it.PublishedAt,
-				IsDateEstimated: it.IsDateEstimated,
-				IsRead:          it.IsRead,
-				IsStarred:       it.IsStarred,
-				HatebuCount:     it.HatebuCount,
+				ID:                 it.ID,
+				FeedID:             it.FeedID,
+				Title:              it.Title,
+				Link:               it.Link,
+				Summary:            it.Summary,
+				PublishedAt:        it.PublishedAt,
+				IsDateEstimated:    it.IsDateEstimated,
+				IsRead:             it.IsRead,
+				IsStarred:          it.IsStarred,
+				Rating:             it.Rating,
+				HatebuCount:        it.HatebuCount,
+				ReadingTimeMinutes: it.ReadingTimeMinutes,
+				GeneratedSummary:   nullableString(it.GeneratedSummary),
 			},
-			FeedTitle: it.FeedTitle,
+			FeedTitle:  it.FeedTitle,
+			LinkStatus: nullableString(string(it.LinkStatus)),
 		}
 	}
 
 	return &starredItemListResult{
 		Items:      items,
-		NextCursor: result.NextCursor,
+		NextCursor: nullableString(result.NextCursor),
 		HasMore:    result.HasMore,
 	}, nil
 }
 
+// ListAuthors はフィード内の著者一覧を handler のレスポンス型で返す。
+func (a *ItemServiceAdapterFromDomain) ListAuthors(ctx context.Context, feedID string) (*feedAuthorListResponse, error) {
+	authors, err := a.svc.ListAuthors(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]feedAuthorResponse, len(authors))
+	for i, au := range authors {
+		resp[i] = feedAuthorResponse{
+			Author:    au.Author,
+			ItemCount: au.ItemCount,
+		}
+	}
+	return &feedAuthorListResponse{Authors: resp}, nil
+}
+
 // GetItem は記事詳細を返す。
-func (a *ItemServiceAdapterFromDomain) GetItem(ctx context.Context, userID, itemID string) (*itemDetailResponse, error) {
-	detail, err := a.svc.GetItem(ctx, userID, itemID)
+func (a *ItemServiceAdapterFromDomain) GetItem(ctx context.Context, userID, itemID string, textOnly bool) (*itemDetailResponse, error) {
+	detail, err := a.svc.GetItem(ctx, userID, itemID, textOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -194,15 +375,18 @@ func (a *ItemServiceAdapterFromDomain) GetItem(ctx context.Context, userID, item
 
 	return &itemDetailResponse{
 		itemSummaryResponse: itemSummaryResponse{
-			ID:              detail.ID,
-			FeedID:          detail.FeedID,
-			Title:           detail.Title,
-			Link:            detail.Link,
-			PublishedAt:     detail.PublishedAt,
-			IsDateEstimated: detail.IsDateEstimated,
-			IsRead:          detail.IsRead,
-			IsStarred:       detail.IsStarred,
-			HatebuCount:     detail.HatebuCount,
+			ID:                 detail.ID,
+			FeedID:             detail.FeedID,
+			Title:              detail.Title,
+			Link:               detail.Link,
+			PublishedAt:        detail.PublishedAt,
+			IsDateEstimated:    detail.IsDateEstimated,
+			IsRead:             detail.IsRead,
+			IsStarred:          detail.IsStarred,
+			Rating:             detail.Rating,
+			HatebuCount:        detail.HatebuCount,
+			ReadingTimeMinutes: detail.ReadingTimeMinutes,
+			GeneratedSummary:   nullableString(detail.GeneratedSummary),
 		},
 		Content: detail.Content,
 		Summary: detail.Summary,
@@ -212,32 +396,50 @@ func (a *ItemServiceAdapterFromDomain) GetItem(ctx context.Context, userID, item
 
 // ItemStateServiceAdapterFromRepo は repository.ItemStateRepository を ItemStateServiceInterface に適合させるアダプタ。
 type ItemStateServiceAdapterFromRepo struct {
-	repo repository.ItemStateRepository
+	repo         repository.ItemStateRepository
+	invalidators []cache.UserInvalidator
 }
 
 // NewItemStateServiceAdapter は repository.ItemStateRepository から ItemStateServiceInterface を生成する。
-func NewItemStateServiceAdapter(repo repository.ItemStateRepository) ItemStateServiceInterface {
-	return &ItemStateServiceAdapterFromRepo{repo: repo}
+// invalidators には既読状態の変化で内容が変わるキャッシュ（購読一覧の未読数）の無効化先を渡す。
+func NewItemStateServiceAdapter(repo repository.ItemStateRepository, invalidators ...cache.UserInvalidator) ItemStateServiceInterface {
+	return &ItemStateServiceAdapterFromRepo{repo: repo, invalidators: invalidators}
 }
 
-// UpdateState は記事の既読・スター状態を冪等に更新する。
-func (a *ItemStateServiceAdapterFromRepo) UpdateState(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
-	return a.repo.Upsert(ctx, userID, itemID, isRead, isStarred)
+// UpdateState は記事の既読・スター・非表示状態と評価を冪等に更新する。
+// 非表示の記事は未読数に含めないため、既読・非表示の変更時に未読数のキャッシュを無効化する。
+func (a *ItemStateServiceAdapterFromRepo) UpdateState(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
+	state, err := a.repo.Upsert(ctx, userID, itemID, isRead, isStarred, isHidden, rating)
+	if err != nil {
+		return nil, err
+	}
+	if isRead != nil || isHidden != nil {
+		invalidateUser(ctx, a.invalidators, userID)
+	}
+	return state, nil
 }
 
 // SubscriptionDeleterAdapter はリポジトリ層を SubscriptionDeleter に適合させるアダプタ。
 type SubscriptionDeleterAdapter struct {
 	subRepo       repository.SubscriptionRepository
 	itemStateRepo repository.ItemStateRepository
+	auditRecorder subscription.AuditRecorder
+	invalidators  []cache.UserInvalidator
 }
 
 // NewSubscriptionDeleterAdapter はSubscriptionDeleterAdapterを生成する。
-func NewSubscriptionDeleterAdapter(subRepo repository.SubscriptionRepository, itemStateRepo repository.ItemStateRepository) SubscriptionDeleter {
-	return &SubscriptionDeleterAdapter{subRepo: subRepo, itemStateRepo: itemStateRepo}
+// auditRecorder には購読解除を記録する監査ログの記録先を渡す（nil の場合は記録しない）。
+// invalidators には購読の削除で内容が変わるキャッシュ（購読一覧）の無効化先を渡す。
+func NewSubscriptionDeleterAdapter(subRepo repository.SubscriptionRepository, itemStateRepo repository.ItemStateRepository, auditRecorder subscription.AuditRecorder, invalidators ...cache.UserInvalidator) SubscriptionDeleter {
+	return &SubscriptionDeleterAdapter{subRepo: subRepo, itemStateRepo: itemStateRepo, auditRecorder: auditRecorder, invalidators: invalidators}
 }
 
 // DeleteByUserAndFeed はユーザーIDとフィードIDで購読と関連item_statesを削除する。
+// 購読を削除した場合は subscription.Service の購読解除と同じ形式で監査ログに記録する。
 func (a *SubscriptionDeleterAdapter) DeleteByUserAndFeed(ctx context.Context, userID, feedID string) error {
+	// 途中で失敗しても一部が削除されている可能性があるため、先に無効化を予約する
+	defer invalidateUser(ctx, a.invalidators, userID)
+
 	// 関連item_statesを削除
 	if err := a.itemStateRepo.DeleteByUserAndFeed(ctx, userID, feedID); err != nil {
 		return err
@@ -251,7 +453,20 @@ func (a *SubscriptionDeleterAdapter) DeleteByUserAndFeed(ctx context.Context, us
 	if sub == nil {
 		return nil
 	}
-	return a.subRepo.Delete(ctx, sub.ID)
+	if err := a.subRepo.Delete(ctx, sub.ID); err != nil {
+		return err
+	}
+	if a.auditRecorder != nil {
+		a.auditRecorder.Record(ctx, userID, model.AuditActionSubscriptionDelete, feedID, map[string]any{"subscription_id": sub.ID})
+	}
+	return nil
+}
+
+// invalidateUser は invalidators のすべてで当該ユーザーのキャッシュを無効化する。
+func invalidateUser(ctx context.Context, invalidators []cache.UserInvalidator, userID string) {
+	for _, inv := range invalidators {
+		inv.InvalidateUser(ctx, userID)
+	}
 }
 
 // ItemSearchServiceAdapter は itemsearch.SearchService を ItemSearchServiceInterface に
@@ -275,7 +490,7 @@ func NewItemSearchServiceAdapter(svc *itemsearch.SearchService) *ItemSearchServi
 //
 // favicon の data URL 化は本メソッドの責務（サービス層は生バイトのまま pass-through する）。
 // 生バイトと MIME のいずれかが空の場合は data URL を生成せず nil を保持し、JSON では
-// `omitempty` でフィールドごと省略される。
+// null となる。
 func (a *ItemSearchServiceAdapter) Search(
 	ctx context.Context,
 	userID, rawQuery string,
@@ -315,7 +530,7 @@ func (a *ItemSearchServiceAdapter) Search(
 
 	return &itemSearchResponse{
 		Items:      hits,
-		NextCursor: result.NextCursor,
+		NextCursor: nullableString(result.NextCursor),
 		HasMore:    result.HasMore,
 	}, nil
 }
@@ -351,24 +566,25 @@ func (a *CrossFeedServiceAdapter) ListNewItems(
 	items := make([]crossFeedItemResponse, len(result.Items))
 	for i, it := range result.Items {
 		items[i] = crossFeedItemResponse{
-			ID:              it.ID,
-			FeedID:          it.FeedID,
-			FeedTitle:       it.FeedTitle,
-			FeedFaviconURL:  it.FeedFaviconURL,
-			Title:           it.Title,
-			Link:            it.Link,
-			Summary:         it.Summary,
-			PublishedAt:     it.PublishedAt,
-			IsDateEstimated: it.IsDateEstimated,
-			IsRead:          it.IsRead,
-			IsStarred:       it.IsStarred,
-			HatebuCount:     it.HatebuCount,
+			ID:                 it.ID,
+			FeedID:             it.FeedID,
+			FeedTitle:          it.FeedTitle,
+			FeedFaviconURL:     it.FeedFaviconURL,
+			Title:              it.Title,
+			Link:               it.Link,
+			Summary:            it.Summary,
+			PublishedAt:        it.PublishedAt,
+			IsDateEstimated:    it.IsDateEstimated,
+			IsRead:             it.IsRead,
+			IsStarred:          it.IsStarred,
+			HatebuCount:        it.HatebuCount,
+			ReadingTimeMinutes: it.ReadingTimeMinutes,
 		}
 	}
 
 	return &crossFeedListResult{
 		Items:      items,
-		NextCursor: result.NextCursor,
+		NextCursor: nullableString(result.NextCursor),
 		HasMore:    result.HasMore,
 		SinceTime:  result.SinceTime,
 	}, nil
@@ -379,7 +595,1304 @@ func (a *CrossFeedServiceAdapter) TouchLastSeen(ctx context.Context, userID stri
 	return a.svc.TouchLastSeen(ctx, userID)
 }
 
-// --- compile-time interface checks ---
+// RandomItemServiceAdapter は crossfeed.RandomService を RandomItemServiceInterface に適合させるアダプタ。
+type RandomItemServiceAdapter struct {
+	svc *crossfeed.RandomService
+}
+
+// NewRandomItemServiceAdapter は RandomItemServiceAdapter を生成する。
+func NewRandomItemServiceAdapter(svc *crossfeed.RandomService) *RandomItemServiceAdapter {
+	return &RandomItemServiceAdapter{svc: svc}
+}
+
+// PickRandomItems は service 層を呼び出し、結果を横断一覧と同形のレスポンス型に変換して返す。
+func (a *RandomItemServiceAdapter) PickRandomItems(
+	ctx context.Context,
+	userID string,
+	filter model.ItemFilter,
+	count int,
+) ([]crossFeedItemResponse, error) {
+	result, err := a.svc.PickRandomItems(ctx, userID, filter, count)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]crossFeedItemResponse, len(result))
+	for i, it := range result {
+		items[i] = crossFeedItemResponse{
+			ID:                 it.ID,
+			FeedID:             it.FeedID,
+			FeedTitle:          it.FeedTitle,
+			FeedFaviconURL:     it.FeedFaviconURL,
+			Title:              it.Title,
+			Link:               it.Link,
+			Summary:            it.Summary,
+			PublishedAt:        it.PublishedAt,
+			IsDateEstimated:    it.IsDateEstimated,
+			IsRead:             it.IsRead,
+			IsStarred:          it.IsStarred,
+			HatebuCount:        it.HatebuCount,
+			ReadingTimeMinutes: it.ReadingTimeMinutes,
+		}
+	}
+	return items, nil
+}
+
+// PublicProfileServiceAdapter は profile.Service を PublicProfileServiceInterface に適合させるアダプタ。
+type PublicProfileServiceAdapter struct {
+	svc *profile.Service
+}
+
+// NewPublicProfileServiceAdapter は PublicProfileServiceAdapter を生成する。
+func NewPublicProfileServiceAdapter(svc *profile.Service) *PublicProfileServiceAdapter {
+	return &PublicProfileServiceAdapter{svc: svc}
+}
+
+// GetProfile は当該ユーザーの公開設定を handler レスポンス型で返す。
+func (a *PublicProfileServiceAdapter) GetProfile(ctx context.Context, userID string) (*publicProfileResponse, error) {
+	p, err := a.svc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &publicProfileResponse{Enabled: p.Enabled, Slug: p.Slug}, nil
+}
+
+// UpdateProfile は公開設定を更新し、更新後の設定を handler レスポンス型で返す。
+func (a *PublicProfileServiceAdapter) UpdateProfile(ctx context.Context, userID string, enabled bool, slug string) (*publicProfileResponse, error) {
+	p, err := a.svc.UpdateProfile(ctx, userID, enabled, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &publicProfileResponse{Enabled: p.Enabled, Slug: p.Slug}, nil
+}
+
+// SetSubscriptionVisibility は購読の公開可否を更新する。
+func (a *PublicProfileServiceAdapter) SetSubscriptionVisibility(ctx context.Context, userID, subscriptionID string, isPublic bool) error {
+	return a.svc.SetSubscriptionVisibility(ctx, userID, subscriptionID, isPublic)
+}
+
+// ListPublicSubscriptions は公開購読一覧を handler レスポンス型で返す。
+func (a *PublicProfileServiceAdapter) ListPublicSubscriptions(ctx context.Context, slug string) ([]publicSubscriptionResponse, error) {
+	subs, err := a.svc.ListPublicSubscriptions(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]publicSubscriptionResponse, len(subs))
+	for i, sub := range subs {
+		results[i] = publicSubscriptionResponse{Title: sub.Title, SiteURL: sub.SiteURL}
+	}
+	return results, nil
+}
+
+// ImportFilterServiceAdapter は importfilter.Service を ImportFilterServiceInterface に適合させるアダプタ。
+type ImportFilterServiceAdapter struct {
+	svc *importfilter.Service
+}
+
+// NewImportFilterServiceAdapter は ImportFilterServiceAdapter を生成する。
+func NewImportFilterServiceAdapter(svc *importfilter.Service) *ImportFilterServiceAdapter {
+	return &ImportFilterServiceAdapter{svc: svc}
+}
+
+// GetImportFilter は購読の取り込みフィルタを handler レスポンス型で返す。
+func (a *ImportFilterServiceAdapter) GetImportFilter(ctx context.Context, userID, subscriptionID string) (*importFilterResponse, error) {
+	f, err := a.svc.GetFilter(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return toImportFilterResponse(f), nil
+}
+
+// UpdateImportFilter は購読の取り込みフィルタを更新し、正規化後の設定を handler レスポンス型で返す。
+func (a *ImportFilterServiceAdapter) UpdateImportFilter(ctx context.Context, userID, subscriptionID string, authors []string, titlePattern string) (*importFilterResponse, error) {
+	f, err := a.svc.UpdateFilter(ctx, userID, subscriptionID, model.ImportFilter{Authors: authors, TitlePattern: titlePattern})
+	if err != nil {
+		return nil, err
+	}
+	return toImportFilterResponse(f), nil
+}
+
+// toImportFilterResponse は取り込みフィルタをレスポンス型に変換する。著者未指定時は空配列を返す。
+func toImportFilterResponse(f *model.ImportFilter) *importFilterResponse {
+	authors := f.Authors
+	if authors == nil {
+		authors = []string{}
+	}
+	return &importFilterResponse{Authors: authors, TitlePattern: f.TitlePattern}
+}
+
+// RetentionServiceAdapter は retention.Service を RetentionServiceInterface に適合させるアダプタ。
+type RetentionServiceAdapter struct {
+	svc *retention.Service
+}
+
+// NewRetentionServiceAdapter は RetentionServiceAdapter を生成する。
+func NewRetentionServiceAdapter(svc *retention.Service) *RetentionServiceAdapter {
+	return &RetentionServiceAdapter{svc: svc}
+}
+
+// GetRetentionOverride は購読の最大記事保持数を handler レスポンス型で返す。
+func (a *RetentionServiceAdapter) GetRetentionOverride(ctx context.Context, userID, subscriptionID string) (*retentionOverrideResponse, error) {
+	o, err := a.svc.GetOverride(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return &retentionOverrideResponse{MaxItems: o.MaxItems}, nil
+}
+
+// UpdateRetentionOverride は購読の最大記事保持数を更新し、更新後の設定を handler レスポンス型で返す。
+func (a *RetentionServiceAdapter) UpdateRetentionOverride(ctx context.Context, userID, subscriptionID string, maxItems *int) (*retentionOverrideResponse, error) {
+	o, err := a.svc.UpdateOverride(ctx, userID, subscriptionID, maxItems)
+	if err != nil {
+		return nil, err
+	}
+	return &retentionOverrideResponse{MaxItems: o.MaxItems}, nil
+}
+
+// NotificationServiceAdapter は notification.Service を NotificationServiceInterface に適合させるアダプタ。
+type NotificationServiceAdapter struct {
+	svc *notification.Service
+}
+
+// NewNotificationServiceAdapter は NotificationServiceAdapter を生成する。
+func NewNotificationServiceAdapter(svc *notification.Service) *NotificationServiceAdapter {
+	return &NotificationServiceAdapter{svc: svc}
+}
+
+// GetNotificationSetting は購読の通知ヒント設定を handler レスポンス型で返す。
+func (a *NotificationServiceAdapter) GetNotificationSetting(ctx context.Context, userID, subscriptionID string) (*notificationSettingResponse, error) {
+	s, err := a.svc.GetSetting(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return &notificationSettingResponse{Priority: string(s.Priority), MuteUntil: s.MuteUntil}, nil
+}
+
+// UpdateNotificationSetting は購読の通知ヒント設定を更新し、更新後の設定を handler レスポンス型で返す。
+func (a *NotificationServiceAdapter) UpdateNotificationSetting(ctx context.Context, userID, subscriptionID, priority string, muteUntil *time.Time) (*notificationSettingResponse, error) {
+	s, err := a.svc.UpdateSetting(ctx, userID, subscriptionID, model.NotificationPriority(priority), muteUntil)
+	if err != nil {
+		return nil, err
+	}
+	return &notificationSettingResponse{Priority: string(s.Priority), MuteUntil: s.MuteUntil}, nil
+}
+
+// FeedTitleServiceAdapter は feedtitle.Service を FeedTitleServiceInterface に適合させるアダプタ。
+type FeedTitleServiceAdapter struct {
+	svc *feedtitle.Service
+}
+
+// NewFeedTitleServiceAdapter は FeedTitleServiceAdapter を生成する。
+func NewFeedTitleServiceAdapter(svc *feedtitle.Service) *FeedTitleServiceAdapter {
+	return &FeedTitleServiceAdapter{svc: svc}
+}
+
+// GetFeedTitleSetting はフィードのタイトル設定を handler レスポンス型で返す。
+func (a *FeedTitleServiceAdapter) GetFeedTitleSetting(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error) {
+	s, err := a.svc.GetSetting(ctx, userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	return toFeedTitleSettingResponse(s), nil
+}
+
+// UpdateTitleUpdatePolicy は自動更新ポリシーを更新し、更新後の設定を handler レスポンス型で返す。
+func (a *FeedTitleServiceAdapter) UpdateTitleUpdatePolicy(ctx context.Context, userID, feedID, policy string) (*feedTitleSettingResponse, error) {
+	s, err := a.svc.UpdatePolicy(ctx, userID, feedID, model.TitleUpdatePolicy(policy))
+	if err != nil {
+		return nil, err
+	}
+	return toFeedTitleSettingResponse(s), nil
+}
+
+// ApprovePendingTitle は承認待ちタイトルを反映し、反映後の設定を handler レスポンス型で返す。
+func (a *FeedTitleServiceAdapter) ApprovePendingTitle(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error) {
+	s, err := a.svc.ApprovePendingTitle(ctx, userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	return toFeedTitleSettingResponse(s), nil
+}
+
+// DismissPendingTitle は承認待ちタイトルを破棄し、破棄後の設定を handler レスポンス型で返す。
+func (a *FeedTitleServiceAdapter) DismissPendingTitle(ctx context.Context, userID, feedID string) (*feedTitleSettingResponse, error) {
+	s, err := a.svc.DismissPendingTitle(ctx, userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	return toFeedTitleSettingResponse(s), nil
+}
+
+// toFeedTitleSettingResponse は model.FeedTitleSetting をレスポンス型に変換する。
+func toFeedTitleSettingResponse(s *model.FeedTitleSetting) *feedTitleSettingResponse {
+	resp := &feedTitleSettingResponse{Title: s.Title, Policy: string(s.Policy)}
+	if s.PendingTitle != "" {
+		pending := s.PendingTitle
+		resp.PendingTitle = &pending
+	}
+	return resp
+}
+
+// IntegrationServiceAdapter は integration.Service を IntegrationServiceInterface に適合させるアダプタ。
+type IntegrationServiceAdapter struct {
+	svc *integration.Service
+}
+
+// NewIntegrationServiceAdapter は IntegrationServiceAdapter を生成する。
+func NewIntegrationServiceAdapter(svc *integration.Service) *IntegrationServiceAdapter {
+	return &IntegrationServiceAdapter{svc: svc}
+}
+
+// ListIntegrations は連携設定の一覧を handler レスポンス型で返す。
+func (a *IntegrationServiceAdapter) ListIntegrations(ctx context.Context, userID string) ([]integrationResponse, error) {
+	integrations, err := a.svc.ListIntegrations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]integrationResponse, len(integrations))
+	for i := range integrations {
+		result[i] = *toIntegrationResponse(&integrations[i])
+	}
+	return result, nil
+}
+
+// GetIntegration は連携設定を handler レスポンス型で返す。
+func (a *IntegrationServiceAdapter) GetIntegration(ctx context.Context, userID, integrationID string) (*integrationResponse, error) {
+	ig, err := a.svc.GetIntegration(ctx, userID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	return toIntegrationResponse(ig), nil
+}
+
+// CreateIntegration は連携設定を作成し、作成後の設定を handler レスポンス型で返す。
+func (a *IntegrationServiceAdapter) CreateIntegration(ctx context.Context, userID string, req integrationRequest) (*integrationResponse, error) {
+	ig, err := a.svc.CreateIntegration(ctx, userID, toIntegrationInput(req))
+	if err != nil {
+		return nil, err
+	}
+	return toIntegrationResponse(ig), nil
+}
+
+// UpdateIntegration は連携設定を更新し、更新後の設定を handler レスポンス型で返す。
+func (a *IntegrationServiceAdapter) UpdateIntegration(ctx context.Context, userID, integrationID string, req integrationRequest) (*integrationResponse, error) {
+	ig, err := a.svc.UpdateIntegration(ctx, userID, integrationID, toIntegrationInput(req))
+	if err != nil {
+		return nil, err
+	}
+	return toIntegrationResponse(ig), nil
+}
+
+// DeleteIntegration は連携設定を削除する。
+func (a *IntegrationServiceAdapter) DeleteIntegration(ctx context.Context, userID, integrationID string) error {
+	return a.svc.DeleteIntegration(ctx, userID, integrationID)
+}
+
+// toIntegrationInput はリクエストボディを integration.Service の入力に変換する。
+// enabled の省略時は true とする。
+func toIntegrationInput(req integrationRequest) model.Integration {
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	return model.Integration{
+		SubscriptionID:  req.SubscriptionID,
+		Kind:            model.IntegrationKind(req.Kind),
+		WebhookURL:      req.WebhookURL,
+		MessageTemplate: req.MessageTemplate,
+		Enabled:         enabled,
+	}
+}
+
+// toIntegrationResponse は model.Integration をレスポンス型に変換する。Webhook URL はマスクする。
+func toIntegrationResponse(ig *model.Integration) *integrationResponse {
+	return &integrationResponse{
+		ID:              ig.ID,
+		SubscriptionID:  ig.SubscriptionID,
+		FeedTitle:       ig.FeedTitle,
+		Kind:            string(ig.Kind),
+		WebhookURL:      integration.MaskWebhookURL(ig.WebhookURL),
+		MessageTemplate: ig.MessageTemplate,
+		Enabled:         ig.Enabled,
+		LastError:       nullableString(ig.LastError),
+		LastDeliveredAt: ig.LastDeliveredAt,
+		CreatedAt:       ig.CreatedAt,
+	}
+}
+
+// BlockedDomainServiceAdapter は moderation.Service を BlockedDomainServiceInterface に適合させるアダプタ。
+type BlockedDomainServiceAdapter struct {
+	svc *moderation.Service
+}
+
+// NewBlockedDomainServiceAdapter は BlockedDomainServiceAdapter を生成する。
+func NewBlockedDomainServiceAdapter(svc *moderation.Service) *BlockedDomainServiceAdapter {
+	return &BlockedDomainServiceAdapter{svc: svc}
+}
+
+// ListBlockedDomains はブロックリストの全項目を返す。
+func (a *BlockedDomainServiceAdapter) ListBlockedDomains(ctx context.Context) ([]blockedDomainResponse, error) {
+	blocked, err := a.svc.ListBlockedDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]blockedDomainResponse, len(blocked))
+	for i := range blocked {
+		resp[i] = toBlockedDomainResponse(&blocked[i])
+	}
+	return resp, nil
+}
+
+// AddBlockedDomain はブロックリストに項目を追加し、遡及適用で停止したフィード数とともに返す。
+func (a *BlockedDomainServiceAdapter) AddBlockedDomain(ctx context.Context, adminUserID string, req blockedDomainRequest) (*blockedDomainCreateResponse, error) {
+	blocked, stopped, err := a.svc.AddBlockedDomain(ctx, adminUserID, req.Pattern, req.Reason, req.ApplyToExisting)
+	if err != nil {
+		return nil, err
+	}
+	return &blockedDomainCreateResponse{
+		blockedDomainResponse: toBlockedDomainResponse(blocked),
+		StoppedFeedCount:      stopped,
+	}, nil
+}
+
+// DeleteBlockedDomain はブロックリストの項目を削除する。
+func (a *BlockedDomainServiceAdapter) DeleteBlockedDomain(ctx context.Context, id string) error {
+	return a.svc.DeleteBlockedDomain(ctx, id)
+}
+
+// toBlockedDomainResponse はブロックリストの項目を API レスポンス型に変換する。
+func toBlockedDomainResponse(b *model.BlockedDomain) blockedDomainResponse {
+	return blockedDomainResponse{
+		ID:        b.ID,
+		Pattern:   b.Pattern,
+		Kind:      string(b.Kind),
+		Reason:    b.Reason,
+		CreatedAt: b.CreatedAt,
+	}
+}
+
+// UserSettingsServiceAdapter は usersettings.Service を UserSettingsServiceInterface に適合させるアダプタ。
+type UserSettingsServiceAdapter struct {
+	svc *usersettings.Service
+}
+
+// NewUserSettingsServiceAdapter は UserSettingsServiceAdapter を生成する。
+func NewUserSettingsServiceAdapter(svc *usersettings.Service) *UserSettingsServiceAdapter {
+	return &UserSettingsServiceAdapter{svc: svc}
+}
+
+// GetUnreadWarning は積読警告設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) GetUnreadWarning(ctx context.Context, userID string) (*unreadWarningResponse, error) {
+	s, err := a.svc.GetUnreadWarning(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &unreadWarningResponse{Threshold: s.Threshold, Enabled: s.Enabled()}, nil
+}
+
+// UpdateUnreadWarning は積読警告の閾値を更新し、更新後の設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) UpdateUnreadWarning(ctx context.Context, userID string, threshold int) (*unreadWarningResponse, error) {
+	s, err := a.svc.UpdateUnreadWarning(ctx, userID, threshold)
+	if err != nil {
+		return nil, err
+	}
+	return &unreadWarningResponse{Threshold: s.Threshold, Enabled: s.Enabled()}, nil
+}
+
+// GetLinkBehavior は記事本文リンクの開き方の設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) GetLinkBehavior(ctx context.Context, userID string) (*linkBehaviorResponse, error) {
+	s, err := a.svc.GetLinkBehavior(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &linkBehaviorResponse{OpenInNewTab: s.OpenInNewTab}, nil
+}
+
+// UpdateLinkBehavior は記事本文リンクの開き方の設定を更新し、更新後の設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) UpdateLinkBehavior(ctx context.Context, userID string, openInNewTab bool) (*linkBehaviorResponse, error) {
+	s, err := a.svc.UpdateLinkBehavior(ctx, userID, openInNewTab)
+	if err != nil {
+		return nil, err
+	}
+	return &linkBehaviorResponse{OpenInNewTab: s.OpenInNewTab}, nil
+}
+
+// GetPrefetch は利用時間帯に合わせたプリフェッチの設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) GetPrefetch(ctx context.Context, userID string) (*prefetchResponse, error) {
+	s, err := a.svc.GetPrefetch(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &prefetchResponse{Enabled: s.Enabled}, nil
+}
+
+// UpdatePrefetch はプリフェッチの設定を更新し、更新後の設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) UpdatePrefetch(ctx context.Context, userID string, enabled bool) (*prefetchResponse, error) {
+	s, err := a.svc.UpdatePrefetch(ctx, userID, enabled)
+	if err != nil {
+		return nil, err
+	}
+	return &prefetchResponse{Enabled: s.Enabled}, nil
+}
+
+// GetTimezone はタイムゾーン設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) GetTimezone(ctx context.Context, userID string) (*timezoneResponse, error) {
+	s, err := a.svc.GetTimezone(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &timezoneResponse{Timezone: s.Timezone}, nil
+}
+
+// UpdateTimezone はタイムゾーンを更新し、更新後の設定を handler レスポンス型で返す。
+func (a *UserSettingsServiceAdapter) UpdateTimezone(ctx context.Context, userID, timezone string) (*timezoneResponse, error) {
+	s, err := a.svc.UpdateTimezone(ctx, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+	return &timezoneResponse{Timezone: s.Timezone}, nil
+}
+
+// RelatedFeedServiceAdapter は feed.FeedService を RelatedFeedServiceInterface に適合させるアダプタ。
+type RelatedFeedServiceAdapter struct {
+	svc *feed.FeedService
+}
+
+// NewRelatedFeedServiceAdapter は RelatedFeedServiceAdapter を生成する。
+func NewRelatedFeedServiceAdapter(svc *feed.FeedService) *RelatedFeedServiceAdapter {
+	return &RelatedFeedServiceAdapter{svc: svc}
+}
+
+// ListRelatedFeeds は同じホストの別フィードを handler レスポンス型で返す。
+func (a *RelatedFeedServiceAdapter) ListRelatedFeeds(ctx context.Context, userID, feedID string) (*relatedFeedsResponse, error) {
+	related, err := a.svc.ListRelatedFeeds(ctx, userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds := make([]relatedFeedResponse, len(related))
+	for i, rf := range related {
+		feeds[i] = relatedFeedResponse{
+			FeedID:          rf.Feed.ID,
+			Title:           rf.Feed.Title,
+			FeedURL:         rf.Feed.FeedURL,
+			SiteURL:         rf.Feed.SiteURL,
+			FetchStatus:     string(rf.Feed.FetchStatus),
+			LastPublishedAt: rf.Feed.LastPublishedAt,
+			Subscribed:      rf.Subscribed(),
+		}
+		if rf.Subscribed() {
+			subID := rf.SubscriptionID
+			feeds[i].SubscriptionID = &subID
+		}
+	}
+	return &relatedFeedsResponse{Feeds: feeds}, nil
+}
+
+// ItemVisitServiceAdapter は item.ItemVisitService を ItemVisitServiceInterface に適合させるアダプタ。
+type ItemVisitServiceAdapter struct {
+	svc          *item.ItemVisitService
+	invalidators []cache.UserInvalidator
+}
+
+// NewItemVisitServiceAdapter は ItemVisitServiceAdapter を生成する。
+// invalidators には既読状態の変化で内容が変わるキャッシュ（購読一覧の未読数）の無効化先を渡す。
+func NewItemVisitServiceAdapter(svc *item.ItemVisitService, invalidators ...cache.UserInvalidator) *ItemVisitServiceAdapter {
+	return &ItemVisitServiceAdapter{svc: svc, invalidators: invalidators}
+}
+
+// Visit は記事を既読化・訪問記録し、元記事の URL を返す。
+func (a *ItemVisitServiceAdapter) Visit(ctx context.Context, userID, itemID string) (string, error) {
+	link, err := a.svc.Visit(ctx, userID, itemID)
+	if err != nil {
+		return "", err
+	}
+	invalidateUser(ctx, a.invalidators, userID)
+	return link, nil
+}
+
+// SyncServiceAdapter は item.SyncService を SyncServiceInterface に適合させるアダプタ。
+type SyncServiceAdapter struct {
+	svc          *item.SyncService
+	invalidators []cache.UserInvalidator
+}
+
+// NewSyncServiceAdapter は SyncServiceAdapter を生成する。
+// invalidators には既読状態の変化で内容が変わるキャッシュ（購読一覧の未読数）の無効化先を渡す。
+func NewSyncServiceAdapter(svc *item.SyncService, invalidators ...cache.UserInvalidator) *SyncServiceAdapter {
+	return &SyncServiceAdapter{svc: svc, invalidators: invalidators}
+}
+
+// ApplyOperations は操作列を適用し、操作ごとの結果を handler のレスポンス型で返す。
+// 1 件でも適用した場合はユーザーのキャッシュを無効化する。
+func (a *SyncServiceAdapter) ApplyOperations(ctx context.Context, userID string, ops []model.SyncOperation) (*syncOperationsResponse, error) {
+	results, err := a.svc.ApplyOperations(ctx, userID, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &syncOperationsResponse{Results: make([]syncOperationResultResponse, len(results))}
+	applied := false
+	for i, res := range results {
+		r := syncOperationResultResponse{
+			Index:  i,
+			Type:   string(res.Operation.Type),
+			ItemID: res.Operation.ItemID,
+			Status: string(res.Status),
+		}
+		if res.State != nil {
+			isRead, isStarred := res.State.IsRead, res.State.IsStarred
+			r.IsRead, r.IsStarred = &isRead, &isStarred
+		}
+		if res.Error != nil {
+			r.Error = &syncOperationError{Code: res.Error.Code, Message: res.Error.Message}
+		}
+		if res.Status == model.SyncStatusApplied {
+			applied = true
+		}
+		resp.Results[i] = r
+	}
+	if applied {
+		invalidateUser(ctx, a.invalidators, userID)
+	}
+	return resp, nil
+}
+
+// FeedBatchServiceAdapter は feed.BatchRegistrationService を FeedBatchServiceInterface に適合させるアダプタ。
+type FeedBatchServiceAdapter struct {
+	svc *feed.BatchRegistrationService
+}
+
+// NewFeedBatchServiceAdapter は FeedBatchServiceAdapter を生成する。
+func NewFeedBatchServiceAdapter(svc *feed.BatchRegistrationService) *FeedBatchServiceAdapter {
+	return &FeedBatchServiceAdapter{svc: svc}
+}
+
+// StartBatch は一括登録を開始し、handler のレスポンス型で返す。
+func (a *FeedBatchServiceAdapter) StartBatch(ctx context.Context, userID string, urls []string) (*feedBatchResponse, error) {
+	batch, err := a.svc.Start(ctx, userID, urls)
+	if err != nil {
+		return nil, err
+	}
+	return toFeedBatchResponse(batch), nil
+}
+
+// GetBatch は一括登録を handler のレスポンス型で返す。
+func (a *FeedBatchServiceAdapter) GetBatch(ctx context.Context, userID, batchID string) (*feedBatchResponse, error) {
+	batch, err := a.svc.Get(ctx, userID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return toFeedBatchResponse(batch), nil
+}
+
+// toFeedBatchResponse は一括登録を handler のレスポンス型に変換する。
+func toFeedBatchResponse(batch *model.FeedRegistrationBatch) *feedBatchResponse {
+	resp := &feedBatchResponse{
+		ID:          batch.ID,
+		Status:      "processing",
+		Results:     make([]feedBatchResultResponse, len(batch.Entries)),
+		CreatedAt:   batch.CreatedAt,
+		CompletedAt: batch.CompletedAt,
+	}
+	if batch.CompletedAt != nil {
+		resp.Status = "completed"
+	}
+	for i, e := range batch.Entries {
+		r := feedBatchResultResponse{
+			Index:  i,
+			URL:    e.URL,
+			Status: string(e.Status),
+			FeedID: e.FeedID,
+		}
+		if e.Error != nil {
+			r.Error = &feedBatchResultError{Code: e.Error.Code, Message: e.Error.Message}
+		}
+		resp.Results[i] = r
+	}
+	return resp
+}
+
+// FeedPreflightServiceAdapter は feed.PreflightService を FeedPreflightServiceInterface に適合させるアダプタ。
+type FeedPreflightServiceAdapter struct {
+	svc *feed.PreflightService
+}
+
+// NewFeedPreflightServiceAdapter は FeedPreflightServiceAdapter を生成する。
+func NewFeedPreflightServiceAdapter(svc *feed.PreflightService) *FeedPreflightServiceAdapter {
+	return &FeedPreflightServiceAdapter{svc: svc}
+}
+
+// ValidateFeedURL はフィード URL を検証し、handler のレスポンス型で返す。
+func (a *FeedPreflightServiceAdapter) ValidateFeedURL(ctx context.Context, url string) (*feedPreflightResponse, error) {
+	result, err := a.svc.Validate(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	resp := &feedPreflightResponse{
+		URL:        result.URL,
+		Valid:      result.Valid,
+		SourceType: result.SourceType,
+		FeedURL:    result.FeedURL,
+		FeedType:   result.FeedType,
+	}
+	if result.Error != nil {
+		resp.Error = &feedPreflightResultError{Code: result.Error.Code, Message: result.Error.Message}
+	}
+	return resp, nil
+}
+
+// HighlightServiceAdapter は highlight.Service を HighlightServiceInterface に適合させるアダプタ。
+type HighlightServiceAdapter struct {
+	svc *highlight.Service
+}
+
+// NewHighlightServiceAdapter は HighlightServiceAdapter を生成する。
+func NewHighlightServiceAdapter(svc *highlight.Service) *HighlightServiceAdapter {
+	return &HighlightServiceAdapter{svc: svc}
+}
+
+// ListHighlights はハイライトを handler レスポンス型で返す。
+func (a *HighlightServiceAdapter) ListHighlights(ctx context.Context, userID, period string) (*highlightsResponse, error) {
+	result, err := a.svc.List(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]highlightItemResponse, len(result.Items))
+	for i, h := range result.Items {
+		items[i] = highlightItemResponse{
+			ID:          h.ItemID,
+			FeedID:      h.FeedID,
+			FeedTitle:   h.FeedTitle,
+			Title:       h.Title,
+			Link:        h.Link,
+			PublishedAt: h.PublishedAt,
+			Score:       h.Score,
+			HatebuCount: h.HatebuCount,
+			StarCount:   h.StarCount,
+			ViewCount:   h.ViewCount,
+			IsRead:      h.IsRead,
+			IsStarred:   h.IsStarred,
+		}
+	}
+	return &highlightsResponse{Period: string(result.Period), ComputedAt: result.ComputedAt, Items: items}, nil
+}
+
+// KeywordServiceAdapter は keyword.Service を KeywordServiceInterface に適合させるアダプタ。
+type KeywordServiceAdapter struct {
+	svc *keyword.Service
+}
+
+// NewKeywordServiceAdapter は KeywordServiceAdapter を生成する。
+func NewKeywordServiceAdapter(svc *keyword.Service) *KeywordServiceAdapter {
+	return &KeywordServiceAdapter{svc: svc}
+}
+
+// GetSubscriptionKeywords は購読フィードの頻出キーワードを handler レスポンス型で返す。
+func (a *KeywordServiceAdapter) GetSubscriptionKeywords(ctx context.Context, userID, subscriptionID string) (*subscriptionKeywordsResponse, error) {
+	summary, err := a.svc.Get(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := make([]keywordResponse, len(summary.Keywords))
+	for i, k := range summary.Keywords {
+		keywords[i] = keywordResponse{Keyword: k.Keyword, Count: k.Count}
+	}
+	return &subscriptionKeywordsResponse{FeedID: summary.FeedID, ComputedAt: summary.ComputedAt, Keywords: keywords}, nil
+}
+
+// ItemSummaryServiceAdapter は item.SummaryService を ItemSummaryServiceInterface に適合させるアダプタ。
+type ItemSummaryServiceAdapter struct {
+	svc *item.SummaryService
+}
+
+// NewItemSummaryServiceAdapter は ItemSummaryServiceAdapter を生成する。
+func NewItemSummaryServiceAdapter(svc *item.SummaryService) *ItemSummaryServiceAdapter {
+	return &ItemSummaryServiceAdapter{svc: svc}
+}
+
+// Summarize は記事の要約を handler レスポンス型で返す。
+func (a *ItemSummaryServiceAdapter) Summarize(ctx context.Context, userID, itemID string) (*itemSummarizeResponse, error) {
+	result, err := a.svc.Summarize(ctx, userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return &itemSummarizeResponse{
+		ItemID:      result.ItemID,
+		Summary:     result.Summary,
+		GeneratedAt: result.GeneratedAt,
+		Cached:      result.Cached,
+		Fallback:    result.Fallback,
+	}, nil
+}
+
+// StatsServiceAdapter は stats.Service を StatsServiceInterface に適合させるアダプタ。
+type StatsServiceAdapter struct {
+	svc *stats.Service
+}
+
+// NewStatsServiceAdapter は StatsServiceAdapter を生成する。
+func NewStatsServiceAdapter(svc *stats.Service) *StatsServiceAdapter {
+	return &StatsServiceAdapter{svc: svc}
+}
+
+// TopFeeds は「よく読むフィード」ランキングを handler レスポンス型で返す。
+func (a *StatsServiceAdapter) TopFeeds(ctx context.Context, userID, period string, limit int) (*topFeedsResponse, error) {
+	result, err := a.svc.TopFeeds(ctx, userID, period, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds := make([]topFeedResponse, len(result.Feeds))
+	for i, f := range result.Feeds {
+		feeds[i] = topFeedResponse{
+			FeedID:       f.FeedID,
+			FeedTitle:    f.FeedTitle,
+			ViewCount:    f.ViewCount,
+			ItemCount:    f.ItemCount,
+			LastViewedAt: f.LastViewedAt,
+		}
+	}
+	return &topFeedsResponse{PeriodDays: result.PeriodDays, Since: result.Since, Feeds: feeds}, nil
+}
+
+// WeeklyTrend は週次トレンドを handler レスポンス型で返す。
+func (a *StatsServiceAdapter) WeeklyTrend(ctx context.Context, userID string, weeks int) (*weeklyTrendResponse, error) {
+	result, err := a.svc.WeeklyTrend(ctx, userID, weeks)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]weeklyTrendPointResponse, len(result.Series))
+	for i, p := range result.Series {
+		feeds := make([]weeklyFeedStatResponse, len(p.Feeds))
+		for j, f := range p.Feeds {
+			feeds[j] = weeklyFeedStatResponse{
+				FeedID:      f.FeedID,
+				FeedTitle:   f.FeedTitle,
+				NewItems:    f.NewItems,
+				ReadItems:   f.ReadItems,
+				HiddenItems: f.HiddenItems,
+			}
+		}
+		series[i] = weeklyTrendPointResponse{
+			WeekStart:   p.WeekStart,
+			NewItems:    p.NewItems,
+			ReadItems:   p.ReadItems,
+			HiddenItems: p.HiddenItems,
+			Feeds:       feeds,
+		}
+	}
+	return &weeklyTrendResponse{Weeks: result.Weeks, Since: result.Since, Series: series}, nil
+}
+
+// UsageServiceAdapter は usage.Service を UsageServiceInterface に適合させるアダプタ。
+type UsageServiceAdapter struct {
+	svc *usage.Service
+}
+
+// NewUsageServiceAdapter は UsageServiceAdapter を生成する。
+func NewUsageServiceAdapter(svc *usage.Service) *UsageServiceAdapter {
+	return &UsageServiceAdapter{svc: svc}
+}
+
+// Daily は API 利用量を handler レスポンス型で返す。
+func (a *UsageServiceAdapter) Daily(ctx context.Context, userID string) (*usageResponse, error) {
+	result, err := a.svc.Daily(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]usageDayResponse, len(result.Series))
+	for i, d := range result.Series {
+		clients := make([]usageClientResponse, len(d.Clients))
+		for j, c := range d.Clients {
+			clients[j] = usageClientResponse{
+				Client:       c.Client,
+				RequestCount: c.RequestCount,
+				ErrorCount:   c.ErrorCount,
+				ErrorRate:    errorRate(c.RequestCount, c.ErrorCount),
+			}
+		}
+		series[i] = usageDayResponse{
+			Date:         d.Date,
+			RequestCount: d.RequestCount,
+			ErrorCount:   d.ErrorCount,
+			ErrorRate:    errorRate(d.RequestCount, d.ErrorCount),
+			Clients:      clients,
+		}
+	}
+	return &usageResponse{
+		Days:         result.Days,
+		Since:        result.Since,
+		RequestCount: result.RequestCount,
+		ErrorCount:   result.ErrorCount,
+		ErrorRate:    errorRate(result.RequestCount, result.ErrorCount),
+		Series:       series,
+	}, nil
+}
+
+// AdminStatsServiceAdapter は adminstats.Service を AdminStatsServiceInterface に適合させるアダプタ。
+type AdminStatsServiceAdapter struct {
+	svc *adminstats.Service
+}
+
+// NewAdminStatsServiceAdapter は AdminStatsServiceAdapter を生成する。
+func NewAdminStatsServiceAdapter(svc *adminstats.Service) *AdminStatsServiceAdapter {
+	return &AdminStatsServiceAdapter{svc: svc}
+}
+
+// GetStats は全体統計を handler レスポンス型で返す。
+func (a *AdminStatsServiceAdapter) GetStats(ctx context.Context) (*adminStatsResponse, error) {
+	stats, err := a.svc.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &adminStatsResponse{
+		UserCount: stats.UserCount,
+		FeedCount: stats.FeedCount,
+		ItemCount: stats.ItemCount,
+		Fetch24h: adminFetchStatsResponse{
+			Attempts:      stats.FetchAttempts24h,
+			Successes:     stats.FetchSuccesses24h,
+			SuccessRate:   stats.FetchSuccessRate24h(),
+			AvgDurationMs: stats.AvgFetchDurationMs24h,
+		},
+		DatabaseSizeBytes: stats.DatabaseSizeBytes,
+		RefreshedAt:       stats.RefreshedAt,
+	}, nil
+}
+
+// WorkerCycleServiceAdapter はリポジトリ層を WorkerCycleServiceInterface に適合させるアダプタ。
+type WorkerCycleServiceAdapter struct {
+	repo repository.WorkerCycleRepository
+}
+
+// NewWorkerCycleServiceAdapter は WorkerCycleServiceAdapter を生成する。
+func NewWorkerCycleServiceAdapter(repo repository.WorkerCycleRepository) *WorkerCycleServiceAdapter {
+	return &WorkerCycleServiceAdapter{repo: repo}
+}
+
+// ListRecentCycles は直近のサイクル結果を handler レスポンス型で返す。
+func (a *WorkerCycleServiceAdapter) ListRecentCycles(ctx context.Context, limit int) ([]workerCycleResponse, error) {
+	cycles, err := a.repo.ListRecentWorkerCycles(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]workerCycleResponse, len(cycles))
+	for i, c := range cycles {
+		resp[i] = workerCycleResponse{
+			ID:             c.ID,
+			StartedAt:      c.StartedAt,
+			FinishedAt:     c.FinishedAt,
+			FeedCount:      c.FeedCount,
+			SucceededCount: c.SucceededCount,
+			FailedCount:    c.FailedCount,
+			ItemsInserted:  c.ItemsInserted,
+			ItemsUpdated:   c.ItemsUpdated,
+			DurationMs:     c.Duration.Milliseconds(),
+		}
+	}
+	return resp, nil
+}
+
+// FeedAdminServiceAdapter はリポジトリ層を FeedAdminServiceInterface に適合させるアダプタ。
+type FeedAdminServiceAdapter struct {
+	repo        repository.FeedConditionalGetRepository
+	rawCaptures repository.FeedRawCaptureRepository
+}
+
+// NewFeedAdminServiceAdapter は FeedAdminServiceAdapter を生成する。
+func NewFeedAdminServiceAdapter(repo repository.FeedConditionalGetRepository, rawCaptures repository.FeedRawCaptureRepository) *FeedAdminServiceAdapter {
+	return &FeedAdminServiceAdapter{repo: repo, rawCaptures: rawCaptures}
+}
+
+// SetIgnoreConditionalGet はフィードの条件付き GET 無効化フラグを更新し、更新後の設定を返す。
+func (a *FeedAdminServiceAdapter) SetIgnoreConditionalGet(ctx context.Context, feedID string, ignore bool) (*feedConditionalGetResponse, error) {
+	updated, err := a.repo.SetIgnoreConditionalGet(ctx, feedID, ignore)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, nil
+	}
+	return &feedConditionalGetResponse{FeedID: feedID, IgnoreConditionalGet: ignore}, nil
+}
+
+// SetRawCapture はフィードのフェッチレスポンス保存の有効・無効を更新し、更新後の設定を返す。
+func (a *FeedAdminServiceAdapter) SetRawCapture(ctx context.Context, feedID string, enabled bool) (*feedRawCaptureSettingResponse, error) {
+	updated, err := a.rawCaptures.SetCaptureRawResponse(ctx, feedID, enabled)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, nil
+	}
+	return &feedRawCaptureSettingResponse{FeedID: feedID, Enabled: enabled}, nil
+}
+
+// GetRawCapture はフィードの直近のフェッチレスポンスを返す。保存されていない場合は FEED_RAW_CAPTURE_NOT_FOUND を返す。
+func (a *FeedAdminServiceAdapter) GetRawCapture(ctx context.Context, feedID string) (*model.FeedRawCapture, error) {
+	capture, err := a.rawCaptures.FindRawCapture(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	if capture == nil {
+		return nil, model.NewFeedRawCaptureNotFoundError(feedID)
+	}
+	return capture, nil
+}
+
+// FeedDebugServiceAdapter は fetch.Diagnoser を FeedDebugServiceInterface に適合させるアダプタ。
+type FeedDebugServiceAdapter struct {
+	diagnoser *fetchpkg.Diagnoser
+}
+
+// NewFeedDebugServiceAdapter は FeedDebugServiceAdapter を生成する。
+func NewFeedDebugServiceAdapter(diagnoser *fetchpkg.Diagnoser) *FeedDebugServiceAdapter {
+	return &FeedDebugServiceAdapter{diagnoser: diagnoser}
+}
+
+// ParseFeed はフィードのパース結果を診断し、handler レスポンス型で返す。
+func (a *FeedDebugServiceAdapter) ParseFeed(ctx context.Context, rawURL, rawXML string) (*feedDiagnosisResponse, error) {
+	d, err := a.diagnoser.Diagnose(ctx, rawURL, rawXML)
+	if err != nil {
+		return nil, err
+	}
+	return toFeedDiagnosisResponse(d), nil
+}
+
+// toFeedDiagnosisResponse はドメインの診断結果をAPIレスポンス型に変換する。
+func toFeedDiagnosisResponse(d *model.FeedDiagnosis) *feedDiagnosisResponse {
+	items := make([]diagnosedItemResponse, 0, len(d.Items))
+	for _, it := range d.Items {
+		items = append(items, diagnosedItemResponse{
+			GuidOrID:        it.GuidOrID,
+			Title:           it.Title,
+			Link:            it.Link,
+			Author:          it.Author,
+			PublishedAt:     it.PublishedAt,
+			IsDateEstimated: it.IsDateEstimated,
+			Warnings:        nonNilStrings(it.Warnings),
+		})
+	}
+	return &feedDiagnosisResponse{
+		FeedType:    d.FeedType,
+		FeedVersion: d.FeedVersion,
+		Title:       d.Title,
+		SiteURL:     d.SiteURL,
+		ItemCount:   len(items),
+		Items:       items,
+		Warnings:    nonNilStrings(d.Warnings),
+	}
+}
+
+// nonNilStrings は JSON で null ではなく空配列を返すため、nil スライスを空スライスに置き換える。
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// --- compile-time interface checks ---
+
+// AuditLogServiceAdapter は audit.Service を AuditLogServiceInterface に適合させるアダプタ。
+type AuditLogServiceAdapter struct {
+	svc *audit.Service
+}
+
+// NewAuditLogServiceAdapter は AuditLogServiceAdapter を生成する。
+func NewAuditLogServiceAdapter(svc *audit.Service) *AuditLogServiceAdapter {
+	return &AuditLogServiceAdapter{svc: svc}
+}
+
+// ListAuditLogs は監査ログの 1 ページを handler レスポンス型で返す。
+func (a *AuditLogServiceAdapter) ListAuditLogs(ctx context.Context, userID, cursor string, limit int) (*auditLogListResponse, error) {
+	result, err := a.svc.List(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]auditLogResponse, len(result.Logs))
+	for i, l := range result.Logs {
+		payload := l.Payload
+		if payload == nil {
+			payload = map[string]any{}
+		}
+		logs[i] = auditLogResponse{
+			ID:        l.ID,
+			Action:    string(l.Action),
+			Target:    l.Target,
+			Payload:   payload,
+			CreatedAt: l.CreatedAt,
+		}
+	}
+	return &auditLogListResponse{
+		Logs:       logs,
+		NextCursor: nullableString(result.NextCursor),
+		HasMore:    result.HasMore,
+	}, nil
+}
+
+// LoginHistoryServiceAdapter は audit.LoginEventService を LoginHistoryServiceInterface に適合させるアダプタ。
+type LoginHistoryServiceAdapter struct {
+	svc *audit.LoginEventService
+}
+
+// NewLoginHistoryServiceAdapter は LoginHistoryServiceAdapter を生成する。
+func NewLoginHistoryServiceAdapter(svc *audit.LoginEventService) *LoginHistoryServiceAdapter {
+	return &LoginHistoryServiceAdapter{svc: svc}
+}
+
+// ListLoginHistory はログイン履歴の 1 ページを handler レスポンス型で返す。
+func (a *LoginHistoryServiceAdapter) ListLoginHistory(ctx context.Context, userID, cursor string, limit int) (*loginHistoryListResponse, error) {
+	result, err := a.svc.List(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]loginEventResponse, len(result.Events))
+	for i, e := range result.Events {
+		events[i] = loginEventResponse{
+			ID:        e.ID,
+			Event:     string(e.Event),
+			Reason:    nullableString(e.Reason),
+			IPAddress: nullableString(e.IPAddress),
+			UserAgent: nullableString(e.UserAgent),
+			CreatedAt: e.CreatedAt,
+		}
+	}
+	return &loginHistoryListResponse{
+		Events:     events,
+		NextCursor: nullableString(result.NextCursor),
+		HasMore:    result.HasMore,
+	}, nil
+}
+
+// IdentityServiceAdapter は auth.IdentityService を IdentityServiceInterface に適合させるアダプタ。
+type IdentityServiceAdapter struct {
+	svc *auth.IdentityService
+}
+
+// NewIdentityServiceAdapter は IdentityServiceAdapter を生成する。
+func NewIdentityServiceAdapter(svc *auth.IdentityService) *IdentityServiceAdapter {
+	return &IdentityServiceAdapter{svc: svc}
+}
+
+// ListIdentities はアカウント連携の一覧を handler レスポンス型で返す。
+func (a *IdentityServiceAdapter) ListIdentities(ctx context.Context, userID string) (*identityListResponse, error) {
+	identities, err := a.svc.ListIdentities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]identityResponse, len(identities))
+	for i, identity := range identities {
+		resp[i] = identityResponse{
+			ID:        identity.ID,
+			Provider:  identity.Provider,
+			CreatedAt: identity.CreatedAt,
+		}
+	}
+	return &identityListResponse{Identities: resp}, nil
+}
+
+// UnlinkIdentity はアカウント連携を解除する。
+func (a *IdentityServiceAdapter) UnlinkIdentity(ctx context.Context, userID, identityID string) error {
+	return a.svc.UnlinkIdentity(ctx, userID, identityID)
+}
+
+// TeamServiceAdapter は team.Service を TeamServiceInterface に適合させるアダプタ。
+type TeamServiceAdapter struct {
+	svc *team.Service
+}
+
+// NewTeamServiceAdapter は TeamServiceAdapter を生成する。
+func NewTeamServiceAdapter(svc *team.Service) *TeamServiceAdapter {
+	return &TeamServiceAdapter{svc: svc}
+}
+
+// ListTeams は所属チームを handler レスポンス型で返す。
+func (a *TeamServiceAdapter) ListTeams(ctx context.Context, userID string) ([]teamResponse, error) {
+	memberships, err := a.svc.ListTeams(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]teamResponse, len(memberships))
+	for i, m := range memberships {
+		teams[i] = toTeamResponse(m)
+	}
+	return teams, nil
+}
+
+// CreateTeam はチームを作成し handler レスポンス型で返す。
+func (a *TeamServiceAdapter) CreateTeam(ctx context.Context, userID, name string) (*teamResponse, error) {
+	membership, err := a.svc.CreateTeam(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	resp := toTeamResponse(*membership)
+	return &resp, nil
+}
+
+// GetTeam はチームの詳細を handler レスポンス型で返す。
+func (a *TeamServiceAdapter) GetTeam(ctx context.Context, userID, teamID string) (*teamDetailResponse, error) {
+	detail, err := a.svc.GetTeam(ctx, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]teamMemberResponse, len(detail.Members))
+	for i, m := range detail.Members {
+		members[i] = teamMemberResponse{
+			UserID:   m.UserID,
+			Name:     m.Name,
+			Role:     string(m.Role),
+			JoinedAt: m.JoinedAt,
+		}
+	}
+	feeds := make([]teamFeedResponse, len(detail.Feeds))
+	for i, f := range detail.Feeds {
+		feeds[i] = teamFeedResponse{
+			FeedID:    f.FeedID,
+			Title:     f.Title,
+			FeedURL:   f.FeedURL,
+			SiteURL:   f.SiteURL,
+			AddedBy:   f.AddedBy,
+			CreatedAt: f.CreatedAt,
+		}
+	}
+	return &teamDetailResponse{
+		teamResponse: toTeamResponse(detail.Membership),
+		Members:      members,
+		Feeds:        feeds,
+	}, nil
+}
+
+// CreateInvitation は招待トークンを発行し handler レスポンス型で返す。
+func (a *TeamServiceAdapter) CreateInvitation(ctx context.Context, userID, teamID string) (*teamInvitationResponse, error) {
+	invitation, err := a.svc.CreateInvitation(ctx, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return &teamInvitationResponse{Token: invitation.Token, ExpiresAt: invitation.ExpiresAt}, nil
+}
+
+// AcceptInvitation は招待トークンでチームに参加し、参加したチームを handler レスポンス型で返す。
+func (a *TeamServiceAdapter) AcceptInvitation(ctx context.Context, userID, token string) (*teamResponse, error) {
+	t, err := a.svc.AcceptInvitation(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	return &teamResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Role:      string(model.TeamRoleMember),
+		CreatedAt: t.CreatedAt,
+	}, nil
+}
+
+// AddFeed は自分の購読フィードをチームの購読に追加する。
+func (a *TeamServiceAdapter) AddFeed(ctx context.Context, userID, teamID, feedID string) error {
+	return a.svc.AddFeed(ctx, userID, teamID, feedID)
+}
+
+// RemoveFeed はチームの購読フィードを削除する。
+func (a *TeamServiceAdapter) RemoveFeed(ctx context.Context, userID, teamID, feedID string) error {
+	return a.svc.RemoveFeed(ctx, userID, teamID, feedID)
+}
+
+// LeaveTeam はチームから脱退する。
+func (a *TeamServiceAdapter) LeaveTeam(ctx context.Context, userID, teamID string) error {
+	return a.svc.LeaveTeam(ctx, userID, teamID)
+}
+
+// toTeamResponse は所属チームを handler レスポンス型に変換する。
+func toTeamResponse(m model.TeamMembership) teamResponse {
+	return teamResponse{
+		ID:        m.ID,
+		Name:      m.Name,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ReadLaterServiceAdapter は readlater.Service を ReadLaterServiceInterface に適合させるアダプタ。
+type ReadLaterServiceAdapter struct {
+	svc *readlater.Service
+}
+
+// NewReadLaterServiceAdapter は ReadLaterServiceAdapter を生成する。
+func NewReadLaterServiceAdapter(svc *readlater.Service) *ReadLaterServiceAdapter {
+	return &ReadLaterServiceAdapter{svc: svc}
+}
+
+// ListConnections は連携できる保存先と連携の一覧を handler レスポンス型で返す。
+func (a *ReadLaterServiceAdapter) ListConnections(ctx context.Context, userID string) (*readLaterListResponse, error) {
+	conns, err := a.svc.ListConnections(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &readLaterListResponse{Connections: make([]readLaterConnectionResponse, len(conns))}
+	for _, p := range a.svc.AvailableProviders() {
+		resp.AvailableProviders = append(resp.AvailableProviders, string(p))
+	}
+	for i := range conns {
+		resp.Connections[i] = *toReadLaterConnectionResponse(&conns[i])
+	}
+	return resp, nil
+}
+
+// StartPocketAuthorization は Pocket の認可を開始し、認可画面の URL を返す。
+func (a *ReadLaterServiceAdapter) StartPocketAuthorization(ctx context.Context, userID string) (string, error) {
+	return a.svc.StartPocketAuthorization(ctx, userID)
+}
+
+// CompletePocketAuthorization は Pocket の認可を完了して連携を保存する。
+func (a *ReadLaterServiceAdapter) CompletePocketAuthorization(ctx context.Context, userID, state string) error {
+	_, err := a.svc.CompletePocketAuthorization(ctx, userID, state)
+	return err
+}
+
+// ConnectInstapaper は Instapaper と連携し、連携を handler レスポンス型で返す。
+func (a *ReadLaterServiceAdapter) ConnectInstapaper(ctx context.Context, userID, username, password string) (*readLaterConnectionResponse, error) {
+	conn, err := a.svc.ConnectInstapaper(ctx, userID, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return toReadLaterConnectionResponse(conn), nil
+}
+
+// SetEnabled は連携の有効・無効を切り替え、更新後の連携を handler レスポンス型で返す。
+func (a *ReadLaterServiceAdapter) SetEnabled(ctx context.Context, userID, provider string, enabled bool) (*readLaterConnectionResponse, error) {
+	conn, err := a.svc.SetEnabled(ctx, userID, model.ReadLaterProvider(provider), enabled)
+	if err != nil {
+		return nil, err
+	}
+	return toReadLaterConnectionResponse(conn), nil
+}
+
+// Disconnect は連携を解除する。
+func (a *ReadLaterServiceAdapter) Disconnect(ctx context.Context, userID, provider string) error {
+	return a.svc.Disconnect(ctx, userID, model.ReadLaterProvider(provider))
+}
+
+// toReadLaterConnectionResponse は model.ReadLaterConnection をレスポンス型に変換する。認証情報は含めない。
+func toReadLaterConnectionResponse(c *model.ReadLaterConnection) *readLaterConnectionResponse {
+	return &readLaterConnectionResponse{
+		Provider:    string(c.Provider),
+		AccountName: c.AccountName,
+		Enabled:     c.Enabled,
+		LastError:   nullableString(c.LastError),
+		LastErrorAt: c.LastErrorAt,
+		LastSavedAt: c.LastSavedAt,
+		CreatedAt:   c.CreatedAt,
+	}
+}
 
 var _ SubscriptionServiceInterface = (*SubscriptionServiceAdapter)(nil)
 var _ UserServiceInterface = (*UserServiceAdapter)(nil)
@@ -388,6 +1901,33 @@ var _ ItemStateServiceInterface = (*ItemStateServiceAdapterFromRepo)(nil)
 var _ ItemSearchServiceInterface = (*ItemSearchServiceAdapter)(nil)
 var _ SubscriptionDeleter = (*SubscriptionDeleterAdapter)(nil)
 var _ CrossFeedServiceInterface = (*CrossFeedServiceAdapter)(nil)
+var _ RandomItemServiceInterface = (*RandomItemServiceAdapter)(nil)
+var _ PublicProfileServiceInterface = (*PublicProfileServiceAdapter)(nil)
+var _ ImportFilterServiceInterface = (*ImportFilterServiceAdapter)(nil)
+var _ RetentionServiceInterface = (*RetentionServiceAdapter)(nil)
+var _ NotificationServiceInterface = (*NotificationServiceAdapter)(nil)
+var _ FeedTitleServiceInterface = (*FeedTitleServiceAdapter)(nil)
+var _ UserSettingsServiceInterface = (*UserSettingsServiceAdapter)(nil)
+var _ FeedDebugServiceInterface = (*FeedDebugServiceAdapter)(nil)
+var _ StatsServiceInterface = (*StatsServiceAdapter)(nil)
+var _ UsageServiceInterface = (*UsageServiceAdapter)(nil)
+var _ AdminStatsServiceInterface = (*AdminStatsServiceAdapter)(nil)
+var _ FeedAdminServiceInterface = (*FeedAdminServiceAdapter)(nil)
+var _ WorkerCycleServiceInterface = (*WorkerCycleServiceAdapter)(nil)
+var _ RelatedFeedServiceInterface = (*RelatedFeedServiceAdapter)(nil)
+var _ ItemVisitServiceInterface = (*ItemVisitServiceAdapter)(nil)
+var _ ItemSummaryServiceInterface = (*ItemSummaryServiceAdapter)(nil)
+var _ AuditLogServiceInterface = (*AuditLogServiceAdapter)(nil)
+var _ LoginHistoryServiceInterface = (*LoginHistoryServiceAdapter)(nil)
+var _ IdentityServiceInterface = (*IdentityServiceAdapter)(nil)
+var _ TeamServiceInterface = (*TeamServiceAdapter)(nil)
+var _ IntegrationServiceInterface = (*IntegrationServiceAdapter)(nil)
+var _ BlockedDomainServiceInterface = (*BlockedDomainServiceAdapter)(nil)
+var _ ReadLaterServiceInterface = (*ReadLaterServiceAdapter)(nil)
+var _ FeedBatchServiceInterface = (*FeedBatchServiceAdapter)(nil)
+var _ FeedPreflightServiceInterface = (*FeedPreflightServiceAdapter)(nil)
+var _ HighlightServiceInterface = (*HighlightServiceAdapter)(nil)
+var _ KeywordServiceInterface = (*KeywordServiceAdapter)(nil)
 
 // zeroTime はゼロ値のtime.Time。
 var zeroTime time.Time
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// stubDeleterSubRepo は SubscriptionDeleterAdapter が使うメソッドだけを実装する購読リポジトリのスタブ。
+type stubDeleterSubRepo struct {
+	repository.SubscriptionRepository
+	sub       *model.Subscription
+	deleteErr error
+	deleted   []string
+}
+
+func (s *stubDeleterSubRepo) FindByUserAndFeed(context.Context, string, string) (*model.Subscription, error) {
+	return s.sub, nil
+}
+
+func (s *stubDeleterSubRepo) Delete(_ context.Context, id string) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+// stubDeleterItemStateRepo は記事状態の削除だけを実装する記事状態リポジトリのスタブ。
+type stubDeleterItemStateRepo struct {
+	repository.ItemStateRepository
+}
+
+func (s *stubDeleterItemStateRepo) DeleteByUserAndFeed(context.Context, string, string) error {
+	return nil
+}
+
+// recordedAudit は mockDeleterAuditRecorder が受け取った監査ログの 1 件。
+type recordedAudit struct {
+	userID  string
+	action  model.AuditAction
+	target  string
+	payload map[string]any
+}
+
+// mockDeleterAuditRecorder は subscription.AuditRecorder のモック実装。
+type mockDeleterAuditRecorder struct {
+	records []recordedAudit
+}
+
+func (m *mockDeleterAuditRecorder) Record(_ context.Context, userID string, action model.AuditAction, target string, payload map[string]any) {
+	m.records = append(m.records, recordedAudit{userID: userID, action: action, target: target, payload: payload})
+}
+
+func TestSubscriptionDeleterAdapter_DeleteByUserAndFeed_Audit(t *testing.T) {
+	t.Run("購読を削除したとき購読解除を監査ログに記録する", func(t *testing.T) {
+		// Arrange
+		subRepo := &stubDeleterSubRepo{sub: &model.Subscription{ID: "sub-1", UserID: "user-1", FeedID: "feed-1"}}
+		recorder := &mockDeleterAuditRecorder{}
+		adapter := NewSubscriptionDeleterAdapter(subRepo, &stubDeleterItemStateRepo{}, recorder)
+
+		// Act
+		err := adapter.DeleteByUserAndFeed(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("DeleteByUserAndFeed() error = %v", err)
+		}
+		if len(recorder.records) != 1 {
+			t.Fatalf("records = %d, want 1", len(recorder.records))
+		}
+		got := recorder.records[0]
+		if got.userID != "user-1" || got.action != model.AuditActionSubscriptionDelete || got.target != "feed-1" {
+			t.Errorf("record = %+v, want user-1 / %s / feed-1", got, model.AuditActionSubscriptionDelete)
+		}
+		if got.payload["subscription_id"] != "sub-1" {
+			t.Errorf("payload subscription_id = %v, want sub-1", got.payload["subscription_id"])
+		}
+	})
+
+	t.Run("購読が存在しないとき監査ログに記録しない", func(t *testing.T) {
+		// Arrange
+		recorder := &mockDeleterAuditRecorder{}
+		adapter := NewSubscriptionDeleterAdapter(&stubDeleterSubRepo{}, &stubDeleterItemStateRepo{}, recorder)
+
+		// Act
+		err := adapter.DeleteByUserAndFeed(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("DeleteByUserAndFeed() error = %v", err)
+		}
+		if len(recorder.records) != 0 {
+			t.Errorf("records = %+v, want none", recorder.records)
+		}
+	})
+
+	t.Run("購読の削除に失敗したとき監査ログに記録しない", func(t *testing.T) {
+		// Arrange
+		subRepo := &stubDeleterSubRepo{sub: &model.Subscription{ID: "sub-1"}, deleteErr: errors.New("db down")}
+		recorder := &mockDeleterAuditRecorder{}
+		adapter := NewSubscriptionDeleterAdapter(subRepo, &stubDeleterItemStateRepo{}, recorder)
+
+		// Act
+		err := adapter.DeleteByUserAndFeed(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err == nil {
+			t.Fatal("DeleteByUserAndFeed() error = nil, want error")
+		}
+		if len(recorder.records) != 0 {
+			t.Errorf("records = %+v, want none", recorder.records)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// StarredItemNotifier はスターを付けた記事の通知先。
+// 「あとで読む」サービスへの保存キュー（repository.ReadLaterDeliveryRepository）が実装する。
+type StarredItemNotifier interface {
+	EnqueueStarredItem(ctx context.Context, userID, itemID string) (int, error)
+}
+
+// starNotifyingItemStateService は記事状態の更新でスターが付いたときに StarredItemNotifier へ通知する
+// ItemStateServiceInterface のデコレータ。
+type starNotifyingItemStateService struct {
+	inner    ItemStateServiceInterface
+	notifier StarredItemNotifier
+}
+
+// NewStarNotifyingItemStateService は inner の更新結果がスター付きのとき notifier へ通知するデコレータを返す。
+// スター・評価を指定しない更新（既読化など）では通知しない。通知の失敗は状態更新の結果に影響させずログに残す。
+func NewStarNotifyingItemStateService(inner ItemStateServiceInterface, notifier StarredItemNotifier) ItemStateServiceInterface {
+	return &starNotifyingItemStateService{inner: inner, notifier: notifier}
+}
+
+// UpdateState は記事状態を更新し、スター付きになった場合に通知する。
+func (s *starNotifyingItemStateService) UpdateState(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
+	state, err := s.inner.UpdateState(ctx, userID, itemID, isRead, isStarred, isHidden, rating)
+	if err != nil {
+		return nil, err
+	}
+	if (isStarred != nil || rating != nil) && state != nil && state.IsStarred {
+		notifyStarredItem(ctx, s.notifier, userID, itemID)
+	}
+	return state, nil
+}
+
+// starNotifyingSyncService はオフライン同期でスターを付ける操作を適用したときに StarredItemNotifier へ通知する
+// SyncServiceInterface のデコレータ。
+type starNotifyingSyncService struct {
+	inner    SyncServiceInterface
+	notifier StarredItemNotifier
+}
+
+// NewStarNotifyingSyncService は inner が適用したスター操作のうち、適用後にスター付きの記事を notifier へ通知するデコレータを返す。
+func NewStarNotifyingSyncService(inner SyncServiceInterface, notifier StarredItemNotifier) SyncServiceInterface {
+	return &starNotifyingSyncService{inner: inner, notifier: notifier}
+}
+
+// ApplyOperations は操作列を適用し、スターを付けた記事を通知する。
+func (s *starNotifyingSyncService) ApplyOperations(ctx context.Context, userID string, ops []model.SyncOperation) (*syncOperationsResponse, error) {
+	resp, err := s.inner.ApplyOperations(ctx, userID, ops)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range resp.Results {
+		if result.Type == string(model.SyncOperationStar) && result.Status == string(model.SyncStatusApplied) &&
+			result.IsStarred != nil && *result.IsStarred {
+			notifyStarredItem(ctx, s.notifier, userID, result.ItemID)
+		}
+	}
+	return resp, nil
+}
+
+// notifyStarredItem はスターを付けた記事を通知する。失敗はログに残すのみとする。
+func notifyStarredItem(ctx context.Context, notifier StarredItemNotifier, userID, itemID string) {
+	if _, err := notifier.EnqueueStarredItem(ctx, userID, itemID); err != nil {
+		slog.Warn("スター記事の保存キューへの投入に失敗しました",
+			slog.String("user_id", userID),
+			slog.String("item_id", itemID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
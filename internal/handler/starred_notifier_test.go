@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockStarredItemNotifier は StarredItemNotifier のモック実装。
+type mockStarredItemNotifier struct {
+	itemIDs []string
+	err     error
+}
+
+func (m *mockStarredItemNotifier) EnqueueStarredItem(_ context.Context, _, itemID string) (int, error) {
+	m.itemIDs = append(m.itemIDs, itemID)
+	return 1, m.err
+}
+
+// stubItemStateService は指定した状態を返す ItemStateServiceInterface のスタブ。
+type stubItemStateService struct {
+	state *model.ItemState
+	err   error
+}
+
+func (s *stubItemStateService) UpdateState(context.Context, string, string, *bool, *bool, *bool, *int) (*model.ItemState, error) {
+	return s.state, s.err
+}
+
+// stubSyncService は指定した結果を返す SyncServiceInterface のスタブ。
+type stubSyncService struct {
+	resp *syncOperationsResponse
+}
+
+func (s *stubSyncService) ApplyOperations(context.Context, string, []model.SyncOperation) (*syncOperationsResponse, error) {
+	return s.resp, nil
+}
+
+func TestStarNotifyingItemStateService(t *testing.T) {
+	starred := true
+	rating := 3
+	read := true
+
+	tests := []struct {
+		name      string
+		state     *model.ItemState
+		isRead    *bool
+		isStarred *bool
+		rating    *int
+		want      int
+	}{
+		{name: "スターを付けた", state: &model.ItemState{IsStarred: true}, isStarred: &starred, want: 1},
+		{name: "評価を付けた", state: &model.ItemState{IsStarred: true, Rating: 3}, rating: &rating, want: 1},
+		{name: "スター付きの記事を既読にした", state: &model.ItemState{IsStarred: true}, isRead: &read, want: 0},
+		{name: "スターを外した", state: &model.ItemState{IsStarred: false}, isStarred: new(bool), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"とき通知の有無が期待どおりになる", func(t *testing.T) {
+			// Arrange
+			notifier := &mockStarredItemNotifier{}
+			svc := NewStarNotifyingItemStateService(&stubItemStateService{state: tt.state}, notifier)
+
+			// Act
+			_, err := svc.UpdateState(context.Background(), "user-1", "item-1", tt.isRead, tt.isStarred, nil, tt.rating)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("UpdateState() error = %v", err)
+			}
+			if len(notifier.itemIDs) != tt.want {
+				t.Errorf("notified = %v, want %d", notifier.itemIDs, tt.want)
+			}
+		})
+	}
+
+	t.Run("通知に失敗しても更新結果を返す", func(t *testing.T) {
+		notifier := &mockStarredItemNotifier{err: errors.New("db down")}
+		svc := NewStarNotifyingItemStateService(&stubItemStateService{state: &model.ItemState{IsStarred: true}}, notifier)
+
+		state, err := svc.UpdateState(context.Background(), "user-1", "item-1", nil, &starred, nil, nil)
+
+		if err != nil || state == nil || !state.IsStarred {
+			t.Errorf("UpdateState() = (%+v, %v), want starred state", state, err)
+		}
+	})
+
+	t.Run("更新に失敗したとき通知しない", func(t *testing.T) {
+		notifier := &mockStarredItemNotifier{}
+		svc := NewStarNotifyingItemStateService(&stubItemStateService{err: model.NewItemNotFoundError("item-1")}, notifier)
+
+		_, err := svc.UpdateState(context.Background(), "user-1", "item-1", nil, &starred, nil, nil)
+
+		if err == nil || len(notifier.itemIDs) != 0 {
+			t.Errorf("err = %v, notified = %v, want error without notification", err, notifier.itemIDs)
+		}
+	})
+}
+
+func TestStarNotifyingSyncService(t *testing.T) {
+	t.Run("適用したスター操作のうちスター付きの記事だけを通知する", func(t *testing.T) {
+		// Arrange
+		on, off := true, false
+		notifier := &mockStarredItemNotifier{}
+		svc := NewStarNotifyingSyncService(&stubSyncService{resp: &syncOperationsResponse{Results: []syncOperationResultResponse{
+			{Type: "star", ItemID: "item-1", Status: "applied", IsStarred: &on},
+			{Type: "star", ItemID: "item-2", Status: "stale", IsStarred: &on},
+			{Type: "star", ItemID: "item-3", Status: "applied", IsStarred: &off},
+			{Type: "read", ItemID: "item-4", Status: "applied", IsStarred: &on},
+			{Type: "star", ItemID: "item-5", Status: "failed"},
+		}}}, notifier)
+
+		// Act
+		_, err := svc.ApplyOperations(context.Background(), "user-1", nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ApplyOperations() error = %v", err)
+		}
+		if len(notifier.itemIDs) != 1 || notifier.itemIDs[0] != "item-1" {
+			t.Errorf("notified = %v, want [item-1]", notifier.itemIDs)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+// Package handler の stats_handler.go は、閲覧統計の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/stats/top-feeds?period=30d&limit=10 : 自分がよく読むフィードのランキング
+//   - GET /api/stats/weekly?weeks=12 : 新着数・未読消化数の週次トレンド
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// StatsServiceInterface は閲覧統計ハンドラが必要とするサービスインターフェース。
+type StatsServiceInterface interface {
+	// TopFeeds は period（「30d」形式、空なら既定）内にユーザーがよく読んだフィードを返す。
+	// limit が 0 の場合は既定件数とする。
+	TopFeeds(ctx context.Context, userID, period string, limit int) (*topFeedsResponse, error)
+	// WeeklyTrend は直近 weeks 週の新着数・未読消化数の推移を返す。weeks が 0 の場合は既定の週数とする。
+	WeeklyTrend(ctx context.Context, userID string, weeks int) (*weeklyTrendResponse, error)
+}
+
+// StatsHandler は閲覧統計の HTTP ハンドラ。
+type StatsHandler struct {
+	service StatsServiceInterface
+}
+
+// NewStatsHandler は StatsHandler を生成する。
+func NewStatsHandler(service StatsServiceInterface) *StatsHandler {
+	return &StatsHandler{service: service}
+}
+
+// topFeedResponse は「よく読むフィード」ランキングの 1 件。
+type topFeedResponse struct {
+	FeedID       string    `json:"feed_id"`
+	FeedTitle    string    `json:"feed_title"`
+	ViewCount    int       `json:"view_count"`
+	ItemCount    int       `json:"item_count"`
+	LastViewedAt time.Time `json:"last_viewed_at"`
+}
+
+// topFeedsResponse は GET /api/stats/top-feeds のレスポンス。
+type topFeedsResponse struct {
+	PeriodDays int               `json:"period_days"`
+	Since      time.Time         `json:"since"`
+	Feeds      []topFeedResponse `json:"feeds"`
+}
+
+// weeklyFeedStatResponse は週次トレンドのフィード別内訳の 1 件。
+type weeklyFeedStatResponse struct {
+	FeedID      string `json:"feed_id"`
+	FeedTitle   string `json:"feed_title"`
+	NewItems    int    `json:"new_items"`
+	ReadItems   int    `json:"read_items"`
+	HiddenItems int    `json:"hidden_items"`
+}
+
+// weeklyTrendPointResponse は週次トレンドの 1 週分。
+type weeklyTrendPointResponse struct {
+	WeekStart   time.Time                `json:"week_start"`
+	NewItems    int                      `json:"new_items"`
+	ReadItems   int                      `json:"read_items"`
+	HiddenItems int                      `json:"hidden_items"`
+	Feeds       []weeklyFeedStatResponse `json:"feeds"`
+}
+
+// weeklyTrendResponse は GET /api/stats/weekly のレスポンス。
+type weeklyTrendResponse struct {
+	Weeks  int                        `json:"weeks"`
+	Since  time.Time                  `json:"since"`
+	Series []weeklyTrendPointResponse `json:"series"`
+}
+
+// TopFeeds は自分がよく読むフィードのランキングを返す。
+// GET /api/stats/top-feeds?period=30d&limit=10
+//
+// 記事詳細（GET /api/items/{id}）の閲覧回数を集計し、多い順に返す。
+// period が不正な場合は 400 INVALID_STATS_PERIOD、limit が不正な場合は 400 INVALID_REQUEST を返す。
+func (h *StatsHandler) TopFeeds(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	q := r.URL.Query()
+	// 未指定の場合はランキングの既定件数をサービス層に任せ、指定時のみ一覧 API 共通の上限で丸める
+	limit := 0
+	if q.Get("limit") != "" {
+		if limit, err = parsePageLimit(r); err != nil {
+			WriteError(w, err)
+			return
+		}
+	}
+
+	resp, err := h.service.TopFeeds(r.Context(), userID, q.Get("period"), limit)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// WeeklyTrend は新着数・未読消化数の週次トレンドを返す。
+// GET /api/stats/weekly?weeks=12
+//
+// 週は月曜 00:00 UTC 始まりで、集計中の今週を除く直近 weeks 週を古い順に返す。
+// weeks は最大 52 に丸め、形式が不正な場合は 400 INVALID_REQUEST を返す。
+func (h *StatsHandler) WeeklyTrend(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	weeks := 0
+	if weeksStr := r.URL.Query().Get("weeks"); weeksStr != "" {
+		n, parseErr := strconv.Atoi(weeksStr)
+		if parseErr != nil || n <= 0 {
+			WriteError(w, &model.APIError{
+				Code:     model.ErrCodeInvalidRequest,
+				Message:  "weeks の形式が不正です。",
+				Category: "validation",
+				Action:   "1 以上の整数を指定してください。",
+			})
+			return
+		}
+		weeks = n
+	}
+
+	resp, err := h.service.WeeklyTrend(r.Context(), userID, weeks)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockStatsService は StatsServiceInterface のモック実装。
+type mockStatsService struct {
+	topFeedsFn    func(ctx context.Context, userID, period string, limit int) (*topFeedsResponse, error)
+	topFeedsCalls int
+	weeklyFn      func(ctx context.Context, userID string, weeks int) (*weeklyTrendResponse, error)
+	weeklyCalls   int
+}
+
+func (m *mockStatsService) TopFeeds(ctx context.Context, userID, period string, limit int) (*topFeedsResponse, error) {
+	m.topFeedsCalls++
+	if m.topFeedsFn != nil {
+		return m.topFeedsFn(ctx, userID, period, limit)
+	}
+	return &topFeedsResponse{PeriodDays: 30, Feeds: []topFeedResponse{}}, nil
+}
+
+func (m *mockStatsService) WeeklyTrend(ctx context.Context, userID string, weeks int) (*weeklyTrendResponse, error) {
+	m.weeklyCalls++
+	if m.weeklyFn != nil {
+		return m.weeklyFn(ctx, userID, weeks)
+	}
+	return &weeklyTrendResponse{Weeks: 12, Series: []weeklyTrendPointResponse{}}, nil
+}
+
+// --- GET /api/stats/top-feeds テスト ---
+
+func TestStatsHandler_TopFeeds(t *testing.T) {
+	t.Run("periodとlimitを指定したときランキングを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockStatsService{
+			topFeedsFn: func(_ context.Context, userID, period string, limit int) (*topFeedsResponse, error) {
+				if userID != "user-1" || period != "7d" || limit != 3 {
+					t.Errorf("args = (%q, %q, %d), want (user-1, 7d, 3)", userID, period, limit)
+				}
+				return &topFeedsResponse{
+					PeriodDays: 7,
+					Since:      time.Date(2025, 5, 25, 12, 0, 0, 0, time.UTC),
+					Feeds: []topFeedResponse{
+						{FeedID: "feed-1", FeedTitle: "Feed 1", ViewCount: 12, ItemCount: 8, LastViewedAt: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)},
+					},
+				}, nil
+			},
+		}
+		h := NewStatsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/stats/top-feeds?period=7d&limit=3", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.TopFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["period_days"] != float64(7) || body["since"] != "2025-05-25T12:00:00Z" {
+			t.Errorf("body = %v", body)
+		}
+		feeds, ok := body["feeds"].([]interface{})
+		if !ok || len(feeds) != 1 {
+			t.Fatalf("feeds = %v, want 1 element", body["feeds"])
+		}
+		feed := feeds[0].(map[string]interface{})
+		if feed["feed_id"] != "feed-1" || feed["view_count"] != float64(12) || feed["item_count"] != float64(8) {
+			t.Errorf("feed = %v", feed)
+		}
+	})
+
+	t.Run("limitが不正なとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		for _, limit := range []string{"abc", "0", "-1"} {
+			// Arrange
+			svc := &mockStatsService{}
+			h := NewStatsHandler(svc)
+			req := withUserID(httptest.NewRequest(http.MethodGet, "/api/stats/top-feeds?limit="+limit, nil), "user-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.TopFeeds(w, req)
+
+			// Assert
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("limit=%q: status = %d, want %d", limit, w.Code, http.StatusBadRequest)
+			}
+			if svc.topFeedsCalls != 0 {
+				t.Errorf("limit=%q: TopFeeds calls = %d, want 0", limit, svc.topFeedsCalls)
+			}
+		}
+	})
+
+	t.Run("periodが不正なとき400 INVALID_STATS_PERIODを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockStatsService{
+			topFeedsFn: func(_ context.Context, _, period string, _ int) (*topFeedsResponse, error) {
+				return nil, model.NewInvalidStatsPeriodError(period)
+			},
+		}
+		h := NewStatsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/stats/top-feeds?period=1y", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.TopFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidStatsPeriod {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidStatsPeriod)
+		}
+	})
+
+	t.Run("ユーザーIDがないとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewStatsHandler(&mockStatsService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/top-feeds", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.TopFeeds(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- GET /api/stats/weekly テスト ---
+
+func TestStatsHandler_WeeklyTrend(t *testing.T) {
+	t.Run("weeksを指定したとき週次トレンドを返す", func(t *testing.T) {
+		// Arrange
+		week := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+		svc := &mockStatsService{
+			weeklyFn: func(_ context.Context, userID string, weeks int) (*weeklyTrendResponse, error) {
+				if userID != "user-1" || weeks != 4 {
+					t.Errorf("args = (%q, %d), want (user-1, 4)", userID, weeks)
+				}
+				return &weeklyTrendResponse{
+					Weeks: 4,
+					Since: week.AddDate(0, 0, -21),
+					Series: []weeklyTrendPointResponse{
+						{WeekStart: week, NewItems: 15, ReadItems: 9, Feeds: []weeklyFeedStatResponse{
+							{FeedID: "feed-1", FeedTitle: "Feed 1", NewItems: 15, ReadItems: 9},
+						}},
+					},
+				}, nil
+			},
+		}
+		h := NewStatsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/stats/weekly?weeks=4", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.WeeklyTrend(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["weeks"] != float64(4) || body["since"] != "2026-05-25T00:00:00Z" {
+			t.Errorf("body = %v", body)
+		}
+		series, ok := body["series"].([]interface{})
+		if !ok || len(series) != 1 {
+			t.Fatalf("series = %v, want 1 element", body["series"])
+		}
+		point := series[0].(map[string]interface{})
+		if point["week_start"] != "2026-06-15T00:00:00Z" || point["new_items"] != float64(15) || point["read_items"] != float64(9) {
+			t.Errorf("point = %v", point)
+		}
+	})
+
+	t.Run("weeksが未指定のとき0を渡す", func(t *testing.T) {
+		// Arrange
+		svc := &mockStatsService{
+			weeklyFn: func(_ context.Context, _ string, weeks int) (*weeklyTrendResponse, error) {
+				if weeks != 0 {
+					t.Errorf("weeks = %d, want 0", weeks)
+				}
+				return &weeklyTrendResponse{Series: []weeklyTrendPointResponse{}}, nil
+			},
+		}
+		h := NewStatsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/stats/weekly", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.WeeklyTrend(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("weeksが不正なとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		for _, weeks := range []string{"abc", "0", "-1"} {
+			// Arrange
+			svc := &mockStatsService{}
+			h := NewStatsHandler(svc)
+			req := withUserID(httptest.NewRequest(http.MethodGet, "/api/stats/weekly?weeks="+weeks, nil), "user-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.WeeklyTrend(w, req)
+
+			// Assert
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("weeks=%q: status = %d, want %d", weeks, w.Code, http.StatusBadRequest)
+			}
+			if svc.weeklyCalls != 0 {
+				t.Errorf("weeks=%q: WeeklyTrend calls = %d, want 0", weeks, svc.weeklyCalls)
+			}
+		}
+	})
+
+	t.Run("ユーザーIDがないとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewStatsHandler(&mockStatsService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/weekly", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.WeeklyTrend(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_StatsRoutes は閲覧統計ルートが認証必須で、StatsService 未配線時は登録されないことを検証する。
+func TestNewRouter_StatsRoutes(t *testing.T) {
+	newRouter := func(svc StatsServiceInterface) http.Handler {
+		deps := &RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+		}
+		if svc != nil {
+			deps.StatsService = svc
+		}
+		return NewRouter(deps)
+	}
+	doRequest := func(router http.Handler, withSession bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/top-feeds?period=30d", nil)
+		if withSession {
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("セッションありのとき200を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockStatsService{}
+		router := newRouter(svc)
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.topFeedsCalls != 1 {
+			t.Errorf("TopFeeds calls = %d, want 1", svc.topFeedsCalls)
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newRouter(&mockStatsService{})
+
+		// Act
+		w := doRequest(router, false)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("StatsService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil)
+
+		// Act
+		w := doRequest(router, true)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+// Package handler の subscribe_handler.go は、ブラウザ拡張・ブックマークレットから
+// 購読追加画面へ誘導するエンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /subscribe?url=... : ログイン済みならフロントエンドの購読確認画面へリダイレクトする。
+//     未ログインなら URL を一時 Cookie に退避してログインへ誘導し、ログイン完了後に確認画面へ戻す。
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+const (
+	// pendingSubscribeCookie はログイン完了後に購読確認画面へ戻すための URL を退避する Cookie 名。
+	pendingSubscribeCookie = "pending_subscribe_url"
+	// pendingSubscribeMaxAge は pendingSubscribeCookie の有効期間（秒）。OAuth の state と揃える。
+	pendingSubscribeMaxAge = 600
+	// maxSubscribeURLLength は /subscribe で受け付ける URL の最大長。
+	maxSubscribeURLLength = 2048
+	// subscribeQueryParam はフロントエンドに購読確認を指示するクエリパラメータ名。
+	subscribeQueryParam = "subscribe"
+)
+
+// Subscribe はブラウザ拡張・ブックマークレットからの購読追加リクエストを受け付ける。
+// GET /subscribe?url=...
+//
+// フィード登録自体は行わず、フロントエンドの購読確認画面（BaseURL/?subscribe=...）へ誘導する。
+// GET で副作用を起こさないことで、外部サイトからのリンク踏ませによる意図しない購読を防ぐ。
+func (h *AuthHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	target, apiErr := validateSubscribeURL(r.URL.Query().Get("url"))
+	if apiErr != nil {
+		WriteError(w, apiErr)
+		return
+	}
+
+	if h.isLoggedIn(r) {
+		http.Redirect(w, r, subscribeConfirmURL(h.config.BaseURL, target), http.StatusTemporaryRedirect)
+		return
+	}
+
+	// 未ログイン: URL を退避してログインへ。Callback が Cookie を見て確認画面へ戻す。
+	http.SetCookie(w, &http.Cookie{
+		Name:     pendingSubscribeCookie,
+		Value:    url.QueryEscape(target),
+		Path:     "/",
+		MaxAge:   pendingSubscribeMaxAge,
+		HttpOnly: true,
+		Secure:   h.config.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/auth/google/login", http.StatusTemporaryRedirect)
+}
+
+// isLoggedIn はセッション Cookie が有効なユーザーに紐付くかを判定する。
+// セッションの検証に失敗した場合は未ログインとして扱う。
+func (h *AuthHandler) isLoggedIn(r *http.Request) bool {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	user, err := h.service.GetCurrentUser(r.Context(), cookie.Value)
+	return err == nil && user != nil
+}
+
+// popPendingSubscribeURL はログイン前に退避した購読対象 URL を取り出し、Cookie を削除する。
+// 退避されていない、または値が不正な場合は空文字を返す。
+func (h *AuthHandler) popPendingSubscribeURL(w http.ResponseWriter, r *http.Request) string {
+	cookie, err := r.Cookie(pendingSubscribeCookie)
+	if err != nil || cookie.Value == "" {
+		return ""
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     pendingSubscribeCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.config.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	raw, err := url.QueryUnescape(cookie.Value)
+	if err != nil {
+		return ""
+	}
+	target, apiErr := validateSubscribeURL(raw)
+	if apiErr != nil {
+		return ""
+	}
+	return target
+}
+
+// validateSubscribeURL は購読対象として渡された URL を検証する。
+// http / https の絶対 URL のみ受け付ける（javascript: 等をフロントエンドへ渡さないため）。
+func validateSubscribeURL(raw string) (string, *model.APIError) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", model.NewInvalidURLError("url パラメータが指定されていません")
+	}
+	if len(raw) > maxSubscribeURLLength {
+		return "", model.NewInvalidURLError("URL が長すぎます")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", model.NewInvalidURLError(raw)
+	}
+	return u.String(), nil
+}
+
+// subscribeConfirmURL はフロントエンドの購読確認画面の URL を組み立てる。
+func subscribeConfirmURL(baseURL, target string) string {
+	q := url.Values{}
+	q.Set(subscribeQueryParam, target)
+	return strings.TrimRight(baseURL, "/") + "/?" + q.Encode()
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func newSubscribeTestHandler(loggedIn bool) *AuthHandler {
+	svc := &mockAuthService{
+		getCurrentUserFn: func(_ context.Context, sessionID string) (*model.User, error) {
+			if loggedIn && sessionID == "valid-session" {
+				return &model.User{ID: "user-1"}, nil
+			}
+			return nil, errors.New("session not found")
+		},
+		handleCallbackFn: func(_ context.Context, _ string) (*model.Session, error) {
+			return &model.Session{ID: "new-session", UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+	}
+	return NewAuthHandler(svc, AuthHandlerConfig{BaseURL: "http://localhost:3000/", SessionMaxAge: 86400})
+}
+
+func findCookie(resp *http.Response, name string) *http.Cookie {
+	for _, c := range resp.Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestAuthHandler_Subscribe(t *testing.T) {
+	const target = "https://example.com/blog?a=1&b=2"
+
+	t.Run("ログイン済みのとき購読確認画面へリダイレクトする", func(t *testing.T) {
+		// Arrange
+		h := newSubscribeTestHandler(true)
+		req := httptest.NewRequest(http.MethodGet, "/subscribe?url="+url.QueryEscape(target), nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Subscribe(w, req)
+
+		// Assert
+		resp := w.Result()
+		if resp.StatusCode != http.StatusTemporaryRedirect {
+			t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusTemporaryRedirect)
+		}
+		want := "http://localhost:3000/?subscribe=" + url.QueryEscape(target)
+		if got := resp.Header.Get("Location"); got != want {
+			t.Errorf("Location = %q, want %q", got, want)
+		}
+		if findCookie(resp, pendingSubscribeCookie) != nil {
+			t.Error("ログイン済みのとき退避用 Cookie を設定すべきでない")
+		}
+	})
+
+	t.Run("未ログインのときURLをCookieに退避してログインへリダイレクトする", func(t *testing.T) {
+		// Arrange
+		h := newSubscribeTestHandler(false)
+		req := httptest.NewRequest(http.MethodGet, "/subscribe?url="+url.QueryEscape(target), nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Subscribe(w, req)
+
+		// Assert
+		resp := w.Result()
+		if got := resp.Header.Get("Location"); got != "/auth/google/login" {
+			t.Errorf("Location = %q, want %q", got, "/auth/google/login")
+		}
+		c := findCookie(resp, pendingSubscribeCookie)
+		if c == nil {
+			t.Fatal("退避用 Cookie が設定されるべき")
+		}
+		if !c.HttpOnly || c.MaxAge != pendingSubscribeMaxAge {
+			t.Errorf("cookie = %+v, want HttpOnly and MaxAge=%d", c, pendingSubscribeMaxAge)
+		}
+	})
+
+	t.Run("不正なURLのとき400 INVALID_URLを返す", func(t *testing.T) {
+		cases := []string{"", "javascript:alert(1)", "ftp://example.com/feed", "/relative/path"}
+		for _, raw := range cases {
+			// Arrange
+			h := newSubscribeTestHandler(true)
+			req := httptest.NewRequest(http.MethodGet, "/subscribe?url="+url.QueryEscape(raw), nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			h.Subscribe(w, req)
+
+			// Assert
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("url=%q: status = %d, want %d", raw, w.Code, http.StatusBadRequest)
+			}
+			if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidURL {
+				t.Errorf("url=%q: code = %q, want %q", raw, resp["code"], model.ErrCodeInvalidURL)
+			}
+		}
+	})
+}
+
+func TestAuthHandler_Callback_PendingSubscribe(t *testing.T) {
+	t.Run("退避されたURLがあるときログイン後に購読確認画面へリダイレクトしCookieを削除する", func(t *testing.T) {
+		// Arrange
+		h := newSubscribeTestHandler(false)
+		req := httptest.NewRequest(http.MethodGet, "/auth/google/callback?code=test-code&state=test-state", nil)
+		req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "test-state"})
+		req.AddCookie(&http.Cookie{Name: pendingSubscribeCookie, Value: url.QueryEscape("https://example.com/")})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Callback(w, req)
+
+		// Assert
+		resp := w.Result()
+		want := "http://localhost:3000/?subscribe=" + url.QueryEscape("https://example.com/")
+		if got := resp.Header.Get("Location"); got != want {
+			t.Errorf("Location = %q, want %q", got, want)
+		}
+		c := findCookie(resp, pendingSubscribeCookie)
+		if c == nil || c.MaxAge >= 0 {
+			t.Errorf("退避用 Cookie は削除されるべき: %+v", c)
+		}
+	})
+
+	t.Run("退避されたURLが不正なときBaseURLへリダイレクトする", func(t *testing.T) {
+		// Arrange
+		h := newSubscribeTestHandler(false)
+		req := httptest.NewRequest(http.MethodGet, "/auth/google/callback?code=test-code&state=test-state", nil)
+		req.AddCookie(&http.Cookie{Name: "oauth_state", Value: "test-state"})
+		req.AddCookie(&http.Cookie{Name: pendingSubscribeCookie, Value: url.QueryEscape("javascript:alert(1)")})
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Callback(w, req)
+
+		// Assert
+		if got := w.Result().Header.Get("Location"); got != "http://localhost:3000/" {
+			t.Errorf("Location = %q, want %q", got, "http://localhost:3000/")
+		}
+	})
+}
+
+// TestNewRouter_SubscribeRoute は /subscribe がセッションなしで到達できることを検証する。
+func TestNewRouter_SubscribeRoute(t *testing.T) {
+	// Arrange
+	router := NewRouter(&RouterDeps{
+		SessionFinder:       &mockSessionFinderForRouter{sessions: map[string]*model.Session{}},
+		CORSAllowedOrigin:   "http://localhost:3000",
+		RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+		AuthService:         &mockAuthService{},
+		AuthConfig:          AuthHandlerConfig{BaseURL: "http://localhost:3000"},
+		FeedService:         &mockFeedService{},
+		ItemService:         &mockItemService{},
+		SubscriptionService: &mockSubscriptionService{},
+		UserService:         &mockUserService{},
+	})
+	req := httptest.NewRequest(http.MethodGet, "/subscribe?url="+url.QueryEscape("https://example.com/"), nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusTemporaryRedirect)
+	}
+}
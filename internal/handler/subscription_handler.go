@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -15,6 +16,12 @@ import (
 type SubscriptionServiceInterface interface {
 	// ListSubscriptions はユーザーの購読一覧を返す。
 	ListSubscriptions(ctx context.Context, userID string) ([]subscriptionResponse, error)
+	// GetSubscription はユーザーの購読 1 件を推定次回更新時刻付きで返す。
+	// 他ユーザーの購読や存在しない購読は SUBSCRIPTION_NOT_FOUND を返す。
+	GetSubscription(ctx context.Context, userID, subscriptionID string) (*subscriptionDetailResponse, error)
+	// ListModTime はユーザーの購読一覧の最終更新日時を返す（Last-Modified / If-Modified-Since 用）。
+	// 判定できない場合はゼロ値を返す。
+	ListModTime(ctx context.Context, userID string) (time.Time, error)
 	// UpdateSettings は購読のフェッチ間隔を更新する。
 	UpdateSettings(ctx context.Context, userID, subscriptionID string, minutes int) (*subscriptionResponse, error)
 	// Unsubscribe は購読を解除する（subscription + 関連item_statesを削除）。
@@ -24,6 +31,17 @@ type SubscriptionServiceInterface interface {
 	// ManualFetch は指定購読のフィードを手動で同期フェッチする（Issue #115）。
 	// クールダウン中は FEED_COOLDOWN、行ロック競合時は FEED_FETCH_IN_PROGRESS を返す。
 	ManualFetch(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
+	// Restore は猶予期間内に解除した購読を記事状態ごと元に戻す。
+	// 期限切れは SUBSCRIPTION_RESTORE_EXPIRED、再購読済みは DUPLICATE_SUBSCRIPTION を返す。
+	Restore(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
+	// UpdateOrder は購読のピン留めと並び順を一括更新し、更新後の購読一覧を返す。
+	// 指定が不正な場合は INVALID_SUBSCRIPTION_ORDER、未知の購読を含む場合は SUBSCRIPTION_NOT_FOUND を返す。
+	UpdateOrder(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) ([]subscriptionResponse, error)
+	// KeepSubscription はお試し購読の期限を外して通常の購読にし、更新後の購読情報を返す。
+	KeepSubscription(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
+	// ApplySuggestedFeedURL はフェッチ停止中のフィードの URL を提案された URL に張り替えてフェッチを再開する。
+	// 提案がない場合は FEED_URL_SUGGESTION_NOT_FOUND、張り替え先が登録済みの場合は FEED_URL_TAKEN を返す。
+	ApplySuggestedFeedURL(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
 }
 
 // SubscriptionHandler は購読管理のHTTPハンドラー。
@@ -40,17 +58,42 @@ func NewSubscriptionHandler(service SubscriptionServiceInterface) *SubscriptionH
 
 // subscriptionResponse は購読情報のAPIレスポンス。
 type subscriptionResponse struct {
-	ID                   string    `json:"id"`
-	UserID               string    `json:"user_id"`
-	FeedID               string    `json:"feed_id"`
-	FeedTitle            string    `json:"feed_title"`
-	FeedURL              string    `json:"feed_url"`
-	FaviconURL           *string   `json:"favicon_url,omitempty"`
-	FetchIntervalMinutes int       `json:"fetch_interval_minutes"`
-	FeedStatus           string    `json:"feed_status"`
-	ErrorMessage         *string   `json:"error_message,omitempty"`
-	UnreadCount          int       `json:"unread_count"`
-	CreatedAt            time.Time `json:"created_at"`
+	ID                   string     `json:"id"`
+	UserID               string     `json:"user_id"`
+	FeedID               string     `json:"feed_id"`
+	FeedTitle            string     `json:"feed_title"`
+	FeedURL              string     `json:"feed_url"`
+	FaviconURL           *string    `json:"favicon_url"`
+	FetchIntervalMinutes int        `json:"fetch_interval_minutes"`
+	FeedStatus           string     `json:"feed_status"`
+	ErrorMessage         *string    `json:"error_message"`
+	ErrorKind            *string    `json:"error_kind"`
+	UnreadCount          int        `json:"unread_count"`
+	TooManyUnread        bool       `json:"too_many_unread"`
+	FeedLanguage         string     `json:"feed_language"`
+	FeedDescription      string     `json:"feed_description"`
+	FeedLastPublishedAt  *time.Time `json:"feed_last_published_at"`
+	IsPinned             bool       `json:"is_pinned"`
+	SortOrder            int        `json:"sort_order"`
+	// Priority と MuteUntil はクライアントが通知の重み付けに使うヒント。ミュートしていない場合の mute_until は null。
+	Priority  string     `json:"priority"`
+	MuteUntil *time.Time `json:"mute_until"`
+	// ExpiresAt はお試し購読の期限。通常の購読では null。
+	ExpiresAt *time.Time `json:"expires_at"`
+	// SuggestedFeedURL はフェッチ停止中のフィードに対してサイトから再検出した新しいフィード URL。提案がない場合は null。
+	SuggestedFeedURL *string `json:"suggested_feed_url"`
+	// FeedTitleUpdatePolicy はフィードタイトルの自動更新ポリシー（always / initial / manual）。
+	// PendingFeedTitle は manual のときに検知した承認待ちの新しいタイトル。ない場合は null。
+	FeedTitleUpdatePolicy string    `json:"feed_title_update_policy"`
+	PendingFeedTitle      *string   `json:"pending_feed_title"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// subscriptionDetailResponse は購読詳細の API レスポンス。購読一覧の項目に推定値を加える。
+type subscriptionDetailResponse struct {
+	subscriptionResponse
+	// EstimatedNextPostAt は過去の記事の公開日時の分布から推定した次回更新時刻。記事が少なく推定できない場合は null。
+	EstimatedNextPostAt *time.Time `json:"estimated_next_post_at"`
 }
 
 // subscriptionSettingsRequest はフェッチ間隔設定更新リクエストのボディ。
@@ -58,13 +101,25 @@ type subscriptionSettingsRequest struct {
 	FetchIntervalMinutes int `json:"fetch_interval_minutes"`
 }
 
+// subscriptionOrderRequest は購読の並び順一括更新リクエストのボディ。
+// subscriptions の配列順がそのままサイドバーの並び順になる。
+type subscriptionOrderRequest struct {
+	Subscriptions []subscriptionOrderEntryRequest `json:"subscriptions"`
+}
+
+// subscriptionOrderEntryRequest は並び順一括更新の 1 購読分の指定。is_pinned 省略時はピン留め状態を変更しない。
+type subscriptionOrderEntryRequest struct {
+	ID       string `json:"id"`
+	IsPinned *bool  `json:"is_pinned"`
+}
+
 // ListSubscriptions はユーザーの購読一覧を取得する。
 // GET /api/subscriptions
 func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -72,14 +127,47 @@ func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if modTime, err := h.service.ListModTime(r.Context(), userID); err != nil {
+		// 最終更新日時は条件付き GET のためだけに使うため、取得に失敗しても一覧は返す
+		slog.Warn("購読一覧の最終更新日時の取得に失敗しました",
+			slog.String("error", err.Error()),
+		)
+	} else if checkNotModified(w, r, modTime) {
+		return
+	}
+
 	subs, err := h.service.ListSubscriptions(r.Context(), userID)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(subs)
+	WriteJSON(w, http.StatusOK, subs)
+}
+
+// GetSubscription は購読 1 件の詳細を取得する。
+// GET /api/subscriptions/:id
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "id")
+
+	sub, err := h.service.GetSubscription(r.Context(), userID, subscriptionID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, sub)
 }
 
 // UpdateSettings は購読のフェッチ間隔設定を更新する。
@@ -87,8 +175,8 @@ func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.R
 func (h *SubscriptionHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -100,8 +188,8 @@ func (h *SubscriptionHandler) UpdateSettings(w http.ResponseWriter, r *http.Requ
 
 	var req subscriptionSettingsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		middleware.WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{
-			Code:     "INVALID_REQUEST",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
 			Message:  "リクエストボディの解析に失敗しました。",
 			Category: "validation",
 			Action:   "正しいJSON形式でリクエストしてください。",
@@ -110,15 +198,56 @@ func (h *SubscriptionHandler) UpdateSettings(w http.ResponseWriter, r *http.Requ
 	}
 
 	// フェッチ間隔のバリデーションはサービス層に集約済み。
-	// 不正値はサービスが INVALID_FETCH_INTERVAL を返し handleServiceError 経由で HTTP 400 になる。
+	// 不正値はサービスが INVALID_FETCH_INTERVAL を返し WriteError 経由で HTTP 400 になる。
 	sub, err := h.service.UpdateSettings(r.Context(), userID, subscriptionID, req.FetchIntervalMinutes)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(sub)
+	WriteJSON(w, http.StatusOK, sub)
+}
+
+// UpdateOrder は購読のピン留めとサイドバー並び順を一括更新する。
+// PUT /api/subscriptions/order
+//
+// 指定順に sort_order を振り直し、更新後の購読一覧（ピン留め → 並び順 → タイトル順）を返す。
+// 指定しなかった購読の並び順は変更しない。
+func (h *SubscriptionHandler) UpdateOrder(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req subscriptionOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	entries := make([]model.SubscriptionOrderEntry, len(req.Subscriptions))
+	for i, e := range req.Subscriptions {
+		entries[i] = model.SubscriptionOrderEntry{SubscriptionID: e.ID, IsPinned: e.IsPinned}
+	}
+
+	subs, err := h.service.UpdateOrder(r.Context(), userID, entries)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, subs)
 }
 
 // Unsubscribe は購読を解除する。
@@ -126,8 +255,8 @@ func (h *SubscriptionHandler) UpdateSettings(w http.ResponseWriter, r *http.Requ
 func (h *SubscriptionHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -138,7 +267,7 @@ func (h *SubscriptionHandler) Unsubscribe(w http.ResponseWriter, r *http.Request
 	subscriptionID := chi.URLParam(r, "id")
 
 	if err := h.service.Unsubscribe(r.Context(), userID, subscriptionID); err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
@@ -150,8 +279,8 @@ func (h *SubscriptionHandler) Unsubscribe(w http.ResponseWriter, r *http.Request
 func (h *SubscriptionHandler) ResumeFetch(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -163,12 +292,11 @@ func (h *SubscriptionHandler) ResumeFetch(w http.ResponseWriter, r *http.Request
 
 	sub, err := h.service.ResumeFetch(r.Context(), userID, subscriptionID)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(sub)
+	WriteJSON(w, http.StatusOK, sub)
 }
 
 // ManualFetch は指定購読のフィードを手動で同期フェッチする（Issue #115）。
@@ -176,13 +304,13 @@ func (h *SubscriptionHandler) ResumeFetch(w http.ResponseWriter, r *http.Request
 //
 // リクエストボディは不要（URL の path param のみで完結 / Req 1.7）。
 // 認証失敗時は 401（Req 1.4）、認可失敗時は 404（Req 1.5 / 1.6）を返す。
-// サービス層が返す APIError は handleServiceError 経由で HTTP マッピングされ、
+// サービス層が返す APIError は WriteError 経由で HTTP マッピングされ、
 // クールダウン中は 429（FEED_COOLDOWN）、行ロック競合時は 409（FEED_FETCH_IN_PROGRESS）になる。
 func (h *SubscriptionHandler) ManualFetch(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -194,12 +322,91 @@ func (h *SubscriptionHandler) ManualFetch(w http.ResponseWriter, r *http.Request
 
 	sub, err := h.service.ManualFetch(r.Context(), userID, subscriptionID)
 	if err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, sub)
+}
+
+// Restore は猶予期間内に解除した購読を記事状態ごと元に戻す。
+// POST /api/subscriptions/:id/restore
+//
+// :id には解除前の購読 ID を指定する。復元後の購読は同じ ID で返る。
+// 猶予期間を過ぎている場合は 410（SUBSCRIPTION_RESTORE_EXPIRED）を返す。
+func (h *SubscriptionHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "id")
+
+	sub, err := h.service.Restore(r.Context(), userID, subscriptionID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, sub)
+}
+
+// KeepSubscription はお試し購読の期限を外して通常の購読にする。
+// POST /api/subscriptions/:id/keep
+//
+// 既に通常の購読の場合は何も変えずに現在の購読情報を返す。
+func (h *SubscriptionHandler) KeepSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "id")
+
+	sub, err := h.service.KeepSubscription(r.Context(), userID, subscriptionID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, sub)
+}
+
+// ApplySuggestedFeedURL はフェッチ停止中のフィードの URL を提案された URL に張り替えてフェッチを再開する。
+// POST /api/subscriptions/:id/apply-suggested-url
+func (h *SubscriptionHandler) ApplySuggestedFeedURL(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	subscriptionID := chi.URLParam(r, "id")
+
+	sub, err := h.service.ApplySuggestedFeedURL(r.Context(), userID, subscriptionID)
+	if err != nil {
+		WriteError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(sub)
+	WriteJSON(w, http.StatusOK, sub)
 }
 
 // SetupSubscriptionRoutes は購読管理関連のルーティングを設定したchi.Routerを返す。
@@ -209,12 +416,16 @@ func SetupSubscriptionRoutes(service SubscriptionServiceInterface) http.Handler
 
 	r.Route("/api/subscriptions", func(r chi.Router) {
 		r.Get("/", h.ListSubscriptions)
+		r.Put("/order", h.UpdateOrder)
 
 		r.Route("/{id}", func(r chi.Router) {
 			r.Delete("/", h.Unsubscribe)
 			r.Put("/settings", h.UpdateSettings)
 			r.Post("/resume", h.ResumeFetch)
 			r.Post("/fetch", h.ManualFetch)
+			r.Post("/restore", h.Restore)
+			r.Post("/keep", h.KeepSubscription)
+			r.Post("/apply-suggested-url", h.ApplySuggestedFeedURL)
 		})
 	})
 
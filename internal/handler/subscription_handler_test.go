@@ -23,6 +23,26 @@ type mockSubscriptionService struct {
 	unsubscribeFn       func(ctx context.Context, userID, subscriptionID string) error
 	resumeFetchFn       func(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
 	manualFetchFn       func(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
+	restoreFn           func(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
+	updateOrderFn       func(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) ([]subscriptionResponse, error)
+	keepSubscriptionFn  func(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
+	applySuggestedFn    func(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error)
+	listModTimeFn       func(ctx context.Context, userID string) (time.Time, error)
+	getSubscriptionFn   func(ctx context.Context, userID, subscriptionID string) (*subscriptionDetailResponse, error)
+}
+
+func (m *mockSubscriptionService) GetSubscription(ctx context.Context, userID, subscriptionID string) (*subscriptionDetailResponse, error) {
+	if m.getSubscriptionFn != nil {
+		return m.getSubscriptionFn(ctx, userID, subscriptionID)
+	}
+	return nil, nil
+}
+
+func (m *mockSubscriptionService) ListModTime(ctx context.Context, userID string) (time.Time, error) {
+	if m.listModTimeFn != nil {
+		return m.listModTimeFn(ctx, userID)
+	}
+	return time.Time{}, nil
 }
 
 func (m *mockSubscriptionService) ListSubscriptions(ctx context.Context, userID string) ([]subscriptionResponse, error) {
@@ -60,6 +80,34 @@ func (m *mockSubscriptionService) ManualFetch(ctx context.Context, userID, subsc
 	return nil, nil
 }
 
+func (m *mockSubscriptionService) Restore(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+	if m.restoreFn != nil {
+		return m.restoreFn(ctx, userID, subscriptionID)
+	}
+	return nil, nil
+}
+
+func (m *mockSubscriptionService) UpdateOrder(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) ([]subscriptionResponse, error) {
+	if m.updateOrderFn != nil {
+		return m.updateOrderFn(ctx, userID, entries)
+	}
+	return nil, nil
+}
+
+func (m *mockSubscriptionService) KeepSubscription(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+	if m.keepSubscriptionFn != nil {
+		return m.keepSubscriptionFn(ctx, userID, subscriptionID)
+	}
+	return nil, nil
+}
+
+func (m *mockSubscriptionService) ApplySuggestedFeedURL(ctx context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+	if m.applySuggestedFn != nil {
+		return m.applySuggestedFn(ctx, userID, subscriptionID)
+	}
+	return nil, nil
+}
+
 // --- GET /api/subscriptions テスト ---
 
 func TestSubscriptionHandler_ListSubscriptions_Success(t *testing.T) {
@@ -99,8 +147,8 @@ func TestSubscriptionHandler_ListSubscriptions_Success(t *testing.T) {
 	}
 
 	contentType := resp.Header.Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", contentType, "application/json; charset=utf-8")
 	}
 
 	var result []map[string]interface{}
@@ -620,6 +668,7 @@ func TestSubscriptionHandler_ListSubscriptions_WithFaviconURL(t *testing.T) {
 func TestSubscriptionHandler_ListSubscriptions_WithErrorMessage(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Second)
 	errMsg := "404 Not Found"
+	errKind := "http_4xx"
 	svc := &mockSubscriptionService{
 		listSubscriptionsFn: func(ctx context.Context, userID string) ([]subscriptionResponse, error) {
 			return []subscriptionResponse{
@@ -632,6 +681,7 @@ func TestSubscriptionHandler_ListSubscriptions_WithErrorMessage(t *testing.T) {
 					FetchIntervalMinutes: 60,
 					FeedStatus:           "stopped",
 					ErrorMessage:         &errMsg,
+					ErrorKind:            &errKind,
 					UnreadCount:          0,
 					CreatedAt:            now,
 				},
@@ -658,6 +708,41 @@ func TestSubscriptionHandler_ListSubscriptions_WithErrorMessage(t *testing.T) {
 	if result[0]["error_message"] != errMsg {
 		t.Errorf("error_message = %v, want %q", result[0]["error_message"], errMsg)
 	}
+	if result[0]["error_kind"] != errKind {
+		t.Errorf("error_kind = %v, want %q", result[0]["error_kind"], errKind)
+	}
+}
+
+func TestSubscriptionHandler_ListSubscriptions_TooManyUnread(t *testing.T) {
+	svc := &mockSubscriptionService{
+		listSubscriptionsFn: func(ctx context.Context, userID string) ([]subscriptionResponse, error) {
+			return []subscriptionResponse{
+				{ID: "sub-1", FeedStatus: "active", UnreadCount: 501, TooManyUnread: true},
+				{ID: "sub-2", FeedStatus: "active", UnreadCount: 3},
+			}, nil
+		},
+	}
+
+	h := NewSubscriptionHandler(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/subscriptions", nil)
+	req = withUserID(req, "user-123")
+	w := httptest.NewRecorder()
+
+	h.ListSubscriptions(w, req)
+
+	var result []map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if result[0]["too_many_unread"] != true {
+		t.Errorf("too_many_unread = %v, want true", result[0]["too_many_unread"])
+	}
+	// 警告なしの購読でもフィールドは常に出力される（クライアントの分岐を単純化するため）
+	if v, ok := result[1]["too_many_unread"]; !ok || v != false {
+		t.Errorf("too_many_unread = %v (present=%v), want false", v, ok)
+	}
 }
 
 // --- バリデーションのエッジケーステスト ---
@@ -1176,3 +1261,584 @@ func TestSetupSubscriptionRoutes_ManualFetchEndpoint(t *testing.T) {
 
 // --- unused import guard for repository (needed for SubscriptionWithFeedInfo type) ---
 var _ = repository.SubscriptionWithFeedInfo{}
+
+// --- POST /api/subscriptions/:id/restore（購読解除の取り消し）テスト ---
+
+func TestSubscriptionHandler_Restore(t *testing.T) {
+	t.Run("期限内のとき200で復元後の購読を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			restoreFn: func(_ context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+				if userID != "user-123" || subscriptionID != "sub-1" {
+					t.Errorf("args = (%q, %q), want (user-123, sub-1)", userID, subscriptionID)
+				}
+				return &subscriptionResponse{ID: "sub-1", FeedTitle: "Restored Feed", UnreadCount: 2}, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/restore", nil), "user-123"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Restore(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result["id"] != "sub-1" || result["feed_title"] != "Restored Feed" {
+			t.Errorf("body = %v", result)
+		}
+	})
+
+	t.Run("期限切れのとき410 SUBSCRIPTION_RESTORE_EXPIREDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			restoreFn: func(context.Context, string, string) (*subscriptionResponse, error) {
+				return nil, model.NewSubscriptionRestoreExpiredError()
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/restore", nil), "user-123"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Restore(w, req)
+
+		// Assert
+		if w.Code != http.StatusGone {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusGone)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeSubscriptionRestoreExpired {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeSubscriptionRestoreExpired)
+		}
+	})
+
+	t.Run("再購読済みのとき409 DUPLICATE_SUBSCRIPTIONを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			restoreFn: func(context.Context, string, string) (*subscriptionResponse, error) {
+				return nil, model.NewDuplicateSubscriptionError()
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/restore", nil), "user-123"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Restore(w, req)
+
+		// Assert
+		if w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewSubscriptionHandler(&mockSubscriptionService{})
+		req := withChiURLParam(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/restore", nil), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.Restore(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestSetupSubscriptionRoutes_RestoreEndpoint(t *testing.T) {
+	// Arrange
+	svc := &mockSubscriptionService{
+		restoreFn: func(_ context.Context, _, subscriptionID string) (*subscriptionResponse, error) {
+			return &subscriptionResponse{ID: subscriptionID}, nil
+		},
+	}
+	router := SetupSubscriptionRoutes(svc)
+	req := withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/restore", nil), "user-123")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Errorf("POST /api/subscriptions/:id/restore status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+// --- POST /api/subscriptions/:id/keep（お試し購読を通常の購読にする）テスト ---
+
+func TestSubscriptionHandler_GetSubscription(t *testing.T) {
+	t.Run("自分の購読のとき200で推定次回更新時刻付きの購読を返す", func(t *testing.T) {
+		// Arrange
+		next := time.Date(2026, 7, 14, 7, 0, 0, 0, time.UTC)
+		svc := &mockSubscriptionService{
+			getSubscriptionFn: func(_ context.Context, userID, subscriptionID string) (*subscriptionDetailResponse, error) {
+				if userID != "user-123" || subscriptionID != "sub-1" {
+					t.Errorf("args = (%q, %q), want (user-123, sub-1)", userID, subscriptionID)
+				}
+				return &subscriptionDetailResponse{
+					subscriptionResponse: subscriptionResponse{ID: "sub-1", FeedTitle: "Morning"},
+					EstimatedNextPostAt:  &next,
+				}, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-1", nil), "user-123"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetSubscription(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result["id"] != "sub-1" || result["feed_title"] != "Morning" {
+			t.Errorf("result = %v, want id=sub-1 feed_title=Morning", result)
+		}
+		if result["estimated_next_post_at"] != "2026-07-14T07:00:00Z" {
+			t.Errorf("estimated_next_post_at = %v, want 2026-07-14T07:00:00Z", result["estimated_next_post_at"])
+		}
+	})
+
+	t.Run("購読が見つからないとき404 SUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			getSubscriptionFn: func(_ context.Context, _, subscriptionID string) (*subscriptionDetailResponse, error) {
+				return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodGet, "/api/subscriptions/sub-x", nil), "user-123"), "id", "sub-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetSubscription(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestSubscriptionHandler_KeepSubscription(t *testing.T) {
+	t.Run("自分の購読のとき200で期限を外した購読を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			keepSubscriptionFn: func(_ context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+				if userID != "user-123" || subscriptionID != "sub-1" {
+					t.Errorf("args = (%q, %q), want (user-123, sub-1)", userID, subscriptionID)
+				}
+				return &subscriptionResponse{ID: "sub-1"}, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/keep", nil), "user-123"), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.KeepSubscription(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result["id"] != "sub-1" {
+			t.Errorf("id = %v, want %q", result["id"], "sub-1")
+		}
+		if v, ok := result["expires_at"]; !ok || v != nil {
+			t.Errorf("expires_at = %v, want null", v)
+		}
+	})
+
+	t.Run("購読が見つからないとき404 SUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			keepSubscriptionFn: func(_ context.Context, _, subscriptionID string) (*subscriptionResponse, error) {
+				return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-x/keep", nil), "user-123"), "id", "sub-x")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.KeepSubscription(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeSubscriptionNotFound {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeSubscriptionNotFound)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewSubscriptionHandler(&mockSubscriptionService{})
+		req := withChiURLParam(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/keep", nil), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.KeepSubscription(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestSetupSubscriptionRoutes_KeepEndpoint(t *testing.T) {
+	// Arrange
+	svc := &mockSubscriptionService{
+		keepSubscriptionFn: func(_ context.Context, _, subscriptionID string) (*subscriptionResponse, error) {
+			return &subscriptionResponse{ID: subscriptionID}, nil
+		},
+	}
+	router := SetupSubscriptionRoutes(svc)
+	req := withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/keep", nil), "user-123")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Errorf("POST /api/subscriptions/:id/keep status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+// --- PUT /api/subscriptions/order テスト ---
+
+func TestSubscriptionHandler_UpdateOrder(t *testing.T) {
+	t.Run("指定順とピン留めをサービスに渡し更新後の一覧を返す", func(t *testing.T) {
+		// Arrange
+		var gotEntries []model.SubscriptionOrderEntry
+		svc := &mockSubscriptionService{
+			updateOrderFn: func(_ context.Context, userID string, entries []model.SubscriptionOrderEntry) ([]subscriptionResponse, error) {
+				if userID != "user-123" {
+					t.Errorf("userID = %q, want %q", userID, "user-123")
+				}
+				gotEntries = entries
+				return []subscriptionResponse{
+					{ID: "sub-2", IsPinned: true, SortOrder: 0},
+					{ID: "sub-1", SortOrder: 1},
+				}, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		body := `{"subscriptions":[{"id":"sub-2","is_pinned":true},{"id":"sub-1"}]}`
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/subscriptions/order", bytes.NewBufferString(body)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateOrder(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if len(gotEntries) != 2 || gotEntries[0].SubscriptionID != "sub-2" || gotEntries[1].SubscriptionID != "sub-1" {
+			t.Fatalf("entries = %+v", gotEntries)
+		}
+		if gotEntries[0].IsPinned == nil || !*gotEntries[0].IsPinned {
+			t.Errorf("entries[0].IsPinned = %v, want true", gotEntries[0].IsPinned)
+		}
+		if gotEntries[1].IsPinned != nil {
+			t.Errorf("entries[1].IsPinned = %v, want nil（省略時は変更しない）", *gotEntries[1].IsPinned)
+		}
+		var subs []subscriptionResponse
+		if err := json.NewDecoder(w.Body).Decode(&subs); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(subs) != 2 || subs[0].ID != "sub-2" || !subs[0].IsPinned {
+			t.Errorf("subs = %+v", subs)
+		}
+	})
+
+	t.Run("不正な指定のとき400 INVALID_SUBSCRIPTION_ORDERを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			updateOrderFn: func(context.Context, string, []model.SubscriptionOrderEntry) ([]subscriptionResponse, error) {
+				return nil, model.NewInvalidSubscriptionOrderError("購読IDが重複しています: sub-1")
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		body := `{"subscriptions":[{"id":"sub-1"},{"id":"sub-1"}]}`
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/subscriptions/order", bytes.NewBufferString(body)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateOrder(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidSubscriptionOrder {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidSubscriptionOrder)
+		}
+	})
+
+	t.Run("不正なJSONのとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		called := false
+		svc := &mockSubscriptionService{
+			updateOrderFn: func(context.Context, string, []model.SubscriptionOrderEntry) ([]subscriptionResponse, error) {
+				called = true
+				return nil, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/subscriptions/order", bytes.NewBufferString(`{`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateOrder(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if called {
+			t.Error("UpdateOrder はサービスを呼ぶべきでない")
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewSubscriptionHandler(&mockSubscriptionService{})
+		req := httptest.NewRequest(http.MethodPut, "/api/subscriptions/order", bytes.NewBufferString(`{"subscriptions":[]}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateOrder(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestSetupSubscriptionRoutes_OrderEndpoint(t *testing.T) {
+	// Arrange
+	called := false
+	svc := &mockSubscriptionService{
+		updateOrderFn: func(context.Context, string, []model.SubscriptionOrderEntry) ([]subscriptionResponse, error) {
+			called = true
+			return []subscriptionResponse{}, nil
+		},
+	}
+	router := SetupSubscriptionRoutes(svc)
+	req := withUserID(httptest.NewRequest(http.MethodPut, "/api/subscriptions/order", bytes.NewBufferString(`{"subscriptions":[{"id":"sub-1"}]}`)), "user-123")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK || !called {
+		t.Errorf("PUT /api/subscriptions/order status = %d, called = %v, want 200, true", w.Code, called)
+	}
+}
+
+// --- POST /api/subscriptions/:id/apply-suggested-url（フィード URL の張り替え提案の適用）テスト ---
+
+func TestSubscriptionHandler_ApplySuggestedFeedURL(t *testing.T) {
+	t.Run("提案があるとき200で張り替え後の購読を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			applySuggestedFn: func(_ context.Context, userID, subscriptionID string) (*subscriptionResponse, error) {
+				if userID != "user-123" || subscriptionID != "sub-1" {
+					t.Errorf("args = (%q, %q), want (user-123, sub-1)", userID, subscriptionID)
+				}
+				return &subscriptionResponse{ID: "sub-1", FeedURL: "https://example.com/new.xml", FeedStatus: "active"}, nil
+			},
+		}
+		router := SetupSubscriptionRoutes(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/apply-suggested-url", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var result map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if result["feed_url"] != "https://example.com/new.xml" {
+			t.Errorf("feed_url = %v, want %q", result["feed_url"], "https://example.com/new.xml")
+		}
+		if v, ok := result["suggested_feed_url"]; !ok || v != nil {
+			t.Errorf("suggested_feed_url = %v, want null", v)
+		}
+	})
+
+	errorCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"提案がないとき404 FEED_URL_SUGGESTION_NOT_FOUNDを返す", model.NewFeedURLSuggestionNotFoundError(), http.StatusNotFound, model.ErrCodeFeedURLSuggestionNotFound},
+		{"張り替え先が登録済みのとき409 FEED_URL_TAKENを返す", model.NewFeedURLTakenError(), http.StatusConflict, model.ErrCodeFeedURLTaken},
+		{"購読が見つからないとき404 SUBSCRIPTION_NOT_FOUNDを返す", model.NewSubscriptionNotFoundError("sub-1"), http.StatusNotFound, model.ErrCodeSubscriptionNotFound},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			svc := &mockSubscriptionService{
+				applySuggestedFn: func(context.Context, string, string) (*subscriptionResponse, error) {
+					return nil, tc.err
+				},
+			}
+			h := NewSubscriptionHandler(svc)
+			req := withChiURLParam(withUserID(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/apply-suggested-url", nil), "user-123"), "id", "sub-1")
+			w := httptest.NewRecorder()
+
+			// Act
+			h.ApplySuggestedFeedURL(w, req)
+
+			// Assert
+			if w.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tc.wantStatus)
+			}
+			if resp := parseAPIErrorResponse(t, w); resp["code"] != tc.wantCode {
+				t.Errorf("code = %q, want %q", resp["code"], tc.wantCode)
+			}
+		})
+	}
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewSubscriptionHandler(&mockSubscriptionService{})
+		req := withChiURLParam(httptest.NewRequest(http.MethodPost, "/api/subscriptions/sub-1/apply-suggested-url", nil), "id", "sub-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApplySuggestedFeedURL(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestSubscriptionHandler_ListSubscriptions_IfModifiedSince(t *testing.T) {
+	modTime := time.Date(2026, 7, 7, 12, 0, 0, 0, time.UTC)
+
+	t.Run("If-Modified-Since以降に変更が無いとき一覧を取得せず304を返す", func(t *testing.T) {
+		// Arrange
+		listCalled := false
+		svc := &mockSubscriptionService{
+			listModTimeFn: func(ctx context.Context, userID string) (time.Time, error) {
+				return modTime, nil
+			},
+			listSubscriptionsFn: func(ctx context.Context, userID string) ([]subscriptionResponse, error) {
+				listCalled = true
+				return []subscriptionResponse{}, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions", nil)
+		req.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListSubscriptions(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotModified {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotModified)
+		}
+		if listCalled {
+			t.Error("304 のとき ListSubscriptions を呼ばないこと")
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("body = %q, want empty", w.Body.String())
+		}
+	})
+
+	t.Run("If-Modified-Sinceより後に変更があるときLast-Modified付きで一覧を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			listModTimeFn: func(ctx context.Context, userID string) (time.Time, error) {
+				return modTime, nil
+			},
+			listSubscriptionsFn: func(ctx context.Context, userID string) ([]subscriptionResponse, error) {
+				return []subscriptionResponse{}, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions", nil)
+		req.Header.Set("If-Modified-Since", modTime.Add(-time.Minute).Format(http.TimeFormat))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListSubscriptions(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if lm := w.Header().Get("Last-Modified"); lm != modTime.Format(http.TimeFormat) {
+			t.Errorf("Last-Modified = %q, want %q", lm, modTime.Format(http.TimeFormat))
+		}
+	})
+
+	t.Run("最終更新日時の取得に失敗したときLast-Modifiedなしで一覧を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSubscriptionService{
+			listModTimeFn: func(ctx context.Context, userID string) (time.Time, error) {
+				return time.Time{}, errors.New("db error")
+			},
+			listSubscriptionsFn: func(ctx context.Context, userID string) ([]subscriptionResponse, error) {
+				return []subscriptionResponse{}, nil
+			},
+		}
+		h := NewSubscriptionHandler(svc)
+		req := httptest.NewRequest(http.MethodGet, "/api/subscriptions", nil)
+		req.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+		req = withUserID(req, "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ListSubscriptions(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if lm := w.Header().Get("Last-Modified"); lm != "" {
+			t.Errorf("Last-Modified = %q, want empty", lm)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+// Package handler の sync_handler.go は、オフライン中に記録した記事状態の操作を同期する HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - POST /api/sync/operations : 既読・スター操作の列を last-write-wins で適用し、操作ごとの結果を返す
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// SyncServiceInterface はオフライン同期ハンドラが必要とするサービスインターフェース。
+type SyncServiceInterface interface {
+	// ApplyOperations は操作列を送信順に適用し、操作ごとの結果を返す。
+	// 操作列が空か上限を超える場合は INVALID_SYNC_OPERATIONS を返す。
+	ApplyOperations(ctx context.Context, userID string, ops []model.SyncOperation) (*syncOperationsResponse, error)
+}
+
+// SyncHandler はオフライン同期の HTTP ハンドラ。
+type SyncHandler struct {
+	service SyncServiceInterface
+}
+
+// NewSyncHandler は SyncHandler を生成する。
+func NewSyncHandler(service SyncServiceInterface) *SyncHandler {
+	return &SyncHandler{service: service}
+}
+
+// syncOperationsRequest はオフライン同期のリクエスト。
+type syncOperationsRequest struct {
+	Operations []syncOperationRequest `json:"operations"`
+}
+
+// syncOperationRequest は 1 件の操作。client_timestamp は RFC3339 形式の操作時刻。
+type syncOperationRequest struct {
+	Type            string     `json:"type"`
+	ItemID          string     `json:"item_id"`
+	Value           *bool      `json:"value"`
+	ClientTimestamp *time.Time `json:"client_timestamp"`
+}
+
+// syncOperationsResponse はオフライン同期のレスポンス。results はリクエストの operations と同じ順に並ぶ。
+type syncOperationsResponse struct {
+	Results []syncOperationResultResponse `json:"results"`
+}
+
+// syncOperationResultResponse は 1 件の操作の結果。
+// status は applied（適用）/ stale（サーバー側の変更の方が新しく不採用）/ failed（不正・記事なし）。
+// is_read / is_starred は操作後のサーバー側の状態で、failed の場合は null。error は failed の場合のみ設定する。
+type syncOperationResultResponse struct {
+	Index     int                 `json:"index"`
+	Type      string              `json:"type"`
+	ItemID    string              `json:"item_id"`
+	Status    string              `json:"status"`
+	IsRead    *bool               `json:"is_read"`
+	IsStarred *bool               `json:"is_starred"`
+	Error     *syncOperationError `json:"error"`
+}
+
+// syncOperationError は適用できなかった操作のエラー。
+type syncOperationError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ApplyOperations はオフライン中に記録した既読・スター操作をまとめて適用する。
+// POST /api/sync/operations
+//
+// 各操作は client_timestamp がサーバー側の状態の最終変更日時より新しい場合だけ適用する（last-write-wins）。
+// 個々の操作の失敗はリクエスト全体のエラーにせず、操作ごとの結果（status: failed）で返す。
+func (h *SyncHandler) ApplyOperations(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req syncOperationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	ops := make([]model.SyncOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		ops[i] = model.SyncOperation{
+			Type:   model.SyncOperationType(op.Type),
+			ItemID: op.ItemID,
+			Value:  op.Value,
+		}
+		if op.ClientTimestamp != nil {
+			ops[i].ClientTimestamp = *op.ClientTimestamp
+		}
+	}
+
+	resp, err := h.service.ApplyOperations(r.Context(), userID, ops)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+	WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockSyncService は SyncServiceInterface のモック実装。
+type mockSyncService struct {
+	applyFn    func(ctx context.Context, userID string, ops []model.SyncOperation) (*syncOperationsResponse, error)
+	applyCalls int
+}
+
+func (m *mockSyncService) ApplyOperations(ctx context.Context, userID string, ops []model.SyncOperation) (*syncOperationsResponse, error) {
+	m.applyCalls++
+	if m.applyFn != nil {
+		return m.applyFn(ctx, userID, ops)
+	}
+	return &syncOperationsResponse{Results: []syncOperationResultResponse{}}, nil
+}
+
+func TestSyncHandler_ApplyOperations(t *testing.T) {
+	t.Run("操作列をサービスに渡し操作ごとの結果を200で返すとき", func(t *testing.T) {
+		// Arrange
+		var gotUserID string
+		var gotOps []model.SyncOperation
+		isRead, isStarred := true, false
+		svc := &mockSyncService{
+			applyFn: func(_ context.Context, userID string, ops []model.SyncOperation) (*syncOperationsResponse, error) {
+				gotUserID, gotOps = userID, ops
+				return &syncOperationsResponse{Results: []syncOperationResultResponse{
+					{Index: 0, Type: "read", ItemID: "item-1", Status: "applied", IsRead: &isRead, IsStarred: &isStarred},
+					{Index: 1, Type: "star", ItemID: "item-x", Status: "failed", Error: &syncOperationError{Code: model.ErrCodeItemNotFound, Message: "記事が見つかりません。"}},
+				}}, nil
+			},
+		}
+		h := NewSyncHandler(svc)
+		body := `{"operations":[` +
+			`{"type":"read","item_id":"item-1","value":true,"client_timestamp":"2026-07-01T09:00:00Z"},` +
+			`{"type":"star","item_id":"item-x","value":true,"client_timestamp":"2026-07-01T09:01:00Z"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/sync/operations", strings.NewReader(body))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApplyOperations(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if gotUserID != "user-1" {
+			t.Errorf("userID = %q, want %q", gotUserID, "user-1")
+		}
+		if len(gotOps) != 2 {
+			t.Fatalf("len(ops) = %d, want 2", len(gotOps))
+		}
+		wantTS := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+		if gotOps[0].Type != model.SyncOperationRead || gotOps[0].ItemID != "item-1" ||
+			gotOps[0].Value == nil || !*gotOps[0].Value || !gotOps[0].ClientTimestamp.Equal(wantTS) {
+			t.Errorf("ops[0] = %+v", gotOps[0])
+		}
+
+		var resp struct {
+			Results []struct {
+				Index     int    `json:"index"`
+				Status    string `json:"status"`
+				IsRead    *bool  `json:"is_read"`
+				IsStarred *bool  `json:"is_starred"`
+				Error     *struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			} `json:"results"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(resp.Results) != 2 {
+			t.Fatalf("len(results) = %d, want 2", len(resp.Results))
+		}
+		if r := resp.Results[0]; r.Status != "applied" || r.IsRead == nil || !*r.IsRead || r.Error != nil {
+			t.Errorf("results[0] = %+v", r)
+		}
+		if r := resp.Results[1]; r.Status != "failed" || r.IsRead != nil || r.Error == nil || r.Error.Code != model.ErrCodeItemNotFound {
+			t.Errorf("results[1] = %+v", r)
+		}
+	})
+
+	t.Run("client_timestampが省略されたときゼロ値のままサービスに渡す", func(t *testing.T) {
+		// Arrange
+		var gotOps []model.SyncOperation
+		svc := &mockSyncService{
+			applyFn: func(_ context.Context, _ string, ops []model.SyncOperation) (*syncOperationsResponse, error) {
+				gotOps = ops
+				return &syncOperationsResponse{Results: []syncOperationResultResponse{}}, nil
+			},
+		}
+		h := NewSyncHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/sync/operations",
+			strings.NewReader(`{"operations":[{"type":"read","item_id":"item-1","value":false}]}`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApplyOperations(w, req)
+
+		// Assert
+		if len(gotOps) != 1 || !gotOps[0].ClientTimestamp.IsZero() {
+			t.Errorf("ops = %+v, want one op with zero timestamp", gotOps)
+		}
+	})
+
+	t.Run("JSONが不正なとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockSyncService{}
+		h := NewSyncHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/sync/operations", strings.NewReader(`{"operations":`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApplyOperations(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.applyCalls != 0 {
+			t.Errorf("applyCalls = %d, want 0", svc.applyCalls)
+		}
+	})
+
+	t.Run("操作列が不正なときINVALID_SYNC_OPERATIONSで400を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSyncService{
+			applyFn: func(context.Context, string, []model.SyncOperation) (*syncOperationsResponse, error) {
+				return nil, model.NewInvalidSyncOperationsError("operations が空です。")
+			},
+		}
+		h := NewSyncHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/sync/operations", strings.NewReader(`{"operations":[]}`))
+		req = withUserID(req, "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApplyOperations(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		apiErr := parseAPIErrorResponse(t, w)
+		if apiErr["code"] != model.ErrCodeInvalidSyncOperations {
+			t.Errorf("code = %q, want %q", apiErr["code"], model.ErrCodeInvalidSyncOperations)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockSyncService{}
+		h := NewSyncHandler(svc)
+		req := httptest.NewRequest(http.MethodPost, "/api/sync/operations", strings.NewReader(`{"operations":[]}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.ApplyOperations(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,322 @@
+// Package handler の team_handler.go は、チーム（家族・チーム）での購読リスト共有の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET    /api/teams                     : 所属チーム一覧
+//   - POST   /api/teams                     : チームの作成（作成者がオーナー）
+//   - POST   /api/teams/invitations/accept  : 招待トークンでチームに参加
+//   - GET    /api/teams/{id}                : チームの詳細（メンバー・購読フィード）
+//   - POST   /api/teams/{id}/invitations    : 招待トークンの発行
+//   - POST   /api/teams/{id}/feeds          : 自分の購読フィードをチームの購読に追加
+//   - DELETE /api/teams/{id}/feeds/{feedId} : チームの購読フィードの削除
+//   - DELETE /api/teams/{id}/members/me     : チームからの脱退
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// TeamServiceInterface はチームハンドラが必要とするサービスインターフェース。
+// 所属していないチームへの操作は TEAM_NOT_FOUND を返す。
+type TeamServiceInterface interface {
+	ListTeams(ctx context.Context, userID string) ([]teamResponse, error)
+	CreateTeam(ctx context.Context, userID, name string) (*teamResponse, error)
+	GetTeam(ctx context.Context, userID, teamID string) (*teamDetailResponse, error)
+	CreateInvitation(ctx context.Context, userID, teamID string) (*teamInvitationResponse, error)
+	AcceptInvitation(ctx context.Context, userID, token string) (*teamResponse, error)
+	AddFeed(ctx context.Context, userID, teamID, feedID string) error
+	RemoveFeed(ctx context.Context, userID, teamID, feedID string) error
+	LeaveTeam(ctx context.Context, userID, teamID string) error
+}
+
+// TeamHandler はチーム共有の HTTP ハンドラ。
+type TeamHandler struct {
+	service TeamServiceInterface
+}
+
+// NewTeamHandler は TeamHandler を生成する。
+func NewTeamHandler(service TeamServiceInterface) *TeamHandler {
+	return &TeamHandler{service: service}
+}
+
+// teamResponse はチーム 1 件（自分の役割を含む）。
+type teamResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// teamMemberResponse はチームメンバー 1 人。
+type teamMemberResponse struct {
+	UserID   string    `json:"user_id"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// teamFeedResponse はチームの購読フィード 1 件。
+type teamFeedResponse struct {
+	FeedID    string    `json:"feed_id"`
+	Title     string    `json:"title"`
+	FeedURL   string    `json:"feed_url"`
+	SiteURL   string    `json:"site_url"`
+	AddedBy   string    `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// teamDetailResponse は GET /api/teams/{id} のレスポンス。
+type teamDetailResponse struct {
+	teamResponse
+	Members []teamMemberResponse `json:"members"`
+	Feeds   []teamFeedResponse   `json:"feeds"`
+}
+
+// teamListResponse は GET /api/teams のレスポンス。
+type teamListResponse struct {
+	Teams []teamResponse `json:"teams"`
+}
+
+// teamInvitationResponse は POST /api/teams/{id}/invitations のレスポンス。
+// token は発行時にのみ返す（サーバーにはハッシュのみ保存する）。
+type teamInvitationResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// createTeamRequest は POST /api/teams のリクエストボディ。
+type createTeamRequest struct {
+	Name string `json:"name"`
+}
+
+// acceptTeamInvitationRequest は POST /api/teams/invitations/accept のリクエストボディ。
+// トークンがアクセスログに残らないよう、URL ではなくボディで受け取る。
+type acceptTeamInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// addTeamFeedRequest は POST /api/teams/{id}/feeds のリクエストボディ。
+type addTeamFeedRequest struct {
+	FeedID string `json:"feed_id"`
+}
+
+// ListTeams は所属チームの一覧を返す。
+// GET /api/teams
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	teams, err := h.service.ListTeams(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, teamListResponse{Teams: teams})
+}
+
+// CreateTeam はリクエストユーザーをオーナーとするチームを作成する。
+// POST /api/teams
+func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req createTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	team, err := h.service.CreateTeam(r.Context(), userID, req.Name)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, team)
+}
+
+// GetTeam はチームの詳細（メンバー・購読フィード）を返す。
+// GET /api/teams/{id}
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	detail, err := h.service.GetTeam(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, detail)
+}
+
+// CreateInvitation はチームへの招待トークンを発行する。
+// POST /api/teams/{id}/invitations
+func (h *TeamHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	invitation, err := h.service.CreateInvitation(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	WriteJSON(w, http.StatusCreated, invitation)
+}
+
+// AcceptInvitation は招待トークンでチームに参加する。
+// POST /api/teams/invitations/accept
+func (h *TeamHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req acceptTeamInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	team, err := h.service.AcceptInvitation(r.Context(), userID, req.Token)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, team)
+}
+
+// AddFeed は自分が購読しているフィードをチームの購読に追加する。
+// POST /api/teams/{id}/feeds
+func (h *TeamHandler) AddFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req addTeamFeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	if err := h.service.AddFeed(r.Context(), userID, chi.URLParam(r, "id"), req.FeedID); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveFeed はチームの購読フィードを削除する。
+// DELETE /api/teams/{id}/feeds/{feedId}
+func (h *TeamHandler) RemoveFeed(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	if err := h.service.RemoveFeed(r.Context(), userID, chi.URLParam(r, "id"), chi.URLParam(r, "feedId")); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LeaveTeam はチームから脱退する。
+// DELETE /api/teams/{id}/members/me
+func (h *TeamHandler) LeaveTeam(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	if err := h.service.LeaveTeam(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
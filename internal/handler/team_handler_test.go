@@ -0,0 +1,419 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockTeamService は TeamServiceInterface のモック実装。
+type mockTeamService struct {
+	createTeamFn       func(ctx context.Context, userID, name string) (*teamResponse, error)
+	getTeamFn          func(ctx context.Context, userID, teamID string) (*teamDetailResponse, error)
+	acceptInvitationFn func(ctx context.Context, userID, token string) (*teamResponse, error)
+	addFeedFn          func(ctx context.Context, userID, teamID, feedID string) error
+	removeFeedFn       func(ctx context.Context, userID, teamID, feedID string) error
+	leaveTeamFn        func(ctx context.Context, userID, teamID string) error
+	listTeamsCalls     int
+}
+
+func (m *mockTeamService) ListTeams(context.Context, string) ([]teamResponse, error) {
+	m.listTeamsCalls++
+	return []teamResponse{}, nil
+}
+
+func (m *mockTeamService) CreateTeam(ctx context.Context, userID, name string) (*teamResponse, error) {
+	if m.createTeamFn != nil {
+		return m.createTeamFn(ctx, userID, name)
+	}
+	return &teamResponse{ID: "team-1", Name: name, Role: "owner"}, nil
+}
+
+func (m *mockTeamService) GetTeam(ctx context.Context, userID, teamID string) (*teamDetailResponse, error) {
+	if m.getTeamFn != nil {
+		return m.getTeamFn(ctx, userID, teamID)
+	}
+	return &teamDetailResponse{teamResponse: teamResponse{ID: teamID}, Members: []teamMemberResponse{}, Feeds: []teamFeedResponse{}}, nil
+}
+
+func (m *mockTeamService) CreateInvitation(context.Context, string, string) (*teamInvitationResponse, error) {
+	return &teamInvitationResponse{Token: "token-abc", ExpiresAt: time.Date(2026, 6, 22, 12, 0, 0, 0, time.UTC)}, nil
+}
+
+func (m *mockTeamService) AcceptInvitation(ctx context.Context, userID, token string) (*teamResponse, error) {
+	if m.acceptInvitationFn != nil {
+		return m.acceptInvitationFn(ctx, userID, token)
+	}
+	return &teamResponse{ID: "team-1", Role: "member"}, nil
+}
+
+func (m *mockTeamService) AddFeed(ctx context.Context, userID, teamID, feedID string) error {
+	if m.addFeedFn != nil {
+		return m.addFeedFn(ctx, userID, teamID, feedID)
+	}
+	return nil
+}
+
+func (m *mockTeamService) RemoveFeed(ctx context.Context, userID, teamID, feedID string) error {
+	if m.removeFeedFn != nil {
+		return m.removeFeedFn(ctx, userID, teamID, feedID)
+	}
+	return nil
+}
+
+func (m *mockTeamService) LeaveTeam(ctx context.Context, userID, teamID string) error {
+	if m.leaveTeamFn != nil {
+		return m.leaveTeamFn(ctx, userID, teamID)
+	}
+	return nil
+}
+
+// newTeamTestRouter は TeamService を配線したルーターを返す（svc が nil の場合は未配線）。
+func newTeamTestRouter(svc TeamServiceInterface) http.Handler {
+	deps := &RouterDeps{
+		SessionFinder: &mockSessionFinderForRouter{
+			sessions: map[string]*model.Session{
+				"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+			},
+		},
+		CORSAllowedOrigin:   "http://localhost:3000",
+		RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+		AuthService:         &mockAuthService{},
+		FeedService:         &mockFeedService{},
+		ItemService:         &mockItemService{},
+		SubscriptionService: &mockSubscriptionService{},
+		UserService:         &mockUserService{},
+	}
+	if svc != nil {
+		deps.TeamService = svc
+	}
+	return NewRouter(deps)
+}
+
+// doTeamRequest はセッション付きでルーターにリクエストを送る。
+func doTeamRequest(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// --- /api/teams テスト ---
+
+func TestTeamHandler_CreateTeam(t *testing.T) {
+	t.Run("名前を指定したとき201でチームを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			createTeamFn: func(_ context.Context, userID, name string) (*teamResponse, error) {
+				if userID != "user-test-1" || name != "家族" {
+					t.Errorf("args = (%q, %q)", userID, name)
+				}
+				return &teamResponse{ID: "team-1", Name: name, Role: "owner"}, nil
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodPost, "/api/teams", `{"name":"家族"}`)
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["id"] != "team-1" || body["role"] != "owner" {
+			t.Errorf("body = %v", body)
+		}
+	})
+
+	t.Run("ボディが不正なJSONのとき400 INVALID_REQUESTを返す", func(t *testing.T) {
+		// Act
+		w := doTeamRequest(newTeamTestRouter(&mockTeamService{}), http.MethodPost, "/api/teams", `{`)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("名前が不正なとき400 INVALID_TEAM_NAMEを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			createTeamFn: func(context.Context, string, string) (*teamResponse, error) {
+				return nil, model.NewInvalidTeamNameError()
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodPost, "/api/teams", `{"name":""}`)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidTeamName {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidTeamName)
+		}
+	})
+}
+
+func TestTeamHandler_GetTeam(t *testing.T) {
+	t.Run("所属チームのときメンバーと購読フィードを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			getTeamFn: func(_ context.Context, userID, teamID string) (*teamDetailResponse, error) {
+				if userID != "user-test-1" || teamID != "team-1" {
+					t.Errorf("args = (%q, %q)", userID, teamID)
+				}
+				return &teamDetailResponse{
+					teamResponse: teamResponse{ID: teamID, Name: "家族", Role: "owner"},
+					Members:      []teamMemberResponse{{UserID: "user-test-1", Name: "Alice", Role: "owner"}},
+					Feeds:        []teamFeedResponse{{FeedID: "feed-1", Title: "Feed 1"}},
+				}, nil
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodGet, "/api/teams/team-1", "")
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["id"] != "team-1" || body["name"] != "家族" {
+			t.Errorf("body = %v", body)
+		}
+		if members, ok := body["members"].([]interface{}); !ok || len(members) != 1 {
+			t.Errorf("members = %v", body["members"])
+		}
+		if feeds, ok := body["feeds"].([]interface{}); !ok || len(feeds) != 1 {
+			t.Errorf("feeds = %v", body["feeds"])
+		}
+	})
+
+	t.Run("所属していないチームのとき404 TEAM_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			getTeamFn: func(_ context.Context, _, teamID string) (*teamDetailResponse, error) {
+				return nil, model.NewTeamNotFoundError(teamID)
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodGet, "/api/teams/team-x", "")
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestTeamHandler_Invitations(t *testing.T) {
+	t.Run("招待を発行したとき201でトークンを返しキャッシュさせない", func(t *testing.T) {
+		// Act
+		w := doTeamRequest(newTeamTestRouter(&mockTeamService{}), http.MethodPost, "/api/teams/team-1/invitations", "")
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		if got := w.Header().Get("Cache-Control"); got != "no-store" {
+			t.Errorf("Cache-Control = %q, want no-store", got)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["token"] != "token-abc" || body["expires_at"] != "2026-06-22T12:00:00Z" {
+			t.Errorf("body = %v", body)
+		}
+	})
+
+	t.Run("招待を受諾したときボディのトークンを渡して参加したチームを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			acceptInvitationFn: func(_ context.Context, userID, token string) (*teamResponse, error) {
+				if userID != "user-test-1" || token != "token-abc" {
+					t.Errorf("args = (%q, %q)", userID, token)
+				}
+				return &teamResponse{ID: "team-1", Role: "member"}, nil
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodPost, "/api/teams/invitations/accept", `{"token":"token-abc"}`)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("招待が無効なとき404 TEAM_INVITATION_INVALIDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			acceptInvitationFn: func(context.Context, string, string) (*teamResponse, error) {
+				return nil, model.NewTeamInvitationInvalidError()
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodPost, "/api/teams/invitations/accept", `{"token":"expired"}`)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeTeamInvitationInvalid {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeTeamInvitationInvalid)
+		}
+	})
+}
+
+func TestTeamHandler_Feeds(t *testing.T) {
+	t.Run("フィードを追加したとき204を返す", func(t *testing.T) {
+		// Arrange
+		var gotTeamID, gotFeedID string
+		svc := &mockTeamService{
+			addFeedFn: func(_ context.Context, _, teamID, feedID string) error {
+				gotTeamID, gotFeedID = teamID, feedID
+				return nil
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodPost, "/api/teams/team-1/feeds", `{"feed_id":"feed-1"}`)
+
+		// Assert
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if gotTeamID != "team-1" || gotFeedID != "feed-1" {
+			t.Errorf("args = (%q, %q)", gotTeamID, gotFeedID)
+		}
+	})
+
+	t.Run("既にチームで購読しているとき409 DUPLICATE_TEAM_FEEDを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			addFeedFn: func(context.Context, string, string, string) error {
+				return model.NewDuplicateTeamFeedError()
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodPost, "/api/teams/team-1/feeds", `{"feed_id":"feed-1"}`)
+
+		// Assert
+		if w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+		}
+	})
+
+	t.Run("フィードを削除したときURLのチームIDとフィードIDを渡して204を返す", func(t *testing.T) {
+		// Arrange
+		var gotTeamID, gotFeedID string
+		svc := &mockTeamService{
+			removeFeedFn: func(_ context.Context, _, teamID, feedID string) error {
+				gotTeamID, gotFeedID = teamID, feedID
+				return nil
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodDelete, "/api/teams/team-1/feeds/feed-1", "")
+
+		// Assert
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if gotTeamID != "team-1" || gotFeedID != "feed-1" {
+			t.Errorf("args = (%q, %q)", gotTeamID, gotFeedID)
+		}
+	})
+}
+
+func TestTeamHandler_LeaveTeam(t *testing.T) {
+	t.Run("オーナーが脱退しようとしたとき409 TEAM_OWNER_CANNOT_LEAVEを返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{
+			leaveTeamFn: func(context.Context, string, string) error {
+				return model.NewTeamOwnerCannotLeaveError()
+			},
+		}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodDelete, "/api/teams/team-1/members/me", "")
+
+		// Assert
+		if w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// TestNewRouter_TeamRoutes はチームルートが認証必須で、TeamService 未配線時は登録されないことを検証する。
+func TestNewRouter_TeamRoutes(t *testing.T) {
+	t.Run("セッションありのとき200を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockTeamService{}
+
+		// Act
+		w := doTeamRequest(newTeamTestRouter(svc), http.MethodGet, "/api/teams", "")
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.listTeamsCalls != 1 {
+			t.Errorf("ListTeams calls = %d, want 1", svc.listTeamsCalls)
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newTeamTestRouter(&mockTeamService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/teams", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("TeamService が nil のときルートを登録しない", func(t *testing.T) {
+		// Act
+		w := doTeamRequest(newTeamTestRouter(nil), http.MethodGet, "/api/teams", "")
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
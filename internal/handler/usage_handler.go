@@ -0,0 +1,92 @@
+// Package handler の usage_handler.go は、ユーザーごとの API 利用量の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/usage : 直近 30 日の API 利用量（リクエスト数・エラー率）を日別・クライアント別に返す
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// UsageServiceInterface は API 利用量ハンドラが必要とするサービスインターフェース。
+type UsageServiceInterface interface {
+	// Daily は当日（UTC）を含む直近 30 日の API 利用量を返す。
+	Daily(ctx context.Context, userID string) (*usageResponse, error)
+}
+
+// UsageHandler は API 利用量の HTTP ハンドラ。
+type UsageHandler struct {
+	service UsageServiceInterface
+}
+
+// NewUsageHandler は UsageHandler を生成する。
+func NewUsageHandler(service UsageServiceInterface) *UsageHandler {
+	return &UsageHandler{service: service}
+}
+
+// usageClientResponse は API 利用量のクライアント別内訳の 1 件。
+type usageClientResponse struct {
+	Client       string  `json:"client"`
+	RequestCount int64   `json:"request_count"`
+	ErrorCount   int64   `json:"error_count"`
+	ErrorRate    float64 `json:"error_rate"`
+}
+
+// usageDayResponse は API 利用量の 1 日分。
+type usageDayResponse struct {
+	Date         time.Time             `json:"date"`
+	RequestCount int64                 `json:"request_count"`
+	ErrorCount   int64                 `json:"error_count"`
+	ErrorRate    float64               `json:"error_rate"`
+	Clients      []usageClientResponse `json:"clients"`
+}
+
+// usageResponse は GET /api/usage のレスポンス。
+// error_rate はエラー数 / リクエスト数（0〜1、リクエストが無い場合は 0）。
+type usageResponse struct {
+	Days         int                `json:"days"`
+	Since        time.Time          `json:"since"`
+	RequestCount int64              `json:"request_count"`
+	ErrorCount   int64              `json:"error_count"`
+	ErrorRate    float64            `json:"error_rate"`
+	Series       []usageDayResponse `json:"series"`
+}
+
+// GetUsage は自分の API 利用量を返す。
+// GET /api/usage
+//
+// 日は 00:00 UTC 始まりで、当日を含む直近 30 日を古い順に返す（利用の無い日も含む）。
+// 集計は非同期のため、直近 1 分程度のリクエストは反映されていない場合がある。
+func (h *UsageHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	resp, err := h.service.Daily(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// errorRate はエラー数 / リクエスト数を返す。リクエストが無い場合は 0。
+func errorRate(requests, errs int64) float64 {
+	if requests == 0 {
+		return 0
+	}
+	return float64(errs) / float64(requests)
+}
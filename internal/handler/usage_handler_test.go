@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// mockUsageService は UsageServiceInterface のモック実装。
+type mockUsageService struct {
+	dailyFn func(ctx context.Context, userID string) (*usageResponse, error)
+}
+
+func (m *mockUsageService) Daily(ctx context.Context, userID string) (*usageResponse, error) {
+	return m.dailyFn(ctx, userID)
+}
+
+func TestUsageHandler_GetUsage(t *testing.T) {
+	t.Run("認証済みのとき200で利用量を返す", func(t *testing.T) {
+		// Arrange
+		var gotUserID string
+		since := time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC)
+		svc := &mockUsageService{
+			dailyFn: func(_ context.Context, userID string) (*usageResponse, error) {
+				gotUserID = userID
+				return &usageResponse{
+					Days: 30, Since: since, RequestCount: 4, ErrorCount: 1, ErrorRate: 0.25,
+					Series: []usageDayResponse{{
+						Date: since, RequestCount: 4, ErrorCount: 1, ErrorRate: 0.25,
+						Clients: []usageClientResponse{{Client: "browser", RequestCount: 4, ErrorCount: 1, ErrorRate: 0.25}},
+					}},
+				}, nil
+			},
+		}
+		h := NewUsageHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/usage", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetUsage(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body usageResponse
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body.Days != 30 || body.ErrorRate != 0.25 || len(body.Series) != 1 || body.Series[0].Clients[0].Client != "browser" {
+			t.Errorf("body = %+v", body)
+		}
+		if gotUserID != "user-1" {
+			t.Errorf("userID = %q, want %q", gotUserID, "user-1")
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewUsageHandler(&mockUsageService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/usage", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetUsage(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("サービスがエラーを返したとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUsageService{
+			dailyFn: func(context.Context, string) (*usageResponse, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		h := NewUsageHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/usage", nil), "user-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetUsage(w, req)
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
+
+func TestErrorRate(t *testing.T) {
+	if got := errorRate(0, 0); got != 0 {
+		t.Errorf("errorRate(0, 0) = %v, want 0", got)
+	}
+	if got := errorRate(8, 2); got != 0.25 {
+		t.Errorf("errorRate(8, 2) = %v, want 0.25", got)
+	}
+}
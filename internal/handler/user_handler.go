@@ -34,8 +34,8 @@ func NewUserHandler(service UserServiceInterface) *UserHandler {
 func (h *UserHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.UserIDFromContext(r.Context())
 	if err != nil {
-		middleware.WriteErrorResponse(w, http.StatusUnauthorized, &model.APIError{
-			Code:     "UNAUTHORIZED",
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
 			Message:  "認証が必要です。",
 			Category: "auth",
 			Action:   "ログインしてください。",
@@ -44,7 +44,7 @@ func (h *UserHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Withdraw(r.Context(), userID); err != nil {
-		handleServiceError(w, err)
+		WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,324 @@
+// Package handler の user_settings_handler.go は、ユーザー設定の HTTP エンドポイントを提供する。
+//
+// 提供エンドポイント:
+//   - GET /api/users/me/unread-warning : 積読警告（too_many_unread）設定の取得
+//   - PUT /api/users/me/unread-warning : 積読警告の閾値の更新
+//   - GET /api/users/me/link-behavior  : 記事本文リンクの開き方（新しいタブで開くか）の取得
+//   - PUT /api/users/me/link-behavior  : 記事本文リンクの開き方の更新
+//   - GET /api/users/me/prefetch       : 利用時間帯に合わせたプリフェッチの設定の取得
+//   - PUT /api/users/me/prefetch       : 利用時間帯に合わせたプリフェッチの設定の更新
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// UserSettingsServiceInterface はユーザー設定ハンドラが必要とするサービスインターフェース。
+type UserSettingsServiceInterface interface {
+	// GetUnreadWarning は当該ユーザーの積読警告設定を返す。
+	GetUnreadWarning(ctx context.Context, userID string) (*unreadWarningResponse, error)
+	// UpdateUnreadWarning は当該ユーザーの積読警告の閾値を更新する。
+	UpdateUnreadWarning(ctx context.Context, userID string, threshold int) (*unreadWarningResponse, error)
+	// GetLinkBehavior は当該ユーザーの記事本文リンクの開き方の設定を返す。
+	GetLinkBehavior(ctx context.Context, userID string) (*linkBehaviorResponse, error)
+	// UpdateLinkBehavior は当該ユーザーの記事本文リンクの開き方の設定を更新する。
+	UpdateLinkBehavior(ctx context.Context, userID string, openInNewTab bool) (*linkBehaviorResponse, error)
+	// GetPrefetch は当該ユーザーの利用時間帯に合わせたプリフェッチの設定を返す。
+	GetPrefetch(ctx context.Context, userID string) (*prefetchResponse, error)
+	// UpdatePrefetch は当該ユーザーの利用時間帯に合わせたプリフェッチの設定を更新する。
+	UpdatePrefetch(ctx context.Context, userID string, enabled bool) (*prefetchResponse, error)
+	// GetTimezone は当該ユーザーのタイムゾーン設定を返す。
+	GetTimezone(ctx context.Context, userID string) (*timezoneResponse, error)
+	// UpdateTimezone は当該ユーザーのタイムゾーンを更新する。無効な名前の場合は INVALID_TIMEZONE を返す。
+	UpdateTimezone(ctx context.Context, userID, timezone string) (*timezoneResponse, error)
+}
+
+// UserSettingsHandler はユーザー設定の HTTP ハンドラ。
+type UserSettingsHandler struct {
+	service UserSettingsServiceInterface
+}
+
+// NewUserSettingsHandler は UserSettingsHandler を生成する。
+func NewUserSettingsHandler(service UserSettingsServiceInterface) *UserSettingsHandler {
+	return &UserSettingsHandler{service: service}
+}
+
+// unreadWarningResponse は積読警告設定のAPIレスポンス。
+// Threshold が 0 の場合は警告無効（Enabled = false）。
+type unreadWarningResponse struct {
+	Threshold int  `json:"threshold"`
+	Enabled   bool `json:"enabled"`
+}
+
+// unreadWarningRequest は積読警告設定更新リクエストのボディ。
+// 閾値の指定漏れ（0 との区別）を検出するためポインタで受ける。
+type unreadWarningRequest struct {
+	Threshold *int `json:"threshold"`
+}
+
+// linkBehaviorResponse は記事本文リンクの開き方の設定のAPIレスポンス。
+type linkBehaviorResponse struct {
+	OpenInNewTab bool `json:"open_in_new_tab"`
+}
+
+// linkBehaviorRequest は記事本文リンクの開き方の設定更新リクエストのボディ。
+// 指定漏れ（false との区別）を検出するためポインタで受ける。
+type linkBehaviorRequest struct {
+	OpenInNewTab *bool `json:"open_in_new_tab"`
+}
+
+// prefetchResponse は利用時間帯に合わせたプリフェッチの設定のAPIレスポンス。
+type prefetchResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// prefetchRequest はプリフェッチの設定更新リクエストのボディ。
+// 指定漏れ（false との区別）を検出するためポインタで受ける。
+type prefetchRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// timezoneResponse はタイムゾーン設定のAPIレスポンス。
+type timezoneResponse struct {
+	Timezone string `json:"timezone"`
+}
+
+// timezoneRequest はタイムゾーン設定更新リクエストのボディ。
+type timezoneRequest struct {
+	Timezone string `json:"timezone"`
+}
+
+// GetUnreadWarning は自分の積読警告設定を返す。
+// GET /api/users/me/unread-warning
+func (h *UserSettingsHandler) GetUnreadWarning(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.GetUnreadWarning(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// UpdateUnreadWarning は自分の積読警告の閾値を更新する。
+// PUT /api/users/me/unread-warning
+func (h *UserSettingsHandler) UpdateUnreadWarning(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req unreadWarningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Threshold == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	// 閾値の範囲検証はサービス層に集約済み。
+	setting, err := h.service.UpdateUnreadWarning(r.Context(), userID, *req.Threshold)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// GetLinkBehavior は自分の記事本文リンクの開き方の設定を返す。
+// GET /api/users/me/link-behavior
+func (h *UserSettingsHandler) GetLinkBehavior(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.GetLinkBehavior(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// UpdateLinkBehavior は自分の記事本文リンクの開き方の設定を更新する。
+// PUT /api/users/me/link-behavior
+func (h *UserSettingsHandler) UpdateLinkBehavior(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req linkBehaviorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.OpenInNewTab == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.UpdateLinkBehavior(r.Context(), userID, *req.OpenInNewTab)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// GetPrefetch は自分の利用時間帯に合わせたプリフェッチの設定を返す。
+// GET /api/users/me/prefetch
+func (h *UserSettingsHandler) GetPrefetch(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.GetPrefetch(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// UpdatePrefetch は自分の利用時間帯に合わせたプリフェッチの設定を更新する。
+// PUT /api/users/me/prefetch
+func (h *UserSettingsHandler) UpdatePrefetch(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req prefetchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.UpdatePrefetch(r.Context(), userID, *req.Enabled)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// GetTimezone は自分のタイムゾーン設定を返す。
+// GET /api/users/me/timezone
+func (h *UserSettingsHandler) GetTimezone(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.GetTimezone(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
+
+// UpdateTimezone は自分のタイムゾーンを更新する。
+// PUT /api/users/me/timezone
+// 記事一覧の日付グルーピング（?group_dates=true）の日付境界に用いる。
+func (h *UserSettingsHandler) UpdateTimezone(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.UserIDFromContext(r.Context())
+	if err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		})
+		return
+	}
+
+	var req timezoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, &model.APIError{
+			Code:     model.ErrCodeInvalidRequest,
+			Message:  "リクエストボディの解析に失敗しました。",
+			Category: "validation",
+			Action:   "正しいJSON形式でリクエストしてください。",
+		})
+		return
+	}
+
+	setting, err := h.service.UpdateTimezone(r.Context(), userID, req.Timezone)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, setting)
+}
@@ -0,0 +1,544 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// --- モック定義 ---
+
+// mockUserSettingsService は UserSettingsServiceInterface のモック実装。
+type mockUserSettingsService struct {
+	getUnreadWarningFn    func(ctx context.Context, userID string) (*unreadWarningResponse, error)
+	updateUnreadWarningFn func(ctx context.Context, userID string, threshold int) (*unreadWarningResponse, error)
+	updateCalls           int
+	openInNewTab          bool
+	prefetchEnabled       bool
+	timezone              string
+}
+
+func (m *mockUserSettingsService) GetUnreadWarning(ctx context.Context, userID string) (*unreadWarningResponse, error) {
+	if m.getUnreadWarningFn != nil {
+		return m.getUnreadWarningFn(ctx, userID)
+	}
+	return &unreadWarningResponse{Threshold: model.DefaultUnreadWarningThreshold, Enabled: true}, nil
+}
+
+func (m *mockUserSettingsService) GetLinkBehavior(ctx context.Context, userID string) (*linkBehaviorResponse, error) {
+	return &linkBehaviorResponse{OpenInNewTab: m.openInNewTab}, nil
+}
+
+func (m *mockUserSettingsService) UpdateLinkBehavior(ctx context.Context, userID string, openInNewTab bool) (*linkBehaviorResponse, error) {
+	m.updateCalls++
+	m.openInNewTab = openInNewTab
+	return &linkBehaviorResponse{OpenInNewTab: openInNewTab}, nil
+}
+
+func (m *mockUserSettingsService) GetPrefetch(ctx context.Context, userID string) (*prefetchResponse, error) {
+	return &prefetchResponse{Enabled: m.prefetchEnabled}, nil
+}
+
+func (m *mockUserSettingsService) UpdatePrefetch(ctx context.Context, userID string, enabled bool) (*prefetchResponse, error) {
+	m.updateCalls++
+	m.prefetchEnabled = enabled
+	return &prefetchResponse{Enabled: enabled}, nil
+}
+
+func (m *mockUserSettingsService) GetTimezone(ctx context.Context, userID string) (*timezoneResponse, error) {
+	if m.timezone == "" {
+		return &timezoneResponse{Timezone: model.DefaultTimezone}, nil
+	}
+	return &timezoneResponse{Timezone: m.timezone}, nil
+}
+
+func (m *mockUserSettingsService) UpdateTimezone(ctx context.Context, userID, timezone string) (*timezoneResponse, error) {
+	m.updateCalls++
+	if timezone == "" || timezone == "Mars/Olympus" {
+		return nil, model.NewInvalidTimezoneError(timezone)
+	}
+	m.timezone = timezone
+	return &timezoneResponse{Timezone: timezone}, nil
+}
+
+func (m *mockUserSettingsService) UpdateUnreadWarning(ctx context.Context, userID string, threshold int) (*unreadWarningResponse, error) {
+	m.updateCalls++
+	if m.updateUnreadWarningFn != nil {
+		return m.updateUnreadWarningFn(ctx, userID, threshold)
+	}
+	return &unreadWarningResponse{Threshold: threshold, Enabled: threshold > 0}, nil
+}
+
+// --- GET /api/users/me/unread-warning テスト ---
+
+func TestUserSettingsHandler_GetUnreadWarning(t *testing.T) {
+	t.Run("認証済みのとき積読警告設定を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{
+			getUnreadWarningFn: func(_ context.Context, userID string) (*unreadWarningResponse, error) {
+				if userID != "user-123" {
+					t.Errorf("userID = %q, want %q", userID, "user-123")
+				}
+				return &unreadWarningResponse{Threshold: 300, Enabled: true}, nil
+			},
+		}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/unread-warning", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["threshold"] != float64(300) || body["enabled"] != true {
+			t.Errorf("body = %v, want threshold=300 enabled=true", body)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/unread-warning", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("サービスがエラーを返したとき500を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{
+			getUnreadWarningFn: func(_ context.Context, _ string) (*unreadWarningResponse, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/unread-warning", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
+
+// --- PUT /api/users/me/unread-warning テスト ---
+
+func TestUserSettingsHandler_UpdateUnreadWarning(t *testing.T) {
+	t.Run("有効な閾値のとき更新後の設定を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/unread-warning", strings.NewReader(`{"threshold":0}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if body["threshold"] != float64(0) || body["enabled"] != false {
+			t.Errorf("body = %v, want threshold=0 enabled=false", body)
+		}
+	})
+
+	t.Run("thresholdが未指定のとき400を返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/unread-warning", strings.NewReader(`{}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("UpdateUnreadWarning calls = %d, want 0", svc.updateCalls)
+		}
+	})
+
+	t.Run("不正なJSONのとき400を返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{})
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/unread-warning", strings.NewReader(`{invalid`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w); got["code"] != "INVALID_REQUEST" {
+			t.Errorf("code = %q, want %q", got["code"], "INVALID_REQUEST")
+		}
+	})
+
+	t.Run("閾値が範囲外のとき400を返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{
+			updateUnreadWarningFn: func(_ context.Context, _ string, threshold int) (*unreadWarningResponse, error) {
+				return nil, model.NewInvalidUnreadWarningThresholdError(threshold)
+			},
+		}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/unread-warning", strings.NewReader(`{"threshold":-1}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if got := parseAPIErrorResponse(t, w); got["code"] != model.ErrCodeInvalidUnreadWarningThreshold {
+			t.Errorf("code = %q, want %q", got["code"], model.ErrCodeInvalidUnreadWarningThreshold)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{})
+		req := httptest.NewRequest(http.MethodPut, "/api/users/me/unread-warning", strings.NewReader(`{"threshold":10}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateUnreadWarning(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- ルーティングテスト ---
+
+// --- /api/users/me/link-behavior テスト ---
+
+func TestUserSettingsHandler_LinkBehavior(t *testing.T) {
+	t.Run("GETのとき現在の設定を返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{openInNewTab: true})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/link-behavior", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetLinkBehavior(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != `{"open_in_new_tab":true}` {
+			t.Errorf("body = %s", body)
+		}
+	})
+
+	t.Run("PUTのとき設定を更新して返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{openInNewTab: true}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/link-behavior", strings.NewReader(`{"open_in_new_tab":false}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateLinkBehavior(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if svc.openInNewTab {
+			t.Error("設定が false に更新されるべき")
+		}
+	})
+
+	t.Run("open_in_new_tabが未指定のとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/link-behavior", strings.NewReader(`{}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateLinkBehavior(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidRequest {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("updateCalls = %d, want 0", svc.updateCalls)
+		}
+	})
+
+	t.Run("未認証のとき401を返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/link-behavior", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetLinkBehavior(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+// --- /api/users/me/prefetch テスト ---
+
+func TestUserSettingsHandler_Prefetch(t *testing.T) {
+	t.Run("GETのとき現在の設定を返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/prefetch", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetPrefetch(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != `{"enabled":false}` {
+			t.Errorf("body = %s", body)
+		}
+	})
+
+	t.Run("PUTのとき設定を更新して返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/prefetch", strings.NewReader(`{"enabled":true}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdatePrefetch(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if !svc.prefetchEnabled {
+			t.Error("設定が true に更新されるべき")
+		}
+	})
+
+	t.Run("enabledが未指定のとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/prefetch", strings.NewReader(`{}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdatePrefetch(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("updateCalls = %d, want 0", svc.updateCalls)
+		}
+	})
+}
+
+// --- /api/users/me/timezone テスト ---
+
+func TestUserSettingsHandler_Timezone(t *testing.T) {
+	t.Run("GETで未設定のときUTCを返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{})
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/users/me/timezone", nil), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.GetTimezone(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != `{"timezone":"UTC"}` {
+			t.Errorf("body = %s", body)
+		}
+	})
+
+	t.Run("PUTのとき設定を更新して返す", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/timezone", strings.NewReader(`{"timezone":"Asia/Tokyo"}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateTimezone(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != `{"timezone":"Asia/Tokyo"}` || svc.timezone != "Asia/Tokyo" {
+			t.Errorf("body = %s, timezone = %q", body, svc.timezone)
+		}
+	})
+
+	t.Run("無効なタイムゾーンのとき400 INVALID_TIMEZONEを返す", func(t *testing.T) {
+		// Arrange
+		h := NewUserSettingsHandler(&mockUserSettingsService{})
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/timezone", strings.NewReader(`{"timezone":"Mars/Olympus"}`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateTimezone(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if resp := parseAPIErrorResponse(t, w); resp["code"] != model.ErrCodeInvalidTimezone {
+			t.Errorf("code = %q, want %q", resp["code"], model.ErrCodeInvalidTimezone)
+		}
+	})
+
+	t.Run("ボディが不正なJSONのとき400 INVALID_REQUESTを返しサービスを呼ばない", func(t *testing.T) {
+		// Arrange
+		svc := &mockUserSettingsService{}
+		h := NewUserSettingsHandler(svc)
+		req := withUserID(httptest.NewRequest(http.MethodPut, "/api/users/me/timezone", strings.NewReader(`{`)), "user-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		h.UpdateTimezone(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+		if svc.updateCalls != 0 {
+			t.Errorf("updateCalls = %d, want 0", svc.updateCalls)
+		}
+	})
+}
+
+// TestNewRouter_UserSettingsRoutes は積読警告設定のルートが認証付きで登録されることを検証する。
+func TestNewRouter_UserSettingsRoutes(t *testing.T) {
+	newRouter := func(svc UserSettingsServiceInterface) http.Handler {
+		return NewRouter(&RouterDeps{
+			SessionFinder: &mockSessionFinderForRouter{
+				sessions: map[string]*model.Session{
+					"valid-session": {ID: "valid-session", UserID: "user-test-1", ExpiresAt: time.Now().Add(time.Hour)},
+				},
+			},
+			CORSAllowedOrigin:   "http://localhost:3000",
+			RateLimiter:         middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()),
+			AuthService:         &mockAuthService{},
+			FeedService:         &mockFeedService{},
+			ItemService:         &mockItemService{},
+			SubscriptionService: &mockSubscriptionService{},
+			UserService:         &mockUserService{},
+			UserSettingsService: svc,
+		})
+	}
+
+	t.Run("認証済みのとき取得・更新ルートが登録されている", func(t *testing.T) {
+		router := newRouter(&mockUserSettingsService{})
+		routes := []struct{ method, path, body string }{
+			{http.MethodGet, "/api/users/me/unread-warning", ""},
+			{http.MethodPut, "/api/users/me/unread-warning", `{"threshold":100}`},
+			{http.MethodGet, "/api/users/me/link-behavior", ""},
+			{http.MethodPut, "/api/users/me/link-behavior", `{"open_in_new_tab":false}`},
+			{http.MethodGet, "/api/users/me/prefetch", ""},
+			{http.MethodPut, "/api/users/me/prefetch", `{"enabled":true}`},
+			{http.MethodGet, "/api/users/me/timezone", ""},
+			{http.MethodPut, "/api/users/me/timezone", `{"timezone":"Europe/Berlin"}`},
+		}
+		for _, rt := range routes {
+			req := httptest.NewRequest(rt.method, rt.path, strings.NewReader(rt.body))
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("%s %s status = %d, want %d", rt.method, rt.path, w.Code, http.StatusOK)
+			}
+		}
+	})
+
+	t.Run("セッションなしのとき401を返す", func(t *testing.T) {
+		// Arrange
+		router := newRouter(&mockUserSettingsService{})
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/unread-warning", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("UserSettingsService が nil のときルートを登録しない", func(t *testing.T) {
+		// Arrange
+		router := newRouter(nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/users/me/unread-warning", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "valid-session"})
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound && w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("status = %d, want 404 or 405", w.Code)
+		}
+	})
+}
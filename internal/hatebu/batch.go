@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/hitoshi/feedman/internal/model"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
@@ -26,6 +27,18 @@ type BatchConfig struct {
 	MaxCallsPerCycle int
 	// HatebuTTL はブックマーク数の再取得間隔（デフォルト: 24時間）。
 	HatebuTTL time.Duration
+	// CountCacheTTL はURL単位のブックマーク数キャッシュの有効期間（デフォルト: 6時間）。
+	// 有効期間内に同じURLの記事が取得対象になった場合はAPIを呼ばずにキャッシュ値で更新する。
+	// 0 以下でキャッシュを無効化する。HatebuTTL より長い値は HatebuTTL に切り詰める。
+	CountCacheTTL time.Duration
+	// PriorityRecencyWeight は取得対象の選定で新しい記事を優先する重み（デフォルト: 1.0）。
+	// 0 で新しさを考慮しない。
+	PriorityRecencyWeight float64
+	// PriorityPopularityWeight は取得対象の選定ではてブ数の多い記事を優先する重み（デフォルト: 0.2）。
+	// ln(1 + はてブ数) に掛けるため、1000 件で約 7 倍になる。0 ではてブ数を考慮しない。
+	PriorityPopularityWeight float64
+	// PriorityRecencyHalfLife は新しさの優先度が半減するまでの経過時間（デフォルト: 24時間）。
+	PriorityRecencyHalfLife time.Duration
 }
 
 // DefaultBatchConfig はデフォルトのバッチジョブ設定を返す。
@@ -35,6 +48,11 @@ func DefaultBatchConfig() BatchConfig {
 		APIInterval:      5 * time.Second,
 		MaxCallsPerCycle: 100,
 		HatebuTTL:        24 * time.Hour,
+		CountCacheTTL:    6 * time.Hour,
+
+		PriorityRecencyWeight:    1.0,
+		PriorityPopularityWeight: 0.2,
+		PriorityRecencyHalfLife:  24 * time.Hour,
 	}
 }
 
@@ -42,12 +60,14 @@ func DefaultBatchConfig() BatchConfig {
 // 定期的にhatebu_fetched_atがNULLまたは24時間経過した記事を対象に
 // はてなブックマークAPIを呼び出してブックマーク数を更新する。
 type BatchJob struct {
-	itemRepo         repository.HatebuItemRepository
-	client           BookmarkCounter
-	logger           *slog.Logger
-	config           BatchConfig
+	itemRepo          repository.HatebuItemRepository
+	client            BookmarkCounter
+	logger            *slog.Logger
+	config            BatchConfig
+	cache             *countCache
+	now               func() time.Time
 	consecutiveErrors int
-	backoffUntil     time.Time
+	backoffUntil      time.Time
 }
 
 // NewBatchJob はBatchJobの新しいインスタンスを生成する。
@@ -57,11 +77,19 @@ func NewBatchJob(
 	logger *slog.Logger,
 	config BatchConfig,
 ) *BatchJob {
+	// キャッシュ値で更新した記事は取得時刻を引き継ぐため、HatebuTTL を超えて保持すると
+	// 同じ記事が毎サイクル取得対象に戻ってしまう。HatebuTTL を上限とする。
+	cacheTTL := config.CountCacheTTL
+	if cacheTTL > config.HatebuTTL {
+		cacheTTL = config.HatebuTTL
+	}
 	return &BatchJob{
 		itemRepo: itemRepo,
 		client:   client,
 		logger:   logger,
 		config:   config,
+		cache:    newCountCache(cacheTTL),
+		now:      time.Now,
 	}
 }
 
@@ -75,6 +103,9 @@ func (b *BatchJob) Start(ctx context.Context) {
 		slog.Duration("batch_interval", b.config.BatchInterval),
 		slog.Duration("api_interval", b.config.APIInterval),
 		slog.Int("max_calls_per_cycle", b.config.MaxCallsPerCycle),
+		slog.Float64("priority_recency_weight", b.config.PriorityRecencyWeight),
+		slog.Float64("priority_popularity_weight", b.config.PriorityPopularityWeight),
+		slog.Duration("priority_recency_half_life", b.config.PriorityRecencyHalfLife),
 	)
 
 	// 起動直後に1回実行
@@ -115,7 +146,7 @@ func (b *BatchJob) RunOnce(ctx context.Context) error {
 	// 取得対象記事の上限 = MaxCallsPerCycle * maxURLsPerRequest
 	fetchLimit := b.config.MaxCallsPerCycle * maxURLsPerRequest
 
-	items, err := b.itemRepo.ListNeedingHatebuFetch(ctx, fetchLimit)
+	items, err := b.itemRepo.ListNeedingHatebuFetch(ctx, fetchLimit, b.priority())
 	if err != nil {
 		return fmt.Errorf("はてブ取得対象記事の取得に失敗しました: %w", err)
 	}
@@ -152,20 +183,29 @@ func (b *BatchJob) RunOnce(ctx context.Context) error {
 		urlToItemIDs[vi.url] = append(urlToItemIDs[vi.url], vi.id)
 	}
 
-	// ユニークなURLリストを構築
+	// ユニークなURLリストを構築する。キャッシュが有効なURLはAPIを呼ばずにキャッシュ値で更新する。
+	var apiCallCount int
+	var updatedCount int
+	var cacheHitCount int
+	var hadError bool
+
+	b.cache.prune(b.now())
 	var uniqueURLs []string
 	seen := make(map[string]bool)
 	for _, vi := range validItems {
-		if !seen[vi.url] {
-			seen[vi.url] = true
-			uniqueURLs = append(uniqueURLs, vi.url)
+		if seen[vi.url] {
+			continue
+		}
+		seen[vi.url] = true
+		if cached, ok := b.cache.get(vi.url, b.now()); ok {
+			cacheHitCount++
+			updatedCount += b.updateItemCounts(ctx, vi.url, urlToItemIDs[vi.url], cached.count, cached.fetchedAt)
+			continue
 		}
+		uniqueURLs = append(uniqueURLs, vi.url)
 	}
 
 	// 50URL単位でチャンクに分割してAPI呼び出し
-	var apiCallCount int
-	var updatedCount int
-	var hadError bool
 
 	for i := 0; i < len(uniqueURLs); i += maxURLsPerRequest {
 		// コンテキストチェック
@@ -223,47 +263,13 @@ func (b *BatchJob) RunOnce(ctx context.Context) error {
 			continue // このチャンクはスキップし次のチャンクへ（前回値維持）
 		}
 
-		// 取得成功: 各記事のブックマーク数を更新
-		now := time.Now()
-		for url, count := range counts {
-			itemIDs, ok := urlToItemIDs[url]
-			if !ok {
-				continue
-			}
-			for _, itemID := range itemIDs {
-				if err := b.itemRepo.UpdateHatebuCount(ctx, itemID, count, now); err != nil {
-					b.logger.Error("はてなブックマーク数の更新に失敗しました",
-						slog.String("item_id", itemID),
-						slog.String("url", url),
-						slog.Int("count", count),
-						slog.String("error", err.Error()),
-					)
-				} else {
-					updatedCount++
-				}
-			}
-		}
-
-		// レスポンスに含まれないURL（0件）も更新する
+		// 取得成功: 各記事のブックマーク数を更新する。
+		// レスポンスに含まれないURLは0件として更新し、いずれもキャッシュに記録する。
+		now := b.now()
 		for _, url := range chunk {
-			if _, ok := counts[url]; !ok {
-				itemIDs, exists := urlToItemIDs[url]
-				if !exists {
-					continue
-				}
-				for _, itemID := range itemIDs {
-					if err := b.itemRepo.UpdateHatebuCount(ctx, itemID, 0, now); err != nil {
-						b.logger.Error("はてなブックマーク数の更新に失敗しました",
-							slog.String("item_id", itemID),
-							slog.String("url", url),
-							slog.Int("count", 0),
-							slog.String("error", err.Error()),
-						)
-					} else {
-						updatedCount++
-					}
-				}
-			}
+			count := counts[url]
+			b.cache.set(url, count, now)
+			updatedCount += b.updateItemCounts(ctx, url, urlToItemIDs[url], count, now)
 		}
 	}
 
@@ -276,6 +282,7 @@ func (b *BatchJob) RunOnce(ctx context.Context) error {
 	duration := time.Since(start)
 	b.logger.Info("はてなブックマークバッチサイクルが完了しました",
 		slog.Int("api_call_count", apiCallCount),
+		slog.Int("cache_hit_urls", cacheHitCount),
 		slog.Int("updated_items", updatedCount),
 		slog.Int("target_items", len(validItems)),
 		slog.Float64("duration_ms", float64(duration.Milliseconds())),
@@ -284,6 +291,34 @@ func (b *BatchJob) RunOnce(ctx context.Context) error {
 	return nil
 }
 
+// priority は取得対象の選定に使う優先度スコアの重みを返す。
+func (b *BatchJob) priority() model.HatebuFetchPriority {
+	return model.HatebuFetchPriority{
+		RecencyWeight:    b.config.PriorityRecencyWeight,
+		PopularityWeight: b.config.PriorityPopularityWeight,
+		RecencyHalfLife:  b.config.PriorityRecencyHalfLife,
+	}
+}
+
+// updateItemCounts は同じURLを持つ記事のブックマーク数をまとめて更新し、更新できた件数を返す。
+// 個別の更新失敗はログに記録して継続する。
+func (b *BatchJob) updateItemCounts(ctx context.Context, url string, itemIDs []string, count int, fetchedAt time.Time) int {
+	var updated int
+	for _, itemID := range itemIDs {
+		if err := b.itemRepo.UpdateHatebuCount(ctx, itemID, count, fetchedAt); err != nil {
+			b.logger.Error("はてなブックマーク数の更新に失敗しました",
+				slog.String("item_id", itemID),
+				slog.String("url", url),
+				slog.Int("count", count),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		updated++
+	}
+	return updated
+}
+
 // calculateErrorBackoff は連続エラー回数に基づくバックオフ時間を計算する。
 // 3回連続: 30分、5回連続: 1時間、10回連続: 6時間。
 func (b *BatchJob) calculateErrorBackoff(consecutiveErrors int) time.Duration {
@@ -298,4 +333,3 @@ func (b *BatchJob) calculateErrorBackoff(consecutiveErrors int) time.Duration {
 		return 0
 	}
 }
-
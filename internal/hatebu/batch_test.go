@@ -22,9 +22,12 @@ import (
 type mockItemRepo struct {
 	listNeedingHatebuFetchFunc func(ctx context.Context, limit int) ([]*model.Item, error)
 	updateHatebuCountFunc      func(ctx context.Context, itemID string, count int, fetchedAt time.Time) error
+	// gotPriority は ListNeedingHatebuFetch に渡された優先度の重み。
+	gotPriority model.HatebuFetchPriority
 }
 
-func (m *mockItemRepo) ListNeedingHatebuFetch(ctx context.Context, limit int) ([]*model.Item, error) {
+func (m *mockItemRepo) ListNeedingHatebuFetch(ctx context.Context, limit int, priority model.HatebuFetchPriority) ([]*model.Item, error) {
+	m.gotPriority = priority
 	if m.listNeedingHatebuFetchFunc != nil {
 		return m.listNeedingHatebuFetchFunc(ctx, limit)
 	}
@@ -82,6 +85,15 @@ func TestDefaultBatchConfig(t *testing.T) {
 	if cfg.HatebuTTL != 24*time.Hour {
 		t.Errorf("HatebuTTL = %v, want 24h", cfg.HatebuTTL)
 	}
+	if cfg.CountCacheTTL != 6*time.Hour {
+		t.Errorf("CountCacheTTL = %v, want 6h", cfg.CountCacheTTL)
+	}
+	if cfg.PriorityRecencyWeight != 1.0 || cfg.PriorityPopularityWeight != 0.2 {
+		t.Errorf("Priority weights = (%v, %v), want (1.0, 0.2)", cfg.PriorityRecencyWeight, cfg.PriorityPopularityWeight)
+	}
+	if cfg.PriorityRecencyHalfLife != 24*time.Hour {
+		t.Errorf("PriorityRecencyHalfLife = %v, want 24h", cfg.PriorityRecencyHalfLife)
+	}
 }
 
 func TestBatchJob_RunOnce_NoItems(t *testing.T) {
@@ -456,6 +468,25 @@ func TestBatchJob_RunOnce_LimitPassedToRepo(t *testing.T) {
 	}
 }
 
+func TestBatchJob_RunOnce_PriorityPassedToRepo(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	repo := &mockItemRepo{}
+	cfg := DefaultBatchConfig()
+	cfg.PriorityRecencyWeight = 2.5
+	cfg.PriorityPopularityWeight = 0
+	cfg.PriorityRecencyHalfLife = 6 * time.Hour
+
+	job := NewBatchJob(repo, &mockHatebuClient{}, logger, cfg)
+	_ = job.RunOnce(context.Background())
+
+	want := model.HatebuFetchPriority{RecencyWeight: 2.5, PopularityWeight: 0, RecencyHalfLife: 6 * time.Hour}
+	if repo.gotPriority != want {
+		t.Errorf("リポジトリに渡された優先度 = %+v, want %+v", repo.gotPriority, want)
+	}
+}
+
 func TestBatchJob_ConsecutiveErrorBackoff(t *testing.T) {
 	var buf bytes.Buffer
 	logger := newTestLogger(&buf)
@@ -766,3 +797,149 @@ func TestBatchJob_RunOnce_ZeroBookmarkItemsAlsoUpdated(t *testing.T) {
 		t.Errorf("0件ブックマーク更新時のcount = %d, want 0", updatedCount)
 	}
 }
+
+func TestBatchJob_RunOnce_DeduplicatesURLsAcrossItems(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	// 別フィードの記事が同じURLを指している
+	items := []*model.Item{
+		{ID: "item-1", Link: "https://example.com/shared"},
+		{ID: "item-2", Link: "https://example.com/shared"},
+		{ID: "item-3", Link: "https://example.com/other"},
+	}
+
+	updated := make(map[string]int)
+	repo := &mockItemRepo{
+		listNeedingHatebuFetchFunc: func(ctx context.Context, limit int) ([]*model.Item, error) {
+			return items, nil
+		},
+		updateHatebuCountFunc: func(ctx context.Context, itemID string, count int, fetchedAt time.Time) error {
+			updated[itemID] = count
+			return nil
+		},
+	}
+
+	var requestedURLs []string
+	client := &mockHatebuClient{
+		getBookmarkCountsFunc: func(ctx context.Context, urls []string) (map[string]int, error) {
+			requestedURLs = append(requestedURLs, urls...)
+			return map[string]int{"https://example.com/shared": 7, "https://example.com/other": 3}, nil
+		},
+	}
+
+	job := NewBatchJob(repo, client, logger, DefaultBatchConfig())
+	if err := job.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce がエラーを返した: %v", err)
+	}
+
+	if len(requestedURLs) != 2 {
+		t.Errorf("APIに問い合わせたURL = %v, want 重複なしの2件", requestedURLs)
+	}
+	if updated["item-1"] != 7 || updated["item-2"] != 7 || updated["item-3"] != 3 {
+		t.Errorf("updated = %v", updated)
+	}
+}
+
+func TestBatchJob_RunOnce_CountCache(t *testing.T) {
+	newJob := func(cacheTTL time.Duration, apiCalls *int, fetchedAts map[string]time.Time) *BatchJob {
+		var buf bytes.Buffer
+		var cycle int
+		repo := &mockItemRepo{
+			listNeedingHatebuFetchFunc: func(ctx context.Context, limit int) ([]*model.Item, error) {
+				// サイクルごとに別フィードの記事が同じURLで取り込まれた想定
+				cycle++
+				return []*model.Item{{ID: fmt.Sprintf("item-%d", cycle), Link: "https://example.com/a1"}}, nil
+			},
+			updateHatebuCountFunc: func(ctx context.Context, itemID string, count int, fetchedAt time.Time) error {
+				fetchedAts[itemID] = fetchedAt
+				return nil
+			},
+		}
+		client := &mockHatebuClient{
+			getBookmarkCountsFunc: func(ctx context.Context, urls []string) (map[string]int, error) {
+				*apiCalls++
+				return map[string]int{"https://example.com/a1": 42}, nil
+			},
+		}
+		cfg := DefaultBatchConfig()
+		cfg.CountCacheTTL = cacheTTL
+		return NewBatchJob(repo, client, newTestLogger(&buf), cfg)
+	}
+
+	t.Run("キャッシュ有効期間内のとき同じURLをAPIに問い合わせず取得時刻を引き継ぐ", func(t *testing.T) {
+		// Arrange
+		var apiCalls int
+		fetchedAts := make(map[string]time.Time)
+		job := newJob(6*time.Hour, &apiCalls, fetchedAts)
+		first := time.Date(2026, 6, 11, 0, 0, 0, 0, time.UTC)
+		job.now = func() time.Time { return first }
+		_ = job.RunOnce(context.Background())
+
+		// Act
+		job.now = func() time.Time { return first.Add(time.Hour) }
+		_ = job.RunOnce(context.Background())
+
+		// Assert
+		if apiCalls != 1 {
+			t.Errorf("API呼び出し回数 = %d, want 1", apiCalls)
+		}
+		if len(fetchedAts) != 2 {
+			t.Fatalf("更新された記事 = %v, want 2件", fetchedAts)
+		}
+		for itemID, at := range fetchedAts {
+			if !at.Equal(first) {
+				t.Errorf("%s の fetchedAt = %v, want %v", itemID, at, first)
+			}
+		}
+	})
+
+	t.Run("キャッシュ有効期間を過ぎたときAPIに再度問い合わせる", func(t *testing.T) {
+		// Arrange
+		var apiCalls int
+		job := newJob(6*time.Hour, &apiCalls, make(map[string]time.Time))
+		first := time.Date(2026, 6, 11, 0, 0, 0, 0, time.UTC)
+		job.now = func() time.Time { return first }
+		_ = job.RunOnce(context.Background())
+
+		// Act
+		job.now = func() time.Time { return first.Add(6 * time.Hour) }
+		_ = job.RunOnce(context.Background())
+
+		// Assert
+		if apiCalls != 2 {
+			t.Errorf("API呼び出し回数 = %d, want 2", apiCalls)
+		}
+	})
+
+	t.Run("CountCacheTTLが0のときキャッシュしない", func(t *testing.T) {
+		// Arrange
+		var apiCalls int
+		job := newJob(0, &apiCalls, make(map[string]time.Time))
+		_ = job.RunOnce(context.Background())
+
+		// Act
+		_ = job.RunOnce(context.Background())
+
+		// Assert
+		if apiCalls != 2 {
+			t.Errorf("API呼び出し回数 = %d, want 2", apiCalls)
+		}
+	})
+
+	t.Run("CountCacheTTLがHatebuTTLより長いときHatebuTTLに切り詰める", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		cfg := DefaultBatchConfig()
+		cfg.HatebuTTL = time.Hour
+		cfg.CountCacheTTL = 6 * time.Hour
+
+		// Act
+		job := NewBatchJob(&mockItemRepo{}, &mockHatebuClient{}, newTestLogger(&buf), cfg)
+
+		// Assert
+		if job.cache.ttl != time.Hour {
+			t.Errorf("cache ttl = %v, want %v", job.cache.ttl, time.Hour)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package hatebu
+
+import "time"
+
+// cachedCount はキャッシュされたブックマーク数と、その値をAPIから取得した時刻。
+type cachedCount struct {
+	count     int
+	fetchedAt time.Time
+}
+
+// countCache はURL単位のブックマーク数の短期キャッシュ。
+// 同じURLの記事が別フィードから後続サイクルで取り込まれた場合に、同じURLを再度APIへ問い合わせるのを防ぐ。
+// BatchJob のサイクルは逐次実行されるため排他制御は行わない。
+type countCache struct {
+	ttl     time.Duration
+	entries map[string]cachedCount
+}
+
+// newCountCache は countCache を生成する。ttl が 0 以下の場合はキャッシュしない。
+func newCountCache(ttl time.Duration) *countCache {
+	return &countCache{ttl: ttl, entries: make(map[string]cachedCount)}
+}
+
+// get は now 時点で有効なキャッシュ値を返す。
+func (c *countCache) get(url string, now time.Time) (cachedCount, bool) {
+	entry, ok := c.entries[url]
+	if !ok || now.Sub(entry.fetchedAt) >= c.ttl {
+		return cachedCount{}, false
+	}
+	return entry, true
+}
+
+// set はAPIから取得したブックマーク数を記録する。
+func (c *countCache) set(url string, count int, fetchedAt time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.entries[url] = cachedCount{count: count, fetchedAt: fetchedAt}
+}
+
+// prune は期限切れのエントリを削除する。サイクル開始時に呼び、キャッシュが際限なく増えるのを防ぐ。
+func (c *countCache) prune(now time.Time) {
+	for url, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) >= c.ttl {
+			delete(c.entries, url)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package hatebu
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// OnDemandConfig は記事詳細の表示を契機とするはてブ数のオンデマンド更新の設定。
+type OnDemandConfig struct {
+	// StaleAfter はオンデマンド更新の対象とする鮮度の閾値（デフォルト: 1時間）。
+	// hatebu_fetched_at がこれより古い、または未取得の記事を再取得する。
+	StaleAfter time.Duration
+	// APIInterval はAPI呼び出しの最低間隔（デフォルト: 5秒）。0 で間隔を空けない。
+	APIInterval time.Duration
+	// BufferSize は更新待ちとして保持できる記事数（デフォルト: 256）。超過分は破棄する。
+	BufferSize int
+}
+
+// DefaultOnDemandConfig はデフォルトのオンデマンド更新設定を返す。
+func DefaultOnDemandConfig() OnDemandConfig {
+	return OnDemandConfig{
+		StaleAfter:  time.Hour,
+		APIInterval: 5 * time.Second,
+		BufferSize:  256,
+	}
+}
+
+// refreshRequest はオンデマンド更新の依頼 1 件。
+type refreshRequest struct {
+	itemID string
+	url    string
+}
+
+// OnDemandRefresher は記事詳細の表示時に古いはてブ数を非同期で再取得する。
+// RequestRefresh はノンブロッキングで、API 呼び出しと保存は Run を実行する goroutine が行う。
+// 表示中のレスポンスには反映せず、次回の表示で新しい値が出ることを狙う。
+// 定期バッチ（BatchJob）の取得周期（HatebuTTL）を待たずに、読まれている記事だけを先に更新する位置づけ。
+type OnDemandRefresher struct {
+	itemRepo repository.HatebuItemRepository
+	client   BookmarkCounter
+	cfg      OnDemandConfig
+	logger   *slog.Logger
+	requests chan refreshRequest
+	now      func() time.Time
+
+	mu sync.Mutex
+	// pending はキュー投入済みで更新が終わっていない記事ID。同じ記事の重複投入を防ぐ。
+	pending map[string]struct{}
+}
+
+// NewOnDemandRefresher は OnDemandRefresher を生成する。
+// StaleAfter・BufferSize が 0 以下、APIInterval が負の場合はデフォルト値で補う。
+func NewOnDemandRefresher(
+	itemRepo repository.HatebuItemRepository,
+	client BookmarkCounter,
+	logger *slog.Logger,
+	cfg OnDemandConfig,
+) *OnDemandRefresher {
+	def := DefaultOnDemandConfig()
+	if cfg.StaleAfter <= 0 {
+		cfg.StaleAfter = def.StaleAfter
+	}
+	if cfg.APIInterval < 0 {
+		cfg.APIInterval = def.APIInterval
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = def.BufferSize
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &OnDemandRefresher{
+		itemRepo: itemRepo,
+		client:   client,
+		cfg:      cfg,
+		logger:   logger,
+		requests: make(chan refreshRequest, cfg.BufferSize),
+		now:      time.Now,
+		pending:  make(map[string]struct{}),
+	}
+}
+
+// RequestRefresh は hatebu_fetched_at（fetchedAt）が StaleAfter より古い、または未取得の記事の再取得をキューに積む。
+// リンクの無い記事、鮮度が十分な記事、更新待ちの記事は何もしない。バッファが満杯の場合は破棄して即座に戻る。
+func (r *OnDemandRefresher) RequestRefresh(itemID, url string, fetchedAt *time.Time) {
+	if url == "" {
+		return
+	}
+	if fetchedAt != nil && r.now().Sub(*fetchedAt) < r.cfg.StaleAfter {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.pending[itemID]; ok {
+		return
+	}
+	select {
+	case r.requests <- refreshRequest{itemID: itemID, url: url}:
+		r.pending[itemID] = struct{}{}
+	default:
+		// オンデマンド更新は定期バッチの補完のため、溢れた分は定期バッチに任せる
+	}
+}
+
+// Run はキューの依頼を最大50URLずつまとめてAPIで取得し、ブックマーク数を更新する。
+// API呼び出しの間は APIInterval 以上空ける。ctx がキャンセルされると残りの依頼を破棄して戻る。
+func (r *OnDemandRefresher) Run(ctx context.Context) {
+	for {
+		var first refreshRequest
+		select {
+		case <-ctx.Done():
+			return
+		case first = <-r.requests:
+		}
+
+		r.refresh(ctx, r.collect(first))
+
+		if r.cfg.APIInterval > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(r.cfg.APIInterval):
+			}
+		}
+	}
+}
+
+// collect は first に続けて、キューに溜まっている依頼を待たずに1リクエスト分（最大50URL）まで取り出す。
+func (r *OnDemandRefresher) collect(first refreshRequest) []refreshRequest {
+	batch := []refreshRequest{first}
+	urls := map[string]struct{}{first.url: {}}
+	for len(urls) < maxURLsPerRequest {
+		select {
+		case req := <-r.requests:
+			batch = append(batch, req)
+			urls[req.url] = struct{}{}
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// refresh は batch の記事のブックマーク数を取得して保存する。
+// 取得に失敗した場合は前回値を維持し、定期バッチの再取得に任せる。
+func (r *OnDemandRefresher) refresh(ctx context.Context, batch []refreshRequest) {
+	defer r.release(batch)
+
+	var urls []string
+	seen := make(map[string]bool, len(batch))
+	for _, req := range batch {
+		if !seen[req.url] {
+			seen[req.url] = true
+			urls = append(urls, req.url)
+		}
+	}
+
+	counts, err := r.client.GetBookmarkCounts(ctx, urls)
+	if err != nil {
+		r.logger.Warn("はてなブックマーク数のオンデマンド取得に失敗しました",
+			slog.Int("url_count", len(urls)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	now := r.now()
+	for _, req := range batch {
+		if err := r.itemRepo.UpdateHatebuCount(ctx, req.itemID, counts[req.url], now); err != nil {
+			r.logger.Error("はてなブックマーク数の更新に失敗しました",
+				slog.String("item_id", req.itemID),
+				slog.String("url", req.url),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// release は batch の記事を更新待ちから外し、再び依頼を受け付けられるようにする。
+func (r *OnDemandRefresher) release(batch []refreshRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, req := range batch {
+		delete(r.pending, req.itemID)
+	}
+}
@@ -0,0 +1,193 @@
+package hatebu
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestOnDemandRefresher(repo *mockItemRepo, client *mockHatebuClient, now time.Time) *OnDemandRefresher {
+	var buf bytes.Buffer
+	r := NewOnDemandRefresher(repo, client, newTestLogger(&buf), OnDemandConfig{
+		StaleAfter: time.Hour,
+		BufferSize: 4,
+	})
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func TestDefaultOnDemandConfig(t *testing.T) {
+	cfg := DefaultOnDemandConfig()
+	if cfg.StaleAfter != time.Hour {
+		t.Errorf("StaleAfter = %v, want 1h", cfg.StaleAfter)
+	}
+	if cfg.APIInterval != 5*time.Second {
+		t.Errorf("APIInterval = %v, want 5s", cfg.APIInterval)
+	}
+	if cfg.BufferSize != 256 {
+		t.Errorf("BufferSize = %d, want 256", cfg.BufferSize)
+	}
+}
+
+func TestOnDemandRefresher_RequestRefresh(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	fresh := now.Add(-30 * time.Minute)
+	stale := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		url       string
+		fetchedAt *time.Time
+		want      int
+	}{
+		{name: "閾値より古いとき更新をキューに積む", url: "https://example.com/a", fetchedAt: &stale, want: 1},
+		{name: "未取得のとき更新をキューに積む", url: "https://example.com/a", fetchedAt: nil, want: 1},
+		{name: "閾値より新しいとき何もしない", url: "https://example.com/a", fetchedAt: &fresh, want: 0},
+		{name: "リンクが無いとき何もしない", url: "", fetchedAt: &stale, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestOnDemandRefresher(&mockItemRepo{}, &mockHatebuClient{}, now)
+
+			r.RequestRefresh("item-1", tt.url, tt.fetchedAt)
+
+			if got := len(r.requests); got != tt.want {
+				t.Errorf("キューの件数 = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnDemandRefresher_RequestRefresh_DeduplicatesAndDropsWhenFull(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	r := newTestOnDemandRefresher(&mockItemRepo{}, &mockHatebuClient{}, now)
+
+	// 同じ記事の重複投入は 1 件にまとめる
+	r.RequestRefresh("item-1", "https://example.com/1", nil)
+	r.RequestRefresh("item-1", "https://example.com/1", nil)
+	if got := len(r.requests); got != 1 {
+		t.Fatalf("重複投入後のキューの件数 = %d, want 1", got)
+	}
+
+	// バッファ（4 件）を超えた分は破棄し、ブロックしない
+	for _, id := range []string{"item-2", "item-3", "item-4", "item-5"} {
+		r.RequestRefresh(id, "https://example.com/"+id, nil)
+	}
+	if got := len(r.requests); got != 4 {
+		t.Errorf("キューの件数 = %d, want 4", got)
+	}
+	if _, ok := r.pending["item-5"]; ok {
+		t.Error("破棄した記事が更新待ちとして残っている")
+	}
+}
+
+func TestOnDemandRefresher_Refresh_UpdatesCounts(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var gotURLs []string
+	client := &mockHatebuClient{
+		getBookmarkCountsFunc: func(ctx context.Context, urls []string) (map[string]int, error) {
+			gotURLs = urls
+			return map[string]int{"https://example.com/shared": 42, "https://example.com/b": 7}, nil
+		},
+	}
+	updated := make(map[string]int)
+	repo := &mockItemRepo{
+		updateHatebuCountFunc: func(ctx context.Context, itemID string, count int, fetchedAt time.Time) error {
+			if !fetchedAt.Equal(now) {
+				t.Errorf("fetchedAt = %v, want %v", fetchedAt, now)
+			}
+			updated[itemID] = count
+			return nil
+		},
+	}
+	r := newTestOnDemandRefresher(repo, client, now)
+	r.RequestRefresh("item-1", "https://example.com/shared", nil)
+	r.RequestRefresh("item-2", "https://example.com/shared", nil)
+	r.RequestRefresh("item-3", "https://example.com/b", nil)
+
+	r.refresh(context.Background(), r.collect(<-r.requests))
+
+	if len(gotURLs) != 2 {
+		t.Errorf("APIに渡したURL = %v, want 重複を除いた 2 件", gotURLs)
+	}
+	want := map[string]int{"item-1": 42, "item-2": 42, "item-3": 7}
+	for id, count := range want {
+		if updated[id] != count {
+			t.Errorf("%s のはてブ数 = %d, want %d", id, updated[id], count)
+		}
+	}
+	if len(r.pending) != 0 {
+		t.Errorf("更新後も更新待ちが残っている: %v", r.pending)
+	}
+}
+
+func TestOnDemandRefresher_Refresh_KeepsPreviousCountOnError(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	client := &mockHatebuClient{
+		getBookmarkCountsFunc: func(ctx context.Context, urls []string) (map[string]int, error) {
+			return nil, errors.New("api unavailable")
+		},
+	}
+	repo := &mockItemRepo{
+		updateHatebuCountFunc: func(ctx context.Context, itemID string, count int, fetchedAt time.Time) error {
+			t.Errorf("API失敗時に %s のはてブ数を更新してはならない", itemID)
+			return nil
+		},
+	}
+	r := newTestOnDemandRefresher(repo, client, now)
+	r.RequestRefresh("item-1", "https://example.com/1", nil)
+
+	r.refresh(context.Background(), r.collect(<-r.requests))
+
+	// 失敗した記事は次回の表示で再び依頼できる
+	r.RequestRefresh("item-1", "https://example.com/1", nil)
+	if got := len(r.requests); got != 1 {
+		t.Errorf("失敗後に再依頼したキューの件数 = %d, want 1", got)
+	}
+}
+
+func TestOnDemandRefresher_Run(t *testing.T) {
+	var mu sync.Mutex
+	done := make(chan struct{})
+	repo := &mockItemRepo{
+		updateHatebuCountFunc: func(ctx context.Context, itemID string, count int, fetchedAt time.Time) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if itemID == "item-1" && count == 3 {
+				close(done)
+			}
+			return nil
+		},
+	}
+	client := &mockHatebuClient{
+		getBookmarkCountsFunc: func(ctx context.Context, urls []string) (map[string]int, error) {
+			return map[string]int{"https://example.com/1": 3}, nil
+		},
+	}
+	var buf bytes.Buffer
+	r := NewOnDemandRefresher(repo, client, newTestLogger(&buf), OnDemandConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(stopped)
+	}()
+
+	r.RequestRefresh("item-1", "https://example.com/1", nil)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("オンデマンド更新が実行されなかった")
+	}
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ctx のキャンセル後に Run が戻らなかった")
+	}
+}
@@ -0,0 +1,64 @@
+package highlight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/worker/periodic"
+)
+
+// DefaultComputeInterval はハイライトの事前計算ジョブの実行間隔の既定値。
+const DefaultComputeInterval = 24 * time.Hour
+
+// DefaultWeights はハイライトのスコアの重みの既定値。
+// スターは明示的な評価のため、はてブ数・閲覧数より重く扱う。
+var DefaultWeights = model.HighlightWeights{Hatebu: 1.0, Star: 2.0, View: 1.0}
+
+// ComputeJob は集計期間（model.HighlightPeriods）ごとに記事のスコアを計算して item_highlights を置き換える worker ジョブ。
+// ユーザーごとの上位 model.HighlightLimit 件を正しく選べるよう、フィードごとに上位 model.HighlightLimit 件を保存する。
+type ComputeJob struct {
+	repo     repository.HighlightRepository
+	weights  model.HighlightWeights
+	logger   *slog.Logger
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewComputeJob は ComputeJob を生成する。interval が 0 以下の場合は DefaultComputeInterval を使う。
+func NewComputeJob(repo repository.HighlightRepository, weights model.HighlightWeights, logger *slog.Logger, interval time.Duration) *ComputeJob {
+	if interval <= 0 {
+		interval = DefaultComputeInterval
+	}
+	return &ComputeJob{repo: repo, weights: weights, logger: logger, interval: interval, now: time.Now}
+}
+
+// RunOnce はすべての集計期間のハイライトを計算し直す。
+// ある期間の計算に失敗しても残りの期間は計算し、失敗した期間のエラーをまとめて返す（前回の結果はそのまま残る）。
+func (j *ComputeJob) RunOnce(ctx context.Context) error {
+	var errs []error
+	for _, period := range model.HighlightPeriods {
+		start := j.now()
+		stored, err := j.repo.ReplaceHighlights(ctx, period, start.Add(-period.Window()), start, j.weights, model.HighlightLimit)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("ハイライト（%s）の計算に失敗: %w", period, err))
+			continue
+		}
+
+		j.logger.Info("ハイライトを計算しました",
+			slog.String("period", string(period)),
+			slog.Int64("stored", stored),
+			slog.Float64("duration_ms", float64(j.now().Sub(start).Milliseconds())),
+		)
+	}
+	return errors.Join(errs...)
+}
+
+// Start は起動直後と interval ごとに RunOnce を実行する。ctx がキャンセルされるまでブロックする。
+func (j *ComputeJob) Start(ctx context.Context) {
+	periodic.Run(ctx, j.logger, "ハイライトの計算", j.interval, j.RunOnce)
+}
@@ -0,0 +1,162 @@
+package highlight
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// replaceCall は ReplaceHighlights の呼び出し 1 回分の引数。
+type replaceCall struct {
+	period     model.HighlightPeriod
+	since      time.Time
+	computedAt time.Time
+	weights    model.HighlightWeights
+	perFeed    int
+}
+
+// mockHighlightRepo は repository.HighlightRepository のモック実装。
+type mockHighlightRepo struct {
+	replaceCalls []replaceCall
+	replaceErr   map[model.HighlightPeriod]error
+
+	listFn func(ctx context.Context, userID string, period model.HighlightPeriod, limit int) ([]model.HighlightItem, error)
+}
+
+func (m *mockHighlightRepo) ReplaceHighlights(_ context.Context, period model.HighlightPeriod, since, computedAt time.Time, weights model.HighlightWeights, perFeed int) (int64, error) {
+	m.replaceCalls = append(m.replaceCalls, replaceCall{period, since, computedAt, weights, perFeed})
+	return 3, m.replaceErr[period]
+}
+
+func (m *mockHighlightRepo) ListHighlightsByUser(ctx context.Context, userID string, period model.HighlightPeriod, limit int) ([]model.HighlightItem, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, userID, period, limit)
+	}
+	return nil, nil
+}
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+}
+
+func TestService_List(t *testing.T) {
+	t.Run("期間の指定がないときウィークリーのハイライトを返す", func(t *testing.T) {
+		// Arrange
+		computedAt := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+		var gotPeriod model.HighlightPeriod
+		var gotLimit int
+		repo := &mockHighlightRepo{
+			listFn: func(_ context.Context, _ string, period model.HighlightPeriod, limit int) ([]model.HighlightItem, error) {
+				gotPeriod, gotLimit = period, limit
+				return []model.HighlightItem{{ItemID: "item-1", Score: 4.2, ComputedAt: computedAt}}, nil
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		result, err := svc.List(context.Background(), "user-1", "")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if gotPeriod != model.HighlightPeriodWeek || gotLimit != model.HighlightLimit {
+			t.Errorf("period = %q, limit = %d, want week, %d", gotPeriod, gotLimit, model.HighlightLimit)
+		}
+		if result.Period != model.HighlightPeriodWeek || len(result.Items) != 1 {
+			t.Errorf("result = %+v", result)
+		}
+		if result.ComputedAt == nil || !result.ComputedAt.Equal(computedAt) {
+			t.Errorf("ComputedAt = %v, want %v", result.ComputedAt, computedAt)
+		}
+	})
+
+	t.Run("ハイライトがないとき空の配列と計算日時なしを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockHighlightRepo{})
+
+		// Act
+		result, err := svc.List(context.Background(), "user-1", "day")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if result.Period != model.HighlightPeriodDay || result.Items == nil || len(result.Items) != 0 || result.ComputedAt != nil {
+			t.Errorf("result = %+v", result)
+		}
+	})
+
+	t.Run("期間の指定が不正なときINVALID_HIGHLIGHT_PERIODを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockHighlightRepo{})
+
+		// Act
+		_, err := svc.List(context.Background(), "user-1", "month")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidHighlightPeriod {
+			t.Errorf("err = %v, want %s", err, model.ErrCodeInvalidHighlightPeriod)
+		}
+	})
+}
+
+func TestComputeJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	t.Run("集計期間ごとに期間の長さだけ遡ってスコアを計算するとき", func(t *testing.T) {
+		// Arrange
+		repo := &mockHighlightRepo{}
+		weights := model.HighlightWeights{Hatebu: 1, Star: 3, View: 0.5}
+		job := NewComputeJob(repo, weights, newTestLogger(), 0)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if len(repo.replaceCalls) != 2 {
+			t.Fatalf("ReplaceHighlights の呼び出し回数 = %d, want 2", len(repo.replaceCalls))
+		}
+		day, week := repo.replaceCalls[0], repo.replaceCalls[1]
+		if day.period != model.HighlightPeriodDay || !day.since.Equal(now.Add(-24*time.Hour)) || !day.computedAt.Equal(now) {
+			t.Errorf("day = %+v", day)
+		}
+		if week.period != model.HighlightPeriodWeek || !week.since.Equal(now.AddDate(0, 0, -7)) {
+			t.Errorf("week = %+v", week)
+		}
+		if day.weights != weights || day.perFeed != model.HighlightLimit {
+			t.Errorf("weights = %+v, perFeed = %d", day.weights, day.perFeed)
+		}
+		if job.interval != DefaultComputeInterval {
+			t.Errorf("interval = %v, want %v", job.interval, DefaultComputeInterval)
+		}
+	})
+
+	t.Run("一方の期間の計算に失敗しても残りの期間を計算しエラーを返すとき", func(t *testing.T) {
+		// Arrange
+		repo := &mockHighlightRepo{replaceErr: map[model.HighlightPeriod]error{model.HighlightPeriodDay: errors.New("db down")}}
+		job := NewComputeJob(repo, DefaultWeights, newTestLogger(), time.Hour)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err == nil {
+			t.Fatal("RunOnce() error = nil, want error")
+		}
+		if len(repo.replaceCalls) != 2 {
+			t.Errorf("ReplaceHighlights の呼び出し回数 = %d, want 2", len(repo.replaceCalls))
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// Package highlight は購読フィードの記事からはてブ数・スター数・閲覧数のスコアが高いものを選ぶ
+// デイリー / ウィークリーの「ハイライト」を提供する。
+//
+// スコアは worker の ComputeJob が集計期間ごとに item_highlights へ事前計算し、
+// API（Service）はユーザーの購読フィードで絞り込んで上位 model.HighlightLimit 件を返すだけにする。
+package highlight
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Result はハイライトの取得結果。
+type Result struct {
+	Period model.HighlightPeriod
+	// ComputedAt はスコアを計算した日時。ハイライトが 0 件の場合は nil。
+	ComputedAt *time.Time
+	Items      []model.HighlightItem
+}
+
+// Service はハイライトのサービス層。
+type Service struct {
+	repo repository.HighlightRepository
+}
+
+// NewService は Service を生成する。
+func NewService(repo repository.HighlightRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// List はユーザーの購読フィードの period のハイライトをスコアの高い順に最大 model.HighlightLimit 件返す。
+// period は day / week で、空の場合は week とする。それ以外は INVALID_HIGHLIGHT_PERIOD を返す。
+func (s *Service) List(ctx context.Context, userID, period string) (*Result, error) {
+	p, err := parsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListHighlightsByUser(ctx, userID, p, model.HighlightLimit)
+	if err != nil {
+		return nil, fmt.Errorf("ハイライトの取得に失敗: %w", err)
+	}
+
+	result := &Result{Period: p, Items: items}
+	if result.Items == nil {
+		result.Items = []model.HighlightItem{}
+	}
+	if len(items) > 0 {
+		computedAt := items[0].ComputedAt
+		result.ComputedAt = &computedAt
+	}
+	return result, nil
+}
+
+// parsePeriod は集計期間の指定を解釈する。
+func parsePeriod(period string) (model.HighlightPeriod, error) {
+	if period == "" {
+		return model.HighlightPeriodWeek, nil
+	}
+	p := model.HighlightPeriod(period)
+	if p.Window() <= 0 {
+		return "", model.NewInvalidHighlightPeriodError(period)
+	}
+	return p, nil
+}
@@ -0,0 +1,109 @@
+package importfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// rule は 1 購読分の取り込みフィルタを評価できる形にコンパイルしたもの。
+type rule struct {
+	// authors は正規化（前後空白除去・小文字化）済みの著者集合。空の場合は著者で絞り込まない。
+	authors map[string]struct{}
+	// title はタイトルの正規表現。nil の場合はタイトルで絞り込まない。
+	title *regexp.Regexp
+}
+
+// compileRule は取り込みフィルタを rule にコンパイルする。
+func compileRule(f model.ImportFilter) (*rule, error) {
+	r := &rule{}
+	if len(f.Authors) > 0 {
+		r.authors = make(map[string]struct{}, len(f.Authors))
+		for _, a := range f.Authors {
+			r.authors[normalizeAuthor(a)] = struct{}{}
+		}
+	}
+	if f.TitlePattern != "" {
+		re, err := regexp.Compile(f.TitlePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern: %w", err)
+		}
+		r.title = re
+	}
+	return r, nil
+}
+
+// match は記事がフィルタ条件をすべて満たすかを判定する。
+func (r *rule) match(item model.ParsedItem) bool {
+	if r.authors != nil {
+		if _, ok := r.authors[normalizeAuthor(item.Author)]; !ok {
+			return false
+		}
+	}
+	if r.title != nil && !r.title.MatchString(item.Title) {
+		return false
+	}
+	return true
+}
+
+// Matcher はフィードの全購読の取り込みフィルタをまとめて評価する。
+// 記事はいずれかの購読のフィルタを満たせば取り込む（記事はフィード単位で共有されるため、
+// ある購読者が必要とする記事を別の購読者のフィルタで取りこぼさない）。
+// nil の Matcher はすべての記事を取り込む。
+type Matcher struct {
+	rules []*rule
+}
+
+// NewMatcher はフィードの全購読の取り込みフィルタから Matcher を生成する。
+// 購読が無い、またはフィルタ未設定の購読が 1 件でもある場合は全記事を取り込むため nil を返す。
+func NewMatcher(filters []model.ImportFilter) (*Matcher, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	m := &Matcher{rules: make([]*rule, 0, len(filters))}
+	for _, f := range filters {
+		if f.IsEmpty() {
+			return nil, nil
+		}
+		r, err := compileRule(f)
+		if err != nil {
+			return nil, err
+		}
+		m.rules = append(m.rules, r)
+	}
+	return m, nil
+}
+
+// Match は記事がいずれかの購読のフィルタを満たすかを判定する。
+func (m *Matcher) Match(item model.ParsedItem) bool {
+	if m == nil {
+		return true
+	}
+	for _, r := range m.rules {
+		if r.match(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter は Match を満たす記事のみを元の順序のまま返す。
+func (m *Matcher) Filter(items []model.ParsedItem) []model.ParsedItem {
+	if m == nil {
+		return items
+	}
+	kept := make([]model.ParsedItem, 0, len(items))
+	for _, item := range items {
+		if m.Match(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// normalizeAuthor は著者名を比較用に正規化する（前後空白除去・小文字化）。
+func normalizeAuthor(author string) string {
+	return strings.ToLower(strings.TrimSpace(author))
+}
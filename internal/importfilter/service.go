@@ -0,0 +1,111 @@
+// Package importfilter は購読単位の記事取り込みフィルタ（ホワイトリスト型）を提供する。
+//
+// ユーザーは購読ごとに「著者がいずれかに一致」「タイトルが正規表現に一致」の条件を設定でき、
+// フェッチ時に条件を満たさない記事は UPSERT の前段で除外され DB に保存されない。
+// 記事はフィード単位で共有されるため、フィルタ未設定の購読者が 1 人でもいるフィードでは
+// 全記事を取り込み、全購読者がフィルタを設定している場合はいずれかを満たす記事を取り込む。
+// フィルタの変更は以降のフェッチから適用され、保存済みの記事は削除しない。
+package importfilter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service は記事取り込みフィルタのサービス層。
+type Service struct {
+	repo repository.SubscriptionImportFilterRepository
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.SubscriptionImportFilterRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetFilter は当該ユーザーの購読の取り込みフィルタを返す。
+// 対象購読が存在しない、または他ユーザーの購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) GetFilter(ctx context.Context, userID, subscriptionID string) (*model.ImportFilter, error) {
+	filter, err := s.repo.GetImportFilter(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("取り込みフィルタの取得に失敗しました: %w", err)
+	}
+	if filter == nil {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	return filter, nil
+}
+
+// UpdateFilter は当該ユーザーの購読の取り込みフィルタを正規化・検証して上書き保存する。
+// 著者・タイトル正規表現をともに空にするとフィルタを解除する（全記事を取り込む）。
+func (s *Service) UpdateFilter(ctx context.Context, userID, subscriptionID string, filter model.ImportFilter) (*model.ImportFilter, error) {
+	normalized, err := normalizeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateImportFilter(ctx, userID, subscriptionID, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("取り込みフィルタの更新に失敗しました: %w", err)
+	}
+	if !updated {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	return &normalized, nil
+}
+
+// FilterItems はフィードの全購読の取り込みフィルタで記事を絞り込む。
+// フェッチャーが UPSERT の前段で呼び出す。
+func (s *Service) FilterItems(ctx context.Context, feedID string, items []model.ParsedItem) ([]model.ParsedItem, error) {
+	filters, err := s.repo.ListImportFiltersByFeedID(ctx, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("取り込みフィルタ一覧の取得に失敗しました: %w", err)
+	}
+	matcher, err := NewMatcher(filters)
+	if err != nil {
+		return nil, fmt.Errorf("取り込みフィルタのコンパイルに失敗しました: %w", err)
+	}
+	return matcher.Filter(items), nil
+}
+
+// normalizeFilter は著者の前後空白除去・空要素除去・重複除去（大文字小文字を区別しない）を行い、
+// 件数・長さ・正規表現の妥当性を検証する。
+func normalizeFilter(filter model.ImportFilter) (model.ImportFilter, error) {
+	authors := make([]string, 0, len(filter.Authors))
+	seen := make(map[string]struct{}, len(filter.Authors))
+	for _, a := range filter.Authors {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		if utf8.RuneCountInString(a) > model.MaxImportFilterAuthorLength {
+			return model.ImportFilter{}, model.NewInvalidImportFilterError("著者名が長すぎます")
+		}
+		key := normalizeAuthor(a)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		authors = append(authors, a)
+	}
+	if len(authors) > model.MaxImportFilterAuthors {
+		return model.ImportFilter{}, model.NewInvalidImportFilterError("著者の指定が多すぎます")
+	}
+
+	pattern := strings.TrimSpace(filter.TitlePattern)
+	if utf8.RuneCountInString(pattern) > model.MaxImportFilterTitlePatternLength {
+		return model.ImportFilter{}, model.NewInvalidImportFilterError("タイトルの正規表現が長すぎます")
+	}
+	if pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return model.ImportFilter{}, model.NewInvalidImportFilterError("タイトルの正規表現が不正です")
+		}
+	}
+
+	return model.ImportFilter{Authors: authors, TitlePattern: pattern}, nil
+}
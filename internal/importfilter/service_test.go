@@ -0,0 +1,220 @@
+package importfilter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// --- テスト用モック ---
+
+// mockImportFilterRepo は SubscriptionImportFilterRepository のモック。
+type mockImportFilterRepo struct {
+	getFn            func(ctx context.Context, userID, subscriptionID string) (*model.ImportFilter, error)
+	updateFn         func(ctx context.Context, userID, subscriptionID string, filter model.ImportFilter) (bool, error)
+	listByFeedFn     func(ctx context.Context, feedID string) ([]model.ImportFilter, error)
+	updateCalledWith *model.ImportFilter
+}
+
+func (m *mockImportFilterRepo) GetImportFilter(ctx context.Context, userID, subscriptionID string) (*model.ImportFilter, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx, userID, subscriptionID)
+	}
+	return &model.ImportFilter{}, nil
+}
+
+func (m *mockImportFilterRepo) UpdateImportFilter(ctx context.Context, userID, subscriptionID string, filter model.ImportFilter) (bool, error) {
+	m.updateCalledWith = &filter
+	if m.updateFn != nil {
+		return m.updateFn(ctx, userID, subscriptionID, filter)
+	}
+	return true, nil
+}
+
+func (m *mockImportFilterRepo) ListImportFiltersByFeedID(ctx context.Context, feedID string) ([]model.ImportFilter, error) {
+	if m.listByFeedFn != nil {
+		return m.listByFeedFn(ctx, feedID)
+	}
+	return nil, nil
+}
+
+var _ repository.SubscriptionImportFilterRepository = (*mockImportFilterRepo)(nil)
+
+// assertAPIErrorCode は err が指定コードの APIError であることを検証する。
+func assertAPIErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Fatalf("err = %v, want APIError code %s", err, code)
+	}
+}
+
+// --- GetFilter ---
+
+func TestService_GetFilter(t *testing.T) {
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockImportFilterRepo{
+			getFn: func(_ context.Context, _, _ string) (*model.ImportFilter, error) { return nil, nil },
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.GetFilter(context.Background(), "user-1", "sub-x")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+	})
+}
+
+// --- UpdateFilter ---
+
+func TestService_UpdateFilter(t *testing.T) {
+	t.Run("著者の空白除去・空要素除去・重複除去をして保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockImportFilterRepo{}
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.UpdateFilter(context.Background(), "user-1", "sub-1", model.ImportFilter{
+			Authors:      []string{" Alice ", "", "alice", "Bob"},
+			TitlePattern: "  ^Go  ",
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("UpdateFilter() error = %v", err)
+		}
+		if len(got.Authors) != 2 || got.Authors[0] != "Alice" || got.Authors[1] != "Bob" {
+			t.Errorf("Authors = %v, want [Alice Bob]", got.Authors)
+		}
+		if got.TitlePattern != "^Go" {
+			t.Errorf("TitlePattern = %q, want %q", got.TitlePattern, "^Go")
+		}
+		if repo.updateCalledWith == nil || repo.updateCalledWith.TitlePattern != "^Go" {
+			t.Errorf("repo に正規化済みのフィルタが渡されるべき: %+v", repo.updateCalledWith)
+		}
+	})
+
+	t.Run("不正な指定のとき400 INVALID_IMPORT_FILTERを返し保存しない", func(t *testing.T) {
+		tooMany := make([]string, model.MaxImportFilterAuthors+1)
+		for i := range tooMany {
+			tooMany[i] = strings.Repeat("a", i+1)
+		}
+		cases := map[string]model.ImportFilter{
+			"正規表現の構文エラー": {TitlePattern: "(unclosed"},
+			"正規表現が長すぎる":  {TitlePattern: strings.Repeat("a", model.MaxImportFilterTitlePatternLength+1)},
+			"著者名が長すぎる":   {Authors: []string{strings.Repeat("あ", model.MaxImportFilterAuthorLength+1)}},
+			"著者が多すぎる":    {Authors: tooMany},
+		}
+		for name, filter := range cases {
+			// Arrange
+			repo := &mockImportFilterRepo{}
+			svc := NewService(repo)
+
+			// Act
+			_, err := svc.UpdateFilter(context.Background(), "user-1", "sub-1", filter)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidImportFilter {
+				t.Errorf("%s: err = %v, want INVALID_IMPORT_FILTER", name, err)
+			}
+			if repo.updateCalledWith != nil {
+				t.Errorf("%s: 不正な指定は保存すべきでない", name)
+			}
+		}
+	})
+
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockImportFilterRepo{
+			updateFn: func(_ context.Context, _, _ string, _ model.ImportFilter) (bool, error) { return false, nil },
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.UpdateFilter(context.Background(), "user-1", "sub-x", model.ImportFilter{})
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+	})
+}
+
+// --- FilterItems ---
+
+func TestService_FilterItems(t *testing.T) {
+	items := []model.ParsedItem{
+		{GuidOrID: "1", Title: "Go 1.25 released", Author: "Alice"},
+		{GuidOrID: "2", Title: "Weekly photo", Author: "alice "},
+		{GuidOrID: "3", Title: "Go tips", Author: "Carol"},
+		{GuidOrID: "4", Title: "Rust news", Author: "Dave"},
+	}
+	guids := func(items []model.ParsedItem) string {
+		ids := make([]string, len(items))
+		for i, it := range items {
+			ids[i] = it.GuidOrID
+		}
+		return strings.Join(ids, ",")
+	}
+
+	cases := []struct {
+		name    string
+		filters []model.ImportFilter
+		want    string
+	}{
+		{"購読が無いとき全記事を返す", nil, "1,2,3,4"},
+		{"著者を指定したとき大文字小文字と前後空白を無視して一致した記事のみ返す", []model.ImportFilter{{Authors: []string{"ALICE"}}}, "1,2"},
+		{"タイトル正規表現を指定したとき一致した記事のみ返す", []model.ImportFilter{{TitlePattern: "^Go "}}, "1,3"},
+		{"著者とタイトルを両方指定したとき両方を満たす記事のみ返す", []model.ImportFilter{{Authors: []string{"Alice"}, TitlePattern: "^Go "}}, "1"},
+		{"複数購読のときいずれかのフィルタを満たす記事を返す", []model.ImportFilter{{Authors: []string{"Dave"}}, {TitlePattern: "photo"}}, "2,4"},
+		{"フィルタ未設定の購読があるとき全記事を返す", []model.ImportFilter{{Authors: []string{"Dave"}}, {}}, "1,2,3,4"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo := &mockImportFilterRepo{
+				listByFeedFn: func(_ context.Context, feedID string) ([]model.ImportFilter, error) {
+					if feedID != "feed-1" {
+						t.Errorf("feedID = %q, want feed-1", feedID)
+					}
+					return tc.filters, nil
+				},
+			}
+			svc := NewService(repo)
+
+			// Act
+			got, err := svc.FilterItems(context.Background(), "feed-1", items)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("FilterItems() error = %v", err)
+			}
+			if guids(got) != tc.want {
+				t.Errorf("items = %s, want %s", guids(got), tc.want)
+			}
+		})
+	}
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockImportFilterRepo{
+			listByFeedFn: func(_ context.Context, _ string) ([]model.ImportFilter, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.FilterItems(context.Background(), "feed-1", items)
+
+		// Assert
+		if err == nil {
+			t.Error("エラーを返すべき")
+		}
+	})
+}
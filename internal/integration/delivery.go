@@ -0,0 +1,263 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// DeliveryConfig は配送ジョブの設定パラメータ。
+type DeliveryConfig struct {
+	// Interval はジョブの実行間隔（デフォルト: 1分）。
+	Interval time.Duration
+	// BatchSize は 1 サイクルで配送する最大件数（デフォルト: 100）。
+	BatchSize int
+	// WebhookInterval は同じ Webhook への送信の最低間隔（デフォルト: 1秒）。
+	// Slack Incoming Webhook は 1 秒 1 件、Discord Webhook は 2 秒 5 件程度が上限の目安。
+	WebhookInterval time.Duration
+	// MaxAttempts は配送を諦めるまでの最大試行回数（デフォルト: 5回）。
+	MaxAttempts int
+	// InitialBackoff は再試行の初回遅延（デフォルト: 1分）。試行ごとに倍にする。
+	InitialBackoff time.Duration
+	// MaxBackoff は再試行の最大遅延（デフォルト: 1時間）。
+	MaxBackoff time.Duration
+	// RequestTimeout は 1 リクエストあたりのタイムアウト（デフォルト: 10秒）。
+	RequestTimeout time.Duration
+}
+
+// DefaultDeliveryConfig はデフォルトの配送ジョブ設定を返す。
+func DefaultDeliveryConfig() DeliveryConfig {
+	return DeliveryConfig{
+		Interval:        time.Minute,
+		BatchSize:       100,
+		WebhookInterval: time.Second,
+		MaxAttempts:     5,
+		InitialBackoff:  time.Minute,
+		MaxBackoff:      time.Hour,
+		RequestTimeout:  10 * time.Second,
+	}
+}
+
+// maxResponseBodySize はエラー応答から読み取る本文の上限。エラーメッセージの記録にのみ使う。
+const maxResponseBodySize = 512
+
+// DeliveryJob は配送キューの新着記事を Slack / Discord の Webhook に送る worker ジョブ。
+type DeliveryJob struct {
+	repo   repository.IntegrationDeliveryRepository
+	guard  security.SSRFGuardService
+	client *http.Client
+	logger *slog.Logger
+	config DeliveryConfig
+	now    func() time.Time
+}
+
+// NewDeliveryJob は DeliveryJob の新しいインスタンスを生成する。
+// HTTP クライアントは guard.NewSafeClient で生成し、プライベート IP 等への接続を遮断する。
+func NewDeliveryJob(
+	repo repository.IntegrationDeliveryRepository,
+	guard security.SSRFGuardService,
+	logger *slog.Logger,
+	config DeliveryConfig,
+) *DeliveryJob {
+	return &DeliveryJob{
+		repo:   repo,
+		guard:  guard,
+		client: guard.NewSafeClient(config.RequestTimeout, 0),
+		logger: logger,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Start はジョブをティッカーで定期実行する。
+// コンテキストがキャンセルされるまで実行を継続する。
+func (j *DeliveryJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	j.logger.Info("連携の配送ジョブを開始しました",
+		slog.Duration("interval", j.config.Interval),
+		slog.Int("batch_size", j.config.BatchSize),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("連携の配送ジョブを停止しました")
+			return
+		case <-ticker.C:
+			if err := j.RunOnce(ctx); err != nil {
+				j.logger.Error("連携の配送サイクルの実行に失敗しました",
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+}
+
+// deliveryOutcome は 1 件の配送結果の分類。
+type deliveryOutcome int
+
+const (
+	// outcomeDelivered は配送に成功した。
+	outcomeDelivered deliveryOutcome = iota
+	// outcomeRetry は一時的な失敗（5xx・通信エラー）で再試行する。
+	outcomeRetry
+	// outcomeRateLimited は 429 で拒否された。Retry-After の経過後に再試行する。
+	outcomeRateLimited
+	// outcomeFailed は恒久的な失敗（Webhook の削除・不正な内容）で再試行しない。
+	outcomeFailed
+)
+
+// RunOnce は 1 回の配送サイクルを実行する。
+// 配送期限の来た未配送を古い順に取り出し、同じ Webhook への送信は WebhookInterval 以上の間隔を空ける。
+// 429 を受けた Webhook にはそのサイクル中は送らない。個別の記録失敗はログに残して次へ進む。
+func (j *DeliveryJob) RunOnce(ctx context.Context) error {
+	start := j.now()
+
+	deliveries, err := j.repo.ListDueDeliveries(ctx, start, j.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("配送キューの取得に失敗しました: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	lastSent := make(map[string]time.Time)
+	rateLimited := make(map[string]bool)
+	var delivered, retried, failed int
+	for _, d := range deliveries {
+		if rateLimited[d.WebhookURL] {
+			continue
+		}
+		if last, ok := lastSent[d.WebhookURL]; ok {
+			if wait := j.config.WebhookInterval - j.now().Sub(last); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		outcome, retryAfter, reason := j.deliver(ctx, d)
+		lastSent[d.WebhookURL] = j.now()
+
+		var recordErr error
+		switch {
+		case outcome == outcomeDelivered:
+			delivered++
+			recordErr = j.repo.MarkDelivered(ctx, d.ID, j.now())
+		case outcome == outcomeFailed || d.Attempts+1 >= j.config.MaxAttempts:
+			failed++
+			recordErr = j.repo.MarkFailed(ctx, d.ID, reason)
+		default:
+			if outcome == outcomeRateLimited {
+				rateLimited[d.WebhookURL] = true
+			}
+			retried++
+			recordErr = j.repo.MarkRetry(ctx, d.ID, j.now().Add(j.retryDelay(d.Attempts, retryAfter)), reason)
+		}
+		if recordErr != nil {
+			j.logger.Warn("配送結果の記録に失敗しました",
+				slog.Int64("delivery_id", d.ID),
+				slog.String("integration_id", d.IntegrationID),
+				slog.String("error", recordErr.Error()),
+			)
+		}
+	}
+
+	j.logger.Info("連携の配送サイクルが完了しました",
+		slog.Int("delivered", delivered),
+		slog.Int("retried", retried),
+		slog.Int("failed", failed),
+		slog.Float64("duration_ms", float64(j.now().Sub(start).Milliseconds())),
+	)
+	return nil
+}
+
+// deliver は 1 件の記事を Webhook に送り、結果の分類・429 の Retry-After・失敗理由を返す。
+// 失敗理由には Webhook URL を含めない（連携設定の last_error として利用者に返すため）。
+func (j *DeliveryJob) deliver(ctx context.Context, d model.IntegrationDelivery) (deliveryOutcome, time.Duration, string) {
+	message, err := renderMessage(d.Kind, d.MessageTemplate, newMessageData(d))
+	if err != nil {
+		return outcomeFailed, 0, fmt.Sprintf("メッセージテンプレートの展開に失敗しました: %v", err)
+	}
+	payload, err := buildPayload(d.Kind, message)
+	if err != nil {
+		return outcomeFailed, 0, fmt.Sprintf("送信内容の組み立てに失敗しました: %v", err)
+	}
+	if err := j.guard.ValidateURL(d.WebhookURL); err != nil {
+		return outcomeFailed, 0, "Webhook URL が安全でないため送信しませんでした"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return outcomeFailed, 0, "Webhook URL が不正です"
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Feedman/1.0 RSS Reader")
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return outcomeRetry, 0, "Webhook への送信に失敗しました（通信エラー）"
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+
+	return classifyResponse(resp.StatusCode, resp.Header.Get("Retry-After"), body)
+}
+
+// classifyResponse は Webhook の応答を配送結果に分類する。
+// 401 / 403 / 404 / 410（Webhook の削除・無効化）やその他の 4xx（内容の不正）は再試行しても
+// 成功しないため恒久的な失敗とし、429 と 5xx のみ再試行する。
+func classifyResponse(status int, retryAfter string, body []byte) (deliveryOutcome, time.Duration, string) {
+	switch {
+	case status >= 200 && status < 300:
+		return outcomeDelivered, 0, ""
+	case status == http.StatusTooManyRequests:
+		return outcomeRateLimited, parseRetryAfter(retryAfter), "Webhook の送信レート制限に達しました（HTTP 429）"
+	case status >= 500:
+		return outcomeRetry, 0, fmt.Sprintf("Webhook がエラーを返しました（HTTP %d）", status)
+	default:
+		reason := fmt.Sprintf("Webhook が送信を拒否しました（HTTP %d）", status)
+		if msg := string(bytes.TrimSpace(body)); msg != "" {
+			reason += ": " + truncateRunes(msg, 200)
+		}
+		return outcomeFailed, 0, reason
+	}
+}
+
+// parseRetryAfter は Retry-After ヘッダー（秒数）を解釈する。解釈できない場合は 0 を返す。
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.ParseFloat(v, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// retryDelay は attempts 回失敗した後の再試行までの遅延を返す。
+// InitialBackoff から試行ごとに倍にして MaxBackoff で頭打ちにし、Retry-After の指定があればそれ以上待つ。
+func (j *DeliveryJob) retryDelay(attempts int, retryAfter time.Duration) time.Duration {
+	delay := j.config.InitialBackoff
+	for i := 0; i < attempts && delay < j.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > j.config.MaxBackoff {
+		delay = j.config.MaxBackoff
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay
+}
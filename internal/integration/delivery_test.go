@@ -0,0 +1,237 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockDeliveryRepo は repository.IntegrationDeliveryRepository のモック実装。
+type mockDeliveryRepo struct {
+	deliveries []model.IntegrationDelivery
+	delivered  []int64
+	retried    map[int64]time.Time
+	failed     map[int64]string
+}
+
+func (m *mockDeliveryRepo) EnqueueNewItems(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockDeliveryRepo) ListDueDeliveries(_ context.Context, _ time.Time, _ int) ([]model.IntegrationDelivery, error) {
+	return m.deliveries, nil
+}
+
+func (m *mockDeliveryRepo) MarkDelivered(_ context.Context, deliveryID int64, _ time.Time) error {
+	m.delivered = append(m.delivered, deliveryID)
+	return nil
+}
+
+func (m *mockDeliveryRepo) MarkRetry(_ context.Context, deliveryID int64, nextAttemptAt time.Time, _ string) error {
+	if m.retried == nil {
+		m.retried = make(map[int64]time.Time)
+	}
+	m.retried[deliveryID] = nextAttemptAt
+	return nil
+}
+
+func (m *mockDeliveryRepo) MarkFailed(_ context.Context, deliveryID int64, lastError string) error {
+	if m.failed == nil {
+		m.failed = make(map[int64]string)
+	}
+	m.failed[deliveryID] = lastError
+	return nil
+}
+
+// mockGuard は security.SSRFGuardService のモック実装。
+// テスト用の httptest サーバー（ループバック）へ接続できるよう通常のクライアントを返す。
+type mockGuard struct {
+	validateErr error
+}
+
+func (g *mockGuard) NewSafeClient(timeout time.Duration, _ int64) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+func (g *mockGuard) ValidateURL(_ string) error {
+	return g.validateErr
+}
+
+func newTestDeliveryJob(repo *mockDeliveryRepo) *DeliveryJob {
+	cfg := DefaultDeliveryConfig()
+	cfg.WebhookInterval = 0
+	return NewDeliveryJob(repo, &mockGuard{}, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), cfg)
+}
+
+func TestDeliveryJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	var received []map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		received = append(received, body)
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusBadGateway) })
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "Unknown Webhook")
+	})
+	mux.HandleFunc("/limited", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "7200")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	delivery := func(id int64, path string, kind model.IntegrationKind, attempts int) model.IntegrationDelivery {
+		return model.IntegrationDelivery{
+			ID: id, IntegrationID: "int-1", Kind: kind, WebhookURL: srv.URL + path,
+			MessageTemplate: model.DefaultIntegrationTemplate, Attempts: attempts,
+			ItemID: "item-1", FeedTitle: "Blog", Title: "<b>Hello</b>", Link: "https://example.com/1",
+		}
+	}
+
+	t.Run("応答に応じて配送済み・再試行・失敗を記録する", func(t *testing.T) {
+		// Arrange
+		received = nil
+		repo := &mockDeliveryRepo{deliveries: []model.IntegrationDelivery{
+			delivery(1, "/ok", model.IntegrationKindSlack, 0),
+			delivery(2, "/error", model.IntegrationKindSlack, 0),
+			delivery(3, "/gone", model.IntegrationKindDiscord, 0),
+			delivery(4, "/error", model.IntegrationKindSlack, DefaultDeliveryConfig().MaxAttempts-1),
+		}}
+		job := newTestDeliveryJob(repo)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if len(repo.delivered) != 1 || repo.delivered[0] != 1 {
+			t.Errorf("delivered = %v, want [1]", repo.delivered)
+		}
+		if want := now.Add(DefaultDeliveryConfig().InitialBackoff); !repo.retried[2].Equal(want) {
+			t.Errorf("retried[2] = %v, want %v", repo.retried[2], want)
+		}
+		if !strings.Contains(repo.failed[3], "HTTP 404") || !strings.Contains(repo.failed[3], "Unknown Webhook") {
+			t.Errorf("failed[3] = %q, want HTTP 404 with body", repo.failed[3])
+		}
+		if _, ok := repo.failed[4]; !ok {
+			t.Error("最大試行回数に達した配送が失敗として確定していない")
+		}
+		if len(received) != 1 || received[0]["text"] != "Blog: &lt;b&gt;Hello&lt;/b&gt;\nhttps://example.com/1" {
+			t.Errorf("received = %v, want escaped Slack text", received)
+		}
+	})
+
+	t.Run("429を受けたときRetry-Afterまで再試行を遅らせ同じWebhookへの送信を見送る", func(t *testing.T) {
+		// Arrange
+		repo := &mockDeliveryRepo{deliveries: []model.IntegrationDelivery{
+			delivery(1, "/limited", model.IntegrationKindDiscord, 0),
+			delivery(2, "/limited", model.IntegrationKindDiscord, 0),
+		}}
+		job := newTestDeliveryJob(repo)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if want := now.Add(2 * time.Hour); !repo.retried[1].Equal(want) {
+			t.Errorf("retried[1] = %v, want %v", repo.retried[1], want)
+		}
+		if _, ok := repo.retried[2]; ok {
+			t.Error("429 を受けた Webhook へ同じサイクルで再送した")
+		}
+	})
+}
+
+func TestDeliveryJob_RetryDelay(t *testing.T) {
+	job := newTestDeliveryJob(&mockDeliveryRepo{})
+	tests := []struct {
+		name       string
+		attempts   int
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{"初回", 0, 0, time.Minute},
+		{"3回失敗後", 3, 0, 8 * time.Minute},
+		{"上限を超える", 10, 0, time.Hour},
+		{"Retry-Afterが長い", 0, 5 * time.Minute, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"のとき遅延を返す", func(t *testing.T) {
+			if got := job.retryDelay(tt.attempts, tt.retryAfter); got != tt.want {
+				t.Errorf("retryDelay(%d, %v) = %v, want %v", tt.attempts, tt.retryAfter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	data := MessageData{Title: "A & B", Link: "https://example.com/1", FeedTitle: "Blog"}
+
+	t.Run("Discordのとき値をエスケープせず上限で切り詰める", func(t *testing.T) {
+		// Arrange
+		long := data
+		long.Title = strings.Repeat("あ", discordMaxMessageLength+10)
+
+		// Act
+		got, err := renderMessage(model.IntegrationKindDiscord, "{{.Title}}", long)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("renderMessage() error = %v", err)
+		}
+		if n := utf8.RuneCountInString(got); n != discordMaxMessageLength {
+			t.Errorf("len = %d, want %d", n, discordMaxMessageLength)
+		}
+		if !strings.HasSuffix(got, "…") {
+			t.Errorf("切り詰めた末尾に … が付いていない: %q", got[len(got)-10:])
+		}
+	})
+
+	t.Run("Slackのとき記事の値だけをエスケープする", func(t *testing.T) {
+		// Act
+		got, err := renderMessage(model.IntegrationKindSlack, "<{{.Link}}|{{.Title}}>", data)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("renderMessage() error = %v", err)
+		}
+		if want := "<https://example.com/1|A &amp; B>"; got != want {
+			t.Errorf("got = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Discordのときメンションを無効にしたペイロードを返す", func(t *testing.T) {
+		// Act
+		payload, err := buildPayload(model.IntegrationKindDiscord, "@everyone")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("buildPayload() error = %v", err)
+		}
+		if want := `{"allowed_mentions":{"parse":[]},"content":"@everyone"}`; string(payload) != want {
+			t.Errorf("payload = %s, want %s", payload, want)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+const (
+	// slackMaxMessageLength は Slack に送るメッセージの最大文字数。
+	// Slack は 40,000 文字を超えると切り詰めるが、通知として読める長さに抑える。
+	slackMaxMessageLength = 4000
+	// discordMaxMessageLength は Discord の content の上限（2,000 文字）。超えると 400 で拒否される。
+	discordMaxMessageLength = 2000
+)
+
+// MessageData はメッセージテンプレートに渡す記事の情報。
+// テンプレートでは {{.Title}} / {{.Link}} / {{.Author}} / {{.FeedTitle}} / {{.PublishedAt}} を参照できる。
+type MessageData struct {
+	Title     string
+	Link      string
+	Author    string
+	FeedTitle string
+	// PublishedAt は RFC 3339（UTC）の公開日時。不明な場合は空文字列。
+	PublishedAt string
+}
+
+// sampleMessageData はテンプレートの検証時に試し実行するための記事。
+var sampleMessageData = MessageData{
+	Title:       "記事タイトル",
+	Link:        "https://example.com/posts/1",
+	Author:      "著者",
+	FeedTitle:   "フィード",
+	PublishedAt: "2026-01-01T00:00:00Z",
+}
+
+// parseTemplate はメッセージテンプレートを解釈し、見本の記事で試し実行して参照先の誤りも検出する。
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, sampleMessageData); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// newMessageData は配送キューの記事からテンプレートに渡す情報を組み立てる。
+func newMessageData(d model.IntegrationDelivery) MessageData {
+	data := MessageData{
+		Title:     d.Title,
+		Link:      d.Link,
+		Author:    d.Author,
+		FeedTitle: d.FeedTitle,
+	}
+	if d.PublishedAt != nil {
+		data.PublishedAt = d.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	return data
+}
+
+// renderMessage はテンプレートで記事のメッセージを組み立て、転送先の上限に収まるよう切り詰める。
+// Slack はテンプレートの展開前に記事の値をエスケープし、記事の内容で @channel などの特殊な書式が
+// 解釈されないようにする（テンプレート自体に書いた書式はそのまま使える）。
+func renderMessage(kind model.IntegrationKind, templateText string, data MessageData) (string, error) {
+	tmpl, err := parseTemplate(templateText)
+	if err != nil {
+		return "", err
+	}
+	if kind == model.IntegrationKindSlack {
+		data = MessageData{
+			Title:       escapeSlack(data.Title),
+			Link:        escapeSlack(data.Link),
+			Author:      escapeSlack(data.Author),
+			FeedTitle:   escapeSlack(data.FeedTitle),
+			PublishedAt: data.PublishedAt,
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	limit := slackMaxMessageLength
+	if kind == model.IntegrationKindDiscord {
+		limit = discordMaxMessageLength
+	}
+	return truncateRunes(strings.TrimSpace(buf.String()), limit), nil
+}
+
+// buildPayload は転送先の Webhook に POST する JSON を組み立てる。
+// Discord は allowed_mentions を空にし、記事の内容による @everyone などのメンションを無効にする。
+func buildPayload(kind model.IntegrationKind, message string) ([]byte, error) {
+	switch kind {
+	case model.IntegrationKindDiscord:
+		return json.Marshal(map[string]any{
+			"content":          message,
+			"allowed_mentions": map[string]any{"parse": []string{}},
+		})
+	default:
+		return json.Marshal(map[string]any{"text": message})
+	}
+}
+
+// slackEscaper は Slack の mrkdwn で制御文字として扱われる &, <, > をエスケープする。
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeSlack は Slack のメッセージに埋め込む値をエスケープする。
+func escapeSlack(s string) string {
+	return slackEscaper.Replace(s)
+}
+
+// truncateRunes は s が limit 文字を超える場合に末尾を「…」にして切り詰める。
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,186 @@
+// Package integration は購読フィードの新着記事を Slack / Discord の Webhook に転送する連携を提供する。
+//
+// 連携設定（対象購読・宛先 Webhook URL・メッセージテンプレート）は購読単位に作成し、
+// フェッチャーが新着記事を取り込んだ直後に配送キュー（integration_deliveries）へ積む。
+// キューは worker の DeliveryJob が Webhook ごとの送信間隔を守りながら配送し、
+// 一時的な失敗は指数バックオフで再試行する。
+//
+// 宛先は各サービスの Webhook のホストに限定し、任意の URL へのリクエストには使わせない。
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service は新着記事の転送設定のサービス層。
+type Service struct {
+	repo repository.IntegrationRepository
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.IntegrationRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// ListIntegrations は当該ユーザーの連携設定を作成日時の昇順で返す。
+func (s *Service) ListIntegrations(ctx context.Context, userID string) ([]model.Integration, error) {
+	integrations, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("連携設定一覧の取得に失敗しました: %w", err)
+	}
+	return integrations, nil
+}
+
+// GetIntegration は当該ユーザーの連携設定を返す。
+// 存在しない、または他ユーザーの連携設定の場合は INTEGRATION_NOT_FOUND を返す。
+func (s *Service) GetIntegration(ctx context.Context, userID, integrationID string) (*model.Integration, error) {
+	integration, err := s.repo.FindByID(ctx, userID, integrationID)
+	if err != nil {
+		return nil, fmt.Errorf("連携設定の取得に失敗しました: %w", err)
+	}
+	if integration == nil {
+		return nil, model.NewIntegrationNotFoundError(integrationID)
+	}
+	return integration, nil
+}
+
+// CreateIntegration は当該ユーザーの購読に連携設定を追加する。
+// テンプレートが空の場合は model.DefaultIntegrationTemplate を用いる。
+// 購読が存在しない、または他ユーザーの購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) CreateIntegration(ctx context.Context, userID string, input model.Integration) (*model.Integration, error) {
+	normalized, err := normalizeIntegration(input)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("連携設定数の取得に失敗しました: %w", err)
+	}
+	if count >= model.MaxIntegrationsPerUser {
+		return nil, model.NewIntegrationLimitError()
+	}
+
+	normalized.UserID = userID
+	created, err := s.repo.Create(ctx, &normalized)
+	if err != nil {
+		return nil, fmt.Errorf("連携設定の作成に失敗しました: %w", err)
+	}
+	if !created {
+		return nil, model.NewSubscriptionNotFoundError(normalized.SubscriptionID)
+	}
+	// 一覧と同じ形（フィードタイトルを含む）で返す
+	return s.GetIntegration(ctx, userID, normalized.ID)
+}
+
+// UpdateIntegration は当該ユーザーの連携設定の種類・Webhook URL・テンプレート・有効フラグを更新する。
+// Webhook URL が空の場合は現在の URL を維持する（応答では URL をマスクして返すため）。
+// 対象の購読は変更できない。
+func (s *Service) UpdateIntegration(ctx context.Context, userID, integrationID string, input model.Integration) (*model.Integration, error) {
+	current, err := s.GetIntegration(ctx, userID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	if input.WebhookURL == "" {
+		input.WebhookURL = current.WebhookURL
+	}
+
+	normalized, err := normalizeIntegration(input)
+	if err != nil {
+		return nil, err
+	}
+	normalized.ID = integrationID
+	normalized.UserID = userID
+
+	updated, err := s.repo.Update(ctx, &normalized)
+	if err != nil {
+		return nil, fmt.Errorf("連携設定の更新に失敗しました: %w", err)
+	}
+	if !updated {
+		return nil, model.NewIntegrationNotFoundError(integrationID)
+	}
+	return s.GetIntegration(ctx, userID, integrationID)
+}
+
+// DeleteIntegration は当該ユーザーの連携設定を削除する。未配送のキューも破棄する。
+func (s *Service) DeleteIntegration(ctx context.Context, userID, integrationID string) error {
+	deleted, err := s.repo.Delete(ctx, userID, integrationID)
+	if err != nil {
+		return fmt.Errorf("連携設定の削除に失敗しました: %w", err)
+	}
+	if !deleted {
+		return model.NewIntegrationNotFoundError(integrationID)
+	}
+	return nil
+}
+
+// normalizeIntegration は入力の前後空白を除去し、種類・Webhook URL・テンプレートを検証する。
+func normalizeIntegration(input model.Integration) (model.Integration, error) {
+	input.WebhookURL = strings.TrimSpace(input.WebhookURL)
+	if strings.TrimSpace(input.MessageTemplate) == "" {
+		input.MessageTemplate = model.DefaultIntegrationTemplate
+	}
+
+	if !input.Kind.Valid() {
+		return input, model.NewInvalidIntegrationError(fmt.Sprintf("未知の kind です: %q", input.Kind))
+	}
+	if err := validateWebhookURL(input.Kind, input.WebhookURL); err != nil {
+		return input, err
+	}
+	if utf8.RuneCountInString(input.MessageTemplate) > model.MaxIntegrationTemplateLength {
+		return input, model.NewInvalidIntegrationError(
+			fmt.Sprintf("message_template は %d 文字以内で指定してください", model.MaxIntegrationTemplateLength))
+	}
+	if _, err := parseTemplate(input.MessageTemplate); err != nil {
+		return input, model.NewInvalidIntegrationError(fmt.Sprintf("message_template を解釈できません: %v", err))
+	}
+	return input, nil
+}
+
+// webhookHosts は種類ごとに許可する Webhook のホストとパスの接頭辞。
+// 任意の URL へのリクエスト（SSRF や情報の持ち出し）に使われないよう、各サービスの Webhook に限定する。
+var webhookHosts = map[model.IntegrationKind]struct {
+	hosts      []string
+	pathPrefix string
+}{
+	model.IntegrationKindSlack:   {hosts: []string{"hooks.slack.com"}, pathPrefix: "/services/"},
+	model.IntegrationKindDiscord: {hosts: []string{"discord.com", "discordapp.com", "ptb.discord.com", "canary.discord.com"}, pathPrefix: "/api/webhooks/"},
+}
+
+// validateWebhookURL は Webhook URL が https で、種類に応じたサービスの Webhook を指すかを検証する。
+func validateWebhookURL(kind model.IntegrationKind, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return model.NewInvalidIntegrationError("webhook_url は https の Webhook URL を指定してください")
+	}
+	allowed := webhookHosts[kind]
+	host := strings.ToLower(u.Hostname())
+	for _, h := range allowed.hosts {
+		if host == h && strings.HasPrefix(u.Path, allowed.pathPrefix) && len(u.Path) > len(allowed.pathPrefix) {
+			return nil
+		}
+	}
+	return model.NewInvalidIntegrationError(
+		fmt.Sprintf("webhook_url は %s の Webhook URL（https://%s%s...）を指定してください", kind, allowed.hosts[0], allowed.pathPrefix))
+}
+
+// MaskWebhookURL は Webhook URL の秘密部分（パスの末尾）を伏せた表示用の文字列を返す。
+// Webhook URL は知っていれば誰でも投稿できるため、API 応答やログには全体を出さない。
+func MaskWebhookURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	path := u.Path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		path = path[:i+1] + "****"
+	}
+	return u.Scheme + "://" + u.Host + path
+}
@@ -0,0 +1,260 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+const (
+	testSlackURL   = "https://hooks.slack.com/services/T000/B000/secret"
+	testDiscordURL = "https://discord.com/api/webhooks/123/secret"
+)
+
+// mockIntegrationRepo は repository.IntegrationRepository のモック実装。
+type mockIntegrationRepo struct {
+	integrations map[string]*model.Integration
+	count        int
+	createOK     bool
+	created      *model.Integration
+	updated      *model.Integration
+}
+
+func newMockIntegrationRepo() *mockIntegrationRepo {
+	return &mockIntegrationRepo{integrations: make(map[string]*model.Integration), createOK: true}
+}
+
+func (m *mockIntegrationRepo) Create(_ context.Context, integration *model.Integration) (bool, error) {
+	if !m.createOK {
+		return false, nil
+	}
+	integration.ID = "int-new"
+	m.created = integration
+	m.integrations[integration.ID] = integration
+	return true, nil
+}
+
+func (m *mockIntegrationRepo) ListByUser(_ context.Context, userID string) ([]model.Integration, error) {
+	var result []model.Integration
+	for _, i := range m.integrations {
+		if i.UserID == userID {
+			result = append(result, *i)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockIntegrationRepo) FindByID(_ context.Context, userID, integrationID string) (*model.Integration, error) {
+	i, ok := m.integrations[integrationID]
+	if !ok || i.UserID != userID {
+		return nil, nil
+	}
+	return i, nil
+}
+
+func (m *mockIntegrationRepo) CountByUser(_ context.Context, _ string) (int, error) {
+	return m.count, nil
+}
+
+func (m *mockIntegrationRepo) Update(_ context.Context, integration *model.Integration) (bool, error) {
+	if _, ok := m.integrations[integration.ID]; !ok {
+		return false, nil
+	}
+	m.updated = integration
+	m.integrations[integration.ID] = integration
+	return true, nil
+}
+
+func (m *mockIntegrationRepo) Delete(_ context.Context, userID, integrationID string) (bool, error) {
+	i, ok := m.integrations[integrationID]
+	if !ok || i.UserID != userID {
+		return false, nil
+	}
+	delete(m.integrations, integrationID)
+	return true, nil
+}
+
+// assertAPIErrorCode は err が指定コードの APIError であることを検証する。
+func assertAPIErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Fatalf("err = %v, want APIError code %s", err, code)
+	}
+}
+
+func TestService_CreateIntegration(t *testing.T) {
+	t.Run("テンプレートを省略したときデフォルトのテンプレートで作成する", func(t *testing.T) {
+		// Arrange
+		repo := newMockIntegrationRepo()
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.CreateIntegration(context.Background(), "user-1", model.Integration{
+			SubscriptionID: "sub-1",
+			Kind:           model.IntegrationKindSlack,
+			WebhookURL:     "  " + testSlackURL + "  ",
+			Enabled:        true,
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("CreateIntegration() error = %v", err)
+		}
+		if got.ID != "int-new" || got.UserID != "user-1" {
+			t.Errorf("got = %+v, want id=int-new user=user-1", got)
+		}
+		if repo.created.WebhookURL != testSlackURL {
+			t.Errorf("WebhookURL = %q, want %q", repo.created.WebhookURL, testSlackURL)
+		}
+		if repo.created.MessageTemplate != model.DefaultIntegrationTemplate {
+			t.Errorf("MessageTemplate = %q, want default", repo.created.MessageTemplate)
+		}
+	})
+
+	t.Run("不正な入力のときINVALID_INTEGRATIONを返す", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input model.Integration
+		}{
+			{"未知のkind", model.Integration{Kind: "teams", WebhookURL: testSlackURL}},
+			{"httpのURL", model.Integration{Kind: model.IntegrationKindSlack, WebhookURL: "http://hooks.slack.com/services/T/B/x"}},
+			{"Slack以外のホスト", model.Integration{Kind: model.IntegrationKindSlack, WebhookURL: "https://example.com/services/T/B/x"}},
+			{"kindとURLの不一致", model.Integration{Kind: model.IntegrationKindSlack, WebhookURL: testDiscordURL}},
+			{"Webhook以外のパス", model.Integration{Kind: model.IntegrationKindDiscord, WebhookURL: "https://discord.com/api/users/@me"}},
+			{"ポート指定", model.Integration{Kind: model.IntegrationKindDiscord, WebhookURL: "https://discord.com:8443/api/webhooks/1/x"}},
+			{"構文エラーのテンプレート", model.Integration{Kind: model.IntegrationKindSlack, WebhookURL: testSlackURL, MessageTemplate: "{{.Title"}},
+			{"未知のフィールドを参照するテンプレート", model.Integration{Kind: model.IntegrationKindSlack, WebhookURL: testSlackURL, MessageTemplate: "{{.Body}}"}},
+			{"長すぎるテンプレート", model.Integration{Kind: model.IntegrationKindSlack, WebhookURL: testSlackURL, MessageTemplate: strings.Repeat("あ", model.MaxIntegrationTemplateLength+1)}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				repo := newMockIntegrationRepo()
+				svc := NewService(repo)
+				tt.input.SubscriptionID = "sub-1"
+
+				// Act
+				_, err := svc.CreateIntegration(context.Background(), "user-1", tt.input)
+
+				// Assert
+				assertAPIErrorCode(t, err, model.ErrCodeInvalidIntegration)
+				if repo.created != nil {
+					t.Error("不正な入力で連携設定が作成された")
+				}
+			})
+		}
+	})
+
+	t.Run("上限に達しているときINTEGRATION_LIMITを返す", func(t *testing.T) {
+		// Arrange
+		repo := newMockIntegrationRepo()
+		repo.count = model.MaxIntegrationsPerUser
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.CreateIntegration(context.Background(), "user-1", model.Integration{
+			SubscriptionID: "sub-1", Kind: model.IntegrationKindDiscord, WebhookURL: testDiscordURL,
+		})
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeIntegrationLimit)
+	})
+
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := newMockIntegrationRepo()
+		repo.createOK = false
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.CreateIntegration(context.Background(), "user-1", model.Integration{
+			SubscriptionID: "sub-x", Kind: model.IntegrationKindDiscord, WebhookURL: testDiscordURL,
+		})
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+	})
+}
+
+func TestService_UpdateIntegration(t *testing.T) {
+	t.Run("webhook_urlを省略したとき現在のURLを維持する", func(t *testing.T) {
+		// Arrange
+		repo := newMockIntegrationRepo()
+		repo.integrations["int-1"] = &model.Integration{
+			ID: "int-1", UserID: "user-1", Kind: model.IntegrationKindSlack, WebhookURL: testSlackURL,
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.UpdateIntegration(context.Background(), "user-1", "int-1", model.Integration{
+			Kind: model.IntegrationKindSlack, MessageTemplate: "{{.Title}}", Enabled: false,
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("UpdateIntegration() error = %v", err)
+		}
+		if repo.updated.WebhookURL != testSlackURL {
+			t.Errorf("WebhookURL = %q, want %q", repo.updated.WebhookURL, testSlackURL)
+		}
+		if repo.updated.MessageTemplate != "{{.Title}}" || repo.updated.Enabled {
+			t.Errorf("updated = %+v, want template={{.Title}} enabled=false", repo.updated)
+		}
+	})
+
+	t.Run("他ユーザーの連携設定のときINTEGRATION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := newMockIntegrationRepo()
+		repo.integrations["int-1"] = &model.Integration{
+			ID: "int-1", UserID: "user-2", Kind: model.IntegrationKindSlack, WebhookURL: testSlackURL,
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.UpdateIntegration(context.Background(), "user-1", "int-1", model.Integration{
+			Kind: model.IntegrationKindSlack,
+		})
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeIntegrationNotFound)
+		if repo.updated != nil {
+			t.Error("他ユーザーの連携設定が更新された")
+		}
+	})
+}
+
+func TestService_DeleteIntegration(t *testing.T) {
+	t.Run("存在しないときINTEGRATION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(newMockIntegrationRepo())
+
+		// Act
+		err := svc.DeleteIntegration(context.Background(), "user-1", "int-x")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeIntegrationNotFound)
+	})
+}
+
+func TestMaskWebhookURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"Slack", testSlackURL, "https://hooks.slack.com/services/T000/B000/****"},
+		{"Discord", testDiscordURL, "https://discord.com/api/webhooks/123/****"},
+		{"不正なURL", "not a url", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"のとき末尾を伏せる", func(t *testing.T) {
+			if got := MaskWebhookURL(tt.url); got != tt.want {
+				t.Errorf("MaskWebhookURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package item
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxAuthorRunes は正規化後の著者名の最大文字数。異常に長い値で一覧や集計が崩れないよう切り詰める。
+const maxAuthorRunes = 200
+
+// emailWithNameRe は RSS 2.0 の author 要素で一般的な "email (Name)" 形式にマッチする。
+var emailWithNameRe = regexp.MustCompile(`^[^\s@()]+@[^\s@()]+\s*\((.+)\)$`)
+
+// normalizeAuthor はフィードから取り出した著者名を著者別フィルタ・集計用に正規化する。
+//
+// NFKC 正規化で全角英数字・全角空白を半角に揃え、制御文字を除いたうえで前後の空白を除去し、
+// 連続する空白を 1 つにまとめる。"john@example.com (John Doe)" 形式は括弧内の名前のみを採用する。
+// 正規化の結果が空の場合は空文字列を返す。
+func normalizeAuthor(author string) string {
+	if author == "" {
+		return ""
+	}
+	s := norm.NFKC.String(author)
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+
+	if m := emailWithNameRe.FindStringSubmatch(s); m != nil {
+		if name := strings.TrimSpace(m[1]); name != "" {
+			s = name
+		}
+	}
+
+	if runes := []rune(s); len(runes) > maxAuthorRunes {
+		s = strings.TrimSpace(string(runes[:maxAuthorRunes]))
+	}
+	return s
+}
@@ -0,0 +1,48 @@
+package item
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAuthor(t *testing.T) {
+	tests := []struct {
+		name   string
+		author string
+		want   string
+	}{
+		{name: "空文字列のとき空文字列を返す", author: "", want: ""},
+		{name: "空白のみのとき空文字列を返す", author: " \t\n　", want: ""},
+		{name: "前後の空白を除去する", author: "  山田 太郎\n", want: "山田 太郎"},
+		{name: "連続する空白と改行を1つの空白にまとめる", author: "John \n\t  Doe", want: "John Doe"},
+		{name: "全角英数字と全角空白を半角に揃える", author: "ＪＯＨＮ　Ｄｏｅ１", want: "JOHN Doe1"},
+		{name: "制御文字を除去する", author: "Ali\u0000ce\u0007", want: "Alice"},
+		{name: "email (Name) 形式のとき名前のみを採用する", author: "john@example.com (John Doe)", want: "John Doe"},
+		{name: "メールアドレスのみのときはそのまま返す", author: "john@example.com", want: "john@example.com"},
+		{name: "括弧を含む通常の名前は変更しない", author: "山田 太郎 (編集部)", want: "山田 太郎 (編集部)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := normalizeAuthor(tt.author)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("normalizeAuthor(%q) = %q, want %q", tt.author, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("最大文字数を超えるとき切り詰める", func(t *testing.T) {
+		// Arrange
+		author := strings.Repeat("あ", maxAuthorRunes+10)
+
+		// Act
+		got := normalizeAuthor(author)
+
+		// Assert
+		if n := len([]rune(got)); n != maxAuthorRunes {
+			t.Errorf("rune count = %d, want %d", n, maxAuthorRunes)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package item
+
+import (
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// itemExcerptRunes は記事一覧で返す抜粋（content_text の先頭）の最大文字数。
+const itemExcerptRunes = 200
+
+// contentTextOf はタグ除去済みのテキストの空白（改行・タブを含む）を 1 つの半角スペースに詰め、
+// 先頭 model.MaxItemContentTextRunes 文字に切り詰めて items.content_text の値にする。
+func contentTextOf(text string) string {
+	return truncateRunes(strings.Join(strings.Fields(text), " "), model.MaxItemContentTextRunes)
+}
+
+// excerptOf は content_text から記事一覧用の抜粋を切り出す。切り詰めた場合は末尾に「…」を付ける。
+func excerptOf(contentText string) string {
+	excerpt := truncateRunes(contentText, itemExcerptRunes)
+	if len(excerpt) < len(contentText) {
+		return strings.TrimRight(excerpt, " ") + "…"
+	}
+	return excerpt
+}
+
+// truncateRunes は s を先頭 n 文字（rune 単位）に切り詰める。
+func truncateRunes(s string, n int) string {
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
@@ -0,0 +1,126 @@
+package item
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestContentTextOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "空文字のとき空文字を返す", content: "", want: ""},
+		{name: "タグを除去してテキストのみを返す", content: "<p>Hello, <b>world</b>.</p><p>次の段落</p>", want: "Hello, world . 次の段落"},
+		{name: "改行やタブを含む空白を1つに詰める", content: "<pre>a\n\n\tb</pre>", want: "a b"},
+		{name: "文字参照はデコードする", content: "R&amp;D &lt;tag&gt;", want: "R&D <tag>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentTextOf(htmlText(tt.content)); got != tt.want {
+				t.Errorf("contentTextOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("上限を超えるとき先頭2000文字に切り詰める", func(t *testing.T) {
+		got := contentTextOf(strings.Repeat("あ", model.MaxItemContentTextRunes+10))
+		if n := utf8.RuneCountInString(got); n != model.MaxItemContentTextRunes {
+			t.Errorf("rune count = %d, want %d", n, model.MaxItemContentTextRunes)
+		}
+	})
+}
+
+func TestExcerptOf(t *testing.T) {
+	t.Run("抜粋の上限以下のときそのまま返す", func(t *testing.T) {
+		if got := excerptOf("短い本文"); got != "短い本文" {
+			t.Errorf("got %q, want %q", got, "短い本文")
+		}
+	})
+
+	t.Run("抜粋の上限を超えるとき切り詰めて末尾に省略記号を付ける", func(t *testing.T) {
+		got := excerptOf(strings.Repeat("い", itemExcerptRunes+1))
+		want := strings.Repeat("い", itemExcerptRunes) + "…"
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestUpsertItems_ContentText(t *testing.T) {
+	t.Run("新規記事のとき本文からプレーンテキストを生成して保存する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{
+			GuidOrID: "ct-guid-1",
+			Title:    "記事",
+			Content:  "<p>本文<br>2 行目</p>",
+			Summary:  "<p>概要</p>",
+		}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastCreatedItem == nil {
+			t.Fatal("lastCreatedItem should not be nil")
+		}
+		if got, want := repo.lastCreatedItem.ContentText, "[sanitized] 本文 2 行目"; got != want {
+			t.Errorf("ContentText = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("本文が空のときサマリーから生成する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{GuidOrID: "ct-guid-2", Title: "概要のみ", Summary: "<p>概要</p>"}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if got, want := repo.lastCreatedItem.ContentText, "[sanitized] 概要"; got != want {
+			t.Errorf("ContentText = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("既存記事の更新のとき再生成し、読了時間は切り詰め前の全文から算出する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		repo.addExistingItem(&model.Item{ID: "existing-ct", FeedID: "feed-1", GuidOrID: "ct-guid-3", ContentText: "古い本文"})
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{
+			GuidOrID: "ct-guid-3",
+			Title:    "長い記事",
+			Content:  "<p>" + strings.Repeat("う", 2990) + "</p>",
+		}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastUpdatedItem == nil {
+			t.Fatal("lastUpdatedItem should not be nil")
+		}
+		if n := utf8.RuneCountInString(repo.lastUpdatedItem.ContentText); n != model.MaxItemContentTextRunes {
+			t.Errorf("ContentText rune count = %d, want %d", n, model.MaxItemContentTextRunes)
+		}
+		if got := repo.lastUpdatedItem.ReadingTimeMinutes; got != 6 {
+			t.Errorf("ReadingTimeMinutes = %d, want 6", got)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// dateGroupKeyLayout は日付グループキーの書式（ユーザーのタイムゾーンでの日付）。
+const dateGroupKeyLayout = "2006-01-02"
+
+// TimezoneResolver はユーザーのタイムゾーン設定を解決するインターフェース。
+// usersettings.Service が実装する。
+type TimezoneResolver interface {
+	Location(ctx context.Context, userID string) (*time.Location, error)
+}
+
+// WithTimezoneResolver は記事一覧の日付グルーピング（DateBoundaries）にユーザーのタイムゾーン設定を用いる。
+// 未設定時は UTC で日付を区切る。
+func WithTimezoneResolver(r TimezoneResolver) ItemServiceOption {
+	return func(s *ItemService) {
+		s.timezone = r
+	}
+}
+
+// DateBoundaries は記事一覧を「今日 / 昨日 / 今週」で見出し分けするための日付境界。
+// いずれもユーザーのタイムゾーンでの 0 時を指す。週は月曜始まりとする（週次統計と同じ）。
+type DateBoundaries struct {
+	Location  *time.Location
+	Today     time.Time
+	Yesterday time.Time
+	WeekStart time.Time
+}
+
+// GroupKey は日時 t のユーザーのタイムゾーンでの日付（YYYY-MM-DD）を返す。
+// 記事の公開日時に適用し、同じキーの記事を同じ日の見出しにまとめる。
+func (b *DateBoundaries) GroupKey(t time.Time) string {
+	return t.In(b.Location).Format(dateGroupKeyLayout)
+}
+
+// DateBoundaries は当該ユーザーのタイムゾーンでの現在日時から日付境界を求める。
+func (s *ItemService) DateBoundaries(ctx context.Context, userID string) (*DateBoundaries, error) {
+	loc := time.UTC
+	if s.timezone != nil {
+		resolved, err := s.timezone.Location(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("タイムゾーンの解決に失敗しました: %w", err)
+		}
+		loc = resolved
+	}
+	return newDateBoundaries(s.now(), loc), nil
+}
+
+// newDateBoundaries は now を loc で解釈した日付境界を返す。
+// 日付の加減算は time.AddDate で行い、夏時間の切り替え日でも 0 時を保つ。
+func newDateBoundaries(now time.Time, loc *time.Location) *DateBoundaries {
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	// time.Weekday は日曜が 0 のため、月曜からの経過日数に直す
+	daysSinceMonday := (int(today.Weekday()) + 6) % 7
+	return &DateBoundaries{
+		Location:  loc,
+		Today:     today,
+		Yesterday: today.AddDate(0, 0, -1),
+		WeekStart: today.AddDate(0, 0, -daysSinceMonday),
+	}
+}
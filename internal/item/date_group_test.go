@@ -0,0 +1,117 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// stubTimezoneResolver は TimezoneResolver のスタブ実装。
+type stubTimezoneResolver struct {
+	loc *time.Location
+	err error
+}
+
+func (s *stubTimezoneResolver) Location(_ context.Context, _ string) (*time.Location, error) {
+	return s.loc, s.err
+}
+
+func TestItemService_DateBoundaries(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	t.Run("ユーザーのタイムゾーンで今日・昨日・週の開始日を求める", func(t *testing.T) {
+		// Arrange（UTC では 7/5(日) 16:00、JST では 7/6(月) 01:00）
+		svc := NewItemService(nil, nil, WithTimezoneResolver(&stubTimezoneResolver{loc: tokyo}))
+		svc.now = func() time.Time { return time.Date(2026, 7, 5, 16, 0, 0, 0, time.UTC) }
+
+		// Act
+		b, err := svc.DateBoundaries(context.Background(), "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := b.GroupKey(b.Today); got != "2026-07-06" {
+			t.Errorf("today = %s, want 2026-07-06", got)
+		}
+		if got := b.GroupKey(b.Yesterday); got != "2026-07-05" {
+			t.Errorf("yesterday = %s, want 2026-07-05", got)
+		}
+		if got := b.GroupKey(b.WeekStart); got != "2026-07-06" {
+			t.Errorf("week_start = %s, want 2026-07-06（月曜）", got)
+		}
+		if !b.Today.Equal(time.Date(2026, 7, 6, 0, 0, 0, 0, tokyo)) {
+			t.Errorf("today = %v, want JST 0 時", b.Today)
+		}
+	})
+
+	t.Run("リゾルバ未設定のときUTCで区切る", func(t *testing.T) {
+		// Arrange（2026-07-05 は日曜）
+		svc := NewItemService(nil, nil)
+		svc.now = func() time.Time { return time.Date(2026, 7, 5, 16, 0, 0, 0, time.UTC) }
+
+		// Act
+		b, err := svc.DateBoundaries(context.Background(), "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b.Location != time.UTC || b.GroupKey(b.Today) != "2026-07-05" || b.GroupKey(b.WeekStart) != "2026-06-29" {
+			t.Errorf("boundaries = %+v", b)
+		}
+	})
+
+	t.Run("タイムゾーンの解決に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewItemService(nil, nil, WithTimezoneResolver(&stubTimezoneResolver{err: errors.New("db error")}))
+
+		// Act
+		_, err := svc.DateBoundaries(context.Background(), "user-1")
+
+		// Assert
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestDateBoundaries_GroupKey(t *testing.T) {
+	t.Run("夏時間の切り替え日でも前日の0時を昨日とする", func(t *testing.T) {
+		// Arrange（2026-03-08 は米国の夏時間開始日で 23 時間しかない）
+		ny, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Fatalf("failed to load location: %v", err)
+		}
+		now := time.Date(2026, 3, 9, 0, 30, 0, 0, ny)
+
+		// Act
+		b := newDateBoundaries(now, ny)
+
+		// Assert
+		if !b.Yesterday.Equal(time.Date(2026, 3, 8, 0, 0, 0, 0, ny)) {
+			t.Errorf("yesterday = %v, want 2026-03-08 00:00 EST", b.Yesterday)
+		}
+	})
+
+	t.Run("記事の公開日時をユーザーのタイムゾーンの日付に変換する", func(t *testing.T) {
+		// Arrange
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		if err != nil {
+			t.Fatalf("failed to load location: %v", err)
+		}
+		b := newDateBoundaries(time.Now(), tokyo)
+
+		// Act
+		got := b.GroupKey(time.Date(2026, 7, 5, 14, 59, 59, 0, time.UTC))
+
+		// Assert
+		if got != "2026-07-05" {
+			t.Errorf("GroupKey = %s, want 2026-07-05", got)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// WithListModTimeRepository は記事一覧の最終更新日時（ListModTime）の取得を有効にする。
+// 未設定時の ListModTime は常にゼロ値を返し、記事一覧 API は条件付き GET に応答しない。
+func WithListModTimeRepository(repo repository.ItemListModTimeRepository) ItemServiceOption {
+	return func(s *ItemService) {
+		s.modTimeRepo = repo
+	}
+}
+
+// ListModTime はフィードの記事一覧の最終更新日時を返す。
+// 記事一覧 API の Last-Modified / If-Modified-Since の判定に用いる。
+// 判定できない場合（未購読・記事なし・リポジトリ未設定）はゼロ値を返す。
+func (s *ItemService) ListModTime(ctx context.Context, userID, feedID string) (time.Time, error) {
+	if s.modTimeRepo == nil {
+		return time.Time{}, nil
+	}
+	modTime, err := s.modTimeRepo.ItemListModTime(ctx, userID, feedID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("記事一覧の最終更新日時の取得に失敗しました: %w", err)
+	}
+	return modTime, nil
+}
@@ -0,0 +1,74 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// stubItemListModTimeRepo は ItemListModTimeRepository のスタブ。
+type stubItemListModTimeRepo struct {
+	modTime              time.Time
+	err                  error
+	gotUserID, gotFeedID string
+}
+
+func (r *stubItemListModTimeRepo) ItemListModTime(ctx context.Context, userID, feedID string) (time.Time, error) {
+	r.gotUserID, r.gotFeedID = userID, feedID
+	return r.modTime, r.err
+}
+
+func TestItemService_ListModTime(t *testing.T) {
+	modTime := time.Date(2026, 7, 7, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ユーザーとフィードを指定してリポジトリの最終更新日時を返す", func(t *testing.T) {
+		// Arrange
+		repo := &stubItemListModTimeRepo{modTime: modTime}
+		svc := NewItemService(nil, nil, WithListModTimeRepository(repo))
+
+		// Act
+		got, err := svc.ListModTime(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.Equal(modTime) {
+			t.Errorf("ListModTime = %v, want %v", got, modTime)
+		}
+		if repo.gotUserID != "user-1" || repo.gotFeedID != "feed-1" {
+			t.Errorf("ItemListModTime(%q, %q), want (user-1, feed-1)", repo.gotUserID, repo.gotFeedID)
+		}
+	})
+
+	t.Run("リポジトリ未設定のときゼロ値を返す", func(t *testing.T) {
+		// Arrange
+		svc := NewItemService(nil, nil)
+
+		// Act
+		got, err := svc.ListModTime(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.IsZero() {
+			t.Errorf("ListModTime = %v, want zero", got)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repoErr := errors.New("db error")
+		svc := NewItemService(nil, nil, WithListModTimeRepository(&stubItemListModTimeRepo{err: repoErr}))
+
+		// Act
+		_, err := svc.ListModTime(context.Background(), "user-1", "feed-1")
+
+		// Assert
+		if !errors.Is(err, repoErr) {
+			t.Errorf("err = %v, want %v", err, repoErr)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// MaxListFeedIDs は複数フィードを指定する記事一覧で一度に指定できるフィード数の上限。
+const MaxListFeedIDs = 50
+
+// WithSubscribedFeedRepository は複数フィードを指定する記事一覧（ListItemsByFeeds）の購読チェックに用いるリポジトリを設定する。
+// 未設定時は購読を確認できないため、ListItemsByFeeds はエラーを返す。
+func WithSubscribedFeedRepository(repo repository.SubscribedFeedRepository) ItemServiceOption {
+	return func(s *ItemService) {
+		s.subscribedRepo = repo
+	}
+}
+
+// ListItemsByFeeds は feedIDs で指定した複数フィードの記事を 1 つの一覧として返す。
+// 絞り込み・カーソル・件数の扱いは ListItems と同じ。重複した ID は 1 件として扱う。
+// フィード数が 0 件または MaxListFeedIDs を超える場合、UUID でない ID を含む場合は INVALID_FILTER を、
+// ユーザーが購読していないフィードを含む場合は FEED_NOT_FOUND を返す（他ユーザーのフィードの存在は明かさない）。
+func (s *ItemService) ListItemsByFeeds(
+	ctx context.Context,
+	userID string,
+	feedIDs []string,
+	conds model.ItemConditions,
+	author string,
+	cursorStr string,
+	limit int,
+) (*ItemListResult, error) {
+	feedIDs, err := normalizeFeedIDs(feedIDs)
+	if err != nil {
+		return nil, err
+	}
+	if s.subscribedRepo == nil {
+		return nil, fmt.Errorf("購読チェック用のリポジトリが設定されていません")
+	}
+
+	subscribed, err := s.subscribedRepo.FilterSubscribedFeedIDs(ctx, userID, feedIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(subscribed) != len(feedIDs) {
+		return nil, &model.APIError{
+			Code:     model.ErrCodeFeedNotFound,
+			Message:  "指定したフィードの一部が見つかりません。",
+			Category: "feed",
+			Action:   "購読中のフィードの ID を指定してください。",
+		}
+	}
+
+	cursor, err := parseItemCursor(feedItemsCursorSort, cursorStr)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.itemRepo.ListByFeeds(ctx, feedIDs, userID, conds, normalizeAuthor(author), cursor.Time, cursor.ID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return buildItemListResult(items, limit), nil
+}
+
+// normalizeFeedIDs はフィード ID の前後の空白と空要素・重複を除き、件数と形式を検証する。
+// UUID は小文字の正規形に揃え、購読チェックの結果と件数で突き合わせられるようにする。
+func normalizeFeedIDs(feedIDs []string) ([]string, error) {
+	seen := make(map[string]bool, len(feedIDs))
+	normalized := make([]string, 0, len(feedIDs))
+	for _, raw := range feedIDs {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, model.NewInvalidFilterError("feed_ids=" + raw)
+		}
+		if seen[id.String()] {
+			continue
+		}
+		seen[id.String()] = true
+		normalized = append(normalized, id.String())
+	}
+	if len(normalized) == 0 {
+		return nil, model.NewInvalidFilterError("feed_ids")
+	}
+	if len(normalized) > MaxListFeedIDs {
+		return nil, &model.APIError{
+			Code:     model.ErrCodeInvalidFilter,
+			Message:  fmt.Sprintf("feed_ids に指定できるフィードは %d 件までです。", MaxListFeedIDs),
+			Category: "validation",
+			Action:   "指定するフィードを減らしてください。",
+		}
+	}
+	return normalized, nil
+}
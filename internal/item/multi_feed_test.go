@@ -0,0 +1,104 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// stubSubscribedFeedRepo は SubscribedFeedRepository のスタブ。subscribed に含まれるフィードだけを購読中として返す。
+type stubSubscribedFeedRepo struct {
+	subscribed map[string]bool
+}
+
+func (r *stubSubscribedFeedRepo) FilterSubscribedFeedIDs(ctx context.Context, userID string, feedIDs []string) ([]string, error) {
+	var got []string
+	for _, id := range feedIDs {
+		if r.subscribed[id] {
+			got = append(got, id)
+		}
+	}
+	return got, nil
+}
+
+func TestItemService_ListItemsByFeeds(t *testing.T) {
+	const (
+		feedA = "11111111-1111-1111-1111-111111111111"
+		feedB = "22222222-2222-2222-2222-222222222222"
+		feedC = "33333333-3333-3333-3333-333333333333"
+	)
+	subs := &stubSubscribedFeedRepo{subscribed: map[string]bool{feedA: true, feedB: true}}
+
+	t.Run("購読中の複数フィードを重複を除いてリポジトリに渡す", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepoForService()
+		var gotFeedIDs []string
+		repo.listByFeedsFn = func(ctx context.Context, feedIDs []string, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+			gotFeedIDs = feedIDs
+			return []model.ItemWithState{{Item: model.Item{ID: "item-1", FeedID: feedB}}}, nil
+		}
+		svc := NewItemService(repo, nil, WithSubscribedFeedRepository(subs))
+
+		// Act
+		result, err := svc.ListItemsByFeeds(context.Background(), "user-1", []string{feedA, " " + feedB, strings.ToUpper(feedA), ""}, model.ItemConditions{}, "", "", 20)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{feedA, feedB}; !reflect.DeepEqual(gotFeedIDs, want) {
+			t.Errorf("feedIDs = %v, want %v", gotFeedIDs, want)
+		}
+		if len(result.Items) != 1 || result.Items[0].FeedID != feedB {
+			t.Errorf("items = %+v, want 1 item of %s", result.Items, feedB)
+		}
+	})
+
+	tooMany := make([]string, MaxListFeedIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+	}
+	tests := []struct {
+		name     string
+		feedIDs  []string
+		wantCode string
+	}{
+		{name: "購読していないフィードを含むときFEED_NOT_FOUNDを返す", feedIDs: []string{feedA, feedC}, wantCode: model.ErrCodeFeedNotFound},
+		{name: "UUIDでないIDを含むときINVALID_FILTERを返す", feedIDs: []string{feedA, "not-a-uuid"}, wantCode: model.ErrCodeInvalidFilter},
+		{name: "フィードが指定されていないときINVALID_FILTERを返す", feedIDs: []string{"", " "}, wantCode: model.ErrCodeInvalidFilter},
+		{name: "上限を超えるフィードを指定したときINVALID_FILTERを返す", feedIDs: tooMany, wantCode: model.ErrCodeInvalidFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := newMockItemRepoForService()
+			repo.listByFeedsFn = func(ctx context.Context, feedIDs []string, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+				t.Error("ListByFeeds should not be called")
+				return nil, nil
+			}
+			svc := NewItemService(repo, nil, WithSubscribedFeedRepository(subs))
+
+			// Act
+			_, err := svc.ListItemsByFeeds(context.Background(), "user-1", tt.feedIDs, model.ItemConditions{}, "", "", 20)
+
+			// Assert
+			apiErr, ok := err.(*model.APIError)
+			if !ok || apiErr.Code != tt.wantCode {
+				t.Errorf("err = %v, want %s", err, tt.wantCode)
+			}
+		})
+	}
+
+	t.Run("購読チェック用のリポジトリが未設定のときエラーを返す", func(t *testing.T) {
+		svc := NewItemService(newMockItemRepoForService(), nil)
+
+		if _, err := svc.ListItemsByFeeds(context.Background(), "user-1", []string{feedA}, model.ItemConditions{}, "", "", 20); err == nil {
+			t.Error("err = nil, want error")
+		}
+	})
+}
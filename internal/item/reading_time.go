@@ -0,0 +1,85 @@
+package item
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// cjkCharsPerMinute は日本語等（CJK 文字）の 1 分あたりの読字数。
+	cjkCharsPerMinute = 500
+	// wordsPerMinute は英語等（空白区切りの言語）の 1 分あたりの読語数。
+	wordsPerMinute = 200
+)
+
+// estimateReadingMinutes はサニタイズ済み HTML 本文から読了時間（分）を推定する。
+//
+// タグを除いたテキストのうち、漢字・ひらがな・カタカナ・ハングルは 1 文字ずつ数えて
+// cjkCharsPerMinute で割り、それ以外の文字や数字の連なりは 1 単語として wordsPerMinute で割る。
+// 日英が混在する本文は両者の合計とし、端数は切り上げる。テキストが無い場合は 0 を返す。
+func estimateReadingMinutes(content string) int {
+	return readingMinutesOfText(htmlText(content))
+}
+
+// readingMinutesOfText はタグ除去済みのテキストから読了時間（分）を推定する。
+func readingMinutesOfText(text string) int {
+	cjkChars, words := countReadingUnits(text)
+	if cjkChars == 0 && words == 0 {
+		return 0
+	}
+	minutes := float64(cjkChars)/cjkCharsPerMinute + float64(words)/wordsPerMinute
+	return max(1, int(math.Ceil(minutes)))
+}
+
+// htmlText は HTML からテキストノードのみを取り出して連結する。
+// 文字参照（&amp; 等）はトークナイザがデコードする。iframe・video の代替テキストは本文として扱わないため含めない
+// （テキストオンリーの本文と読了時間・抜粋を一致させる）。
+func htmlText(content string) string {
+	if content == "" {
+		return ""
+	}
+	var b strings.Builder
+	scanHTML(content, func(z *html.Tokenizer, tt html.TokenType, inMedia bool) {
+		switch tt {
+		case html.TextToken:
+			if !inMedia {
+				b.Write(z.Text())
+				b.WriteByte(' ')
+			}
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			// タグ境界で単語が連結されないよう区切りを入れる
+			b.WriteByte(' ')
+		}
+	})
+	return b.String()
+}
+
+// countReadingUnits はテキスト中の CJK 文字数と単語数を数える。
+func countReadingUnits(text string) (cjkChars, words int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			cjkChars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+				inWord = true
+			}
+		case r == '\'' || r == '’' || r == '-':
+			// don't / well-known のような語中の記号では単語を区切らない
+		default:
+			inWord = false
+		}
+	}
+	return cjkChars, words
+}
+
+// isCJK は文字単位で読字数を数える文字（漢字・かな・ハングル）かどうかを返す。
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,115 @@
+package item
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestEstimateReadingMinutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{name: "空文字のとき0を返す", content: "", want: 0},
+		{name: "タグのみでテキストが無いとき0を返す", content: `<p><img src="a.png"></p>`, want: 0},
+		{name: "短い英文のとき最低1分を返す", content: "<p>Hello, world.</p>", want: 1},
+		{name: "英語は200単語ごとに1分とする", content: "<p>" + strings.Repeat("word ", 400) + "</p>", want: 2},
+		{name: "英語は端数を切り上げる", content: strings.Repeat("word ", 401), want: 3},
+		{name: "日本語は500文字ごとに1分とする", content: "<p>" + strings.Repeat("あ", 1500) + "</p>", want: 3},
+		{name: "カタカナと漢字も文字数で数える", content: strings.Repeat("記事テスト", 200), want: 2},
+		{name: "日英混在のとき文字数と単語数の時間を合算する", content: strings.Repeat("日", 500) + " " + strings.Repeat("word ", 200), want: 2},
+		{name: "タグ境界で単語が連結されない", content: strings.Repeat("<b>a</b><i>b</i>", 100), want: 1},
+		{name: "語中のアポストロフィとハイフンで単語を分割しない", content: strings.Repeat("don't well-known ", 150), want: 2},
+		{name: "文字参照はデコードして数える", content: strings.Repeat("R&amp;D ", 200), want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := estimateReadingMinutes(tt.content); got != tt.want {
+				t.Errorf("estimateReadingMinutes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertItems_ReadingTimeMinutes(t *testing.T) {
+	t.Run("新規記事のとき本文から読了時間を算出して保存する", func(t *testing.T) {
+		// Arrange: mockSanitizer が付与する "[sanitized]" も 1 単語として数えられる
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{
+			GuidOrID: "rt-guid-1",
+			Title:    "長い記事",
+			Content:  "<p>" + strings.Repeat("あ", 990) + "</p>",
+			Summary:  "短い概要",
+		}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastCreatedItem == nil {
+			t.Fatal("lastCreatedItem should not be nil")
+		}
+		if got := repo.lastCreatedItem.ReadingTimeMinutes; got != 2 {
+			t.Errorf("ReadingTimeMinutes = %d, want 2", got)
+		}
+	})
+
+	t.Run("本文が空のときサマリーから読了時間を算出する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{
+			GuidOrID: "rt-guid-2",
+			Title:    "概要のみの記事",
+			Summary:  strings.Repeat("い", 600),
+		}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if got := repo.lastCreatedItem.ReadingTimeMinutes; got != 2 {
+			t.Errorf("ReadingTimeMinutes = %d, want 2", got)
+		}
+	})
+
+	t.Run("既存記事の更新のとき読了時間を再計算する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		repo.addExistingItem(&model.Item{
+			ID:                 "existing-rt",
+			FeedID:             "feed-1",
+			GuidOrID:           "rt-guid-3",
+			ReadingTimeMinutes: 10,
+		})
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{
+			GuidOrID: "rt-guid-3",
+			Title:    "短くなった記事",
+			Content:  "<p>short</p>",
+		}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastUpdatedItem == nil {
+			t.Fatal("lastUpdatedItem should not be nil")
+		}
+		if got := repo.lastUpdatedItem.ReadingTimeMinutes; got != 1 {
+			t.Errorf("ReadingTimeMinutes = %d, want 1", got)
+		}
+	})
+}
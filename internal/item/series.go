@@ -0,0 +1,117 @@
+package item
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSeriesKeyRunes は series_key の最大文字数。異常に長いタイトルでインデックスが肥大しないよう切り詰める。
+const maxSeriesKeyRunes = 200
+
+// minSeriesKeyRunes は連載とみなす残りタイトルの最小文字数。「第 3 回」のみのタイトルは連載として扱わない。
+const minSeriesKeyRunes = 2
+
+// seriesNumber は連番として扱う数字（算用数字・漢数字）。全角数字は NFKC 正規化で半角に揃えてから照合する。
+const seriesNumber = `[0-9〇零一二三四五六七八九十百]+`
+
+// seriesMarkers は連載の回数を表す表記。先に並ぶものほど優先し、最初に一致した 1 箇所だけを取り除く。
+var seriesMarkers = []*regexp.Regexp{
+	// 第3回 / 第十二話 / 第2部 / 第1章 など
+	regexp.MustCompile(`第\s*` + seriesNumber + `\s*[回話部章弾夜号巻]`),
+	// その3 / パート2
+	regexp.MustCompile(`(?:その|パート)\s*` + seriesNumber),
+	// Part 2 / Vol.3 / Episode 4 / Ep.5 / Chapter 6 / No.7
+	regexp.MustCompile(`\b(?:part|pt\.?|vol\.?|volume|episode|ep\.?|chapter|ch\.|no\.)\s*[0-9]+\b`),
+	// 前編 / 中編 / 後編 / (上) / (下)
+	regexp.MustCompile(`[前中後]編|\(\s*[上中下]\s*\)`),
+	// #12（C# 12 のような言語名は除くため、直前が英数字の場合は一致させない）
+	regexp.MustCompile(`(?:^|[^a-z0-9])#\s*[0-9]+`),
+	// 末尾の (3)
+	regexp.MustCompile(`\(\s*[0-9]+\s*\)\s*$`),
+}
+
+// seriesSeparators は連番表記の前後に置かれがちな区切り文字。連番と一緒に取り除く。
+const seriesSeparators = " -–—:|/,、。.!?~〜・"
+
+// seriesBrackets はタイトル中の括弧。【連載】のような見出しの括弧の有無でキーが揺れないよう空白に置き換える。
+const seriesBrackets = "「」『』【】[]()〔〕<>"
+
+// detectSeriesKey は記事タイトルから連載（シリーズ）のキーを検出する。
+//
+// NFKC 正規化・小文字化したタイトルから「第 N 回」「その N」「Part N」「Vol.N」「前編」「#N」等の
+// 連番表記を前後の区切り文字ごと 1 箇所取り除き、括弧を空白に置き換えて空白と前後の区切り文字を整えたうえでキーとする。
+// 同じ連載の記事は連番以外のタイトルが一致する前提のヒューリスティクスであり、
+// 連番表記が見つからない場合や残りが短すぎる場合は空文字列（連載ではない）を返す。
+func detectSeriesKey(title string) string {
+	if title == "" {
+		return ""
+	}
+	s := strings.ToLower(norm.NFKC.String(title))
+
+	loc := findSeriesMarker(s)
+	if loc == nil {
+		return ""
+	}
+	before := strings.TrimRight(s[:loc[0]], seriesSeparators)
+	after := strings.TrimLeft(s[loc[1]:], seriesSeparators)
+	s = before + " " + after
+
+	s = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(seriesBrackets, r) {
+			return ' '
+		}
+		return r
+	}, s)
+	s = strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+	s = strings.Trim(s, seriesSeparators)
+
+	runes := []rune(s)
+	if len(runes) < minSeriesKeyRunes {
+		return ""
+	}
+	if len(runes) > maxSeriesKeyRunes {
+		s = strings.TrimSpace(string(runes[:maxSeriesKeyRunes]))
+	}
+	return s
+}
+
+// findSeriesMarker は s の中で最初に一致した連番表記の位置を返す。見つからない場合は nil を返す。
+func findSeriesMarker(s string) []int {
+	for _, re := range seriesMarkers {
+		if loc := re.FindStringIndex(s); loc != nil {
+			return loc
+		}
+	}
+	return nil
+}
+
+// ItemGroup は記事一覧を連載（series_key）で折りたたんだ 1 グループ。
+// 連載ではない記事は SeriesKey が空の 1 件だけのグループになる。
+type ItemGroup struct {
+	SeriesKey string
+	Items     []ItemSummary
+}
+
+// groupBySeries は記事一覧を series_key でグループ化する。
+// グループの並びは各グループで最初に現れた記事の位置（published_at 降順）を保ち、
+// グループ内の記事も元の並び順を保つ。
+func groupBySeries(items []ItemSummary) []ItemGroup {
+	groups := make([]ItemGroup, 0, len(items))
+	index := make(map[string]int)
+	for _, it := range items {
+		if it.SeriesKey == "" {
+			groups = append(groups, ItemGroup{Items: []ItemSummary{it}})
+			continue
+		}
+		if i, ok := index[it.SeriesKey]; ok {
+			groups[i].Items = append(groups[i].Items, it)
+			continue
+		}
+		index[it.SeriesKey] = len(groups)
+		groups = append(groups, ItemGroup{SeriesKey: it.SeriesKey, Items: []ItemSummary{it}})
+	}
+	return groups
+}
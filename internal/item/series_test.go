@@ -0,0 +1,148 @@
+package item
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestDetectSeriesKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "空文字のとき空文字を返す", title: "", want: ""},
+		{name: "連番表記がないとき空文字を返す", title: "Go 1.25 のリリースノートを読む", want: ""},
+		{name: "第N回を取り除く", title: "Go 入門 第3回", want: "go 入門"},
+		{name: "漢数字の第N話を取り除く", title: "第十二話 はじめての Kubernetes", want: "はじめての kubernetes"},
+		{name: "全角数字と隅付き括弧を正規化して取り除く", title: "【連載】Rust で作る DB 第２回", want: "連載 rust で作る db"},
+		{name: "括弧内の連番を取り除き空の括弧を残さない", title: "【第4回】Rust で作る DB", want: "rust で作る db"},
+		{name: "Part Nを大文字小文字を区別せず取り除く", title: "Building a Compiler, PART 2", want: "building a compiler"},
+		{name: "Vol.Nを取り除く", title: "Weekly Digest Vol.42", want: "weekly digest"},
+		{name: "その Nを取り除く", title: "家庭菜園日記 その5", want: "家庭菜園日記"},
+		{name: "前編・後編を取り除く", title: "データベース設計の勘所（後編）", want: "データベース設計の勘所"},
+		{name: "#Nを取り除く", title: "Podcast #128: 今週のニュース", want: "podcast 今週のニュース"},
+		{name: "C# のような言語名の#は連番とみなさない", title: "C# 12 の新機能", want: ""},
+		{name: "末尾の(N)を取り除く", title: "はじめての SQL (3)", want: "はじめての sql"},
+		{name: "連番のみのタイトルは連載とみなさない", title: "第3回", want: ""},
+		{name: "前後の区切り文字を取り除く", title: "第1回 - 自作 OS 入門 -", want: "自作 os 入門"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectSeriesKey(tt.title); got != tt.want {
+				t.Errorf("detectSeriesKey(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("同じ連載の異なる回は同じキーになる", func(t *testing.T) {
+		a := detectSeriesKey("Go 入門 第1回")
+		b := detectSeriesKey("Go入門　第２回")
+		if a == "" || a != detectSeriesKey("Go 入門 第10回") {
+			t.Errorf("keys = %q, %q", a, detectSeriesKey("Go 入門 第10回"))
+		}
+		if b == "" {
+			t.Errorf("全角空白・全角数字のタイトルからキーを検出できない")
+		}
+	})
+}
+
+func TestGroupBySeries(t *testing.T) {
+	t.Run("同じ連載の記事を最初の出現位置にまとめ連載以外は1件ずつのグループにする", func(t *testing.T) {
+		// Arrange
+		items := []ItemSummary{
+			{ID: "1", SeriesKey: "go 入門"},
+			{ID: "2"},
+			{ID: "3", SeriesKey: "rust"},
+			{ID: "4", SeriesKey: "go 入門"},
+			{ID: "5"},
+		}
+
+		// Act
+		groups := groupBySeries(items)
+
+		// Assert
+		want := []struct {
+			key string
+			ids []string
+		}{
+			{"go 入門", []string{"1", "4"}},
+			{"", []string{"2"}},
+			{"rust", []string{"3"}},
+			{"", []string{"5"}},
+		}
+		if len(groups) != len(want) {
+			t.Fatalf("len(groups) = %d, want %d", len(groups), len(want))
+		}
+		for i, w := range want {
+			if groups[i].SeriesKey != w.key {
+				t.Errorf("groups[%d].SeriesKey = %q, want %q", i, groups[i].SeriesKey, w.key)
+			}
+			if len(groups[i].Items) != len(w.ids) {
+				t.Fatalf("groups[%d] has %d items, want %d", i, len(groups[i].Items), len(w.ids))
+			}
+			for j, id := range w.ids {
+				if groups[i].Items[j].ID != id {
+					t.Errorf("groups[%d].Items[%d].ID = %q, want %q", i, j, groups[i].Items[j].ID, id)
+				}
+			}
+		}
+	})
+
+	t.Run("記事がないとき空のスライスを返す", func(t *testing.T) {
+		if groups := groupBySeries(nil); groups == nil || len(groups) != 0 {
+			t.Errorf("groups = %v, want empty slice", groups)
+		}
+	})
+}
+
+func TestUpsertItems_SeriesKey(t *testing.T) {
+	t.Run("新規記事のときタイトルから連載のキーを検出して保存する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{GuidOrID: "series-guid-1", Title: "Go 入門 第3回"}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastCreatedItem == nil {
+			t.Fatal("lastCreatedItem should not be nil")
+		}
+		if got := repo.lastCreatedItem.SeriesKey; got != "go 入門" {
+			t.Errorf("SeriesKey = %q, want %q", got, "go 入門")
+		}
+	})
+
+	t.Run("既存記事のタイトルから連番がなくなったときキーを空にする", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		repo.addExistingItem(&model.Item{
+			ID:        "existing-series",
+			FeedID:    "feed-1",
+			GuidOrID:  "series-guid-2",
+			SeriesKey: "go 入門",
+		})
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{GuidOrID: "series-guid-2", Title: "Go 入門のまとめ"}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastUpdatedItem == nil {
+			t.Fatal("lastUpdatedItem should not be nil")
+		}
+		if got := repo.lastUpdatedItem.SeriesKey; got != "" {
+			t.Errorf("SeriesKey = %q, want empty", got)
+		}
+	})
+}
@@ -3,27 +3,97 @@ package item
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
 	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
 )
 
+// LinkPreference は記事本文リンクの開き方に関するユーザー設定を参照するインターフェース。
+// usersettings.Service が実装する。
+type LinkPreference interface {
+	OpenLinksInNewTab(ctx context.Context, userID string) (bool, error)
+}
+
+// ViewRecorder は記事詳細の閲覧イベントを記録するインターフェース。
+// stats.ViewRecorder が実装する。呼び出し元をブロックしないことを実装側に求める。
+type ViewRecorder interface {
+	RecordView(userID, itemID string)
+}
+
+// HatebuRefresher は記事詳細の取得時に古いはてブ数の再取得を依頼するインターフェース。
+// hatebu.OnDemandRefresher が実装する。鮮度の判定は実装側が行い、呼び出し元をブロックしないことを求める。
+type HatebuRefresher interface {
+	RequestRefresh(itemID, url string, fetchedAt *time.Time)
+}
+
 // ItemService は記事取得・フィルタリングのサービス。
 type ItemService struct {
-	itemRepo      repository.ItemRepository
-	itemStateRepo repository.ItemStateRepository
+	itemRepo       repository.ItemRepository
+	itemStateRepo  repository.ItemStateRepository
+	linkPreference LinkPreference
+	viewRecorder   ViewRecorder
+	hatebu         HatebuRefresher
+	authorRepo     repository.FeedAuthorRepository
+	timezone       TimezoneResolver
+	modTimeRepo    repository.ItemListModTimeRepository
+	subscribedRepo repository.SubscribedFeedRepository
+	now            func() time.Time
+}
+
+// ItemServiceOption は NewItemService の任意設定を表す functional option。
+type ItemServiceOption func(*ItemService)
+
+// WithLinkPreference は記事詳細の本文リンクにユーザーの「新しいタブで開く」設定を反映する。
+// 未設定時は model.DefaultOpenLinksInNewTab を用いる。
+func WithLinkPreference(p LinkPreference) ItemServiceOption {
+	return func(s *ItemService) {
+		s.linkPreference = p
+	}
+}
+
+// WithViewRecorder は記事詳細の取得（GetItem）を閲覧イベントとして記録する。
+func WithViewRecorder(r ViewRecorder) ItemServiceOption {
+	return func(s *ItemService) {
+		s.viewRecorder = r
+	}
+}
+
+// WithHatebuRefresher は記事詳細の取得（GetItem）時に、古いはてブ数の非同期の再取得を依頼する。
+// 取得中のレスポンスには反映せず、次回の表示で新しい値が返る。
+func WithHatebuRefresher(r HatebuRefresher) ItemServiceOption {
+	return func(s *ItemService) {
+		s.hatebu = r
+	}
+}
+
+// WithAuthorRepository はフィード内の著者一覧（ListAuthors）の集計に用いるリポジトリを設定する。
+// 未設定時の ListAuthors は空の一覧を返す。
+func WithAuthorRepository(repo repository.FeedAuthorRepository) ItemServiceOption {
+	return func(s *ItemService) {
+		s.authorRepo = repo
+	}
 }
 
 // NewItemService はItemServiceの新しいインスタンスを生成する。
 func NewItemService(
 	itemRepo repository.ItemRepository,
 	itemStateRepo repository.ItemStateRepository,
+	opts ...ItemServiceOption,
 ) *ItemService {
-	return &ItemService{
+	s := &ItemService{
 		itemRepo:      itemRepo,
 		itemStateRepo: itemStateRepo,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // ItemListResult はListItemsの戻り値。
@@ -44,7 +114,28 @@ type ItemSummary struct {
 	IsDateEstimated bool
 	IsRead          bool
 	IsStarred       bool
-	HatebuCount     int
+	// IsHidden は記事が非表示（ミュート）にされているか。include_hidden=true の一覧でのみ true になり得る。
+	IsHidden bool
+	// Rating はユーザーが付けた評価（1〜model.MaxItemRating）。未評価は 0。
+	Rating      int
+	HatebuCount int
+	// ReadingTimeMinutes は本文から推定した読了時間（分）。本文が無い場合は 0。
+	ReadingTimeMinutes int
+	// Excerpt は本文のプレーンテキスト（content_text）の先頭 itemExcerptRunes 文字。未生成の記事は空文字列。
+	Excerpt string
+	// ThumbnailURL は取り込み時に検証したサムネイル画像の URL。無い記事は空文字列。
+	ThumbnailURL string
+	// SeriesKey はタイトルから検出した連載のキー。連載ではない記事は空文字列。
+	SeriesKey string
+	// GeneratedSummary は要約器が生成した要約（POST /api/items/{id}/summarize）。未生成の記事は空文字列。
+	GeneratedSummary string
+}
+
+// ItemGroupListResult は ListItemGroups の戻り値。ページングは ListItems と同じ記事単位で行う。
+type ItemGroupListResult struct {
+	Groups     []ItemGroup
+	NextCursor string
+	HasMore    bool
 }
 
 // StarredItemSummary は全フィード横断スター記事一覧のサマリー情報。
@@ -54,6 +145,8 @@ type StarredItemSummary struct {
 	ItemSummary
 	// FeedTitle は当該記事が所属するフィードのタイトル（feeds.title）。
 	FeedTitle string
+	// LinkStatus はリンク切れチェックの判定結果。未判定の場合は空文字列。
+	LinkStatus model.LinkStatus
 }
 
 // StarredItemListResult は ListStarredItems の戻り値。
@@ -64,33 +157,39 @@ type StarredItemListResult struct {
 	HasMore    bool
 }
 
-// validFilters は有効なフィルタ値のセット。
-var validFilters = map[model.ItemFilter]bool{
-	model.ItemFilterAll:     true,
-	model.ItemFilterUnread:  true,
-	model.ItemFilterStarred: true,
-}
+// 記事一覧カーソルの並び順識別子。別 API のカーソルの流用を拒否するため API ごとに分ける。
+const (
+	feedItemsCursorSort          = "feed_items.published_at_desc"
+	starredItemsCursorSort       = "starred_items.published_at_desc"
+	starredItemsRatingCursorSort = "starred_items.rating_desc"
+)
 
-// parseItemCursor は RFC3339Nano → RFC3339 の順でカーソル文字列をパースする。
-// 空文字列の場合はゼロ値（先頭ページ取得を意味する）を返す。
-// パース不能な場合は model.NewInvalidFilterError を返す。
+// parseItemCursor は pagination の不透明カーソル（移行期間中は旧形式の RFC3339 文字列も可）を
+// (published_at, id) の複合カーソル値に復元する。
+// 空文字列の場合はゼロ値（先頭ページ取得を意味する）を返す。旧形式の RFC3339 文字列は ID が空文字列になり、
+// リポジトリは published_at のみで境界を判定する（移行互換）。
+// 復元できない場合、または ID が UUID でない場合は model.NewInvalidFilterError を返す。
 // 本ヘルパは ListItems / ListStarredItems で共有され、横断 API のカーソル規約を
 // 既存単一フィード API と完全に同一に保つ（Requirement 4.5 / 4.8 / NFR 3.1）。
-func parseItemCursor(cursorStr string) (time.Time, error) {
-	if cursorStr == "" {
-		return time.Time{}, nil
-	}
-	cursor, err := time.Parse(time.RFC3339Nano, cursorStr)
+func parseItemCursor(sort, cursorStr string) (pagination.Cursor, error) {
+	cursor, err := pagination.Decode(sort, cursorStr)
 	if err != nil {
-		// RFC3339でもパースを試みる
-		cursor, err = time.Parse(time.RFC3339, cursorStr)
-		if err != nil {
-			return time.Time{}, model.NewInvalidFilterError("無効なカーソル値: " + cursorStr)
+		return pagination.Cursor{}, model.NewInvalidFilterError("無効なカーソル値: " + cursorStr)
+	}
+	// id は DB で uuid として比較するため、形式不正は DB エラーではなく入力エラーとして返す
+	if cursor.ID != "" {
+		if _, err := uuid.Parse(cursor.ID); err != nil {
+			return pagination.Cursor{}, model.NewInvalidFilterError("無効なカーソル値: " + cursorStr)
 		}
 	}
 	return cursor, nil
 }
 
+// formatItemCursor は末尾記事の published_at と ID から次ページのカーソルを組み立てる。
+func formatItemCursor(sort string, last ItemSummary) string {
+	return pagination.Encode(sort, pagination.Cursor{Time: last.PublishedAt, ID: last.ID})
+}
+
 // toItemSummary は model.ItemWithState を ItemSummary に変換する。
 // PublishedAt が nil の場合はゼロ値の time.Time を採用する。
 func toItemSummary(item model.ItemWithState) ItemSummary {
@@ -99,24 +198,31 @@ func toItemSummary(item model.ItemWithState) ItemSummary {
 		pubAt = *item.PublishedAt
 	}
 	return ItemSummary{
-		ID:              item.ID,
-		FeedID:          item.FeedID,
-		Title:           item.Title,
-		Link:            item.Link,
-		Summary:         item.Summary,
-		PublishedAt:     pubAt,
-		IsDateEstimated: item.IsDateEstimated,
-		IsRead:          item.IsRead,
-		IsStarred:       item.IsStarred,
-		HatebuCount:     item.HatebuCount,
+		ID:                 item.ID,
+		FeedID:             item.FeedID,
+		Title:              item.Title,
+		Link:               item.Link,
+		Summary:            item.Summary,
+		PublishedAt:        pubAt,
+		IsDateEstimated:    item.IsDateEstimated,
+		IsRead:             item.IsRead,
+		IsStarred:          item.IsStarred,
+		IsHidden:           item.IsHidden,
+		Rating:             item.Rating,
+		HatebuCount:        item.HatebuCount,
+		ReadingTimeMinutes: item.ReadingTimeMinutes,
+		Excerpt:            excerptOf(item.ContentText),
+		ThumbnailURL:       item.ThumbnailURL,
+		SeriesKey:          item.SeriesKey,
+		GeneratedSummary:   item.GeneratedSummary,
 	}
 }
 
 // buildItemListResult は limit+1件取得の結果から HasMore 判定・NextCursor 算出・
 // サマリー変換を行い ItemListResult を組み立てる。
 // items は limit+1 件以下を想定し、items の件数が limit を超える場合に HasMore=true
-// として末尾を切り詰める。NextCursor は最後尾の PublishedAt を RFC3339Nano で
-// フォーマットしたもの（HasMore=true のときのみ非空）。
+// として末尾を切り詰める。NextCursor は最後尾の記事を指す不透明カーソル
+// （HasMore=true のときのみ非空）。
 func buildItemListResult(items []model.ItemWithState, limit int) *ItemListResult {
 	hasMore := len(items) > limit
 	if hasMore {
@@ -130,8 +236,7 @@ func buildItemListResult(items []model.ItemWithState, limit int) *ItemListResult
 
 	var nextCursor string
 	if hasMore && len(summaries) > 0 {
-		lastItem := summaries[len(summaries)-1]
-		nextCursor = lastItem.PublishedAt.Format(time.RFC3339Nano)
+		nextCursor = formatItemCursor(feedItemsCursorSort, summaries[len(summaries)-1])
 	}
 
 	return &ItemListResult{
@@ -144,27 +249,24 @@ func buildItemListResult(items []model.ItemWithState, limit int) *ItemListResult
 // ListItems はフィードの記事一覧をフィルタ・ページネーション付きで返す。
 // カーソルベースページネーションを使用し、published_at降順でソートする。
 // limit+1件を取得してHasMoreを判定する。
+// author が空でない場合は upsert 時と同じ規則で正規化したうえで、著者名の完全一致で絞り込む。
 func (s *ItemService) ListItems(
 	ctx context.Context,
 	userID, feedID string,
-	filter model.ItemFilter,
+	conds model.ItemConditions,
+	author string,
 	cursorStr string,
 	limit int,
 ) (*ItemListResult, error) {
-	// フィルタのバリデーション
-	if !validFilters[filter] {
-		return nil, model.NewInvalidFilterError(string(filter))
-	}
-
 	// カーソルのパース
-	cursor, err := parseItemCursor(cursorStr)
+	cursor, err := parseItemCursor(feedItemsCursorSort, cursorStr)
 	if err != nil {
 		return nil, err
 	}
 
 	// limit+1件を取得してHasMoreを判定する
 	fetchLimit := limit + 1
-	items, err := s.itemRepo.ListByFeed(ctx, feedID, userID, filter, cursor, fetchLimit)
+	items, err := s.itemRepo.ListByFeed(ctx, feedID, userID, conds, normalizeAuthor(author), cursor.Time, cursor.ID, fetchLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -172,27 +274,76 @@ func (s *ItemService) ListItems(
 	return buildItemListResult(items, limit), nil
 }
 
+// ListItemGroups はフィードの記事一覧を連載（series_key）で折りたたんだ構造で返す。
+// 取得・絞り込み・ページングは ListItems と同じで、取得したページ内の記事をグループ化する。
+// そのため同じ連載の記事が複数ページにまたがる場合は、ページごとに別のグループとして返る。
+func (s *ItemService) ListItemGroups(
+	ctx context.Context,
+	userID, feedID string,
+	conds model.ItemConditions,
+	author string,
+	cursorStr string,
+	limit int,
+) (*ItemGroupListResult, error) {
+	result, err := s.ListItems(ctx, userID, feedID, conds, author, cursorStr, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &ItemGroupListResult{
+		Groups:     groupBySeries(result.Items),
+		NextCursor: result.NextCursor,
+		HasMore:    result.HasMore,
+	}, nil
+}
+
+// ListAuthors はフィード内の著者一覧を記事数付きで返す。
+// 記事数の多い順に並び、著者名が無い記事は集計に含まない。著者がいない場合は空スライスを返す。
+func (s *ItemService) ListAuthors(ctx context.Context, feedID string) ([]model.FeedAuthor, error) {
+	if s.authorRepo == nil {
+		return []model.FeedAuthor{}, nil
+	}
+	authors, err := s.authorRepo.ListAuthorsByFeed(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	if authors == nil {
+		authors = []model.FeedAuthor{}
+	}
+	return authors, nil
+}
+
 // ListStarredItems はユーザーの全フィード横断スター記事一覧を返す。
 // カーソルベースページネーションを使用し、published_at 降順でソートする。
 // cursorStr が空文字列の場合は先頭ページを返す。
 // 不正な cursorStr は model.NewInvalidFilterError（code: INVALID_FILTER）を返す。
 // 戻り値の形状は ItemListResult と同形だが、Items の各要素に FeedTitle を併記する
 // （Requirement 2.4 / 4.10）。
+// filter でリンク切れ・最低評価による絞り込みと評価順の並び替えを指定する。
+// 評価順のカーソルは評価を含むため、公開日時順のカーソルとは相互に流用できない（INVALID_FILTER）。
 func (s *ItemService) ListStarredItems(
 	ctx context.Context,
 	userID string,
 	cursorStr string,
 	limit int,
+	filter model.StarredItemFilter,
 ) (*StarredItemListResult, error) {
 	// カーソルのパース（既存 ListItems と完全同一の規約 / Requirement 4.5 / 4.8）
-	cursor, err := parseItemCursor(cursorStr)
+	sort := starredItemsCursorSort
+	if filter.SortByRating {
+		sort = starredItemsRatingCursorSort
+	}
+	cursor, err := parseItemCursor(sort, cursorStr)
 	if err != nil {
 		return nil, err
 	}
+	// 評価順のカーソルは旧形式を持たないため、ID の無いカーソルは不正とする
+	if filter.SortByRating && cursorStr != "" && cursor.ID == "" {
+		return nil, model.NewInvalidFilterError("無効なカーソル値: " + cursorStr)
+	}
 
 	// limit+1件を取得してHasMoreを判定する（既存 ListItems と同形 / Requirement 4.3 / NFR 3.1）
 	fetchLimit := limit + 1
-	rows, err := s.itemRepo.ListStarredByUser(ctx, userID, cursor, fetchLimit)
+	rows, err := s.itemRepo.ListStarredByUser(ctx, userID, cursor.Rank, cursor.Time, cursor.ID, fetchLimit, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -207,13 +358,18 @@ func (s *ItemService) ListStarredItems(
 		summaries[i] = StarredItemSummary{
 			ItemSummary: toItemSummary(row.ItemWithState),
 			FeedTitle:   row.FeedTitle,
+			LinkStatus:  row.LinkStatus,
 		}
 	}
 
 	var nextCursor string
 	if hasMore && len(summaries) > 0 {
-		lastItem := summaries[len(summaries)-1]
-		nextCursor = lastItem.PublishedAt.Format(time.RFC3339Nano)
+		last := summaries[len(summaries)-1].ItemSummary
+		if filter.SortByRating {
+			nextCursor = pagination.Encode(sort, pagination.Cursor{Time: last.PublishedAt, ID: last.ID, Rank: last.Rating})
+		} else {
+			nextCursor = formatItemCursor(sort, last)
+		}
 	}
 
 	return &StarredItemListResult{
@@ -224,9 +380,13 @@ func (s *ItemService) ListStarredItems(
 }
 
 // GetItem は記事詳細をユーザーの状態付きで返す。
+// 本文・要約のリンクには rel="noopener noreferrer" を強制付与し、ユーザー設定に応じて target を制御する。
+// textOnly が true の場合は本文・要約から画像・埋め込み・動画を除去する（データセーバー）。
+// 読了時間は除去する要素のテキストを含めずに推定しているため、textOnly によらず同じ値を返す。
 func (s *ItemService) GetItem(
 	ctx context.Context,
 	userID, itemID string,
+	textOnly bool,
 ) (*ItemDetail, error) {
 	item, err := s.itemRepo.FindByID(ctx, itemID)
 	if err != nil {
@@ -244,34 +404,68 @@ func (s *ItemService) GetItem(
 
 	isRead := false
 	isStarred := false
+	rating := 0
 	if state != nil {
 		isRead = state.IsRead
 		isStarred = state.IsStarred
+		rating = state.Rating
 	}
 
 	pubAt := time.Time{}
 	if item.PublishedAt != nil {
 		pubAt = *item.PublishedAt
 	}
+	openInNewTab := s.openLinksInNewTab(ctx, userID)
+	content, summary := item.Content, item.Summary
+	if textOnly {
+		content, summary = stripMedia(content), stripMedia(summary)
+	}
+
+	if s.viewRecorder != nil {
+		s.viewRecorder.RecordView(userID, item.ID)
+	}
+	if s.hatebu != nil {
+		s.hatebu.RequestRefresh(item.ID, item.Link, item.HatebuFetchedAt)
+	}
 
 	return &ItemDetail{
 		ItemSummary: ItemSummary{
-			ID:              item.ID,
-			FeedID:          item.FeedID,
-			Title:           item.Title,
-			Link:            item.Link,
-			PublishedAt:     pubAt,
-			IsDateEstimated: item.IsDateEstimated,
-			IsRead:          isRead,
-			IsStarred:       isStarred,
-			HatebuCount:     item.HatebuCount,
+			ID:                 item.ID,
+			FeedID:             item.FeedID,
+			Title:              item.Title,
+			Link:               item.Link,
+			PublishedAt:        pubAt,
+			IsDateEstimated:    item.IsDateEstimated,
+			IsRead:             isRead,
+			IsStarred:          isStarred,
+			Rating:             rating,
+			HatebuCount:        item.HatebuCount,
+			ReadingTimeMinutes: item.ReadingTimeMinutes,
+			GeneratedSummary:   item.GeneratedSummary,
 		},
-		Content: item.Content,
-		Summary: item.Summary,
+		Content: security.ApplyLinkAttributes(content, openInNewTab),
+		Summary: security.ApplyLinkAttributes(summary, openInNewTab),
 		Author:  item.Author,
 	}, nil
 }
 
+// openLinksInNewTab はユーザーの「リンクを新しいタブで開く」設定を返す。
+// 設定の取得に失敗した場合は記事の閲覧を妨げないよう既定値にフォールバックする。
+func (s *ItemService) openLinksInNewTab(ctx context.Context, userID string) bool {
+	if s.linkPreference == nil {
+		return model.DefaultOpenLinksInNewTab
+	}
+	v, err := s.linkPreference.OpenLinksInNewTab(ctx, userID)
+	if err != nil {
+		slog.Warn("リンクの開き方の設定の取得に失敗したため既定値を使用します",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return model.DefaultOpenLinksInNewTab
+	}
+	return v
+}
+
 // ItemDetail は記事詳細情報。
 type ItemDetail struct {
 	ItemSummary
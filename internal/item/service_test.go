@@ -2,10 +2,14 @@ package item
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
@@ -14,8 +18,9 @@ import (
 // mockItemRepoForService はサービステスト用のItemRepositoryモック。
 type mockItemRepoForService struct {
 	*mockItemRepo
-	listByFeedFn        func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error)
-	listStarredByUserFn func(ctx context.Context, userID string, cursor time.Time, limit int) ([]repository.StarredItemRow, error)
+	listByFeedFn        func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error)
+	listByFeedsFn       func(ctx context.Context, feedIDs []string, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error)
+	listStarredByUserFn func(ctx context.Context, userID string, cursorRating int, cursor time.Time, cursorID string, limit int, filter model.StarredItemFilter) ([]repository.StarredItemRow, error)
 	findByIDFn          func(ctx context.Context, id string) (*model.Item, error)
 }
 
@@ -25,16 +30,23 @@ func newMockItemRepoForService() *mockItemRepoForService {
 	}
 }
 
-func (m *mockItemRepoForService) ListByFeed(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
+func (m *mockItemRepoForService) ListByFeed(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
 	if m.listByFeedFn != nil {
-		return m.listByFeedFn(ctx, feedID, userID, filter, cursor, limit)
+		return m.listByFeedFn(ctx, feedID, userID, conds, author, cursor, cursorID, limit)
 	}
 	return nil, nil
 }
 
-func (m *mockItemRepoForService) ListStarredByUser(ctx context.Context, userID string, cursor time.Time, limit int) ([]repository.StarredItemRow, error) {
+func (m *mockItemRepoForService) ListByFeeds(ctx context.Context, feedIDs []string, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+	if m.listByFeedsFn != nil {
+		return m.listByFeedsFn(ctx, feedIDs, userID, conds, author, cursor, cursorID, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockItemRepoForService) ListStarredByUser(ctx context.Context, userID string, cursorRating int, cursor time.Time, cursorID string, limit int, filter model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 	if m.listStarredByUserFn != nil {
-		return m.listStarredByUserFn(ctx, userID, cursor, limit)
+		return m.listStarredByUserFn(ctx, userID, cursorRating, cursor, cursorID, limit, filter)
 	}
 	return nil, nil
 }
@@ -63,7 +75,7 @@ func (m *mockItemRepoForService) FindByID(ctx context.Context, id string) (*mode
 // mockItemStateRepoForService はサービステスト用のItemStateRepositoryモック。
 type mockItemStateRepoForService struct {
 	states   map[string]*model.ItemState // userID+itemID -> state
-	upsertFn func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error)
+	upsertFn func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error)
 }
 
 func newMockItemStateRepoForService() *mockItemStateRepoForService {
@@ -81,9 +93,9 @@ func (m *mockItemStateRepoForService) FindByUserAndItem(_ context.Context, userI
 	return state, nil
 }
 
-func (m *mockItemStateRepoForService) Upsert(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+func (m *mockItemStateRepoForService) Upsert(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 	if m.upsertFn != nil {
-		return m.upsertFn(ctx, userID, itemID, isRead, isStarred)
+		return m.upsertFn(ctx, userID, itemID, isRead, isStarred, isHidden, rating)
 	}
 	return nil, nil
 }
@@ -102,15 +114,15 @@ func (m *mockItemStateRepoForService) DeleteByUserID(_ context.Context, _ string
 func TestItemService_ListItems_ReturnsItems(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Second)
 	repo := newMockItemRepoForService()
-	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
+	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
 		if feedID != "feed-1" {
 			t.Errorf("feedID = %q, want %q", feedID, "feed-1")
 		}
 		if userID != "user-123" {
 			t.Errorf("userID = %q, want %q", userID, "user-123")
 		}
-		if filter != model.ItemFilterAll {
-			t.Errorf("filter = %q, want %q", filter, model.ItemFilterAll)
+		if conds != (model.ItemConditions{}) {
+			t.Errorf("conds = %+v, want no conditions", conds)
 		}
 		if limit != 51 {
 			// limit+1で取得して、HasMoreを判定する
@@ -133,7 +145,7 @@ func TestItemService_ListItems_ReturnsItems(t *testing.T) {
 	}
 
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterAll, "", 50)
+	result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 50)
 	if err != nil {
 		t.Fatalf("ListItems returned error: %v", err)
 	}
@@ -172,7 +184,7 @@ func TestItemService_ListItems_IncludesSummary(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			repo := newMockItemRepoForService()
-			repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
+			repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
 				return []model.ItemWithState{
 					{
 						Item: model.Item{
@@ -189,7 +201,7 @@ func TestItemService_ListItems_IncludesSummary(t *testing.T) {
 			svc := NewItemService(repo, newMockItemStateRepoForService())
 
 			// Act
-			result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterAll, "", 50)
+			result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 50)
 
 			// Assert
 			if err != nil {
@@ -205,6 +217,45 @@ func TestItemService_ListItems_IncludesSummary(t *testing.T) {
 	}
 }
 
+// TestItemService_ListItems_Excerpt は記事一覧のサマリーに content_text の先頭が抜粋として含まれることを検証する。
+func TestItemService_ListItems_Excerpt(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	cases := []struct {
+		name        string
+		contentText string
+		wantExcerpt string
+	}{
+		{name: "本文テキストが短いときそのまま抜粋にする", contentText: "本文の先頭", wantExcerpt: "本文の先頭"},
+		{name: "本文テキストが長いとき200文字で切り詰める", contentText: strings.Repeat("a", 300), wantExcerpt: strings.Repeat("a", 200) + "…"},
+		{name: "本文テキストが未生成のとき空文字列にする", contentText: "", wantExcerpt: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			repo := newMockItemRepoForService()
+			repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+				return []model.ItemWithState{
+					{Item: model.Item{ID: "item-1", FeedID: "feed-1", ContentText: tc.contentText, PublishedAt: &now}},
+				}, nil
+			}
+			svc := NewItemService(repo, newMockItemStateRepoForService())
+
+			// Act
+			result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 50)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ListItems returned error: %v", err)
+			}
+			if got := result.Items[0].Excerpt; got != tc.wantExcerpt {
+				t.Errorf("Excerpt = %q, want %q", got, tc.wantExcerpt)
+			}
+		})
+	}
+}
+
 // TestItemService_SummaryConsistentBetweenListAndDetail は同一記事の概要が
 // 一覧(ListItems)と詳細(GetItem)で同一の文字列値になることを検証する。Req 1.2 に対応。
 func TestItemService_SummaryConsistentBetweenListAndDetail(t *testing.T) {
@@ -222,7 +273,7 @@ func TestItemService_SummaryConsistentBetweenListAndDetail(t *testing.T) {
 	}
 
 	repo := newMockItemRepoForService()
-	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
+	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
 		return []model.ItemWithState{{Item: srcItem}}, nil
 	}
 	repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
@@ -231,11 +282,11 @@ func TestItemService_SummaryConsistentBetweenListAndDetail(t *testing.T) {
 	}
 	svc := NewItemService(repo, newMockItemStateRepoForService())
 
-	listResult, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterAll, "", 50)
+	listResult, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 50)
 	if err != nil {
 		t.Fatalf("ListItems returned error: %v", err)
 	}
-	detail, err := svc.GetItem(context.Background(), "user-123", "item-1")
+	detail, err := svc.GetItem(context.Background(), "user-123", "item-1", false)
 	if err != nil {
 		t.Fatalf("GetItem returned error: %v", err)
 	}
@@ -258,7 +309,7 @@ func TestItemService_SummaryConsistentBetweenListAndDetail(t *testing.T) {
 func TestItemService_ListItems_HasMore(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Second)
 	repo := newMockItemRepoForService()
-	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
+	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
 		// limit+1件（51件）を返してHasMoreを検証
 		items := make([]model.ItemWithState, limit)
 		for i := 0; i < limit; i++ {
@@ -276,7 +327,7 @@ func TestItemService_ListItems_HasMore(t *testing.T) {
 	}
 
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterAll, "", 50)
+	result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 50)
 	if err != nil {
 		t.Fatalf("ListItems returned error: %v", err)
 	}
@@ -295,58 +346,178 @@ func TestItemService_ListItems_HasMore(t *testing.T) {
 	}
 }
 
-// TestItemService_ListItems_InvalidFilter は無効なフィルタでエラーが返されることをテストする。
-func TestItemService_ListItems_InvalidFilter(t *testing.T) {
+// TestItemService_ListItemGroups はページ内の記事が series_key ごとに折りたたまれ、
+// ページングの情報は ListItems と同じく記事単位で返されることをテストする。
+func TestItemService_ListItemGroups(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
 	repo := newMockItemRepoForService()
-	svc := NewItemService(repo, newMockItemStateRepoForService())
+	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+		items := make([]model.ItemWithState, 0, limit)
+		for i, key := range []string{"go 入門", "", "go 入門", "rust"} {
+			pubTime := now.Add(-time.Duration(i) * time.Hour)
+			items = append(items, model.ItemWithState{Item: model.Item{
+				ID:          fmt.Sprintf("item-%d", i),
+				FeedID:      feedID,
+				PublishedAt: &pubTime,
+				SeriesKey:   key,
+			}})
+		}
+		return items, nil
+	}
 
-	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilter("invalid"), "", 50)
-	if err == nil {
-		t.Fatal("expected error for invalid filter")
+	svc := NewItemService(repo, newMockItemStateRepoForService())
+	result, err := svc.ListItemGroups(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 3)
+	if err != nil {
+		t.Fatalf("ListItemGroups returned error: %v", err)
 	}
 
-	apiErr, ok := err.(*model.APIError)
-	if !ok {
-		t.Fatalf("expected *model.APIError, got %T", err)
+	// limit（3 件）を超えた 4 件目はページに含まれず、次ページがあることだけを返す
+	if !result.HasMore || result.NextCursor == "" {
+		t.Errorf("HasMore = %v, NextCursor = %q, want next page", result.HasMore, result.NextCursor)
 	}
-	if apiErr.Code != model.ErrCodeInvalidFilter {
-		t.Errorf("error code = %q, want %q", apiErr.Code, model.ErrCodeInvalidFilter)
+	if len(result.Groups) != 2 {
+		t.Fatalf("len(Groups) = %d, want 2", len(result.Groups))
+	}
+	if result.Groups[0].SeriesKey != "go 入門" || len(result.Groups[0].Items) != 2 {
+		t.Errorf("Groups[0] = %+v, want 2 items of series %q", result.Groups[0], "go 入門")
+	}
+	if result.Groups[1].SeriesKey != "" || result.Groups[1].Items[0].ID != "item-1" {
+		t.Errorf("Groups[1] = %+v, want non-series item-1", result.Groups[1])
 	}
 }
 
 // TestItemService_ListItems_CursorParsing はカーソル文字列が正しくパースされることをテストする。
 func TestItemService_ListItems_CursorParsing(t *testing.T) {
+	const cursorItemID = "0b6f2c1e-6f0a-4d47-9c55-2f7c1d9a3e01"
 	var receivedCursor time.Time
+	var receivedCursorID string
 	repo := newMockItemRepoForService()
-	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
-		receivedCursor = cursor
+	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+		receivedCursor, receivedCursorID = cursor, cursorID
 		return nil, nil
 	}
 
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	cursorStr := "2026-02-27T10:00:00Z"
-	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterAll, cursorStr, 50)
+	expectedCursor := time.Date(2026, 2, 27, 10, 0, 0, 0, time.UTC)
+	cursorStr := pagination.Encode(feedItemsCursorSort, pagination.Cursor{Time: expectedCursor, ID: cursorItemID})
+	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", cursorStr, 50)
 	if err != nil {
 		t.Fatalf("ListItems returned error: %v", err)
 	}
 
-	expectedCursor, _ := time.Parse(time.RFC3339, cursorStr)
 	if !receivedCursor.Equal(expectedCursor) {
 		t.Errorf("cursor = %v, want %v", receivedCursor, expectedCursor)
 	}
+	if receivedCursorID != cursorItemID {
+		t.Errorf("cursorID = %q, want %q", receivedCursorID, cursorItemID)
+	}
+}
+
+// TestItemService_ListItems_CompositeCursorCompat は (published_at, id) 複合カーソルの移行互換と検証をテストする。
+func TestItemService_ListItems_CompositeCursorCompat(t *testing.T) {
+	t.Run("旧形式のRFC3339カーソルのときIDを空にしてpublished_atのみを渡す", func(t *testing.T) {
+		// Arrange
+		var receivedCursor time.Time
+		receivedCursorID := "unset"
+		repo := newMockItemRepoForService()
+		repo.listByFeedFn = func(_ context.Context, _, _ string, _ model.ItemConditions, _ string, cursor time.Time, cursorID string, _ int) ([]model.ItemWithState, error) {
+			receivedCursor, receivedCursorID = cursor, cursorID
+			return nil, nil
+		}
+		svc := NewItemService(repo, newMockItemStateRepoForService())
+		expected := time.Date(2026, 2, 27, 10, 0, 0, 0, time.UTC)
+
+		// Act
+		_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", expected.Format(time.RFC3339), 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListItems returned error: %v", err)
+		}
+		if !receivedCursor.Equal(expected) || receivedCursorID != "" {
+			t.Errorf("cursor = (%v, %q), want (%v, \"\")", receivedCursor, receivedCursorID, expected)
+		}
+	})
+
+	t.Run("カーソルのIDがUUIDでないときINVALID_FILTERを返しリポジトリを呼ばない", func(t *testing.T) {
+		// Arrange
+		called := false
+		repo := newMockItemRepoForService()
+		repo.listByFeedFn = func(context.Context, string, string, model.ItemConditions, string, time.Time, string, int) ([]model.ItemWithState, error) {
+			called = true
+			return nil, nil
+		}
+		svc := NewItemService(repo, newMockItemStateRepoForService())
+		cursorStr := pagination.Encode(feedItemsCursorSort, pagination.Cursor{Time: time.Now(), ID: "not-a-uuid"})
+
+		// Act
+		_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", cursorStr, 50)
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidFilter {
+			t.Errorf("err = %v, want INVALID_FILTER", err)
+		}
+		if called {
+			t.Error("repository should not be called when cursor ID is invalid")
+		}
+	})
+
+	t.Run("次ページのカーソルが末尾記事のpublished_atとIDを保持するとき", func(t *testing.T) {
+		// Arrange: 同一秒の記事がページ境界をまたぐ
+		pubAt := time.Date(2026, 2, 27, 10, 0, 0, 0, time.UTC)
+		repo := newMockItemRepoForService()
+		repo.listByFeedFn = func(context.Context, string, string, model.ItemConditions, string, time.Time, string, int) ([]model.ItemWithState, error) {
+			return []model.ItemWithState{
+				{Item: model.Item{ID: "0b6f2c1e-6f0a-4d47-9c55-2f7c1d9a3e03", PublishedAt: &pubAt}},
+				{Item: model.Item{ID: "0b6f2c1e-6f0a-4d47-9c55-2f7c1d9a3e02", PublishedAt: &pubAt}},
+				{Item: model.Item{ID: "0b6f2c1e-6f0a-4d47-9c55-2f7c1d9a3e01", PublishedAt: &pubAt}},
+			}, nil
+		}
+		svc := NewItemService(repo, newMockItemStateRepoForService())
+
+		// Act
+		result, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 2)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListItems returned error: %v", err)
+		}
+		cursor, derr := pagination.Decode(feedItemsCursorSort, result.NextCursor)
+		if derr != nil {
+			t.Fatalf("NextCursor %q could not be decoded: %v", result.NextCursor, derr)
+		}
+		if !cursor.Time.Equal(pubAt) || cursor.ID != "0b6f2c1e-6f0a-4d47-9c55-2f7c1d9a3e02" {
+			t.Errorf("cursor = %+v, want (%v, 0b6f2c1e-6f0a-4d47-9c55-2f7c1d9a3e02)", cursor, pubAt)
+		}
+	})
+}
+
+// TestItemService_ListItems_CursorFromOtherList は別一覧（スター一覧）で発行されたカーソルを
+// INVALID_FILTER として拒否することをテストする。
+func TestItemService_ListItems_CursorFromOtherList(t *testing.T) {
+	svc := NewItemService(newMockItemRepoForService(), newMockItemStateRepoForService())
+	cursorStr := pagination.Encode(starredItemsCursorSort, pagination.Cursor{Time: time.Now(), ID: "item-1"})
+
+	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", cursorStr, 50)
+
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidFilter {
+		t.Errorf("err = %v, want INVALID_FILTER", err)
+	}
 }
 
 // TestItemService_ListItems_EmptyCursor は空カーソルでゼロ値が渡されることをテストする。
 func TestItemService_ListItems_EmptyCursor(t *testing.T) {
 	var receivedCursor time.Time
 	repo := newMockItemRepoForService()
-	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
+	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
 		receivedCursor = cursor
 		return nil, nil
 	}
 
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterAll, "", 50)
+	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, "", "", 50)
 	if err != nil {
 		t.Fatalf("ListItems returned error: %v", err)
 	}
@@ -356,44 +527,137 @@ func TestItemService_ListItems_EmptyCursor(t *testing.T) {
 	}
 }
 
-// TestItemService_ListItems_UnreadFilter は未読フィルタがリポジトリに渡されることをテストする。
-func TestItemService_ListItems_UnreadFilter(t *testing.T) {
-	var receivedFilter model.ItemFilter
+// TestItemService_ListItems_CombinedConditions は組み合わせた絞り込み条件がそのままリポジトリに渡されることをテストする。
+func TestItemService_ListItems_CombinedConditions(t *testing.T) {
+	unread, starred := true, true
+	var received model.ItemConditions
 	repo := newMockItemRepoForService()
-	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
-		receivedFilter = filter
+	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+		received = conds
 		return nil, nil
 	}
 
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterUnread, "", 50)
+	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{Unread: &unread, Starred: &starred}, "", "", 50)
 	if err != nil {
 		t.Fatalf("ListItems returned error: %v", err)
 	}
 
-	if receivedFilter != model.ItemFilterUnread {
-		t.Errorf("filter = %q, want %q", receivedFilter, model.ItemFilterUnread)
+	if received.Unread == nil || !*received.Unread || received.Starred == nil || !*received.Starred {
+		t.Errorf("conds = %+v, want unread=true AND starred=true", received)
 	}
 }
 
-// TestItemService_ListItems_StarredFilter はスターフィルタがリポジトリに渡されることをテストする。
-func TestItemService_ListItems_StarredFilter(t *testing.T) {
-	var receivedFilter model.ItemFilter
-	repo := newMockItemRepoForService()
-	repo.listByFeedFn = func(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
-		receivedFilter = filter
-		return nil, nil
+// TestItemService_ListItems_AuthorFilter は著者名が正規化されてリポジトリに渡されることをテストする。
+func TestItemService_ListItems_AuthorFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		author string
+		want   string
+	}{
+		{name: "著者未指定のとき空文字列が渡る", author: "", want: ""},
+		{name: "前後の空白や全角文字を含むとき正規化した著者名が渡る", author: "　ＪＯＨＮ  Doe ", want: "JOHN Doe"},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			receivedAuthor := "unset"
+			repo := newMockItemRepoForService()
+			repo.listByFeedFn = func(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+				receivedAuthor = author
+				return nil, nil
+			}
+			svc := NewItemService(repo, newMockItemStateRepoForService())
 
-	svc := NewItemService(repo, newMockItemStateRepoForService())
-	_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemFilterStarred, "", 50)
-	if err != nil {
-		t.Fatalf("ListItems returned error: %v", err)
+			// Act
+			_, err := svc.ListItems(context.Background(), "user-123", "feed-1", model.ItemConditions{}, tt.author, "", 50)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ListItems returned error: %v", err)
+			}
+			if receivedAuthor != tt.want {
+				t.Errorf("author = %q, want %q", receivedAuthor, tt.want)
+			}
+		})
 	}
+}
+
+// mockFeedAuthorRepo は repository.FeedAuthorRepository のモック実装。
+type mockFeedAuthorRepo struct {
+	listAuthorsByFeedFn func(ctx context.Context, feedID string) ([]model.FeedAuthor, error)
+}
 
-	if receivedFilter != model.ItemFilterStarred {
-		t.Errorf("filter = %q, want %q", receivedFilter, model.ItemFilterStarred)
+func (m *mockFeedAuthorRepo) ListAuthorsByFeed(ctx context.Context, feedID string) ([]model.FeedAuthor, error) {
+	if m.listAuthorsByFeedFn != nil {
+		return m.listAuthorsByFeedFn(ctx, feedID)
 	}
+	return nil, nil
+}
+
+func TestItemService_ListAuthors(t *testing.T) {
+	t.Run("リポジトリの集計結果を返す", func(t *testing.T) {
+		// Arrange
+		var receivedFeedID string
+		authorRepo := &mockFeedAuthorRepo{
+			listAuthorsByFeedFn: func(ctx context.Context, feedID string) ([]model.FeedAuthor, error) {
+				receivedFeedID = feedID
+				return []model.FeedAuthor{{Author: "山田 太郎", ItemCount: 2}}, nil
+			},
+		}
+		svc := NewItemService(newMockItemRepoForService(), newMockItemStateRepoForService(),
+			WithAuthorRepository(authorRepo))
+
+		// Act
+		authors, err := svc.ListAuthors(context.Background(), "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListAuthors returned error: %v", err)
+		}
+		if receivedFeedID != "feed-1" {
+			t.Errorf("feedID = %q, want %q", receivedFeedID, "feed-1")
+		}
+		if len(authors) != 1 || authors[0].Author != "山田 太郎" || authors[0].ItemCount != 2 {
+			t.Errorf("authors = %+v, want [{山田 太郎 2}]", authors)
+		}
+	})
+
+	t.Run("著者がいないとき空スライスを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewItemService(newMockItemRepoForService(), newMockItemStateRepoForService(),
+			WithAuthorRepository(&mockFeedAuthorRepo{}))
+
+		// Act
+		authors, err := svc.ListAuthors(context.Background(), "feed-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListAuthors returned error: %v", err)
+		}
+		if authors == nil || len(authors) != 0 {
+			t.Errorf("authors = %#v, want empty non-nil slice", authors)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		authorRepo := &mockFeedAuthorRepo{
+			listAuthorsByFeedFn: func(ctx context.Context, feedID string) ([]model.FeedAuthor, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewItemService(newMockItemRepoForService(), newMockItemStateRepoForService(),
+			WithAuthorRepository(authorRepo))
+
+		// Act
+		_, err := svc.ListAuthors(context.Background(), "feed-1")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
 }
 
 // --- ItemService ListStarredItems テスト ---
@@ -427,7 +691,7 @@ func TestItemService_ListStarredItems_EmptyCursor(t *testing.T) {
 	var receivedLimit int
 	var receivedUserID string
 	repo := newMockItemRepoForService()
-	repo.listStarredByUserFn = func(_ context.Context, userID string, cursor time.Time, limit int) ([]repository.StarredItemRow, error) {
+	repo.listStarredByUserFn = func(_ context.Context, userID string, _ int, cursor time.Time, _ string, limit int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 		receivedCursor = cursor
 		receivedLimit = limit
 		receivedUserID = userID
@@ -438,7 +702,7 @@ func TestItemService_ListStarredItems_EmptyCursor(t *testing.T) {
 	svc := NewItemService(repo, newMockItemStateRepoForService())
 
 	// Act
-	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 50)
+	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 50, model.StarredItemFilter{})
 
 	// Assert
 	if err != nil {
@@ -470,6 +734,36 @@ func TestItemService_ListStarredItems_EmptyCursor(t *testing.T) {
 	}
 }
 
+// TestItemService_ListStarredItems_BrokenLinkOnly はリンク切れ絞り込み指定が repository に伝搬し、
+// 判定結果が各記事に併記されることを検証する。
+func TestItemService_ListStarredItems_BrokenLinkOnly(t *testing.T) {
+	// Arrange
+	now := time.Now().UTC().Truncate(time.Second)
+	var receivedBrokenLinkOnly bool
+	repo := newMockItemRepoForService()
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, _ time.Time, _ string, _ int, filter model.StarredItemFilter) ([]repository.StarredItemRow, error) {
+		receivedBrokenLinkOnly = filter.BrokenLinkOnly
+		row := makeStarredRow("item-1", "feed-1", "Feed A", now)
+		row.LinkStatus = model.LinkStatusNotFound
+		return []repository.StarredItemRow{row}, nil
+	}
+	svc := NewItemService(repo, newMockItemStateRepoForService())
+
+	// Act
+	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 50, model.StarredItemFilter{BrokenLinkOnly: true})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListStarredItems returned error: %v", err)
+	}
+	if !receivedBrokenLinkOnly {
+		t.Error("brokenLinkOnly = false, want true")
+	}
+	if len(result.Items) != 1 || result.Items[0].LinkStatus != model.LinkStatusNotFound {
+		t.Errorf("items = %+v, want 1 item with LinkStatus=not_found", result.Items)
+	}
+}
+
 // TestItemService_ListStarredItems_InvalidCursor は不正なカーソル文字列で
 // INVALID_FILTER エラーが返されることを検証する。
 // 対応 AC: Req 4.8（不正カーソルで既存と同等の 400 相当）
@@ -477,14 +771,14 @@ func TestItemService_ListStarredItems_InvalidCursor(t *testing.T) {
 	// Arrange
 	repo := newMockItemRepoForService()
 	repoCalled := false
-	repo.listStarredByUserFn = func(_ context.Context, _ string, _ time.Time, _ int) ([]repository.StarredItemRow, error) {
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, _ time.Time, _ string, _ int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 		repoCalled = true
 		return nil, nil
 	}
 	svc := NewItemService(repo, newMockItemStateRepoForService())
 
 	// Act
-	_, err := svc.ListStarredItems(context.Background(), "user-123", "not-a-timestamp", 50)
+	_, err := svc.ListStarredItems(context.Background(), "user-123", "not-a-timestamp", 50, model.StarredItemFilter{})
 
 	// Assert
 	if err == nil {
@@ -510,7 +804,7 @@ func TestItemService_ListStarredItems_HasMoreTrue(t *testing.T) {
 	// Arrange
 	base := time.Date(2026, 5, 29, 12, 0, 0, 0, time.UTC)
 	repo := newMockItemRepoForService()
-	repo.listStarredByUserFn = func(_ context.Context, _ string, _ time.Time, limit int) ([]repository.StarredItemRow, error) {
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, _ time.Time, _ string, limit int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 		// limit+1 件（51 件）返却して HasMore を発火させる
 		rows := make([]repository.StarredItemRow, limit)
 		for i := 0; i < limit; i++ {
@@ -527,7 +821,7 @@ func TestItemService_ListStarredItems_HasMoreTrue(t *testing.T) {
 	svc := NewItemService(repo, newMockItemStateRepoForService())
 
 	// Act
-	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 50)
+	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 50, model.StarredItemFilter{})
 
 	// Assert
 	if err != nil {
@@ -542,14 +836,13 @@ func TestItemService_ListStarredItems_HasMoreTrue(t *testing.T) {
 	if result.NextCursor == "" {
 		t.Fatal("expected NextCursor to be set when HasMore=true")
 	}
-	// NextCursor は最後尾（index 49）の PublishedAt を RFC3339Nano でフォーマットしたもの
-	wantCursor := base.Add(-49 * time.Hour).Format(time.RFC3339Nano)
-	if result.NextCursor != wantCursor {
-		t.Errorf("NextCursor = %q, want %q", result.NextCursor, wantCursor)
+	// NextCursor は最後尾（index 49）の PublishedAt を指す不透明カーソル
+	cursor, derr := pagination.Decode(starredItemsCursorSort, result.NextCursor)
+	if derr != nil {
+		t.Fatalf("NextCursor %q could not be decoded: %v", result.NextCursor, derr)
 	}
-	// RFC3339Nano としてパースできること
-	if _, perr := time.Parse(time.RFC3339Nano, result.NextCursor); perr != nil {
-		t.Errorf("NextCursor %q is not valid RFC3339Nano: %v", result.NextCursor, perr)
+	if want := base.Add(-49 * time.Hour); !cursor.Time.Equal(want) {
+		t.Errorf("cursor time = %v, want %v", cursor.Time, want)
 	}
 }
 
@@ -560,7 +853,7 @@ func TestItemService_ListStarredItems_HasMoreFalse(t *testing.T) {
 	// Arrange
 	now := time.Now().UTC().Truncate(time.Second)
 	repo := newMockItemRepoForService()
-	repo.listStarredByUserFn = func(_ context.Context, _ string, _ time.Time, _ int) ([]repository.StarredItemRow, error) {
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, _ time.Time, _ string, _ int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 		return []repository.StarredItemRow{
 			makeStarredRow("item-1", "feed-1", "Feed A", now),
 			makeStarredRow("item-2", "feed-2", "Feed B", now.Add(-time.Hour)),
@@ -569,7 +862,7 @@ func TestItemService_ListStarredItems_HasMoreFalse(t *testing.T) {
 	svc := NewItemService(repo, newMockItemStateRepoForService())
 
 	// Act
-	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 50)
+	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 50, model.StarredItemFilter{})
 
 	// Assert
 	if err != nil {
@@ -593,15 +886,16 @@ func TestItemService_ListStarredItems_HasMoreFalse(t *testing.T) {
 	}
 }
 
-// TestItemService_ListStarredItems_NextCursorRFC3339NanoFormat は NextCursor が
-// RFC3339Nano フォーマットで返されることを精密に検証する（nanosecond 精度を含む）。
+// TestItemService_ListStarredItems_NextCursorPrecision は NextCursor が末尾記事の
+// published_at を nanosecond 精度で保持した不透明カーソルで返されることを検証する。
 // 対応 AC: Req 4.5 の cursor 送り規約一貫性、NFR 3.1（既存 API と区別不能）
-func TestItemService_ListStarredItems_NextCursorRFC3339NanoFormat(t *testing.T) {
+func TestItemService_ListStarredItems_NextCursorPrecision(t *testing.T) {
 	// Arrange: nanosecond 精度を含む時刻を、保持される末尾（外部 limit=50 → index 49）に置く
 	const outerLimit = 50
 	tailTime := time.Date(2026, 5, 29, 12, 34, 56, 123456789, time.UTC)
+	const tailID = "5d1c7a0e-2b4f-4c8e-9a61-3e7b0f2d9c10"
 	repo := newMockItemRepoForService()
-	repo.listStarredByUserFn = func(_ context.Context, _ string, _ time.Time, limit int) ([]repository.StarredItemRow, error) {
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, _ time.Time, _ string, limit int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 		// fetchLimit (=outerLimit+1) 件返却して HasMore=true を発火させる。
 		// インデックス outerLimit-1 (=49) が truncate 後の末尾になり、ここに tailTime を置く。
 		// それ以前のインデックスは tailTime より後の時刻（公開日時降順を維持）。
@@ -612,7 +906,7 @@ func TestItemService_ListStarredItems_NextCursorRFC3339NanoFormat(t *testing.T)
 			rows[i] = makeStarredRow("item-"+string(rune('A'+i%26)), "feed-1", "Feed A", pubAt)
 		}
 		// 保持される末尾（HasMore truncate 後の最終要素）
-		rows[outerLimit-1] = makeStarredRow("item-tail", "feed-1", "Feed A", tailTime)
+		rows[outerLimit-1] = makeStarredRow(tailID, "feed-1", "Feed A", tailTime)
 		// 切り捨てられる余分行（tailTime より過去の時刻）
 		rows[outerLimit] = makeStarredRow("item-overflow", "feed-1", "Feed A", tailTime.Add(-time.Hour))
 		return rows, nil
@@ -620,7 +914,7 @@ func TestItemService_ListStarredItems_NextCursorRFC3339NanoFormat(t *testing.T)
 	svc := NewItemService(repo, newMockItemStateRepoForService())
 
 	// Act
-	result, err := svc.ListStarredItems(context.Background(), "user-123", "", outerLimit)
+	result, err := svc.ListStarredItems(context.Background(), "user-123", "", outerLimit, model.StarredItemFilter{})
 
 	// Assert
 	if err != nil {
@@ -629,17 +923,25 @@ func TestItemService_ListStarredItems_NextCursorRFC3339NanoFormat(t *testing.T)
 	if !result.HasMore {
 		t.Fatal("expected HasMore=true")
 	}
-	wantCursor := tailTime.Format(time.RFC3339Nano)
-	if result.NextCursor != wantCursor {
-		t.Errorf("NextCursor = %q, want %q (RFC3339Nano)", result.NextCursor, wantCursor)
+	cursor, derr := pagination.Decode(starredItemsCursorSort, result.NextCursor)
+	if derr != nil {
+		t.Fatalf("NextCursor %q could not be decoded: %v", result.NextCursor, derr)
+	}
+	if !cursor.Time.Equal(tailTime) {
+		t.Errorf("cursor time = %v, want %v", cursor.Time, tailTime)
+	}
+	if cursor.ID != tailID {
+		t.Errorf("cursor ID = %q, want %q", cursor.ID, tailID)
 	}
-	// 既存 ListItems と完全同一フォーマットで生成されることを再確認（NFR 3.1）
-	parsed, perr := time.Parse(time.RFC3339Nano, result.NextCursor)
-	if perr != nil {
-		t.Fatalf("NextCursor %q failed RFC3339Nano parse: %v", result.NextCursor, perr)
+	// 一覧のカーソルを続きページとして受理できること
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, c time.Time, cID string, _ int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
+		if !c.Equal(tailTime) || cID != tailID {
+			t.Errorf("repo cursor = (%v, %q), want (%v, %q)", c, cID, tailTime, tailID)
+		}
+		return nil, nil
 	}
-	if !parsed.Equal(tailTime) {
-		t.Errorf("parsed cursor = %v, want %v", parsed, tailTime)
+	if _, err := svc.ListStarredItems(context.Background(), "user-123", result.NextCursor, outerLimit, model.StarredItemFilter{}); err != nil {
+		t.Errorf("ListStarredItems with NextCursor returned error: %v", err)
 	}
 }
 
@@ -650,26 +952,75 @@ func TestItemService_ListStarredItems_CursorPassedToRepo(t *testing.T) {
 	// Arrange
 	var receivedCursor time.Time
 	repo := newMockItemRepoForService()
-	repo.listStarredByUserFn = func(_ context.Context, _ string, cursor time.Time, _ int) ([]repository.StarredItemRow, error) {
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, cursor time.Time, _ string, _ int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 		receivedCursor = cursor
 		return nil, nil
 	}
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	cursorStr := "2026-02-27T10:00:00Z"
+	expected := time.Date(2026, 2, 27, 10, 0, 0, 0, time.UTC)
+	cursorStr := pagination.Encode(starredItemsCursorSort, pagination.Cursor{Time: expected, ID: "0b6f2c1e-6f0a-4d47-9c55-2f7c1d9a3e01"})
 
 	// Act
-	_, err := svc.ListStarredItems(context.Background(), "user-123", cursorStr, 50)
+	_, err := svc.ListStarredItems(context.Background(), "user-123", cursorStr, 50, model.StarredItemFilter{})
 
 	// Assert
 	if err != nil {
 		t.Fatalf("ListStarredItems returned error: %v", err)
 	}
-	expected, _ := time.Parse(time.RFC3339, cursorStr)
 	if !receivedCursor.Equal(expected) {
 		t.Errorf("cursor passed to repo = %v, want %v", receivedCursor, expected)
 	}
 }
 
+// TestItemService_ListStarredItems_SortByRating は評価順の一覧で、末尾記事の評価を含むカーソルを返し、
+// 続きページでその評価が repository 層に伝搬することを検証する。
+func TestItemService_ListStarredItems_SortByRating(t *testing.T) {
+	// Arrange
+	pubAt := time.Date(2026, 7, 15, 9, 0, 0, 0, time.UTC)
+	repo := newMockItemRepoForService()
+	repo.listStarredByUserFn = func(_ context.Context, _ string, _ int, _ time.Time, _ string, limit int, filter model.StarredItemFilter) ([]repository.StarredItemRow, error) {
+		if !filter.SortByRating {
+			t.Error("SortByRating = false, want true")
+		}
+		rows := make([]repository.StarredItemRow, limit)
+		for i := range rows {
+			rows[i] = makeStarredRow(fmt.Sprintf("00000000-0000-4000-8000-%012d", i), "feed-1", "Feed A", pubAt)
+			rows[i].Rating = model.MaxItemRating - i
+		}
+		return rows, nil
+	}
+	svc := NewItemService(repo, newMockItemStateRepoForService())
+	filter := model.StarredItemFilter{SortByRating: true}
+
+	// Act
+	result, err := svc.ListStarredItems(context.Background(), "user-123", "", 2, filter)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListStarredItems returned error: %v", err)
+	}
+	if len(result.Items) != 2 || result.Items[0].Rating != 5 || result.Items[1].Rating != 4 {
+		t.Fatalf("items = %+v, want ratings [5 4]", result.Items)
+	}
+	var receivedRank int
+	var receivedID string
+	repo.listStarredByUserFn = func(_ context.Context, _ string, cursorRating int, _ time.Time, cursorID string, _ int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
+		receivedRank, receivedID = cursorRating, cursorID
+		return nil, nil
+	}
+	if _, err := svc.ListStarredItems(context.Background(), "user-123", result.NextCursor, 2, filter); err != nil {
+		t.Fatalf("ListStarredItems with NextCursor returned error: %v", err)
+	}
+	if receivedRank != 4 || receivedID != result.Items[1].ID {
+		t.Errorf("repo cursor = (%d, %q), want (4, %q)", receivedRank, receivedID, result.Items[1].ID)
+	}
+
+	// 公開日時順のカーソルは評価順の一覧では受理しない
+	if _, err := svc.ListStarredItems(context.Background(), "user-123", result.NextCursor, 2, model.StarredItemFilter{}); err == nil {
+		t.Error("expected error for cursor of a different sort, got nil")
+	}
+}
+
 // --- ItemService GetItem テスト ---
 
 // TestItemService_GetItem_ReturnsDetail は記事詳細が正しく取得されることをテストする。
@@ -703,7 +1054,7 @@ func TestItemService_GetItem_ReturnsDetail(t *testing.T) {
 	}
 
 	svc := NewItemService(repo, stateRepo)
-	detail, err := svc.GetItem(context.Background(), "user-123", "item-1")
+	detail, err := svc.GetItem(context.Background(), "user-123", "item-1", false)
 	if err != nil {
 		t.Fatalf("GetItem returned error: %v", err)
 	}
@@ -739,7 +1090,7 @@ func TestItemService_GetItem_NotFound(t *testing.T) {
 	}
 
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	_, err := svc.GetItem(context.Background(), "user-123", "nonexistent")
+	_, err := svc.GetItem(context.Background(), "user-123", "nonexistent", false)
 	if err == nil {
 		t.Fatal("expected error for non-existent item")
 	}
@@ -768,7 +1119,7 @@ func TestItemService_GetItem_NoState(t *testing.T) {
 
 	// item_statesにレコードなし
 	svc := NewItemService(repo, newMockItemStateRepoForService())
-	detail, err := svc.GetItem(context.Background(), "user-123", "item-1")
+	detail, err := svc.GetItem(context.Background(), "user-123", "item-1", false)
 	if err != nil {
 		t.Fatalf("GetItem returned error: %v", err)
 	}
@@ -782,12 +1133,204 @@ func TestItemService_GetItem_NoState(t *testing.T) {
 	}
 }
 
+// mockLinkPreference は LinkPreference のモック。
+type mockLinkPreference struct {
+	openInNewTab bool
+	err          error
+}
+
+func (m *mockLinkPreference) OpenLinksInNewTab(_ context.Context, _ string) (bool, error) {
+	return m.openInNewTab, m.err
+}
+
+// TestItemService_GetItem_LinkAttributes は本文リンクに rel が強制付与され、
+// ユーザー設定に応じて target が制御されることをテストする。
+func TestItemService_GetItem_LinkAttributes(t *testing.T) {
+	newRepo := func() *mockItemRepoForService {
+		repo := newMockItemRepoForService()
+		repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
+			return &model.Item{
+				ID:      "item-1",
+				FeedID:  "feed-1",
+				Content: `<p><a href="https://example.com/x" rel="noreferrer noopener" target="_blank">x</a></p>`,
+			}, nil
+		}
+		return repo
+	}
+
+	tests := []struct {
+		name string
+		pref LinkPreference
+		want string
+	}{
+		{
+			name: "設定が未注入のとき既定で新しいタブで開く",
+			pref: nil,
+			want: `<p><a href="https://example.com/x" rel="noopener noreferrer" target="_blank">x</a></p>`,
+		},
+		{
+			name: "同じタブで開く設定のときtargetを除去する",
+			pref: &mockLinkPreference{openInNewTab: false},
+			want: `<p><a href="https://example.com/x" rel="noopener noreferrer">x</a></p>`,
+		},
+		{
+			name: "設定の取得に失敗したとき既定値にフォールバックする",
+			pref: &mockLinkPreference{err: errors.New("db error")},
+			want: `<p><a href="https://example.com/x" rel="noopener noreferrer" target="_blank">x</a></p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var opts []ItemServiceOption
+			if tt.pref != nil {
+				opts = append(opts, WithLinkPreference(tt.pref))
+			}
+			svc := NewItemService(newRepo(), newMockItemStateRepoForService(), opts...)
+
+			// Act
+			detail, err := svc.GetItem(context.Background(), "user-123", "item-1", false)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("GetItem returned error: %v", err)
+			}
+			if detail.Content != tt.want {
+				t.Errorf("detail.Content = %q, want %q", detail.Content, tt.want)
+			}
+		})
+	}
+}
+
+// mockViewRecorder は ViewRecorder のテスト用モック。
+type mockViewRecorder struct {
+	views [][2]string
+}
+
+func (m *mockViewRecorder) RecordView(userID, itemID string) {
+	m.views = append(m.views, [2]string{userID, itemID})
+}
+
+// TestItemService_GetItem_RecordsView は記事詳細の取得が閲覧イベントとして記録されることを検証する。
+func TestItemService_GetItem_RecordsView(t *testing.T) {
+	t.Run("記事を取得したとき閲覧イベントを記録する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepoForService()
+		repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
+			return &model.Item{ID: id, FeedID: "feed-1"}, nil
+		}
+		recorder := &mockViewRecorder{}
+		svc := NewItemService(repo, newMockItemStateRepoForService(), WithViewRecorder(recorder))
+
+		// Act
+		_, err := svc.GetItem(context.Background(), "user-123", "item-1", false)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("GetItem returned error: %v", err)
+		}
+		if len(recorder.views) != 1 || recorder.views[0] != [2]string{"user-123", "item-1"} {
+			t.Errorf("views = %v, want [[user-123 item-1]]", recorder.views)
+		}
+	})
+
+	t.Run("記事が存在しないとき閲覧イベントを記録しない", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepoForService()
+		repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
+			return nil, nil
+		}
+		recorder := &mockViewRecorder{}
+		svc := NewItemService(repo, newMockItemStateRepoForService(), WithViewRecorder(recorder))
+
+		// Act
+		_, err := svc.GetItem(context.Background(), "user-123", "missing", false)
+
+		// Assert
+		if err == nil {
+			t.Fatal("GetItem should return error for missing item")
+		}
+		if len(recorder.views) != 0 {
+			t.Errorf("views = %v, want none", recorder.views)
+		}
+	})
+}
+
+// mockHatebuRefresher は HatebuRefresher のテスト用モック。
+type mockHatebuRefresher struct {
+	requests []hatebuRefreshRequest
+}
+
+type hatebuRefreshRequest struct {
+	itemID    string
+	url       string
+	fetchedAt *time.Time
+}
+
+func (m *mockHatebuRefresher) RequestRefresh(itemID, url string, fetchedAt *time.Time) {
+	m.requests = append(m.requests, hatebuRefreshRequest{itemID: itemID, url: url, fetchedAt: fetchedAt})
+}
+
+// TestItemService_GetItem_RequestsHatebuRefresh は記事詳細の取得時にはてブ数の再取得を依頼することを検証する。
+func TestItemService_GetItem_RequestsHatebuRefresh(t *testing.T) {
+	t.Run("記事を取得したときリンクと取得日時を渡して再取得を依頼する", func(t *testing.T) {
+		// Arrange
+		fetchedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		repo := newMockItemRepoForService()
+		repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
+			return &model.Item{ID: id, FeedID: "feed-1", Link: "https://example.com/a", HatebuCount: 5, HatebuFetchedAt: &fetchedAt}, nil
+		}
+		refresher := &mockHatebuRefresher{}
+		svc := NewItemService(repo, newMockItemStateRepoForService(), WithHatebuRefresher(refresher))
+
+		// Act
+		detail, err := svc.GetItem(context.Background(), "user-123", "item-1", false)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("GetItem returned error: %v", err)
+		}
+		if detail.HatebuCount != 5 {
+			t.Errorf("HatebuCount = %d, want 5（レスポンスは保存済みの値を返す）", detail.HatebuCount)
+		}
+		if len(refresher.requests) != 1 {
+			t.Fatalf("requests = %v, want 1 件", refresher.requests)
+		}
+		got := refresher.requests[0]
+		if got.itemID != "item-1" || got.url != "https://example.com/a" || got.fetchedAt == nil || !got.fetchedAt.Equal(fetchedAt) {
+			t.Errorf("request = %+v, want item-1 / https://example.com/a / %v", got, fetchedAt)
+		}
+	})
+
+	t.Run("記事が存在しないとき再取得を依頼しない", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepoForService()
+		repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
+			return nil, nil
+		}
+		refresher := &mockHatebuRefresher{}
+		svc := NewItemService(repo, newMockItemStateRepoForService(), WithHatebuRefresher(refresher))
+
+		// Act
+		_, err := svc.GetItem(context.Background(), "user-123", "missing", false)
+
+		// Assert
+		if err == nil {
+			t.Fatal("GetItem should return error for missing item")
+		}
+		if len(refresher.requests) != 0 {
+			t.Errorf("requests = %v, want none", refresher.requests)
+		}
+	})
+}
+
 // --- ItemStateService テスト ---
 
 // TestItemStateService_UpdateState_SetRead は既読状態の設定をテストする。
 func TestItemStateService_UpdateState_SetRead(t *testing.T) {
 	stateRepo := newMockItemStateRepoForService()
-	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 		if userID != "user-123" {
 			t.Errorf("userID = %q, want %q", userID, "user-123")
 		}
@@ -815,7 +1358,7 @@ func TestItemStateService_UpdateState_SetRead(t *testing.T) {
 
 	svc := NewItemStateService(itemRepo, stateRepo)
 	isRead := true
-	state, err := svc.UpdateState(context.Background(), "user-123", "item-1", &isRead, nil)
+	state, err := svc.UpdateState(context.Background(), "user-123", "item-1", &isRead, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("UpdateState returned error: %v", err)
 	}
@@ -828,7 +1371,7 @@ func TestItemStateService_UpdateState_SetRead(t *testing.T) {
 // TestItemStateService_UpdateState_SetStarred はスター状態の設定をテストする。
 func TestItemStateService_UpdateState_SetStarred(t *testing.T) {
 	stateRepo := newMockItemStateRepoForService()
-	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 		if isStarred == nil || !*isStarred {
 			t.Error("expected isStarred to be true")
 		}
@@ -847,7 +1390,7 @@ func TestItemStateService_UpdateState_SetStarred(t *testing.T) {
 
 	svc := NewItemStateService(itemRepo, stateRepo)
 	isStarred := true
-	state, err := svc.UpdateState(context.Background(), "user-123", "item-1", nil, &isStarred)
+	state, err := svc.UpdateState(context.Background(), "user-123", "item-1", nil, &isStarred, nil, nil)
 	if err != nil {
 		t.Fatalf("UpdateState returned error: %v", err)
 	}
@@ -860,7 +1403,7 @@ func TestItemStateService_UpdateState_SetStarred(t *testing.T) {
 // TestItemStateService_UpdateState_NilFieldsNotChanged はnilフィールドが変更されないことをテストする。
 func TestItemStateService_UpdateState_NilFieldsNotChanged(t *testing.T) {
 	stateRepo := newMockItemStateRepoForService()
-	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 		// isReadのみ指定されている
 		if isRead == nil {
 			t.Error("expected isRead to be non-nil")
@@ -884,7 +1427,7 @@ func TestItemStateService_UpdateState_NilFieldsNotChanged(t *testing.T) {
 
 	svc := NewItemStateService(itemRepo, stateRepo)
 	isRead := false
-	state, err := svc.UpdateState(context.Background(), "user-123", "item-1", &isRead, nil)
+	state, err := svc.UpdateState(context.Background(), "user-123", "item-1", &isRead, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("UpdateState returned error: %v", err)
 	}
@@ -906,7 +1449,7 @@ func TestItemStateService_UpdateState_ItemNotFound(t *testing.T) {
 
 	svc := NewItemStateService(itemRepo, newMockItemStateRepoForService())
 	isRead := true
-	_, err := svc.UpdateState(context.Background(), "user-123", "nonexistent", &isRead, nil)
+	_, err := svc.UpdateState(context.Background(), "user-123", "nonexistent", &isRead, nil, nil, nil)
 	if err == nil {
 		t.Fatal("expected error for non-existent item")
 	}
@@ -924,7 +1467,7 @@ func TestItemStateService_UpdateState_ItemNotFound(t *testing.T) {
 func TestItemStateService_UpdateState_UserDataIsolation(t *testing.T) {
 	receivedUserID := ""
 	stateRepo := newMockItemStateRepoForService()
-	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+	stateRepo.upsertFn = func(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 		receivedUserID = userID
 		return &model.ItemState{
 			UserID:    userID,
@@ -941,7 +1484,7 @@ func TestItemStateService_UpdateState_UserDataIsolation(t *testing.T) {
 
 	svc := NewItemStateService(itemRepo, stateRepo)
 	isRead := true
-	_, err := svc.UpdateState(context.Background(), "user-456", "item-1", &isRead, nil)
+	_, err := svc.UpdateState(context.Background(), "user-456", "item-1", &isRead, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("UpdateState returned error: %v", err)
 	}
@@ -7,7 +7,7 @@ import (
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
-// ItemStateService は記事の既読・スター状態の管理サービス。
+// ItemStateService は記事の既読・スター・非表示状態と評価の管理サービス。
 // 冪等な明示的更新（トグルではない）で状態を変更する。
 type ItemStateService struct {
 	itemRepo      repository.ItemRepository
@@ -25,8 +25,9 @@ func NewItemStateService(
 	}
 }
 
-// UpdateState は記事の既読・スター状態を冪等に更新する。
+// UpdateState は記事の既読・スター・非表示状態と評価を冪等に更新する。
 // nilフィールドは変更せず、既存の値を維持する部分更新を行う。
+// スターと評価は評価 1 以上をスター付きとみなす規則（model.ApplyStarRating）で両方を更新する。
 // 記事が存在しない場合はITEM_NOT_FOUNDエラーを返す。
 // ユーザーデータ分離（全クエリにuser_id条件付与）をRepository層で強制する。
 func (s *ItemStateService) UpdateState(
@@ -34,6 +35,8 @@ func (s *ItemStateService) UpdateState(
 	userID, itemID string,
 	isRead *bool,
 	isStarred *bool,
+	isHidden *bool,
+	rating *int,
 ) (*model.ItemState, error) {
 	// 記事の存在確認
 	item, err := s.itemRepo.FindByID(ctx, itemID)
@@ -45,7 +48,7 @@ func (s *ItemStateService) UpdateState(
 	}
 
 	// 記事状態をUPSERT（user_idを常に条件に含める）
-	state, err := s.itemStateRepo.Upsert(ctx, userID, itemID, isRead, isStarred)
+	state, err := s.itemStateRepo.Upsert(ctx, userID, itemID, isRead, isStarred, isHidden, rating)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,194 @@
+package item
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/summarizer"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultSummaryRatePerHour はユーザーあたりの要約生成の既定の上限回数（1 時間あたり）。
+	DefaultSummaryRatePerHour = 20
+	// defaultSummaryMaxConcurrent は要約器を同時に呼び出す既定の最大数（インスタンス全体）。
+	defaultSummaryMaxConcurrent = 2
+)
+
+// GeneratedSummary は要約生成（Summarize）の結果。
+type GeneratedSummary struct {
+	ItemID      string
+	Summary     string
+	GeneratedAt time.Time
+	// Cached は保存済みの要約を返した（要約器を呼び出していない）場合に true。
+	Cached bool
+	// Fallback は要約器が失敗し、フォールバックの要約器で生成した場合に true。
+	// フォールバックの要約は保存しないため、次回の要求で改めて要約器を呼び出す。
+	Fallback bool
+}
+
+// SummaryService は記事の要約をオンデマンドで生成・保存するサービス。
+//
+// 生成した要約は items.generated_summary に保存し、以降は要約器を呼び出さずに返す。
+// 要約器の呼び出しはユーザーごとの回数制限とインスタンス全体の同時実行数で制御し、
+// 要約器が失敗した場合はフォールバックの要約器（ローカルの抽出型要約）で生成した要約を返す。
+type SummaryService struct {
+	itemRepo    repository.ItemRepository
+	summaryRepo repository.ItemSummaryRepository
+	summarizer  summarizer.Summarizer
+	fallback    summarizer.Summarizer
+	ratePerHour int
+	sem         chan struct{}
+	now         func() time.Time
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// SummaryServiceOption は NewSummaryService の任意設定を表す functional option。
+type SummaryServiceOption func(*SummaryService)
+
+// WithSummaryFallback は要約器が失敗したときに用いるフォールバックの要約器を設定する。
+// 未設定時は要約器の失敗を SUMMARY_UNAVAILABLE として返す。
+func WithSummaryFallback(fallback summarizer.Summarizer) SummaryServiceOption {
+	return func(s *SummaryService) {
+		s.fallback = fallback
+	}
+}
+
+// WithSummaryRatePerHour はユーザーあたりの要約生成の上限回数（1 時間あたり）を設定する。
+// 0 以下の値は DefaultSummaryRatePerHour として扱う。
+func WithSummaryRatePerHour(n int) SummaryServiceOption {
+	return func(s *SummaryService) {
+		if n > 0 {
+			s.ratePerHour = n
+		}
+	}
+}
+
+// NewSummaryService は SummaryService の新しいインスタンスを生成する。
+func NewSummaryService(
+	itemRepo repository.ItemRepository,
+	summaryRepo repository.ItemSummaryRepository,
+	sum summarizer.Summarizer,
+	opts ...SummaryServiceOption,
+) *SummaryService {
+	s := &SummaryService{
+		itemRepo:    itemRepo,
+		summaryRepo: summaryRepo,
+		summarizer:  sum,
+		ratePerHour: DefaultSummaryRatePerHour,
+		sem:         make(chan struct{}, defaultSummaryMaxConcurrent),
+		now:         time.Now,
+		limiters:    make(map[string]*rate.Limiter),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Summarize は記事の要約を返す。保存済みの要約があればそれを返し、無ければ要約器で生成して保存する。
+//
+// 記事が存在しない場合は ITEM_NOT_FOUND、本文も概要も無い場合は ITEM_NOT_SUMMARIZABLE、
+// ユーザーあたりの回数制限を超えた場合は SUMMARIZE_RATE_LIMITED を返す（保存済みの要約の取得は制限しない）。
+// 要約器が失敗した場合はフォールバックの要約器で生成した要約を保存せずに返し、
+// いずれでも生成できない場合は SUMMARY_UNAVAILABLE を返す。
+func (s *SummaryService) Summarize(ctx context.Context, userID, itemID string) (*GeneratedSummary, error) {
+	item, err := s.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, model.NewItemNotFoundError(itemID)
+	}
+	if item.GeneratedSummary != "" {
+		result := &GeneratedSummary{ItemID: item.ID, Summary: item.GeneratedSummary, Cached: true}
+		if item.GeneratedSummaryAt != nil {
+			result.GeneratedAt = *item.GeneratedSummaryAt
+		}
+		return result, nil
+	}
+
+	// 本文が無い記事（概要のみのフィード）は概要を要約対象にする
+	text := strings.Join(strings.Fields(htmlText(item.Content)), " ")
+	if text == "" {
+		text = strings.Join(strings.Fields(htmlText(item.Summary)), " ")
+	}
+	if text == "" {
+		return nil, model.NewItemNotSummarizableError(itemID)
+	}
+
+	if err := s.allow(userID); err != nil {
+		return nil, err
+	}
+
+	doc := summarizer.Document{Title: item.Title, Text: text}
+	summary, err := s.generate(ctx, doc)
+	if err == nil {
+		generatedAt := s.now()
+		if err := s.summaryRepo.UpdateGeneratedSummary(ctx, item.ID, summary, generatedAt); err != nil {
+			// 保存に失敗しても生成した要約の表示は妨げない（次回の要求で再生成される）
+			slog.Warn("生成要約の保存に失敗しました",
+				slog.String("item_id", item.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+		return &GeneratedSummary{ItemID: item.ID, Summary: summary, GeneratedAt: generatedAt}, nil
+	}
+
+	slog.Warn("要約の生成に失敗しました",
+		slog.String("item_id", item.ID),
+		slog.String("error", err.Error()),
+	)
+	if s.fallback == nil {
+		return nil, model.NewSummaryUnavailableError()
+	}
+	summary, err = s.fallback.Summarize(ctx, doc)
+	if err != nil {
+		slog.Warn("フォールバックの要約の生成に失敗しました",
+			slog.String("item_id", item.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, model.NewSummaryUnavailableError()
+	}
+	return &GeneratedSummary{ItemID: item.ID, Summary: summary, GeneratedAt: s.now(), Fallback: true}, nil
+}
+
+// generate は同時実行数の枠を確保してから要約器を呼び出す。
+// 枠の空きを待つ間に ctx が終了した場合は ctx のエラーを返す。
+func (s *SummaryService) generate(ctx context.Context, doc summarizer.Document) (string, error) {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-s.sem }()
+	return s.summarizer.Summarize(ctx, doc)
+}
+
+// allow はユーザーの要約生成の回数制限を 1 回分消費する。
+// 上限に達している場合は消費せずに、次に生成できるまでの秒数付きの SUMMARIZE_RATE_LIMITED を返す。
+func (s *SummaryService) allow(userID string) error {
+	s.mu.Lock()
+	limiter, ok := s.limiters[userID]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Every(time.Hour/time.Duration(s.ratePerHour)), s.ratePerHour)
+		s.limiters[userID] = limiter
+	}
+	s.mu.Unlock()
+
+	now := s.now()
+	reservation := limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return model.NewSummarizeRateLimitedError(int(math.Ceil(delay.Seconds())))
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/summarizer"
+)
+
+// mockItemSummaryRepo はテスト用のItemSummaryRepositoryモック。
+type mockItemSummaryRepo struct {
+	updateErr   error
+	updateCalls int
+	gotItemID   string
+	gotSummary  string
+	gotAt       time.Time
+}
+
+func (m *mockItemSummaryRepo) UpdateGeneratedSummary(_ context.Context, itemID, summary string, generatedAt time.Time) error {
+	m.updateCalls++
+	m.gotItemID, m.gotSummary, m.gotAt = itemID, summary, generatedAt
+	return m.updateErr
+}
+
+// mockSummarizer はテスト用のSummarizerモック。
+type mockSummarizer struct {
+	summary string
+	err     error
+	calls   int
+	gotDoc  summarizer.Document
+}
+
+func (m *mockSummarizer) Summarize(_ context.Context, doc summarizer.Document) (string, error) {
+	m.calls++
+	m.gotDoc = doc
+	return m.summary, m.err
+}
+
+func assertAPIErrorCode(t *testing.T, err error, want string) *model.APIError {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != want {
+		t.Fatalf("err = %v, want %s", err, want)
+	}
+	return apiErr
+}
+
+func TestSummaryService_Summarize(t *testing.T) {
+	fixedNow := time.Date(2026, 7, 2, 12, 0, 0, 0, time.UTC)
+
+	newService := func(item *model.Item, summaryRepo *mockItemSummaryRepo, sum summarizer.Summarizer, opts ...SummaryServiceOption) *SummaryService {
+		itemRepo := newMockItemRepoForService()
+		itemRepo.findByIDFn = func(_ context.Context, _ string) (*model.Item, error) {
+			return item, nil
+		}
+		svc := NewSummaryService(itemRepo, summaryRepo, sum, opts...)
+		svc.now = func() time.Time { return fixedNow }
+		return svc
+	}
+
+	t.Run("要約が未生成のとき本文から生成して保存する", func(t *testing.T) {
+		// Arrange
+		summaryRepo := &mockItemSummaryRepo{}
+		sum := &mockSummarizer{summary: "要約です。"}
+		svc := newService(&model.Item{ID: "item-1", Title: "タイトル", Content: "<p>本文の<b>一文目</b>。</p>"}, summaryRepo, sum)
+
+		// Act
+		got, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Summary != "要約です。" || got.Cached || got.Fallback || !got.GeneratedAt.Equal(fixedNow) {
+			t.Errorf("result = %+v", got)
+		}
+		if sum.gotDoc.Title != "タイトル" || sum.gotDoc.Text != "本文の 一文目 。" {
+			t.Errorf("要約器に渡した記事 = %+v", sum.gotDoc)
+		}
+		if summaryRepo.updateCalls != 1 || summaryRepo.gotItemID != "item-1" || summaryRepo.gotSummary != "要約です。" || !summaryRepo.gotAt.Equal(fixedNow) {
+			t.Errorf("保存内容 = (%d, %q, %q, %v)", summaryRepo.updateCalls, summaryRepo.gotItemID, summaryRepo.gotSummary, summaryRepo.gotAt)
+		}
+	})
+
+	t.Run("要約が保存済みのとき要約器を呼ばずに返す", func(t *testing.T) {
+		// Arrange
+		generatedAt := fixedNow.Add(-time.Hour)
+		summaryRepo := &mockItemSummaryRepo{}
+		sum := &mockSummarizer{summary: "新しい要約"}
+		svc := newService(&model.Item{ID: "item-1", Content: "本文", GeneratedSummary: "保存済み", GeneratedSummaryAt: &generatedAt}, summaryRepo, sum,
+			WithSummaryRatePerHour(1))
+
+		// Act
+		got, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Summary != "保存済み" || !got.Cached || !got.GeneratedAt.Equal(generatedAt) {
+			t.Errorf("result = %+v", got)
+		}
+		if sum.calls != 0 || summaryRepo.updateCalls != 0 {
+			t.Errorf("要約器・保存は呼ばれるべきではない (summarize=%d, update=%d)", sum.calls, summaryRepo.updateCalls)
+		}
+	})
+
+	t.Run("本文が無いとき概要を要約対象にする", func(t *testing.T) {
+		// Arrange
+		sum := &mockSummarizer{summary: "要約"}
+		svc := newService(&model.Item{ID: "item-1", Summary: "<p>概要</p>"}, &mockItemSummaryRepo{}, sum)
+
+		// Act
+		_, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sum.gotDoc.Text != "概要" {
+			t.Errorf("Text = %q, want %q", sum.gotDoc.Text, "概要")
+		}
+	})
+
+	t.Run("本文も概要も無いときITEM_NOT_SUMMARIZABLEを返す", func(t *testing.T) {
+		// Arrange
+		sum := &mockSummarizer{summary: "要約"}
+		svc := newService(&model.Item{ID: "item-1", Content: "<img src=\"a.png\">"}, &mockItemSummaryRepo{}, sum)
+
+		// Act
+		_, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeItemNotSummarizable)
+		if sum.calls != 0 {
+			t.Errorf("要約器は呼ばれるべきではない (calls=%d)", sum.calls)
+		}
+	})
+
+	t.Run("記事が存在しないときITEM_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := newService(nil, &mockItemSummaryRepo{}, &mockSummarizer{})
+
+		// Act
+		_, err := svc.Summarize(context.Background(), "user-1", "item-x")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeItemNotFound)
+	})
+
+	t.Run("回数制限を超えたときretry_after_seconds付きのSUMMARIZE_RATE_LIMITEDを返す", func(t *testing.T) {
+		// Arrange
+		sum := &mockSummarizer{summary: "要約"}
+		svc := newService(&model.Item{ID: "item-1", Content: "本文"}, &mockItemSummaryRepo{}, sum,
+			WithSummaryRatePerHour(1))
+		if _, err := svc.Summarize(context.Background(), "user-1", "item-1"); err != nil {
+			t.Fatalf("1 回目は成功するべき: %v", err)
+		}
+
+		// Act
+		_, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		apiErr := assertAPIErrorCode(t, err, model.ErrCodeSummarizeRateLimited)
+		if got := apiErr.Details["retry_after_seconds"]; got != 3600 {
+			t.Errorf("retry_after_seconds = %v, want 3600", got)
+		}
+		if sum.calls != 1 {
+			t.Errorf("summarize calls = %d, want 1", sum.calls)
+		}
+	})
+
+	t.Run("回数制限はユーザーごとに数える", func(t *testing.T) {
+		// Arrange
+		svc := newService(&model.Item{ID: "item-1", Content: "本文"}, &mockItemSummaryRepo{}, &mockSummarizer{summary: "要約"},
+			WithSummaryRatePerHour(1))
+		if _, err := svc.Summarize(context.Background(), "user-1", "item-1"); err != nil {
+			t.Fatalf("user-1 は成功するべき: %v", err)
+		}
+
+		// Act
+		_, err := svc.Summarize(context.Background(), "user-2", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Errorf("別ユーザーは制限されるべきではない: %v", err)
+		}
+	})
+
+	t.Run("要約器が失敗したときフォールバックの要約を保存せずに返す", func(t *testing.T) {
+		// Arrange
+		summaryRepo := &mockItemSummaryRepo{}
+		svc := newService(&model.Item{ID: "item-1", Content: "本文"}, summaryRepo, &mockSummarizer{err: errors.New("api down")},
+			WithSummaryFallback(&mockSummarizer{summary: "抜粋"}))
+
+		// Act
+		got, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Summary != "抜粋" || !got.Fallback {
+			t.Errorf("result = %+v", got)
+		}
+		if summaryRepo.updateCalls != 0 {
+			t.Errorf("フォールバックの要約は保存されるべきではない (update=%d)", summaryRepo.updateCalls)
+		}
+	})
+
+	t.Run("要約器が失敗しフォールバックが無いときSUMMARY_UNAVAILABLEを返す", func(t *testing.T) {
+		// Arrange
+		svc := newService(&model.Item{ID: "item-1", Content: "本文"}, &mockItemSummaryRepo{}, &mockSummarizer{err: errors.New("api down")})
+
+		// Act
+		_, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSummaryUnavailable)
+	})
+
+	t.Run("保存に失敗しても生成した要約を返す", func(t *testing.T) {
+		// Arrange
+		svc := newService(&model.Item{ID: "item-1", Content: "本文"}, &mockItemSummaryRepo{updateErr: errors.New("db error")}, &mockSummarizer{summary: "要約"})
+
+		// Act
+		got, err := svc.Summarize(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Summary != "要約" {
+			t.Errorf("Summary = %q, want %q", got.Summary, "要約")
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// SyncService はモバイル等がオフライン中に記録した既読・スター操作をまとめて適用するサービス。
+// 操作ごとに操作の時刻とサーバー側の状態の最終変更日時を比べ、新しい方を採用する（last-write-wins）。
+type SyncService struct {
+	itemRepo repository.ItemRepository
+	syncRepo repository.ItemStateSyncRepository
+	now      func() time.Time
+}
+
+// NewSyncService はSyncServiceの新しいインスタンスを生成する。
+func NewSyncService(itemRepo repository.ItemRepository, syncRepo repository.ItemStateSyncRepository) *SyncService {
+	return &SyncService{
+		itemRepo: itemRepo,
+		syncRepo: syncRepo,
+		now:      time.Now,
+	}
+}
+
+// ApplyOperations は操作列を送信順に適用し、操作ごとの結果を同じ順で返す。
+// 操作列が空か MaxSyncOperations 件を超える場合は INVALID_SYNC_OPERATIONS を返して何も適用しない。
+// 個々の操作が不正な場合（INVALID_SYNC_OPERATIONS）と記事が存在しない場合（ITEM_NOT_FOUND）は
+// その操作の結果を failed とし、残りの操作の適用を続ける。
+//
+// 未来の client_timestamp はサーバーの現在時刻に丸める。時計のずれた端末の操作が、
+// 以降のオンラインでの変更を上書きできなくなるのを防ぐため。
+// 保存に失敗した場合はエラーを返す。適用済みの操作は同じ操作列を再送しても二重に適用されない（stale になる）。
+func (s *SyncService) ApplyOperations(ctx context.Context, userID string, ops []model.SyncOperation) ([]model.SyncOperationResult, error) {
+	if len(ops) == 0 {
+		return nil, model.NewInvalidSyncOperationsError("操作が指定されていません")
+	}
+	if len(ops) > model.MaxSyncOperations {
+		return nil, model.NewInvalidSyncOperationsError(fmt.Sprintf("一度に送れる操作は%d件までです", model.MaxSyncOperations))
+	}
+
+	now := s.now()
+	results := make([]model.SyncOperationResult, len(ops))
+	for i, op := range ops {
+		results[i].Operation = op
+		if apiErr := validateSyncOperation(op); apiErr != nil {
+			results[i].Status = model.SyncStatusFailed
+			results[i].Error = apiErr
+			continue
+		}
+
+		item, err := s.itemRepo.FindByID(ctx, op.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			results[i].Status = model.SyncStatusFailed
+			results[i].Error = model.NewItemNotFoundError(op.ItemID)
+			continue
+		}
+
+		changedAt := op.ClientTimestamp
+		if changedAt.After(now) {
+			changedAt = now
+		}
+		state, applied, err := s.syncRepo.ApplySyncOperation(ctx, userID, op.ItemID, op.Type, *op.Value, changedAt)
+		if err != nil {
+			return nil, err
+		}
+		results[i].State = state
+		if applied {
+			results[i].Status = model.SyncStatusApplied
+		} else {
+			results[i].Status = model.SyncStatusStale
+		}
+	}
+	return results, nil
+}
+
+// validateSyncOperation は 1 件の操作の必須項目を検証する。
+func validateSyncOperation(op model.SyncOperation) *model.APIError {
+	switch {
+	case !op.Type.Valid():
+		return model.NewInvalidSyncOperationsError(fmt.Sprintf("未知の操作です: %q", op.Type))
+	case op.ItemID == "":
+		return model.NewInvalidSyncOperationsError("item_id が指定されていません")
+	case op.Value == nil:
+		return model.NewInvalidSyncOperationsError("value が指定されていません")
+	case op.ClientTimestamp.IsZero():
+		return model.NewInvalidSyncOperationsError("client_timestamp が指定されていません")
+	}
+	return nil
+}
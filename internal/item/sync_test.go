@@ -0,0 +1,220 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// syncCall は mockItemStateSyncRepo への呼び出しの記録。
+type syncCall struct {
+	itemID    string
+	opType    model.SyncOperationType
+	value     bool
+	changedAt time.Time
+}
+
+// mockItemStateSyncRepo は ItemStateSyncRepository のモック。
+// 記事ごとの最終変更日時を保持し、実装と同じく新しい操作だけを適用する。
+type mockItemStateSyncRepo struct {
+	changedAt map[string]time.Time
+	calls     []syncCall
+	err       error
+}
+
+func (m *mockItemStateSyncRepo) ApplySyncOperation(ctx context.Context, userID, itemID string, opType model.SyncOperationType, value bool, changedAt time.Time) (*model.ItemState, bool, error) {
+	m.calls = append(m.calls, syncCall{itemID: itemID, opType: opType, value: value, changedAt: changedAt})
+	if m.err != nil {
+		return nil, false, m.err
+	}
+	key := itemID + "|" + string(opType)
+	if last, ok := m.changedAt[key]; ok && !changedAt.After(last) {
+		return &model.ItemState{UserID: userID, ItemID: itemID}, false, nil
+	}
+	m.changedAt[key] = changedAt
+	state := &model.ItemState{UserID: userID, ItemID: itemID}
+	if opType == model.SyncOperationRead {
+		state.IsRead = value
+	} else {
+		state.IsStarred = value
+	}
+	return state, true, nil
+}
+
+func newSyncTestService(syncRepo *mockItemStateSyncRepo, now time.Time) *SyncService {
+	repo := newMockItemRepoForService()
+	repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
+		if id == "missing" {
+			return nil, nil
+		}
+		return &model.Item{ID: id, FeedID: "feed-1"}, nil
+	}
+	svc := NewSyncService(repo, syncRepo)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestSyncService_ApplyOperations(t *testing.T) {
+	now := time.Date(2026, 7, 9, 12, 0, 0, 0, time.UTC)
+	yes, no := true, false
+
+	t.Run("サーバー側より新しい操作は適用し古い操作はstaleにする", func(t *testing.T) {
+		// Arrange
+		syncRepo := &mockItemStateSyncRepo{changedAt: map[string]time.Time{
+			"item-2|read": now.Add(-time.Minute),
+		}}
+		svc := newSyncTestService(syncRepo, now)
+		ops := []model.SyncOperation{
+			{Type: model.SyncOperationRead, ItemID: "item-1", Value: &yes, ClientTimestamp: now.Add(-time.Hour)},
+			{Type: model.SyncOperationRead, ItemID: "item-2", Value: &no, ClientTimestamp: now.Add(-time.Hour)},
+			{Type: model.SyncOperationStar, ItemID: "item-2", Value: &yes, ClientTimestamp: now.Add(-time.Hour)},
+		}
+
+		// Act
+		results, err := svc.ApplyOperations(context.Background(), "user-1", ops)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []model.SyncOperationStatus{model.SyncStatusApplied, model.SyncStatusStale, model.SyncStatusApplied}
+		if len(results) != len(want) {
+			t.Fatalf("len(results) = %d, want %d", len(results), len(want))
+		}
+		for i, w := range want {
+			if results[i].Status != w {
+				t.Errorf("results[%d].Status = %q, want %q", i, results[i].Status, w)
+			}
+			if results[i].State == nil {
+				t.Errorf("results[%d].State should not be nil", i)
+			}
+			if results[i].Operation != ops[i] {
+				t.Errorf("results[%d].Operation = %+v, want %+v", i, results[i].Operation, ops[i])
+			}
+		}
+	})
+
+	t.Run("同じ操作列を再送したとき二重に適用しない", func(t *testing.T) {
+		// Arrange
+		syncRepo := &mockItemStateSyncRepo{changedAt: map[string]time.Time{}}
+		svc := newSyncTestService(syncRepo, now)
+		ops := []model.SyncOperation{{Type: model.SyncOperationRead, ItemID: "item-1", Value: &yes, ClientTimestamp: now.Add(-time.Hour)}}
+		if _, err := svc.ApplyOperations(context.Background(), "user-1", ops); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Act
+		results, err := svc.ApplyOperations(context.Background(), "user-1", ops)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if results[0].Status != model.SyncStatusStale {
+			t.Errorf("Status = %q, want %q", results[0].Status, model.SyncStatusStale)
+		}
+	})
+
+	t.Run("未来のclient_timestampはサーバーの現在時刻に丸める", func(t *testing.T) {
+		// Arrange
+		syncRepo := &mockItemStateSyncRepo{changedAt: map[string]time.Time{}}
+		svc := newSyncTestService(syncRepo, now)
+		ops := []model.SyncOperation{{Type: model.SyncOperationStar, ItemID: "item-1", Value: &yes, ClientTimestamp: now.Add(24 * time.Hour)}}
+
+		// Act
+		if _, err := svc.ApplyOperations(context.Background(), "user-1", ops); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Assert
+		if got := syncRepo.calls[0].changedAt; !got.Equal(now) {
+			t.Errorf("changedAt = %v, want %v", got, now)
+		}
+	})
+
+	t.Run("不正な操作と存在しない記事はfailedにして残りを適用する", func(t *testing.T) {
+		// Arrange
+		syncRepo := &mockItemStateSyncRepo{changedAt: map[string]time.Time{}}
+		svc := newSyncTestService(syncRepo, now)
+		ops := []model.SyncOperation{
+			{Type: "archive", ItemID: "item-1", Value: &yes, ClientTimestamp: now},
+			{Type: model.SyncOperationRead, ItemID: "", Value: &yes, ClientTimestamp: now},
+			{Type: model.SyncOperationRead, ItemID: "item-1", Value: &yes},
+			{Type: model.SyncOperationStar, ItemID: "item-1", ClientTimestamp: now},
+			{Type: model.SyncOperationRead, ItemID: "missing", Value: &yes, ClientTimestamp: now},
+			{Type: model.SyncOperationRead, ItemID: "item-1", Value: &yes, ClientTimestamp: now},
+		}
+
+		// Act
+		results, err := svc.ApplyOperations(context.Background(), "user-1", ops)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wantCodes := []string{
+			model.ErrCodeInvalidSyncOperations,
+			model.ErrCodeInvalidSyncOperations,
+			model.ErrCodeInvalidSyncOperations,
+			model.ErrCodeInvalidSyncOperations,
+			model.ErrCodeItemNotFound,
+		}
+		for i, code := range wantCodes {
+			if results[i].Status != model.SyncStatusFailed {
+				t.Errorf("results[%d].Status = %q, want %q", i, results[i].Status, model.SyncStatusFailed)
+			}
+			if results[i].Error == nil || results[i].Error.Code != code {
+				t.Errorf("results[%d].Error = %v, want code %s", i, results[i].Error, code)
+			}
+			if results[i].State != nil {
+				t.Errorf("results[%d].State = %+v, want nil", i, results[i].State)
+			}
+		}
+		if results[5].Status != model.SyncStatusApplied {
+			t.Errorf("results[5].Status = %q, want %q", results[5].Status, model.SyncStatusApplied)
+		}
+		if len(syncRepo.calls) != 1 {
+			t.Errorf("ApplySyncOperation calls = %d, want 1", len(syncRepo.calls))
+		}
+	})
+
+	t.Run("操作列が空または上限を超えるときINVALID_SYNC_OPERATIONSを返す", func(t *testing.T) {
+		// Arrange
+		syncRepo := &mockItemStateSyncRepo{changedAt: map[string]time.Time{}}
+		svc := newSyncTestService(syncRepo, now)
+		tooMany := make([]model.SyncOperation, model.MaxSyncOperations+1)
+
+		for _, ops := range [][]model.SyncOperation{nil, tooMany} {
+			// Act
+			_, err := svc.ApplyOperations(context.Background(), "user-1", ops)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidSyncOperations {
+				t.Errorf("len(ops)=%d: err = %v, want %s", len(ops), err, model.ErrCodeInvalidSyncOperations)
+			}
+		}
+		if len(syncRepo.calls) != 0 {
+			t.Errorf("ApplySyncOperation calls = %d, want 0", len(syncRepo.calls))
+		}
+	})
+
+	t.Run("保存に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repoErr := errors.New("db error")
+		syncRepo := &mockItemStateSyncRepo{changedAt: map[string]time.Time{}, err: repoErr}
+		svc := newSyncTestService(syncRepo, now)
+		ops := []model.SyncOperation{{Type: model.SyncOperationRead, ItemID: "item-1", Value: &yes, ClientTimestamp: now}}
+
+		// Act
+		_, err := svc.ApplyOperations(context.Background(), "user-1", ops)
+
+		// Assert
+		if !errors.Is(err, repoErr) {
+			t.Errorf("err = %v, want %v", err, repoErr)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package item
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// byteOrderMark は UTF-8 の BOM（U+FEFF）。フィードの先頭や本文の途中に混入していることがある。
+const byteOrderMark = "\uFEFF"
+
+// normalizeText はフィードから取り出したテキストを保存前に UTF-8 として正規化する。
+//
+//   - 不正な UTF-8 バイト列（UTF-8 に変換されたサロゲート等を含む）は U+FFFD に置き換える
+//   - BOM（U+FEFF）は位置を問わず除去する
+//   - NUL 文字は PostgreSQL の text 型に保存できないため除去する
+//   - 合成済み文字と結合文字列の表記ゆれを NFC 正規化で揃える
+//
+// 正規化済みのテキストを再度渡しても結果は変わらない（冪等）。
+func normalizeText(s string) string {
+	if s == "" {
+		return ""
+	}
+	return norm.NFC.String(normalizeIdentifier(s))
+}
+
+// normalizeIdentifier は guid・link・URL など同一性判定に使う値を保存できる UTF-8 に揃える。
+// 既存記事との照合結果を変えないよう、NFC 正規化は行わず不正なバイト・BOM・NUL 文字の除去のみを行う。
+func normalizeIdentifier(s string) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	}
+	if strings.Contains(s, byteOrderMark) {
+		s = strings.ReplaceAll(s, byteOrderMark, "")
+	}
+	if strings.Contains(s, "\x00") {
+		s = strings.ReplaceAll(s, "\x00", "")
+	}
+	return s
+}
+
+// normalizeParsedItem は記事のテキスト項目に normalizeText、同一性判定に使う項目に normalizeIdentifier を適用した p を返す。
+func normalizeParsedItem(p model.ParsedItem) model.ParsedItem {
+	p.Title = normalizeText(p.Title)
+	p.Content = normalizeText(p.Content)
+	p.Summary = normalizeText(p.Summary)
+	p.Author = normalizeText(p.Author)
+	p.GuidOrID = normalizeIdentifier(p.GuidOrID)
+	p.Link = normalizeIdentifier(p.Link)
+	p.ThumbnailURL = normalizeIdentifier(p.ThumbnailURL)
+	return p
+}
@@ -0,0 +1,88 @@
+package item
+
+import (
+	"context"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "空文字のとき空文字を返す", in: "", want: ""},
+		{name: "正規化済みのとき入力のまま返す", in: "記事のタイトル", want: "記事のタイトル"},
+		{name: "先頭のBOMを除去する", in: "\uFEFF記事", want: "記事"},
+		{name: "途中のBOMも除去する", in: "前\uFEFF後", want: "前後"},
+		{name: "不正なバイトをU+FFFDに置き換える", in: "前\xff\xfe後", want: "前\uFFFD後"},
+		{name: "UTF-8に変換されたサロゲートをU+FFFDに置き換える", in: "前\xed\xa0\x80後", want: "前\uFFFD後"},
+		{name: "NUL文字を除去する", in: "前\x00後", want: "前後"},
+		{name: "結合文字列をNFCの合成済み文字に揃える", in: "か\u3099", want: "が"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeText(tt.in)
+			if got != tt.want {
+				t.Errorf("normalizeText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if again := normalizeText(got); again != got {
+				t.Errorf("normalizeText() は冪等でない: %q -> %q", got, again)
+			}
+		})
+	}
+}
+
+func TestNormalizeIdentifier_DoesNotApplyNFC(t *testing.T) {
+	in := "https://example.com/か\u3099"
+	if got := normalizeIdentifier(in); got != in {
+		t.Errorf("normalizeIdentifier(%q) = %q, want unchanged", in, got)
+	}
+	if got := normalizeIdentifier("\uFEFFguid\xff"); got != "guid\uFFFD" {
+		t.Errorf("normalizeIdentifier() = %q, want %q", got, "guid\uFFFD")
+	}
+}
+
+// TestUpsertItems_TextIsNormalized は BOM・不正なバイトを含む記事が UTF-8 として正規化されて保存されることをテストする。
+func TestUpsertItems_TextIsNormalized(t *testing.T) {
+	repo := newMockItemRepo()
+	svc := NewItemUpsertService(repo, &mockSanitizer{})
+
+	parsedItems := []model.ParsedItem{
+		{
+			GuidOrID: "\uFEFFnormalize-test",
+			Title:    "\uFEFFタイトル\xff",
+			Link:     "https://example.com/normalize",
+			Content:  "<p>本文\xed\xa0\x80</p>",
+			Summary:  "か\u3099\x00",
+		},
+	}
+
+	_, _, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems)
+	if err != nil {
+		t.Fatalf("UpsertItems returned error: %v", err)
+	}
+
+	created := repo.lastCreatedItem
+	if created == nil {
+		t.Fatal("lastCreatedItem should not be nil")
+	}
+	if created.GuidOrID != "normalize-test" {
+		t.Errorf("guid = %q, want %q", created.GuidOrID, "normalize-test")
+	}
+	if created.Title != "タイトル\uFFFD" {
+		t.Errorf("title = %q, want %q", created.Title, "タイトル\uFFFD")
+	}
+	if created.Summary != "[sanitized]が" {
+		t.Errorf("summary = %q, want %q", created.Summary, "[sanitized]が")
+	}
+	for name, v := range map[string]string{"content": created.Content, "content_text": created.ContentText} {
+		if !utf8.ValidString(v) {
+			t.Errorf("%s = %q, want valid UTF-8", name, v)
+		}
+	}
+}
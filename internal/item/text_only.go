@@ -0,0 +1,68 @@
+package item
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// mediaElements はテキストオンリーモード（データセーバー）で本文から除去する要素。
+// iframe / video は代替テキストや source・track 等の子要素を含めて除去する。
+var mediaElements = map[atom.Atom]bool{
+	atom.Img:    true,
+	atom.Iframe: true,
+	atom.Video:  true,
+}
+
+// scanHTML は HTML のトークンを先頭から順に visit に渡す。
+// inMedia はトークンが mediaElements の要素そのものか、その内側にあるかを表す。
+// 読了時間・抜粋の元になるテキストの抽出（htmlText）とテキストオンリーの本文（stripMedia）は
+// このトークンの判定を共有し、メディア要素の内側のテキストをどちらも本文として扱わない。
+func scanHTML(content string, visit func(z *html.Tokenizer, tt html.TokenType, inMedia bool)) {
+	depth := 0
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// io.EOF を含め、以降は読み取れないため終了する
+			return
+		}
+
+		inMedia := depth > 0
+		if tt == html.StartTagToken || tt == html.EndTagToken || tt == html.SelfClosingTagToken {
+			name, _ := z.TagName()
+			if a := atom.Lookup(name); mediaElements[a] {
+				inMedia = true
+				// 空要素の img 以外は終了タグまでの内側も除去する
+				if a != atom.Img {
+					switch {
+					case tt == html.StartTagToken:
+						depth++
+					case tt == html.EndTagToken && depth > 0:
+						depth--
+					}
+				}
+			}
+		}
+		visit(z, tt, inMedia)
+	}
+}
+
+// stripMedia はサニタイズ済み HTML から画像・埋め込み・動画（mediaElements）を除去したテキストオンリーの本文を返す。
+// 記事詳細の ?text_only=true で、保存済みの本文に応答時の追加フィルタとして適用する。
+// 除去する要素以外のトークンは入力のまま出力するため、同一入力に対して常に同一出力を返す（冪等）。
+func stripMedia(sanitizedHTML string) string {
+	if sanitizedHTML == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(sanitizedHTML))
+	scanHTML(sanitizedHTML, func(z *html.Tokenizer, _ html.TokenType, inMedia bool) {
+		if !inMedia {
+			buf.Write(z.Raw())
+		}
+	})
+	return buf.String()
+}
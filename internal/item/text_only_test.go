@@ -0,0 +1,102 @@
+package item
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestStripMedia(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "空文字のとき空文字を返す", content: "", want: ""},
+		{name: "メディアを含まないとき入力のまま返す", content: `<p>本文 <a href="https://example.com" rel="noopener noreferrer">リンク</a></p>`, want: `<p>本文 <a href="https://example.com" rel="noopener noreferrer">リンク</a></p>`},
+		{name: "imgを除去する", content: `<p>前<img src="https://example.com/a.png" alt="図">後</p>`, want: `<p>前後</p>`},
+		{name: "自己終了形式のimgを除去する", content: `<p><img src="https://example.com/a.png"/>本文</p>`, want: `<p>本文</p>`},
+		{name: "iframeを内側ごと除去する", content: `<p>前</p><iframe src="https://example.com/embed">埋め込み</iframe><p>後</p>`, want: `<p>前</p><p>後</p>`},
+		{name: "videoをsourceと代替テキストごと除去する", content: `<video controls><source src="https://example.com/a.mp4"><p>再生できません</p></video><p>本文</p>`, want: `<p>本文</p>`},
+		{name: "入れ子のvideoも閉じタグまで除去する", content: `<video><video></video>代替</video><p>本文</p>`, want: `<p>本文</p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stripMedia(tt.content)
+			if got != tt.want {
+				t.Errorf("stripMedia() = %q, want %q", got, tt.want)
+			}
+			if again := stripMedia(got); again != got {
+				t.Errorf("stripMedia() は冪等でない: %q -> %q", got, again)
+			}
+		})
+	}
+}
+
+func TestStripMedia_SharesTextWithReadingTime(t *testing.T) {
+	// Arrange
+	content := `<p>` + strings.Repeat("word ", 250) + `</p><video>` + strings.Repeat("fallback ", 400) + `</video><img src="https://example.com/a.png">`
+
+	// Act
+	textOnly := stripMedia(content)
+
+	// Assert
+	if got, want := excerptOf(contentTextOf(htmlText(textOnly))), excerptOf(contentTextOf(htmlText(content))); got != want {
+		t.Errorf("テキストオンリーの本文の抜粋 = %q, want 元の本文の抜粋 %q", got, want)
+	}
+	if got := estimateReadingMinutes(content); got != 2 {
+		t.Errorf("estimateReadingMinutes() = %d, want 2（video の代替テキストを含めない）", got)
+	}
+}
+
+func TestItemService_GetItem_TextOnly(t *testing.T) {
+	now := time.Now()
+	repo := newMockItemRepoForService()
+	repo.findByIDFn = func(ctx context.Context, id string) (*model.Item, error) {
+		return &model.Item{
+			ID:                 "item-1",
+			FeedID:             "feed-1",
+			Content:            `<p>本文<img src="https://example.com/a.png"></p><p><a href="https://example.com">リンク</a></p>`,
+			Summary:            `<p><img src="https://example.com/b.png">概要</p>`,
+			PublishedAt:        &now,
+			ReadingTimeMinutes: 1,
+		}, nil
+	}
+	svc := NewItemService(repo, newMockItemStateRepoForService())
+
+	t.Run("textOnlyがtrueのとき本文と要約から画像を除去しリンク属性は付与するとき", func(t *testing.T) {
+		// Act
+		detail, err := svc.GetItem(context.Background(), "user-123", "item-1", true)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("GetItem returned error: %v", err)
+		}
+		if strings.Contains(detail.Content, "<img") || strings.Contains(detail.Summary, "<img") {
+			t.Errorf("Content = %q, Summary = %q, want 画像を除去した本文", detail.Content, detail.Summary)
+		}
+		if !strings.Contains(detail.Content, `rel="noopener noreferrer"`) {
+			t.Errorf("Content = %q, want rel 属性を付与したリンク", detail.Content)
+		}
+		if detail.ReadingTimeMinutes != 1 {
+			t.Errorf("ReadingTimeMinutes = %d, want 1", detail.ReadingTimeMinutes)
+		}
+	})
+
+	t.Run("textOnlyがfalseのとき画像を残すとき", func(t *testing.T) {
+		// Act
+		detail, err := svc.GetItem(context.Background(), "user-123", "item-1", false)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("GetItem returned error: %v", err)
+		}
+		if !strings.Contains(detail.Content, "<img") {
+			t.Errorf("Content = %q, want 画像を含む本文", detail.Content)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package item
+
+import (
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/security"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// thumbnailURLOf は記事のサムネイル画像の URL を決める。
+// フィードが指定した画像（media:thumbnail 等）を優先し、無いか安全でない場合はサニタイズ後の本文 body の
+// 最初の img を候補にする。候補はいずれも security.SafeImageURL で検証し、相対 URL は base で絶対化する。
+// 安全な候補が無い場合は空文字列を返す。
+func thumbnailURLOf(parsed model.ParsedItem, base, body string) string {
+	if u, ok := security.SafeImageURL(parsed.ThumbnailURL, base); ok {
+		return u
+	}
+	if u, ok := security.SafeImageURL(firstImageSrc(body), base); ok {
+		return u
+	}
+	return ""
+}
+
+// firstImageSrc は HTML 中で最初に現れる img の src を返す。無い場合は空文字列。
+// 文字参照（&amp; 等）はトークナイザがデコードする。
+func firstImageSrc(content string) string {
+	if !strings.Contains(content, "<img") {
+		return ""
+	}
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if tok.DataAtom != atom.Img {
+				continue
+			}
+			for _, a := range tok.Attr {
+				if a.Namespace == "" && a.Key == "src" && strings.TrimSpace(a.Val) != "" {
+					return a.Val
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package item
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestThumbnailURLOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		parsed model.ParsedItem
+		base   string
+		body   string
+		want   string
+	}{
+		{
+			name:   "フィードが画像を指定しているときその画像を使う",
+			parsed: model.ParsedItem{ThumbnailURL: "https://example.com/thumb.jpg"},
+			body:   `<p><img src="https://example.com/body.jpg"></p>`,
+			want:   "https://example.com/thumb.jpg",
+		},
+		{
+			name:   "フィードの画像が相対URLのとき基準URLで絶対化する",
+			parsed: model.ParsedItem{ThumbnailURL: "/thumb.jpg"},
+			base:   "https://example.com/posts/1",
+			want:   "https://example.com/thumb.jpg",
+		},
+		{
+			name: "フィードの画像が無いとき本文の最初のimgを使う",
+			body: `<p>本文</p><img alt="a" src="https://example.com/first.jpg?a=1&amp;b=2"><img src="https://example.com/second.jpg">`,
+			want: "https://example.com/first.jpg?a=1&b=2",
+		},
+		{
+			name:   "フィードの画像が安全でないとき本文の画像にフォールバックする",
+			parsed: model.ParsedItem{ThumbnailURL: "https://169.254.169.254/latest/meta-data"},
+			body:   `<img src="https://example.com/body.jpg">`,
+			want:   "https://example.com/body.jpg",
+		},
+		{
+			name:   "候補がいずれも安全でないとき空文字列を返す",
+			parsed: model.ParsedItem{ThumbnailURL: "http://example.com/thumb.jpg"},
+			body:   `<img src="https://localhost/body.jpg">`,
+			want:   "",
+		},
+		{
+			name: "画像が無いとき空文字列を返す",
+			body: `<p>本文のみ</p>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := thumbnailURLOf(tt.parsed, tt.base, tt.body)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("thumbnailURLOf = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertItems_ThumbnailURL(t *testing.T) {
+	t.Run("新規記事のときフィードの画像をサムネイルとして保存する", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{
+			GuidOrID:     "thumb-guid-1",
+			Title:        "記事",
+			Link:         "https://example.com/posts/1",
+			Content:      "<p>本文</p>",
+			ThumbnailURL: "/images/1.jpg",
+		}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastCreatedItem == nil {
+			t.Fatal("lastCreatedItem should not be nil")
+		}
+		if got, want := repo.lastCreatedItem.ThumbnailURL, "https://example.com/images/1.jpg"; got != want {
+			t.Errorf("ThumbnailURL = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("既存記事の更新で画像が無くなったときサムネイルを空にする", func(t *testing.T) {
+		// Arrange
+		repo := newMockItemRepo()
+		repo.addExistingItem(&model.Item{ID: "existing-thumb", FeedID: "feed-1", GuidOrID: "thumb-guid-2", ThumbnailURL: "https://example.com/old.jpg"})
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+		parsed := []model.ParsedItem{{GuidOrID: "thumb-guid-2", Title: "画像なし", Content: "<p>本文</p>"}}
+
+		// Act
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsed); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		// Assert
+		if repo.lastUpdatedItem == nil {
+			t.Fatal("lastUpdatedItem should not be nil")
+		}
+		if got := repo.lastUpdatedItem.ThumbnailURL; got != "" {
+			t.Errorf("ThumbnailURL = %q, want empty", got)
+		}
+	})
+}
@@ -6,6 +6,9 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +24,8 @@ type ItemUpsertService struct {
 	itemRepo  repository.ItemRepository
 	sanitizer security.ContentSanitizerService
 	metrics   metrics.MetricsCollector
+	// sanitizeConcurrency は記事のサニタイズを並列に行うワーカー数の上限（0 以下は GOMAXPROCS）。
+	sanitizeConcurrency int
 }
 
 // UpsertOption は NewItemUpsertService の任意設定を表す functional option。
@@ -34,6 +39,15 @@ func WithMetrics(c metrics.MetricsCollector) UpsertOption {
 	}
 }
 
+// WithSanitizeConcurrency は記事のサニタイズを並列に行うワーカー数の上限を設定する。
+// 未指定時（または 0 以下）は GOMAXPROCS を上限とし、1 を指定すると逐次処理になる。
+// 注入するサニタイザは複数のゴルーチンから同時に呼び出されるため、スレッドセーフであること。
+func WithSanitizeConcurrency(n int) UpsertOption {
+	return func(s *ItemUpsertService) {
+		s.sanitizeConcurrency = n
+	}
+}
+
 // NewItemUpsertService はItemUpsertServiceの新しいインスタンスを生成する。
 // 既存の 2 引数 call site との後方互換のため、メトリクスコレクタは末尾の可変長
 // functional option（WithMetrics）として受け取る。opts 未指定時は no-op コレクタを既定値とする。
@@ -59,14 +73,33 @@ type preparedItem struct {
 	sanitizedContent string
 	sanitizedSummary string
 	contentHash      string
+	// contentText はサニタイズ後の本文（本文が空ならサマリー）からタグを除去したプレーンテキスト。
+	contentText string
+	// thumbnailURL は検証済みのサムネイル画像の URL（無い場合は空文字列）。
+	thumbnailURL string
+	// readingMinutes はサニタイズ後の本文（本文が空ならサマリー）から推定した読了時間（分）。
+	readingMinutes int
+	// seriesKey はタイトルから検出した連載のキー（連載ではない場合は空文字列）。
+	seriesKey string
+	// position はフィード内での記事の出現位置（0 始まり、先頭ほど新しい想定）。
+	// published_at を推定する際に記事の並び順を保つためのオフセットに使う。
+	position int
 }
 
+// estimatedDateStep は published_at 推定時に記事 1 件ごとにずらす時間幅。
+// フィード先頭の記事を fetched_at とし、以降の記事は position × estimatedDateStep だけ過去にする。
+const estimatedDateStep = time.Second
+
 // UpsertItems はフィードから取得した記事をUPSERTする。
 // 3段階の同一性判定ロジック:
 //  1. (feed_id, guid_or_id) - 最優先
 //  2. (feed_id, link) - 第2優先
 //  3. hash(title + published + summary) - 第3優先
 //
+// GUID で一致せず link / content_hash で既存記事に一致した記事は、フィードが GUID の形式を
+// 変えたものとみなして既存記事の GUID を新しい GUID に付け替える（マージ）。記事 ID を保つため、
+// 既読・スター等の item_states はそのまま残る。別の記事を誤って上書きしない条件は matchAllExisting を参照。
+//
 // 記事件数に比例した DB 往復を避けるため、既存記事の一括取得 → Go 側での同一性判定 →
 // 新規一括 INSERT・既存一括 UPDATE を単一トランザクションで実行する。
 // 永続化中にエラーが発生した場合はバッチ全件をロールバックし、(0, 0, err) を返す。
@@ -101,11 +134,15 @@ func (s *ItemUpsertService) UpsertItems(
 	}
 
 	// Go 側で 3 段階優先順位判定を行い、新規/更新を仕分けする。
+	matches := matchAllExisting(existing, deduped)
 	var toCreate []*model.Item
 	var toUpdate []*model.Item
-	for _, p := range deduped {
-		match := matchExisting(existing, p)
-		if match != nil {
+	relinked := 0
+	for i, p := range deduped {
+		if match := matches[i]; match != nil {
+			if match.GuidOrID != "" && p.parsed.GuidOrID != "" && match.GuidOrID != p.parsed.GuidOrID {
+				relinked++
+			}
 			toUpdate = append(toUpdate, buildUpdatedItem(match, p, now))
 		} else {
 			toCreate = append(toCreate, buildNewItem(feedID, p, now))
@@ -134,29 +171,95 @@ func (s *ItemUpsertService) UpsertItems(
 		"feed_id", feedID,
 		"inserted", inserted,
 		"updated", updated,
+		"guid_relinked", relinked,
 	)
 
 	return inserted, updated, nil
 }
 
-// prepareItems は各記事のコンテンツ・サマリーをサニタイズし content_hash を計算する。
+// minParallelPrepareItems は記事の前処理を並列化する最小の記事数。
+// これ未満ではゴルーチンの起動コストの方が大きいため逐次処理する。
+const minParallelPrepareItems = 8
+
+// prepareItems は各記事のサニタイズと content_hash 等の計算を行い、記事と同じ並びで返す。
+// サニタイズは大量記事のフィードで CPU のボトルネックになるため、記事単位で
+// sanitizeConcurrency（既定は GOMAXPROCS）を上限とするワーカーで並列に処理する。
+// 各ワーカーは担当する index の要素だけを書き込むため、結果の並びは逐次処理と変わらない。
 func (s *ItemUpsertService) prepareItems(items []model.ParsedItem) []preparedItem {
-	prepared := make([]preparedItem, 0, len(items))
-	for _, parsed := range items {
-		sanitizedContent := s.sanitizer.Sanitize(parsed.Content)
-		sanitizedSummary := s.sanitizer.Sanitize(parsed.Summary)
-		// content_hashはサニタイズ後のサマリーを使用する（現状アルゴリズム不変）。
-		contentHash := computeContentHash(parsed.Title, parsed.PublishedAt, sanitizedSummary)
-		prepared = append(prepared, preparedItem{
-			parsed:           parsed,
-			sanitizedContent: sanitizedContent,
-			sanitizedSummary: sanitizedSummary,
-			contentHash:      contentHash,
-		})
+	prepared := make([]preparedItem, len(items))
+
+	workers := s.sanitizeConcurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
 	}
+	workers = min(workers, len(items))
+	if workers <= 1 || len(items) < minParallelPrepareItems {
+		for i, parsed := range items {
+			prepared[i] = s.prepareItem(i, parsed)
+		}
+		return prepared
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				prepared[i] = s.prepareItem(i, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 	return prepared
 }
 
+// prepareItem は記事 1 件のコンテンツ・サマリーをサニタイズし content_hash とプレーンテキストを計算する。
+// 著者名は著者別フィルタ・集計のためにここで正規化し、連載のキーもタイトルから検出する。
+// サニタイザは相対 URL を除去するため、本文中の相対 URL はサニタイズ前に絶対化する。
+// 不正な UTF-8・BOM を含むフィードでも保存・JSON エンコードできるよう、最初にテキストを UTF-8 として正規化する。
+// position はフィード内での記事の出現位置。
+func (s *ItemUpsertService) prepareItem(position int, parsed model.ParsedItem) preparedItem {
+	parsed = normalizeParsedItem(parsed)
+	parsed.Author = normalizeAuthor(parsed.Author)
+	base := contentBaseURL(parsed)
+	sanitizedContent := s.sanitizer.Sanitize(security.ResolveRelativeURLs(parsed.Content, base))
+	sanitizedSummary := s.sanitizer.Sanitize(security.ResolveRelativeURLs(parsed.Summary, base))
+	// content_hashはサニタイズ後のサマリーを使用する（現状アルゴリズム不変）。
+	contentHash := computeContentHash(parsed.Title, parsed.PublishedAt, sanitizedSummary)
+	body := sanitizedContent
+	if body == "" {
+		body = sanitizedSummary
+	}
+	// 読了時間は切り詰め前のテキスト全体から推定する
+	text := htmlText(body)
+	return preparedItem{
+		parsed:           parsed,
+		sanitizedContent: sanitizedContent,
+		sanitizedSummary: sanitizedSummary,
+		contentHash:      contentHash,
+		contentText:      contentTextOf(text),
+		thumbnailURL:     thumbnailURLOf(parsed, base, body),
+		readingMinutes:   readingMinutesOfText(text),
+		seriesKey:        detectSeriesKey(parsed.Title),
+		position:         position,
+	}
+}
+
+// contentBaseURL は本文中の相対 URL を絶対化する基準 URL を返す。
+// 記事の link が http / https の絶対 URL ならそれを、そうでなければフィードの site_url（BaseURL）を使う。
+func contentBaseURL(p model.ParsedItem) string {
+	if u, err := url.Parse(p.Link); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return p.Link
+	}
+	return p.BaseURL
+}
+
 // identityKey は 3 段階の優先順位に沿って同一性判定の代表キーを返す。
 // guid_or_id > link > content_hash の順で最初に非空のキーを採用する。
 // いずれも空の場合は空キー（kind="" / value=""）を返し、dedup 対象外として扱う。
@@ -219,26 +322,53 @@ func collectIdentityKeys(items []preparedItem) (guids, links, hashes []string) {
 	return guids, links, hashes
 }
 
-// matchExisting は 3 段階の優先順位で既存記事を引き当てる。
-// 優先順位: (feed_id, guid_or_id) > (feed_id, link) > content_hash。
-// 一致しない場合は nil を返す（新規記事とみなす）。
-func matchExisting(existing *repository.ExistingItems, p preparedItem) *model.Item {
-	if p.parsed.GuidOrID != "" {
+// matchAllExisting はバッチ内の各記事に対応する既存記事を、記事と同じ並びで返す（一致しない記事は nil）。
+//
+// GUID で一致する記事を先に確定させ、残りの記事を link → content_hash の順で引き当てる。
+// link / content_hash による引き当ては GUID の付け替え（マージ）を伴うため、次の既存記事には引き当てない:
+//   - バッチ内の別の記事が既に引き当てた既存記事（1 件の既存記事を複数の記事で更新しない）
+//   - GUID がバッチ内の別の記事の GUID として現れている既存記事（フィードに載っている別の記事）
+//
+// また、バッチ内の複数の記事が同じ link を持つ場合、その link はトップページへのリンクなど
+// 記事を識別しないものとみなし、link による引き当てに使わない。
+func matchAllExisting(existing *repository.ExistingItems, items []preparedItem) []*model.Item {
+	matches := make([]*model.Item, len(items))
+	claimed := make(map[string]bool)
+	batchGUIDs := make(map[string]bool)
+	linkCounts := make(map[string]int)
+	for i, p := range items {
+		if p.parsed.Link != "" {
+			linkCounts[p.parsed.Link]++
+		}
+		if p.parsed.GuidOrID == "" {
+			continue
+		}
+		batchGUIDs[p.parsed.GuidOrID] = true
 		if item, ok := existing.ByGUID[p.parsed.GuidOrID]; ok {
-			return item
+			matches[i] = item
+			claimed[item.ID] = true
 		}
 	}
-	if p.parsed.Link != "" {
-		if item, ok := existing.ByLink[p.parsed.Link]; ok {
-			return item
-		}
+
+	available := func(item *model.Item) bool {
+		return !claimed[item.ID] && (item.GuidOrID == "" || !batchGUIDs[item.GuidOrID])
 	}
-	if p.contentHash != "" {
-		if item, ok := existing.ByContentHash[p.contentHash]; ok {
-			return item
+	for i, p := range items {
+		if matches[i] != nil {
+			continue
+		}
+		var match *model.Item
+		if item, ok := existing.ByLink[p.parsed.Link]; ok && p.parsed.Link != "" && linkCounts[p.parsed.Link] == 1 && available(item) {
+			match = item
+		} else if item, ok := existing.ByContentHash[p.contentHash]; ok && p.contentHash != "" && available(item) {
+			match = item
+		}
+		if match != nil {
+			matches[i] = match
+			claimed[match.ID] = true
 		}
 	}
-	return nil
+	return matches
 }
 
 // buildUpdatedItem は既存記事に新しい内容を反映した更新後の記事を構築する。
@@ -250,8 +380,12 @@ func buildUpdatedItem(existing *model.Item, p preparedItem, now time.Time) *mode
 	updated.Link = p.parsed.Link
 	updated.Content = p.sanitizedContent
 	updated.Summary = p.sanitizedSummary
+	updated.ContentText = p.contentText
+	updated.ThumbnailURL = p.thumbnailURL
 	updated.Author = p.parsed.Author
 	updated.ContentHash = p.contentHash
+	updated.ReadingTimeMinutes = p.readingMinutes
+	updated.SeriesKey = p.seriesKey
 	updated.UpdatedAt = now
 
 	// published_atの設定。parsed.PublishedAtがnilの場合は既存の値を維持する。
@@ -265,28 +399,35 @@ func buildUpdatedItem(existing *model.Item, p preparedItem, now time.Time) *mode
 
 // buildNewItem は新規記事を構築する。
 // published_at未設定の場合はfetched_atを代用し、推定フラグを付与する。
+// 日付のないフィードで全記事が同時刻になり並び順が崩れないよう、フィード内の出現位置に応じて
+// 1 秒ずつ過去にずらす（先頭の記事が最も新しくなる）。
 func buildNewItem(feedID string, p preparedItem, now time.Time) *model.Item {
 	item := &model.Item{
-		ID:          uuid.New().String(),
-		FeedID:      feedID,
-		GuidOrID:    p.parsed.GuidOrID,
-		Title:       p.parsed.Title,
-		Link:        p.parsed.Link,
-		Content:     p.sanitizedContent,
-		Summary:     p.sanitizedSummary,
-		Author:      p.parsed.Author,
-		ContentHash: p.contentHash,
-		FetchedAt:   now,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:                 uuid.New().String(),
+		FeedID:             feedID,
+		GuidOrID:           p.parsed.GuidOrID,
+		Title:              p.parsed.Title,
+		Link:               p.parsed.Link,
+		Content:            p.sanitizedContent,
+		Summary:            p.sanitizedSummary,
+		ContentText:        p.contentText,
+		ThumbnailURL:       p.thumbnailURL,
+		Author:             p.parsed.Author,
+		ContentHash:        p.contentHash,
+		FetchedAt:          now,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		ReadingTimeMinutes: p.readingMinutes,
+		SeriesKey:          p.seriesKey,
 	}
 
-	// published_atの設定: 未設定の場合はfetched_atを代用し推定フラグを付与する。
+	// published_atの設定: 未設定の場合はfetched_atから位置分ずらした値を代用し推定フラグを付与する。
 	if p.parsed.PublishedAt != nil {
 		item.PublishedAt = p.parsed.PublishedAt
 		item.IsDateEstimated = false
 	} else {
-		item.PublishedAt = &now
+		estimated := now.Add(-time.Duration(p.position) * estimatedDateStep)
+		item.PublishedAt = &estimated
 		item.IsDateEstimated = true
 	}
 
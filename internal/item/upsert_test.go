@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
 	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
 )
 
 // --- テスト用モック ---
@@ -55,14 +59,18 @@ func (m *mockItemRepo) FindByID(_ context.Context, id string) (*model.Item, erro
 	return item, nil
 }
 
-func (m *mockItemRepo) ListByFeed(_ context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error) {
+func (m *mockItemRepo) ListByFeed(_ context.Context, feedID, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
+	return nil, nil
+}
+
+func (m *mockItemRepo) ListByFeeds(_ context.Context, feedIDs []string, userID string, conds model.ItemConditions, author string, cursor time.Time, cursorID string, limit int) ([]model.ItemWithState, error) {
 	return nil, nil
 }
 
 // ListStarredByUser はインターフェース充足のための最小スタブ。
 // 本 task では Repository 層の実装と DB 結合テストのみがスコープであり、
 // service 層への組み込みは task 2 で行うため、サービス層テストでは未使用。
-func (m *mockItemRepo) ListStarredByUser(_ context.Context, _ string, _ time.Time, _ int) ([]repository.StarredItemRow, error) {
+func (m *mockItemRepo) ListStarredByUser(_ context.Context, _ string, _ int, _ time.Time, _ string, _ int, _ model.StarredItemFilter) ([]repository.StarredItemRow, error) {
 	return nil, nil
 }
 
@@ -213,12 +221,13 @@ func (m *mockItemRepo) addExistingItem(item *model.Item) {
 }
 
 // mockSanitizer はテスト用のContentSanitizerServiceモック。
+// 記事の前処理は並列に行われるため、呼び出し回数はアトミックに数える。
 type mockSanitizer struct {
-	sanitizeCalls int
+	sanitizeCalls atomic.Int32
 }
 
 func (m *mockSanitizer) Sanitize(rawHTML string) string {
-	m.sanitizeCalls++
+	m.sanitizeCalls.Add(1)
 	// テスト用: [sanitized] プレフィックスを付与して呼び出しを検証可能にする
 	if rawHTML == "" {
 		return ""
@@ -562,6 +571,55 @@ func TestUpsertItems_NewItem_PublishedAtMissing_UsesFetchedAt(t *testing.T) {
 	}
 }
 
+// TestUpsertItems_NewItem_PublishedAtMissing_PreservesFeedOrder は日付のない記事が複数あるとき、
+// 推定 published_at がフィード内の出現順（先頭が最新）を保つことをテストする。
+func TestUpsertItems_NewItem_PublishedAtMissing_PreservesFeedOrder(t *testing.T) {
+	// Arrange
+	repo := newMockItemRepo()
+	svc := NewItemUpsertService(repo, &mockSanitizer{})
+	explicit := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	parsedItems := []model.ParsedItem{
+		{GuidOrID: "guid-0", Title: "先頭", Link: "https://example.com/0"},
+		{GuidOrID: "guid-1", Title: "日付あり", Link: "https://example.com/1", PublishedAt: &explicit},
+		{GuidOrID: "guid-2", Title: "3番目", Link: "https://example.com/2"},
+		{GuidOrID: "guid-3", Title: "末尾", Link: "https://example.com/3"},
+	}
+
+	// Act
+	inserted, _, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("UpsertItems returned error: %v", err)
+	}
+	if inserted != 4 {
+		t.Fatalf("inserted = %d, want 4", inserted)
+	}
+	created := make(map[string]*model.Item, len(repo.lastBulkCreated))
+	for _, it := range repo.lastBulkCreated {
+		created[it.GuidOrID] = it
+	}
+
+	first, third, last := created["guid-0"], created["guid-2"], created["guid-3"]
+	if !first.PublishedAt.Equal(first.FetchedAt) {
+		t.Errorf("先頭記事の published_at(%v) は fetched_at(%v) と等しいべき", first.PublishedAt, first.FetchedAt)
+	}
+	if got := first.PublishedAt.Sub(*third.PublishedAt); got != 2*time.Second {
+		t.Errorf("先頭と3番目の差 = %v, want 2s（出現位置 × 1秒）", got)
+	}
+	if !third.PublishedAt.After(*last.PublishedAt) {
+		t.Errorf("3番目(%v)は末尾(%v)より新しいべき", third.PublishedAt, last.PublishedAt)
+	}
+	for _, it := range []*model.Item{first, third, last} {
+		if !it.IsDateEstimated {
+			t.Errorf("%s: IsDateEstimated should be true", it.GuidOrID)
+		}
+	}
+	if got := created["guid-1"]; !got.PublishedAt.Equal(explicit) || got.IsDateEstimated {
+		t.Errorf("日付ありの記事は明示値を保つべき: %v (estimated=%v)", got.PublishedAt, got.IsDateEstimated)
+	}
+}
+
 // --- サニタイズテスト ---
 
 // TestUpsertItems_ContentIsSanitized は記事コンテンツにサニタイズが適用されることをテストする。
@@ -586,8 +644,8 @@ func TestUpsertItems_ContentIsSanitized(t *testing.T) {
 		t.Fatalf("UpsertItems returned error: %v", err)
 	}
 
-	if sanitizer.sanitizeCalls < 2 {
-		t.Errorf("sanitize should be called for content and summary, got %d calls", sanitizer.sanitizeCalls)
+	if calls := sanitizer.sanitizeCalls.Load(); calls < 2 {
+		t.Errorf("sanitize should be called for content and summary, got %d calls", calls)
 	}
 
 	created := repo.lastCreatedItem
@@ -603,6 +661,88 @@ func TestUpsertItems_ContentIsSanitized(t *testing.T) {
 	}
 }
 
+// TestUpsertItems_AuthorIsNormalized は著者名が正規化されて保存されることをテストする。
+func TestUpsertItems_AuthorIsNormalized(t *testing.T) {
+	repo := newMockItemRepo()
+	svc := NewItemUpsertService(repo, &mockSanitizer{})
+
+	parsedItems := []model.ParsedItem{
+		{
+			GuidOrID: "author-test",
+			Title:    "著者テスト",
+			Link:     "https://example.com/author",
+			Author:   "  ｊｏｈｎ@example.com   (John  Doe)\n",
+		},
+	}
+
+	_, _, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems)
+	if err != nil {
+		t.Fatalf("UpsertItems returned error: %v", err)
+	}
+
+	created := repo.lastCreatedItem
+	if created == nil {
+		t.Fatal("lastCreatedItem should not be nil")
+	}
+	if created.Author != "John Doe" {
+		t.Errorf("author = %q, want %q", created.Author, "John Doe")
+	}
+}
+
+// TestUpsertItems_RelativeURLsAreResolved は本文中の相対 URL がサニタイズ前に絶対化されることをテストする。
+func TestUpsertItems_RelativeURLsAreResolved(t *testing.T) {
+	t.Run("記事のlinkが絶対URLのときlinkを基準に絶対化する", func(t *testing.T) {
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+
+		parsedItems := []model.ParsedItem{
+			{
+				GuidOrID: "relative-link",
+				Title:    "相対URL",
+				Link:     "https://blog.example.com/posts/1",
+				Content:  `<img src="img/a.png">`,
+				Summary:  `<a href="/about">about</a>`,
+				BaseURL:  "https://example.com/",
+			},
+		}
+
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		created := repo.lastCreatedItem
+		if want := `[sanitized]<img src="https://blog.example.com/posts/img/a.png">`; created.Content != want {
+			t.Errorf("content = %q, want %q", created.Content, want)
+		}
+		if want := `[sanitized]<a href="https://blog.example.com/about">about</a>`; created.Summary != want {
+			t.Errorf("summary = %q, want %q", created.Summary, want)
+		}
+	})
+
+	t.Run("記事のlinkが相対URLのときフィードのsite_urlを基準に絶対化する", func(t *testing.T) {
+		repo := newMockItemRepo()
+		svc := NewItemUpsertService(repo, &mockSanitizer{})
+
+		parsedItems := []model.ParsedItem{
+			{
+				GuidOrID: "relative-site",
+				Title:    "相対URL",
+				Link:     "/posts/2",
+				Content:  `<img src="/img/b.png">`,
+				BaseURL:  "https://example.com/",
+			},
+		}
+
+		if _, _, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems); err != nil {
+			t.Fatalf("UpsertItems returned error: %v", err)
+		}
+
+		if want := `[sanitized]<img src="https://example.com/img/b.png">`; repo.lastCreatedItem.Content != want {
+			t.Errorf("content = %q, want %q", repo.lastCreatedItem.Content, want)
+		}
+	})
+}
+
 // TestUpsertItems_EmptyContentNotSanitized は空コンテンツがサニタイズされないことをテストする。
 func TestUpsertItems_EmptyContentNotSanitized(t *testing.T) {
 	repo := newMockItemRepo()
@@ -1045,6 +1185,162 @@ func TestUpsertItems_GUIDAndLinkNotFound_FallbackToHash(t *testing.T) {
 	}
 }
 
+// --- GUID 変更の検出と既存記事へのマージのテスト ---
+
+// TestUpsertItems_GUIDChanged_RelinksExistingByLink はGUIDの形式が変わった記事をlinkで既存記事に
+// 引き当て、既存記事のIDを保ったままGUIDを付け替えることをテストする。
+func TestUpsertItems_GUIDChanged_RelinksExistingByLink(t *testing.T) {
+	repo := newMockItemRepo()
+	sanitizer := &mockSanitizer{}
+
+	repo.addExistingItem(&model.Item{
+		ID:       "existing-item",
+		FeedID:   "feed-1",
+		GuidOrID: "https://example.com/?p=123",
+		Link:     "https://example.com/2026/07/article",
+		Title:    "記事",
+	})
+
+	svc := NewItemUpsertService(repo, sanitizer)
+
+	parsedItems := []model.ParsedItem{
+		{
+			GuidOrID: "tag:example.com,2026:article-123", // GUID の形式が変わった
+			Link:     "https://example.com/2026/07/article",
+			Title:    "記事",
+		},
+	}
+
+	inserted, updated, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems)
+	if err != nil {
+		t.Fatalf("UpsertItems returned error: %v", err)
+	}
+	if inserted != 0 || updated != 1 {
+		t.Fatalf("(inserted, updated) = (%d, %d), want (0, 1)", inserted, updated)
+	}
+	if repo.lastUpdatedItem.ID != "existing-item" {
+		t.Errorf("更新対象 id = %q, want %q（既存記事の id を保つ）", repo.lastUpdatedItem.ID, "existing-item")
+	}
+	if repo.lastUpdatedItem.GuidOrID != "tag:example.com,2026:article-123" {
+		t.Errorf("GuidOrID = %q, want 新しい GUID に付け替え", repo.lastUpdatedItem.GuidOrID)
+	}
+}
+
+// TestUpsertItems_GUIDChanged_RelinksExistingByContentHash はGUIDとlinkが変わった記事を
+// content_hashで既存記事に引き当てGUIDを付け替えることをテストする。
+func TestUpsertItems_GUIDChanged_RelinksExistingByContentHash(t *testing.T) {
+	repo := newMockItemRepo()
+	sanitizer := &mockSanitizer{}
+
+	pubTime := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	repo.addExistingItem(&model.Item{
+		ID:          "existing-item",
+		FeedID:      "feed-1",
+		GuidOrID:    "old-guid",
+		Link:        "http://example.com/article",
+		Title:       "記事",
+		PublishedAt: &pubTime,
+		ContentHash: computeContentHash("記事", &pubTime, "[sanitized]概要"),
+	})
+
+	svc := NewItemUpsertService(repo, sanitizer)
+
+	parsedItems := []model.ParsedItem{
+		{
+			GuidOrID:    "new-guid",
+			Link:        "https://example.com/article",
+			Title:       "記事",
+			Summary:     "概要",
+			PublishedAt: &pubTime,
+		},
+	}
+
+	inserted, updated, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems)
+	if err != nil {
+		t.Fatalf("UpsertItems returned error: %v", err)
+	}
+	if inserted != 0 || updated != 1 {
+		t.Fatalf("(inserted, updated) = (%d, %d), want (0, 1)", inserted, updated)
+	}
+	if repo.lastUpdatedItem.ID != "existing-item" || repo.lastUpdatedItem.GuidOrID != "new-guid" {
+		t.Errorf("更新後 = (id %q, guid %q), want (existing-item, new-guid)", repo.lastUpdatedItem.ID, repo.lastUpdatedItem.GuidOrID)
+	}
+}
+
+// TestUpsertItems_SharedLink_NotMerged はバッチ内の複数の記事が同じlinkを持つ場合に、
+// そのlinkで既存記事に引き当てず新規記事として取り込むことをテストする。
+func TestUpsertItems_SharedLink_NotMerged(t *testing.T) {
+	repo := newMockItemRepo()
+	sanitizer := &mockSanitizer{}
+
+	repo.addExistingItem(&model.Item{
+		ID:       "existing-item",
+		FeedID:   "feed-1",
+		GuidOrID: "guid-1",
+		Link:     "https://example.com/",
+		Title:    "お知らせ1",
+	})
+
+	svc := NewItemUpsertService(repo, sanitizer)
+
+	// 全記事がトップページへリンクするフィード
+	parsedItems := []model.ParsedItem{
+		{GuidOrID: "guid-3", Link: "https://example.com/", Title: "お知らせ3"},
+		{GuidOrID: "guid-2", Link: "https://example.com/", Title: "お知らせ2"},
+	}
+
+	inserted, updated, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems)
+	if err != nil {
+		t.Fatalf("UpsertItems returned error: %v", err)
+	}
+	if inserted != 2 || updated != 0 {
+		t.Errorf("(inserted, updated) = (%d, %d), want (2, 0)", inserted, updated)
+	}
+	if got := repo.items["existing-item"]; got.Title != "お知らせ1" || got.GuidOrID != "guid-1" {
+		t.Errorf("既存記事は上書きされるべきではない: %+v", got)
+	}
+}
+
+// TestUpsertItems_ExistingGUIDInBatch_NotMerged は既存記事のGUIDがバッチ内に残っている場合に、
+// 別GUIDの記事をその既存記事に引き当てないことをテストする（1 件の既存記事を複数の記事で更新しない）。
+func TestUpsertItems_ExistingGUIDInBatch_NotMerged(t *testing.T) {
+	repo := newMockItemRepo()
+	sanitizer := &mockSanitizer{}
+
+	pubTime := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	repo.addExistingItem(&model.Item{
+		ID:          "existing-item",
+		FeedID:      "feed-1",
+		GuidOrID:    "guid-1",
+		Link:        "https://example.com/a",
+		Title:       "記事",
+		PublishedAt: &pubTime,
+		ContentHash: computeContentHash("記事", &pubTime, "[sanitized]概要"),
+	})
+
+	svc := NewItemUpsertService(repo, sanitizer)
+
+	// guid-2 は content_hash が既存記事と一致するが、既存記事の guid-1 もフィードに載っている
+	parsedItems := []model.ParsedItem{
+		{GuidOrID: "guid-2", Link: "https://example.com/b", Title: "記事", Summary: "概要", PublishedAt: &pubTime},
+		{GuidOrID: "guid-1", Link: "https://example.com/a", Title: "記事（改題）", Summary: "概要", PublishedAt: &pubTime},
+	}
+
+	inserted, updated, err := svc.UpsertItems(context.Background(), "feed-1", parsedItems)
+	if err != nil {
+		t.Fatalf("UpsertItems returned error: %v", err)
+	}
+	if inserted != 1 || updated != 1 {
+		t.Fatalf("(inserted, updated) = (%d, %d), want (1, 1)", inserted, updated)
+	}
+	if repo.lastUpdatedItem.ID != "existing-item" || repo.lastUpdatedItem.GuidOrID != "guid-1" {
+		t.Errorf("更新後 = (id %q, guid %q), want (existing-item, guid-1)", repo.lastUpdatedItem.ID, repo.lastUpdatedItem.GuidOrID)
+	}
+	if repo.lastCreatedItem.GuidOrID != "guid-2" {
+		t.Errorf("新規記事の GUID = %q, want %q", repo.lastCreatedItem.GuidOrID, "guid-2")
+	}
+}
+
 // --- 更新時のContentHashが更新されるテスト ---
 
 // TestUpsertItems_Update_ContentHashUpdated は更新時にContentHashが再計算されることをテストする。
@@ -1493,6 +1789,7 @@ type mockMetricsCollector struct {
 
 func (m *mockMetricsCollector) RecordFetchSuccess(_ string)        {}
 func (m *mockMetricsCollector) RecordFetchFailure(_, _ string)     {}
+func (m *mockMetricsCollector) RecordFetchErrorKind(_ string)      {}
 func (m *mockMetricsCollector) RecordParseFailure(_ string)        {}
 func (m *mockMetricsCollector) RecordHTTPStatus(_ int)             {}
 func (m *mockMetricsCollector) RecordFetchLatency(_ time.Duration) {}
@@ -1613,3 +1910,73 @@ func TestNewItemUpsertService_DefaultMetricsIsNopAndNilSafe(t *testing.T) {
 		t.Fatalf("option 未指定の UpsertItems がエラーを返した: %v", err)
 	}
 }
+
+// --- 並列サニタイズ ---
+
+// largeParsedItems は本文が数 KB の HTML の記事を n 件生成する（大量記事のフィードの想定）。
+func largeParsedItems(n int) []model.ParsedItem {
+	paragraph := `<p>本文の段落です。<a href="/posts/related" onclick="alert(1)">関連記事</a>と<strong>強調</strong>、` +
+		`<img src="https://example.com/img.png" alt="画像"><script>alert(1)</script></p>`
+	body := strings.Repeat(paragraph, 30)
+	items := make([]model.ParsedItem, n)
+	for i := range items {
+		published := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(i) * time.Hour)
+		items[i] = model.ParsedItem{
+			GuidOrID:    fmt.Sprintf("guid-%d", i),
+			Title:       fmt.Sprintf("記事 第%d回", i),
+			Link:        fmt.Sprintf("https://example.com/posts/%d", i),
+			Content:     body,
+			Summary:     paragraph,
+			Author:      " Author ",
+			PublishedAt: &published,
+		}
+	}
+	return items
+}
+
+// TestPrepareItems_ParallelMatchesSequential は並列に前処理した結果が逐次処理と同一（並びも同じ）であることを検証する。
+func TestPrepareItems_ParallelMatchesSequential(t *testing.T) {
+	// Arrange
+	items := largeParsedItems(50)
+	sanitizer := security.NewContentSanitizer()
+	sequential := NewItemUpsertService(newMockItemRepo(), sanitizer, WithSanitizeConcurrency(1))
+	parallel := NewItemUpsertService(newMockItemRepo(), sanitizer, WithSanitizeConcurrency(4))
+
+	// Act
+	want := sequential.prepareItems(items)
+	got := parallel.prepareItems(items)
+
+	// Assert
+	if !reflect.DeepEqual(got, want) {
+		t.Error("parallel prepareItems() differs from sequential result")
+	}
+	for i, p := range got {
+		if p.position != i {
+			t.Fatalf("prepared[%d].position = %d, want %d", i, p.position, i)
+		}
+	}
+}
+
+// BenchmarkPrepareItems は記事の前処理（サニタイズ等）の逐次処理と並列処理を比較する。
+//
+//	go test ./internal/item -run '^$' -bench PrepareItems -benchmem
+func BenchmarkPrepareItems(b *testing.B) {
+	items := largeParsedItems(200)
+	sanitizer := security.SharedContentSanitizer()
+	benchmarks := []struct {
+		name        string
+		concurrency int
+	}{
+		{name: "sequential", concurrency: 1},
+		{name: "gomaxprocs", concurrency: 0},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			svc := NewItemUpsertService(newMockItemRepo(), sanitizer, WithSanitizeConcurrency(bm.concurrency))
+			b.ReportAllocs()
+			for b.Loop() {
+				svc.prepareItems(items)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package item
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// ItemVisitService は元記事ページへの訪問（リダイレクト）と、それに伴う既読化・訪問記録を担うサービス。
+type ItemVisitService struct {
+	itemRepo  repository.ItemRepository
+	visitRepo repository.ItemVisitRepository
+	now       func() time.Time
+}
+
+// NewItemVisitService はItemVisitServiceの新しいインスタンスを生成する。
+func NewItemVisitService(itemRepo repository.ItemRepository, visitRepo repository.ItemVisitRepository) *ItemVisitService {
+	return &ItemVisitService{
+		itemRepo:  itemRepo,
+		visitRepo: visitRepo,
+		now:       time.Now,
+	}
+}
+
+// Visit は記事を既読にして最終訪問日時を記録し、リダイレクト先となる元記事の URL を返す。
+// 記事が存在しない場合は ITEM_NOT_FOUND、元記事のリンクが無いか http/https 以外の場合は
+// ITEM_LINK_UNAVAILABLE を返す（オープンリダイレクトや javascript: 等への遷移を防ぐ）。
+//
+// 訪問記録の保存に失敗してもリダイレクトは妨げず、ログに残して URL を返す。
+func (s *ItemVisitService) Visit(ctx context.Context, userID, itemID string) (string, error) {
+	item, err := s.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		return "", err
+	}
+	if item == nil {
+		return "", model.NewItemNotFoundError(itemID)
+	}
+	if !isVisitableLink(item.Link) {
+		return "", model.NewItemLinkUnavailableError(itemID)
+	}
+
+	if err := s.visitRepo.MarkVisited(ctx, userID, itemID, s.now()); err != nil {
+		slog.Warn("記事の訪問記録に失敗しました",
+			"user_id", userID,
+			"item_id", itemID,
+			"error", err,
+		)
+	}
+	return item.Link, nil
+}
+
+// isVisitableLink はリダイレクト先として許可する URL（ホスト付きの http/https 絶対 URL）かどうかを返す。
+func isVisitableLink(link string) bool {
+	if link == "" {
+		return false
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
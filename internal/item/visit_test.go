@@ -0,0 +1,144 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockItemVisitRepo はテスト用のItemVisitRepositoryモック。
+type mockItemVisitRepo struct {
+	markVisitedFn    func(ctx context.Context, userID, itemID string, visitedAt time.Time) error
+	markVisitedCalls int
+}
+
+func (m *mockItemVisitRepo) MarkVisited(ctx context.Context, userID, itemID string, visitedAt time.Time) error {
+	m.markVisitedCalls++
+	if m.markVisitedFn != nil {
+		return m.markVisitedFn(ctx, userID, itemID, visitedAt)
+	}
+	return nil
+}
+
+func TestItemVisitService_Visit(t *testing.T) {
+	fixedNow := time.Date(2026, 6, 13, 9, 0, 0, 0, time.UTC)
+
+	newService := func(item *model.Item, visitRepo *mockItemVisitRepo) *ItemVisitService {
+		itemRepo := newMockItemRepoForService()
+		itemRepo.findByIDFn = func(_ context.Context, _ string) (*model.Item, error) {
+			return item, nil
+		}
+		svc := NewItemVisitService(itemRepo, visitRepo)
+		svc.now = func() time.Time { return fixedNow }
+		return svc
+	}
+
+	t.Run("記事が存在するとき既読化と訪問記録を行い元記事のURLを返す", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotItemID string
+		var gotVisitedAt time.Time
+		visitRepo := &mockItemVisitRepo{
+			markVisitedFn: func(_ context.Context, userID, itemID string, visitedAt time.Time) error {
+				gotUserID, gotItemID, gotVisitedAt = userID, itemID, visitedAt
+				return nil
+			},
+		}
+		svc := newService(&model.Item{ID: "item-1", Link: "https://example.com/article"}, visitRepo)
+
+		// Act
+		link, err := svc.Visit(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if link != "https://example.com/article" {
+			t.Errorf("link = %q, want %q", link, "https://example.com/article")
+		}
+		if gotUserID != "user-1" || gotItemID != "item-1" {
+			t.Errorf("MarkVisited(%q, %q), want (user-1, item-1)", gotUserID, gotItemID)
+		}
+		if !gotVisitedAt.Equal(fixedNow) {
+			t.Errorf("visitedAt = %v, want %v", gotVisitedAt, fixedNow)
+		}
+	})
+
+	t.Run("記事が存在しないときITEM_NOT_FOUNDを返し訪問を記録しない", func(t *testing.T) {
+		// Arrange
+		visitRepo := &mockItemVisitRepo{}
+		svc := newService(nil, visitRepo)
+
+		// Act
+		_, err := svc.Visit(context.Background(), "user-1", "missing")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeItemNotFound {
+			t.Fatalf("err = %v, want ITEM_NOT_FOUND", err)
+		}
+		if visitRepo.markVisitedCalls != 0 {
+			t.Errorf("MarkVisited calls = %d, want 0", visitRepo.markVisitedCalls)
+		}
+	})
+
+	t.Run("リンクが無いまたはhttp/https以外のときITEM_LINK_UNAVAILABLEを返す", func(t *testing.T) {
+		for _, link := range []string{"", "javascript:alert(1)", "/relative/path", "//example.com/a", "ftp://example.com/a"} {
+			// Arrange
+			visitRepo := &mockItemVisitRepo{}
+			svc := newService(&model.Item{ID: "item-1", Link: link}, visitRepo)
+
+			// Act
+			_, err := svc.Visit(context.Background(), "user-1", "item-1")
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeItemLinkUnavailable {
+				t.Errorf("link=%q: err = %v, want ITEM_LINK_UNAVAILABLE", link, err)
+			}
+			if visitRepo.markVisitedCalls != 0 {
+				t.Errorf("link=%q: MarkVisited calls = %d, want 0", link, visitRepo.markVisitedCalls)
+			}
+		}
+	})
+
+	t.Run("訪問記録に失敗してもURLを返す", func(t *testing.T) {
+		// Arrange
+		visitRepo := &mockItemVisitRepo{
+			markVisitedFn: func(_ context.Context, _, _ string, _ time.Time) error {
+				return errors.New("db error")
+			},
+		}
+		svc := newService(&model.Item{ID: "item-1", Link: "https://example.com/article"}, visitRepo)
+
+		// Act
+		link, err := svc.Visit(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if link != "https://example.com/article" {
+			t.Errorf("link = %q, want %q", link, "https://example.com/article")
+		}
+	})
+
+	t.Run("記事の取得に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		itemRepo := newMockItemRepoForService()
+		itemRepo.findByIDFn = func(_ context.Context, _ string) (*model.Item, error) {
+			return nil, errors.New("db error")
+		}
+		svc := NewItemVisitService(itemRepo, &mockItemVisitRepo{})
+
+		// Act
+		_, err := svc.Visit(context.Background(), "user-1", "item-1")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
@@ -35,7 +36,7 @@ const (
 //   - キーワードの前後空白 trim と空入力判定（Req 1.5）
 //   - LIKE メタ文字（%, _, \）のエスケープ（Req 2.4）
 //   - feed_id 指定時の購読確認と未購読時の 403 への変換（Req 3.5）
-//   - cursor 形式 `<RFC3339Nano>|<uuid>` のパースと形式不正時の 400 への変換
+//   - 不透明カーソルの復元と形式不正時の 400 への変換
 //   - リポジトリへの limit+1 取得依頼、HasMore 判定、NextCursor 生成
 //
 // 認証チェックは行わない（handler 層の責務）。トランザクションは持たない
@@ -64,7 +65,7 @@ func NewSearchService(
 //
 // Items は published_at 降順、同 published_at では id 降順で整列済み。
 // HasMore は次ページの存在を示し、NextCursor は次ページ取得用のカーソル文字列
-// （`<RFC3339Nano>|<uuid>` 形式）。HasMore が false の場合や末尾項目の PublishedAt が
+// （pagination の不透明トークン）。HasMore が false の場合や末尾項目の PublishedAt が
 // ゼロ値の場合、NextCursor は空文字となる。
 type SearchResult struct {
 	Items      []ItemSearchSummary
@@ -199,7 +200,7 @@ func (s *SearchService) Search(
 	if hasMore && len(summaries) > 0 {
 		last := summaries[len(summaries)-1]
 		if !last.PublishedAt.IsZero() {
-			nextCursor = last.PublishedAt.UTC().Format(time.RFC3339Nano) + "|" + last.ID
+			nextCursor = pagination.Encode(searchCursorSort, pagination.Cursor{Time: last.PublishedAt, ID: last.ID})
 		}
 	}
 
@@ -210,12 +211,15 @@ func (s *SearchService) Search(
 	}, nil
 }
 
+// searchCursorSort は記事検索結果カーソルの並び順識別子（published_at DESC, id DESC）。
+const searchCursorSort = "item_search.published_at_desc.id_desc"
+
 // parseCursor は cursorStr を (publishedAt, id) のタプルに分解する。
 //
 // cursorStr が空文字の場合はゼロ値 + 空 ID を返す（リポジトリは zero value を
-// 「先頭ページ」として扱う）。空でない場合は `<RFC3339Nano>|<uuid>` 形式を期待し、
-// 区切り `|` が無い・複数ある・RFC3339Nano パース失敗・ID 部が空のいずれでも
-// NewInvalidSearchQueryError を返す。
+// 「先頭ページ」として扱う）。空でない場合は pagination の不透明カーソル
+// （移行期間中は旧形式の `<RFC3339Nano>|<uuid>` も可）を期待し、復元できない・
+// ID 部が空のいずれでも NewInvalidSearchQueryError を返す。
 //
 // UUID 部の厳密パースは行わない（リポジトリ層が SQL の `$5::uuid` cast で検証する）。
 // 本サービス層は「空でない」「タイムスタンプがパース可能」程度の sanity check に留め、
@@ -224,22 +228,14 @@ func parseCursor(cursorStr string) (time.Time, string, error) {
 	if cursorStr == "" {
 		return time.Time{}, "", nil
 	}
-	parts := strings.SplitN(cursorStr, "|", 2)
-	if len(parts) != 2 {
-		return time.Time{}, "", model.NewInvalidSearchQueryError("cursor の形式が不正です")
-	}
-	if strings.Contains(parts[1], "|") {
-		// `a|b|c` のように区切りが複数ある場合は不正
-		return time.Time{}, "", model.NewInvalidSearchQueryError("cursor の形式が不正です")
-	}
-	publishedAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	cursor, err := pagination.Decode(searchCursorSort, cursorStr)
 	if err != nil {
-		return time.Time{}, "", model.NewInvalidSearchQueryError("cursor のタイムスタンプが不正です")
+		return time.Time{}, "", model.NewInvalidSearchQueryError("cursor の形式が不正です")
 	}
-	if strings.TrimSpace(parts[1]) == "" {
+	if strings.TrimSpace(cursor.ID) == "" {
 		return time.Time{}, "", model.NewInvalidSearchQueryError("cursor の id が空です")
 	}
-	return publishedAt, parts[1], nil
+	return cursor.Time, cursor.ID, nil
 }
 
 // escapeLikePattern は ILIKE パターンの中身として安全に埋め込めるよう、
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/pagination"
 	"github.com/hitoshi/feedman/internal/repository"
 )
 
@@ -193,6 +194,23 @@ func TestParseCursor(t *testing.T) {
 	})
 
 	t.Run("valid -> parsed tuple", func(t *testing.T) {
+		ts, _ := time.Parse(time.RFC3339Nano, validTs)
+		gotTs, gotID, err := parseCursor(pagination.Encode(searchCursorSort, pagination.Cursor{Time: ts, ID: validUUID}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !gotTs.Equal(ts) {
+			t.Errorf("ts = %v, want %v", gotTs, ts)
+		}
+		if gotID != validUUID {
+			t.Errorf("id = %q, want %q", gotID, validUUID)
+		}
+	})
+
+	t.Run("legacy format -> parsed tuple", func(t *testing.T) {
+		if !time.Now().Before(pagination.LegacyFormatSunset) {
+			t.Skip("旧形式カーソルの受理期限を過ぎている")
+		}
 		gotTs, gotID, err := parseCursor(validTs + "|" + validUUID)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -217,6 +235,7 @@ func TestParseCursor(t *testing.T) {
 		{"empty id", validTs + "|"},
 		{"only whitespace id", validTs + "|   "},
 		{"completely bogus", "garbage"},
+		{"other sort", pagination.Encode("audit_logs.created_at_desc.id_desc", pagination.Cursor{Time: time.Now(), ID: validUUID})},
 	}
 	for _, tc := range invalidCases {
 		t.Run("invalid_"+tc.name, func(t *testing.T) {
@@ -587,18 +606,17 @@ func TestSearch_HasMoreTrue(t *testing.T) {
 	if got.NextCursor == "" {
 		t.Errorf("NextCursor is empty, want non-empty")
 	}
-	// NextCursor の形式: <RFC3339Nano>|<id>
-	parts := strings.SplitN(got.NextCursor, "|", 2)
-	if len(parts) != 2 {
-		t.Fatalf("NextCursor format invalid: %q", got.NextCursor)
+	// NextCursor は末尾ヒットの (published_at, id) を内包する
+	gotTs, gotID, err := parseCursor(got.NextCursor)
+	if err != nil {
+		t.Fatalf("NextCursor %q could not be parsed: %v", got.NextCursor, err)
 	}
-	wantTs := base.Add(-9 * time.Second).UTC().Format(time.RFC3339Nano)
-	if parts[0] != wantTs {
-		t.Errorf("NextCursor ts = %q, want %q", parts[0], wantTs)
+	if wantTs := base.Add(-9 * time.Second); !gotTs.Equal(wantTs) {
+		t.Errorf("NextCursor ts = %v, want %v", gotTs, wantTs)
 	}
 	wantID := "item-" + string(rune('a'+9))
-	if parts[1] != wantID {
-		t.Errorf("NextCursor id = %q, want %q", parts[1], wantID)
+	if gotID != wantID {
+		t.Errorf("NextCursor id = %q, want %q", gotID, wantID)
 	}
 }
 
@@ -0,0 +1,72 @@
+package keyword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/worker/periodic"
+)
+
+// DefaultComputeInterval は頻出キーワードの計算ジョブの実行間隔の既定値。
+const DefaultComputeInterval = 24 * time.Hour
+
+// ComputeJob は購読者のいるフィードごとに直近 model.FeedKeywordWindow の記事から頻出キーワードを計算し、
+// feed_keywords を置き換える worker ジョブ。
+type ComputeJob struct {
+	repo     repository.FeedKeywordRepository
+	logger   *slog.Logger
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewComputeJob は ComputeJob を生成する。interval が 0 以下の場合は DefaultComputeInterval を使う。
+func NewComputeJob(repo repository.FeedKeywordRepository, logger *slog.Logger, interval time.Duration) *ComputeJob {
+	if interval <= 0 {
+		interval = DefaultComputeInterval
+	}
+	return &ComputeJob{repo: repo, logger: logger, interval: interval, now: time.Now}
+}
+
+// RunOnce は購読者のいるすべてのフィードの頻出キーワードを計算し直す。
+// あるフィードの計算に失敗しても残りのフィードは計算し、失敗したフィードのエラーをまとめて返す（前回の結果はそのまま残る）。
+func (j *ComputeJob) RunOnce(ctx context.Context) error {
+	start := j.now()
+	feedIDs, err := j.repo.ListSubscribedFeedIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("購読フィードの取得に失敗: %w", err)
+	}
+
+	since := start.Add(-model.FeedKeywordWindow)
+	var errs []error
+	for _, feedID := range feedIDs {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		texts, err := j.repo.ListItemTextsSince(ctx, feedID, since)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("フィード %s の記事テキストの取得に失敗: %w", feedID, err))
+			continue
+		}
+		if err := j.repo.ReplaceFeedKeywords(ctx, feedID, topKeywords(texts, model.FeedKeywordLimit), start); err != nil {
+			errs = append(errs, fmt.Errorf("フィード %s の頻出キーワードの保存に失敗: %w", feedID, err))
+		}
+	}
+
+	j.logger.Info("頻出キーワードを計算しました",
+		slog.Int("feed_count", len(feedIDs)),
+		slog.Int("failed_count", len(errs)),
+		slog.Float64("duration_ms", float64(j.now().Sub(start).Milliseconds())),
+	)
+	return errors.Join(errs...)
+}
+
+// Start は起動直後と interval ごとに RunOnce を実行する。ctx がキャンセルされるまでブロックする。
+func (j *ComputeJob) Start(ctx context.Context) {
+	periodic.Run(ctx, j.logger, "頻出キーワードの計算", j.interval, j.RunOnce)
+}
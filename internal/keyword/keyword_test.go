@@ -0,0 +1,188 @@
+package keyword
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// replaceCall は ReplaceFeedKeywords の呼び出し 1 回分の引数。
+type replaceCall struct {
+	feedID     string
+	keywords   []model.FeedKeyword
+	computedAt time.Time
+}
+
+// mockKeywordRepo は repository.FeedKeywordRepository のモック実装。
+type mockKeywordRepo struct {
+	feedIDs    []string
+	texts      map[string][]string
+	textsErr   map[string]error
+	gotSince   time.Time
+	replaced   []replaceCall
+	summary    *model.FeedKeywordSummary
+	summaryErr error
+}
+
+func (m *mockKeywordRepo) ListSubscribedFeedIDs(context.Context) ([]string, error) {
+	return m.feedIDs, nil
+}
+
+func (m *mockKeywordRepo) ListItemTextsSince(_ context.Context, feedID string, since time.Time) ([]string, error) {
+	m.gotSince = since
+	return m.texts[feedID], m.textsErr[feedID]
+}
+
+func (m *mockKeywordRepo) ReplaceFeedKeywords(_ context.Context, feedID string, keywords []model.FeedKeyword, computedAt time.Time) error {
+	m.replaced = append(m.replaced, replaceCall{feedID, keywords, computedAt})
+	return nil
+}
+
+func (m *mockKeywordRepo) GetBySubscription(context.Context, string, string) (*model.FeedKeywordSummary, error) {
+	return m.summary, m.summaryErr
+}
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "英単語を小文字にして分割する", text: "Go Generics, RUST!", want: []string{"go", "generics", "rust"}},
+		{name: "1文字の語と数字のみの語とストップワードを除く", text: "a 2026 of the k8s", want: []string{"k8s"}},
+		{name: "全角英数字をNFKC正規化する", text: "ＧＯ言語", want: []string{"go", "言語"}},
+		{name: "漢字とカタカナの連続をbigramに分割する", text: "機械学習", want: []string{"機械", "械学", "学習"}},
+		{name: "ひらがなを区切りとして扱う", text: "データを分析する", want: []string{"デー", "ータ", "分析"}},
+		{name: "1文字の漢字は除く", text: "本の話", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokenize(tt.text)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("tokenize(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopKeywords(t *testing.T) {
+	texts := []string{
+		"Go のリリース\nGo 1.25 の新機能",
+		"Rust と Go の比較",
+		"リリースノート",
+	}
+
+	got := topKeywords(texts, 3)
+
+	want := []model.FeedKeyword{{Keyword: "go", Count: 3}, {Keyword: "リリ", Count: 2}, {Keyword: "リー", Count: 2}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("topKeywords() = %v, want %v", got, want)
+	}
+	for _, k := range got {
+		if k.Keyword == "rust" {
+			t.Error("出現回数が 1 回の語を含めてはならない")
+		}
+	}
+}
+
+func TestComputeJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+
+	t.Run("購読フィードごとに直近30日の記事から計算して置き換えるとき", func(t *testing.T) {
+		// Arrange
+		repo := &mockKeywordRepo{
+			feedIDs: []string{"feed-1", "feed-2"},
+			texts:   map[string][]string{"feed-1": {"Kubernetes 入門", "Kubernetes 運用"}},
+		}
+		job := NewComputeJob(repo, newTestLogger(), 0)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if !repo.gotSince.Equal(now.Add(-model.FeedKeywordWindow)) {
+			t.Errorf("since = %v, want %v", repo.gotSince, now.Add(-model.FeedKeywordWindow))
+		}
+		if len(repo.replaced) != 2 {
+			t.Fatalf("ReplaceFeedKeywords の呼び出し回数 = %d, want 2", len(repo.replaced))
+		}
+		first := repo.replaced[0]
+		if first.feedID != "feed-1" || len(first.keywords) != 1 || first.keywords[0] != (model.FeedKeyword{Keyword: "kubernetes", Count: 2}) || !first.computedAt.Equal(now) {
+			t.Errorf("replaced[0] = %+v", first)
+		}
+		if second := repo.replaced[1]; second.feedID != "feed-2" || len(second.keywords) != 0 {
+			t.Errorf("記事の無いフィードのキーワードは空で置き換える: %+v", second)
+		}
+		if job.interval != DefaultComputeInterval {
+			t.Errorf("interval = %v, want %v", job.interval, DefaultComputeInterval)
+		}
+	})
+
+	t.Run("一部のフィードの取得に失敗しても残りを計算しエラーを返すとき", func(t *testing.T) {
+		// Arrange
+		repo := &mockKeywordRepo{
+			feedIDs:  []string{"feed-1", "feed-2"},
+			textsErr: map[string]error{"feed-1": errors.New("db down")},
+		}
+		job := NewComputeJob(repo, newTestLogger(), time.Hour)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err == nil {
+			t.Fatal("RunOnce() error = nil, want error")
+		}
+		if len(repo.replaced) != 1 || repo.replaced[0].feedID != "feed-2" {
+			t.Errorf("replaced = %+v, want feed-2 のみ", repo.replaced)
+		}
+	})
+}
+
+func TestService_Get(t *testing.T) {
+	t.Run("未計算のとき空の一覧を返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockKeywordRepo{summary: &model.FeedKeywordSummary{FeedID: "feed-1"}})
+
+		// Act
+		got, err := svc.Get(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Keywords == nil || len(got.Keywords) != 0 || got.ComputedAt != nil {
+			t.Errorf("got = %+v", got)
+		}
+	})
+
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockKeywordRepo{})
+
+		// Act
+		_, err := svc.Get(context.Background(), "user-1", "sub-x")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeSubscriptionNotFound {
+			t.Errorf("err = %v, want %s", err, model.ErrCodeSubscriptionNotFound)
+		}
+	})
+}
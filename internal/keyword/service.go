@@ -0,0 +1,41 @@
+// Package keyword は購読フィードの記事傾向サマリー（頻出キーワード）を提供する。
+//
+// 頻出キーワードは worker の ComputeJob がフィードごとに直近 model.FeedKeywordWindow の記事の
+// タイトルと本文テキストから簡易なトークナイズ（日本語は bigram）で数え、上位 model.FeedKeywordLimit 件を
+// feed_keywords へ事前計算する。API（Service）は購読のフィードの計算結果を返すだけにする。
+package keyword
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service は記事傾向サマリーのサービス層。
+type Service struct {
+	repo repository.FeedKeywordRepository
+}
+
+// NewService は Service を生成する。
+func NewService(repo repository.FeedKeywordRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// Get は当該ユーザーの購読のフィードの頻出キーワードを出現回数の多い順に返す。
+// 未計算またはキーワードが無い場合は空の一覧を返す。
+// 対象購読が存在しない、または他ユーザーの購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) Get(ctx context.Context, userID, subscriptionID string) (*model.FeedKeywordSummary, error) {
+	summary, err := s.repo.GetBySubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("頻出キーワードの取得に失敗: %w", err)
+	}
+	if summary == nil {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	if summary.Keywords == nil {
+		summary.Keywords = []model.FeedKeyword{}
+	}
+	return summary, nil
+}
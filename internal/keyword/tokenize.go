@@ -0,0 +1,114 @@
+package keyword
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// minKeywordCount は頻出キーワードとして扱う最小の出現回数。1 回しか出現しない語は傾向とみなさない。
+const minKeywordCount = 2
+
+// stopWords は英語の機能語など、キーワードとして意味を持たない語。
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true, "not": true, "no": true,
+	"of": true, "in": true, "on": true, "at": true, "to": true, "for": true, "from": true, "by": true,
+	"with": true, "as": true, "into": true, "about": true, "than": true, "then": true, "so": true,
+	"is": true, "are": true, "was": true, "were": true, "be": true, "been": true, "being": true,
+	"am": true, "do": true, "does": true, "did": true, "have": true, "has": true, "had": true,
+	"will": true, "would": true, "can": true, "could": true, "should": true, "may": true, "might": true,
+	"it": true, "its": true, "this": true, "that": true, "these": true, "those": true, "there": true,
+	"i": true, "we": true, "you": true, "he": true, "she": true, "they": true, "me": true, "us": true,
+	"my": true, "our": true, "your": true, "his": true, "her": true, "their": true, "them": true,
+	"what": true, "which": true, "who": true, "how": true, "when": true, "where": true, "why": true,
+	"all": true, "any": true, "some": true, "more": true, "most": true, "also": true, "just": true,
+	"if": true, "up": true, "out": true, "new": true, "one": true, "via": true,
+	"http": true, "https": true, "www": true, "com": true,
+}
+
+// tokenize はテキストを語に分割する。
+//
+// テキストは NFKC 正規化して小文字に揃える。英数字の連続は 1 語とし、2 文字未満の語・数字のみの語・stopWords は除く。
+// 日本語は形態素解析を行わず、漢字・カタカナの連続を 2 文字ずつの bigram に分割する（1 文字の連続は除く）。
+// ひらがなは助詞・活用語尾が大半のため語の区切りとして扱う。
+func tokenize(text string) []string {
+	text = strings.ToLower(norm.NFKC.String(text))
+
+	var tokens []string
+	var word, cjk []rune
+	flushWord := func() {
+		if len(word) >= 2 && !isDigits(word) && !stopWords[string(word)] {
+			tokens = append(tokens, string(word))
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, string(cjk[i:i+2]))
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) && !unicode.Is(unicode.Hiragana, r), unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// isCJK は r が bigram に分割する文字（漢字・カタカナ・長音記号）かを返す。
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Katakana, r) || r == 'ー'
+}
+
+func isDigits(word []rune) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// topKeywords は texts 全体での語の出現回数を数え、出現回数の多い順（同数は語の順）に最大 limit 件を返す。
+// 出現回数が minKeywordCount 未満の語は含めない。
+func topKeywords(texts []string, limit int) []model.FeedKeyword {
+	counts := make(map[string]int)
+	for _, text := range texts {
+		for _, token := range tokenize(text) {
+			counts[token]++
+		}
+	}
+
+	keywords := make([]model.FeedKeyword, 0, len(counts))
+	for word, count := range counts {
+		if count >= minKeywordCount {
+			keywords = append(keywords, model.FeedKeyword{Keyword: word, Count: count})
+		}
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Count != keywords[j].Count {
+			return keywords[i].Count > keywords[j].Count
+		}
+		return keywords[i].Keyword < keywords[j].Keyword
+	})
+	if len(keywords) > limit {
+		keywords = keywords[:limit]
+	}
+	return keywords
+}
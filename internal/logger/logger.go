@@ -44,14 +44,18 @@ func resolveLevel(raw string) (level slog.Level, invalid bool) {
 // Setup はJSON構造化ログ出力のslog.Loggerを生成して返す。
 // 出力レベルは起動時に環境変数 LOG_LEVEL から1回だけ決定する
 // （未設定・空文字・不正値の場合は INFO へフォールバックする）。
-// 不正値が指定された場合は INFO で起動を継続しつつ、フォールバックを示す警告ログを
+// URL・タイトル系フィールドのマスキングは環境変数 LOG_PRIVACY_LEVEL から同様に決定する
+// （未設定・空文字・不正値の場合はマスキングなし）。
+// 不正値が指定された場合はデフォルトで起動を継続しつつ、フォールバックを示す警告ログを
 // 同じ writer へ出力する（サイレント失敗を回避する）。
 // writerが指定された場合はそのwriterに出力する。
 func Setup(w io.Writer) *slog.Logger {
 	level, invalid := resolveLevel(os.Getenv(envLogLevel))
+	privacyLevel, invalidPrivacy := resolvePrivacyLevel(os.Getenv(envLogPrivacyLevel))
 
 	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
-		Level: level,
+		Level:       level,
+		ReplaceAttr: privacyReplaceAttr(privacyLevel),
 	})
 	logger := slog.New(handler)
 
@@ -63,6 +67,13 @@ func Setup(w io.Writer) *slog.Logger {
 			slog.String("default", defaultLevel.String()),
 		)
 	}
+	if invalidPrivacy {
+		logger.Warn(invalidLevelWarnMsg,
+			slog.String("key", envLogPrivacyLevel),
+			slog.String("value", os.Getenv(envLogPrivacyLevel)),
+			slog.String("default", string(defaultPrivacyLevel)),
+		)
+	}
 
 	return logger
 }
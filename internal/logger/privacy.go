@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// envLogPrivacyLevel はログのプライバシーレベルを指定する環境変数名。
+const envLogPrivacyLevel = "LOG_PRIVACY_LEVEL"
+
+// PrivacyLevel はログ出力時に記事タイトルや URL をどこまで伏せるかを表す。
+type PrivacyLevel string
+
+const (
+	// PrivacyLevelNone はマスキングを行わない（本設定導入前と同じ出力）。
+	PrivacyLevelNone PrivacyLevel = "none"
+	// PrivacyLevelStandard は URL 系フィールドをハッシュ化し、タイトル系フィールドを省略する。
+	PrivacyLevelStandard PrivacyLevel = "standard"
+	// PrivacyLevelStrict は standard に加え、error 等の任意の文字列値に埋め込まれた URL もハッシュ化する。
+	PrivacyLevelStrict PrivacyLevel = "strict"
+)
+
+// defaultPrivacyLevel は LOG_PRIVACY_LEVEL が未設定・空文字・不正値のときに採用するレベル。
+// 既存の運用ログとの後方互換のためマスキングなしとする。
+const defaultPrivacyLevel = PrivacyLevelNone
+
+// omittedValue はタイトル系フィールドを省略した際に出力するプレースホルダ。
+const omittedValue = "[omitted]"
+
+// embeddedURLPattern は strict レベルで文字列値から URL を検出するパターン。
+// Go の HTTP クライアントのエラー（`Get "https://...": ...`）のように引用符で囲まれた URL も
+// 引用符の手前で区切れるよう、空白と引用符を URL の終端とみなす。
+var embeddedURLPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// resolvePrivacyLevel は環境変数 LOG_PRIVACY_LEVEL の値を PrivacyLevel に変換する。
+// 未設定・空文字の場合は defaultPrivacyLevel を返す。許容値（none / standard / strict）以外は
+// defaultPrivacyLevel を返し invalid に true をセットする。値の解釈は大文字小文字を区別しない。
+func resolvePrivacyLevel(raw string) (level PrivacyLevel, invalid bool) {
+	if raw == "" {
+		return defaultPrivacyLevel, false
+	}
+	switch PrivacyLevel(strings.ToLower(raw)) {
+	case PrivacyLevelNone:
+		return PrivacyLevelNone, false
+	case PrivacyLevelStandard:
+		return PrivacyLevelStandard, false
+	case PrivacyLevelStrict:
+		return PrivacyLevelStrict, false
+	default:
+		return defaultPrivacyLevel, true
+	}
+}
+
+// isURLKey は属性キーが URL を保持するフィールドかを判定する（url / link / *_url / *_link）。
+func isURLKey(key string) bool {
+	return key == "url" || key == "link" ||
+		strings.HasSuffix(key, "_url") || strings.HasSuffix(key, "_link")
+}
+
+// isTitleKey は属性キーが記事・フィードのタイトルを保持するフィールドかを判定する（title / *_title）。
+func isTitleKey(key string) bool {
+	return key == "title" || strings.HasSuffix(key, "_title")
+}
+
+// hashForLog は値をログ出力用の復元不能な短縮値に変換する。
+// 同じ URL は同じ値になるため、生の URL を残さずに同一フィードの追跡は可能なままにする。
+func hashForLog(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])[:16]
+}
+
+// privacyReplaceAttr は level に応じて属性値をマスキングする slog.HandlerOptions.ReplaceAttr を返す。
+// PrivacyLevelNone の場合は nil を返し、ハンドラの既定動作（無加工）とする。
+func privacyReplaceAttr(level PrivacyLevel) func(groups []string, a slog.Attr) slog.Attr {
+	if level == PrivacyLevelNone {
+		return nil
+	}
+	return func(groups []string, a slog.Attr) slog.Attr {
+		// time / level / msg 等の組み込み属性は対象外
+		if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+			return a
+		}
+		switch {
+		case isURLKey(a.Key):
+			if s := a.Value.String(); s != "" {
+				return slog.String(a.Key, hashForLog(s))
+			}
+			return a
+		case isTitleKey(a.Key):
+			return slog.String(a.Key, omittedValue)
+		}
+		if level == PrivacyLevelStrict && a.Value.Kind() == slog.KindString {
+			masked := embeddedURLPattern.ReplaceAllStringFunc(a.Value.String(), hashForLog)
+			return slog.String(a.Key, masked)
+		}
+		return a
+	}
+}
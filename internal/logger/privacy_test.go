@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// logWithPrivacy は LOG_PRIVACY_LEVEL を設定した Setup のロガーで 1 行出力し、パース結果を返す。
+func logWithPrivacy(t *testing.T, envValue string, args ...any) map[string]interface{} {
+	t.Helper()
+	t.Setenv("LOG_LEVEL", "")
+	t.Setenv(envLogPrivacyLevel, envValue)
+	var buf bytes.Buffer
+	l := Setup(&buf)
+
+	l.Info("fetch failed", args...)
+
+	entries := parseLogEntries(t, buf.String())
+	return entries[len(entries)-1]
+}
+
+func TestSetup_PrivacyLevel(t *testing.T) {
+	const feedURL = "https://example.com/feed.xml"
+	fetchErr := errors.New(`Get "https://example.com/feed.xml": dial tcp: timeout`)
+	args := []any{
+		slog.String("feed_url", feedURL),
+		slog.String("title", "秘密の記事"),
+		slog.String("feed_id", "feed-1"),
+		slog.String("error", fetchErr.Error()),
+	}
+
+	t.Run("未設定のときマスキングしない", func(t *testing.T) {
+		// Act
+		entry := logWithPrivacy(t, "", args...)
+
+		// Assert
+		if entry["feed_url"] != feedURL || entry["title"] != "秘密の記事" {
+			t.Errorf("entry = %v, want 無加工", entry)
+		}
+	})
+
+	t.Run("standardのときURLをハッシュ化しタイトルを省略する", func(t *testing.T) {
+		// Act
+		entry := logWithPrivacy(t, "standard", args...)
+
+		// Assert
+		if entry["feed_url"] != hashForLog(feedURL) {
+			t.Errorf("feed_url = %v, want %s", entry["feed_url"], hashForLog(feedURL))
+		}
+		if entry["title"] != omittedValue {
+			t.Errorf("title = %v, want %s", entry["title"], omittedValue)
+		}
+		if entry["feed_id"] != "feed-1" {
+			t.Errorf("feed_id = %v, want feed-1（対象外のフィールドは無加工）", entry["feed_id"])
+		}
+		if entry["error"] != fetchErr.Error() {
+			t.Errorf("error = %v, want 無加工（埋め込み URL のマスキングは strict のみ）", entry["error"])
+		}
+		if entry["msg"] != "fetch failed" {
+			t.Errorf("msg = %v, want fetch failed", entry["msg"])
+		}
+	})
+
+	t.Run("strictのとき文字列値に埋め込まれたURLもハッシュ化する", func(t *testing.T) {
+		// Act
+		entry := logWithPrivacy(t, "STRICT", args...)
+
+		// Assert
+		errStr, _ := entry["error"].(string)
+		if strings.Contains(errStr, "example.com") {
+			t.Errorf("error = %q, want URL がハッシュ化されている", errStr)
+		}
+		if !strings.Contains(errStr, hashForLog(feedURL)) {
+			t.Errorf("error = %q, want %s を含む", errStr, hashForLog(feedURL))
+		}
+		if entry["feed_url"] != hashForLog(feedURL) || entry["title"] != omittedValue {
+			t.Errorf("entry = %v, want standard 相当のマスキング", entry)
+		}
+	})
+
+	t.Run("グループ内のURL系フィールドもハッシュ化する", func(t *testing.T) {
+		// Act
+		entry := logWithPrivacy(t, "standard", slog.Group("req", slog.String("url", feedURL)))
+
+		// Assert
+		group, _ := entry["req"].(map[string]interface{})
+		if group["url"] != hashForLog(feedURL) {
+			t.Errorf("req.url = %v, want %s", group["url"], hashForLog(feedURL))
+		}
+	})
+
+	t.Run("不正値のときマスキングなしで起動し警告ログを出す", func(t *testing.T) {
+		// Arrange
+		t.Setenv("LOG_LEVEL", "")
+		t.Setenv(envLogPrivacyLevel, "paranoid")
+		var buf bytes.Buffer
+
+		// Act
+		l := Setup(&buf)
+		l.Info("fetch failed", slog.String("feed_url", feedURL))
+
+		// Assert
+		entries := parseLogEntries(t, buf.String())
+		if len(entries) != 2 {
+			t.Fatalf("entries = %d, want 2（警告 + 本ログ）", len(entries))
+		}
+		if entries[0]["msg"] != invalidLevelWarnMsg || entries[0]["key"] != envLogPrivacyLevel || entries[0]["default"] != "none" {
+			t.Errorf("warn entry = %v, want LOG_PRIVACY_LEVEL のフォールバック警告", entries[0])
+		}
+		if entries[1]["feed_url"] != feedURL {
+			t.Errorf("feed_url = %v, want 無加工", entries[1]["feed_url"])
+		}
+	})
+}
@@ -0,0 +1,115 @@
+// Package mail はメール送信の抽象と SMTP による実装を提供する。
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message は送信するメール。本文はプレーンテキスト（UTF-8）とする。
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender はメールの送信先。SMTPSender が実装する。
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPConfig は SMTP サーバーの接続設定。
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username が空の場合は SMTP 認証を行わない。
+	Username string
+	Password string
+	// From は送信元アドレス（"Feedman <noreply@example.com>" の形式も可）。
+	From string
+}
+
+// SMTPSender は SMTP サーバー経由でメールを送信する Sender。
+// サーバーが STARTTLS に対応している場合は net/smtp が自動で TLS に切り替える。
+type SMTPSender struct {
+	config SMTPConfig
+	from   *mail.Address
+	// sendMail は送信処理。テストで差し替えるためにフィールドで保持する。
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+}
+
+// NewSMTPSender は SMTPSender を生成する。送信元アドレスを解釈できない場合はエラーを返す。
+func NewSMTPSender(config SMTPConfig) (*SMTPSender, error) {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q: %w", config.From, err)
+	}
+	return &SMTPSender{
+		config:   config,
+		from:     from,
+		sendMail: smtp.SendMail,
+		now:      time.Now,
+	}, nil
+}
+
+// Send はメールを送信する。net/smtp はコンテキストによる中断に対応しないため、
+// 送信前にキャンセル済みかどうかだけを確認する。
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+	body, err := s.build(to, msg)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	if err := s.sendMail(addr, auth, s.from.Address, []string{to.Address}, body); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// build はヘッダーと base64 でエンコードした本文から RFC 5322 のメッセージを組み立てる。
+// ヘッダーインジェクションを防ぐため、件名に改行を含むメッセージは拒否する。
+func (s *SMTPSender) build(to *mail.Address, msg Message) ([]byte, error) {
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, fmt.Errorf("mail subject must not contain line breaks")
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n")
+	b.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return b.Bytes(), nil
+}
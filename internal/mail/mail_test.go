@@ -0,0 +1,148 @@
+package mail
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sentMail struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+	msg  string
+}
+
+func newTestSender(t *testing.T, config SMTPConfig, sendErr error) (*SMTPSender, *[]sentMail) {
+	t.Helper()
+	s, err := NewSMTPSender(config)
+	if err != nil {
+		t.Fatalf("NewSMTPSender() error = %v", err)
+	}
+	var sent []sentMail
+	s.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		sent = append(sent, sentMail{addr: addr, auth: a, from: from, to: to, msg: string(msg)})
+		return sendErr
+	}
+	s.now = func() time.Time { return time.Date(2026, 7, 22, 12, 0, 0, 0, time.UTC) }
+	return s, &sent
+}
+
+func TestNewSMTPSender(t *testing.T) {
+	t.Run("送信元アドレスを解釈できないときエラーを返す", func(t *testing.T) {
+		// Act
+		_, err := NewSMTPSender(SMTPConfig{Host: "smtp.example.com", Port: 587, From: "noreply"})
+
+		// Assert
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	config := SMTPConfig{Host: "smtp.example.com", Port: 587, From: "Feedman <noreply@example.com>"}
+
+	t.Run("ヘッダーと base64 の本文を組み立てて送信する", func(t *testing.T) {
+		// Arrange
+		s, sent := newTestSender(t, config, nil)
+
+		// Act
+		err := s.Send(context.Background(), Message{To: "user@example.com", Subject: "ログインリンク", Body: "本文です"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		if len(*sent) != 1 {
+			t.Fatalf("sent = %d, want 1", len(*sent))
+		}
+		got := (*sent)[0]
+		if got.addr != "smtp.example.com:587" || got.from != "noreply@example.com" || len(got.to) != 1 || got.to[0] != "user@example.com" {
+			t.Errorf("envelope = %+v", got)
+		}
+		if got.auth != nil {
+			t.Error("Username 未設定のとき認証しないこと")
+		}
+		for _, want := range []string{
+			"From: \"Feedman\" <noreply@example.com>\r\n",
+			"To: <user@example.com>\r\n",
+			"Subject: =?utf-8?q?",
+			"Content-Type: text/plain; charset=UTF-8\r\n",
+			"\r\n\r\n" + base64.StdEncoding.EncodeToString([]byte("本文です")) + "\r\n",
+		} {
+			if !strings.Contains(got.msg, want) {
+				t.Errorf("message does not contain %q:\n%s", want, got.msg)
+			}
+		}
+	})
+
+	t.Run("Username を設定したとき PLAIN 認証する", func(t *testing.T) {
+		// Arrange
+		withAuth := config
+		withAuth.Username = "mailer"
+		withAuth.Password = "secret"
+		s, sent := newTestSender(t, withAuth, nil)
+
+		// Act
+		err := s.Send(context.Background(), Message{To: "user@example.com", Subject: "s", Body: "b"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		if (*sent)[0].auth == nil {
+			t.Error("expected smtp auth")
+		}
+	})
+
+	t.Run("件名に改行を含むとき送信しない", func(t *testing.T) {
+		// Arrange
+		s, sent := newTestSender(t, config, nil)
+
+		// Act
+		err := s.Send(context.Background(), Message{To: "user@example.com", Subject: "s\r\nBcc: x@example.com", Body: "b"})
+
+		// Assert
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+		if len(*sent) != 0 {
+			t.Errorf("sent = %d, want 0", len(*sent))
+		}
+	})
+
+	t.Run("宛先を解釈できないとき送信しない", func(t *testing.T) {
+		// Arrange
+		s, sent := newTestSender(t, config, nil)
+
+		// Act
+		err := s.Send(context.Background(), Message{To: "user@example.com\r\nBcc: x@example.com", Subject: "s", Body: "b"})
+
+		// Assert
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+		if len(*sent) != 0 {
+			t.Errorf("sent = %d, want 0", len(*sent))
+		}
+	})
+
+	t.Run("SMTP サーバーへの送信に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		s, _ := newTestSender(t, config, errors.New("connection refused"))
+
+		// Act
+		err := s.Send(context.Background(), Message{To: "user@example.com", Subject: "s", Body: "b"})
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Errorf("Send() error = %v", err)
+		}
+	})
+}
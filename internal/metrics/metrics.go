@@ -19,6 +19,7 @@ import (
 type MetricsCollector interface {
 	RecordFetchSuccess(feedID string)
 	RecordFetchFailure(feedID string, reason string)
+	RecordFetchErrorKind(kind string)
 	RecordParseFailure(feedID string)
 	RecordHTTPStatus(statusCode int)
 	RecordFetchLatency(duration time.Duration)
@@ -33,11 +34,13 @@ type MetricsCollector interface {
 type Collector struct {
 	fetchSuccess     prometheus.Counter
 	fetchFail        prometheus.Counter
+	fetchErrorKind   *prometheus.CounterVec
 	parseFail        prometheus.Counter
 	httpStatus       *prometheus.CounterVec
 	fetchLatency     prometheus.Histogram
 	itemsUpserted    prometheus.Counter
 	manualFetchTotal *prometheus.CounterVec
+	cacheRequests    *prometheus.CounterVec
 }
 
 // NewCollector は新しいCollectorを生成し、指定されたレジストリにメトリクスを登録する。
@@ -51,6 +54,10 @@ func NewCollector(reg prometheus.Registerer) *Collector {
 			Name: "feedman_fetch_fail_total",
 			Help: "フィードフェッチ失敗の合計数",
 		}),
+		fetchErrorKind: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "feedman_fetch_error_kind_total",
+			Help: "フェッチ失敗の原因分類（dns / tls / timeout / too_large / redirect / parse / http_4xx / http_5xx / ssrf / other）別の件数",
+		}, []string{"kind"}),
 		parseFail: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "feedman_parse_fail_total",
 			Help: "フィードパース失敗の合計数",
@@ -72,16 +79,22 @@ func NewCollector(reg prometheus.Registerer) *Collector {
 			Name: "feedman_manual_fetch_total",
 			Help: "手動フェッチの実行回数（result ラベルで成功・失敗カテゴリ・拒否を区別）",
 		}, []string{"result"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "feedman_cache_requests_total",
+			Help: "サービス層キャッシュの参照回数（cache ラベルでキャッシュ種別、result ラベルで hit / miss を区別）",
+		}, []string{"cache", "result"}),
 	}
 
 	reg.MustRegister(
 		c.fetchSuccess,
 		c.fetchFail,
+		c.fetchErrorKind,
 		c.parseFail,
 		c.httpStatus,
 		c.fetchLatency,
 		c.itemsUpserted,
 		c.manualFetchTotal,
+		c.cacheRequests,
 	)
 
 	return c
@@ -97,6 +110,12 @@ func (c *Collector) RecordFetchFailure(feedID string, reason string) {
 	c.fetchFail.Inc()
 }
 
+// RecordFetchErrorKind はフェッチ失敗の原因分類を記録する。
+// kind は model.FetchErrorKind の値で、フェッチャーが値域を決定する。
+func (c *Collector) RecordFetchErrorKind(kind string) {
+	c.fetchErrorKind.WithLabelValues(kind).Inc()
+}
+
 // RecordParseFailure はパース失敗を記録する。
 func (c *Collector) RecordParseFailure(feedID string) {
 	c.parseFail.Inc()
@@ -151,6 +170,17 @@ func (c *Collector) RecordManualFetchLockConflict() {
 	c.manualFetchTotal.WithLabelValues(manualFetchResultLockConflict).Inc()
 }
 
+// RecordCacheHit はサービス層キャッシュのヒットを記録する（cache.Recorder の実装）。
+// ヒット率は hit / (hit + miss) で算出する。
+func (c *Collector) RecordCacheHit(cache string) {
+	c.cacheRequests.WithLabelValues(cache, "hit").Inc()
+}
+
+// RecordCacheMiss はサービス層キャッシュのミスを記録する（cache.Recorder の実装）。
+func (c *Collector) RecordCacheMiss(cache string) {
+	c.cacheRequests.WithLabelValues(cache, "miss").Inc()
+}
+
 // Handler はPrometheusスクレイプ用のHTTPハンドラーを返す。
 func Handler(gatherer prometheus.Gatherer) http.Handler {
 	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
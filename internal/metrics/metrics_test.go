@@ -152,6 +152,51 @@ func TestRecordHTTPStatus_IncrementsCounterWithLabel(t *testing.T) {
 	}
 }
 
+// TestRecordFetchErrorKind_IncrementsCounterWithLabel はフェッチ失敗の原因分類カウンタが
+// kind ラベル付きで増加することを検証する。
+func TestRecordFetchErrorKind_IncrementsCounterWithLabel(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c := NewCollector(reg)
+
+	c.RecordFetchErrorKind("timeout")
+	c.RecordFetchErrorKind("timeout")
+	c.RecordFetchErrorKind("too_large")
+
+	metrics, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	found := false
+	for _, mf := range metrics {
+		if mf.GetName() == "feedman_fetch_error_kind_total" {
+			found = true
+			if len(mf.GetMetric()) != 2 {
+				t.Fatalf("expected 2 label combinations, got %d", len(mf.GetMetric()))
+			}
+			for _, m := range mf.GetMetric() {
+				label := m.GetLabel()[0].GetValue()
+				val := m.GetCounter().GetValue()
+				switch label {
+				case "timeout":
+					if val != 2 {
+						t.Errorf("fetch_error_kind_total{kind=timeout} = %v, want 2", val)
+					}
+				case "too_large":
+					if val != 1 {
+						t.Errorf("fetch_error_kind_total{kind=too_large} = %v, want 1", val)
+					}
+				default:
+					t.Errorf("unexpected label value: %s", label)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("feedman_fetch_error_kind_total metric not found")
+	}
+}
+
 // TestRecordFetchLatency_ObservesHistogram はフェッチレイテンシのヒストグラムに値が記録されることを検証する。
 func TestRecordFetchLatency_ObservesHistogram(t *testing.T) {
 	reg := prometheus.NewRegistry()
@@ -472,3 +517,40 @@ func TestMultipleCollectors_IndependentRegistries(t *testing.T) {
 		t.Errorf("reg2 fetch_success = %v, want 2", val2)
 	}
 }
+
+// TestRecordCacheHitMiss_IncrementsCounterWithLabels はキャッシュのヒット・ミスが
+// feedman_cache_requests_total{cache,result} に記録されることを検証する。
+func TestRecordCacheHitMiss_IncrementsCounterWithLabels(t *testing.T) {
+	// Arrange
+	reg := prometheus.NewRegistry()
+	c := NewCollector(reg)
+
+	// Act
+	c.RecordCacheHit("subscriptions")
+	c.RecordCacheHit("subscriptions")
+	c.RecordCacheMiss("subscriptions")
+
+	// Assert
+	metrics, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	got := map[string]float64{}
+	for _, mf := range metrics {
+		if mf.GetName() != "feedman_cache_requests_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			labels := map[string]string{}
+			for _, lp := range m.GetLabel() {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			if labels["cache"] == "subscriptions" {
+				got[labels["result"]] = m.GetCounter().GetValue()
+			}
+		}
+	}
+	if got["hit"] != 2 || got["miss"] != 1 {
+		t.Errorf("cache_requests_total = %v, want hit=2 miss=1", got)
+	}
+}
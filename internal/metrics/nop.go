@@ -13,6 +13,9 @@ func (NopCollector) RecordFetchSuccess(feedID string) {}
 // RecordFetchFailure は何も記録しない。
 func (NopCollector) RecordFetchFailure(feedID, reason string) {}
 
+// RecordFetchErrorKind は何も記録しない。
+func (NopCollector) RecordFetchErrorKind(kind string) {}
+
 // RecordParseFailure は何も記録しない。
 func (NopCollector) RecordParseFailure(feedID string) {}
 
@@ -36,3 +39,9 @@ func (NopCollector) RecordManualFetchCooldownRejected() {}
 
 // RecordManualFetchLockConflict は何も記録しない。
 func (NopCollector) RecordManualFetchLockConflict() {}
+
+// RecordCacheHit は何も記録しない。
+func (NopCollector) RecordCacheHit(cache string) {}
+
+// RecordCacheMiss は何も記録しない。
+func (NopCollector) RecordCacheMiss(cache string) {}
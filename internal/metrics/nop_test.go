@@ -18,7 +18,7 @@ func TestNopCollector_ImplementsMetricsCollector(t *testing.T) {
 	}
 }
 
-// TestNopCollector_MethodsDoNotPanic は NopCollector の全 10 メソッドが
+// TestNopCollector_MethodsDoNotPanic は NopCollector の全 11 メソッドが
 // panic せず副作用なく呼べることを検証する。
 // Collector 未注入時の既定値として nil 安全に振る舞うことを担保する。
 // Requirement 5.1 / NFR 1.2 / Issue #115 Req 8.1〜8.4 に対応。
@@ -35,6 +35,10 @@ func TestNopCollector_MethodsDoNotPanic(t *testing.T) {
 			name: "RecordFetchFailureを呼んでもpanicしない",
 			call: func(c NopCollector) { c.RecordFetchFailure("feed-1", "timeout") },
 		},
+		{
+			name: "RecordFetchErrorKindを呼んでもpanicしない",
+			call: func(c NopCollector) { c.RecordFetchErrorKind("timeout") },
+		},
 		{
 			name: "RecordParseFailureを呼んでもpanicしない",
 			call: func(c NopCollector) { c.RecordParseFailure("feed-1") },
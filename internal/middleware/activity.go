@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// ActivityTracker は認証済みユーザーの最終アクティブ日時の記録先。
+// TouchActivity はリクエストごとに呼ばれるため、記録を間引いて短時間で戻ること。
+type ActivityTracker interface {
+	TouchActivity(ctx context.Context, userID string)
+}
+
+// NewActivityMiddleware は認証済みユーザーのアクティブを tracker に記録するミドルウェアを返す。
+// Session ミドルウェアの内側に置き、コンテキストにユーザーIDが無いリクエストは記録しない。
+func NewActivityMiddleware(tracker ActivityTracker) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if userID, err := UserIDFromContext(r.Context()); err == nil && userID != "" {
+				tracker.TouchActivity(r.Context(), userID)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// mockActivityTracker は ActivityTracker のモック実装。
+type mockActivityTracker struct {
+	userIDs []string
+}
+
+func (m *mockActivityTracker) TouchActivity(_ context.Context, userID string) {
+	m.userIDs = append(m.userIDs, userID)
+}
+
+func TestActivityMiddleware(t *testing.T) {
+	t.Run("認証済みリクエストのユーザーIDを記録し後続に渡す", func(t *testing.T) {
+		// Arrange
+		tracker := &mockActivityTracker{}
+		called := false
+		handler := NewActivityMiddleware(tracker)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds", nil)
+		req = req.WithContext(ContextWithUserID(req.Context(), "user-1"))
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if !called {
+			t.Error("next handler was not called")
+		}
+		if len(tracker.userIDs) != 1 || tracker.userIDs[0] != "user-1" {
+			t.Errorf("userIDs = %v, want [user-1]", tracker.userIDs)
+		}
+	})
+
+	t.Run("ユーザーIDが無いリクエストは記録しない", func(t *testing.T) {
+		// Arrange
+		tracker := &mockActivityTracker{}
+		handler := NewActivityMiddleware(tracker)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds", nil)
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if len(tracker.userIDs) != 0 {
+			t.Errorf("userIDs = %v, want none", tracker.userIDs)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// NewAdminOnlyMiddleware は管理者ユーザーのリクエストのみ通過させるミドルウェアを返す。
+// Session ミドルウェアの内側に置き、コンテキストのユーザーIDが adminUserIDs に
+// 含まれる場合のみ next を呼ぶ。含まれない場合は 403 FORBIDDEN を返す。
+//
+// adminUserIDs が空（未設定）の場合は全リクエストを拒否する（安全側）。
+func NewAdminOnlyMiddleware(adminUserIDs []string) func(next http.Handler) http.Handler {
+	admins := make(map[string]struct{}, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			admins[id] = struct{}{}
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := UserIDFromContext(r.Context())
+			if err != nil {
+				WriteErrorResponse(w, http.StatusUnauthorized, model.NewUnauthorizedError())
+				return
+			}
+			if _, ok := admins[userID]; !ok {
+				WriteErrorResponse(w, http.StatusForbidden, model.NewAdminRequiredError())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminOnlyMiddleware(t *testing.T) {
+	cases := []struct {
+		name       string
+		admins     []string
+		userID     string // 空の場合はコンテキストにユーザーIDを注入しない
+		wantStatus int
+		wantNext   bool
+		wantCode   string
+	}{
+		{
+			name:       "管理者のユーザーIDのとき200でnextに到達する",
+			admins:     []string{"admin-1", "admin-2"},
+			userID:     "admin-2",
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name:       "管理者でないユーザーIDのとき403 FORBIDDENで拒否する",
+			admins:     []string{"admin-1"},
+			userID:     "user-1",
+			wantStatus: http.StatusForbidden,
+			wantCode:   "FORBIDDEN",
+		},
+		{
+			name:       "管理者が未設定のとき全リクエストを403で拒否する",
+			admins:     nil,
+			userID:     "admin-1",
+			wantStatus: http.StatusForbidden,
+			wantCode:   "FORBIDDEN",
+		},
+		{
+			name:       "管理者IDの前後の空白は無視して判定する",
+			admins:     []string{" admin-1 "},
+			userID:     "admin-1",
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name:       "ユーザーIDがコンテキストにないとき401 UNAUTHORIZEDを返す",
+			admins:     []string{"admin-1"},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "UNAUTHORIZED",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			})
+			h := NewAdminOnlyMiddleware(tc.admins)(next)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/debug/parse-feed", nil)
+			if tc.userID != "" {
+				req = req.WithContext(ContextWithUserID(context.Background(), tc.userID))
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			h.ServeHTTP(rec, req)
+
+			// Assert
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status: got %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if nextCalled != tc.wantNext {
+				t.Errorf("next called: got %v, want %v", nextCalled, tc.wantNext)
+			}
+			if tc.wantCode != "" {
+				var body map[string]any
+				if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode body: %v", err)
+				}
+				if body["code"] != tc.wantCode {
+					t.Errorf("code: got %v, want %s", body["code"], tc.wantCode)
+				}
+			}
+		})
+	}
+}
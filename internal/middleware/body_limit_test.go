@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// newBodyLimitTestHandler は本文を読み切り、読み取りに失敗したら 400 を返すテスト用ハンドラ。
+// 読み取れたバイト数を readBytes に記録する。
+func newBodyLimitTestHandler(readBytes *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		*readBytes = len(data)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, &model.APIError{Code: model.ErrCodeInvalidRequest, Message: "bad request"})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// decodeErrorBody はエラーレスポンスのボディを ErrorResponseBody にデコードする。
+func decodeErrorBody(t *testing.T, w *httptest.ResponseRecorder) ErrorResponseBody {
+	t.Helper()
+	var body ErrorResponseBody
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return body
+}
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	t.Run("上限以内のとき本文をそのまま渡す", func(t *testing.T) {
+		// Arrange
+		var readBytes int
+		handler := NewBodyLimitMiddleware(10)(newBodyLimitTestHandler(&readBytes))
+		req := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader("0123456789"))
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if readBytes != 10 {
+			t.Errorf("readBytes = %d, want 10", readBytes)
+		}
+	})
+
+	t.Run("Content-Lengthが上限を超えるとき本文を読まずに413を返す", func(t *testing.T) {
+		// Arrange
+		var readBytes int
+		handler := NewBodyLimitMiddleware(10)(newBodyLimitTestHandler(&readBytes))
+		req := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(strings.Repeat("a", 11)))
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+		}
+		if readBytes != 0 {
+			t.Errorf("readBytes = %d, want 0", readBytes)
+		}
+		body := decodeErrorBody(t, w)
+		if body.Code != model.ErrCodePayloadTooLarge {
+			t.Errorf("code = %q, want %q", body.Code, model.ErrCodePayloadTooLarge)
+		}
+		if body.Details["max_bytes"] != float64(10) {
+			t.Errorf("details.max_bytes = %v, want 10", body.Details["max_bytes"])
+		}
+	})
+
+	t.Run("Content-Lengthが無く読み取り中に上限を超えたときハンドラのエラーを413に差し替える", func(t *testing.T) {
+		// Arrange
+		var readBytes int
+		handler := NewBodyLimitMiddleware(10)(newBodyLimitTestHandler(&readBytes))
+		req := httptest.NewRequest(http.MethodPost, "/api/test", io.NopCloser(strings.NewReader(strings.Repeat("a", 100))))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+		}
+		if readBytes != 10 {
+			t.Errorf("readBytes = %d, want 10", readBytes)
+		}
+		if body := decodeErrorBody(t, w); body.Code != model.ErrCodePayloadTooLarge {
+			t.Errorf("code = %q, want %q", body.Code, model.ErrCodePayloadTooLarge)
+		}
+	})
+
+	t.Run("内側で重ねて適用したとき内側の上限が優先される", func(t *testing.T) {
+		// Arrange
+		var readBytes int
+		inner := NewBodyLimitMiddleware(100)(newBodyLimitTestHandler(&readBytes))
+		handler := NewBodyLimitMiddleware(10)(inner)
+		req := httptest.NewRequest(http.MethodPost, "/api/test", io.NopCloser(strings.NewReader(strings.Repeat("a", 50))))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if readBytes != 50 {
+			t.Errorf("readBytes = %d, want 50", readBytes)
+		}
+	})
+
+	t.Run("上限が0以下のとき制限しない", func(t *testing.T) {
+		// Arrange
+		var readBytes int
+		handler := NewBodyLimitMiddleware(0)(newBodyLimitTestHandler(&readBytes))
+		req := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(strings.Repeat("a", 50)))
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+}
@@ -1,16 +1,143 @@
 package middleware
 
-import "net/http"
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CORSOption は NewCORSMiddleware の任意設定を表す functional option。
+type CORSOption func(*corsConfig)
+
+// corsConfig は NewCORSMiddleware の内部設定。
+type corsConfig struct {
+	// extensionOrigins はブラウザ拡張（chrome-extension://... 等）のオリジン集合。
+	// フィード登録（POST /api/feeds）に限り、既定オリジンに加えて許可する。
+	extensionOrigins map[string]struct{}
+	// allowedOrigins は全エンドポイントで既定オリジンに加えて許可するオリジン（ワイルドカードを含む）。
+	allowedOrigins []originPattern
+}
+
+// originPattern は許可オリジンの照合条件。
+// host が "*." で始まる場合はそのドメインのサブドメイン（任意の深さ）に一致する。
+type originPattern struct {
+	scheme string
+	host   string
+	port   string
+}
+
+// parseOriginPattern は "https://app.example.com" や "https://*.example.com:8443" 形式の
+// 許可オリジンを解釈する。パス・クエリ・ユーザー情報を含むもの、"*" 単体、
+// ホスト先頭以外のワイルドカードは受け付けない。
+func parseOriginPattern(s string) (originPattern, bool) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Scheme == "" || u.Host == "" || u.User != nil ||
+		(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return originPattern{}, false
+	}
+	host := strings.ToLower(u.Hostname())
+	if rest, ok := strings.CutPrefix(host, "*."); ok {
+		if rest == "" || strings.Contains(rest, "*") || !strings.Contains(rest, ".") {
+			return originPattern{}, false
+		}
+	} else if strings.Contains(host, "*") {
+		return originPattern{}, false
+	}
+	return originPattern{scheme: strings.ToLower(u.Scheme), host: host, port: u.Port()}, true
+}
+
+// matches はリクエストの Origin ヘッダーの値がパターンに一致するかを判定する。
+// Origin は "scheme://host[:port]" の形式のみを受け付け、スキームとポートは完全一致とする。
+func (p originPattern) matches(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" || u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+	if strings.ToLower(u.Scheme) != p.scheme || u.Port() != p.port {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if suffix, ok := strings.CutPrefix(p.host, "*"); ok {
+		// suffix は ".example.com"。"example.com" 自体や "evil-example.com" には一致させない
+		return len(host) > len(suffix) && strings.HasSuffix(host, suffix)
+	}
+	return host == p.host
+}
+
+// WithAllowedOrigins は全エンドポイントで既定オリジンに加えて許可するオリジンを追加する。
+// "https://*.example.com" のようにホスト先頭のワイルドカードでサブドメインを一括で許可できる。
+// 空要素は無視し、解釈できない要素（"*" 単体など）は Warn ログを出力してスキップする。
+func WithAllowedOrigins(origins []string) CORSOption {
+	return func(c *corsConfig) {
+		for _, o := range origins {
+			if strings.TrimSpace(o) == "" {
+				continue
+			}
+			p, ok := parseOriginPattern(o)
+			if !ok {
+				slog.Warn("CORS 許可オリジンの形式が不正なためスキップします", slog.String("origin", o))
+				continue
+			}
+			c.allowedOrigins = append(c.allowedOrigins, p)
+		}
+	}
+}
+
+// isAllowedOrigin は origin が WithAllowedOrigins で追加したオリジンのいずれかに一致するかを判定する。
+func (c *corsConfig) isAllowedOrigin(origin string) bool {
+	for _, p := range c.allowedOrigins {
+		if p.matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithExtensionOrigins はブラウザ拡張・ブックマークレットからのフィード登録を許可するオリジンを追加する。
+// 許可されるのは POST /api/feeds（およびそのプリフライト）のみで、その他のエンドポイントには
+// 既定オリジンのみが適用される。空要素は無視する。
+func WithExtensionOrigins(origins []string) CORSOption {
+	return func(c *corsConfig) {
+		for _, o := range origins {
+			if o = strings.TrimSpace(o); o != "" {
+				c.extensionOrigins[o] = struct{}{}
+			}
+		}
+	}
+}
 
 // NewCORSMiddleware は指定されたオリジンに対するCORSミドルウェアを返す。
 // credentials送信と共存するため、ワイルドカード(*)は使用しない。
 // OPTIONSプリフライトリクエストには204で応答する。
-func NewCORSMiddleware(allowedOrigin string) func(next http.Handler) http.Handler {
+//
+// WithAllowedOrigins が指定された場合は一致したオリジン、WithExtensionOrigins が指定された場合は
+// 該当オリジンからのフィード登録リクエストに限り、Access-Control-Allow-Origin にリクエストの Origin を
+// そのまま返す（Vary: Origin を付与）。credentials を許可するため、一致しない Origin はエコーバックせず
+// 既定オリジンを返す。
+func NewCORSMiddleware(allowedOrigin string, opts ...CORSOption) func(next http.Handler) http.Handler {
+	cfg := &corsConfig{extensionOrigins: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+			origin := allowedOrigin
+			if len(cfg.extensionOrigins) > 0 || len(cfg.allowedOrigins) > 0 {
+				w.Header().Add("Vary", "Origin")
+				if reqOrigin := r.Header.Get("Origin"); reqOrigin != "" && reqOrigin != allowedOrigin {
+					if cfg.isAllowedOrigin(reqOrigin) {
+						origin = reqOrigin
+					} else if _, ok := cfg.extensionOrigins[reqOrigin]; ok && isFeedRegistrationRequest(r) {
+						origin = reqOrigin
+					}
+				}
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Idempotency-Key")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 			w.Header().Set("Access-Control-Max-Age", "86400")
 
@@ -24,3 +151,19 @@ func NewCORSMiddleware(allowedOrigin string) func(next http.Handler) http.Handle
 		})
 	}
 }
+
+// isFeedRegistrationRequest はリクエストがフィード登録（POST /api/feeds）またはその
+// プリフライトかを判定する。
+func isFeedRegistrationRequest(r *http.Request) bool {
+	if r.URL.Path != "/api/feeds" && r.URL.Path != "/api/feeds/" {
+		return false
+	}
+	switch r.Method {
+	case http.MethodPost:
+		return true
+	case http.MethodOptions:
+		return r.Header.Get("Access-Control-Request-Method") == http.MethodPost
+	default:
+		return false
+	}
+}
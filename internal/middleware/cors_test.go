@@ -29,7 +29,7 @@ func TestCORSMiddleware_SetsHeaders(t *testing.T) {
 	}{
 		{"Access-Control-Allow-Origin", "http://localhost:3000"},
 		{"Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS"},
-		{"Access-Control-Allow-Headers", "Content-Type"},
+		{"Access-Control-Allow-Headers", "Content-Type, Idempotency-Key"},
 		{"Access-Control-Allow-Credentials", "true"},
 		{"Access-Control-Max-Age", "86400"},
 	}
@@ -94,3 +94,161 @@ func TestCORSMiddleware_POSTRequest_PassesThroughWithHeaders(t *testing.T) {
 		t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, "https://app.example.com")
 	}
 }
+
+func TestCORSMiddleware_WithExtensionOrigins(t *testing.T) {
+	const extOrigin = "chrome-extension://abcdefghijklmnop"
+	mw := NewCORSMiddleware("http://localhost:3000", WithExtensionOrigins([]string{" " + extOrigin + " ", ""}))
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		origin     string
+		preflight  string // Access-Control-Request-Method
+		wantOrigin string
+	}{
+		{
+			name:       "拡張オリジンからのPOST /api/feedsのとき拡張オリジンを許可する",
+			method:     http.MethodPost,
+			path:       "/api/feeds",
+			origin:     extOrigin,
+			wantOrigin: extOrigin,
+		},
+		{
+			name:       "拡張オリジンからのPOST /api/feedsのプリフライトのとき拡張オリジンを許可する",
+			method:     http.MethodOptions,
+			path:       "/api/feeds/",
+			origin:     extOrigin,
+			preflight:  http.MethodPost,
+			wantOrigin: extOrigin,
+		},
+		{
+			name:       "拡張オリジンからのフィード登録以外のリクエストのとき既定オリジンのみ返す",
+			method:     http.MethodGet,
+			path:       "/api/subscriptions",
+			origin:     extOrigin,
+			wantOrigin: "http://localhost:3000",
+		},
+		{
+			name:       "拡張オリジンからのDELETEプリフライトのとき既定オリジンのみ返す",
+			method:     http.MethodOptions,
+			path:       "/api/feeds",
+			origin:     extOrigin,
+			preflight:  http.MethodDelete,
+			wantOrigin: "http://localhost:3000",
+		},
+		{
+			name:       "未登録オリジンからのPOST /api/feedsのとき既定オリジンのみ返す",
+			method:     http.MethodPost,
+			path:       "/api/feeds",
+			origin:     "chrome-extension://unknown",
+			wantOrigin: "http://localhost:3000",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("Origin", tc.origin)
+			if tc.preflight != "" {
+				req.Header.Set("Access-Control-Request-Method", tc.preflight)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, req)
+
+			// Assert
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tc.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tc.wantOrigin)
+			}
+			if got := w.Header().Get("Vary"); got != "Origin" {
+				t.Errorf("Vary = %q, want %q", got, "Origin")
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_WithAllowedOrigins(t *testing.T) {
+	mw := NewCORSMiddleware("http://localhost:3000", WithAllowedOrigins([]string{
+		"https://staging.example.com",
+		" https://*.preview.example.com ",
+		"http://localhost:5173",
+		"*",
+		"https://*",
+		"",
+	}))
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	cases := []struct {
+		name       string
+		origin     string
+		wantOrigin string
+	}{
+		{"完全一致するオリジンのときOriginをエコーバックする", "https://staging.example.com", "https://staging.example.com"},
+		{"ワイルドカードのサブドメインのときOriginをエコーバックする", "https://pr-12.preview.example.com", "https://pr-12.preview.example.com"},
+		{"ワイルドカードの多段サブドメインのときOriginをエコーバックする", "https://a.b.preview.example.com", "https://a.b.preview.example.com"},
+		{"ホスト名の大文字小文字が異なるときも一致とみなす", "https://PR-1.Preview.Example.com", "https://PR-1.Preview.Example.com"},
+		{"ポート付きのオリジンが一致するときOriginをエコーバックする", "http://localhost:5173", "http://localhost:5173"},
+		{"ワイルドカードの親ドメイン自体のとき既定オリジンを返す", "https://preview.example.com", "http://localhost:3000"},
+		{"ドメイン名の末尾だけが一致するとき既定オリジンを返す", "https://evil-preview.example.com", "http://localhost:3000"},
+		{"スキームが異なるとき既定オリジンを返す", "http://staging.example.com", "http://localhost:3000"},
+		{"ポートが異なるとき既定オリジンを返す", "https://staging.example.com:8443", "http://localhost:3000"},
+		{"パスを含むOriginのとき既定オリジンを返す", "https://staging.example.com/path", "http://localhost:3000"},
+		{"未登録オリジンのとき既定オリジンを返す", "https://attacker.example.net", "http://localhost:3000"},
+		{"Originがないとき既定オリジンを返す", "", "http://localhost:3000"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodGet, "/api/subscriptions", nil)
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, req)
+
+			// Assert
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tc.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tc.wantOrigin)
+			}
+			if got := w.Header().Get("Vary"); got != "Origin" {
+				t.Errorf("Vary = %q, want %q", got, "Origin")
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want true", got)
+			}
+		})
+	}
+
+	t.Run("プリフライトのとき一致したOriginと許可ヘッダーを返す", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest(http.MethodOptions, "/api/items/1", nil)
+		req.Header.Set("Origin", "https://staging.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://staging.example.com" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want https://staging.example.com", got)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Headers"); got != "Content-Type, Idempotency-Key" {
+			t.Errorf("Access-Control-Allow-Headers = %q, want Content-Type, Idempotency-Key", got)
+		}
+	})
+}
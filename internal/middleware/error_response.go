@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/hitoshi/feedman/internal/model"
@@ -11,26 +10,43 @@ import (
 // 原因カテゴリと対処方法を含む。
 // Details は任意の構造化追加情報（429 等で retry_after_seconds 等を載せる）。
 // nil の場合は JSON シリアライズ時に出力されない（omitempty 相当）。
+// RequestID はサポート問い合わせ時にサーバーログと照合するためのリクエスト ID、
+// DocsURL はエラーコードの解説ページ URL。
 type ErrorResponseBody struct {
-	Code     string         `json:"code"`
-	Message  string         `json:"message"`
-	Category string         `json:"category"`
-	Action   string         `json:"action"`
-	Details  map[string]any `json:"details,omitempty"`
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Category  string         `json:"category"`
+	Action    string         `json:"action"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+	DocsURL   string         `json:"docs_url"`
 }
 
 // WriteErrorResponse は統一エラーフォーマットでHTTPエラーレスポンスを書き込む。
 // すべてのAPIエンドポイントで一貫したエラーレスポンスを提供する。
 // apiErr.Details が nil でない場合は JSON に `details` フィールドとして含める（Issue #115 Req 2.2）。
+// apiErr.RequestID が空の場合は RequestID ミドルウェアが設定した X-Request-Id レスポンスヘッダーの値を、
+// apiErr.DocsURL が空の場合はエラーコードから求めた解説ページ URL を補う（apiErr 自体は変更しない）。
 func WriteErrorResponse(w http.ResponseWriter, statusCode int, apiErr *model.APIError) {
-	w.Header().Set("Content-Type", "application/json")
+	requestID := apiErr.RequestID
+	if requestID == "" {
+		requestID = w.Header().Get(RequestIDHeader)
+	}
+	docsURL := apiErr.DocsURL
+	if docsURL == "" {
+		docsURL = model.ErrorDocsURL(apiErr.Code)
+	}
+
+	w.Header().Set("Content-Type", JSONContentType)
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponseBody{
-		Code:     apiErr.Code,
-		Message:  apiErr.Message,
-		Category: apiErr.Category,
-		Action:   apiErr.Action,
-		Details:  apiErr.Details,
+	NewJSONEncoder(w).Encode(ErrorResponseBody{
+		Code:      apiErr.Code,
+		Message:   apiErr.Message,
+		Category:  apiErr.Category,
+		Action:    apiErr.Action,
+		Details:   apiErr.Details,
+		RequestID: requestID,
+		DocsURL:   docsURL,
 	})
 }
 
@@ -38,7 +54,7 @@ func WriteErrorResponse(w http.ResponseWriter, statusCode int, apiErr *model.API
 // 詳細はログのみに記録し、ユーザーには一般的なメッセージを返す。
 func WriteInternalServerError(w http.ResponseWriter) {
 	WriteErrorResponse(w, http.StatusInternalServerError, &model.APIError{
-		Code:     "INTERNAL_ERROR",
+		Code:     model.ErrCodeInternal,
 		Message:  "内部エラーが発生しました。",
 		Category: "system",
 		Action:   "しばらく待ってから再度お試しください。",
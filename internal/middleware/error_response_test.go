@@ -27,8 +27,8 @@ func TestWriteErrorResponse_WritesUnifiedFormat(t *testing.T) {
 		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
 	}
 
-	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", ct, JSONContentType)
 	}
 
 	var body ErrorResponseBody
@@ -211,3 +211,56 @@ func TestWriteErrorResponse_NilDetailsOmitted(t *testing.T) {
 		t.Errorf("details field should be omitted when nil, got raw=%v", raw)
 	}
 }
+
+// TestWriteErrorResponse_RequestIDAndDocsURL は request_id と docs_url がボディに含まれることを検証する。
+func TestWriteErrorResponse_RequestIDAndDocsURL(t *testing.T) {
+	t.Run("X-Request-Idヘッダーが設定されているときrequest_idに載る", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		w.Header().Set(RequestIDHeader, "req-123")
+		apiErr := &model.APIError{
+			Code:     model.ErrCodeUnauthorized,
+			Message:  "認証が必要です。",
+			Category: "auth",
+			Action:   "ログインしてください。",
+		}
+
+		// Act
+		WriteErrorResponse(w, http.StatusUnauthorized, apiErr)
+
+		// Assert
+		var body ErrorResponseBody
+		if err := json.NewDecoder(w.Result().Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response body: %v", err)
+		}
+		if body.RequestID != "req-123" {
+			t.Errorf("request_id = %q, want %q", body.RequestID, "req-123")
+		}
+		if want := model.ErrorDocsBaseURL + "#unauthorized"; body.DocsURL != want {
+			t.Errorf("docs_url = %q, want %q", body.DocsURL, want)
+		}
+		if apiErr.RequestID != "" || apiErr.DocsURL != "" {
+			t.Error("WriteErrorResponse should not mutate the given APIError")
+		}
+	})
+
+	t.Run("X-Request-Idヘッダーがないときrequest_idは省略される", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+
+		// Act
+		WriteInternalServerError(w)
+
+		// Assert
+		var raw map[string]any
+		if err := json.NewDecoder(w.Result().Body).Decode(&raw); err != nil {
+			t.Fatalf("failed to decode response body: %v", err)
+		}
+		if _, ok := raw["request_id"]; ok {
+			t.Error("request_id should be omitted when no request id is set")
+		}
+		if raw["docs_url"] != model.ErrorDocsBaseURL+"#internal_error" {
+			t.Errorf("docs_url = %v, want %q", raw["docs_url"], model.ErrorDocsBaseURL+"#internal_error")
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+const (
+	// IdempotencyKeyHeader は書き込み系リクエストの冪等性キーを受け取るリクエストヘッダ。
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader は保存済みのレスポンスを再生したことを示すレスポンスヘッダ。
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotentResponseBytes は再生用に保存するレスポンスボディの上限。
+	// 超えた場合は保存せず、同じキーでの再送は再実行される。
+	maxIdempotentResponseBytes = 1 << 20
+	// maxIdempotentRequestBytes は再送の同一性判定のためにメモリへ読み込むリクエストボディの上限。
+	// 超えるリクエストは冪等性の対象外とし、ボディを後続へそのまま渡す（アップロード等の大きなボディを
+	// ルートごとの上限より先に全量読み込まないため）。
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+// IdempotencyStore は Idempotency-Key ごとのリクエスト・レスポンスの保存先。
+// repository.IdempotencyKeyRepository と同じシグネチャで、PostgresIdempotencyKeyRepo が実装する。
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, userID, key, requestHash string, now, expiresAt time.Time) (bool, *model.IdempotencyRecord, error)
+	Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, userID, key string) error
+}
+
+// idempotencyRecorder は後続ハンドラのレスポンスをクライアントに書き込みつつ、
+// 再生用にステータスコードとボディを記録する ResponseWriter。
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *idempotencyRecorder) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *idempotencyRecorder) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.overflow {
+		if w.body.Len()+len(p) > maxIdempotentResponseBytes {
+			w.overflow = true
+			w.body.Reset()
+		} else {
+			w.body.Write(p)
+		}
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap は http.ResponseController が元の ResponseWriter に到達できるようにする。
+func (w *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// errReader は保持したエラーを返し続ける io.Reader。
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// NewIdempotencyMiddleware は書き込み系リクエスト（POST / PUT / PATCH / DELETE）の
+// Idempotency-Key ヘッダを解釈し、同じキーでの再送に最初のレスポンスを再生するミドルウェアを返す。
+//
+// キーはユーザー単位で管理するため、Session ミドルウェアの内側に置く。ヘッダが無いリクエスト、
+// 読み取り系のリクエスト、未認証のリクエストはそのまま後続に渡す。
+//
+//   - 初回: キーを処理中として予約してから後続を実行し、レスポンスを ttl の間保存する。
+//     5xx のレスポンスやボディが上限を超えたレスポンスは保存せず予約を解放し、再送で再実行できるようにする。
+//   - 再送（処理済み）: 保存したステータス・Content-Type・ボディを Idempotent-Replayed: true 付きで返す。
+//   - 再送（処理中）: 409 IDEMPOTENCY_KEY_IN_USE を返す。
+//   - 同じキーで別内容（メソッド・パス・ボディが異なる）: 422 IDEMPOTENCY_KEY_MISMATCH を返す。
+//
+// ボディが maxIdempotentRequestBytes を超えるリクエストは冪等性の対象外とし、後続をそのまま実行する。
+// 保存先の障害時は冪等性を諦めて後続をそのまま実行する（書き込み API 自体は止めない）。
+func NewIdempotencyMiddleware(store IdempotencyStore, ttl time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isIdempotentTarget(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userID, err := UserIDFromContext(r.Context())
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !validIdempotencyKey(key) {
+				WriteErrorResponse(w, http.StatusBadRequest, model.NewInvalidIdempotencyKeyError())
+				return
+			}
+
+			body, buffered, err := bufferRequestBody(r)
+			if err != nil {
+				// 上限超過等でボディを読めないリクエストは冪等性の対象外とし、
+				// 後続で同じエラーを発生させて既存のエラーレスポンスに委ねる。
+				r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err: err}))
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !buffered {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			requestHash := hashIdempotentRequest(r, body)
+			now := time.Now()
+			reserved, existing, err := store.Reserve(r.Context(), userID, key, requestHash, now, now.Add(ttl))
+			if err != nil {
+				slog.Error("failed to reserve idempotency key",
+					slog.String("user_id", userID),
+					slog.String("error", err.Error()),
+				)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !reserved {
+				switch {
+				case existing != nil && existing.RequestHash != requestHash:
+					WriteErrorResponse(w, http.StatusUnprocessableEntity, model.NewIdempotencyKeyMismatchError())
+				case existing == nil || !existing.Completed():
+					WriteErrorResponse(w, http.StatusConflict, model.NewIdempotencyKeyInUseError())
+				default:
+					replayIdempotentResponse(w, existing)
+				}
+				return
+			}
+
+			rec := &idempotencyRecorder{ResponseWriter: w}
+			// クライアントの切断やパニックで後続が途中終了しても保存・解放を完了させる
+			storeCtx := context.WithoutCancel(r.Context())
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if err := store.Release(storeCtx, userID, key); err != nil {
+					slog.Error("failed to release idempotency key",
+						slog.String("user_id", userID),
+						slog.String("error", err.Error()),
+					)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError || rec.overflow {
+				return
+			}
+			if err := store.Complete(storeCtx, userID, key, status, w.Header().Get("Content-Type"), rec.body.Bytes()); err != nil {
+				slog.Error("failed to store idempotent response",
+					slog.String("user_id", userID),
+					slog.String("error", err.Error()),
+				)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// isIdempotentTarget は Idempotency-Key を解釈する対象のメソッドかを返す。
+func isIdempotentTarget(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// validIdempotencyKey はキーが 1〜MaxIdempotencyKeyLength バイトの空白を含まない表示可能 ASCII 文字列かを返す。
+func validIdempotencyKey(key string) bool {
+	if len(key) == 0 || len(key) > model.MaxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// bufferRequestBody は再送の同一性判定のためにリクエストボディを読み込み、読み込めた場合は true を返す。
+// エラー時はそれまでに読めた分を返す。
+//
+// ボディが maxIdempotentRequestBytes を超える場合は false を返し、r.Body は後続が全量を読めるようにしておく。
+// Content-Length で超過が分かる場合は読み込まずにそのまま残し、不明な場合（chunked 等）は
+// 上限 + 1 バイトまで読んだ分と残りを連結して戻す。
+func bufferRequestBody(r *http.Request) ([]byte, bool, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, true, nil
+	}
+	if r.ContentLength > maxIdempotentRequestBytes {
+		return nil, false, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentRequestBytes+1))
+	if err != nil {
+		return body, false, err
+	}
+	if int64(len(body)) > maxIdempotentRequestBytes {
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		return nil, false, nil
+	}
+	return body, true, nil
+}
+
+// hashIdempotentRequest はメソッド・パス（クエリを含む）・ボディから、同じキーでの再送が
+// 同一内容かを判定するためのハッシュを計算する。
+func hashIdempotentRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.RequestURI())
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replayIdempotentResponse は保存済みのレスポンスを再生する。
+func replayIdempotentResponse(w http.ResponseWriter, rec *model.IdempotencyRecord) {
+	if rec.ContentType != "" {
+		w.Header().Set("Content-Type", rec.ContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(rec.StatusCode)
+	w.Write(rec.ResponseBody) //nolint:errcheck
+}
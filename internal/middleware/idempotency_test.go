@@ -0,0 +1,372 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// fakeIdempotencyStore は IdempotencyStore のインメモリ実装。
+type fakeIdempotencyStore struct {
+	mu         sync.Mutex
+	records    map[string]*model.IdempotencyRecord
+	reserveErr error
+	released   int
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{records: make(map[string]*model.IdempotencyRecord)}
+}
+
+func (s *fakeIdempotencyStore) Reserve(_ context.Context, userID, key, requestHash string, now, expiresAt time.Time) (bool, *model.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reserveErr != nil {
+		return false, nil, s.reserveErr
+	}
+	id := userID + "|" + key
+	if rec, ok := s.records[id]; ok && rec.ExpiresAt.After(now) {
+		copied := *rec
+		return false, &copied, nil
+	}
+	s.records[id] = &model.IdempotencyRecord{RequestHash: requestHash, ExpiresAt: expiresAt}
+	return true, nil, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(_ context.Context, userID, key string, statusCode int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := s.records[userID+"|"+key]
+	rec.StatusCode = statusCode
+	rec.ContentType = contentType
+	rec.ResponseBody = append([]byte(nil), body...)
+	return nil
+}
+
+func (s *fakeIdempotencyStore) Release(_ context.Context, userID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := userID + "|" + key
+	if rec, ok := s.records[id]; ok && !rec.Completed() {
+		delete(s.records, id)
+		s.released++
+	}
+	return nil
+}
+
+// newIdempotencyTestHandler は呼び出し回数を数え、status と本文を返すテスト用ハンドラ。
+func newIdempotencyTestHandler(calls *int, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", JSONContentType)
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"call":%d,"body":%q}`, *calls, body)
+	})
+}
+
+// newIdempotentRequest は認証済みユーザーの Idempotency-Key 付きリクエストを組み立てる。
+func newIdempotentRequest(method, path, body, key string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req.WithContext(ContextWithUserID(req.Context(), "user-1"))
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Run("同じキーで再送したとき最初のレスポンスを再生し後続を再実行しない", func(t *testing.T) {
+		// Arrange
+		var calls int
+		handler := NewIdempotencyMiddleware(newFakeIdempotencyStore(), time.Hour)(newIdempotencyTestHandler(&calls, http.StatusCreated))
+
+		// Act
+		first := httptest.NewRecorder()
+		handler.ServeHTTP(first, newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+		second := httptest.NewRecorder()
+		handler.ServeHTTP(second, newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+
+		// Assert
+		if calls != 1 {
+			t.Errorf("calls = %d, want 1", calls)
+		}
+		if second.Code != http.StatusCreated {
+			t.Errorf("status = %d, want %d", second.Code, http.StatusCreated)
+		}
+		if second.Body.String() != first.Body.String() {
+			t.Errorf("body = %q, want %q", second.Body.String(), first.Body.String())
+		}
+		if got := second.Header().Get("Content-Type"); got != JSONContentType {
+			t.Errorf("Content-Type = %q, want %q", got, JSONContentType)
+		}
+		if got := second.Header().Get(IdempotentReplayedHeader); got != "true" {
+			t.Errorf("%s = %q, want true", IdempotentReplayedHeader, got)
+		}
+		if got := first.Header().Get(IdempotentReplayedHeader); got != "" {
+			t.Errorf("初回レスポンスに %s が付与されている: %q", IdempotentReplayedHeader, got)
+		}
+	})
+
+	t.Run("キーがないとき毎回後続を実行する", func(t *testing.T) {
+		// Arrange
+		var calls int
+		handler := NewIdempotencyMiddleware(newFakeIdempotencyStore(), time.Hour)(newIdempotencyTestHandler(&calls, http.StatusCreated))
+
+		// Act
+		for i := 0; i < 2; i++ {
+			handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(http.MethodPost, "/api/feeds", "a", ""))
+		}
+
+		// Assert
+		if calls != 2 {
+			t.Errorf("calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("GETのときキーを無視して毎回後続を実行する", func(t *testing.T) {
+		// Arrange
+		var calls int
+		handler := NewIdempotencyMiddleware(newFakeIdempotencyStore(), time.Hour)(newIdempotencyTestHandler(&calls, http.StatusOK))
+
+		// Act
+		for i := 0; i < 2; i++ {
+			handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(http.MethodGet, "/api/feeds", "", "key-1"))
+		}
+
+		// Assert
+		if calls != 2 {
+			t.Errorf("calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("同じキーで別内容のリクエストのとき422を返す", func(t *testing.T) {
+		// Arrange
+		var calls int
+		handler := NewIdempotencyMiddleware(newFakeIdempotencyStore(), time.Hour)(newIdempotencyTestHandler(&calls, http.StatusCreated))
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+
+		// Act
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest(http.MethodPost, "/api/feeds", "b", "key-1"))
+
+		// Assert
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+		}
+		if body := decodeErrorBody(t, w); body.Code != model.ErrCodeIdempotencyKeyMismatch {
+			t.Errorf("code = %q, want %q", body.Code, model.ErrCodeIdempotencyKeyMismatch)
+		}
+		if calls != 1 {
+			t.Errorf("calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("最初のリクエストが処理中のとき409を返す", func(t *testing.T) {
+		// Arrange
+		store := newFakeIdempotencyStore()
+		var calls int
+		handler := NewIdempotencyMiddleware(store, time.Hour)(newIdempotencyTestHandler(&calls, http.StatusCreated))
+		req := newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1")
+		store.records["user-1|key-1"] = &model.IdempotencyRecord{
+			RequestHash: hashIdempotentRequest(req, []byte("a")),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+
+		// Act
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusConflict {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusConflict)
+		}
+		if body := decodeErrorBody(t, w); body.Code != model.ErrCodeIdempotencyKeyInUse {
+			t.Errorf("code = %q, want %q", body.Code, model.ErrCodeIdempotencyKeyInUse)
+		}
+		if calls != 0 {
+			t.Errorf("calls = %d, want 0", calls)
+		}
+	})
+
+	t.Run("5xxのとき保存せず再送で再実行できる", func(t *testing.T) {
+		// Arrange
+		store := newFakeIdempotencyStore()
+		var calls int
+		handler := NewIdempotencyMiddleware(store, time.Hour)(newIdempotencyTestHandler(&calls, http.StatusInternalServerError))
+
+		// Act
+		for i := 0; i < 2; i++ {
+			handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+		}
+
+		// Assert
+		if calls != 2 {
+			t.Errorf("calls = %d, want 2", calls)
+		}
+		if store.released != 2 {
+			t.Errorf("released = %d, want 2", store.released)
+		}
+	})
+
+	t.Run("4xxのとき保存して再生する", func(t *testing.T) {
+		// Arrange
+		var calls int
+		handler := NewIdempotencyMiddleware(newFakeIdempotencyStore(), time.Hour)(newIdempotencyTestHandler(&calls, http.StatusConflict))
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+
+		// Assert
+		if calls != 1 {
+			t.Errorf("calls = %d, want 1", calls)
+		}
+		if w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+		}
+	})
+
+	t.Run("後続がパニックしたとき予約を解放する", func(t *testing.T) {
+		// Arrange
+		store := newFakeIdempotencyStore()
+		handler := NewIdempotencyMiddleware(store, time.Hour)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		}))
+
+		// Act
+		func() {
+			defer func() { _ = recover() }()
+			handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+		}()
+
+		// Assert
+		if store.released != 1 {
+			t.Errorf("released = %d, want 1", store.released)
+		}
+		if _, ok := store.records["user-1|key-1"]; ok {
+			t.Error("処理中の予約が残っている")
+		}
+	})
+
+	t.Run("キーの形式が不正なとき400を返す", func(t *testing.T) {
+		tests := []struct {
+			name string
+			key  string
+		}{
+			{name: "空白を含む", key: "key 1"},
+			{name: "非ASCIIを含む", key: "キー"},
+			{name: "長すぎる", key: strings.Repeat("a", model.MaxIdempotencyKeyLength+1)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				var calls int
+				handler := NewIdempotencyMiddleware(newFakeIdempotencyStore(), time.Hour)(newIdempotencyTestHandler(&calls, http.StatusCreated))
+
+				// Act
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, newIdempotentRequest(http.MethodPost, "/api/feeds", "a", tt.key))
+
+				// Assert
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+				if body := decodeErrorBody(t, w); body.Code != model.ErrCodeInvalidIdempotencyKey {
+					t.Errorf("code = %q, want %q", body.Code, model.ErrCodeInvalidIdempotencyKey)
+				}
+				if calls != 0 {
+					t.Errorf("calls = %d, want 0", calls)
+				}
+			})
+		}
+	})
+
+	t.Run("保存先の障害時は冪等性を諦めて後続を実行する", func(t *testing.T) {
+		// Arrange
+		store := newFakeIdempotencyStore()
+		store.reserveErr = errors.New("db down")
+		var calls int
+		handler := NewIdempotencyMiddleware(store, time.Hour)(newIdempotencyTestHandler(&calls, http.StatusCreated))
+
+		// Act
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest(http.MethodPost, "/api/feeds", "a", "key-1"))
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		if calls != 1 {
+			t.Errorf("calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("ボディが読み込み上限を超えるとき冪等性の対象外としボディを後続へそのまま渡す", func(t *testing.T) {
+		large := strings.Repeat("a", maxIdempotentRequestBytes+1)
+		tests := []struct {
+			name          string
+			contentLength int64
+		}{
+			{name: "Content-Lengthあり", contentLength: int64(len(large))},
+			{name: "Content-Length不明", contentLength: -1},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				store := newFakeIdempotencyStore()
+				var calls, readBytes int
+				handler := NewIdempotencyMiddleware(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls++
+					body, _ := io.ReadAll(r.Body)
+					readBytes = len(body)
+					w.WriteHeader(http.StatusCreated)
+				}))
+
+				// Act
+				for range 2 {
+					req := newIdempotentRequest(http.MethodPost, "/api/feeds", large, "key-1")
+					req.ContentLength = tt.contentLength
+					handler.ServeHTTP(httptest.NewRecorder(), req)
+				}
+
+				// Assert
+				if calls != 2 {
+					t.Errorf("calls = %d, want 2", calls)
+				}
+				if readBytes != len(large) {
+					t.Errorf("read %d bytes, want %d", readBytes, len(large))
+				}
+				if len(store.records) != 0 {
+					t.Errorf("records = %d, want 0", len(store.records))
+				}
+			})
+		}
+	})
+
+	t.Run("ボディが上限を超えるとき冪等性の対象外とし413を返す", func(t *testing.T) {
+		// Arrange
+		var readBytes int
+		handler := NewBodyLimitMiddleware(4)(
+			NewIdempotencyMiddleware(newFakeIdempotencyStore(), time.Hour)(newBodyLimitTestHandler(&readBytes)),
+		)
+
+		// Act
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest(http.MethodPost, "/api/feeds", "0123456789", "key-1"))
+
+		// Assert
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+		}
+	})
+}
@@ -91,7 +91,7 @@ func (rl *IPRateLimiter) Middleware() func(next http.Handler) http.Handler {
 			limiter := rl.getOrCreateLimiter(key)
 
 			if !limiter.Allow() {
-				writeRateLimitResponse(w, rl.config.Rate)
+				writeRateLimitResponse(w, rl.config.Rate, 1)
 				slog.Warn("rate limit exceeded",
 					slog.String("limit_type", "unauth_ip"),
 				)
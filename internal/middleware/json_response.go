@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// JSONContentType は JSON レスポンスの Content-Type。charset を明示して全エンドポイントで統一する。
+const JSONContentType = "application/json; charset=utf-8"
+
+// NewJSONEncoder は API レスポンスで共通のエスケープポリシーを適用した json.Encoder を返す。
+// 成功・エラー・NDJSON のいずれのレスポンスもこのエンコーダで書き込む。
+//
+// エスケープポリシー:
+//   - "<" ">" "&" は \u003c 等にエスケープする（HTML に埋め込まれてもタグとして解釈されない）
+//   - それ以外の非 ASCII 文字はエスケープせず UTF-8 のまま出力する
+//   - 不正な UTF-8 バイト列は U+FFFD に置き換える（エンコードは失敗しない）
+func NewJSONEncoder(w io.Writer) *json.Encoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(true)
+	return enc
+}
+
+// WriteJSON は v を統一ルールでシリアライズして JSON レスポンスとして書き込む。
+//
+// 統一ルール:
+//   - time.Time はすべて UTC に変換して RFC3339（小数秒あり）で出力する
+//   - nil スライスは []、nil マップは {} として出力する（配列・オブジェクトが null にならない）
+//   - nil ポインタは null として出力する（値がないことを表す）
+//   - 文字列中の不正な UTF-8 バイト列は U+FFFD に置き換える
+//   - エスケープは NewJSONEncoder のポリシーに従う
+//   - Content-Type は JSONContentType 固定
+//
+// エンコードに失敗した場合はレスポンスを書き始める前に 500 を返す。
+func WriteJSON(w http.ResponseWriter, statusCode int, v any) {
+	var buf bytes.Buffer
+	if err := NewJSONEncoder(&buf).Encode(NormalizeJSON(v)); err != nil {
+		slog.Error("JSONレスポンスのエンコードに失敗しました", slog.String("error", err.Error()))
+		WriteInternalServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", JSONContentType)
+	w.WriteHeader(statusCode)
+	w.Write(buf.Bytes())
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// NormalizeJSON は WriteJSON の統一ルールを適用した v のコピーを返す。
+// 元の値は変更しない。独自の MarshalJSON を持つ型（time.Time を除く）はそのまま残す。
+func NormalizeJSON(v any) any {
+	if v == nil {
+		return nil
+	}
+	return normalizeValue(reflect.ValueOf(v)).Interface()
+}
+
+func normalizeValue(v reflect.Value) reflect.Value {
+	t := v.Type()
+	if t == timeType {
+		return reflect.ValueOf(v.Interface().(time.Time).UTC())
+	}
+	if k := v.Kind(); k != reflect.Pointer && k != reflect.Interface &&
+		(t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType)) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(t.Elem())
+		out.Elem().Set(normalizeValue(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(t).Elem()
+		out.Set(normalizeValue(v.Elem()))
+		return out
+	case reflect.Slice:
+		// []byte は encoding/json が base64 文字列として扱うため対象外
+		if t.Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(normalizeValue(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(normalizeValue(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		out := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), normalizeValue(iter.Value()))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(v)
+		normalizeStructFields(out)
+		return out
+	case reflect.String:
+		if utf8.ValidString(v.String()) {
+			return v
+		}
+		out := reflect.New(t).Elem()
+		out.SetString(strings.ToValidUTF8(v.String(), string(utf8.RuneError)))
+		return out
+	default:
+		return v
+	}
+}
+
+// normalizeStructFields はアドレス可能な構造体 s の公開フィールドをその場で正規化する。
+// 非公開型の埋め込み構造体も、昇格した公開フィールドは JSON に出力されるため再帰的に処理する。
+func normalizeStructFields(s reflect.Value) {
+	t := s.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		f := s.Field(i)
+		switch {
+		case sf.IsExported():
+			f.Set(normalizeValue(f))
+		case sf.Anonymous && f.Kind() == reflect.Struct:
+			normalizeStructFields(f)
+		}
+	}
+}
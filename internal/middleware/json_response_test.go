@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type jsonTestInner struct {
+	At time.Time `json:"at"`
+}
+
+type jsonTestEmbedded struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type jsonTestBody struct {
+	jsonTestEmbedded
+	At      time.Time         `json:"at"`
+	AtPtr   *time.Time        `json:"at_ptr"`
+	NilPtr  *string           `json:"nil_ptr"`
+	Items   []jsonTestInner   `json:"items"`
+	Tags    []string          `json:"tags"`
+	Meta    map[string]any    `json:"meta"`
+	Raw     json.RawMessage   `json:"raw"`
+	Nested  map[string][]int  `json:"nested"`
+	Omitted []string          `json:"omitted,omitempty"`
+	Any     any               `json:"any"`
+	ByKey   map[string]string `json:"by_key"`
+}
+
+func TestWriteJSON(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	at := time.Date(2026, 6, 1, 9, 0, 0, 500, jst)
+
+	t.Run("日時をUTCに正規化しnilスライスとnilマップを空で出力する", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		body := jsonTestBody{
+			jsonTestEmbedded: jsonTestEmbedded{CreatedAt: at},
+			At:               at,
+			AtPtr:            &at,
+			Items:            []jsonTestInner{{At: at}},
+			Raw:              json.RawMessage(`{"k":1}`),
+			Nested:           map[string][]int{"a": nil},
+			Any:              jsonTestInner{At: at},
+		}
+
+		// Act
+		WriteJSON(w, http.StatusCreated, body)
+
+		// Assert
+		if w.Code != http.StatusCreated {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusCreated)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != JSONContentType {
+			t.Errorf("Content-Type = %q, want %q", ct, JSONContentType)
+		}
+		want := `{"created_at":"2026-06-01T00:00:00.0000005Z","at":"2026-06-01T00:00:00.0000005Z",` +
+			`"at_ptr":"2026-06-01T00:00:00.0000005Z","nil_ptr":null,"items":[{"at":"2026-06-01T00:00:00.0000005Z"}],` +
+			`"tags":[],"meta":{},"raw":{"k":1},"nested":{"a":[]},"any":{"at":"2026-06-01T00:00:00.0000005Z"},"by_key":{}}` + "\n"
+		if got := w.Body.String(); got != want {
+			t.Errorf("body =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("元の値を変更しない", func(t *testing.T) {
+		// Arrange
+		body := &jsonTestBody{At: at, AtPtr: &at}
+
+		// Act
+		WriteJSON(httptest.NewRecorder(), http.StatusOK, body)
+
+		// Assert
+		if body.At.Location() != jst || body.AtPtr.Location() != jst {
+			t.Error("WriteJSON should not mutate the original value")
+		}
+		if body.Tags != nil {
+			t.Error("WriteJSON should not replace nil slices in the original value")
+		}
+	})
+
+	t.Run("nilを渡したときnullを出力する", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+
+		// Act
+		WriteJSON(w, http.StatusOK, nil)
+
+		// Assert
+		if got := w.Body.String(); got != "null\n" {
+			t.Errorf("body = %q, want %q", got, "null\n")
+		}
+	})
+
+	t.Run("不正なUTF-8を含む文字列のときU+FFFDに置き換えて出力する", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		body := map[string]any{"title": "前\xff後", "tags": []string{"\xed\xa0\x80"}}
+
+		// Act
+		WriteJSON(w, http.StatusOK, body)
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		want := "{\"tags\":[\"\uFFFD\"],\"title\":\"前\uFFFD後\"}\n"
+		if got := w.Body.String(); got != want {
+			t.Errorf("body = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("HTMLの特殊文字をエスケープし非ASCII文字はそのまま出力する", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+
+		// Act
+		WriteJSON(w, http.StatusOK, map[string]string{"html": "<b>日本語&</b>"})
+
+		// Assert
+		want := `{"html":"\u003cb\u003e日本語\u0026\u003c/b\u003e"}` + "\n"
+		if got := w.Body.String(); got != want {
+			t.Errorf("body = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("エンコードできない値のとき500を返す", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+
+		// Act
+		WriteJSON(w, http.StatusOK, map[string]any{"ch": make(chan int)})
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+		}
+	})
+}
@@ -32,7 +32,8 @@ func (sr *statusRecorder) Write(b []byte) (int, error) {
 }
 
 // NewLoggingMiddleware はリクエストのJSON構造化ログを出力するミドルウェアを返す。
-// ログにはmethod、path、status、duration_ms、user_id（認証済みの場合）を含む。
+// ログにはmethod、path、status、duration_ms、request_id（RequestID ミドルウェア適用時）、
+// user_id（認証済みの場合）を含む。
 func NewLoggingMiddleware(logger *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -55,6 +56,11 @@ func NewLoggingMiddleware(logger *slog.Logger) func(next http.Handler) http.Hand
 				slog.Float64("duration_ms", durationMs),
 			}
 
+			// リクエスト ID がコンテキストにある場合は追加（エラー応答の request_id と照合できるようにする）
+			if requestID := RequestIDFromContext(r.Context()); requestID != "" {
+				attrs = append(attrs, slog.String("request_id", requestID))
+			}
+
 			// ユーザーIDがコンテキストにある場合は追加
 			if userID, err := UserIDFromContext(r.Context()); err == nil && userID != "" {
 				attrs = append(attrs, slog.String("user_id", userID))
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"encoding/json"
 	"log/slog"
 	"math"
 	"net/http"
@@ -10,6 +9,8 @@ import (
 	"time"
 
 	"golang.org/x/time/rate"
+
+	"github.com/hitoshi/feedman/internal/model"
 )
 
 // RateLimiterConfig はレート制限の設定を保持する。
@@ -18,17 +19,22 @@ type RateLimiterConfig struct {
 	GeneralBurst    int           // API全般のバーストサイズ
 	FeedRegRate     rate.Limit    // フィード登録のレート（req/sec）。10/60
 	FeedRegBurst    int           // フィード登録のバーストサイズ
+	FeedBatchRate   rate.Limit    // 一括登録の URL のレート（件/sec）。10/60
+	FeedBatchBurst  int           // 一括登録のバーストサイズ（1 回の一括登録で受け付ける URL 数以上にする）
 	CleanupInterval time.Duration // 期限切れエントリのクリーンアップ間隔
 }
 
 // DefaultRateLimiterConfig はデフォルトのレート制限設定を返す。
 // 要件: API全般 120 req/min/user、フィード登録 10 req/min/user
+// 一括登録は登録と同じ 10 件/min で補充し、上限（model.MaxBatchFeedURLs）いっぱいの 1 回分をバーストで受け付ける。
 func DefaultRateLimiterConfig() RateLimiterConfig {
 	return RateLimiterConfig{
 		GeneralRate:     rate.Limit(120.0 / 60.0), // 2 req/sec
 		GeneralBurst:    120,
 		FeedRegRate:     rate.Limit(10.0 / 60.0), // ~0.167 req/sec
 		FeedRegBurst:    10,
+		FeedBatchRate:   rate.Limit(10.0 / 60.0), // ~0.167 件/sec
+		FeedBatchBurst:  model.MaxBatchFeedURLs,
 		CleanupInterval: 5 * time.Minute,
 	}
 }
@@ -40,7 +46,7 @@ type userLimiter struct {
 }
 
 // RateLimiter はユーザーごとのレート制限を管理する。
-// API全般のレート制限とフィード登録のレート制限の2種類を提供する。
+// API全般・フィード登録・フィードの一括登録の3種類のレート制限を提供する。
 type RateLimiter struct {
 	config RateLimiterConfig
 
@@ -50,6 +56,9 @@ type RateLimiter struct {
 	feedRegMu       sync.RWMutex
 	feedRegLimiters map[string]*userLimiter
 
+	feedBatchMu       sync.RWMutex
+	feedBatchLimiters map[string]*userLimiter
+
 	stopCh chan struct{}
 }
 
@@ -57,10 +66,11 @@ type RateLimiter struct {
 // バックグラウンドで期限切れエントリのクリーンアップを開始する。
 func NewRateLimiter(config RateLimiterConfig) *RateLimiter {
 	rl := &RateLimiter{
-		config:          config,
-		generalLimiters: make(map[string]*userLimiter),
-		feedRegLimiters: make(map[string]*userLimiter),
-		stopCh:          make(chan struct{}),
+		config:            config,
+		generalLimiters:   make(map[string]*userLimiter),
+		feedRegLimiters:   make(map[string]*userLimiter),
+		feedBatchLimiters: make(map[string]*userLimiter),
+		stopCh:            make(chan struct{}),
 	}
 
 	go rl.cleanupLoop()
@@ -80,14 +90,14 @@ func (rl *RateLimiter) GeneralMiddleware() func(next http.Handler) http.Handler
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userID, err := UserIDFromContext(r.Context())
 			if err != nil {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteErrorResponse(w, http.StatusUnauthorized, model.NewUnauthorizedError())
 				return
 			}
 
 			limiter := rl.getOrCreateGeneralLimiter(userID)
 
 			if !limiter.Allow() {
-				writeRateLimitResponse(w, rl.config.GeneralRate)
+				writeRateLimitResponse(w, rl.config.GeneralRate, 1)
 				slog.Warn("rate limit exceeded",
 					slog.String("user_id", userID),
 					slog.String("limit_type", "general"),
@@ -101,23 +111,44 @@ func (rl *RateLimiter) GeneralMiddleware() func(next http.Handler) http.Handler
 }
 
 // FeedRegistrationMiddleware はフィード登録専用のレート制限ミドルウェアを返す。
-// API全般のレート制限とは独立に動作する。
+// API全般のレート制限とは独立に動作する。1 リクエストを 1 件の登録として数える。
 func (rl *RateLimiter) FeedRegistrationMiddleware() func(next http.Handler) http.Handler {
+	return rl.costMiddleware("feed_registration", rl.config.FeedRegRate, rl.getOrCreateFeedRegLimiter,
+		func(*http.Request) int { return 1 })
+}
+
+// FeedBatchRegistrationMiddleware は 1 リクエストで複数のフィードを登録する一括登録向けのレート制限ミドルウェアを返す。
+// 単発のフィード登録とは別のユーザーごとのバケットから、cost が返す件数分のトークンをまとめて消費する
+// （1 未満は 1 件として数える）。件数が FeedBatchBurst を超えるリクエストは常に 429 になる。
+func (rl *RateLimiter) FeedBatchRegistrationMiddleware(cost func(r *http.Request) int) func(next http.Handler) http.Handler {
+	return rl.costMiddleware("feed_batch_registration", rl.config.FeedBatchRate, rl.getOrCreateFeedBatchLimiter, cost)
+}
+
+// costMiddleware は getLimiter が返すユーザーごとのバケットから cost 件分のトークンを消費するミドルウェアを返す。
+// limitType はレート超過時のログに出す制限の種類。
+func (rl *RateLimiter) costMiddleware(
+	limitType string,
+	limit rate.Limit,
+	getLimiter func(userID string) *rate.Limiter,
+	cost func(r *http.Request) int,
+) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userID, err := UserIDFromContext(r.Context())
 			if err != nil {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteErrorResponse(w, http.StatusUnauthorized, model.NewUnauthorizedError())
 				return
 			}
 
-			limiter := rl.getOrCreateFeedRegLimiter(userID)
+			n := max(cost(r), 1)
+			limiter := getLimiter(userID)
 
-			if !limiter.Allow() {
-				writeRateLimitResponse(w, rl.config.FeedRegRate)
+			if !limiter.AllowN(time.Now(), n) {
+				writeRateLimitResponse(w, limit, n)
 				slog.Warn("rate limit exceeded",
 					slog.String("user_id", userID),
-					slog.String("limit_type", "feed_registration"),
+					slog.String("limit_type", limitType),
+					slog.Int("cost", n),
 				)
 				return
 			}
@@ -143,6 +174,14 @@ func (rl *RateLimiter) FeedRegLimiterCount() int {
 	return len(rl.feedRegLimiters)
 }
 
+// FeedBatchLimiterCount は現在管理されている一括登録リミッターのエントリ数を返す。
+// テストおよびメトリクス用。
+func (rl *RateLimiter) FeedBatchLimiterCount() int {
+	rl.feedBatchMu.RLock()
+	defer rl.feedBatchMu.RUnlock()
+	return len(rl.feedBatchLimiters)
+}
+
 // getOrCreateGeneralLimiter はユーザーのAPI全般リミッターを取得または作成する。
 func (rl *RateLimiter) getOrCreateGeneralLimiter(userID string) *rate.Limiter {
 	rl.generalMu.RLock()
@@ -205,6 +244,37 @@ func (rl *RateLimiter) getOrCreateFeedRegLimiter(userID string) *rate.Limiter {
 	return limiter
 }
 
+// getOrCreateFeedBatchLimiter はユーザーの一括登録リミッターを取得または作成する。
+func (rl *RateLimiter) getOrCreateFeedBatchLimiter(userID string) *rate.Limiter {
+	rl.feedBatchMu.RLock()
+	ul, exists := rl.feedBatchLimiters[userID]
+	rl.feedBatchMu.RUnlock()
+
+	if exists {
+		rl.feedBatchMu.Lock()
+		ul.lastAccess = time.Now()
+		rl.feedBatchMu.Unlock()
+		return ul.limiter
+	}
+
+	rl.feedBatchMu.Lock()
+	defer rl.feedBatchMu.Unlock()
+
+	// ダブルチェック
+	if ul, exists := rl.feedBatchLimiters[userID]; exists {
+		ul.lastAccess = time.Now()
+		return ul.limiter
+	}
+
+	limiter := rate.NewLimiter(rl.config.FeedBatchRate, rl.config.FeedBatchBurst)
+	rl.feedBatchLimiters[userID] = &userLimiter{
+		limiter:    limiter,
+		lastAccess: time.Now(),
+	}
+
+	return limiter
+}
+
 // cleanupLoop はバックグラウンドで期限切れエントリを定期的にクリーンアップする。
 func (rl *RateLimiter) cleanupLoop() {
 	ticker := time.NewTicker(rl.config.CleanupInterval)
@@ -241,22 +311,30 @@ func (rl *RateLimiter) cleanup() {
 		}
 	}
 	rl.feedRegMu.Unlock()
+
+	rl.feedBatchMu.Lock()
+	for userID, ul := range rl.feedBatchLimiters {
+		if now.Sub(ul.lastAccess) > ttl {
+			delete(rl.feedBatchLimiters, userID)
+		}
+	}
+	rl.feedBatchMu.Unlock()
 }
 
 // writeRateLimitResponse は429 Too Many Requestsレスポンスを書き込む。
-// Retry-Afterヘッダーにはトークンが補充されるまでの推定秒数を設定する。
-func writeRateLimitResponse(w http.ResponseWriter, r rate.Limit) {
-	// Retry-Afterの算出: 1トークンが補充されるまでの秒数
-	retryAfterSec := int(math.Ceil(1.0 / float64(r)))
+// Retry-Afterヘッダーには tokens 個のトークンが補充されるまでの推定秒数を設定する。
+func writeRateLimitResponse(w http.ResponseWriter, r rate.Limit, tokens int) {
+	// Retry-Afterの算出: tokens 個のトークンが補充されるまでの秒数
+	retryAfterSec := int(math.Ceil(float64(tokens) / float64(r)))
 	if retryAfterSec < 1 {
 		retryAfterSec = 1
 	}
 
 	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSec))
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", JSONContentType)
 	w.WriteHeader(http.StatusTooManyRequests)
 
-	json.NewEncoder(w).Encode(map[string]string{
+	NewJSONEncoder(w).Encode(map[string]string{
 		"code":     "rate_limit_exceeded",
 		"message":  "Too many requests. Please try again later.",
 		"category": "system",
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/hitoshi/feedman/internal/model"
 )
 
@@ -228,6 +230,9 @@ func TestRateLimitMiddleware_NoUserID_Returns401(t *testing.T) {
 	if w.Result().StatusCode != http.StatusUnauthorized {
 		t.Errorf("status = %d, want %d", w.Result().StatusCode, http.StatusUnauthorized)
 	}
+	if body := decodeErrorBody(t, w); body.Code != model.ErrCodeUnauthorized {
+		t.Errorf("code = %q, want %q", body.Code, model.ErrCodeUnauthorized)
+	}
 }
 
 // --- FeedRegistrationRateLimit のテスト ---
@@ -362,6 +367,118 @@ func TestFeedRegistrationRateLimit_IndependentFromGeneralLimit(t *testing.T) {
 
 // --- 429レスポンスフォーマットのテスト ---
 
+func TestFeedBatchRegistrationMiddleware(t *testing.T) {
+	newRequest := func(userID string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/feeds/batch", nil)
+		return req.WithContext(context.WithValue(req.Context(), userIDContextKey, userID))
+	}
+	newLimiter := func() *RateLimiter {
+		return NewRateLimiter(RateLimiterConfig{
+			GeneralRate:     100,
+			GeneralBurst:    200,
+			FeedRegRate:     rate.Limit(10.0 / 60.0),
+			FeedRegBurst:    10,
+			FeedBatchRate:   rate.Limit(10.0 / 60.0),
+			FeedBatchBurst:  20,
+			CleanupInterval: 1 * time.Minute,
+		})
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("件数分のトークンを消費し残りが足りないとき429を返す", func(t *testing.T) {
+		// Arrange
+		rl := newLimiter()
+		defer rl.Stop()
+		handler := rl.FeedBatchRegistrationMiddleware(func(*http.Request) int { return 12 })(ok)
+
+		// Act
+		w1 := httptest.NewRecorder()
+		handler.ServeHTTP(w1, newRequest("user-cost"))
+		w2 := httptest.NewRecorder()
+		handler.ServeHTTP(w2, newRequest("user-cost"))
+
+		// Assert
+		if w1.Code != http.StatusOK {
+			t.Errorf("request 1: status = %d, want %d", w1.Code, http.StatusOK)
+		}
+		if w2.Code != http.StatusTooManyRequests {
+			t.Errorf("request 2: status = %d, want %d", w2.Code, http.StatusTooManyRequests)
+		}
+		if got := w2.Header().Get("Retry-After"); got != "72" {
+			t.Errorf("Retry-After = %q, want %q (12 件分の補充時間)", got, "72")
+		}
+	})
+
+	t.Run("単発の登録のバーストを超える件数のとき一括登録のバーストまで受け付ける", func(t *testing.T) {
+		// Arrange
+		rl := newLimiter()
+		defer rl.Stop()
+		handler := rl.FeedBatchRegistrationMiddleware(func(*http.Request) int { return 20 })(ok)
+
+		// Act
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("user-full"))
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("単発の登録とは別のバケットで数えるとき一括登録の消費分が単発の登録に効かない", func(t *testing.T) {
+		// Arrange
+		rl := newLimiter()
+		defer rl.Stop()
+		batch := rl.FeedBatchRegistrationMiddleware(func(*http.Request) int { return 20 })(ok)
+		single := rl.FeedRegistrationMiddleware()(ok)
+
+		// Act
+		wBatch := httptest.NewRecorder()
+		batch.ServeHTTP(wBatch, newRequest("user-shared"))
+		wSingle := httptest.NewRecorder()
+		single.ServeHTTP(wSingle, newRequest("user-shared"))
+
+		// Assert
+		if wBatch.Code != http.StatusOK {
+			t.Errorf("batch: status = %d, want %d", wBatch.Code, http.StatusOK)
+		}
+		if wSingle.Code != http.StatusOK {
+			t.Errorf("single: status = %d, want %d", wSingle.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("件数が1未満のとき1件として数える", func(t *testing.T) {
+		// Arrange
+		rl := NewRateLimiter(RateLimiterConfig{
+			GeneralRate:     100,
+			GeneralBurst:    200,
+			FeedRegRate:     1,
+			FeedRegBurst:    1,
+			FeedBatchRate:   1,
+			FeedBatchBurst:  1,
+			CleanupInterval: 1 * time.Minute,
+		})
+		defer rl.Stop()
+		handler := rl.FeedBatchRegistrationMiddleware(func(*http.Request) int { return 0 })(ok)
+
+		// Act
+		w1 := httptest.NewRecorder()
+		handler.ServeHTTP(w1, newRequest("user-zero"))
+		w2 := httptest.NewRecorder()
+		handler.ServeHTTP(w2, newRequest("user-zero"))
+
+		// Assert
+		if w1.Code != http.StatusOK {
+			t.Errorf("request 1: status = %d, want %d", w1.Code, http.StatusOK)
+		}
+		if w2.Code != http.StatusTooManyRequests {
+			t.Errorf("request 2: status = %d, want %d", w2.Code, http.StatusTooManyRequests)
+		}
+	})
+}
+
 func TestRateLimitMiddleware_429ResponseIsJSON(t *testing.T) {
 	cfg := RateLimiterConfig{
 		GeneralRate:  1,
@@ -400,8 +517,8 @@ func TestRateLimitMiddleware_429ResponseIsJSON(t *testing.T) {
 	}
 
 	contentType := resp.Header.Get("Content-Type")
-	if contentType != "application/json" {
-		t.Errorf("Content-Type = %q, want %q", contentType, "application/json")
+	if contentType != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want %q", contentType, "application/json; charset=utf-8")
 	}
 
 	var body map[string]string
@@ -546,4 +663,7 @@ func TestDefaultRateLimiterConfig(t *testing.T) {
 	if cfg.FeedRegBurst != 10 {
 		t.Errorf("FeedRegBurst = %d, want 10", cfg.FeedRegBurst)
 	}
+	if cfg.FeedBatchBurst < model.MaxBatchFeedURLs {
+		t.Errorf("FeedBatchBurst = %d, want >= MaxBatchFeedURLs (%d)", cfg.FeedBatchBurst, model.MaxBatchFeedURLs)
+	}
 }
@@ -7,7 +7,7 @@ import (
 )
 
 // NewRecoveryMiddleware はpanic発生時にプロセスクラッシュを防ぎ、
-// 500レスポンスを返すミドルウェアを生成する。
+// 統一フォーマットの 500 INTERNAL_ERROR レスポンスを返すミドルウェアを生成する。
 func NewRecoveryMiddleware() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -17,9 +17,10 @@ func NewRecoveryMiddleware() func(next http.Handler) http.Handler {
 						slog.Any("panic", rec),
 						slog.String("method", r.Method),
 						slog.String("path", r.URL.Path),
+						slog.String("request_id", RequestIDFromContext(r.Context())),
 						slog.String("stack", string(debug.Stack())),
 					)
-					http.Error(w, "internal server error", http.StatusInternalServerError)
+					WriteInternalServerError(w)
 				}
 			}()
 			next.ServeHTTP(w, r)
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// TestRecoveryMiddleware はpanic発生時に統一フォーマットの 500 応答を返すことを検証する。
+func TestRecoveryMiddleware(t *testing.T) {
+	t.Run("panicが発生したとき500 INTERNAL_ERRORをJSONで返しrequest_idを含む", func(t *testing.T) {
+		// Arrange
+		handler := NewRequestIDMiddleware()(NewRecoveryMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		})))
+		req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+		req.Header.Set(RequestIDHeader, "panic-req-1")
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		resp := w.Result()
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != JSONContentType {
+			t.Errorf("Content-Type = %q, want %q", ct, JSONContentType)
+		}
+		var body ErrorResponseBody
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response body: %v", err)
+		}
+		if body.Code != model.ErrCodeInternal {
+			t.Errorf("code = %q, want %q", body.Code, model.ErrCodeInternal)
+		}
+		if body.RequestID != "panic-req-1" {
+			t.Errorf("request_id = %q, want %q", body.RequestID, "panic-req-1")
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader はリクエスト ID を受け渡す HTTP ヘッダー名。
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDContextKey はリクエストコンテキストにリクエスト ID を格納するためのキー。
+var requestIDContextKey = contextKey("request_id")
+
+// validRequestID は上流（リバースプロキシ等）から引き継ぐリクエスト ID として受け付ける形式。
+// ログやレスポンスにそのまま載せるため、英数字と一部の記号のみ・128 文字までに制限する。
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// NewRequestIDMiddleware はリクエストごとに ID を採番し、コンテキストと X-Request-Id レスポンスヘッダーに
+// 設定するミドルウェアを返す。
+//
+// リクエストに妥当な X-Request-Id ヘッダーがある場合はその値を引き継ぎ、上流のログと突き合わせられるようにする。
+// WriteErrorResponse はレスポンスヘッダーの値をエラーボディの request_id に載せるため、
+// このミドルウェアより内側で書き出したエラー応答には自動で request_id が含まれる。
+func NewRequestIDMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID.MatchString(id) {
+				id = uuid.NewString()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			ctx := context.WithValue(r.Context(), requestIDContextKey, id)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequestIDFromContext はリクエストコンテキストからリクエスト ID を取得する。
+// NewRequestIDMiddleware を通っていない場合は空文字列を返す。
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
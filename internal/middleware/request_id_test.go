@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRequestIDMiddleware はリクエスト ID の採番・引き継ぎとコンテキストへの格納を検証する。
+func TestRequestIDMiddleware(t *testing.T) {
+	newHandler := func(gotID *string) http.Handler {
+		return NewRequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*gotID = RequestIDFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	t.Run("ヘッダーがないとき新しいIDを採番してレスポンスヘッダーとコンテキストに設定する", func(t *testing.T) {
+		// Arrange
+		var gotID string
+		handler := newHandler(&gotID)
+		req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		headerID := w.Result().Header.Get(RequestIDHeader)
+		if headerID == "" {
+			t.Fatal("X-Request-Id header should be set")
+		}
+		if gotID != headerID {
+			t.Errorf("context request id = %q, want %q", gotID, headerID)
+		}
+	})
+
+	t.Run("妥当なヘッダーがあるときその値を引き継ぐ", func(t *testing.T) {
+		// Arrange
+		var gotID string
+		handler := newHandler(&gotID)
+		req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+		req.Header.Set(RequestIDHeader, "upstream-req.123")
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if got := w.Result().Header.Get(RequestIDHeader); got != "upstream-req.123" {
+			t.Errorf("X-Request-Id = %q, want %q", got, "upstream-req.123")
+		}
+		if gotID != "upstream-req.123" {
+			t.Errorf("context request id = %q, want %q", gotID, "upstream-req.123")
+		}
+	})
+
+	t.Run("不正な形式のヘッダーのとき引き継がずに新しいIDを採番する", func(t *testing.T) {
+		invalids := []string{
+			"bad id with spaces",
+			"<script>",
+			strings.Repeat("a", 129),
+		}
+		for _, in := range invalids {
+			// Arrange
+			var gotID string
+			handler := newHandler(&gotID)
+			req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+			req.Header.Set(RequestIDHeader, in)
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, req)
+
+			// Assert
+			got := w.Result().Header.Get(RequestIDHeader)
+			if got == in || got == "" {
+				t.Errorf("input %q: X-Request-Id = %q, want newly generated id", in, got)
+			}
+			if gotID != got {
+				t.Errorf("input %q: context request id = %q, want %q", in, gotID, got)
+			}
+		}
+	})
+}
+
+// TestRequestIDMiddleware_ErrorResponseIncludesRequestID は内側で書き出したエラー応答に
+// request_id が自動で含まれることを検証する。
+func TestRequestIDMiddleware_ErrorResponseIncludesRequestID(t *testing.T) {
+	// Arrange
+	handler := NewRequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteInternalServerError(w)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	req.Header.Set(RequestIDHeader, "trace-abc")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, req)
+
+	// Assert
+	if !strings.Contains(w.Body.String(), `"request_id":"trace-abc"`) {
+		t.Errorf("body = %q, want request_id trace-abc", w.Body.String())
+	}
+}
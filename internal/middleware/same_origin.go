@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// NewSameOriginMiddleware は Origin ヘッダーが許可オリジンに一致するリクエストのみを通すミドルウェアを返す。
+//
+// navigator.sendBeacon のように JSON ボディ（Content-Type: application/json）を付けられず、
+// SameSite Cookie と JSON 限定の受け付けによる CSRF 対策が効かないエンドポイントに適用する。
+// 許可オリジンは CORS と同じく allowedOrigin と allowedOrigins（ワイルドカード可）で、
+// Origin ヘッダーが無いリクエストも拒否する（sendBeacon の POST には常に Origin が付く）。
+// 一致しない場合は 403 FORBIDDEN を返す。
+func NewSameOriginMiddleware(allowedOrigin string, allowedOrigins []string) func(next http.Handler) http.Handler {
+	cfg := &corsConfig{}
+	WithAllowedOrigins(allowedOrigins)(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || (origin != allowedOrigin && !cfg.isAllowedOrigin(origin)) {
+				WriteErrorResponse(w, http.StatusForbidden, model.NewCrossOriginRequestError())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSameOriginMiddleware(t *testing.T) {
+	mw := NewSameOriginMiddleware("http://localhost:3000", []string{"https://*.example.com"})
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name   string
+		origin string
+		want   int
+	}{
+		{"既定オリジンのとき通す", "http://localhost:3000", http.StatusNoContent},
+		{"許可オリジンのワイルドカードに一致するとき通す", "https://app.example.com", http.StatusNoContent},
+		{"他のオリジンのとき403を返す", "https://evil.test", http.StatusForbidden},
+		{"スキームが異なるとき403を返す", "https://localhost:3000", http.StatusForbidden},
+		{"Originヘッダーがないとき403を返す", "", http.StatusForbidden},
+		{"Originがnullのとき403を返す", "null", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodPost, "/api/items/item-1/read-beacon", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusForbidden && !strings.Contains(w.Body.String(), `"FORBIDDEN"`) {
+				t.Errorf("body = %s, want code FORBIDDEN", w.Body.String())
+			}
+		})
+	}
+}
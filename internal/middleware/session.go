@@ -27,14 +27,14 @@ type SessionFinder interface {
 // NewSessionMiddleware はHTTP Only Cookieからセッションを読み取り、
 // 有効性を検証するミドルウェアを返す。
 // 認証済みユーザーIDをリクエストコンテキストに注入する。
-// 未認証リクエストには401 Unauthorizedを返す。
+// 未認証リクエストには401 UNAUTHORIZED（統一エラーフォーマット）を返す。
 func NewSessionMiddleware(sessionFinder SessionFinder) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// 1. CookieからセッションIDを取得
 			cookie, err := r.Cookie(sessionCookieName)
 			if err != nil || cookie.Value == "" {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteErrorResponse(w, http.StatusUnauthorized, model.NewUnauthorizedError())
 				return
 			}
 
@@ -44,11 +44,11 @@ func NewSessionMiddleware(sessionFinder SessionFinder) func(next http.Handler) h
 				slog.Error("failed to find session",
 					slog.String("error", err.Error()),
 				)
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteErrorResponse(w, http.StatusUnauthorized, model.NewUnauthorizedError())
 				return
 			}
 			if session == nil {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteErrorResponse(w, http.StatusUnauthorized, model.NewUnauthorizedError())
 				return
 			}
 
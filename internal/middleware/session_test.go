@@ -83,6 +83,9 @@ func TestSessionMiddleware_NoSessionCookie_Returns401(t *testing.T) {
 	if resp.StatusCode != http.StatusUnauthorized {
 		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
 	}
+	if body := decodeErrorBody(t, w); body.Code != model.ErrCodeUnauthorized {
+		t.Errorf("code = %q, want %q", body.Code, model.ErrCodeUnauthorized)
+	}
 }
 
 func TestSessionMiddleware_EmptySessionCookie_Returns401(t *testing.T) {
@@ -4,6 +4,8 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+
+	"github.com/hitoshi/feedman/internal/model"
 )
 
 // NewTrustedCIDRMiddleware は信頼 CIDR 範囲内の送信元のみ通過させるミドルウェアを返す。
@@ -16,7 +18,7 @@ import (
 //
 // 信頼 CIDR が空（未設定）の場合は全リクエストを 403 で拒否する（安全側 / NFR 2.1）。
 // X-Forwarded-For は信頼せず、判定には r.RemoteAddr のみを用いる（Requirement 4.3）。
-// 拒否時は next を呼ばず http.Error で即終了し、メトリクス本文を一切応答に含めない（NFR 2.2）。
+// 拒否時は next を呼ばず 403 FORBIDDEN（統一エラーフォーマット）で即終了し、メトリクス本文を一切応答に含めない（NFR 2.2）。
 func NewTrustedCIDRMiddleware(cidrs []string) func(next http.Handler) http.Handler {
 	nets := make([]*net.IPNet, 0, len(cidrs))
 	for _, c := range cidrs {
@@ -34,7 +36,7 @@ func NewTrustedCIDRMiddleware(cidrs []string) func(next http.Handler) http.Handl
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if !isTrustedRemoteAddr(r.RemoteAddr, nets) {
-				http.Error(w, "forbidden", http.StatusForbidden)
+				WriteErrorResponse(w, http.StatusForbidden, model.NewUntrustedNetworkError())
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
 )
 
 // metricsBodyMarker は拒否時にメトリクス本文が漏れていないことを確認するための next 側出力。
@@ -113,6 +115,9 @@ func TestTrustedCIDRMiddleware(t *testing.T) {
 				if strings.Contains(w.Body.String(), metricsBodyMarker) {
 					t.Errorf("拒否時のレスポンスボディにメトリクス本文が含まれている: %q", w.Body.String())
 				}
+				if body := decodeErrorBody(t, w); body.Code != model.ErrCodeForbidden {
+					t.Errorf("code = %q, want %q", body.Code, model.ErrCodeForbidden)
+				}
 			}
 		})
 	}
@@ -0,0 +1,28 @@
+package middleware
+
+import "net/http"
+
+// UsageRecorder は認証済みリクエストの API 利用量の記録先。
+// RecordRequest はリクエストごとに呼ばれるため、ブロックせずに戻ること。
+type UsageRecorder interface {
+	RecordRequest(userID, userAgent string, status int)
+}
+
+// NewUsageMiddleware はリクエストの応答ステータスを API 利用量として recorder に記録するミドルウェアを返す。
+// Session ミドルウェアの内側に置き、コンテキストにユーザーIDが無いリクエストは記録しない。
+func NewUsageMiddleware(recorder UsageRecorder) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &statusRecorder{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(rec, r)
+
+			if userID, err := UserIDFromContext(r.Context()); err == nil && userID != "" {
+				recorder.RecordRequest(userID, r.UserAgent(), rec.statusCode)
+			}
+		})
+	}
+}
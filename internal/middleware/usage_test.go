@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// usageCall は mockUsageRecorder に記録された呼び出し 1 回分。
+type usageCall struct {
+	userID    string
+	userAgent string
+	status    int
+}
+
+// mockUsageRecorder は UsageRecorder のモック実装。
+type mockUsageRecorder struct {
+	calls []usageCall
+}
+
+func (m *mockUsageRecorder) RecordRequest(userID, userAgent string, status int) {
+	m.calls = append(m.calls, usageCall{userID: userID, userAgent: userAgent, status: status})
+}
+
+func TestUsageMiddleware(t *testing.T) {
+	t.Run("認証済みリクエストのユーザーID・User-Agent・ステータスを記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockUsageRecorder{}
+		handler := NewUsageMiddleware(recorder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/api/items/x", nil)
+		req.Header.Set("User-Agent", "curl/8.0")
+		req = req.WithContext(ContextWithUserID(req.Context(), "user-1"))
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if len(recorder.calls) != 1 {
+			t.Fatalf("calls = %d, want 1", len(recorder.calls))
+		}
+		if got := recorder.calls[0]; got != (usageCall{userID: "user-1", userAgent: "curl/8.0", status: http.StatusNotFound}) {
+			t.Errorf("call = %+v", got)
+		}
+	})
+
+	t.Run("ステータスを明示しないとき200として記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockUsageRecorder{}
+		handler := NewUsageMiddleware(recorder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds", nil)
+		req = req.WithContext(ContextWithUserID(req.Context(), "user-1"))
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if len(recorder.calls) != 1 || recorder.calls[0].status != http.StatusOK {
+			t.Errorf("calls = %+v, want one call with status 200", recorder.calls)
+		}
+	})
+
+	t.Run("ユーザーIDが無いリクエストは記録しない", func(t *testing.T) {
+		// Arrange
+		recorder := &mockUsageRecorder{}
+		handler := NewUsageMiddleware(recorder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		req := httptest.NewRequest(http.MethodGet, "/api/feeds", nil)
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if len(recorder.calls) != 0 {
+			t.Errorf("calls = %d, want 0", len(recorder.calls))
+		}
+	})
+}
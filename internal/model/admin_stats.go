@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// FetchAttempt はフェッチ 1 回分の結果を表す。fetch_attempts に対応する。
+// 304（未変更）も成功として扱う。
+type FetchAttempt struct {
+	FeedID      string
+	Succeeded   bool
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+// AdminStats はインスタンス全体の統計を表す。admin_stats マテリアライズドビューに対応し、
+// 値は RefreshedAt 時点のスナップショット。
+type AdminStats struct {
+	UserCount int64
+	FeedCount int64
+	ItemCount int64
+	// FetchAttempts24h / FetchSuccesses24h はスナップショット時点から直近 24 時間のフェッチ回数と成功回数。
+	FetchAttempts24h  int64
+	FetchSuccesses24h int64
+	// AvgFetchDurationMs24h は直近 24 時間のフェッチ所要時間の平均（ミリ秒）。フェッチが無い場合は 0。
+	AvgFetchDurationMs24h float64
+	// DatabaseSizeBytes は pg_database_size による DB サイズの概算。
+	DatabaseSizeBytes int64
+	RefreshedAt       time.Time
+}
+
+// FetchSuccessRate24h は直近 24 時間のフェッチ成功率（0〜1）を返す。フェッチが無い場合は nil。
+func (s *AdminStats) FetchSuccessRate24h() *float64 {
+	if s.FetchAttempts24h == 0 {
+		return nil
+	}
+	rate := float64(s.FetchSuccesses24h) / float64(s.FetchAttempts24h)
+	return &rate
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+const (
+	// APIUsageDays は API 利用量（GET /api/usage）で返す日数。
+	APIUsageDays = 30
+	// APIUsageRetention は API 利用量カウンタの保持期間。
+	APIUsageRetention = 90 * 24 * time.Hour
+)
+
+// APIUsage はユーザー×日×クライアント単位の API 利用量を表す。api_usage_daily に対応する。
+type APIUsage struct {
+	UserID string
+	// Date は集計日（00:00 UTC）。
+	Date time.Time
+	// Client は User-Agent から分類したクライアント名（browser / unknown / 製品名の小文字）。
+	Client       string
+	RequestCount int64
+	// ErrorCount はステータス 400 以上で応答したリクエスト数。
+	ErrorCount int64
+}
+
+// UsageDate は t を含む日の開始時刻（00:00 UTC）を返す。
+func UsageDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// AuditAction は監査ログに記録する操作の種別を表す。
+type AuditAction string
+
+const (
+	// AuditActionSubscriptionCreate はフィードの購読を表す。
+	AuditActionSubscriptionCreate AuditAction = "subscription.create"
+	// AuditActionSubscriptionDelete は購読解除を表す。
+	AuditActionSubscriptionDelete AuditAction = "subscription.delete"
+	// AuditActionSubscriptionRestore は購読解除の取り消しを表す。
+	AuditActionSubscriptionRestore AuditAction = "subscription.restore"
+	// AuditActionSubscriptionUpdateSettings は購読設定（フェッチ間隔）の変更を表す。
+	AuditActionSubscriptionUpdateSettings AuditAction = "subscription.update_settings"
+	// AuditActionSubscriptionResume は停止フィードのフェッチ再開を表す。
+	AuditActionSubscriptionResume AuditAction = "subscription.resume"
+	// AuditActionSubscriptionApplySuggestedFeedURL は停止フィードへの URL 張り替え提案の適用（URL 変更とフェッチ再開）を表す。
+	AuditActionSubscriptionApplySuggestedFeedURL AuditAction = "subscription.apply_suggested_feed_url"
+)
+
+const (
+	// DefaultAuditLogLimit は監査ログ一覧の 1 ページあたりの既定件数。
+	DefaultAuditLogLimit = 50
+	// MaxAuditLogLimit は監査ログ一覧の 1 ページあたりの最大件数。
+	MaxAuditLogLimit = 200
+)
+
+// AuditLog はユーザー操作の履歴 1 件を表す。audit_logs に対応する。
+type AuditLog struct {
+	ID     string
+	UserID string
+	Action AuditAction
+	// Target は操作対象の ID（購読操作ではフィードID）。
+	Target string
+	// Payload は操作時点の補足情報（購読ID・変更前後の値など）。JSONB として保存する。
+	Payload   map[string]any
+	CreatedAt time.Time
+}
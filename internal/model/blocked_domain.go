@@ -0,0 +1,60 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BlockKind はブロックリストの項目の照合方法を表す。
+type BlockKind string
+
+const (
+	// BlockKindDomain はドメインとそのサブドメインのフィードをブロックする。
+	BlockKindDomain BlockKind = "domain"
+	// BlockKindURL は特定のフィード URL のみをブロックする。
+	BlockKindURL BlockKind = "url"
+)
+
+// BlockedDomain はインスタンス全体でフィードの登録をブロックするドメイン・URL を表す。blocked_domains に対応する。
+// Pattern は正規化済みの値で、Kind が domain の場合は小文字のホスト名、url の場合はスキームとホストを小文字にした URL。
+type BlockedDomain struct {
+	ID      string
+	Pattern string
+	Kind    BlockKind
+	Reason  string
+	// CreatedBy は追加した管理者のユーザーID。ユーザーの削除後は空文字。
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// Matches は rawURL がこの項目に一致するかを返す。
+// domain の項目はホストが Pattern と一致するか Pattern のサブドメインの場合に一致する
+// （"example.com" は "feeds.example.com" に一致し、"badexample.com" には一致しない）。
+// url の項目はスキームとホストの大文字・小文字を区別せずに URL 全体が一致する場合に一致する。
+func (b BlockedDomain) Matches(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch b.Kind {
+	case BlockKindDomain:
+		host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+		return host == b.Pattern || strings.HasSuffix(host, "."+b.Pattern)
+	case BlockKindURL:
+		return NormalizeBlockedURL(u) == b.Pattern
+	default:
+		return false
+	}
+}
+
+// NormalizeBlockedURL は url の項目の照合に用いる形に URL を正規化する。
+// スキームとホストを小文字にし、フラグメントを除去する。パスとクエリはそのまま残す。
+func NormalizeBlockedURL(u *url.URL) string {
+	normalized := *u
+	normalized.Scheme = strings.ToLower(u.Scheme)
+	normalized.Host = strings.ToLower(u.Host)
+	normalized.Fragment = ""
+	normalized.RawFragment = ""
+	return normalized.String()
+}
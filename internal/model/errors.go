@@ -1,7 +1,10 @@
 // Package model はドメインモデルを定義する。
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // APIError は統一エラーフォーマットを表す。
 // UIに表示する原因カテゴリと対処方法を含む。
@@ -14,6 +17,19 @@ type APIError struct {
 	Category string         // カテゴリ: auth, validation, feed, system
 	Action   string         // ユーザー向け対処方法
 	Details  map[string]any // 任意の構造化追加情報（429 等で retry_after_seconds 等を載せる）
+	// RequestID はエラーを返したリクエストの ID。空の場合は書き出し時にリクエストの ID で補う。
+	RequestID string
+	// DocsURL はエラーコードの解説ページ URL。空の場合は書き出し時に ErrorDocsURL(Code) で補う。
+	DocsURL string
+}
+
+// ErrorDocsBaseURL はエラーコードの解説ページ（docs/errors.md）の URL。
+// 各エラーコードはページ内の見出しアンカー（コードの小文字表記）で参照する。
+const ErrorDocsBaseURL = "https://github.com/hitoshiichikawa/feedman/blob/main/docs/errors.md"
+
+// ErrorDocsURL はエラーコードの解説ページ URL を返す。
+func ErrorDocsURL(code string) string {
+	return ErrorDocsBaseURL + "#" + strings.ToLower(code)
 }
 
 // Error はerrorインターフェースを実装する。
@@ -23,8 +39,16 @@ func (e *APIError) Error() string {
 
 // 定義済みエラーコード
 const (
+	ErrCodeUnauthorized          = "UNAUTHORIZED"
+	ErrCodeInvalidRequest        = "INVALID_REQUEST"
+	ErrCodeForbidden             = "FORBIDDEN"
+	ErrCodeInternal              = "INTERNAL_ERROR"
+	ErrCodeFeedNotFound          = "FEED_NOT_FOUND"
+	ErrCodeDuplicateSubscription = "DUPLICATE_SUBSCRIPTION"
+
 	ErrCodeFeedNotDetected      = "FEED_NOT_DETECTED"
 	ErrCodeInvalidURL           = "INVALID_URL"
+	ErrCodeInvalidURLScheme     = "INVALID_URL_SCHEME"
 	ErrCodeSSRFBlocked          = "SSRF_BLOCKED"
 	ErrCodeFetchFailed          = "FETCH_FAILED"
 	ErrCodeParseFailed          = "PARSE_FAILED"
@@ -39,6 +63,79 @@ const (
 	ErrCodeFeedCooldown         = "FEED_COOLDOWN"
 	ErrCodeInvalidSearchQuery   = "INVALID_SEARCH_QUERY"
 	ErrCodeFeedNotSubscribed    = "FEED_NOT_SUBSCRIBED"
+	ErrCodeProfileNotFound      = "PROFILE_NOT_FOUND"
+	ErrCodeInvalidProfileSlug   = "INVALID_PROFILE_SLUG"
+	ErrCodeProfileSlugTaken     = "PROFILE_SLUG_TAKEN"
+
+	ErrCodeInvalidUnreadWarningThreshold = "INVALID_UNREAD_WARNING_THRESHOLD"
+	ErrCodeInvalidDebugParseInput        = "INVALID_DEBUG_PARSE_INPUT"
+	ErrCodeInvalidStatsPeriod            = "INVALID_STATS_PERIOD"
+	ErrCodeInvalidImportFilter           = "INVALID_IMPORT_FILTER"
+	ErrCodeInvalidTimezone               = "INVALID_TIMEZONE"
+
+	ErrCodeSubscriptionRestoreExpired = "SUBSCRIPTION_RESTORE_EXPIRED"
+
+	ErrCodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+
+	ErrCodeItemLinkUnavailable = "ITEM_LINK_UNAVAILABLE"
+
+	ErrCodeTeamNotFound          = "TEAM_NOT_FOUND"
+	ErrCodeInvalidTeamName       = "INVALID_TEAM_NAME"
+	ErrCodeTeamInvitationInvalid = "TEAM_INVITATION_INVALID"
+	ErrCodeTeamMemberLimit       = "TEAM_MEMBER_LIMIT"
+	ErrCodeAlreadyTeamMember     = "ALREADY_TEAM_MEMBER"
+	ErrCodeTeamOwnerCannotLeave  = "TEAM_OWNER_CANNOT_LEAVE"
+	ErrCodeDuplicateTeamFeed     = "DUPLICATE_TEAM_FEED"
+
+	ErrCodeInvalidRetentionOverride = "INVALID_RETENTION_OVERRIDE"
+
+	ErrCodeInvalidSubscriptionOrder = "INVALID_SUBSCRIPTION_ORDER"
+
+	ErrCodeInvalidNotificationSetting = "INVALID_NOTIFICATION_SETTING"
+
+	ErrCodeInvalidIdempotencyKey  = "INVALID_IDEMPOTENCY_KEY"
+	ErrCodeIdempotencyKeyInUse    = "IDEMPOTENCY_KEY_IN_USE"
+	ErrCodeIdempotencyKeyMismatch = "IDEMPOTENCY_KEY_MISMATCH"
+
+	ErrCodeFeedURLSuggestionNotFound = "FEED_URL_SUGGESTION_NOT_FOUND"
+	ErrCodeFeedURLTaken              = "FEED_URL_TAKEN"
+
+	ErrCodeInvalidTitleUpdatePolicy = "INVALID_TITLE_UPDATE_POLICY"
+	ErrCodePendingTitleNotFound     = "PENDING_TITLE_NOT_FOUND"
+
+	ErrCodeIntegrationNotFound = "INTEGRATION_NOT_FOUND"
+	ErrCodeInvalidIntegration  = "INVALID_INTEGRATION"
+	ErrCodeIntegrationLimit    = "INTEGRATION_LIMIT"
+
+	ErrCodeReadLaterNotConnected    = "READ_LATER_NOT_CONNECTED"
+	ErrCodeInvalidReadLaterProvider = "INVALID_READ_LATER_PROVIDER"
+	ErrCodeReadLaterAuthFailed      = "READ_LATER_AUTH_FAILED"
+	ErrCodeReadLaterUnavailable     = "READ_LATER_UNAVAILABLE"
+
+	ErrCodeFeedBlocked           = "FEED_BLOCKED"
+	ErrCodeInvalidBlockedDomain  = "INVALID_BLOCKED_DOMAIN"
+	ErrCodeBlockedDomainNotFound = "BLOCKED_DOMAIN_NOT_FOUND"
+
+	ErrCodeItemNotSummarizable  = "ITEM_NOT_SUMMARIZABLE"
+	ErrCodeSummarizeRateLimited = "SUMMARIZE_RATE_LIMITED"
+	ErrCodeSummaryUnavailable   = "SUMMARY_UNAVAILABLE"
+
+	ErrCodeInvalidSyncOperations = "INVALID_SYNC_OPERATIONS"
+
+	ErrCodeFeedRawCaptureNotFound = "FEED_RAW_CAPTURE_NOT_FOUND"
+
+	ErrCodeFeedRedirectLoop = "FEED_REDIRECT_LOOP"
+
+	ErrCodeIdentityNotFound = "IDENTITY_NOT_FOUND"
+	ErrCodeLastIdentity     = "LAST_IDENTITY"
+
+	ErrCodeInvalidBatchFeedURLs          = "INVALID_BATCH_FEED_URLS"
+	ErrCodeFeedRegistrationBatchNotFound = "FEED_REGISTRATION_BATCH_NOT_FOUND"
+
+	ErrCodeInvalidHighlightPeriod = "INVALID_HIGHLIGHT_PERIOD"
+
+	ErrCodeInvalidEmail      = "INVALID_EMAIL"
+	ErrCodeInvalidLoginToken = "INVALID_LOGIN_TOKEN"
 )
 
 // NewItemNotFoundError は記事未検出エラーを生成する。
@@ -51,13 +148,24 @@ func NewItemNotFoundError(itemID string) *APIError {
 	}
 }
 
+// NewItemLinkUnavailableError は元記事へのリンクが無い（または http/https 以外の）記事への
+// 訪問を拒否するエラーを生成する。
+func NewItemLinkUnavailableError(itemID string) *APIError {
+	return &APIError{
+		Code:     ErrCodeItemLinkUnavailable,
+		Message:  fmt.Sprintf("この記事には元記事へのリンクがありません: %s", itemID),
+		Category: "feed",
+		Action:   "記事詳細から本文を確認してください。",
+	}
+}
+
 // NewInvalidFilterError は無効なフィルタエラーを生成する。
 func NewInvalidFilterError(filter string) *APIError {
 	return &APIError{
 		Code:     ErrCodeInvalidFilter,
 		Message:  fmt.Sprintf("無効なフィルタです: %s", filter),
 		Category: "validation",
-		Action:   "フィルタには all、unread、starred のいずれかを指定してください。",
+		Action:   "filter には all、unread、starred のいずれかを、unread・starred には true か false を指定してください。",
 	}
 }
 
@@ -81,6 +189,30 @@ func NewInvalidURLError(reason string) *APIError {
 	}
 }
 
+// NewInvalidURLSchemeError は http / https 以外のスキームの URL を登録しようとしたときのエラーを生成する。
+// scheme が空の場合はスキームのない入力（相対パスやローカルのファイルパス）を表す。
+// Details の scheme で拒否したスキームを返す。
+func NewInvalidURLSchemeError(scheme string) *APIError {
+	var msg string
+	switch scheme {
+	case "":
+		msg = "URLにスキーム（https:// など）が含まれていません。"
+	case "file":
+		msg = "ローカルファイル（file://）は登録できません。"
+	default:
+		msg = fmt.Sprintf("%s:// のURLは登録できません。対応しているのは http:// と https:// のみです。", scheme)
+	}
+	return &APIError{
+		Code:     ErrCodeInvalidURLScheme,
+		Message:  msg,
+		Category: "validation",
+		Action:   "http:// または https:// で始まるWebサイトまたはフィードのURLを入力してください。",
+		Details: map[string]any{
+			"scheme": scheme,
+		},
+	}
+}
+
 // NewSSRFBlockedError はSSRFブロックエラーを生成する。
 func NewSSRFBlockedError() *APIError {
 	return &APIError{
@@ -101,6 +233,17 @@ func NewFetchFailedError(reason string) *APIError {
 	}
 }
 
+// NewFeedRedirectLoopError はフィードの URL がリダイレクトの循環・上限超過を起こす場合や、
+// HTML ページが自分自身をフィードとして参照している場合のエラーを生成する。
+func NewFeedRedirectLoopError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeFeedRedirectLoop,
+		Message:  fmt.Sprintf("リダイレクトが循環しているためフィードを取得できません: %s", reason),
+		Category: "feed",
+		Action:   "ブラウザで開いたときの最終的なフィードの URL を直接指定してください。",
+	}
+}
+
 // NewParseFailedError はパース失敗エラーを生成する。
 func NewParseFailedError() *APIError {
 	return &APIError{
@@ -124,7 +267,7 @@ func NewSubscriptionLimitError() *APIError {
 // NewDuplicateSubscriptionError は既に購読済みのフィードを再度登録しようとした場合のエラーを生成する。
 func NewDuplicateSubscriptionError() *APIError {
 	return &APIError{
-		Code:     "DUPLICATE_SUBSCRIPTION",
+		Code:     ErrCodeDuplicateSubscription,
 		Message:  "このフィードは既に購読しています。",
 		Category: "feed",
 		Action:   "購読一覧から該当フィードを確認してください。",
@@ -220,3 +363,562 @@ func NewFeedNotSubscribedError(feedID string) *APIError {
 		Action:   "購読中のフィードを指定するか、横断検索を利用してください。",
 	}
 }
+
+// NewProfileNotFoundError は公開プロフィールが存在しない、または非公開の場合のエラーを生成する。
+// 非公開と未登録を区別せず同一のエラーとし、スラッグの存在有無を外部に漏らさない。
+func NewProfileNotFoundError(slug string) *APIError {
+	return &APIError{
+		Code:     ErrCodeProfileNotFound,
+		Message:  fmt.Sprintf("公開プロフィールが見つかりません: %s", slug),
+		Category: "feed",
+		Action:   "URLを確認してください。",
+	}
+}
+
+// NewInvalidProfileSlugError は公開プロフィールのスラッグが形式不正の場合のエラーを生成する。
+func NewInvalidProfileSlugError(slug string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidProfileSlug,
+		Message:  fmt.Sprintf("無効なスラッグです: %s", slug),
+		Category: "validation",
+		Action:   "スラッグは英小文字・数字・ハイフンの3〜32文字で、先頭は英小文字または数字にしてください。",
+	}
+}
+
+// NewProfileSlugTakenError は公開プロフィールのスラッグが他のユーザーに使用済みの場合のエラーを生成する。
+func NewProfileSlugTakenError(slug string) *APIError {
+	return &APIError{
+		Code:     ErrCodeProfileSlugTaken,
+		Message:  fmt.Sprintf("このスラッグは既に使用されています: %s", slug),
+		Category: "validation",
+		Action:   "別のスラッグを指定してください。",
+	}
+}
+
+// NewInvalidUnreadWarningThresholdError は積読警告の閾値が無効な場合のエラーを生成する。
+func NewInvalidUnreadWarningThresholdError(threshold int) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidUnreadWarningThreshold,
+		Message:  fmt.Sprintf("無効な積読警告の閾値です: %d件", threshold),
+		Category: "validation",
+		Action:   fmt.Sprintf("閾値は0（警告無効）から%d件の範囲で指定してください。", MaxUnreadWarningThreshold),
+	}
+}
+
+// NewInvalidTimezoneError はタイムゾーン名が無効な場合のエラーを生成する。
+func NewInvalidTimezoneError(timezone string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidTimezone,
+		Message:  fmt.Sprintf("無効なタイムゾーンです: %q", timezone),
+		Category: "validation",
+		Action:   "IANA タイムゾーン名（例: Asia/Tokyo）を指定してください。",
+	}
+}
+
+// NewUnauthorizedError は認証が必要なエンドポイントにセッションなし、または無効なセッションで
+// アクセスした場合のエラーを生成する。
+func NewUnauthorizedError() *APIError {
+	return &APIError{
+		Code:     ErrCodeUnauthorized,
+		Message:  "認証が必要です。",
+		Category: "auth",
+		Action:   "ログインしてください。",
+	}
+}
+
+// NewUntrustedNetworkError は信頼ネットワーク限定のエンドポイント（/metrics）に
+// 信頼 CIDR 外からアクセスした場合のエラーを生成する。
+func NewUntrustedNetworkError() *APIError {
+	return &APIError{
+		Code:     ErrCodeForbidden,
+		Message:  "このネットワークからのアクセスは許可されていません。",
+		Category: "authorization",
+		Action:   "信頼ネットワーク（METRICS_TRUSTED_CIDRS）内からアクセスしてください。",
+	}
+}
+
+// NewAdminRequiredError は管理者限定のエンドポイントに一般ユーザーがアクセスした場合のエラーを生成する。
+func NewAdminRequiredError() *APIError {
+	return &APIError{
+		Code:     ErrCodeForbidden,
+		Message:  "この操作は管理者のみ実行できます。",
+		Category: "authorization",
+		Action:   "管理者権限を持つアカウントでログインしてください。",
+	}
+}
+
+// NewTeamFeedRemovalForbiddenError はオーナーでも追加者でもないメンバーがチームの購読フィードを
+// 削除しようとした場合のエラーを生成する。
+func NewTeamFeedRemovalForbiddenError() *APIError {
+	return &APIError{
+		Code:     ErrCodeForbidden,
+		Message:  "このフィードをチームの購読から削除する権限がありません。",
+		Category: "authorization",
+		Action:   "チームのオーナーか、フィードを追加したメンバーに削除を依頼してください。",
+	}
+}
+
+// NewCrossOriginRequestError は同一オリジン限定のエンドポイントに、許可されていないオリジン
+// （または Origin ヘッダーなし）からリクエストされた場合のエラーを生成する。
+func NewCrossOriginRequestError() *APIError {
+	return &APIError{
+		Code:     ErrCodeForbidden,
+		Message:  "このオリジンからのリクエストは受け付けていません。",
+		Category: "authorization",
+		Action:   "Feedman の画面から操作してください。",
+	}
+}
+
+// NewInvalidDebugParseInputError はフィードパース診断の入力（URL / 生XML）が不正な場合のエラーを生成する。
+func NewInvalidDebugParseInputError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidDebugParseInput,
+		Message:  fmt.Sprintf("診断の入力が無効です: %s", reason),
+		Category: "validation",
+		Action:   "url と xml のどちらか一方を指定してください。",
+	}
+}
+
+// NewInvalidStatsPeriodError は閲覧統計の集計期間の指定が不正な場合のエラーを生成する。
+func NewInvalidStatsPeriodError(period string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidStatsPeriod,
+		Message:  fmt.Sprintf("無効な集計期間です: %s", period),
+		Category: "validation",
+		Action:   fmt.Sprintf("period は 1d から %dd の範囲で「30d」の形式で指定してください。", MaxStatsPeriodDays),
+	}
+}
+
+// NewInvalidImportFilterError は記事取り込みフィルタの指定が不正な場合のエラーを生成する。
+func NewInvalidImportFilterError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidImportFilter,
+		Message:  fmt.Sprintf("無効な取り込みフィルタです: %s", reason),
+		Category: "validation",
+		Action: fmt.Sprintf("著者は %d 件以内（各 %d 文字以内）、タイトルの正規表現は %d 文字以内の有効な形式で指定してください。",
+			MaxImportFilterAuthors, MaxImportFilterAuthorLength, MaxImportFilterTitlePatternLength),
+	}
+}
+
+// NewSubscriptionRestoreExpiredError は購読解除の取り消し期限を過ぎた（または取り消し対象が無い）場合のエラーを生成する。
+func NewSubscriptionRestoreExpiredError() *APIError {
+	return &APIError{
+		Code:     ErrCodeSubscriptionRestoreExpired,
+		Message:  "購読解除を取り消せる期限を過ぎています。",
+		Category: "feed",
+		Action:   "フィードを再度登録してください。",
+	}
+}
+
+// NewPayloadTooLargeError はリクエストボディが上限サイズを超えた場合のエラーを生成する。
+// HTTP 413 にマップされ、Details["max_bytes"] に上限バイト数（int64）を載せる。
+func NewPayloadTooLargeError(maxBytes int64) *APIError {
+	return &APIError{
+		Code:     ErrCodePayloadTooLarge,
+		Message:  "リクエストボディが大きすぎます。",
+		Category: "validation",
+		Action:   fmt.Sprintf("%d バイト以下のサイズで送信してください。", maxBytes),
+		Details: map[string]any{
+			"max_bytes": maxBytes,
+		},
+	}
+}
+
+// NewTeamNotFoundError はチームが存在しない（または自分が所属していない）場合のエラーを生成する。
+// 所属していないチームの存在を明かさないため、両者を区別しない。
+func NewTeamNotFoundError(teamID string) *APIError {
+	return &APIError{
+		Code:     ErrCodeTeamNotFound,
+		Message:  fmt.Sprintf("指定されたチームが見つかりません: %s", teamID),
+		Category: "feed",
+		Action:   "チームIDを確認してください。",
+	}
+}
+
+// NewInvalidTeamNameError はチーム名が空または長すぎる場合のエラーを生成する。
+func NewInvalidTeamNameError() *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidTeamName,
+		Message:  "無効なチーム名です。",
+		Category: "validation",
+		Action:   fmt.Sprintf("チーム名は 1〜%d 文字で指定してください。", MaxTeamNameLength),
+	}
+}
+
+// NewTeamInvitationInvalidError は招待トークンが無効・期限切れ・使用済みの場合のエラーを生成する。
+func NewTeamInvitationInvalidError() *APIError {
+	return &APIError{
+		Code:     ErrCodeTeamInvitationInvalid,
+		Message:  "招待が無効か、期限切れです。",
+		Category: "feed",
+		Action:   "チームのメンバーに新しい招待を発行してもらってください。",
+	}
+}
+
+// NewTeamMemberLimitError はチームのメンバー数が上限に達している場合のエラーを生成する。
+func NewTeamMemberLimitError() *APIError {
+	return &APIError{
+		Code:     ErrCodeTeamMemberLimit,
+		Message:  fmt.Sprintf("チームのメンバー数が上限（%d人）に達しています。", MaxTeamMembers),
+		Category: "feed",
+		Action:   "不要なメンバーに脱退してもらってから再度お試しください。",
+	}
+}
+
+// NewAlreadyTeamMemberError は既に所属しているチームの招待を受諾しようとした場合のエラーを生成する。
+func NewAlreadyTeamMemberError() *APIError {
+	return &APIError{
+		Code:     ErrCodeAlreadyTeamMember,
+		Message:  "既にこのチームのメンバーです。",
+		Category: "feed",
+		Action:   "チーム一覧から確認してください。",
+	}
+}
+
+// NewTeamOwnerCannotLeaveError はオーナーがチームから脱退しようとした場合のエラーを生成する。
+func NewTeamOwnerCannotLeaveError() *APIError {
+	return &APIError{
+		Code:     ErrCodeTeamOwnerCannotLeave,
+		Message:  "チームのオーナーは脱退できません。",
+		Category: "feed",
+		Action:   "チームを共有したまま利用を続けてください。",
+	}
+}
+
+// NewDuplicateTeamFeedError は既にチームで購読しているフィードを追加しようとした場合のエラーを生成する。
+func NewDuplicateTeamFeedError() *APIError {
+	return &APIError{
+		Code:     ErrCodeDuplicateTeamFeed,
+		Message:  "このフィードは既にチームで購読しています。",
+		Category: "feed",
+		Action:   "チームの購読一覧を確認してください。",
+	}
+}
+
+// NewInvalidRetentionOverrideError は購読単位の最大記事保持数が範囲外の場合のエラーを生成する。
+func NewInvalidRetentionOverrideError(maxItems int) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidRetentionOverride,
+		Message:  fmt.Sprintf("無効な最大記事保持数です: %d件", maxItems),
+		Category: "validation",
+		Action:   fmt.Sprintf("最大記事保持数は%d件から%d件の範囲で指定してください（解除する場合は null）。", MinRetentionOverride, MaxRetentionOverride),
+	}
+}
+
+// NewInvalidNotificationSettingError は購読の通知ヒント設定（優先度・ミュート期限）が不正な場合のエラーを生成する。
+func NewInvalidNotificationSettingError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidNotificationSetting,
+		Message:  fmt.Sprintf("通知設定が不正です: %s", reason),
+		Category: "validation",
+		Action:   "priority は high / normal / low のいずれか、mute_until は未来の日時（解除する場合は null）を指定してください。",
+	}
+}
+
+// NewInvalidSubscriptionOrderError は購読の並び順の一括更新リクエストが不正な場合のエラーを生成する。
+func NewInvalidSubscriptionOrderError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidSubscriptionOrder,
+		Message:  fmt.Sprintf("購読の並び順の指定が不正です: %s", reason),
+		Category: "validation",
+		Action:   fmt.Sprintf("購読IDを重複なく1件以上%d件以内で指定してください。", MaxSubscriptionOrderEntries),
+	}
+}
+
+// NewInvalidIdempotencyKeyError は Idempotency-Key ヘッダの形式が不正な場合のエラーを生成する。
+func NewInvalidIdempotencyKeyError() *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidIdempotencyKey,
+		Message:  "Idempotency-Key ヘッダの形式が不正です。",
+		Category: "validation",
+		Action:   fmt.Sprintf("Idempotency-Key には空白を含まない%dバイト以内の ASCII 文字列（UUID 等）を指定してください。", MaxIdempotencyKeyLength),
+	}
+}
+
+// NewIdempotencyKeyInUseError は同じ Idempotency-Key の最初のリクエストがまだ処理中の場合のエラーを生成する。
+func NewIdempotencyKeyInUseError() *APIError {
+	return &APIError{
+		Code:     ErrCodeIdempotencyKeyInUse,
+		Message:  "同じ Idempotency-Key のリクエストを処理中です。",
+		Category: "validation",
+		Action:   "しばらく待ってから同じ Idempotency-Key で再送してください。",
+	}
+}
+
+// NewIdempotencyKeyMismatchError は同じ Idempotency-Key が異なる内容のリクエストに使われた場合のエラーを生成する。
+func NewIdempotencyKeyMismatchError() *APIError {
+	return &APIError{
+		Code:     ErrCodeIdempotencyKeyMismatch,
+		Message:  "この Idempotency-Key は別の内容のリクエストで使用済みです。",
+		Category: "validation",
+		Action:   "新しいリクエストには新しい Idempotency-Key を指定してください。",
+	}
+}
+
+// NewFeedURLSuggestionNotFoundError は適用できるフィード URL の張り替え提案がない場合のエラーを生成する。
+func NewFeedURLSuggestionNotFoundError() *APIError {
+	return &APIError{
+		Code:     ErrCodeFeedURLSuggestionNotFound,
+		Message:  "このフィードには URL 変更の提案がありません。",
+		Category: "feed",
+		Action:   "提案はフェッチが 404/410 で停止し、サイトから新しいフィード URL が見つかった場合にのみ作成されます。フィード URL を直接変更するか、フェッチを再開してください。",
+	}
+}
+
+// NewFeedURLTakenError は張り替え先のフィード URL が別のフィードとして登録済みの場合のエラーを生成する。
+func NewFeedURLTakenError() *APIError {
+	return &APIError{
+		Code:     ErrCodeFeedURLTaken,
+		Message:  "提案されたフィード URL は別のフィードとして登録済みです。",
+		Category: "feed",
+		Action:   "提案された URL（購読一覧の suggested_feed_url）のフィードを購読し、停止したフィードの購読を解除してください。",
+	}
+}
+
+// NewInvalidTitleUpdatePolicyError はフィードタイトルの自動更新ポリシーが未知の値の場合のエラーを生成する。
+func NewInvalidTitleUpdatePolicyError(policy string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidTitleUpdatePolicy,
+		Message:  fmt.Sprintf("未知のタイトル自動更新ポリシーです: %q", policy),
+		Category: "validation",
+		Action:   "policy は always / initial / manual のいずれかを指定してください。",
+	}
+}
+
+// NewPendingTitleNotFoundError は承認待ちのフィードタイトルがない場合のエラーを生成する。
+func NewPendingTitleNotFoundError() *APIError {
+	return &APIError{
+		Code:     ErrCodePendingTitleNotFound,
+		Message:  "承認待ちのフィードタイトルがありません。",
+		Category: "feed",
+		Action:   "承認待ちタイトルはポリシーが manual のフィードでタイトルの変更を検知した場合にのみ作成されます。",
+	}
+}
+
+// NewIntegrationNotFoundError は連携設定が存在しない、または他ユーザーの連携設定の場合のエラーを生成する。
+func NewIntegrationNotFoundError(integrationID string) *APIError {
+	return &APIError{
+		Code:     ErrCodeIntegrationNotFound,
+		Message:  fmt.Sprintf("連携設定が見つかりません: %s", integrationID),
+		Category: "feed",
+		Action:   "連携設定の一覧を再読み込みしてください。",
+	}
+}
+
+// NewInvalidIntegrationError は連携設定の入力（種類・Webhook URL・テンプレート）が不正な場合のエラーを生成する。
+func NewInvalidIntegrationError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidIntegration,
+		Message:  fmt.Sprintf("連携設定が不正です: %s", reason),
+		Category: "validation",
+		Action:   "kind は slack / discord のいずれか、webhook_url は各サービスで発行した Webhook URL を指定してください。",
+	}
+}
+
+// NewIntegrationLimitError は連携設定数が上限に達している場合のエラーを生成する。
+func NewIntegrationLimitError() *APIError {
+	return &APIError{
+		Code:     ErrCodeIntegrationLimit,
+		Message:  fmt.Sprintf("連携設定は %d 件までです。", MaxIntegrationsPerUser),
+		Category: "feed",
+		Action:   "不要な連携設定を削除してから追加してください。",
+	}
+}
+
+// NewReadLaterNotConnectedError は「あとで読む」サービスと連携していない場合のエラーを生成する。
+func NewReadLaterNotConnectedError(provider ReadLaterProvider) *APIError {
+	return &APIError{
+		Code:     ErrCodeReadLaterNotConnected,
+		Message:  fmt.Sprintf("%s と連携していません。", provider),
+		Category: "feed",
+		Action:   "連携の一覧を再読み込みし、必要であれば連携し直してください。",
+	}
+}
+
+// NewInvalidReadLaterProviderError は未知の、またはこのインスタンスで利用できない保存先を指定した場合のエラーを生成する。
+func NewInvalidReadLaterProviderError(provider string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidReadLaterProvider,
+		Message:  fmt.Sprintf("利用できない保存先です: %q", provider),
+		Category: "validation",
+		Action:   "GET /api/read-later の available_providers に含まれる保存先を指定してください。",
+	}
+}
+
+// NewReadLaterAuthFailedError は「あとで読む」サービスでの認証・認可に失敗した場合のエラーを生成する。
+func NewReadLaterAuthFailedError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeReadLaterAuthFailed,
+		Message:  fmt.Sprintf("連携先サービスでの認証に失敗しました: %s", reason),
+		Category: "auth",
+		Action:   "アカウント情報を確認し、最初から連携をやり直してください。",
+	}
+}
+
+// NewReadLaterUnavailableError は「あとで読む」サービスに接続できない、またはエラーが返った場合のエラーを生成する。
+func NewReadLaterUnavailableError(provider ReadLaterProvider) *APIError {
+	return &APIError{
+		Code:     ErrCodeReadLaterUnavailable,
+		Message:  fmt.Sprintf("%s に接続できませんでした。", provider),
+		Category: "network",
+		Action:   "しばらく待ってから再度お試しください。",
+	}
+}
+
+// NewFeedBlockedError はフィードの URL がインスタンスのブロックリストに一致する場合のエラーを生成する。
+// どのブロック項目に一致したかは返さない（ブロックリストの内容を一般ユーザーに開示しないため）。
+func NewFeedBlockedError(rawURL string) *APIError {
+	return &APIError{
+		Code:     ErrCodeFeedBlocked,
+		Message:  fmt.Sprintf("このフィードはインスタンスの管理者によってブロックされています: %s", rawURL),
+		Category: "feed",
+		Action:   "別のフィードを登録してください。誤ってブロックされている場合はインスタンスの管理者に問い合わせてください。",
+	}
+}
+
+// NewInvalidBlockedDomainError はブロックリストに追加するドメイン・URL が不正な場合のエラーを生成する。
+func NewInvalidBlockedDomainError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidBlockedDomain,
+		Message:  fmt.Sprintf("ブロックするドメインまたは URL が不正です: %s", reason),
+		Category: "validation",
+		Action:   "pattern には example.com のようなドメイン、または http(s):// で始まるフィード URL を指定してください。",
+	}
+}
+
+// NewBlockedDomainNotFoundError はブロックリストの項目が存在しない場合のエラーを生成する。
+func NewBlockedDomainNotFoundError(id string) *APIError {
+	return &APIError{
+		Code:     ErrCodeBlockedDomainNotFound,
+		Message:  fmt.Sprintf("ブロックリストの項目が見つかりません: %s", id),
+		Category: "feed",
+		Action:   "ブロックリストを再読み込みしてください。",
+	}
+}
+
+// NewItemNotSummarizableError は本文も概要も無く要約できない記事の要約を要求した場合のエラーを生成する。
+func NewItemNotSummarizableError(itemID string) *APIError {
+	return &APIError{
+		Code:     ErrCodeItemNotSummarizable,
+		Message:  fmt.Sprintf("この記事には要約できる本文がありません: %s", itemID),
+		Category: "feed",
+		Action:   "元記事を開いて内容を確認してください。",
+	}
+}
+
+// NewSummarizeRateLimitedError はユーザーあたりの要約生成の回数制限を超えた場合のエラーを生成する。
+// HTTP 429 にマップされ、Details["retry_after_seconds"] に次に要約を生成できるまでの秒数（int）を載せる。
+func NewSummarizeRateLimitedError(retryAfterSeconds int) *APIError {
+	return &APIError{
+		Code:     ErrCodeSummarizeRateLimited,
+		Message:  fmt.Sprintf("要約の生成回数の上限に達しました。再試行まで残り %d 秒です。", retryAfterSeconds),
+		Category: "feed",
+		Action:   "しばらく待ってから再試行してください。",
+		Details: map[string]any{
+			"retry_after_seconds": retryAfterSeconds,
+		},
+	}
+}
+
+// NewSummaryUnavailableError は要約器とフォールバックのいずれでも要約を生成できなかった場合のエラーを生成する。
+func NewSummaryUnavailableError() *APIError {
+	return &APIError{
+		Code:     ErrCodeSummaryUnavailable,
+		Message:  "要約を生成できませんでした。",
+		Category: "feed",
+		Action:   "しばらく待ってから再試行してください。",
+	}
+}
+
+// NewInvalidSyncOperationsError はオフライン同期の操作列、または個々の操作が不正な場合のエラーを生成する。
+// 操作列全体が不正な場合はリクエストを 400 で拒否し、個々の操作が不正な場合は操作ごとの結果に載せる。
+func NewInvalidSyncOperationsError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidSyncOperations,
+		Message:  fmt.Sprintf("同期する操作の指定が不正です: %s", reason),
+		Category: "validation",
+		Action:   fmt.Sprintf("操作は1件以上%d件以内で、type（read / star）・item_id・value・client_timestamp を指定してください。", MaxSyncOperations),
+	}
+}
+
+// NewFeedRawCaptureNotFoundError はフィードのフェッチレスポンスが保存されていない場合のエラーを生成する。
+func NewFeedRawCaptureNotFoundError(feedID string) *APIError {
+	return &APIError{
+		Code:     ErrCodeFeedRawCaptureNotFound,
+		Message:  fmt.Sprintf("保存されたフェッチレスポンスがありません: %s", feedID),
+		Category: "feed",
+		Action:   "フェッチレスポンスの保存を有効にし、次回のフェッチ後に再度取得してください。",
+	}
+}
+
+// NewIdentityNotFoundError は解除しようとした連携（identity）が存在しない、または他ユーザーのものである場合のエラーを生成する。
+func NewIdentityNotFoundError(identityID string) *APIError {
+	return &APIError{
+		Code:     ErrCodeIdentityNotFound,
+		Message:  fmt.Sprintf("指定されたアカウント連携が見つかりません: %s", identityID),
+		Category: "auth",
+		Action:   "アカウント連携の一覧を取得し直してください。",
+	}
+}
+
+// NewLastIdentityError は最後の 1 つのアカウント連携（identity）を解除しようとした場合のエラーを生成する。
+// 解除するとどのプロバイダでもログインできなくなるため拒否する。
+func NewLastIdentityError() *APIError {
+	return &APIError{
+		Code:     ErrCodeLastIdentity,
+		Message:  "最後のアカウント連携は解除できません。",
+		Category: "auth",
+		Action:   "ログインできなくなるのを防ぐため、他のプロバイダと連携してから解除してください。",
+	}
+}
+
+// NewInvalidBatchFeedURLsError は一括登録する URL の配列が空、または上限を超える場合のエラーを生成する。
+func NewInvalidBatchFeedURLsError(reason string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidBatchFeedURLs,
+		Message:  fmt.Sprintf("一括登録する URL の指定が不正です: %s", reason),
+		Category: "validation",
+		Action:   fmt.Sprintf("URL は1件以上%d件以内で指定してください。", MaxBatchFeedURLs),
+	}
+}
+
+// NewFeedRegistrationBatchNotFoundError は一括登録の結果が見つからない場合のエラーを生成する。
+func NewFeedRegistrationBatchNotFoundError(batchID string) *APIError {
+	return &APIError{
+		Code:     ErrCodeFeedRegistrationBatchNotFound,
+		Message:  fmt.Sprintf("指定された一括登録が見つかりません: %s", batchID),
+		Category: "feed",
+		Action:   "結果の照会期間（24時間）を過ぎた可能性があります。フィード一覧で登録状況を確認してください。",
+	}
+}
+
+// NewInvalidHighlightPeriodError はハイライトの集計期間の指定が不正な場合のエラーを生成する。
+func NewInvalidHighlightPeriodError(period string) *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidHighlightPeriod,
+		Message:  fmt.Sprintf("無効な集計期間です: %s", period),
+		Category: "validation",
+		Action:   "period は day または week を指定してください。",
+	}
+}
+
+// NewInvalidEmailError はマジックリンクの送信先のメールアドレスが不正な場合のエラーを生成する。
+func NewInvalidEmailError() *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidEmail,
+		Message:  "メールアドレスの形式が正しくありません。",
+		Category: "validation",
+		Action:   "name@example.com の形式でメールアドレスを入力してください。",
+	}
+}
+
+// NewInvalidLoginTokenError はマジックリンクのトークンが存在しない・期限切れ・使用済みの場合のエラーを生成する。
+// 推測の手がかりを与えないよう、理由は区別しない。
+func NewInvalidLoginTokenError() *APIError {
+	return &APIError{
+		Code:     ErrCodeInvalidLoginToken,
+		Message:  "ログインリンクが無効です。",
+		Category: "auth",
+		Action:   "ログインリンクは 15 分以内に 1 回だけ使えます。もう一度ログインリンクを送信してください。",
+	}
+}
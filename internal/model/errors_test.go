@@ -171,3 +171,54 @@ func TestNewFeedNotSubscribedError(t *testing.T) {
 		}
 	})
 }
+
+// TestNewPublicProfileErrors は公開プロフィール関連エラーが
+// Code / Category / Message の各フィールドを期待どおりに設定することを検証する。
+func TestNewPublicProfileErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          *APIError
+		wantCode     string
+		wantCategory string
+		wantInMsg    string
+	}{
+		{name: "公開プロフィール未検出のとき", err: NewProfileNotFoundError("alice"), wantCode: ErrCodeProfileNotFound, wantCategory: "feed", wantInMsg: "alice"},
+		{name: "スラッグ形式不正のとき", err: NewInvalidProfileSlugError("a_b"), wantCode: ErrCodeInvalidProfileSlug, wantCategory: "validation", wantInMsg: "a_b"},
+		{name: "スラッグ重複のとき", err: NewProfileSlugTakenError("bob"), wantCode: ErrCodeProfileSlugTaken, wantCategory: "validation", wantInMsg: "bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", tt.err.Code, tt.wantCode)
+			}
+			if tt.err.Category != tt.wantCategory {
+				t.Errorf("Category = %q, want %q", tt.err.Category, tt.wantCategory)
+			}
+			if !strings.Contains(tt.err.Message, tt.wantInMsg) {
+				t.Errorf("Message = %q, want to contain %q", tt.err.Message, tt.wantInMsg)
+			}
+			if tt.err.Action == "" {
+				t.Error("Action が空である（ユーザー向け対処方法が必要）")
+			}
+		})
+	}
+}
+
+// TestNewInvalidUnreadWarningThresholdError は積読警告閾値の検証エラーが
+// validation カテゴリで閾値を含むメッセージを返すことを検証する。
+func TestNewInvalidUnreadWarningThresholdError(t *testing.T) {
+	err := NewInvalidUnreadWarningThresholdError(-1)
+
+	if err.Code != ErrCodeInvalidUnreadWarningThreshold {
+		t.Errorf("Code = %q, want %q", err.Code, ErrCodeInvalidUnreadWarningThreshold)
+	}
+	if err.Category != "validation" {
+		t.Errorf("Category = %q, want %q", err.Category, "validation")
+	}
+	if !strings.Contains(err.Message, "-1") {
+		t.Errorf("Message = %q, want to contain %q", err.Message, "-1")
+	}
+	if err.Action == "" {
+		t.Error("Action が空である（ユーザー向け対処方法が必要）")
+	}
+}
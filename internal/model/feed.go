@@ -1,7 +1,10 @@
 // Package model はドメインモデルを定義する。
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Feed はRSS/Atomフィードを表す。
 type Feed struct {
@@ -16,13 +19,83 @@ type Feed struct {
 	FetchStatus       FetchStatus
 	ConsecutiveErrors int
 	ErrorMessage      string
-	NextFetchAt       time.Time
+	// ErrorKind は直近のフェッチ失敗の原因分類。エラーなしの場合は空文字。
+	ErrorKind FetchErrorKind
+	// ErrorDetail は直近のフェッチ失敗の詳細（TLS エラー種別・証明書情報・HTTP ステータス）。
+	// エラーなし、または詳細を取得できない失敗の場合は nil。
+	ErrorDetail *FetchErrorDetail
+	// HTTPVersion は直近に応答を受信したときのプロトコル（"HTTP/1.1"・"HTTP/2.0" 等）。未取得の場合は空文字。
+	HTTPVersion string
+	NextFetchAt time.Time
 	// LastSuccessfulFetchAt は直近のフェッチ成功時刻。
 	// nil の場合は過去に成功実績がないことを表し、手動フェッチのクールダウン判定では非適用となる。
 	// 自動ワーカー / 手動フェッチの双方の成功経路で更新される。
 	LastSuccessfulFetchAt *time.Time
-	CreatedAt             time.Time
-	UpdatedAt             time.Time
+	// Language はフィードの言語（RSS の <language> / Atom の xml:lang）。不明な場合は空文字。
+	Language string
+	// Description はフィードの説明文（RSS の <description> / Atom の <subtitle>）。不明な場合は空文字。
+	Description string
+	// Copyright はフィードの著作権・利用条件の表記（RSS の <copyright> / Atom の <rights>）。表記がない場合は空文字。
+	Copyright string
+	// TTLMinutes はフィードが示すキャッシュ有効期間（RSS の <ttl>、分）。次回フェッチまでの間隔の下限として扱う。
+	// 指定がない場合は 0。
+	TTLMinutes int
+	// Robots はフィードが示すロボット向け指示（X-Robots-Tag ヘッダー / channel の xhtml:meta name="robots"）を
+	// 小文字・カンマ区切りに正規化したもの（例: "noindex, noarchive"）。指定がない場合は空文字。
+	Robots string
+	// LastPublishedAt はフィード内で観測した記事の最新公開日時。
+	// nil の場合は公開日時付きの記事をまだ取得していないことを表す。
+	LastPublishedAt *time.Time
+	// IgnoreConditionalGet が true の場合、フェッチ時に ETag / Last-Modified による条件付き GET を行わない。
+	// 不正確な ETag を返し 304 ばかり応答するサーバー向けに管理者が設定する。
+	IgnoreConditionalGet bool
+	// CaptureRawResponse が true の場合、フェッチのたびに直近 1 回分のレスポンス（ヘッダーとボディ先頭）を
+	// feed_raw_captures に保存する。取り込み不具合の調査用に管理者が設定する。
+	CaptureRawResponse bool
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NoIndex はフィードが noindex（または none）を指示しているかを返す。
+// 購読前にクライアントが再配布・公開に関する注意を表示する判断に使う。
+func (f *Feed) NoIndex() bool {
+	for _, d := range strings.Split(f.Robots, ",") {
+		switch strings.TrimSpace(d) {
+		case "noindex", "none":
+			return true
+		}
+	}
+	return false
+}
+
+// TitleUpdatePolicy は購読一覧に表示するフィードタイトルの自動更新のポリシーを表す。
+// subscriptions.title_update_policy に購読ごとに永続化される。feeds.title はポリシーによらずフェッチのたびに更新する。
+type TitleUpdatePolicy string
+
+const (
+	// TitleUpdatePolicyAlways はフェッチで取得した最新のタイトルを表示する（既定）。
+	TitleUpdatePolicyAlways TitleUpdatePolicy = "always"
+	// TitleUpdatePolicyInitial はポリシーを設定した時点のタイトルを表示し続ける。
+	TitleUpdatePolicyInitial TitleUpdatePolicy = "initial"
+	// TitleUpdatePolicyManual はタイトルの変更を検知したら承認待ちタイトルとして保留し、購読者の承認で表示を切り替える。
+	TitleUpdatePolicyManual TitleUpdatePolicy = "manual"
+)
+
+// Valid はポリシーが定義済みの値かを返す。
+func (p TitleUpdatePolicy) Valid() bool {
+	switch p {
+	case TitleUpdatePolicyAlways, TitleUpdatePolicyInitial, TitleUpdatePolicyManual:
+		return true
+	}
+	return false
+}
+
+// FeedTitleSetting は購読一覧に表示するフィードのタイトルと自動更新ポリシー・承認待ちタイトルの組を表す。
+// PendingTitle が空文字の場合は承認待ちのタイトルがない。
+type FeedTitleSetting struct {
+	Title        string
+	Policy       TitleUpdatePolicy
+	PendingTitle string
 }
 
 // FetchStatus はフィードのフェッチ状態を表す。
@@ -35,14 +108,126 @@ const (
 	FetchStatusStopped FetchStatus = "stopped"
 	// FetchStatusError はエラーによるフェッチ停止状態。
 	FetchStatusError FetchStatus = "error"
+	// FetchStatusNoSubscribers は購読者がいなくなったためフェッチを止めている状態。
+	// 購読解除で最後の購読者がいなくなった active のフィードがこの状態になり、再購読で active に戻る。
+	FetchStatusNoSubscribers FetchStatus = "no_subscribers"
+)
+
+// FetchErrorKind はフェッチ失敗の原因分類を表す。
+// feeds.error_kind に永続化され、分類別のバックオフポリシーと原因別の集計に用いる。
+type FetchErrorKind string
+
+const (
+	// FetchErrorKindNone はエラーなし（直近のフェッチが成功）を表す。
+	FetchErrorKindNone FetchErrorKind = ""
+	// FetchErrorKindDNS は名前解決の失敗。
+	FetchErrorKindDNS FetchErrorKind = "dns"
+	// FetchErrorKindTLS はTLSハンドシェイク・証明書検証の失敗。
+	FetchErrorKindTLS FetchErrorKind = "tls"
+	// FetchErrorKindTimeout は接続・応答・ボディ読み取りのタイムアウト。
+	FetchErrorKindTimeout FetchErrorKind = "timeout"
+	// FetchErrorKindTooLarge はレスポンスボディが最大サイズを超過したことを表す。
+	FetchErrorKindTooLarge FetchErrorKind = "too_large"
+	// FetchErrorKindParse はフィードのパース失敗。
+	FetchErrorKindParse FetchErrorKind = "parse"
+	// FetchErrorKindHTTP4xx はHTTP 4xx 応答（404 / 410 / 401 / 403 / 429 等）。
+	FetchErrorKindHTTP4xx FetchErrorKind = "http_4xx"
+	// FetchErrorKindHTTP5xx はHTTP 5xx 応答。
+	FetchErrorKindHTTP5xx FetchErrorKind = "http_5xx"
+	// FetchErrorKindRedirect はリダイレクトの循環、または上限（security.MaxRedirects ホップ）の超過。
+	FetchErrorKindRedirect FetchErrorKind = "redirect"
+	// FetchErrorKindSSRF はSSRF対策による接続拒否。
+	FetchErrorKindSSRF FetchErrorKind = "ssrf"
+	// FetchErrorKindBlocked はインスタンスのブロックリスト（モデレーション）による停止。
+	FetchErrorKindBlocked FetchErrorKind = "blocked"
+	// FetchErrorKindOther は上記のいずれにも分類できない失敗（接続拒否・記事保存失敗等）。
+	FetchErrorKindOther FetchErrorKind = "other"
+)
+
+// FetchErrorDetail はフェッチ失敗の詳細情報。feeds.error_detail（jsonb）に永続化される。
+// 該当しない項目はゼロ値のままとする。
+type FetchErrorDetail struct {
+	// HTTPStatus は失敗時に受信した HTTP ステータスコード。応答を受信できなかった場合は 0。
+	HTTPStatus int
+	// TLSError は TLS 失敗の種別。TLS 以外の失敗では空文字。
+	TLSError TLSErrorType
+	// CertSubject・CertIssuer はサーバー証明書の Subject / Issuer（CommonName を優先）。
+	CertSubject string
+	CertIssuer  string
+	// CertDNSNames はサーバー証明書の SAN に含まれる DNS 名。
+	CertDNSNames []string
+	// CertNotBefore・CertNotAfter はサーバー証明書の有効期間。証明書を取得できなかった場合は nil。
+	CertNotBefore *time.Time
+	CertNotAfter  *time.Time
+}
+
+// TLSErrorType は TLS 失敗の種別を表す。
+type TLSErrorType string
+
+const (
+	// TLSErrorCertExpired は証明書の有効期限切れ。
+	TLSErrorCertExpired TLSErrorType = "certificate_expired"
+	// TLSErrorCertNotYetValid は証明書の有効期間開始前。
+	TLSErrorCertNotYetValid TLSErrorType = "certificate_not_yet_valid"
+	// TLSErrorUnknownAuthority は信頼できない認証局（自己署名・中間証明書不足等）。
+	TLSErrorUnknownAuthority TLSErrorType = "unknown_authority"
+	// TLSErrorHostnameMismatch は証明書のホスト名不一致。
+	TLSErrorHostnameMismatch TLSErrorType = "hostname_mismatch"
+	// TLSErrorCertInvalid は上記以外の証明書検証失敗。
+	TLSErrorCertInvalid TLSErrorType = "certificate_invalid"
+	// TLSErrorHandshake は証明書検証以外のハンドシェイク失敗（プロトコル不一致・alert 受信等）。
+	TLSErrorHandshake TLSErrorType = "handshake"
 )
 
+// MaxSubscriptionsPerUser はユーザーあたりの購読上限。
+const MaxSubscriptionsPerUser = 100
+
+// TrialSubscriptionDuration はお試し購読の期間。作成からこの期間を過ぎると自動で購読を解除する。
+const TrialSubscriptionDuration = 7 * 24 * time.Hour
+
+// RegisterFeedOptions はフィード登録（購読作成）時の任意指定。
+type RegisterFeedOptions struct {
+	// Trial が true の場合、TrialSubscriptionDuration の期限付きのお試し購読として作成する。
+	Trial bool
+}
+
 // Subscription はユーザーとフィードの購読関係を表す。
 type Subscription struct {
 	ID                   string
 	UserID               string
 	FeedID               string
 	FetchIntervalMinutes int
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
+	// IsPinned はサイドバー上部に固定（ピン留め）されているかを表す。
+	IsPinned bool
+	// SortOrder はサイドバーでの並び順（昇順）。同順位はフィードタイトル順に並ぶ。
+	SortOrder int
+	// Priority はクライアントが通知の重み付けに使う優先度のヒント。
+	Priority NotificationPriority
+	// MuteUntil はこの日時まで新着通知イベントを生成しないことを表す。nil の場合はミュートしていない。
+	MuteUntil *time.Time
+	// ExpiresAt はお試し購読の期限。期限を過ぎるとワーカーが自動で購読を解除する。nil の場合は通常の購読。
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NotificationSetting は購読の通知ヒント設定を返す。
+// 新着通知イベントを生成する処理は NotificationSetting().Muted(now) が true の購読に対してイベントを生成しないこと。
+func (s *Subscription) NotificationSetting() SubscriptionNotificationSetting {
+	return SubscriptionNotificationSetting{Priority: s.Priority, MuteUntil: s.MuteUntil}
+}
+
+// IsTrial はお試し購読（期限付き購読）であるかを返す。
+func (s *Subscription) IsTrial() bool {
+	return s.ExpiresAt != nil
+}
+
+// MaxSubscriptionOrderEntries は並び順の一括更新で一度に指定できる購読数の上限。
+const MaxSubscriptionOrderEntries = MaxSubscriptionsPerUser
+
+// SubscriptionOrderEntry は並び順の一括更新における 1 購読分の指定。
+// 一括更新では指定順に sort_order を振り直す。IsPinned が nil の場合はピン留め状態を変更しない。
+type SubscriptionOrderEntry struct {
+	SubscriptionID string
+	IsPinned       *bool
 }
@@ -0,0 +1,27 @@
+// Package model はドメインモデルを定義する。
+package model
+
+import "time"
+
+// FeedDiagnosis はフィードのパース結果を DB に保存せずに診断した結果を表す。
+// 取り込みがうまくいかないフィードの調査用（POST /api/debug/parse-feed）。
+type FeedDiagnosis struct {
+	FeedType    string // rss / atom / json
+	FeedVersion string // 例: 2.0, 1.0
+	Title       string
+	SiteURL     string
+	Items       []DiagnosedItem
+	Warnings    []string // フィード全体に関する警告
+}
+
+// DiagnosedItem は診断で検出された記事 1 件を表す。
+// 値はワーカーが取り込み時に用いる変換（GUID 補完・日付フォールバック）を適用した後のもの。
+type DiagnosedItem struct {
+	GuidOrID        string
+	Title           string
+	Link            string
+	Author          string
+	PublishedAt     *time.Time
+	IsDateEstimated bool     // 日付が取得できず取り込み時刻で推定される場合 true
+	Warnings        []string // 記事単位の警告
+}
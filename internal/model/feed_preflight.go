@@ -0,0 +1,13 @@
+package model
+
+// FeedPreflight はフィード URL を登録せずに検証（プリフライト）した結果を表す（POST /api/feeds/validate）。
+// SourceType は入力 URL の応答の種類（feed / html / other）で、応答を取得できなかった場合は空。
+// FeedURL / FeedType は登録できる見込みの場合のみ設定する。Error は登録できない理由で、Valid が false の場合のみ設定する。
+type FeedPreflight struct {
+	URL        string
+	Valid      bool
+	SourceType string
+	FeedURL    string
+	FeedType   string
+	Error      *APIError
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// MaxFeedRawCaptureBodyBytes は保存するフェッチレスポンスのボディの最大バイト数（先頭 256KB）。
+const MaxFeedRawCaptureBodyBytes = 256 * 1024
+
+// FeedRawCapture はフィードの直近 1 回分のフェッチレスポンスを表す。feed_raw_captures に対応する。
+// 取り込み不具合の再現調査用で、Feed.CaptureRawResponse が true のフィードについてのみ保存する。
+type FeedRawCapture struct {
+	FeedID     string
+	FetchedAt  time.Time
+	RequestURL string
+	StatusCode int
+	// Proto はレスポンスの HTTP バージョン（例: HTTP/1.1）。
+	Proto string
+	// Header はレスポンスヘッダー。Set-Cookie は保存しない。
+	Header http.Header
+	// Body はレスポンスボディの先頭 MaxFeedRawCaptureBodyBytes バイト。
+	Body []byte
+	// BodyTruncated はボディが MaxFeedRawCaptureBodyBytes を超えて切り詰められたかどうか。
+	BodyTruncated bool
+}
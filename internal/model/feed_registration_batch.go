@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// MaxBatchFeedURLs は一括登録（POST /api/feeds/batch）で一度に受け付ける URL 数の上限。
+// 一括登録のレート制限は URL 1 件を 1 件として数えるため、middleware.DefaultRateLimiterConfig の
+// FeedBatchBurst をこの値以上にして、上限いっぱいの一括登録が常に 429 にならないようにする。
+const MaxBatchFeedURLs = 20
+
+// FeedRegistrationBatchRetention は一括登録の結果を照会できる期間。
+// 期間を過ぎた結果は、同じユーザーが次に一括登録を開始したときに削除する。
+const FeedRegistrationBatchRetention = 24 * time.Hour
+
+// FeedRegistrationStatus は一括登録の URL ごとの登録結果。
+type FeedRegistrationStatus string
+
+const (
+	// FeedRegistrationPending はまだ処理していない。
+	FeedRegistrationPending FeedRegistrationStatus = "pending"
+	// FeedRegistrationRegistered はフィードを検出して購読した。
+	FeedRegistrationRegistered FeedRegistrationStatus = "registered"
+	// FeedRegistrationDuplicate はすでに購読済みのフィードだった。
+	FeedRegistrationDuplicate FeedRegistrationStatus = "duplicate"
+	// FeedRegistrationNotDetected は URL が不正、取得できない、またはフィードを検出できなかった。
+	FeedRegistrationNotDetected FeedRegistrationStatus = "not_detected"
+	// FeedRegistrationLimitExceeded は購読数の上限に達したため登録しなかった。
+	FeedRegistrationLimitExceeded FeedRegistrationStatus = "limit_exceeded"
+	// FeedRegistrationFailed はそれ以外の理由（ブロック対象のドメイン・内部エラー等）で登録できなかった。
+	FeedRegistrationFailed FeedRegistrationStatus = "failed"
+)
+
+// FeedRegistrationBatchEntry は一括登録の URL 1 件分の結果。
+// FeedID は registered / duplicate の場合に、Error は not_detected / limit_exceeded / failed の場合に設定する。
+type FeedRegistrationBatchEntry struct {
+	URL    string
+	Status FeedRegistrationStatus
+	FeedID string
+	Error  *APIError
+}
+
+// FeedRegistrationBatch は URL の一括登録 1 回分。Entries はリクエストの URL と同じ順に並ぶ。
+// CompletedAt はすべての URL の処理を終えた日時で、処理中は nil。
+type FeedRegistrationBatch struct {
+	ID          string
+	UserID      string
+	Entries     []FeedRegistrationBatchEntry
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
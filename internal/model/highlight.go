@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// HighlightLimit はハイライト（GET /api/highlights）で返す記事数。
+const HighlightLimit = 10
+
+// HighlightPeriod はハイライトの集計期間。
+type HighlightPeriod string
+
+const (
+	// HighlightPeriodDay は直近 24 時間に公開された記事のハイライト。
+	HighlightPeriodDay HighlightPeriod = "day"
+	// HighlightPeriodWeek は直近 7 日間に公開された記事のハイライト。
+	HighlightPeriodWeek HighlightPeriod = "week"
+)
+
+// HighlightPeriods は事前計算するハイライトの集計期間。
+var HighlightPeriods = []HighlightPeriod{HighlightPeriodDay, HighlightPeriodWeek}
+
+// Window は集計期間の長さを返す。未知の期間は 0 を返す。
+func (p HighlightPeriod) Window() time.Duration {
+	switch p {
+	case HighlightPeriodDay:
+		return 24 * time.Hour
+	case HighlightPeriodWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// HighlightWeights はハイライトのスコアの重み。
+// スコアは Hatebu·ln(1+はてブ数) + Star·ln(1+スター数) + View·ln(1+閲覧数) で計算する。
+type HighlightWeights struct {
+	Hatebu float64
+	Star   float64
+	View   float64
+}
+
+// HighlightItem はハイライトの記事 1 件。item_highlights に事前計算したスコアと、閲覧ユーザーの記事状態を持つ。
+type HighlightItem struct {
+	ItemID      string
+	FeedID      string
+	FeedTitle   string
+	Title       string
+	Link        string
+	PublishedAt *time.Time
+	Score       float64
+	// HatebuCount・StarCount・ViewCount はスコアの計算に用いた値。
+	// StarCount は記事にスターを付けたユーザー数、ViewCount は集計期間内の記事詳細の閲覧回数。
+	HatebuCount int
+	StarCount   int
+	ViewCount   int
+	IsRead      bool
+	IsStarred   bool
+	// ComputedAt はスコアを計算した日時。
+	ComputedAt time.Time
+}
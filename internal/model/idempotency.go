@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+const (
+	// IdempotencyKeyTTL は Idempotency-Key ごとに保存したレスポンスを再生する期間。
+	IdempotencyKeyTTL = 24 * time.Hour
+	// MaxIdempotencyKeyLength は Idempotency-Key ヘッダの最大長（バイト）。
+	MaxIdempotencyKeyLength = 255
+)
+
+// IdempotencyRecord は Idempotency-Key ごとに保存したリクエストとレスポンスを表す。idempotency_keys に対応する。
+// StatusCode が 0 の場合は最初のリクエストが処理中（レスポンス未確定）であることを表す。
+type IdempotencyRecord struct {
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	ExpiresAt    time.Time
+}
+
+// Completed は最初のリクエストのレスポンスが確定済みかを返す。
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
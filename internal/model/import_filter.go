@@ -0,0 +1,23 @@
+package model
+
+const (
+	// MaxImportFilterAuthors は取り込みフィルタに指定できる著者の最大数。
+	MaxImportFilterAuthors = 20
+	// MaxImportFilterAuthorLength は取り込みフィルタの著者 1 件あたりの最大文字数。
+	MaxImportFilterAuthorLength = 200
+	// MaxImportFilterTitlePatternLength は取り込みフィルタのタイトル正規表現の最大文字数。
+	MaxImportFilterTitlePatternLength = 500
+)
+
+// ImportFilter は購読単位の記事取り込みフィルタ（ホワイトリスト型）を表す。
+// Authors が空でなければ著者がいずれかに一致する記事のみ、TitlePattern が空でなければ
+// タイトルが正規表現に一致する記事のみを取り込む。両方指定時は両方を満たす記事のみを取り込む。
+type ImportFilter struct {
+	Authors      []string
+	TitlePattern string
+}
+
+// IsEmpty は絞り込み条件が指定されていない（全記事を取り込む）かを返す。
+func (f ImportFilter) IsEmpty() bool {
+	return len(f.Authors) == 0 && f.TitlePattern == ""
+}
@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+// IntegrationKind は新着記事の転送先サービスの種類を表す。
+type IntegrationKind string
+
+const (
+	// IntegrationKindSlack は Slack Incoming Webhook への転送を表す。
+	IntegrationKindSlack IntegrationKind = "slack"
+	// IntegrationKindDiscord は Discord Webhook への転送を表す。
+	IntegrationKindDiscord IntegrationKind = "discord"
+)
+
+// Valid は既知の転送先の種類かを返す。
+func (k IntegrationKind) Valid() bool {
+	switch k {
+	case IntegrationKindSlack, IntegrationKindDiscord:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	// MaxIntegrationsPerUser はユーザーあたりの連携設定数の上限。
+	MaxIntegrationsPerUser = 20
+	// MaxIntegrationTemplateLength はメッセージテンプレートの最大文字数（rune 数）。
+	MaxIntegrationTemplateLength = 1000
+	// DefaultIntegrationTemplate はメッセージテンプレート未指定時の既定値。
+	DefaultIntegrationTemplate = "{{.FeedTitle}}: {{.Title}}\n{{.Link}}"
+)
+
+// Integration は購読フィードの新着記事を Webhook に転送する連携設定を表す。integrations に対応する。
+// FeedTitle は購読先フィードのタイトルで、一覧・詳細の取得時にのみ設定する。
+type Integration struct {
+	ID              string
+	UserID          string
+	SubscriptionID  string
+	FeedTitle       string
+	Kind            IntegrationKind
+	WebhookURL      string
+	MessageTemplate string
+	Enabled         bool
+	// LastError / LastDeliveredAt は配送ワーカーが記録する直近の配送結果。
+	LastError       string
+	LastDeliveredAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IntegrationDelivery は配送キュー（integration_deliveries）の 1 件を表す。
+// 配送に必要な連携設定と記事の内容を結合して保持する。
+type IntegrationDelivery struct {
+	ID              int64
+	IntegrationID   string
+	Kind            IntegrationKind
+	WebhookURL      string
+	MessageTemplate string
+	// Attempts はこれまでの配送試行回数（今回の試行を含まない）。
+	Attempts int
+
+	ItemID      string
+	FeedTitle   string
+	Title       string
+	Link        string
+	Author      string
+	PublishedAt *time.Time
+}
@@ -12,6 +12,8 @@ type Item struct {
 	Link               string
 	Content            string // サニタイズ済みHTML
 	Summary            string // サニタイズ済み
+	ContentText        string // 本文（本文が空ならサマリー）からタグを除去したプレーンテキスト。先頭 MaxItemContentTextRunes 文字まで
+	ThumbnailURL       string // サムネイル画像の URL（https のみ）。無い場合は空文字列
 	Author             string
 	PublishedAt        *time.Time
 	IsDateEstimated    bool
@@ -19,16 +21,34 @@ type Item struct {
 	ContentHash        string
 	HatebuCount        int
 	HatebuFetchedAt    *time.Time
+	ReadingTimeMinutes int        // 本文から推定した読了時間（分）。本文が空の場合は 0
+	SeriesKey          string     // タイトルから検出した連載のキー。連載と判定できない場合は空文字
+	GeneratedSummary   string     // 要約器が生成した要約（プレーンテキスト）。未生成の場合は空文字
+	GeneratedSummaryAt *time.Time // 要約の生成日時。未生成の場合は nil
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
 
-// ItemWithState は記事とユーザーごとの状態（既読/スター）を結合したモデル。
+// MaxItemContentTextRunes は items.content_text に保存するプレーンテキストの最大文字数。
+const MaxItemContentTextRunes = 2000
+
+// ItemWithState は記事とユーザーごとの状態（既読/スター/非表示）を結合したモデル。
 // item_statesテーブルとLEFT JOINして取得される。
 type ItemWithState struct {
 	Item
 	IsRead    bool
 	IsStarred bool
+	IsHidden  bool
+	// Rating はユーザーが付けた評価（1〜MaxItemRating）。未評価は 0。
+	Rating int
+}
+
+// FeedAuthor はフィード内の著者 1 名分の集計結果を表す。
+type FeedAuthor struct {
+	// Author は正規化済みの著者名（items.author）。
+	Author string
+	// ItemCount は当該著者の記事数。
+	ItemCount int
 }
 
 // ItemSearchHit は記事検索結果 1 件の DB レベル射影を表すモデル。
@@ -67,17 +87,136 @@ const (
 	ItemFilterStarred ItemFilter = "starred"
 )
 
-// ItemState はユーザーごとの記事状態（既読/スター）を表す。
+// ItemConditions は記事一覧の絞り込み条件の組み合わせを表す。
+// 各条件は nil のとき絞り込まず、値を持つときその状態の記事に限る。条件同士は AND で結合する。
+type ItemConditions struct {
+	// Unread が true なら未読、false なら既読の記事に限る。
+	Unread *bool
+	// Starred が true ならスター付き、false ならスターなしの記事に限る。
+	Starred *bool
+	// IncludeHidden が true なら非表示（ミュート）にした記事も含める。false の場合は除外する。
+	IncludeHidden bool
+	// MinRating が 1 以上ならその評価以上の記事に限る。0 の場合は評価で絞り込まない。
+	MinRating int
+}
+
+// Conditions は単一値のフィルタを絞り込み条件に変換する。
+// 空文字列は ItemFilterAll として扱い、未知のフィルタ値の場合は ok=false を返す。
+func (f ItemFilter) Conditions() (conds ItemConditions, ok bool) {
+	on := true
+	switch f {
+	case "", ItemFilterAll:
+		return ItemConditions{}, true
+	case ItemFilterUnread:
+		return ItemConditions{Unread: &on}, true
+	case ItemFilterStarred:
+		return ItemConditions{Starred: &on}, true
+	default:
+		return ItemConditions{}, false
+	}
+}
+
+// Merge は c と other の条件を AND で結合した条件を返す。
+// 同じ条件に異なる値が指定されている場合は該当する記事がないため ok=false を返す。
+func (c ItemConditions) Merge(other ItemConditions) (merged ItemConditions, ok bool) {
+	merged = c
+	for _, pair := range []struct {
+		dst **bool
+		src *bool
+	}{
+		{&merged.Unread, other.Unread},
+		{&merged.Starred, other.Starred},
+	} {
+		if pair.src == nil {
+			continue
+		}
+		if *pair.dst != nil && **pair.dst != *pair.src {
+			return ItemConditions{}, false
+		}
+		*pair.dst = pair.src
+	}
+	return merged, true
+}
+
+// HatebuFetchPriority ははてなブックマーク数の取得対象を選ぶ際の優先度スコアの重みを表す。
+// スコアは RecencyWeight × 新しさ + PopularityWeight × ln(1 + はてブ数) で計算し、高い記事から取得する。
+// 新しさは公開日時（未設定の場合は取得日時）からの経過時間が RecencyHalfLife 経つごとに半減する 0〜1 の値。
+// 重みがいずれも 0 の場合は未取得の記事、取得日時が古い記事の順に選ぶ。
+type HatebuFetchPriority struct {
+	RecencyWeight    float64
+	PopularityWeight float64
+	RecencyHalfLife  time.Duration
+}
+
+// LinkStatus は記事の元記事 URL に対するリンク切れチェックの判定結果を表す。
+// 空文字列は未判定（items.link_status が NULL）を表す。
+type LinkStatus string
+
+const (
+	// LinkStatusOK は元記事 URL に到達できたことを表す。
+	LinkStatusOK LinkStatus = "ok"
+	// LinkStatusNotFound は元記事 URL が 404 / 410 を返したことを表す。
+	LinkStatusNotFound LinkStatus = "not_found"
+	// LinkStatusDomainUnresolvable は元記事 URL のドメイン名を解決できなかったことを表す。
+	LinkStatusDomainUnresolvable LinkStatus = "domain_unresolvable"
+)
+
+// IsBroken はリンク切れと判定された状態かどうかを返す。
+func (s LinkStatus) IsBroken() bool {
+	return s == LinkStatusNotFound || s == LinkStatusDomainUnresolvable
+}
+
+// MaxItemRating は記事の評価の最大値。評価は 1〜MaxItemRating の段階で、0 は未評価を表す。
+const MaxItemRating = 5
+
+// ApplyStarRating は現在の評価 current にスター（isStarred）・評価（rating）の更新を適用した評価を返す。
+// rating を指定した場合はその値を採用する。isStarred のみを指定した場合は、true なら未評価の記事を評価 1 にし
+// （評価済みなら維持する）、false なら未評価に戻す。いずれも nil の場合は current を返す。
+// スター状態は戻り値が 1 以上かどうかで決まる（rating >= 1 をスター付きとみなす互換規則）。
+func ApplyStarRating(current int, isStarred *bool, rating *int) int {
+	switch {
+	case rating != nil:
+		return *rating
+	case isStarred == nil:
+		return current
+	case !*isStarred:
+		return 0
+	default:
+		return max(current, 1)
+	}
+}
+
+// StarredItemFilter はスター記事一覧の絞り込みと並び順を表す。
+type StarredItemFilter struct {
+	// BrokenLinkOnly が true ならリンク切れと判定された記事に限る。
+	BrokenLinkOnly bool
+	// MinRating が 1 以上ならその評価以上の記事に限る。
+	MinRating int
+	// SortByRating が true なら評価の高い順（同じ評価内は公開日時の新しい順）に並べる。
+	// false の場合は公開日時の新しい順に並べる。
+	SortByRating bool
+}
+
+// ItemState はユーザーごとの記事状態（既読/スター/非表示）を表す。
 type ItemState struct {
 	ID        string
 	UserID    string
 	ItemID    string
 	IsRead    bool
 	IsStarred bool
+	// IsHidden はユーザーが記事を非表示（ミュート）にしたかどうか。既読とは独立した状態。
+	IsHidden bool
+	// Rating はユーザーが付けた評価（1〜MaxItemRating）。未評価は 0。
+	// 評価 1 以上の記事をスター付きとみなし、IsStarred は常に Rating >= 1 と一致する。
+	Rating    int
 	ReadAt    *time.Time
 	StarredAt *time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	// HiddenAt は記事を非表示にした日時。非表示でない場合は nil。
+	HiddenAt *time.Time
+	// LastVisitedAt は元記事ページへ最後に訪問（リダイレクト）した日時。未訪問の場合は nil。
+	LastVisitedAt *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 // ParsedItem はフィードパーサーから取得した未保存の記事データを表す。
@@ -86,8 +225,13 @@ type ParsedItem struct {
 	GuidOrID    string
 	Title       string
 	Link        string
-	Content     string     // 未サニタイズのHTML
-	Summary     string     // 未サニタイズ
+	Content     string // 未サニタイズのHTML
+	Summary     string // 未サニタイズ
 	Author      string
 	PublishedAt *time.Time
+	// ThumbnailURL はフィードが指定するサムネイル画像（media:thumbnail / 画像の enclosure 等）の URL（未検証）。
+	ThumbnailURL string
+	// BaseURL は本文中の相対 URL を絶対化する際の基準 URL（フィードの site_url）。
+	// Link が絶対 URL の場合は Link を優先する。
+	BaseURL string
 }
@@ -0,0 +1,30 @@
+package model
+
+import "testing"
+
+func TestApplyStarRating(t *testing.T) {
+	on, off := true, false
+	three, zero := 3, 0
+
+	tests := []struct {
+		name      string
+		current   int
+		isStarred *bool
+		rating    *int
+		want      int
+	}{
+		{name: "いずれも未指定のとき現在の評価を維持する", current: 4, want: 4},
+		{name: "未評価の記事にスターを付けたとき評価1にする", current: 0, isStarred: &on, want: 1},
+		{name: "評価済みの記事にスターを付けたとき評価を維持する", current: 4, isStarred: &on, want: 4},
+		{name: "スターを外したとき未評価に戻す", current: 4, isStarred: &off, want: 0},
+		{name: "ratingを指定したときその値を採用する", current: 1, rating: &three, want: 3},
+		{name: "rating=0を指定したとき未評価に戻す", current: 5, rating: &zero, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyStarRating(tt.current, tt.isStarred, tt.rating); got != tt.want {
+				t.Errorf("ApplyStarRating(%d) = %d, want %d", tt.current, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+const (
+	// DefaultStatsPeriodDays は閲覧統計の集計期間（日）の既定値。
+	DefaultStatsPeriodDays = 30
+	// MaxStatsPeriodDays は閲覧統計の集計期間（日）の上限。
+	MaxStatsPeriodDays = 90
+)
+
+// ItemView は記事詳細の閲覧イベントを表す。item_views に対応する。
+// feed_id は保存時に記事から補完するため保持しない。
+type ItemView struct {
+	UserID   string
+	ItemID   string
+	ViewedAt time.Time
+}
+
+// FeedViewStat はフィード単位の閲覧集計結果を表す。
+type FeedViewStat struct {
+	FeedID    string
+	FeedTitle string
+	// ViewCount は期間内の閲覧回数（同じ記事の再閲覧も数える）。
+	ViewCount int
+	// ItemCount は期間内に閲覧した記事の種類数。
+	ItemCount    int
+	LastViewedAt time.Time
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	// FeedKeywordLimit は記事傾向サマリー（GET /api/subscriptions/{id}/keywords）で返す頻出キーワードの件数。
+	FeedKeywordLimit = 10
+	// FeedKeywordWindow は頻出キーワードの集計対象とする記事の期間（公開日時が直近 30 日以内）。
+	FeedKeywordWindow = 30 * 24 * time.Hour
+)
+
+// FeedKeyword はフィードの頻出キーワード 1 件。Count は集計期間内の記事での出現回数。
+type FeedKeyword struct {
+	Keyword string
+	Count   int
+}
+
+// FeedKeywordSummary は購読フィードの記事傾向サマリー。
+// ComputedAt はキーワードを計算した日時で、未計算またはキーワードが無い場合は nil。
+type FeedKeywordSummary struct {
+	FeedID     string
+	Keywords   []FeedKeyword
+	ComputedAt *time.Time
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// LoginEventType はログインイベントの種別を表す。
+type LoginEventType string
+
+const (
+	// LoginEventSuccess はログイン成功（セッションの発行）を表す。
+	LoginEventSuccess LoginEventType = "login_success"
+	// LoginEventFailure はログイン失敗を表す。
+	LoginEventFailure LoginEventType = "login_failure"
+	// LoginEventLogout はログアウト（セッションの破棄）を表す。
+	LoginEventLogout LoginEventType = "logout"
+	// LoginEventSessionExpired はセッションの有効期限切れによる失効を表す。
+	LoginEventSessionExpired LoginEventType = "session_expired"
+)
+
+const (
+	// DefaultLoginHistoryLimit はログイン履歴の 1 ページあたりの既定件数。
+	DefaultLoginHistoryLimit = 50
+	// MaxLoginHistoryLimit はログイン履歴の 1 ページあたりの最大件数。
+	MaxLoginHistoryLimit = 200
+)
+
+// LoginEvent はログインイベント 1 件を表す。login_events に対応する。
+type LoginEvent struct {
+	ID string
+	// UserID はイベントの対象ユーザー。ユーザーを特定できないログイン失敗では空文字。
+	UserID string
+	Event  LoginEventType
+	// Reason はログイン失敗の理由（"oauth_exchange_failed" など）。成功・ログアウト等では空文字。
+	Reason string
+	// IPAddress は接続元 IP の部分マスク値（IPv4 は /24、IPv6 は /48 まで）。
+	IPAddress string
+	// UserAgent は User-Agent の部分マスク値（括弧内のプラットフォーム情報を伏せたもの）。
+	UserAgent string
+	CreatedAt time.Time
+}
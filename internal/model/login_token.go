@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// LoginTokenTTL はマジックリンクのトークンの有効期間。
+const LoginTokenTTL = 15 * time.Minute
+
+// MaxLoginTokensPerWindow は同じメールアドレスに LoginTokenTTL の間に送るマジックリンクの上限。
+// 上限を超えた依頼はメールを送らずに受け付ける（第三者による大量送信の抑止）。
+const MaxLoginTokensPerWindow = 3
+
+// LoginToken はマジックリンクログインの一回限りのトークンを表す。
+// トークンの平文は保存せず、SHA-256 の hex 表現（TokenHash）のみを保持する。
+type LoginToken struct {
+	TokenHash string
+	// Email は正規化（前後の空白の除去・小文字化）したメールアドレス。
+	Email     string
+	ExpiresAt time.Time
+	// UsedAt はログインに使った日時。未使用の場合は nil。
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
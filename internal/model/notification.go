@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// NotificationPriority はクライアントが通知の重み付け（通知音・表示の強さ等）に使う購読単位の優先度のヒント。
+type NotificationPriority string
+
+const (
+	NotificationPriorityHigh   NotificationPriority = "high"
+	NotificationPriorityNormal NotificationPriority = "normal"
+	NotificationPriorityLow    NotificationPriority = "low"
+)
+
+// DefaultNotificationPriority は優先度を設定していない購読の優先度。
+const DefaultNotificationPriority = NotificationPriorityNormal
+
+// Valid は優先度が定義済みの値かを返す。
+func (p NotificationPriority) Valid() bool {
+	switch p {
+	case NotificationPriorityHigh, NotificationPriorityNormal, NotificationPriorityLow:
+		return true
+	}
+	return false
+}
+
+// SubscriptionNotificationSetting は購読単位の通知ヒント設定を表す。
+// MuteUntil が nil の場合はミュートしていない。
+type SubscriptionNotificationSetting struct {
+	Priority  NotificationPriority
+	MuteUntil *time.Time
+}
+
+// Muted は now の時点でミュート中かを返す。
+func (s SubscriptionNotificationSetting) Muted(now time.Time) bool {
+	return s.MuteUntil != nil && now.Before(*s.MuteUntil)
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscriptionNotificationSetting_Muted(t *testing.T) {
+	now := time.Date(2026, 6, 23, 12, 0, 0, 0, time.UTC)
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		muteUntil *time.Time
+		want      bool
+	}{
+		{name: "mute_untilが未設定のときミュートしていない", muteUntil: nil, want: false},
+		{name: "mute_untilが未来のときミュート中", muteUntil: &future, want: true},
+		{name: "mute_untilが現在時刻のときミュートしていない", muteUntil: &now, want: false},
+		{name: "mute_untilが過去のときミュートしていない", muteUntil: &past, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SubscriptionNotificationSetting{Priority: NotificationPriorityNormal, MuteUntil: tt.muteUntil}
+			if got := s.Muted(now); got != tt.want {
+				t.Errorf("Muted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationPriority_Valid(t *testing.T) {
+	for _, p := range []NotificationPriority{NotificationPriorityHigh, NotificationPriorityNormal, NotificationPriorityLow} {
+		if !p.Valid() {
+			t.Errorf("%q.Valid() = false, want true", p)
+		}
+	}
+	for _, p := range []NotificationPriority{"", "urgent", "HIGH"} {
+		if p.Valid() {
+			t.Errorf("%q.Valid() = true, want false", p)
+		}
+	}
+}
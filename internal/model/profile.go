@@ -0,0 +1,16 @@
+package model
+
+// PublicProfile はユーザーの購読リスト公開設定を表す。
+// user_settings の public_profile / public_slug に対応する。
+type PublicProfile struct {
+	UserID  string
+	Enabled bool   // 公開プロフィールの有効/無効
+	Slug    string // 公開 URL（/public/{slug}/subscriptions）に用いるスラッグ。未設定時は空文字
+}
+
+// PublicSubscription は公開プロフィールで閲覧できる購読 1 件分の情報を表す。
+// 未認証の閲覧者に返すため、フィードのタイトルとサイト URL のみを保持する。
+type PublicSubscription struct {
+	Title   string
+	SiteURL string
+}
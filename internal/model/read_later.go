@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// ReadLaterProvider はスター記事を保存する「あとで読む」サービスの種類を表す。
+type ReadLaterProvider string
+
+const (
+	// ReadLaterProviderPocket は Pocket への保存を表す。
+	ReadLaterProviderPocket ReadLaterProvider = "pocket"
+	// ReadLaterProviderInstapaper は Instapaper への保存を表す。
+	ReadLaterProviderInstapaper ReadLaterProvider = "instapaper"
+)
+
+// Valid は既知の保存先の種類かを返す。
+func (p ReadLaterProvider) Valid() bool {
+	switch p {
+	case ReadLaterProviderPocket, ReadLaterProviderInstapaper:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReadLaterConnection はユーザーと「あとで読む」サービスの連携を表す。read_later_connections に対応する。
+// Credentials は OAuth のアクセストークン等を暗号化したもので、復号は readlater パッケージが行う。
+type ReadLaterConnection struct {
+	ID       string
+	UserID   string
+	Provider ReadLaterProvider
+	// AccountName は連携先サービスのアカウント名（表示用）。
+	AccountName string
+	Credentials []byte
+	Enabled     bool
+	// LastError / LastErrorAt / LastSavedAt は保存ワーカーが記録する直近の保存結果。
+	// 連携先で認証が無効になった場合は Enabled を false にしたうえで LastError に理由を記録する。
+	LastError   string
+	LastErrorAt *time.Time
+	LastSavedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ReadLaterDelivery は保存キュー（read_later_deliveries）の 1 件を表す。
+// 保存に必要な連携の認証情報と記事の内容を結合して保持する。
+type ReadLaterDelivery struct {
+	ID           int64
+	ConnectionID string
+	UserID       string
+	Provider     ReadLaterProvider
+	Credentials  []byte
+	// Attempts はこれまでの保存試行回数（今回の試行を含まない）。
+	Attempts int
+
+	ItemID string
+	Title  string
+	Link   string
+}
@@ -0,0 +1,14 @@
+package model
+
+const (
+	// MinRetentionOverride は購読単位の最大記事保持数に指定できる下限。
+	MinRetentionOverride = 10
+	// MaxRetentionOverride は購読単位の最大記事保持数に指定できる上限。
+	MaxRetentionOverride = 10000
+)
+
+// RetentionOverride は購読単位の記事保持数の上書き設定を表す。
+// MaxItems が nil の場合は上書きせず、全体の保持期間（日数）に従う。
+type RetentionOverride struct {
+	MaxItems *int
+}
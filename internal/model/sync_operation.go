@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// SyncOperationType はオフライン同期で受け付ける記事状態の操作の種別。
+type SyncOperationType string
+
+const (
+	// SyncOperationRead は既読（value=true）・未読（value=false）にする操作。
+	SyncOperationRead SyncOperationType = "read"
+	// SyncOperationStar はスターを付ける（value=true）・外す（value=false）操作。
+	SyncOperationStar SyncOperationType = "star"
+)
+
+// Valid は操作の種別が既知の値かを返す。
+func (t SyncOperationType) Valid() bool {
+	return t == SyncOperationRead || t == SyncOperationStar
+}
+
+// MaxSyncOperations はオフライン同期で一度に送れる操作数の上限。
+const MaxSyncOperations = 500
+
+// SyncOperation はクライアントがオフライン中に記録した記事状態の操作。
+// Value は操作後の値（既読 / スター付き）で、nil は未指定（不正な操作）を表す。
+// ClientTimestamp は操作した時刻で、サーバー側の状態より新しい操作だけを適用する（last-write-wins）。
+type SyncOperation struct {
+	Type            SyncOperationType
+	ItemID          string
+	Value           *bool
+	ClientTimestamp time.Time
+}
+
+// SyncOperationStatus はオフライン同期の操作ごとの適用結果。
+type SyncOperationStatus string
+
+const (
+	// SyncStatusApplied は操作を適用した。
+	SyncStatusApplied SyncOperationStatus = "applied"
+	// SyncStatusStale はサーバー側でより新しい変更があったため操作を適用しなかった。
+	SyncStatusStale SyncOperationStatus = "stale"
+	// SyncStatusFailed は操作が不正か記事が存在しないため適用できなかった。
+	SyncStatusFailed SyncOperationStatus = "failed"
+)
+
+// SyncOperationResult はオフライン同期の 1 操作分の結果。
+// State は適用後（stale の場合は現在）の記事状態で、failed の場合は nil。Error は failed の場合のみ設定する。
+type SyncOperationResult struct {
+	Operation SyncOperation
+	Status    SyncOperationStatus
+	State     *ItemState
+	Error     *APIError
+}
@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// TeamRole はチーム内でのメンバーの役割を表す。
+type TeamRole string
+
+const (
+	// TeamRoleOwner はチームの作成者を表す。チームから脱退できない。
+	TeamRoleOwner TeamRole = "owner"
+	// TeamRoleMember は招待から参加したメンバーを表す。
+	TeamRoleMember TeamRole = "member"
+)
+
+const (
+	// MaxTeamNameLength はチーム名の最大文字数（rune 数）。
+	MaxTeamNameLength = 50
+	// MaxTeamMembers はチームあたりのメンバー数の上限（オーナーを含む）。
+	MaxTeamMembers = 20
+	// TeamInvitationTTL は招待トークンの有効期間。
+	TeamInvitationTTL = 7 * 24 * time.Hour
+)
+
+// Team は購読リストを共有するチーム（家族・チーム）を表す。teams に対応する。
+type Team struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TeamMembership はユーザーから見た所属チームと自分の役割を表す。
+type TeamMembership struct {
+	Team
+	Role     TeamRole
+	JoinedAt time.Time
+}
+
+// TeamMember はチームのメンバー 1 人を表す。team_members と users の結合結果。
+type TeamMember struct {
+	UserID   string
+	Name     string
+	Role     TeamRole
+	JoinedAt time.Time
+}
+
+// TeamFeed はチーム所有の購読フィード 1 件を表す。team_feeds と feeds の結合結果。
+// メンバーそれぞれの購読（subscriptions.team_id 付き）として展開されるため、
+// 既読・スターはメンバーごとに分離される。
+type TeamFeed struct {
+	FeedID    string
+	Title     string
+	FeedURL   string
+	SiteURL   string
+	AddedBy   string
+	CreatedAt time.Time
+}
+
+// TeamInvitation はチームへの招待を表す。team_invitations に対応する。
+// トークン自体は保存せず、ハッシュのみを保存する。
+type TeamInvitation struct {
+	ID        string
+	TeamID    string
+	InvitedBy string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -24,8 +24,11 @@ type Identity struct {
 
 // Session はユーザーのログインセッションを表す。
 type Session struct {
-	ID        string
-	UserID    string
-	ExpiresAt time.Time
-	CreatedAt time.Time
+	ID     string
+	UserID string
+	// IdentityID はセッション発行時のログインに用いた identity の ID。
+	// 記録されていない（記録を始める前に発行した）セッションは空文字。
+	IdentityID string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
 }
@@ -0,0 +1,48 @@
+package model
+
+const (
+	// DefaultUnreadWarningThreshold は積読警告（too_many_unread）の既定閾値。
+	// ユーザーが閾値を設定していない場合、未読数がこの件数を超えた購読に警告を出す。
+	DefaultUnreadWarningThreshold = 500
+	// MaxUnreadWarningThreshold はユーザーが設定できる積読警告閾値の上限。
+	MaxUnreadWarningThreshold = 100000
+	// DefaultOpenLinksInNewTab は記事本文のリンクを新しいタブで開くかの既定値。
+	DefaultOpenLinksInNewTab = true
+	// DefaultPrefetchEnabled は利用時間帯に合わせたプリフェッチの既定値（オプトイン）。
+	DefaultPrefetchEnabled = false
+	// DefaultTimezone はタイムゾーンが未設定のユーザーに用いるタイムゾーン。
+	DefaultTimezone = "UTC"
+)
+
+// UnreadWarningSetting はユーザーごとの積読警告設定。
+// user_settings.unread_warning_threshold に対応する。Threshold が 0 の場合は警告を無効とする。
+type UnreadWarningSetting struct {
+	UserID    string
+	Threshold int
+}
+
+// Enabled は積読警告が有効かを返す。
+func (s *UnreadWarningSetting) Enabled() bool {
+	return s.Threshold > 0
+}
+
+// LinkBehaviorSetting はユーザーごとの記事本文リンクの開き方の設定。
+// user_settings.open_links_in_new_tab に対応する。
+type LinkBehaviorSetting struct {
+	UserID       string
+	OpenInNewTab bool
+}
+
+// PrefetchSetting はユーザーごとの利用時間帯に合わせたプリフェッチの設定。
+// user_settings.prefetch_enabled に対応する。
+type PrefetchSetting struct {
+	UserID  string
+	Enabled bool
+}
+
+// TimezoneSetting はユーザーごとのタイムゾーン設定。
+// user_settings.timezone に対応し、Timezone は IANA タイムゾーン名（例: "Asia/Tokyo"）。
+type TimezoneSetting struct {
+	UserID   string
+	Timezone string
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	// DefaultWeeklyStatsWeeks は週次トレンドで返す週数の既定値。
+	DefaultWeeklyStatsWeeks = 12
+	// MaxWeeklyStatsWeeks は週次トレンドで返す週数の上限。
+	MaxWeeklyStatsWeeks = 52
+	// WeeklyStatsRetention は週次統計スナップショットの保持期間。
+	WeeklyStatsRetention = 365 * 24 * time.Hour
+)
+
+// WeeklyFeedStat はユーザー×フィード単位の週次統計スナップショットを表す。weekly_subscription_stats に対応する。
+type WeeklyFeedStat struct {
+	// WeekStart は集計週の開始時刻（月曜 00:00 UTC）。
+	WeekStart time.Time
+	FeedID    string
+	FeedTitle string
+	// NewItems は週内に取り込まれたフィードの記事数。
+	NewItems int
+	// ReadItems は週内にユーザーが既読にした当該フィードの記事数。
+	ReadItems int
+	// HiddenItems は週内にユーザーが非表示（ミュート）にした当該フィードの記事数。既読数とは別に数える。
+	HiddenItems int
+}
+
+// WeekStart は t を含む週の開始時刻（月曜 00:00 UTC）を返す。
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // 月曜を 0 とする
+	return day.AddDate(0, 0, -offset)
+}
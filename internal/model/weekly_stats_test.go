@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 6, 22, 0, 0, 0, 0, time.UTC)
+	jst := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "月曜0時のときその時刻を返す", t: monday, want: monday},
+		{name: "週の途中のとき直前の月曜0時を返す", t: time.Date(2026, 6, 24, 15, 30, 0, 0, time.UTC), want: monday},
+		{name: "日曜のとき6日前の月曜を返す", t: time.Date(2026, 6, 28, 23, 59, 59, 0, time.UTC), want: monday},
+		{name: "UTC以外のタイムゾーンのときUTCに変換して週を決める", t: time.Date(2026, 6, 22, 8, 0, 0, 0, jst), want: monday.AddDate(0, 0, -7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStart(tt.t); !got.Equal(tt.want) {
+				t.Errorf("WeekStart(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
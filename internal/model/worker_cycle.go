@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// WorkerCycle はフェッチスケジューラの 1 サイクル分の結果を表す。worker_cycles に対応する。
+// 304（未変更）も成功として数える（FetchAttempt と同じ基準）。
+type WorkerCycle struct {
+	ID             int64
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	FeedCount      int
+	SucceededCount int
+	FailedCount    int
+	ItemsInserted  int
+	ItemsUpdated   int
+	Duration       time.Duration
+}
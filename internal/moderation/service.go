@@ -0,0 +1,175 @@
+// Package moderation はインスタンス管理者向けのフィードのモデレーション（ブロックリスト）を提供する。
+//
+// ブロックリストの項目はドメイン（サブドメインを含む）またはフィード URL で、
+// フィードの登録（FeedService.RegisterFeed）・URL の変更・自動検出（FeedDetector）・フェッチで
+// CheckURL により照合し、一致した場合は FEED_BLOCKED を返す。項目の追加時には、一致する既存フィードのフェッチを
+// 停止する遡及適用を選べる。
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// maxBlockReasonLength はブロック理由の最大文字数（rune 数）。
+const maxBlockReasonLength = 500
+
+// blockedFeedMessage は遡及適用で停止したフィードに記録するエラーメッセージ。
+const blockedFeedMessage = "インスタンスの管理者によってブロックされたため、フェッチを停止しました"
+
+// blocklistCacheTTL は CheckURL が照合に使うブロックリストのキャッシュ有効期間。
+// 同じプロセスでの追加・削除では即時に無効化し、別プロセス（worker など）での変更はこの期間内に反映される。
+const blocklistCacheTTL = time.Minute
+
+// hostnamePattern は小文字化済みのホスト名（ラベルを "." で連結したもの）の形式。
+var hostnamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// Service はモデレーション用ブロックリストのサービス層。
+type Service struct {
+	repo repository.BlockedDomainRepository
+	now  func() time.Time
+
+	// mu は CheckURL が使うブロックリストのキャッシュ（cached / cachedAt）を保護する。
+	mu       sync.Mutex
+	cached   []model.BlockedDomain
+	cachedAt time.Time
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.BlockedDomainRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// ListBlockedDomains はブロックリストの全項目を追加日時の昇順で返す。
+func (s *Service) ListBlockedDomains(ctx context.Context) ([]model.BlockedDomain, error) {
+	blocked, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ブロックリストの取得に失敗しました: %w", err)
+	}
+	return blocked, nil
+}
+
+// AddBlockedDomain はブロックリストに項目を追加し、追加した項目と遡及適用で停止したフィード数を返す。
+// pattern が "://" を含む場合はフィード URL、含まない場合はドメインとして扱う。
+// applyToExisting が true の場合は一致する既存フィードのフェッチを停止する（false の場合は 0 を返す）。
+func (s *Service) AddBlockedDomain(ctx context.Context, adminUserID, pattern, reason string, applyToExisting bool) (*model.BlockedDomain, int, error) {
+	normalized, kind, err := NormalizePattern(pattern)
+	if err != nil {
+		return nil, 0, err
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxBlockReasonLength {
+		return nil, 0, model.NewInvalidBlockedDomainError(fmt.Sprintf("reason は %d 文字以内で指定してください", maxBlockReasonLength))
+	}
+
+	blocked := &model.BlockedDomain{
+		Pattern:   normalized,
+		Kind:      kind,
+		Reason:    reason,
+		CreatedBy: adminUserID,
+	}
+	if err := s.repo.Create(ctx, blocked); err != nil {
+		return nil, 0, fmt.Errorf("ブロックリストへの追加に失敗しました: %w", err)
+	}
+	s.invalidateCache()
+
+	if !applyToExisting {
+		return blocked, 0, nil
+	}
+	stopped, err := s.repo.StopMatchingFeeds(ctx, *blocked, blockedFeedMessage)
+	if err != nil {
+		return nil, 0, fmt.Errorf("既存フィードへの遡及適用に失敗しました: %w", err)
+	}
+	return blocked, stopped, nil
+}
+
+// DeleteBlockedDomain はブロックリストの項目を削除する。
+// 遡及適用で停止したフィードは自動では再開せず、購読者のフェッチ再開操作を受け付けるようになる。
+func (s *Service) DeleteBlockedDomain(ctx context.Context, id string) error {
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("ブロックリストからの削除に失敗しました: %w", err)
+	}
+	s.invalidateCache()
+	if !deleted {
+		return model.NewBlockedDomainNotFoundError(id)
+	}
+	return nil
+}
+
+// CheckURL は rawURL がブロックリストのいずれかの項目に一致する場合に FEED_BLOCKED を返す。
+// 解釈できない URL は一致しないものとして扱う（URL の検証は呼び出し元の責務）。
+// フェッチごとに呼ばれるため、ブロックリストは blocklistCacheTTL の間キャッシュしたものと照合する。
+func (s *Service) CheckURL(ctx context.Context, rawURL string) error {
+	blocked, err := s.cachedList(ctx)
+	if err != nil {
+		return err
+	}
+	for _, b := range blocked {
+		if b.Matches(rawURL) {
+			return model.NewFeedBlockedError(rawURL)
+		}
+	}
+	return nil
+}
+
+// cachedList はキャッシュが有効期間内であればキャッシュしたブロックリストを、期限切れであれば取得し直したものを返す。
+func (s *Service) cachedList(ctx context.Context) ([]model.BlockedDomain, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if !s.cachedAt.IsZero() && now.Sub(s.cachedAt) < blocklistCacheTTL {
+		return s.cached, nil
+	}
+	blocked, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ブロックリストの取得に失敗しました: %w", err)
+	}
+	s.cached = blocked
+	s.cachedAt = now
+	return blocked, nil
+}
+
+// invalidateCache は CheckURL のキャッシュを破棄し、次回の照合でブロックリストを取得し直させる。
+func (s *Service) invalidateCache() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = nil
+	s.cachedAt = time.Time{}
+}
+
+// NormalizePattern はブロックリストの入力を検証し、保存する形に正規化する。
+// "://" を含む入力は http / https のフィード URL（スキームとホストを小文字化しフラグメントを除去）、
+// それ以外はドメイン（小文字化し、先頭の "*." と末尾の "." を除去）として扱う。
+func NormalizePattern(raw string) (string, model.BlockKind, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", "", model.NewInvalidBlockedDomainError("pattern を指定してください")
+	}
+
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil || u.Hostname() == "" || u.User != nil {
+			return "", "", model.NewInvalidBlockedDomainError(fmt.Sprintf("URL を解釈できません: %s", raw))
+		}
+		if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "https" {
+			return "", "", model.NewInvalidBlockedDomainError(fmt.Sprintf("http / https 以外の URL は指定できません: %s", raw))
+		}
+		return model.NormalizeBlockedURL(u), model.BlockKindURL, nil
+	}
+
+	domain := strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(raw), "*."), ".")
+	if len(domain) > 253 || !hostnamePattern.MatchString(domain) {
+		return "", "", model.NewInvalidBlockedDomainError(fmt.Sprintf("ドメインの形式が不正です: %s", raw))
+	}
+	return domain, model.BlockKindDomain, nil
+}
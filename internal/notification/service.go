@@ -0,0 +1,90 @@
+// Package notification は購読単位の通知ヒント設定（priority / mute_until）を提供する。
+//
+// priority はクライアントが通知音や表示の強さの重み付けに使うヒントで、サーバは値を保持して
+// 購読一覧 API（および将来のプッシュ通知 payload）に含めるだけで解釈しない。mute_until は
+// その日時までミュートすることを表し、新着通知イベントを生成する側は
+// model.SubscriptionNotificationSetting.Muted が true の購読についてイベントを生成してはならない。
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service は購読単位の通知ヒント設定のサービス層。
+type Service struct {
+	repo repository.SubscriptionNotificationRepository
+	// cacheInvalidator は購読一覧キャッシュの無効化先。未設定時は nil。
+	cacheInvalidator cache.UserInvalidator
+	now              func() time.Time
+}
+
+// Option は NewService の任意設定を表す functional option。
+type Option func(*Service)
+
+// WithCacheInvalidator は設定の更新時に当該ユーザーの購読一覧キャッシュ
+// （priority / mute_until を含む）を無効化する invalidator を設定する。
+func WithCacheInvalidator(inv cache.UserInvalidator) Option {
+	return func(s *Service) {
+		s.cacheInvalidator = inv
+	}
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.SubscriptionNotificationRepository, opts ...Option) *Service {
+	s := &Service{repo: repo, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetSetting は当該ユーザーの購読の通知ヒント設定を返す。
+// 期限を過ぎた mute_until はミュート解除済みとして nil で返す。
+// 対象購読が存在しない、または他ユーザーの購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) GetSetting(ctx context.Context, userID, subscriptionID string) (*model.SubscriptionNotificationSetting, error) {
+	setting, err := s.repo.GetNotificationSetting(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("通知設定の取得に失敗しました: %w", err)
+	}
+	if setting == nil {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	if !setting.Muted(s.now()) {
+		setting.MuteUntil = nil
+	}
+	return setting, nil
+}
+
+// UpdateSetting は当該ユーザーの購読の通知ヒント設定を検証して上書き保存する。
+// priority が空の場合は既定値（normal）とし、muteUntil に nil を指定するとミュートを解除する。
+// muteUntil には現在より後の日時のみ指定できる。
+func (s *Service) UpdateSetting(ctx context.Context, userID, subscriptionID string, priority model.NotificationPriority, muteUntil *time.Time) (*model.SubscriptionNotificationSetting, error) {
+	if priority == "" {
+		priority = model.DefaultNotificationPriority
+	}
+	if !priority.Valid() {
+		return nil, model.NewInvalidNotificationSettingError(fmt.Sprintf("未知の優先度 %q", priority))
+	}
+	if muteUntil != nil && !muteUntil.After(s.now()) {
+		return nil, model.NewInvalidNotificationSettingError("mute_until が過去の日時です")
+	}
+
+	setting := model.SubscriptionNotificationSetting{Priority: priority, MuteUntil: muteUntil}
+	updated, err := s.repo.UpdateNotificationSetting(ctx, userID, subscriptionID, setting)
+	if err != nil {
+		return nil, fmt.Errorf("通知設定の更新に失敗しました: %w", err)
+	}
+	if !updated {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	if s.cacheInvalidator != nil {
+		s.cacheInvalidator.InvalidateUser(ctx, userID)
+	}
+	return &setting, nil
+}
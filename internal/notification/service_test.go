@@ -0,0 +1,239 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// --- テスト用モック ---
+
+// mockNotificationRepo は SubscriptionNotificationRepository のモック。
+type mockNotificationRepo struct {
+	getFn       func(ctx context.Context, userID, subscriptionID string) (*model.SubscriptionNotificationSetting, error)
+	updateFn    func(ctx context.Context, userID, subscriptionID string, setting model.SubscriptionNotificationSetting) (bool, error)
+	updateCalls int
+	saved       model.SubscriptionNotificationSetting
+}
+
+func (m *mockNotificationRepo) GetNotificationSetting(ctx context.Context, userID, subscriptionID string) (*model.SubscriptionNotificationSetting, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx, userID, subscriptionID)
+	}
+	return &model.SubscriptionNotificationSetting{Priority: model.DefaultNotificationPriority}, nil
+}
+
+func (m *mockNotificationRepo) UpdateNotificationSetting(ctx context.Context, userID, subscriptionID string, setting model.SubscriptionNotificationSetting) (bool, error) {
+	m.updateCalls++
+	m.saved = setting
+	if m.updateFn != nil {
+		return m.updateFn(ctx, userID, subscriptionID, setting)
+	}
+	return true, nil
+}
+
+var _ repository.SubscriptionNotificationRepository = (*mockNotificationRepo)(nil)
+
+// mockInvalidator は cache.UserInvalidator のモック。
+type mockInvalidator struct {
+	invalidated []string
+}
+
+func (m *mockInvalidator) InvalidateUser(_ context.Context, userID string) {
+	m.invalidated = append(m.invalidated, userID)
+}
+
+// assertAPIErrorCode は err が指定コードの APIError であることを検証する。
+func assertAPIErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Fatalf("err = %v, want APIError code %s", err, code)
+	}
+}
+
+var fixedNow = time.Date(2026, 6, 23, 12, 0, 0, 0, time.UTC)
+
+func newTestService(repo repository.SubscriptionNotificationRepository, opts ...Option) *Service {
+	s := NewService(repo, opts...)
+	s.now = func() time.Time { return fixedNow }
+	return s
+}
+
+// --- GetSetting ---
+
+func TestService_GetSetting(t *testing.T) {
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockNotificationRepo{
+			getFn: func(context.Context, string, string) (*model.SubscriptionNotificationSetting, error) { return nil, nil },
+		}
+		svc := newTestService(repo)
+
+		// Act
+		_, err := svc.GetSetting(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+	})
+
+	t.Run("ミュート期限内のときmute_untilをそのまま返す", func(t *testing.T) {
+		// Arrange
+		until := fixedNow.Add(time.Hour)
+		repo := &mockNotificationRepo{
+			getFn: func(context.Context, string, string) (*model.SubscriptionNotificationSetting, error) {
+				return &model.SubscriptionNotificationSetting{Priority: model.NotificationPriorityHigh, MuteUntil: &until}, nil
+			},
+		}
+		svc := newTestService(repo)
+
+		// Act
+		got, err := svc.GetSetting(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Priority != model.NotificationPriorityHigh {
+			t.Errorf("Priority = %q, want high", got.Priority)
+		}
+		if got.MuteUntil == nil || !got.MuteUntil.Equal(until) {
+			t.Errorf("MuteUntil = %v, want %v", got.MuteUntil, until)
+		}
+	})
+
+	t.Run("ミュート期限を過ぎているときmute_untilをnilで返す", func(t *testing.T) {
+		// Arrange
+		until := fixedNow.Add(-time.Minute)
+		repo := &mockNotificationRepo{
+			getFn: func(context.Context, string, string) (*model.SubscriptionNotificationSetting, error) {
+				return &model.SubscriptionNotificationSetting{Priority: model.NotificationPriorityLow, MuteUntil: &until}, nil
+			},
+		}
+		svc := newTestService(repo)
+
+		// Act
+		got, err := svc.GetSetting(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.MuteUntil != nil {
+			t.Errorf("MuteUntil = %v, want nil", got.MuteUntil)
+		}
+	})
+}
+
+// --- UpdateSetting ---
+
+func TestService_UpdateSetting(t *testing.T) {
+	t.Run("優先度が空のとき既定値normalで保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockNotificationRepo{}
+		svc := newTestService(repo)
+
+		// Act
+		got, err := svc.UpdateSetting(context.Background(), "user-1", "sub-1", "", nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Priority != model.NotificationPriorityNormal || repo.saved.Priority != model.NotificationPriorityNormal {
+			t.Errorf("Priority = %q (saved %q), want normal", got.Priority, repo.saved.Priority)
+		}
+	})
+
+	t.Run("未知の優先度のときINVALID_NOTIFICATION_SETTINGを返し保存しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockNotificationRepo{}
+		svc := newTestService(repo)
+
+		// Act
+		_, err := svc.UpdateSetting(context.Background(), "user-1", "sub-1", "urgent", nil)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeInvalidNotificationSetting)
+		if repo.updateCalls != 0 {
+			t.Errorf("updateCalls = %d, want 0", repo.updateCalls)
+		}
+	})
+
+	t.Run("mute_untilが現在以前のときINVALID_NOTIFICATION_SETTINGを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockNotificationRepo{}
+		svc := newTestService(repo)
+		past := fixedNow
+
+		// Act
+		_, err := svc.UpdateSetting(context.Background(), "user-1", "sub-1", model.NotificationPriorityHigh, &past)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeInvalidNotificationSetting)
+	})
+
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返しキャッシュを無効化しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockNotificationRepo{
+			updateFn: func(context.Context, string, string, model.SubscriptionNotificationSetting) (bool, error) {
+				return false, nil
+			},
+		}
+		inv := &mockInvalidator{}
+		svc := newTestService(repo, WithCacheInvalidator(inv))
+
+		// Act
+		_, err := svc.UpdateSetting(context.Background(), "user-1", "sub-1", model.NotificationPriorityLow, nil)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+		if len(inv.invalidated) != 0 {
+			t.Errorf("invalidated = %v, want none", inv.invalidated)
+		}
+	})
+
+	t.Run("更新に成功したとき設定を返し購読一覧キャッシュを無効化する", func(t *testing.T) {
+		// Arrange
+		repo := &mockNotificationRepo{}
+		inv := &mockInvalidator{}
+		svc := newTestService(repo, WithCacheInvalidator(inv))
+		until := fixedNow.Add(8 * time.Hour)
+
+		// Act
+		got, err := svc.UpdateSetting(context.Background(), "user-1", "sub-1", model.NotificationPriorityHigh, &until)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Priority != model.NotificationPriorityHigh || got.MuteUntil == nil || !got.MuteUntil.Equal(until) {
+			t.Errorf("got = %+v, want high until %v", got, until)
+		}
+		if len(inv.invalidated) != 1 || inv.invalidated[0] != "user-1" {
+			t.Errorf("invalidated = %v, want [user-1]", inv.invalidated)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockNotificationRepo{
+			updateFn: func(context.Context, string, string, model.SubscriptionNotificationSetting) (bool, error) {
+				return false, errors.New("db down")
+			},
+		}
+		svc := newTestService(repo)
+
+		// Act
+		_, err := svc.UpdateSetting(context.Background(), "user-1", "sub-1", model.NotificationPriorityNormal, nil)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,140 @@
+// Package pagination はカーソルベースページネーション API 共通のカーソル形式を提供する。
+//
+// カーソルは「並び順の識別子 + ソートキーのタイムスタンプ + 同時刻内の tiebreaker ID」を
+// JSON にまとめて base64url（パディングなし）でエンコードした不透明トークンとして発行する。
+// クライアントはトークンの中身に依存せず、前回レスポンスの next_cursor をそのまま渡す。
+// 並び順の識別子を内包するため、別の並び順で発行されたカーソルの流用は不正として拒否できる。
+//
+// 後方互換のため、LegacyFormatSunset までは旧形式（`<RFC3339Nano>`・`<RFC3339Nano>|<id>`・
+// `<RFC3339Nano>:<id>`）のカーソルも受理する。
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// cursorVersion はトークン形式のバージョン。形式を変更する場合に増やす。
+const cursorVersion = 1
+
+// LegacyFormatSunset は旧形式カーソルの受理期限。これ以降は旧形式を不正なカーソルとして扱う。
+var LegacyFormatSunset = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrInvalidCursor はカーソルを解釈できないことを表す。
+// 呼び出し側は API ごとの入力エラー（INVALID_FILTER 等）に変換して返す。
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor はカーソルが指すページ境界の位置を表す。
+type Cursor struct {
+	// Time は直前ページ末尾のソートキー（published_at / created_at 等）。
+	Time time.Time
+	// ID は同一タイムスタンプ内の並びを決める tiebreaker（通常はレコードの ID）。
+	// 旧形式の `<RFC3339Nano>` から復元した場合は空文字列。
+	ID string
+	// Rank はタイムスタンプより優先するソートキー（評価順の rating 等）。並び順が用いない場合は 0。
+	Rank int
+}
+
+// payload はトークンにエンコードする内容。キーは短縮名でトークン長を抑える。
+type payload struct {
+	Version int    `json:"v"`
+	Sort    string `json:"s"`
+	Time    string `json:"t"`
+	ID      string `json:"id,omitempty"`
+	Rank    int    `json:"r,omitempty"`
+}
+
+// Encode は sort（並び順の識別子）と c から不透明トークンを生成する。
+func Encode(sort string, c Cursor) string {
+	b, _ := json.Marshal(payload{
+		Version: cursorVersion,
+		Sort:    sort,
+		Time:    c.Time.UTC().Format(time.RFC3339Nano),
+		ID:      c.ID,
+		Rank:    c.Rank,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode は token を sort の並び順のカーソルとして復元する。
+// token が空文字列の場合はゼロ値（先頭ページ）を返す。
+// 並び順の不一致・形式不正・受理期限を過ぎた旧形式は ErrInvalidCursor を返す。
+func Decode(sort, token string) (Cursor, error) {
+	return decodeAt(sort, token, time.Now())
+}
+
+// decodeAt は now を基準に旧形式の受理可否を判定する Decode の本体。
+func decodeAt(sort, token string, now time.Time) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+	if c, ok := decodeToken(sort, token); ok {
+		return c, nil
+	}
+	if now.Before(LegacyFormatSunset) {
+		if c, ok := decodeLegacy(token); ok {
+			return c, nil
+		}
+	}
+	return Cursor{}, ErrInvalidCursor
+}
+
+// decodeToken は不透明トークン形式を復元する。
+func decodeToken(sort, token string) (Cursor, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, false
+	}
+	var p payload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return Cursor{}, false
+	}
+	if p.Version != cursorVersion || p.Sort != sort {
+		return Cursor{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, p.Time)
+	if err != nil {
+		return Cursor{}, false
+	}
+	return Cursor{Time: t, ID: p.ID, Rank: p.Rank}, true
+}
+
+// decodeLegacy は旧形式のカーソルを復元する。
+// タイムスタンプ単体を先に試し、次に `|` 区切り、最後に末尾の `:` 区切りを試す
+// （RFC3339 自体が `:` を含むため、`:` 区切りは末尾で分割する）。
+func decodeLegacy(token string) (Cursor, bool) {
+	if t, ok := parseLegacyTime(token); ok {
+		return Cursor{Time: t}, true
+	}
+	if ts, id, found := strings.Cut(token, "|"); found {
+		t, ok := parseLegacyTime(ts)
+		if !ok || id == "" || strings.Contains(id, "|") {
+			return Cursor{}, false
+		}
+		return Cursor{Time: t, ID: id}, true
+	}
+	idx := strings.LastIndex(token, ":")
+	if idx <= 0 || idx == len(token)-1 {
+		return Cursor{}, false
+	}
+	t, ok := parseLegacyTime(token[:idx])
+	if !ok {
+		return Cursor{}, false
+	}
+	return Cursor{Time: t, ID: token[idx+1:]}, true
+}
+
+// parseLegacyTime は旧形式のタイムスタンプ部を RFC3339Nano → RFC3339 の順でパースする。
+func parseLegacyTime(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return time.Time{}, false
+		}
+	}
+	return t, true
+}
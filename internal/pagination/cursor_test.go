@@ -0,0 +1,135 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	ts := time.Date(2026, 6, 21, 12, 34, 56, 123456789, time.FixedZone("JST", 9*60*60))
+
+	t.Run("エンコードしたカーソルを同じ並び順で復元できる", func(t *testing.T) {
+		// Arrange
+		token := Encode("items.published_at_desc", Cursor{Time: ts, ID: "item-1"})
+
+		// Act
+		got, err := Decode("items.published_at_desc", token)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if !got.Time.Equal(ts) || got.ID != "item-1" {
+			t.Errorf("Decode() = %+v, want (%v, item-1)", got, ts)
+		}
+	})
+
+	t.Run("ランクを含むカーソルを復元できる", func(t *testing.T) {
+		// Arrange
+		token := Encode("starred_items.rating_desc", Cursor{Time: ts, ID: "item-1", Rank: 4})
+
+		// Act
+		got, err := Decode("starred_items.rating_desc", token)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if got.Rank != 4 || got.ID != "item-1" || !got.Time.Equal(ts) {
+			t.Errorf("Decode() = %+v, want (%v, item-1, 4)", got, ts)
+		}
+	})
+
+	t.Run("トークンはクエリパラメータにそのまま使える文字のみで構成される", func(t *testing.T) {
+		token := Encode("items.published_at_desc", Cursor{Time: ts, ID: "item-1"})
+
+		if strings.ContainsAny(token, "+/=:|") {
+			t.Errorf("token %q contains characters that need escaping", token)
+		}
+	})
+
+	t.Run("空文字列のときゼロ値を返す", func(t *testing.T) {
+		got, err := Decode("items.published_at_desc", "")
+
+		if err != nil || !got.Time.IsZero() || got.ID != "" {
+			t.Errorf("Decode(\"\") = (%+v, %v), want zero value", got, err)
+		}
+	})
+
+	t.Run("別の並び順で発行されたカーソルは不正とする", func(t *testing.T) {
+		token := Encode("audit_logs.created_at_desc", Cursor{Time: ts, ID: "log-1"})
+
+		_, err := Decode("items.published_at_desc", token)
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode() error = %v, want ErrInvalidCursor", err)
+		}
+	})
+
+	t.Run("未知のバージョンのトークンは不正とする", func(t *testing.T) {
+		token := base64.RawURLEncoding.EncodeToString([]byte(`{"v":99,"s":"items","t":"2026-06-21T00:00:00Z"}`))
+
+		_, err := Decode("items", token)
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode() error = %v, want ErrInvalidCursor", err)
+		}
+	})
+}
+
+func TestDecodeLegacy(t *testing.T) {
+	beforeSunset := LegacyFormatSunset.Add(-time.Hour)
+	ts := time.Date(2026, 6, 21, 12, 34, 56, 123456789, time.UTC)
+	tsStr := ts.Format(time.RFC3339Nano)
+
+	tests := []struct {
+		name   string
+		token  string
+		wantID string
+	}{
+		{name: "タイムスタンプ単体", token: tsStr},
+		{name: "パイプ区切り", token: tsStr + "|item-1", wantID: "item-1"},
+		{name: "コロン区切り", token: tsStr + ":item-1", wantID: "item-1"},
+	}
+	for _, tt := range tests {
+		t.Run("受理期限前は旧形式の"+tt.name+"を受理する", func(t *testing.T) {
+			got, err := decodeAt("items", tt.token, beforeSunset)
+
+			if err != nil {
+				t.Fatalf("decodeAt() error = %v", err)
+			}
+			if !got.Time.Equal(ts) || got.ID != tt.wantID {
+				t.Errorf("decodeAt() = %+v, want (%v, %q)", got, ts, tt.wantID)
+			}
+		})
+	}
+
+	t.Run("受理期限後は旧形式を不正とする", func(t *testing.T) {
+		_, err := decodeAt("items", tsStr, LegacyFormatSunset)
+
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("decodeAt() error = %v, want ErrInvalidCursor", err)
+		}
+	})
+
+	t.Run("受理期限後も新形式は受理する", func(t *testing.T) {
+		token := Encode("items", Cursor{Time: ts, ID: "item-1"})
+
+		if _, err := decodeAt("items", token, LegacyFormatSunset.Add(time.Hour)); err != nil {
+			t.Errorf("decodeAt() error = %v", err)
+		}
+	})
+
+	for _, token := range []string{"garbage", "a|b|c", tsStr + "|", tsStr + ":", "not-a-time:item-1"} {
+		t.Run("不正な旧形式 "+token+" を拒否する", func(t *testing.T) {
+			_, err := decodeAt("items", token, beforeSunset)
+
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeAt(%q) error = %v, want ErrInvalidCursor", token, err)
+			}
+		})
+	}
+}
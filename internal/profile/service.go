@@ -0,0 +1,105 @@
+// Package profile は購読リストの公開プロフィール共有のドメインロジックを提供する。
+//
+// ユーザーは公開フラグとスラッグを設定し、個別購読ごとに公開/非公開を選べる。
+// 未認証の閲覧者は GET /public/{slug}/subscriptions で公開設定された購読の
+// タイトルとサイト URL のみを閲覧できる。
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// slugPattern は公開スラッグの許容形式（英小文字・数字・ハイフンの 3〜32 文字、先頭は英数字）。
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,31}$`)
+
+// Service は公開プロフィールのサービス層。
+type Service struct {
+	repo repository.PublicProfileRepository
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.PublicProfileRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetProfile は当該ユーザーの公開設定を返す。
+// 未設定の場合は公開無効・スラッグ空のゼロ値設定を返す。
+func (s *Service) GetProfile(ctx context.Context, userID string) (*model.PublicProfile, error) {
+	profile, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("公開プロフィール設定の取得に失敗しました: %w", err)
+	}
+	if profile == nil {
+		return &model.PublicProfile{UserID: userID}, nil
+	}
+	return profile, nil
+}
+
+// UpdateProfile は当該ユーザーの公開フラグとスラッグを更新する。
+// スラッグは小文字に正規化してから検証する。公開を有効にする場合はスラッグ必須。
+// 公開を無効にする場合もスラッグは保持でき、再公開時に同じ URL を使える。
+func (s *Service) UpdateProfile(ctx context.Context, userID string, enabled bool, slug string) (*model.PublicProfile, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug != "" && !slugPattern.MatchString(slug) {
+		return nil, model.NewInvalidProfileSlugError(slug)
+	}
+	if enabled && slug == "" {
+		return nil, model.NewInvalidProfileSlugError(slug)
+	}
+
+	profile := &model.PublicProfile{
+		UserID:  userID,
+		Enabled: enabled,
+		Slug:    slug,
+	}
+	if err := s.repo.Upsert(ctx, profile); err != nil {
+		if errors.Is(err, repository.ErrPublicSlugTaken) {
+			return nil, model.NewProfileSlugTakenError(slug)
+		}
+		return nil, fmt.Errorf("公開プロフィール設定の更新に失敗しました: %w", err)
+	}
+	return profile, nil
+}
+
+// SetSubscriptionVisibility は当該ユーザーの購読の公開可否を更新する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) SetSubscriptionVisibility(ctx context.Context, userID, subscriptionID string, isPublic bool) error {
+	updated, err := s.repo.UpdateSubscriptionVisibility(ctx, userID, subscriptionID, isPublic)
+	if err != nil {
+		return fmt.Errorf("購読の公開設定の更新に失敗しました: %w", err)
+	}
+	if !updated {
+		return model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	return nil
+}
+
+// ListPublicSubscriptions はスラッグで指定された公開プロフィールの公開購読一覧を返す。
+// スラッグが未登録、または公開が無効な場合は区別せず PROFILE_NOT_FOUND を返す。
+func (s *Service) ListPublicSubscriptions(ctx context.Context, slug string) ([]model.PublicSubscription, error) {
+	slug = strings.ToLower(slug)
+	if !slugPattern.MatchString(slug) {
+		return nil, model.NewProfileNotFoundError(slug)
+	}
+
+	userID, err := s.repo.FindUserIDBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("公開プロフィールの検索に失敗しました: %w", err)
+	}
+	if userID == "" {
+		return nil, model.NewProfileNotFoundError(slug)
+	}
+
+	subs, err := s.repo.ListPublicSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("公開購読一覧の取得に失敗しました: %w", err)
+	}
+	return subs, nil
+}
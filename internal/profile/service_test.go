@@ -0,0 +1,337 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// --- テスト用モック ---
+
+// mockPublicProfileRepo は PublicProfileRepository のモック。
+type mockPublicProfileRepo struct {
+	getByUserIDFn          func(ctx context.Context, userID string) (*model.PublicProfile, error)
+	upsertFn               func(ctx context.Context, profile *model.PublicProfile) error
+	findUserIDBySlugFn     func(ctx context.Context, slug string) (string, error)
+	listPublicSubsFn       func(ctx context.Context, userID string) ([]model.PublicSubscription, error)
+	updateVisibilityFn     func(ctx context.Context, userID, subscriptionID string, isPublic bool) (bool, error)
+	upsertCalledWith       *model.PublicProfile
+	listPublicSubsCalledID string
+}
+
+func (m *mockPublicProfileRepo) GetByUserID(ctx context.Context, userID string) (*model.PublicProfile, error) {
+	if m.getByUserIDFn != nil {
+		return m.getByUserIDFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockPublicProfileRepo) Upsert(ctx context.Context, profile *model.PublicProfile) error {
+	m.upsertCalledWith = profile
+	if m.upsertFn != nil {
+		return m.upsertFn(ctx, profile)
+	}
+	return nil
+}
+
+func (m *mockPublicProfileRepo) FindUserIDBySlug(ctx context.Context, slug string) (string, error) {
+	if m.findUserIDBySlugFn != nil {
+		return m.findUserIDBySlugFn(ctx, slug)
+	}
+	return "", nil
+}
+
+func (m *mockPublicProfileRepo) ListPublicSubscriptions(ctx context.Context, userID string) ([]model.PublicSubscription, error) {
+	m.listPublicSubsCalledID = userID
+	if m.listPublicSubsFn != nil {
+		return m.listPublicSubsFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockPublicProfileRepo) UpdateSubscriptionVisibility(ctx context.Context, userID, subscriptionID string, isPublic bool) (bool, error) {
+	if m.updateVisibilityFn != nil {
+		return m.updateVisibilityFn(ctx, userID, subscriptionID, isPublic)
+	}
+	return true, nil
+}
+
+// assertAPIErrorCode は err が期待コードの APIError であることを検証する。
+func assertAPIErrorCode(t *testing.T, err error, wantCode string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.Code != wantCode {
+		t.Errorf("Code = %q, want %q", apiErr.Code, wantCode)
+	}
+}
+
+// --- GetProfile テスト ---
+
+func TestGetProfile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("設定未登録のとき公開無効のゼロ値設定を返す", func(t *testing.T) {
+		// Arrange
+		s := NewService(&mockPublicProfileRepo{})
+
+		// Act
+		got, err := s.GetProfile(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.UserID != "user-1" || got.Enabled || got.Slug != "" {
+			t.Errorf("got %+v, want zero profile for user-1", got)
+		}
+	})
+
+	t.Run("設定登録済みのときリポジトリの値をそのまま返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockPublicProfileRepo{
+			getByUserIDFn: func(_ context.Context, userID string) (*model.PublicProfile, error) {
+				return &model.PublicProfile{UserID: userID, Enabled: true, Slug: "alice"}, nil
+			},
+		}
+		s := NewService(repo)
+
+		// Act
+		got, err := s.GetProfile(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.Enabled || got.Slug != "alice" {
+			t.Errorf("got %+v, want enabled profile with slug alice", got)
+		}
+	})
+}
+
+// --- UpdateProfile テスト ---
+
+func TestUpdateProfile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("有効なスラッグのとき小文字に正規化して保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockPublicProfileRepo{}
+		s := NewService(repo)
+
+		// Act
+		got, err := s.UpdateProfile(ctx, "user-1", true, "  My-Feeds ")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Slug != "my-feeds" {
+			t.Errorf("Slug = %q, want %q", got.Slug, "my-feeds")
+		}
+		if repo.upsertCalledWith == nil || repo.upsertCalledWith.Slug != "my-feeds" || !repo.upsertCalledWith.Enabled {
+			t.Errorf("upsert called with %+v", repo.upsertCalledWith)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		enabled bool
+		slug    string
+	}{
+		{name: "公開有効でスラッグが空のとき INVALID_PROFILE_SLUG を返す", enabled: true, slug: ""},
+		{name: "スラッグが短すぎるとき INVALID_PROFILE_SLUG を返す", enabled: true, slug: "ab"},
+		{name: "スラッグに記号が含まれるとき INVALID_PROFILE_SLUG を返す", enabled: false, slug: "my_feeds"},
+		{name: "スラッグがハイフン始まりのとき INVALID_PROFILE_SLUG を返す", enabled: true, slug: "-feeds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := &mockPublicProfileRepo{}
+			s := NewService(repo)
+
+			// Act
+			_, err := s.UpdateProfile(ctx, "user-1", tt.enabled, tt.slug)
+
+			// Assert
+			assertAPIErrorCode(t, err, model.ErrCodeInvalidProfileSlug)
+			if repo.upsertCalledWith != nil {
+				t.Error("不正なスラッグで Upsert が呼ばれてはならない")
+			}
+		})
+	}
+
+	t.Run("公開無効でスラッグが空のとき保存できる", func(t *testing.T) {
+		// Arrange
+		s := NewService(&mockPublicProfileRepo{})
+
+		// Act
+		got, err := s.UpdateProfile(ctx, "user-1", false, "")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Enabled {
+			t.Error("Enabled = true, want false")
+		}
+	})
+
+	t.Run("スラッグが重複したとき PROFILE_SLUG_TAKEN を返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockPublicProfileRepo{
+			upsertFn: func(_ context.Context, _ *model.PublicProfile) error {
+				return repository.ErrPublicSlugTaken
+			},
+		}
+		s := NewService(repo)
+
+		// Act
+		_, err := s.UpdateProfile(ctx, "user-1", true, "taken")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeProfileSlugTaken)
+	})
+}
+
+// --- SetSubscriptionVisibility テスト ---
+
+func TestSetSubscriptionVisibility(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("対象購読を更新できたとき nil を返す", func(t *testing.T) {
+		// Arrange
+		var gotUserID, gotSubID string
+		var gotPublic bool
+		repo := &mockPublicProfileRepo{
+			updateVisibilityFn: func(_ context.Context, userID, subscriptionID string, isPublic bool) (bool, error) {
+				gotUserID, gotSubID, gotPublic = userID, subscriptionID, isPublic
+				return true, nil
+			},
+		}
+		s := NewService(repo)
+
+		// Act
+		err := s.SetSubscriptionVisibility(ctx, "user-1", "sub-1", true)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotUserID != "user-1" || gotSubID != "sub-1" || !gotPublic {
+			t.Errorf("called with (%q, %q, %v)", gotUserID, gotSubID, gotPublic)
+		}
+	})
+
+	t.Run("対象購読が存在しないとき SUBSCRIPTION_NOT_FOUND を返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockPublicProfileRepo{
+			updateVisibilityFn: func(_ context.Context, _, _ string, _ bool) (bool, error) {
+				return false, nil
+			},
+		}
+		s := NewService(repo)
+
+		// Act
+		err := s.SetSubscriptionVisibility(ctx, "user-1", "sub-x", false)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+	})
+}
+
+// --- ListPublicSubscriptions テスト ---
+
+func TestListPublicSubscriptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("公開プロフィールが有効なとき公開購読一覧を返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockPublicProfileRepo{
+			findUserIDBySlugFn: func(_ context.Context, slug string) (string, error) {
+				if slug != "alice" {
+					t.Errorf("slug = %q, want %q", slug, "alice")
+				}
+				return "user-1", nil
+			},
+			listPublicSubsFn: func(_ context.Context, _ string) ([]model.PublicSubscription, error) {
+				return []model.PublicSubscription{{Title: "Go Blog", SiteURL: "https://go.dev/blog"}}, nil
+			},
+		}
+		s := NewService(repo)
+
+		// Act
+		got, err := s.ListPublicSubscriptions(ctx, "Alice")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.listPublicSubsCalledID != "user-1" {
+			t.Errorf("ListPublicSubscriptions userID = %q, want %q", repo.listPublicSubsCalledID, "user-1")
+		}
+		if len(got) != 1 || got[0].Title != "Go Blog" {
+			t.Errorf("got %+v", got)
+		}
+	})
+
+	t.Run("スラッグが未登録または非公開のとき PROFILE_NOT_FOUND を返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockPublicProfileRepo{}
+		s := NewService(repo)
+
+		// Act
+		_, err := s.ListPublicSubscriptions(ctx, "nobody")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeProfileNotFound)
+		if repo.listPublicSubsCalledID != "" {
+			t.Error("非公開プロフィールで購読一覧を取得してはならない")
+		}
+	})
+
+	t.Run("スラッグが形式不正のときリポジトリを呼ばず PROFILE_NOT_FOUND を返す", func(t *testing.T) {
+		// Arrange
+		called := false
+		repo := &mockPublicProfileRepo{
+			findUserIDBySlugFn: func(_ context.Context, _ string) (string, error) {
+				called = true
+				return "", nil
+			},
+		}
+		s := NewService(repo)
+
+		// Act
+		_, err := s.ListPublicSubscriptions(ctx, "../etc")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeProfileNotFound)
+		if called {
+			t.Error("形式不正なスラッグでリポジトリを呼んではならない")
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときラップして返す", func(t *testing.T) {
+		// Arrange
+		dbErr := errors.New("db down")
+		repo := &mockPublicProfileRepo{
+			findUserIDBySlugFn: func(_ context.Context, _ string) (string, error) {
+				return "", dbErr
+			},
+		}
+		s := NewService(repo)
+
+		// Act
+		_, err := s.ListPublicSubscriptions(ctx, "alice")
+
+		// Assert
+		if !errors.Is(err, dbErr) {
+			t.Errorf("err = %v, want wrapped %v", err, dbErr)
+		}
+	})
+}
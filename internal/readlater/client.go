@@ -0,0 +1,93 @@
+package readlater
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// userAgent は連携先サービスへのリクエストに付ける User-Agent。
+const userAgent = "Feedman/1.0 RSS Reader"
+
+// maxResponseBodySize は連携先サービスの応答から読み取る本文の上限。
+const maxResponseBodySize = 64 << 10
+
+// Credentials は連携先サービスの認証情報。JSON にして SecretBox で暗号化した値を DB に保存する。
+// Pocket は AccessToken のみ、Instapaper は OAuth 1.0a のトークンとトークンシークレットを使う。
+type Credentials struct {
+	AccessToken string `json:"access_token"`
+	TokenSecret string `json:"token_secret,omitempty"`
+}
+
+// Saver は記事を「あとで読む」サービスに保存するクライアント。
+// 失敗時は *SaveError を返し、保存ワーカーは Outcome に応じて再試行・連携の無効化を判断する。
+type Saver interface {
+	Save(ctx context.Context, creds Credentials, link, title string) error
+}
+
+// SaveOutcome は保存に失敗した理由の分類。
+type SaveOutcome int
+
+const (
+	// SaveRetry は一時的な失敗（5xx・通信エラー）で再試行する。
+	SaveRetry SaveOutcome = iota
+	// SaveRateLimited は連携先のレート制限に達した。RetryAfter の経過後に再試行する。
+	SaveRateLimited
+	// SaveAuthRevoked は認証情報が無効（連携の取り消し・トークンの失効）で、連携し直すまで保存できない。
+	SaveAuthRevoked
+	// SaveRejected は記事の内容を拒否された（URL の不正など）恒久的な失敗で、再試行しない。
+	SaveRejected
+)
+
+// SaveError は保存の失敗を表す。Reason は連携の last_error として利用者に返すため、認証情報を含めない。
+type SaveError struct {
+	Outcome    SaveOutcome
+	RetryAfter time.Duration
+	Reason     string
+}
+
+func (e *SaveError) Error() string {
+	return e.Reason
+}
+
+// classifySaveStatus は保存 API の HTTP ステータスを失敗の分類に変換する。2xx の場合は nil を返す。
+// 401 / 403 は認証情報の無効、429 はレート制限、5xx は一時的な失敗、その他の 4xx は記事の拒否とする。
+func classifySaveStatus(provider model.ReadLaterProvider, status int, retryAfter string) *SaveError {
+	switch {
+	case status >= 200 && status < 300:
+		return nil
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return &SaveError{Outcome: SaveAuthRevoked, Reason: fmt.Sprintf("%s の認証が無効になりました（HTTP %d）。連携し直してください", provider, status)}
+	case status == http.StatusTooManyRequests:
+		return &SaveError{Outcome: SaveRateLimited, RetryAfter: parseRetryAfter(retryAfter), Reason: fmt.Sprintf("%s の保存レート制限に達しました（HTTP 429）", provider)}
+	case status >= 500:
+		return &SaveError{Outcome: SaveRetry, Reason: fmt.Sprintf("%s がエラーを返しました（HTTP %d）", provider, status)}
+	default:
+		return &SaveError{Outcome: SaveRejected, Reason: fmt.Sprintf("%s が保存を拒否しました（HTTP %d）", provider, status)}
+	}
+}
+
+// parseRetryAfter は Retry-After 等の秒数指定を解釈する。解釈できない場合は 0 を返す。
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.ParseFloat(v, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// newRequest は連携先サービスへの POST リクエストを組み立てる。
+func newRequest(ctx context.Context, rawURL, contentType string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", userAgent)
+	return req, nil
+}
@@ -0,0 +1,203 @@
+package readlater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// DeliveryConfig は保存ジョブの設定パラメータ。
+type DeliveryConfig struct {
+	// Interval はジョブの実行間隔（デフォルト: 1分）。
+	Interval time.Duration
+	// BatchSize は 1 サイクルで保存する最大件数（デフォルト: 100）。
+	BatchSize int
+	// MaxAttempts は保存を諦めるまでの最大試行回数（デフォルト: 8回）。
+	MaxAttempts int
+	// InitialBackoff は再試行の初回遅延（デフォルト: 1分）。試行ごとに倍にする。
+	InitialBackoff time.Duration
+	// MaxBackoff は再試行の最大遅延（デフォルト: 6時間）。
+	// Pocket / Instapaper のレート制限は時間単位でリセットされるため、配送ジョブより長くする。
+	MaxBackoff time.Duration
+	// RequestTimeout は 1 リクエストあたりのタイムアウト（デフォルト: 10秒）。
+	RequestTimeout time.Duration
+}
+
+// DefaultDeliveryConfig はデフォルトの保存ジョブ設定を返す。
+func DefaultDeliveryConfig() DeliveryConfig {
+	return DeliveryConfig{
+		Interval:       time.Minute,
+		BatchSize:      100,
+		MaxAttempts:    8,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     6 * time.Hour,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+// DeliveryJob は保存キューのスター記事を「あとで読む」サービスへ保存する worker ジョブ。
+type DeliveryJob struct {
+	repo   repository.ReadLaterDeliveryRepository
+	box    *security.SecretBox
+	savers map[model.ReadLaterProvider]Saver
+	logger *slog.Logger
+	config DeliveryConfig
+	now    func() time.Time
+}
+
+// NewDeliveryJob は DeliveryJob の新しいインスタンスを生成する。
+// savers に無い保存先のキューは取り出しても保存せず、再試行として後回しにする。
+func NewDeliveryJob(
+	repo repository.ReadLaterDeliveryRepository,
+	box *security.SecretBox,
+	savers map[model.ReadLaterProvider]Saver,
+	logger *slog.Logger,
+	config DeliveryConfig,
+) *DeliveryJob {
+	return &DeliveryJob{
+		repo:   repo,
+		box:    box,
+		savers: savers,
+		logger: logger,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Start はジョブをティッカーで定期実行する。
+// コンテキストがキャンセルされるまで実行を継続する。
+func (j *DeliveryJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	j.logger.Info("あとで読むサービスへの保存ジョブを開始しました",
+		slog.Duration("interval", j.config.Interval),
+		slog.Int("batch_size", j.config.BatchSize),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("あとで読むサービスへの保存ジョブを停止しました")
+			return
+		case <-ticker.C:
+			if err := j.RunOnce(ctx); err != nil {
+				j.logger.Error("あとで読むサービスへの保存サイクルの実行に失敗しました",
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+}
+
+// RunOnce は 1 回の保存サイクルを実行する。
+// 保存期限の来た未保存を古い順に取り出して保存し、結果に応じて次のように記録する。
+//   - 認証情報の無効（連携の取り消し等）・復号できない認証情報: 失敗として確定し、連携を無効化する
+//   - 記事の拒否・最大試行回数への到達: 失敗として確定する
+//   - 一時的な失敗・レート制限: 指数バックオフで再試行を予約する（レート制限の保存先にはそのサイクル中は送らない）
+//
+// 個別の記録失敗はログに残して次へ進む。
+func (j *DeliveryJob) RunOnce(ctx context.Context) error {
+	start := j.now()
+
+	deliveries, err := j.repo.ListDueDeliveries(ctx, start, j.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("保存キューの取得に失敗しました: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	rateLimited := make(map[model.ReadLaterProvider]bool)
+	var sent, retried, failed int
+	for _, d := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if rateLimited[d.Provider] {
+			continue
+		}
+
+		saveErr := j.save(ctx, d)
+		var se *SaveError
+		if saveErr != nil && !errors.As(saveErr, &se) {
+			se = &SaveError{Outcome: SaveRetry, Reason: saveErr.Error()}
+		}
+
+		var recordErr error
+		switch {
+		case saveErr == nil:
+			sent++
+			recordErr = j.repo.MarkSent(ctx, d.ID, j.now())
+		case se.Outcome == SaveAuthRevoked:
+			failed++
+			recordErr = j.repo.MarkFailed(ctx, d.ID, se.Reason, j.now(), true)
+		case se.Outcome == SaveRejected || d.Attempts+1 >= j.config.MaxAttempts:
+			failed++
+			recordErr = j.repo.MarkFailed(ctx, d.ID, se.Reason, j.now(), false)
+		default:
+			if se.Outcome == SaveRateLimited {
+				rateLimited[d.Provider] = true
+			}
+			retried++
+			recordErr = j.repo.MarkRetry(ctx, d.ID, j.now().Add(j.retryDelay(d.Attempts, se.RetryAfter)), se.Reason, j.now())
+		}
+		if recordErr != nil {
+			j.logger.Warn("保存結果の記録に失敗しました",
+				slog.Int64("delivery_id", d.ID),
+				slog.String("connection_id", d.ConnectionID),
+				slog.String("error", recordErr.Error()),
+			)
+		}
+	}
+
+	j.logger.Info("あとで読むサービスへの保存サイクルが完了しました",
+		slog.Int("sent", sent),
+		slog.Int("retried", retried),
+		slog.Int("failed", failed),
+		slog.Float64("duration_ms", float64(j.now().Sub(start).Milliseconds())),
+	)
+	return nil
+}
+
+// save は 1 件の記事を保存先に保存する。失敗時は *SaveError を返す。
+func (j *DeliveryJob) save(ctx context.Context, d model.ReadLaterDelivery) error {
+	saver, ok := j.savers[d.Provider]
+	if !ok {
+		return &SaveError{Outcome: SaveRetry, Reason: fmt.Sprintf("%s への保存はこのサーバーで設定されていません", d.Provider)}
+	}
+	creds, err := openCredentials(j.box, d.Credentials)
+	if err != nil {
+		// 暗号鍵の変更などで復号できない認証情報は再試行しても使えないため、連携し直してもらう
+		return &SaveError{Outcome: SaveAuthRevoked, Reason: "保存済みの認証情報を読み取れませんでした。連携し直してください"}
+	}
+	if d.Link == "" {
+		return &SaveError{Outcome: SaveRejected, Reason: "記事にリンクが無いため保存できません"}
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, j.config.RequestTimeout)
+	defer cancel()
+	return saver.Save(reqCtx, creds, d.Link, d.Title)
+}
+
+// retryDelay は attempts 回失敗した後の再試行までの遅延を返す。
+// InitialBackoff から試行ごとに倍にして MaxBackoff で頭打ちにし、連携先の指定があればそれ以上待つ。
+func (j *DeliveryJob) retryDelay(attempts int, retryAfter time.Duration) time.Duration {
+	delay := j.config.InitialBackoff
+	for i := 0; i < attempts && delay < j.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > j.config.MaxBackoff {
+		delay = j.config.MaxBackoff
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay
+}
@@ -0,0 +1,202 @@
+package readlater
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockDeliveryRepo は repository.ReadLaterDeliveryRepository のモック実装。
+type mockDeliveryRepo struct {
+	deliveries []model.ReadLaterDelivery
+	sent       []int64
+	retried    map[int64]time.Time
+	failed     map[int64]string
+	disabled   map[int64]bool
+}
+
+func (m *mockDeliveryRepo) EnqueueStarredItem(_ context.Context, _, _ string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockDeliveryRepo) ListDueDeliveries(_ context.Context, _ time.Time, _ int) ([]model.ReadLaterDelivery, error) {
+	return m.deliveries, nil
+}
+
+func (m *mockDeliveryRepo) MarkSent(_ context.Context, deliveryID int64, _ time.Time) error {
+	m.sent = append(m.sent, deliveryID)
+	return nil
+}
+
+func (m *mockDeliveryRepo) MarkRetry(_ context.Context, deliveryID int64, nextAttemptAt time.Time, _ string, _ time.Time) error {
+	if m.retried == nil {
+		m.retried = make(map[int64]time.Time)
+	}
+	m.retried[deliveryID] = nextAttemptAt
+	return nil
+}
+
+func (m *mockDeliveryRepo) MarkFailed(_ context.Context, deliveryID int64, lastError string, _ time.Time, disableConnection bool) error {
+	if m.failed == nil {
+		m.failed = make(map[int64]string)
+		m.disabled = make(map[int64]bool)
+	}
+	m.failed[deliveryID] = lastError
+	m.disabled[deliveryID] = disableConnection
+	return nil
+}
+
+// mockSaver は Saver のモック実装。リンクごとに返すエラーを指定する。
+type mockSaver struct {
+	errs  map[string]error
+	saved []string
+	creds []Credentials
+}
+
+func (m *mockSaver) Save(_ context.Context, creds Credentials, link, _ string) error {
+	m.creds = append(m.creds, creds)
+	if err := m.errs[link]; err != nil {
+		return err
+	}
+	m.saved = append(m.saved, link)
+	return nil
+}
+
+func TestDeliveryJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 7, 17, 12, 0, 0, 0, time.UTC)
+	box := newTestSecretBox(t)
+	plaintext, _ := json.Marshal(Credentials{AccessToken: "acc"})
+	sealed, _ := box.Seal(plaintext)
+
+	delivery := func(id int64, provider model.ReadLaterProvider, link string, attempts int) model.ReadLaterDelivery {
+		return model.ReadLaterDelivery{
+			ID: id, ConnectionID: "conn-1", UserID: "user-1", Provider: provider, Credentials: sealed,
+			Attempts: attempts, ItemID: "item-1", Title: "Hello", Link: link,
+		}
+	}
+	newJob := func(repo *mockDeliveryRepo, savers map[model.ReadLaterProvider]Saver) *DeliveryJob {
+		job := NewDeliveryJob(repo, box, savers, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), DefaultDeliveryConfig())
+		job.now = func() time.Time { return now }
+		return job
+	}
+
+	t.Run("保存結果に応じて保存済み・再試行・失敗を記録する", func(t *testing.T) {
+		// Arrange
+		saver := &mockSaver{errs: map[string]error{
+			"https://example.com/retry":    &SaveError{Outcome: SaveRetry, Reason: "HTTP 503"},
+			"https://example.com/rejected": &SaveError{Outcome: SaveRejected, Reason: "HTTP 400"},
+			"https://example.com/revoked":  &SaveError{Outcome: SaveAuthRevoked, Reason: "HTTP 401"},
+		}}
+		repo := &mockDeliveryRepo{deliveries: []model.ReadLaterDelivery{
+			delivery(1, model.ReadLaterProviderPocket, "https://example.com/ok", 0),
+			delivery(2, model.ReadLaterProviderPocket, "https://example.com/retry", 0),
+			delivery(3, model.ReadLaterProviderPocket, "https://example.com/rejected", 0),
+			delivery(4, model.ReadLaterProviderPocket, "https://example.com/revoked", 0),
+			delivery(5, model.ReadLaterProviderPocket, "https://example.com/retry", DefaultDeliveryConfig().MaxAttempts-1),
+		}}
+		job := newJob(repo, map[model.ReadLaterProvider]Saver{model.ReadLaterProviderPocket: saver})
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if len(repo.sent) != 1 || repo.sent[0] != 1 {
+			t.Errorf("sent = %v, want [1]", repo.sent)
+		}
+		if saver.creds[0].AccessToken != "acc" {
+			t.Errorf("credentials = %+v, want decrypted access token", saver.creds[0])
+		}
+		if want := now.Add(DefaultDeliveryConfig().InitialBackoff); !repo.retried[2].Equal(want) {
+			t.Errorf("retried[2] = %v, want %v", repo.retried[2], want)
+		}
+		if repo.failed[3] != "HTTP 400" || repo.disabled[3] {
+			t.Errorf("failed[3] = %q (disable=%v), want HTTP 400 without disabling", repo.failed[3], repo.disabled[3])
+		}
+		if !repo.disabled[4] {
+			t.Error("認証が無効になった連携が無効化されていない")
+		}
+		if _, ok := repo.failed[5]; !ok || repo.disabled[5] {
+			t.Error("最大試行回数に達した保存が失敗として確定していない")
+		}
+	})
+
+	t.Run("レート制限を受けたとき同じ保存先への送信をそのサイクル中は見送る", func(t *testing.T) {
+		// Arrange
+		pocket := &mockSaver{errs: map[string]error{
+			"https://example.com/1": &SaveError{Outcome: SaveRateLimited, RetryAfter: 2 * time.Hour, Reason: "rate limited"},
+		}}
+		instapaper := &mockSaver{}
+		repo := &mockDeliveryRepo{deliveries: []model.ReadLaterDelivery{
+			delivery(1, model.ReadLaterProviderPocket, "https://example.com/1", 0),
+			delivery(2, model.ReadLaterProviderPocket, "https://example.com/2", 0),
+			delivery(3, model.ReadLaterProviderInstapaper, "https://example.com/3", 0),
+		}}
+		job := newJob(repo, map[model.ReadLaterProvider]Saver{
+			model.ReadLaterProviderPocket:     pocket,
+			model.ReadLaterProviderInstapaper: instapaper,
+		})
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if want := now.Add(2 * time.Hour); !repo.retried[1].Equal(want) {
+			t.Errorf("retried[1] = %v, want %v", repo.retried[1], want)
+		}
+		if len(pocket.creds) != 1 {
+			t.Errorf("pocket calls = %d, want 1", len(pocket.creds))
+		}
+		if len(repo.sent) != 1 || repo.sent[0] != 3 {
+			t.Errorf("sent = %v, want [3]", repo.sent)
+		}
+	})
+
+	t.Run("認証情報を復号できないとき連携を無効化する", func(t *testing.T) {
+		saver := &mockSaver{}
+		d := delivery(1, model.ReadLaterProviderPocket, "https://example.com/1", 0)
+		d.Credentials = []byte("broken")
+		repo := &mockDeliveryRepo{deliveries: []model.ReadLaterDelivery{d}}
+		job := newJob(repo, map[model.ReadLaterProvider]Saver{model.ReadLaterProviderPocket: saver})
+
+		if err := job.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+
+		if !repo.disabled[1] || len(saver.creds) != 0 {
+			t.Errorf("disabled = %v, saver calls = %d, want disabled without saving", repo.disabled[1], len(saver.creds))
+		}
+	})
+}
+
+func TestDeliveryJob_RetryDelay(t *testing.T) {
+	job := NewDeliveryJob(&mockDeliveryRepo{}, nil, nil, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), DefaultDeliveryConfig())
+	tests := []struct {
+		name       string
+		attempts   int
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{"初回", 0, 0, time.Minute},
+		{"3回失敗後", 3, 0, 8 * time.Minute},
+		{"上限を超える", 20, 0, 6 * time.Hour},
+		{"連携先の指定が長い", 0, 5 * time.Minute, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"のとき遅延を返す", func(t *testing.T) {
+			if got := job.retryDelay(tt.attempts, tt.retryAfter); got != tt.want {
+				t.Errorf("retryDelay(%d, %v) = %v, want %v", tt.attempts, tt.retryAfter, got, tt.want)
+			}
+		})
+	}
+}
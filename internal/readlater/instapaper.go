@@ -0,0 +1,113 @@
+package readlater
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// InstapaperAPIBaseURL は Instapaper Full API のベース URL。
+const InstapaperAPIBaseURL = "https://www.instapaper.com"
+
+// instapaperRateLimitErrorCode は Instapaper がレート制限の超過を表すエラーコード（HTTP 400 で返る）。
+const instapaperRateLimitErrorCode = "1040"
+
+// InstapaperClient は Instapaper Full API のクライアント。
+// xAuth（利用者のユーザー名・パスワードを OAuth 1.0a のトークンに交換する方式）で連携し、
+// 以降の保存は OAuth 1.0a の署名付きリクエストで行う。パスワードは保存しない。
+type InstapaperClient struct {
+	signer  *oauth1Signer
+	baseURL string
+	client  *http.Client
+}
+
+// NewInstapaperClient は InstapaperClient を生成する。baseURL が空の場合は InstapaperAPIBaseURL を使う。
+func NewInstapaperClient(consumerKey, consumerSecret, baseURL string, client *http.Client) *InstapaperClient {
+	if baseURL == "" {
+		baseURL = InstapaperAPIBaseURL
+	}
+	return &InstapaperClient{
+		signer:  newOAuth1Signer(consumerKey, consumerSecret),
+		baseURL: baseURL,
+		client:  client,
+	}
+}
+
+// AccessToken は利用者のユーザー名・パスワードを OAuth 1.0a のトークンとトークンシークレットに交換する。
+// ユーザー名・パスワードが誤っている場合は ErrAuthRejected を返す。
+func (c *InstapaperClient) AccessToken(ctx context.Context, username, password string) (Credentials, error) {
+	form := url.Values{}
+	form.Set("x_auth_username", username)
+	form.Set("x_auth_password", password)
+	form.Set("x_auth_mode", "client_auth")
+
+	resp, body, err := c.post(ctx, "/api/1/oauth/access_token", form, Credentials{})
+	if err != nil {
+		return Credentials{}, fmt.Errorf("instapaper: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return Credentials{}, fmt.Errorf("%w: instapaper returned HTTP %d", ErrAuthRejected, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return Credentials{}, fmt.Errorf("instapaper: unexpected status HTTP %d", resp.StatusCode)
+	}
+
+	values, err := url.ParseQuery(strings.TrimSpace(string(body)))
+	if err != nil {
+		return Credentials{}, fmt.Errorf("instapaper: invalid response: %w", err)
+	}
+	creds := Credentials{AccessToken: values.Get("oauth_token"), TokenSecret: values.Get("oauth_token_secret")}
+	if creds.AccessToken == "" || creds.TokenSecret == "" {
+		return Credentials{}, fmt.Errorf("instapaper: empty access token")
+	}
+	return creds, nil
+}
+
+// Save は記事を Instapaper に保存する。
+func (c *InstapaperClient) Save(ctx context.Context, creds Credentials, link, title string) error {
+	form := url.Values{}
+	form.Set("url", link)
+	if title != "" {
+		form.Set("title", title)
+	}
+
+	resp, body, err := c.post(ctx, "/api/1/bookmarks/add", form, creds)
+	if err != nil {
+		return &SaveError{Outcome: SaveRetry, Reason: "Instapaper への送信に失敗しました（通信エラー）"}
+	}
+	// Instapaper はレート制限の超過を HTTP 400 とエラーコード 1040 で返す
+	if resp.StatusCode == http.StatusBadRequest && bytes.Contains(body, []byte(instapaperRateLimitErrorCode)) {
+		return &SaveError{Outcome: SaveRateLimited, Reason: "Instapaper の保存レート制限に達しました"}
+	}
+	if saveErr := classifySaveStatus(model.ReadLaterProviderInstapaper, resp.StatusCode, resp.Header.Get("Retry-After")); saveErr != nil {
+		return saveErr
+	}
+	return nil
+}
+
+// post は OAuth 1.0a の署名を付けてフォームを POST し、応答と本文（上限 maxResponseBodySize）を返す。
+func (c *InstapaperClient) post(ctx context.Context, path string, form url.Values, creds Credentials) (*http.Response, []byte, error) {
+	endpoint := c.baseURL + path
+	req, err := newRequest(ctx, endpoint, "application/x-www-form-urlencoded", []byte(form.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Authorization", c.signer.authorization(http.MethodPost, endpoint, form, creds.AccessToken, creds.TokenSecret))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
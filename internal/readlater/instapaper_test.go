@@ -0,0 +1,98 @@
+package readlater
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInstapaperClient_AccessToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.URL.Path != "/api/1/oauth/access_token" || r.PostForm.Get("x_auth_mode") != "client_auth" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "OAuth ") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("x_auth_username") != "alice" || r.PostForm.Get("x_auth_password") != "pw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("oauth_token_secret=tsecret&oauth_token=tok"))
+	}))
+	defer srv.Close()
+	client := NewInstapaperClient("key", "secret", srv.URL, srv.Client())
+
+	t.Run("ユーザー名とパスワードをトークンに交換する", func(t *testing.T) {
+		creds, err := client.AccessToken(context.Background(), "alice", "pw")
+
+		if err != nil {
+			t.Fatalf("AccessToken() error = %v", err)
+		}
+		if creds.AccessToken != "tok" || creds.TokenSecret != "tsecret" {
+			t.Errorf("AccessToken() = %+v, want tok/tsecret", creds)
+		}
+	})
+
+	t.Run("パスワードが誤っているときErrAuthRejectedを返す", func(t *testing.T) {
+		_, err := client.AccessToken(context.Background(), "alice", "wrong")
+
+		if !errors.Is(err, ErrAuthRejected) {
+			t.Errorf("AccessToken() error = %v, want ErrAuthRejected", err)
+		}
+	})
+}
+
+func TestInstapaperClient_Save(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   *SaveError
+	}{
+		{name: "200", status: http.StatusOK, body: `[{"type":"bookmark"}]`},
+		{name: "エラーコード1040の400", status: http.StatusBadRequest, body: `[{"type":"error","error_code":1040}]`, want: &SaveError{Outcome: SaveRateLimited}},
+		{name: "その他の400", status: http.StatusBadRequest, body: `[{"type":"error","error_code":1240}]`, want: &SaveError{Outcome: SaveRejected}},
+		{name: "403", status: http.StatusForbidden, want: &SaveError{Outcome: SaveAuthRevoked}},
+		{name: "500", status: http.StatusInternalServerError, want: &SaveError{Outcome: SaveRetry}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"のとき応答に応じた結果を返す", func(t *testing.T) {
+			// Arrange
+			var auth, link string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				auth = r.Header.Get("Authorization")
+				link = r.PostForm.Get("url")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			client := NewInstapaperClient("key", "secret", srv.URL, srv.Client())
+
+			// Act
+			err := client.Save(context.Background(), Credentials{AccessToken: "tok", TokenSecret: "tsecret"}, "https://example.com/1", "Title")
+
+			// Assert
+			if link != "https://example.com/1" || !strings.Contains(auth, `oauth_token="tok"`) {
+				t.Errorf("request url = %q, authorization = %q", link, auth)
+			}
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Save() error = %v, want nil", err)
+				}
+				return
+			}
+			var se *SaveError
+			if !errors.As(err, &se) || se.Outcome != tt.want.Outcome {
+				t.Errorf("Save() error = %v, want outcome %v", err, tt.want.Outcome)
+			}
+		})
+	}
+}
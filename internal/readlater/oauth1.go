@@ -0,0 +1,125 @@
+package readlater
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// oauth1Signer は OAuth 1.0a（RFC 5849）の HMAC-SHA1 署名付き Authorization ヘッダーを生成する。
+// Instapaper の Full API（xAuth によるトークン取得と記事の保存）で使う。
+type oauth1Signer struct {
+	consumerKey    string
+	consumerSecret string
+	nonce          func() string
+	now            func() time.Time
+}
+
+// newOAuth1Signer は oauth1Signer を生成する。
+func newOAuth1Signer(consumerKey, consumerSecret string) *oauth1Signer {
+	return &oauth1Signer{
+		consumerKey:    consumerKey,
+		consumerSecret: consumerSecret,
+		nonce:          randomNonce,
+		now:            time.Now,
+	}
+}
+
+// authorization は method・rawURL・フォームパラメータに対する Authorization ヘッダーの値を返す。
+// token / tokenSecret はトークン取得前（xAuth）は空文字とする。
+func (s *oauth1Signer) authorization(method, rawURL string, form url.Values, token, tokenSecret string) string {
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     s.consumerKey,
+		"oauth_nonce":            s.nonce(),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(s.now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if token != "" {
+		oauthParams["oauth_token"] = token
+	}
+
+	params := url.Values{}
+	for k, vs := range form {
+		params[k] = append([]string(nil), vs...)
+	}
+	for k, v := range oauthParams {
+		params.Set(k, v)
+	}
+	oauthParams["oauth_signature"] = oauth1Signature(signatureBaseString(method, rawURL, params), s.consumerSecret, tokenSecret)
+
+	keys := make([]string, 0, len(oauthParams))
+	for k := range oauthParams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = percentEncode(k) + `="` + percentEncode(oauthParams[k]) + `"`
+	}
+	return "OAuth " + strings.Join(parts, ", ")
+}
+
+// signatureBaseString は RFC 5849 3.4.1 の署名対象文字列を返す。
+// rawURL のクエリは params と合わせて正規化し、ベース URI には含めない。
+func signatureBaseString(method, rawURL string, params url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	all := url.Values{}
+	for k, vs := range u.Query() {
+		all[k] = append(all[k], vs...)
+	}
+	for k, vs := range params {
+		all[k] = append(all[k], vs...)
+	}
+
+	pairs := make([]string, 0, len(all))
+	for k, vs := range all {
+		for _, v := range vs {
+			pairs = append(pairs, percentEncode(k)+"="+percentEncode(v))
+		}
+	}
+	sort.Strings(pairs)
+
+	base := strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + u.EscapedPath()
+	return strings.ToUpper(method) + "&" + percentEncode(base) + "&" + percentEncode(strings.Join(pairs, "&"))
+}
+
+// oauth1Signature は署名対象文字列の HMAC-SHA1 署名を base64 で返す。
+func oauth1Signature(baseString, consumerSecret, tokenSecret string) string {
+	mac := hmac.New(sha1.New, []byte(percentEncode(consumerSecret)+"&"+percentEncode(tokenSecret)))
+	mac.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// percentEncode は RFC 3986 の非予約文字（英数字と -._~）以外を %XX にエンコードする。
+// url.QueryEscape は空白を + にするため OAuth の署名には使えない。
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+	}
+	return b.String()
+}
+
+// randomNonce はリクエストごとに一意な oauth_nonce を返す。
+func randomNonce() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
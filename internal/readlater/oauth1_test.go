@@ -0,0 +1,74 @@
+package readlater
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignatureBaseString(t *testing.T) {
+	t.Run("RFC 5849 の例と同じ署名対象文字列を返す", func(t *testing.T) {
+		// Arrange: RFC 5849 3.4.1.1 の例（クエリ・フォーム・oauth_* パラメータ）
+		params := url.Values{
+			"c2":                     {""},
+			"a3":                     {"2 q"},
+			"oauth_consumer_key":     {"9djdj82h48djs9d2"},
+			"oauth_token":            {"kkk9d7dh3k39sjv7"},
+			"oauth_signature_method": {"HMAC-SHA1"},
+			"oauth_timestamp":        {"137131201"},
+			"oauth_nonce":            {"7d8f3e4a"},
+		}
+
+		// Act
+		got := signatureBaseString(http.MethodPost, "http://Example.com/request?b5=%3D%253D&a3=a&c%40=&a2=r%20b", params)
+
+		// Assert
+		want := "POST&http%3A%2F%2Fexample.com%2Frequest&a2%3Dr%2520b%26a3%3D2%2520q%26a3%3Da%26b5%3D%253D%25253D%26c%2540%3D%26c2%3D%26oauth_consumer_key%3D9djdj82h48djs9d2%26oauth_nonce%3D7d8f3e4a%26oauth_signature_method%3DHMAC-SHA1%26oauth_timestamp%3D137131201%26oauth_token%3Dkkk9d7dh3k39sjv7"
+		if got != want {
+			t.Errorf("signatureBaseString() =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
+
+func TestOAuth1Signer_Authorization(t *testing.T) {
+	signer := newOAuth1Signer("key", "secret")
+	signer.nonce = func() string { return "nonce" }
+	signer.now = func() time.Time { return time.Unix(1700000000, 0) }
+	form := url.Values{"url": {"https://example.com/a b"}}
+
+	t.Run("トークンと署名を含むヘッダーを返し同じ入力なら同じ署名になる", func(t *testing.T) {
+		got := signer.authorization(http.MethodPost, "https://www.instapaper.com/api/1/bookmarks/add", form, "tok", "tsecret")
+
+		for _, want := range []string{`OAuth `, `oauth_consumer_key="key"`, `oauth_token="tok"`, `oauth_nonce="nonce"`, `oauth_timestamp="1700000000"`, `oauth_signature="`} {
+			if !strings.Contains(got, want) {
+				t.Errorf("authorization() = %q, want to contain %q", got, want)
+			}
+		}
+		if again := signer.authorization(http.MethodPost, "https://www.instapaper.com/api/1/bookmarks/add", form, "tok", "tsecret"); again != got {
+			t.Errorf("authorization() is not deterministic: %q vs %q", got, again)
+		}
+	})
+
+	t.Run("トークンシークレットが異なるとき署名が変わる", func(t *testing.T) {
+		a := signer.authorization(http.MethodPost, "https://www.instapaper.com/api/1/bookmarks/add", form, "tok", "s1")
+		b := signer.authorization(http.MethodPost, "https://www.instapaper.com/api/1/bookmarks/add", form, "tok", "s2")
+		if a == b {
+			t.Error("signature does not depend on token secret")
+		}
+	})
+
+	t.Run("トークンが無いときoauth_tokenを含めない", func(t *testing.T) {
+		got := signer.authorization(http.MethodPost, "https://www.instapaper.com/api/1/oauth/access_token", form, "", "")
+		if strings.Contains(got, "oauth_token=") {
+			t.Errorf("authorization() = %q, want without oauth_token", got)
+		}
+	})
+}
+
+func TestPercentEncode(t *testing.T) {
+	if got, want := percentEncode("a b+c~-._/あ"), "a%20b%2Bc~-._%2F%E3%81%82"; got != want {
+		t.Errorf("percentEncode() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+package readlater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// ErrAuthRejected は連携先サービスがアカウント情報・認可を拒否したことを表す。
+var ErrAuthRejected = errors.New("read later: authorization rejected")
+
+// PocketAPIBaseURL は Pocket API のベース URL。
+const PocketAPIBaseURL = "https://getpocket.com"
+
+// PocketClient は Pocket の OAuth（request token → 利用者の認可 → access token）と記事の保存を行うクライアント。
+// 保存時のレート制限は X-Limit-User-Remaining / X-Limit-User-Reset ヘッダーで判定する。
+type PocketClient struct {
+	consumerKey string
+	baseURL     string
+	client      *http.Client
+}
+
+// NewPocketClient は PocketClient を生成する。baseURL が空の場合は PocketAPIBaseURL を使う。
+func NewPocketClient(consumerKey, baseURL string, client *http.Client) *PocketClient {
+	if baseURL == "" {
+		baseURL = PocketAPIBaseURL
+	}
+	return &PocketClient{consumerKey: consumerKey, baseURL: baseURL, client: client}
+}
+
+// RequestToken は認可の開始に使う request token を取得する。
+func (c *PocketClient) RequestToken(ctx context.Context, redirectURI string) (string, error) {
+	var resp struct {
+		Code string `json:"code"`
+	}
+	if err := c.postJSON(ctx, "/v3/oauth/request", map[string]string{
+		"consumer_key": c.consumerKey,
+		"redirect_uri": redirectURI,
+	}, &resp); err != nil {
+		return "", err
+	}
+	if resp.Code == "" {
+		return "", errors.New("pocket: empty request token")
+	}
+	return resp.Code, nil
+}
+
+// AuthorizeURL は利用者を誘導する Pocket の認可画面の URL を返す。
+// 利用者が認可（または拒否）すると redirectURI に戻る。
+func (c *PocketClient) AuthorizeURL(requestToken, redirectURI string) string {
+	q := url.Values{}
+	q.Set("request_token", requestToken)
+	q.Set("redirect_uri", redirectURI)
+	return c.baseURL + "/auth/authorize?" + q.Encode()
+}
+
+// Authorize は利用者が認可した request token を access token と Pocket のユーザー名に交換する。
+// 利用者が認可を拒否した場合は ErrAuthRejected を返す。
+func (c *PocketClient) Authorize(ctx context.Context, requestToken string) (accessToken, username string, err error) {
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		Username    string `json:"username"`
+	}
+	if err := c.postJSON(ctx, "/v3/oauth/authorize", map[string]string{
+		"consumer_key": c.consumerKey,
+		"code":         requestToken,
+	}, &resp); err != nil {
+		return "", "", err
+	}
+	if resp.AccessToken == "" {
+		return "", "", errors.New("pocket: empty access token")
+	}
+	return resp.AccessToken, resp.Username, nil
+}
+
+// Save は記事を Pocket に保存する。
+func (c *PocketClient) Save(ctx context.Context, creds Credentials, link, title string) error {
+	body, err := json.Marshal(map[string]string{
+		"url":          link,
+		"title":        title,
+		"consumer_key": c.consumerKey,
+		"access_token": creds.AccessToken,
+	})
+	if err != nil {
+		return &SaveError{Outcome: SaveRejected, Reason: "送信内容の組み立てに失敗しました"}
+	}
+	req, err := newRequest(ctx, c.baseURL+"/v3/add", "application/json; charset=UTF-8", body)
+	if err != nil {
+		return &SaveError{Outcome: SaveRejected, Reason: "Pocket へのリクエストを組み立てられませんでした"}
+	}
+	req.Header.Set("X-Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return &SaveError{Outcome: SaveRetry, Reason: "Pocket への送信に失敗しました（通信エラー）"}
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	// Pocket はレート制限の超過も 403 で返すため、残り回数のヘッダーで認証エラーと区別する
+	if resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-Limit-User-Remaining") == "0" {
+		return &SaveError{
+			Outcome:    SaveRateLimited,
+			RetryAfter: parseRetryAfter(resp.Header.Get("X-Limit-User-Reset")),
+			Reason:     "Pocket の保存レート制限に達しました",
+		}
+	}
+	if saveErr := classifySaveStatus(model.ReadLaterProviderPocket, resp.StatusCode, resp.Header.Get("Retry-After")); saveErr != nil {
+		return saveErr
+	}
+	return nil
+}
+
+// postJSON は OAuth のエンドポイントに JSON を送り、応答を out にデコードする。
+// 401 / 403 は ErrAuthRejected とする（Pocket は認可の拒否・request token の失効を 403 で返す）。
+func (c *PocketClient) postJSON(ctx context.Context, path string, in map[string]string, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := newRequest(ctx, c.baseURL+path, "application/json; charset=UTF-8", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("pocket: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: pocket returned HTTP %d (%s)", ErrAuthRejected, resp.StatusCode, resp.Header.Get("X-Error"))
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("pocket: unexpected status HTTP %d (%s)", resp.StatusCode, resp.Header.Get("X-Error"))
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBodySize)).Decode(out); err != nil {
+		return fmt.Errorf("pocket: invalid response: %w", err)
+	}
+	return nil
+}
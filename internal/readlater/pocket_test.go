@@ -0,0 +1,128 @@
+package readlater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPocketClient_OAuth(t *testing.T) {
+	var received []map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/oauth/request", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		received = append(received, body)
+		_, _ = w.Write([]byte(`{"code":"req-token"}`))
+	})
+	mux.HandleFunc("/v3/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["code"] != "req-token" {
+			w.Header().Set("X-Error", "User rejected code.")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"acc-token","username":"alice"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := NewPocketClient("consumer", srv.URL, srv.Client())
+
+	t.Run("request tokenを取得し認可後にaccess tokenへ交換する", func(t *testing.T) {
+		// Act
+		code, err := client.RequestToken(context.Background(), "https://feedman.example.com/cb")
+		if err != nil {
+			t.Fatalf("RequestToken() error = %v", err)
+		}
+		accessToken, username, err := client.Authorize(context.Background(), code)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Authorize() error = %v", err)
+		}
+		if accessToken != "acc-token" || username != "alice" {
+			t.Errorf("Authorize() = (%q, %q), want (acc-token, alice)", accessToken, username)
+		}
+		if received[0]["consumer_key"] != "consumer" || received[0]["redirect_uri"] != "https://feedman.example.com/cb" {
+			t.Errorf("request body = %v", received[0])
+		}
+	})
+
+	t.Run("利用者が認可を拒否したときErrAuthRejectedを返す", func(t *testing.T) {
+		_, _, err := client.Authorize(context.Background(), "other")
+
+		if !errors.Is(err, ErrAuthRejected) {
+			t.Errorf("Authorize() error = %v, want ErrAuthRejected", err)
+		}
+	})
+
+	t.Run("認可画面のURLにrequest tokenとredirect_uriを含める", func(t *testing.T) {
+		got := client.AuthorizeURL("req-token", "https://feedman.example.com/cb?state=x")
+
+		want := srv.URL + "/auth/authorize?redirect_uri=https%3A%2F%2Ffeedman.example.com%2Fcb%3Fstate%3Dx&request_token=req-token"
+		if got != want {
+			t.Errorf("AuthorizeURL() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestPocketClient_Save(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		want    *SaveError
+	}{
+		{name: "200", status: http.StatusOK},
+		{name: "残り回数0の403", status: http.StatusForbidden, headers: map[string]string{"X-Limit-User-Remaining": "0", "X-Limit-User-Reset": "120"}, want: &SaveError{Outcome: SaveRateLimited, RetryAfter: 2 * time.Minute}},
+		{name: "401", status: http.StatusUnauthorized, want: &SaveError{Outcome: SaveAuthRevoked}},
+		{name: "403", status: http.StatusForbidden, want: &SaveError{Outcome: SaveAuthRevoked}},
+		{name: "503", status: http.StatusServiceUnavailable, want: &SaveError{Outcome: SaveRetry}},
+		{name: "400", status: http.StatusBadRequest, want: &SaveError{Outcome: SaveRejected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"のとき応答に応じた結果を返す", func(t *testing.T) {
+			// Arrange
+			var body map[string]string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+			client := NewPocketClient("consumer", srv.URL, srv.Client())
+
+			// Act
+			err := client.Save(context.Background(), Credentials{AccessToken: "acc"}, "https://example.com/1", "Title")
+
+			// Assert
+			if body["access_token"] != "acc" || body["url"] != "https://example.com/1" || body["title"] != "Title" {
+				t.Errorf("request body = %v", body)
+			}
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Save() error = %v, want nil", err)
+				}
+				return
+			}
+			var se *SaveError
+			if !errors.As(err, &se) {
+				t.Fatalf("Save() error = %v, want *SaveError", err)
+			}
+			if se.Outcome != tt.want.Outcome || se.RetryAfter != tt.want.RetryAfter {
+				t.Errorf("Save() = {%v %v}, want {%v %v}", se.Outcome, se.RetryAfter, tt.want.Outcome, tt.want.RetryAfter)
+			}
+			if strings.Contains(se.Reason, "acc") {
+				t.Errorf("reason %q contains access token", se.Reason)
+			}
+		})
+	}
+}
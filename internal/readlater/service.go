@@ -0,0 +1,293 @@
+// Package readlater はスターを付けた記事を Pocket / Instapaper などの「あとで読む」サービスへ自動保存する連携を提供する。
+//
+// 連携の認証情報（OAuth のアクセストークン等）は security.SecretBox で暗号化して read_later_connections に保存する。
+// スター操作の直後に保存キュー（read_later_deliveries）へ積み、worker の DeliveryJob が取り出して保存する。
+// 一時的な失敗は指数バックオフで再試行し、連携先で認証が無効になった場合は連携を無効化して
+// 理由を連携の last_error として利用者に返す。
+package readlater
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// pocketAuthorizationTTL は Pocket の認可を開始してから完了するまでの有効期限。
+const pocketAuthorizationTTL = 10 * time.Minute
+
+// PocketAuthorizer は Pocket の OAuth を行うクライアント。*PocketClient が実装する。
+type PocketAuthorizer interface {
+	RequestToken(ctx context.Context, redirectURI string) (string, error)
+	AuthorizeURL(requestToken, redirectURI string) string
+	Authorize(ctx context.Context, requestToken string) (accessToken, username string, err error)
+}
+
+// InstapaperAuthorizer は Instapaper の xAuth を行うクライアント。*InstapaperClient が実装する。
+type InstapaperAuthorizer interface {
+	AccessToken(ctx context.Context, username, password string) (Credentials, error)
+}
+
+// Service は「あとで読む」サービス連携のサービス層。
+type Service struct {
+	repo repository.ReadLaterConnectionRepository
+	box  *security.SecretBox
+
+	// pocket / instapaper は連携先のクライアント。未設定の保存先は利用できない。
+	pocket            PocketAuthorizer
+	pocketRedirectURL string
+	instapaper        InstapaperAuthorizer
+
+	now func() time.Time
+}
+
+// Option は NewService の任意設定を表す functional option。
+type Option func(*Service)
+
+// WithPocket は Pocket 連携を有効にする。redirectURL は認可後に戻るコールバック URL。
+func WithPocket(client PocketAuthorizer, redirectURL string) Option {
+	return func(s *Service) {
+		s.pocket = client
+		s.pocketRedirectURL = redirectURL
+	}
+}
+
+// WithInstapaper は Instapaper 連携を有効にする。
+func WithInstapaper(client InstapaperAuthorizer) Option {
+	return func(s *Service) {
+		s.instapaper = client
+	}
+}
+
+// NewService は Service の新しいインスタンスを生成する。box は認証情報の暗号化に使う。
+func NewService(repo repository.ReadLaterConnectionRepository, box *security.SecretBox, opts ...Option) *Service {
+	s := &Service{repo: repo, box: box, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// AvailableProviders はこのインスタンスで連携できる保存先を返す。
+func (s *Service) AvailableProviders() []model.ReadLaterProvider {
+	var providers []model.ReadLaterProvider
+	if s.pocket != nil {
+		providers = append(providers, model.ReadLaterProviderPocket)
+	}
+	if s.instapaper != nil {
+		providers = append(providers, model.ReadLaterProviderInstapaper)
+	}
+	return providers
+}
+
+// ListConnections は当該ユーザーの連携を作成日時の昇順で返す。
+func (s *Service) ListConnections(ctx context.Context, userID string) ([]model.ReadLaterConnection, error) {
+	conns, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("あとで読むサービス連携一覧の取得に失敗しました: %w", err)
+	}
+	return conns, nil
+}
+
+// pocketState は Pocket の認可のコールバックに引き継ぐ状態。
+// SecretBox で暗号化して state パラメータに載せるため、改ざん・他ユーザーによる再利用はできない。
+type pocketState struct {
+	UserID    string    `json:"user_id"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// StartPocketAuthorization は Pocket の request token を取得し、利用者を誘導する認可画面の URL を返す。
+// request token は暗号化した state としてコールバック URL に付け、CompletePocketAuthorization で取り出す。
+func (s *Service) StartPocketAuthorization(ctx context.Context, userID string) (string, error) {
+	if s.pocket == nil {
+		return "", model.NewInvalidReadLaterProviderError(string(model.ReadLaterProviderPocket))
+	}
+
+	code, err := s.pocket.RequestToken(ctx, s.pocketRedirectURL)
+	if err != nil {
+		return "", mapClientError(model.ReadLaterProviderPocket, err)
+	}
+	state, err := s.sealState(pocketState{UserID: userID, Code: code, ExpiresAt: s.now().Add(pocketAuthorizationTTL)})
+	if err != nil {
+		return "", err
+	}
+
+	redirect, err := url.Parse(s.pocketRedirectURL)
+	if err != nil {
+		return "", fmt.Errorf("Pocket のコールバック URL が不正です: %w", err)
+	}
+	q := redirect.Query()
+	q.Set("state", state)
+	redirect.RawQuery = q.Encode()
+	return s.pocket.AuthorizeURL(code, redirect.String()), nil
+}
+
+// CompletePocketAuthorization は認可画面から戻った利用者の request token を access token に交換して連携を保存する。
+// state が不正・期限切れ・他ユーザーのものの場合や、利用者が認可を拒否した場合は READ_LATER_AUTH_FAILED を返す。
+func (s *Service) CompletePocketAuthorization(ctx context.Context, userID, state string) (*model.ReadLaterConnection, error) {
+	if s.pocket == nil {
+		return nil, model.NewInvalidReadLaterProviderError(string(model.ReadLaterProviderPocket))
+	}
+
+	st, err := s.openState(state)
+	if err != nil || st.UserID != userID {
+		return nil, model.NewReadLaterAuthFailedError("認可の状態が不正です。もう一度連携してください")
+	}
+	if !s.now().Before(st.ExpiresAt) {
+		return nil, model.NewReadLaterAuthFailedError("認可の有効期限が切れました。もう一度連携してください")
+	}
+
+	accessToken, username, err := s.pocket.Authorize(ctx, st.Code)
+	if err != nil {
+		return nil, mapClientError(model.ReadLaterProviderPocket, err)
+	}
+	return s.saveConnection(ctx, userID, model.ReadLaterProviderPocket, username, Credentials{AccessToken: accessToken})
+}
+
+// ConnectInstapaper は Instapaper のユーザー名・パスワードをトークンに交換して連携を保存する。
+// パスワードは交換にのみ使い、保存しない。
+func (s *Service) ConnectInstapaper(ctx context.Context, userID, username, password string) (*model.ReadLaterConnection, error) {
+	if s.instapaper == nil {
+		return nil, model.NewInvalidReadLaterProviderError(string(model.ReadLaterProviderInstapaper))
+	}
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, model.NewReadLaterAuthFailedError("Instapaper のユーザー名を指定してください")
+	}
+
+	creds, err := s.instapaper.AccessToken(ctx, username, password)
+	if err != nil {
+		return nil, mapClientError(model.ReadLaterProviderInstapaper, err)
+	}
+	return s.saveConnection(ctx, userID, model.ReadLaterProviderInstapaper, username, creds)
+}
+
+// SetEnabled は連携の有効・無効を切り替えて更新後の連携を返す。
+// 有効化すると直近のエラーをクリアし、無効の間に積まれていた保存を再開する。
+func (s *Service) SetEnabled(ctx context.Context, userID string, provider model.ReadLaterProvider, enabled bool) (*model.ReadLaterConnection, error) {
+	if !provider.Valid() {
+		return nil, model.NewInvalidReadLaterProviderError(string(provider))
+	}
+	updated, err := s.repo.SetEnabled(ctx, userID, provider, enabled)
+	if err != nil {
+		return nil, fmt.Errorf("あとで読むサービス連携の更新に失敗しました: %w", err)
+	}
+	if !updated {
+		return nil, model.NewReadLaterNotConnectedError(provider)
+	}
+	conn, err := s.repo.FindByProvider(ctx, userID, provider)
+	if err != nil {
+		return nil, fmt.Errorf("あとで読むサービス連携の取得に失敗しました: %w", err)
+	}
+	if conn == nil {
+		return nil, model.NewReadLaterNotConnectedError(provider)
+	}
+	return conn, nil
+}
+
+// Disconnect は連携を解除し、認証情報と未保存のキューを削除する。
+func (s *Service) Disconnect(ctx context.Context, userID string, provider model.ReadLaterProvider) error {
+	if !provider.Valid() {
+		return model.NewInvalidReadLaterProviderError(string(provider))
+	}
+	deleted, err := s.repo.Delete(ctx, userID, provider)
+	if err != nil {
+		return fmt.Errorf("あとで読むサービス連携の削除に失敗しました: %w", err)
+	}
+	if !deleted {
+		return model.NewReadLaterNotConnectedError(provider)
+	}
+	return nil
+}
+
+// saveConnection は認証情報を暗号化して連携を作成（既にあれば置き換え）する。
+func (s *Service) saveConnection(ctx context.Context, userID string, provider model.ReadLaterProvider, accountName string, creds Credentials) (*model.ReadLaterConnection, error) {
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return nil, fmt.Errorf("認証情報のエンコードに失敗しました: %w", err)
+	}
+	sealed, err := s.box.Seal(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("認証情報の暗号化に失敗しました: %w", err)
+	}
+
+	conn := &model.ReadLaterConnection{
+		UserID:      userID,
+		Provider:    provider,
+		AccountName: accountName,
+		Credentials: sealed,
+	}
+	if err := s.repo.Upsert(ctx, conn); err != nil {
+		return nil, fmt.Errorf("あとで読むサービス連携の保存に失敗しました: %w", err)
+	}
+	return conn, nil
+}
+
+// sealState は state を暗号化して URL に載せられる文字列にする。
+func (s *Service) sealState(st pocketState) (string, error) {
+	plaintext, err := json.Marshal(st)
+	if err != nil {
+		return "", fmt.Errorf("認可の状態のエンコードに失敗しました: %w", err)
+	}
+	sealed, err := s.box.Seal(plaintext)
+	if err != nil {
+		return "", fmt.Errorf("認可の状態の暗号化に失敗しました: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// openState は sealState の逆変換を行う。
+func (s *Service) openState(state string) (*pocketState, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(state)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := s.box.Open(sealed)
+	if err != nil {
+		return nil, err
+	}
+	var st pocketState
+	if err := json.Unmarshal(plaintext, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// mapClientError は連携先クライアントのエラーを API エラーに変換する。
+// 認証・認可の拒否は READ_LATER_AUTH_FAILED、それ以外（通信エラー・想定外の応答）は READ_LATER_UNAVAILABLE とする。
+func mapClientError(provider model.ReadLaterProvider, err error) error {
+	if errors.Is(err, ErrAuthRejected) {
+		return model.NewReadLaterAuthFailedError(fmt.Sprintf("%s で認証・認可が拒否されました", provider))
+	}
+	return model.NewReadLaterUnavailableError(provider)
+}
+
+// openCredentials は暗号化された認証情報を復号する。
+func openCredentials(box *security.SecretBox, sealed []byte) (Credentials, error) {
+	plaintext, err := box.Open(sealed)
+	if err != nil {
+		return Credentials{}, err
+	}
+	var creds Credentials
+	if err := json.Unmarshal(plaintext, &creds); err != nil {
+		return Credentials{}, err
+	}
+	return creds, nil
+}
+
+// compile-time interface checks
+var (
+	_ PocketAuthorizer     = (*PocketClient)(nil)
+	_ InstapaperAuthorizer = (*InstapaperClient)(nil)
+	_ Saver                = (*PocketClient)(nil)
+	_ Saver                = (*InstapaperClient)(nil)
+)
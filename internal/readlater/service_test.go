@@ -0,0 +1,275 @@
+package readlater
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// mockConnectionRepo は repository.ReadLaterConnectionRepository のモック実装。
+type mockConnectionRepo struct {
+	conns map[model.ReadLaterProvider]*model.ReadLaterConnection
+}
+
+func (m *mockConnectionRepo) Upsert(_ context.Context, conn *model.ReadLaterConnection) error {
+	if m.conns == nil {
+		m.conns = make(map[model.ReadLaterProvider]*model.ReadLaterConnection)
+	}
+	conn.ID = "conn-" + string(conn.Provider)
+	conn.Enabled = true
+	c := *conn
+	m.conns[conn.Provider] = &c
+	return nil
+}
+
+func (m *mockConnectionRepo) ListByUser(_ context.Context, _ string) ([]model.ReadLaterConnection, error) {
+	var conns []model.ReadLaterConnection
+	for _, c := range m.conns {
+		conns = append(conns, *c)
+	}
+	return conns, nil
+}
+
+func (m *mockConnectionRepo) FindByProvider(_ context.Context, _ string, provider model.ReadLaterProvider) (*model.ReadLaterConnection, error) {
+	return m.conns[provider], nil
+}
+
+func (m *mockConnectionRepo) SetEnabled(_ context.Context, _ string, provider model.ReadLaterProvider, enabled bool) (bool, error) {
+	c, ok := m.conns[provider]
+	if !ok {
+		return false, nil
+	}
+	c.Enabled = enabled
+	return true, nil
+}
+
+func (m *mockConnectionRepo) Delete(_ context.Context, _ string, provider model.ReadLaterProvider) (bool, error) {
+	_, ok := m.conns[provider]
+	delete(m.conns, provider)
+	return ok, nil
+}
+
+// mockPocket は PocketAuthorizer のモック実装。
+type mockPocket struct {
+	requestErr   error
+	authorizeErr error
+	redirectURI  string
+}
+
+func (m *mockPocket) RequestToken(_ context.Context, redirectURI string) (string, error) {
+	return "req-token", m.requestErr
+}
+
+func (m *mockPocket) AuthorizeURL(requestToken, redirectURI string) string {
+	m.redirectURI = redirectURI
+	return "https://getpocket.com/auth/authorize?request_token=" + requestToken + "&redirect_uri=" + url.QueryEscape(redirectURI)
+}
+
+func (m *mockPocket) Authorize(_ context.Context, requestToken string) (string, string, error) {
+	if m.authorizeErr != nil {
+		return "", "", m.authorizeErr
+	}
+	return "acc-" + requestToken, "alice", nil
+}
+
+// mockInstapaper は InstapaperAuthorizer のモック実装。
+type mockInstapaper struct {
+	err error
+}
+
+func (m *mockInstapaper) AccessToken(_ context.Context, _, _ string) (Credentials, error) {
+	return Credentials{AccessToken: "tok", TokenSecret: "tsecret"}, m.err
+}
+
+func newTestSecretBox(t *testing.T) *security.SecretBox {
+	t.Helper()
+	box, err := security.NewSecretBox(bytes.Repeat([]byte{0x42}, security.SecretKeySize))
+	if err != nil {
+		t.Fatalf("NewSecretBox() error = %v", err)
+	}
+	return box
+}
+
+func assertAPIErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Errorf("error = %v, want %s", err, code)
+	}
+}
+
+func TestService_PocketAuthorization(t *testing.T) {
+	now := time.Date(2026, 7, 17, 12, 0, 0, 0, time.UTC)
+	box := newTestSecretBox(t)
+
+	start := func(t *testing.T, svc *Service, pocket *mockPocket) string {
+		t.Helper()
+		if _, err := svc.StartPocketAuthorization(context.Background(), "user-1"); err != nil {
+			t.Fatalf("StartPocketAuthorization() error = %v", err)
+		}
+		u, err := url.Parse(pocket.redirectURI)
+		if err != nil {
+			t.Fatalf("invalid redirect uri %q", pocket.redirectURI)
+		}
+		return u.Query().Get("state")
+	}
+
+	t.Run("認可を完了したとき認証情報を暗号化して連携を保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockConnectionRepo{}
+		pocket := &mockPocket{}
+		svc := NewService(repo, box, WithPocket(pocket, "https://feedman.example.com/api/read-later/pocket/callback"))
+		svc.now = func() time.Time { return now }
+		state := start(t, svc, pocket)
+
+		// Act
+		conn, err := svc.CompletePocketAuthorization(context.Background(), "user-1", state)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("CompletePocketAuthorization() error = %v", err)
+		}
+		if conn.AccountName != "alice" || !conn.Enabled {
+			t.Errorf("connection = %+v, want alice and enabled", conn)
+		}
+		if bytes.Contains(conn.Credentials, []byte("acc-req-token")) {
+			t.Error("credentials are stored in plaintext")
+		}
+		creds, err := openCredentials(box, repo.conns[model.ReadLaterProviderPocket].Credentials)
+		if err != nil || creds.AccessToken != "acc-req-token" {
+			t.Errorf("openCredentials() = (%+v, %v), want acc-req-token", creds, err)
+		}
+	})
+
+	t.Run("他ユーザーのstateのときREAD_LATER_AUTH_FAILEDを返す", func(t *testing.T) {
+		pocket := &mockPocket{}
+		svc := NewService(&mockConnectionRepo{}, box, WithPocket(pocket, "https://feedman.example.com/cb"))
+		state := start(t, svc, pocket)
+
+		_, err := svc.CompletePocketAuthorization(context.Background(), "user-2", state)
+
+		assertAPIErrorCode(t, err, model.ErrCodeReadLaterAuthFailed)
+	})
+
+	t.Run("有効期限を過ぎたstateのときREAD_LATER_AUTH_FAILEDを返す", func(t *testing.T) {
+		pocket := &mockPocket{}
+		svc := NewService(&mockConnectionRepo{}, box, WithPocket(pocket, "https://feedman.example.com/cb"))
+		svc.now = func() time.Time { return now }
+		state := start(t, svc, pocket)
+		svc.now = func() time.Time { return now.Add(pocketAuthorizationTTL) }
+
+		_, err := svc.CompletePocketAuthorization(context.Background(), "user-1", state)
+
+		assertAPIErrorCode(t, err, model.ErrCodeReadLaterAuthFailed)
+	})
+
+	t.Run("改ざんされたstateのときREAD_LATER_AUTH_FAILEDを返す", func(t *testing.T) {
+		svc := NewService(&mockConnectionRepo{}, box, WithPocket(&mockPocket{}, "https://feedman.example.com/cb"))
+
+		_, err := svc.CompletePocketAuthorization(context.Background(), "user-1", "tampered")
+
+		assertAPIErrorCode(t, err, model.ErrCodeReadLaterAuthFailed)
+	})
+
+	t.Run("利用者が認可を拒否したときREAD_LATER_AUTH_FAILEDを返す", func(t *testing.T) {
+		pocket := &mockPocket{authorizeErr: ErrAuthRejected}
+		svc := NewService(&mockConnectionRepo{}, box, WithPocket(pocket, "https://feedman.example.com/cb"))
+		state := start(t, svc, pocket)
+
+		_, err := svc.CompletePocketAuthorization(context.Background(), "user-1", state)
+
+		assertAPIErrorCode(t, err, model.ErrCodeReadLaterAuthFailed)
+	})
+
+	t.Run("Pocketに接続できないときREAD_LATER_UNAVAILABLEを返す", func(t *testing.T) {
+		svc := NewService(&mockConnectionRepo{}, box, WithPocket(&mockPocket{requestErr: errors.New("timeout")}, "https://feedman.example.com/cb"))
+
+		_, err := svc.StartPocketAuthorization(context.Background(), "user-1")
+
+		assertAPIErrorCode(t, err, model.ErrCodeReadLaterUnavailable)
+	})
+
+	t.Run("Pocketが設定されていないときINVALID_READ_LATER_PROVIDERを返す", func(t *testing.T) {
+		svc := NewService(&mockConnectionRepo{}, box)
+
+		_, err := svc.StartPocketAuthorization(context.Background(), "user-1")
+
+		assertAPIErrorCode(t, err, model.ErrCodeInvalidReadLaterProvider)
+	})
+}
+
+func TestService_ConnectInstapaper(t *testing.T) {
+	box := newTestSecretBox(t)
+
+	t.Run("トークンとトークンシークレットを暗号化して保存しパスワードは保存しない", func(t *testing.T) {
+		repo := &mockConnectionRepo{}
+		svc := NewService(repo, box, WithInstapaper(&mockInstapaper{}))
+
+		conn, err := svc.ConnectInstapaper(context.Background(), "user-1", " alice ", "pw")
+
+		if err != nil {
+			t.Fatalf("ConnectInstapaper() error = %v", err)
+		}
+		if conn.AccountName != "alice" {
+			t.Errorf("AccountName = %q, want alice", conn.AccountName)
+		}
+		creds, err := openCredentials(box, conn.Credentials)
+		if err != nil || creds != (Credentials{AccessToken: "tok", TokenSecret: "tsecret"}) {
+			t.Errorf("openCredentials() = (%+v, %v)", creds, err)
+		}
+	})
+
+	t.Run("パスワードが誤っているときREAD_LATER_AUTH_FAILEDを返す", func(t *testing.T) {
+		svc := NewService(&mockConnectionRepo{}, box, WithInstapaper(&mockInstapaper{err: ErrAuthRejected}))
+
+		_, err := svc.ConnectInstapaper(context.Background(), "user-1", "alice", "wrong")
+
+		assertAPIErrorCode(t, err, model.ErrCodeReadLaterAuthFailed)
+	})
+}
+
+func TestService_SetEnabledAndDisconnect(t *testing.T) {
+	box := newTestSecretBox(t)
+	repo := &mockConnectionRepo{conns: map[model.ReadLaterProvider]*model.ReadLaterConnection{
+		model.ReadLaterProviderPocket: {ID: "conn-1", Provider: model.ReadLaterProviderPocket, Enabled: true},
+	}}
+	svc := NewService(repo, box)
+
+	t.Run("連携を無効化して更新後の連携を返す", func(t *testing.T) {
+		conn, err := svc.SetEnabled(context.Background(), "user-1", model.ReadLaterProviderPocket, false)
+
+		if err != nil {
+			t.Fatalf("SetEnabled() error = %v", err)
+		}
+		if conn.Enabled {
+			t.Error("Enabled = true, want false")
+		}
+	})
+
+	t.Run("連携していないときREAD_LATER_NOT_CONNECTEDを返す", func(t *testing.T) {
+		_, err := svc.SetEnabled(context.Background(), "user-1", model.ReadLaterProviderInstapaper, true)
+
+		assertAPIErrorCode(t, err, model.ErrCodeReadLaterNotConnected)
+	})
+
+	t.Run("未知の保存先のときINVALID_READ_LATER_PROVIDERを返す", func(t *testing.T) {
+		err := svc.Disconnect(context.Background(), "user-1", "evernote")
+
+		assertAPIErrorCode(t, err, model.ErrCodeInvalidReadLaterProvider)
+	})
+
+	t.Run("連携を解除する", func(t *testing.T) {
+		if err := svc.Disconnect(context.Background(), "user-1", model.ReadLaterProviderPocket); err != nil {
+			t.Fatalf("Disconnect() error = %v", err)
+		}
+		if _, ok := repo.conns[model.ReadLaterProviderPocket]; ok {
+			t.Error("connection was not deleted")
+		}
+	})
+}
@@ -22,6 +22,14 @@ type UserRepository interface {
 	DeleteByID(ctx context.Context, id string) error
 }
 
+// UserActivityRepository はユーザーの最終アクティブ日時を記録するインターフェース。
+// 休眠ユーザーのみが購読するフィードのフェッチ抑制と、復帰時の即時フェッチに用いる。
+type UserActivityRepository interface {
+	// TouchLastActive は最終アクティブ日時を now に更新する。更新前の日時が dormantBefore より前だった
+	// （休眠から復帰した）場合は購読フィードの次回フェッチ日時を now に前倒しし、true を返す。
+	TouchLastActive(ctx context.Context, userID string, now, dormantBefore time.Time) (bool, error)
+}
+
 // IdentityRepository は外部IdP紐付け情報の永続化インターフェース。
 type IdentityRepository interface {
 	// FindByProviderAndProviderUserID はproviderとprovider_user_idでidentityを検索する。
@@ -29,6 +37,16 @@ type IdentityRepository interface {
 	FindByProviderAndProviderUserID(ctx context.Context, provider, providerUserID string) (*model.Identity, error)
 }
 
+// IdentityManagementRepository はユーザー自身による identity（外部 IdP 連携）の一覧・解除のインターフェース。
+type IdentityManagementRepository interface {
+	// ListByUserID はユーザーの identity を連携日時の昇順で返す。
+	ListByUserID(ctx context.Context, userID string) ([]*model.Identity, error)
+	// DeleteForUser はユーザーの identity を 1 件削除する。
+	// identity が存在しないか他ユーザーのものの場合は ErrIdentityNotFound、
+	// ユーザーの最後の identity の場合は ErrLastIdentity を返す（同時に解除しても最後の 1 つは残る）。
+	DeleteForUser(ctx context.Context, userID, identityID string) error
+}
+
 // SessionRepository はセッションデータの永続化インターフェース。
 type SessionRepository interface {
 	// Create はセッションを作成する。
@@ -39,6 +57,9 @@ type SessionRepository interface {
 	DeleteByID(ctx context.Context, id string) error
 	// DeleteByUserID は指定ユーザーの全セッションを削除する。
 	DeleteByUserID(ctx context.Context, userID string) error
+	// DeleteByIdentityID は指定ユーザーのセッションのうち identityID の identity でのログインで発行したものを削除する。
+	// 発行元の identity が記録されていないセッションも、該当する可能性があるため併せて削除する。
+	DeleteByIdentityID(ctx context.Context, userID, identityID string) error
 }
 
 // FeedRepository はフィードデータの永続化インターフェース。
@@ -76,6 +97,12 @@ type FeedRepository interface {
 	// UpdateLastSuccessfulFetchAt は指定フィードの last_successful_fetch_at を更新する。
 	// 自動ワーカーの成功経路と手動フェッチの成功経路の双方から呼ばれる共有更新メソッド。
 	UpdateLastSuccessfulFetchAt(ctx context.Context, feedID string, at time.Time) error
+
+	// ListBySiteHost はサイトのホスト（site_url 未設定時は feed_url のホスト）が host に一致するフィードのうち、
+	// userID が購読しているもの、または公開プロフィールで公開されている購読のフィードをタイトル順に最大 limit 件返す。
+	// 他ユーザーが非公開で購読しているだけのフィード（トークン付きの feed_url など）は返さない。
+	// host は小文字・先頭の "www." を除いた形で渡す。excludeFeedID に一致するフィードは結果から除く。
+	ListBySiteHost(ctx context.Context, userID, host, excludeFeedID string, limit int) ([]*model.Feed, error)
 }
 
 // SubscriptionRepository は購読データの永続化インターフェース。
@@ -131,17 +158,26 @@ type ItemRepository interface {
 	FindByContentHash(ctx context.Context, feedID, contentHash string) (*model.Item, error)
 
 	// ListByFeed はフィードの記事一覧をユーザーの状態とJOINして取得する。
-	// published_at降順でカーソルベースページネーションを使用する。
-	// cursorがゼロ値の場合は先頭から取得する。
-	// filter: "all"=全件, "unread"=未読のみ, "starred"=スターのみ
-	ListByFeed(ctx context.Context, feedID, userID string, filter model.ItemFilter, cursor time.Time, limit int) ([]model.ItemWithState, error)
-
-	// ListStarredByUser は指定ユーザーがスター付与した記事を全フィード横断・published_at降順で取得する。
+	// (published_at, id) 降順で、直前ページ末尾の (cursorPublishedAt, cursorID) より後ろの記事を返す。
+	// cursorPublishedAt がゼロ値の場合は先頭から取得する。cursorID が空文字の場合（旧形式のカーソル）は
+	// published_at のみで境界を判定する。
+	// conds の各条件（未読・スター・最低評価）は指定されたものだけを AND で結合して絞り込む。
+	// author が空でない場合は正規化済みの著者名（items.author）が完全一致する記事のみに絞り込む。
+	ListByFeed(ctx context.Context, feedID, userID string, conds model.ItemConditions, author string, cursorPublishedAt time.Time, cursorID string, limit int) ([]model.ItemWithState, error)
+
+	// ListByFeeds は ListByFeed を複数フィード（feed_id IN (...)）に広げたもの。
+	// 指定したフィードの記事を 1 つの (published_at, id) 降順の一覧として返す。購読の確認は呼び出し側で行うこと。
+	ListByFeeds(ctx context.Context, feedIDs []string, userID string, conds model.ItemConditions, author string, cursorPublishedAt time.Time, cursorID string, limit int) ([]model.ItemWithState, error)
+
+	// ListStarredByUser は指定ユーザーがスター付与した記事を全フィード横断・(published_at, id) 降順で取得する。
 	// items と item_states と feeds を INNER JOIN し、feed_title を付与する。
-	// cursor がゼロ値の場合は先頭から取得する。
+	// カーソルの扱いは ListByFeed と同じ（cursorPublishedAt がゼロ値なら先頭から、cursorID が空文字なら published_at のみで判定）。
 	// 返却スライス内の全行は s.user_id = userID AND s.is_starred = true を満たし、
 	// 他ユーザーのスター記事は一切含まれない（NFR 2.1）。
-	ListStarredByUser(ctx context.Context, userID string, cursor time.Time, limit int) ([]StarredItemRow, error)
+	// filter.BrokenLinkOnly が true の場合は link_status がリンク切れ（not_found / domain_unresolvable）の記事のみに、
+	// filter.MinRating が 1 以上の場合はその評価以上の記事のみに絞り込む。
+	// filter.SortByRating が true の場合は (rating, published_at, id) 降順で並べ、cursorRating を境界の評価として用いる。
+	ListStarredByUser(ctx context.Context, userID string, cursorRating int, cursorPublishedAt time.Time, cursorID string, limit int, filter model.StarredItemFilter) ([]StarredItemRow, error)
 
 	// ListNewAcrossFeeds はユーザーの全購読フィードから sinceTime より後の記事を横断取得する。
 	// items × subscriptions × feeds × item_states を 1 クエリで JOIN し、N+1 を回避する。
@@ -186,6 +222,8 @@ type StarredItemRow struct {
 	model.ItemWithState
 	// FeedTitle は当該記事が所属するフィードのタイトル（feeds.title）。
 	FeedTitle string
+	// LinkStatus はリンク切れチェックの判定結果（items.link_status）。未判定の場合は空文字列。
+	LinkStatus model.LinkStatus
 }
 
 // CrossFeedItem はフィード横断新着一覧の 1 行分のデータを表す。
@@ -242,21 +280,23 @@ type ItemSearchRepository interface {
 // HatebuItemRepository ははてなブックマーク取得に必要な記事データ操作のインターフェース。
 type HatebuItemRepository interface {
 	// ListNeedingHatebuFetch ははてなブックマーク数の取得が必要な記事を取得する。
-	// hatebu_fetched_at IS NULL（未取得）を優先し、次にhatebu_fetched_atが古い順に処理する。
-	ListNeedingHatebuFetch(ctx context.Context, limit int) ([]*model.Item, error)
+	// priority のスコアが高い順（新しい記事・はてブ数の多い記事を優先）に返し、
+	// 同スコアでは hatebu_fetched_at IS NULL（未取得）、hatebu_fetched_at が古い順に処理する。
+	ListNeedingHatebuFetch(ctx context.Context, limit int, priority model.HatebuFetchPriority) ([]*model.Item, error)
 
 	// UpdateHatebuCount は記事のはてなブックマーク数と取得日時を更新する。
 	UpdateHatebuCount(ctx context.Context, itemID string, count int, fetchedAt time.Time) error
 }
 
-// ItemStateRepository はユーザーごとの記事状態（既読/スター）の永続化インターフェース。
+// ItemStateRepository はユーザーごとの記事状態（既読/スター/非表示）の永続化インターフェース。
 type ItemStateRepository interface {
 	// FindByUserAndItem はユーザーIDと記事IDで記事状態を取得する。見つからない場合はnilを返す。
 	FindByUserAndItem(ctx context.Context, userID, itemID string) (*model.ItemState, error)
 
 	// Upsert は記事状態を冪等にUPSERTする。
 	// nilフィールドは変更せず、既存の値を維持する部分更新を行う。
-	Upsert(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error)
+	// スター（isStarred）と評価（rating）は model.ApplyStarRating の規則で両方を更新する。
+	Upsert(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error)
 
 	// DeleteByUserAndFeed はユーザーIDとフィードIDに関連する記事状態を全て削除する。
 	DeleteByUserAndFeed(ctx context.Context, userID, feedID string) error
@@ -265,6 +305,28 @@ type ItemStateRepository interface {
 	DeleteByUserID(ctx context.Context, userID string) error
 }
 
+// ItemVisitRepository は元記事ページへの訪問記録の永続化インターフェース。
+// 訪問は既読化を伴うため item_states に記録する。
+type ItemVisitRepository interface {
+	// MarkVisited は記事を既読にし、最終訪問日時を visitedAt で記録する（冪等）。
+	MarkVisited(ctx context.Context, userID, itemID string, visitedAt time.Time) error
+}
+
+// ItemStateSyncRepository はオフライン同期の記事状態の操作を適用するインターフェース。
+// PostgresItemStateRepo が実装する。
+type ItemStateSyncRepository interface {
+	// ApplySyncOperation は既読またはスター状態を、changedAt が現在の状態の最終変更日時より新しい場合に限り
+	// value に更新する（last-write-wins）。適用した場合は applied=true を返し、いずれの場合も操作後の記事状態を返す。
+	ApplySyncOperation(ctx context.Context, userID, itemID string, opType model.SyncOperationType, value bool, changedAt time.Time) (*model.ItemState, bool, error)
+}
+
+// ItemSummaryRepository は要約器が生成した記事の要約（items.generated_summary）の永続化インターフェース。
+// PostgresItemRepo が実装する。
+type ItemSummaryRepository interface {
+	// UpdateGeneratedSummary は記事の生成要約と生成日時を保存する。
+	UpdateGeneratedSummary(ctx context.Context, itemID, summary string, generatedAt time.Time) error
+}
+
 // UserCrossFeedViewRepository は「最後にフィード横断新着一覧を開いた時刻」の永続化インターフェース。
 // ユーザーごとに 1 行を保持し、未読判定の基準時刻として用いる（Issue #121 / Req 4.1, 4.3, 4.5）。
 type UserCrossFeedViewRepository interface {
@@ -276,6 +338,528 @@ type UserCrossFeedViewRepository interface {
 	Upsert(ctx context.Context, userID string, lastSeenAt time.Time) error
 }
 
+// PublicProfileRepository は購読リスト公開プロフィールの永続化インターフェース。
+// 公開設定は user_settings、購読ごとの公開可否は subscriptions.is_public に保持する。
+type PublicProfileRepository interface {
+	// GetByUserID は当該ユーザーの公開設定を取得する。未登録の場合は (nil, nil) を返す。
+	GetByUserID(ctx context.Context, userID string) (*model.PublicProfile, error)
+
+	// Upsert は user_id をキーに公開フラグとスラッグを冪等に上書き保存する。
+	// スラッグが他ユーザーと衝突した場合は ErrPublicSlugTaken を返す。
+	Upsert(ctx context.Context, profile *model.PublicProfile) error
+
+	// FindUserIDBySlug は公開が有効なプロフィールのスラッグからユーザーIDを取得する。
+	// 該当なし、または公開が無効な場合は空文字を返す。
+	FindUserIDBySlug(ctx context.Context, slug string) (string, error)
+
+	// ListPublicSubscriptions は当該ユーザーの公開設定された購読をフィード情報付きで返す。
+	ListPublicSubscriptions(ctx context.Context, userID string) ([]model.PublicSubscription, error)
+
+	// UpdateSubscriptionVisibility は当該ユーザーが所有する購読の公開可否を更新する。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+	UpdateSubscriptionVisibility(ctx context.Context, userID, subscriptionID string, isPublic bool) (bool, error)
+}
+
+// RandomItemRepository は「何か読む」向けに購読中フィードの記事をランダムに取り出す DB アクセスを提供する。
+// ItemSearchRepository と同様に PostgresItemRepo が実装し、単一の DB ハンドルを共有する。
+type RandomItemRepository interface {
+	// PickRandomItems は当該ユーザーが購読中のフィードに属し filter に合致する記事を、
+	// items.id（gen_random_uuid による UUID）が pivotID 以上のものから id 昇順で最大 limit 件取得する。
+	// 末尾まで走査して limit に満たない場合は先頭（id < pivotID）に折り返して不足分を補う。
+	// pivotID を呼び出し側でランダムに選ぶことで、全件ソートを伴わないランダム抽出になる。
+	PickRandomItems(ctx context.Context, userID string, filter model.ItemFilter, pivotID string, limit int) ([]CrossFeedItem, error)
+}
+
+// LinkCheckRepository はスター記事のリンク切れチェック（items.link_status / link_checked_at）の DB アクセスを提供する。
+// ItemSearchRepository と同様に PostgresItemRepo が実装する。
+type LinkCheckRepository interface {
+	// ListLinkCheckTargets はいずれかのユーザーがスター付与している記事のうち、
+	// publishedBefore より前に公開され、link_checked_at が NULL または checkedBefore より前のものを
+	// 未チェック・チェック日時の古い順に最大 limit 件取得する。link が空の記事は含まない。
+	ListLinkCheckTargets(ctx context.Context, publishedBefore, checkedBefore time.Time, limit int) ([]LinkCheckTarget, error)
+	// RecordLinkCheck は記事のリンク切れチェック結果を記録する。
+	// status が空文字列（判定保留）の場合は link_status を変更せず link_checked_at のみ更新する。
+	RecordLinkCheck(ctx context.Context, itemID string, status model.LinkStatus, checkedAt time.Time) error
+}
+
+// FeedAuthorRepository はフィード内の著者一覧の集計を提供する。
+// ItemSearchRepository と同様に PostgresItemRepo が実装する。
+type FeedAuthorRepository interface {
+	// ListAuthorsByFeed はフィードの記事を正規化済みの著者名（items.author）ごとに集計し、
+	// 記事数の多い順（同数の場合は著者名の昇順）で返す。著者名が NULL の記事は含まない。
+	ListAuthorsByFeed(ctx context.Context, feedID string) ([]model.FeedAuthor, error)
+}
+
+// LinkCheckTarget はリンク切れチェック対象の記事 1 件を表す。
+type LinkCheckTarget struct {
+	// ItemID は記事 ID（items.id）。
+	ItemID string
+	// Link は元記事の URL（items.link）。
+	Link string
+}
+
+// SubscriptionRetentionRepository は購読単位の最大記事保持数（retention_override）の永続化インターフェース。
+type SubscriptionRetentionRepository interface {
+	// GetRetentionOverride は当該ユーザーが所有する購読の保持数設定を取得する。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+	GetRetentionOverride(ctx context.Context, userID, subscriptionID string) (*model.RetentionOverride, error)
+	// UpdateRetentionOverride は当該ユーザーが所有する購読の保持数設定を上書き保存する（nil で解除）。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+	UpdateRetentionOverride(ctx context.Context, userID, subscriptionID string, maxItems *int) (bool, error)
+}
+
+// SubscriptionNotificationRepository は購読単位の通知ヒント設定（priority / mute_until）の永続化インターフェース。
+type SubscriptionNotificationRepository interface {
+	// GetNotificationSetting は当該ユーザーが所有する購読の通知ヒント設定を取得する。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+	GetNotificationSetting(ctx context.Context, userID, subscriptionID string) (*model.SubscriptionNotificationSetting, error)
+	// UpdateNotificationSetting は当該ユーザーが所有する購読の通知ヒント設定を上書き保存する。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+	UpdateNotificationSetting(ctx context.Context, userID, subscriptionID string, setting model.SubscriptionNotificationSetting) (bool, error)
+}
+
+// FeedTitleRepository は購読ごとのフィードタイトルの自動更新ポリシーと承認待ちタイトルの永続化インターフェース。
+// いずれのメソッドも userID の当該フィードの購読のみを対象とし、
+// 対象フィードが存在しない、または購読していない場合は nil を返す。
+type FeedTitleRepository interface {
+	// GetFeedTitleSetting は購読一覧に表示するタイトル・自動更新ポリシー・承認待ちタイトルを取得する。
+	GetFeedTitleSetting(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error)
+	// UpdateTitleUpdatePolicy は自動更新ポリシーを更新する。initial / manual では現在表示しているタイトルを固定し、
+	// manual 以外に変更した場合は承認待ちタイトルを破棄する。
+	UpdateTitleUpdatePolicy(ctx context.Context, userID, feedID string, policy model.TitleUpdatePolicy) (*model.FeedTitleSetting, error)
+	// ApplyPendingTitle は承認待ちタイトルを表示するタイトルに反映する。承認待ちがない場合は何もせず現在の設定を返す。
+	ApplyPendingTitle(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error)
+	// DismissPendingTitle は承認待ちタイトルを破棄する。
+	DismissPendingTitle(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error)
+}
+
+// SubscriptionExpiryRepository はお試し購読（期限付き購読）の期限（expires_at）の永続化インターフェース。
+type SubscriptionExpiryRepository interface {
+	// ListExpiredSubscriptions は期限が now 以前のお試し購読を期限の古い順に最大 limit 件返す。
+	ListExpiredSubscriptions(ctx context.Context, now time.Time, limit int) ([]*model.Subscription, error)
+	// ClearSubscriptionExpiry は当該ユーザーが所有する購読の期限を外し、通常の購読にする。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+	ClearSubscriptionExpiry(ctx context.Context, userID, subscriptionID string) (bool, error)
+}
+
+// FeedURLSuggestionRepository は停止したフィードの URL 張り替え提案（feeds.suggested_feed_url）の永続化インターフェース。
+type FeedURLSuggestionRepository interface {
+	// SetSuggestedFeedURL はフィードの URL 張り替え提案を保存する。空文字列の場合は提案を取り消す。
+	SetSuggestedFeedURL(ctx context.Context, feedID, suggestedURL string) error
+	// FindSuggestedFeedURL はフェッチ停止中のフィードの URL 張り替え提案を返す。
+	// 提案がない（またはフィードが停止中でない）場合は空文字列を返す。
+	FindSuggestedFeedURL(ctx context.Context, feedID string) (string, error)
+	// ApplySuggestedFeedURL はフェッチ停止中のフィードの feed_url を提案 URL（suggestedURL）に置き換え、
+	// エラー状態と条件付き GET の検証子をクリアしてフェッチを再開する。
+	// 適用した URL を返し、提案がない（またはフィードが停止中でない、提案が suggestedURL から変わった）場合は空文字列を返す。
+	// 提案 URL が別のフィードで登録済みの場合は ErrFeedURLTaken を返す。
+	ApplySuggestedFeedURL(ctx context.Context, feedID, suggestedURL string) (string, error)
+}
+
+// SubscriptionOrderRepository は購読のピン留めとサイドバー並び順（is_pinned / sort_order）の永続化インターフェース。
+type SubscriptionOrderRepository interface {
+	// UpdateOrder は当該ユーザーの購読に entries の指定順で sort_order を振り直し、
+	// IsPinned が指定されたものはピン留め状態も更新する。全件を同一トランザクションで更新する。
+	// 存在しない、または他ユーザーの購読が含まれる場合は何も更新せず、最初に見つからなかった購読 ID を返す
+	// （全件更新できた場合は空文字）。
+	UpdateOrder(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) (notFoundID string, err error)
+}
+
+// SubscriptionImportFilterRepository は購読単位の記事取り込みフィルタの永続化インターフェース。
+// フィルタは subscriptions.import_filter_authors / import_filter_title_pattern に保持する。
+type SubscriptionImportFilterRepository interface {
+	// GetImportFilter は当該ユーザーが所有する購読の取り込みフィルタを取得する。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+	GetImportFilter(ctx context.Context, userID, subscriptionID string) (*model.ImportFilter, error)
+
+	// UpdateImportFilter は当該ユーザーが所有する購読の取り込みフィルタを上書き保存する。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+	UpdateImportFilter(ctx context.Context, userID, subscriptionID string, filter model.ImportFilter) (bool, error)
+
+	// ListImportFiltersByFeedID は指定フィードの全購読の取り込みフィルタを購読 1 件につき 1 要素で返す。
+	// フィルタ未設定の購読は IsEmpty() が true の要素として含める。
+	ListImportFiltersByFeedID(ctx context.Context, feedID string) ([]model.ImportFilter, error)
+}
+
+// SubscriptionUndoRepository は購読解除の取り消し（Undo）用スナップショットの永続化インターフェース。
+// スナップショットは subscription_undos に保持し、期限を過ぎたものは復元できない。
+type SubscriptionUndoRepository interface {
+	// DeleteWithUndo は当該ユーザーが所有する購読と関連 item_states を削除し、
+	// expiresAt まで復元可能なスナップショットを保存する。
+	// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+	DeleteWithUndo(ctx context.Context, userID, subscriptionID string, now, expiresAt time.Time) (bool, error)
+
+	// Restore は期限内のスナップショットから購読と item_states を復元する。
+	// スナップショットが無い場合は ErrSubscriptionUndoNotFound、
+	// 同じフィードを既に購読している場合は ErrSubscriptionAlreadyExists を返す。
+	Restore(ctx context.Context, userID, subscriptionID string, now time.Time) error
+}
+
+// UserSettingsRepository はユーザー設定（user_settings）の永続化インターフェース。
+type UserSettingsRepository interface {
+	// GetUnreadWarningThreshold は当該ユーザーの積読警告の閾値を取得する。
+	// user_settings に行が無い、または閾値が未設定（NULL）の場合は nil を返す。
+	GetUnreadWarningThreshold(ctx context.Context, userID string) (*int, error)
+	// UpsertUnreadWarningThreshold は user_id をキーに積読警告の閾値を冪等に上書き保存する。
+	UpsertUnreadWarningThreshold(ctx context.Context, userID string, threshold int) error
+	// GetOpenLinksInNewTab は当該ユーザーの「リンクを新しいタブで開く」設定を取得する。
+	// user_settings に行が無い場合は nil を返す。
+	GetOpenLinksInNewTab(ctx context.Context, userID string) (*bool, error)
+	// UpsertOpenLinksInNewTab は user_id をキーに「リンクを新しいタブで開く」設定を冪等に上書き保存する。
+	UpsertOpenLinksInNewTab(ctx context.Context, userID string, openInNewTab bool) error
+	// GetPrefetchEnabled は当該ユーザーの利用時間帯に合わせたプリフェッチの設定を取得する。
+	// user_settings に行が無い場合は nil を返す。
+	GetPrefetchEnabled(ctx context.Context, userID string) (*bool, error)
+	// UpsertPrefetchEnabled は user_id をキーにプリフェッチの設定を冪等に上書き保存する。
+	UpsertPrefetchEnabled(ctx context.Context, userID string, enabled bool) error
+	// GetTimezone は当該ユーザーのタイムゾーン（IANA 名）を取得する。
+	// user_settings に行が無い、またはタイムゾーンが未設定（NULL）の場合は nil を返す。
+	GetTimezone(ctx context.Context, userID string) (*string, error)
+	// UpsertTimezone は user_id をキーにタイムゾーンを冪等に上書き保存する。
+	UpsertTimezone(ctx context.Context, userID, timezone string) error
+}
+
+// ItemViewRepository は記事閲覧イベント（item_views）の永続化インターフェース。
+type ItemViewRepository interface {
+	// InsertBatch は閲覧イベントをまとめて保存する。
+	// 保存までの間に削除された記事・ユーザーのイベントは黙って破棄する。
+	InsertBatch(ctx context.Context, views []model.ItemView) error
+	// TopFeedsByUser は since 以降の閲覧回数が多い順にフィードを最大 limit 件返す。
+	TopFeedsByUser(ctx context.Context, userID string, since time.Time, limit int) ([]model.FeedViewStat, error)
+}
+
+// FeedSubscriberCounter はフィードの購読者数を数えるインターフェース。
+// 購読者数に応じたフェッチ間隔の延長で worker から参照する。
+type FeedSubscriberCounter interface {
+	// CountSubscribersByFeedID は当該フィードの購読数を返す。
+	CountSubscribersByFeedID(ctx context.Context, feedID string) (int, error)
+}
+
+// FeedActiveSubscriberChecker はフィードにアクティブな購読者がいるかを判定するインターフェース。
+// 休眠ユーザーのみが購読するフィードのフェッチ間隔の延長で worker から参照する。
+type FeedActiveSubscriberChecker interface {
+	// HasActiveSubscriber は since 以降にアクティブだった購読者が当該フィードに 1 人でもいるかを返す。
+	HasActiveSubscriber(ctx context.Context, feedID string, since time.Time) (bool, error)
+}
+
+// SubscriptionListModTimeRepository は購読一覧の最終更新日時を求めるインターフェース。
+// 購読一覧 API の条件付き GET（Last-Modified / If-Modified-Since）で使う。
+type SubscriptionListModTimeRepository interface {
+	// SubscriptionListModTime はユーザーの購読・記事状態・購読中フィードの最終更新日時を返す。
+	// ユーザーが存在しない場合はゼロ値を返す。
+	SubscriptionListModTime(ctx context.Context, userID string) (time.Time, error)
+}
+
+// ItemListModTimeRepository はフィードの記事一覧の最終更新日時を求めるインターフェース。
+// 記事一覧 API の条件付き GET（Last-Modified / If-Modified-Since）で使う。
+type ItemListModTimeRepository interface {
+	// ItemListModTime はフィードの記事とユーザーの記事状態の最終更新日時を返す。
+	// ユーザーが当該フィードを購読していない場合はゼロ値を返す。
+	ItemListModTime(ctx context.Context, userID, feedID string) (time.Time, error)
+}
+
+// FeedPublishHistoryRepository はフィードの記事の公開日時の履歴を取得するインターフェース。
+// 購読詳細の推定次回更新時刻の算出に使う。
+type FeedPublishHistoryRepository interface {
+	// ListRecentPublishedAt はフィードの記事のうち since 以降に公開されたものの公開日時を新しい順に最大 limit 件返す。
+	// 公開日時が推定値（フィードに日時が無く取得時刻で補った）の記事は含めない。
+	ListRecentPublishedAt(ctx context.Context, feedID string, since time.Time, limit int) ([]time.Time, error)
+}
+
+// SubscribedFeedRepository はユーザーが購読しているフィードを確認するインターフェース。
+// 複数フィードを指定する記事一覧 API の購読チェックで使う。
+type SubscribedFeedRepository interface {
+	// FilterSubscribedFeedIDs は feedIDs のうちユーザーが購読しているフィードの ID を返す。順序は問わない。
+	FilterSubscribedFeedIDs(ctx context.Context, userID string, feedIDs []string) ([]string, error)
+}
+
+// ActiveHourRepository は閲覧履歴からユーザーの利用時間帯を集計するインターフェース。
+// 利用時間帯に合わせたプリフェッチ（next_fetch_at の前倒し）で worker から参照する。
+type ActiveHourRepository interface {
+	// ActiveHoursByFeed は当該フィードを購読しプリフェッチを有効にしているユーザーについて、
+	// since 以降に記事を閲覧した日が minDays 日以上ある時間帯（UTC の時、0〜23）を昇順で返す。
+	// 時間帯は購読者ごとに判定し、その和集合（いずれかの購読者の利用時間帯）を返す。
+	ActiveHoursByFeed(ctx context.Context, feedID string, since time.Time, minDays int) ([]int, error)
+}
+
+// WeeklyStatsRepository はユーザー×フィード単位の週次統計スナップショット（weekly_subscription_stats）の
+// 永続化インターフェース。
+type WeeklyStatsRepository interface {
+	// SnapshotWeeklyStats は [weekStart, weekEnd) の新着数・既読数を全購読について記録し、記録した件数を返す。
+	// 記録済みの週・購読は上書きしない。
+	SnapshotWeeklyStats(ctx context.Context, weekStart, weekEnd time.Time) (int64, error)
+	// DeleteWeeklyStatsBefore は week_start が before より前のスナップショットを削除し、削除件数を返す。
+	DeleteWeeklyStatsBefore(ctx context.Context, before time.Time) (int64, error)
+	// ListWeeklyStatsByUser は week_start が since 以降の当該ユーザーのスナップショットを週の古い順に返す。
+	ListWeeklyStatsByUser(ctx context.Context, userID string, since time.Time) ([]model.WeeklyFeedStat, error)
+}
+
+// APIUsageRepository はユーザー×日×クライアント単位の API 利用量カウンタ（api_usage_daily）の
+// 永続化インターフェース。
+type APIUsageRepository interface {
+	// AddUsage は usages のリクエスト数・エラー数を既存のカウンタに加算する（無ければ作成する）。
+	AddUsage(ctx context.Context, usages []model.APIUsage) error
+	// ListUsageByUser は集計日が since 以降の当該ユーザーの利用量を日付・クライアント名の昇順で返す。
+	ListUsageByUser(ctx context.Context, userID string, since time.Time) ([]model.APIUsage, error)
+	// DeleteUsageBefore は集計日が before より前の利用量を削除し、削除件数を返す。
+	DeleteUsageBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AuditLogRepository は監査ログ（audit_logs）の永続化インターフェース。
+type AuditLogRepository interface {
+	// Create は監査ログを 1 件保存する。ID・CreatedAt が空の場合は DB 側で採番・補完し、log に書き戻す。
+	Create(ctx context.Context, log *model.AuditLog) error
+	// ListByUser は当該ユーザーの監査ログを (created_at, id) の降順で最大 limit 件返す。
+	// cursorCreatedAt がゼロ値でない場合は (cursorCreatedAt, cursorID) より古いものだけを返す。
+	ListByUser(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.AuditLog, error)
+}
+
+// LoginEventRepository はログインイベント（login_events）の永続化インターフェース。
+type LoginEventRepository interface {
+	// Create はログインイベントを 1 件保存する。ID・CreatedAt が空の場合は DB 側で採番・補完し、event に書き戻す。
+	// UserID が空の場合は user_id を NULL として保存する。
+	Create(ctx context.Context, event *model.LoginEvent) error
+	// ListByUser は当該ユーザーのログインイベントを (created_at, id) の降順で最大 limit 件返す。
+	// cursorCreatedAt がゼロ値でない場合は (cursorCreatedAt, cursorID) より古いものだけを返す。
+	ListByUser(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.LoginEvent, error)
+}
+
+// ExpiredSessionRepository は有効期限を過ぎたセッションの削除インターフェース。
+// PostgreSQL のセッションストアのみが実装する（Redis は TTL で自動的に失効する）。
+type ExpiredSessionRepository interface {
+	// DeleteExpired は now 時点で有効期限を過ぎたセッションを最大 limit 件削除し、削除したセッションを返す。
+	DeleteExpired(ctx context.Context, now time.Time, limit int) ([]*model.Session, error)
+}
+
+// TeamRepository はチーム共有（teams / team_members / team_feeds / team_invitations）の永続化インターフェース。
+// チームの購読フィードはメンバー全員の購読（subscriptions.team_id 付き）として展開し、
+// 既読・スターはユーザー単位の item_states のままメンバーごとに分離する。
+type TeamRepository interface {
+	// Create はチームを作成し、ownerUserID をオーナーとして登録する。ID・日時は team に書き戻す。
+	Create(ctx context.Context, team *model.Team, ownerUserID string) error
+	// ListByUser は当該ユーザーが所属するチームを参加日時の昇順で返す。
+	ListByUser(ctx context.Context, userID string) ([]model.TeamMembership, error)
+	// FindMembership は当該ユーザーのチームへの所属を返す。所属していない場合は nil を返す。
+	FindMembership(ctx context.Context, teamID, userID string) (*model.TeamMembership, error)
+	// ListMembers はチームのメンバーを参加日時の昇順で返す。
+	ListMembers(ctx context.Context, teamID string) ([]model.TeamMember, error)
+	// ListFeeds はチームの購読フィードを追加日時の昇順で返す。
+	ListFeeds(ctx context.Context, teamID string) ([]model.TeamFeed, error)
+	// FindFeed はチームの購読フィードを返す。チームで購読していない場合は nil を返す。
+	FindFeed(ctx context.Context, teamID, feedID string) (*model.TeamFeed, error)
+	// AddFeed はチームの購読フィードを追加し、全メンバーの購読として展開する。
+	// 既に個人で購読しているメンバーは既存の購読をそのまま使い、購読数が maxSubscriptions に
+	// 達しているメンバーには展開しない。既にチームで購読している場合は ErrTeamFeedAlreadyExists を返す。
+	AddFeed(ctx context.Context, teamID, feedID, addedBy string, maxSubscriptions int) error
+	// RemoveFeed はチームの購読フィードを削除し、チームから展開された購読と記事状態を削除する。
+	// 対象が無い場合は false を返す。
+	RemoveFeed(ctx context.Context, teamID, feedID string) (bool, error)
+	// CreateInvitation は招待を保存する。トークンはハッシュ（tokenHash）のみ保存する。
+	CreateInvitation(ctx context.Context, invitation *model.TeamInvitation, tokenHash string) error
+	// AcceptInvitation は期限内・未使用の招待で userID をメンバーとして追加し、招待を使用済みにする。
+	// チームの購読フィードは、新メンバーの購読数が maxSubscriptions に達するまで追加日時の古い順に展開する。
+	// 招待が無い・期限切れ・使用済みの場合は ErrTeamInvitationNotFound、既に所属している場合は
+	// ErrAlreadyTeamMember、メンバー数が maxMembers に達している場合は ErrTeamMemberLimit を返す。
+	AcceptInvitation(ctx context.Context, tokenHash, userID string, now time.Time, maxMembers, maxSubscriptions int) (*model.Team, error)
+	// RemoveMember はメンバーをチームから外し、チームから展開された当該メンバーの購読と記事状態を削除する。
+	// 所属していない場合は false を返す。
+	RemoveMember(ctx context.Context, teamID, userID string) (bool, error)
+}
+
+// IntegrationRepository は新着記事の転送設定（integrations）の永続化インターフェース。
+// いずれの操作も userID の所有する連携設定・購読に限る。
+type IntegrationRepository interface {
+	// Create は当該ユーザーの購読に連携設定を追加する。ID・日時は integration に書き戻す。
+	// 購読が存在しない、または他ユーザーの購読の場合は false を返す。
+	Create(ctx context.Context, integration *model.Integration) (bool, error)
+	// ListByUser は当該ユーザーの連携設定を作成日時の昇順で返す。
+	ListByUser(ctx context.Context, userID string) ([]model.Integration, error)
+	// FindByID は当該ユーザーの連携設定を返す。存在しない場合は nil を返す。
+	FindByID(ctx context.Context, userID, integrationID string) (*model.Integration, error)
+	// CountByUser は当該ユーザーの連携設定数を返す。
+	CountByUser(ctx context.Context, userID string) (int, error)
+	// Update は連携設定の種類・Webhook URL・テンプレート・有効フラグを更新する。
+	// Webhook URL を変更した場合は直近の配送エラーをクリアする。対象が無い場合は false を返す。
+	Update(ctx context.Context, integration *model.Integration) (bool, error)
+	// Delete は当該ユーザーの連携設定と配送キューを削除する。対象が無い場合は false を返す。
+	Delete(ctx context.Context, userID, integrationID string) (bool, error)
+}
+
+// IntegrationDeliveryRepository は新着記事の配送キュー（integration_deliveries）の永続化インターフェース。
+// フェッチャーがキューに積み、配送ワーカーが取り出して結果を記録する。
+type IntegrationDeliveryRepository interface {
+	// EnqueueNewItems は当該フィードの新着記事を、有効な連携設定ごとに配送キューへ積み、積んだ件数を返す。
+	// 連携設定の作成前に取り込まれた記事、ミュート中の購読、配送済み・キュー投入済みの記事は対象外とする。
+	EnqueueNewItems(ctx context.Context, feedID string) (int, error)
+	// ListDueDeliveries は配送期限（next_attempt_at）が now 以前の未配送を古い順に最大 limit 件返す。
+	// 無効化された連携設定の配送は返さない。
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]model.IntegrationDelivery, error)
+	// MarkDelivered は配送済みとして記録し、連携設定の直近の配送結果を更新する。
+	MarkDelivered(ctx context.Context, deliveryID int64, at time.Time) error
+	// MarkRetry は試行回数を加算して nextAttemptAt に再試行を予約し、連携設定に直近のエラーを記録する。
+	MarkRetry(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, lastError string) error
+	// MarkFailed は試行回数を加算して配送失敗として確定し、連携設定に直近のエラーを記録する。
+	MarkFailed(ctx context.Context, deliveryID int64, lastError string) error
+}
+
+// ReadLaterConnectionRepository は「あとで読む」サービス連携（read_later_connections）の永続化インターフェース。
+// いずれの操作も userID の所有する連携に限る。認証情報は暗号化済みのバイト列のまま扱う。
+type ReadLaterConnectionRepository interface {
+	// Upsert は当該ユーザーの保存先サービスとの連携を作成し、既にある場合は認証情報とアカウント名を置き換える。
+	// いずれの場合も有効化し、直近のエラーをクリアする。ID・日時は conn に書き戻す。
+	Upsert(ctx context.Context, conn *model.ReadLaterConnection) error
+	// ListByUser は当該ユーザーの連携を作成日時の昇順で返す。
+	ListByUser(ctx context.Context, userID string) ([]model.ReadLaterConnection, error)
+	// FindByProvider は当該ユーザーの保存先サービスとの連携を返す。存在しない場合は nil を返す。
+	FindByProvider(ctx context.Context, userID string, provider model.ReadLaterProvider) (*model.ReadLaterConnection, error)
+	// SetEnabled は連携の有効フラグを更新する。有効化する場合は直近のエラーをクリアする。対象が無い場合は false を返す。
+	SetEnabled(ctx context.Context, userID string, provider model.ReadLaterProvider, enabled bool) (bool, error)
+	// Delete は当該ユーザーの連携と保存キューを削除する。対象が無い場合は false を返す。
+	Delete(ctx context.Context, userID string, provider model.ReadLaterProvider) (bool, error)
+}
+
+// ReadLaterDeliveryRepository はスター記事の保存キュー（read_later_deliveries）の永続化インターフェース。
+// スター操作の直後にキューへ積み、保存ワーカーが取り出して結果を記録する。
+type ReadLaterDeliveryRepository interface {
+	// EnqueueStarredItem は当該ユーザーの有効な連携ごとに記事を保存キューへ積み、積んだ件数を返す。
+	// 保存済み・キュー投入済みの記事は対象外とする。
+	EnqueueStarredItem(ctx context.Context, userID, itemID string) (int, error)
+	// ListDueDeliveries は保存期限（next_attempt_at）が now 以前の未保存を古い順に最大 limit 件返す。
+	// 無効化された連携の保存は返さない。
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]model.ReadLaterDelivery, error)
+	// MarkSent は保存済みとして記録し、連携の直近の保存日時を更新してエラーをクリアする。
+	MarkSent(ctx context.Context, deliveryID int64, at time.Time) error
+	// MarkRetry は試行回数を加算して nextAttemptAt に再試行を予約し、連携に直近のエラーを記録する。
+	MarkRetry(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, lastError string, at time.Time) error
+	// MarkFailed は試行回数を加算して保存失敗として確定し、連携に直近のエラーを記録する。
+	// disableConnection が true の場合（連携先で認証が無効になった場合）は連携も無効化する。
+	MarkFailed(ctx context.Context, deliveryID int64, lastError string, at time.Time, disableConnection bool) error
+}
+
+// FeedRegistrationBatchRepository は URL の一括登録（feed_registration_batches）の永続化インターフェース。
+// 一括登録の開始時に全件を pending で作成し、処理しながら URL ごとの結果を書き換える。
+type FeedRegistrationBatchRepository interface {
+	// Create は一括登録を作成する。同じユーザーの expiredBefore より前に作成した一括登録は削除する。
+	// ID・作成日時は batch に書き戻す。
+	Create(ctx context.Context, batch *model.FeedRegistrationBatch, expiredBefore time.Time) error
+	// UpdateEntry は一括登録の index 番目の URL の結果を entry で置き換える。
+	UpdateEntry(ctx context.Context, batchID string, index int, entry model.FeedRegistrationBatchEntry) error
+	// Complete は一括登録を completedAt に処理完了として記録する。
+	Complete(ctx context.Context, batchID string, completedAt time.Time) error
+	// FindByUser は当該ユーザーの一括登録を返す。存在しない場合は nil を返す。
+	FindByUser(ctx context.Context, userID, batchID string) (*model.FeedRegistrationBatch, error)
+}
+
+// HighlightRepository はハイライトの事前計算結果（item_highlights）の永続化インターフェース。
+type HighlightRepository interface {
+	// ReplaceHighlights は公開日時（未設定の記事は取り込み日時）が since 以降の記事のスコアを weights で計算し、
+	// period の事前計算結果をフィードごとの上位 perFeed 件で置き換える。保存した件数を返す。
+	// スコアが 0 の記事（はてブ・スター・閲覧のいずれもない記事）は保存しない。
+	ReplaceHighlights(ctx context.Context, period model.HighlightPeriod, since, computedAt time.Time, weights model.HighlightWeights, perFeed int) (int64, error)
+	// ListHighlightsByUser は当該ユーザーの購読フィードの period のハイライトをスコアの高い順に最大 limit 件返す。
+	// ユーザーが非表示にした記事は除く。
+	ListHighlightsByUser(ctx context.Context, userID string, period model.HighlightPeriod, limit int) ([]model.HighlightItem, error)
+}
+
+// FeedKeywordRepository は記事傾向サマリーの頻出キーワード（feed_keywords）の永続化インターフェース。
+type FeedKeywordRepository interface {
+	// ListSubscribedFeedIDs は購読者が 1 人以上いるフィードの ID を返す。
+	ListSubscribedFeedIDs(ctx context.Context) ([]string, error)
+	// ListItemTextsSince はフィードの公開日時（未設定の記事は取り込み日時）が since 以降の記事の
+	// タイトルと本文テキスト（content_text）を連結したテキストを返す。
+	ListItemTextsSince(ctx context.Context, feedID string, since time.Time) ([]string, error)
+	// ReplaceFeedKeywords はフィードの頻出キーワードを keywords で置き換える。keywords が空の場合は削除のみを行う。
+	ReplaceFeedKeywords(ctx context.Context, feedID string, keywords []model.FeedKeyword, computedAt time.Time) error
+	// GetBySubscription は当該ユーザーの購読のフィードの頻出キーワードを出現回数の多い順に返す。
+	// 購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+	GetBySubscription(ctx context.Context, userID, subscriptionID string) (*model.FeedKeywordSummary, error)
+}
+
+// LoginTokenRepository はマジックリンクログインの一回限りのトークン（login_tokens）の永続化インターフェース。
+type LoginTokenRepository interface {
+	// Create はトークンを保存する。
+	Create(ctx context.Context, token *model.LoginToken) error
+	// CountCreatedSince は email に since 以降に発行したトークンの数を返す（使用済み・期限切れを含む）。
+	CountCreatedSince(ctx context.Context, email string, since time.Time) (int, error)
+	// Consume は tokenHash のトークンが未使用かつ now の時点で有効であれば使用済みにして返す。
+	// 存在しない・期限切れ・使用済みの場合は nil を返す。同じトークンを同時に検証しても 1 回しか成功しない。
+	Consume(ctx context.Context, tokenHash string, now time.Time) (*model.LoginToken, error)
+	// DeleteExpired は有効期限が before 以前のトークンを削除し、削除した件数を返す。
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// BlockedDomainRepository はモデレーション用ブロックリスト（blocked_domains）の永続化インターフェース。
+type BlockedDomainRepository interface {
+	// List はブロックリストの全項目を追加日時の昇順で返す。
+	List(ctx context.Context) ([]model.BlockedDomain, error)
+	// Create はブロックリストに項目を追加する。同じ Pattern の項目が既にある場合は種類と理由を更新する。
+	// ID・追加日時は blocked に書き戻す。
+	Create(ctx context.Context, blocked *model.BlockedDomain) error
+	// Delete はブロックリストの項目を削除する。対象が無い場合は false を返す。
+	Delete(ctx context.Context, id string) (bool, error)
+	// StopMatchingFeeds は blocked に一致するフィードのフェッチを停止し（error_kind は blocked）、停止した件数を返す。
+	// 既にブロックにより停止済みのフィードは数えない。
+	StopMatchingFeeds(ctx context.Context, blocked model.BlockedDomain, message string) (int, error)
+}
+
+// FeedConditionalGetRepository はフィード単位の条件付き GET 無効化フラグの更新インターフェース。
+// フラグの読み取りは FeedRepository が返す model.Feed.IgnoreConditionalGet で行う。
+type FeedConditionalGetRepository interface {
+	// SetIgnoreConditionalGet は条件付き GET 無効化フラグを更新する。対象フィードが存在しない場合は false を返す。
+	SetIgnoreConditionalGet(ctx context.Context, feedID string, ignore bool) (bool, error)
+}
+
+// FeedRawCaptureRepository はデバッグ用に保存するフィードの直近のフェッチレスポンス（feed_raw_captures）の
+// 永続化インターフェース。保存の有効・無効は model.Feed.CaptureRawResponse で読み取る。
+type FeedRawCaptureRepository interface {
+	// SetCaptureRawResponse はフェッチレスポンスの保存の有効・無効を更新する。無効にした場合は保存済みのレスポンスを削除する。
+	// 対象フィードが存在しない場合は false を返す。
+	SetCaptureRawResponse(ctx context.Context, feedID string, enabled bool) (bool, error)
+	// SaveRawCapture はフィードの直近のフェッチレスポンスを上書き保存する。
+	SaveRawCapture(ctx context.Context, capture *model.FeedRawCapture) error
+	// FindRawCapture はフィードの直近のフェッチレスポンスを返す。保存されていない場合は nil を返す。
+	FindRawCapture(ctx context.Context, feedID string) (*model.FeedRawCapture, error)
+}
+
+// FetchAttemptRepository はフェッチ結果の履歴（fetch_attempts）の永続化インターフェース。
+type FetchAttemptRepository interface {
+	// RecordFetchAttempt はフェッチ 1 回分の結果を保存する。
+	RecordFetchAttempt(ctx context.Context, attempt model.FetchAttempt) error
+	// DeleteFetchAttemptsBefore は before より前のフェッチ結果を削除し、削除件数を返す。
+	DeleteFetchAttemptsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WorkerCycleRepository はフェッチスケジューラのサイクル結果（worker_cycles）の永続化インターフェース。
+type WorkerCycleRepository interface {
+	// RecordWorkerCycle はサイクル 1 回分の結果を保存する。
+	RecordWorkerCycle(ctx context.Context, cycle model.WorkerCycle) error
+	// ListRecentWorkerCycles は開始時刻の新しい順に最大 limit 件のサイクル結果を返す。
+	ListRecentWorkerCycles(ctx context.Context, limit int) ([]model.WorkerCycle, error)
+	// DeleteWorkerCyclesBefore は before より前に開始したサイクル結果を削除し、削除件数を返す。
+	DeleteWorkerCyclesBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// IdempotencyKeyRepository は Idempotency-Key ごとのリクエスト・レスポンス（idempotency_keys）の永続化インターフェース。
+type IdempotencyKeyRepository interface {
+	// Reserve はユーザー・キーの組を処理中として予約する。予約できた場合は reserved=true を返す。
+	// 期限内の同じキーが既にある場合は reserved=false と保存済みのレコードを返す
+	// （予約直後に解放された等でレコードを取得できない場合は nil）。
+	// 当該ユーザーの期限切れのキーはこの機会にまとめて削除する。
+	Reserve(ctx context.Context, userID, key, requestHash string, now, expiresAt time.Time) (reserved bool, existing *model.IdempotencyRecord, err error)
+	// Complete は予約済みのキーにレスポンスを保存し、処理済みにする。
+	Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error
+	// Release は処理中のまま残った予約を削除し、同じキーでの再実行を可能にする。
+	Release(ctx context.Context, userID, key string) error
+}
+
+// AdminStatsRepository は管理者向け全体統計（admin_stats マテリアライズドビュー）のインターフェース。
+type AdminStatsRepository interface {
+	// GetAdminStats は最後に集計した統計のスナップショットを返す。
+	GetAdminStats(ctx context.Context) (*model.AdminStats, error)
+	// RefreshAdminStats は統計を再集計する。集計中も GetAdminStats は直前のスナップショットを返す。
+	RefreshAdminStats(ctx context.Context) error
+}
+
 // SubscriptionWithFeedInfo は購読とフィード情報、未読数を結合した構造体。
 type SubscriptionWithFeedInfo struct {
 	model.Subscription
@@ -285,7 +869,19 @@ type SubscriptionWithFeedInfo struct {
 	FaviconMime  string
 	FetchStatus  model.FetchStatus
 	ErrorMessage string
+	ErrorKind    model.FetchErrorKind
 	UnreadCount  int
+	// TooManyUnread は未読数がユーザー設定の積読警告閾値を超えているかを表す。
+	TooManyUnread bool
+	// FeedLanguage / FeedDescription / FeedLastPublishedAt はフィードのメタデータ（未取得時は空文字 / nil）。
+	FeedLanguage        string
+	FeedDescription     string
+	FeedLastPublishedAt *time.Time
+	// SuggestedFeedURL はフェッチ停止中のフィードに対する URL 張り替えの提案（提案がない、または停止中でない場合は空文字）。
+	SuggestedFeedURL string
+	// FeedTitleUpdatePolicy / PendingFeedTitle は購読ごとのフィードタイトルの自動更新ポリシーと承認待ちタイトル（ない場合は空文字）。
+	FeedTitleUpdatePolicy model.TitleUpdatePolicy
+	PendingFeedTitle      string
 }
 
 // UserRepository の拡張メソッド用。
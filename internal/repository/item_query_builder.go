@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// itemQueryBuilder は記事一覧クエリの WHERE 句を条件の有無に応じて動的に組み立てる。
+// 引数はプレースホルダ番号（$1, $2, ...）を振りながら順に積み、条件は AND で結合する。
+type itemQueryBuilder struct {
+	base       string
+	conditions []string
+	args       []interface{}
+}
+
+// newItemQueryBuilder は WHERE 句より前のクエリ base と、base 内で使用済みの引数 args から
+// itemQueryBuilder を生成する。
+func newItemQueryBuilder(base string, args ...interface{}) *itemQueryBuilder {
+	return &itemQueryBuilder{base: base, args: args}
+}
+
+// arg は引数 v を追加し、対応するプレースホルダを返す。
+func (b *itemQueryBuilder) arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// where は条件 cond を AND で追加する。
+func (b *itemQueryBuilder) where(cond string) {
+	b.conditions = append(b.conditions, cond)
+}
+
+// build は WHERE 句の後ろに suffix（ORDER BY / LIMIT 等）を付けたクエリと引数を返す。
+func (b *itemQueryBuilder) build(suffix string) (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString(b.base)
+	if len(b.conditions) > 0 {
+		sb.WriteString("\n\t\tWHERE ")
+		sb.WriteString(strings.Join(b.conditions, " AND "))
+	}
+	sb.WriteString(suffix)
+	return sb.String(), b.args
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestItemQueryBuilder(t *testing.T) {
+	t.Run("条件を追加したときプレースホルダ番号を振りANDで結合すること", func(t *testing.T) {
+		// Arrange
+		q := newItemQueryBuilder("SELECT * FROM items i JOIN s ON s.user_id = $1", "user-1")
+		q.where("i.feed_id = " + q.arg("feed-1"))
+		q.where("COALESCE(s.is_read, false) = " + q.arg(false))
+
+		// Act
+		sql, args := q.build(" LIMIT " + q.arg(10))
+
+		// Assert
+		if !strings.Contains(sql, "WHERE i.feed_id = $2 AND COALESCE(s.is_read, false) = $3 LIMIT $4") {
+			t.Errorf("sql = %q", sql)
+		}
+		if want := []interface{}{"user-1", "feed-1", false, 10}; !reflect.DeepEqual(args, want) {
+			t.Errorf("args = %v, want %v", args, want)
+		}
+	})
+
+	t.Run("条件がないときWHERE句を付けないこと", func(t *testing.T) {
+		// Act
+		sql, _ := newItemQueryBuilder("SELECT * FROM items").build(" LIMIT 1")
+
+		// Assert
+		if sql != "SELECT * FROM items LIMIT 1" {
+			t.Errorf("sql = %q", sql)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresAdminStatsRepo は admin_stats マテリアライズドビューを使用した全体統計リポジトリ。
+type PostgresAdminStatsRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresAdminStatsRepo は PostgresAdminStatsRepo を生成する。
+func NewPostgresAdminStatsRepo(db *sql.DB) *PostgresAdminStatsRepo {
+	return &PostgresAdminStatsRepo{db: db}
+}
+
+// GetAdminStats は最後に集計した統計のスナップショットを返す。
+func (r *PostgresAdminStatsRepo) GetAdminStats(ctx context.Context) (*model.AdminStats, error) {
+	stats := &model.AdminStats{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT user_count, feed_count, item_count,
+		        fetch_attempts_24h, fetch_successes_24h, avg_fetch_duration_ms_24h,
+		        database_size_bytes, refreshed_at
+		 FROM admin_stats`,
+	).Scan(
+		&stats.UserCount, &stats.FeedCount, &stats.ItemCount,
+		&stats.FetchAttempts24h, &stats.FetchSuccesses24h, &stats.AvgFetchDurationMs24h,
+		&stats.DatabaseSizeBytes, &stats.RefreshedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("全体統計の取得に失敗しました: %w", err)
+	}
+	return stats, nil
+}
+
+// RefreshAdminStats は admin_stats を CONCURRENTLY で再集計する。
+// 集計中も読み取りはブロックされず、直前のスナップショットが返る。
+func (r *PostgresAdminStatsRepo) RefreshAdminStats(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY admin_stats`); err != nil {
+		return fmt.Errorf("全体統計の再集計に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// compile-time interface check
+var _ AdminStatsRepository = (*PostgresAdminStatsRepo)(nil)
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介したフェッチ結果履歴・全体統計の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresAdminStatsRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "stats-owner@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/stats.xml", "Stats Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+	insertTestItemsForSub(t, db, feedID, 3)
+
+	attempts := NewPostgresFetchAttemptRepo(db)
+	statsRepo := NewPostgresAdminStatsRepo(db)
+	now := time.Now()
+
+	t.Run("再集計前は空のスナップショットを返す", func(t *testing.T) {
+		stats, err := statsRepo.GetAdminStats(ctx)
+		if err != nil {
+			t.Fatalf("GetAdminStats() error = %v", err)
+		}
+		if stats.UserCount != 0 || stats.FetchAttempts24h != 0 {
+			t.Errorf("stats = %+v, want マイグレーション時点の値", stats)
+		}
+	})
+
+	t.Run("再集計すると件数と直近24時間のフェッチ統計を返す", func(t *testing.T) {
+		for _, a := range []model.FetchAttempt{
+			{FeedID: feedID, Succeeded: true, Duration: 100 * time.Millisecond, AttemptedAt: now.Add(-time.Hour)},
+			{FeedID: feedID, Succeeded: false, Duration: 300 * time.Millisecond, AttemptedAt: now.Add(-2 * time.Hour)},
+			// 24 時間より前の結果は集計に含めない
+			{FeedID: feedID, Succeeded: false, Duration: 900 * time.Millisecond, AttemptedAt: now.Add(-48 * time.Hour)},
+			// 存在しないフィードの結果は黙って破棄する
+			{FeedID: "00000000-0000-0000-0000-000000000000", Succeeded: true, AttemptedAt: now},
+		} {
+			if err := attempts.RecordFetchAttempt(ctx, a); err != nil {
+				t.Fatalf("RecordFetchAttempt() error = %v", err)
+			}
+		}
+
+		if err := statsRepo.RefreshAdminStats(ctx); err != nil {
+			t.Fatalf("RefreshAdminStats() error = %v", err)
+		}
+		stats, err := statsRepo.GetAdminStats(ctx)
+		if err != nil {
+			t.Fatalf("GetAdminStats() error = %v", err)
+		}
+		if stats.UserCount != 1 || stats.FeedCount != 1 || stats.ItemCount != 3 {
+			t.Errorf("counts = (%d, %d, %d), want (1, 1, 3)", stats.UserCount, stats.FeedCount, stats.ItemCount)
+		}
+		if stats.FetchAttempts24h != 2 || stats.FetchSuccesses24h != 1 || stats.AvgFetchDurationMs24h != 200 {
+			t.Errorf("fetch = (%d, %d, %v), want (2, 1, 200)", stats.FetchAttempts24h, stats.FetchSuccesses24h, stats.AvgFetchDurationMs24h)
+		}
+		if stats.DatabaseSizeBytes <= 0 {
+			t.Errorf("DatabaseSizeBytes = %d, want > 0", stats.DatabaseSizeBytes)
+		}
+	})
+
+	t.Run("保持期間を過ぎたフェッチ結果を削除する", func(t *testing.T) {
+		deleted, err := attempts.DeleteFetchAttemptsBefore(ctx, now.Add(-24*time.Hour))
+		if err != nil {
+			t.Fatalf("DeleteFetchAttemptsBefore() error = %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("deleted = %d, want 1", deleted)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/lib/pq"
+)
+
+// PostgresAPIUsageRepo は PostgreSQL を使用した API 利用量カウンタリポジトリ。
+type PostgresAPIUsageRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIUsageRepo は PostgresAPIUsageRepo を生成する。
+func NewPostgresAPIUsageRepo(db *sql.DB) *PostgresAPIUsageRepo {
+	return &PostgresAPIUsageRepo{db: db}
+}
+
+// AddUsage は usages のリクエスト数・エラー数を既存のカウンタに加算する（無ければ作成する）。
+// 複数の API サーバーが同じ行に加算しても欠損しないよう、ON CONFLICT で加算する。
+// usages は (UserID, Date, Client) が重複しないこと（1 文の中で同じ行を 2 回更新できないため）。
+// users との JOIN により、集計から保存までの間に退会したユーザーの利用量は外部キー違反にせず破棄する。
+func (r *PostgresAPIUsageRepo) AddUsage(ctx context.Context, usages []model.APIUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+	userIDs := make([]string, len(usages))
+	dates := make([]string, len(usages))
+	clients := make([]string, len(usages))
+	requests := make([]int64, len(usages))
+	errs := make([]int64, len(usages))
+	for i, u := range usages {
+		userIDs[i], dates[i], clients[i] = u.UserID, u.Date.UTC().Format(time.DateOnly), u.Client
+		requests[i], errs[i] = u.RequestCount, u.ErrorCount
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO api_usage_daily (user_id, usage_date, client, request_count, error_count)
+		 SELECT u.user_id, u.usage_date, u.client, u.request_count, u.error_count
+		   FROM unnest($1::uuid[], $2::date[], $3::text[], $4::bigint[], $5::bigint[])
+		        AS u(user_id, usage_date, client, request_count, error_count)
+		   JOIN users ON users.id = u.user_id
+		 ON CONFLICT (user_id, usage_date, client) DO UPDATE
+		    SET request_count = api_usage_daily.request_count + EXCLUDED.request_count,
+		        error_count = api_usage_daily.error_count + EXCLUDED.error_count`,
+		pq.Array(userIDs), pq.Array(dates), pq.Array(clients), pq.Array(requests), pq.Array(errs),
+	)
+	if err != nil {
+		return fmt.Errorf("API 利用量の記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ListUsageByUser は集計日が since 以降の当該ユーザーの利用量を日付・クライアント名の昇順で返す。
+func (r *PostgresAPIUsageRepo) ListUsageByUser(ctx context.Context, userID string, since time.Time) ([]model.APIUsage, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT usage_date, client, request_count, error_count
+		   FROM api_usage_daily
+		  WHERE user_id = $1 AND usage_date >= $2::date
+		  ORDER BY usage_date, client`,
+		userID, since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("API 利用量の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var usages []model.APIUsage
+	for rows.Next() {
+		u := model.APIUsage{UserID: userID}
+		if err := rows.Scan(&u.Date, &u.Client, &u.RequestCount, &u.ErrorCount); err != nil {
+			return nil, fmt.Errorf("API 利用量の読み取りに失敗しました: %w", err)
+		}
+		u.Date = model.UsageDate(u.Date)
+		usages = append(usages, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("API 利用量の読み取りに失敗しました: %w", err)
+	}
+	return usages, nil
+}
+
+// DeleteUsageBefore は集計日が before より前の利用量を削除し、削除件数を返す。
+func (r *PostgresAPIUsageRepo) DeleteUsageBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM api_usage_daily WHERE usage_date < $1::date`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("API 利用量の削除に失敗しました: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("削除件数の取得に失敗しました: %w", err)
+	}
+	return n, nil
+}
+
+// compile-time interface check
+var _ APIUsageRepository = (*PostgresAPIUsageRepo)(nil)
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresAuditLogRepo は PostgreSQL を使用した監査ログリポジトリ。
+type PostgresAuditLogRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresAuditLogRepo は PostgresAuditLogRepo を生成する。
+func NewPostgresAuditLogRepo(db *sql.DB) *PostgresAuditLogRepo {
+	return &PostgresAuditLogRepo{db: db}
+}
+
+// Create は監査ログを 1 件保存し、採番された ID と保存日時を log に書き戻す。
+// CreatedAt がゼロ値の場合は DB の now() を用いる。
+func (r *PostgresAuditLogRepo) Create(ctx context.Context, log *model.AuditLog) error {
+	payload := log.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("監査ログの payload のエンコードに失敗しました: %w", err)
+	}
+
+	var createdAt sql.NullTime
+	if !log.CreatedAt.IsZero() {
+		createdAt = sql.NullTime{Time: log.CreatedAt.UTC(), Valid: true}
+	}
+
+	err = r.db.QueryRowContext(ctx,
+		`INSERT INTO audit_logs (user_id, action, target, payload, created_at)
+		 VALUES ($1, $2, $3, $4, COALESCE($5, now()))
+		 RETURNING id, created_at`,
+		log.UserID, string(log.Action), log.Target, payloadJSON, createdAt,
+	).Scan(&log.ID, &log.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("監査ログの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ListByUser は当該ユーザーの監査ログを (created_at, id) の降順で最大 limit 件返す。
+// cursorCreatedAt がゼロ値でない場合は (cursorCreatedAt, cursorID) より古いものだけを返す。
+func (r *PostgresAuditLogRepo) ListByUser(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.AuditLog, error) {
+	query := `SELECT id, user_id, action, target, payload, created_at
+		 FROM audit_logs
+		 WHERE user_id = $1`
+	args := []interface{}{userID}
+	if !cursorCreatedAt.IsZero() {
+		query += ` AND (created_at, id) < ($2, $3::uuid)`
+		args = append(args, cursorCreatedAt, cursorID)
+	}
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("監査ログの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []model.AuditLog
+	for rows.Next() {
+		var l model.AuditLog
+		var action string
+		var payloadJSON []byte
+		if err := rows.Scan(&l.ID, &l.UserID, &action, &l.Target, &payloadJSON, &l.CreatedAt); err != nil {
+			return nil, fmt.Errorf("監査ログの行読み取りに失敗しました: %w", err)
+		}
+		l.Action = model.AuditAction(action)
+		if err := json.Unmarshal(payloadJSON, &l.Payload); err != nil {
+			return nil, fmt.Errorf("監査ログの payload のデコードに失敗しました: %w", err)
+		}
+		logs = append(logs, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("監査ログの走査に失敗しました: %w", err)
+	}
+
+	return logs, nil
+}
+
+// compile-time interface check
+var _ AuditLogRepository = (*PostgresAuditLogRepo)(nil)
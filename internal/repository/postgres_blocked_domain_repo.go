@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/lib/pq"
+)
+
+// PostgresBlockedDomainRepo は PostgreSQL を使用したモデレーション用ブロックリストのリポジトリ。
+type PostgresBlockedDomainRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresBlockedDomainRepo は PostgresBlockedDomainRepo を生成する。
+func NewPostgresBlockedDomainRepo(db *sql.DB) *PostgresBlockedDomainRepo {
+	return &PostgresBlockedDomainRepo{db: db}
+}
+
+// List はブロックリストの全項目を追加日時の昇順で返す。
+func (r *PostgresBlockedDomainRepo) List(ctx context.Context) ([]model.BlockedDomain, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, pattern, kind, reason, created_by, created_at
+		 FROM blocked_domains
+		 ORDER BY created_at ASC, id ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ブロックリストの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var result []model.BlockedDomain
+	for rows.Next() {
+		var b model.BlockedDomain
+		var kind string
+		var createdBy sql.NullString
+		if err := rows.Scan(&b.ID, &b.Pattern, &kind, &b.Reason, &createdBy, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ブロックリストの読み取りに失敗しました: %w", err)
+		}
+		b.Kind = model.BlockKind(kind)
+		b.CreatedBy = nullStringValue(createdBy)
+		result = append(result, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ブロックリストの読み取りに失敗しました: %w", err)
+	}
+	return result, nil
+}
+
+// Create はブロックリストに項目を追加する。同じ Pattern の項目が既にある場合は種類と理由を更新し、
+// 既存の ID・追加日時を書き戻す（同じドメインの再追加を冪等にする）。
+func (r *PostgresBlockedDomainRepo) Create(ctx context.Context, blocked *model.BlockedDomain) error {
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO blocked_domains (pattern, kind, reason, created_by)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (pattern) DO UPDATE SET kind = EXCLUDED.kind, reason = EXCLUDED.reason
+		 RETURNING id, created_at`,
+		blocked.Pattern, string(blocked.Kind), blocked.Reason, nullString(blocked.CreatedBy),
+	).Scan(&blocked.ID, &blocked.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ブロックリストへの追加に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Delete はブロックリストの項目を削除する。対象が無い場合は false を返す。
+func (r *PostgresBlockedDomainRepo) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM blocked_domains WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("ブロックリストからの削除に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("削除結果の取得に失敗しました: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// StopMatchingFeeds は blocked に一致するフィードのフェッチを停止し、停止した件数を返す。
+//
+// 候補は feed_host 関数（先頭の "www." を除いたホスト）で絞り込み、最終的な一致判定は
+// model.BlockedDomain.Matches で行う（登録時の判定と同じ規則で遡及適用するため）。
+// 停止したフィードは error_kind を blocked とし、購読者によるフェッチ再開はブロックの解除まで拒否される。
+func (r *PostgresBlockedDomainRepo) StopMatchingFeeds(ctx context.Context, blocked model.BlockedDomain, message string) (int, error) {
+	host := blocked.Pattern
+	if blocked.Kind == model.BlockKindURL {
+		host = feedHostOf(blocked.Pattern)
+	}
+	host = strings.TrimPrefix(host, "www.")
+	if host == "" {
+		return 0, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, feed_url
+		 FROM feeds
+		 WHERE (feed_host(feed_url) = $1 OR feed_host(feed_url) LIKE '%.' || $1)
+		   AND NOT (fetch_status = $2 AND error_kind IS NOT DISTINCT FROM $3)`,
+		host, string(model.FetchStatusStopped), string(model.FetchErrorKindBlocked),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("ブロック対象のフィードの検索に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var feedIDs []string
+	for rows.Next() {
+		var id, feedURL string
+		if err := rows.Scan(&id, &feedURL); err != nil {
+			return 0, fmt.Errorf("ブロック対象のフィードの読み取りに失敗しました: %w", err)
+		}
+		if blocked.Matches(feedURL) {
+			feedIDs = append(feedIDs, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("ブロック対象のフィードの読み取りに失敗しました: %w", err)
+	}
+	if len(feedIDs) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE feeds
+		 SET fetch_status = $2, error_kind = $3, error_message = $4
+		 WHERE id = ANY($1)`,
+		pq.Array(feedIDs), string(model.FetchStatusStopped), string(model.FetchErrorKindBlocked), message,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("ブロック対象のフィードの停止に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+	}
+	return int(rowsAffected), nil
+}
+
+// feedHostOf は URL のホスト名を小文字で返す。解釈できない場合は空文字を返す。
+func feedHostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+var _ BlockedDomainRepository = (*PostgresBlockedDomainRepo)(nil)
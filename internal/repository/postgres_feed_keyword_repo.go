@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/lib/pq"
+)
+
+// PostgresFeedKeywordRepo は PostgreSQL を使用した記事傾向サマリーの頻出キーワードのリポジトリ。
+type PostgresFeedKeywordRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresFeedKeywordRepo は PostgresFeedKeywordRepo を生成する。
+func NewPostgresFeedKeywordRepo(db *sql.DB) *PostgresFeedKeywordRepo {
+	return &PostgresFeedKeywordRepo{db: db}
+}
+
+// ListSubscribedFeedIDs は購読者が 1 人以上いるフィードの ID を返す。
+func (r *PostgresFeedKeywordRepo) ListSubscribedFeedIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT DISTINCT feed_id FROM subscriptions ORDER BY feed_id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("購読フィードの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var feedIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("購読フィードの読み取りに失敗しました: %w", err)
+		}
+		feedIDs = append(feedIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("購読フィードの読み取りに失敗しました: %w", err)
+	}
+	return feedIDs, nil
+}
+
+// ListItemTextsSince はフィードの公開日時（未設定の記事は取り込み日時）が since 以降の記事の
+// タイトルと本文テキスト（content_text）を改行で連結したテキストを返す。
+func (r *PostgresFeedKeywordRepo) ListItemTextsSince(ctx context.Context, feedID string, since time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT title || E'\n' || COALESCE(content_text, '')
+		   FROM items
+		  WHERE feed_id = $1 AND COALESCE(published_at, created_at) >= $2`,
+		feedID, since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("記事テキストの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var texts []string
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return nil, fmt.Errorf("記事テキストの読み取りに失敗しました: %w", err)
+		}
+		texts = append(texts, text)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("記事テキストの読み取りに失敗しました: %w", err)
+	}
+	return texts, nil
+}
+
+// ReplaceFeedKeywords はフィードの頻出キーワードを keywords で置き換える。keywords が空の場合は削除のみを行う。
+// 削除と挿入は 1 トランザクションで行い、計算中も前回の結果を返せるようにする。
+func (r *PostgresFeedKeywordRepo) ReplaceFeedKeywords(ctx context.Context, feedID string, keywords []model.FeedKeyword, computedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM feed_keywords WHERE feed_id = $1`, feedID); err != nil {
+		return fmt.Errorf("頻出キーワードの削除に失敗しました: %w", err)
+	}
+
+	if len(keywords) > 0 {
+		words := make([]string, len(keywords))
+		counts := make([]int64, len(keywords))
+		for i, k := range keywords {
+			words[i] = k.Keyword
+			counts[i] = int64(k.Count)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO feed_keywords (feed_id, keyword, count, computed_at)
+			 SELECT $1, k.keyword, k.count, $4
+			   FROM unnest($2::text[], $3::int[]) AS k(keyword, count)`,
+			feedID, pq.Array(words), pq.Array(counts), computedAt,
+		); err != nil {
+			return fmt.Errorf("頻出キーワードの保存に失敗しました: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// GetBySubscription は当該ユーザーの購読のフィードの頻出キーワードを出現回数の多い順（同数はキーワード順）に返す。
+// 購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+func (r *PostgresFeedKeywordRepo) GetBySubscription(ctx context.Context, userID, subscriptionID string) (*model.FeedKeywordSummary, error) {
+	summary := &model.FeedKeywordSummary{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT feed_id FROM subscriptions WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID,
+	).Scan(&summary.FeedID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("購読の取得に失敗しました: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT keyword, count, computed_at
+		   FROM feed_keywords
+		  WHERE feed_id = $1
+		  ORDER BY count DESC, keyword`,
+		summary.FeedID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("頻出キーワードの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var k model.FeedKeyword
+		var computedAt time.Time
+		if err := rows.Scan(&k.Keyword, &k.Count, &computedAt); err != nil {
+			return nil, fmt.Errorf("頻出キーワードの読み取りに失敗しました: %w", err)
+		}
+		computedAt = computedAt.UTC()
+		summary.ComputedAt = &computedAt
+		summary.Keywords = append(summary.Keywords, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("頻出キーワードの読み取りに失敗しました: %w", err)
+	}
+	return summary, nil
+}
+
+// compile-time interface check
+var _ FeedKeywordRepository = (*PostgresFeedKeywordRepo)(nil)
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介した頻出キーワードの事前計算の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresFeedKeywordRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "keyword-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "keyword-other@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/keyword.xml", "Keyword Feed", nil)
+	unsubscribedFeedID := insertTestFeedForSub(t, db, "https://example.com/keyword-none.xml", "No Subscribers", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+	var subID string
+	if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1 AND feed_id = $2`, userID, feedID).Scan(&subID); err != nil {
+		t.Fatalf("購読 ID の取得に失敗: %v", err)
+	}
+
+	now := time.Now().UTC()
+	recent := insertTestItem(t, db, feedID, "新しい記事", "", now.Add(-24*time.Hour))
+	insertTestItem(t, db, feedID, "古い記事", "", now.Add(-40*24*time.Hour))
+	if _, err := db.Exec(`UPDATE items SET content_text = '本文のテキスト' WHERE id = $1`, recent); err != nil {
+		t.Fatalf("content_text の更新に失敗: %v", err)
+	}
+
+	repo := NewPostgresFeedKeywordRepo(db)
+
+	t.Run("購読者のいるフィードだけを返す", func(t *testing.T) {
+		ids, err := repo.ListSubscribedFeedIDs(ctx)
+		if err != nil {
+			t.Fatalf("ListSubscribedFeedIDs() error = %v", err)
+		}
+		if len(ids) != 1 || ids[0] != feedID {
+			t.Errorf("ids = %v, want [%s]（%s を含まない）", ids, feedID, unsubscribedFeedID)
+		}
+	})
+
+	t.Run("期間内の記事のタイトルと本文テキストを返す", func(t *testing.T) {
+		texts, err := repo.ListItemTextsSince(ctx, feedID, now.Add(-model.FeedKeywordWindow))
+		if err != nil {
+			t.Fatalf("ListItemTextsSince() error = %v", err)
+		}
+		if len(texts) != 1 || texts[0] != "新しい記事\n本文のテキスト" {
+			t.Errorf("texts = %q", texts)
+		}
+	})
+
+	t.Run("保存したキーワードを出現回数の多い順に返し再計算で置き換える", func(t *testing.T) {
+		keywords := []model.FeedKeyword{{Keyword: "記事", Count: 2}, {Keyword: "go", Count: 5}}
+		if err := repo.ReplaceFeedKeywords(ctx, feedID, keywords, now); err != nil {
+			t.Fatalf("ReplaceFeedKeywords() error = %v", err)
+		}
+		got, err := repo.GetBySubscription(ctx, userID, subID)
+		if err != nil || got == nil {
+			t.Fatalf("GetBySubscription() = (%v, %v)", got, err)
+		}
+		if got.FeedID != feedID || len(got.Keywords) != 2 || got.Keywords[0].Keyword != "go" || got.ComputedAt == nil {
+			t.Errorf("got = %+v", got)
+		}
+
+		if err := repo.ReplaceFeedKeywords(ctx, feedID, nil, now); err != nil {
+			t.Fatalf("ReplaceFeedKeywords(nil) error = %v", err)
+		}
+		got, err = repo.GetBySubscription(ctx, userID, subID)
+		if err != nil || got == nil || len(got.Keywords) != 0 || got.ComputedAt != nil {
+			t.Errorf("GetBySubscription() = (%+v, %v), want 空のサマリー", got, err)
+		}
+	})
+
+	t.Run("他ユーザーの購読のときnilを返す", func(t *testing.T) {
+		got, err := repo.GetBySubscription(ctx, otherID, subID)
+		if err != nil || got != nil {
+			t.Errorf("GetBySubscription() = (%+v, %v), want (nil, nil)", got, err)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresFeedRawCaptureRepo は PostgreSQL を使用したフェッチレスポンス保存（デバッグ用）のリポジトリ。
+type PostgresFeedRawCaptureRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresFeedRawCaptureRepo は PostgresFeedRawCaptureRepo を生成する。
+func NewPostgresFeedRawCaptureRepo(db *sql.DB) *PostgresFeedRawCaptureRepo {
+	return &PostgresFeedRawCaptureRepo{db: db}
+}
+
+// SetCaptureRawResponse はフェッチレスポンスの保存の有効・無効を更新する。
+// 無効にした場合は同じ文で保存済みのレスポンスも削除する。対象フィードが存在しない場合は false を返す。
+func (r *PostgresFeedRawCaptureRepo) SetCaptureRawResponse(ctx context.Context, feedID string, enabled bool) (bool, error) {
+	var updated int
+	err := r.db.QueryRowContext(ctx,
+		`WITH updated AS (
+		     UPDATE feeds SET capture_raw_response = $2 WHERE id = $1 RETURNING id
+		 ), deleted AS (
+		     DELETE FROM feed_raw_captures
+		      WHERE NOT $2 AND feed_id IN (SELECT id FROM updated)
+		 )
+		 SELECT COUNT(*) FROM updated`,
+		feedID, enabled,
+	).Scan(&updated)
+	if err != nil {
+		return false, fmt.Errorf("フェッチレスポンス保存設定の更新に失敗しました: %w", err)
+	}
+	return updated > 0, nil
+}
+
+// SaveRawCapture はフィードの直近のフェッチレスポンスを上書き保存する。
+// フィードが削除済みの場合は外部キー制約によりエラーになる。
+func (r *PostgresFeedRawCaptureRepo) SaveRawCapture(ctx context.Context, capture *model.FeedRawCapture) error {
+	headers, err := json.Marshal(capture.Header)
+	if err != nil {
+		return fmt.Errorf("レスポンスヘッダーのエンコードに失敗しました: %w", err)
+	}
+	body := capture.Body
+	if body == nil {
+		body = []byte{}
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO feed_raw_captures (feed_id, fetched_at, request_url, status_code, proto, headers, body, body_truncated)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (feed_id) DO UPDATE SET
+		     fetched_at = EXCLUDED.fetched_at,
+		     request_url = EXCLUDED.request_url,
+		     status_code = EXCLUDED.status_code,
+		     proto = EXCLUDED.proto,
+		     headers = EXCLUDED.headers,
+		     body = EXCLUDED.body,
+		     body_truncated = EXCLUDED.body_truncated`,
+		capture.FeedID, capture.FetchedAt.UTC(), capture.RequestURL, capture.StatusCode,
+		capture.Proto, headers, body, capture.BodyTruncated,
+	)
+	if err != nil {
+		return fmt.Errorf("フェッチレスポンスの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// FindRawCapture はフィードの直近のフェッチレスポンスを返す。保存されていない場合は nil を返す。
+func (r *PostgresFeedRawCaptureRepo) FindRawCapture(ctx context.Context, feedID string) (*model.FeedRawCapture, error) {
+	capture := &model.FeedRawCapture{}
+	var headers []byte
+
+	err := r.db.QueryRowContext(ctx,
+		`SELECT feed_id, fetched_at, request_url, status_code, proto, headers, body, body_truncated
+		 FROM feed_raw_captures WHERE feed_id = $1`,
+		feedID,
+	).Scan(
+		&capture.FeedID, &capture.FetchedAt, &capture.RequestURL, &capture.StatusCode,
+		&capture.Proto, &headers, &capture.Body, &capture.BodyTruncated,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("フェッチレスポンスの取得に失敗しました: %w", err)
+	}
+
+	if err := json.Unmarshal(headers, &capture.Header); err != nil {
+		return nil, fmt.Errorf("レスポンスヘッダーのデコードに失敗しました: %w", err)
+	}
+	capture.FetchedAt = capture.FetchedAt.UTC()
+	return capture, nil
+}
+
+// compile-time interface check
+var _ FeedRawCaptureRepository = (*PostgresFeedRawCaptureRepo)(nil)
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresFeedRegistrationBatchRepo は PostgreSQL を使用した URL の一括登録のリポジトリ。
+type PostgresFeedRegistrationBatchRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresFeedRegistrationBatchRepo は PostgresFeedRegistrationBatchRepo を生成する。
+func NewPostgresFeedRegistrationBatchRepo(db *sql.DB) *PostgresFeedRegistrationBatchRepo {
+	return &PostgresFeedRegistrationBatchRepo{db: db}
+}
+
+// feedRegistrationBatchEntryJSON は entries 列に保存する URL 1 件分の結果。
+type feedRegistrationBatchEntryJSON struct {
+	URL          string `json:"url"`
+	Status       string `json:"status"`
+	FeedID       string `json:"feed_id,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// toFeedRegistrationBatchEntryJSON は entry を保存形式に変換する。エラーはコードとメッセージのみ保存する。
+func toFeedRegistrationBatchEntryJSON(entry model.FeedRegistrationBatchEntry) feedRegistrationBatchEntryJSON {
+	e := feedRegistrationBatchEntryJSON{URL: entry.URL, Status: string(entry.Status), FeedID: entry.FeedID}
+	if entry.Error != nil {
+		e.ErrorCode = entry.Error.Code
+		e.ErrorMessage = entry.Error.Message
+	}
+	return e
+}
+
+// Create は一括登録を作成する。同じユーザーの expiredBefore より前に作成した一括登録の削除と作成は同一トランザクションで行う。
+func (r *PostgresFeedRegistrationBatchRepo) Create(ctx context.Context, batch *model.FeedRegistrationBatch, expiredBefore time.Time) error {
+	entries := make([]feedRegistrationBatchEntryJSON, len(batch.Entries))
+	for i, entry := range batch.Entries {
+		entries[i] = toFeedRegistrationBatchEntryJSON(entry)
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("一括登録の結果のエンコードに失敗しました: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM feed_registration_batches WHERE user_id = $1 AND created_at < $2`,
+		batch.UserID, expiredBefore,
+	); err != nil {
+		return fmt.Errorf("照会期間を過ぎた一括登録の削除に失敗しました: %w", err)
+	}
+	if err := tx.QueryRowContext(ctx,
+		`INSERT INTO feed_registration_batches (user_id, entries)
+		 VALUES ($1, $2)
+		 RETURNING id, created_at`,
+		batch.UserID, data,
+	).Scan(&batch.ID, &batch.CreatedAt); err != nil {
+		return fmt.Errorf("一括登録の作成に失敗しました: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// UpdateEntry は一括登録の index 番目の URL の結果を entry で置き換える。
+// 他の URL の結果を並行して書き換えても失われないよう、配列全体ではなく要素単位で更新する。
+func (r *PostgresFeedRegistrationBatchRepo) UpdateEntry(ctx context.Context, batchID string, index int, entry model.FeedRegistrationBatchEntry) error {
+	data, err := json.Marshal(toFeedRegistrationBatchEntryJSON(entry))
+	if err != nil {
+		return fmt.Errorf("一括登録の結果のエンコードに失敗しました: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE feed_registration_batches
+		 SET entries = jsonb_set(entries, ARRAY[$2]::text[], $3::jsonb)
+		 WHERE id = $1`,
+		batchID, strconv.Itoa(index), data,
+	); err != nil {
+		return fmt.Errorf("一括登録の結果の更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Complete は一括登録を completedAt に処理完了として記録する。
+func (r *PostgresFeedRegistrationBatchRepo) Complete(ctx context.Context, batchID string, completedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE feed_registration_batches SET completed_at = $2 WHERE id = $1`,
+		batchID, completedAt,
+	); err != nil {
+		return fmt.Errorf("一括登録の完了の記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// FindByUser は当該ユーザーの一括登録を返す。存在しない場合と batchID が UUID 形式でない場合は nil を返す。
+func (r *PostgresFeedRegistrationBatchRepo) FindByUser(ctx context.Context, userID, batchID string) (*model.FeedRegistrationBatch, error) {
+	var (
+		batch       model.FeedRegistrationBatch
+		data        []byte
+		completedAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, user_id, entries, created_at, completed_at
+		 FROM feed_registration_batches
+		 WHERE user_id = $1 AND id::text = $2`,
+		userID, batchID,
+	).Scan(&batch.ID, &batch.UserID, &data, &batch.CreatedAt, &completedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("一括登録の取得に失敗しました: %w", err)
+	}
+
+	var entries []feedRegistrationBatchEntryJSON
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("一括登録の結果のデコードに失敗しました: %w", err)
+	}
+	batch.Entries = make([]model.FeedRegistrationBatchEntry, len(entries))
+	for i, e := range entries {
+		batch.Entries[i] = model.FeedRegistrationBatchEntry{
+			URL:    e.URL,
+			Status: model.FeedRegistrationStatus(e.Status),
+			FeedID: e.FeedID,
+		}
+		if e.ErrorCode != "" {
+			batch.Entries[i].Error = &model.APIError{Code: e.ErrorCode, Message: e.ErrorMessage}
+		}
+	}
+	batch.CompletedAt = nullTimeValue(completedAt)
+	return &batch, nil
+}
+
+// compile-time interface check
+var _ FeedRegistrationBatchRepository = (*PostgresFeedRegistrationBatchRepo)(nil)
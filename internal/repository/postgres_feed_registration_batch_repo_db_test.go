@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	_ "github.com/lib/pq"
+)
+
+// TestPostgresFeedRegistrationBatchRepo は一括登録の作成・URL ごとの結果の更新・完了の記録と照会期間の扱いを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresFeedRegistrationBatchRepo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("URL ごとの結果を書き換えて完了を記録でき他ユーザーからは見えない", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresFeedRegistrationBatchRepo(db)
+
+		// Arrange
+		userA := insertTestUser(t, db, "batch-a@example.com")
+		userB := insertTestUser(t, db, "batch-b@example.com")
+		batch := &model.FeedRegistrationBatch{
+			UserID: userA,
+			Entries: []model.FeedRegistrationBatchEntry{
+				{URL: "https://a.example.com/", Status: model.FeedRegistrationPending},
+				{URL: "https://b.example.com/", Status: model.FeedRegistrationPending},
+			},
+		}
+		if err := repo.Create(ctx, batch, time.Now().Add(-model.FeedRegistrationBatchRetention)); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+
+		// Act
+		if err := repo.UpdateEntry(ctx, batch.ID, 1, model.FeedRegistrationBatchEntry{
+			URL:    "https://b.example.com/",
+			Status: model.FeedRegistrationNotDetected,
+			Error:  model.NewFeedNotDetectedError("https://b.example.com/"),
+		}); err != nil {
+			t.Fatalf("UpdateEntry returned error: %v", err)
+		}
+		completedAt := time.Now().UTC().Truncate(time.Second)
+		if err := repo.Complete(ctx, batch.ID, completedAt); err != nil {
+			t.Fatalf("Complete returned error: %v", err)
+		}
+		got, err := repo.FindByUser(ctx, userA, batch.ID)
+
+		// Assert
+		if err != nil || got == nil {
+			t.Fatalf("FindByUser = (%v, %v), want batch", got, err)
+		}
+		if len(got.Entries) != 2 || got.Entries[0].Status != model.FeedRegistrationPending {
+			t.Fatalf("entries = %+v, want 2 entries with first pending", got.Entries)
+		}
+		if e := got.Entries[1]; e.Status != model.FeedRegistrationNotDetected || e.Error == nil || e.Error.Code != model.ErrCodeFeedNotDetected {
+			t.Errorf("entries[1] = %+v, want not_detected with FEED_NOT_DETECTED", e)
+		}
+		if got.CompletedAt == nil || !got.CompletedAt.Equal(completedAt) {
+			t.Errorf("CompletedAt = %v, want %v", got.CompletedAt, completedAt)
+		}
+		if other, err := repo.FindByUser(ctx, userB, batch.ID); err != nil || other != nil {
+			t.Errorf("FindByUser(userB) = (%v, %v), want nil", other, err)
+		}
+		if invalid, err := repo.FindByUser(ctx, userA, "not-a-uuid"); err != nil || invalid != nil {
+			t.Errorf("FindByUser(not-a-uuid) = (%v, %v), want nil", invalid, err)
+		}
+	})
+
+	t.Run("作成時に照会期間を過ぎた同じユーザーの一括登録を削除する", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresFeedRegistrationBatchRepo(db)
+
+		// Arrange
+		userID := insertTestUser(t, db, "batch-expire@example.com")
+		old := &model.FeedRegistrationBatch{UserID: userID, Entries: []model.FeedRegistrationBatchEntry{{URL: "https://old.example.com/"}}}
+		if err := repo.Create(ctx, old, time.Now().Add(-time.Hour)); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+
+		// Act: old の作成日時より後を期限として次の一括登録を作成する
+		next := &model.FeedRegistrationBatch{UserID: userID, Entries: []model.FeedRegistrationBatchEntry{{URL: "https://new.example.com/"}}}
+		if err := repo.Create(ctx, next, time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+
+		// Assert
+		if got, err := repo.FindByUser(ctx, userID, old.ID); err != nil || got != nil {
+			t.Errorf("FindByUser(old) = (%v, %v), want deleted", got, err)
+		}
+		if got, err := repo.FindByUser(ctx, userID, next.ID); err != nil || got == nil {
+			t.Errorf("FindByUser(next) = (%v, %v), want batch", got, err)
+		}
+	})
+}
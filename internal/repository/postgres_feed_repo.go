@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -35,21 +36,28 @@ func NewPostgresFeedRepo(db *sql.DB) *PostgresFeedRepo {
 func (r *PostgresFeedRepo) FindByID(ctx context.Context, id string) (*model.Feed, error) {
 	feed := &model.Feed{}
 	var faviconData []byte
-	var faviconMime, siteURL, etag, lastModified, errorMessage sql.NullString
-	var lastSuccessfulFetchAt sql.NullTime
+	var faviconMime, siteURL, etag, lastModified, errorMessage, errorKind, language, description, httpVersion, copyright, robots sql.NullString
+	var lastSuccessfulFetchAt, lastPublishedAt sql.NullTime
+	var ttlMinutes sql.NullInt64
+	var errorDetail []byte
 
 	err := r.db.QueryRowContext(ctx,
 		`SELECT id, feed_url, site_url, title, favicon_data, favicon_mime,
 		        etag, last_modified, fetch_status, consecutive_errors,
-		        error_message, next_fetch_at, last_successful_fetch_at,
-		        created_at, updated_at
+		        error_message, error_kind, next_fetch_at, last_successful_fetch_at,
+		        language, description, last_published_at, ignore_conditional_get, capture_raw_response,
+		        error_detail, http_version,
+		        copyright, ttl_minutes, robots, created_at, updated_at
 		 FROM feeds WHERE id = $1`,
 		id,
 	).Scan(
 		&feed.ID, &feed.FeedURL, &siteURL, &feed.Title,
 		&faviconData, &faviconMime,
 		&etag, &lastModified, &feed.FetchStatus, &feed.ConsecutiveErrors,
-		&errorMessage, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+		&errorMessage, &errorKind, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+		&language, &description, &lastPublishedAt, &feed.IgnoreConditionalGet, &feed.CaptureRawResponse,
+		&errorDetail, &httpVersion,
+		&copyright, &ttlMinutes, &robots,
 		&feed.CreatedAt, &feed.UpdatedAt,
 	)
 
@@ -66,7 +74,20 @@ func (r *PostgresFeedRepo) FindByID(ctx context.Context, id string) (*model.Feed
 	feed.ETag = nullStringValue(etag)
 	feed.LastModified = nullStringValue(lastModified)
 	feed.ErrorMessage = nullStringValue(errorMessage)
+	feed.ErrorKind = model.FetchErrorKind(nullStringValue(errorKind))
 	feed.LastSuccessfulFetchAt = nullTimeValue(lastSuccessfulFetchAt)
+	feed.Language = nullStringValue(language)
+	feed.Description = nullStringValue(description)
+	feed.LastPublishedAt = nullTimeValue(lastPublishedAt)
+	feed.HTTPVersion = nullStringValue(httpVersion)
+	feed.Copyright = nullStringValue(copyright)
+	feed.TTLMinutes = int(ttlMinutes.Int64)
+	feed.Robots = nullStringValue(robots)
+	detail, err := decodeFetchErrorDetail(errorDetail)
+	if err != nil {
+		return nil, err
+	}
+	feed.ErrorDetail = detail
 
 	return feed, nil
 }
@@ -75,21 +96,28 @@ func (r *PostgresFeedRepo) FindByID(ctx context.Context, id string) (*model.Feed
 func (r *PostgresFeedRepo) FindByFeedURL(ctx context.Context, feedURL string) (*model.Feed, error) {
 	feed := &model.Feed{}
 	var faviconData []byte
-	var faviconMime, siteURL, etag, lastModified, errorMessage sql.NullString
-	var lastSuccessfulFetchAt sql.NullTime
+	var faviconMime, siteURL, etag, lastModified, errorMessage, errorKind, language, description, httpVersion, copyright, robots sql.NullString
+	var lastSuccessfulFetchAt, lastPublishedAt sql.NullTime
+	var ttlMinutes sql.NullInt64
+	var errorDetail []byte
 
 	err := r.db.QueryRowContext(ctx,
 		`SELECT id, feed_url, site_url, title, favicon_data, favicon_mime,
 		        etag, last_modified, fetch_status, consecutive_errors,
-		        error_message, next_fetch_at, last_successful_fetch_at,
-		        created_at, updated_at
+		        error_message, error_kind, next_fetch_at, last_successful_fetch_at,
+		        language, description, last_published_at, ignore_conditional_get, capture_raw_response,
+		        error_detail, http_version,
+		        copyright, ttl_minutes, robots, created_at, updated_at
 		 FROM feeds WHERE feed_url = $1`,
 		feedURL,
 	).Scan(
 		&feed.ID, &feed.FeedURL, &siteURL, &feed.Title,
 		&faviconData, &faviconMime,
 		&etag, &lastModified, &feed.FetchStatus, &feed.ConsecutiveErrors,
-		&errorMessage, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+		&errorMessage, &errorKind, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+		&language, &description, &lastPublishedAt, &feed.IgnoreConditionalGet, &feed.CaptureRawResponse,
+		&errorDetail, &httpVersion,
+		&copyright, &ttlMinutes, &robots,
 		&feed.CreatedAt, &feed.UpdatedAt,
 	)
 
@@ -106,7 +134,20 @@ func (r *PostgresFeedRepo) FindByFeedURL(ctx context.Context, feedURL string) (*
 	feed.ETag = nullStringValue(etag)
 	feed.LastModified = nullStringValue(lastModified)
 	feed.ErrorMessage = nullStringValue(errorMessage)
+	feed.ErrorKind = model.FetchErrorKind(nullStringValue(errorKind))
 	feed.LastSuccessfulFetchAt = nullTimeValue(lastSuccessfulFetchAt)
+	feed.Language = nullStringValue(language)
+	feed.Description = nullStringValue(description)
+	feed.LastPublishedAt = nullTimeValue(lastPublishedAt)
+	feed.HTTPVersion = nullStringValue(httpVersion)
+	feed.Copyright = nullStringValue(copyright)
+	feed.TTLMinutes = int(ttlMinutes.Int64)
+	feed.Robots = nullStringValue(robots)
+	detail, err := decodeFetchErrorDetail(errorDetail)
+	if err != nil {
+		return nil, err
+	}
+	feed.ErrorDetail = detail
 
 	return feed, nil
 }
@@ -138,12 +179,13 @@ func (r *PostgresFeedRepo) Update(ctx context.Context, feed *model.Feed) error {
 		    feed_url = $2, site_url = $3, title = $4,
 		    etag = $5, last_modified = $6, fetch_status = $7,
 		    consecutive_errors = $8, error_message = $9,
-		    next_fetch_at = $10, updated_at = $11
+		    next_fetch_at = $10, error_kind = $11
 		 WHERE id = $1`,
 		feed.ID, feed.FeedURL, nullString(feed.SiteURL), feed.Title,
 		nullString(feed.ETag), nullString(feed.LastModified),
 		feed.FetchStatus, feed.ConsecutiveErrors,
-		nullString(feed.ErrorMessage), feed.NextFetchAt, feed.UpdatedAt,
+		nullString(feed.ErrorMessage), feed.NextFetchAt,
+		nullString(string(feed.ErrorKind)),
 	)
 	if err != nil {
 		return fmt.Errorf("フィードの更新に失敗しました: %w", err)
@@ -154,7 +196,7 @@ func (r *PostgresFeedRepo) Update(ctx context.Context, feed *model.Feed) error {
 // UpdateFavicon はフィードのfaviconデータを更新する。
 func (r *PostgresFeedRepo) UpdateFavicon(ctx context.Context, feedID string, faviconData []byte, faviconMime string) error {
 	_, err := r.db.ExecContext(ctx,
-		`UPDATE feeds SET favicon_data = $2, favicon_mime = $3, updated_at = now() WHERE id = $1`,
+		`UPDATE feeds SET favicon_data = $2, favicon_mime = $3 WHERE id = $1`,
 		feedID, faviconData, nullString(faviconMime),
 	)
 	if err != nil {
@@ -171,6 +213,14 @@ func nullString(s string) sql.NullString {
 	return sql.NullString{String: s, Valid: true}
 }
 
+// nullPositiveInt は 0 以下の値を NULL とする sql.NullInt64 に変換する。
+func nullPositiveInt(n int) sql.NullInt64 {
+	if n <= 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(n), Valid: true}
+}
+
 // nullStringValue はsql.NullStringから文字列を取得する。
 func nullStringValue(ns sql.NullString) string {
 	if ns.Valid {
@@ -189,6 +239,57 @@ func nullTimeValue(nt sql.NullTime) *time.Time {
 	return nil
 }
 
+// fetchErrorDetailRecord は feeds.error_detail（jsonb）の保存形式。
+type fetchErrorDetailRecord struct {
+	HTTPStatus    int        `json:"http_status,omitempty"`
+	TLSError      string     `json:"tls_error,omitempty"`
+	CertSubject   string     `json:"cert_subject,omitempty"`
+	CertIssuer    string     `json:"cert_issuer,omitempty"`
+	CertDNSNames  []string   `json:"cert_dns_names,omitempty"`
+	CertNotBefore *time.Time `json:"cert_not_before,omitempty"`
+	CertNotAfter  *time.Time `json:"cert_not_after,omitempty"`
+}
+
+// encodeFetchErrorDetail はフェッチ失敗の詳細を error_detail 列の値に変換する。nil の場合は NULL。
+func encodeFetchErrorDetail(detail *model.FetchErrorDetail) (sql.NullString, error) {
+	if detail == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(fetchErrorDetailRecord{
+		HTTPStatus:    detail.HTTPStatus,
+		TLSError:      string(detail.TLSError),
+		CertSubject:   detail.CertSubject,
+		CertIssuer:    detail.CertIssuer,
+		CertDNSNames:  detail.CertDNSNames,
+		CertNotBefore: detail.CertNotBefore,
+		CertNotAfter:  detail.CertNotAfter,
+	})
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("エラー詳細のエンコードに失敗しました: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// decodeFetchErrorDetail は error_detail 列の値をフェッチ失敗の詳細に変換する。NULL の場合は nil。
+func decodeFetchErrorDetail(data []byte) (*model.FetchErrorDetail, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var rec fetchErrorDetailRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("エラー詳細のデコードに失敗しました: %w", err)
+	}
+	return &model.FetchErrorDetail{
+		HTTPStatus:    rec.HTTPStatus,
+		TLSError:      model.TLSErrorType(rec.TLSError),
+		CertSubject:   rec.CertSubject,
+		CertIssuer:    rec.CertIssuer,
+		CertDNSNames:  rec.CertDNSNames,
+		CertNotBefore: rec.CertNotBefore,
+		CertNotAfter:  rec.CertNotAfter,
+	}, nil
+}
+
 // ListDueForFetch はフェッチ対象のフィードを取得する。
 // next_fetch_at <= now() かつ fetch_status = 'active' かつ購読者が存在するフィードを
 // FOR UPDATE SKIP LOCKEDで排他的に取得する。
@@ -202,8 +303,10 @@ func (r *PostgresFeedRepo) ListDueForFetch(ctx context.Context) ([]*model.Feed,
 	rows, err := r.db.QueryContext(ctx,
 		`SELECT f.id, f.feed_url, f.site_url, f.title, f.favicon_data, f.favicon_mime,
 		        f.etag, f.last_modified, f.fetch_status, f.consecutive_errors,
-		        f.error_message, f.next_fetch_at, f.last_successful_fetch_at,
-		        f.created_at, f.updated_at
+		        f.error_message, f.error_kind, f.next_fetch_at, f.last_successful_fetch_at,
+		        f.language, f.description, f.last_published_at, f.ignore_conditional_get, f.capture_raw_response,
+		        f.error_detail, f.http_version,
+		        f.copyright, f.ttl_minutes, f.robots, f.created_at, f.updated_at
 		 FROM feeds f
 		 WHERE f.next_fetch_at <= now()
 		   AND f.fetch_status = 'active'
@@ -220,14 +323,19 @@ func (r *PostgresFeedRepo) ListDueForFetch(ctx context.Context) ([]*model.Feed,
 	for rows.Next() {
 		feed := &model.Feed{}
 		var faviconData []byte
-		var faviconMime, siteURL, etag, lastModified, errorMessage sql.NullString
-		var lastSuccessfulFetchAt sql.NullTime
+		var faviconMime, siteURL, etag, lastModified, errorMessage, errorKind, language, description, httpVersion, copyright, robots sql.NullString
+		var lastSuccessfulFetchAt, lastPublishedAt sql.NullTime
+		var ttlMinutes sql.NullInt64
+		var errorDetail []byte
 
 		if err := rows.Scan(
 			&feed.ID, &feed.FeedURL, &siteURL, &feed.Title,
 			&faviconData, &faviconMime,
 			&etag, &lastModified, &feed.FetchStatus, &feed.ConsecutiveErrors,
-			&errorMessage, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+			&errorMessage, &errorKind, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+			&language, &description, &lastPublishedAt, &feed.IgnoreConditionalGet, &feed.CaptureRawResponse,
+			&errorDetail, &httpVersion,
+			&copyright, &ttlMinutes, &robots,
 			&feed.CreatedAt, &feed.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("フェッチ対象フィードの読み取りに失敗しました: %w", err)
@@ -239,7 +347,20 @@ func (r *PostgresFeedRepo) ListDueForFetch(ctx context.Context) ([]*model.Feed,
 		feed.ETag = nullStringValue(etag)
 		feed.LastModified = nullStringValue(lastModified)
 		feed.ErrorMessage = nullStringValue(errorMessage)
+		feed.ErrorKind = model.FetchErrorKind(nullStringValue(errorKind))
 		feed.LastSuccessfulFetchAt = nullTimeValue(lastSuccessfulFetchAt)
+		feed.Language = nullStringValue(language)
+		feed.Description = nullStringValue(description)
+		feed.LastPublishedAt = nullTimeValue(lastPublishedAt)
+		feed.HTTPVersion = nullStringValue(httpVersion)
+		feed.Copyright = nullStringValue(copyright)
+		feed.TTLMinutes = int(ttlMinutes.Int64)
+		feed.Robots = nullStringValue(robots)
+		detail, err := decodeFetchErrorDetail(errorDetail)
+		if err != nil {
+			return nil, err
+		}
+		feed.ErrorDetail = detail
 
 		feeds = append(feeds, feed)
 	}
@@ -253,24 +374,38 @@ func (r *PostgresFeedRepo) ListDueForFetch(ctx context.Context) ([]*model.Feed,
 
 // UpdateFetchState はフィードのフェッチ状態を更新する。
 //
-// フェッチ状態項目（fetch_status / consecutive_errors / error_message /
-// next_fetch_at / etag / last_modified）に加えて、フェッチ成功時にパースされた
-// title / site_url も永続化する。呼び出し側（Fetcher）はパース済みタイトル・
+// フェッチ状態項目（fetch_status / consecutive_errors / error_message / error_kind /
+// error_detail / http_version / next_fetch_at / etag / last_modified）に加えて、フェッチ成功時にパースされた
+// title / site_url と利用条件のメタ情報（copyright / ttl_minutes / robots）も永続化する。呼び出し側（Fetcher）はパース済みタイトル・
 // サイト URL が空のときは feed.Title / feed.SiteURL を上書きしない（既存値を維持する）
 // ため、本メソッドは渡された feed の値をそのまま書き込めば、フェッチ失敗・未変更
 // パスでは DB 上の既存値が破壊されない。
+// フェッチ中に最後の購読者が購読を解除して no_subscribers になったフィードは、active で上書きせず停止したままにする。
 func (r *PostgresFeedRepo) UpdateFetchState(ctx context.Context, feed *model.Feed) error {
-	_, err := r.db.ExecContext(ctx,
+	errorDetail, err := encodeFetchErrorDetail(feed.ErrorDetail)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx,
 		`UPDATE feeds SET
 		    title = $2,
 		    site_url = $3,
-		    fetch_status = $4,
+		    fetch_status = CASE WHEN fetch_status = 'no_subscribers' AND $4 = 'active' THEN fetch_status ELSE $4 END,
 		    consecutive_errors = $5,
 		    error_message = $6,
 		    next_fetch_at = $7,
 		    etag = $8,
 		    last_modified = $9,
-		    updated_at = now()
+		    error_kind = $10,
+		    language = $11,
+		    description = $12,
+		    last_published_at = $13,
+		    error_detail = $14,
+		    http_version = $15,
+		    copyright = $16,
+		    ttl_minutes = $17,
+		    robots = $18
 		 WHERE id = $1`,
 		feed.ID,
 		feed.Title,
@@ -281,6 +416,15 @@ func (r *PostgresFeedRepo) UpdateFetchState(ctx context.Context, feed *model.Fee
 		feed.NextFetchAt,
 		nullString(feed.ETag),
 		nullString(feed.LastModified),
+		nullString(string(feed.ErrorKind)),
+		nullString(feed.Language),
+		nullString(feed.Description),
+		feed.LastPublishedAt,
+		errorDetail,
+		nullString(feed.HTTPVersion),
+		nullString(feed.Copyright),
+		nullPositiveInt(feed.TTLMinutes),
+		nullString(feed.Robots),
 	)
 	if err != nil {
 		return fmt.Errorf("フェッチ状態の更新に失敗しました: %w", err)
@@ -295,21 +439,28 @@ func (r *PostgresFeedRepo) UpdateFetchState(ctx context.Context, feed *model.Fee
 func (r *PostgresFeedRepo) LockFeedForUpdateNowait(ctx context.Context, tx *sql.Tx, feedID string) (*model.Feed, error) {
 	feed := &model.Feed{}
 	var faviconData []byte
-	var faviconMime, siteURL, etag, lastModified, errorMessage sql.NullString
-	var lastSuccessfulFetchAt sql.NullTime
+	var faviconMime, siteURL, etag, lastModified, errorMessage, errorKind, language, description, httpVersion, copyright, robots sql.NullString
+	var lastSuccessfulFetchAt, lastPublishedAt sql.NullTime
+	var ttlMinutes sql.NullInt64
+	var errorDetail []byte
 
 	err := tx.QueryRowContext(ctx,
 		`SELECT id, feed_url, site_url, title, favicon_data, favicon_mime,
 		        etag, last_modified, fetch_status, consecutive_errors,
-		        error_message, next_fetch_at, last_successful_fetch_at,
-		        created_at, updated_at
+		        error_message, error_kind, next_fetch_at, last_successful_fetch_at,
+		        language, description, last_published_at, ignore_conditional_get, capture_raw_response,
+		        error_detail, http_version,
+		        copyright, ttl_minutes, robots, created_at, updated_at
 		 FROM feeds WHERE id = $1 FOR UPDATE NOWAIT`,
 		feedID,
 	).Scan(
 		&feed.ID, &feed.FeedURL, &siteURL, &feed.Title,
 		&faviconData, &faviconMime,
 		&etag, &lastModified, &feed.FetchStatus, &feed.ConsecutiveErrors,
-		&errorMessage, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+		&errorMessage, &errorKind, &feed.NextFetchAt, &lastSuccessfulFetchAt,
+		&language, &description, &lastPublishedAt, &feed.IgnoreConditionalGet, &feed.CaptureRawResponse,
+		&errorDetail, &httpVersion,
+		&copyright, &ttlMinutes, &robots,
 		&feed.CreatedAt, &feed.UpdatedAt,
 	)
 
@@ -330,17 +481,93 @@ func (r *PostgresFeedRepo) LockFeedForUpdateNowait(ctx context.Context, tx *sql.
 	feed.ETag = nullStringValue(etag)
 	feed.LastModified = nullStringValue(lastModified)
 	feed.ErrorMessage = nullStringValue(errorMessage)
+	feed.ErrorKind = model.FetchErrorKind(nullStringValue(errorKind))
 	feed.LastSuccessfulFetchAt = nullTimeValue(lastSuccessfulFetchAt)
+	feed.Language = nullStringValue(language)
+	feed.Description = nullStringValue(description)
+	feed.LastPublishedAt = nullTimeValue(lastPublishedAt)
+	feed.HTTPVersion = nullStringValue(httpVersion)
+	feed.Copyright = nullStringValue(copyright)
+	feed.TTLMinutes = int(ttlMinutes.Int64)
+	feed.Robots = nullStringValue(robots)
+	detail, err := decodeFetchErrorDetail(errorDetail)
+	if err != nil {
+		return nil, err
+	}
+	feed.ErrorDetail = detail
 
 	return feed, nil
 }
 
+// SetIgnoreConditionalGet は指定フィードの条件付き GET 無効化フラグを更新する。
+// 対象フィードが存在しない場合は false を返す。
+func (r *PostgresFeedRepo) SetIgnoreConditionalGet(ctx context.Context, feedID string, ignore bool) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE feeds SET ignore_conditional_get = $2 WHERE id = $1`,
+		feedID, ignore,
+	)
+	if err != nil {
+		return false, fmt.Errorf("条件付きGET設定の更新に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ListBySiteHost はサイトのホストが host に一致するフィードのうち、userID が購読しているもの、
+// または公開プロフィール（user_settings.public_profile）で公開された購読（subscriptions.is_public）の
+// フィードをタイトル順に最大 limit 件返す。
+// ホストの抽出は feed_host 関数で行い、idx_feeds_site_host 式インデックスを使って検索する。
+// 関連フィードの一覧表示用のため、favicon やフェッチ状態の詳細は読み込まない。
+func (r *PostgresFeedRepo) ListBySiteHost(ctx context.Context, userID, host, excludeFeedID string, limit int) ([]*model.Feed, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT f.id, f.feed_url, f.site_url, f.title, f.fetch_status, f.last_published_at
+		 FROM feeds f
+		 WHERE feed_host(COALESCE(NULLIF(f.site_url, ''), f.feed_url)) = $1
+		   AND f.id::text <> $2
+		   AND (
+		     EXISTS (SELECT 1 FROM subscriptions s WHERE s.feed_id = f.id AND s.user_id = $3)
+		     OR EXISTS (
+		       SELECT 1 FROM subscriptions s
+		       JOIN user_settings us ON us.user_id = s.user_id
+		       WHERE s.feed_id = f.id AND s.is_public AND us.public_profile
+		     )
+		   )
+		 ORDER BY f.title ASC, f.feed_url ASC
+		 LIMIT $4`,
+		host, excludeFeedID, userID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ホスト別フィードの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []*model.Feed
+	for rows.Next() {
+		feed := &model.Feed{}
+		var siteURL sql.NullString
+		var lastPublishedAt sql.NullTime
+		if err := rows.Scan(&feed.ID, &feed.FeedURL, &siteURL, &feed.Title, &feed.FetchStatus, &lastPublishedAt); err != nil {
+			return nil, fmt.Errorf("フィード行の読み取りに失敗しました: %w", err)
+		}
+		feed.SiteURL = nullStringValue(siteURL)
+		feed.LastPublishedAt = nullTimeValue(lastPublishedAt)
+		feeds = append(feeds, feed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ホスト別フィードの走査に失敗しました: %w", err)
+	}
+	return feeds, nil
+}
+
 // UpdateLastSuccessfulFetchAt は指定フィードの last_successful_fetch_at を更新する。
 // 自動ワーカーの成功経路と手動フェッチの成功経路の双方から呼ばれる共有更新メソッド。
 // 既存値の有無に関わらず単純上書きする（成功時刻は単調増加するため呼び出し側で順序を保証する）。
 func (r *PostgresFeedRepo) UpdateLastSuccessfulFetchAt(ctx context.Context, feedID string, at time.Time) error {
 	_, err := r.db.ExecContext(ctx,
-		`UPDATE feeds SET last_successful_fetch_at = $2, updated_at = now() WHERE id = $1`,
+		`UPDATE feeds SET last_successful_fetch_at = $2 WHERE id = $1`,
 		feedID, at,
 	)
 	if err != nil {
@@ -350,4 +577,7 @@ func (r *PostgresFeedRepo) UpdateLastSuccessfulFetchAt(ctx context.Context, feed
 }
 
 // compile-time interface check
-var _ FeedRepository = (*PostgresFeedRepo)(nil)
+var (
+	_ FeedRepository               = (*PostgresFeedRepo)(nil)
+	_ FeedConditionalGetRepository = (*PostgresFeedRepo)(nil)
+)
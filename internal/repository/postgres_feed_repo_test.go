@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -103,11 +104,35 @@ func setupListDueTestDB(t *testing.T) *sql.DB {
 	}
 
 	cleanupSQL := `
+		DROP TABLE IF EXISTS feed_raw_captures CASCADE;
+		DROP TABLE IF EXISTS read_later_deliveries CASCADE;
+		DROP TABLE IF EXISTS read_later_connections CASCADE;
+		DROP TABLE IF EXISTS feed_registration_batches CASCADE;
+		DROP TABLE IF EXISTS item_highlights CASCADE;
+		DROP TABLE IF EXISTS feed_keywords CASCADE;
+		DROP TABLE IF EXISTS login_tokens CASCADE;
+		DROP TABLE IF EXISTS api_usage_daily CASCADE;
+		DROP TABLE IF EXISTS login_events CASCADE;
+		DROP TABLE IF EXISTS blocked_domains CASCADE;
+		DROP TABLE IF EXISTS integration_deliveries CASCADE;
+		DROP TABLE IF EXISTS integrations CASCADE;
 		DROP TABLE IF EXISTS user_cross_feed_views CASCADE;
 		DROP TABLE IF EXISTS sessions CASCADE;
 		DROP TABLE IF EXISTS user_settings CASCADE;
+		DROP MATERIALIZED VIEW IF EXISTS admin_stats;
+		DROP TABLE IF EXISTS fetch_attempts CASCADE;
+		DROP TABLE IF EXISTS idempotency_keys CASCADE;
+		DROP TABLE IF EXISTS weekly_subscription_stats CASCADE;
+		DROP TABLE IF EXISTS worker_cycles CASCADE;
+		DROP TABLE IF EXISTS subscription_undos CASCADE;
+		DROP TABLE IF EXISTS team_invitations CASCADE;
+		DROP TABLE IF EXISTS team_feeds CASCADE;
+		DROP TABLE IF EXISTS team_members CASCADE;
+		DROP TABLE IF EXISTS audit_logs CASCADE;
+		DROP TABLE IF EXISTS item_views CASCADE;
 		DROP TABLE IF EXISTS item_states CASCADE;
 		DROP TABLE IF EXISTS subscriptions CASCADE;
+		DROP TABLE IF EXISTS teams CASCADE;
 		DROP TABLE IF EXISTS items CASCADE;
 		DROP TABLE IF EXISTS feeds CASCADE;
 		DROP TABLE IF EXISTS identities CASCADE;
@@ -386,6 +411,10 @@ func TestPostgresFeedRepo_UpdateFetchState(t *testing.T) {
 		original.ETag = `"etag-xyz"`
 		original.LastModified = "Wed, 01 Jan 2025 00:00:00 GMT"
 		original.NextFetchAt = time.Now().Add(60 * time.Minute)
+		lastPublishedAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		original.Language = "ja"
+		original.Description = "Example のニュース"
+		original.LastPublishedAt = &lastPublishedAt
 		if err := repo.UpdateFetchState(ctx, original); err != nil {
 			t.Fatalf("UpdateFetchState returned error: %v", err)
 		}
@@ -411,6 +440,13 @@ func TestPostgresFeedRepo_UpdateFetchState(t *testing.T) {
 		if reloaded.FetchStatus != model.FetchStatusActive {
 			t.Errorf("永続化後の FetchStatus = %q, want %q", reloaded.FetchStatus, model.FetchStatusActive)
 		}
+		// フィードのメタデータも永続化される
+		if reloaded.Language != "ja" || reloaded.Description != "Example のニュース" {
+			t.Errorf("永続化後の Language/Description = %q/%q, want %q/%q", reloaded.Language, reloaded.Description, "ja", "Example のニュース")
+		}
+		if reloaded.LastPublishedAt == nil || !reloaded.LastPublishedAt.Equal(lastPublishedAt) {
+			t.Errorf("永続化後の LastPublishedAt = %v, want %v", reloaded.LastPublishedAt, lastPublishedAt)
+		}
 	})
 
 	// Requirement 3.1 / 3.2 / NFR 1.1:
@@ -459,6 +495,110 @@ func TestPostgresFeedRepo_UpdateFetchState(t *testing.T) {
 			t.Errorf("更新後の ErrorMessage = %q, want %q", reloaded.ErrorMessage, "一時的な取得失敗")
 		}
 	})
+
+	t.Run("エラー詳細とHTTPバージョンを永続化し成功時にクリアできる", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresFeedRepo(db)
+
+		feedID := insertTestFeedWithTitle(t, db, "https://tls.example.com/feed.xml", "TLS", "", model.FetchStatusActive)
+		feed, err := repo.FindByID(ctx, feedID)
+		if err != nil {
+			t.Fatalf("FindByID returned error: %v", err)
+		}
+
+		// Act: 証明書切れによる失敗を記録
+		notAfter := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+		feed.ErrorKind = model.FetchErrorKindTLS
+		feed.ErrorDetail = &model.FetchErrorDetail{
+			TLSError:     model.TLSErrorCertExpired,
+			CertSubject:  "tls.example.com",
+			CertDNSNames: []string{"tls.example.com"},
+			CertNotAfter: &notAfter,
+		}
+		feed.HTTPVersion = "HTTP/2.0"
+		if err := repo.UpdateFetchState(ctx, feed); err != nil {
+			t.Fatalf("UpdateFetchState returned error: %v", err)
+		}
+
+		// Assert
+		reloaded, err := repo.FindByID(ctx, feedID)
+		if err != nil {
+			t.Fatalf("再読込の FindByID returned error: %v", err)
+		}
+		if reloaded.HTTPVersion != "HTTP/2.0" {
+			t.Errorf("HTTPVersion = %q, want %q", reloaded.HTTPVersion, "HTTP/2.0")
+		}
+		d := reloaded.ErrorDetail
+		if d == nil || d.TLSError != model.TLSErrorCertExpired || d.CertNotAfter == nil || !d.CertNotAfter.Equal(notAfter) {
+			t.Fatalf("ErrorDetail = %+v, want expired certificate detail", d)
+		}
+
+		// Act: 成功時はエラー詳細をクリアする
+		reloaded.ErrorKind = model.FetchErrorKindNone
+		reloaded.ErrorDetail = nil
+		if err := repo.UpdateFetchState(ctx, reloaded); err != nil {
+			t.Fatalf("UpdateFetchState returned error: %v", err)
+		}
+		cleared, err := repo.FindByID(ctx, feedID)
+		if err != nil {
+			t.Fatalf("再読込の FindByID returned error: %v", err)
+		}
+		if cleared.ErrorDetail != nil {
+			t.Errorf("ErrorDetail = %+v, want nil", cleared.ErrorDetail)
+		}
+		if cleared.HTTPVersion != "HTTP/2.0" {
+			t.Errorf("HTTPVersion = %q, want %q（維持される）", cleared.HTTPVersion, "HTTP/2.0")
+		}
+	})
+}
+
+// TestFetchErrorDetail_EncodeDecode は error_detail 列との相互変換を検証する（DB 不要）。
+func TestFetchErrorDetail_EncodeDecode(t *testing.T) {
+	t.Run("nilのときNULLとして扱う", func(t *testing.T) {
+		v, err := encodeFetchErrorDetail(nil)
+		if err != nil {
+			t.Fatalf("encodeFetchErrorDetail returned error: %v", err)
+		}
+		if v.Valid {
+			t.Errorf("encoded = %+v, want NULL", v)
+		}
+		d, err := decodeFetchErrorDetail(nil)
+		if err != nil || d != nil {
+			t.Errorf("decodeFetchErrorDetail(nil) = %+v, %v, want nil, nil", d, err)
+		}
+	})
+
+	t.Run("エンコードした値をデコードすると元の詳細に戻る", func(t *testing.T) {
+		notBefore := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		notAfter := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+		want := &model.FetchErrorDetail{
+			HTTPStatus:    0,
+			TLSError:      model.TLSErrorHostnameMismatch,
+			CertSubject:   "other.example.com",
+			CertIssuer:    "Example CA",
+			CertDNSNames:  []string{"other.example.com", "www.other.example.com"},
+			CertNotBefore: &notBefore,
+			CertNotAfter:  &notAfter,
+		}
+
+		v, err := encodeFetchErrorDetail(want)
+		if err != nil {
+			t.Fatalf("encodeFetchErrorDetail returned error: %v", err)
+		}
+		got, err := decodeFetchErrorDetail([]byte(v.String))
+		if err != nil {
+			t.Fatalf("decodeFetchErrorDetail returned error: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("不正なJSONのときエラーを返す", func(t *testing.T) {
+		if _, err := decodeFetchErrorDetail([]byte("{")); err == nil {
+			t.Error("expected error for invalid JSON")
+		}
+	})
 }
 
 // TestPostgresFeedRepo_LastSuccessfulFetchAt_Scan は Issue #115 (Req 2.4) の追加カラム
@@ -728,3 +868,91 @@ func TestPostgresFeedRepo_UpdateLastSuccessfulFetchAt(t *testing.T) {
 		}
 	})
 }
+
+func TestPostgresFeedRepo_ListBySiteHost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("サイトのホストが一致するフィードのみ返り除外IDのフィードは含まれない", func(t *testing.T) {
+		// Arrange
+		db := setupListDueTestDB(t)
+		repo := NewPostgresFeedRepo(db)
+		due := time.Now().Add(-1 * time.Minute)
+
+		base := insertTestFeed(t, db, "https://example.com/tech.xml", due, model.FetchStatusActive)
+		sameSite := insertTestFeed(t, db, "https://feeds.example.net/life.xml", due, model.FetchStatusActive)
+		noSiteURL := insertTestFeed(t, db, "https://WWW.example.com:8080/news.xml", due, model.FetchStatusActive)
+		other := insertTestFeed(t, db, "https://other.example.com/feed.xml", due, model.FetchStatusActive)
+		if _, err := db.Exec(`UPDATE feeds SET site_url = 'https://www.example.com/' WHERE id IN ($1, $2)`, base, sameSite); err != nil {
+			t.Fatalf("site_url の更新に失敗: %v", err)
+		}
+		userID := insertTestUser(t, db, "related@example.com")
+		for _, feedID := range []string{base, sameSite, noSiteURL, other} {
+			insertTestSubscription(t, db, userID, feedID)
+		}
+
+		// Act
+		feeds, err := repo.ListBySiteHost(ctx, userID, "example.com", base, 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListBySiteHost returned error: %v", err)
+		}
+		if got := countFeedID(feeds, sameSite); got != 1 {
+			t.Errorf("site_url が同じホストのフィードの出現回数 = %d, want 1", got)
+		}
+		if got := countFeedID(feeds, noSiteURL); got != 1 {
+			t.Errorf("site_url 未設定で feed_url が同じホストのフィードの出現回数 = %d, want 1", got)
+		}
+		if got := countFeedID(feeds, base); got != 0 {
+			t.Errorf("除外IDのフィードの出現回数 = %d, want 0", got)
+		}
+		if got := countFeedID(feeds, other); got != 0 {
+			t.Errorf("別ホストのフィードの出現回数 = %d, want 0", got)
+		}
+	})
+
+	t.Run("他ユーザーが非公開で購読しているフィードは返さず公開購読のフィードは返す", func(t *testing.T) {
+		// Arrange
+		db := setupListDueTestDB(t)
+		repo := NewPostgresFeedRepo(db)
+		due := time.Now().Add(-1 * time.Minute)
+
+		base := insertTestFeed(t, db, "https://example.com/feed.xml", due, model.FetchStatusActive)
+		private := insertTestFeed(t, db, "https://example.com/private.xml?token=secret", due, model.FetchStatusActive)
+		public := insertTestFeed(t, db, "https://example.com/public.xml", due, model.FetchStatusActive)
+		hiddenProfile := insertTestFeed(t, db, "https://example.com/hidden.xml", due, model.FetchStatusActive)
+		userID := insertTestUser(t, db, "viewer@example.com")
+		otherID := insertTestUser(t, db, "owner@example.com")
+		hiddenID := insertTestUser(t, db, "hidden@example.com")
+		insertTestSubscription(t, db, userID, base)
+		insertTestSubscription(t, db, otherID, private)
+		insertTestSubscription(t, db, otherID, public)
+		insertTestSubscription(t, db, hiddenID, hiddenProfile)
+		if _, err := db.Exec(`UPDATE subscriptions SET is_public = true WHERE feed_id IN ($1, $2)`, public, hiddenProfile); err != nil {
+			t.Fatalf("is_public の更新に失敗: %v", err)
+		}
+		if _, err := db.Exec(
+			`INSERT INTO user_settings (user_id, public_profile) VALUES ($1, true), ($2, false)`,
+			otherID, hiddenID,
+		); err != nil {
+			t.Fatalf("user_settings の挿入に失敗: %v", err)
+		}
+
+		// Act
+		feeds, err := repo.ListBySiteHost(ctx, userID, "example.com", base, 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListBySiteHost returned error: %v", err)
+		}
+		if got := countFeedID(feeds, private); got != 0 {
+			t.Errorf("他ユーザーの非公開購読のフィードの出現回数 = %d, want 0", got)
+		}
+		if got := countFeedID(feeds, hiddenProfile); got != 0 {
+			t.Errorf("プロフィール非公開のユーザーの購読のフィードの出現回数 = %d, want 0", got)
+		}
+		if got := countFeedID(feeds, public); got != 1 {
+			t.Errorf("公開購読のフィードの出現回数 = %d, want 1", got)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresFeedTitleRepo は PostgreSQL を使用したフィードタイトル自動更新ポリシーのリポジトリ。
+// ポリシーと承認待ちタイトルは購読（subscriptions）ごとに保持し、feeds.title は変更しない。
+type PostgresFeedTitleRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresFeedTitleRepo は PostgresFeedTitleRepo を生成する。
+func NewPostgresFeedTitleRepo(db *sql.DB) *PostgresFeedTitleRepo {
+	return &PostgresFeedTitleRepo{db: db}
+}
+
+// feedTitleSubscriptionCondition は当該ユーザーのフィードの購読を表す条件（$1=feed_id, $2=user_id）。
+const feedTitleSubscriptionCondition = `s.feed_id = $1 AND s.user_id = $2 AND f.id = s.feed_id`
+
+// feedTitleSettingColumns は購読一覧に表示するタイトル・自動更新ポリシー・承認待ちタイトルの列。
+const feedTitleSettingColumns = `COALESCE(s.display_title, f.title), s.title_update_policy, s.pending_title`
+
+// GetFeedTitleSetting は購読一覧に表示するタイトル・自動更新ポリシー・承認待ちタイトルを取得する。
+// 対象フィードが存在しない、または購読していない場合は nil を返す。
+func (r *PostgresFeedTitleRepo) GetFeedTitleSetting(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error) {
+	return r.queryFeedTitleSetting(ctx, "フィードタイトル設定の取得",
+		`SELECT `+feedTitleSettingColumns+` FROM subscriptions s, feeds f WHERE `+feedTitleSubscriptionCondition,
+		feedID, userID,
+	)
+}
+
+// UpdateTitleUpdatePolicy は自動更新ポリシーを更新する。
+// initial / manual に変更した場合は現在表示しているタイトルを固定し、always に変更した場合は固定を解く。
+// manual 以外に変更した場合は承認待ちタイトルを破棄する。
+// 対象フィードが存在しない、または購読していない場合は nil を返す。
+func (r *PostgresFeedTitleRepo) UpdateTitleUpdatePolicy(ctx context.Context, userID, feedID string, policy model.TitleUpdatePolicy) (*model.FeedTitleSetting, error) {
+	return r.queryFeedTitleSetting(ctx, "フィードタイトル自動更新ポリシーの更新",
+		`UPDATE subscriptions s SET title_update_policy = $3,
+		    display_title = CASE WHEN $3 = 'always' THEN NULL ELSE COALESCE(s.display_title, NULLIF(f.title, '')) END,
+		    pending_title = CASE WHEN $3 = 'manual' THEN s.pending_title ELSE NULL END
+		 FROM feeds f
+		 WHERE `+feedTitleSubscriptionCondition+`
+		 RETURNING `+feedTitleSettingColumns,
+		feedID, userID, string(policy),
+	)
+}
+
+// ApplyPendingTitle は承認待ちタイトルを表示するタイトルに反映する。承認待ちがない場合は何もせず現在の設定を返す。
+// 対象フィードが存在しない、または購読していない場合は nil を返す。
+func (r *PostgresFeedTitleRepo) ApplyPendingTitle(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error) {
+	return r.queryFeedTitleSetting(ctx, "承認待ちタイトルの反映",
+		`UPDATE subscriptions s SET display_title = COALESCE(s.pending_title, s.display_title), pending_title = NULL
+		 FROM feeds f
+		 WHERE `+feedTitleSubscriptionCondition+`
+		 RETURNING `+feedTitleSettingColumns,
+		feedID, userID,
+	)
+}
+
+// DismissPendingTitle は承認待ちタイトルを破棄する。
+// 対象フィードが存在しない、または購読していない場合は nil を返す。
+func (r *PostgresFeedTitleRepo) DismissPendingTitle(ctx context.Context, userID, feedID string) (*model.FeedTitleSetting, error) {
+	return r.queryFeedTitleSetting(ctx, "承認待ちタイトルの破棄",
+		`UPDATE subscriptions s SET pending_title = NULL
+		 FROM feeds f
+		 WHERE `+feedTitleSubscriptionCondition+`
+		 RETURNING `+feedTitleSettingColumns,
+		feedID, userID,
+	)
+}
+
+// RecordFeedTitleChange はフェッチで変わったフィードタイトルを、ポリシーが initial / manual の購読に反映する。
+// 表示するタイトルが未固定の購読は変更前のタイトル（previousTitle）で固定し、
+// manual の購読は固定したタイトルと異なる場合に新しいタイトル（title）を承認待ちとして保留する（同じなら保留を解く）。
+// always の購読は feeds.title をそのまま表示するため更新しない。
+func (r *PostgresFeedTitleRepo) RecordFeedTitleChange(ctx context.Context, feedID, previousTitle, title string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE subscriptions SET
+		    display_title = COALESCE(display_title, NULLIF($2, '')),
+		    pending_title = CASE
+		        WHEN title_update_policy = 'manual' AND COALESCE(display_title, NULLIF($2, '')) <> $3 THEN $3
+		        ELSE NULL
+		    END
+		 WHERE feed_id = $1 AND title_update_policy <> 'always'`,
+		feedID, previousTitle, title,
+	)
+	if err != nil {
+		return fmt.Errorf("フィードタイトルの変更の反映に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// queryFeedTitleSetting は表示するタイトル / title_update_policy / pending_title を返すクエリを実行する。
+// 行がない場合は nil を返す。op はエラーメッセージに使う操作名。
+func (r *PostgresFeedTitleRepo) queryFeedTitleSetting(ctx context.Context, op, query string, args ...any) (*model.FeedTitleSetting, error) {
+	setting := &model.FeedTitleSetting{}
+	var pendingTitle sql.NullString
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&setting.Title, &setting.Policy, &pendingTitle)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%sに失敗しました: %w", op, err)
+	}
+	setting.PendingTitle = nullStringValue(pendingTitle)
+	return setting, nil
+}
+
+// compile-time interface check
+var _ FeedTitleRepository = (*PostgresFeedTitleRepo)(nil)
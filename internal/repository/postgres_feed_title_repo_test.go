@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestPostgresFeedTitleRepo_PolicyIsPerSubscription(t *testing.T) {
+	// Arrange
+	db := setupListDueTestDB(t)
+	ctx := context.Background()
+	repo := NewPostgresFeedTitleRepo(db)
+	manualUser := insertTestUser(t, db, "title-manual@example.com")
+	alwaysUser := insertTestUser(t, db, "title-always@example.com")
+	feedID := insertTestFeed(t, db, "https://title.example.com/feed.xml", time.Now(), model.FetchStatusActive)
+	insertTestSubscription(t, db, manualUser, feedID)
+	insertTestSubscription(t, db, alwaysUser, feedID)
+	if _, err := repo.UpdateTitleUpdatePolicy(ctx, manualUser, feedID, model.TitleUpdatePolicyManual); err != nil {
+		t.Fatalf("UpdateTitleUpdatePolicy returned error: %v", err)
+	}
+
+	// Act: フェッチでフィードタイトルが変わる
+	if _, err := db.Exec(`UPDATE feeds SET title = 'Renamed Feed' WHERE id = $1`, feedID); err != nil {
+		t.Fatalf("フィードタイトルの更新に失敗: %v", err)
+	}
+	if err := repo.RecordFeedTitleChange(ctx, feedID, "Test Feed", "Renamed Feed"); err != nil {
+		t.Fatalf("RecordFeedTitleChange returned error: %v", err)
+	}
+
+	// Assert
+	manual, err := repo.GetFeedTitleSetting(ctx, manualUser, feedID)
+	if err != nil {
+		t.Fatalf("GetFeedTitleSetting returned error: %v", err)
+	}
+	if manual.Title != "Test Feed" || manual.PendingTitle != "Renamed Feed" {
+		t.Errorf("manual = %+v, want Title=Test Feed, PendingTitle=Renamed Feed", manual)
+	}
+	always, err := repo.GetFeedTitleSetting(ctx, alwaysUser, feedID)
+	if err != nil {
+		t.Fatalf("GetFeedTitleSetting returned error: %v", err)
+	}
+	if always.Title != "Renamed Feed" || always.Policy != model.TitleUpdatePolicyAlways || always.PendingTitle != "" {
+		t.Errorf("always = %+v, want Title=Renamed Feed, Policy=always, PendingTitle=\"\"", always)
+	}
+
+	// Act: 承認すると manual の購読のみ新しいタイトルを表示する
+	approved, err := repo.ApplyPendingTitle(ctx, manualUser, feedID)
+	if err != nil {
+		t.Fatalf("ApplyPendingTitle returned error: %v", err)
+	}
+
+	// Assert
+	if approved.Title != "Renamed Feed" || approved.PendingTitle != "" {
+		t.Errorf("approved = %+v, want Title=Renamed Feed, PendingTitle=\"\"", approved)
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// ErrFeedURLTaken は URL 張り替え提案の適用先 URL が別のフィードで既に登録されている場合に返される。
+// feeds.feed_url の UNIQUE 制約違反（23505）を sentinel error として正規化する。
+var ErrFeedURLTaken = errors.New("suggested feed url is already registered as another feed")
+
+// PostgresFeedURLSuggestionRepo は PostgreSQL を使用したフィード URL 張り替え提案リポジトリ。
+type PostgresFeedURLSuggestionRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresFeedURLSuggestionRepo は PostgresFeedURLSuggestionRepo を生成する。
+func NewPostgresFeedURLSuggestionRepo(db *sql.DB) *PostgresFeedURLSuggestionRepo {
+	return &PostgresFeedURLSuggestionRepo{db: db}
+}
+
+// SetSuggestedFeedURL はフィードの URL 張り替え提案を保存する。空文字列の場合は提案を取り消す。
+func (r *PostgresFeedURLSuggestionRepo) SetSuggestedFeedURL(ctx context.Context, feedID, suggestedURL string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE feeds SET suggested_feed_url = $2 WHERE id = $1`,
+		feedID, nullString(suggestedURL),
+	)
+	if err != nil {
+		return fmt.Errorf("フィード URL の張り替え提案の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// FindSuggestedFeedURL はフェッチ停止中のフィードの URL 張り替え提案を返す。
+// 提案がない（またはフィードが停止中でない）場合は空文字列を返す。
+func (r *PostgresFeedURLSuggestionRepo) FindSuggestedFeedURL(ctx context.Context, feedID string) (string, error) {
+	var suggested string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT suggested_feed_url FROM feeds
+		 WHERE id = $1 AND fetch_status = 'stopped' AND suggested_feed_url IS NOT NULL`,
+		feedID,
+	).Scan(&suggested)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("フィード URL の張り替え提案の取得に失敗しました: %w", err)
+	}
+	return suggested, nil
+}
+
+// ApplySuggestedFeedURL はフェッチ停止中のフィードの feed_url を提案 URL に置き換えてフェッチを再開する。
+// 旧 URL の ETag / Last-Modified は新しい URL では意味を持たないためクリアする。
+// 呼び出し元が検証した URL 以外を適用しないよう、提案が suggestedURL と一致する場合のみ置き換える。
+// 適用した URL を返し、提案がない（またはフィードが停止中でない、提案が変わった）場合は空文字列を返す。
+func (r *PostgresFeedURLSuggestionRepo) ApplySuggestedFeedURL(ctx context.Context, feedID, suggestedURL string) (string, error) {
+	var applied string
+	err := r.db.QueryRowContext(ctx,
+		`UPDATE feeds SET
+		    feed_url = suggested_feed_url, suggested_feed_url = NULL,
+		    etag = NULL, last_modified = NULL,
+		    fetch_status = 'active', consecutive_errors = 0,
+		    error_message = NULL, error_kind = NULL, error_detail = NULL,
+		    next_fetch_at = now()
+		 WHERE id = $1 AND fetch_status = 'stopped' AND suggested_feed_url = $2
+		 RETURNING feed_url`,
+		feedID, suggestedURL,
+	).Scan(&applied)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && string(pgErr.Code) == pgErrCodeUniqueViolation {
+			return "", ErrFeedURLTaken
+		}
+		return "", fmt.Errorf("フィード URL の張り替え提案の適用に失敗しました: %w", err)
+	}
+	return applied, nil
+}
+
+// compile-time interface check
+var _ FeedURLSuggestionRepository = (*PostgresFeedURLSuggestionRepo)(nil)
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresFetchAttemptRepo は PostgreSQL を使用したフェッチ結果履歴リポジトリ。
+type PostgresFetchAttemptRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresFetchAttemptRepo は PostgresFetchAttemptRepo を生成する。
+func NewPostgresFetchAttemptRepo(db *sql.DB) *PostgresFetchAttemptRepo {
+	return &PostgresFetchAttemptRepo{db: db}
+}
+
+// RecordFetchAttempt はフェッチ 1 回分の結果を保存する。
+// フェッチ中に削除されたフィードの結果は外部キー違反にせず破棄する。
+func (r *PostgresFetchAttemptRepo) RecordFetchAttempt(ctx context.Context, attempt model.FetchAttempt) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO fetch_attempts (feed_id, succeeded, duration_ms, attempted_at)
+		 SELECT f.id, $2, $3, $4 FROM feeds f WHERE f.id = $1`,
+		attempt.FeedID, attempt.Succeeded, attempt.Duration.Milliseconds(), attempt.AttemptedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("フェッチ結果の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// DeleteFetchAttemptsBefore は before より前のフェッチ結果を削除し、削除件数を返す。
+func (r *PostgresFetchAttemptRepo) DeleteFetchAttemptsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM fetch_attempts WHERE attempted_at < $1`, before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("古いフェッチ結果の削除に失敗しました: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("削除結果の取得に失敗しました: %w", err)
+	}
+	return deleted, nil
+}
+
+// compile-time interface check
+var _ FetchAttemptRepository = (*PostgresFetchAttemptRepo)(nil)
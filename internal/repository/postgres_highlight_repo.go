@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresHighlightRepo は PostgreSQL を使用したハイライトの事前計算結果リポジトリ。
+type PostgresHighlightRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresHighlightRepo は PostgresHighlightRepo を生成する。
+func NewPostgresHighlightRepo(db *sql.DB) *PostgresHighlightRepo {
+	return &PostgresHighlightRepo{db: db}
+}
+
+// ReplaceHighlights は公開日時（未設定の記事は取り込み日時）が since 以降の記事のスコアを weights で計算し、
+// period の事前計算結果をフィードごとの上位 perFeed 件で置き換える。保存した件数を返す。
+// スター数は記事にスターを付けているユーザー数、閲覧数は since 以降の item_views の件数で数える。
+// スター数・閲覧数は期間内の記事（windowed）に絞ってから集計し、item_states 全体を集計しないようにする。
+// 削除と挿入は 1 トランザクションで行い、計算中も前回の結果を返せるようにする。
+func (r *PostgresHighlightRepo) ReplaceHighlights(ctx context.Context, period model.HighlightPeriod, since, computedAt time.Time, weights model.HighlightWeights, perFeed int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM item_highlights WHERE period = $1`, string(period)); err != nil {
+		return 0, fmt.Errorf("ハイライトの削除に失敗しました: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`WITH windowed AS (
+		     SELECT i.id, i.feed_id, GREATEST(i.hatebu_count, 0) AS hatebu_count
+		       FROM items i
+		      WHERE COALESCE(i.published_at, i.created_at) >= $2
+		        AND COALESCE(i.published_at, i.created_at) <= $3
+		 ), stars AS (
+		     SELECT st.item_id, COUNT(*) AS star_count
+		       FROM windowed w
+		       JOIN item_states st ON st.item_id = w.id AND st.is_starred = true
+		      GROUP BY st.item_id
+		 ), views AS (
+		     SELECT iv.item_id, COUNT(*) AS view_count
+		       FROM windowed w
+		       JOIN item_views iv ON iv.item_id = w.id AND iv.viewed_at >= $2
+		      GROUP BY iv.item_id
+		 )
+		 INSERT INTO item_highlights (period, item_id, feed_id, score, hatebu_count, star_count, view_count, computed_at)
+		 SELECT $1, item_id, feed_id, score, hatebu_count, star_count, view_count, $3
+		   FROM (
+		        SELECT c.*, ROW_NUMBER() OVER (PARTITION BY c.feed_id ORDER BY c.score DESC, c.item_id) AS rank
+		          FROM (
+		               SELECT w.id AS item_id, w.feed_id, w.hatebu_count,
+		                      COALESCE(s.star_count, 0) AS star_count,
+		                      COALESCE(v.view_count, 0) AS view_count,
+		                      $4::float8 * ln(1 + w.hatebu_count)
+		                        + $5::float8 * ln(1 + COALESCE(s.star_count, 0))
+		                        + $6::float8 * ln(1 + COALESCE(v.view_count, 0)) AS score
+		                 FROM windowed w
+		                 LEFT JOIN stars s ON s.item_id = w.id
+		                 LEFT JOIN views v ON v.item_id = w.id
+		               ) c
+		         WHERE c.score > 0
+		        ) ranked
+		  WHERE rank <= $7`,
+		string(period), since, computedAt, weights.Hatebu, weights.Star, weights.View, perFeed,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("ハイライトの計算に失敗しました: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("保存件数の取得に失敗しました: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return n, nil
+}
+
+// ListHighlightsByUser は当該ユーザーの購読フィードの period のハイライトをスコアの高い順に最大 limit 件返す。
+// ユーザーが非表示にした記事は除く。同じスコアの記事は公開日時の新しい順に並べる。
+// リンクのない記事の Link は空文字を返す。
+func (r *PostgresHighlightRepo) ListHighlightsByUser(ctx context.Context, userID string, period model.HighlightPeriod, limit int) ([]model.HighlightItem, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT h.item_id, h.feed_id, f.title, i.title, COALESCE(i.link, ''), i.published_at,
+		        h.score, h.hatebu_count, h.star_count, h.view_count,
+		        COALESCE(st.is_read, false), COALESCE(st.is_starred, false), h.computed_at
+		   FROM item_highlights h
+		   JOIN subscriptions s ON s.feed_id = h.feed_id AND s.user_id = $1
+		   JOIN items i ON i.id = h.item_id
+		   JOIN feeds f ON f.id = h.feed_id
+		   LEFT JOIN item_states st ON st.item_id = h.item_id AND st.user_id = $1
+		  WHERE h.period = $2 AND COALESCE(st.is_hidden, false) = false
+		  ORDER BY h.score DESC, i.published_at DESC NULLS LAST, h.item_id
+		  LIMIT $3`,
+		userID, string(period), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ハイライトの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var items []model.HighlightItem
+	for rows.Next() {
+		var h model.HighlightItem
+		var publishedAt sql.NullTime
+		if err := rows.Scan(&h.ItemID, &h.FeedID, &h.FeedTitle, &h.Title, &h.Link, &publishedAt,
+			&h.Score, &h.HatebuCount, &h.StarCount, &h.ViewCount,
+			&h.IsRead, &h.IsStarred, &h.ComputedAt); err != nil {
+			return nil, fmt.Errorf("ハイライトの読み取りに失敗しました: %w", err)
+		}
+		if publishedAt.Valid {
+			t := publishedAt.Time.UTC()
+			h.PublishedAt = &t
+		}
+		h.ComputedAt = h.ComputedAt.UTC()
+		items = append(items, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ハイライトの読み取りに失敗しました: %w", err)
+	}
+	return items, nil
+}
+
+// compile-time interface check
+var _ HighlightRepository = (*PostgresHighlightRepo)(nil)
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介したハイライトの事前計算の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresHighlightRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "highlight-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "highlight-other@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/highlight.xml", "Highlight Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+
+	now := time.Now().UTC()
+	popular := insertTestItem(t, db, feedID, "人気の記事", "", now.Add(-2*time.Hour))
+	starred := insertTestItem(t, db, feedID, "スターの多い記事", "", now.Add(-3*time.Hour))
+	quiet := insertTestItem(t, db, feedID, "反応のない記事", "", now.Add(-4*time.Hour))
+	old := insertTestItem(t, db, feedID, "古い記事", "", now.Add(-10*24*time.Hour))
+	if _, err := db.Exec(`UPDATE items SET hatebu_count = 50 WHERE id IN ($1, $2)`, popular, old); err != nil {
+		t.Fatalf("はてブ数の更新に失敗: %v", err)
+	}
+	insertTestItemState(t, db, userID, starred, true, true)
+	insertTestItemState(t, db, otherID, starred, false, true)
+	_ = quiet
+
+	repo := NewPostgresHighlightRepo(db)
+	weights := model.HighlightWeights{Hatebu: 1, Star: 2, View: 1}
+
+	t.Run("期間内でスコアが正の記事だけをスコアの高い順に返す", func(t *testing.T) {
+		n, err := repo.ReplaceHighlights(ctx, model.HighlightPeriodWeek, now.Add(-7*24*time.Hour), now, weights, model.HighlightLimit)
+		if err != nil || n != 2 {
+			t.Fatalf("ReplaceHighlights() = (%d, %v), want (2, nil)", n, err)
+		}
+
+		got, err := repo.ListHighlightsByUser(ctx, userID, model.HighlightPeriodWeek, model.HighlightLimit)
+		if err != nil {
+			t.Fatalf("ListHighlightsByUser() error = %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("len = %d, want 2", len(got))
+		}
+		if got[0].ItemID != popular || got[0].HatebuCount != 50 || got[0].FeedTitle != "Highlight Feed" {
+			t.Errorf("got[0] = %+v, want はてブ数 50 の記事", got[0])
+		}
+		if got[0].Link != "" {
+			t.Errorf("got[0].Link = %q, want リンクのない記事は空文字", got[0].Link)
+		}
+		if got[1].ItemID != starred || got[1].StarCount != 2 || !got[1].IsStarred || !got[1].IsRead {
+			t.Errorf("got[1] = %+v, want スター 2 件で既読・スター済みの記事", got[1])
+		}
+	})
+
+	t.Run("再計算で前回の結果を置き換える", func(t *testing.T) {
+		n, err := repo.ReplaceHighlights(ctx, model.HighlightPeriodWeek, now.Add(-7*24*time.Hour), now, weights, 1)
+		if err != nil || n != 1 {
+			t.Fatalf("ReplaceHighlights() = (%d, %v), want (1, nil)", n, err)
+		}
+		got, err := repo.ListHighlightsByUser(ctx, userID, model.HighlightPeriodWeek, model.HighlightLimit)
+		if err != nil || len(got) != 1 {
+			t.Errorf("ListHighlightsByUser() = (%d 件, %v), want (1 件, nil)", len(got), err)
+		}
+	})
+
+	t.Run("購読していないユーザーには返さない", func(t *testing.T) {
+		got, err := repo.ListHighlightsByUser(ctx, otherID, model.HighlightPeriodWeek, model.HighlightLimit)
+		if err != nil || len(got) != 0 {
+			t.Errorf("ListHighlightsByUser() = (%d 件, %v), want (0 件, nil)", len(got), err)
+		}
+	})
+}
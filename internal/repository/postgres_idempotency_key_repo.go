@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresIdempotencyKeyRepo は PostgreSQL を使用した Idempotency-Key リポジトリ。
+type PostgresIdempotencyKeyRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresIdempotencyKeyRepo は PostgresIdempotencyKeyRepo を生成する。
+func NewPostgresIdempotencyKeyRepo(db *sql.DB) *PostgresIdempotencyKeyRepo {
+	return &PostgresIdempotencyKeyRepo{db: db}
+}
+
+// Reserve はユーザー・キーの組を処理中として予約する。
+// 当該ユーザーの期限切れのキーを削除してから INSERT ... ON CONFLICT DO NOTHING で予約を試み、
+// 競合した場合は保存済みのレコードを返す。削除と予約は同一トランザクションで行う。
+func (r *PostgresIdempotencyKeyRepo) Reserve(ctx context.Context, userID, key, requestHash string, now, expiresAt time.Time) (bool, *model.IdempotencyRecord, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, nil, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM idempotency_keys WHERE user_id = $1 AND expires_at <= $2`,
+		userID, now,
+	); err != nil {
+		return false, nil, fmt.Errorf("期限切れの Idempotency-Key の削除に失敗しました: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO idempotency_keys (user_id, key, request_hash, expires_at)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (user_id, key) DO NOTHING`,
+		userID, key, requestHash, expiresAt,
+	)
+	if err != nil {
+		return false, nil, fmt.Errorf("Idempotency-Key の予約に失敗しました: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, nil, fmt.Errorf("予約結果の取得に失敗しました: %w", err)
+	}
+
+	if inserted == 1 {
+		if err := tx.Commit(); err != nil {
+			return false, nil, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+		}
+		return true, nil, nil
+	}
+
+	var (
+		rec         model.IdempotencyRecord
+		statusCode  sql.NullInt64
+		contentType sql.NullString
+	)
+	err = tx.QueryRowContext(ctx,
+		`SELECT request_hash, status_code, content_type, response_body, expires_at
+		 FROM idempotency_keys
+		 WHERE user_id = $1 AND key = $2`,
+		userID, key,
+	).Scan(&rec.RequestHash, &statusCode, &contentType, &rec.ResponseBody, &rec.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("Idempotency-Key の取得に失敗しました: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, nil, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+
+	rec.StatusCode = int(statusCode.Int64)
+	rec.ContentType = contentType.String
+	return false, &rec, nil
+}
+
+// Complete は予約済みのキーにレスポンスを保存し、処理済みにする。
+func (r *PostgresIdempotencyKeyRepo) Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error {
+	if body == nil {
+		body = []byte{}
+	}
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE idempotency_keys
+		 SET status_code = $3, content_type = $4, response_body = $5
+		 WHERE user_id = $1 AND key = $2`,
+		userID, key, statusCode, contentType, body,
+	)
+	if err != nil {
+		return fmt.Errorf("Idempotency-Key のレスポンス保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Release は処理中のまま残った予約を削除する。処理済みのキーは削除しない。
+func (r *PostgresIdempotencyKeyRepo) Release(ctx context.Context, userID, key string) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM idempotency_keys
+		 WHERE user_id = $1 AND key = $2 AND status_code IS NULL`,
+		userID, key,
+	)
+	if err != nil {
+		return fmt.Errorf("Idempotency-Key の予約解放に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// compile-time interface check
+var _ IdempotencyKeyRepository = (*PostgresIdempotencyKeyRepo)(nil)
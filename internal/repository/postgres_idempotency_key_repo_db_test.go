@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// TestPostgresIdempotencyKeyRepo は Idempotency-Key の予約・レスポンス保存・解放と期限切れの扱いを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresIdempotencyKeyRepo(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("予約済みのキーは保存したレスポンスを返しユーザーごとに独立している", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresIdempotencyKeyRepo(db)
+
+		// Arrange
+		userA := insertTestUser(t, db, "idem-a@example.com")
+		userB := insertTestUser(t, db, "idem-b@example.com")
+		reserved, _, err := repo.Reserve(ctx, userA, "key-1", "hash-1", now, now.Add(time.Hour))
+		if err != nil || !reserved {
+			t.Fatalf("Reserve = (%v, %v), want reserved", reserved, err)
+		}
+
+		// Act: 処理中の再送
+		reserved, inProgress, err := repo.Reserve(ctx, userA, "key-1", "hash-1", now, now.Add(time.Hour))
+
+		// Assert
+		if err != nil || reserved {
+			t.Fatalf("Reserve = (%v, %v), want not reserved", reserved, err)
+		}
+		if inProgress == nil || inProgress.Completed() || inProgress.RequestHash != "hash-1" {
+			t.Fatalf("existing = %+v, want in-progress record", inProgress)
+		}
+
+		// Act: 保存後の再送
+		if err := repo.Complete(ctx, userA, "key-1", 201, "application/json", []byte(`{"id":"x"}`)); err != nil {
+			t.Fatalf("Complete returned error: %v", err)
+		}
+		_, done, err := repo.Reserve(ctx, userA, "key-1", "hash-1", now, now.Add(time.Hour))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Reserve returned error: %v", err)
+		}
+		if done == nil || done.StatusCode != 201 || done.ContentType != "application/json" || string(done.ResponseBody) != `{"id":"x"}` {
+			t.Errorf("existing = %+v, want stored response", done)
+		}
+
+		// 別ユーザーは同じキーでも予約できる
+		if reserved, _, err := repo.Reserve(ctx, userB, "key-1", "hash-2", now, now.Add(time.Hour)); err != nil || !reserved {
+			t.Errorf("Reserve(userB) = (%v, %v), want reserved", reserved, err)
+		}
+	})
+
+	t.Run("解放したキーと期限切れのキーは再び予約できる", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresIdempotencyKeyRepo(db)
+
+		// Arrange
+		user := insertTestUser(t, db, "idem-release@example.com")
+		if _, _, err := repo.Reserve(ctx, user, "released", "hash", now, now.Add(time.Hour)); err != nil {
+			t.Fatalf("Reserve returned error: %v", err)
+		}
+		if err := repo.Release(ctx, user, "released"); err != nil {
+			t.Fatalf("Release returned error: %v", err)
+		}
+		if _, _, err := repo.Reserve(ctx, user, "expired", "hash", now.Add(-2*time.Hour), now.Add(-time.Hour)); err != nil {
+			t.Fatalf("Reserve returned error: %v", err)
+		}
+		if err := repo.Complete(ctx, user, "expired", 201, "application/json", nil); err != nil {
+			t.Fatalf("Complete returned error: %v", err)
+		}
+
+		// Act & Assert
+		for _, key := range []string{"released", "expired"} {
+			reserved, existing, err := repo.Reserve(ctx, user, key, "hash-new", now, now.Add(time.Hour))
+			if err != nil || !reserved {
+				t.Errorf("Reserve(%s) = (%v, %+v, %v), want reserved", key, reserved, existing, err)
+			}
+		}
+	})
+}
@@ -3,11 +3,18 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/hitoshi/feedman/internal/model"
 )
 
+// ErrIdentityNotFound は解除しようとした identity が存在しない、または他ユーザーのものであることを表す。
+var ErrIdentityNotFound = errors.New("identity not found")
+
+// ErrLastIdentity はユーザーの最後の identity を解除しようとしたことを表す（解除するとログインできなくなる）。
+var ErrLastIdentity = errors.New("cannot delete the last identity of the user")
+
 // PostgresIdentityRepo はPostgreSQLを使用したidentityリポジトリ。
 type PostgresIdentityRepo struct {
 	db *sql.DB
@@ -39,5 +46,87 @@ func (r *PostgresIdentityRepo) FindByProviderAndProviderUserID(ctx context.Conte
 	return identity, nil
 }
 
+// ListByUserID はユーザーの identity を連携日時の昇順で返す。
+func (r *PostgresIdentityRepo) ListByUserID(ctx context.Context, userID string) ([]*model.Identity, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, user_id, provider, provider_user_id, created_at
+		 FROM identities
+		 WHERE user_id = $1
+		 ORDER BY created_at, id`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*model.Identity
+	for rows.Next() {
+		identity := &model.Identity{}
+		if err := rows.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.ProviderUserID, &identity.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate identities: %w", err)
+	}
+	return identities, nil
+}
+
+// DeleteForUser はユーザーの identity を 1 件削除する。
+// ユーザーの identity 行をロックしてから件数を確かめるため、同じユーザーの identity を同時に解除しても
+// 最後の 1 つは削除されない。
+func (r *PostgresIdentityRepo) DeleteForUser(ctx context.Context, userID, identityID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id FROM identities WHERE user_id = $1 FOR UPDATE`,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lock identities: %w", err)
+	}
+	found := false
+	count := 0
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan identity: %w", err)
+		}
+		count++
+		if id == identityID {
+			found = true
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate identities: %w", err)
+	}
+	if !found {
+		return ErrIdentityNotFound
+	}
+	if count <= 1 {
+		return ErrLastIdentity
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM identities WHERE id = $1 AND user_id = $2`,
+		identityID, userID,
+	); err != nil {
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit identity deletion: %w", err)
+	}
+	return nil
+}
+
 // compile-time interface check
 var _ IdentityRepository = (*PostgresIdentityRepo)(nil)
+var _ IdentityManagementRepository = (*PostgresIdentityRepo)(nil)
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresIntegrationRepo は PostgreSQL を使用した新着記事の転送設定・配送キューのリポジトリ。
+type PostgresIntegrationRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresIntegrationRepo は PostgresIntegrationRepo を生成する。
+func NewPostgresIntegrationRepo(db *sql.DB) *PostgresIntegrationRepo {
+	return &PostgresIntegrationRepo{db: db}
+}
+
+// integrationColumns は Integration の取得列。scanIntegration と対応する。
+const integrationColumns = `ig.id, ig.user_id, ig.subscription_id, f.title, ig.kind, ig.webhook_url,
+	ig.message_template, ig.enabled, ig.last_error, ig.last_delivered_at, ig.created_at, ig.updated_at`
+
+// integrationFrom は integrationColumns の取得元。購読先フィードのタイトルを結合する。
+const integrationFrom = `integrations ig
+	JOIN subscriptions s ON s.id = ig.subscription_id
+	JOIN feeds f ON f.id = s.feed_id`
+
+// scanIntegration は integrationColumns の 1 行を読み取る。
+func scanIntegration(scanner interface{ Scan(...any) error }) (*model.Integration, error) {
+	var ig model.Integration
+	var kind string
+	var lastError sql.NullString
+	var lastDeliveredAt sql.NullTime
+	if err := scanner.Scan(&ig.ID, &ig.UserID, &ig.SubscriptionID, &ig.FeedTitle, &kind, &ig.WebhookURL,
+		&ig.MessageTemplate, &ig.Enabled, &lastError, &lastDeliveredAt, &ig.CreatedAt, &ig.UpdatedAt); err != nil {
+		return nil, err
+	}
+	ig.Kind = model.IntegrationKind(kind)
+	ig.LastError = nullStringValue(lastError)
+	ig.LastDeliveredAt = nullTimeValue(lastDeliveredAt)
+	return &ig, nil
+}
+
+// Create は当該ユーザーの購読に連携設定を追加する。
+// 購読の所有者確認と挿入を 1 文で行い、購読が無い（他ユーザーの購読を含む）場合は false を返す。
+func (r *PostgresIntegrationRepo) Create(ctx context.Context, integration *model.Integration) (bool, error) {
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO integrations (user_id, subscription_id, kind, webhook_url, message_template, enabled)
+		 SELECT s.user_id, s.id, $3, $4, $5, $6
+		 FROM subscriptions s
+		 WHERE s.id = $2 AND s.user_id = $1
+		 RETURNING id, created_at, updated_at`,
+		integration.UserID, integration.SubscriptionID, string(integration.Kind),
+		integration.WebhookURL, integration.MessageTemplate, integration.Enabled,
+	).Scan(&integration.ID, &integration.CreatedAt, &integration.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("連携設定の作成に失敗しました: %w", err)
+	}
+	return true, nil
+}
+
+// ListByUser は当該ユーザーの連携設定を作成日時の昇順で返す。
+func (r *PostgresIntegrationRepo) ListByUser(ctx context.Context, userID string) ([]model.Integration, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+integrationColumns+`
+		 FROM `+integrationFrom+`
+		 WHERE ig.user_id = $1
+		 ORDER BY ig.created_at ASC, ig.id ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("連携設定一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var integrations []model.Integration
+	for rows.Next() {
+		ig, err := scanIntegration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("連携設定の読み取りに失敗しました: %w", err)
+		}
+		integrations = append(integrations, *ig)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("連携設定一覧の走査に失敗しました: %w", err)
+	}
+	return integrations, nil
+}
+
+// FindByID は当該ユーザーの連携設定を返す。存在しない場合は (nil, nil) を返す。
+func (r *PostgresIntegrationRepo) FindByID(ctx context.Context, userID, integrationID string) (*model.Integration, error) {
+	ig, err := scanIntegration(r.db.QueryRowContext(ctx,
+		`SELECT `+integrationColumns+`
+		 FROM `+integrationFrom+`
+		 WHERE ig.id = $1 AND ig.user_id = $2`,
+		integrationID, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("連携設定の取得に失敗しました: %w", err)
+	}
+	return ig, nil
+}
+
+// CountByUser は当該ユーザーの連携設定数を返す。
+func (r *PostgresIntegrationRepo) CountByUser(ctx context.Context, userID string) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM integrations WHERE user_id = $1`,
+		userID,
+	).Scan(&count); err != nil {
+		return 0, fmt.Errorf("連携設定数の取得に失敗しました: %w", err)
+	}
+	return count, nil
+}
+
+// Update は連携設定の種類・Webhook URL・テンプレート・有効フラグを更新する。
+// Webhook URL を変更した場合は旧 URL での配送エラーが残らないよう last_error をクリアする。
+// 対象が無い（他ユーザーの連携設定を含む）場合は false を返す。
+func (r *PostgresIntegrationRepo) Update(ctx context.Context, integration *model.Integration) (bool, error) {
+	err := r.db.QueryRowContext(ctx,
+		`UPDATE integrations
+		 SET kind             = $3,
+		     webhook_url      = $4,
+		     message_template = $5,
+		     enabled          = $6,
+		     last_error       = CASE WHEN webhook_url = $4 THEN last_error END
+		 WHERE id = $1 AND user_id = $2
+		 RETURNING updated_at`,
+		integration.ID, integration.UserID, string(integration.Kind),
+		integration.WebhookURL, integration.MessageTemplate, integration.Enabled,
+	).Scan(&integration.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("連携設定の更新に失敗しました: %w", err)
+	}
+	return true, nil
+}
+
+// Delete は当該ユーザーの連携設定を削除する。配送キューは CASCADE で削除される。
+// 対象が無い場合は false を返す。
+func (r *PostgresIntegrationRepo) Delete(ctx context.Context, userID, integrationID string) (bool, error) {
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM integrations WHERE id = $1 AND user_id = $2`,
+		integrationID, userID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("連携設定の削除に失敗しました: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("連携設定の削除件数の取得に失敗しました: %w", err)
+	}
+	return n > 0, nil
+}
+
+// EnqueueNewItems は当該フィードの新着記事を、有効な連携設定ごとに配送キューへ積む。
+// 連携設定の作成より前に取り込まれた記事は積まない（作成直後に過去記事をまとめて転送しない）。
+// ミュート中（mute_until が未来）の購読は新着通知イベントを生成しない取り決めのため対象外とする。
+// (integration_id, item_id) の一意制約により、同じ記事を再度積むことはない。
+// フェッチ直後に呼ばれる前提で、走査する記事は直近 1 日に取り込まれたものに限る。
+func (r *PostgresIntegrationRepo) EnqueueNewItems(ctx context.Context, feedID string) (int, error) {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO integration_deliveries (integration_id, item_id)
+		 SELECT ig.id, i.id
+		 FROM integrations ig
+		 JOIN subscriptions s ON s.id = ig.subscription_id
+		 JOIN items i ON i.feed_id = s.feed_id AND i.created_at >= ig.created_at
+		 WHERE s.feed_id = $1
+		   AND i.created_at >= now() - interval '1 day'
+		   AND ig.enabled
+		   AND (s.mute_until IS NULL OR s.mute_until <= now())
+		 ON CONFLICT (integration_id, item_id) DO NOTHING`,
+		feedID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("配送キューへの投入に失敗しました: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("配送キューへの投入件数の取得に失敗しました: %w", err)
+	}
+	return int(n), nil
+}
+
+// ListDueDeliveries は配送期限が now 以前の未配送を古い順に最大 limit 件返す。
+// 連携設定が無効化された配送はキューに残し、再度有効化されたときに配送する。
+func (r *PostgresIntegrationRepo) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]model.IntegrationDelivery, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT d.id, ig.id, ig.kind, ig.webhook_url, ig.message_template, d.attempts,
+		        i.id, f.title, i.title, COALESCE(i.link, ''), COALESCE(i.author, ''), i.published_at
+		 FROM integration_deliveries d
+		 JOIN integrations ig ON ig.id = d.integration_id
+		 JOIN items i ON i.id = d.item_id
+		 JOIN feeds f ON f.id = i.feed_id
+		 WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND ig.enabled
+		 ORDER BY d.next_attempt_at ASC, d.id ASC
+		 LIMIT $2`,
+		now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("配送キューの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.IntegrationDelivery
+	for rows.Next() {
+		var d model.IntegrationDelivery
+		var kind string
+		var publishedAt sql.NullTime
+		if err := rows.Scan(&d.ID, &d.IntegrationID, &kind, &d.WebhookURL, &d.MessageTemplate, &d.Attempts,
+			&d.ItemID, &d.FeedTitle, &d.Title, &d.Link, &d.Author, &publishedAt); err != nil {
+			return nil, fmt.Errorf("配送キューの読み取りに失敗しました: %w", err)
+		}
+		d.Kind = model.IntegrationKind(kind)
+		d.PublishedAt = nullTimeValue(publishedAt)
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("配送キューの走査に失敗しました: %w", err)
+	}
+	return deliveries, nil
+}
+
+// MarkDelivered は配送済みとして記録し、連携設定の直近の配送結果（成功日時・エラーのクリア）を更新する。
+func (r *PostgresIntegrationRepo) MarkDelivered(ctx context.Context, deliveryID int64, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`WITH d AS (
+		   UPDATE integration_deliveries
+		   SET status = 'sent', attempts = attempts + 1, sent_at = $2, last_error = NULL
+		   WHERE id = $1
+		   RETURNING integration_id
+		 )
+		 UPDATE integrations
+		 SET last_delivered_at = $2, last_error = NULL
+		 WHERE id = (SELECT integration_id FROM d)`,
+		deliveryID, at,
+	)
+	if err != nil {
+		return fmt.Errorf("配送済みの記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// MarkRetry は試行回数を加算して nextAttemptAt に再試行を予約し、連携設定に直近のエラーを記録する。
+func (r *PostgresIntegrationRepo) MarkRetry(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.db.ExecContext(ctx,
+		`WITH d AS (
+		   UPDATE integration_deliveries
+		   SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+		   WHERE id = $1
+		   RETURNING integration_id
+		 )
+		 UPDATE integrations
+		 SET last_error = $3
+		 WHERE id = (SELECT integration_id FROM d)`,
+		deliveryID, nextAttemptAt, lastError,
+	)
+	if err != nil {
+		return fmt.Errorf("配送の再試行の予約に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed は試行回数を加算して配送失敗として確定し、連携設定に直近のエラーを記録する。
+func (r *PostgresIntegrationRepo) MarkFailed(ctx context.Context, deliveryID int64, lastError string) error {
+	_, err := r.db.ExecContext(ctx,
+		`WITH d AS (
+		   UPDATE integration_deliveries
+		   SET status = 'failed', attempts = attempts + 1, last_error = $2
+		   WHERE id = $1
+		   RETURNING integration_id
+		 )
+		 UPDATE integrations
+		 SET last_error = $2
+		 WHERE id = (SELECT integration_id FROM d)`,
+		deliveryID, lastError,
+	)
+	if err != nil {
+		return fmt.Errorf("配送失敗の記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// compile-time interface check
+var (
+	_ IntegrationRepository         = (*PostgresIntegrationRepo)(nil)
+	_ IntegrationDeliveryRepository = (*PostgresIntegrationRepo)(nil)
+)
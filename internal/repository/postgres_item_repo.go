@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/hitoshi/feedman/internal/model"
 )
 
@@ -25,19 +27,22 @@ func (r *PostgresItemRepo) FindByID(ctx context.Context, id string) (*model.Item
 	item := &model.Item{}
 	var publishedAt sql.NullTime
 	var hatebuFetchedAt sql.NullTime
+	var generatedSummaryAt sql.NullTime
 	var guidOrID, link, content, summary, author, contentHash sql.NullString
 
 	err := r.db.QueryRowContext(ctx,
 		`SELECT id, feed_id, guid_or_id, title, link, content, summary, author,
 		        published_at, is_date_estimated, fetched_at, content_hash,
-		        hatebu_count, hatebu_fetched_at, created_at, updated_at
+		        hatebu_count, hatebu_fetched_at, reading_time_minutes,
+		        COALESCE(generated_summary, ''), generated_summary_at, created_at, updated_at
 		 FROM items WHERE id = $1`,
 		id,
 	).Scan(
 		&item.ID, &item.FeedID, &guidOrID, &item.Title, &link,
 		&content, &summary, &author,
 		&publishedAt, &item.IsDateEstimated, &item.FetchedAt, &contentHash,
-		&item.HatebuCount, &hatebuFetchedAt, &item.CreatedAt, &item.UpdatedAt,
+		&item.HatebuCount, &hatebuFetchedAt, &item.ReadingTimeMinutes,
+		&item.GeneratedSummary, &generatedSummaryAt, &item.CreatedAt, &item.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -59,6 +64,9 @@ func (r *PostgresItemRepo) FindByID(ctx context.Context, id string) (*model.Item
 	if hatebuFetchedAt.Valid {
 		item.HatebuFetchedAt = &hatebuFetchedAt.Time
 	}
+	if generatedSummaryAt.Valid {
+		item.GeneratedSummaryAt = &generatedSummaryAt.Time
+	}
 
 	return item, nil
 }
@@ -73,14 +81,14 @@ func (r *PostgresItemRepo) FindByFeedAndGUID(ctx context.Context, feedID, guid s
 	err := r.db.QueryRowContext(ctx,
 		`SELECT id, feed_id, guid_or_id, title, link, content, summary, author,
 		        published_at, is_date_estimated, fetched_at, content_hash,
-		        hatebu_count, hatebu_fetched_at, created_at, updated_at
+		        hatebu_count, hatebu_fetched_at, reading_time_minutes, created_at, updated_at
 		 FROM items WHERE feed_id = $1 AND guid_or_id = $2`,
 		feedID, guid,
 	).Scan(
 		&item.ID, &item.FeedID, &guidOrID, &item.Title, &link,
 		&content, &summary, &author,
 		&publishedAt, &item.IsDateEstimated, &item.FetchedAt, &contentHash,
-		&item.HatebuCount, &hatebuFetchedAt, &item.CreatedAt, &item.UpdatedAt,
+		&item.HatebuCount, &hatebuFetchedAt, &item.ReadingTimeMinutes, &item.CreatedAt, &item.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -116,14 +124,14 @@ func (r *PostgresItemRepo) FindByFeedAndLink(ctx context.Context, feedID, link s
 	err := r.db.QueryRowContext(ctx,
 		`SELECT id, feed_id, guid_or_id, title, link, content, summary, author,
 		        published_at, is_date_estimated, fetched_at, content_hash,
-		        hatebu_count, hatebu_fetched_at, created_at, updated_at
+		        hatebu_count, hatebu_fetched_at, reading_time_minutes, created_at, updated_at
 		 FROM items WHERE feed_id = $1 AND link = $2`,
 		feedID, link,
 	).Scan(
 		&item.ID, &item.FeedID, &guidOrID, &item.Title, &linkVal,
 		&content, &summary, &author,
 		&publishedAt, &item.IsDateEstimated, &item.FetchedAt, &contentHash,
-		&item.HatebuCount, &hatebuFetchedAt, &item.CreatedAt, &item.UpdatedAt,
+		&item.HatebuCount, &hatebuFetchedAt, &item.ReadingTimeMinutes, &item.CreatedAt, &item.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -159,14 +167,14 @@ func (r *PostgresItemRepo) FindByContentHash(ctx context.Context, feedID, conten
 	err := r.db.QueryRowContext(ctx,
 		`SELECT id, feed_id, guid_or_id, title, link, content, summary, author,
 		        published_at, is_date_estimated, fetched_at, content_hash,
-		        hatebu_count, hatebu_fetched_at, created_at, updated_at
+		        hatebu_count, hatebu_fetched_at, reading_time_minutes, created_at, updated_at
 		 FROM items WHERE feed_id = $1 AND content_hash = $2`,
 		feedID, contentHash,
 	).Scan(
 		&item.ID, &item.FeedID, &guidOrID, &item.Title, &link,
 		&content, &summary, &author,
 		&publishedAt, &item.IsDateEstimated, &item.FetchedAt, &contentHashVal,
-		&item.HatebuCount, &hatebuFetchedAt, &item.CreatedAt, &item.UpdatedAt,
+		&item.HatebuCount, &hatebuFetchedAt, &item.ReadingTimeMinutes, &item.CreatedAt, &item.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -192,53 +200,106 @@ func (r *PostgresItemRepo) FindByContentHash(ctx context.Context, feedID, conten
 	return item, nil
 }
 
+// ItemListModTime はフィードの記事一覧の最終更新日時を返す。
+// 記事の取り込み・更新（items.updated_at。新着記事では fetched_at と同時刻）と、
+// ユーザーの当該フィードの記事状態の更新のうち最も新しい日時を用いる。
+// ユーザーが当該フィードを購読していない、または記事が無い場合はゼロ値を返す。
+func (r *PostgresItemRepo) ItemListModTime(ctx context.Context, userID, feedID string) (time.Time, error) {
+	var modTime sql.NullTime
+	err := r.db.QueryRowContext(ctx,
+		`SELECT GREATEST(
+			(SELECT MAX(i.updated_at) FROM items i WHERE i.feed_id = s.feed_id),
+			(SELECT MAX(ist.updated_at) FROM item_states ist
+				JOIN items i ON i.id = ist.item_id
+				WHERE ist.user_id = s.user_id AND i.feed_id = s.feed_id)
+		)
+		FROM subscriptions s WHERE s.user_id = $1 AND s.feed_id = $2`,
+		userID, feedID,
+	).Scan(&modTime)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("記事一覧の最終更新日時の取得に失敗しました: %w", err)
+	}
+	return modTime.Time, nil
+}
+
 // ListByFeed はフィードの記事一覧をユーザーの状態とJOINして取得する。
-// published_at降順でカーソルベースページネーションを使用する。
-// cursorがゼロ値の場合は先頭から取得する。
-// filter: "all"=全件, "unread"=未読のみ, "starred"=スターのみ
+// (published_at, id) 降順の複合カーソルでページングし、published_at が同じ秒の記事がページ境界で重複・欠落しないようにする。
+// cursorPublishedAt がゼロ値の場合は先頭から取得する。
+// conds の各条件（未読・スター）は指定されたものだけを AND で結合して絞り込む。
+// 非表示（ミュート）にした記事は conds.IncludeHidden が true の場合のみ含める。
+// author が空でない場合は著者名の完全一致で絞り込む。
 func (r *PostgresItemRepo) ListByFeed(
 	ctx context.Context,
 	feedID, userID string,
-	filter model.ItemFilter,
-	cursor time.Time,
+	conds model.ItemConditions,
+	author string,
+	cursorPublishedAt time.Time,
+	cursorID string,
+	limit int,
+) ([]model.ItemWithState, error) {
+	return r.ListByFeeds(ctx, []string{feedID}, userID, conds, author, cursorPublishedAt, cursorID, limit)
+}
+
+// ListByFeeds は複数フィードの記事を 1 つの一覧として取得する。絞り込み・ページングは ListByFeed と同じ。
+// フィードが 1 件の場合は feed_id の等値条件とし、単一フィードの一覧と同じ実行計画になるようにする。
+func (r *PostgresItemRepo) ListByFeeds(
+	ctx context.Context,
+	feedIDs []string,
+	userID string,
+	conds model.ItemConditions,
+	author string,
+	cursorPublishedAt time.Time,
+	cursorID string,
 	limit int,
 ) ([]model.ItemWithState, error) {
 	// ベースクエリ: items LEFT JOIN item_states
-	baseQuery := `
+	q := newItemQueryBuilder(`
 		SELECT i.id, i.feed_id, i.guid_or_id, i.title, i.link, i.summary, i.author,
 		       i.published_at, i.is_date_estimated, i.fetched_at,
-		       i.hatebu_count, i.created_at, i.updated_at,
+		       i.hatebu_count, i.reading_time_minutes, COALESCE(i.series_key, ''),
+		       COALESCE(i.generated_summary, ''), i.content_text, i.thumbnail_url, i.created_at, i.updated_at,
 		       COALESCE(s.is_read, false) AS is_read,
-		       COALESCE(s.is_starred, false) AS is_starred
+		       COALESCE(s.is_starred, false) AS is_starred,
+		       COALESCE(s.is_hidden, false) AS is_hidden,
+		       COALESCE(s.rating, 0) AS rating
 		FROM items i
-		LEFT JOIN item_states s ON i.id = s.item_id AND s.user_id = $1
-		WHERE i.feed_id = $2`
+		LEFT JOIN item_states s ON i.id = s.item_id AND s.user_id = $1`, userID)
 
-	args := []interface{}{userID, feedID}
-	argIndex := 3
+	if len(feedIDs) == 1 {
+		q.where("i.feed_id = " + q.arg(feedIDs[0]))
+	} else {
+		q.where("i.feed_id = ANY(" + q.arg(pq.Array(feedIDs)) + "::uuid[])")
+	}
 
-	// カーソルベースページネーション
-	if !cursor.IsZero() {
-		baseQuery += fmt.Sprintf(" AND i.published_at < $%d", argIndex)
-		args = append(args, cursor)
-		argIndex++
+	// カーソルベースページネーション（(published_at, id) の複合キー）
+	if cond := itemCursorCondition(cursorPublishedAt, cursorID, q.arg); cond != "" {
+		q.where(cond)
+	}
+
+	// 著者で絞り込む（idx_items_feed_author を利用する）
+	if author != "" {
+		q.where("i.author = " + q.arg(author))
 	}
 
-	// フィルタ条件
-	switch filter {
-	case model.ItemFilterUnread:
-		// 未読: item_statesにレコードがない、またはis_read=false
-		baseQuery += " AND COALESCE(s.is_read, false) = false"
-	case model.ItemFilterStarred:
-		// スター付き: is_starred=true
-		baseQuery += " AND COALESCE(s.is_starred, false) = true"
-	case model.ItemFilterAll:
-		// 全件: 追加条件なし
+	// 状態の条件（item_statesにレコードがない記事は未読・スターなしとして扱う）
+	if conds.Unread != nil {
+		q.where("COALESCE(s.is_read, false) = " + q.arg(!*conds.Unread))
+	}
+	if conds.Starred != nil {
+		q.where("COALESCE(s.is_starred, false) = " + q.arg(*conds.Starred))
+	}
+	if conds.MinRating > 0 {
+		q.where("COALESCE(s.rating, 0) >= " + q.arg(conds.MinRating))
+	}
+	if !conds.IncludeHidden {
+		q.where("COALESCE(s.is_hidden, false) = false")
 	}
 
 	// ソートとリミット
-	baseQuery += fmt.Sprintf(" ORDER BY i.published_at DESC LIMIT $%d", argIndex)
-	args = append(args, limit)
+	baseQuery, args := q.build(" ORDER BY i.published_at DESC, i.id DESC LIMIT " + q.arg(limit))
 
 	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
 	if err != nil {
@@ -256,8 +317,9 @@ func (r *PostgresItemRepo) ListByFeed(
 			&iws.ID, &iws.FeedID, &guidOrID, &iws.Title, &link,
 			&summary, &author,
 			&publishedAt, &iws.IsDateEstimated, &iws.FetchedAt,
-			&iws.HatebuCount, &iws.CreatedAt, &iws.UpdatedAt,
-			&iws.IsRead, &iws.IsStarred,
+			&iws.HatebuCount, &iws.ReadingTimeMinutes, &iws.SeriesKey,
+			&iws.GeneratedSummary, &iws.ContentText, &iws.ThumbnailURL, &iws.CreatedAt, &iws.UpdatedAt,
+			&iws.IsRead, &iws.IsStarred, &iws.IsHidden, &iws.Rating,
 		); err != nil {
 			return nil, fmt.Errorf("記事行の読み取りに失敗しました: %w", err)
 		}
@@ -279,16 +341,66 @@ func (r *PostgresItemRepo) ListByFeed(
 	return items, nil
 }
 
-// ListStarredByUser は指定ユーザーがスター付与した記事を全フィード横断・published_at降順で取得する。
+// ListRecentPublishedAt はフィードの記事のうち since 以降に公開されたものの公開日時を新しい順に最大 limit 件返す。
+// 公開日時が推定値の記事（is_date_estimated）は更新パターンを歪めるため含めない。
+func (r *PostgresItemRepo) ListRecentPublishedAt(ctx context.Context, feedID string, since time.Time, limit int) ([]time.Time, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT published_at FROM items
+		 WHERE feed_id = $1 AND published_at >= $2 AND NOT is_date_estimated
+		 ORDER BY published_at DESC
+		 LIMIT $3`,
+		feedID, since, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("記事の公開日時の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var published []time.Time
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("記事の公開日時の読み取りに失敗しました: %w", err)
+		}
+		published = append(published, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("記事の公開日時の走査に失敗しました: %w", err)
+	}
+	return published, nil
+}
+
+// itemCursorCondition は (published_at, id) 降順の記事一覧で、直前ページ末尾の (cursorPublishedAt, cursorID) より
+// 後ろの記事に絞り込む WHERE 条件を返す。arg はパラメータを登録してプレースホルダを返す関数。
+// cursorPublishedAt がゼロ値の場合は空文字（条件なし）を返す。cursorID が空文字の旧形式カーソルは
+// 従来どおり published_at のみで境界を判定する（移行互換。同一時刻の記事がページ境界にあると欠落し得る）。
+func itemCursorCondition(cursorPublishedAt time.Time, cursorID string, arg func(interface{}) string) string {
+	if cursorPublishedAt.IsZero() {
+		return ""
+	}
+	if cursorID == "" {
+		return "i.published_at < " + arg(cursorPublishedAt)
+	}
+	return "(i.published_at, i.id) < (" + arg(cursorPublishedAt) + ", " + arg(cursorID) + "::uuid)"
+}
+
+// ListStarredByUser は指定ユーザーがスター付与した記事を全フィード横断・(published_at, id) 降順で取得する。
 // items と item_states と feeds を INNER JOIN し、feed_title を付与する。
-// cursor がゼロ値の場合は先頭から取得する。
+// カーソルの扱いは ListByFeed と同じ（cursorPublishedAt がゼロ値の場合は先頭から取得する）。
 // SQL 形状は既存 idx_item_states_user_starred (user_id, is_starred) WHERE is_starred = true
 // 部分インデックスを利用可能（NFR 1.1 / NFR 1.2）。
+// filter.BrokenLinkOnly が true の場合は link_status がリンク切れの記事のみに、
+// filter.MinRating が 1 以上の場合はその評価以上の記事のみに絞り込む。
+// filter.SortByRating が true の場合は (rating, published_at, id) 降順で並べ、
+// カーソルは (cursorRating, cursorPublishedAt, cursorID) の複合キーで判定する。
 func (r *PostgresItemRepo) ListStarredByUser(
 	ctx context.Context,
 	userID string,
-	cursor time.Time,
+	cursorRating int,
+	cursorPublishedAt time.Time,
+	cursorID string,
 	limit int,
+	filter model.StarredItemFilter,
 ) ([]StarredItemRow, error) {
 	// ベースクエリ: items INNER JOIN item_states INNER JOIN feeds
 	// INNER JOIN を採用（スター付き = item_states 行存在が前提なので LEFT JOIN は不要）。
@@ -296,10 +408,12 @@ func (r *PostgresItemRepo) ListStarredByUser(
 	baseQuery := `
 		SELECT i.id, i.feed_id, i.guid_or_id, i.title, i.link, i.summary, i.author,
 		       i.published_at, i.is_date_estimated, i.fetched_at,
-		       i.hatebu_count, i.created_at, i.updated_at,
+		       i.hatebu_count, i.reading_time_minutes, COALESCE(i.generated_summary, ''), i.created_at, i.updated_at,
 		       COALESCE(s.is_read, false) AS is_read,
 		       true AS is_starred,
-		       f.title AS feed_title
+		       s.rating,
+		       f.title AS feed_title,
+		       COALESCE(i.link_status, '') AS link_status
 		FROM items i
 		INNER JOIN item_states s ON i.id = s.item_id
 		INNER JOIN feeds f ON i.feed_id = f.id
@@ -309,15 +423,34 @@ func (r *PostgresItemRepo) ListStarredByUser(
 	args := []interface{}{userID}
 	argIndex := 2
 
-	// カーソルベースページネーション
-	if !cursor.IsZero() {
-		baseQuery += fmt.Sprintf(" AND i.published_at < $%d", argIndex)
-		args = append(args, cursor)
+	if filter.BrokenLinkOnly {
+		baseQuery += " AND i.link_status IN ('not_found', 'domain_unresolvable')"
+	}
+
+	placeholder := func(v interface{}) string {
+		args = append(args, v)
 		argIndex++
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.MinRating > 0 {
+		baseQuery += " AND s.rating >= " + placeholder(filter.MinRating)
 	}
 
-	// ソートとリミット（既存 ListByFeed と同じ published_at DESC）
-	baseQuery += fmt.Sprintf(" ORDER BY i.published_at DESC LIMIT $%d", argIndex)
+	if filter.SortByRating {
+		// 評価順: カーソルベースページネーション（(rating, published_at, id) の複合キー）
+		if !cursorPublishedAt.IsZero() {
+			baseQuery += " AND (s.rating, i.published_at, i.id) < (" + placeholder(cursorRating) + ", " +
+				placeholder(cursorPublishedAt) + ", " + placeholder(cursorID) + "::uuid)"
+		}
+		baseQuery += fmt.Sprintf(" ORDER BY s.rating DESC, i.published_at DESC, i.id DESC LIMIT $%d", argIndex)
+	} else {
+		// カーソルベースページネーション（(published_at, id) の複合キー）
+		if cond := itemCursorCondition(cursorPublishedAt, cursorID, placeholder); cond != "" {
+			baseQuery += " AND " + cond
+		}
+		// ソートとリミット（既存 ListByFeed と同じ published_at DESC, id DESC）
+		baseQuery += fmt.Sprintf(" ORDER BY i.published_at DESC, i.id DESC LIMIT $%d", argIndex)
+	}
 	args = append(args, limit)
 
 	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
@@ -336,9 +469,9 @@ func (r *PostgresItemRepo) ListStarredByUser(
 			&row.ID, &row.FeedID, &guidOrID, &row.Title, &link,
 			&summary, &author,
 			&publishedAt, &row.IsDateEstimated, &row.FetchedAt,
-			&row.HatebuCount, &row.CreatedAt, &row.UpdatedAt,
-			&row.IsRead, &row.IsStarred,
-			&row.FeedTitle,
+			&row.HatebuCount, &row.ReadingTimeMinutes, &row.GeneratedSummary, &row.CreatedAt, &row.UpdatedAt,
+			&row.IsRead, &row.IsStarred, &row.Rating,
+			&row.FeedTitle, &row.LinkStatus,
 		); err != nil {
 			return nil, fmt.Errorf("スター記事行の読み取りに失敗しました: %w", err)
 		}
@@ -365,6 +498,7 @@ func (r *PostgresItemRepo) ListStarredByUser(
 // (published_at, id) 複合キーによる cursor ベースページングを提供する。
 // cursorPublishedAt がゼロ値かつ cursorItemID が空文字の場合は cursor なし扱いで先頭から取得する。
 // 戻り値は published_at DESC, id DESC で決定論的に並ぶ（Issue #121 / Req 2.1, 2.2, 2.3, 4.2）。
+// 非表示（ミュート）にした記事は含めない。
 func (r *PostgresItemRepo) ListNewAcrossFeeds(
 	ctx context.Context,
 	userID string,
@@ -389,7 +523,7 @@ func (r *PostgresItemRepo) ListNewAcrossFeeds(
 		query = `
 			SELECT i.id, i.feed_id, i.guid_or_id, i.title, i.link, i.summary, i.author,
 			       i.published_at, i.is_date_estimated, i.fetched_at,
-			       i.hatebu_count, i.created_at, i.updated_at,
+			       i.hatebu_count, i.reading_time_minutes, i.created_at, i.updated_at,
 			       COALESCE(st.is_read, false)   AS is_read,
 			       COALESCE(st.is_starred, false) AS is_starred,
 			       f.title AS feed_title,
@@ -399,6 +533,7 @@ func (r *PostgresItemRepo) ListNewAcrossFeeds(
 			JOIN feeds f ON f.id = i.feed_id
 			LEFT JOIN item_states st ON st.item_id = i.id AND st.user_id = $1
 			WHERE i.published_at > $2
+			  AND COALESCE(st.is_hidden, false) = false
 			  AND (i.published_at, i.id) < ($3, $4::uuid)
 			ORDER BY i.published_at DESC, i.id DESC
 			LIMIT $5`
@@ -407,7 +542,7 @@ func (r *PostgresItemRepo) ListNewAcrossFeeds(
 		query = `
 			SELECT i.id, i.feed_id, i.guid_or_id, i.title, i.link, i.summary, i.author,
 			       i.published_at, i.is_date_estimated, i.fetched_at,
-			       i.hatebu_count, i.created_at, i.updated_at,
+			       i.hatebu_count, i.reading_time_minutes, i.created_at, i.updated_at,
 			       COALESCE(st.is_read, false)   AS is_read,
 			       COALESCE(st.is_starred, false) AS is_starred,
 			       f.title AS feed_title,
@@ -417,6 +552,7 @@ func (r *PostgresItemRepo) ListNewAcrossFeeds(
 			JOIN feeds f ON f.id = i.feed_id
 			LEFT JOIN item_states st ON st.item_id = i.id AND st.user_id = $1
 			WHERE i.published_at > $2
+			  AND COALESCE(st.is_hidden, false) = false
 			ORDER BY i.published_at DESC, i.id DESC
 			LIMIT $3`
 		args = []interface{}{userID, sinceTime, limit}
@@ -438,7 +574,7 @@ func (r *PostgresItemRepo) ListNewAcrossFeeds(
 			&row.ID, &row.FeedID, &guidOrID, &row.Title, &link,
 			&summary, &author,
 			&publishedAt, &row.IsDateEstimated, &row.FetchedAt,
-			&row.HatebuCount, &row.CreatedAt, &row.UpdatedAt,
+			&row.HatebuCount, &row.ReadingTimeMinutes, &row.CreatedAt, &row.UpdatedAt,
 			&row.IsRead, &row.IsStarred,
 			&row.FeedTitle,
 			&row.FaviconData, &row.FaviconMime,
@@ -468,13 +604,15 @@ func (r *PostgresItemRepo) Create(ctx context.Context, item *model.Item) error {
 	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO items (id, feed_id, guid_or_id, title, link, content, summary, author,
 		                    published_at, is_date_estimated, fetched_at, content_hash,
-		                    hatebu_count, hatebu_fetched_at, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		                    hatebu_count, hatebu_fetched_at, reading_time_minutes, series_key, created_at, updated_at,
+		                    content_text, thumbnail_url)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`,
 		item.ID, item.FeedID, nullString(item.GuidOrID), item.Title,
 		nullString(item.Link), nullString(item.Content), nullString(item.Summary),
 		nullString(item.Author), item.PublishedAt, item.IsDateEstimated, item.FetchedAt,
 		nullString(item.ContentHash), item.HatebuCount, item.HatebuFetchedAt,
-		item.CreatedAt, item.UpdatedAt,
+		item.ReadingTimeMinutes, nullString(item.SeriesKey), item.CreatedAt, item.UpdatedAt,
+		item.ContentText, item.ThumbnailURL,
 	)
 	if err != nil {
 		return fmt.Errorf("記事の作成に失敗しました: %w", err)
@@ -483,17 +621,21 @@ func (r *PostgresItemRepo) Create(ctx context.Context, item *model.Item) error {
 }
 
 // Update は既存記事を上書き更新する。履歴は保持しない。
+// 本文（content_hash）が変わった場合は生成要約を破棄する（古い本文の要約を表示しないため）。
 func (r *PostgresItemRepo) Update(ctx context.Context, item *model.Item) error {
 	_, err := r.db.ExecContext(ctx,
 		`UPDATE items SET
 		    guid_or_id = $2, title = $3, link = $4, content = $5,
 		    summary = $6, author = $7, published_at = $8,
-		    is_date_estimated = $9, content_hash = $10, updated_at = $11
+		    is_date_estimated = $9, content_hash = $10, reading_time_minutes = $11,
+		    series_key = $12, content_text = $13, thumbnail_url = $14,
+		    generated_summary = CASE WHEN content_hash IS DISTINCT FROM $10 THEN NULL ELSE generated_summary END,
+		    generated_summary_at = CASE WHEN content_hash IS DISTINCT FROM $10 THEN NULL ELSE generated_summary_at END
 		 WHERE id = $1`,
 		item.ID, nullString(item.GuidOrID), item.Title, nullString(item.Link),
 		nullString(item.Content), nullString(item.Summary), nullString(item.Author),
 		item.PublishedAt, item.IsDateEstimated, nullString(item.ContentHash),
-		item.UpdatedAt,
+		item.ReadingTimeMinutes, nullString(item.SeriesKey), item.ContentText, item.ThumbnailURL,
 	)
 	if err != nil {
 		return fmt.Errorf("記事の更新に失敗しました: %w", err)
@@ -501,21 +643,45 @@ func (r *PostgresItemRepo) Update(ctx context.Context, item *model.Item) error {
 	return nil
 }
 
+// UpdateGeneratedSummary は記事の生成要約と生成日時を保存する。
+func (r *PostgresItemRepo) UpdateGeneratedSummary(ctx context.Context, itemID, summary string, generatedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE items SET generated_summary = $2, generated_summary_at = $3 WHERE id = $1`,
+		itemID, summary, generatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("生成要約の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
 // ListNeedingHatebuFetch ははてなブックマーク数の取得が必要な記事を取得する。
-// hatebu_fetched_at IS NULL（未取得）を優先し、次にhatebu_fetched_atが古い順に処理する。
-func (r *PostgresItemRepo) ListNeedingHatebuFetch(ctx context.Context, limit int) ([]*model.Item, error) {
+// priority のスコアが高い順（新しい記事・はてブ数の多い記事を優先）に返し、
+// 同スコアでは hatebu_fetched_at IS NULL（未取得）、hatebu_fetched_at が古い順に処理する。
+func (r *PostgresItemRepo) ListNeedingHatebuFetch(ctx context.Context, limit int, priority model.HatebuFetchPriority) ([]*model.Item, error) {
+	// 半減期が 0 以下の場合は新しさの項を打ち消す（ゼロ除算を避ける）。
+	// 経過時間は半減期 60 回分で打ち切り、power のアンダーフローエラーを避ける。
+	halfLifeSeconds := priority.RecencyHalfLife.Seconds()
+	recencyWeight := priority.RecencyWeight
+	if halfLifeSeconds <= 0 {
+		halfLifeSeconds = 1
+		recencyWeight = 0
+	}
+
 	rows, err := r.db.QueryContext(ctx,
 		`SELECT id, feed_id, guid_or_id, title, link, content, summary, author,
 		        published_at, is_date_estimated, fetched_at, content_hash,
-		        hatebu_count, hatebu_fetched_at, created_at, updated_at
+		        hatebu_count, hatebu_fetched_at, reading_time_minutes, created_at, updated_at
 		 FROM items
 		 WHERE hatebu_fetched_at IS NULL
 		    OR hatebu_fetched_at < now() - interval '24 hours'
 		 ORDER BY
+		    $2::float8 * power(0.5, LEAST(GREATEST(EXTRACT(EPOCH FROM now() - COALESCE(published_at, fetched_at)), 0) / $3::float8, 60))
+		      + $4::float8 * ln(1 + GREATEST(hatebu_count, 0)) DESC,
 		    CASE WHEN hatebu_fetched_at IS NULL THEN 0 ELSE 1 END,
 		    hatebu_fetched_at ASC NULLS FIRST
 		 LIMIT $1`,
-		limit,
+		limit, recencyWeight, halfLifeSeconds, priority.PopularityWeight,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("はてブ取得対象記事の一覧取得に失敗しました: %w", err)
@@ -533,7 +699,7 @@ func (r *PostgresItemRepo) ListNeedingHatebuFetch(ctx context.Context, limit int
 			&item.ID, &item.FeedID, &guidOrID, &item.Title, &link,
 			&content, &summary, &author,
 			&publishedAt, &item.IsDateEstimated, &item.FetchedAt, &contentHash,
-			&item.HatebuCount, &hatebuFetchedAt, &item.CreatedAt, &item.UpdatedAt,
+			&item.HatebuCount, &hatebuFetchedAt, &item.ReadingTimeMinutes, &item.CreatedAt, &item.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("はてブ取得対象記事の行読み取りに失敗しました: %w", err)
 		}
@@ -563,7 +729,7 @@ func (r *PostgresItemRepo) ListNeedingHatebuFetch(ctx context.Context, limit int
 // UpdateHatebuCount は記事のはてなブックマーク数と取得日時を更新する。
 func (r *PostgresItemRepo) UpdateHatebuCount(ctx context.Context, itemID string, count int, fetchedAt time.Time) error {
 	_, err := r.db.ExecContext(ctx,
-		`UPDATE items SET hatebu_count = $2, hatebu_fetched_at = $3, updated_at = now()
+		`UPDATE items SET hatebu_count = $2, hatebu_fetched_at = $3
 		 WHERE id = $1`,
 		itemID, count, fetchedAt,
 	)
@@ -576,7 +742,7 @@ func (r *PostgresItemRepo) UpdateHatebuCount(ctx context.Context, itemID string,
 // itemSelectColumns は records 取得時に共通利用するカラム列。
 const itemSelectColumns = `id, feed_id, guid_or_id, title, link, content, summary, author,
 	published_at, is_date_estimated, fetched_at, content_hash,
-	hatebu_count, hatebu_fetched_at, created_at, updated_at`
+	hatebu_count, hatebu_fetched_at, reading_time_minutes, created_at, updated_at`
 
 // scanItem は items テーブルの 1 行を model.Item にスキャンする。
 // itemSelectColumns の列順に対応する。
@@ -592,7 +758,7 @@ func scanItem(scanner interface {
 		&item.ID, &item.FeedID, &guidOrID, &item.Title, &link,
 		&content, &summary, &author,
 		&publishedAt, &item.IsDateEstimated, &item.FetchedAt, &contentHash,
-		&item.HatebuCount, &hatebuFetchedAt, &item.CreatedAt, &item.UpdatedAt,
+		&item.HatebuCount, &hatebuFetchedAt, &item.ReadingTimeMinutes, &item.CreatedAt, &item.UpdatedAt,
 	); err != nil {
 		return nil, err
 	}
@@ -718,7 +884,7 @@ func bulkInsertItems(ctx context.Context, tx *sql.Tx, items []*model.Item) error
 		return nil
 	}
 
-	const colsPerRow = 16
+	const colsPerRow = 20
 	args := make([]interface{}, 0, len(items)*colsPerRow)
 	rowClauses := make([]string, len(items))
 	for i, item := range items {
@@ -733,13 +899,15 @@ func bulkInsertItems(ctx context.Context, tx *sql.Tx, items []*model.Item) error
 			nullString(item.Link), nullString(item.Content), nullString(item.Summary),
 			nullString(item.Author), item.PublishedAt, item.IsDateEstimated, item.FetchedAt,
 			nullString(item.ContentHash), item.HatebuCount, item.HatebuFetchedAt,
-			item.CreatedAt, item.UpdatedAt,
+			item.ReadingTimeMinutes, nullString(item.SeriesKey), item.CreatedAt, item.UpdatedAt,
+			item.ContentText, item.ThumbnailURL,
 		)
 	}
 
 	query := `INSERT INTO items (id, feed_id, guid_or_id, title, link, content, summary, author,
 		published_at, is_date_estimated, fetched_at, content_hash,
-		hatebu_count, hatebu_fetched_at, created_at, updated_at)
+		hatebu_count, hatebu_fetched_at, reading_time_minutes, series_key, created_at, updated_at,
+		content_text, thumbnail_url)
 		VALUES ` + strings.Join(rowClauses, ", ")
 
 	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
@@ -750,13 +918,15 @@ func bulkInsertItems(ctx context.Context, tx *sql.Tx, items []*model.Item) error
 
 // bulkUpdateItems は複数記事を 1 回の UPDATE（VALUES 由来の派生テーブルと JOIN）で更新する。
 // 更新カラムは既存の Update と同一（guid_or_id / title / link / content / summary /
-// author / published_at / is_date_estimated / content_hash / updated_at）。
+// author / published_at / is_date_estimated / content_hash / reading_time_minutes / series_key / content_text / thumbnail_url）で、
+// Update と同じく本文（content_hash）が変わった記事は生成要約を破棄する。
+// updated_at はトリガー（set_updated_at）が更新する。
 func bulkUpdateItems(ctx context.Context, tx *sql.Tx, items []*model.Item) error {
 	if len(items) == 0 {
 		return nil
 	}
 
-	const colsPerRow = 11
+	const colsPerRow = 14
 	args := make([]interface{}, 0, len(items)*colsPerRow)
 	rowClauses := make([]string, len(items))
 	for i, item := range items {
@@ -764,15 +934,15 @@ func bulkUpdateItems(ctx context.Context, tx *sql.Tx, items []*model.Item) error
 		// 型を明示するため id::uuid 等のキャストは VALUES の最初の行で行わず、
 		// UPDATE 側の比較で items.id（uuid）と v.id（text）を ::text 比較する方針を取る。
 		rowClauses[i] = fmt.Sprintf(
-			"($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			"($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
 			base+1, base+2, base+3, base+4, base+5, base+6,
-			base+7, base+8, base+9, base+10, base+11,
+			base+7, base+8, base+9, base+10, base+11, base+12, base+13, base+14,
 		)
 		args = append(args,
 			item.ID, nullString(item.GuidOrID), item.Title, nullString(item.Link),
 			nullString(item.Content), nullString(item.Summary), nullString(item.Author),
 			item.PublishedAt, item.IsDateEstimated, nullString(item.ContentHash),
-			item.UpdatedAt,
+			item.ReadingTimeMinutes, nullString(item.SeriesKey), item.ContentText, item.ThumbnailURL,
 		)
 	}
 
@@ -788,7 +958,12 @@ func bulkUpdateItems(ctx context.Context, tx *sql.Tx, items []*model.Item) error
 		published_at = v.published_at,
 		is_date_estimated = v.is_date_estimated,
 		content_hash = v.content_hash,
-		updated_at = v.updated_at
+		reading_time_minutes = v.reading_time_minutes,
+		series_key = v.series_key,
+		content_text = v.content_text,
+		thumbnail_url = v.thumbnail_url,
+		generated_summary = CASE WHEN items.content_hash IS DISTINCT FROM v.content_hash THEN NULL ELSE items.generated_summary END,
+		generated_summary_at = CASE WHEN items.content_hash IS DISTINCT FROM v.content_hash THEN NULL ELSE items.generated_summary_at END
 	FROM (
 		SELECT
 			t.id::uuid AS id,
@@ -801,8 +976,11 @@ func bulkUpdateItems(ctx context.Context, tx *sql.Tx, items []*model.Item) error
 			t.published_at::timestamptz AS published_at,
 			t.is_date_estimated::boolean AS is_date_estimated,
 			t.content_hash::text AS content_hash,
-			t.updated_at::timestamptz AS updated_at
-		FROM (VALUES ` + strings.Join(rowClauses, ", ") + `) AS t(id, guid_or_id, title, link, content, summary, author, published_at, is_date_estimated, content_hash, updated_at)
+			t.reading_time_minutes::integer AS reading_time_minutes,
+			t.series_key::text AS series_key,
+			t.content_text::text AS content_text,
+			t.thumbnail_url::text AS thumbnail_url
+		FROM (VALUES ` + strings.Join(rowClauses, ", ") + `) AS t(id, guid_or_id, title, link, content, summary, author, published_at, is_date_estimated, content_hash, reading_time_minutes, series_key, content_text, thumbnail_url)
 	) AS v
 	WHERE items.id = v.id`
 
@@ -920,7 +1098,208 @@ func (r *PostgresItemRepo) SearchByUserAndKeyword(
 	return hits, nil
 }
 
+// randomItemFilterConditions は PickRandomItems の filter ごとの追加 WHERE 条件。
+var randomItemFilterConditions = map[model.ItemFilter]string{
+	model.ItemFilterAll:     "",
+	model.ItemFilterUnread:  " AND COALESCE(st.is_read, false) = false",
+	model.ItemFilterStarred: " AND COALESCE(st.is_starred, false) = true",
+}
+
+// PickRandomItems は購読中フィードの記事を pivotID を起点に id 昇順で最大 limit 件取得する。
+//
+// ORDER BY RANDOM() は対象行を全件ソートするため記事数に比例して重くなる。本実装は
+// UUID 空間上のランダムな位置（pivotID）から主キーインデックスを順に辿るため、
+// filter に合致する記事が疎でない限り走査量は limit 程度に収まる。
+// pivotID 以降で limit に満たない場合は先頭側（id < pivotID）から不足分を補う。
+// 非表示（ミュート）にした記事は filter によらず選ばない。
+func (r *PostgresItemRepo) PickRandomItems(
+	ctx context.Context,
+	userID string,
+	filter model.ItemFilter,
+	pivotID string,
+	limit int,
+) ([]CrossFeedItem, error) {
+	cond, ok := randomItemFilterConditions[filter]
+	if !ok {
+		return nil, fmt.Errorf("未対応のフィルタです: %s", filter)
+	}
+
+	items, err := r.pickRandomItemsFrom(ctx, userID, cond, "i.id >= $2::uuid", pivotID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) >= limit {
+		return items, nil
+	}
+
+	// 折り返し: pivotID より前の範囲から不足分を取得する
+	rest, err := r.pickRandomItemsFrom(ctx, userID, cond, "i.id < $2::uuid", pivotID, limit-len(items))
+	if err != nil {
+		return nil, err
+	}
+	return append(items, rest...), nil
+}
+
+// pickRandomItemsFrom は PickRandomItems の片側範囲（pivot 以上 / pivot 未満）を取得する。
+func (r *PostgresItemRepo) pickRandomItemsFrom(
+	ctx context.Context,
+	userID, filterCond, rangeCond, pivotID string,
+	limit int,
+) ([]CrossFeedItem, error) {
+	query := `
+		SELECT i.id, i.feed_id, i.guid_or_id, i.title, i.link, i.summary, i.author,
+		       i.published_at, i.is_date_estimated, i.fetched_at,
+		       i.hatebu_count, i.reading_time_minutes, i.created_at, i.updated_at,
+		       COALESCE(st.is_read, false)   AS is_read,
+		       COALESCE(st.is_starred, false) AS is_starred,
+		       f.title AS feed_title,
+		       f.favicon_data, COALESCE(f.favicon_mime, '') AS favicon_mime
+		FROM items i
+		JOIN subscriptions s ON s.feed_id = i.feed_id AND s.user_id = $1
+		JOIN feeds f ON f.id = i.feed_id
+		LEFT JOIN item_states st ON st.item_id = i.id AND st.user_id = $1
+		WHERE ` + rangeCond + ` AND COALESCE(st.is_hidden, false) = false` + filterCond + `
+		ORDER BY i.id
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pivotID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ランダム記事の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var items []CrossFeedItem
+	for rows.Next() {
+		var row CrossFeedItem
+		var publishedAt sql.NullTime
+		var guidOrID, link, summary, author sql.NullString
+
+		if err := rows.Scan(
+			&row.ID, &row.FeedID, &guidOrID, &row.Title, &link,
+			&summary, &author,
+			&publishedAt, &row.IsDateEstimated, &row.FetchedAt,
+			&row.HatebuCount, &row.ReadingTimeMinutes, &row.CreatedAt, &row.UpdatedAt,
+			&row.IsRead, &row.IsStarred,
+			&row.FeedTitle,
+			&row.FaviconData, &row.FaviconMime,
+		); err != nil {
+			return nil, fmt.Errorf("ランダム記事行の読み取りに失敗しました: %w", err)
+		}
+
+		row.GuidOrID = nullStringValue(guidOrID)
+		row.Link = nullStringValue(link)
+		row.Summary = nullStringValue(summary)
+		row.Author = nullStringValue(author)
+		if publishedAt.Valid {
+			row.PublishedAt = &publishedAt.Time
+		}
+
+		items = append(items, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ランダム記事行の走査に失敗しました: %w", err)
+	}
+
+	return items, nil
+}
+
+// ListLinkCheckTargets はリンク切れチェック対象のスター記事を取得する。
+// 複数ユーザーがスター付与していても 1 記事 1 行になるよう EXISTS で判定する。
+func (r *PostgresItemRepo) ListLinkCheckTargets(
+	ctx context.Context,
+	publishedBefore, checkedBefore time.Time,
+	limit int,
+) ([]LinkCheckTarget, error) {
+	query := `
+		SELECT i.id, i.link
+		FROM items i
+		WHERE EXISTS (
+		        SELECT 1 FROM item_states s
+		        WHERE s.item_id = i.id AND s.is_starred = true
+		      )
+		  AND i.link IS NOT NULL AND i.link <> ''
+		  AND COALESCE(i.published_at, i.created_at) < $1
+		  AND (i.link_checked_at IS NULL OR i.link_checked_at < $2)
+		ORDER BY i.link_checked_at ASC NULLS FIRST, i.id
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, publishedBefore, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("リンク切れチェック対象の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []LinkCheckTarget
+	for rows.Next() {
+		var t LinkCheckTarget
+		if err := rows.Scan(&t.ItemID, &t.Link); err != nil {
+			return nil, fmt.Errorf("リンク切れチェック対象行の読み取りに失敗しました: %w", err)
+		}
+		targets = append(targets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("リンク切れチェック対象の走査に失敗しました: %w", err)
+	}
+
+	return targets, nil
+}
+
+// RecordLinkCheck は記事のリンク切れチェック結果を記録する。
+// status が空文字列の場合は既存の link_status を保持し、link_checked_at のみ更新する。
+func (r *PostgresItemRepo) RecordLinkCheck(
+	ctx context.Context,
+	itemID string,
+	status model.LinkStatus,
+	checkedAt time.Time,
+) error {
+	query := `
+		UPDATE items
+		SET link_status = COALESCE(NULLIF($2, ''), link_status),
+		    link_checked_at = $3
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, itemID, string(status), checkedAt); err != nil {
+		return fmt.Errorf("リンク切れチェック結果の記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ListAuthorsByFeed はフィードの記事を著者名ごとに集計し、記事数の多い順で返す。
+// 著者名が NULL の記事は集計対象外とする。
+func (r *PostgresItemRepo) ListAuthorsByFeed(ctx context.Context, feedID string) ([]model.FeedAuthor, error) {
+	query := `
+		SELECT author, COUNT(*) AS item_count
+		FROM items
+		WHERE feed_id = $1 AND author IS NOT NULL
+		GROUP BY author
+		ORDER BY item_count DESC, author ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("著者一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var authors []model.FeedAuthor
+	for rows.Next() {
+		var a model.FeedAuthor
+		if err := rows.Scan(&a.Author, &a.ItemCount); err != nil {
+			return nil, fmt.Errorf("著者行の読み取りに失敗しました: %w", err)
+		}
+		authors = append(authors, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("著者一覧の走査に失敗しました: %w", err)
+	}
+	return authors, nil
+}
+
 // compile-time interface check
 var _ ItemRepository = (*PostgresItemRepo)(nil)
 var _ HatebuItemRepository = (*PostgresItemRepo)(nil)
 var _ ItemSearchRepository = (*PostgresItemRepo)(nil)
+var _ RandomItemRepository = (*PostgresItemRepo)(nil)
+var _ LinkCheckRepository = (*PostgresItemRepo)(nil)
+var _ FeedAuthorRepository = (*PostgresItemRepo)(nil)
+var _ ItemSummaryRepository = (*PostgresItemRepo)(nil)
+var _ ItemListModTimeRepository = (*PostgresItemRepo)(nil)
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	_ "github.com/lib/pq"
+)
+
+// insertAuthorTestItem は著者名付きのテスト用記事を挿入する。author が空の場合は NULL を保存する。
+func insertAuthorTestItem(t *testing.T, db *sql.DB, feedID, title, author string, publishedAt time.Time) string {
+	t.Helper()
+	itemID := insertStarredTestItem(t, db, feedID, title, publishedAt)
+	if _, err := db.Exec(`UPDATE items SET author = NULLIF($2, '') WHERE id = $1`, itemID, author); err != nil {
+		t.Fatalf("著者名の設定に失敗: %v", err)
+	}
+	return itemID
+}
+
+// TestPostgresItemRepo_Author はフィード内の著者一覧の集計と著者別の記事一覧絞り込みを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresItemRepo_Author(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("著者ごとの記事数を多い順に返し著者なしの記事と他フィードは含まない", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+
+		// Arrange
+		feed := insertTestFeedWithTitle(t, db, "https://example.com/author.xml", "Feed", "", model.FetchStatusActive)
+		other := insertTestFeedWithTitle(t, db, "https://example.com/author-other.xml", "Other", "", model.FetchStatusActive)
+		insertAuthorTestItem(t, db, feed, "a1", "Bob", now)
+		insertAuthorTestItem(t, db, feed, "a2", "Alice", now.Add(-time.Hour))
+		insertAuthorTestItem(t, db, feed, "a3", "Carol", now.Add(-2*time.Hour))
+		insertAuthorTestItem(t, db, feed, "a4", "Carol", now.Add(-3*time.Hour))
+		insertAuthorTestItem(t, db, feed, "a5", "", now.Add(-4*time.Hour))
+		insertAuthorTestItem(t, db, other, "o1", "Dave", now)
+
+		// Act
+		authors, err := repo.ListAuthorsByFeed(ctx, feed)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListAuthorsByFeed returned error: %v", err)
+		}
+		want := []model.FeedAuthor{
+			{Author: "Carol", ItemCount: 2},
+			{Author: "Alice", ItemCount: 1},
+			{Author: "Bob", ItemCount: 1},
+		}
+		if len(authors) != len(want) {
+			t.Fatalf("authors = %+v, want %+v", authors, want)
+		}
+		for i := range want {
+			if authors[i] != want[i] {
+				t.Errorf("authors[%d] = %+v, want %+v", i, authors[i], want[i])
+			}
+		}
+	})
+
+	t.Run("author を指定したとき該当著者の記事のみを返す", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+
+		// Arrange
+		user := insertTestUser(t, db, "author-filter@example.com")
+		feed := insertTestFeedWithTitle(t, db, "https://example.com/author-filter.xml", "Feed", "", model.FetchStatusActive)
+		carol1 := insertAuthorTestItem(t, db, feed, "c1", "Carol", now)
+		insertAuthorTestItem(t, db, feed, "b1", "Bob", now.Add(-time.Hour))
+		carol2 := insertAuthorTestItem(t, db, feed, "c2", "Carol", now.Add(-2*time.Hour))
+
+		// Act
+		items, err := repo.ListByFeed(ctx, feed, user, model.ItemConditions{}, "Carol", time.Time{}, "", 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListByFeed returned error: %v", err)
+		}
+		if len(items) != 2 || items[0].ID != carol1 || items[1].ID != carol2 {
+			t.Fatalf("items = %+v, want [%s %s]", items, carol1, carol2)
+		}
+		if items[0].Author != "Carol" {
+			t.Errorf("Author = %q, want %q", items[0].Author, "Carol")
+		}
+	})
+}
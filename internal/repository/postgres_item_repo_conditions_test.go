@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	_ "github.com/lib/pq"
+)
+
+// TestPostgresItemRepo_ListByFeed_Conditions は記事一覧の絞り込み条件の組み合わせを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresItemRepo_ListByFeed_Conditions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	on, off := true, false
+
+	db := setupListDueTestDB(t)
+	repo := NewPostgresItemRepo(db)
+
+	// Arrange: 未読スター・既読スター・未読スターなし・状態なし（未読スターなし扱い）の 4 記事
+	user := insertTestUser(t, db, "conditions@example.com")
+	feed := insertTestFeedWithTitle(t, db, "https://example.com/conditions.xml", "Feed", "", model.FetchStatusActive)
+	unreadStarred := insertStarredTestItem(t, db, feed, "unread-starred", now)
+	readStarred := insertStarredTestItem(t, db, feed, "read-starred", now.Add(-time.Hour))
+	unreadPlain := insertStarredTestItem(t, db, feed, "unread-plain", now.Add(-2*time.Hour))
+	noState := insertStarredTestItem(t, db, feed, "no-state", now.Add(-3*time.Hour))
+	insertCrossFeedTestItemState(t, db, user, unreadStarred, false, true)
+	insertCrossFeedTestItemState(t, db, user, readStarred, true, true)
+	insertCrossFeedTestItemState(t, db, user, unreadPlain, false, false)
+
+	tests := []struct {
+		name  string
+		conds model.ItemConditions
+		want  []string
+	}{
+		{name: "条件なしのとき全件を返す", conds: model.ItemConditions{}, want: []string{unreadStarred, readStarred, unreadPlain, noState}},
+		{name: "未読かつスター付きのとき両方を満たす記事のみを返す", conds: model.ItemConditions{Unread: &on, Starred: &on}, want: []string{unreadStarred}},
+		{name: "未読かつスターなしのとき状態のない記事も含めて返す", conds: model.ItemConditions{Unread: &on, Starred: &off}, want: []string{unreadPlain, noState}},
+		{name: "既読のみのとき既読記事を返す", conds: model.ItemConditions{Unread: &off}, want: []string{readStarred}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			items, err := repo.ListByFeed(ctx, feed, user, tt.conds, "", time.Time{}, "", 50)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ListByFeed returned error: %v", err)
+			}
+			if len(items) != len(tt.want) {
+				t.Fatalf("len(items) = %d, want %d", len(items), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if items[i].ID != id {
+					t.Errorf("items[%d].ID = %s, want %s", i, items[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+// TestPostgresItemRepo_ListByFeed_Hidden は非表示にした記事が既定で除外され、
+// IncludeHidden 指定時のみ is_hidden 付きで返ることを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresItemRepo_ListByFeed_Hidden(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	on := true
+
+	db := setupListDueTestDB(t)
+	repo := NewPostgresItemRepo(db)
+	stateRepo := NewPostgresItemStateRepo(db)
+
+	// Arrange: 非表示にした未読記事と、通常の未読記事の 2 件
+	user := insertTestUser(t, db, "hidden@example.com")
+	feed := insertTestFeedWithTitle(t, db, "https://example.com/hidden.xml", "Feed", "", model.FetchStatusActive)
+	visible := insertStarredTestItem(t, db, feed, "visible", now)
+	hidden := insertStarredTestItem(t, db, feed, "hidden", now.Add(-time.Hour))
+	state, err := stateRepo.Upsert(ctx, user, hidden, nil, nil, &on, nil)
+	if err != nil {
+		t.Fatalf("Upsert returned error: %v", err)
+	}
+	if !state.IsHidden || state.HiddenAt == nil || state.IsRead {
+		t.Fatalf("state = %+v, want hidden and unread", state)
+	}
+
+	t.Run("IncludeHidden未指定のとき非表示の記事を除外する", func(t *testing.T) {
+		// Act
+		items, err := repo.ListByFeed(ctx, feed, user, model.ItemConditions{Unread: &on}, "", time.Time{}, "", 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListByFeed returned error: %v", err)
+		}
+		if len(items) != 1 || items[0].ID != visible {
+			t.Fatalf("items = %+v, want only %s", items, visible)
+		}
+	})
+
+	t.Run("IncludeHidden指定のとき非表示の記事もis_hidden付きで返す", func(t *testing.T) {
+		// Act
+		items, err := repo.ListByFeed(ctx, feed, user, model.ItemConditions{IncludeHidden: true}, "", time.Time{}, "", 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListByFeed returned error: %v", err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("len(items) = %d, want 2", len(items))
+		}
+		if items[0].IsHidden || !items[1].IsHidden || items[1].ID != hidden {
+			t.Errorf("IsHidden = [%v %v], want [false true]", items[0].IsHidden, items[1].IsHidden)
+		}
+	})
+}
+
+// TestPostgresItemRepo_ListByFeeds は複数フィードの記事が 1 つの (published_at, id) 降順の一覧になり、
+// 指定外のフィードの記事を含まないことを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresItemRepo_ListByFeeds(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	db := setupListDueTestDB(t)
+	repo := NewPostgresItemRepo(db)
+	subRepo := NewPostgresSubscriptionRepo(db)
+
+	// Arrange: 3 フィードに記事を 1 件ずつ置き、ユーザーは A と B のみを購読する
+	user := insertTestUser(t, db, "multi-feed@example.com")
+	feedA := insertTestFeedWithTitle(t, db, "https://example.com/multi-a.xml", "A", "", model.FetchStatusActive)
+	feedB := insertTestFeedWithTitle(t, db, "https://example.com/multi-b.xml", "B", "", model.FetchStatusActive)
+	feedC := insertTestFeedWithTitle(t, db, "https://example.com/multi-c.xml", "C", "", model.FetchStatusActive)
+	itemA := insertStarredTestItem(t, db, feedA, "a", now.Add(-time.Hour))
+	itemB := insertStarredTestItem(t, db, feedB, "b", now)
+	insertStarredTestItem(t, db, feedC, "c", now.Add(-30*time.Minute))
+	insertTestSubscription(t, db, user, feedA)
+	insertTestSubscription(t, db, user, feedB)
+
+	t.Run("指定したフィードの記事を公開日時の降順で返す", func(t *testing.T) {
+		// Act
+		items, err := repo.ListByFeeds(ctx, []string{feedA, feedB}, user, model.ItemConditions{}, "", time.Time{}, "", 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListByFeeds returned error: %v", err)
+		}
+		if len(items) != 2 || items[0].ID != itemB || items[1].ID != itemA {
+			t.Fatalf("items = %+v, want [%s %s]", items, itemB, itemA)
+		}
+	})
+
+	t.Run("購読中のフィードのみを返す", func(t *testing.T) {
+		// Act
+		got, err := subRepo.FilterSubscribedFeedIDs(ctx, user, []string{feedA, feedB, feedC})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("FilterSubscribedFeedIDs returned error: %v", err)
+		}
+		if len(got) != 2 {
+			t.Errorf("subscribed = %v, want [%s %s]", got, feedA, feedB)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	_ "github.com/lib/pq"
+)
+
+// TestPostgresItemRepo_ListByFeed_CompositeCursor は (published_at, id) 複合カーソルによるページングを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresItemRepo_ListByFeed_CompositeCursor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	db := setupListDueTestDB(t)
+	repo := NewPostgresItemRepo(db)
+
+	// Arrange: 同一秒に公開された 5 記事と、それより古い 1 記事
+	user := insertTestUser(t, db, "cursor@example.com")
+	feed := insertTestFeedWithTitle(t, db, "https://example.com/cursor.xml", "Feed", "", model.FetchStatusActive)
+	var sameSecond []string
+	for _, guid := range []string{"a", "b", "c", "d", "e"} {
+		sameSecond = append(sameSecond, insertStarredTestItem(t, db, feed, guid, now))
+	}
+	older := insertStarredTestItem(t, db, feed, "older", now.Add(-time.Hour))
+	sort.Sort(sort.Reverse(sort.StringSlice(sameSecond)))
+	want := append(append([]string{}, sameSecond...), older)
+
+	t.Run("同一秒の記事がページ境界をまたぐとき重複も欠落もなく全件を返す", func(t *testing.T) {
+		// Act: 2 件ずつ末尾記事の (published_at, id) をカーソルにして読み進める
+		var got []string
+		var cursorAt time.Time
+		var cursorID string
+		for page := 0; page < 10; page++ {
+			items, err := repo.ListByFeed(ctx, feed, user, model.ItemConditions{}, "", cursorAt, cursorID, 2)
+			if err != nil {
+				t.Fatalf("ListByFeed returned error: %v", err)
+			}
+			if len(items) == 0 {
+				break
+			}
+			for _, it := range items {
+				got = append(got, it.ID)
+			}
+			last := items[len(items)-1]
+			cursorAt, cursorID = *last.PublishedAt, last.ID
+		}
+
+		// Assert
+		if len(got) != len(want) {
+			t.Fatalf("got %d items %v, want %d items %v", len(got), got, len(want), want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("items[%d] = %s, want %s", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("旧形式のカーソル（IDなし）のときpublished_atより古い記事のみを返す", func(t *testing.T) {
+		// Act
+		items, err := repo.ListByFeed(ctx, feed, user, model.ItemConditions{}, "", now, "", 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListByFeed returned error: %v", err)
+		}
+		if len(items) != 1 || items[0].ID != older {
+			t.Errorf("items = %v, want only %s", items, older)
+		}
+	})
+}
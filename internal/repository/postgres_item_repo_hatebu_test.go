@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	_ "github.com/lib/pq"
+)
+
+// TestPostgresItemRepo_ListNeedingHatebuFetch_Priority ははてブ取得対象の選定が
+// 優先度スコア（新しさ・はてブ数）の順になることを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresItemRepo_ListNeedingHatebuFetch_Priority(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	db := setupListDueTestDB(t)
+	repo := NewPostgresItemRepo(db)
+
+	// Arrange: 新しい記事・古い人気記事・古い記事・取得済み（TTL 内）の記事
+	feed := insertTestFeedWithTitle(t, db, "https://example.com/hatebu-priority.xml", "Feed", "", model.FetchStatusActive)
+	fresh := insertStarredTestItem(t, db, feed, "fresh", now.Add(-time.Hour))
+	popular := insertStarredTestItem(t, db, feed, "popular", now.Add(-10*24*time.Hour))
+	old := insertStarredTestItem(t, db, feed, "old", now.Add(-20*24*time.Hour))
+	recentlyFetched := insertStarredTestItem(t, db, feed, "recently-fetched", now)
+	if _, err := db.Exec(
+		`UPDATE items SET hatebu_count = 500, hatebu_fetched_at = now() - interval '2 days' WHERE id = $1`, popular,
+	); err != nil {
+		t.Fatalf("はてブ数の設定に失敗: %v", err)
+	}
+	if _, err := db.Exec(
+		`UPDATE items SET hatebu_count = 10, hatebu_fetched_at = now() WHERE id = $1`, recentlyFetched,
+	); err != nil {
+		t.Fatalf("取得日時の設定に失敗: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		priority model.HatebuFetchPriority
+		want     []string
+	}{
+		{
+			name:     "新しさのみを重視するとき公開日時が新しい順に返す",
+			priority: model.HatebuFetchPriority{RecencyWeight: 1, RecencyHalfLife: 24 * time.Hour},
+			want:     []string{fresh, popular, old},
+		},
+		{
+			name:     "はてブ数を重視するとき人気記事を先頭に返す",
+			priority: model.HatebuFetchPriority{RecencyWeight: 1, PopularityWeight: 1, RecencyHalfLife: 24 * time.Hour},
+			want:     []string{popular, fresh, old},
+		},
+		{
+			name:     "重みがいずれも0のとき未取得の記事を取得済みより先に返す",
+			priority: model.HatebuFetchPriority{},
+			want:     []string{fresh, old, popular},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			items, err := repo.ListNeedingHatebuFetch(ctx, 10, tt.priority)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ListNeedingHatebuFetch に失敗: %v", err)
+			}
+			var got []string
+			for _, it := range items {
+				got = append(got, it.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("件数 = %d, want %d（TTL 内の記事は含まない）", len(got), len(tt.want))
+			}
+			// 重み 0 のときは未取得同士の順序を規定しないため、取得済みの記事が最後に来ることのみ検証する
+			if tt.priority == (model.HatebuFetchPriority{}) {
+				if got[2] != tt.want[2] {
+					t.Errorf("最後の記事 = %s, want %s（取得済みは未取得より後）", got[2], tt.want[2])
+				}
+				return
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("順序[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	_ "github.com/lib/pq"
+)
+
+// TestPostgresItemRepo_LinkCheck はリンク切れチェック対象の抽出・結果の記録と、
+// スター記事一覧のリンク切れ絞り込みを検証する。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresItemRepo_LinkCheck(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	old := now.Add(-60 * 24 * time.Hour)
+
+	t.Run("公開から期間が経過した未チェックのスター記事のみ対象になる", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+
+		// Arrange
+		userA := insertTestUser(t, db, "linkcheck-a@example.com")
+		userB := insertTestUser(t, db, "linkcheck-b@example.com")
+		feed := insertTestFeedWithTitle(t, db, "https://example.com/linkcheck.xml", "Feed", "", model.FetchStatusActive)
+		target := insertStarredTestItem(t, db, feed, "old-starred", old)
+		recent := insertStarredTestItem(t, db, feed, "recent-starred", now)
+		unstarred := insertStarredTestItem(t, db, feed, "old-unstarred", old)
+		checked := insertStarredTestItem(t, db, feed, "old-checked", old)
+		// 複数ユーザーがスターしていても 1 件として返る
+		insertStarredTestItemState(t, db, userA, target, false, true)
+		insertStarredTestItemState(t, db, userB, target, false, true)
+		insertStarredTestItemState(t, db, userA, recent, false, true)
+		insertStarredTestItemState(t, db, userA, unstarred, false, false)
+		insertStarredTestItemState(t, db, userA, checked, false, true)
+		if err := repo.RecordLinkCheck(ctx, checked, model.LinkStatusOK, now); err != nil {
+			t.Fatalf("RecordLinkCheck returned error: %v", err)
+		}
+
+		// Act
+		targets, err := repo.ListLinkCheckTargets(ctx, now.Add(-30*24*time.Hour), now.Add(-30*24*time.Hour), 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListLinkCheckTargets returned error: %v", err)
+		}
+		if len(targets) != 1 || targets[0].ItemID != target {
+			t.Fatalf("targets = %+v, want only %s", targets, target)
+		}
+		if targets[0].Link != "https://example.com/article/old-starred" {
+			t.Errorf("Link = %q", targets[0].Link)
+		}
+	})
+
+	t.Run("判定保留のとき既存の判定結果を保持しスター一覧をリンク切れで絞り込める", func(t *testing.T) {
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+
+		// Arrange
+		user := insertTestUser(t, db, "linkcheck-filter@example.com")
+		feed := insertTestFeedWithTitle(t, db, "https://example.com/linkcheck-filter.xml", "Feed", "", model.FetchStatusActive)
+		broken := insertStarredTestItem(t, db, feed, "broken", old)
+		alive := insertStarredTestItem(t, db, feed, "alive", old.Add(time.Hour))
+		insertStarredTestItemState(t, db, user, broken, false, true)
+		insertStarredTestItemState(t, db, user, alive, false, true)
+		if err := repo.RecordLinkCheck(ctx, broken, model.LinkStatusNotFound, now.Add(-time.Hour)); err != nil {
+			t.Fatalf("RecordLinkCheck returned error: %v", err)
+		}
+		if err := repo.RecordLinkCheck(ctx, broken, "", now); err != nil {
+			t.Fatalf("RecordLinkCheck returned error: %v", err)
+		}
+		if err := repo.RecordLinkCheck(ctx, alive, model.LinkStatusOK, now); err != nil {
+			t.Fatalf("RecordLinkCheck returned error: %v", err)
+		}
+
+		// Act
+		rows, err := repo.ListStarredByUser(ctx, user, 0, time.Time{}, "", 50, model.StarredItemFilter{BrokenLinkOnly: true})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListStarredByUser returned error: %v", err)
+		}
+		if len(rows) != 1 || rows[0].ID != broken {
+			t.Fatalf("rows = %d 件, want only %s", len(rows), broken)
+		}
+		if rows[0].LinkStatus != model.LinkStatusNotFound {
+			t.Errorf("LinkStatus = %q, want %q", rows[0].LinkStatus, model.LinkStatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	_ "github.com/lib/pq"
+)
+
+// このファイルは PostgresItemRepo.PickRandomItems の DB 結合テスト。
+// テスト用 PostgreSQL に接続できない場合は setupListDueTestDB 経由で自動的にスキップされる。
+// 記事・状態の挿入には postgres_item_repo_cross_feed_test.go のヘルパを流用する。
+
+func TestPostgresItemRepo_PickRandomItems(t *testing.T) {
+	ctx := context.Background()
+	base := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	const minUUID = "00000000-0000-0000-0000-000000000000"
+	const maxUUID = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+
+	t.Run("unreadのとき購読中フィードの未読記事のみを返す", func(t *testing.T) {
+		// Arrange
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+		user := insertTestUser(t, db, "random-unread@example.com")
+		feedA := insertTestFeedWithTitle(t, db, "https://example.com/random-a.xml", "Feed A", "https://example.com/a", model.FetchStatusActive)
+		feedOther := insertTestFeedWithTitle(t, db, "https://example.com/random-other.xml", "Other", "https://example.com/o", model.FetchStatusActive)
+		insertTestSubscription(t, db, user, feedA)
+
+		unread := insertCrossFeedTestItem(t, db, feedA, "unread", base)
+		read := insertCrossFeedTestItem(t, db, feedA, "read", base.Add(time.Hour))
+		insertCrossFeedTestItemState(t, db, user, read, true, false)
+		_ = insertCrossFeedTestItem(t, db, feedOther, "not-subscribed", base)
+
+		// Act
+		rows, err := repo.PickRandomItems(ctx, user, model.ItemFilterUnread, minUUID, 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("PickRandomItems returned error: %v", err)
+		}
+		if len(rows) != 1 || rows[0].ID != unread {
+			t.Fatalf("rows = %+v, want 未読 1 件のみ", rows)
+		}
+		if rows[0].FeedTitle != "Feed A" || rows[0].IsRead {
+			t.Errorf("row = %+v, want FeedTitle=Feed A, IsRead=false", rows[0])
+		}
+	})
+
+	t.Run("pivot以降で不足するとき先頭に折り返して補う", func(t *testing.T) {
+		// Arrange
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+		user := insertTestUser(t, db, "random-wrap@example.com")
+		feed := insertTestFeedWithTitle(t, db, "https://example.com/random-wrap.xml", "Feed", "https://example.com/w", model.FetchStatusActive)
+		insertTestSubscription(t, db, user, feed)
+		_ = insertCrossFeedTestItem(t, db, feed, "a", base)
+		_ = insertCrossFeedTestItem(t, db, feed, "b", base)
+
+		// Act: 最大 UUID を起点にすると pivot 以降は 0 件のため全件が折り返しで返る
+		rows, err := repo.PickRandomItems(ctx, user, model.ItemFilterAll, maxUUID, 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("PickRandomItems returned error: %v", err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("返却件数 = %d, want 2", len(rows))
+		}
+	})
+
+	t.Run("limitを超える記事があるときlimit件で打ち切る", func(t *testing.T) {
+		// Arrange
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+		user := insertTestUser(t, db, "random-limit@example.com")
+		feed := insertTestFeedWithTitle(t, db, "https://example.com/random-limit.xml", "Feed", "https://example.com/l", model.FetchStatusActive)
+		insertTestSubscription(t, db, user, feed)
+		for _, title := range []string{"a", "b", "c"} {
+			_ = insertCrossFeedTestItem(t, db, feed, title, base)
+		}
+
+		// Act
+		rows, err := repo.PickRandomItems(ctx, user, model.ItemFilterAll, minUUID, 2)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("PickRandomItems returned error: %v", err)
+		}
+		if len(rows) != 2 {
+			t.Errorf("返却件数 = %d, want 2", len(rows))
+		}
+	})
+
+	t.Run("starredのときスター付き記事のみを返す", func(t *testing.T) {
+		// Arrange
+		db := setupListDueTestDB(t)
+		repo := NewPostgresItemRepo(db)
+		user := insertTestUser(t, db, "random-starred@example.com")
+		feed := insertTestFeedWithTitle(t, db, "https://example.com/random-starred.xml", "Feed", "https://example.com/s", model.FetchStatusActive)
+		insertTestSubscription(t, db, user, feed)
+		starred := insertCrossFeedTestItem(t, db, feed, "starred", base)
+		insertCrossFeedTestItemState(t, db, user, starred, true, true)
+		_ = insertCrossFeedTestItem(t, db, feed, "plain", base)
+
+		// Act
+		rows, err := repo.PickRandomItems(ctx, user, model.ItemFilterStarred, minUUID, 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("PickRandomItems returned error: %v", err)
+		}
+		if len(rows) != 1 || rows[0].ID != starred || !rows[0].IsStarred {
+			t.Fatalf("rows = %+v, want スター付き 1 件のみ", rows)
+		}
+	})
+}
@@ -44,11 +44,35 @@ func setupItemSearchTestDB(t *testing.T) *sql.DB {
 	}
 
 	cleanupSQL := `
+		DROP TABLE IF EXISTS feed_raw_captures CASCADE;
+		DROP TABLE IF EXISTS read_later_deliveries CASCADE;
+		DROP TABLE IF EXISTS read_later_connections CASCADE;
+		DROP TABLE IF EXISTS feed_registration_batches CASCADE;
+		DROP TABLE IF EXISTS item_highlights CASCADE;
+		DROP TABLE IF EXISTS feed_keywords CASCADE;
+		DROP TABLE IF EXISTS login_tokens CASCADE;
+		DROP TABLE IF EXISTS api_usage_daily CASCADE;
+		DROP TABLE IF EXISTS login_events CASCADE;
+		DROP TABLE IF EXISTS blocked_domains CASCADE;
+		DROP TABLE IF EXISTS integration_deliveries CASCADE;
+		DROP TABLE IF EXISTS integrations CASCADE;
 		DROP TABLE IF EXISTS user_cross_feed_views CASCADE;
 		DROP TABLE IF EXISTS sessions CASCADE;
 		DROP TABLE IF EXISTS user_settings CASCADE;
+		DROP MATERIALIZED VIEW IF EXISTS admin_stats;
+		DROP TABLE IF EXISTS fetch_attempts CASCADE;
+		DROP TABLE IF EXISTS idempotency_keys CASCADE;
+		DROP TABLE IF EXISTS weekly_subscription_stats CASCADE;
+		DROP TABLE IF EXISTS worker_cycles CASCADE;
+		DROP TABLE IF EXISTS subscription_undos CASCADE;
+		DROP TABLE IF EXISTS team_invitations CASCADE;
+		DROP TABLE IF EXISTS team_feeds CASCADE;
+		DROP TABLE IF EXISTS team_members CASCADE;
+		DROP TABLE IF EXISTS audit_logs CASCADE;
+		DROP TABLE IF EXISTS item_views CASCADE;
 		DROP TABLE IF EXISTS item_states CASCADE;
 		DROP TABLE IF EXISTS subscriptions CASCADE;
+		DROP TABLE IF EXISTS teams CASCADE;
 		DROP TABLE IF EXISTS items CASCADE;
 		DROP TABLE IF EXISTS feeds CASCADE;
 		DROP TABLE IF EXISTS identities CASCADE;
@@ -78,7 +78,7 @@ func TestPostgresItemRepo_ListStarredByUser(t *testing.T) {
 		insertStarredTestItemState(t, db, user, newerItem, false, true)
 
 		// Act
-		rows, err := repo.ListStarredByUser(ctx, user, time.Time{}, 50)
+		rows, err := repo.ListStarredByUser(ctx, user, 0, time.Time{}, "", 50, model.StarredItemFilter{})
 		if err != nil {
 			t.Fatalf("ListStarredByUser returned error: %v", err)
 		}
@@ -131,7 +131,7 @@ func TestPostgresItemRepo_ListStarredByUser(t *testing.T) {
 		insertStarredTestItemState(t, db, userB, itemB, false, true)
 
 		// Act: userA の一覧を取得する。
-		rows, err := repo.ListStarredByUser(ctx, userA, time.Time{}, 50)
+		rows, err := repo.ListStarredByUser(ctx, userA, 0, time.Time{}, "", 50, model.StarredItemFilter{})
 		if err != nil {
 			t.Fatalf("ListStarredByUser returned error: %v", err)
 		}
@@ -160,7 +160,7 @@ func TestPostgresItemRepo_ListStarredByUser(t *testing.T) {
 		insertStarredTestItemState(t, db, user, unstarred, false, false) // 既読/スター無しの状態行
 
 		// Act
-		rows, err := repo.ListStarredByUser(ctx, user, time.Time{}, 50)
+		rows, err := repo.ListStarredByUser(ctx, user, 0, time.Time{}, "", 50, model.StarredItemFilter{})
 		if err != nil {
 			t.Fatalf("ListStarredByUser returned error: %v", err)
 		}
@@ -195,7 +195,7 @@ func TestPostgresItemRepo_ListStarredByUser(t *testing.T) {
 		insertStarredTestItemState(t, db, user, future, false, true)
 
 		// Act: cursor = pubAtMid を指定（境界条件: i.published_at < pubAtMid のみ返る）
-		rows, err := repo.ListStarredByUser(ctx, user, pubAtMid, 50)
+		rows, err := repo.ListStarredByUser(ctx, user, 0, pubAtMid, "", 50, model.StarredItemFilter{})
 		if err != nil {
 			t.Fatalf("ListStarredByUser returned error: %v", err)
 		}
@@ -209,7 +209,7 @@ func TestPostgresItemRepo_ListStarredByUser(t *testing.T) {
 		}
 
 		// 補足: cursor=zero では全件返ることを確認（境界の双方向確認）
-		allRows, err := repo.ListStarredByUser(ctx, user, time.Time{}, 50)
+		allRows, err := repo.ListStarredByUser(ctx, user, 0, time.Time{}, "", 50, model.StarredItemFilter{})
 		if err != nil {
 			t.Fatalf("ListStarredByUser (cursor=zero) returned error: %v", err)
 		}
@@ -233,7 +233,7 @@ func TestPostgresItemRepo_ListStarredByUser(t *testing.T) {
 		insertStarredTestItemState(t, db, user, item, false, false)
 
 		// Act
-		rows, err := repo.ListStarredByUser(ctx, user, time.Time{}, 50)
+		rows, err := repo.ListStarredByUser(ctx, user, 0, time.Time{}, "", 50, model.StarredItemFilter{})
 		if err != nil {
 			t.Fatalf("ListStarredByUser returned error: %v", err)
 		}
@@ -259,7 +259,7 @@ func TestPostgresItemRepo_ListStarredByUser(t *testing.T) {
 		insertStarredTestItemState(t, db, user, item3, false, true)
 
 		// Act: limit=2 を指定
-		rows, err := repo.ListStarredByUser(ctx, user, time.Time{}, 2)
+		rows, err := repo.ListStarredByUser(ctx, user, 0, time.Time{}, "", 2, model.StarredItemFilter{})
 		if err != nil {
 			t.Fatalf("ListStarredByUser returned error: %v", err)
 		}
@@ -23,16 +23,16 @@ func NewPostgresItemStateRepo(db *sql.DB) *PostgresItemStateRepo {
 // FindByUserAndItem はユーザーIDと記事IDで記事状態を取得する。見つからない場合はnilを返す。
 func (r *PostgresItemStateRepo) FindByUserAndItem(ctx context.Context, userID, itemID string) (*model.ItemState, error) {
 	state := &model.ItemState{}
-	var readAt, starredAt sql.NullTime
+	var readAt, starredAt, hiddenAt, lastVisitedAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, user_id, item_id, is_read, is_starred, read_at, starred_at, created_at, updated_at
+		`SELECT id, user_id, item_id, is_read, is_starred, is_hidden, rating, read_at, starred_at, hidden_at, last_visited_at, created_at, updated_at
 		 FROM item_states WHERE user_id = $1 AND item_id = $2`,
 		userID, itemID,
 	).Scan(
 		&state.ID, &state.UserID, &state.ItemID,
-		&state.IsRead, &state.IsStarred,
-		&readAt, &starredAt,
+		&state.IsRead, &state.IsStarred, &state.IsHidden, &state.Rating,
+		&readAt, &starredAt, &hiddenAt, &lastVisitedAt,
 		&state.CreatedAt, &state.UpdatedAt,
 	)
 
@@ -49,18 +49,27 @@ func (r *PostgresItemStateRepo) FindByUserAndItem(ctx context.Context, userID, i
 	if starredAt.Valid {
 		state.StarredAt = &starredAt.Time
 	}
+	if hiddenAt.Valid {
+		state.HiddenAt = &hiddenAt.Time
+	}
+	if lastVisitedAt.Valid {
+		state.LastVisitedAt = &lastVisitedAt.Time
+	}
 
 	return state, nil
 }
 
 // Upsert は記事状態を冪等にUPSERTする。
 // nilフィールドは変更せず、既存の値を維持する部分更新を行う。
+// スターと評価は model.ApplyStarRating で評価を決め、is_starred を評価 1 以上かどうかに揃える。
 // UNIQUE(user_id, item_id)制約を利用したINSERT ON CONFLICTで実装する。
 func (r *PostgresItemStateRepo) Upsert(
 	ctx context.Context,
 	userID, itemID string,
 	isRead *bool,
 	isStarred *bool,
+	isHidden *bool,
+	rating *int,
 ) (*model.ItemState, error) {
 	now := time.Now().UTC()
 
@@ -88,25 +97,33 @@ func (r *PostgresItemStateRepo) Upsert(
 				state.ReadAt = &now
 			}
 		}
-		if isStarred != nil {
-			state.IsStarred = *isStarred
-			if *isStarred {
-				state.StarredAt = &now
+		state.Rating = model.ApplyStarRating(0, isStarred, rating)
+		if state.Rating > 0 {
+			state.IsStarred = true
+			state.StarredAt = &now
+		}
+		if isHidden != nil {
+			state.IsHidden = *isHidden
+			if *isHidden {
+				state.HiddenAt = &now
 			}
 		}
 
 		_, err := r.db.ExecContext(ctx,
-			`INSERT INTO item_states (id, user_id, item_id, is_read, is_starred, read_at, starred_at, created_at, updated_at)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			`INSERT INTO item_states (id, user_id, item_id, is_read, is_starred, is_hidden, rating, read_at, starred_at, hidden_at, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 			 ON CONFLICT (user_id, item_id) DO UPDATE SET
 			     is_read = EXCLUDED.is_read,
 			     is_starred = EXCLUDED.is_starred,
+			     is_hidden = EXCLUDED.is_hidden,
+			     rating = EXCLUDED.rating,
 			     read_at = EXCLUDED.read_at,
 			     starred_at = EXCLUDED.starred_at,
+			     hidden_at = EXCLUDED.hidden_at,
 			     updated_at = EXCLUDED.updated_at`,
 			state.ID, state.UserID, state.ItemID,
-			state.IsRead, state.IsStarred,
-			state.ReadAt, state.StarredAt,
+			state.IsRead, state.IsStarred, state.IsHidden, state.Rating,
+			state.ReadAt, state.StarredAt, state.HiddenAt,
 			state.CreatedAt, state.UpdatedAt,
 		)
 		if err != nil {
@@ -126,23 +143,33 @@ func (r *PostgresItemStateRepo) Upsert(
 			existing.ReadAt = nil
 		}
 	}
-	if isStarred != nil {
-		existing.IsStarred = *isStarred
-		if *isStarred && existing.StarredAt == nil {
+	if isStarred != nil || rating != nil {
+		existing.Rating = model.ApplyStarRating(existing.Rating, isStarred, rating)
+		existing.IsStarred = existing.Rating > 0
+		if existing.IsStarred && existing.StarredAt == nil {
 			existing.StarredAt = &now
-		} else if !*isStarred {
+		} else if !existing.IsStarred {
 			existing.StarredAt = nil
 		}
 	}
+	if isHidden != nil {
+		existing.IsHidden = *isHidden
+		if *isHidden && existing.HiddenAt == nil {
+			existing.HiddenAt = &now
+		} else if !*isHidden {
+			existing.HiddenAt = nil
+		}
+	}
 
 	_, err = r.db.ExecContext(ctx,
 		`UPDATE item_states SET
-		    is_read = $3, is_starred = $4, read_at = $5, starred_at = $6, updated_at = $7
+		    is_read = $3, is_starred = $4, is_hidden = $5, rating = $10,
+		    read_at = $6, starred_at = $7, hidden_at = $8, updated_at = $9
 		 WHERE user_id = $1 AND item_id = $2`,
 		existing.UserID, existing.ItemID,
-		existing.IsRead, existing.IsStarred,
-		existing.ReadAt, existing.StarredAt,
-		existing.UpdatedAt,
+		existing.IsRead, existing.IsStarred, existing.IsHidden,
+		existing.ReadAt, existing.StarredAt, existing.HiddenAt,
+		existing.UpdatedAt, existing.Rating,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("記事状態の更新に失敗しました: %w", err)
@@ -185,5 +212,85 @@ func (r *PostgresItemStateRepo) DeleteByUserIDExec(ctx context.Context, q DBTX,
 	return nil
 }
 
+// MarkVisited は記事を既読にし、最終訪問日時（last_visited_at）を visitedAt で記録する。
+// 記事状態が未作成の場合は既読・スターなしの状態で作成する。
+// read_at は既読化済みの場合は最初の既読日時を維持する。
+func (r *PostgresItemStateRepo) MarkVisited(ctx context.Context, userID, itemID string, visitedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO item_states (id, user_id, item_id, is_read, is_starred, read_at, last_visited_at, created_at, updated_at)
+		 VALUES ($1, $2, $3, true, false, $4, $4, $4, $4)
+		 ON CONFLICT (user_id, item_id) DO UPDATE SET
+		     is_read = true,
+		     read_at = COALESCE(item_states.read_at, EXCLUDED.read_at),
+		     last_visited_at = EXCLUDED.last_visited_at,
+		     updated_at = EXCLUDED.updated_at`,
+		uuid.New().String(), userID, itemID, visitedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("記事の訪問記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// syncReadQuery / syncStarQuery はオフライン同期の操作を last-write-wins で適用する UPSERT。
+// スターの操作は評価を持たないため、スターを付ける場合は評価済みの評価を維持し（未評価なら 1）、外す場合は未評価に戻す。
+// 既存の行は、操作の時刻（$5）が状態の最終変更日時より新しい場合に限り更新する（WHERE 句が偽なら 0 行）。
+// 最終変更日時が未記録の行は、既読・スター済みなら updated_at、そうでなければ未変更（常に操作が勝つ）とみなす。
+const (
+	syncReadQuery = `INSERT INTO item_states (id, user_id, item_id, is_read, is_starred, read_at, read_changed_at, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4::boolean, false, CASE WHEN $4::boolean THEN $5::timestamptz END, $5, now(), now())
+		 ON CONFLICT (user_id, item_id) DO UPDATE SET
+		     is_read = EXCLUDED.is_read,
+		     read_at = CASE WHEN EXCLUDED.is_read THEN COALESCE(item_states.read_at, EXCLUDED.read_at) END,
+		     read_changed_at = EXCLUDED.read_changed_at,
+		     updated_at = now()
+		 WHERE (COALESCE(item_states.read_changed_at,
+		                 CASE WHEN item_states.is_read THEN item_states.updated_at END)
+		        < EXCLUDED.read_changed_at) IS NOT FALSE`
+	syncStarQuery = `INSERT INTO item_states (id, user_id, item_id, is_read, is_starred, rating, starred_at, starred_changed_at, created_at, updated_at)
+		 VALUES ($1, $2, $3, false, $4::boolean, CASE WHEN $4::boolean THEN 1 ELSE 0 END, CASE WHEN $4::boolean THEN $5::timestamptz END, $5, now(), now())
+		 ON CONFLICT (user_id, item_id) DO UPDATE SET
+		     is_starred = EXCLUDED.is_starred,
+		     rating = CASE WHEN EXCLUDED.is_starred THEN GREATEST(item_states.rating, 1) ELSE 0 END,
+		     starred_at = CASE WHEN EXCLUDED.is_starred THEN COALESCE(item_states.starred_at, EXCLUDED.starred_at) END,
+		     starred_changed_at = EXCLUDED.starred_changed_at,
+		     updated_at = now()
+		 WHERE (COALESCE(item_states.starred_changed_at,
+		                 CASE WHEN item_states.is_starred THEN item_states.updated_at END)
+		        < EXCLUDED.starred_changed_at) IS NOT FALSE`
+)
+
+// ApplySyncOperation は記事の既読またはスター状態を、操作の時刻 changedAt が現在の状態の最終変更日時より
+// 新しい場合に限り value に更新する（last-write-wins）。状態の最終変更日時には changedAt を記録する。
+// 適用した場合は applied=true を返し、いずれの場合も操作後の記事状態を返す。
+func (r *PostgresItemStateRepo) ApplySyncOperation(
+	ctx context.Context,
+	userID, itemID string,
+	opType model.SyncOperationType,
+	value bool,
+	changedAt time.Time,
+) (*model.ItemState, bool, error) {
+	query := syncReadQuery
+	if opType == model.SyncOperationStar {
+		query = syncStarQuery
+	}
+	result, err := r.db.ExecContext(ctx, query, uuid.New().String(), userID, itemID, value, changedAt.UTC())
+	if err != nil {
+		return nil, false, fmt.Errorf("同期操作の適用に失敗しました: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("同期操作の適用結果の取得に失敗しました: %w", err)
+	}
+
+	state, err := r.FindByUserAndItem(ctx, userID, itemID)
+	if err != nil {
+		return nil, false, err
+	}
+	return state, affected > 0, nil
+}
+
 // compile-time interface check
 var _ ItemStateRepository = (*PostgresItemStateRepo)(nil)
+var _ ItemVisitRepository = (*PostgresItemStateRepo)(nil)
+var _ ItemStateSyncRepository = (*PostgresItemStateRepo)(nil)
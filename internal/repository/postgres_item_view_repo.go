@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/lib/pq"
+)
+
+// PostgresItemViewRepo は PostgreSQL を使用した記事閲覧イベントリポジトリ。
+type PostgresItemViewRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresItemViewRepo は PostgresItemViewRepo を生成する。
+func NewPostgresItemViewRepo(db *sql.DB) *PostgresItemViewRepo {
+	return &PostgresItemViewRepo{db: db}
+}
+
+// InsertBatch は閲覧イベントを 1 文の INSERT ... SELECT でまとめて保存する。
+// feed_id は items から補完する。items / users との JOIN により、記録から保存までの間に
+// 削除された記事・退会ユーザーのイベントは外部キー違反にせず破棄する。
+func (r *PostgresItemViewRepo) InsertBatch(ctx context.Context, views []model.ItemView) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	userIDs := make([]string, len(views))
+	itemIDs := make([]string, len(views))
+	viewedAts := make([]string, len(views))
+	for i, v := range views {
+		userIDs[i] = v.UserID
+		itemIDs[i] = v.ItemID
+		viewedAts[i] = v.ViewedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO item_views (user_id, item_id, feed_id, viewed_at)
+		 SELECT v.user_id, v.item_id, i.feed_id, v.viewed_at
+		 FROM unnest($1::uuid[], $2::uuid[], $3::timestamptz[]) AS v(user_id, item_id, viewed_at)
+		 JOIN items i ON i.id = v.item_id
+		 JOIN users u ON u.id = v.user_id`,
+		pq.Array(userIDs), pq.Array(itemIDs), pq.Array(viewedAts),
+	)
+	if err != nil {
+		return fmt.Errorf("閲覧イベントの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// TopFeedsByUser は since 以降の閲覧回数が多い順にフィードを最大 limit 件返す。
+// 閲覧回数が同じ場合は最終閲覧が新しいフィードを優先する。
+func (r *PostgresItemViewRepo) TopFeedsByUser(ctx context.Context, userID string, since time.Time, limit int) ([]model.FeedViewStat, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT v.feed_id, f.title, COUNT(*), COUNT(DISTINCT v.item_id), MAX(v.viewed_at)
+		 FROM item_views v
+		 JOIN feeds f ON f.id = v.feed_id
+		 WHERE v.user_id = $1 AND v.viewed_at >= $2
+		 GROUP BY v.feed_id, f.title
+		 ORDER BY COUNT(*) DESC, MAX(v.viewed_at) DESC
+		 LIMIT $3`,
+		userID, since, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("よく読むフィードの集計に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []model.FeedViewStat
+	for rows.Next() {
+		var s model.FeedViewStat
+		if err := rows.Scan(&s.FeedID, &s.FeedTitle, &s.ViewCount, &s.ItemCount, &s.LastViewedAt); err != nil {
+			return nil, fmt.Errorf("よく読むフィードの読み取りに失敗しました: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("よく読むフィードの走査に失敗しました: %w", err)
+	}
+	return stats, nil
+}
+
+// ActiveHoursByFeed は当該フィードを購読しプリフェッチを有効にしているユーザーの利用時間帯を返す。
+// 閲覧イベントをユーザー × 時（UTC）で集計し、閲覧のあった日数が minDays 日以上の時間帯を
+// ユーザー間で重複を除いて昇順に返す。閲覧するフィードを問わず集計するため、
+// 当該フィードの記事をまだ読んでいない時間帯でも一覧を開く時間帯として扱う。
+func (r *PostgresItemViewRepo) ActiveHoursByFeed(ctx context.Context, feedID string, since time.Time, minDays int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT DISTINCT h.hour
+		 FROM (
+		   SELECT v.user_id,
+		          EXTRACT(HOUR FROM v.viewed_at AT TIME ZONE 'UTC')::int AS hour,
+		          COUNT(DISTINCT (v.viewed_at AT TIME ZONE 'UTC')::date) AS days
+		   FROM item_views v
+		   JOIN subscriptions s ON s.user_id = v.user_id AND s.feed_id = $1
+		   JOIN user_settings us ON us.user_id = v.user_id AND us.prefetch_enabled
+		   WHERE v.viewed_at >= $2
+		   GROUP BY v.user_id, hour
+		 ) h
+		 WHERE h.days >= $3
+		 ORDER BY h.hour`,
+		feedID, since, minDays,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("利用時間帯の集計に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var hours []int
+	for rows.Next() {
+		var h int
+		if err := rows.Scan(&h); err != nil {
+			return nil, fmt.Errorf("利用時間帯の読み取りに失敗しました: %w", err)
+		}
+		hours = append(hours, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("利用時間帯の走査に失敗しました: %w", err)
+	}
+	return hours, nil
+}
+
+// compile-time interface check
+var (
+	_ ItemViewRepository   = (*PostgresItemViewRepo)(nil)
+	_ ActiveHourRepository = (*PostgresItemViewRepo)(nil)
+)
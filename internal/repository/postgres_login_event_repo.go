@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresLoginEventRepo は PostgreSQL を使用したログインイベントリポジトリ。
+type PostgresLoginEventRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresLoginEventRepo は PostgresLoginEventRepo を生成する。
+func NewPostgresLoginEventRepo(db *sql.DB) *PostgresLoginEventRepo {
+	return &PostgresLoginEventRepo{db: db}
+}
+
+// Create はログインイベントを 1 件保存し、採番された ID と保存日時を event に書き戻す。
+// UserID が空の場合は user_id を NULL、CreatedAt がゼロ値の場合は DB の now() を用いる。
+func (r *PostgresLoginEventRepo) Create(ctx context.Context, event *model.LoginEvent) error {
+	var userID sql.NullString
+	if event.UserID != "" {
+		userID = sql.NullString{String: event.UserID, Valid: true}
+	}
+	var createdAt sql.NullTime
+	if !event.CreatedAt.IsZero() {
+		createdAt = sql.NullTime{Time: event.CreatedAt.UTC(), Valid: true}
+	}
+
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO login_events (user_id, event, reason, ip_address, user_agent, created_at)
+		 VALUES ($1, $2, $3, $4, $5, COALESCE($6, now()))
+		 RETURNING id, created_at`,
+		userID, string(event.Event), event.Reason, event.IPAddress, event.UserAgent, createdAt,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("ログインイベントの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ListByUser は当該ユーザーのログインイベントを (created_at, id) の降順で最大 limit 件返す。
+// cursorCreatedAt がゼロ値でない場合は (cursorCreatedAt, cursorID) より古いものだけを返す。
+func (r *PostgresLoginEventRepo) ListByUser(ctx context.Context, userID string, cursorCreatedAt time.Time, cursorID string, limit int) ([]model.LoginEvent, error) {
+	query := `SELECT id, user_id, event, reason, ip_address, user_agent, created_at
+		 FROM login_events
+		 WHERE user_id = $1`
+	args := []interface{}{userID}
+	if !cursorCreatedAt.IsZero() {
+		query += ` AND (created_at, id) < ($2, $3::uuid)`
+		args = append(args, cursorCreatedAt, cursorID)
+	}
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ログインイベントの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var events []model.LoginEvent
+	for rows.Next() {
+		var e model.LoginEvent
+		var eventType string
+		if err := rows.Scan(&e.ID, &e.UserID, &eventType, &e.Reason, &e.IPAddress, &e.UserAgent, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("ログインイベントの行読み取りに失敗しました: %w", err)
+		}
+		e.Event = model.LoginEventType(eventType)
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ログインイベントの走査に失敗しました: %w", err)
+	}
+
+	return events, nil
+}
+
+// compile-time interface check
+var _ LoginEventRepository = (*PostgresLoginEventRepo)(nil)
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresLoginTokenRepo は PostgreSQL を使用したマジックリンクのトークンのリポジトリ。
+type PostgresLoginTokenRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresLoginTokenRepo は PostgresLoginTokenRepo を生成する。
+func NewPostgresLoginTokenRepo(db *sql.DB) *PostgresLoginTokenRepo {
+	return &PostgresLoginTokenRepo{db: db}
+}
+
+// Create はトークンを保存する。
+func (r *PostgresLoginTokenRepo) Create(ctx context.Context, token *model.LoginToken) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO login_tokens (token_hash, email, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4)`,
+		token.TokenHash, token.Email, token.ExpiresAt, token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create login token: %w", err)
+	}
+	return nil
+}
+
+// CountCreatedSince は email に since 以降に発行したトークンの数を返す。
+func (r *PostgresLoginTokenRepo) CountCreatedSince(ctx context.Context, email string, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT count(*) FROM login_tokens WHERE email = $1 AND created_at >= $2`,
+		email, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count login tokens: %w", err)
+	}
+	return count, nil
+}
+
+// Consume は未使用かつ有効なトークンを使用済みにして返す。
+// 条件付きの UPDATE で使用済みにするため、同じトークンを同時に検証しても 1 回しか成功しない。
+func (r *PostgresLoginTokenRepo) Consume(ctx context.Context, tokenHash string, now time.Time) (*model.LoginToken, error) {
+	token := &model.LoginToken{}
+	var usedAt time.Time
+	err := r.db.QueryRowContext(ctx,
+		`UPDATE login_tokens SET used_at = $2
+		 WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		 RETURNING token_hash, email, expires_at, used_at, created_at`,
+		tokenHash, now,
+	).Scan(&token.TokenHash, &token.Email, &token.ExpiresAt, &usedAt, &token.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume login token: %w", err)
+	}
+	token.UsedAt = &usedAt
+	return token, nil
+}
+
+// DeleteExpired は有効期限が before 以前のトークンを削除する。
+func (r *PostgresLoginTokenRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM login_tokens WHERE expires_at <= $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired login tokens: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+var _ LoginTokenRepository = (*PostgresLoginTokenRepo)(nil)
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介したマジックリンクのトークンの結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresLoginTokenRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	repo := NewPostgresLoginTokenRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	create := func(t *testing.T, hash, email string, createdAt time.Time) {
+		t.Helper()
+		token := &model.LoginToken{TokenHash: hash, Email: email, ExpiresAt: createdAt.Add(model.LoginTokenTTL), CreatedAt: createdAt}
+		if err := repo.Create(ctx, token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	hash := func(c string) string { return strings.Repeat(c, 64) }
+
+	create(t, hash("a"), "user@example.com", now)
+	create(t, hash("b"), "user@example.com", now.Add(-time.Hour))
+	create(t, hash("c"), "other@example.com", now)
+
+	t.Run("期間内に発行したトークンの数をメールアドレスごとに返す", func(t *testing.T) {
+		count, err := repo.CountCreatedSince(ctx, "user@example.com", now.Add(-model.LoginTokenTTL))
+		if err != nil {
+			t.Fatalf("CountCreatedSince() error = %v", err)
+		}
+		if count != 1 {
+			t.Errorf("count = %d, want 1", count)
+		}
+	})
+
+	t.Run("有効なトークンは1回だけ使用できる", func(t *testing.T) {
+		var wg sync.WaitGroup
+		results := make([]*model.LoginToken, 5)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := repo.Consume(ctx, hash("a"), now.Add(time.Minute))
+				if err != nil {
+					t.Errorf("Consume() error = %v", err)
+				}
+				results[i] = token
+			}()
+		}
+		wg.Wait()
+
+		var consumed []*model.LoginToken
+		for _, r := range results {
+			if r != nil {
+				consumed = append(consumed, r)
+			}
+		}
+		if len(consumed) != 1 {
+			t.Fatalf("成功した回数 = %d, want 1", len(consumed))
+		}
+		if consumed[0].Email != "user@example.com" || consumed[0].UsedAt == nil {
+			t.Errorf("token = %+v", consumed[0])
+		}
+	})
+
+	t.Run("期限切れのトークンは使用できない", func(t *testing.T) {
+		token, err := repo.Consume(ctx, hash("b"), now)
+		if err != nil {
+			t.Fatalf("Consume() error = %v", err)
+		}
+		if token != nil {
+			t.Errorf("token = %+v, want nil", token)
+		}
+	})
+
+	t.Run("期限切れのトークンを削除する", func(t *testing.T) {
+		deleted, err := repo.DeleteExpired(ctx, now)
+		if err != nil {
+			t.Fatalf("DeleteExpired() error = %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("deleted = %d, want 1", deleted)
+		}
+	})
+}
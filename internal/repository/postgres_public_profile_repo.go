@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/lib/pq"
+)
+
+// ErrPublicSlugTaken は公開プロフィールのスラッグが他ユーザーに使用済みであることを表す。
+var ErrPublicSlugTaken = errors.New("public slug is already taken")
+
+// pgErrCodeUniqueViolation は PostgreSQL の unique_violation エラーコード。
+const pgErrCodeUniqueViolation = "23505"
+
+// PostgresPublicProfileRepo は PostgreSQL を使用した公開プロフィールリポジトリ。
+type PostgresPublicProfileRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresPublicProfileRepo は PostgresPublicProfileRepo を生成する。
+func NewPostgresPublicProfileRepo(db *sql.DB) *PostgresPublicProfileRepo {
+	return &PostgresPublicProfileRepo{db: db}
+}
+
+// GetByUserID は当該ユーザーの公開設定を取得する。
+// user_settings に行が存在しない場合は (nil, nil) を返す。
+func (r *PostgresPublicProfileRepo) GetByUserID(ctx context.Context, userID string) (*model.PublicProfile, error) {
+	profile := &model.PublicProfile{}
+	var slug sql.NullString
+	err := r.db.QueryRowContext(ctx,
+		`SELECT user_id, public_profile, public_slug
+		 FROM user_settings WHERE user_id = $1`,
+		userID,
+	).Scan(&profile.UserID, &profile.Enabled, &slug)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("公開プロフィール設定の取得に失敗しました: %w", err)
+	}
+	profile.Slug = nullStringValue(slug)
+
+	return profile, nil
+}
+
+// Upsert は user_id をキーに公開フラグとスラッグを冪等に上書き保存する。
+// user_settings に行が無ければ新規挿入し（theme は既定値）、存在すれば公開設定のみ更新する。
+// スラッグの一意制約違反は ErrPublicSlugTaken に変換する。
+func (r *PostgresPublicProfileRepo) Upsert(ctx context.Context, profile *model.PublicProfile) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_settings (user_id, public_profile, public_slug, updated_at)
+		 VALUES ($1, $2, $3, now())
+		 ON CONFLICT (user_id) DO UPDATE
+		   SET public_profile = EXCLUDED.public_profile,
+		       public_slug    = EXCLUDED.public_slug,
+		       updated_at     = now()`,
+		profile.UserID, profile.Enabled, nullString(profile.Slug),
+	)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && string(pgErr.Code) == pgErrCodeUniqueViolation {
+			return ErrPublicSlugTaken
+		}
+		return fmt.Errorf("公開プロフィール設定の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// FindUserIDBySlug は公開が有効なプロフィールのスラッグからユーザーIDを取得する。
+// 該当なし、または公開が無効な場合は空文字を返す。
+func (r *PostgresPublicProfileRepo) FindUserIDBySlug(ctx context.Context, slug string) (string, error) {
+	var userID string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT user_id FROM user_settings
+		 WHERE public_slug = $1 AND public_profile = true`,
+		slug,
+	).Scan(&userID)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("スラッグによる公開プロフィールの検索に失敗しました: %w", err)
+	}
+	return userID, nil
+}
+
+// ListPublicSubscriptions は当該ユーザーの公開設定された購読を、フィードのタイトルと
+// サイト URL のみを含む形で購読登録順に返す。
+func (r *PostgresPublicProfileRepo) ListPublicSubscriptions(ctx context.Context, userID string) ([]model.PublicSubscription, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT f.title, COALESCE(f.site_url, '')
+		 FROM subscriptions s
+		 JOIN feeds f ON s.feed_id = f.id
+		 WHERE s.user_id = $1 AND s.is_public = true
+		 ORDER BY s.created_at ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("公開購読一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var results []model.PublicSubscription
+	for rows.Next() {
+		var sub model.PublicSubscription
+		if err := rows.Scan(&sub.Title, &sub.SiteURL); err != nil {
+			return nil, fmt.Errorf("公開購読行の読み取りに失敗しました: %w", err)
+		}
+		results = append(results, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("公開購読一覧の走査に失敗しました: %w", err)
+	}
+	return results, nil
+}
+
+// UpdateSubscriptionVisibility は当該ユーザーが所有する購読の公開可否を更新する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+func (r *PostgresPublicProfileRepo) UpdateSubscriptionVisibility(ctx context.Context, userID, subscriptionID string, isPublic bool) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE subscriptions SET is_public = $3, updated_at = NOW()
+		 WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID, isPublic,
+	)
+	if err != nil {
+		return false, fmt.Errorf("購読の公開設定の更新に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// compile-time interface check
+var _ PublicProfileRepository = (*PostgresPublicProfileRepo)(nil)
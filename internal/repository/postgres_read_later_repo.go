@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresReadLaterRepo は PostgreSQL を使用した「あとで読む」サービス連携・保存キューのリポジトリ。
+type PostgresReadLaterRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresReadLaterRepo は PostgresReadLaterRepo を生成する。
+func NewPostgresReadLaterRepo(db *sql.DB) *PostgresReadLaterRepo {
+	return &PostgresReadLaterRepo{db: db}
+}
+
+// readLaterConnectionColumns は ReadLaterConnection の取得列。scanReadLaterConnection と対応する。
+const readLaterConnectionColumns = `id, user_id, provider, account_name, credentials, enabled,
+	last_error, last_error_at, last_saved_at, created_at, updated_at`
+
+// scanReadLaterConnection は readLaterConnectionColumns の 1 行を読み取る。
+func scanReadLaterConnection(scanner interface{ Scan(...any) error }) (*model.ReadLaterConnection, error) {
+	var c model.ReadLaterConnection
+	var provider string
+	var lastError sql.NullString
+	var lastErrorAt, lastSavedAt sql.NullTime
+	if err := scanner.Scan(&c.ID, &c.UserID, &provider, &c.AccountName, &c.Credentials, &c.Enabled,
+		&lastError, &lastErrorAt, &lastSavedAt, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	c.Provider = model.ReadLaterProvider(provider)
+	c.LastError = nullStringValue(lastError)
+	c.LastErrorAt = nullTimeValue(lastErrorAt)
+	c.LastSavedAt = nullTimeValue(lastSavedAt)
+	return &c, nil
+}
+
+// Upsert は当該ユーザーの保存先サービスとの連携を作成し、既にある場合は認証情報とアカウント名を置き換える。
+// 連携し直した場合も有効化して直近のエラーをクリアする。
+func (r *PostgresReadLaterRepo) Upsert(ctx context.Context, conn *model.ReadLaterConnection) error {
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO read_later_connections (user_id, provider, account_name, credentials)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (user_id, provider) DO UPDATE
+		 SET account_name  = EXCLUDED.account_name,
+		     credentials   = EXCLUDED.credentials,
+		     enabled       = true,
+		     last_error    = NULL,
+		     last_error_at = NULL
+		 RETURNING id, enabled, created_at, updated_at`,
+		conn.UserID, string(conn.Provider), conn.AccountName, conn.Credentials,
+	).Scan(&conn.ID, &conn.Enabled, &conn.CreatedAt, &conn.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("あとで読むサービス連携の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ListByUser は当該ユーザーの連携を作成日時の昇順で返す。
+func (r *PostgresReadLaterRepo) ListByUser(ctx context.Context, userID string) ([]model.ReadLaterConnection, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+readLaterConnectionColumns+`
+		 FROM read_later_connections
+		 WHERE user_id = $1
+		 ORDER BY created_at ASC, id ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("あとで読むサービス連携一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var conns []model.ReadLaterConnection
+	for rows.Next() {
+		c, err := scanReadLaterConnection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("あとで読むサービス連携の読み取りに失敗しました: %w", err)
+		}
+		conns = append(conns, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("あとで読むサービス連携一覧の走査に失敗しました: %w", err)
+	}
+	return conns, nil
+}
+
+// FindByProvider は当該ユーザーの保存先サービスとの連携を返す。存在しない場合は (nil, nil) を返す。
+func (r *PostgresReadLaterRepo) FindByProvider(ctx context.Context, userID string, provider model.ReadLaterProvider) (*model.ReadLaterConnection, error) {
+	c, err := scanReadLaterConnection(r.db.QueryRowContext(ctx,
+		`SELECT `+readLaterConnectionColumns+`
+		 FROM read_later_connections
+		 WHERE user_id = $1 AND provider = $2`,
+		userID, string(provider),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("あとで読むサービス連携の取得に失敗しました: %w", err)
+	}
+	return c, nil
+}
+
+// SetEnabled は連携の有効フラグを更新する。有効化する場合は直近のエラーをクリアする。
+// 対象が無い場合は false を返す。
+func (r *PostgresReadLaterRepo) SetEnabled(ctx context.Context, userID string, provider model.ReadLaterProvider, enabled bool) (bool, error) {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE read_later_connections
+		 SET enabled       = $3,
+		     last_error    = CASE WHEN $3 THEN NULL ELSE last_error END,
+		     last_error_at = CASE WHEN $3 THEN NULL ELSE last_error_at END
+		 WHERE user_id = $1 AND provider = $2`,
+		userID, string(provider), enabled,
+	)
+	if err != nil {
+		return false, fmt.Errorf("あとで読むサービス連携の更新に失敗しました: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("あとで読むサービス連携の更新件数の取得に失敗しました: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Delete は当該ユーザーの連携を削除する。保存キューは CASCADE で削除される。
+// 対象が無い場合は false を返す。
+func (r *PostgresReadLaterRepo) Delete(ctx context.Context, userID string, provider model.ReadLaterProvider) (bool, error) {
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM read_later_connections WHERE user_id = $1 AND provider = $2`,
+		userID, string(provider),
+	)
+	if err != nil {
+		return false, fmt.Errorf("あとで読むサービス連携の削除に失敗しました: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("あとで読むサービス連携の削除件数の取得に失敗しました: %w", err)
+	}
+	return n > 0, nil
+}
+
+// EnqueueStarredItem は当該ユーザーの有効な連携ごとに記事を保存キューへ積む。
+// (connection_id, item_id) の一意制約により、スターを外して付け直しても同じ記事を再度積むことはない。
+func (r *PostgresReadLaterRepo) EnqueueStarredItem(ctx context.Context, userID, itemID string) (int, error) {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO read_later_deliveries (connection_id, item_id)
+		 SELECT c.id, $2
+		 FROM read_later_connections c
+		 WHERE c.user_id = $1 AND c.enabled
+		 ON CONFLICT (connection_id, item_id) DO NOTHING`,
+		userID, itemID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("保存キューへの投入に失敗しました: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("保存キューへの投入件数の取得に失敗しました: %w", err)
+	}
+	return int(n), nil
+}
+
+// ListDueDeliveries は保存期限が now 以前の未保存を古い順に最大 limit 件返す。
+// 連携が無効化された保存はキューに残し、再度有効化されたときに保存する。
+func (r *PostgresReadLaterRepo) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]model.ReadLaterDelivery, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT d.id, c.id, c.user_id, c.provider, c.credentials, d.attempts,
+		        i.id, i.title, COALESCE(i.link, '')
+		 FROM read_later_deliveries d
+		 JOIN read_later_connections c ON c.id = d.connection_id
+		 JOIN items i ON i.id = d.item_id
+		 WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND c.enabled
+		 ORDER BY d.next_attempt_at ASC, d.id ASC
+		 LIMIT $2`,
+		now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("保存キューの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.ReadLaterDelivery
+	for rows.Next() {
+		var d model.ReadLaterDelivery
+		var provider string
+		if err := rows.Scan(&d.ID, &d.ConnectionID, &d.UserID, &provider, &d.Credentials, &d.Attempts,
+			&d.ItemID, &d.Title, &d.Link); err != nil {
+			return nil, fmt.Errorf("保存キューの読み取りに失敗しました: %w", err)
+		}
+		d.Provider = model.ReadLaterProvider(provider)
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("保存キューの走査に失敗しました: %w", err)
+	}
+	return deliveries, nil
+}
+
+// MarkSent は保存済みとして記録し、連携の直近の保存結果（保存日時・エラーのクリア）を更新する。
+func (r *PostgresReadLaterRepo) MarkSent(ctx context.Context, deliveryID int64, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`WITH d AS (
+		   UPDATE read_later_deliveries
+		   SET status = 'sent', attempts = attempts + 1, sent_at = $2, last_error = NULL
+		   WHERE id = $1
+		   RETURNING connection_id
+		 )
+		 UPDATE read_later_connections
+		 SET last_saved_at = $2, last_error = NULL, last_error_at = NULL
+		 WHERE id = (SELECT connection_id FROM d)`,
+		deliveryID, at,
+	)
+	if err != nil {
+		return fmt.Errorf("保存済みの記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// MarkRetry は試行回数を加算して nextAttemptAt に再試行を予約し、連携に直近のエラーを記録する。
+func (r *PostgresReadLaterRepo) MarkRetry(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, lastError string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`WITH d AS (
+		   UPDATE read_later_deliveries
+		   SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+		   WHERE id = $1
+		   RETURNING connection_id
+		 )
+		 UPDATE read_later_connections
+		 SET last_error = $3, last_error_at = $4
+		 WHERE id = (SELECT connection_id FROM d)`,
+		deliveryID, nextAttemptAt, lastError, at,
+	)
+	if err != nil {
+		return fmt.Errorf("保存の再試行の予約に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed は試行回数を加算して保存失敗として確定し、連携に直近のエラーを記録する。
+// disableConnection が true の場合は連携も無効化する（再度有効化するか連携し直すまで保存しない）。
+func (r *PostgresReadLaterRepo) MarkFailed(ctx context.Context, deliveryID int64, lastError string, at time.Time, disableConnection bool) error {
+	_, err := r.db.ExecContext(ctx,
+		`WITH d AS (
+		   UPDATE read_later_deliveries
+		   SET status = 'failed', attempts = attempts + 1, last_error = $2
+		   WHERE id = $1
+		   RETURNING connection_id
+		 )
+		 UPDATE read_later_connections
+		 SET last_error = $2, last_error_at = $3, enabled = enabled AND NOT $4
+		 WHERE id = (SELECT connection_id FROM d)`,
+		deliveryID, lastError, at, disableConnection,
+	)
+	if err != nil {
+		return fmt.Errorf("保存失敗の記録に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// compile-time interface check
+var (
+	_ ReadLaterConnectionRepository = (*PostgresReadLaterRepo)(nil)
+	_ ReadLaterDeliveryRepository   = (*PostgresReadLaterRepo)(nil)
+)
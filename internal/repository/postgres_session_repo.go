@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
 )
@@ -21,9 +22,9 @@ func NewPostgresSessionRepo(db *sql.DB) *PostgresSessionRepo {
 // Create はセッションを作成する。
 func (r *PostgresSessionRepo) Create(ctx context.Context, session *model.Session) error {
 	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO sessions (id, user_id, data, expires_at, created_at)
-		 VALUES ($1, $2, $3, $4, $5)`,
-		session.ID, session.UserID, []byte("{}"), session.ExpiresAt, session.CreatedAt,
+		`INSERT INTO sessions (id, user_id, identity_id, data, expires_at, created_at)
+		 VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6)`,
+		session.ID, session.UserID, session.IdentityID, []byte("{}"), session.ExpiresAt, session.CreatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
@@ -35,11 +36,11 @@ func (r *PostgresSessionRepo) Create(ctx context.Context, session *model.Session
 func (r *PostgresSessionRepo) FindByID(ctx context.Context, id string) (*model.Session, error) {
 	session := &model.Session{}
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, user_id, expires_at, created_at
+		`SELECT id, user_id, COALESCE(identity_id::text, ''), expires_at, created_at
 		 FROM sessions
 		 WHERE id = $1 AND expires_at > now()`,
 		id,
-	).Scan(&session.ID, &session.UserID, &session.ExpiresAt, &session.CreatedAt)
+	).Scan(&session.ID, &session.UserID, &session.IdentityID, &session.ExpiresAt, &session.CreatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -81,5 +82,81 @@ func (r *PostgresSessionRepo) DeleteByUserIDExec(ctx context.Context, q DBTX, us
 	return nil
 }
 
+// DeleteByIdentityID は指定ユーザーのセッションのうち identityID の identity でのログインで発行したものと、
+// 発行元の identity が記録されていないものを削除する。
+func (r *PostgresSessionRepo) DeleteByIdentityID(ctx context.Context, userID, identityID string) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM sessions
+		 WHERE user_id = $1 AND (identity_id = $2 OR identity_id IS NULL)`,
+		userID, identityID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete identity sessions: %w", err)
+	}
+	return nil
+}
+
+// ListActive は失効していない全セッションを失効時刻の昇順で返す。
+// セッションストアの移行（PostgreSQL → Redis）で用いる。
+func (r *PostgresSessionRepo) ListActive(ctx context.Context) ([]*model.Session, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, user_id, COALESCE(identity_id::text, ''), expires_at, created_at
+		 FROM sessions
+		 WHERE expires_at > now()
+		 ORDER BY expires_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*model.Session
+	for rows.Next() {
+		s := &model.Session{}
+		if err := rows.Scan(&s.ID, &s.UserID, &s.IdentityID, &s.ExpiresAt, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// DeleteExpired は now 時点で有効期限を過ぎたセッションを失効時刻の古い順に最大 limit 件削除し、
+// 削除したセッションを返す。
+func (r *PostgresSessionRepo) DeleteExpired(ctx context.Context, now time.Time, limit int) ([]*model.Session, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`DELETE FROM sessions
+		 WHERE id IN (
+			SELECT id FROM sessions
+			WHERE expires_at <= $1
+			ORDER BY expires_at
+			LIMIT $2
+		 )
+		 RETURNING id, user_id, COALESCE(identity_id::text, ''), expires_at, created_at`,
+		now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*model.Session
+	for rows.Next() {
+		s := &model.Session{}
+		if err := rows.Scan(&s.ID, &s.UserID, &s.IdentityID, &s.ExpiresAt, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+	return sessions, nil
+}
+
 // compile-time interface check
 var _ SessionRepository = (*PostgresSessionRepo)(nil)
+var _ ExpiredSessionRepository = (*PostgresSessionRepo)(nil)
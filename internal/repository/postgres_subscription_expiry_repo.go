@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresSubscriptionExpiryRepo は PostgreSQL を使用したお試し購読の期限リポジトリ。
+type PostgresSubscriptionExpiryRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresSubscriptionExpiryRepo は PostgresSubscriptionExpiryRepo を生成する。
+func NewPostgresSubscriptionExpiryRepo(db *sql.DB) *PostgresSubscriptionExpiryRepo {
+	return &PostgresSubscriptionExpiryRepo{db: db}
+}
+
+// ListExpiredSubscriptions は期限が now 以前のお試し購読を期限の古い順に最大 limit 件返す。
+func (r *PostgresSubscriptionExpiryRepo) ListExpiredSubscriptions(ctx context.Context, now time.Time, limit int) ([]*model.Subscription, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, user_id, feed_id, fetch_interval_minutes, is_pinned, sort_order, priority, mute_until, expires_at, created_at, updated_at
+		 FROM subscriptions
+		 WHERE expires_at IS NOT NULL AND expires_at <= $1
+		 ORDER BY expires_at ASC, id ASC
+		 LIMIT $2`,
+		now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("期限切れ購読の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*model.Subscription
+	for rows.Next() {
+		sub := &model.Subscription{}
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.FeedID, &sub.FetchIntervalMinutes, &sub.IsPinned, &sub.SortOrder, &sub.Priority, &sub.MuteUntil, &sub.ExpiresAt, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("期限切れ購読のスキャンに失敗しました: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("期限切れ購読の走査に失敗しました: %w", err)
+	}
+	return subs, nil
+}
+
+// ClearSubscriptionExpiry は当該ユーザーが所有する購読の期限を外し、通常の購読にする。
+// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す（既に通常の購読の場合は true）。
+func (r *PostgresSubscriptionExpiryRepo) ClearSubscriptionExpiry(ctx context.Context, userID, subscriptionID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE subscriptions SET expires_at = NULL, updated_at = NOW()
+		 WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("購読の期限の解除に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// compile-time interface check
+var _ SubscriptionExpiryRepository = (*PostgresSubscriptionExpiryRepo)(nil)
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// このファイルはテスト用 PostgreSQL を介したお試し購読の期限の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresSubscriptionExpiryRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "trial-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "trial-other@example.com")
+	expiredFeedID := insertTestFeedForSub(t, db, "https://example.com/trial-expired.xml", "Expired Trial", nil)
+	activeFeedID := insertTestFeedForSub(t, db, "https://example.com/trial-active.xml", "Active Trial", nil)
+	regularFeedID := insertTestFeedForSub(t, db, "https://example.com/regular.xml", "Regular", nil)
+	insertTestSubscriptionForSub(t, db, userID, expiredFeedID)
+	insertTestSubscriptionForSub(t, db, userID, activeFeedID)
+	insertTestSubscriptionForSub(t, db, userID, regularFeedID)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if _, err := db.Exec(`UPDATE subscriptions SET expires_at = $2 WHERE feed_id = $1`, expiredFeedID, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("期限の設定に失敗: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE subscriptions SET expires_at = $2 WHERE feed_id = $1`, activeFeedID, now.Add(time.Hour)); err != nil {
+		t.Fatalf("期限の設定に失敗: %v", err)
+	}
+	var expiredSubID string
+	if err := db.QueryRow(`SELECT id FROM subscriptions WHERE feed_id = $1`, expiredFeedID).Scan(&expiredSubID); err != nil {
+		t.Fatalf("購読 ID の取得に失敗: %v", err)
+	}
+	repo := NewPostgresSubscriptionExpiryRepo(db)
+
+	t.Run("期限を過ぎたお試し購読のみを返す", func(t *testing.T) {
+		subs, err := repo.ListExpiredSubscriptions(ctx, now, 100)
+		if err != nil {
+			t.Fatalf("ListExpiredSubscriptions() error = %v", err)
+		}
+		if len(subs) != 1 || subs[0].ID != expiredSubID || subs[0].ExpiresAt == nil {
+			t.Errorf("subs = %+v, want only %s", subs, expiredSubID)
+		}
+	})
+
+	t.Run("他ユーザーの購読の期限は外せない", func(t *testing.T) {
+		cleared, err := repo.ClearSubscriptionExpiry(ctx, otherID, expiredSubID)
+		if err != nil || cleared {
+			t.Errorf("ClearSubscriptionExpiry() = (%v, %v), want (false, nil)", cleared, err)
+		}
+	})
+
+	t.Run("期限を外すと期限切れの対象から外れ購読一覧の期限もnullになる", func(t *testing.T) {
+		cleared, err := repo.ClearSubscriptionExpiry(ctx, userID, expiredSubID)
+		if err != nil || !cleared {
+			t.Fatalf("ClearSubscriptionExpiry() = (%v, %v), want (true, nil)", cleared, err)
+		}
+		subs, err := repo.ListExpiredSubscriptions(ctx, now, 100)
+		if err != nil {
+			t.Fatalf("ListExpiredSubscriptions() error = %v", err)
+		}
+		if len(subs) != 0 {
+			t.Errorf("subs = %+v, want empty", subs)
+		}
+		sub, err := NewPostgresSubscriptionRepo(db).FindByID(ctx, expiredSubID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if sub.ExpiresAt != nil {
+			t.Errorf("ExpiresAt = %v, want nil", sub.ExpiresAt)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介した、購読の作成・削除に連動するフィードの fetch_status の遷移の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+// feedFetchStatusForSub はフィードの fetch_status と next_fetch_at を返す。
+func feedFetchStatusForSub(t *testing.T, db *sql.DB, feedID string) (model.FetchStatus, time.Time) {
+	t.Helper()
+	var status model.FetchStatus
+	var nextFetchAt time.Time
+	if err := db.QueryRow(`SELECT fetch_status, next_fetch_at FROM feeds WHERE id = $1`, feedID).Scan(&status, &nextFetchAt); err != nil {
+		t.Fatalf("フィードの取得に失敗: %v", err)
+	}
+	return status, nextFetchAt
+}
+
+func TestPostgresSubscriptionRepo_FeedFetchStatusFollowsSubscribers(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+	repo := NewPostgresSubscriptionRepo(db)
+
+	userA := insertTestUserForSub(t, db, "release-a@example.com")
+	userB := insertTestUserForSub(t, db, "release-b@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/release.xml", "Release Feed", nil)
+
+	newSub := func(userID string) *model.Subscription {
+		now := time.Now()
+		sub := &model.Subscription{ID: uuid.New().String(), UserID: userID, FeedID: feedID, FetchIntervalMinutes: 60, CreatedAt: now, UpdatedAt: now}
+		if err := repo.Create(ctx, sub); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return sub
+	}
+	subA := newSub(userA)
+	subB := newSub(userB)
+
+	t.Run("購読者が残っているときはフェッチを続ける", func(t *testing.T) {
+		if err := repo.Delete(ctx, subA.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if status, _ := feedFetchStatusForSub(t, db, feedID); status != model.FetchStatusActive {
+			t.Errorf("fetch_status = %q, want %q", status, model.FetchStatusActive)
+		}
+	})
+
+	t.Run("最後の購読者が解除したときフェッチを止める", func(t *testing.T) {
+		if err := repo.Delete(ctx, subB.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if status, _ := feedFetchStatusForSub(t, db, feedID); status != model.FetchStatusNoSubscribers {
+			t.Errorf("fetch_status = %q, want %q", status, model.FetchStatusNoSubscribers)
+		}
+	})
+
+	t.Run("再購読したときフェッチを再開しすぐにフェッチ対象にする", func(t *testing.T) {
+		if _, err := db.Exec(`UPDATE feeds SET next_fetch_at = now() + interval '1 day' WHERE id = $1`, feedID); err != nil {
+			t.Fatalf("next_fetch_at の更新に失敗: %v", err)
+		}
+		sub := newSub(userA)
+		status, nextFetchAt := feedFetchStatusForSub(t, db, feedID)
+		if status != model.FetchStatusActive || nextFetchAt.After(time.Now()) {
+			t.Errorf("fetch_status = %q, next_fetch_at = %v, want active かつ現在時刻以前", status, nextFetchAt)
+		}
+
+		// エラーで停止中のフィードは購読の解除で状態を変えない
+		if _, err := db.Exec(`UPDATE feeds SET fetch_status = 'stopped' WHERE id = $1`, feedID); err != nil {
+			t.Fatalf("fetch_status の更新に失敗: %v", err)
+		}
+		if err := repo.DeleteByUserID(ctx, userA); err != nil {
+			t.Fatalf("DeleteByUserID() error = %v", err)
+		}
+		if status, _ := feedFetchStatusForSub(t, db, feedID); status != model.FetchStatusStopped {
+			t.Errorf("fetch_status = %q, want %q（購読 %s の削除後）", status, model.FetchStatusStopped, sub.ID)
+		}
+	})
+}
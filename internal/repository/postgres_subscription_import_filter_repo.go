@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/lib/pq"
+)
+
+// PostgresSubscriptionImportFilterRepo は PostgreSQL を使用した記事取り込みフィルタリポジトリ。
+type PostgresSubscriptionImportFilterRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresSubscriptionImportFilterRepo は PostgresSubscriptionImportFilterRepo を生成する。
+func NewPostgresSubscriptionImportFilterRepo(db *sql.DB) *PostgresSubscriptionImportFilterRepo {
+	return &PostgresSubscriptionImportFilterRepo{db: db}
+}
+
+// GetImportFilter は当該ユーザーが所有する購読の取り込みフィルタを取得する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+func (r *PostgresSubscriptionImportFilterRepo) GetImportFilter(ctx context.Context, userID, subscriptionID string) (*model.ImportFilter, error) {
+	filter := &model.ImportFilter{}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT import_filter_authors, import_filter_title_pattern
+		 FROM subscriptions WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID,
+	).Scan(pq.Array(&filter.Authors), &filter.TitlePattern)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("取り込みフィルタの取得に失敗しました: %w", err)
+	}
+	return filter, nil
+}
+
+// UpdateImportFilter は当該ユーザーが所有する購読の取り込みフィルタを上書き保存する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+func (r *PostgresSubscriptionImportFilterRepo) UpdateImportFilter(ctx context.Context, userID, subscriptionID string, filter model.ImportFilter) (bool, error) {
+	authors := filter.Authors
+	if authors == nil {
+		// NOT NULL 列のため nil スライスは空配列として保存する
+		authors = []string{}
+	}
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE subscriptions
+		 SET import_filter_authors = $3, import_filter_title_pattern = $4, updated_at = NOW()
+		 WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID, pq.Array(authors), filter.TitlePattern,
+	)
+	if err != nil {
+		return false, fmt.Errorf("取り込みフィルタの更新に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ListImportFiltersByFeedID は指定フィードの全購読の取り込みフィルタを購読 1 件につき 1 要素で返す。
+func (r *PostgresSubscriptionImportFilterRepo) ListImportFiltersByFeedID(ctx context.Context, feedID string) ([]model.ImportFilter, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT import_filter_authors, import_filter_title_pattern
+		 FROM subscriptions WHERE feed_id = $1`,
+		feedID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("取り込みフィルタ一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var filters []model.ImportFilter
+	for rows.Next() {
+		var f model.ImportFilter
+		if err := rows.Scan(pq.Array(&f.Authors), &f.TitlePattern); err != nil {
+			return nil, fmt.Errorf("取り込みフィルタのスキャンに失敗しました: %w", err)
+		}
+		filters = append(filters, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("取り込みフィルタ一覧の走査に失敗しました: %w", err)
+	}
+	return filters, nil
+}
+
+// compile-time interface check
+var _ SubscriptionImportFilterRepository = (*PostgresSubscriptionImportFilterRepo)(nil)
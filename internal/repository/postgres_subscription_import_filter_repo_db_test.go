@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介した取り込みフィルタの結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresSubscriptionImportFilterRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "filter-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "filter-other@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/filter.xml", "Filter Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+	insertTestSubscriptionForSub(t, db, otherID, feedID)
+
+	var subID string
+	if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1`, userID).Scan(&subID); err != nil {
+		t.Fatalf("購読 ID の取得に失敗: %v", err)
+	}
+	repo := NewPostgresSubscriptionImportFilterRepo(db)
+
+	t.Run("未設定のとき空のフィルタを返す", func(t *testing.T) {
+		got, err := repo.GetImportFilter(ctx, userID, subID)
+		if err != nil {
+			t.Fatalf("GetImportFilter() error = %v", err)
+		}
+		if got == nil || !got.IsEmpty() {
+			t.Errorf("filter = %+v, want empty", got)
+		}
+	})
+
+	t.Run("更新したフィルタを取得できフィード単位の一覧に含まれる", func(t *testing.T) {
+		filter := model.ImportFilter{Authors: []string{"Alice", "Bob"}, TitlePattern: "^Go"}
+		updated, err := repo.UpdateImportFilter(ctx, userID, subID, filter)
+		if err != nil || !updated {
+			t.Fatalf("UpdateImportFilter() = (%v, %v), want (true, nil)", updated, err)
+		}
+
+		got, err := repo.GetImportFilter(ctx, userID, subID)
+		if err != nil {
+			t.Fatalf("GetImportFilter() error = %v", err)
+		}
+		if len(got.Authors) != 2 || got.Authors[0] != "Alice" || got.TitlePattern != "^Go" {
+			t.Errorf("filter = %+v", got)
+		}
+
+		filters, err := repo.ListImportFiltersByFeedID(ctx, feedID)
+		if err != nil {
+			t.Fatalf("ListImportFiltersByFeedID() error = %v", err)
+		}
+		if len(filters) != 2 {
+			t.Fatalf("filters = %d, want 2（購読ごとに 1 件）", len(filters))
+		}
+		empty := 0
+		for _, f := range filters {
+			if f.IsEmpty() {
+				empty++
+			}
+		}
+		if empty != 1 {
+			t.Errorf("未設定の購読のフィルタ数 = %d, want 1", empty)
+		}
+	})
+
+	t.Run("他ユーザーの購読は取得も更新もできない", func(t *testing.T) {
+		got, err := repo.GetImportFilter(ctx, otherID, subID)
+		if err != nil || got != nil {
+			t.Errorf("GetImportFilter() = (%+v, %v), want (nil, nil)", got, err)
+		}
+		updated, err := repo.UpdateImportFilter(ctx, otherID, subID, model.ImportFilter{})
+		if err != nil || updated {
+			t.Errorf("UpdateImportFilter() = (%v, %v), want (false, nil)", updated, err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresSubscriptionNotificationRepo は PostgreSQL を使用した購読単位の通知ヒント設定リポジトリ。
+type PostgresSubscriptionNotificationRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresSubscriptionNotificationRepo は PostgresSubscriptionNotificationRepo を生成する。
+func NewPostgresSubscriptionNotificationRepo(db *sql.DB) *PostgresSubscriptionNotificationRepo {
+	return &PostgresSubscriptionNotificationRepo{db: db}
+}
+
+// GetNotificationSetting は当該ユーザーが所有する購読の通知ヒント設定を取得する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+func (r *PostgresSubscriptionNotificationRepo) GetNotificationSetting(ctx context.Context, userID, subscriptionID string) (*model.SubscriptionNotificationSetting, error) {
+	var priority string
+	var muteUntil sql.NullTime
+	err := r.db.QueryRowContext(ctx,
+		`SELECT priority, mute_until FROM subscriptions WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID,
+	).Scan(&priority, &muteUntil)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("通知設定の取得に失敗しました: %w", err)
+	}
+
+	setting := &model.SubscriptionNotificationSetting{Priority: model.NotificationPriority(priority)}
+	if muteUntil.Valid {
+		t := muteUntil.Time
+		setting.MuteUntil = &t
+	}
+	return setting, nil
+}
+
+// UpdateNotificationSetting は当該ユーザーが所有する購読の通知ヒント設定を上書き保存する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+func (r *PostgresSubscriptionNotificationRepo) UpdateNotificationSetting(ctx context.Context, userID, subscriptionID string, setting model.SubscriptionNotificationSetting) (bool, error) {
+	var muteUntil sql.NullTime
+	if setting.MuteUntil != nil {
+		muteUntil = sql.NullTime{Time: *setting.MuteUntil, Valid: true}
+	}
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE subscriptions SET priority = $3, mute_until = $4, updated_at = NOW()
+		 WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID, string(setting.Priority), muteUntil,
+	)
+	if err != nil {
+		return false, fmt.Errorf("通知設定の更新に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// compile-time interface check
+var _ SubscriptionNotificationRepository = (*PostgresSubscriptionNotificationRepo)(nil)
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介した購読単位の通知ヒント設定の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresSubscriptionNotificationRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "notification-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "notification-other@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/notification.xml", "Notification Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+
+	var subID string
+	if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1`, userID).Scan(&subID); err != nil {
+		t.Fatalf("購読 ID の取得に失敗: %v", err)
+	}
+	repo := NewPostgresSubscriptionNotificationRepo(db)
+
+	t.Run("未設定のとき優先度normalでミュートなしの設定を返す", func(t *testing.T) {
+		got, err := repo.GetNotificationSetting(ctx, userID, subID)
+		if err != nil {
+			t.Fatalf("GetNotificationSetting() error = %v", err)
+		}
+		if got == nil || got.Priority != model.NotificationPriorityNormal || got.MuteUntil != nil {
+			t.Errorf("setting = %+v, want normal without mute", got)
+		}
+	})
+
+	t.Run("更新した設定を取得でき購読一覧にも反映される", func(t *testing.T) {
+		until := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Microsecond)
+		setting := model.SubscriptionNotificationSetting{Priority: model.NotificationPriorityHigh, MuteUntil: &until}
+		updated, err := repo.UpdateNotificationSetting(ctx, userID, subID, setting)
+		if err != nil || !updated {
+			t.Fatalf("UpdateNotificationSetting() = (%v, %v), want (true, nil)", updated, err)
+		}
+		got, err := repo.GetNotificationSetting(ctx, userID, subID)
+		if err != nil {
+			t.Fatalf("GetNotificationSetting() error = %v", err)
+		}
+		if got.Priority != model.NotificationPriorityHigh || got.MuteUntil == nil || !got.MuteUntil.Equal(until) {
+			t.Errorf("setting = %+v, want high until %v", got, until)
+		}
+
+		rows, err := NewPostgresSubscriptionRepo(db).ListByUserIDWithFeedInfo(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListByUserIDWithFeedInfo() error = %v", err)
+		}
+		if len(rows) != 1 || rows[0].Priority != model.NotificationPriorityHigh || rows[0].MuteUntil == nil {
+			t.Errorf("rows = %+v, want priority high with mute_until", rows)
+		}
+	})
+
+	t.Run("他ユーザーの購読は取得も更新もできない", func(t *testing.T) {
+		got, err := repo.GetNotificationSetting(ctx, otherID, subID)
+		if err != nil || got != nil {
+			t.Errorf("GetNotificationSetting() = (%+v, %v), want (nil, nil)", got, err)
+		}
+		setting := model.SubscriptionNotificationSetting{Priority: model.NotificationPriorityLow}
+		updated, err := repo.UpdateNotificationSetting(ctx, otherID, subID, setting)
+		if err != nil || updated {
+			t.Errorf("UpdateNotificationSetting() = (%v, %v), want (false, nil)", updated, err)
+		}
+	})
+}
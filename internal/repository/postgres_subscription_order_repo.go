@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresSubscriptionOrderRepo は PostgreSQL を使用した購読のピン留め・並び順リポジトリ。
+type PostgresSubscriptionOrderRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresSubscriptionOrderRepo は PostgresSubscriptionOrderRepo を生成する。
+func NewPostgresSubscriptionOrderRepo(db *sql.DB) *PostgresSubscriptionOrderRepo {
+	return &PostgresSubscriptionOrderRepo{db: db}
+}
+
+// UpdateOrder は当該ユーザーの購読に entries の指定順で sort_order（0 始まり）を振り直す。
+// IsPinned が nil のエントリはピン留め状態を変更しない。
+// 存在しない、または他ユーザーの購読が含まれる場合はロールバックし、その購読 ID を返す。
+func (r *PostgresSubscriptionOrderRepo) UpdateOrder(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for i, entry := range entries {
+		var isPinned sql.NullBool
+		if entry.IsPinned != nil {
+			isPinned = sql.NullBool{Bool: *entry.IsPinned, Valid: true}
+		}
+		result, err := tx.ExecContext(ctx,
+			`UPDATE subscriptions
+			 SET sort_order = $3, is_pinned = COALESCE($4, is_pinned), updated_at = NOW()
+			 WHERE id = $1 AND user_id = $2`,
+			entry.SubscriptionID, userID, i, isPinned,
+		)
+		if err != nil {
+			return "", fmt.Errorf("購読の並び順の更新に失敗しました: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return "", fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+		}
+		if rowsAffected == 0 {
+			return entry.SubscriptionID, nil
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return "", nil
+}
+
+// compile-time interface check
+var _ SubscriptionOrderRepository = (*PostgresSubscriptionOrderRepo)(nil)
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介した購読のピン留め・並び順の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresSubscriptionOrderRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "order-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "order-other@example.com")
+	feedA := insertTestFeedForSub(t, db, "https://example.com/a.xml", "A Feed", nil)
+	feedB := insertTestFeedForSub(t, db, "https://example.com/b.xml", "B Feed", nil)
+	feedC := insertTestFeedForSub(t, db, "https://example.com/c.xml", "C Feed", nil)
+	for _, feedID := range []string{feedA, feedB, feedC} {
+		insertTestSubscriptionForSub(t, db, userID, feedID)
+	}
+	insertTestSubscriptionForSub(t, db, otherID, feedA)
+
+	subIDs := map[string]string{}
+	for _, feedID := range []string{feedA, feedB, feedC} {
+		var id string
+		if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1 AND feed_id = $2`, userID, feedID).Scan(&id); err != nil {
+			t.Fatalf("購読 ID の取得に失敗: %v", err)
+		}
+		subIDs[feedID] = id
+	}
+	var otherSubID string
+	if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1`, otherID).Scan(&otherSubID); err != nil {
+		t.Fatalf("購読 ID の取得に失敗: %v", err)
+	}
+
+	repo := NewPostgresSubscriptionOrderRepo(db)
+	subRepo := NewPostgresSubscriptionRepo(db)
+
+	listTitles := func(t *testing.T) []string {
+		t.Helper()
+		infos, err := subRepo.ListByUserIDWithFeedInfo(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListByUserIDWithFeedInfo() error = %v", err)
+		}
+		titles := make([]string, len(infos))
+		for i, info := range infos {
+			titles[i] = info.FeedTitle
+		}
+		return titles
+	}
+
+	t.Run("未設定のときタイトル順に返す", func(t *testing.T) {
+		if got := listTitles(t); len(got) != 3 || got[0] != "A Feed" || got[1] != "B Feed" || got[2] != "C Feed" {
+			t.Errorf("titles = %v, want [A Feed B Feed C Feed]", got)
+		}
+	})
+
+	t.Run("ピン留めを先頭にし指定した並び順で返す", func(t *testing.T) {
+		pinned := true
+		notFoundID, err := repo.UpdateOrder(ctx, userID, []model.SubscriptionOrderEntry{
+			{SubscriptionID: subIDs[feedC]},
+			{SubscriptionID: subIDs[feedA]},
+			{SubscriptionID: subIDs[feedB], IsPinned: &pinned},
+		})
+		if err != nil || notFoundID != "" {
+			t.Fatalf("UpdateOrder() = (%q, %v), want (\"\", nil)", notFoundID, err)
+		}
+
+		if got := listTitles(t); len(got) != 3 || got[0] != "B Feed" || got[1] != "C Feed" || got[2] != "A Feed" {
+			t.Errorf("titles = %v, want [B Feed C Feed A Feed]", got)
+		}
+		sub, err := subRepo.FindByID(ctx, subIDs[feedB])
+		if err != nil || !sub.IsPinned || sub.SortOrder != 2 {
+			t.Errorf("FindByID() = (%+v, %v), want IsPinned true, SortOrder 2", sub, err)
+		}
+	})
+
+	t.Run("他ユーザーの購読を含むとき何も更新せずそのIDを返す", func(t *testing.T) {
+		notFoundID, err := repo.UpdateOrder(ctx, userID, []model.SubscriptionOrderEntry{
+			{SubscriptionID: subIDs[feedA]},
+			{SubscriptionID: otherSubID},
+		})
+		if err != nil || notFoundID != otherSubID {
+			t.Fatalf("UpdateOrder() = (%q, %v), want (%q, nil)", notFoundID, err, otherSubID)
+		}
+		if got := listTitles(t); len(got) != 3 || got[0] != "B Feed" || got[1] != "C Feed" || got[2] != "A Feed" {
+			t.Errorf("titles = %v, want [B Feed C Feed A Feed]（ロールバックされていること）", got)
+		}
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/hitoshi/feedman/internal/model"
 )
@@ -22,10 +25,10 @@ func NewPostgresSubscriptionRepo(db *sql.DB) *PostgresSubscriptionRepo {
 func (r *PostgresSubscriptionRepo) FindByID(ctx context.Context, id string) (*model.Subscription, error) {
 	sub := &model.Subscription{}
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, user_id, feed_id, fetch_interval_minutes, created_at, updated_at
+		`SELECT id, user_id, feed_id, fetch_interval_minutes, is_pinned, sort_order, priority, mute_until, expires_at, created_at, updated_at
 		 FROM subscriptions WHERE id = $1`,
 		id,
-	).Scan(&sub.ID, &sub.UserID, &sub.FeedID, &sub.FetchIntervalMinutes, &sub.CreatedAt, &sub.UpdatedAt)
+	).Scan(&sub.ID, &sub.UserID, &sub.FeedID, &sub.FetchIntervalMinutes, &sub.IsPinned, &sub.SortOrder, &sub.Priority, &sub.MuteUntil, &sub.ExpiresAt, &sub.CreatedAt, &sub.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -41,10 +44,10 @@ func (r *PostgresSubscriptionRepo) FindByID(ctx context.Context, id string) (*mo
 func (r *PostgresSubscriptionRepo) FindByUserAndFeed(ctx context.Context, userID, feedID string) (*model.Subscription, error) {
 	sub := &model.Subscription{}
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, user_id, feed_id, fetch_interval_minutes, created_at, updated_at
+		`SELECT id, user_id, feed_id, fetch_interval_minutes, is_pinned, sort_order, priority, mute_until, expires_at, created_at, updated_at
 		 FROM subscriptions WHERE user_id = $1 AND feed_id = $2`,
 		userID, feedID,
-	).Scan(&sub.ID, &sub.UserID, &sub.FeedID, &sub.FetchIntervalMinutes, &sub.CreatedAt, &sub.UpdatedAt)
+	).Scan(&sub.ID, &sub.UserID, &sub.FeedID, &sub.FetchIntervalMinutes, &sub.IsPinned, &sub.SortOrder, &sub.Priority, &sub.MuteUntil, &sub.ExpiresAt, &sub.CreatedAt, &sub.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -56,6 +59,31 @@ func (r *PostgresSubscriptionRepo) FindByUserAndFeed(ctx context.Context, userID
 	return sub, nil
 }
 
+// FilterSubscribedFeedIDs は feedIDs のうちユーザーが購読しているフィードの ID を返す。
+func (r *PostgresSubscriptionRepo) FilterSubscribedFeedIDs(ctx context.Context, userID string, feedIDs []string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT feed_id FROM subscriptions WHERE user_id = $1 AND feed_id = ANY($2::uuid[])`,
+		userID, pq.Array(feedIDs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("購読中のフィードの確認に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var subscribed []string
+	for rows.Next() {
+		var feedID string
+		if err := rows.Scan(&feedID); err != nil {
+			return nil, fmt.Errorf("購読中のフィードの読み取りに失敗しました: %w", err)
+		}
+		subscribed = append(subscribed, feedID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("購読中のフィードの走査に失敗しました: %w", err)
+	}
+	return subscribed, nil
+}
+
 // CountByUserID はユーザーの購読数を返す。
 func (r *PostgresSubscriptionRepo) CountByUserID(ctx context.Context, userID string) (int, error) {
 	var count int
@@ -69,23 +97,36 @@ func (r *PostgresSubscriptionRepo) CountByUserID(ctx context.Context, userID str
 	return count, nil
 }
 
-// Create は購読を作成する。
+// Create は購読を作成する。Priority が空の場合は既定の優先度（normal）で作成する。
+// 購読者がいないためにフェッチを止めていたフィード（no_subscribers）は同じトランザクションでフェッチを再開する。
 func (r *PostgresSubscriptionRepo) Create(ctx context.Context, sub *model.Subscription) error {
-	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO subscriptions (id, user_id, feed_id, fetch_interval_minutes, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6)`,
-		sub.ID, sub.UserID, sub.FeedID, sub.FetchIntervalMinutes, sub.CreatedAt, sub.UpdatedAt,
-	)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO subscriptions (id, user_id, feed_id, fetch_interval_minutes, priority, mute_until, expires_at, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'normal'), $6, $7, $8, $9)`,
+		sub.ID, sub.UserID, sub.FeedID, sub.FetchIntervalMinutes, string(sub.Priority), sub.MuteUntil, sub.ExpiresAt, sub.CreatedAt, sub.UpdatedAt,
+	); err != nil {
 		return fmt.Errorf("購読の作成に失敗しました: %w", err)
 	}
+	if err := reactivateSubscribedFeeds(ctx, tx, sub.FeedID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
 	return nil
 }
 
 // ListByUserID はユーザーの購読一覧を返す。
 func (r *PostgresSubscriptionRepo) ListByUserID(ctx context.Context, userID string) ([]*model.Subscription, error) {
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, user_id, feed_id, fetch_interval_minutes, created_at, updated_at
+		`SELECT id, user_id, feed_id, fetch_interval_minutes, is_pinned, sort_order, priority, mute_until, expires_at, created_at, updated_at
 		 FROM subscriptions WHERE user_id = $1 ORDER BY created_at ASC`,
 		userID,
 	)
@@ -97,7 +138,7 @@ func (r *PostgresSubscriptionRepo) ListByUserID(ctx context.Context, userID stri
 	var subs []*model.Subscription
 	for rows.Next() {
 		sub := &model.Subscription{}
-		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.FeedID, &sub.FetchIntervalMinutes, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.FeedID, &sub.FetchIntervalMinutes, &sub.IsPinned, &sub.SortOrder, &sub.Priority, &sub.MuteUntil, &sub.ExpiresAt, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("購読行の読み取りに失敗しました: %w", err)
 		}
 		subs = append(subs, sub)
@@ -122,6 +163,60 @@ func (r *PostgresSubscriptionRepo) MinFetchIntervalByFeedID(ctx context.Context,
 	return minInterval, nil
 }
 
+// CountSubscribersByFeedID は当該フィードの購読数を返す。
+func (r *PostgresSubscriptionRepo) CountSubscribersByFeedID(ctx context.Context, feedID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM subscriptions WHERE feed_id = $1`,
+		feedID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("フィードの購読者数の取得に失敗しました: %w", err)
+	}
+	return count, nil
+}
+
+// HasActiveSubscriber は since 以降にアクティブだった購読者が当該フィードに 1 人でもいるかを返す。
+func (r *PostgresSubscriptionRepo) HasActiveSubscriber(ctx context.Context, feedID string, since time.Time) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM subscriptions s JOIN users u ON u.id = s.user_id
+		      WHERE s.feed_id = $1 AND u.last_active_at >= $2
+		 )`,
+		feedID, since,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("アクティブな購読者の有無の取得に失敗しました: %w", err)
+	}
+	return exists, nil
+}
+
+// SubscriptionListModTime はユーザーの購読一覧の最終更新日時を返す。
+// 購読の追加・解除（users.subscriptions_changed_at）、購読設定・記事状態の更新、
+// 購読中フィードの更新（フェッチによる未読数・状態の変化を含む）のうち最も新しい日時を用いる。
+// ユーザーが存在しない場合はゼロ値を返す。
+func (r *PostgresSubscriptionRepo) SubscriptionListModTime(ctx context.Context, userID string) (time.Time, error) {
+	var modTime sql.NullTime
+	err := r.db.QueryRowContext(ctx,
+		`SELECT GREATEST(
+			u.subscriptions_changed_at,
+			(SELECT MAX(s.updated_at) FROM subscriptions s WHERE s.user_id = u.id),
+			(SELECT MAX(f.updated_at) FROM subscriptions s JOIN feeds f ON f.id = s.feed_id WHERE s.user_id = u.id),
+			(SELECT MAX(ist.updated_at) FROM item_states ist WHERE ist.user_id = u.id)
+		)
+		FROM users u WHERE u.id = $1`,
+		userID,
+	).Scan(&modTime)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("購読一覧の最終更新日時の取得に失敗しました: %w", err)
+	}
+	return modTime.Time, nil
+}
+
 // UpdateFetchInterval は購読のフェッチ間隔を更新する。
 func (r *PostgresSubscriptionRepo) UpdateFetchInterval(ctx context.Context, id string, minutes int) error {
 	result, err := r.db.ExecContext(ctx,
@@ -142,63 +237,101 @@ func (r *PostgresSubscriptionRepo) UpdateFetchInterval(ctx context.Context, id s
 }
 
 // Delete は指定IDの購読を削除する。
+// 最後の購読者がいなくなったフィードは同じトランザクションでフェッチを止める（no_subscribers）。
 func (r *PostgresSubscriptionRepo) Delete(ctx context.Context, id string) error {
-	result, err := r.db.ExecContext(ctx,
-		`DELETE FROM subscriptions WHERE id = $1`,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var feedID string
+	err = tx.QueryRowContext(ctx,
+		`DELETE FROM subscriptions WHERE id = $1 RETURNING feed_id`,
 		id,
-	)
+	).Scan(&feedID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("購読が見つかりません: %s", id)
+	}
 	if err != nil {
 		return fmt.Errorf("購読の削除に失敗しました: %w", err)
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("削除結果の取得に失敗しました: %w", err)
+	if err := releaseUnsubscribedFeeds(ctx, tx, feedID); err != nil {
+		return err
 	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("購読が見つかりません: %s", id)
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
 	}
 	return nil
 }
 
 // DeleteByUserID はユーザーの全購読を削除する。
 func (r *PostgresSubscriptionRepo) DeleteByUserID(ctx context.Context, userID string) error {
-	return r.DeleteByUserIDExec(ctx, r.db, userID)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := r.DeleteByUserIDExec(ctx, tx, userID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return nil
 }
 
 // DeleteByUserIDExec は指定の DBTX（*sql.DB または共有トランザクション）上で
-// ユーザーの全購読を削除する。
+// ユーザーの全購読を削除し、最後の購読者がいなくなったフィードのフェッチを止める。
+// 購読の再作成との競合を防ぐため、q にはトランザクションを渡す。
 func (r *PostgresSubscriptionRepo) DeleteByUserIDExec(ctx context.Context, q DBTX, userID string) error {
-	_, err := q.ExecContext(ctx,
-		`DELETE FROM subscriptions WHERE user_id = $1`,
+	feedIDs, err := queryFeedIDs(ctx, q,
+		`DELETE FROM subscriptions WHERE user_id = $1 RETURNING feed_id`,
 		userID,
 	)
 	if err != nil {
 		return fmt.Errorf("ユーザーの全購読の削除に失敗しました: %w", err)
 	}
-	return nil
+	return releaseUnsubscribedFeeds(ctx, q, feedIDs...)
 }
 
 // ListByUserIDWithFeedInfo はユーザーの購読一覧をフィード情報と未読数付きで返す。
 // feeds, items, item_statesとJOINして、フィードタイトル、favicon、フェッチステータス、未読数を取得する。
+// 非表示（ミュート）にした記事は未読数に含めない。
+// 積読警告（too_many_unread）は未読数の集計結果と user_settings の閾値を同一クエリ内で比較して
+// 算出し、追加のクエリを発行しない。閾値が未設定の場合は model.DefaultUnreadWarningThreshold、
+// 0 の場合は警告無効として扱う。
+// フィードの言語・説明文・最終投稿日時、購読ごとのタイトルの自動更新ポリシーと承認待ちタイトルもあわせて返す。
+// フィードタイトルはポリシーで固定したタイトル（display_title）があればそれを返す。
+// 並び順はピン留め → sort_order（昇順）→ フィードタイトルの順（同値は購読日時順）。
 func (r *PostgresSubscriptionRepo) ListByUserIDWithFeedInfo(ctx context.Context, userID string) ([]SubscriptionWithFeedInfo, error) {
 	rows, err := r.db.QueryContext(ctx,
 		`SELECT
-			s.id, s.user_id, s.feed_id, s.fetch_interval_minutes, s.created_at, s.updated_at,
-			f.title, f.feed_url, f.favicon_data, COALESCE(f.favicon_mime, ''), f.fetch_status, COALESCE(f.error_message, ''),
-			COALESCE(unread.cnt, 0)
+			s.id, s.user_id, s.feed_id, s.fetch_interval_minutes, s.is_pinned, s.sort_order, s.priority, s.mute_until, s.expires_at, s.created_at, s.updated_at,
+			COALESCE(s.display_title, f.title), f.feed_url, f.favicon_data, COALESCE(f.favicon_mime, ''), f.fetch_status, COALESCE(f.error_message, ''), COALESCE(f.error_kind, ''),
+			COALESCE(unread.cnt, 0),
+			COALESCE(us.unread_warning_threshold, $2) > 0
+			  AND COALESCE(unread.cnt, 0) > COALESCE(us.unread_warning_threshold, $2),
+			COALESCE(f.language, ''), COALESCE(f.description, ''), f.last_published_at,
+			CASE WHEN f.fetch_status = 'stopped' THEN COALESCE(f.suggested_feed_url, '') ELSE '' END,
+			s.title_update_policy, COALESCE(s.pending_title, '')
 		 FROM subscriptions s
 		 JOIN feeds f ON s.feed_id = f.id
+		 LEFT JOIN user_settings us ON us.user_id = s.user_id
 		 LEFT JOIN (
 		     SELECT i.feed_id, COUNT(*) AS cnt
 		     FROM items i
 		     LEFT JOIN item_states ist ON ist.item_id = i.id AND ist.user_id = $1
 		     WHERE i.feed_id IN (SELECT feed_id FROM subscriptions WHERE user_id = $1)
 		       AND (ist.is_read IS NULL OR ist.is_read = false)
+		       AND (ist.is_hidden IS NULL OR ist.is_hidden = false)
 		     GROUP BY i.feed_id
 		 ) unread ON unread.feed_id = s.feed_id
 		 WHERE s.user_id = $1
-		 ORDER BY s.created_at ASC`,
-		userID,
+		 ORDER BY s.is_pinned DESC, s.sort_order ASC, COALESCE(s.display_title, f.title) ASC, s.created_at ASC`,
+		userID, model.DefaultUnreadWarningThreshold,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("購読一覧（フィード情報付き）の取得に失敗しました: %w", err)
@@ -209,9 +342,12 @@ func (r *PostgresSubscriptionRepo) ListByUserIDWithFeedInfo(ctx context.Context,
 	for rows.Next() {
 		var info SubscriptionWithFeedInfo
 		if err := rows.Scan(
-			&info.ID, &info.UserID, &info.FeedID, &info.FetchIntervalMinutes, &info.CreatedAt, &info.UpdatedAt,
-			&info.FeedTitle, &info.FeedURL, &info.FaviconData, &info.FaviconMime, &info.FetchStatus, &info.ErrorMessage,
-			&info.UnreadCount,
+			&info.ID, &info.UserID, &info.FeedID, &info.FetchIntervalMinutes, &info.IsPinned, &info.SortOrder, &info.Priority, &info.MuteUntil, &info.ExpiresAt, &info.CreatedAt, &info.UpdatedAt,
+			&info.FeedTitle, &info.FeedURL, &info.FaviconData, &info.FaviconMime, &info.FetchStatus, &info.ErrorMessage, &info.ErrorKind,
+			&info.UnreadCount, &info.TooManyUnread,
+			&info.FeedLanguage, &info.FeedDescription, &info.FeedLastPublishedAt,
+			&info.SuggestedFeedURL,
+			&info.FeedTitleUpdatePolicy, &info.PendingFeedTitle,
 		); err != nil {
 			return nil, fmt.Errorf("購読行（フィード情報付き）の読み取りに失敗しました: %w", err)
 		}
@@ -223,5 +359,85 @@ func (r *PostgresSubscriptionRepo) ListByUserIDWithFeedInfo(ctx context.Context,
 	return results, nil
 }
 
+// queryFeedIDs は feed_id の列を返す query（DELETE ... RETURNING feed_id 等）を実行し、feed_id の一覧を返す。
+func queryFeedIDs(ctx context.Context, q DBTX, query string, args ...any) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var feedIDs []string
+	for rows.Next() {
+		var feedID string
+		if err := rows.Scan(&feedID); err != nil {
+			return nil, err
+		}
+		feedIDs = append(feedIDs, feedID)
+	}
+	return feedIDs, rows.Err()
+}
+
+// lockFeeds は feedIDs のフィードの行をロックする。購読の作成・削除とフィードの fetch_status の遷移を
+// フィード単位で直列化し、購読者数の確認と遷移の間に他のトランザクションの購読が割り込まないようにする。
+// デッドロックを避けるため ID 順にロックする。
+func lockFeeds(ctx context.Context, q DBTX, feedIDs []string) error {
+	rows, err := q.QueryContext(ctx,
+		`SELECT id FROM feeds WHERE id = ANY($1) ORDER BY id FOR UPDATE`,
+		pq.Array(feedIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("フィードのロックに失敗しました: %w", err)
+	}
+	return rows.Close()
+}
+
+// releaseUnsubscribedFeeds は購読の削除後に呼び、feedIDs のうち購読者がいなくなった active のフィードを
+// no_subscribers にしてフェッチを止める。エラーで停止中（stopped / error）のフィードは状態を変えない。
+// 購読の削除と同じトランザクションの q で呼ぶ。
+func releaseUnsubscribedFeeds(ctx context.Context, q DBTX, feedIDs ...string) error {
+	if len(feedIDs) == 0 {
+		return nil
+	}
+	// ロックの取得後に購読者数を確認するため、ロック待ちの間にコミットされた購読も数えられる
+	if err := lockFeeds(ctx, q, feedIDs); err != nil {
+		return err
+	}
+	if _, err := q.ExecContext(ctx,
+		`UPDATE feeds f SET fetch_status = $2
+		 WHERE f.id = ANY($1)
+		   AND f.fetch_status = $3
+		   AND NOT EXISTS (SELECT 1 FROM subscriptions s WHERE s.feed_id = f.id)`,
+		pq.Array(feedIDs), string(model.FetchStatusNoSubscribers), string(model.FetchStatusActive),
+	); err != nil {
+		return fmt.Errorf("購読者のいないフィードのフェッチ停止に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// reactivateSubscribedFeeds は購読の作成後に呼び、feedIDs のうち no_subscribers のフィードを active に戻して
+// すぐにフェッチされるよう next_fetch_at を現在時刻にする。購読の作成と同じトランザクションの q で呼ぶ。
+func reactivateSubscribedFeeds(ctx context.Context, q DBTX, feedIDs ...string) error {
+	if len(feedIDs) == 0 {
+		return nil
+	}
+	if err := lockFeeds(ctx, q, feedIDs); err != nil {
+		return err
+	}
+	if _, err := q.ExecContext(ctx,
+		`UPDATE feeds SET fetch_status = $2, next_fetch_at = now()
+		 WHERE id = ANY($1) AND fetch_status = $3`,
+		pq.Array(feedIDs), string(model.FetchStatusActive), string(model.FetchStatusNoSubscribers),
+	); err != nil {
+		return fmt.Errorf("フィードのフェッチ再開に失敗しました: %w", err)
+	}
+	return nil
+}
+
 // compile-time interface check
-var _ SubscriptionRepository = (*PostgresSubscriptionRepo)(nil)
+var (
+	_ SubscriptionRepository            = (*PostgresSubscriptionRepo)(nil)
+	_ FeedSubscriberCounter             = (*PostgresSubscriptionRepo)(nil)
+	_ FeedActiveSubscriberChecker       = (*PostgresSubscriptionRepo)(nil)
+	_ SubscriptionListModTimeRepository = (*PostgresSubscriptionRepo)(nil)
+)
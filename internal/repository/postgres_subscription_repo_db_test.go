@@ -44,11 +44,35 @@ func setupSubscriptionTestDB(t *testing.T) *sql.DB {
 
 	// クリーンアップ: 既存のテーブルとマイグレーション履歴を削除してクリーンな状態にする
 	cleanupSQL := `
+		DROP TABLE IF EXISTS feed_raw_captures CASCADE;
+		DROP TABLE IF EXISTS read_later_deliveries CASCADE;
+		DROP TABLE IF EXISTS read_later_connections CASCADE;
+		DROP TABLE IF EXISTS feed_registration_batches CASCADE;
+		DROP TABLE IF EXISTS item_highlights CASCADE;
+		DROP TABLE IF EXISTS feed_keywords CASCADE;
+		DROP TABLE IF EXISTS login_tokens CASCADE;
+		DROP TABLE IF EXISTS api_usage_daily CASCADE;
+		DROP TABLE IF EXISTS login_events CASCADE;
+		DROP TABLE IF EXISTS blocked_domains CASCADE;
+		DROP TABLE IF EXISTS integration_deliveries CASCADE;
+		DROP TABLE IF EXISTS integrations CASCADE;
 		DROP TABLE IF EXISTS user_cross_feed_views CASCADE;
 		DROP TABLE IF EXISTS sessions CASCADE;
 		DROP TABLE IF EXISTS user_settings CASCADE;
+		DROP MATERIALIZED VIEW IF EXISTS admin_stats;
+		DROP TABLE IF EXISTS fetch_attempts CASCADE;
+		DROP TABLE IF EXISTS idempotency_keys CASCADE;
+		DROP TABLE IF EXISTS weekly_subscription_stats CASCADE;
+		DROP TABLE IF EXISTS worker_cycles CASCADE;
+		DROP TABLE IF EXISTS subscription_undos CASCADE;
+		DROP TABLE IF EXISTS team_invitations CASCADE;
+		DROP TABLE IF EXISTS team_feeds CASCADE;
+		DROP TABLE IF EXISTS team_members CASCADE;
+		DROP TABLE IF EXISTS audit_logs CASCADE;
+		DROP TABLE IF EXISTS item_views CASCADE;
 		DROP TABLE IF EXISTS item_states CASCADE;
 		DROP TABLE IF EXISTS subscriptions CASCADE;
+		DROP TABLE IF EXISTS teams CASCADE;
 		DROP TABLE IF EXISTS items CASCADE;
 		DROP TABLE IF EXISTS feeds CASCADE;
 		DROP TABLE IF EXISTS identities CASCADE;
@@ -199,3 +223,194 @@ func TestListByUserIDWithFeedInfo_FaviconMimeNull(t *testing.T) {
 		}
 	})
 }
+
+// insertTestItemsForSub はテスト用の未読記事を count 件挿入する。
+func insertTestItemsForSub(t *testing.T, db *sql.DB, feedID string, count int) {
+	t.Helper()
+	_, err := db.Exec(
+		`INSERT INTO items (feed_id, guid_or_id, title)
+		 SELECT $1, 'guid-' || g, 'item ' || g FROM generate_series(1, $2) AS g`,
+		feedID, count,
+	)
+	if err != nil {
+		t.Fatalf("記事挿入に失敗: %v", err)
+	}
+}
+
+// TestListByUserIDWithFeedInfo_TooManyUnread は未読数が積読警告閾値を超えた購読に
+// TooManyUnread が立つこと、閾値がユーザー設定で制御されることを検証する。
+func TestListByUserIDWithFeedInfo_TooManyUnread(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+
+	repo := NewPostgresSubscriptionRepo(db)
+	settingsRepo := NewPostgresUserSettingsRepo(db)
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "unread@test.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/unread.xml", "Unread Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+	insertTestItemsForSub(t, db, feedID, 10)
+
+	t.Run("閾値が未設定のとき既定値（500件）以下なのでフラグが立たない", func(t *testing.T) {
+		results, err := repo.ListByUserIDWithFeedInfo(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListByUserIDWithFeedInfo がエラーを返した: %v", err)
+		}
+		if results[0].TooManyUnread {
+			t.Error("TooManyUnread = true, want false")
+		}
+	})
+
+	t.Run("未読数が設定閾値を超えたときフラグが立つ", func(t *testing.T) {
+		if err := settingsRepo.UpsertUnreadWarningThreshold(ctx, userID, 5); err != nil {
+			t.Fatalf("閾値の保存に失敗: %v", err)
+		}
+		results, err := repo.ListByUserIDWithFeedInfo(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListByUserIDWithFeedInfo がエラーを返した: %v", err)
+		}
+		if !results[0].TooManyUnread {
+			t.Error("TooManyUnread = false, want true")
+		}
+	})
+
+	t.Run("未読数が閾値と等しいときフラグが立たない", func(t *testing.T) {
+		if err := settingsRepo.UpsertUnreadWarningThreshold(ctx, userID, 10); err != nil {
+			t.Fatalf("閾値の保存に失敗: %v", err)
+		}
+		results, err := repo.ListByUserIDWithFeedInfo(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListByUserIDWithFeedInfo がエラーを返した: %v", err)
+		}
+		if results[0].TooManyUnread {
+			t.Error("TooManyUnread = true, want false")
+		}
+	})
+
+	t.Run("閾値が0のとき警告が無効になる", func(t *testing.T) {
+		if err := settingsRepo.UpsertUnreadWarningThreshold(ctx, userID, 0); err != nil {
+			t.Fatalf("閾値の保存に失敗: %v", err)
+		}
+		results, err := repo.ListByUserIDWithFeedInfo(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListByUserIDWithFeedInfo がエラーを返した: %v", err)
+		}
+		if results[0].TooManyUnread {
+			t.Error("TooManyUnread = true, want false")
+		}
+	})
+}
+
+// TestPostgresUserSettingsRepo_UnreadWarningThreshold は積読警告閾値の取得・保存を検証する。
+func TestPostgresUserSettingsRepo_UnreadWarningThreshold(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+
+	repo := NewPostgresUserSettingsRepo(db)
+	ctx := context.Background()
+	userID := insertTestUserForSub(t, db, "settings@test.com")
+
+	t.Run("user_settingsに行がないときnilを返す", func(t *testing.T) {
+		got, err := repo.GetUnreadWarningThreshold(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetUnreadWarningThreshold がエラーを返した: %v", err)
+		}
+		if got != nil {
+			t.Errorf("got %d, want nil", *got)
+		}
+	})
+
+	t.Run("保存した閾値を取得できる", func(t *testing.T) {
+		if err := repo.UpsertUnreadWarningThreshold(ctx, userID, 200); err != nil {
+			t.Fatalf("UpsertUnreadWarningThreshold がエラーを返した: %v", err)
+		}
+		got, err := repo.GetUnreadWarningThreshold(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetUnreadWarningThreshold がエラーを返した: %v", err)
+		}
+		if got == nil || *got != 200 {
+			t.Errorf("got %v, want 200", got)
+		}
+	})
+}
+
+// TestPostgresUserSettingsRepo_OpenLinksInNewTab は「リンクを新しいタブで開く」設定の取得・保存を検証する。
+func TestPostgresUserSettingsRepo_OpenLinksInNewTab(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+
+	repo := NewPostgresUserSettingsRepo(db)
+	ctx := context.Background()
+	userID := insertTestUserForSub(t, db, "link-settings@test.com")
+
+	t.Run("user_settingsに行がないときnilを返す", func(t *testing.T) {
+		got, err := repo.GetOpenLinksInNewTab(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetOpenLinksInNewTab がエラーを返した: %v", err)
+		}
+		if got != nil {
+			t.Errorf("got %v, want nil", *got)
+		}
+	})
+
+	t.Run("保存した設定を取得でき他の設定を上書きしない", func(t *testing.T) {
+		if err := repo.UpsertUnreadWarningThreshold(ctx, userID, 200); err != nil {
+			t.Fatalf("UpsertUnreadWarningThreshold がエラーを返した: %v", err)
+		}
+		if err := repo.UpsertOpenLinksInNewTab(ctx, userID, false); err != nil {
+			t.Fatalf("UpsertOpenLinksInNewTab がエラーを返した: %v", err)
+		}
+		got, err := repo.GetOpenLinksInNewTab(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetOpenLinksInNewTab がエラーを返した: %v", err)
+		}
+		if got == nil || *got {
+			t.Errorf("got %v, want false", got)
+		}
+		threshold, _ := repo.GetUnreadWarningThreshold(ctx, userID)
+		if threshold == nil || *threshold != 200 {
+			t.Errorf("threshold = %v, want 200", threshold)
+		}
+	})
+}
+
+// TestPostgresUserSettingsRepo_PrefetchEnabled はプリフェッチ設定の取得・保存を検証する。
+func TestPostgresUserSettingsRepo_PrefetchEnabled(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+
+	repo := NewPostgresUserSettingsRepo(db)
+	ctx := context.Background()
+	userID := insertTestUserForSub(t, db, "prefetch-settings@test.com")
+
+	t.Run("user_settingsに行がないときnilを返す", func(t *testing.T) {
+		got, err := repo.GetPrefetchEnabled(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetPrefetchEnabled がエラーを返した: %v", err)
+		}
+		if got != nil {
+			t.Errorf("got %v, want nil", *got)
+		}
+	})
+
+	t.Run("保存した設定を取得でき他の設定を上書きしない", func(t *testing.T) {
+		if err := repo.UpsertOpenLinksInNewTab(ctx, userID, false); err != nil {
+			t.Fatalf("UpsertOpenLinksInNewTab がエラーを返した: %v", err)
+		}
+		if err := repo.UpsertPrefetchEnabled(ctx, userID, true); err != nil {
+			t.Fatalf("UpsertPrefetchEnabled がエラーを返した: %v", err)
+		}
+		got, err := repo.GetPrefetchEnabled(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetPrefetchEnabled がエラーを返した: %v", err)
+		}
+		if got == nil || !*got {
+			t.Errorf("got %v, want true", got)
+		}
+		openInNewTab, _ := repo.GetOpenLinksInNewTab(ctx, userID)
+		if openInNewTab == nil || *openInNewTab {
+			t.Errorf("open_links_in_new_tab = %v, want false", openInNewTab)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresSubscriptionRetentionRepo は PostgreSQL を使用した購読単位の最大記事保持数リポジトリ。
+type PostgresSubscriptionRetentionRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresSubscriptionRetentionRepo は PostgresSubscriptionRetentionRepo を生成する。
+func NewPostgresSubscriptionRetentionRepo(db *sql.DB) *PostgresSubscriptionRetentionRepo {
+	return &PostgresSubscriptionRetentionRepo{db: db}
+}
+
+// GetRetentionOverride は当該ユーザーが所有する購読の保持数設定を取得する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は nil を返す。
+func (r *PostgresSubscriptionRetentionRepo) GetRetentionOverride(ctx context.Context, userID, subscriptionID string) (*model.RetentionOverride, error) {
+	var maxItems sql.NullInt64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT retention_override FROM subscriptions WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID,
+	).Scan(&maxItems)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("最大記事保持数の取得に失敗しました: %w", err)
+	}
+
+	override := &model.RetentionOverride{}
+	if maxItems.Valid {
+		n := int(maxItems.Int64)
+		override.MaxItems = &n
+	}
+	return override, nil
+}
+
+// UpdateRetentionOverride は当該ユーザーが所有する購読の保持数設定を上書き保存する（nil で解除）。
+// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+func (r *PostgresSubscriptionRetentionRepo) UpdateRetentionOverride(ctx context.Context, userID, subscriptionID string, maxItems *int) (bool, error) {
+	var value sql.NullInt64
+	if maxItems != nil {
+		value = sql.NullInt64{Int64: int64(*maxItems), Valid: true}
+	}
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE subscriptions SET retention_override = $3, updated_at = NOW()
+		 WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID, value,
+	)
+	if err != nil {
+		return false, fmt.Errorf("最大記事保持数の更新に失敗しました: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("更新結果の取得に失敗しました: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// compile-time interface check
+var _ SubscriptionRetentionRepository = (*PostgresSubscriptionRetentionRepo)(nil)
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+)
+
+// このファイルはテスト用 PostgreSQL を介した最大記事保持数の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresSubscriptionRetentionRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "retention-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "retention-other@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/retention.xml", "Retention Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+
+	var subID string
+	if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1`, userID).Scan(&subID); err != nil {
+		t.Fatalf("購読 ID の取得に失敗: %v", err)
+	}
+	repo := NewPostgresSubscriptionRetentionRepo(db)
+
+	t.Run("未設定のときMaxItemsがnilの設定を返す", func(t *testing.T) {
+		got, err := repo.GetRetentionOverride(ctx, userID, subID)
+		if err != nil {
+			t.Fatalf("GetRetentionOverride() error = %v", err)
+		}
+		if got == nil || got.MaxItems != nil {
+			t.Errorf("override = %+v, want MaxItems nil", got)
+		}
+	})
+
+	t.Run("更新した保持数を取得でき解除もできる", func(t *testing.T) {
+		n := 200
+		updated, err := repo.UpdateRetentionOverride(ctx, userID, subID, &n)
+		if err != nil || !updated {
+			t.Fatalf("UpdateRetentionOverride() = (%v, %v), want (true, nil)", updated, err)
+		}
+		got, err := repo.GetRetentionOverride(ctx, userID, subID)
+		if err != nil {
+			t.Fatalf("GetRetentionOverride() error = %v", err)
+		}
+		if got.MaxItems == nil || *got.MaxItems != 200 {
+			t.Errorf("MaxItems = %v, want 200", got.MaxItems)
+		}
+
+		updated, err = repo.UpdateRetentionOverride(ctx, userID, subID, nil)
+		if err != nil || !updated {
+			t.Fatalf("UpdateRetentionOverride(nil) = (%v, %v), want (true, nil)", updated, err)
+		}
+		got, err = repo.GetRetentionOverride(ctx, userID, subID)
+		if err != nil || got.MaxItems != nil {
+			t.Errorf("GetRetentionOverride() = (%+v, %v), want MaxItems nil", got, err)
+		}
+	})
+
+	t.Run("他ユーザーの購読は取得も更新もできない", func(t *testing.T) {
+		got, err := repo.GetRetentionOverride(ctx, otherID, subID)
+		if err != nil || got != nil {
+			t.Errorf("GetRetentionOverride() = (%+v, %v), want (nil, nil)", got, err)
+		}
+		n := 100
+		updated, err := repo.UpdateRetentionOverride(ctx, otherID, subID, &n)
+		if err != nil || updated {
+			t.Errorf("UpdateRetentionOverride() = (%v, %v), want (false, nil)", updated, err)
+		}
+	})
+}
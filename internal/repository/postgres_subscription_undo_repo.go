@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrSubscriptionUndoNotFound は取り消し可能な購読解除（期限内のスナップショット）が存在しないことを表す。
+var ErrSubscriptionUndoNotFound = errors.New("subscription undo snapshot not found or expired")
+
+// ErrSubscriptionAlreadyExists は復元しようとした購読と同じフィードを既に購読していることを表す。
+var ErrSubscriptionAlreadyExists = errors.New("subscription for the feed already exists")
+
+// PostgresSubscriptionUndoRepo は PostgreSQL を使用した購読解除取り消しリポジトリ。
+// 解除した購読行と記事状態を subscription_undos に JSONB で退避し、期限内であれば同じ行を復元する。
+type PostgresSubscriptionUndoRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresSubscriptionUndoRepo は PostgresSubscriptionUndoRepo を生成する。
+func NewPostgresSubscriptionUndoRepo(db *sql.DB) *PostgresSubscriptionUndoRepo {
+	return &PostgresSubscriptionUndoRepo{db: db}
+}
+
+// DeleteWithUndo は当該ユーザーが所有する購読と関連 item_states を削除し、expiresAt まで復元可能な
+// スナップショットを保存する。退避と削除は同一トランザクションで行う。
+// 期限切れのスナップショットはこの機会にまとめて削除する。
+// 対象購読が存在しない、または他ユーザーの購読の場合は false を返す。
+func (r *PostgresSubscriptionUndoRepo) DeleteWithUndo(ctx context.Context, userID, subscriptionID string, now, expiresAt time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM subscription_undos WHERE expires_at <= $1`, now,
+	); err != nil {
+		return false, fmt.Errorf("期限切れの購読解除スナップショットの削除に失敗しました: %w", err)
+	}
+
+	var feedID string
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO subscription_undos (subscription_id, user_id, feed_id, subscription, item_states, expires_at)
+		 SELECT s.id, s.user_id, s.feed_id, to_jsonb(s),
+		        COALESCE((
+		            SELECT jsonb_agg(to_jsonb(st))
+		            FROM item_states st
+		            JOIN items i ON i.id = st.item_id
+		            WHERE st.user_id = s.user_id AND i.feed_id = s.feed_id
+		        ), '[]'::jsonb),
+		        $3
+		 FROM subscriptions s
+		 WHERE s.id = $1 AND s.user_id = $2
+		 ON CONFLICT (subscription_id) DO UPDATE
+		   SET subscription = EXCLUDED.subscription,
+		       item_states  = EXCLUDED.item_states,
+		       expires_at   = EXCLUDED.expires_at,
+		       created_at   = now()
+		 RETURNING feed_id`,
+		subscriptionID, userID, expiresAt,
+	).Scan(&feedID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("購読解除スナップショットの保存に失敗しました: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM item_states
+		 WHERE user_id = $1 AND item_id IN (
+		     SELECT id FROM items WHERE feed_id = $2
+		 )`,
+		userID, feedID,
+	); err != nil {
+		return false, fmt.Errorf("ユーザーとフィードに関連する記事状態の削除に失敗しました: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM subscriptions WHERE id = $1 AND user_id = $2`,
+		subscriptionID, userID,
+	); err != nil {
+		return false, fmt.Errorf("購読の削除に失敗しました: %w", err)
+	}
+	if err := releaseUnsubscribedFeeds(ctx, tx, feedID); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return true, nil
+}
+
+// Restore は当該ユーザーの期限内スナップショットから購読と item_states を復元し、スナップショットを削除する。
+// 購読は解除前と同じ ID・設定で戻す（期限切れのお試し購読は通常の購読として戻す）。記事状態は解除後に記事が削除されたものを除いて戻す。
+// スナップショットが無い（期限切れ・他ユーザー含む）場合は ErrSubscriptionUndoNotFound、
+// 同じフィードを既に再購読している場合は ErrSubscriptionAlreadyExists を返す（スナップショットは残す）。
+func (r *PostgresSubscriptionUndoRepo) Restore(ctx context.Context, userID, subscriptionID string, now time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var subscription, itemStates []byte
+	err = tx.QueryRowContext(ctx,
+		`DELETE FROM subscription_undos
+		 WHERE subscription_id = $1 AND user_id = $2 AND expires_at > $3
+		 RETURNING subscription, item_states`,
+		subscriptionID, userID, now,
+	).Scan(&subscription, &itemStates)
+	if err == sql.ErrNoRows {
+		return ErrSubscriptionUndoNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("購読解除スナップショットの取得に失敗しました: %w", err)
+	}
+
+	var feedID string
+	if err := tx.QueryRowContext(ctx,
+		`INSERT INTO subscriptions
+		 SELECT * FROM jsonb_populate_record(NULL::subscriptions, $1::jsonb)
+		 RETURNING feed_id`,
+		subscription,
+	).Scan(&feedID); err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && string(pgErr.Code) == pgErrCodeUniqueViolation {
+			return ErrSubscriptionAlreadyExists
+		}
+		return fmt.Errorf("購読の復元に失敗しました: %w", err)
+	}
+	if err := reactivateSubscribedFeeds(ctx, tx, feedID); err != nil {
+		return err
+	}
+
+	// 期限切れで自動解除されたお試し購読を戻した場合は、次の期限切れスキャンで再び解除されないよう通常の購読にする
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE subscriptions SET expires_at = NULL WHERE id = $1 AND expires_at <= $2`,
+		subscriptionID, now,
+	); err != nil {
+		return fmt.Errorf("お試し購読の期限の解除に失敗しました: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO item_states
+		 SELECT r.* FROM jsonb_populate_recordset(NULL::item_states, $1::jsonb) r
+		 WHERE EXISTS (SELECT 1 FROM items i WHERE i.id = r.item_id)
+		 ON CONFLICT DO NOTHING`,
+		itemStates,
+	); err != nil {
+		return fmt.Errorf("記事状態の復元に失敗しました: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// compile-time interface check
+var _ SubscriptionUndoRepository = (*PostgresSubscriptionUndoRepo)(nil)
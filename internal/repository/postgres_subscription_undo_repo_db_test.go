@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// このファイルはテスト用 PostgreSQL を介した購読解除取り消しの結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresSubscriptionUndoRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "undo-owner@example.com")
+	otherID := insertTestUserForSub(t, db, "undo-other@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/undo.xml", "Undo Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+	itemID := insertTestItem(t, db, feedID, "Undo Item", "content", time.Now())
+	insertTestItemState(t, db, userID, itemID, true, true)
+
+	var subID string
+	if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1`, userID).Scan(&subID); err != nil {
+		t.Fatalf("購読 ID の取得に失敗: %v", err)
+	}
+	repo := NewPostgresSubscriptionUndoRepo(db)
+	now := time.Now()
+
+	t.Run("他ユーザーの購読のときfalseを返し削除しない", func(t *testing.T) {
+		deleted, err := repo.DeleteWithUndo(ctx, otherID, subID, now, now.Add(time.Minute))
+		if err != nil || deleted {
+			t.Fatalf("DeleteWithUndo() = (%v, %v), want (false, nil)", deleted, err)
+		}
+	})
+
+	t.Run("削除後に期限内なら購読と記事状態を復元できる", func(t *testing.T) {
+		deleted, err := repo.DeleteWithUndo(ctx, userID, subID, now, now.Add(time.Minute))
+		if err != nil || !deleted {
+			t.Fatalf("DeleteWithUndo() = (%v, %v), want (true, nil)", deleted, err)
+		}
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM subscriptions WHERE id = $1`, subID).Scan(&count); err != nil || count != 0 {
+			t.Fatalf("削除後の購読数 = %d (err=%v), want 0", count, err)
+		}
+
+		if err := repo.Restore(ctx, otherID, subID, now); !errors.Is(err, ErrSubscriptionUndoNotFound) {
+			t.Errorf("他ユーザーの Restore() error = %v, want ErrSubscriptionUndoNotFound", err)
+		}
+		if err := repo.Restore(ctx, userID, subID, now); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+
+		var isRead, isStarred bool
+		if err := db.QueryRow(
+			`SELECT is_read, is_starred FROM item_states WHERE user_id = $1 AND item_id = $2`, userID, itemID,
+		).Scan(&isRead, &isStarred); err != nil {
+			t.Fatalf("復元後の記事状態の取得に失敗: %v", err)
+		}
+		if !isRead || !isStarred {
+			t.Errorf("item_state = (read=%v, starred=%v), want (true, true)", isRead, isStarred)
+		}
+		if err := repo.Restore(ctx, userID, subID, now); !errors.Is(err, ErrSubscriptionUndoNotFound) {
+			t.Errorf("2 回目の Restore() error = %v, want ErrSubscriptionUndoNotFound", err)
+		}
+	})
+
+	t.Run("期限切れのときErrSubscriptionUndoNotFoundを返す", func(t *testing.T) {
+		if _, err := repo.DeleteWithUndo(ctx, userID, subID, now, now.Add(time.Minute)); err != nil {
+			t.Fatalf("DeleteWithUndo() error = %v", err)
+		}
+		if err := repo.Restore(ctx, userID, subID, now.Add(2*time.Minute)); !errors.Is(err, ErrSubscriptionUndoNotFound) {
+			t.Errorf("Restore() error = %v, want ErrSubscriptionUndoNotFound", err)
+		}
+	})
+
+	t.Run("再購読済みのときErrSubscriptionAlreadyExistsを返しスナップショットを残す", func(t *testing.T) {
+		insertTestSubscriptionForSub(t, db, otherID, feedID)
+		var otherSubID string
+		if err := db.QueryRow(`SELECT id FROM subscriptions WHERE user_id = $1`, otherID).Scan(&otherSubID); err != nil {
+			t.Fatalf("購読 ID の取得に失敗: %v", err)
+		}
+		if _, err := repo.DeleteWithUndo(ctx, otherID, otherSubID, now, now.Add(time.Minute)); err != nil {
+			t.Fatalf("DeleteWithUndo() error = %v", err)
+		}
+		insertTestSubscriptionForSub(t, db, otherID, feedID)
+
+		if err := repo.Restore(ctx, otherID, otherSubID, now); !errors.Is(err, ErrSubscriptionAlreadyExists) {
+			t.Errorf("Restore() error = %v, want ErrSubscriptionAlreadyExists", err)
+		}
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM subscription_undos WHERE subscription_id = $1`, otherSubID).Scan(&count); err != nil || count != 1 {
+			t.Errorf("スナップショット数 = %d (err=%v), want 1", count, err)
+		}
+	})
+}
@@ -0,0 +1,440 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/lib/pq"
+)
+
+// ErrTeamFeedAlreadyExists はチームで既に購読しているフィードを追加しようとしたことを表す。
+var ErrTeamFeedAlreadyExists = errors.New("team feed already exists")
+
+// ErrTeamInvitationNotFound は招待が存在しない・期限切れ・使用済みであることを表す。
+var ErrTeamInvitationNotFound = errors.New("team invitation not found, expired or already used")
+
+// ErrAlreadyTeamMember は既に所属しているチームに参加しようとしたことを表す。
+var ErrAlreadyTeamMember = errors.New("user is already a member of the team")
+
+// ErrTeamMemberLimit はチームのメンバー数が上限に達していることを表す。
+var ErrTeamMemberLimit = errors.New("team member limit reached")
+
+// PostgresTeamRepo は PostgreSQL を使用したチーム共有リポジトリ。
+type PostgresTeamRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresTeamRepo は PostgresTeamRepo を生成する。
+func NewPostgresTeamRepo(db *sql.DB) *PostgresTeamRepo {
+	return &PostgresTeamRepo{db: db}
+}
+
+// Create はチームを作成し、ownerUserID をオーナーとして登録する。作成と登録は同一トランザクションで行う。
+func (r *PostgresTeamRepo) Create(ctx context.Context, team *model.Team, ownerUserID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := tx.QueryRowContext(ctx,
+		`INSERT INTO teams (name) VALUES ($1)
+		 RETURNING id, created_at, updated_at`,
+		team.Name,
+	).Scan(&team.ID, &team.CreatedAt, &team.UpdatedAt); err != nil {
+		return fmt.Errorf("チームの作成に失敗しました: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO team_members (team_id, user_id, role) VALUES ($1, $2, $3)`,
+		team.ID, ownerUserID, string(model.TeamRoleOwner),
+	); err != nil {
+		return fmt.Errorf("チームオーナーの登録に失敗しました: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// teamMembershipColumns は TeamMembership の取得列。scanTeamMembership と対応する。
+const teamMembershipColumns = `t.id, t.name, t.created_at, t.updated_at, m.role, m.joined_at`
+
+// scanTeamMembership は teamMembershipColumns の 1 行を読み取る。
+func scanTeamMembership(scanner interface{ Scan(...any) error }) (*model.TeamMembership, error) {
+	var m model.TeamMembership
+	var role string
+	if err := scanner.Scan(&m.ID, &m.Name, &m.CreatedAt, &m.UpdatedAt, &role, &m.JoinedAt); err != nil {
+		return nil, err
+	}
+	m.Role = model.TeamRole(role)
+	return &m, nil
+}
+
+// ListByUser は当該ユーザーが所属するチームを参加日時の昇順で返す。
+func (r *PostgresTeamRepo) ListByUser(ctx context.Context, userID string) ([]model.TeamMembership, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+teamMembershipColumns+`
+		 FROM team_members m
+		 JOIN teams t ON t.id = m.team_id
+		 WHERE m.user_id = $1
+		 ORDER BY m.joined_at, t.id`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("所属チームの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	memberships := []model.TeamMembership{}
+	for rows.Next() {
+		m, err := scanTeamMembership(rows)
+		if err != nil {
+			return nil, fmt.Errorf("所属チームの行読み取りに失敗しました: %w", err)
+		}
+		memberships = append(memberships, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("所属チームの取得に失敗しました: %w", err)
+	}
+	return memberships, nil
+}
+
+// FindMembership は当該ユーザーのチームへの所属を返す。所属していない場合は nil を返す。
+func (r *PostgresTeamRepo) FindMembership(ctx context.Context, teamID, userID string) (*model.TeamMembership, error) {
+	m, err := scanTeamMembership(r.db.QueryRowContext(ctx,
+		`SELECT `+teamMembershipColumns+`
+		 FROM team_members m
+		 JOIN teams t ON t.id = m.team_id
+		 WHERE m.team_id = $1 AND m.user_id = $2`,
+		teamID, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("チームへの所属の取得に失敗しました: %w", err)
+	}
+	return m, nil
+}
+
+// ListMembers はチームのメンバーを参加日時の昇順で返す。
+func (r *PostgresTeamRepo) ListMembers(ctx context.Context, teamID string) ([]model.TeamMember, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT m.user_id, u.name, m.role, m.joined_at
+		 FROM team_members m
+		 JOIN users u ON u.id = m.user_id
+		 WHERE m.team_id = $1
+		 ORDER BY m.joined_at, m.user_id`,
+		teamID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("チームメンバーの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	members := []model.TeamMember{}
+	for rows.Next() {
+		var m model.TeamMember
+		var role string
+		if err := rows.Scan(&m.UserID, &m.Name, &role, &m.JoinedAt); err != nil {
+			return nil, fmt.Errorf("チームメンバーの行読み取りに失敗しました: %w", err)
+		}
+		m.Role = model.TeamRole(role)
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("チームメンバーの取得に失敗しました: %w", err)
+	}
+	return members, nil
+}
+
+// ListFeeds はチームの購読フィードを追加日時の昇順で返す。
+func (r *PostgresTeamRepo) ListFeeds(ctx context.Context, teamID string) ([]model.TeamFeed, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT f.id, f.title, f.feed_url, COALESCE(f.site_url, ''),
+		        COALESCE(tf.added_by::text, ''), tf.created_at
+		 FROM team_feeds tf
+		 JOIN feeds f ON f.id = tf.feed_id
+		 WHERE tf.team_id = $1
+		 ORDER BY tf.created_at, f.id`,
+		teamID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("チームの購読フィードの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	feeds := []model.TeamFeed{}
+	for rows.Next() {
+		var f model.TeamFeed
+		if err := rows.Scan(&f.FeedID, &f.Title, &f.FeedURL, &f.SiteURL, &f.AddedBy, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("チームの購読フィードの行読み取りに失敗しました: %w", err)
+		}
+		feeds = append(feeds, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("チームの購読フィードの取得に失敗しました: %w", err)
+	}
+	return feeds, nil
+}
+
+// FindFeed はチームの購読フィードを返す。チームで購読していない場合は nil を返す。
+func (r *PostgresTeamRepo) FindFeed(ctx context.Context, teamID, feedID string) (*model.TeamFeed, error) {
+	var f model.TeamFeed
+	err := r.db.QueryRowContext(ctx,
+		`SELECT f.id, f.title, f.feed_url, COALESCE(f.site_url, ''),
+		        COALESCE(tf.added_by::text, ''), tf.created_at
+		 FROM team_feeds tf
+		 JOIN feeds f ON f.id = tf.feed_id
+		 WHERE tf.team_id = $1 AND tf.feed_id = $2`,
+		teamID, feedID,
+	).Scan(&f.FeedID, &f.Title, &f.FeedURL, &f.SiteURL, &f.AddedBy, &f.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("チームの購読フィードの取得に失敗しました: %w", err)
+	}
+	return &f, nil
+}
+
+// AddFeed はチームの購読フィードを追加し、全メンバーの購読（team_id 付き）として展開する。
+// 既に個人で購読しているメンバーは既存の購読をそのまま使う（チームとは紐付けない）。
+// 購読数が maxSubscriptions に達しているメンバーには展開しない（個人の購読と同じ上限を守る）。
+// 追加と展開は同一トランザクションで行う。
+func (r *PostgresTeamRepo) AddFeed(ctx context.Context, teamID, feedID, addedBy string, maxSubscriptions int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO team_feeds (team_id, feed_id, added_by) VALUES ($1, $2, $3)`,
+		teamID, feedID, addedBy,
+	); err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && string(pgErr.Code) == pgErrCodeUniqueViolation {
+			return ErrTeamFeedAlreadyExists
+		}
+		return fmt.Errorf("チームの購読フィードの追加に失敗しました: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO subscriptions (user_id, feed_id, team_id)
+		 SELECT m.user_id, $2, $1
+		 FROM team_members m
+		 WHERE m.team_id = $1
+		   AND (SELECT count(*) FROM subscriptions s WHERE s.user_id = m.user_id) < $3
+		 ON CONFLICT (user_id, feed_id) DO NOTHING`,
+		teamID, feedID, maxSubscriptions,
+	); err != nil {
+		return fmt.Errorf("チーム購読の展開に失敗しました: %w", err)
+	}
+	if err := reactivateSubscribedFeeds(ctx, tx, feedID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// RemoveFeed はチームの購読フィードを削除し、チームから展開された購読（team_id 付き）と
+// 当該メンバーの記事状態を削除する。チームに追加される前から個人で購読していたメンバーの購読
+// （team_id なし）とその記事状態は残す。対象が無い場合は false を返す。
+func (r *PostgresTeamRepo) RemoveFeed(ctx context.Context, teamID, feedID string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM team_feeds WHERE team_id = $1 AND feed_id = $2`,
+		teamID, feedID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("チームの購読フィードの削除に失敗しました: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return false, fmt.Errorf("チームの購読フィードの削除件数の取得に失敗しました: %w", err)
+	} else if n == 0 {
+		return false, nil
+	}
+
+	if err := deleteTeamSubscriptions(ctx, tx,
+		`s.team_id = $1 AND s.feed_id = $2`, teamID, feedID,
+	); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return true, nil
+}
+
+// deleteTeamSubscriptions は where（subscriptions s に対する条件）に一致するチーム購読と、
+// その購読者の当該フィードの記事状態を削除する。最後の購読者がいなくなったフィードはフェッチを止める。
+func deleteTeamSubscriptions(ctx context.Context, tx *sql.Tx, where string, args ...any) error {
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM item_states st
+		 USING items i, subscriptions s
+		 WHERE st.item_id = i.id
+		   AND st.user_id = s.user_id
+		   AND i.feed_id = s.feed_id
+		   AND `+where,
+		args...,
+	); err != nil {
+		return fmt.Errorf("チーム購読の記事状態の削除に失敗しました: %w", err)
+	}
+	feedIDs, err := queryFeedIDs(ctx, tx,
+		`DELETE FROM subscriptions s WHERE `+where+` RETURNING s.feed_id`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("チーム購読の削除に失敗しました: %w", err)
+	}
+	return releaseUnsubscribedFeeds(ctx, tx, feedIDs...)
+}
+
+// CreateInvitation は招待を保存し、採番された ID と作成日時を invitation に書き戻す。
+func (r *PostgresTeamRepo) CreateInvitation(ctx context.Context, invitation *model.TeamInvitation, tokenHash string) error {
+	if err := r.db.QueryRowContext(ctx,
+		`INSERT INTO team_invitations (team_id, token_hash, invited_by, expires_at)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, created_at`,
+		invitation.TeamID, tokenHash, invitation.InvitedBy, invitation.ExpiresAt,
+	).Scan(&invitation.ID, &invitation.CreatedAt); err != nil {
+		return fmt.Errorf("招待の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// AcceptInvitation は期限内・未使用の招待で userID をメンバーとして追加し、招待を使用済みにする。
+// チームの購読フィードは新メンバーの購読（team_id 付き）として、購読数が maxSubscriptions に
+// 達するまで追加日時の古い順に展開する。既に購読しているフィードは個人の購読のまま残す。
+// 招待行をロックしてから処理するため、同じ招待の同時受諾は 1 件だけが成功する。
+func (r *PostgresTeamRepo) AcceptInvitation(ctx context.Context, tokenHash, userID string, now time.Time, maxMembers, maxSubscriptions int) (*model.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var invitationID string
+	var team model.Team
+	err = tx.QueryRowContext(ctx,
+		`SELECT inv.id, t.id, t.name, t.created_at, t.updated_at
+		 FROM team_invitations inv
+		 JOIN teams t ON t.id = inv.team_id
+		 WHERE inv.token_hash = $1 AND inv.accepted_at IS NULL AND inv.expires_at > $2
+		 FOR UPDATE OF inv, t`,
+		tokenHash, now,
+	).Scan(&invitationID, &team.ID, &team.Name, &team.CreatedAt, &team.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrTeamInvitationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("招待の取得に失敗しました: %w", err)
+	}
+
+	var isMember bool
+	var memberCount int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COALESCE(bool_or(user_id = $2), false), count(*)
+		 FROM team_members WHERE team_id = $1`,
+		team.ID, userID,
+	).Scan(&isMember, &memberCount); err != nil {
+		return nil, fmt.Errorf("チームメンバーの確認に失敗しました: %w", err)
+	}
+	if isMember {
+		return nil, ErrAlreadyTeamMember
+	}
+	if memberCount >= maxMembers {
+		return nil, ErrTeamMemberLimit
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO team_members (team_id, user_id, role, joined_at) VALUES ($1, $2, $3, $4)`,
+		team.ID, userID, string(model.TeamRoleMember), now,
+	); err != nil {
+		return nil, fmt.Errorf("チームメンバーの追加に失敗しました: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE team_invitations SET accepted_by = $2, accepted_at = $3 WHERE id = $1`,
+		invitationID, userID, now,
+	); err != nil {
+		return nil, fmt.Errorf("招待の使用済み化に失敗しました: %w", err)
+	}
+
+	feedIDs, err := queryFeedIDs(ctx, tx,
+		`INSERT INTO subscriptions (user_id, feed_id, team_id)
+		 SELECT $2, tf.feed_id, tf.team_id
+		 FROM team_feeds tf
+		 WHERE tf.team_id = $1
+		   AND NOT EXISTS (SELECT 1 FROM subscriptions s WHERE s.user_id = $2 AND s.feed_id = tf.feed_id)
+		 ORDER BY tf.created_at, tf.feed_id
+		 LIMIT GREATEST($3 - (SELECT count(*) FROM subscriptions s WHERE s.user_id = $2), 0)
+		 ON CONFLICT (user_id, feed_id) DO NOTHING
+		 RETURNING feed_id`,
+		team.ID, userID, maxSubscriptions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("チーム購読の展開に失敗しました: %w", err)
+	}
+	if err := reactivateSubscribedFeeds(ctx, tx, feedIDs...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return &team, nil
+}
+
+// RemoveMember はメンバーをチームから外し、チームから展開された当該メンバーの購読と記事状態を削除する。
+// 所属していない場合は false を返す。
+func (r *PostgresTeamRepo) RemoveMember(ctx context.Context, teamID, userID string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM team_members WHERE team_id = $1 AND user_id = $2`,
+		teamID, userID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("チームメンバーの削除に失敗しました: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return false, fmt.Errorf("チームメンバーの削除件数の取得に失敗しました: %w", err)
+	} else if n == 0 {
+		return false, nil
+	}
+
+	if err := deleteTeamSubscriptions(ctx, tx,
+		`s.team_id = $1 AND s.user_id = $2`, teamID, userID,
+	); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return true, nil
+}
+
+// compile-time interface check
+var _ TeamRepository = (*PostgresTeamRepo)(nil)
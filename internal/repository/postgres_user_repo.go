@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/hitoshi/feedman/internal/model"
 )
@@ -98,5 +99,34 @@ func (r *PostgresUserRepo) DeleteByIDExec(ctx context.Context, q DBTX, id string
 	return nil
 }
 
+// TouchLastActive はユーザーの最終アクティブ日時を now に更新する。
+// 更新前の最終アクティブ日時が dormantBefore より前（休眠中）だった場合は、同じ文で当該ユーザーが購読する
+// アクティブなフィードの次回フェッチ日時を now に前倒しし、true を返す。
+// 既に now 以降の日時が記録されている場合は更新しない。ユーザーが存在しない場合は false を返す。
+func (r *PostgresUserRepo) TouchLastActive(ctx context.Context, userID string, now, dormantBefore time.Time) (bool, error) {
+	var reactivated bool
+	err := r.db.QueryRowContext(ctx,
+		`WITH prev AS (
+		     SELECT last_active_at < $3 AS dormant FROM users WHERE id = $1
+		 ), touched AS (
+		     UPDATE users SET last_active_at = $2 WHERE id = $1 AND last_active_at < $2
+		 ), woken AS (
+		     UPDATE feeds SET next_fetch_at = $2
+		      WHERE fetch_status = 'active' AND next_fetch_at > $2
+		        AND id IN (SELECT feed_id FROM subscriptions WHERE user_id = $1)
+		        AND EXISTS (SELECT 1 FROM prev WHERE dormant)
+		 )
+		 SELECT COALESCE((SELECT dormant FROM prev), false)`,
+		userID, now, dormantBefore,
+	).Scan(&reactivated)
+	if err != nil {
+		return false, fmt.Errorf("failed to touch last active time: %w", err)
+	}
+	return reactivated, nil
+}
+
 // compile-time interface check
-var _ UserRepository = (*PostgresUserRepo)(nil)
+var (
+	_ UserRepository         = (*PostgresUserRepo)(nil)
+	_ UserActivityRepository = (*PostgresUserRepo)(nil)
+)
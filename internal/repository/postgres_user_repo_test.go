@@ -100,3 +100,118 @@ func TestPostgresSessionRepo_DeleteByID_Concept(t *testing.T) {
 		t.Fatal("context should not be nil")
 	}
 }
+
+// TestPostgresUserRepo_TouchLastActive は最終アクティブ日時の更新と、休眠からの復帰時に
+// 購読フィードの次回フェッチ日時を前倒しすることを検証する DB 結合テスト。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresUserRepo_TouchLastActive(t *testing.T) {
+	ctx := context.Background()
+	db := setupListDueTestDB(t)
+	repo := NewPostgresUserRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	dormantBefore := now.Add(-90 * 24 * time.Hour)
+	later := now.Add(24 * time.Hour)
+
+	userID := insertTestUser(t, db, "touch@example.com")
+	feedID := insertTestFeedWithTitle(t, db, "https://example.com/touch.xml", "Touch", "", model.FetchStatusActive)
+	insertTestSubscription(t, db, userID, feedID)
+	if _, err := db.Exec(`UPDATE feeds SET next_fetch_at = $2 WHERE id = $1`, feedID, later); err != nil {
+		t.Fatalf("次回フェッチ日時の更新に失敗: %v", err)
+	}
+	nextFetchAt := func() time.Time {
+		t.Helper()
+		var got time.Time
+		if err := db.QueryRow(`SELECT next_fetch_at FROM feeds WHERE id = $1`, feedID).Scan(&got); err != nil {
+			t.Fatalf("次回フェッチ日時の取得に失敗: %v", err)
+		}
+		return got
+	}
+
+	t.Run("アクティブなユーザーのとき最終アクティブ日時のみ更新する", func(t *testing.T) {
+		reactivated, err := repo.TouchLastActive(ctx, userID, now, dormantBefore)
+		if err != nil {
+			t.Fatalf("TouchLastActive: %v", err)
+		}
+		if reactivated {
+			t.Error("reactivated = true, want false")
+		}
+		var lastActiveAt time.Time
+		if err := db.QueryRow(`SELECT last_active_at FROM users WHERE id = $1`, userID).Scan(&lastActiveAt); err != nil {
+			t.Fatalf("最終アクティブ日時の取得に失敗: %v", err)
+		}
+		if !lastActiveAt.Equal(now) {
+			t.Errorf("last_active_at = %v, want %v", lastActiveAt, now)
+		}
+		if got := nextFetchAt(); !got.Equal(later) {
+			t.Errorf("next_fetch_at = %v, want %v", got, later)
+		}
+	})
+
+	t.Run("休眠中のユーザーのとき購読フィードの次回フェッチを前倒しする", func(t *testing.T) {
+		if _, err := db.Exec(`UPDATE users SET last_active_at = $2 WHERE id = $1`, userID, dormantBefore.Add(-time.Hour)); err != nil {
+			t.Fatalf("最終アクティブ日時の更新に失敗: %v", err)
+		}
+		reactivated, err := repo.TouchLastActive(ctx, userID, now, dormantBefore)
+		if err != nil {
+			t.Fatalf("TouchLastActive: %v", err)
+		}
+		if !reactivated {
+			t.Error("reactivated = false, want true")
+		}
+		if got := nextFetchAt(); !got.Equal(now) {
+			t.Errorf("next_fetch_at = %v, want %v", got, now)
+		}
+	})
+
+	t.Run("ユーザーが存在しないとき false を返す", func(t *testing.T) {
+		reactivated, err := repo.TouchLastActive(ctx, "00000000-0000-0000-0000-000000000000", now, dormantBefore)
+		if err != nil {
+			t.Fatalf("TouchLastActive: %v", err)
+		}
+		if reactivated {
+			t.Error("reactivated = true, want false")
+		}
+	})
+}
+
+// TestPostgresSubscriptionRepo_HasActiveSubscriber は購読者の最終アクティブ日時から
+// アクティブな購読者の有無を判定することを検証する DB 結合テスト。
+// テスト用 PostgreSQL に接続できない場合はスキップする。
+func TestPostgresSubscriptionRepo_HasActiveSubscriber(t *testing.T) {
+	ctx := context.Background()
+	db := setupListDueTestDB(t)
+	repo := NewPostgresSubscriptionRepo(db)
+
+	since := time.Now().Add(-90 * 24 * time.Hour)
+	activeID := insertTestUser(t, db, "active@example.com")
+	dormantID := insertTestUser(t, db, "dormant@example.com")
+	if _, err := db.Exec(`UPDATE users SET last_active_at = $2 WHERE id = $1`, dormantID, since.Add(-time.Hour)); err != nil {
+		t.Fatalf("最終アクティブ日時の更新に失敗: %v", err)
+	}
+	mixedFeed := insertTestFeedWithTitle(t, db, "https://example.com/mixed.xml", "Mixed", "", model.FetchStatusActive)
+	dormantFeed := insertTestFeedWithTitle(t, db, "https://example.com/dormant.xml", "Dormant", "", model.FetchStatusActive)
+	insertTestSubscription(t, db, activeID, mixedFeed)
+	insertTestSubscription(t, db, dormantID, mixedFeed)
+	insertTestSubscription(t, db, dormantID, dormantFeed)
+
+	tests := []struct {
+		name   string
+		feedID string
+		want   bool
+	}{
+		{name: "アクティブな購読者がいるとき true", feedID: mixedFeed, want: true},
+		{name: "休眠ユーザーのみが購読するとき false", feedID: dormantFeed, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.HasActiveSubscriber(ctx, tt.feedID, since)
+			if err != nil {
+				t.Fatalf("HasActiveSubscriber: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasActiveSubscriber = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresUserSettingsRepo は PostgreSQL を使用したユーザー設定リポジトリ。
+type PostgresUserSettingsRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresUserSettingsRepo は PostgresUserSettingsRepo を生成する。
+func NewPostgresUserSettingsRepo(db *sql.DB) *PostgresUserSettingsRepo {
+	return &PostgresUserSettingsRepo{db: db}
+}
+
+// GetUnreadWarningThreshold は当該ユーザーの積読警告の閾値を取得する。
+// user_settings に行が無い、または閾値が未設定（NULL）の場合は (nil, nil) を返す。
+func (r *PostgresUserSettingsRepo) GetUnreadWarningThreshold(ctx context.Context, userID string) (*int, error) {
+	var threshold sql.NullInt64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT unread_warning_threshold FROM user_settings WHERE user_id = $1`,
+		userID,
+	).Scan(&threshold)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("積読警告の閾値の取得に失敗しました: %w", err)
+	}
+	if !threshold.Valid {
+		return nil, nil
+	}
+
+	v := int(threshold.Int64)
+	return &v, nil
+}
+
+// UpsertUnreadWarningThreshold は user_id をキーに積読警告の閾値を冪等に上書き保存する。
+// user_settings に行が無ければ新規挿入し（他の設定は既定値）、存在すれば閾値のみ更新する。
+func (r *PostgresUserSettingsRepo) UpsertUnreadWarningThreshold(ctx context.Context, userID string, threshold int) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_settings (user_id, unread_warning_threshold, updated_at)
+		 VALUES ($1, $2, now())
+		 ON CONFLICT (user_id) DO UPDATE
+		   SET unread_warning_threshold = EXCLUDED.unread_warning_threshold,
+		       updated_at               = now()`,
+		userID, threshold,
+	)
+	if err != nil {
+		return fmt.Errorf("積読警告の閾値の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// GetOpenLinksInNewTab は当該ユーザーの「リンクを新しいタブで開く」設定を取得する。
+// user_settings に行が無い場合は (nil, nil) を返す。
+func (r *PostgresUserSettingsRepo) GetOpenLinksInNewTab(ctx context.Context, userID string) (*bool, error) {
+	var v bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT open_links_in_new_tab FROM user_settings WHERE user_id = $1`,
+		userID,
+	).Scan(&v)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("リンクの開き方の設定の取得に失敗しました: %w", err)
+	}
+	return &v, nil
+}
+
+// UpsertOpenLinksInNewTab は user_id をキーに「リンクを新しいタブで開く」設定を冪等に上書き保存する。
+// user_settings に行が無ければ新規挿入し（他の設定は既定値）、存在すれば当該設定のみ更新する。
+func (r *PostgresUserSettingsRepo) UpsertOpenLinksInNewTab(ctx context.Context, userID string, openInNewTab bool) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_settings (user_id, open_links_in_new_tab, updated_at)
+		 VALUES ($1, $2, now())
+		 ON CONFLICT (user_id) DO UPDATE
+		   SET open_links_in_new_tab = EXCLUDED.open_links_in_new_tab,
+		       updated_at            = now()`,
+		userID, openInNewTab,
+	)
+	if err != nil {
+		return fmt.Errorf("リンクの開き方の設定の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// GetPrefetchEnabled は当該ユーザーの利用時間帯に合わせたプリフェッチの設定を取得する。
+// user_settings に行が無い場合は (nil, nil) を返す。
+func (r *PostgresUserSettingsRepo) GetPrefetchEnabled(ctx context.Context, userID string) (*bool, error) {
+	var v bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT prefetch_enabled FROM user_settings WHERE user_id = $1`,
+		userID,
+	).Scan(&v)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("プリフェッチ設定の取得に失敗しました: %w", err)
+	}
+	return &v, nil
+}
+
+// UpsertPrefetchEnabled は user_id をキーにプリフェッチの設定を冪等に上書き保存する。
+// user_settings に行が無ければ新規挿入し（他の設定は既定値）、存在すれば当該設定のみ更新する。
+func (r *PostgresUserSettingsRepo) UpsertPrefetchEnabled(ctx context.Context, userID string, enabled bool) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_settings (user_id, prefetch_enabled, updated_at)
+		 VALUES ($1, $2, now())
+		 ON CONFLICT (user_id) DO UPDATE
+		   SET prefetch_enabled = EXCLUDED.prefetch_enabled,
+		       updated_at       = now()`,
+		userID, enabled,
+	)
+	if err != nil {
+		return fmt.Errorf("プリフェッチ設定の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// GetTimezone は当該ユーザーのタイムゾーン（IANA 名）を取得する。
+// user_settings に行が無い、またはタイムゾーンが未設定（NULL）の場合は (nil, nil) を返す。
+func (r *PostgresUserSettingsRepo) GetTimezone(ctx context.Context, userID string) (*string, error) {
+	var v sql.NullString
+	err := r.db.QueryRowContext(ctx,
+		`SELECT timezone FROM user_settings WHERE user_id = $1`,
+		userID,
+	).Scan(&v)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("タイムゾーン設定の取得に失敗しました: %w", err)
+	}
+	if !v.Valid {
+		return nil, nil
+	}
+	return &v.String, nil
+}
+
+// UpsertTimezone は user_id をキーにタイムゾーンを冪等に上書き保存する。
+// user_settings に行が無ければ新規挿入し（他の設定は既定値）、存在すれば当該設定のみ更新する。
+func (r *PostgresUserSettingsRepo) UpsertTimezone(ctx context.Context, userID, timezone string) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_settings (user_id, timezone, updated_at)
+		 VALUES ($1, $2, now())
+		 ON CONFLICT (user_id) DO UPDATE
+		   SET timezone   = EXCLUDED.timezone,
+		       updated_at = now()`,
+		userID, timezone,
+	)
+	if err != nil {
+		return fmt.Errorf("タイムゾーン設定の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+var _ UserSettingsRepository = (*PostgresUserSettingsRepo)(nil)
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresWeeklyStatsRepo は PostgreSQL を使用した週次統計スナップショットリポジトリ。
+type PostgresWeeklyStatsRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresWeeklyStatsRepo は PostgresWeeklyStatsRepo を生成する。
+func NewPostgresWeeklyStatsRepo(db *sql.DB) *PostgresWeeklyStatsRepo {
+	return &PostgresWeeklyStatsRepo{db: db}
+}
+
+// SnapshotWeeklyStats は [weekStart, weekEnd) の新着数・既読数・非表示数を全購読について記録し、記録した件数を返す。
+// 新着数は items.created_at、既読数は item_states.read_at、非表示数は item_states.hidden_at で数える。週の終了後に購読したフィードは対象外とする。
+// 記録済みの週・購読は上書きしない（後から記事が削除されても週の記録は変えない）。
+func (r *PostgresWeeklyStatsRepo) SnapshotWeeklyStats(ctx context.Context, weekStart, weekEnd time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO weekly_subscription_stats (user_id, feed_id, week_start, new_items, read_items, hidden_items)
+		 SELECT s.user_id, s.feed_id, $1,
+		        (SELECT COUNT(*) FROM items i
+		          WHERE i.feed_id = s.feed_id AND i.created_at >= $1 AND i.created_at < $2),
+		        (SELECT COUNT(*) FROM item_states st
+		           JOIN items i ON i.id = st.item_id
+		          WHERE st.user_id = s.user_id AND i.feed_id = s.feed_id
+		            AND st.is_read = true AND st.read_at >= $1 AND st.read_at < $2),
+		        (SELECT COUNT(*) FROM item_states st
+		           JOIN items i ON i.id = st.item_id
+		          WHERE st.user_id = s.user_id AND i.feed_id = s.feed_id
+		            AND st.is_hidden = true AND st.hidden_at >= $1 AND st.hidden_at < $2)
+		   FROM subscriptions s
+		  WHERE s.created_at < $2
+		 ON CONFLICT (user_id, week_start, feed_id) DO NOTHING`,
+		weekStart, weekEnd,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("週次統計の記録に失敗しました: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("記録件数の取得に失敗しました: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteWeeklyStatsBefore は week_start が before より前のスナップショットを削除し、削除件数を返す。
+func (r *PostgresWeeklyStatsRepo) DeleteWeeklyStatsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM weekly_subscription_stats WHERE week_start < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("週次統計の削除に失敗しました: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("削除件数の取得に失敗しました: %w", err)
+	}
+	return n, nil
+}
+
+// ListWeeklyStatsByUser は week_start が since 以降の当該ユーザーのスナップショットを週の古い順に返す。
+// 同じ週の中はフィードタイトル順に並べる。購読解除済みのフィードの記録も含む。
+func (r *PostgresWeeklyStatsRepo) ListWeeklyStatsByUser(ctx context.Context, userID string, since time.Time) ([]model.WeeklyFeedStat, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT w.week_start, w.feed_id, f.title, w.new_items, w.read_items, w.hidden_items
+		   FROM weekly_subscription_stats w
+		   JOIN feeds f ON f.id = w.feed_id
+		  WHERE w.user_id = $1 AND w.week_start >= $2
+		  ORDER BY w.week_start, f.title, w.feed_id`,
+		userID, since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("週次統計の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []model.WeeklyFeedStat
+	for rows.Next() {
+		var s model.WeeklyFeedStat
+		if err := rows.Scan(&s.WeekStart, &s.FeedID, &s.FeedTitle, &s.NewItems, &s.ReadItems, &s.HiddenItems); err != nil {
+			return nil, fmt.Errorf("週次統計の読み取りに失敗しました: %w", err)
+		}
+		s.WeekStart = s.WeekStart.UTC()
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("週次統計の読み取りに失敗しました: %w", err)
+	}
+	return stats, nil
+}
+
+// compile-time interface check
+var _ WeeklyStatsRepository = (*PostgresWeeklyStatsRepo)(nil)
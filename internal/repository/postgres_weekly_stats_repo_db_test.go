@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介した週次統計スナップショットの結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresWeeklyStatsRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	userID := insertTestUserForSub(t, db, "weekly-owner@example.com")
+	feedID := insertTestFeedForSub(t, db, "https://example.com/weekly.xml", "Weekly Feed", nil)
+	insertTestSubscriptionForSub(t, db, userID, feedID)
+	insertTestItemsForSub(t, db, feedID, 3)
+
+	// 3 件のうち 2 件を既読にする
+	if _, err := db.Exec(
+		`INSERT INTO item_states (user_id, item_id, is_read, read_at)
+		 SELECT $1, id, true, now() FROM items WHERE feed_id = $2 ORDER BY guid_or_id LIMIT 2`,
+		userID, feedID,
+	); err != nil {
+		t.Fatalf("既読状態の挿入に失敗: %v", err)
+	}
+	// 残りの 1 件は既読にせず非表示にする
+	if _, err := db.Exec(
+		`INSERT INTO item_states (user_id, item_id, is_hidden, hidden_at)
+		 SELECT $1, id, true, now() FROM items WHERE feed_id = $2 ORDER BY guid_or_id OFFSET 2`,
+		userID, feedID,
+	); err != nil {
+		t.Fatalf("非表示状態の挿入に失敗: %v", err)
+	}
+
+	repo := NewPostgresWeeklyStatsRepo(db)
+	weekStart := model.WeekStart(time.Now())
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	t.Run("週内の新着数・既読数・非表示数を購読ごとに記録する", func(t *testing.T) {
+		n, err := repo.SnapshotWeeklyStats(ctx, weekStart, weekEnd)
+		if err != nil || n != 1 {
+			t.Fatalf("SnapshotWeeklyStats() = (%d, %v), want (1, nil)", n, err)
+		}
+
+		got, err := repo.ListWeeklyStatsByUser(ctx, userID, weekStart)
+		if err != nil {
+			t.Fatalf("ListWeeklyStatsByUser() error = %v", err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("len = %d, want 1", len(got))
+		}
+		if s := got[0]; !s.WeekStart.Equal(weekStart) || s.FeedID != feedID || s.FeedTitle != "Weekly Feed" || s.NewItems != 3 || s.ReadItems != 2 || s.HiddenItems != 1 {
+			t.Errorf("stat = %+v, want week %v new=3 read=2 hidden=1", s, weekStart)
+		}
+	})
+
+	t.Run("記録済みの週は上書きしない", func(t *testing.T) {
+		n, err := repo.SnapshotWeeklyStats(ctx, weekStart, weekEnd)
+		if err != nil || n != 0 {
+			t.Errorf("SnapshotWeeklyStats() = (%d, %v), want (0, nil)", n, err)
+		}
+	})
+
+	t.Run("基準より前の週のスナップショットを削除する", func(t *testing.T) {
+		n, err := repo.DeleteWeeklyStatsBefore(ctx, weekStart)
+		if err != nil || n != 0 {
+			t.Errorf("DeleteWeeklyStatsBefore(weekStart) = (%d, %v), want (0, nil)", n, err)
+		}
+		n, err = repo.DeleteWeeklyStatsBefore(ctx, weekEnd)
+		if err != nil || n != 1 {
+			t.Errorf("DeleteWeeklyStatsBefore(weekEnd) = (%d, %v), want (1, nil)", n, err)
+		}
+	})
+}
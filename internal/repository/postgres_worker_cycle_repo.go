@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// PostgresWorkerCycleRepo は PostgreSQL を使用したフェッチサイクル結果リポジトリ。
+type PostgresWorkerCycleRepo struct {
+	db *sql.DB
+}
+
+// NewPostgresWorkerCycleRepo は PostgresWorkerCycleRepo を生成する。
+func NewPostgresWorkerCycleRepo(db *sql.DB) *PostgresWorkerCycleRepo {
+	return &PostgresWorkerCycleRepo{db: db}
+}
+
+// RecordWorkerCycle はサイクル 1 回分の結果を保存する。
+func (r *PostgresWorkerCycleRepo) RecordWorkerCycle(ctx context.Context, cycle model.WorkerCycle) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO worker_cycles
+		     (started_at, finished_at, feed_count, succeeded_count, failed_count,
+		      items_inserted, items_updated, duration_ms)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		cycle.StartedAt, cycle.FinishedAt, cycle.FeedCount, cycle.SucceededCount, cycle.FailedCount,
+		cycle.ItemsInserted, cycle.ItemsUpdated, cycle.Duration.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("フェッチサイクル結果の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ListRecentWorkerCycles は開始時刻の新しい順に最大 limit 件のサイクル結果を返す。
+func (r *PostgresWorkerCycleRepo) ListRecentWorkerCycles(ctx context.Context, limit int) ([]model.WorkerCycle, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, started_at, finished_at, feed_count, succeeded_count, failed_count,
+		        items_inserted, items_updated, duration_ms
+		 FROM worker_cycles
+		 ORDER BY started_at DESC, id DESC
+		 LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("フェッチサイクル結果の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var cycles []model.WorkerCycle
+	for rows.Next() {
+		var c model.WorkerCycle
+		var durationMs int64
+		if err := rows.Scan(
+			&c.ID, &c.StartedAt, &c.FinishedAt, &c.FeedCount, &c.SucceededCount, &c.FailedCount,
+			&c.ItemsInserted, &c.ItemsUpdated, &durationMs,
+		); err != nil {
+			return nil, fmt.Errorf("フェッチサイクル結果の読み取りに失敗しました: %w", err)
+		}
+		c.Duration = time.Duration(durationMs) * time.Millisecond
+		cycles = append(cycles, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("フェッチサイクル結果の走査に失敗しました: %w", err)
+	}
+	return cycles, nil
+}
+
+// DeleteWorkerCyclesBefore は before より前に開始したサイクル結果を削除し、削除件数を返す。
+func (r *PostgresWorkerCycleRepo) DeleteWorkerCyclesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM worker_cycles WHERE started_at < $1`, before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("古いフェッチサイクル結果の削除に失敗しました: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("削除結果の取得に失敗しました: %w", err)
+	}
+	return deleted, nil
+}
+
+// compile-time interface check
+var _ WorkerCycleRepository = (*PostgresWorkerCycleRepo)(nil)
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// このファイルはテスト用 PostgreSQL を介したフェッチサイクル結果の結合テスト。
+// DB へ接続できない環境では setupSubscriptionTestDB が t.Skip でスキップする。
+
+func TestPostgresWorkerCycleRepo(t *testing.T) {
+	db := setupSubscriptionTestDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	repo := NewPostgresWorkerCycleRepo(db)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	t.Run("記録したサイクル結果を開始時刻の新しい順に返す", func(t *testing.T) {
+		for _, c := range []model.WorkerCycle{
+			{StartedAt: now.Add(-48 * time.Hour), FinishedAt: now.Add(-48 * time.Hour), Duration: 0},
+			{StartedAt: now.Add(-10 * time.Minute), FinishedAt: now.Add(-10*time.Minute + 2*time.Second),
+				FeedCount: 3, SucceededCount: 2, FailedCount: 1, ItemsInserted: 5, ItemsUpdated: 1, Duration: 2 * time.Second},
+			{StartedAt: now.Add(-5 * time.Minute), FinishedAt: now.Add(-5 * time.Minute), FeedCount: 0, Duration: 0},
+		} {
+			if err := repo.RecordWorkerCycle(ctx, c); err != nil {
+				t.Fatalf("RecordWorkerCycle() error = %v", err)
+			}
+		}
+
+		cycles, err := repo.ListRecentWorkerCycles(ctx, 2)
+		if err != nil {
+			t.Fatalf("ListRecentWorkerCycles() error = %v", err)
+		}
+		if len(cycles) != 2 {
+			t.Fatalf("len = %d, want 2", len(cycles))
+		}
+		if !cycles[0].StartedAt.Equal(now.Add(-5*time.Minute)) || !cycles[1].StartedAt.Equal(now.Add(-10*time.Minute)) {
+			t.Errorf("順序が不正: %v, %v", cycles[0].StartedAt, cycles[1].StartedAt)
+		}
+		got := cycles[1]
+		if got.FeedCount != 3 || got.SucceededCount != 2 || got.FailedCount != 1 ||
+			got.ItemsInserted != 5 || got.ItemsUpdated != 1 || got.Duration != 2*time.Second {
+			t.Errorf("cycle = %+v, want 記録した値", got)
+		}
+	})
+
+	t.Run("保持期間を過ぎたサイクル結果を削除する", func(t *testing.T) {
+		deleted, err := repo.DeleteWorkerCyclesBefore(ctx, now.Add(-24*time.Hour))
+		if err != nil {
+			t.Fatalf("DeleteWorkerCyclesBefore() error = %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("deleted = %d, want 1", deleted)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// DefaultRedisSessionKeyPrefix は Redis セッションストアのキー接頭辞の既定値。
+const DefaultRedisSessionKeyPrefix = "feedman:"
+
+// redisSessionRecord は Redis に保存するセッションの JSON 表現。
+type redisSessionRecord struct {
+	UserID     string    `json:"user_id"`
+	IdentityID string    `json:"identity_id,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RedisSessionRepo は Redis を使用したセッションリポジトリ。
+//
+// キー構成:
+//   - {prefix}session:{id}            : セッション本体（JSON）。expires_at までの TTL を付与し自動失効させる。
+//   - {prefix}user_sessions:{user_id} : ユーザーのセッション ID 集合（DeleteByUserID / DeleteByIdentityID 用の索引）。
+//     TTL は保持するセッションのうち最も遅い失効時刻に合わせて延長する。
+//
+// 索引に残った失効済み ID は DeleteByUserID 時にまとめて削除されるため、別途の掃除は不要。
+type RedisSessionRepo struct {
+	client redis.UniversalClient
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisSessionRepo は RedisSessionRepo を生成する。prefix が空の場合は DefaultRedisSessionKeyPrefix を使う。
+func NewRedisSessionRepo(client redis.UniversalClient, prefix string) *RedisSessionRepo {
+	if prefix == "" {
+		prefix = DefaultRedisSessionKeyPrefix
+	}
+	return &RedisSessionRepo{client: client, prefix: prefix, now: time.Now}
+}
+
+func (r *RedisSessionRepo) sessionKey(id string) string {
+	return r.prefix + "session:" + id
+}
+
+func (r *RedisSessionRepo) userSessionsKey(userID string) string {
+	return r.prefix + "user_sessions:" + userID
+}
+
+// Create はセッションを作成する。既に失効しているセッションは保存しない。
+func (r *RedisSessionRepo) Create(ctx context.Context, session *model.Session) error {
+	ttl := session.ExpiresAt.Sub(r.now())
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(redisSessionRecord{
+		UserID:     session.UserID,
+		IdentityID: session.IdentityID,
+		ExpiresAt:  session.ExpiresAt,
+		CreatedAt:  session.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	userKey := r.userSessionsKey(session.UserID)
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, r.sessionKey(session.ID), data, ttl)
+		pipe.SAdd(ctx, userKey, session.ID)
+		// 索引の TTL は最も遅いセッションに合わせて延長のみ行う（GT: 既存より長い場合のみ更新）
+		pipe.ExpireNX(ctx, userKey, ttl)
+		pipe.ExpireGT(ctx, userKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// FindByID は指定IDのセッションを取得する。期限切れの場合はnilを返す。
+func (r *RedisSessionRepo) FindByID(ctx context.Context, id string) (*model.Session, error) {
+	data, err := r.client.Get(ctx, r.sessionKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	var rec redisSessionRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	// TTL と expires_at のずれ（時計差・丸め）に備えて expires_at でも判定する
+	if !rec.ExpiresAt.After(r.now()) {
+		return nil, nil
+	}
+
+	return &model.Session{
+		ID:         id,
+		UserID:     rec.UserID,
+		IdentityID: rec.IdentityID,
+		ExpiresAt:  rec.ExpiresAt,
+		CreatedAt:  rec.CreatedAt,
+	}, nil
+}
+
+// DeleteByID は指定IDのセッションを削除する。
+// ユーザー索引からの除去は DeleteByUserID に委ねる（本体が無ければ索引の ID は無害なため）。
+func (r *RedisSessionRepo) DeleteByID(ctx context.Context, id string) error {
+	if err := r.client.Del(ctx, r.sessionKey(id)).Err(); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUserID は指定ユーザーの全セッションを削除する。
+func (r *RedisSessionRepo) DeleteByUserID(ctx context.Context, userID string) error {
+	userKey := r.userSessionsKey(userID)
+	ids, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, r.sessionKey(id))
+	}
+	keys = append(keys, userKey)
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	return nil
+}
+
+// DeleteByIdentityID は指定ユーザーのセッションのうち identityID の identity でのログインで発行したものと、
+// 発行元の identity が記録されていないものを削除する。
+// ユーザー索引のセッションを読み出して判定するため、失効済み・復号できないセッションの ID も併せて索引から除く。
+func (r *RedisSessionRepo) DeleteByIdentityID(ctx context.Context, userID, identityID string) error {
+	userKey := r.userSessionsKey(userID)
+	ids, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list user sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.sessionKey(id)
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return fmt.Errorf("failed to load user sessions: %w", err)
+	}
+
+	var delKeys []string
+	var staleIDs []any
+	for i, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			// 失効済み（本体が無い）セッションは索引から除くだけでよい
+			staleIDs = append(staleIDs, ids[i])
+			continue
+		}
+		var rec redisSessionRecord
+		if err := json.Unmarshal([]byte(data), &rec); err == nil && rec.IdentityID != "" && rec.IdentityID != identityID {
+			continue
+		}
+		delKeys = append(delKeys, keys[i])
+		staleIDs = append(staleIDs, ids[i])
+	}
+	if len(staleIDs) == 0 {
+		return nil
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(delKeys) > 0 {
+			pipe.Del(ctx, delKeys...)
+		}
+		pipe.SRem(ctx, userKey, staleIDs...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete identity sessions: %w", err)
+	}
+	return nil
+}
+
+// compile-time interface check
+var _ SessionRepository = (*RedisSessionRepo)(nil)
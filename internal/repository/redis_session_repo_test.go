@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// newTestRedisSessionRepo は miniredis 上の RedisSessionRepo を返す。
+func newTestRedisSessionRepo(t *testing.T) (*RedisSessionRepo, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisSessionRepo(client, ""), mr
+}
+
+func TestRedisSessionRepo_CreateAndFind(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("作成したセッションを取得できる", func(t *testing.T) {
+		// Arrange
+		repo, _ := newTestRedisSessionRepo(t)
+		now := time.Now().UTC().Truncate(time.Second)
+		session := &model.Session{ID: "sess-1", UserID: "user-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+
+		// Act
+		if err := repo.Create(ctx, session); err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		got, err := repo.FindByID(ctx, "sess-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("FindByID returned error: %v", err)
+		}
+		if got == nil || got.UserID != "user-1" || !got.ExpiresAt.Equal(session.ExpiresAt) || !got.CreatedAt.Equal(now) {
+			t.Errorf("FindByID = %+v, want %+v", got, session)
+		}
+	})
+
+	t.Run("存在しないセッションのときnilを返す", func(t *testing.T) {
+		// Arrange
+		repo, _ := newTestRedisSessionRepo(t)
+
+		// Act
+		got, err := repo.FindByID(ctx, "missing")
+
+		// Assert
+		if err != nil || got != nil {
+			t.Errorf("FindByID = (%+v, %v), want (nil, nil)", got, err)
+		}
+	})
+
+	t.Run("TTLが経過したとき自動で失効する", func(t *testing.T) {
+		// Arrange
+		repo, mr := newTestRedisSessionRepo(t)
+		now := time.Now()
+		_ = repo.Create(ctx, &model.Session{ID: "sess-1", UserID: "user-1", ExpiresAt: now.Add(time.Minute), CreatedAt: now})
+
+		// Act
+		mr.FastForward(2 * time.Minute)
+		got, err := repo.FindByID(ctx, "sess-1")
+
+		// Assert
+		if err != nil || got != nil {
+			t.Errorf("FindByID = (%+v, %v), want (nil, nil)", got, err)
+		}
+	})
+
+	t.Run("expires_atが過ぎたときキーが残っていてもnilを返す", func(t *testing.T) {
+		// Arrange
+		repo, _ := newTestRedisSessionRepo(t)
+		now := time.Now()
+		_ = repo.Create(ctx, &model.Session{ID: "sess-1", UserID: "user-1", ExpiresAt: now.Add(time.Minute), CreatedAt: now})
+		repo.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+		// Act
+		got, err := repo.FindByID(ctx, "sess-1")
+
+		// Assert
+		if err != nil || got != nil {
+			t.Errorf("FindByID = (%+v, %v), want (nil, nil)", got, err)
+		}
+	})
+
+	t.Run("既に失効したセッションは保存しない", func(t *testing.T) {
+		// Arrange
+		repo, mr := newTestRedisSessionRepo(t)
+		now := time.Now()
+
+		// Act
+		err := repo.Create(ctx, &model.Session{ID: "sess-1", UserID: "user-1", ExpiresAt: now.Add(-time.Minute), CreatedAt: now})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		if mr.Exists(DefaultRedisSessionKeyPrefix + "session:sess-1") {
+			t.Error("失効済みセッションが保存されている")
+		}
+	})
+}
+
+func TestRedisSessionRepo_Delete(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("DeleteByIDで指定セッションのみ削除する", func(t *testing.T) {
+		// Arrange
+		repo, _ := newTestRedisSessionRepo(t)
+		_ = repo.Create(ctx, &model.Session{ID: "sess-1", UserID: "user-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+		_ = repo.Create(ctx, &model.Session{ID: "sess-2", UserID: "user-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+
+		// Act
+		if err := repo.DeleteByID(ctx, "sess-1"); err != nil {
+			t.Fatalf("DeleteByID returned error: %v", err)
+		}
+
+		// Assert
+		if got, _ := repo.FindByID(ctx, "sess-1"); got != nil {
+			t.Error("sess-1 は削除されるべき")
+		}
+		if got, _ := repo.FindByID(ctx, "sess-2"); got == nil {
+			t.Error("sess-2 は残るべき")
+		}
+	})
+
+	t.Run("DeleteByUserIDで当該ユーザーの全セッションと索引を削除する", func(t *testing.T) {
+		// Arrange
+		repo, mr := newTestRedisSessionRepo(t)
+		_ = repo.Create(ctx, &model.Session{ID: "sess-1", UserID: "user-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+		_ = repo.Create(ctx, &model.Session{ID: "sess-2", UserID: "user-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+		_ = repo.Create(ctx, &model.Session{ID: "sess-3", UserID: "user-2", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+
+		// Act
+		if err := repo.DeleteByUserID(ctx, "user-1"); err != nil {
+			t.Fatalf("DeleteByUserID returned error: %v", err)
+		}
+
+		// Assert
+		for _, id := range []string{"sess-1", "sess-2"} {
+			if got, _ := repo.FindByID(ctx, id); got != nil {
+				t.Errorf("%s は削除されるべき", id)
+			}
+		}
+		if got, _ := repo.FindByID(ctx, "sess-3"); got == nil {
+			t.Error("他ユーザーのセッションは残るべき")
+		}
+		if mr.Exists(DefaultRedisSessionKeyPrefix + "user_sessions:user-1") {
+			t.Error("ユーザー索引は削除されるべき")
+		}
+	})
+	t.Run("DeleteByIdentityIDで当該identityと発行元不明のセッションだけを削除する", func(t *testing.T) {
+		// Arrange
+		repo, mr := newTestRedisSessionRepo(t)
+		_ = repo.Create(ctx, &model.Session{ID: "sess-google", UserID: "user-1", IdentityID: "ident-google", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+		_ = repo.Create(ctx, &model.Session{ID: "sess-github", UserID: "user-1", IdentityID: "ident-github", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+		_ = repo.Create(ctx, &model.Session{ID: "sess-legacy", UserID: "user-1", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+		_ = repo.Create(ctx, &model.Session{ID: "sess-other", UserID: "user-2", IdentityID: "ident-google", ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+
+		// Act
+		if err := repo.DeleteByIdentityID(ctx, "user-1", "ident-google"); err != nil {
+			t.Fatalf("DeleteByIdentityID returned error: %v", err)
+		}
+
+		// Assert
+		for _, id := range []string{"sess-google", "sess-legacy"} {
+			if got, _ := repo.FindByID(ctx, id); got != nil {
+				t.Errorf("%s は削除されるべき", id)
+			}
+		}
+		for _, id := range []string{"sess-github", "sess-other"} {
+			if got, _ := repo.FindByID(ctx, id); got == nil {
+				t.Errorf("%s は残るべき", id)
+			}
+		}
+		members, _ := mr.Members(DefaultRedisSessionKeyPrefix + "user_sessions:user-1")
+		if len(members) != 1 || members[0] != "sess-github" {
+			t.Errorf("user index = %v, want [sess-github]", members)
+		}
+	})
+}
+
+func TestRedisSessionRepo_UserIndexTTL(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo, mr := newTestRedisSessionRepo(t)
+	now := time.Now()
+	_ = repo.Create(ctx, &model.Session{ID: "long", UserID: "user-1", ExpiresAt: now.Add(2 * time.Hour), CreatedAt: now})
+
+	// Act: 後から短いセッションを作成しても索引の TTL は短縮されない
+	_ = repo.Create(ctx, &model.Session{ID: "short", UserID: "user-1", ExpiresAt: now.Add(time.Minute), CreatedAt: now})
+
+	// Assert
+	ttl := mr.TTL(DefaultRedisSessionKeyPrefix + "user_sessions:user-1")
+	if ttl < time.Hour {
+		t.Errorf("user index TTL = %v, want >= 1h", ttl)
+	}
+}
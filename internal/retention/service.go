@@ -0,0 +1,55 @@
+// Package retention は購読単位の最大記事保持数（retention_override）の設定を提供する。
+//
+// ユーザーは購読ごとに「新しい記事から N 件だけ保持する」上書き設定を行える。記事はフィード単位で
+// 共有されるため、実際の削除はクリーンアップジョブが担い、全購読者が上書きを設定しているフィード
+// にのみ適用する（保持数は購読者間の最大値を採用する）。上書き未設定の購読者が 1 人でもいる
+// フィードは、従来どおり全体の保持期間（日数）に従う。
+package retention
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service は購読単位の最大記事保持数のサービス層。
+type Service struct {
+	repo repository.SubscriptionRetentionRepository
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.SubscriptionRetentionRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// GetOverride は当該ユーザーの購読の保持数設定を返す。
+// 対象購読が存在しない、または他ユーザーの購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) GetOverride(ctx context.Context, userID, subscriptionID string) (*model.RetentionOverride, error) {
+	override, err := s.repo.GetRetentionOverride(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("最大記事保持数の取得に失敗しました: %w", err)
+	}
+	if override == nil {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	return override, nil
+}
+
+// UpdateOverride は当該ユーザーの購読の保持数設定を検証して上書き保存する。
+// maxItems に nil を指定すると上書きを解除し、全体の保持期間に従う。
+func (s *Service) UpdateOverride(ctx context.Context, userID, subscriptionID string, maxItems *int) (*model.RetentionOverride, error) {
+	if maxItems != nil && (*maxItems < model.MinRetentionOverride || *maxItems > model.MaxRetentionOverride) {
+		return nil, model.NewInvalidRetentionOverrideError(*maxItems)
+	}
+
+	updated, err := s.repo.UpdateRetentionOverride(ctx, userID, subscriptionID, maxItems)
+	if err != nil {
+		return nil, fmt.Errorf("最大記事保持数の更新に失敗しました: %w", err)
+	}
+	if !updated {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	return &model.RetentionOverride{MaxItems: maxItems}, nil
+}
@@ -0,0 +1,179 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// --- テスト用モック ---
+
+// mockRetentionRepo は SubscriptionRetentionRepository のモック。
+type mockRetentionRepo struct {
+	getFn       func(ctx context.Context, userID, subscriptionID string) (*model.RetentionOverride, error)
+	updateFn    func(ctx context.Context, userID, subscriptionID string, maxItems *int) (bool, error)
+	updateCalls int
+}
+
+func (m *mockRetentionRepo) GetRetentionOverride(ctx context.Context, userID, subscriptionID string) (*model.RetentionOverride, error) {
+	if m.getFn != nil {
+		return m.getFn(ctx, userID, subscriptionID)
+	}
+	return &model.RetentionOverride{}, nil
+}
+
+func (m *mockRetentionRepo) UpdateRetentionOverride(ctx context.Context, userID, subscriptionID string, maxItems *int) (bool, error) {
+	m.updateCalls++
+	if m.updateFn != nil {
+		return m.updateFn(ctx, userID, subscriptionID, maxItems)
+	}
+	return true, nil
+}
+
+var _ repository.SubscriptionRetentionRepository = (*mockRetentionRepo)(nil)
+
+// assertAPIErrorCode は err が指定コードの APIError であることを検証する。
+func assertAPIErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Fatalf("err = %v, want APIError code %s", err, code)
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+// --- GetOverride ---
+
+func TestService_GetOverride(t *testing.T) {
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockRetentionRepo{
+			getFn: func(context.Context, string, string) (*model.RetentionOverride, error) { return nil, nil },
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.GetOverride(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+	})
+
+	t.Run("設定済みのとき保持数を返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockRetentionRepo{
+			getFn: func(context.Context, string, string) (*model.RetentionOverride, error) {
+				return &model.RetentionOverride{MaxItems: intPtr(200)}, nil
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.GetOverride(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("GetOverride() error = %v", err)
+		}
+		if got.MaxItems == nil || *got.MaxItems != 200 {
+			t.Errorf("MaxItems = %v, want 200", got.MaxItems)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockRetentionRepo{
+			getFn: func(context.Context, string, string) (*model.RetentionOverride, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.GetOverride(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// --- UpdateOverride ---
+
+func TestService_UpdateOverride(t *testing.T) {
+	t.Run("範囲内の保持数のとき保存して返す", func(t *testing.T) {
+		// Arrange
+		var saved *int
+		repo := &mockRetentionRepo{
+			updateFn: func(_ context.Context, _, _ string, maxItems *int) (bool, error) {
+				saved = maxItems
+				return true, nil
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.UpdateOverride(context.Background(), "user-1", "sub-1", intPtr(200))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("UpdateOverride() error = %v", err)
+		}
+		if saved == nil || *saved != 200 || got.MaxItems == nil || *got.MaxItems != 200 {
+			t.Errorf("saved = %v, got = %v, want 200", saved, got.MaxItems)
+		}
+	})
+
+	t.Run("nilのとき上書きを解除する", func(t *testing.T) {
+		// Arrange
+		repo := &mockRetentionRepo{}
+		svc := NewService(repo)
+
+		// Act
+		got, err := svc.UpdateOverride(context.Background(), "user-1", "sub-1", nil)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("UpdateOverride() error = %v", err)
+		}
+		if got.MaxItems != nil || repo.updateCalls != 1 {
+			t.Errorf("MaxItems = %v, updateCalls = %d, want nil, 1", got.MaxItems, repo.updateCalls)
+		}
+	})
+
+	t.Run("範囲外の保持数のときINVALID_RETENTION_OVERRIDEを返し保存しない", func(t *testing.T) {
+		for _, n := range []int{0, model.MinRetentionOverride - 1, model.MaxRetentionOverride + 1} {
+			// Arrange
+			repo := &mockRetentionRepo{}
+			svc := NewService(repo)
+
+			// Act
+			_, err := svc.UpdateOverride(context.Background(), "user-1", "sub-1", intPtr(n))
+
+			// Assert
+			assertAPIErrorCode(t, err, model.ErrCodeInvalidRetentionOverride)
+			if repo.updateCalls != 0 {
+				t.Errorf("n=%d: updateCalls = %d, want 0", n, repo.updateCalls)
+			}
+		}
+	})
+
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockRetentionRepo{
+			updateFn: func(context.Context, string, string, *int) (bool, error) { return false, nil },
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.UpdateOverride(context.Background(), "user-1", "sub-1", intPtr(100))
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSubscriptionNotFound)
+	})
+}
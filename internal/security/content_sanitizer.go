@@ -8,6 +8,7 @@ package security
 
 import (
 	"net/url"
+	"sync"
 
 	"github.com/microcosm-cc/bluemonday"
 )
@@ -38,6 +39,7 @@ type contentSanitizer struct {
 //   - 禁止タグ: script, iframe, style および全てのon*イベント属性
 //   - imgのsrc属性: httpsスキームのみ許可
 //   - aタグ: target="_blank" と rel="noopener noreferrer" を自動付与
+//     （API 応答時に ApplyLinkAttributes でユーザー設定に応じて target を付け直す）
 func NewContentSanitizer() *contentSanitizer {
 	p := bluemonday.NewPolicy()
 
@@ -75,6 +77,16 @@ func NewContentSanitizer() *contentSanitizer {
 	}
 }
 
+// sharedContentSanitizer は SharedContentSanitizer が返すプロセス内で共有のインスタンス。
+var sharedContentSanitizer = sync.OnceValue(NewContentSanitizer)
+
+// SharedContentSanitizer はプロセス内で共有する ContentSanitizerService を返す。
+// ポリシーの構築は初回呼び出し時の 1 度だけ行い、以降は同じインスタンスを返す。
+// 構築後のポリシーは変更しないため、複数のゴルーチンから同時に Sanitize を呼び出してよい。
+func SharedContentSanitizer() ContentSanitizerService {
+	return sharedContentSanitizer()
+}
+
 // Sanitize はHTMLコンテンツをサニタイズして安全なHTMLを返す。
 func (s *contentSanitizer) Sanitize(rawHTML string) string {
 	return s.policy.Sanitize(rawHTML)
@@ -0,0 +1,174 @@
+package security
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// dnsCacheSweepThreshold はキャッシュの件数がこれに達したときに期限切れのエントリを掃除する件数。
+const dnsCacheSweepThreshold = 1024
+
+// DNSCache はフェッチ用 HTTP クライアントのホスト名解決の結果を TTL 付きでキャッシュする。
+// 大量のフィードを同じホストから取得する場合に DNS 解決の重複を避け、解決に失敗したホストは
+// negativeTTL の間その失敗を返して問い合わせを繰り返さない（ネガティブキャッシュ）。
+// 同じホストの解決が同時に要求された場合は 1 回だけ問い合わせ、残りはその結果を待つ。
+//
+// キャッシュするのはホスト名から IP アドレスへの解決結果だけで、接続先 IP の検証は行わない。
+// 接続は解決済みの IP アドレス宛てに safeurl の Dialer で行うため、SSRF 防止の IP 検証は
+// キャッシュの有無にかかわらず接続ごとに適用される。
+type DNSCache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	lookup      func(ctx context.Context, host string) ([]string, error)
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*dnsCacheEntry
+}
+
+// dnsCacheEntry はホスト 1 件分の解決結果。done は解決が終わると close される。
+type dnsCacheEntry struct {
+	addrs     []string
+	err       error
+	expiresAt time.Time
+	done      chan struct{}
+}
+
+// NewDNSCache は DNSCache を生成する。
+// ttl は解決に成功した結果を、negativeTTL は解決に失敗した結果を保持する期間。negativeTTL が 0 の場合は失敗を保持しない。
+func NewDNSCache(ttl, negativeTTL time.Duration) *DNSCache {
+	return &DNSCache{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		lookup:      net.DefaultResolver.LookupHost,
+		now:         time.Now,
+		entries:     make(map[string]*dnsCacheEntry),
+	}
+}
+
+// WithDNSCache は NewSafeClient が生成するクライアントのホスト名解決に cache を使う。
+func WithDNSCache(cache *DNSCache) SSRFGuardOption {
+	return func(g *ssrfGuard) {
+		g.dnsCache = cache
+	}
+}
+
+// LookupHost は host の IP アドレスを IPv4 を先にして返す。有効期限内の結果があればそれを返す。
+// 呼び出し元の ctx のキャンセル・タイムアウトで中断した解決は失敗としてキャッシュしない。
+func (c *DNSCache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	for {
+		c.mu.Lock()
+		e, ok := c.entries[host]
+		if ok {
+			select {
+			case <-e.done:
+				if c.now().Before(e.expiresAt) {
+					c.mu.Unlock()
+					return e.addrs, e.err
+				}
+			default:
+				// 他の goroutine が解決中のため、その結果を待つ
+				c.mu.Unlock()
+				select {
+				case <-e.done:
+					continue
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+		}
+
+		if len(c.entries) >= dnsCacheSweepThreshold {
+			c.sweepLocked()
+		}
+		e = &dnsCacheEntry{done: make(chan struct{})}
+		c.entries[host] = e
+		c.mu.Unlock()
+
+		addrs, err := c.lookup(ctx, host)
+		if err == nil && len(addrs) == 0 {
+			err = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		sortIPv4First(addrs)
+
+		c.mu.Lock()
+		e.addrs, e.err = addrs, err
+		now := c.now()
+		switch {
+		case err == nil:
+			e.expiresAt = now.Add(c.ttl)
+		case ctx.Err() != nil:
+			// 呼び出し元の都合で中断した解決はホストの失敗ではないため、保持しない
+			e.expiresAt = now
+		default:
+			e.expiresAt = now.Add(c.negativeTTL)
+		}
+		close(e.done)
+		c.mu.Unlock()
+		return addrs, err
+	}
+}
+
+// sweepLocked は解決済みで期限切れのエントリを削除する。c.mu を保持した状態で呼ぶ。
+func (c *DNSCache) sweepLocked() {
+	now := c.now()
+	for host, e := range c.entries {
+		select {
+		case <-e.done:
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, host)
+			}
+		default:
+		}
+	}
+}
+
+// sortIPv4First は addrs を IPv4 アドレスが先になるよう並べ替える（同じ種類の中の順序は保つ）。
+// フェッチ用クライアントは IPv6 への接続を許可しないため、IPv4 から接続を試みる。
+func sortIPv4First(addrs []string) {
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return isIPv4(addrs[i]) && !isIPv4(addrs[j])
+	})
+}
+
+func isIPv4(addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.To4() != nil
+}
+
+// dialFunc は http.Transport.DialContext の関数型。
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// dialContext は address のホスト名をキャッシュ経由で解決し、解決したアドレスへ順に dial で接続する関数を返す。
+// address が IP アドレスの場合はそのまま dial に渡す。すべてのアドレスへの接続に失敗した場合は最初のエラーを返す。
+// TLS の SNI と証明書の検証はリクエストの URL のホスト名で行うため、IP アドレス宛てに接続しても影響しない。
+func (c *DNSCache) dialContext(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || net.ParseIP(host) != nil {
+			return dial(ctx, network, address)
+		}
+
+		addrs, err := c.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var firstErr error
+		for _, addr := range addrs {
+			conn, err := dial(ctx, network, net.JoinHostPort(addr, port))
+			if err == nil {
+				return conn, nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		return nil, firstErr
+	}
+}
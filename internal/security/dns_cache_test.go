@@ -0,0 +1,214 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestDNSCache は lookup を results で置き換えた DNSCache と、問い合わせ回数を返す関数を生成する。
+func newTestDNSCache(now *time.Time, results map[string][]string) (*DNSCache, func(host string) int) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	c := NewDNSCache(time.Minute, 30*time.Second)
+	c.now = func() time.Time { return *now }
+	c.lookup = func(_ context.Context, host string) ([]string, error) {
+		mu.Lock()
+		calls[host]++
+		mu.Unlock()
+		addrs, ok := results[host]
+		if !ok {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return append([]string(nil), addrs...), nil
+	}
+	return c, func(host string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[host]
+	}
+}
+
+func TestDNSCache_LookupHost(t *testing.T) {
+	t.Run("TTL の間は解決結果を再利用するとき", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		c, calls := newTestDNSCache(&now, map[string][]string{"feeds.example.com": {"2001:db8::1", "203.0.113.10"}})
+
+		// Act
+		first, err := c.LookupHost(context.Background(), "feeds.example.com")
+		if err != nil {
+			t.Fatalf("LookupHost() error = %v", err)
+		}
+		now = now.Add(59 * time.Second)
+		second, _ := c.LookupHost(context.Background(), "feeds.example.com")
+
+		// Assert
+		if calls("feeds.example.com") != 1 {
+			t.Errorf("問い合わせ回数 = %d, want 1", calls("feeds.example.com"))
+		}
+		if len(first) != 2 || first[0] != "203.0.113.10" {
+			t.Errorf("addrs = %v, want IPv4 を先頭にした 2 件", first)
+		}
+		if len(second) != 2 {
+			t.Errorf("キャッシュの addrs = %v", second)
+		}
+	})
+
+	t.Run("TTL を過ぎたとき再び問い合わせる", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		c, calls := newTestDNSCache(&now, map[string][]string{"feeds.example.com": {"203.0.113.10"}})
+		_, _ = c.LookupHost(context.Background(), "feeds.example.com")
+
+		// Act
+		now = now.Add(time.Minute)
+		_, _ = c.LookupHost(context.Background(), "feeds.example.com")
+
+		// Assert
+		if calls("feeds.example.com") != 2 {
+			t.Errorf("問い合わせ回数 = %d, want 2", calls("feeds.example.com"))
+		}
+	})
+
+	t.Run("解決に失敗したホストはネガティブ TTL の間失敗を返すとき", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		c, calls := newTestDNSCache(&now, nil)
+
+		// Act
+		_, err1 := c.LookupHost(context.Background(), "gone.example.com")
+		now = now.Add(29 * time.Second)
+		_, err2 := c.LookupHost(context.Background(), "gone.example.com")
+		now = now.Add(time.Second)
+		_, _ = c.LookupHost(context.Background(), "gone.example.com")
+
+		// Assert
+		var dnsErr *net.DNSError
+		if !errors.As(err1, &dnsErr) || !errors.As(err2, &dnsErr) {
+			t.Errorf("err = %v, %v, want *net.DNSError", err1, err2)
+		}
+		if calls("gone.example.com") != 2 {
+			t.Errorf("問い合わせ回数 = %d, want ネガティブ TTL 経過後の再問い合わせを含む 2", calls("gone.example.com"))
+		}
+	})
+
+	t.Run("呼び出し元が中断した解決はキャッシュしないとき", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		c, _ := newTestDNSCache(&now, nil)
+		c.lookup = func(ctx context.Context, host string) ([]string, error) {
+			return nil, ctx.Err()
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _ = c.LookupHost(ctx, "slow.example.com")
+
+		// Act
+		c.lookup = func(_ context.Context, host string) ([]string, error) {
+			return []string{"203.0.113.20"}, nil
+		}
+		addrs, err := c.LookupHost(context.Background(), "slow.example.com")
+
+		// Assert
+		if err != nil || len(addrs) != 1 {
+			t.Errorf("addrs = %v, err = %v, want 中断後に解決し直した結果", addrs, err)
+		}
+	})
+}
+
+func TestNewSafeClient_DNSCache(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+	port, _ := strconv.Atoi(u.Port())
+	target := "http://feeds.example.test:" + u.Port() + "/"
+
+	// feeds.example.test を httptest サーバー（127.0.0.1）に解決するキャッシュを用いる
+	newGuard := func(opts ...SSRFGuardOption) (*ssrfGuard, func(string) int) {
+		now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		cache, calls := newTestDNSCache(&now, map[string][]string{"feeds.example.test": {"127.0.0.1"}})
+		guard := NewSSRFGuard(append(opts, WithDNSCache(cache))...)
+		guard.ports = []int{80, 443, port}
+		return guard, calls
+	}
+
+	t.Run("キャッシュから解決したプライベート IP への接続を拒否するとき", func(t *testing.T) {
+		// Arrange
+		guard, _ := newGuard()
+		client := guard.NewSafeClient(5*time.Second, 1024)
+
+		// Act
+		resp, err := client.Get(target)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		// Assert
+		if err == nil || !IsSSRFBlockedError(err) {
+			t.Fatalf("err = %v, want SSRF 防止による拒否", err)
+		}
+	})
+
+	t.Run("許可 CIDR に含まれる IP のときキャッシュした解決結果で接続するとき", func(t *testing.T) {
+		// Arrange
+		list, err := ParseSSRFAllowlist([]string{"127.0.0.1/32"})
+		if err != nil {
+			t.Fatalf("ParseSSRFAllowlist() error = %v", err)
+		}
+		guard, calls := newGuard(WithAllowlist(list))
+		client := guard.NewSafeClient(5*time.Second, 1024)
+
+		// Act
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(target)
+			if err != nil {
+				t.Fatalf("expected request to succeed, got %v", err)
+			}
+			_ = resp.Body.Close()
+			client.CloseIdleConnections()
+		}
+
+		// Assert
+		if calls("feeds.example.test") != 1 {
+			t.Errorf("問い合わせ回数 = %d, want 1", calls("feeds.example.test"))
+		}
+	})
+
+	t.Run("許可ホスト名のときもキャッシュした解決結果で接続するとき", func(t *testing.T) {
+		// Arrange
+		list, err := ParseSSRFAllowlist([]string{"feeds.example.test"})
+		if err != nil {
+			t.Fatalf("ParseSSRFAllowlist() error = %v", err)
+		}
+		guard, calls := newGuard(WithAllowlist(list))
+		client := guard.NewSafeClient(5*time.Second, 1024)
+
+		// Act
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(target)
+			if err != nil {
+				t.Fatalf("expected request to succeed, got %v", err)
+			}
+			_ = resp.Body.Close()
+			client.CloseIdleConnections()
+		}
+
+		// Assert
+		if calls("feeds.example.test") != 1 {
+			t.Errorf("問い合わせ回数 = %d, want 1", calls("feeds.example.test"))
+		}
+	})
+}
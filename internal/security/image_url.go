@@ -0,0 +1,75 @@
+package security
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// maxImageURLLength は SafeImageURL が受け付ける URL の最大長（バイト）。
+const maxImageURLLength = 2048
+
+// SafeImageURL は記事のサムネイルなど、クライアントに画像として読み込ませる URL を検証して返す。
+//
+// 相対 URL は baseURL を基準に絶対化する。本文の img と同じく https のみを許可し、
+// ValidateURL と同じ静的チェック（ブロック対象ネットワークの IP リテラル、ループバック別名・
+// メタデータのホスト名の拒否）に加えて、社内ネットワークを指しやすい単一ラベルのホスト名や
+// 数値表記のホスト名（10 進・16 進の IPv4 表記）、既定以外のポート、ユーザー情報付き・過長な URL を拒否する。
+// 安全と判断できない場合は ok=false を返す。
+func SafeImageURL(rawURL, baseURL string) (string, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || len(rawURL) > maxImageURLLength {
+		return "", false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	if !u.IsAbs() {
+		base, err := url.Parse(baseURL)
+		if err != nil || !base.IsAbs() {
+			return "", false
+		}
+		u = base.ResolveReference(u)
+	}
+
+	if !strings.EqualFold(u.Scheme, "https") || u.User != nil {
+		return "", false
+	}
+	if port := u.Port(); port != "" && port != "443" {
+		return "", false
+	}
+	host := u.Hostname()
+	if host == "" {
+		return "", false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if isBlockedIP(ip) {
+			return "", false
+		}
+	} else if isBlockedHostname(host) || !isPublicHostname(host) {
+		return "", false
+	}
+
+	resolved := u.String()
+	if len(resolved) > maxImageURLLength {
+		return "", false
+	}
+	return resolved, true
+}
+
+// isPublicHostname はホスト名がインターネット上の名前として妥当な形かを判定する。
+// 単一ラベル（intranet 等）と、最後のラベルが数字や 0x で始まるもの（2130706433 や 0x7f.1 等、
+// ブラウザが IPv4 として解釈しうる表記）は公開ホストとみなさない。
+func isPublicHostname(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	i := strings.LastIndexByte(host, '.')
+	if i < 0 {
+		return false
+	}
+	tld := host[i+1:]
+	if tld == "" || strings.HasPrefix(tld, "0x") {
+		return false
+	}
+	return tld[0] < '0' || tld[0] > '9'
+}
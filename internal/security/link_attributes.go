@@ -0,0 +1,62 @@
+package security
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// LinkRel は記事本文のリンクに強制付与する rel 属性値。
+// 遷移先からの window.opener 経由の操作とリファラ送信を防ぐ。
+const LinkRel = "noopener noreferrer"
+
+// ApplyLinkAttributes はサニタイズ済み HTML の全 a タグに rel="noopener noreferrer" を強制付与し、
+// openInNewTab に応じて target="_blank" を付与（true）または除去（false）する後処理。
+//
+// 保存済みの本文はユーザー設定に依存させられないため、API 応答時にユーザーの
+// 「リンクを新しいタブで開く」設定を反映する目的で用いる。a タグ以外のトークンは入力のまま出力する。
+// 同一入力に対して常に同一出力を返す（冪等）。
+func ApplyLinkAttributes(sanitizedHTML string, openInNewTab bool) string {
+	if !strings.Contains(sanitizedHTML, "<a") && !strings.Contains(sanitizedHTML, "<A") {
+		return sanitizedHTML
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(sanitizedHTML) + 64)
+
+	z := html.NewTokenizer(strings.NewReader(sanitizedHTML))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// io.EOF を含め、以降は読み取れないため終了する
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			buf.Write(z.Raw())
+			continue
+		}
+
+		tok := z.Token()
+		if tok.DataAtom != atom.A {
+			buf.WriteString(tok.String())
+			continue
+		}
+
+		attrs := make([]html.Attribute, 0, len(tok.Attr)+2)
+		for _, a := range tok.Attr {
+			if a.Key == "rel" || a.Key == "target" {
+				continue
+			}
+			attrs = append(attrs, a)
+		}
+		attrs = append(attrs, html.Attribute{Key: "rel", Val: LinkRel})
+		if openInNewTab {
+			attrs = append(attrs, html.Attribute{Key: "target", Val: "_blank"})
+		}
+		tok.Attr = attrs
+		buf.WriteString(tok.String())
+	}
+	return buf.String()
+}
@@ -0,0 +1,89 @@
+package security
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestApplyLinkAttributes は a タグへの rel / target の強制付与を検証する。
+func TestApplyLinkAttributes(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		openInNewTab bool
+		want         string
+	}{
+		{
+			name:         "新しいタブで開く設定のときrelとtarget=_blankを付与する",
+			input:        `<p>本文<a href="https://example.com/a">リンク</a></p>`,
+			openInNewTab: true,
+			want:         `<p>本文<a href="https://example.com/a" rel="noopener noreferrer" target="_blank">リンク</a></p>`,
+		},
+		{
+			name:         "同じタブで開く設定のときtargetを除去しrelのみ付与する",
+			input:        `<a href="https://example.com/a" rel="noreferrer noopener" target="_blank">リンク</a>`,
+			openInNewTab: false,
+			want:         `<a href="https://example.com/a" rel="noopener noreferrer">リンク</a>`,
+		},
+		{
+			name:         "既存のrelやtargetの値は上書きする",
+			input:        `<a href="https://example.com/a" rel="opener" target="_self">リンク</a>`,
+			openInNewTab: true,
+			want:         `<a href="https://example.com/a" rel="noopener noreferrer" target="_blank">リンク</a>`,
+		},
+		{
+			name:         "aタグがないとき入力をそのまま返す",
+			input:        `<p>a &amp; b</p><img src="https://example.com/x.png" alt="x">`,
+			openInNewTab: true,
+			want:         `<p>a &amp; b</p><img src="https://example.com/x.png" alt="x">`,
+		},
+		{
+			name:         "空文字のとき空文字を返す",
+			input:        "",
+			openInNewTab: true,
+			want:         "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := ApplyLinkAttributes(tt.input, tt.openInNewTab)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("ApplyLinkAttributes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyLinkAttributes_PreservesSurroundingContent は a タグ以外の要素・テキスト・実体参照を保持することを検証する。
+func TestApplyLinkAttributes_PreservesSurroundingContent(t *testing.T) {
+	// Arrange
+	input := `<p>前 &lt;tag&gt; <strong>強調</strong></p><a href="https://example.com/?a=1&amp;b=2">x</a><pre><code>if a &lt; b {}</code></pre>`
+
+	// Act
+	got := ApplyLinkAttributes(input, true)
+
+	// Assert
+	for _, want := range []string{
+		`<p>前 &lt;tag&gt; <strong>強調</strong></p>`,
+		`href="https://example.com/?a=1&amp;b=2"`,
+		`<pre><code>if a &lt; b {}</code></pre>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("出力に %q が含まれていない: %q", want, got)
+		}
+	}
+}
+
+// TestApplyLinkAttributes_Idempotent は同一入力への再適用で結果が変わらないことを検証する。
+func TestApplyLinkAttributes_Idempotent(t *testing.T) {
+	sanitizer := NewContentSanitizer()
+	once := ApplyLinkAttributes(sanitizer.Sanitize(`<a href="https://example.com">x</a>`), false)
+	twice := ApplyLinkAttributes(once, false)
+	if once != twice {
+		t.Errorf("再適用で結果が変わった: %q -> %q", once, twice)
+	}
+}
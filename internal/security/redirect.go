@@ -0,0 +1,75 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MaxRedirects はフィードの取得・検出で追従するリダイレクトの最大ホップ数。
+// 既定の http.Client（10 ホップ）より小さくし、リダイレクトを繰り返すサイトで早めに打ち切る。
+const MaxRedirects = 5
+
+var (
+	// ErrRedirectLoop はリダイレクト先が既に経由した URL に戻った（循環した）ことを表す。
+	ErrRedirectLoop = errors.New("redirect loop detected")
+	// ErrTooManyRedirects はリダイレクトが MaxRedirects ホップを超えたことを表す。
+	ErrTooManyRedirects = errors.New("too many redirects")
+)
+
+// CheckRedirect は http.Client の CheckRedirect として、リダイレクトの循環と上限超過を検出する。
+// 循環は経由済みの URL（スキーム・ホストの大文字小文字、既定ポート、フラグメントの差は無視する）への
+// 再訪で判定し、上限に達する前でも即座に失敗させる。
+// 返すエラーは http.Client により *url.Error で包まれるため、判定には IsRedirectError を使う。
+func CheckRedirect(req *http.Request, via []*http.Request) error {
+	next := redirectKey(req.URL)
+	for _, prev := range via {
+		if redirectKey(prev.URL) == next {
+			return fmt.Errorf("%w: %s (after %d redirects)", ErrRedirectLoop, req.URL.Redacted(), len(via))
+		}
+	}
+	if len(via) > MaxRedirects {
+		return fmt.Errorf("%w: stopped after %d redirects", ErrTooManyRedirects, MaxRedirects)
+	}
+	return nil
+}
+
+// IsRedirectError は err がリダイレクトの循環または上限超過（CheckRedirect が返すエラー）に起因するかを判定する。
+func IsRedirectError(err error) bool {
+	return errors.Is(err, ErrRedirectLoop) || errors.Is(err, ErrTooManyRedirects)
+}
+
+// SameResourceURL は a と b が同じリソースを指す URL かを CheckRedirect と同じ正規化で判定する。
+// HTML ページが自分自身をフィードとして参照している（自己参照）かの判定に用いる。
+func SameResourceURL(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return redirectKey(ua) == redirectKey(ub)
+}
+
+// redirectKey は循環判定に用いる URL の正規形を返す。
+// スキームとホストは小文字化し、スキームの既定ポートとフラグメントは除く。パスとクエリはそのまま比較する。
+func redirectKey(u *url.URL) string {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	key := scheme + "://" + host + path
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
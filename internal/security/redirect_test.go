@@ -0,0 +1,120 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// redirectRequests は rawURLs を順に経由したリダイレクトの via を組み立てる。
+func redirectRequests(t *testing.T, rawURLs ...string) []*http.Request {
+	t.Helper()
+	via := make([]*http.Request, len(rawURLs))
+	for i, raw := range rawURLs {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatalf("url.Parse(%q) error = %v", raw, err)
+		}
+		via[i] = &http.Request{URL: u}
+	}
+	return via
+}
+
+func TestCheckRedirect(t *testing.T) {
+	tests := []struct {
+		name    string
+		next    string
+		via     []string
+		wantErr error
+	}{
+		{name: "初めての宛先のとき許可する", next: "https://example.com/b", via: []string{"https://example.com/a"}},
+		{name: "経由済みのURLに戻るとき循環として拒否する", next: "https://example.com/a", via: []string{"https://example.com/a", "https://example.com/b"}, wantErr: ErrRedirectLoop},
+		{name: "自分自身へのリダイレクトのとき循環として拒否する", next: "https://example.com/feed", via: []string{"https://example.com/feed"}, wantErr: ErrRedirectLoop},
+		{name: "ホストの大文字小文字と既定ポートとフラグメントの差は同じURLとみなす", next: "https://EXAMPLE.com:443/feed#top", via: []string{"https://example.com/feed"}, wantErr: ErrRedirectLoop},
+		{name: "クエリが異なるとき別のURLとみなす", next: "https://example.com/feed?page=2", via: []string{"https://example.com/feed"}},
+		{name: "上限のホップ数までは許可する", next: "https://example.com/5", via: []string{"https://example.com/0", "https://example.com/1", "https://example.com/2", "https://example.com/3", "https://example.com/4"}},
+		{name: "上限のホップ数を超えるとき拒否する", next: "https://example.com/6", via: []string{"https://example.com/0", "https://example.com/1", "https://example.com/2", "https://example.com/3", "https://example.com/4", "https://example.com/5"}, wantErr: ErrTooManyRedirects},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			next := redirectRequests(t, tt.next)[0]
+			via := redirectRequests(t, tt.via...)
+
+			// Act
+			err := CheckRedirect(next, via)
+
+			// Assert
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("CheckRedirect() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckRedirect() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckRedirect_WithHTTPClient(t *testing.T) {
+	t.Run("リダイレクトが循環するとき上限を待たずに失敗する", func(t *testing.T) {
+		// Arrange: /a → /b → /a → ...
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			next := "/b"
+			if r.URL.Path == "/b" {
+				next = "/a"
+			}
+			http.Redirect(w, r, next, http.StatusFound)
+		}))
+		defer server.Close()
+		client := &http.Client{CheckRedirect: CheckRedirect}
+
+		// Act
+		_, err := client.Get(server.URL + "/a")
+
+		// Assert
+		if !IsRedirectError(err) || !errors.Is(err, ErrRedirectLoop) {
+			t.Errorf("err = %v, want ErrRedirectLoop", err)
+		}
+		if got := requests.Load(); got != 2 {
+			t.Errorf("requests = %d, want 2", got)
+		}
+	})
+
+	t.Run("リダイレクトが上限を超えて続くとき失敗する", func(t *testing.T) {
+		// Arrange: /0 → /1 → /2 → ...
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/"))
+			http.Redirect(w, r, fmt.Sprintf("/%d", n+1), http.StatusFound)
+		}))
+		defer server.Close()
+		client := &http.Client{CheckRedirect: CheckRedirect}
+
+		// Act
+		_, err := client.Get(server.URL + "/0")
+
+		// Assert
+		if !errors.Is(err, ErrTooManyRedirects) {
+			t.Errorf("err = %v, want ErrTooManyRedirects", err)
+		}
+	})
+}
+
+func TestSameResourceURL(t *testing.T) {
+	if !SameResourceURL("https://Example.com/blog/", "https://example.com:443/blog/#feed") {
+		t.Error("SameResourceURL() = false, want true for the same page")
+	}
+	if SameResourceURL("https://example.com/blog/", "https://example.com/blog/feed") {
+		t.Error("SameResourceURL() = true, want false for different paths")
+	}
+}
@@ -0,0 +1,85 @@
+package security
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ResolveRelativeURLs は HTML 中の a タグの href と img タグの src に含まれる相対 URL を
+// baseURL を基準に絶対 URL へ書き換える。
+//
+// サニタイザは相対 URL を除去するため、フィード本文の画像やリンクを残すにはサニタイズ前に
+// 適用する。baseURL が http / https の絶対 URL でない場合と、書き換え対象のタグがない場合は
+// 入力をそのまま返す。解釈できない URL や既に絶対 URL の値は変更しない（可否はサニタイザに任せる）。
+func ResolveRelativeURLs(rawHTML, baseURL string) string {
+	base, err := url.Parse(baseURL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return rawHTML
+	}
+	lower := strings.ToLower(rawHTML)
+	if !strings.Contains(lower, "<a") && !strings.Contains(lower, "<img") {
+		return rawHTML
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(rawHTML) + 64)
+
+	z := html.NewTokenizer(strings.NewReader(rawHTML))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// io.EOF を含め、以降は読み取れないため終了する
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			buf.Write(z.Raw())
+			continue
+		}
+
+		tok := z.Token()
+		var key string
+		switch tok.DataAtom {
+		case atom.A:
+			key = "href"
+		case atom.Img:
+			key = "src"
+		default:
+			buf.Write(z.Raw())
+			continue
+		}
+
+		changed := false
+		for i, a := range tok.Attr {
+			if a.Namespace != "" || a.Key != key {
+				continue
+			}
+			if resolved, ok := resolveURL(base, a.Val); ok {
+				tok.Attr[i].Val = resolved
+				changed = true
+			}
+		}
+		if changed {
+			buf.WriteString(tok.String())
+		} else {
+			buf.Write(z.Raw())
+		}
+	}
+	return buf.String()
+}
+
+// resolveURL は相対 URL の raw を base で解決する。絶対 URL・空・解釈できない値の場合は ok=false を返す。
+func resolveURL(base *url.URL, raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false
+	}
+	ref, err := url.Parse(raw)
+	if err != nil || ref.IsAbs() {
+		return "", false
+	}
+	return base.ResolveReference(ref).String(), true
+}
@@ -0,0 +1,58 @@
+package security
+
+import "testing"
+
+// TestResolveRelativeURLs は a の href と img の src の相対 URL の絶対化を検証する。
+func TestResolveRelativeURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		baseURL string
+		want    string
+	}{
+		{
+			name:    "ルート相対のimgとaを基準URLのホストで絶対化する",
+			input:   `<p><img src="/img/a.png" alt="a"><a href="/posts/2">次</a></p>`,
+			baseURL: "https://example.com/blog/posts/1",
+			want:    `<p><img src="https://example.com/img/a.png" alt="a"><a href="https://example.com/posts/2">次</a></p>`,
+		},
+		{
+			name:    "パス相対のURLは基準URLのディレクトリから解決する",
+			input:   `<img src="images/b.png">`,
+			baseURL: "https://example.com/blog/posts/1",
+			want:    `<img src="https://example.com/blog/posts/images/b.png">`,
+		},
+		{
+			name:    "スキーム相対のURLは基準URLのスキームを補う",
+			input:   `<img src="//cdn.example.net/c.png">`,
+			baseURL: "https://example.com/",
+			want:    `<img src="https://cdn.example.net/c.png">`,
+		},
+		{
+			name:    "絶対URLとその他のタグは入力のまま出力する",
+			input:   `<p class="x">a &amp; b <a href="https://other.example/a">外部</a></p>`,
+			baseURL: "https://example.com/",
+			want:    `<p class="x">a &amp; b <a href="https://other.example/a">外部</a></p>`,
+		},
+		{
+			name:    "基準URLが絶対URLでないとき入力をそのまま返す",
+			input:   `<img src="/img/a.png">`,
+			baseURL: "/relative/base",
+			want:    `<img src="/img/a.png">`,
+		},
+		{
+			name:    "基準URLがhttp以外のスキームのとき入力をそのまま返す",
+			input:   `<a href="/a">a</a>`,
+			baseURL: "ftp://example.com/",
+			want:    `<a href="/a">a</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveRelativeURLs(tt.input, tt.baseURL); got != tt.want {
+				t.Errorf("ResolveRelativeURLs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// SecretKeySize は SecretBox の鍵長（AES-256）。
+const SecretKeySize = 32
+
+// secretBoxVersion は暗号文の先頭に付ける形式バージョン。鍵や方式を変えたときに旧形式を判別するために使う。
+const secretBoxVersion byte = 1
+
+// ErrSecretBoxOpen は暗号文を復号できない（改ざん・鍵の不一致・形式不正）ことを表す。
+var ErrSecretBoxOpen = errors.New("secret box: cannot open ciphertext")
+
+// SecretBox は外部サービスのアクセストークンなど、DB に保存する秘密情報を AES-256-GCM で暗号化する。
+// 暗号文は「バージョン 1 バイト + nonce + 暗号文（認証タグ付き）」の形式で、改ざんは復号時に検出する。
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox は SecretKeySize バイトの鍵から SecretBox を生成する。
+func NewSecretBox(key []byte) (*SecretBox, error) {
+	if len(key) != SecretKeySize {
+		return nil, fmt.Errorf("secret box: key must be %d bytes (got %d)", SecretKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secret box: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("secret box: %w", err)
+	}
+	return &SecretBox{aead: aead}, nil
+}
+
+// ParseSecretKey は base64（標準・URL セーフのいずれも可）でエンコードされた鍵を復号し、鍵長を検証する。
+// 鍵は `openssl rand -base64 32` などで生成する。
+func ParseSecretKey(encoded string) ([]byte, error) {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		key, err := enc.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		if len(key) != SecretKeySize {
+			return nil, fmt.Errorf("key must be %d bytes (got %d)", SecretKeySize, len(key))
+		}
+		return key, nil
+	}
+	return nil, errors.New("key must be base64 encoded")
+}
+
+// Seal は plaintext を暗号化する。同じ平文でも nonce が毎回異なるため暗号文は一致しない。
+func (b *SecretBox) Seal(plaintext []byte) ([]byte, error) {
+	nonceSize := b.aead.NonceSize()
+	out := make([]byte, 1+nonceSize, 1+nonceSize+len(plaintext)+b.aead.Overhead())
+	out[0] = secretBoxVersion
+	nonce := out[1:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("secret box: %w", err)
+	}
+	return b.aead.Seal(out, nonce, plaintext, nil), nil
+}
+
+// Open は Seal で暗号化した ciphertext を復号する。復号できない場合は ErrSecretBoxOpen を返す。
+func (b *SecretBox) Open(ciphertext []byte) ([]byte, error) {
+	nonceSize := b.aead.NonceSize()
+	if len(ciphertext) < 1+nonceSize || ciphertext[0] != secretBoxVersion {
+		return nil, ErrSecretBoxOpen
+	}
+	nonce := ciphertext[1 : 1+nonceSize]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext[1+nonceSize:], nil)
+	if err != nil {
+		return nil, ErrSecretBoxOpen
+	}
+	return plaintext, nil
+}
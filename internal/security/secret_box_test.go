@@ -0,0 +1,94 @@
+package security
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestSecretBox(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, SecretKeySize)
+	box, err := NewSecretBox(key)
+	if err != nil {
+		t.Fatalf("NewSecretBox() error = %v", err)
+	}
+
+	t.Run("暗号化した値を復号すると元の値に戻る", func(t *testing.T) {
+		plaintext := []byte(`{"access_token":"secret"}`)
+
+		sealed, err := box.Seal(plaintext)
+		if err != nil {
+			t.Fatalf("Seal() error = %v", err)
+		}
+		opened, err := box.Open(sealed)
+
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		if !bytes.Equal(opened, plaintext) {
+			t.Errorf("Open() = %q, want %q", opened, plaintext)
+		}
+		if bytes.Contains(sealed, []byte("secret")) {
+			t.Error("sealed value contains plaintext")
+		}
+	})
+
+	t.Run("同じ平文でも暗号文は毎回異なる", func(t *testing.T) {
+		a, _ := box.Seal([]byte("token"))
+		b, _ := box.Seal([]byte("token"))
+		if bytes.Equal(a, b) {
+			t.Error("Seal() returned identical ciphertexts")
+		}
+	})
+
+	sealed, _ := box.Seal([]byte("token"))
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 0xff
+	otherBox, _ := NewSecretBox(bytes.Repeat([]byte{0x24}, SecretKeySize))
+	tests := []struct {
+		name       string
+		box        *SecretBox
+		ciphertext []byte
+	}{
+		{name: "改ざんされたときErrSecretBoxOpenを返す", box: box, ciphertext: tampered},
+		{name: "鍵が異なるときErrSecretBoxOpenを返す", box: otherBox, ciphertext: sealed},
+		{name: "短すぎるときErrSecretBoxOpenを返す", box: box, ciphertext: sealed[:5]},
+		{name: "バージョンが異なるときErrSecretBoxOpenを返す", box: box, ciphertext: append([]byte{0}, sealed[1:]...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.box.Open(tt.ciphertext); !errors.Is(err, ErrSecretBoxOpen) {
+				t.Errorf("Open() error = %v, want ErrSecretBoxOpen", err)
+			}
+		})
+	}
+}
+
+func TestParseSecretKey(t *testing.T) {
+	key := bytes.Repeat([]byte{0xfb}, SecretKeySize)
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{name: "標準のbase64のとき鍵を返す", encoded: base64.StdEncoding.EncodeToString(key)},
+		{name: "URLセーフのbase64のとき鍵を返す", encoded: base64.RawURLEncoding.EncodeToString(key)},
+		{name: "鍵長が足りないときエラーを返す", encoded: base64.StdEncoding.EncodeToString(key[:16]), wantErr: true},
+		{name: "base64でないときエラーを返す", encoded: "not base64!", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSecretKey(tt.encoded)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ParseSecretKey() error = nil, want error")
+				}
+				return
+			}
+			if err != nil || !bytes.Equal(got, key) {
+				t.Errorf("ParseSecretKey() = %x, %v, want %x", got, err, key)
+			}
+		})
+	}
+}
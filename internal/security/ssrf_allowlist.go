@@ -0,0 +1,248 @@
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/doyensec/safeurl"
+)
+
+// neverAllowedNetworks は許可リストに含めても接続を許可しないネットワーク範囲。
+// クラウドメタデータ（169.254.169.254）を含むリンクローカル等は、社内フィードの宛先になり得ないため例外の対象外とする。
+var neverAllowedNetworks []net.IPNet
+
+func init() {
+	for _, cidr := range []string{
+		// リンクローカル (RFC 3927) - クラウドメタデータIPを含む
+		"169.254.0.0/16",
+		// カレントネットワーク
+		"0.0.0.0/8",
+		// IPv6リンクローカル
+		"fe80::/10",
+		// マルチキャスト・ブロードキャスト
+		"224.0.0.0/4",
+		"255.255.255.255/32",
+	} {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("invalid CIDR in neverAllowedNetworks: %s: %v", cidr, err))
+		}
+		neverAllowedNetworks = append(neverAllowedNetworks, *network)
+	}
+}
+
+// SSRFAllowlist は SSRF 対策の例外として、プライベート IP への接続を明示的に許可する宛先の一覧。
+// 社内フィード（イントラネットの RSS）など、管理者が設定した宛先に限ってフェッチを許容する。
+type SSRFAllowlist struct {
+	// hosts は小文字化したホスト名。先頭が "*." のものはそのサブドメインに一致する。
+	hosts []string
+	// networks は接続を許可する IP アドレスの範囲。
+	networks []net.IPNet
+}
+
+// ParseSSRFAllowlist は許可リストのエントリを解釈する。
+// エントリはホスト名（"rss.intranet.example.com"）、サブドメインのワイルドカード（"*.corp.example.com"）、
+// CIDR（"10.1.2.0/24"）、IP アドレス（"10.1.2.3"）のいずれか。空のエントリは無視する。
+// リンクローカル等の許可できない範囲と重なる CIDR・IP はエラーとする。
+func ParseSSRFAllowlist(entries []string) (*SSRFAllowlist, error) {
+	list := &SSRFAllowlist{}
+	for _, raw := range entries {
+		entry := strings.ToLower(strings.TrimSpace(raw))
+		if entry == "" {
+			continue
+		}
+
+		var network *net.IPNet
+		if strings.Contains(entry, "/") {
+			_, n, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SSRF allowlist entry %q: %w", raw, err)
+			}
+			network = n
+		} else if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			network = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		}
+		if network != nil {
+			if overlapsNeverAllowed(*network) {
+				return nil, fmt.Errorf("SSRF allowlist entry %q overlaps a network that cannot be allowed", raw)
+			}
+			list.networks = append(list.networks, *network)
+			continue
+		}
+
+		host := strings.TrimPrefix(entry, "*.")
+		if host == "" || strings.ContainsAny(host, "*:/ ") {
+			return nil, fmt.Errorf("invalid SSRF allowlist entry %q", raw)
+		}
+		list.hosts = append(list.hosts, entry)
+	}
+	return list, nil
+}
+
+// overlapsNeverAllowed は network が許可できない範囲と重なるかを判定する。
+func overlapsNeverAllowed(network net.IPNet) bool {
+	for _, never := range neverAllowedNetworks {
+		if network.Contains(never.IP) || never.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNeverAllowedIP は ip が許可リストに関わらず接続を許可しない範囲に含まれるかを判定する。
+func isNeverAllowedIP(ip net.IP) bool {
+	for _, never := range neverAllowedNetworks {
+		if never.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty は許可リストにエントリが無いかを返す。nil の場合も true を返す。
+func (l *SSRFAllowlist) Empty() bool {
+	return l == nil || (len(l.hosts) == 0 && len(l.networks) == 0)
+}
+
+// allowsHost は host（ホスト名）が許可リストのホスト名に一致するかを判定する。大文字小文字は区別しない。
+func (l *SSRFAllowlist) allowsHost(host string) bool {
+	if l == nil {
+		return false
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range l.hosts {
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsIP は ip が許可リストの IP アドレス範囲に含まれるかを判定する。
+func (l *SSRFAllowlist) allowsIP(ip net.IP) bool {
+	if l == nil || isNeverAllowedIP(ip) {
+		return false
+	}
+	for _, network := range l.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowlistDialError は許可リスト経由の接続で、宛先が許可されなかったことを表す。
+type AllowlistDialError struct {
+	Address string
+	Reason  string
+}
+
+// Error は error インターフェースを実装する。
+func (e *AllowlistDialError) Error() string {
+	return fmt.Sprintf("connection to %s blocked: %s", e.Address, e.Reason)
+}
+
+// allowlistTransport は許可リストのホスト名宛てのリクエストを、プライベート IP への接続を許す
+// Transport に振り分ける http.RoundTripper。それ以外は SSRF 対策付きの既定の Transport で送る。
+type allowlistTransport struct {
+	allowlist *SSRFAllowlist
+	allowed   http.RoundTripper
+	fallback  http.RoundTripper
+}
+
+// RoundTrip は http.RoundTripper を実装する。
+func (t *allowlistTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.allowlist.allowsHost(req.URL.Hostname()) {
+		return t.allowed.RoundTrip(req)
+	}
+	return t.fallback.RoundTrip(req)
+}
+
+// withAllowlist は safeurl が生成した client に許可リストの例外を組み込む。
+// 許可ホスト名宛ては allowedHostTransport で、それ以外は safeurl の接続検証で拒否された場合に限り、
+// 許可 CIDR に含まれる宛先として接続し直す。
+func (g *ssrfGuard) withAllowlist(client *http.Client) *http.Client {
+	base, ok := client.Transport.(*http.Transport)
+	if !ok {
+		return client
+	}
+
+	if len(g.allowlist.networks) > 0 {
+		safeDial := base.DialContext
+		networkDialer := &net.Dialer{Control: g.allowlistControl(func(ip net.IP) bool {
+			return g.allowlist.allowsIP(ip)
+		})}
+		base.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			conn, err := safeDial(ctx, network, address)
+			var ipErr *safeurl.AllowedIPError
+			if err == nil || !errors.As(err, &ipErr) {
+				return conn, err
+			}
+			return networkDialer.DialContext(ctx, network, address)
+		}
+	}
+
+	if len(g.allowlist.hosts) > 0 {
+		hostDialer := &net.Dialer{Control: g.allowlistControl(func(ip net.IP) bool {
+			return !isNeverAllowedIP(ip)
+		})}
+		allowed := &http.Transport{
+			DialContext:     hostDialer.DialContext,
+			TLSClientConfig: g.allowlistTLSConfig(),
+		}
+		client.Transport = &allowlistTransport{allowlist: g.allowlist, allowed: allowed, fallback: base}
+	}
+	return client
+}
+
+// allowlistControl は net.Dialer の Control フックとして、ポートと IPv6 の制限を既定のクライアントと揃えた上で、
+// 解決済みの宛先 IP を allow で検証する関数を返す。DNS 再バインディングにも接続単位で対応する。
+func (g *ssrfGuard) allowlistControl(allow func(net.IP) bool) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, _ syscall.RawConn) error {
+		if network == "tcp6" {
+			return &AllowlistDialError{Address: address, Reason: "ipv6 is disabled"}
+		}
+		host, portStr, err := net.SplitHostPort(address)
+		if err != nil {
+			return &AllowlistDialError{Address: address, Reason: "invalid address"}
+		}
+		port, _ := strconv.Atoi(portStr)
+		if !g.isAllowedPort(port) {
+			return &AllowlistDialError{Address: address, Reason: "port not allowed"}
+		}
+		ip := net.ParseIP(host)
+		if ip == nil || !allow(ip) {
+			return &AllowlistDialError{Address: address, Reason: "ip not in allowlist"}
+		}
+		return nil
+	}
+}
+
+// allowlistTLSConfig は許可ホスト名への接続に使う TLS 設定を返す。
+// クライアント証明書が設定されている場合は、許可ホストへの接続に限って提示する。
+func (g *ssrfGuard) allowlistTLSConfig() *tls.Config {
+	if g.clientCert == nil {
+		return nil
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*g.clientCert},
+	}
+}
@@ -0,0 +1,153 @@
+package security
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseSSRFAllowlist(t *testing.T) {
+	t.Run("ホスト名・ワイルドカード・CIDR・IPアドレスを解釈する", func(t *testing.T) {
+		// Act
+		list, err := ParseSSRFAllowlist([]string{" RSS.Intranet.example.com ", "*.corp.example.com", "10.1.2.0/24", "192.168.0.10", ""})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ParseSSRFAllowlist() error = %v", err)
+		}
+		for _, host := range []string{"rss.intranet.example.com", "news.corp.example.com"} {
+			if !list.allowsHost(host) {
+				t.Errorf("allowsHost(%q) = false, want true", host)
+			}
+		}
+		for _, host := range []string{"intranet.example.com", "corp.example.com", "evil-corp.example.com.attacker.test"} {
+			if list.allowsHost(host) {
+				t.Errorf("allowsHost(%q) = true, want false", host)
+			}
+		}
+		for _, ip := range []string{"10.1.2.3", "192.168.0.10"} {
+			if !list.allowsIP(net.ParseIP(ip)) {
+				t.Errorf("allowsIP(%s) = false, want true", ip)
+			}
+		}
+		if list.allowsIP(net.ParseIP("10.1.3.1")) {
+			t.Error("allowsIP(10.1.3.1) = true, want false")
+		}
+	})
+
+	tests := []struct {
+		name  string
+		entry string
+	}{
+		{name: "CIDRの形式が不正なときエラーを返す", entry: "10.0.0.0/33"},
+		{name: "メタデータIPを含むときエラーを返す", entry: "169.254.169.254"},
+		{name: "全アドレスを許可しようとしたときエラーを返す", entry: "0.0.0.0/0"},
+		{name: "ホスト名にスキームを含むときエラーを返す", entry: "http://intranet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSSRFAllowlist([]string{tt.entry}); err == nil {
+				t.Errorf("ParseSSRFAllowlist(%q) error = nil, want error", tt.entry)
+			}
+		})
+	}
+
+	t.Run("エントリが無いとき空の許可リストを返す", func(t *testing.T) {
+		list, err := ParseSSRFAllowlist(nil)
+		if err != nil || !list.Empty() {
+			t.Errorf("ParseSSRFAllowlist(nil) = (%v, %v), want empty", list, err)
+		}
+	})
+}
+
+func TestValidateURL_Allowlist(t *testing.T) {
+	list, err := ParseSSRFAllowlist([]string{"10.1.0.0/16", "localhost"})
+	if err != nil {
+		t.Fatalf("ParseSSRFAllowlist() error = %v", err)
+	}
+	guard := NewSSRFGuard(WithAllowlist(list))
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "許可CIDRのIPアドレスのとき許可する", url: "http://10.1.2.3/feed.xml"},
+		{name: "許可ホスト名のとき許可する", url: "http://localhost/feed.xml"},
+		{name: "許可CIDR外のプライベートIPのとき拒否する", url: "http://10.2.0.1/feed.xml", wantErr: true},
+		{name: "メタデータIPのとき拒否する", url: "http://169.254.169.254/latest/meta-data/", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.ValidateURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSafeClient_Allowlist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+	port, _ := strconv.Atoi(u.Port())
+
+	// httptest サーバーは 127.0.0.1 の任意ポートで起動するため、テストでは接続先ポートの制限を広げる
+	newGuard := func(entries ...string) *ssrfGuard {
+		list, err := ParseSSRFAllowlist(entries)
+		if err != nil {
+			t.Fatalf("ParseSSRFAllowlist() error = %v", err)
+		}
+		guard := NewSSRFGuard(WithAllowlist(list))
+		guard.ports = []int{80, 443, port}
+		return guard
+	}
+
+	tests := []struct {
+		name    string
+		guard   *ssrfGuard
+		url     string
+		wantErr bool
+	}{
+		{name: "許可CIDRに含まれるIPアドレスのとき接続できる", guard: newGuard("127.0.0.1/32"), url: ts.URL},
+		{name: "許可ホスト名のとき接続できる", guard: newGuard("localhost"), url: "http://localhost:" + u.Port()},
+		{name: "許可リストに無いとき既定どおり拒否する", guard: newGuard("10.0.0.0/8"), url: ts.URL, wantErr: true},
+		{name: "許可ホスト名でない別名のとき拒否する", guard: newGuard("intranet.example.com"), url: "http://localhost:" + u.Port(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			client := tt.guard.NewSafeClient(5*time.Second, 1024)
+
+			// Act
+			resp, err := client.Get(tt.url)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			// Assert
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected request to be blocked, got nil error")
+				}
+				if !IsSSRFBlockedError(err) {
+					t.Errorf("IsSSRFBlockedError(%v) = false, want true", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected request to succeed, got %v", err)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@
 package security
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -64,12 +66,48 @@ func init() {
 	}
 }
 
+// allowedPorts はSSRF防止で許可される接続先ポート。
+var allowedPorts = []int{80, 443}
+
 // ssrfGuard はSSRFGuardServiceの実装。
-type ssrfGuard struct{}
+type ssrfGuard struct {
+	// allowlist はプライベートIPへの接続を例外的に許可する宛先。nil の場合は例外を設けない。
+	allowlist *SSRFAllowlist
+	// clientCert は許可ホストへの接続時に提示するTLSクライアント証明書。nil の場合は提示しない。
+	clientCert *tls.Certificate
+	// ports は許可する接続先ポート。
+	ports []int
+	// dnsCache はホスト名解決のキャッシュ。nil の場合は接続ごとに解決する。
+	dnsCache *DNSCache
+}
+
+// SSRFGuardOption は NewSSRFGuard のオプション。
+type SSRFGuardOption func(*ssrfGuard)
+
+// WithAllowlist はプライベートIPへの接続を例外的に許可する宛先を設定する。
+// 許可ホスト名宛て、または許可CIDRに含まれるIPアドレス宛ての接続のみを許容し、
+// それ以外の宛先には既定のSSRF防止をそのまま適用する。
+func WithAllowlist(allowlist *SSRFAllowlist) SSRFGuardOption {
+	return func(g *ssrfGuard) {
+		g.allowlist = allowlist
+	}
+}
+
+// WithAllowlistClientCertificate は許可ホスト名への接続時に提示するTLSクライアント証明書を設定する。
+// 許可リスト外の宛先には提示しない。
+func WithAllowlistClientCertificate(cert tls.Certificate) SSRFGuardOption {
+	return func(g *ssrfGuard) {
+		g.clientCert = &cert
+	}
+}
 
 // NewSSRFGuard はSSRFGuardServiceの新しいインスタンスを生成する。
-func NewSSRFGuard() *ssrfGuard {
-	return &ssrfGuard{}
+func NewSSRFGuard(opts ...SSRFGuardOption) *ssrfGuard {
+	g := &ssrfGuard{ports: allowedPorts}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
 }
 
 // NewSafeClient はSSRF防止機能付きのHTTPクライアントを生成する。
@@ -81,15 +119,34 @@ func NewSSRFGuard() *ssrfGuard {
 //
 // safeurlはnet.DialerのControlフックでDNS解決後のIPアドレスを検証するため、
 // DNS再バインディング攻撃にも対応している。
+// 許可リストが設定されている場合は、許可された宛先に限ってプライベートIPへの接続を許容する。
+// リダイレクトは CheckRedirect により MaxRedirects ホップまでとし、循環を検出した時点で失敗させる。
+// DNS キャッシュが設定されている場合は、許可ホスト名宛てを含め、ホスト名をキャッシュ経由で解決した
+// IP アドレス宛てに接続する（宛先 IP の検証は上記の Dialer フックで接続ごとに行う）。
 func (g *ssrfGuard) NewSafeClient(timeout time.Duration, maxResponseSize int64) *http.Client {
 	config := safeurl.GetConfigBuilder().
 		SetTimeout(timeout).
 		SetAllowedSchemes(allowedSchemes...).
-		SetAllowedPorts(80, 443).
+		SetAllowedPorts(g.ports...).
+		SetCheckRedirect(CheckRedirect).
 		Build()
 
-	wrappedClient := safeurl.Client(config)
-	return wrappedClient.Client
+	client := safeurl.Client(config).Client
+	base, _ := client.Transport.(*http.Transport)
+	if !g.allowlist.Empty() {
+		client = g.withAllowlist(client)
+	}
+	if g.dnsCache != nil && base != nil {
+		// 許可リストの例外（許可 CIDR への接続し直し）も含めて、解決済みの IP アドレス宛てに接続させる
+		base.DialContext = g.dnsCache.dialContext(base.DialContext)
+		// 許可ホスト名宛ては別の Transport で接続するため、そちらもキャッシュ経由で解決させる
+		if at, ok := client.Transport.(*allowlistTransport); ok {
+			if allowed, ok := at.allowed.(*http.Transport); ok {
+				allowed.DialContext = g.dnsCache.dialContext(allowed.DialContext)
+			}
+		}
+	}
+	return client
 }
 
 // ValidateURL はURLの安全性を事前に検証する。
@@ -119,17 +176,17 @@ func (g *ssrfGuard) ValidateURL(rawURL string) error {
 		return fmt.Errorf("empty host in URL: %s", rawURL)
 	}
 
-	// IPアドレスの場合: ブロック対象CIDRとの照合
+	// IPアドレスの場合: ブロック対象CIDRとの照合（許可リストのCIDRに含まれるものは除く）
 	ip := net.ParseIP(host)
 	if ip != nil {
-		if isBlockedIP(ip) {
+		if isBlockedIP(ip) && !g.allowlist.allowsIP(ip) {
 			return fmt.Errorf("blocked IP address: %s", ip.String())
 		}
 		return nil
 	}
 
-	// ホスト名の場合: localhost等の危険なホスト名を拒否
-	if isBlockedHostname(host) {
+	// ホスト名の場合: localhost等の危険なホスト名を拒否（許可リストのホスト名は除く）
+	if isBlockedHostname(host) && !g.allowlist.allowsHost(host) {
 		return fmt.Errorf("blocked host: %s", host)
 	}
 
@@ -146,6 +203,16 @@ func isAllowedScheme(scheme string) bool {
 	return false
 }
 
+// isAllowedPort は接続先ポートが許可されているかを検証する。
+func (g *ssrfGuard) isAllowedPort(port int) bool {
+	for _, allowed := range g.ports {
+		if port == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 // isBlockedIP はIPアドレスがブロック対象のネットワーク範囲に含まれるかを検証する。
 func isBlockedIP(ip net.IP) bool {
 	for _, network := range blockedNetworks {
@@ -186,3 +253,30 @@ func isBlockedHostname(host string) bool {
 	}
 	return false
 }
+
+// IsSSRFBlockedError は err が NewSafeClient の Dialer / リクエスト検証による
+// 接続拒否（SSRF 対策）に起因するかを判定する。
+// フェッチ失敗の原因分類（error_kind = ssrf）に用いる。
+func IsSSRFBlockedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var (
+		ipErr     *safeurl.AllowedIPError
+		portErr   *safeurl.AllowedPortError
+		schemeErr *safeurl.AllowedSchemeError
+		hostErr   *safeurl.AllowedHostError
+		invalid   *safeurl.InvalidHostError
+		ipv6Err   *safeurl.IPv6BlockedError
+		credErr   *safeurl.SendingCredentialsBlockedError
+		allowErr  *AllowlistDialError
+	)
+	return errors.As(err, &ipErr) ||
+		errors.As(err, &portErr) ||
+		errors.As(err, &schemeErr) ||
+		errors.As(err, &hostErr) ||
+		errors.As(err, &invalid) ||
+		errors.As(err, &ipv6Err) ||
+		errors.As(err, &credErr) ||
+		errors.As(err, &allowErr)
+}
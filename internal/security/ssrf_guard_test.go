@@ -1,6 +1,7 @@
 package security
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -66,6 +67,37 @@ func TestNewSafeClientBlocksLoopback(t *testing.T) {
 	}
 }
 
+// TestIsSSRFBlockedError はSSRF対策による接続拒否エラーを判定できることをテストする。
+func TestIsSSRFBlockedError(t *testing.T) {
+	t.Run("ループバックへの接続拒否のときtrueを返す", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		client := NewSSRFGuard().NewSafeClient(5*time.Second, 5*1024*1024)
+		_, err := client.Get(ts.URL)
+		if err == nil {
+			t.Fatal("expected error for loopback address request, got nil")
+		}
+		if !IsSSRFBlockedError(err) {
+			t.Errorf("IsSSRFBlockedError(%v) = false, want true", err)
+		}
+	})
+
+	t.Run("nilのときfalseを返す", func(t *testing.T) {
+		if IsSSRFBlockedError(nil) {
+			t.Error("IsSSRFBlockedError(nil) = true, want false")
+		}
+	})
+
+	t.Run("SSRF以外のエラーのときfalseを返す", func(t *testing.T) {
+		if IsSSRFBlockedError(errors.New("connection refused")) {
+			t.Error("IsSSRFBlockedError = true, want false")
+		}
+	})
+}
+
 // TestValidateURL_PublicURL は公開URLの検証が成功することをテストする。
 func TestValidateURL_PublicURL(t *testing.T) {
 	guard := NewSSRFGuard()
@@ -0,0 +1,147 @@
+// Package stats は記事の閲覧イベントの記録と、それに基づく閲覧統計を提供する。
+//
+// 閲覧イベントは ViewRecorder がチャネル経由で非同期に受け取り、バッチ INSERT で保存する。
+// 記事詳細 API のリクエストレイテンシに DB 書き込みを載せないための構成で、
+// バッファが溢れた場合はイベントを破棄する（統計用途のため欠損を許容する）。
+package stats
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// flushTimeout はシャットダウン時の最終フラッシュに許容する時間。
+const flushTimeout = 5 * time.Second
+
+// ViewRecorderConfig は ViewRecorder のバッファとバッチの設定。
+type ViewRecorderConfig struct {
+	// BufferSize はチャネルに保持できる未保存イベント数。超過分は破棄する。
+	BufferSize int
+	// BatchSize は 1 回の INSERT でまとめて保存するイベント数。
+	BatchSize int
+	// FlushInterval は BatchSize に満たないイベントを保存する間隔。
+	FlushInterval time.Duration
+}
+
+// DefaultViewRecorderConfig はデフォルトの ViewRecorder 設定を返す。
+func DefaultViewRecorderConfig() ViewRecorderConfig {
+	return ViewRecorderConfig{
+		BufferSize:    1024,
+		BatchSize:     100,
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+// ViewRecorder は記事の閲覧イベントを非同期にバッチ保存する。
+// Record はノンブロッキングで、保存は Run を実行する goroutine が行う。
+type ViewRecorder struct {
+	repo    repository.ItemViewRepository
+	cfg     ViewRecorderConfig
+	events  chan model.ItemView
+	dropped chan struct{}
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// NewViewRecorder は ViewRecorder を生成する。0 以下の設定値はデフォルト値で補う。
+func NewViewRecorder(repo repository.ItemViewRepository, cfg ViewRecorderConfig, logger *slog.Logger) *ViewRecorder {
+	def := DefaultViewRecorderConfig()
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = def.BufferSize
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = def.BatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = def.FlushInterval
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ViewRecorder{
+		repo:    repo,
+		cfg:     cfg,
+		events:  make(chan model.ItemView, cfg.BufferSize),
+		dropped: make(chan struct{}, 1),
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// RecordView は閲覧イベントをキューに積む。バッファが満杯の場合は破棄して即座に戻る。
+func (r *ViewRecorder) RecordView(userID, itemID string) {
+	select {
+	case r.events <- model.ItemView{UserID: userID, ItemID: itemID, ViewedAt: r.now()}:
+	default:
+		// 破棄の通知は次回フラッシュ時にまとめてログ出力する
+		select {
+		case r.dropped <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run はキューのイベントを BatchSize 件ごと、または FlushInterval ごとに保存する。
+// ctx がキャンセルされるとキューに残ったイベントを保存してから戻る。
+func (r *ViewRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]model.ItemView, 0, r.cfg.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			batch = r.drain(batch)
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			r.flush(flushCtx, batch)
+			cancel()
+			return
+		case v := <-r.events:
+			batch = append(batch, v)
+			if len(batch) >= r.cfg.BatchSize {
+				r.flush(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			r.flush(ctx, batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// drain はキューに残っているイベントを待たずに batch へ取り出す。
+func (r *ViewRecorder) drain(batch []model.ItemView) []model.ItemView {
+	for {
+		select {
+		case v := <-r.events:
+			batch = append(batch, v)
+		default:
+			return batch
+		}
+	}
+}
+
+// flush は batch を保存する。保存に失敗したイベントは再試行せず破棄する。
+func (r *ViewRecorder) flush(ctx context.Context, batch []model.ItemView) {
+	select {
+	case <-r.dropped:
+		r.logger.Warn("閲覧イベントのバッファが満杯のため一部のイベントを破棄しました",
+			slog.Int("buffer_size", r.cfg.BufferSize),
+		)
+	default:
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+	if err := r.repo.InsertBatch(ctx, batch); err != nil {
+		r.logger.Error("閲覧イベントの保存に失敗しました",
+			slog.Int("count", len(batch)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,175 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockItemViewRepo は repository.ItemViewRepository のテスト用モック。
+type mockItemViewRepo struct {
+	mu        sync.Mutex
+	batches   [][]model.ItemView
+	insertErr error
+
+	topFeedsFn func(ctx context.Context, userID string, since time.Time, limit int) ([]model.FeedViewStat, error)
+}
+
+func (m *mockItemViewRepo) InsertBatch(_ context.Context, views []model.ItemView) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, append([]model.ItemView(nil), views...))
+	return m.insertErr
+}
+
+func (m *mockItemViewRepo) TopFeedsByUser(ctx context.Context, userID string, since time.Time, limit int) ([]model.FeedViewStat, error) {
+	if m.topFeedsFn != nil {
+		return m.topFeedsFn(ctx, userID, since, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockItemViewRepo) snapshot() [][]model.ItemView {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([][]model.ItemView(nil), m.batches...)
+}
+
+func countViews(batches [][]model.ItemView) int {
+	n := 0
+	for _, b := range batches {
+		n += len(b)
+	}
+	return n
+}
+
+// runRecorder は Run を別 goroutine で起動し、停止関数を返す。停止関数は Run の終了を待つ。
+func runRecorder(r *ViewRecorder) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestViewRecorder(t *testing.T) {
+	t.Run("BatchSize件たまったときまとめて保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockItemViewRepo{}
+		r := NewViewRecorder(repo, ViewRecorderConfig{BatchSize: 2, FlushInterval: time.Hour}, nil)
+		stop := runRecorder(r)
+		defer stop()
+
+		// Act
+		r.RecordView("user-1", "item-1")
+		r.RecordView("user-1", "item-2")
+
+		// Assert
+		deadline := time.Now().Add(time.Second)
+		for len(repo.snapshot()) == 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		batches := repo.snapshot()
+		if len(batches) != 1 || len(batches[0]) != 2 {
+			t.Fatalf("batches = %v, want 1 batch of 2", batches)
+		}
+		if batches[0][0].UserID != "user-1" || batches[0][0].ItemID != "item-1" || batches[0][0].ViewedAt.IsZero() {
+			t.Errorf("view = %+v", batches[0][0])
+		}
+	})
+
+	t.Run("FlushIntervalが経過したときBatchSize未満でも保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockItemViewRepo{}
+		r := NewViewRecorder(repo, ViewRecorderConfig{BatchSize: 100, FlushInterval: 10 * time.Millisecond}, nil)
+		stop := runRecorder(r)
+		defer stop()
+
+		// Act
+		r.RecordView("user-1", "item-1")
+
+		// Assert
+		deadline := time.Now().Add(time.Second)
+		for countViews(repo.snapshot()) == 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if got := countViews(repo.snapshot()); got != 1 {
+			t.Errorf("保存件数 = %d, want 1", got)
+		}
+	})
+
+	t.Run("停止時にキューに残ったイベントを保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockItemViewRepo{}
+		r := NewViewRecorder(repo, ViewRecorderConfig{BatchSize: 100, FlushInterval: time.Hour}, nil)
+		for i := 0; i < 3; i++ {
+			r.RecordView("user-1", "item-1")
+		}
+
+		// Act
+		stop := runRecorder(r)
+		stop()
+
+		// Assert
+		if got := countViews(repo.snapshot()); got != 3 {
+			t.Errorf("保存件数 = %d, want 3", got)
+		}
+	})
+
+	t.Run("バッファが満杯のときブロックせずに破棄する", func(t *testing.T) {
+		// Arrange: Run を起動しないのでキューは消費されない
+		repo := &mockItemViewRepo{}
+		r := NewViewRecorder(repo, ViewRecorderConfig{BufferSize: 2, BatchSize: 100, FlushInterval: time.Hour}, nil)
+
+		// Act
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < 5; i++ {
+				r.RecordView("user-1", "item-1")
+			}
+			close(done)
+		}()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("バッファが満杯のとき RecordView がブロックした")
+		}
+		stop := runRecorder(r)
+		stop()
+		if got := countViews(repo.snapshot()); got != 2 {
+			t.Errorf("保存件数 = %d, want 2（バッファサイズ分のみ）", got)
+		}
+	})
+
+	t.Run("保存に失敗したとき後続のイベントの記録を継続する", func(t *testing.T) {
+		// Arrange
+		repo := &mockItemViewRepo{insertErr: errors.New("db error")}
+		r := NewViewRecorder(repo, ViewRecorderConfig{BatchSize: 1, FlushInterval: time.Hour}, nil)
+		stop := runRecorder(r)
+
+		// Act
+		r.RecordView("user-1", "item-1")
+		r.RecordView("user-1", "item-2")
+		deadline := time.Now().Add(time.Second)
+		for len(repo.snapshot()) < 2 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		stop()
+
+		// Assert
+		if got := len(repo.snapshot()); got != 2 {
+			t.Errorf("InsertBatch 呼び出し回数 = %d, want 2", got)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	// DefaultTopFeedsLimit は「よく読むフィード」ランキングの既定件数。
+	DefaultTopFeedsLimit = 10
+	// MaxTopFeedsLimit は「よく読むフィード」ランキングの最大件数。
+	MaxTopFeedsLimit = 50
+)
+
+// TopFeedsResult は「よく読むフィード」ランキングの結果。
+type TopFeedsResult struct {
+	PeriodDays int
+	Since      time.Time
+	Feeds      []model.FeedViewStat
+}
+
+// WeeklyTrendPoint は週次トレンドの 1 週分の集計。
+type WeeklyTrendPoint struct {
+	// WeekStart は集計週の開始時刻（月曜 00:00 UTC）。
+	WeekStart time.Time
+	NewItems  int
+	ReadItems int
+	// HiddenItems は週内に非表示（ミュート）にした記事数。既読数には含めない。
+	HiddenItems int
+	// Feeds はフィード別の内訳。スナップショットがない週は空。
+	Feeds []model.WeeklyFeedStat
+}
+
+// WeeklyTrendResult は週次トレンドの結果。Series は週の古い順に Weeks 件並ぶ。
+type WeeklyTrendResult struct {
+	Weeks  int
+	Since  time.Time
+	Series []WeeklyTrendPoint
+}
+
+// Service は閲覧統計のサービス層。
+type Service struct {
+	repo repository.ItemViewRepository
+	// weeklyRepo は週次統計スナップショットの取得先。未設定時は nil で、週次トレンドは 0 件の週で埋める。
+	weeklyRepo repository.WeeklyStatsRepository
+	now        func() time.Time
+}
+
+// Option は NewService の任意設定を表す functional option。
+type Option func(*Service)
+
+// WithWeeklyStatsRepository は週次トレンドの取得に使うスナップショットのリポジトリを設定する。
+func WithWeeklyStatsRepository(repo repository.WeeklyStatsRepository) Option {
+	return func(s *Service) {
+		s.weeklyRepo = repo
+	}
+}
+
+// NewService は Service を生成する。
+func NewService(repo repository.ItemViewRepository, opts ...Option) *Service {
+	s := &Service{repo: repo, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// TopFeeds は指定期間内にユーザーがよく読んだフィードを閲覧回数の多い順に返す。
+// period は「30d」形式（1d〜model.MaxStatsPeriodDays 日）で、空の場合は既定の 30 日とする。
+// limit は 1〜MaxTopFeedsLimit にクランプし、0 以下の場合は DefaultTopFeedsLimit を用いる。
+func (s *Service) TopFeeds(ctx context.Context, userID, period string, limit int) (*TopFeedsResult, error) {
+	days, err := parsePeriodDays(period)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultTopFeedsLimit
+	}
+	if limit > MaxTopFeedsLimit {
+		limit = MaxTopFeedsLimit
+	}
+
+	since := s.now().AddDate(0, 0, -days)
+	feeds, err := s.repo.TopFeedsByUser(ctx, userID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("よく読むフィードの取得に失敗しました: %w", err)
+	}
+	if feeds == nil {
+		feeds = []model.FeedViewStat{}
+	}
+
+	return &TopFeedsResult{PeriodDays: days, Since: since, Feeds: feeds}, nil
+}
+
+// WeeklyTrend は直近 weeks 週（集計中の今週を除く）の新着数・未読消化数・非表示数の推移を週の古い順に返す。
+// weeks は 1〜model.MaxWeeklyStatsWeeks にクランプし、0 以下の場合は model.DefaultWeeklyStatsWeeks を用いる。
+// スナップショットがない週（worker の停止中など）は 0 件として返す。
+func (s *Service) WeeklyTrend(ctx context.Context, userID string, weeks int) (*WeeklyTrendResult, error) {
+	if weeks <= 0 {
+		weeks = model.DefaultWeeklyStatsWeeks
+	}
+	if weeks > model.MaxWeeklyStatsWeeks {
+		weeks = model.MaxWeeklyStatsWeeks
+	}
+
+	since := model.WeekStart(s.now()).AddDate(0, 0, -7*weeks)
+	series := make([]WeeklyTrendPoint, weeks)
+	index := make(map[time.Time]int, weeks)
+	for i := range series {
+		start := since.AddDate(0, 0, 7*i)
+		series[i] = WeeklyTrendPoint{WeekStart: start, Feeds: []model.WeeklyFeedStat{}}
+		index[start] = i
+	}
+
+	if s.weeklyRepo != nil {
+		stats, err := s.weeklyRepo.ListWeeklyStatsByUser(ctx, userID, since)
+		if err != nil {
+			return nil, fmt.Errorf("週次統計の取得に失敗しました: %w", err)
+		}
+		for _, st := range stats {
+			i, ok := index[st.WeekStart]
+			if !ok {
+				continue
+			}
+			series[i].NewItems += st.NewItems
+			series[i].ReadItems += st.ReadItems
+			series[i].HiddenItems += st.HiddenItems
+			series[i].Feeds = append(series[i].Feeds, st)
+		}
+	}
+
+	return &WeeklyTrendResult{Weeks: weeks, Since: since, Series: series}, nil
+}
+
+// parsePeriodDays は「30d」形式の集計期間を日数に変換する。
+func parsePeriodDays(period string) (int, error) {
+	if period == "" {
+		return model.DefaultStatsPeriodDays, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(period, "d"))
+	if err != nil || !strings.HasSuffix(period, "d") || n < 1 || n > model.MaxStatsPeriodDays {
+		return 0, model.NewInvalidStatsPeriodError(period)
+	}
+	return n, nil
+}
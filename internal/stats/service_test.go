@@ -0,0 +1,186 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestService_TopFeeds(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("periodを指定したとき期間の開始時刻と件数を渡して結果を返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockItemViewRepo{
+			topFeedsFn: func(_ context.Context, userID string, since time.Time, limit int) ([]model.FeedViewStat, error) {
+				if userID != "user-1" {
+					t.Errorf("userID = %q, want %q", userID, "user-1")
+				}
+				if want := now.AddDate(0, 0, -7); !since.Equal(want) {
+					t.Errorf("since = %v, want %v", since, want)
+				}
+				if limit != 5 {
+					t.Errorf("limit = %d, want 5", limit)
+				}
+				return []model.FeedViewStat{{FeedID: "feed-1", FeedTitle: "Feed 1", ViewCount: 12, ItemCount: 8}}, nil
+			},
+		}
+		svc := NewService(repo)
+		svc.now = func() time.Time { return now }
+
+		// Act
+		result, err := svc.TopFeeds(context.Background(), "user-1", "7d", 5)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("TopFeeds returned error: %v", err)
+		}
+		if result.PeriodDays != 7 || len(result.Feeds) != 1 || result.Feeds[0].ViewCount != 12 {
+			t.Errorf("result = %+v", result)
+		}
+	})
+
+	t.Run("periodとlimitが未指定のとき既定値を使い結果が無くても空スライスを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockItemViewRepo{
+			topFeedsFn: func(_ context.Context, _ string, since time.Time, limit int) ([]model.FeedViewStat, error) {
+				if want := now.AddDate(0, 0, -model.DefaultStatsPeriodDays); !since.Equal(want) {
+					t.Errorf("since = %v, want %v", since, want)
+				}
+				if limit != DefaultTopFeedsLimit {
+					t.Errorf("limit = %d, want %d", limit, DefaultTopFeedsLimit)
+				}
+				return nil, nil
+			},
+		}
+		svc := NewService(repo)
+		svc.now = func() time.Time { return now }
+
+		// Act
+		result, err := svc.TopFeeds(context.Background(), "user-1", "", 0)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("TopFeeds returned error: %v", err)
+		}
+		if result.Feeds == nil || len(result.Feeds) != 0 {
+			t.Errorf("Feeds = %v, want empty slice", result.Feeds)
+		}
+	})
+
+	t.Run("limitが上限を超えるとき上限にクランプする", func(t *testing.T) {
+		// Arrange
+		repo := &mockItemViewRepo{
+			topFeedsFn: func(_ context.Context, _ string, _ time.Time, limit int) ([]model.FeedViewStat, error) {
+				if limit != MaxTopFeedsLimit {
+					t.Errorf("limit = %d, want %d", limit, MaxTopFeedsLimit)
+				}
+				return nil, nil
+			},
+		}
+
+		// Act
+		_, err := NewService(repo).TopFeeds(context.Background(), "user-1", "30d", 1000)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("TopFeeds returned error: %v", err)
+		}
+	})
+
+	t.Run("periodが不正なときINVALID_STATS_PERIODを返す", func(t *testing.T) {
+		for _, period := range []string{"30", "0d", "91d", "abc", "d", "1w"} {
+			// Act
+			_, err := NewService(&mockItemViewRepo{}).TopFeeds(context.Background(), "user-1", period, 0)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidStatsPeriod {
+				t.Errorf("period=%q: err = %v, want %s", period, err, model.ErrCodeInvalidStatsPeriod)
+			}
+		}
+	})
+}
+
+func TestService_WeeklyTrend(t *testing.T) {
+	// 2026-06-24（水）時点では今週は 2026-06-22 始まりで、直近 2 週は 06-08 と 06-15。
+	now := time.Date(2026, 6, 24, 3, 0, 0, 0, time.UTC)
+	week1 := time.Date(2026, 6, 8, 0, 0, 0, 0, time.UTC)
+	week2 := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("週ごとにフィード別の内訳を合計しスナップショットのない週は0件で埋める", func(t *testing.T) {
+		// Arrange
+		weekly := &mockWeeklyStatsRepo{
+			listFn: func(_ context.Context, userID string, since time.Time) ([]model.WeeklyFeedStat, error) {
+				if userID != "user-1" || !since.Equal(week1) {
+					t.Errorf("args = (%q, %v), want (user-1, %v)", userID, since, week1)
+				}
+				return []model.WeeklyFeedStat{
+					{WeekStart: week2, FeedID: "feed-1", NewItems: 10, ReadItems: 4, HiddenItems: 2},
+					{WeekStart: week2, FeedID: "feed-2", NewItems: 5, ReadItems: 5, HiddenItems: 1},
+				}, nil
+			},
+		}
+		svc := NewService(&mockItemViewRepo{}, WithWeeklyStatsRepository(weekly))
+		svc.now = func() time.Time { return now }
+
+		// Act
+		result, err := svc.WeeklyTrend(context.Background(), "user-1", 2)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("WeeklyTrend returned error: %v", err)
+		}
+		if result.Weeks != 2 || len(result.Series) != 2 {
+			t.Fatalf("result = %+v, want 2 weeks", result)
+		}
+		if p := result.Series[0]; !p.WeekStart.Equal(week1) || p.NewItems != 0 || p.ReadItems != 0 || len(p.Feeds) != 0 {
+			t.Errorf("Series[0] = %+v, want empty week %v", p, week1)
+		}
+		if p := result.Series[1]; !p.WeekStart.Equal(week2) || p.NewItems != 15 || p.ReadItems != 9 || p.HiddenItems != 3 || len(p.Feeds) != 2 {
+			t.Errorf("Series[1] = %+v, want new=15 read=9 hidden=3 with 2 feeds", p)
+		}
+	})
+
+	t.Run("weeksが未指定のとき既定値を使い上限を超えるとき上限に丸める", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockItemViewRepo{}, WithWeeklyStatsRepository(&mockWeeklyStatsRepo{}))
+		svc.now = func() time.Time { return now }
+
+		// Act
+		def, err1 := svc.WeeklyTrend(context.Background(), "user-1", 0)
+		capped, err2 := svc.WeeklyTrend(context.Background(), "user-1", 1000)
+
+		// Assert
+		if err1 != nil || err2 != nil {
+			t.Fatalf("WeeklyTrend returned error: %v, %v", err1, err2)
+		}
+		if def.Weeks != model.DefaultWeeklyStatsWeeks || len(def.Series) != model.DefaultWeeklyStatsWeeks {
+			t.Errorf("default weeks = %d (%d points), want %d", def.Weeks, len(def.Series), model.DefaultWeeklyStatsWeeks)
+		}
+		if capped.Weeks != model.MaxWeeklyStatsWeeks {
+			t.Errorf("capped weeks = %d, want %d", capped.Weeks, model.MaxWeeklyStatsWeeks)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		weekly := &mockWeeklyStatsRepo{
+			listFn: func(context.Context, string, time.Time) ([]model.WeeklyFeedStat, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewService(&mockItemViewRepo{}, WithWeeklyStatsRepository(weekly))
+
+		// Act
+		_, err := svc.WeeklyTrend(context.Background(), "user-1", 4)
+
+		// Assert
+		if err == nil {
+			t.Error("WeeklyTrend error = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/worker/periodic"
+)
+
+// DefaultWeeklySnapshotInterval は週次統計スナップショットジョブの実行間隔の既定値。
+// 記録済みの週は上書きしないため、週の切り替わりから最大この間隔だけ遅れて前週分が記録される。
+const DefaultWeeklySnapshotInterval = 6 * time.Hour
+
+// WeeklySnapshotJob はユーザー×フィード単位の週次統計スナップショットを記録する worker ジョブ。
+// 実行のたびに直前の週（月曜 00:00 UTC 始まり）を記録し、保持期間（model.WeeklyStatsRetention）を
+// 過ぎたスナップショットを削除する。
+type WeeklySnapshotJob struct {
+	repo     repository.WeeklyStatsRepository
+	logger   *slog.Logger
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewWeeklySnapshotJob は WeeklySnapshotJob を生成する。interval が 0 以下の場合は DefaultWeeklySnapshotInterval を使う。
+func NewWeeklySnapshotJob(repo repository.WeeklyStatsRepository, logger *slog.Logger, interval time.Duration) *WeeklySnapshotJob {
+	if interval <= 0 {
+		interval = DefaultWeeklySnapshotInterval
+	}
+	return &WeeklySnapshotJob{repo: repo, logger: logger, interval: interval, now: time.Now}
+}
+
+// RunOnce は保持期間を過ぎたスナップショットを削除した上で、直前の週のスナップショットを記録する。
+// 削除に失敗しても記録は行う（古いスナップショットはトレンド API の集計範囲外のため結果に影響しない）。
+func (j *WeeklySnapshotJob) RunOnce(ctx context.Context) error {
+	start := j.now()
+	weekEnd := model.WeekStart(start)
+	weekStart := weekEnd.AddDate(0, 0, -7)
+
+	deleted, err := j.repo.DeleteWeeklyStatsBefore(ctx, weekEnd.Add(-model.WeeklyStatsRetention))
+	if err != nil {
+		j.logger.Warn("古い週次統計の削除に失敗しました",
+			slog.String("error", err.Error()),
+		)
+	}
+
+	recorded, err := j.repo.SnapshotWeeklyStats(ctx, weekStart, weekEnd)
+	if err != nil {
+		return fmt.Errorf("週次統計の記録に失敗: %w", err)
+	}
+
+	j.logger.Info("週次統計を記録しました",
+		slog.Time("week_start", weekStart),
+		slog.Int64("recorded", recorded),
+		slog.Int64("deleted", deleted),
+		slog.Float64("duration_ms", float64(j.now().Sub(start).Milliseconds())),
+	)
+	return nil
+}
+
+// Start は起動直後と interval ごとに RunOnce を実行する。ctx がキャンセルされるまでブロックする。
+func (j *WeeklySnapshotJob) Start(ctx context.Context) {
+	periodic.Run(ctx, j.logger, "週次統計のスナップショット", j.interval, j.RunOnce)
+}
@@ -0,0 +1,113 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockWeeklyStatsRepo は repository.WeeklyStatsRepository のモック実装。
+type mockWeeklyStatsRepo struct {
+	snapshotStart time.Time
+	snapshotEnd   time.Time
+	snapshotCalls int
+	snapshotErr   error
+	deleteBefore  time.Time
+	deleteErr     error
+
+	listFn func(ctx context.Context, userID string, since time.Time) ([]model.WeeklyFeedStat, error)
+}
+
+func (m *mockWeeklyStatsRepo) SnapshotWeeklyStats(_ context.Context, weekStart, weekEnd time.Time) (int64, error) {
+	m.snapshotCalls++
+	m.snapshotStart, m.snapshotEnd = weekStart, weekEnd
+	return 2, m.snapshotErr
+}
+
+func (m *mockWeeklyStatsRepo) DeleteWeeklyStatsBefore(_ context.Context, before time.Time) (int64, error) {
+	m.deleteBefore = before
+	return 1, m.deleteErr
+}
+
+func (m *mockWeeklyStatsRepo) ListWeeklyStatsByUser(ctx context.Context, userID string, since time.Time) ([]model.WeeklyFeedStat, error) {
+	if m.listFn != nil {
+		return m.listFn(ctx, userID, since)
+	}
+	return nil, nil
+}
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+}
+
+func TestWeeklySnapshotJob_RunOnce(t *testing.T) {
+	// 2026-06-24 は水曜日。直前の週は 2026-06-15（月）〜 2026-06-22（月）。
+	now := time.Date(2026, 6, 24, 3, 0, 0, 0, time.UTC)
+	wantStart := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2026, 6, 22, 0, 0, 0, 0, time.UTC)
+
+	t.Run("保持期間より古いスナップショットを削除してから直前の週を記録する", func(t *testing.T) {
+		// Arrange
+		repo := &mockWeeklyStatsRepo{}
+		job := NewWeeklySnapshotJob(repo, newTestLogger(), 0)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if !repo.snapshotStart.Equal(wantStart) || !repo.snapshotEnd.Equal(wantEnd) {
+			t.Errorf("集計範囲 = [%v, %v), want [%v, %v)", repo.snapshotStart, repo.snapshotEnd, wantStart, wantEnd)
+		}
+		if want := wantEnd.Add(-model.WeeklyStatsRetention); !repo.deleteBefore.Equal(want) {
+			t.Errorf("削除基準 = %v, want %v", repo.deleteBefore, want)
+		}
+	})
+
+	t.Run("削除に失敗しても記録する", func(t *testing.T) {
+		// Arrange
+		repo := &mockWeeklyStatsRepo{deleteErr: errors.New("db error")}
+		job := NewWeeklySnapshotJob(repo, newTestLogger(), 0)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if repo.snapshotCalls != 1 {
+			t.Errorf("SnapshotWeeklyStats calls = %d, want 1", repo.snapshotCalls)
+		}
+	})
+
+	t.Run("記録に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockWeeklyStatsRepo{snapshotErr: errors.New("db error")}
+		job := NewWeeklySnapshotJob(repo, newTestLogger(), 0)
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err == nil {
+			t.Error("RunOnce() error = nil, want error")
+		}
+	})
+}
+
+func TestNewWeeklySnapshotJob_DefaultInterval(t *testing.T) {
+	job := NewWeeklySnapshotJob(&mockWeeklyStatsRepo{}, newTestLogger(), 0)
+	if job.interval != DefaultWeeklySnapshotInterval {
+		t.Errorf("interval = %v, want %v", job.interval, DefaultWeeklySnapshotInterval)
+	}
+}
@@ -0,0 +1,33 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// AuditRecorder は購読操作を監査ログに記録するインターフェース。
+// audit.Service が実装する。記録の失敗は実装側で処理し、呼び出し元の操作を失敗させない。
+type AuditRecorder interface {
+	Record(ctx context.Context, userID string, action model.AuditAction, target string, payload map[string]any)
+}
+
+// WithAuditRecorder は購読解除・解除の取り消し・設定変更・フェッチ再開を監査ログに記録する。
+// 監査ログの target にはフィードIDを、payload には購読IDと変更内容を記録する。
+func WithAuditRecorder(r AuditRecorder) ServiceOption {
+	return func(s *Service) {
+		s.auditRecorder = r
+	}
+}
+
+// recordAudit は AuditRecorder が設定されていれば購読操作を監査ログに記録する。
+func (s *Service) recordAudit(ctx context.Context, userID string, action model.AuditAction, feedID, subscriptionID string, extra map[string]any) {
+	if s.auditRecorder == nil {
+		return
+	}
+	payload := map[string]any{"subscription_id": subscriptionID}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	s.auditRecorder.Record(ctx, userID, action, feedID, payload)
+}
@@ -0,0 +1,211 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// auditRecord は mockAuditRecorder が受け取った 1 件分の記録。
+type auditRecord struct {
+	userID  string
+	action  model.AuditAction
+	target  string
+	payload map[string]any
+}
+
+// mockAuditRecorder は AuditRecorder のモック実装。
+type mockAuditRecorder struct {
+	records []auditRecord
+}
+
+func (m *mockAuditRecorder) Record(_ context.Context, userID string, action model.AuditAction, target string, payload map[string]any) {
+	m.records = append(m.records, auditRecord{userID: userID, action: action, target: target, payload: payload})
+}
+
+func TestService_AuditRecorder(t *testing.T) {
+	ownedSub := func(context.Context, string) (*model.Subscription, error) {
+		return &model.Subscription{ID: "sub-1", UserID: "user-1", FeedID: "feed-1", FetchIntervalMinutes: 60}, nil
+	}
+	listWithFeed := func(_ context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error) {
+		return []repository.SubscriptionWithFeedInfo{
+			{
+				Subscription: model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1", FetchIntervalMinutes: 120},
+				FetchStatus:  model.FetchStatusActive,
+			},
+		}, nil
+	}
+
+	t.Run("設定変更のとき変更前後のフェッチ間隔を記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		subRepo := &mockSubRepo{findByIDFn: ownedSub, listByUserIDWithFeedFn: listWithFeed}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil, WithAuditRecorder(recorder))
+
+		// Act
+		if _, err := svc.UpdateSettings(context.Background(), "user-1", "sub-1", 120); err != nil {
+			t.Fatalf("UpdateSettings returned error: %v", err)
+		}
+
+		// Assert
+		if len(recorder.records) != 1 {
+			t.Fatalf("records = %d, want 1", len(recorder.records))
+		}
+		got := recorder.records[0]
+		if got.userID != "user-1" || got.action != model.AuditActionSubscriptionUpdateSettings || got.target != "feed-1" {
+			t.Errorf("record = %+v", got)
+		}
+		if got.payload["subscription_id"] != "sub-1" ||
+			got.payload["fetch_interval_minutes"] != 120 ||
+			got.payload["previous_fetch_interval_minutes"] != 60 {
+			t.Errorf("payload = %v", got.payload)
+		}
+	})
+
+	t.Run("設定変更が検証エラーのとき記録しない", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		subRepo := &mockSubRepo{findByIDFn: ownedSub}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil, WithAuditRecorder(recorder))
+
+		// Act
+		_, err := svc.UpdateSettings(context.Background(), "user-1", "sub-1", 31)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+		if len(recorder.records) != 0 {
+			t.Errorf("records = %+v, want none", recorder.records)
+		}
+	})
+
+	t.Run("購読解除のときフィードIDを対象として記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		subRepo := &mockSubRepo{
+			findByIDFn: ownedSub,
+			deleteFn:   func(context.Context, string) error { return nil },
+		}
+		itemStateRepo := &mockItemStateRepo{
+			deleteByUserAndFeedFn: func(context.Context, string, string) error { return nil },
+		}
+		svc := NewService(subRepo, itemStateRepo, nil, nil, nil, nil, WithAuditRecorder(recorder))
+
+		// Act
+		if err := svc.Unsubscribe(context.Background(), "user-1", "sub-1"); err != nil {
+			t.Fatalf("Unsubscribe returned error: %v", err)
+		}
+
+		// Assert
+		if len(recorder.records) != 1 {
+			t.Fatalf("records = %d, want 1", len(recorder.records))
+		}
+		got := recorder.records[0]
+		if got.action != model.AuditActionSubscriptionDelete || got.target != "feed-1" || got.payload["subscription_id"] != "sub-1" {
+			t.Errorf("record = %+v", got)
+		}
+	})
+
+	t.Run("取り消し可能な購読解除でも削除前に引いたフィードIDを記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		subRepo := &mockSubRepo{findByIDFn: ownedSub}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil,
+			WithUndo(&mockUndoRepo{}, 30*time.Second),
+			WithAuditRecorder(recorder),
+		)
+
+		// Act
+		if err := svc.Unsubscribe(context.Background(), "user-1", "sub-1"); err != nil {
+			t.Fatalf("Unsubscribe returned error: %v", err)
+		}
+
+		// Assert
+		if len(recorder.records) != 1 {
+			t.Fatalf("records = %d, want 1", len(recorder.records))
+		}
+		if got := recorder.records[0]; got.action != model.AuditActionSubscriptionDelete || got.target != "feed-1" {
+			t.Errorf("record = %+v", got)
+		}
+	})
+
+	t.Run("取り消し可能な購読解除で対象が無いとき記録しない", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		undoRepo := &mockUndoRepo{
+			deleteWithUndoFn: func(context.Context, string, string, time.Time, time.Time) (bool, error) {
+				return false, nil
+			},
+		}
+		subRepo := &mockSubRepo{
+			findByIDFn: func(context.Context, string) (*model.Subscription, error) { return nil, nil },
+		}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil,
+			WithUndo(undoRepo, 30*time.Second),
+			WithAuditRecorder(recorder),
+		)
+
+		// Act
+		err := svc.Unsubscribe(context.Background(), "user-1", "sub-x")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected not found error")
+		}
+		if len(recorder.records) != 0 {
+			t.Errorf("records = %+v, want none", recorder.records)
+		}
+	})
+
+	t.Run("購読解除の取り消しのとき復元した購読のフィードIDを記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		subRepo := &mockSubRepo{listByUserIDWithFeedFn: listWithFeed}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil,
+			WithUndo(&mockUndoRepo{}, 30*time.Second),
+			WithAuditRecorder(recorder),
+		)
+
+		// Act
+		if _, err := svc.Restore(context.Background(), "user-1", "sub-1"); err != nil {
+			t.Fatalf("Restore returned error: %v", err)
+		}
+
+		// Assert
+		if len(recorder.records) != 1 {
+			t.Fatalf("records = %d, want 1", len(recorder.records))
+		}
+		if got := recorder.records[0]; got.action != model.AuditActionSubscriptionRestore || got.target != "feed-1" {
+			t.Errorf("record = %+v", got)
+		}
+	})
+
+	t.Run("フェッチ再開のとき記録する", func(t *testing.T) {
+		// Arrange
+		recorder := &mockAuditRecorder{}
+		subRepo := &mockSubRepo{findByIDFn: ownedSub, listByUserIDWithFeedFn: listWithFeed}
+		feedRepo := &mockFeedRepo{
+			findByIDFn: func(context.Context, string) (*model.Feed, error) {
+				return &model.Feed{ID: "feed-1", FetchStatus: model.FetchStatusStopped}, nil
+			},
+		}
+		svc := NewService(subRepo, nil, feedRepo, nil, nil, nil, WithAuditRecorder(recorder))
+
+		// Act
+		if _, err := svc.ResumeFetch(context.Background(), "user-1", "sub-1"); err != nil {
+			t.Fatalf("ResumeFetch returned error: %v", err)
+		}
+
+		// Assert
+		if len(recorder.records) != 1 {
+			t.Fatalf("records = %d, want 1", len(recorder.records))
+		}
+		if got := recorder.records[0]; got.action != model.AuditActionSubscriptionResume || got.target != "feed-1" {
+			t.Errorf("record = %+v", got)
+		}
+	})
+}
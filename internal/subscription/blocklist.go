@@ -0,0 +1,18 @@
+package subscription
+
+import "context"
+
+// BlocklistChecker はインスタンスのブロックリスト（モデレーション）との照合のインターフェース。
+// moderation.Service が実装し、一致した場合は FEED_BLOCKED の APIError を返す。
+type BlocklistChecker interface {
+	CheckURL(ctx context.Context, rawURL string) error
+}
+
+// WithBlocklist はフェッチ再開（ResumeFetch）と URL 張り替え提案の適用（ApplySuggestedFeedURL）の前に
+// フィード URL をブロックリストと照合する checker を設定する。
+// ブロックの遡及適用で停止したフィードは、ブロックが解除されるまで再開できない。
+func WithBlocklist(checker BlocklistChecker) ServiceOption {
+	return func(s *Service) {
+		s.blocklist = checker
+	}
+}
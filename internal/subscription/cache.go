@@ -0,0 +1,63 @@
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/cache"
+)
+
+// ListCacheName はメトリクス（feedman_cache_requests_total の cache ラベル）に用いる購読一覧キャッシュの名前。
+const ListCacheName = "subscriptions"
+
+// DefaultListCacheTTL は購読一覧キャッシュの既定の有効期間。
+// ワーカーによる新着記事の取り込みは無効化されないため、未読数の反映遅延の上限となる。
+const DefaultListCacheTTL = 30 * time.Second
+
+// ListCache は購読一覧（未読数・積読警告を含む）のユーザー単位短期キャッシュ。
+type ListCache = cache.Cache[[]SubscriptionInfo]
+
+// ServiceOption は NewService の任意設定を表す functional option。
+type ServiceOption func(*Service)
+
+// WithListCache は ListSubscriptions の結果をユーザー単位でキャッシュする。
+// 本サービス内の書き込み（設定更新・購読解除・フェッチ再開・手動フェッチ）では該当ユーザーの
+// キャッシュを即時に無効化する。サービス外の書き込み（既読更新・フィード登録等）は
+// ListCacheInvalidator 経由で無効化し、ワーカーによる新着記事の反映は TTL の経過に委ねる。
+func WithListCache(c ListCache) ServiceOption {
+	return func(s *Service) {
+		s.listCache = c
+	}
+}
+
+// listCacheKey は購読一覧キャッシュのキーを返す。
+// 将来 Redis 等の共有ストアへ差し替えた際に他のキャッシュと衝突しないよう名前空間を付ける。
+func listCacheKey(userID string) string {
+	return ListCacheName + ":" + userID
+}
+
+// ListCacheInvalidator は購読一覧キャッシュをユーザー単位で無効化する cache.UserInvalidator 実装。
+// 購読一覧の内容（未読数・購読の増減など）を変える他サービスに注入する。
+type ListCacheInvalidator struct {
+	cache ListCache
+}
+
+// NewListCacheInvalidator は ListCacheInvalidator を生成する。
+func NewListCacheInvalidator(c ListCache) *ListCacheInvalidator {
+	return &ListCacheInvalidator{cache: c}
+}
+
+// InvalidateUser は指定ユーザーの購読一覧キャッシュを無効化する。
+func (i *ListCacheInvalidator) InvalidateUser(ctx context.Context, userID string) {
+	i.cache.Delete(ctx, listCacheKey(userID))
+}
+
+// invalidateListCache はキャッシュが設定されていれば指定ユーザーの購読一覧キャッシュを無効化する。
+func (s *Service) invalidateListCache(ctx context.Context, userID string) {
+	if s.listCache != nil {
+		s.listCache.Delete(ctx, listCacheKey(userID))
+	}
+}
+
+// compile-time interface check
+var _ cache.UserInvalidator = (*ListCacheInvalidator)(nil)
@@ -0,0 +1,147 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// newCountingSubRepo は ListByUserIDWithFeedInfo の呼び出し回数を数える mockSubRepo を返す。
+func newCountingSubRepo(calls *int) *mockSubRepo {
+	return &mockSubRepo{
+		findByIDFn: func(_ context.Context, id string) (*model.Subscription, error) {
+			return &model.Subscription{ID: id, UserID: "user-1", FeedID: "feed-1"}, nil
+		},
+		listByUserIDWithFeedFn: func(_ context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error) {
+			*calls++
+			return []repository.SubscriptionWithFeedInfo{
+				{
+					Subscription: model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1", FetchIntervalMinutes: 60},
+					FeedTitle:    "Test Feed",
+					FetchStatus:  model.FetchStatusActive,
+					UnreadCount:  *calls,
+				},
+			}, nil
+		},
+		deleteFn: func(_ context.Context, _ string) error { return nil },
+	}
+}
+
+func TestService_ListSubscriptions_Cache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TTL内の2回目の呼び出しはキャッシュを返しリポジトリを参照しない", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil,
+			WithListCache(cache.NewMemory[[]SubscriptionInfo](30*time.Second)))
+
+		// Act
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+		results, err := svc.ListSubscriptions(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ListSubscriptions returned error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("repository calls = %d, want 1", calls)
+		}
+		if results[0].UnreadCount != 1 {
+			t.Errorf("UnreadCount = %d, want 1（キャッシュの値）", results[0].UnreadCount)
+		}
+	})
+
+	t.Run("ユーザーが異なるときキャッシュを共有しない", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil,
+			WithListCache(cache.NewMemory[[]SubscriptionInfo](30*time.Second)))
+
+		// Act
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+		_, _ = svc.ListSubscriptions(ctx, "user-2")
+
+		// Assert
+		if calls != 2 {
+			t.Errorf("repository calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("設定更新後はキャッシュが無効化される", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil,
+			WithListCache(cache.NewMemory[[]SubscriptionInfo](30*time.Second)))
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Act
+		if _, err := svc.UpdateSettings(ctx, "user-1", "sub-1", 120); err != nil {
+			t.Fatalf("UpdateSettings returned error: %v", err)
+		}
+		calls = 0
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Assert
+		if calls != 1 {
+			t.Errorf("無効化後はリポジトリを参照すべき: calls = %d", calls)
+		}
+	})
+
+	t.Run("購読解除後はキャッシュが無効化される", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil,
+			WithListCache(cache.NewMemory[[]SubscriptionInfo](30*time.Second)))
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Act
+		if err := svc.Unsubscribe(ctx, "user-1", "sub-1"); err != nil {
+			t.Fatalf("Unsubscribe returned error: %v", err)
+		}
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Assert
+		if calls != 2 {
+			t.Errorf("repository calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("ListCacheInvalidatorで外部から無効化できる", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		c := cache.NewMemory[[]SubscriptionInfo](30 * time.Second)
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil, WithListCache(c))
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Act
+		NewListCacheInvalidator(c).InvalidateUser(ctx, "user-1")
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Assert
+		if calls != 2 {
+			t.Errorf("repository calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("返却したスライスを変更してもキャッシュに影響しない", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil,
+			WithListCache(cache.NewMemory[[]SubscriptionInfo](30*time.Second)))
+		first, _ := svc.ListSubscriptions(ctx, "user-1")
+
+		// Act
+		first[0].FeedTitle = "mutated"
+		second, _ := svc.ListSubscriptions(ctx, "user-1")
+
+		// Assert
+		if second[0].FeedTitle != "Test Feed" {
+			t.Errorf("FeedTitle = %q, want %q", second[0].FeedTitle, "Test Feed")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// errSuggestionNotConfigured はフィード URL 張り替え提案のリポジトリ未設定のまま ApplySuggestedFeedURL が呼ばれたことを表す。
+var errSuggestionNotConfigured = errors.New("feed url suggestion repository is not configured")
+
+// WithFeedURLSuggestion はフェッチ停止中のフィードへの URL 張り替え提案を適用する操作（ApplySuggestedFeedURL）を有効にする。
+// 未設定時の ApplySuggestedFeedURL はエラーを返す。
+func WithFeedURLSuggestion(repo repository.FeedURLSuggestionRepository) ServiceOption {
+	return func(s *Service) {
+		s.suggestionRepo = repo
+	}
+}
+
+// SSRFValidator は URL の SSRF 検証のインターフェース。security.SSRFGuardService が実装する。
+type SSRFValidator interface {
+	ValidateURL(rawURL string) error
+}
+
+// WithSSRFValidator は URL 張り替え提案の適用（ApplySuggestedFeedURL）で提案 URL を SSRF 検証する guard を設定する。
+// 未設定時は SSRF 検証を行わない（フェッチ時の検証のみとなる）。
+func WithSSRFValidator(guard SSRFValidator) ServiceOption {
+	return func(s *Service) {
+		s.ssrfGuard = guard
+	}
+}
+
+// ApplySuggestedFeedURL は購読先フィードの URL を提案された URL に張り替えてフェッチを再開し、更新後の購読情報を返す。
+// フィードは購読者間で共有されるため、張り替えは同じフィードを購読する全ユーザーに反映される。
+// 提案 URL は登録時と同じくブロックリストと SSRF を検証してから適用する。
+// 存在しない（他ユーザーのものを含む）購読の場合は SUBSCRIPTION_NOT_FOUND、
+// 提案がない（またはフェッチが停止していない）場合は FEED_URL_SUGGESTION_NOT_FOUND、
+// 提案 URL がブロック対象の場合は FEED_BLOCKED、SSRF 検証に失敗した場合は SSRF_BLOCKED、
+// 提案 URL が別フィードとして登録済みの場合は FEED_URL_TAKEN を返す。
+func (s *Service) ApplySuggestedFeedURL(ctx context.Context, userID, subscriptionID string) (*SubscriptionInfo, error) {
+	if s.suggestionRepo == nil {
+		return nil, errSuggestionNotConfigured
+	}
+
+	sub, err := s.subRepo.FindByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("購読の取得に失敗しました: %w", err)
+	}
+	if sub == nil || sub.UserID != userID {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+
+	feed, err := s.feedRepo.FindByID(ctx, sub.FeedID)
+	if err != nil {
+		return nil, fmt.Errorf("フィードの取得に失敗しました: %w", err)
+	}
+	if feed == nil {
+		return nil, fmt.Errorf("フィードが見つかりません: %s", sub.FeedID)
+	}
+
+	suggested, err := s.suggestionRepo.FindSuggestedFeedURL(ctx, sub.FeedID)
+	if err != nil {
+		return nil, fmt.Errorf("フィード URL の張り替え提案の取得に失敗しました: %w", err)
+	}
+	if suggested == "" {
+		return nil, model.NewFeedURLSuggestionNotFoundError()
+	}
+	if s.blocklist != nil {
+		if err := s.blocklist.CheckURL(ctx, suggested); err != nil {
+			return nil, err
+		}
+	}
+	if s.ssrfGuard != nil {
+		if err := s.ssrfGuard.ValidateURL(suggested); err != nil {
+			return nil, model.NewSSRFBlockedError()
+		}
+	}
+
+	applied, err := s.suggestionRepo.ApplySuggestedFeedURL(ctx, sub.FeedID, suggested)
+	if errors.Is(err, repository.ErrFeedURLTaken) {
+		return nil, model.NewFeedURLTakenError()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("フィード URL の張り替えに失敗しました: %w", err)
+	}
+	if applied == "" {
+		return nil, model.NewFeedURLSuggestionNotFoundError()
+	}
+	s.invalidateListCache(ctx, userID)
+	s.recordAudit(ctx, userID, model.AuditActionSubscriptionApplySuggestedFeedURL, sub.FeedID, subscriptionID, map[string]any{
+		"feed_url":          applied,
+		"previous_feed_url": feed.FeedURL,
+	})
+
+	infos, err := s.loadSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range infos {
+		if infos[i].ID == subscriptionID {
+			return &infos[i], nil
+		}
+	}
+	return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+}
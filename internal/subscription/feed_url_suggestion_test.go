@@ -0,0 +1,151 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockSuggestionRepo は repository.FeedURLSuggestionRepository のモック実装。
+type mockSuggestionRepo struct {
+	suggested    string
+	applyFn      func(ctx context.Context, feedID, suggestedURL string) (string, error)
+	gotFeedID    string
+	gotSuggested string
+	applyCalls   int
+}
+
+func (m *mockSuggestionRepo) SetSuggestedFeedURL(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockSuggestionRepo) FindSuggestedFeedURL(_ context.Context, _ string) (string, error) {
+	return m.suggested, nil
+}
+
+func (m *mockSuggestionRepo) ApplySuggestedFeedURL(ctx context.Context, feedID, suggestedURL string) (string, error) {
+	m.applyCalls++
+	m.gotFeedID = feedID
+	m.gotSuggested = suggestedURL
+	if m.applyFn != nil {
+		return m.applyFn(ctx, feedID, suggestedURL)
+	}
+	return "", nil
+}
+
+// mockSSRFValidator は SSRFValidator のモック実装。blockAll が true のときすべての URL を拒否する。
+type mockSSRFValidator struct {
+	blockAll bool
+}
+
+func (m *mockSSRFValidator) ValidateURL(_ string) error {
+	if m.blockAll {
+		return errors.New("blocked by SSRF guard")
+	}
+	return nil
+}
+
+var _ repository.FeedURLSuggestionRepository = (*mockSuggestionRepo)(nil)
+
+func TestService_ApplySuggestedFeedURL(t *testing.T) {
+	ctx := context.Background()
+	feedRepo := &mockFeedRepo{
+		findByIDFn: func(_ context.Context, id string) (*model.Feed, error) {
+			return &model.Feed{ID: id, FeedURL: "https://example.com/old.xml"}, nil
+		},
+	}
+
+	t.Run("提案があるとき張り替えて監査ログを記録し更新後の購読を返す", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		repo := &mockSuggestionRepo{
+			suggested: "https://example.com/new.xml",
+			applyFn:   func(_ context.Context, _, u string) (string, error) { return u, nil },
+		}
+		recorder := &mockAuditRecorder{}
+		svc := NewService(newCountingSubRepo(&calls), nil, feedRepo, nil, nil, nil,
+			WithFeedURLSuggestion(repo), WithAuditRecorder(recorder))
+
+		// Act
+		info, err := svc.ApplySuggestedFeedURL(ctx, "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ApplySuggestedFeedURL returned error: %v", err)
+		}
+		if repo.gotFeedID != "feed-1" || repo.gotSuggested != "https://example.com/new.xml" {
+			t.Errorf("apply args = (%q, %q), want (feed-1, https://example.com/new.xml)", repo.gotFeedID, repo.gotSuggested)
+		}
+		if info.ID != "sub-1" {
+			t.Errorf("info.ID = %q, want sub-1", info.ID)
+		}
+		if len(recorder.records) != 1 || recorder.records[0].action != model.AuditActionSubscriptionApplySuggestedFeedURL {
+			t.Fatalf("audit records = %+v, want 1 件の %s", recorder.records, model.AuditActionSubscriptionApplySuggestedFeedURL)
+		}
+		if got := recorder.records[0].payload["previous_feed_url"]; got != "https://example.com/old.xml" {
+			t.Errorf("previous_feed_url = %v, want https://example.com/old.xml", got)
+		}
+	})
+
+	cases := []struct {
+		name      string
+		userID    string
+		suggested string
+		applyErr  error
+		applied   string
+		blocked   bool
+		blockSSRF bool
+		wantCode  string
+	}{
+		{name: "他ユーザーの購読のときSUBSCRIPTION_NOT_FOUNDを返す", userID: "user-2", suggested: "https://example.com/new.xml", wantCode: model.ErrCodeSubscriptionNotFound},
+		{name: "提案がないときFEED_URL_SUGGESTION_NOT_FOUNDを返す", userID: "user-1", wantCode: model.ErrCodeFeedURLSuggestionNotFound},
+		{name: "適用までに提案が変わったときFEED_URL_SUGGESTION_NOT_FOUNDを返す", userID: "user-1", suggested: "https://example.com/new.xml", wantCode: model.ErrCodeFeedURLSuggestionNotFound},
+		{name: "提案URLが登録済みのときFEED_URL_TAKENを返す", userID: "user-1", suggested: "https://example.com/new.xml", applyErr: repository.ErrFeedURLTaken, wantCode: model.ErrCodeFeedURLTaken},
+		{name: "提案URLがブロック対象のときFEED_BLOCKEDを返す", userID: "user-1", suggested: "https://spam.example/feed.xml", blocked: true, wantCode: model.ErrCodeFeedBlocked},
+		{name: "提案URLがSSRF検証に失敗したときSSRF_BLOCKEDを返す", userID: "user-1", suggested: "http://127.0.0.1/feed.xml", blockSSRF: true, wantCode: model.ErrCodeSSRFBlocked},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			calls := 0
+			repo := &mockSuggestionRepo{
+				suggested: tc.suggested,
+				applyFn:   func(context.Context, string, string) (string, error) { return tc.applied, tc.applyErr },
+			}
+			blocklist := stubBlocklist{}
+			if tc.blocked {
+				blocklist.err = model.NewFeedBlockedError(tc.suggested)
+			}
+			svc := NewService(newCountingSubRepo(&calls), nil, feedRepo, nil, nil, nil, WithFeedURLSuggestion(repo),
+				WithBlocklist(blocklist), WithSSRFValidator(&mockSSRFValidator{blockAll: tc.blockSSRF}))
+
+			// Act
+			_, err := svc.ApplySuggestedFeedURL(ctx, tc.userID, "sub-1")
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tc.wantCode {
+				t.Errorf("error = %v, want %s", err, tc.wantCode)
+			}
+			if (tc.blocked || tc.blockSSRF) && repo.applyCalls != 0 {
+				t.Errorf("検証に失敗した提案は適用されるべきではない (applyCalls=%d)", repo.applyCalls)
+			}
+		})
+	}
+
+	t.Run("リポジトリが未設定のときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil)
+
+		// Act
+		_, err := svc.ApplySuggestedFeedURL(ctx, "user-1", "sub-1")
+
+		// Assert
+		if !errors.Is(err, errSuggestionNotConfigured) {
+			t.Errorf("error = %v, want errSuggestionNotConfigured", err)
+		}
+	})
+}
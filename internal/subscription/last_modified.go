@@ -0,0 +1,31 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// WithListModTime は購読一覧の最終更新日時（ListModTime）の取得を有効にする。
+// 未設定時の ListModTime は常にゼロ値を返し、購読一覧 API は条件付き GET に応答しない。
+func WithListModTime(repo repository.SubscriptionListModTimeRepository) ServiceOption {
+	return func(s *Service) {
+		s.modTimeRepo = repo
+	}
+}
+
+// ListModTime はユーザーの購読一覧の最終更新日時を返す。
+// 購読一覧 API の Last-Modified / If-Modified-Since の判定に用いる。
+// 購読一覧キャッシュは参照せず、常にリポジトリから求める。
+func (s *Service) ListModTime(ctx context.Context, userID string) (time.Time, error) {
+	if s.modTimeRepo == nil {
+		return time.Time{}, nil
+	}
+	modTime, err := s.modTimeRepo.SubscriptionListModTime(ctx, userID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("購読一覧の最終更新日時の取得に失敗しました: %w", err)
+	}
+	return modTime, nil
+}
@@ -0,0 +1,70 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// stubListModTimeRepo は SubscriptionListModTimeRepository のスタブ。
+type stubListModTimeRepo struct {
+	modTime time.Time
+	err     error
+}
+
+func (r *stubListModTimeRepo) SubscriptionListModTime(ctx context.Context, userID string) (time.Time, error) {
+	return r.modTime, r.err
+}
+
+func TestService_ListModTime(t *testing.T) {
+	modTime := time.Date(2026, 7, 7, 12, 0, 0, 0, time.UTC)
+
+	t.Run("リポジトリの最終更新日時を返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil,
+			WithListModTime(&stubListModTimeRepo{modTime: modTime}))
+
+		// Act
+		got, err := svc.ListModTime(context.Background(), "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.Equal(modTime) {
+			t.Errorf("ListModTime = %v, want %v", got, modTime)
+		}
+	})
+
+	t.Run("リポジトリ未設定のときゼロ値を返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil)
+
+		// Act
+		got, err := svc.ListModTime(context.Background(), "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.IsZero() {
+			t.Errorf("ListModTime = %v, want zero", got)
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repoErr := errors.New("db error")
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil,
+			WithListModTime(&stubListModTimeRepo{err: repoErr}))
+
+		// Act
+		_, err := svc.ListModTime(context.Background(), "user-1")
+
+		// Assert
+		if !errors.Is(err, repoErr) {
+			t.Errorf("err = %v, want %v", err, repoErr)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// errOrderNotConfigured は並び順リポジトリ未設定のまま UpdateOrder が呼ばれたことを表す。
+var errOrderNotConfigured = errors.New("subscription order repository is not configured")
+
+// WithOrder は購読のピン留めとサイドバー並び順の一括更新（UpdateOrder）を有効にする。
+// 未設定時の UpdateOrder はエラーを返す。
+func WithOrder(repo repository.SubscriptionOrderRepository) ServiceOption {
+	return func(s *Service) {
+		s.orderRepo = repo
+	}
+}
+
+// UpdateOrder は購読の並び順を entries の指定順に一括更新し、更新後の購読一覧を返す。
+// 指定しなかった購読の並び順とピン留め状態は変更しない。
+// entries が空・上限超過・購読 ID の重複を含む場合は INVALID_SUBSCRIPTION_ORDER、
+// 存在しない（他ユーザーのものを含む）購読が含まれる場合は何も更新せず SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) UpdateOrder(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) ([]SubscriptionInfo, error) {
+	if err := validateOrderEntries(entries); err != nil {
+		return nil, err
+	}
+	if s.orderRepo == nil {
+		return nil, errOrderNotConfigured
+	}
+
+	notFoundID, err := s.orderRepo.UpdateOrder(ctx, userID, entries)
+	if err != nil {
+		return nil, fmt.Errorf("購読の並び順の更新に失敗しました: %w", err)
+	}
+	if notFoundID != "" {
+		return nil, model.NewSubscriptionNotFoundError(notFoundID)
+	}
+	s.invalidateListCache(ctx, userID)
+
+	return s.loadSubscriptions(ctx, userID)
+}
+
+// validateOrderEntries は並び順の一括更新の指定を検証する。
+func validateOrderEntries(entries []model.SubscriptionOrderEntry) error {
+	if len(entries) == 0 {
+		return model.NewInvalidSubscriptionOrderError("購読が指定されていません")
+	}
+	if len(entries) > model.MaxSubscriptionOrderEntries {
+		return model.NewInvalidSubscriptionOrderError(fmt.Sprintf("指定できる購読は%d件までです", model.MaxSubscriptionOrderEntries))
+	}
+	seen := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		if e.SubscriptionID == "" {
+			return model.NewInvalidSubscriptionOrderError("購読IDが空です")
+		}
+		if _, ok := seen[e.SubscriptionID]; ok {
+			return model.NewInvalidSubscriptionOrderError(fmt.Sprintf("購読IDが重複しています: %s", e.SubscriptionID))
+		}
+		seen[e.SubscriptionID] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockOrderRepo は repository.SubscriptionOrderRepository のモック実装。
+type mockOrderRepo struct {
+	updateOrderFn func(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) (string, error)
+	calls         int
+}
+
+func (m *mockOrderRepo) UpdateOrder(ctx context.Context, userID string, entries []model.SubscriptionOrderEntry) (string, error) {
+	m.calls++
+	if m.updateOrderFn != nil {
+		return m.updateOrderFn(ctx, userID, entries)
+	}
+	return "", nil
+}
+
+var _ repository.SubscriptionOrderRepository = (*mockOrderRepo)(nil)
+
+func TestService_UpdateOrder(t *testing.T) {
+	ctx := context.Background()
+	pinned := true
+
+	t.Run("指定を保存しキャッシュを無効化して更新後の一覧を返す", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		var gotEntries []model.SubscriptionOrderEntry
+		orderRepo := &mockOrderRepo{
+			updateOrderFn: func(_ context.Context, userID string, entries []model.SubscriptionOrderEntry) (string, error) {
+				if userID != "user-1" {
+					t.Errorf("userID = %q, want user-1", userID)
+				}
+				gotEntries = entries
+				return "", nil
+			},
+		}
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil,
+			WithListCache(cache.NewMemory[[]SubscriptionInfo](30*time.Second)),
+			WithOrder(orderRepo))
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Act
+		results, err := svc.UpdateOrder(ctx, "user-1", []model.SubscriptionOrderEntry{
+			{SubscriptionID: "sub-1", IsPinned: &pinned},
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("UpdateOrder returned error: %v", err)
+		}
+		if len(gotEntries) != 1 || gotEntries[0].SubscriptionID != "sub-1" {
+			t.Errorf("entries = %+v", gotEntries)
+		}
+		// キャッシュ済みの一覧ではなくリポジトリから再取得していること
+		if len(results) != 1 || calls != 2 {
+			t.Errorf("results = %d, repository calls = %d, want 1, 2", len(results), calls)
+		}
+	})
+
+	t.Run("指定が不正なときINVALID_SUBSCRIPTION_ORDERを返し保存しない", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			entries []model.SubscriptionOrderEntry
+		}{
+			{name: "空", entries: nil},
+			{name: "ID空", entries: []model.SubscriptionOrderEntry{{SubscriptionID: ""}}},
+			{name: "重複", entries: []model.SubscriptionOrderEntry{{SubscriptionID: "sub-1"}, {SubscriptionID: "sub-1"}}},
+			{name: "上限超過", entries: make([]model.SubscriptionOrderEntry, model.MaxSubscriptionOrderEntries+1)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				orderRepo := &mockOrderRepo{}
+				svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil, WithOrder(orderRepo))
+
+				// Act
+				_, err := svc.UpdateOrder(ctx, "user-1", tt.entries)
+
+				// Assert
+				var apiErr *model.APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidSubscriptionOrder {
+					t.Fatalf("err = %v, want INVALID_SUBSCRIPTION_ORDER", err)
+				}
+				if orderRepo.calls != 0 {
+					t.Errorf("UpdateOrder calls = %d, want 0", orderRepo.calls)
+				}
+			})
+		}
+	})
+
+	t.Run("未知の購読を含むときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		orderRepo := &mockOrderRepo{
+			updateOrderFn: func(context.Context, string, []model.SubscriptionOrderEntry) (string, error) {
+				return "sub-x", nil
+			},
+		}
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil, WithOrder(orderRepo))
+
+		// Act
+		_, err := svc.UpdateOrder(ctx, "user-1", []model.SubscriptionOrderEntry{{SubscriptionID: "sub-x"}})
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeSubscriptionNotFound {
+			t.Fatalf("err = %v, want SUBSCRIPTION_NOT_FOUND", err)
+		}
+	})
+
+	t.Run("並び順リポジトリが未設定のときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil)
+
+		// Act
+		_, err := svc.UpdateOrder(ctx, "user-1", []model.SubscriptionOrderEntry{{SubscriptionID: "sub-1"}})
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
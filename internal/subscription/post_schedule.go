@@ -0,0 +1,153 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	// postScheduleLookback は更新パターンの推定に用いる記事の公開日時の遡及期間。
+	postScheduleLookback = 8 * 7 * 24 * time.Hour
+	// postScheduleSampleLimit は更新パターンの推定に用いる記事の最大件数（新しい順）。
+	postScheduleSampleLimit = 200
+	// postScheduleMinSamples は推定を行うのに必要な最小の記事数。これ未満では推定しない。
+	postScheduleMinSamples = 5
+	// slotsPerWeek は 1 週間を 1 時間単位に区切った枠の数。
+	slotsPerWeek = 7 * 24
+)
+
+// WithPostSchedule は購読詳細（GetSubscription）での次回更新時刻の推定を有効にする。
+// 未設定時の GetSubscription は estimated_next_post_at を常に nil で返す。
+func WithPostSchedule(repo repository.FeedPublishHistoryRepository) ServiceOption {
+	return func(s *Service) {
+		s.publishHistoryRepo = repo
+	}
+}
+
+// GetSubscription はユーザーの購読 1 件をフィード情報と推定次回更新時刻付きで返す。
+// 他ユーザーの購読や存在しない購読は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) GetSubscription(ctx context.Context, userID, subscriptionID string) (*SubscriptionInfo, error) {
+	infos, err := s.ListSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range infos {
+		if infos[i].ID != subscriptionID {
+			continue
+		}
+		info := infos[i]
+		if s.publishHistoryRepo != nil {
+			now := s.now()
+			published, err := s.publishHistoryRepo.ListRecentPublishedAt(ctx, info.FeedID, now.Add(-postScheduleLookback), postScheduleSampleLimit)
+			if err != nil {
+				return nil, fmt.Errorf("記事の公開日時の取得に失敗しました: %w", err)
+			}
+			info.EstimatedNextPostAt = EstimateNextPostAt(published, now)
+		}
+		return &info, nil
+	}
+	return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+}
+
+// EstimateNextPostAt は過去の記事の公開日時から、now より後で次に記事が公開されるおおよその時刻を推定する。
+//
+// 公開日時を曜日と時（1 週間 168 枠）に振り分け、観測した週の半数以上（かつ 2 週以上）で記事があった枠を
+// 定期的な更新枠とみなす（「毎朝 7 時」は 7 枠、「平日のみ 12 時」は 5 枠になる）。
+// 定期的な更新枠があれば、now 以降で最も近い枠の、その枠で公開された記事の分の中央値を返す。
+// 定期的な更新枠が無い場合は、公開間隔の中央値で最後の記事から進めた now 以降の時刻を返す。
+// 記事が postScheduleMinSamples 件未満の場合は推定せず nil を返す。
+// 曜日と時は UTC で数える（週単位の周期だけを見るため、フィードのタイムゾーンには依存しない）。
+func EstimateNextPostAt(published []time.Time, now time.Time) *time.Time {
+	if len(published) < postScheduleMinSamples {
+		return nil
+	}
+	times := make([]time.Time, len(published))
+	for i, t := range published {
+		times[i] = t.UTC()
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	now = now.UTC()
+
+	if next, ok := nextRegularSlot(times, now); ok {
+		return &next
+	}
+	return nextByMedianInterval(times, now)
+}
+
+// isoWeek は ISO 8601 の年と週番号。枠ごとに記事があった週を重複なく数えるためのキー。
+type isoWeek struct {
+	year, week int
+}
+
+// nextRegularSlot は定期的な更新枠のうち now 以降で最も近い枠の推定時刻を返す。枠が無い場合は false を返す。
+// 枠ごとに数えるのは記事数ではなく記事があった週（ISO 週）の数で、1 週の同じ枠に記事が集中しても 1 週と数える。
+// times は昇順であること。
+func nextRegularSlot(times []time.Time, now time.Time) (time.Time, bool) {
+	var counts [slotsPerWeek]int
+	var lastWeek [slotsPerWeek]isoWeek
+	var minutes [slotsPerWeek][]int
+	for _, t := range times {
+		slot := int(t.Weekday())*24 + t.Hour()
+		year, week := t.ISOWeek()
+		// times は昇順のため、同じ枠の同じ週の記事は連続して現れる
+		if w := (isoWeek{year, week}); counts[slot] == 0 || lastWeek[slot] != w {
+			counts[slot]++
+			lastWeek[slot] = w
+		}
+		minutes[slot] = append(minutes[slot], t.Minute())
+	}
+
+	weeks := int(now.Sub(times[0]).Hours()/(7*24)) + 1
+	threshold := max(2, (weeks+1)/2)
+
+	// now の属する枠から 1 週間先まで順に見て、最初の定期枠の推定時刻を返す
+	start := now.Truncate(time.Hour)
+	for h := 0; h <= slotsPerWeek; h++ {
+		slotStart := start.Add(time.Duration(h) * time.Hour)
+		slot := int(slotStart.Weekday())*24 + slotStart.Hour()
+		if counts[slot] < threshold {
+			continue
+		}
+		candidate := slotStart.Add(time.Duration(medianInt(minutes[slot])) * time.Minute)
+		if candidate.After(now) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextByMedianInterval は公開間隔の中央値で最後の記事から進めた、now より後の最初の時刻を返す。
+// times は昇順であること。間隔が求まらない場合は nil を返す。
+func nextByMedianInterval(times []time.Time, now time.Time) *time.Time {
+	intervals := make([]time.Duration, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		if d := times[i].Sub(times[i-1]); d > 0 {
+			intervals = append(intervals, d)
+		}
+	}
+	if len(intervals) == 0 {
+		return nil
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	median := intervals[len(intervals)/2]
+
+	next := times[len(times)-1].Add(median)
+	if !next.After(now) {
+		steps := now.Sub(next)/median + 1
+		next = next.Add(steps * median)
+	}
+	return &next
+}
+
+// medianInt は values の中央値（要素数が偶数の場合は小さい側）を返す。values は空でないこと。
+func medianInt(values []int) int {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	return sorted[(len(sorted)-1)/2]
+}
@@ -0,0 +1,159 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// stubPublishHistoryRepo は FeedPublishHistoryRepository のスタブ。
+type stubPublishHistoryRepo struct {
+	published []time.Time
+	gotFeedID string
+}
+
+func (r *stubPublishHistoryRepo) ListRecentPublishedAt(ctx context.Context, feedID string, since time.Time, limit int) ([]time.Time, error) {
+	r.gotFeedID = feedID
+	return r.published, nil
+}
+
+// dailyPosts は start の日から days 日分、各日の hour 時 minute 分に公開した記事の日時を返す。
+// weekdaysOnly が true の場合は土日を除く。
+func dailyPosts(start time.Time, days, hour, minute int, weekdaysOnly bool) []time.Time {
+	var posts []time.Time
+	for d := 0; d < days; d++ {
+		t := time.Date(start.Year(), start.Month(), start.Day()+d, hour, minute, 0, 0, time.UTC)
+		if weekdaysOnly && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+			continue
+		}
+		posts = append(posts, t)
+	}
+	return posts
+}
+
+func TestEstimateNextPostAt(t *testing.T) {
+	// 2026-07-13 は月曜日
+	start := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		published []time.Time
+		now       time.Time
+		want      *time.Time
+	}{
+		{
+			name:      "毎朝7時の更新のとき翌朝7時を返す",
+			published: dailyPosts(start, 28, 7, 5, false),
+			now:       time.Date(2026, 7, 13, 9, 0, 0, 0, time.UTC),
+			want:      ptrTime(time.Date(2026, 7, 14, 7, 5, 0, 0, time.UTC)),
+		},
+		{
+			name:      "毎朝7時の更新で当日の公開前のとき当日7時を返す",
+			published: dailyPosts(start, 28, 7, 5, false),
+			now:       time.Date(2026, 7, 13, 6, 30, 0, 0, time.UTC),
+			want:      ptrTime(time.Date(2026, 7, 13, 7, 5, 0, 0, time.UTC)),
+		},
+		{
+			name:      "平日のみの更新で金曜の公開後のとき月曜を返す",
+			published: dailyPosts(start, 28, 12, 0, true),
+			now:       time.Date(2026, 7, 10, 13, 0, 0, 0, time.UTC),
+			want:      ptrTime(time.Date(2026, 7, 13, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "定期的な時刻が無いとき公開間隔の中央値で進めた時刻を返す",
+			published: []time.Time{
+				time.Date(2026, 7, 1, 3, 0, 0, 0, time.UTC),
+				time.Date(2026, 7, 4, 14, 0, 0, 0, time.UTC),
+				time.Date(2026, 7, 7, 9, 0, 0, 0, time.UTC),
+				time.Date(2026, 7, 10, 20, 0, 0, 0, time.UTC),
+				time.Date(2026, 7, 13, 1, 0, 0, 0, time.UTC),
+			},
+			now:  time.Date(2026, 7, 13, 2, 0, 0, 0, time.UTC),
+			want: ptrTime(time.Date(2026, 7, 16, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "1 週の同じ枠に記事が集中したとき定期的な更新枠とみなさない",
+			published: []time.Time{
+				time.Date(2026, 6, 17, 10, 0, 0, 0, time.UTC),
+				time.Date(2026, 6, 17, 10, 10, 0, 0, time.UTC),
+				time.Date(2026, 6, 17, 10, 20, 0, 0, time.UTC),
+				time.Date(2026, 6, 17, 10, 30, 0, 0, time.UTC),
+				time.Date(2026, 6, 17, 10, 40, 0, 0, time.UTC),
+				time.Date(2026, 7, 10, 20, 0, 0, 0, time.UTC),
+			},
+			now:  time.Date(2026, 7, 13, 2, 0, 0, 0, time.UTC),
+			want: ptrTime(time.Date(2026, 7, 13, 2, 10, 0, 0, time.UTC)),
+		},
+		{
+			name:      "記事が少ないときnilを返す",
+			published: dailyPosts(start, 3, 7, 0, false),
+			now:       time.Date(2026, 6, 18, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateNextPostAt(tt.published, tt.now)
+
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("EstimateNextPostAt() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || !got.Equal(*tt.want) {
+				t.Errorf("EstimateNextPostAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetSubscription(t *testing.T) {
+	now := time.Date(2026, 7, 13, 9, 0, 0, 0, time.UTC)
+	subRepo := &mockSubRepo{
+		listByUserIDWithFeedFn: func(ctx context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error) {
+			return []repository.SubscriptionWithFeedInfo{
+				{Subscription: model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1"}, FeedTitle: "Morning"},
+			}, nil
+		},
+	}
+
+	t.Run("購読とフィードの公開履歴から推定した次回更新時刻を返す", func(t *testing.T) {
+		// Arrange
+		history := &stubPublishHistoryRepo{published: dailyPosts(now.AddDate(0, 0, -28), 28, 7, 0, false)}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil, WithPostSchedule(history))
+		svc.now = func() time.Time { return now }
+
+		// Act
+		info, err := svc.GetSubscription(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if history.gotFeedID != "feed-1" {
+			t.Errorf("feedID = %q, want feed-1", history.gotFeedID)
+		}
+		want := time.Date(2026, 7, 14, 7, 0, 0, 0, time.UTC)
+		if info.EstimatedNextPostAt == nil || !info.EstimatedNextPostAt.Equal(want) {
+			t.Errorf("EstimatedNextPostAt = %v, want %v", info.EstimatedNextPostAt, want)
+		}
+	})
+
+	t.Run("他ユーザーや存在しない購読のときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		svc := NewService(subRepo, nil, nil, nil, nil, nil)
+
+		_, err := svc.GetSubscription(context.Background(), "user-1", "sub-unknown")
+
+		apiErr, ok := err.(*model.APIError)
+		if !ok || apiErr.Code != model.ErrCodeSubscriptionNotFound {
+			t.Errorf("err = %v, want SUBSCRIPTION_NOT_FOUND", err)
+		}
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -75,25 +75,58 @@ type SubscriptionInfo struct {
 	FetchIntervalMinutes int
 	FeedStatus           string
 	ErrorMessage         *string
+	ErrorKind            *string
 	UnreadCount          int
-	CreatedAt            time.Time
+	TooManyUnread        bool
+	FeedLanguage         string
+	FeedDescription      string
+	FeedLastPublishedAt  *time.Time
+	IsPinned             bool
+	SortOrder            int
+	// Priority と MuteUntil はクライアント向けの通知ヒント（MuteUntil が nil の場合はミュートしていない）。
+	Priority  model.NotificationPriority
+	MuteUntil *time.Time
+	// ExpiresAt はお試し購読の期限（通常の購読では nil）。
+	ExpiresAt *time.Time
+	// SuggestedFeedURL はフェッチ停止中のフィードに対する URL 張り替えの提案（提案がない場合は nil）。
+	SuggestedFeedURL *string
+	// FeedTitleUpdatePolicy はフィードタイトルの自動更新ポリシー、PendingFeedTitle は manual のときの承認待ちタイトル（ない場合は nil）。
+	FeedTitleUpdatePolicy model.TitleUpdatePolicy
+	PendingFeedTitle      *string
+	CreatedAt             time.Time
+	// EstimatedNextPostAt は過去の記事の公開日時から推定した次回更新時刻。購読詳細（GetSubscription）でのみ設定し、
+	// 推定できない場合は nil。
+	EstimatedNextPostAt *time.Time
 }
 
 // Service は購読管理のサービス層。
 // 購読一覧取得、設定更新、購読解除、フェッチ再開、手動フェッチのビジネスロジックを提供する。
 type Service struct {
-	subRepo         repository.SubscriptionRepository
-	itemStateRepo   repository.ItemStateRepository
-	feedRepo        repository.FeedRepository
-	feedFetcher     fetch.FeedFetcherService
-	txBeginner      ManualFetchTxBeginner
-	metricsRecorder metrics.MetricsCollector
+	subRepo            repository.SubscriptionRepository
+	itemStateRepo      repository.ItemStateRepository
+	feedRepo           repository.FeedRepository
+	feedFetcher        fetch.FeedFetcherService
+	txBeginner         ManualFetchTxBeginner
+	metricsRecorder    metrics.MetricsCollector
+	listCache          ListCache
+	undoRepo           repository.SubscriptionUndoRepository
+	undoWindow         time.Duration
+	orderRepo          repository.SubscriptionOrderRepository
+	expiryRepo         repository.SubscriptionExpiryRepository
+	suggestionRepo     repository.FeedURLSuggestionRepository
+	auditRecorder      AuditRecorder
+	blocklist          BlocklistChecker
+	ssrfGuard          SSRFValidator
+	modTimeRepo        repository.SubscriptionListModTimeRepository
+	publishHistoryRepo repository.FeedPublishHistoryRepository
+	now                func() time.Time
 }
 
 // NewService はServiceの新しいインスタンスを生成する。
 // feedFetcher / txBeginner / metricsRecorder は ManualFetch でのみ使用され、
 // ListSubscriptions / UpdateSettings / Unsubscribe / ResumeFetch の各経路では参照されない。
 // app.go の wiring（task 6.1）が完了するまでは nil を渡しても既存パスは正常動作する。
+// opts で購読一覧キャッシュ（WithListCache）を設定できる。未指定時は毎回リポジトリを参照する。
 func NewService(
 	subRepo repository.SubscriptionRepository,
 	itemStateRepo repository.ItemStateRepository,
@@ -101,19 +134,46 @@ func NewService(
 	feedFetcher fetch.FeedFetcherService,
 	txBeginner ManualFetchTxBeginner,
 	metricsRecorder metrics.MetricsCollector,
+	opts ...ServiceOption,
 ) *Service {
-	return &Service{
+	s := &Service{
 		subRepo:         subRepo,
 		itemStateRepo:   itemStateRepo,
 		feedRepo:        feedRepo,
 		feedFetcher:     feedFetcher,
 		txBeginner:      txBeginner,
 		metricsRecorder: metricsRecorder,
+		now:             time.Now,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // ListSubscriptions はユーザーの購読一覧をフィード情報付きで返す。
+// 購読一覧キャッシュが設定されている場合は TTL 内のキャッシュを返す。
+// 返却するスライスは呼び出し元が変更してもキャッシュに影響しないようコピーする。
 func (s *Service) ListSubscriptions(ctx context.Context, userID string) ([]SubscriptionInfo, error) {
+	if s.listCache != nil {
+		if cached, ok := s.listCache.Get(ctx, listCacheKey(userID)); ok {
+			return append([]SubscriptionInfo(nil), cached...), nil
+		}
+	}
+
+	results, err := s.loadSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.listCache != nil {
+		s.listCache.Set(ctx, listCacheKey(userID), append([]SubscriptionInfo(nil), results...))
+	}
+	return results, nil
+}
+
+// loadSubscriptions はリポジトリから購読一覧を取得し SubscriptionInfo に変換する。
+func (s *Service) loadSubscriptions(ctx context.Context, userID string) ([]SubscriptionInfo, error) {
 	rows, err := s.subRepo.ListByUserIDWithFeedInfo(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("購読一覧の取得に失敗しました: %w", err)
@@ -121,36 +181,62 @@ func (s *Service) ListSubscriptions(ctx context.Context, userID string) ([]Subsc
 
 	results := make([]SubscriptionInfo, len(rows))
 	for i, row := range rows {
-		info := SubscriptionInfo{
-			ID:                   row.ID,
-			UserID:               row.UserID,
-			FeedID:               row.FeedID,
-			FeedTitle:            row.FeedTitle,
-			FeedURL:              row.FeedURL,
-			FetchIntervalMinutes: row.FetchIntervalMinutes,
-			FeedStatus:           string(row.FetchStatus),
-			UnreadCount:          row.UnreadCount,
-			CreatedAt:            row.CreatedAt,
-		}
-
-		// faviconデータがある場合はdata URLに変換
-		if len(row.FaviconData) > 0 && row.FaviconMime != "" {
-			dataURL := fmt.Sprintf("data:%s;base64,%s", row.FaviconMime, base64.StdEncoding.EncodeToString(row.FaviconData))
-			info.FaviconURL = &dataURL
-		}
-
-		// エラーメッセージがある場合
-		if row.ErrorMessage != "" {
-			msg := row.ErrorMessage
-			info.ErrorMessage = &msg
-		}
-
-		results[i] = info
+		results[i] = newSubscriptionInfo(row)
 	}
 
 	return results, nil
 }
 
+// newSubscriptionInfo はリポジトリの購読行（フィード情報付き）を SubscriptionInfo に変換する。
+func newSubscriptionInfo(row repository.SubscriptionWithFeedInfo) SubscriptionInfo {
+	info := SubscriptionInfo{
+		ID:                    row.ID,
+		UserID:                row.UserID,
+		FeedID:                row.FeedID,
+		FeedTitle:             row.FeedTitle,
+		FeedURL:               row.FeedURL,
+		FetchIntervalMinutes:  row.FetchIntervalMinutes,
+		FeedStatus:            string(row.FetchStatus),
+		UnreadCount:           row.UnreadCount,
+		TooManyUnread:         row.TooManyUnread,
+		FeedLanguage:          row.FeedLanguage,
+		FeedDescription:       row.FeedDescription,
+		FeedLastPublishedAt:   row.FeedLastPublishedAt,
+		IsPinned:              row.IsPinned,
+		SortOrder:             row.SortOrder,
+		Priority:              row.Priority,
+		MuteUntil:             row.MuteUntil,
+		ExpiresAt:             row.ExpiresAt,
+		FeedTitleUpdatePolicy: row.FeedTitleUpdatePolicy,
+		CreatedAt:             row.CreatedAt,
+	}
+
+	// faviconデータがある場合はdata URLに変換
+	if len(row.FaviconData) > 0 && row.FaviconMime != "" {
+		dataURL := fmt.Sprintf("data:%s;base64,%s", row.FaviconMime, base64.StdEncoding.EncodeToString(row.FaviconData))
+		info.FaviconURL = &dataURL
+	}
+
+	// エラーメッセージがある場合
+	if row.ErrorMessage != "" {
+		msg := row.ErrorMessage
+		info.ErrorMessage = &msg
+	}
+	if row.ErrorKind != model.FetchErrorKindNone {
+		kind := string(row.ErrorKind)
+		info.ErrorKind = &kind
+	}
+	if row.SuggestedFeedURL != "" {
+		suggested := row.SuggestedFeedURL
+		info.SuggestedFeedURL = &suggested
+	}
+	if row.PendingFeedTitle != "" {
+		pending := row.PendingFeedTitle
+		info.PendingFeedTitle = &pending
+	}
+	return info
+}
+
 // fetchIntervalMin はフェッチ間隔の下限（分）。
 const fetchIntervalMin = 30
 
@@ -186,6 +272,11 @@ func (s *Service) UpdateSettings(ctx context.Context, userID, subscriptionID str
 	if err := s.subRepo.UpdateFetchInterval(ctx, subscriptionID, minutes); err != nil {
 		return nil, fmt.Errorf("フェッチ間隔の更新に失敗しました: %w", err)
 	}
+	s.invalidateListCache(ctx, userID)
+	s.recordAudit(ctx, userID, model.AuditActionSubscriptionUpdateSettings, sub.FeedID, subscriptionID, map[string]any{
+		"fetch_interval_minutes":          minutes,
+		"previous_fetch_interval_minutes": sub.FetchIntervalMinutes,
+	})
 
 	// 更新後の購読情報を取得して返す
 	infos, err := s.subRepo.ListByUserIDWithFeedInfo(ctx, userID)
@@ -195,18 +286,8 @@ func (s *Service) UpdateSettings(ctx context.Context, userID, subscriptionID str
 
 	for _, info := range infos {
 		if info.ID == subscriptionID {
-			result := &SubscriptionInfo{
-				ID:                   info.ID,
-				UserID:               info.UserID,
-				FeedID:               info.FeedID,
-				FeedTitle:            info.FeedTitle,
-				FeedURL:              info.FeedURL,
-				FetchIntervalMinutes: info.FetchIntervalMinutes,
-				FeedStatus:           string(info.FetchStatus),
-				UnreadCount:          info.UnreadCount,
-				CreatedAt:            info.CreatedAt,
-			}
-			return result, nil
+			result := newSubscriptionInfo(info)
+			return &result, nil
 		}
 	}
 
@@ -215,7 +296,12 @@ func (s *Service) UpdateSettings(ctx context.Context, userID, subscriptionID str
 
 // Unsubscribe は購読を解除する。
 // subscription と関連 item_states を削除する。
+// WithUndo が設定されている場合は削除前にスナップショットを退避し、猶予期間内は Restore で取り消せる。
 func (s *Service) Unsubscribe(ctx context.Context, userID, subscriptionID string) error {
+	if s.undoRepo != nil {
+		return s.unsubscribeWithUndo(ctx, userID, subscriptionID)
+	}
+
 	sub, err := s.subRepo.FindByID(ctx, subscriptionID)
 	if err != nil {
 		return fmt.Errorf("購読の取得に失敗しました: %w", err)
@@ -227,6 +313,9 @@ func (s *Service) Unsubscribe(ctx context.Context, userID, subscriptionID string
 		return model.NewSubscriptionNotFoundError(subscriptionID)
 	}
 
+	// 途中で失敗しても一部が削除されている可能性があるため、先に無効化を予約する
+	defer s.invalidateListCache(ctx, userID)
+
 	// 関連item_statesを削除
 	if s.itemStateRepo != nil {
 		if err := s.itemStateRepo.DeleteByUserAndFeed(ctx, userID, sub.FeedID); err != nil {
@@ -238,11 +327,13 @@ func (s *Service) Unsubscribe(ctx context.Context, userID, subscriptionID string
 	if err := s.subRepo.Delete(ctx, subscriptionID); err != nil {
 		return fmt.Errorf("購読の削除に失敗しました: %w", err)
 	}
+	s.recordAudit(ctx, userID, model.AuditActionSubscriptionDelete, sub.FeedID, subscriptionID, nil)
 
 	return nil
 }
 
 // ResumeFetch は停止中フィードのフェッチを再開する。
+// ブロックリストが設定されている場合、フィード URL がブロック対象なら FEED_BLOCKED を返す。
 func (s *Service) ResumeFetch(ctx context.Context, userID, subscriptionID string) (*SubscriptionInfo, error) {
 	sub, err := s.subRepo.FindByID(ctx, subscriptionID)
 	if err != nil {
@@ -267,16 +358,25 @@ func (s *Service) ResumeFetch(ctx context.Context, userID, subscriptionID string
 	if feed.FetchStatus != model.FetchStatusStopped {
 		return nil, model.NewFeedNotStoppedError()
 	}
+	if s.blocklist != nil {
+		if err := s.blocklist.CheckURL(ctx, feed.FeedURL); err != nil {
+			return nil, err
+		}
+	}
 
 	// フェッチ状態をactiveに戻す
 	feed.FetchStatus = model.FetchStatusActive
 	feed.ErrorMessage = ""
+	feed.ErrorKind = model.FetchErrorKindNone
+	feed.ErrorDetail = nil
 	feed.ConsecutiveErrors = 0
 	feed.NextFetchAt = time.Now()
 
 	if err := s.feedRepo.UpdateFetchState(ctx, feed); err != nil {
 		return nil, fmt.Errorf("フィード状態の更新に失敗しました: %w", err)
 	}
+	s.invalidateListCache(ctx, userID)
+	s.recordAudit(ctx, userID, model.AuditActionSubscriptionResume, sub.FeedID, subscriptionID, nil)
 
 	// 更新後の購読情報を返す
 	infos, err := s.subRepo.ListByUserIDWithFeedInfo(ctx, userID)
@@ -286,18 +386,8 @@ func (s *Service) ResumeFetch(ctx context.Context, userID, subscriptionID string
 
 	for _, info := range infos {
 		if info.ID == subscriptionID {
-			result := &SubscriptionInfo{
-				ID:                   info.ID,
-				UserID:               info.UserID,
-				FeedID:               info.FeedID,
-				FeedTitle:            info.FeedTitle,
-				FeedURL:              info.FeedURL,
-				FetchIntervalMinutes: info.FetchIntervalMinutes,
-				FeedStatus:           string(info.FetchStatus),
-				UnreadCount:          info.UnreadCount,
-				CreatedAt:            info.CreatedAt,
-			}
-			return result, nil
+			result := newSubscriptionInfo(info)
+			return &result, nil
 		}
 	}
 
@@ -386,6 +476,8 @@ func (s *Service) ManualFetch(ctx context.Context, userID, subscriptionID string
 
 	// (6) fetcher.Fetch 実行
 	fetchErr := s.feedFetcher.Fetch(ctx, feed)
+	// 成否にかかわらずフィード状態・記事が更新され得るため無効化する
+	s.invalidateListCache(ctx, userID)
 	if fetchErr != nil {
 		reason := classifyFetchError(fetchErr)
 		s.metricsRecorder.RecordManualFetchFailure(reason)
@@ -444,26 +536,8 @@ func (s *Service) ManualFetch(ctx context.Context, userID, subscriptionID string
 	}
 	for _, info := range infos {
 		if info.ID == subscriptionID {
-			result := &SubscriptionInfo{
-				ID:                   info.ID,
-				UserID:               info.UserID,
-				FeedID:               info.FeedID,
-				FeedTitle:            info.FeedTitle,
-				FeedURL:              info.FeedURL,
-				FetchIntervalMinutes: info.FetchIntervalMinutes,
-				FeedStatus:           string(info.FetchStatus),
-				UnreadCount:          info.UnreadCount,
-				CreatedAt:            info.CreatedAt,
-			}
-			if len(info.FaviconData) > 0 && info.FaviconMime != "" {
-				dataURL := fmt.Sprintf("data:%s;base64,%s", info.FaviconMime, base64.StdEncoding.EncodeToString(info.FaviconData))
-				result.FaviconURL = &dataURL
-			}
-			if info.ErrorMessage != "" {
-				msg := info.ErrorMessage
-				result.ErrorMessage = &msg
-			}
-			return result, nil
+			result := newSubscriptionInfo(info)
+			return &result, nil
 		}
 	}
 
@@ -18,6 +18,7 @@ type mockSubRepo struct {
 	listByUserIDWithFeedFn func(ctx context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error)
 	updateFetchIntervalFn  func(ctx context.Context, id string, minutes int) error
 	deleteFn               func(ctx context.Context, id string) error
+	countByUserIDFn        func(ctx context.Context, userID string) (int, error)
 }
 
 func (m *mockSubRepo) FindByID(ctx context.Context, id string) (*model.Subscription, error) {
@@ -27,6 +28,9 @@ func (m *mockSubRepo) FindByUserAndFeed(ctx context.Context, userID, feedID stri
 	return nil, nil
 }
 func (m *mockSubRepo) CountByUserID(ctx context.Context, userID string) (int, error) {
+	if m.countByUserIDFn != nil {
+		return m.countByUserIDFn(ctx, userID)
+	}
 	return 0, nil
 }
 func (m *mockSubRepo) Create(ctx context.Context, sub *model.Subscription) error {
@@ -61,7 +65,7 @@ type mockItemStateRepo struct {
 func (m *mockItemStateRepo) FindByUserAndItem(ctx context.Context, userID, itemID string) (*model.ItemState, error) {
 	return nil, nil
 }
-func (m *mockItemStateRepo) Upsert(ctx context.Context, userID, itemID string, isRead *bool, isStarred *bool) (*model.ItemState, error) {
+func (m *mockItemStateRepo) Upsert(ctx context.Context, userID, itemID string, isRead, isStarred, isHidden *bool, rating *int) (*model.ItemState, error) {
 	return nil, nil
 }
 func (m *mockItemStateRepo) DeleteByUserAndFeed(ctx context.Context, userID, feedID string) error {
@@ -119,6 +123,10 @@ func (m *mockFeedRepo) UpdateLastSuccessfulFetchAt(ctx context.Context, feedID s
 	return nil
 }
 
+func (m *mockFeedRepo) ListBySiteHost(ctx context.Context, userID, host, excludeFeedID string, limit int) ([]*model.Feed, error) {
+	return nil, nil
+}
+
 type mockFeedFetcher struct {
 	fetchFn func(ctx context.Context, feed *model.Feed) error
 }
@@ -194,6 +202,7 @@ func (m *mockManualFetchMetricsRecorder) RecordManualFetchLockConflict() {
 // interface 充足のための no-op 実装。
 func (m *mockManualFetchMetricsRecorder) RecordFetchSuccess(_ string)        {}
 func (m *mockManualFetchMetricsRecorder) RecordFetchFailure(_, _ string)     {}
+func (m *mockManualFetchMetricsRecorder) RecordFetchErrorKind(_ string)      {}
 func (m *mockManualFetchMetricsRecorder) RecordParseFailure(_ string)        {}
 func (m *mockManualFetchMetricsRecorder) RecordHTTPStatus(_ int)             {}
 func (m *mockManualFetchMetricsRecorder) RecordFetchLatency(_ time.Duration) {}
@@ -241,6 +250,113 @@ func TestService_ListSubscriptions(t *testing.T) {
 	}
 }
 
+// TestService_ListSubscriptions_ErrorKind はフェッチ失敗の原因分類が購読情報に引き継がれることを検証する。
+func TestService_ListSubscriptions_ErrorKind(t *testing.T) {
+	subRepo := &mockSubRepo{
+		listByUserIDWithFeedFn: func(ctx context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error) {
+			return []repository.SubscriptionWithFeedInfo{
+				{
+					Subscription: model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1"},
+					FetchStatus:  model.FetchStatusActive,
+					ErrorMessage: "HTTPリクエスト失敗: i/o timeout",
+					ErrorKind:    model.FetchErrorKindTimeout,
+				},
+				{
+					Subscription: model.Subscription{ID: "sub-2", UserID: userID, FeedID: "feed-2"},
+					FetchStatus:  model.FetchStatusActive,
+				},
+			}, nil
+		},
+	}
+
+	svc := NewService(subRepo, nil, nil, nil, nil, nil)
+
+	// Act
+	results, err := svc.ListSubscriptions(context.Background(), "user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListSubscriptions returned error: %v", err)
+	}
+	if results[0].ErrorKind == nil || *results[0].ErrorKind != "timeout" {
+		t.Errorf("ErrorKind = %v, want %q", results[0].ErrorKind, "timeout")
+	}
+	if results[1].ErrorKind != nil {
+		t.Errorf("エラーがない購読の ErrorKind は nil であるべき: %q", *results[1].ErrorKind)
+	}
+}
+
+// TestService_ListSubscriptions_TooManyUnread は積読警告フラグが購読情報に引き継がれることを検証する。
+func TestService_ListSubscriptions_TooManyUnread(t *testing.T) {
+	subRepo := &mockSubRepo{
+		listByUserIDWithFeedFn: func(ctx context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error) {
+			return []repository.SubscriptionWithFeedInfo{
+				{
+					Subscription:  model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1"},
+					FetchStatus:   model.FetchStatusActive,
+					UnreadCount:   501,
+					TooManyUnread: true,
+				},
+				{
+					Subscription: model.Subscription{ID: "sub-2", UserID: userID, FeedID: "feed-2"},
+					FetchStatus:  model.FetchStatusActive,
+					UnreadCount:  3,
+				},
+			}, nil
+		},
+	}
+
+	svc := NewService(subRepo, nil, nil, nil, nil, nil)
+
+	// Act
+	results, err := svc.ListSubscriptions(context.Background(), "user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListSubscriptions returned error: %v", err)
+	}
+	if !results[0].TooManyUnread {
+		t.Error("未読数が閾値を超えた購読の TooManyUnread は true であるべき")
+	}
+	if results[1].TooManyUnread {
+		t.Error("未読数が閾値以下の購読の TooManyUnread は false であるべき")
+	}
+}
+
+// TestService_ListSubscriptions_FeedMetadata はフィードの言語・説明文・最終投稿日時が購読情報に引き継がれることを検証する。
+func TestService_ListSubscriptions_FeedMetadata(t *testing.T) {
+	lastPublishedAt := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	subRepo := &mockSubRepo{
+		listByUserIDWithFeedFn: func(ctx context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error) {
+			return []repository.SubscriptionWithFeedInfo{
+				{
+					Subscription:        model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1"},
+					FetchStatus:         model.FetchStatusActive,
+					FeedLanguage:        "ja",
+					FeedDescription:     "技術ブログ",
+					FeedLastPublishedAt: &lastPublishedAt,
+				},
+			}, nil
+		},
+	}
+
+	svc := NewService(subRepo, nil, nil, nil, nil, nil)
+
+	// Act
+	results, err := svc.ListSubscriptions(context.Background(), "user-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ListSubscriptions returned error: %v", err)
+	}
+	if results[0].FeedLanguage != "ja" || results[0].FeedDescription != "技術ブログ" {
+		t.Errorf("FeedLanguage/FeedDescription = %q/%q, want %q/%q", results[0].FeedLanguage, results[0].FeedDescription, "ja", "技術ブログ")
+	}
+	if results[0].FeedLastPublishedAt == nil || !results[0].FeedLastPublishedAt.Equal(lastPublishedAt) {
+		t.Errorf("FeedLastPublishedAt = %v, want %v", results[0].FeedLastPublishedAt, lastPublishedAt)
+	}
+}
+
 // TestService_UpdateSettings_BoundaryValues はフェッチ間隔の境界値バリデーションを検証する。
 // 要件 1.1-1.10 / 2.1 / 2.4 / 3.1 / NFR 1.1 / NFR 2.1 に対応する。
 func TestService_UpdateSettings_BoundaryValues(t *testing.T) {
@@ -397,10 +513,11 @@ func TestService_ResumeFetch(t *testing.T) {
 		listByUserIDWithFeedFn: func(ctx context.Context, userID string) ([]repository.SubscriptionWithFeedInfo, error) {
 			return []repository.SubscriptionWithFeedInfo{
 				{
-					Subscription: model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1", FetchIntervalMinutes: 60},
-					FeedTitle:    "Test Feed",
-					FeedURL:      "https://example.com/feed.xml",
-					FetchStatus:  model.FetchStatusActive,
+					Subscription:     model.Subscription{ID: "sub-1", UserID: userID, FeedID: "feed-1", FetchIntervalMinutes: 60},
+					FeedTitle:        "Test Feed",
+					FeedURL:          "https://example.com/feed.xml",
+					FetchStatus:      model.FetchStatusActive,
+					PendingFeedTitle: "Renamed Feed",
 				},
 			}, nil
 		},
@@ -410,12 +527,16 @@ func TestService_ResumeFetch(t *testing.T) {
 			return &model.Feed{
 				ID:          "feed-1",
 				FetchStatus: model.FetchStatusStopped,
+				ErrorKind:   model.FetchErrorKindHTTP4xx,
 			}, nil
 		},
 		updateFetchStateFn: func(ctx context.Context, feed *model.Feed) error {
 			if feed.FetchStatus != model.FetchStatusActive {
 				t.Errorf("expected FetchStatus = active, got %s", feed.FetchStatus)
 			}
+			if feed.ErrorKind != model.FetchErrorKindNone {
+				t.Errorf("expected ErrorKind to be cleared, got %s", feed.ErrorKind)
+			}
 			return nil
 		},
 	}
@@ -432,6 +553,9 @@ func TestService_ResumeFetch(t *testing.T) {
 	if result.FeedTitle != "Test Feed" {
 		t.Errorf("FeedTitle = %q, want %q", result.FeedTitle, "Test Feed")
 	}
+	if result.PendingFeedTitle == nil || *result.PendingFeedTitle != "Renamed Feed" {
+		t.Errorf("PendingFeedTitle = %v, want Renamed Feed", result.PendingFeedTitle)
+	}
 }
 
 // TestService_ResumeFetch_NotStopped_ReturnsError はアクティブなフィードの再開がエラーになることを検証する。
@@ -458,6 +582,55 @@ func TestService_ResumeFetch_NotStopped_ReturnsError(t *testing.T) {
 	}
 }
 
+// stubBlocklist は BlocklistChecker のスタブ。err をそのまま返す。
+type stubBlocklist struct {
+	err error
+}
+
+func (s stubBlocklist) CheckURL(_ context.Context, _ string) error {
+	return s.err
+}
+
+// TestService_ResumeFetch_Blocked_ReturnsError はブロックリストに一致するフィードの再開が
+// フェッチ状態を更新せずに FEED_BLOCKED になることを検証する。
+func TestService_ResumeFetch_Blocked_ReturnsError(t *testing.T) {
+	// Arrange
+	subRepo := &mockSubRepo{
+		findByIDFn: func(ctx context.Context, id string) (*model.Subscription, error) {
+			return &model.Subscription{ID: "sub-1", UserID: "user-1", FeedID: "feed-1"}, nil
+		},
+	}
+	updated := false
+	feedRepo := &mockFeedRepo{
+		findByIDFn: func(ctx context.Context, id string) (*model.Feed, error) {
+			return &model.Feed{
+				ID:          "feed-1",
+				FeedURL:     "https://spam.example/feed.xml",
+				FetchStatus: model.FetchStatusStopped,
+				ErrorKind:   model.FetchErrorKindBlocked,
+			}, nil
+		},
+		updateFetchStateFn: func(ctx context.Context, feed *model.Feed) error {
+			updated = true
+			return nil
+		},
+	}
+	svc := NewService(subRepo, nil, feedRepo, nil, nil, nil,
+		WithBlocklist(stubBlocklist{err: model.NewFeedBlockedError("https://spam.example/feed.xml")}))
+
+	// Act
+	_, err := svc.ResumeFetch(context.Background(), "user-1", "sub-1")
+
+	// Assert
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeFeedBlocked {
+		t.Fatalf("err = %v, want %s", err, model.ErrCodeFeedBlocked)
+	}
+	if updated {
+		t.Error("ブロック中のフィードのフェッチ状態は更新されるべきではない")
+	}
+}
+
 // TestService_Unsubscribe_NilItemStateRepo_SkipsItemStateDelete は
 // 記事状態リポジトリが未設定（nil）のとき、記事状態削除をスキップしたうえで
 // 購読削除が正常に完了することを検証する（要件 3.1）。
@@ -598,10 +771,12 @@ func TestService_UpdateSettings_Success(t *testing.T) {
 						FetchIntervalMinutes: wantMinutes,
 						CreatedAt:            now,
 					},
-					FeedTitle:   "Test Feed",
-					FeedURL:     "https://example.com/feed.xml",
-					FetchStatus: model.FetchStatusActive,
-					UnreadCount: 3,
+					FeedTitle:             "Test Feed",
+					FeedURL:               "https://example.com/feed.xml",
+					FetchStatus:           model.FetchStatusActive,
+					UnreadCount:           3,
+					FeedTitleUpdatePolicy: model.TitleUpdatePolicyManual,
+					PendingFeedTitle:      "Renamed Feed",
 				},
 			}, nil
 		},
@@ -634,6 +809,9 @@ func TestService_UpdateSettings_Success(t *testing.T) {
 	if result.UnreadCount != 3 {
 		t.Errorf("UnreadCount = %d, want %d", result.UnreadCount, 3)
 	}
+	if result.PendingFeedTitle == nil || *result.PendingFeedTitle != "Renamed Feed" {
+		t.Errorf("PendingFeedTitle = %v, want Renamed Feed", result.PendingFeedTitle)
+	}
 }
 
 // TestService_UpdateSettings_WrongUser_ReturnsSubscriptionNotFound は
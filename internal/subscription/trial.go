@@ -0,0 +1,50 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// errExpiryNotConfigured はお試し購読の期限リポジトリ未設定のまま KeepSubscription が呼ばれたことを表す。
+var errExpiryNotConfigured = errors.New("subscription expiry repository is not configured")
+
+// WithExpiry はお試し購読を通常の購読に切り替える操作（KeepSubscription）を有効にする。
+// 未設定時の KeepSubscription はエラーを返す。
+func WithExpiry(repo repository.SubscriptionExpiryRepository) ServiceOption {
+	return func(s *Service) {
+		s.expiryRepo = repo
+	}
+}
+
+// KeepSubscription はお試し購読の期限を外して通常の購読にし、更新後の購読情報を返す。
+// 既に通常の購読の場合は何も変えずに現在の購読情報を返す。
+// 存在しない（他ユーザーのものを含む）購読の場合は SUBSCRIPTION_NOT_FOUND を返す。
+func (s *Service) KeepSubscription(ctx context.Context, userID, subscriptionID string) (*SubscriptionInfo, error) {
+	if s.expiryRepo == nil {
+		return nil, errExpiryNotConfigured
+	}
+
+	found, err := s.expiryRepo.ClearSubscriptionExpiry(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("お試し購読の期限の解除に失敗しました: %w", err)
+	}
+	if !found {
+		return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	s.invalidateListCache(ctx, userID)
+
+	infos, err := s.loadSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range infos {
+		if infos[i].ID == subscriptionID {
+			return &infos[i], nil
+		}
+	}
+	return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+}
@@ -0,0 +1,95 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/worker/periodic"
+)
+
+const (
+	// DefaultTrialExpiryInterval はお試し購読の期限切れスキャンの間隔の既定値。
+	DefaultTrialExpiryInterval = 10 * time.Minute
+	// trialExpiryBatchSize は 1 回の取得で処理する期限切れ購読の最大件数。
+	trialExpiryBatchSize = 100
+)
+
+// Unsubscriber は購読解除を行うインターフェース。Service が実装する。
+type Unsubscriber interface {
+	Unsubscribe(ctx context.Context, userID, subscriptionID string) error
+}
+
+// TrialExpiryJob は期限を過ぎたお試し購読を自動で解除する worker ジョブ。
+// 解除は通常の購読解除と同じ経路で行うため、解除の取り消し（Restore）が有効な場合は
+// 猶予期間内であれば利用者が元に戻せる（戻した購読は通常の購読になる）。
+type TrialExpiryJob struct {
+	repo         repository.SubscriptionExpiryRepository
+	unsubscriber Unsubscriber
+	logger       *slog.Logger
+	interval     time.Duration
+	now          func() time.Time
+}
+
+// NewTrialExpiryJob は TrialExpiryJob を生成する。interval が 0 以下の場合は DefaultTrialExpiryInterval を使う。
+func NewTrialExpiryJob(repo repository.SubscriptionExpiryRepository, unsubscriber Unsubscriber, logger *slog.Logger, interval time.Duration) *TrialExpiryJob {
+	if interval <= 0 {
+		interval = DefaultTrialExpiryInterval
+	}
+	return &TrialExpiryJob{repo: repo, unsubscriber: unsubscriber, logger: logger, interval: interval, now: time.Now}
+}
+
+// RunOnce は期限切れのお試し購読をすべて解除する。
+// 個別の解除に失敗した購読はログに残して次回のスキャンで再試行する。
+// 失敗した購読が取得結果に残り続けないよう、失敗を含むバッチの後は続きを次回に回す。
+func (j *TrialExpiryJob) RunOnce(ctx context.Context) error {
+	now := j.now()
+	var expired, failed int
+	for {
+		subs, err := j.repo.ListExpiredSubscriptions(ctx, now, trialExpiryBatchSize)
+		if err != nil {
+			return fmt.Errorf("期限切れのお試し購読の取得に失敗: %w", err)
+		}
+
+		batchFailed := 0
+		for _, sub := range subs {
+			if err := j.unsubscriber.Unsubscribe(ctx, sub.UserID, sub.ID); err != nil {
+				// 取得後に利用者が解除済みの場合は対象外
+				var apiErr *model.APIError
+				if errors.As(err, &apiErr) && apiErr.Code == model.ErrCodeSubscriptionNotFound {
+					continue
+				}
+				batchFailed++
+				j.logger.Warn("お試し購読の自動解除に失敗しました",
+					slog.String("subscription_id", sub.ID),
+					slog.String("user_id", sub.UserID),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			expired++
+		}
+		failed += batchFailed
+
+		if len(subs) < trialExpiryBatchSize || batchFailed > 0 {
+			break
+		}
+	}
+
+	if expired > 0 || failed > 0 {
+		j.logger.Info("期限切れのお試し購読を解除しました",
+			slog.Int("expired", expired),
+			slog.Int("failed", failed),
+		)
+	}
+	return nil
+}
+
+// Start は起動直後と interval ごとに RunOnce を実行する。ctx がキャンセルされるまでブロックする。
+func (j *TrialExpiryJob) Start(ctx context.Context) {
+	periodic.Run(ctx, j.logger, "お試し購読の期限切れ解除", j.interval, j.RunOnce)
+}
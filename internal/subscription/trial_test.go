@@ -0,0 +1,287 @@
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockExpiryRepo は repository.SubscriptionExpiryRepository のモック実装。
+type mockExpiryRepo struct {
+	listExpiredFn func(ctx context.Context, now time.Time, limit int) ([]*model.Subscription, error)
+	clearFn       func(ctx context.Context, userID, subscriptionID string) (bool, error)
+	listCalls     int
+}
+
+func (m *mockExpiryRepo) ListExpiredSubscriptions(ctx context.Context, now time.Time, limit int) ([]*model.Subscription, error) {
+	m.listCalls++
+	if m.listExpiredFn != nil {
+		return m.listExpiredFn(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockExpiryRepo) ClearSubscriptionExpiry(ctx context.Context, userID, subscriptionID string) (bool, error) {
+	if m.clearFn != nil {
+		return m.clearFn(ctx, userID, subscriptionID)
+	}
+	return true, nil
+}
+
+var _ repository.SubscriptionExpiryRepository = (*mockExpiryRepo)(nil)
+
+// mockUnsubscriber は Unsubscriber のモック実装。
+type mockUnsubscriber struct {
+	unsubscribeFn func(ctx context.Context, userID, subscriptionID string) error
+	calls         []string
+}
+
+func (m *mockUnsubscriber) Unsubscribe(ctx context.Context, userID, subscriptionID string) error {
+	m.calls = append(m.calls, subscriptionID)
+	if m.unsubscribeFn != nil {
+		return m.unsubscribeFn(ctx, userID, subscriptionID)
+	}
+	return nil
+}
+
+func newTrialTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+}
+
+func TestService_KeepSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("自分の購読のとき期限を外しキャッシュを無効化して更新後の購読を返す", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		var gotUserID, gotSubID string
+		expiryRepo := &mockExpiryRepo{
+			clearFn: func(_ context.Context, userID, subscriptionID string) (bool, error) {
+				gotUserID, gotSubID = userID, subscriptionID
+				return true, nil
+			},
+		}
+		svc := NewService(newCountingSubRepo(&calls), nil, nil, nil, nil, nil,
+			WithListCache(cache.NewMemory[[]SubscriptionInfo](30*time.Second)),
+			WithExpiry(expiryRepo))
+		_, _ = svc.ListSubscriptions(ctx, "user-1")
+
+		// Act
+		info, err := svc.KeepSubscription(ctx, "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("KeepSubscription returned error: %v", err)
+		}
+		if gotUserID != "user-1" || gotSubID != "sub-1" {
+			t.Errorf("args = (%q, %q), want (user-1, sub-1)", gotUserID, gotSubID)
+		}
+		if info.ID != "sub-1" {
+			t.Errorf("info.ID = %q, want sub-1", info.ID)
+		}
+		// キャッシュ済みの一覧ではなくリポジトリから再取得していること
+		if calls != 2 {
+			t.Errorf("repository calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("購読が見つからないときSUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		expiryRepo := &mockExpiryRepo{
+			clearFn: func(context.Context, string, string) (bool, error) { return false, nil },
+		}
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil, WithExpiry(expiryRepo))
+
+		// Act
+		_, err := svc.KeepSubscription(ctx, "user-1", "sub-x")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeSubscriptionNotFound {
+			t.Errorf("error = %v, want SUBSCRIPTION_NOT_FOUND", err)
+		}
+	})
+
+	t.Run("期限リポジトリが未設定のときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil)
+
+		// Act
+		_, err := svc.KeepSubscription(ctx, "user-1", "sub-1")
+
+		// Assert
+		if !errors.Is(err, errExpiryNotConfigured) {
+			t.Errorf("error = %v, want errExpiryNotConfigured", err)
+		}
+	})
+}
+
+func TestTrialExpiryJob_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 6, 15, 9, 0, 0, 0, time.UTC)
+
+	t.Run("期限切れのお試し購読を所有者として解除する", func(t *testing.T) {
+		// Arrange
+		var gotNow time.Time
+		repo := &mockExpiryRepo{
+			listExpiredFn: func(_ context.Context, at time.Time, _ int) ([]*model.Subscription, error) {
+				gotNow = at
+				return []*model.Subscription{
+					{ID: "sub-1", UserID: "user-1"},
+					{ID: "sub-2", UserID: "user-2"},
+				}, nil
+			},
+		}
+		var gotUsers []string
+		unsub := &mockUnsubscriber{
+			unsubscribeFn: func(_ context.Context, userID, _ string) error {
+				gotUsers = append(gotUsers, userID)
+				return nil
+			},
+		}
+		job := NewTrialExpiryJob(repo, unsub, newTrialTestLogger(), 0)
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if !gotNow.Equal(now) {
+			t.Errorf("基準時刻 = %v, want %v", gotNow, now)
+		}
+		if len(unsub.calls) != 2 || unsub.calls[0] != "sub-1" || unsub.calls[1] != "sub-2" {
+			t.Errorf("unsubscribe calls = %v, want [sub-1 sub-2]", unsub.calls)
+		}
+		if len(gotUsers) != 2 || gotUsers[0] != "user-1" || gotUsers[1] != "user-2" {
+			t.Errorf("users = %v, want [user-1 user-2]", gotUsers)
+		}
+	})
+
+	t.Run("1バッチ分の件数があるとき続きを取得して解除する", func(t *testing.T) {
+		// Arrange
+		repo := &mockExpiryRepo{}
+		repo.listExpiredFn = func(_ context.Context, _ time.Time, limit int) ([]*model.Subscription, error) {
+			if repo.listCalls > 1 {
+				return []*model.Subscription{{ID: "sub-last", UserID: "user-1"}}, nil
+			}
+			subs := make([]*model.Subscription, limit)
+			for i := range subs {
+				subs[i] = &model.Subscription{ID: "sub", UserID: "user-1"}
+			}
+			return subs, nil
+		}
+		unsub := &mockUnsubscriber{}
+		job := NewTrialExpiryJob(repo, unsub, newTrialTestLogger(), 0)
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if repo.listCalls != 2 {
+			t.Errorf("list calls = %d, want 2", repo.listCalls)
+		}
+		if len(unsub.calls) != trialExpiryBatchSize+1 {
+			t.Errorf("unsubscribe calls = %d, want %d", len(unsub.calls), trialExpiryBatchSize+1)
+		}
+	})
+
+	t.Run("解除に失敗した購読があっても残りを解除し続きは次回に回す", func(t *testing.T) {
+		// Arrange
+		repo := &mockExpiryRepo{
+			listExpiredFn: func(_ context.Context, _ time.Time, limit int) ([]*model.Subscription, error) {
+				subs := make([]*model.Subscription, limit)
+				for i := range subs {
+					subs[i] = &model.Subscription{ID: "sub", UserID: "user-1"}
+				}
+				subs[0].ID = "sub-fail"
+				return subs, nil
+			},
+		}
+		unsub := &mockUnsubscriber{
+			unsubscribeFn: func(_ context.Context, _, subscriptionID string) error {
+				if subscriptionID == "sub-fail" {
+					return errors.New("db error")
+				}
+				return nil
+			},
+		}
+		job := NewTrialExpiryJob(repo, unsub, newTrialTestLogger(), 0)
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if repo.listCalls != 1 {
+			t.Errorf("list calls = %d, want 1", repo.listCalls)
+		}
+		if len(unsub.calls) != trialExpiryBatchSize {
+			t.Errorf("unsubscribe calls = %d, want %d", len(unsub.calls), trialExpiryBatchSize)
+		}
+	})
+
+	t.Run("取得後に解除済みの購読は失敗として扱わない", func(t *testing.T) {
+		// Arrange
+		repo := &mockExpiryRepo{}
+		repo.listExpiredFn = func(_ context.Context, _ time.Time, limit int) ([]*model.Subscription, error) {
+			if repo.listCalls > 1 {
+				return nil, nil
+			}
+			subs := make([]*model.Subscription, limit)
+			for i := range subs {
+				subs[i] = &model.Subscription{ID: "sub", UserID: "user-1"}
+			}
+			return subs, nil
+		}
+		unsub := &mockUnsubscriber{
+			unsubscribeFn: func(_ context.Context, _, subscriptionID string) error {
+				return model.NewSubscriptionNotFoundError(subscriptionID)
+			},
+		}
+		job := NewTrialExpiryJob(repo, unsub, newTrialTestLogger(), 0)
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if repo.listCalls != 2 {
+			t.Errorf("list calls = %d, want 2（解除済みは失敗扱いせず続きを取得する）", repo.listCalls)
+		}
+	})
+
+	t.Run("取得に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockExpiryRepo{
+			listExpiredFn: func(context.Context, time.Time, int) ([]*model.Subscription, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		job := NewTrialExpiryJob(repo, &mockUnsubscriber{}, newTrialTestLogger(), 0)
+
+		// Act
+		err := job.RunOnce(ctx)
+
+		// Assert
+		if err == nil {
+			t.Fatal("RunOnce() should return error")
+		}
+	})
+}
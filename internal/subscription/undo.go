@@ -0,0 +1,97 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// DefaultUndoWindow は購読解除を取り消せる猶予期間の既定値。
+const DefaultUndoWindow = 2 * time.Minute
+
+// WithUndo は購読解除を window の間だけ取り消し可能にする。
+// 設定時の Unsubscribe は購読と記事状態のスナップショットを repo に退避してから削除し、
+// Restore で同じ購読 ID・記事状態のまま元に戻せる。window が 0 以下の場合は DefaultUndoWindow を使う。
+// 未設定時の Unsubscribe は従来どおり即時に削除し、Restore は常に期限切れを返す。
+func WithUndo(repo repository.SubscriptionUndoRepository, window time.Duration) ServiceOption {
+	return func(s *Service) {
+		if window <= 0 {
+			window = DefaultUndoWindow
+		}
+		s.undoRepo = repo
+		s.undoWindow = window
+	}
+}
+
+// unsubscribeWithUndo はスナップショットを退避した上で購読と記事状態を削除する。
+func (s *Service) unsubscribeWithUndo(ctx context.Context, userID, subscriptionID string) error {
+	// 監査ログの target（フィードID）は削除後には引けないため、記録する場合のみ先に取得しておく
+	var feedID string
+	if s.auditRecorder != nil {
+		sub, err := s.subRepo.FindByID(ctx, subscriptionID)
+		if err != nil {
+			return fmt.Errorf("購読の取得に失敗しました: %w", err)
+		}
+		if sub != nil && sub.UserID == userID {
+			feedID = sub.FeedID
+		}
+	}
+
+	now := s.now()
+	deleted, err := s.undoRepo.DeleteWithUndo(ctx, userID, subscriptionID, now, now.Add(s.undoWindow))
+	if err != nil {
+		return fmt.Errorf("購読の削除に失敗しました: %w", err)
+	}
+	if !deleted {
+		return model.NewSubscriptionNotFoundError(subscriptionID)
+	}
+	s.invalidateListCache(ctx, userID)
+	s.recordAudit(ctx, userID, model.AuditActionSubscriptionDelete, feedID, subscriptionID, nil)
+	return nil
+}
+
+// Restore は猶予期間内に解除された購読を記事状態ごと元に戻し、復元後の購読情報を返す。
+// 猶予期間を過ぎた、または取り消し対象の解除が無い場合は SUBSCRIPTION_RESTORE_EXPIRED を返す。
+// 解除後に同じフィードを再購読している場合は DUPLICATE_SUBSCRIPTION、
+// 購読上限に達している場合は SUBSCRIPTION_LIMIT を返す。
+func (s *Service) Restore(ctx context.Context, userID, subscriptionID string) (*SubscriptionInfo, error) {
+	if s.undoRepo == nil {
+		return nil, model.NewSubscriptionRestoreExpiredError()
+	}
+
+	count, err := s.subRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("購読数の確認に失敗しました: %w", err)
+	}
+	if count >= model.MaxSubscriptionsPerUser {
+		return nil, model.NewSubscriptionLimitError()
+	}
+
+	if err := s.undoRepo.Restore(ctx, userID, subscriptionID, s.now()); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSubscriptionUndoNotFound):
+			return nil, model.NewSubscriptionRestoreExpiredError()
+		case errors.Is(err, repository.ErrSubscriptionAlreadyExists):
+			return nil, model.NewDuplicateSubscriptionError()
+		default:
+			return nil, fmt.Errorf("購読の復元に失敗しました: %w", err)
+		}
+	}
+	s.invalidateListCache(ctx, userID)
+
+	infos, err := s.loadSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range infos {
+		if infos[i].ID == subscriptionID {
+			s.recordAudit(ctx, userID, model.AuditActionSubscriptionRestore, infos[i].FeedID, subscriptionID, nil)
+			return &infos[i], nil
+		}
+	}
+	return nil, model.NewSubscriptionNotFoundError(subscriptionID)
+}
@@ -0,0 +1,183 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockUndoRepo は repository.SubscriptionUndoRepository のモック実装。
+type mockUndoRepo struct {
+	deleteWithUndoFn func(ctx context.Context, userID, subscriptionID string, now, expiresAt time.Time) (bool, error)
+	restoreFn        func(ctx context.Context, userID, subscriptionID string, now time.Time) error
+	restoreCalls     int
+}
+
+func (m *mockUndoRepo) DeleteWithUndo(ctx context.Context, userID, subscriptionID string, now, expiresAt time.Time) (bool, error) {
+	if m.deleteWithUndoFn != nil {
+		return m.deleteWithUndoFn(ctx, userID, subscriptionID, now, expiresAt)
+	}
+	return true, nil
+}
+
+func (m *mockUndoRepo) Restore(ctx context.Context, userID, subscriptionID string, now time.Time) error {
+	m.restoreCalls++
+	if m.restoreFn != nil {
+		return m.restoreFn(ctx, userID, subscriptionID, now)
+	}
+	return nil
+}
+
+func TestService_Unsubscribe_WithUndo(t *testing.T) {
+	t.Run("設定時はスナップショット付きで削除し猶予期間を期限として渡す", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 6, 8, 12, 0, 0, 0, time.UTC)
+		var gotExpiresAt time.Time
+		undoRepo := &mockUndoRepo{
+			deleteWithUndoFn: func(_ context.Context, userID, subscriptionID string, gotNow, expiresAt time.Time) (bool, error) {
+				if userID != "user-1" || subscriptionID != "sub-1" || !gotNow.Equal(now) {
+					t.Errorf("args = (%q, %q, %v)", userID, subscriptionID, gotNow)
+				}
+				gotExpiresAt = expiresAt
+				return true, nil
+			},
+		}
+		subRepo := &mockSubRepo{
+			deleteFn: func(context.Context, string) error {
+				t.Error("従来の Delete は呼ばれるべきでない")
+				return nil
+			},
+		}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil, WithUndo(undoRepo, 30*time.Second))
+		svc.now = func() time.Time { return now }
+
+		// Act
+		err := svc.Unsubscribe(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Unsubscribe returned error: %v", err)
+		}
+		if want := now.Add(30 * time.Second); !gotExpiresAt.Equal(want) {
+			t.Errorf("expiresAt = %v, want %v", gotExpiresAt, want)
+		}
+	})
+
+	t.Run("対象購読が無いとき404 SUBSCRIPTION_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		undoRepo := &mockUndoRepo{
+			deleteWithUndoFn: func(context.Context, string, string, time.Time, time.Time) (bool, error) {
+				return false, nil
+			},
+		}
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil, WithUndo(undoRepo, 0))
+
+		// Act
+		err := svc.Unsubscribe(context.Background(), "user-1", "sub-x")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeSubscriptionNotFound {
+			t.Errorf("err = %v, want SUBSCRIPTION_NOT_FOUND", err)
+		}
+	})
+}
+
+func TestService_Restore(t *testing.T) {
+	restoredRows := func(context.Context, string) ([]repository.SubscriptionWithFeedInfo, error) {
+		return []repository.SubscriptionWithFeedInfo{
+			{
+				Subscription: model.Subscription{ID: "sub-1", UserID: "user-1", FeedID: "feed-1", FetchIntervalMinutes: 60},
+				FeedTitle:    "Restored",
+				UnreadCount:  3,
+			},
+		}, nil
+	}
+
+	t.Run("期限内のとき復元して購読情報を返す", func(t *testing.T) {
+		// Arrange
+		undoRepo := &mockUndoRepo{}
+		subRepo := &mockSubRepo{listByUserIDWithFeedFn: restoredRows}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil, WithUndo(undoRepo, time.Minute))
+
+		// Act
+		info, err := svc.Restore(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Restore returned error: %v", err)
+		}
+		if info.ID != "sub-1" || info.FeedTitle != "Restored" || info.UnreadCount != 3 {
+			t.Errorf("info = %+v", info)
+		}
+		if undoRepo.restoreCalls != 1 {
+			t.Errorf("Restore calls = %d, want 1", undoRepo.restoreCalls)
+		}
+	})
+
+	t.Run("リポジトリのエラーをAPIエラーに変換する", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			repoErr  error
+			wantCode string
+		}{
+			{"期限切れ", repository.ErrSubscriptionUndoNotFound, model.ErrCodeSubscriptionRestoreExpired},
+			{"再購読済み", repository.ErrSubscriptionAlreadyExists, model.ErrCodeDuplicateSubscription},
+		}
+		for _, tc := range cases {
+			// Arrange
+			undoRepo := &mockUndoRepo{
+				restoreFn: func(context.Context, string, string, time.Time) error { return tc.repoErr },
+			}
+			svc := NewService(&mockSubRepo{listByUserIDWithFeedFn: restoredRows}, nil, nil, nil, nil, nil, WithUndo(undoRepo, time.Minute))
+
+			// Act
+			_, err := svc.Restore(context.Background(), "user-1", "sub-1")
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tc.wantCode {
+				t.Errorf("%s: err = %v, want %s", tc.name, err, tc.wantCode)
+			}
+		}
+	})
+
+	t.Run("購読上限に達しているとき復元せずSUBSCRIPTION_LIMITを返す", func(t *testing.T) {
+		// Arrange
+		undoRepo := &mockUndoRepo{}
+		subRepo := &mockSubRepo{
+			countByUserIDFn: func(context.Context, string) (int, error) { return model.MaxSubscriptionsPerUser, nil },
+		}
+		svc := NewService(subRepo, nil, nil, nil, nil, nil, WithUndo(undoRepo, time.Minute))
+
+		// Act
+		_, err := svc.Restore(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeSubscriptionLimit {
+			t.Errorf("err = %v, want SUBSCRIPTION_LIMIT", err)
+		}
+		if undoRepo.restoreCalls != 0 {
+			t.Errorf("Restore calls = %d, want 0", undoRepo.restoreCalls)
+		}
+	})
+
+	t.Run("WithUndo未設定のときSUBSCRIPTION_RESTORE_EXPIREDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockSubRepo{}, nil, nil, nil, nil, nil)
+
+		// Act
+		_, err := svc.Restore(context.Background(), "user-1", "sub-1")
+
+		// Assert
+		var apiErr *model.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeSubscriptionRestoreExpired {
+			t.Errorf("err = %v, want SUBSCRIPTION_RESTORE_EXPIRED", err)
+		}
+	})
+}
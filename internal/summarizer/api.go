@@ -0,0 +1,130 @@
+package summarizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxInputRunes は要約 API に送る本文の最大文字数（rune 数）。長文記事のトークン消費を抑えるため先頭のみを送る。
+	maxInputRunes = 8000
+	// maxOutputTokens は要約 API に要求する応答の最大トークン数。
+	maxOutputTokens = 400
+	// maxAPIResponseBodySize はレスポンスボディの読み込み上限サイズ（1 MiB）。
+	maxAPIResponseBodySize = 1 * 1024 * 1024
+	// maxErrorBodyLength はエラーに含めるレスポンスボディの最大バイト数。
+	maxErrorBodyLength = 200
+)
+
+// systemPrompt は要約 API に渡す指示。記事の言語のまま短く要約させる。
+const systemPrompt = "あなたはニュースリーダーの要約アシスタントです。与えられた記事を、記事と同じ言語で 3 文以内・200 文字程度に要約してください。前置きや箇条書きは付けず、要約本文のみを出力してください。"
+
+// APISummarizer は OpenAI 互換の Chat Completions API を呼び出して要約を生成する要約器。
+type APISummarizer struct {
+	httpClient *http.Client
+	endpoint   string
+	apiKey     string
+	model      string
+}
+
+// NewAPISummarizer は APISummarizer を生成する。
+// endpoint は Chat Completions API の URL（例: https://api.openai.com/v1/chat/completions）、
+// apiKey は Authorization: Bearer に載せる API キー（空の場合は送らない）。
+// タイムアウトは httpClient に設定する。
+func NewAPISummarizer(httpClient *http.Client, endpoint, apiKey, model string) *APISummarizer {
+	return &APISummarizer{
+		httpClient: httpClient,
+		endpoint:   endpoint,
+		apiKey:     apiKey,
+		model:      model,
+	}
+}
+
+// chatMessage は Chat Completions API のメッセージ 1 件。
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest は Chat Completions API のリクエストボディ。
+type chatRequest struct {
+	Model     string        `json:"model"`
+	Messages  []chatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens"`
+}
+
+// chatResponse は Chat Completions API のレスポンスのうち要約に必要な部分。
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Summarize は要約 API を呼び出して要約を返す。
+// 2xx 以外の応答、応答の解釈の失敗、空の要約はエラーとして返す（フォールバックの判断は呼び出し元が行う）。
+func (s *APISummarizer) Summarize(ctx context.Context, doc Document) (string, error) {
+	text := strings.TrimSpace(doc.Text)
+	if text == "" {
+		return "", ErrEmptyText
+	}
+	if runes := []rune(text); len(runes) > maxInputRunes {
+		text = string(runes[:maxInputRunes])
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model: s.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: "タイトル: " + doc.Title + "\n\n" + text},
+		},
+		MaxTokens: maxOutputTokens,
+	})
+	if err != nil {
+		return "", fmt.Errorf("要約 API のリクエストの生成に失敗しました: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("要約 API のリクエストの作成に失敗しました: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Feedman/1.0 RSS Reader")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("要約 API の呼び出しに失敗しました: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxAPIResponseBodySize))
+	if err != nil {
+		return "", fmt.Errorf("要約 API のレスポンスの読み取りに失敗しました: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if len(respBody) > maxErrorBodyLength {
+			respBody = respBody[:maxErrorBodyLength]
+		}
+		return "", fmt.Errorf("要約 API がエラーを返しました: status=%d body=%s", resp.StatusCode, respBody)
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return "", fmt.Errorf("要約 API のレスポンスの解析に失敗しました: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("要約 API のレスポンスに要約が含まれていません")
+	}
+	summary := strings.TrimSpace(parsed.Choices[0].Message.Content)
+	if summary == "" {
+		return "", fmt.Errorf("要約 API が空の要約を返しました")
+	}
+	return summary, nil
+}
@@ -0,0 +1,104 @@
+package summarizer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPISummarizer_Summarize(t *testing.T) {
+	t.Run("Chat Completions API を呼び出して最初の選択肢を要約として返す", func(t *testing.T) {
+		// Arrange
+		var gotAuth string
+		var gotReq chatRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"  要約です。\n"}}]}`))
+		}))
+		defer server.Close()
+		s := NewAPISummarizer(server.Client(), server.URL, "secret", "test-model")
+
+		// Act
+		got, err := s.Summarize(context.Background(), Document{Title: "タイトル", Text: "本文"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "要約です。" {
+			t.Errorf("got %q, want %q", got, "要約です。")
+		}
+		if gotAuth != "Bearer secret" {
+			t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer secret")
+		}
+		if gotReq.Model != "test-model" || len(gotReq.Messages) != 2 || gotReq.MaxTokens != maxOutputTokens {
+			t.Fatalf("request = %+v", gotReq)
+		}
+		if user := gotReq.Messages[1]; user.Role != "user" || !strings.Contains(user.Content, "タイトル") || !strings.Contains(user.Content, "本文") {
+			t.Errorf("user message = %+v", user)
+		}
+	})
+
+	t.Run("APIキーが空のときAuthorizationヘッダーを送らない", func(t *testing.T) {
+		// Arrange
+		var gotAuth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth = r.Header.Get("Authorization")
+			w.Write([]byte(`{"choices":[{"message":{"content":"要約"}}]}`))
+		}))
+		defer server.Close()
+		s := NewAPISummarizer(server.Client(), server.URL, "", "test-model")
+
+		// Act
+		_, err := s.Summarize(context.Background(), Document{Text: "本文"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotAuth != "" {
+			t.Errorf("Authorization = %q, want empty", gotAuth)
+		}
+	})
+
+	t.Run("APIが2xx以外を返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+		s := NewAPISummarizer(server.Client(), server.URL, "secret", "test-model")
+
+		// Act
+		_, err := s.Summarize(context.Background(), Document{Text: "本文"})
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), "status=429") {
+			t.Errorf("err = %v, want status=429", err)
+		}
+	})
+
+	t.Run("選択肢が空のときエラーを返す", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"choices":[]}`))
+		}))
+		defer server.Close()
+		s := NewAPISummarizer(server.Client(), server.URL, "secret", "test-model")
+
+		// Act
+		_, err := s.Summarize(context.Background(), Document{Text: "本文"})
+
+		// Assert
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package summarizer
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultLocalMaxRunes は LocalSummarizer の要約の既定の最大文字数（rune 数）。
+const DefaultLocalMaxRunes = 200
+
+// LocalSummarizer は本文の先頭から文単位で最大文字数まで抜き出す抽出型の要約器。
+// 外部サービスを呼び出さないため、要約 API を設定していない環境の既定実装と、
+// 要約 API が失敗したときのフォールバックに用いる。
+type LocalSummarizer struct {
+	maxRunes int
+}
+
+// NewLocalSummarizer は LocalSummarizer を生成する。maxRunes が 0 以下の場合は DefaultLocalMaxRunes を用いる。
+func NewLocalSummarizer(maxRunes int) *LocalSummarizer {
+	if maxRunes <= 0 {
+		maxRunes = DefaultLocalMaxRunes
+	}
+	return &LocalSummarizer{maxRunes: maxRunes}
+}
+
+// Summarize は本文の先頭の文を最大文字数に収まるだけ連結して返す。
+// 先頭の 1 文だけで最大文字数を超える場合は切り詰めて末尾に "…" を付ける。
+func (s *LocalSummarizer) Summarize(_ context.Context, doc Document) (string, error) {
+	text := strings.Join(strings.Fields(doc.Text), " ")
+	if text == "" {
+		return "", ErrEmptyText
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, sentence := range splitSentences(text) {
+		runes := utf8.RuneCountInString(sentence)
+		if n+runes > s.maxRunes {
+			break
+		}
+		b.WriteString(sentence)
+		n += runes
+	}
+	if b.Len() > 0 {
+		return strings.TrimSpace(b.String()), nil
+	}
+	return string([]rune(text)[:s.maxRunes-1]) + "…", nil
+}
+
+// splitSentences はテキストを文末記号（。．！？!?、および後ろに空白が続く "."）の直後で区切る。
+// 区切った各文は末尾の記号と後続の空白を含む。
+func splitSentences(text string) []string {
+	var sentences []string
+	runes := []rune(text)
+	start := 0
+	for i, r := range runes {
+		end := false
+		switch r {
+		case '。', '．', '！', '？', '!', '?':
+			end = true
+		case '.':
+			end = i+1 == len(runes) || unicode.IsSpace(runes[i+1])
+		}
+		if !end {
+			continue
+		}
+		// 文末記号に続く空白は同じ文に含める
+		j := i + 1
+		for j < len(runes) && unicode.IsSpace(runes[j]) {
+			j++
+		}
+		sentences = append(sentences, string(runes[start:j]))
+		start = j
+	}
+	if start < len(runes) {
+		sentences = append(sentences, string(runes[start:]))
+	}
+	return sentences
+}
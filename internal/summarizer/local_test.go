@@ -0,0 +1,75 @@
+package summarizer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLocalSummarizer_Summarize(t *testing.T) {
+	t.Run("最大文字数に収まるだけ先頭の文を連結する", func(t *testing.T) {
+		// Arrange
+		s := NewLocalSummarizer(20)
+		doc := Document{Text: "一文目です。二文目です。三文目はとても長い文章になっています。"}
+
+		// Act
+		got, err := s.Summarize(context.Background(), doc)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "一文目です。二文目です。" {
+			t.Errorf("got %q, want %q", got, "一文目です。二文目です。")
+		}
+	})
+
+	t.Run("英文はピリオドと空白で文を区切る", func(t *testing.T) {
+		// Arrange
+		s := NewLocalSummarizer(30)
+		doc := Document{Text: "First sentence. Version 1.2 is out.\n\nThird one is long enough."}
+
+		// Act
+		got, err := s.Summarize(context.Background(), doc)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "First sentence." {
+			t.Errorf("got %q, want %q", got, "First sentence.")
+		}
+	})
+
+	t.Run("先頭の文だけで最大文字数を超えるとき切り詰めて省略記号を付ける", func(t *testing.T) {
+		// Arrange
+		s := NewLocalSummarizer(10)
+		doc := Document{Text: strings.Repeat("あ", 30) + "。"}
+
+		// Act
+		got, err := s.Summarize(context.Background(), doc)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if utf8.RuneCountInString(got) != 10 || !strings.HasSuffix(got, "…") {
+			t.Errorf("got %q, want 10 runes ending with …", got)
+		}
+	})
+
+	t.Run("テキストが空白のみのときErrEmptyTextを返す", func(t *testing.T) {
+		// Arrange
+		s := NewLocalSummarizer(0)
+
+		// Act
+		_, err := s.Summarize(context.Background(), Document{Title: "タイトル", Text: " \n\t"})
+
+		// Assert
+		if !errors.Is(err, ErrEmptyText) {
+			t.Errorf("err = %v, want ErrEmptyText", err)
+		}
+	})
+}
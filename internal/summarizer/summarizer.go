@@ -0,0 +1,25 @@
+// Package summarizer は記事の要約を生成する要約器の抽象と実装を提供する。
+//
+// 要約器は Summarizer インターフェースで抽象化し、外部の LLM API を呼び出す APISummarizer と、
+// 外部サービスを使わずに本文の先頭の文を抜き出す LocalSummarizer（ダミー実装・フォールバック用）を持つ。
+package summarizer
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrEmptyText は要約対象のテキストが空の場合のエラー。
+var ErrEmptyText = errors.New("要約対象のテキストが空です")
+
+// Document は要約対象の記事。Text はタグを除いたプレーンテキストの本文。
+type Document struct {
+	Title string
+	Text  string
+}
+
+// Summarizer は記事の要約を生成する要約器のインターフェース。
+// 返す要約はプレーンテキストで、Text が空の場合は ErrEmptyText を返す。
+type Summarizer interface {
+	Summarize(ctx context.Context, doc Document) (string, error)
+}
@@ -0,0 +1,321 @@
+// Package team は家族・チームで購読リストを共有するドメインロジックを提供する。
+//
+// チームはオーナーとメンバーからなり、メンバーは招待トークンで参加する。チームの購読フィードは
+// メンバー全員の購読（subscriptions.team_id 付き）として展開するため、記事の閲覧権限は通常の購読と
+// 同じ経路で判定され、既読・スターはユーザー単位の item_states によりメンバーごとに分離される。
+// 個人で既に購読していたフィードは個人の購読のまま扱い、チームから外れても削除しない。
+package team
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Detail はチームの詳細（自分の所属・メンバー・購読フィード）。
+type Detail struct {
+	Membership model.TeamMembership
+	Members    []model.TeamMember
+	Feeds      []model.TeamFeed
+}
+
+// Invitation は発行した招待。Token は発行時にのみ返し、保存はハッシュのみ行う。
+type Invitation struct {
+	model.TeamInvitation
+	Token string
+}
+
+// Service はチーム共有のサービス層。
+type Service struct {
+	repo    repository.TeamRepository
+	subRepo repository.SubscriptionRepository
+	// cacheInvalidator は購読一覧キャッシュの無効化先。未設定時は nil。
+	cacheInvalidator cache.UserInvalidator
+	now              func() time.Time
+	newToken         func() (string, error)
+}
+
+// Option は NewService の任意設定を表す functional option。
+type Option func(*Service)
+
+// WithCacheInvalidator はチーム購読の追加・削除やチームへの参加・脱退で購読一覧が変わるメンバーの
+// 購読一覧キャッシュを無効化する invalidator を設定する。
+func WithCacheInvalidator(inv cache.UserInvalidator) Option {
+	return func(s *Service) {
+		s.cacheInvalidator = inv
+	}
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+// subRepo はチーム購読に追加するフィードを自分が購読しているかの確認に用いる。
+func NewService(repo repository.TeamRepository, subRepo repository.SubscriptionRepository, opts ...Option) *Service {
+	s := &Service{
+		repo:     repo,
+		subRepo:  subRepo,
+		now:      time.Now,
+		newToken: generateInvitationToken,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateTeam は userID をオーナーとするチームを作成する。
+// チーム名は前後の空白を除いて 1〜model.MaxTeamNameLength 文字で指定する。
+func (s *Service) CreateTeam(ctx context.Context, userID, name string) (*model.TeamMembership, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > model.MaxTeamNameLength {
+		return nil, model.NewInvalidTeamNameError()
+	}
+
+	team := &model.Team{Name: name}
+	if err := s.repo.Create(ctx, team, userID); err != nil {
+		return nil, fmt.Errorf("チームの作成に失敗しました: %w", err)
+	}
+	return &model.TeamMembership{Team: *team, Role: model.TeamRoleOwner, JoinedAt: team.CreatedAt}, nil
+}
+
+// ListTeams は当該ユーザーが所属するチームを返す。
+func (s *Service) ListTeams(ctx context.Context, userID string) ([]model.TeamMembership, error) {
+	teams, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("所属チームの取得に失敗しました: %w", err)
+	}
+	return teams, nil
+}
+
+// GetTeam はチームの詳細を返す。所属していないチームは TEAM_NOT_FOUND を返す。
+func (s *Service) GetTeam(ctx context.Context, userID, teamID string) (*Detail, error) {
+	membership, err := s.requireMembership(ctx, userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.ListMembers(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("チームメンバーの取得に失敗しました: %w", err)
+	}
+	feeds, err := s.repo.ListFeeds(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("チームの購読フィードの取得に失敗しました: %w", err)
+	}
+	return &Detail{Membership: *membership, Members: members, Feeds: feeds}, nil
+}
+
+// CreateInvitation はチームへの招待トークンを発行する。チームのメンバーであれば誰でも発行できる。
+// トークンは 1 回限り・model.TeamInvitationTTL の間だけ有効。
+func (s *Service) CreateInvitation(ctx context.Context, userID, teamID string) (*Invitation, error) {
+	if _, err := s.requireMembership(ctx, userID, teamID); err != nil {
+		return nil, err
+	}
+
+	token, err := s.newToken()
+	if err != nil {
+		return nil, fmt.Errorf("招待トークンの生成に失敗しました: %w", err)
+	}
+	invitation := &Invitation{
+		TeamInvitation: model.TeamInvitation{
+			TeamID:    teamID,
+			InvitedBy: userID,
+			ExpiresAt: s.now().Add(model.TeamInvitationTTL),
+		},
+		Token: token,
+	}
+	if err := s.repo.CreateInvitation(ctx, &invitation.TeamInvitation, hashInvitationToken(token)); err != nil {
+		return nil, fmt.Errorf("招待の保存に失敗しました: %w", err)
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation は招待トークンでチームに参加し、チームの購読フィードを自分の購読として展開する。
+// 展開は購読数の上限（model.MaxSubscriptionsPerUser）までとし、超える分は追加しない。
+// 無効・期限切れ・使用済みのトークンは TEAM_INVITATION_INVALID、既に所属している場合は
+// ALREADY_TEAM_MEMBER、メンバー数が上限の場合は TEAM_MEMBER_LIMIT を返す。
+func (s *Service) AcceptInvitation(ctx context.Context, userID, token string) (*model.Team, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, model.NewTeamInvitationInvalidError()
+	}
+
+	team, err := s.repo.AcceptInvitation(ctx, hashInvitationToken(token), userID, s.now(), model.MaxTeamMembers, model.MaxSubscriptionsPerUser)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTeamInvitationNotFound):
+			return nil, model.NewTeamInvitationInvalidError()
+		case errors.Is(err, repository.ErrAlreadyTeamMember):
+			return nil, model.NewAlreadyTeamMemberError()
+		case errors.Is(err, repository.ErrTeamMemberLimit):
+			return nil, model.NewTeamMemberLimitError()
+		default:
+			return nil, fmt.Errorf("招待の受諾に失敗しました: %w", err)
+		}
+	}
+	s.invalidateUserCache(ctx, userID)
+	return team, nil
+}
+
+// AddFeed は自分が購読しているフィードをチームの購読に追加し、メンバー全員の購読として展開する。
+// 購読数が上限（model.MaxSubscriptionsPerUser）に達しているメンバーには展開しない。
+// 自分が購読していないフィードは FEED_NOT_SUBSCRIBED、既にチームで購読している場合は
+// DUPLICATE_TEAM_FEED を返す。
+func (s *Service) AddFeed(ctx context.Context, userID, teamID, feedID string) error {
+	if _, err := s.requireMembership(ctx, userID, teamID); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(feedID); err != nil {
+		return model.NewFeedNotSubscribedError(feedID)
+	}
+	sub, err := s.subRepo.FindByUserAndFeed(ctx, userID, feedID)
+	if err != nil {
+		return fmt.Errorf("購読の確認に失敗しました: %w", err)
+	}
+	if sub == nil {
+		return model.NewFeedNotSubscribedError(feedID)
+	}
+
+	if err := s.repo.AddFeed(ctx, teamID, feedID, userID, model.MaxSubscriptionsPerUser); err != nil {
+		if errors.Is(err, repository.ErrTeamFeedAlreadyExists) {
+			return model.NewDuplicateTeamFeedError()
+		}
+		return fmt.Errorf("チームの購読フィードの追加に失敗しました: %w", err)
+	}
+	s.invalidateMembersCache(ctx, teamID)
+	return nil
+}
+
+// RemoveFeed はチームの購読フィードを削除し、チームから展開された各メンバーの購読を削除する。
+// 削除できるのはオーナーと当該フィードを追加したメンバーのみで、それ以外は FORBIDDEN を返す。
+// チームで購読していないフィードは FEED_NOT_FOUND を返す。
+func (s *Service) RemoveFeed(ctx context.Context, userID, teamID, feedID string) error {
+	membership, err := s.requireMembership(ctx, userID, teamID)
+	if err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(feedID); err != nil {
+		return teamFeedNotFoundError()
+	}
+
+	teamFeed, err := s.repo.FindFeed(ctx, teamID, feedID)
+	if err != nil {
+		return fmt.Errorf("チームの購読フィードの取得に失敗しました: %w", err)
+	}
+	if teamFeed == nil {
+		return teamFeedNotFoundError()
+	}
+	if membership.Role != model.TeamRoleOwner && teamFeed.AddedBy != userID {
+		return model.NewTeamFeedRemovalForbiddenError()
+	}
+
+	removed, err := s.repo.RemoveFeed(ctx, teamID, feedID)
+	if err != nil {
+		return fmt.Errorf("チームの購読フィードの削除に失敗しました: %w", err)
+	}
+	if !removed {
+		return teamFeedNotFoundError()
+	}
+	s.invalidateMembersCache(ctx, teamID)
+	return nil
+}
+
+// LeaveTeam はチームから脱退し、チームから展開された自分の購読を削除する。
+// オーナーは脱退できない（TEAM_OWNER_CANNOT_LEAVE）。
+func (s *Service) LeaveTeam(ctx context.Context, userID, teamID string) error {
+	membership, err := s.requireMembership(ctx, userID, teamID)
+	if err != nil {
+		return err
+	}
+	if membership.Role == model.TeamRoleOwner {
+		return model.NewTeamOwnerCannotLeaveError()
+	}
+
+	removed, err := s.repo.RemoveMember(ctx, teamID, userID)
+	if err != nil {
+		return fmt.Errorf("チームからの脱退に失敗しました: %w", err)
+	}
+	if !removed {
+		return model.NewTeamNotFoundError(teamID)
+	}
+	s.invalidateUserCache(ctx, userID)
+	return nil
+}
+
+// requireMembership は当該ユーザーのチームへの所属を返す。
+// 形式不正な ID や所属していないチームは、存在を明かさないよう TEAM_NOT_FOUND を返す。
+func (s *Service) requireMembership(ctx context.Context, userID, teamID string) (*model.TeamMembership, error) {
+	if _, err := uuid.Parse(teamID); err != nil {
+		return nil, model.NewTeamNotFoundError(teamID)
+	}
+	membership, err := s.repo.FindMembership(ctx, teamID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("チームへの所属の確認に失敗しました: %w", err)
+	}
+	if membership == nil {
+		return nil, model.NewTeamNotFoundError(teamID)
+	}
+	return membership, nil
+}
+
+// invalidateMembersCache はチームの全メンバーの購読一覧キャッシュを無効化する。
+// チームの購読フィードの変更は既にコミット済みのため、無効化はベストエフォートとし、
+// メンバーの取得に失敗した場合はログに残すだけにする（キャッシュは TTL で失効する）。
+func (s *Service) invalidateMembersCache(ctx context.Context, teamID string) {
+	if s.cacheInvalidator == nil {
+		return
+	}
+	members, err := s.repo.ListMembers(ctx, teamID)
+	if err != nil {
+		slog.Warn("failed to list team members for cache invalidation",
+			slog.String("team_id", teamID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	for _, m := range members {
+		s.cacheInvalidator.InvalidateUser(ctx, m.UserID)
+	}
+}
+
+// invalidateUserCache は当該ユーザーの購読一覧キャッシュを無効化する。
+func (s *Service) invalidateUserCache(ctx context.Context, userID string) {
+	if s.cacheInvalidator != nil {
+		s.cacheInvalidator.InvalidateUser(ctx, userID)
+	}
+}
+
+// teamFeedNotFoundError はチームで購読していないフィードを指定された場合のエラーを生成する。
+func teamFeedNotFoundError() *model.APIError {
+	return &model.APIError{
+		Code:     model.ErrCodeFeedNotFound,
+		Message:  "指定されたフィードはチームで購読していません。",
+		Category: "feed",
+		Action:   "チームの購読一覧を確認してください。",
+	}
+}
+
+// generateInvitationToken は暗号的に安全な招待トークン（URL セーフな 43 文字）を生成する。
+func generateInvitationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashInvitationToken は保存・照合用に招待トークンの SHA-256 ハッシュ（16 進）を返す。
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
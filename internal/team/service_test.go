@@ -0,0 +1,593 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	testTeamID = "11111111-1111-1111-1111-111111111111"
+	testFeedID = "22222222-2222-2222-2222-222222222222"
+)
+
+// mockTeamRepo は repository.TeamRepository のモック実装。
+type mockTeamRepo struct {
+	createFn           func(ctx context.Context, team *model.Team, ownerUserID string) error
+	findMembershipFn   func(ctx context.Context, teamID, userID string) (*model.TeamMembership, error)
+	listMembersFn      func(ctx context.Context, teamID string) ([]model.TeamMember, error)
+	findFeedFn         func(ctx context.Context, teamID, feedID string) (*model.TeamFeed, error)
+	addFeedFn          func(ctx context.Context, teamID, feedID, addedBy string, maxSubscriptions int) error
+	removeFeedFn       func(ctx context.Context, teamID, feedID string) (bool, error)
+	createInvitationFn func(ctx context.Context, invitation *model.TeamInvitation, tokenHash string) error
+	acceptInvitationFn func(ctx context.Context, tokenHash, userID string, now time.Time, maxMembers, maxSubscriptions int) (*model.Team, error)
+	removeMemberFn     func(ctx context.Context, teamID, userID string) (bool, error)
+
+	addFeedCalls      int
+	removeFeedCalls   int
+	removeMemberCalls int
+}
+
+func (m *mockTeamRepo) Create(ctx context.Context, team *model.Team, ownerUserID string) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, team, ownerUserID)
+	}
+	return nil
+}
+
+func (m *mockTeamRepo) ListByUser(context.Context, string) ([]model.TeamMembership, error) {
+	return []model.TeamMembership{}, nil
+}
+
+func (m *mockTeamRepo) FindMembership(ctx context.Context, teamID, userID string) (*model.TeamMembership, error) {
+	if m.findMembershipFn != nil {
+		return m.findMembershipFn(ctx, teamID, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockTeamRepo) ListMembers(ctx context.Context, teamID string) ([]model.TeamMember, error) {
+	if m.listMembersFn != nil {
+		return m.listMembersFn(ctx, teamID)
+	}
+	return []model.TeamMember{}, nil
+}
+
+func (m *mockTeamRepo) ListFeeds(context.Context, string) ([]model.TeamFeed, error) {
+	return []model.TeamFeed{}, nil
+}
+
+func (m *mockTeamRepo) FindFeed(ctx context.Context, teamID, feedID string) (*model.TeamFeed, error) {
+	if m.findFeedFn != nil {
+		return m.findFeedFn(ctx, teamID, feedID)
+	}
+	return &model.TeamFeed{FeedID: feedID}, nil
+}
+
+func (m *mockTeamRepo) AddFeed(ctx context.Context, teamID, feedID, addedBy string, maxSubscriptions int) error {
+	m.addFeedCalls++
+	if m.addFeedFn != nil {
+		return m.addFeedFn(ctx, teamID, feedID, addedBy, maxSubscriptions)
+	}
+	return nil
+}
+
+func (m *mockTeamRepo) RemoveFeed(ctx context.Context, teamID, feedID string) (bool, error) {
+	m.removeFeedCalls++
+	if m.removeFeedFn != nil {
+		return m.removeFeedFn(ctx, teamID, feedID)
+	}
+	return true, nil
+}
+
+func (m *mockTeamRepo) CreateInvitation(ctx context.Context, invitation *model.TeamInvitation, tokenHash string) error {
+	if m.createInvitationFn != nil {
+		return m.createInvitationFn(ctx, invitation, tokenHash)
+	}
+	return nil
+}
+
+func (m *mockTeamRepo) AcceptInvitation(ctx context.Context, tokenHash, userID string, now time.Time, maxMembers, maxSubscriptions int) (*model.Team, error) {
+	if m.acceptInvitationFn != nil {
+		return m.acceptInvitationFn(ctx, tokenHash, userID, now, maxMembers, maxSubscriptions)
+	}
+	return &model.Team{ID: testTeamID}, nil
+}
+
+func (m *mockTeamRepo) RemoveMember(ctx context.Context, teamID, userID string) (bool, error) {
+	m.removeMemberCalls++
+	if m.removeMemberFn != nil {
+		return m.removeMemberFn(ctx, teamID, userID)
+	}
+	return true, nil
+}
+
+// mockSubRepo は repository.SubscriptionRepository のモック実装。
+type mockSubRepo struct {
+	findByUserAndFeedFn func(ctx context.Context, userID, feedID string) (*model.Subscription, error)
+}
+
+func (m *mockSubRepo) FindByID(context.Context, string) (*model.Subscription, error) {
+	return nil, nil
+}
+func (m *mockSubRepo) FindByUserAndFeed(ctx context.Context, userID, feedID string) (*model.Subscription, error) {
+	if m.findByUserAndFeedFn != nil {
+		return m.findByUserAndFeedFn(ctx, userID, feedID)
+	}
+	return nil, nil
+}
+func (m *mockSubRepo) CountByUserID(context.Context, string) (int, error) { return 0, nil }
+func (m *mockSubRepo) Create(context.Context, *model.Subscription) error  { return nil }
+func (m *mockSubRepo) ListByUserID(context.Context, string) ([]*model.Subscription, error) {
+	return nil, nil
+}
+func (m *mockSubRepo) MinFetchIntervalByFeedID(context.Context, string) (int, error) { return 0, nil }
+func (m *mockSubRepo) UpdateFetchInterval(context.Context, string, int) error        { return nil }
+func (m *mockSubRepo) Delete(context.Context, string) error                          { return nil }
+func (m *mockSubRepo) DeleteByUserID(context.Context, string) error                  { return nil }
+func (m *mockSubRepo) ListByUserIDWithFeedInfo(context.Context, string) ([]repository.SubscriptionWithFeedInfo, error) {
+	return nil, nil
+}
+
+// mockInvalidator は cache.UserInvalidator のモック実装。
+type mockInvalidator struct {
+	userIDs []string
+}
+
+func (m *mockInvalidator) InvalidateUser(_ context.Context, userID string) {
+	m.userIDs = append(m.userIDs, userID)
+}
+
+// memberOf は userID を role で所属させる FindMembership を返す。
+func memberOf(userID string, role model.TeamRole) func(context.Context, string, string) (*model.TeamMembership, error) {
+	return func(_ context.Context, teamID, gotUserID string) (*model.TeamMembership, error) {
+		if gotUserID != userID {
+			return nil, nil
+		}
+		return &model.TeamMembership{Team: model.Team{ID: teamID, Name: "家族"}, Role: role}, nil
+	}
+}
+
+// assertAPIErrorCode は err が指定コードの APIError であることを検証する。
+func assertAPIErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Errorf("err = %v, want %s", err, code)
+	}
+}
+
+func TestService_CreateTeam(t *testing.T) {
+	t.Run("前後の空白を除いた名前で作成し作成者をオーナーとして返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			createFn: func(_ context.Context, team *model.Team, ownerUserID string) error {
+				if team.Name != "家族" || ownerUserID != "user-1" {
+					t.Errorf("args = (%q, %q)", team.Name, ownerUserID)
+				}
+				team.ID = testTeamID
+				return nil
+			},
+		}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		got, err := svc.CreateTeam(context.Background(), "user-1", "  家族  ")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("CreateTeam returned error: %v", err)
+		}
+		if got.ID != testTeamID || got.Role != model.TeamRoleOwner {
+			t.Errorf("membership = %+v", got)
+		}
+	})
+
+	t.Run("名前が空または長すぎるとき400 INVALID_TEAM_NAMEを返す", func(t *testing.T) {
+		for _, name := range []string{"", "   ", strings.Repeat("あ", model.MaxTeamNameLength+1)} {
+			// Arrange
+			repo := &mockTeamRepo{
+				createFn: func(context.Context, *model.Team, string) error {
+					t.Error("不正な名前で作成すべきでない")
+					return nil
+				},
+			}
+			svc := NewService(repo, &mockSubRepo{})
+
+			// Act
+			_, err := svc.CreateTeam(context.Background(), "user-1", name)
+
+			// Assert
+			assertAPIErrorCode(t, err, model.ErrCodeInvalidTeamName)
+		}
+	})
+}
+
+func TestService_GetTeam(t *testing.T) {
+	t.Run("所属していないチームまたは形式不正なIDのとき404 TEAM_NOT_FOUNDを返す", func(t *testing.T) {
+		for _, teamID := range []string{testTeamID, "not-a-uuid"} {
+			// Arrange
+			svc := NewService(&mockTeamRepo{findMembershipFn: memberOf("user-2", model.TeamRoleOwner)}, &mockSubRepo{})
+
+			// Act
+			_, err := svc.GetTeam(context.Background(), "user-1", teamID)
+
+			// Assert
+			assertAPIErrorCode(t, err, model.ErrCodeTeamNotFound)
+		}
+	})
+}
+
+func TestService_CreateInvitation(t *testing.T) {
+	t.Run("メンバーのときトークンを返しハッシュと有効期限を保存する", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+		var gotHash string
+		var gotInvitation *model.TeamInvitation
+		repo := &mockTeamRepo{
+			findMembershipFn: memberOf("user-1", model.TeamRoleMember),
+			createInvitationFn: func(_ context.Context, invitation *model.TeamInvitation, tokenHash string) error {
+				gotInvitation = invitation
+				gotHash = tokenHash
+				return nil
+			},
+		}
+		svc := NewService(repo, &mockSubRepo{})
+		svc.now = func() time.Time { return now }
+		svc.newToken = func() (string, error) { return "token-abc", nil }
+
+		// Act
+		got, err := svc.CreateInvitation(context.Background(), "user-1", testTeamID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("CreateInvitation returned error: %v", err)
+		}
+		if got.Token != "token-abc" {
+			t.Errorf("Token = %q, want %q", got.Token, "token-abc")
+		}
+		if gotHash != hashInvitationToken("token-abc") || gotHash == "token-abc" {
+			t.Errorf("tokenHash = %q, want hash of token", gotHash)
+		}
+		if gotInvitation.TeamID != testTeamID || gotInvitation.InvitedBy != "user-1" ||
+			!gotInvitation.ExpiresAt.Equal(now.Add(model.TeamInvitationTTL)) {
+			t.Errorf("invitation = %+v", gotInvitation)
+		}
+	})
+
+	t.Run("所属していないチームのとき招待を発行しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			createInvitationFn: func(context.Context, *model.TeamInvitation, string) error {
+				t.Error("非メンバーの招待を保存すべきでない")
+				return nil
+			},
+		}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		_, err := svc.CreateInvitation(context.Background(), "user-1", testTeamID)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeTeamNotFound)
+	})
+}
+
+func TestGenerateInvitationToken(t *testing.T) {
+	a, err := generateInvitationToken()
+	if err != nil {
+		t.Fatalf("generateInvitationToken returned error: %v", err)
+	}
+	b, _ := generateInvitationToken()
+	if len(a) != 43 || a == b {
+		t.Errorf("tokens = %q, %q, want distinct 43-char tokens", a, b)
+	}
+}
+
+func TestService_AcceptInvitation(t *testing.T) {
+	t.Run("有効なトークンのときハッシュで照合して参加し購読一覧キャッシュを無効化する", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			acceptInvitationFn: func(_ context.Context, tokenHash, userID string, _ time.Time, maxMembers, maxSubscriptions int) (*model.Team, error) {
+				if tokenHash != hashInvitationToken("token-abc") || userID != "user-2" ||
+					maxMembers != model.MaxTeamMembers || maxSubscriptions != model.MaxSubscriptionsPerUser {
+					t.Errorf("args = (%q, %q, %d, %d)", tokenHash, userID, maxMembers, maxSubscriptions)
+				}
+				return &model.Team{ID: testTeamID, Name: "家族"}, nil
+			},
+		}
+		inv := &mockInvalidator{}
+		svc := NewService(repo, &mockSubRepo{}, WithCacheInvalidator(inv))
+
+		// Act
+		got, err := svc.AcceptInvitation(context.Background(), "user-2", " token-abc ")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("AcceptInvitation returned error: %v", err)
+		}
+		if got.ID != testTeamID {
+			t.Errorf("team = %+v", got)
+		}
+		if len(inv.userIDs) != 1 || inv.userIDs[0] != "user-2" {
+			t.Errorf("invalidated = %v, want [user-2]", inv.userIDs)
+		}
+	})
+
+	t.Run("リポジトリのエラーをAPIエラーに変換する", func(t *testing.T) {
+		tests := []struct {
+			repoErr error
+			want    string
+		}{
+			{repository.ErrTeamInvitationNotFound, model.ErrCodeTeamInvitationInvalid},
+			{repository.ErrAlreadyTeamMember, model.ErrCodeAlreadyTeamMember},
+			{repository.ErrTeamMemberLimit, model.ErrCodeTeamMemberLimit},
+		}
+		for _, tt := range tests {
+			// Arrange
+			repo := &mockTeamRepo{
+				acceptInvitationFn: func(context.Context, string, string, time.Time, int, int) (*model.Team, error) {
+					return nil, tt.repoErr
+				},
+			}
+			svc := NewService(repo, &mockSubRepo{})
+
+			// Act
+			_, err := svc.AcceptInvitation(context.Background(), "user-2", "token-abc")
+
+			// Assert
+			assertAPIErrorCode(t, err, tt.want)
+		}
+	})
+
+	t.Run("トークンが空のとき404 TEAM_INVITATION_INVALIDを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockTeamRepo{}, &mockSubRepo{})
+
+		// Act
+		_, err := svc.AcceptInvitation(context.Background(), "user-2", "")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeTeamInvitationInvalid)
+	})
+}
+
+func TestService_AddFeed(t *testing.T) {
+	t.Run("自分が購読しているフィードのとき追加して全メンバーのキャッシュを無効化する", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			findMembershipFn: memberOf("user-1", model.TeamRoleMember),
+			addFeedFn: func(_ context.Context, teamID, feedID, addedBy string, maxSubscriptions int) error {
+				if teamID != testTeamID || feedID != testFeedID || addedBy != "user-1" || maxSubscriptions != model.MaxSubscriptionsPerUser {
+					t.Errorf("args = (%q, %q, %q, %d)", teamID, feedID, addedBy, maxSubscriptions)
+				}
+				return nil
+			},
+			listMembersFn: func(context.Context, string) ([]model.TeamMember, error) {
+				return []model.TeamMember{{UserID: "user-1"}, {UserID: "user-2"}}, nil
+			},
+		}
+		subRepo := &mockSubRepo{
+			findByUserAndFeedFn: func(context.Context, string, string) (*model.Subscription, error) {
+				return &model.Subscription{ID: "sub-1"}, nil
+			},
+		}
+		inv := &mockInvalidator{}
+		svc := NewService(repo, subRepo, WithCacheInvalidator(inv))
+
+		// Act
+		err := svc.AddFeed(context.Background(), "user-1", testTeamID, testFeedID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("AddFeed returned error: %v", err)
+		}
+		if len(inv.userIDs) != 2 {
+			t.Errorf("invalidated = %v, want 2 members", inv.userIDs)
+		}
+	})
+
+	t.Run("キャッシュ無効化のためのメンバー取得に失敗したとき追加は成功として扱う", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			findMembershipFn: memberOf("user-1", model.TeamRoleMember),
+			listMembersFn: func(context.Context, string) ([]model.TeamMember, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		subRepo := &mockSubRepo{
+			findByUserAndFeedFn: func(context.Context, string, string) (*model.Subscription, error) {
+				return &model.Subscription{ID: "sub-1"}, nil
+			},
+		}
+		inv := &mockInvalidator{}
+		svc := NewService(repo, subRepo, WithCacheInvalidator(inv))
+
+		// Act
+		err := svc.AddFeed(context.Background(), "user-1", testTeamID, testFeedID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("AddFeed returned error: %v", err)
+		}
+		if repo.addFeedCalls != 1 {
+			t.Errorf("AddFeed calls = %d, want 1", repo.addFeedCalls)
+		}
+		if len(inv.userIDs) != 0 {
+			t.Errorf("invalidated = %v, want none", inv.userIDs)
+		}
+	})
+
+	t.Run("自分が購読していないフィードのとき403 FEED_NOT_SUBSCRIBEDを返し追加しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{findMembershipFn: memberOf("user-1", model.TeamRoleMember)}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		err := svc.AddFeed(context.Background(), "user-1", testTeamID, testFeedID)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeFeedNotSubscribed)
+		if repo.addFeedCalls != 0 {
+			t.Errorf("AddFeed calls = %d, want 0", repo.addFeedCalls)
+		}
+	})
+
+	t.Run("既にチームで購読しているとき409 DUPLICATE_TEAM_FEEDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			findMembershipFn: memberOf("user-1", model.TeamRoleMember),
+			addFeedFn: func(context.Context, string, string, string, int) error {
+				return repository.ErrTeamFeedAlreadyExists
+			},
+		}
+		subRepo := &mockSubRepo{
+			findByUserAndFeedFn: func(context.Context, string, string) (*model.Subscription, error) {
+				return &model.Subscription{ID: "sub-1"}, nil
+			},
+		}
+		svc := NewService(repo, subRepo)
+
+		// Act
+		err := svc.AddFeed(context.Background(), "user-1", testTeamID, testFeedID)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeDuplicateTeamFeed)
+	})
+}
+
+func TestService_RemoveFeed(t *testing.T) {
+	t.Run("チームで購読していないフィードのとき404 FEED_NOT_FOUNDを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			findMembershipFn: memberOf("user-1", model.TeamRoleMember),
+			findFeedFn:       func(context.Context, string, string) (*model.TeamFeed, error) { return nil, nil },
+		}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		err := svc.RemoveFeed(context.Background(), "user-1", testTeamID, testFeedID)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeFeedNotFound)
+		if repo.removeFeedCalls != 0 {
+			t.Errorf("RemoveFeed calls = %d, want 0", repo.removeFeedCalls)
+		}
+	})
+
+	addedByUser1 := func(_ context.Context, _, feedID string) (*model.TeamFeed, error) {
+		return &model.TeamFeed{FeedID: feedID, AddedBy: "user-1"}, nil
+	}
+
+	t.Run("フィードを追加したメンバーのとき削除できる", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{findMembershipFn: memberOf("user-1", model.TeamRoleMember), findFeedFn: addedByUser1}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		err := svc.RemoveFeed(context.Background(), "user-1", testTeamID, testFeedID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RemoveFeed returned error: %v", err)
+		}
+		if repo.removeFeedCalls != 1 {
+			t.Errorf("RemoveFeed calls = %d, want 1", repo.removeFeedCalls)
+		}
+	})
+
+	t.Run("オーナーのとき他のメンバーが追加したフィードも削除できる", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{findMembershipFn: memberOf("user-2", model.TeamRoleOwner), findFeedFn: addedByUser1}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		err := svc.RemoveFeed(context.Background(), "user-2", testTeamID, testFeedID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RemoveFeed returned error: %v", err)
+		}
+		if repo.removeFeedCalls != 1 {
+			t.Errorf("RemoveFeed calls = %d, want 1", repo.removeFeedCalls)
+		}
+	})
+
+	t.Run("キャッシュ無効化のためのメンバー取得に失敗したとき削除は成功として扱う", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{
+			findMembershipFn: memberOf("user-1", model.TeamRoleMember),
+			findFeedFn:       addedByUser1,
+			listMembersFn: func(context.Context, string) ([]model.TeamMember, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		svc := NewService(repo, &mockSubRepo{}, WithCacheInvalidator(&mockInvalidator{}))
+
+		// Act
+		err := svc.RemoveFeed(context.Background(), "user-1", testTeamID, testFeedID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RemoveFeed returned error: %v", err)
+		}
+		if repo.removeFeedCalls != 1 {
+			t.Errorf("RemoveFeed calls = %d, want 1", repo.removeFeedCalls)
+		}
+	})
+
+	t.Run("オーナーでも追加者でもないメンバーのとき403 FORBIDDENを返し削除しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{findMembershipFn: memberOf("user-2", model.TeamRoleMember), findFeedFn: addedByUser1}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		err := svc.RemoveFeed(context.Background(), "user-2", testTeamID, testFeedID)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeForbidden)
+		if repo.removeFeedCalls != 0 {
+			t.Errorf("RemoveFeed calls = %d, want 0", repo.removeFeedCalls)
+		}
+	})
+}
+
+func TestService_LeaveTeam(t *testing.T) {
+	t.Run("メンバーのとき脱退して自分のキャッシュを無効化する", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{findMembershipFn: memberOf("user-2", model.TeamRoleMember)}
+		inv := &mockInvalidator{}
+		svc := NewService(repo, &mockSubRepo{}, WithCacheInvalidator(inv))
+
+		// Act
+		err := svc.LeaveTeam(context.Background(), "user-2", testTeamID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("LeaveTeam returned error: %v", err)
+		}
+		if repo.removeMemberCalls != 1 || len(inv.userIDs) != 1 {
+			t.Errorf("removeMemberCalls = %d, invalidated = %v", repo.removeMemberCalls, inv.userIDs)
+		}
+	})
+
+	t.Run("オーナーのとき409 TEAM_OWNER_CANNOT_LEAVEを返し脱退しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockTeamRepo{findMembershipFn: memberOf("user-1", model.TeamRoleOwner)}
+		svc := NewService(repo, &mockSubRepo{})
+
+		// Act
+		err := svc.LeaveTeam(context.Background(), "user-1", testTeamID)
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeTeamOwnerCannotLeave)
+		if repo.removeMemberCalls != 0 {
+			t.Errorf("removeMemberCalls = %d, want 0", repo.removeMemberCalls)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testRSS は E2E テスト用フィードサーバーが返す RSS。
+const testRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>E2E Test Feed</title>
+    <link>https://example.com</link>
+    <description>E2E テスト用フィード</description>
+    <item>
+      <title>Article 1</title>
+      <link>https://example.com/article/1</link>
+      <guid>e2e-guid-1</guid>
+      <pubDate>Mon, 01 Jan 2024 00:00:00 +0000</pubDate>
+      <description>First article content</description>
+    </item>
+    <item>
+      <title>Article 2</title>
+      <link>https://example.com/article/2</link>
+      <guid>e2e-guid-2</guid>
+      <pubDate>Tue, 02 Jan 2024 00:00:00 +0000</pubDate>
+      <description>Second article content</description>
+    </item>
+  </channel>
+</rss>`
+
+// newFeedServer はテスト用の RSS を配信するサーバーを起動する。
+func newFeedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feed.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, testRSS)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type subscriptionBody struct {
+	ID          string `json:"id"`
+	FeedID      string `json:"feed_id"`
+	FeedTitle   string `json:"feed_title"`
+	UnreadCount int    `json:"unread_count"`
+}
+
+type itemListBody struct {
+	Items []struct {
+		ID     string `json:"id"`
+		Title  string `json:"title"`
+		IsRead bool   `json:"is_read"`
+	} `json:"items"`
+}
+
+// listSubscriptions は購読一覧を取得し、1 件であることを確認して返す。
+func listSubscriptions(t *testing.T, c *Client) subscriptionBody {
+	t.Helper()
+	var subs []subscriptionBody
+	c.Do(t, http.MethodGet, "/api/subscriptions", nil).MustStatus(t, http.StatusOK).Decode(t, &subs)
+	if len(subs) != 1 {
+		t.Fatalf("subscriptions = %d, want 1", len(subs))
+	}
+	return subs[0]
+}
+
+// TestE2E_RegisterFetchListRead は登録 → フェッチ → 一覧 → 既読の主要フローを実 DB・実サービスで検証する。
+func TestE2E_RegisterFetchListRead(t *testing.T) {
+	h := New(t)
+	feedServer := newFeedServer(t)
+	c := h.NewUser(t, "alice")
+
+	// 登録: フィード URL を直接指定すると購読が作成される
+	var registered struct {
+		ID string `json:"id"`
+	}
+	c.Do(t, http.MethodPost, "/api/feeds", map[string]string{"url": feedServer.URL + "/feed.xml"}).
+		MustStatus(t, http.StatusCreated).Decode(t, &registered)
+	if registered.ID == "" {
+		t.Fatal("registered feed id is empty")
+	}
+
+	sub := listSubscriptions(t, c)
+	if sub.FeedID != registered.ID {
+		t.Errorf("subscription feed_id = %q, want %q", sub.FeedID, registered.ID)
+	}
+	if sub.UnreadCount != 0 {
+		t.Errorf("unread_count before fetch = %d, want 0", sub.UnreadCount)
+	}
+
+	// フェッチ: 手動フェッチ API で記事を取り込む
+	c.Do(t, http.MethodPost, "/api/subscriptions/"+sub.ID+"/fetch", nil).MustStatus(t, http.StatusOK)
+
+	// 一覧: 取り込んだ記事が新しい順に未読で返る
+	var items itemListBody
+	c.Do(t, http.MethodGet, "/api/feeds/"+registered.ID+"/items", nil).MustStatus(t, http.StatusOK).Decode(t, &items)
+	if len(items.Items) != 2 {
+		t.Fatalf("items = %d, want 2", len(items.Items))
+	}
+	if items.Items[0].Title != "Article 2" || items.Items[1].Title != "Article 1" {
+		t.Errorf("item order = [%q, %q], want [Article 2, Article 1]", items.Items[0].Title, items.Items[1].Title)
+	}
+	for _, it := range items.Items {
+		if it.IsRead {
+			t.Errorf("item %q is_read = true, want false", it.Title)
+		}
+	}
+	fetched := listSubscriptions(t, c)
+	if fetched.UnreadCount != 2 {
+		t.Errorf("unread_count after fetch = %d, want 2", fetched.UnreadCount)
+	}
+	if fetched.FeedTitle != "E2E Test Feed" {
+		t.Errorf("feed_title after fetch = %q, want %q", fetched.FeedTitle, "E2E Test Feed")
+	}
+
+	// 既読: 1 件を既読にすると未読数と未読フィルタの結果に反映される
+	readID := items.Items[0].ID
+	c.Do(t, http.MethodPut, "/api/items/"+readID+"/state", map[string]bool{"is_read": true}).MustStatus(t, http.StatusOK)
+
+	if got := listSubscriptions(t, c).UnreadCount; got != 1 {
+		t.Errorf("unread_count after read = %d, want 1", got)
+	}
+	var unread itemListBody
+	c.Do(t, http.MethodGet, "/api/feeds/"+registered.ID+"/items?filter=unread", nil).
+		MustStatus(t, http.StatusOK).Decode(t, &unread)
+	if len(unread.Items) != 1 || unread.Items[0].ID == readID {
+		t.Errorf("unread items = %+v, want only the other item", unread.Items)
+	}
+}
+
+// TestE2E_OtherUserCannotSeeSubscription は購読がユーザー単位に分離されていることを検証する。
+func TestE2E_OtherUserCannotSeeSubscription(t *testing.T) {
+	h := New(t)
+	feedServer := newFeedServer(t)
+	alice := h.NewUser(t, "alice")
+	bob := h.NewUser(t, "bob")
+
+	alice.Do(t, http.MethodPost, "/api/feeds", map[string]string{"url": feedServer.URL + "/feed.xml"}).
+		MustStatus(t, http.StatusCreated)
+	sub := listSubscriptions(t, alice)
+
+	var subs []subscriptionBody
+	bob.Do(t, http.MethodGet, "/api/subscriptions", nil).MustStatus(t, http.StatusOK).Decode(t, &subs)
+	if len(subs) != 0 {
+		t.Errorf("bob subscriptions = %d, want 0", len(subs))
+	}
+	bob.Do(t, http.MethodPost, "/api/subscriptions/"+sub.ID+"/fetch", nil).MustStatus(t, http.StatusNotFound)
+}
+
+// TestE2E_ImportFilter は取り込みフィルタに一致しない記事がフェッチ時に保存されないことを検証する。
+func TestE2E_ImportFilter(t *testing.T) {
+	h := New(t)
+	feedServer := newFeedServer(t)
+	c := h.NewUser(t, "alice")
+
+	var registered struct {
+		ID string `json:"id"`
+	}
+	c.Do(t, http.MethodPost, "/api/feeds", map[string]string{"url": feedServer.URL + "/feed.xml"}).
+		MustStatus(t, http.StatusCreated).Decode(t, &registered)
+	sub := listSubscriptions(t, c)
+
+	c.Do(t, http.MethodPut, "/api/subscriptions/"+sub.ID+"/import-filter", map[string]any{"title_pattern": "2$"}).
+		MustStatus(t, http.StatusOK)
+	c.Do(t, http.MethodPost, "/api/subscriptions/"+sub.ID+"/fetch", nil).MustStatus(t, http.StatusOK)
+
+	var items itemListBody
+	c.Do(t, http.MethodGet, "/api/feeds/"+registered.ID+"/items", nil).MustStatus(t, http.StatusOK).Decode(t, &items)
+	if len(items.Items) != 1 || items.Items[0].Title != "Article 2" {
+		t.Errorf("items = %+v, want only Article 2", items.Items)
+	}
+	var stored int
+	if err := h.DB.QueryRow(`SELECT COUNT(*) FROM items WHERE feed_id = $1`, registered.ID).Scan(&stored); err != nil {
+		t.Fatalf("記事数の取得に失敗: %v", err)
+	}
+	if stored != 1 {
+		t.Errorf("stored items = %d, want 1", stored)
+	}
+}
+
+// TestE2E_NotificationMute は通知ヒント設定の更新（PUT /api/subscriptions/{id}/notification）で
+// mute_until が保存され、購読一覧に反映され、解除できることを検証する。
+func TestE2E_NotificationMute(t *testing.T) {
+	h := New(t)
+	feedServer := newFeedServer(t)
+	c := h.NewUser(t, "alice")
+
+	c.Do(t, http.MethodPost, "/api/feeds", map[string]string{"url": feedServer.URL + "/feed.xml"}).
+		MustStatus(t, http.StatusCreated)
+	sub := listSubscriptions(t, c)
+
+	type notificationBody struct {
+		Priority  string     `json:"priority"`
+		MuteUntil *time.Time `json:"mute_until"`
+	}
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	c.Do(t, http.MethodPut, "/api/subscriptions/"+sub.ID+"/notification", map[string]any{"priority": "high", "mute_until": until}).
+		MustStatus(t, http.StatusOK)
+
+	var stored *time.Time
+	if err := h.DB.QueryRow(`SELECT mute_until FROM subscriptions WHERE id = $1`, sub.ID).Scan(&stored); err != nil {
+		t.Fatalf("mute_until の取得に失敗: %v", err)
+	}
+	if stored == nil || !stored.Equal(until) {
+		t.Errorf("stored mute_until = %v, want %v", stored, until)
+	}
+	var subs []notificationBody
+	c.Do(t, http.MethodGet, "/api/subscriptions", nil).MustStatus(t, http.StatusOK).Decode(t, &subs)
+	if len(subs) != 1 || subs[0].Priority != "high" || subs[0].MuteUntil == nil || !subs[0].MuteUntil.Equal(until) {
+		t.Errorf("subscriptions = %+v, want priority high muted until %v", subs, until)
+	}
+
+	c.Do(t, http.MethodPut, "/api/subscriptions/"+sub.ID+"/notification", map[string]any{"priority": "high", "mute_until": nil}).
+		MustStatus(t, http.StatusOK)
+	var got notificationBody
+	c.Do(t, http.MethodGet, "/api/subscriptions/"+sub.ID+"/notification", nil).MustStatus(t, http.StatusOK).Decode(t, &got)
+	if got.MuteUntil != nil {
+		t.Errorf("mute_until after unmute = %v, want nil", got.MuteUntil)
+	}
+}
@@ -0,0 +1,333 @@
+// Package e2e はハンドラ統合テスト（E2E）用のテストハーネスを提供する。
+//
+// testcontainers-go で使い捨ての PostgreSQL コンテナを起動し、マイグレーションを適用したうえで
+// 実リポジトリ・実サービスを serve と同じ構成で組み立て、httptest.Server 経由で HTTP リクエストを
+// 送れる状態にする。Docker が利用できない環境ではテストをスキップする。
+//
+// serve との差分は次のとおり（いずれもテスト環境で外部依存を持たないための置き換え）:
+//   - SSRF ガードはループバックのテスト用フィードサーバーへ到達できるよう、スキーム検証のみを行う。
+//   - OAuth ログインは経由せず、NewUser でユーザーとセッションを直接作成する。
+//   - 閲覧イベントの非同期記録・管理者向けパース診断・メトリクス公開は組み立てない。
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	"github.com/hitoshi/feedman/internal/auth"
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/database"
+	"github.com/hitoshi/feedman/internal/feed"
+	"github.com/hitoshi/feedman/internal/handler"
+	"github.com/hitoshi/feedman/internal/importfilter"
+	"github.com/hitoshi/feedman/internal/item"
+	"github.com/hitoshi/feedman/internal/itemsearch"
+	"github.com/hitoshi/feedman/internal/metrics"
+	"github.com/hitoshi/feedman/internal/middleware"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/notification"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
+	"github.com/hitoshi/feedman/internal/subscription"
+	"github.com/hitoshi/feedman/internal/user"
+	"github.com/hitoshi/feedman/internal/usersettings"
+	fetchpkg "github.com/hitoshi/feedman/internal/worker/fetch"
+)
+
+const (
+	// postgresImage は E2E テストで起動する PostgreSQL のイメージ。CI のサービスコンテナと揃える。
+	postgresImage = "postgres:16"
+	// sessionCookieName は API が認証に使用するセッション Cookie 名。
+	sessionCookieName = "session_id"
+	// fetchTimeout / fetchMaxSize は手動フェッチで使用するタイムアウト・最大サイズ。
+	fetchTimeout = 10 * time.Second
+	fetchMaxSize = 5 * 1024 * 1024
+)
+
+// Harness は E2E テスト用に組み立てた API サーバーと DB を保持する。
+type Harness struct {
+	// DB はマイグレーション適用済みのテスト用 DB への接続。状態の直接検証に使用する。
+	DB *sql.DB
+	// Server は実サービスを組み込んだ API サーバー。
+	Server *httptest.Server
+
+	userRepo    *repository.PostgresUserRepo
+	sessionRepo *repository.PostgresSessionRepo
+}
+
+// New は PostgreSQL コンテナを起動して API サーバーを組み立てる。
+// コンテナ・DB 接続・サーバーは t.Cleanup で破棄される。
+// Docker が利用できない場合、または -short 指定時はテストをスキップする。
+func New(t *testing.T) *Harness {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("E2E テストは -short 指定時はスキップする")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	ctr, err := postgres.Run(ctx, postgresImage,
+		postgres.WithDatabase("feedman_e2e"),
+		postgres.WithUsername("feedman"),
+		postgres.WithPassword("feedman"),
+		postgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(t, ctr)
+	if err != nil {
+		t.Fatalf("PostgreSQL コンテナの起動に失敗: %v", err)
+	}
+
+	dsn, err := ctr.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("接続文字列の取得に失敗: %v", err)
+	}
+	if err := database.RunMigrations(dsn); err != nil {
+		t.Fatalf("マイグレーションの適用に失敗: %v", err)
+	}
+
+	db, err := database.Open(dsn)
+	if err != nil {
+		t.Fatalf("データベースへの接続に失敗: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	h := &Harness{
+		DB:          db,
+		userRepo:    repository.NewPostgresUserRepo(db),
+		sessionRepo: repository.NewPostgresSessionRepo(db),
+	}
+	h.Server = httptest.NewServer(h.newRouter(t, db))
+	t.Cleanup(h.Server.Close)
+	return h
+}
+
+// newRouter は serve（app.runServe）と同じ依存配線で API ルーターを組み立てる。
+func (h *Harness) newRouter(t *testing.T, db *sql.DB) http.Handler {
+	t.Helper()
+
+	identRepo := repository.NewPostgresIdentityRepo(db)
+	feedRepo := repository.NewPostgresFeedRepo(db)
+	subRepo := repository.NewPostgresSubscriptionRepo(db)
+	itemRepo := repository.NewPostgresItemRepo(db)
+	itemStateRepo := repository.NewPostgresItemStateRepo(db)
+	userSettingsRepo := repository.NewPostgresUserSettingsRepo(db)
+
+	ssrfGuard := loopbackAllowedGuard{}
+	sanitizer := security.SharedContentSanitizer()
+	collector := metrics.NewCollector(prometheus.NewRegistry())
+
+	authService := auth.NewService(
+		auth.NewGoogleOAuthProvider(auth.GoogleOAuthConfig{}),
+		h.userRepo, identRepo, h.sessionRepo,
+		auth.ServiceConfig{SessionMaxAge: 86400},
+	)
+
+	subListCache := cache.Instrument[[]subscription.SubscriptionInfo](
+		cache.NewMemory[[]subscription.SubscriptionInfo](subscription.DefaultListCacheTTL),
+		subscription.ListCacheName, collector,
+	)
+	subListInvalidator := subscription.NewListCacheInvalidator(subListCache)
+
+	feedService := feed.NewFeedService(
+		feedRepo, subRepo, feed.NewFeedDetector(ssrfGuard), feed.NewFaviconFetcher(ssrfGuard),
+		feed.WithCacheInvalidator(subListInvalidator),
+	)
+	userSettingsService := usersettings.NewService(userSettingsRepo, usersettings.WithCacheInvalidator(subListInvalidator))
+	itemService := item.NewItemService(itemRepo, itemStateRepo, item.WithLinkPreference(userSettingsService))
+
+	importFilterService := importfilter.NewService(repository.NewPostgresSubscriptionImportFilterRepo(db))
+	upsertSvc := item.NewItemUpsertService(itemRepo, sanitizer, item.WithMetrics(collector))
+	fetcher := fetchpkg.NewFetcher(
+		feedRepo, subRepo, upsertSvc, ssrfGuard,
+		slog.Default(), fetchTimeout, fetchMaxSize,
+		fetchpkg.WithMetrics(collector),
+		fetchpkg.WithItemFilter(importFilterService),
+	)
+	subService := subscription.NewService(
+		subRepo, itemStateRepo, feedRepo,
+		fetcher, subscription.NewSQLManualFetchTxBeginner(db), collector,
+		subscription.WithListCache(subListCache),
+		subscription.WithUndo(repository.NewPostgresSubscriptionUndoRepo(db), subscription.DefaultUndoWindow),
+	)
+	userService := user.NewService(h.userRepo, h.sessionRepo, subRepo, itemStateRepo)
+
+	rateLimiter := middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig())
+	t.Cleanup(rateLimiter.Stop)
+
+	return handler.NewRouter(&handler.RouterDeps{
+		HealthChecker:     db,
+		SessionFinder:     h.sessionRepo,
+		CORSAllowedOrigin: "http://localhost:3000",
+		RateLimiter:       rateLimiter,
+		Logger:            slog.Default(),
+
+		AuthService: authService,
+		AuthConfig:  handler.AuthHandlerConfig{BaseURL: "http://localhost:3000", SessionMaxAge: 86400},
+
+		FeedService:         feedService,
+		SubscriptionDeleter: handler.NewSubscriptionDeleterAdapter(subRepo, itemStateRepo, nil, subListInvalidator),
+
+		ItemService:      handler.NewItemServiceAdapter(itemService),
+		ItemStateService: handler.NewItemStateServiceAdapter(itemStateRepo, subListInvalidator),
+
+		ItemSearchService: handler.NewItemSearchServiceAdapter(itemsearch.NewSearchService(itemRepo, subRepo)),
+
+		SubscriptionService: handler.NewSubscriptionServiceAdapter(subService),
+		UserService:         handler.NewUserServiceAdapter(userService),
+
+		ImportFilterService: handler.NewImportFilterServiceAdapter(importFilterService),
+
+		UserSettingsService: handler.NewUserSettingsServiceAdapter(userSettingsService),
+
+		NotificationService: handler.NewNotificationServiceAdapter(notification.NewService(
+			repository.NewPostgresSubscriptionNotificationRepo(db),
+			notification.WithCacheInvalidator(subListInvalidator),
+		)),
+	})
+}
+
+// Client は特定ユーザーのセッションで API を呼び出すクライアント。
+type Client struct {
+	// UserID はクライアントに紐付くユーザーの ID。
+	UserID string
+
+	h         *Harness
+	sessionID string
+}
+
+// NewUser はユーザーとセッションを DB に直接作成し、そのセッションで API を呼ぶクライアントを返す。
+// OAuth ログインの経路は通さない。
+func (h *Harness) NewUser(t *testing.T, name string) *Client {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+
+	u := &model.User{
+		ID:        uuid.New().String(),
+		Email:     name + "@example.com",
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	identity := &model.Identity{
+		ID:             uuid.New().String(),
+		UserID:         u.ID,
+		Provider:       "google",
+		ProviderUserID: "e2e-" + u.ID,
+		CreatedAt:      now,
+	}
+	if err := h.userRepo.CreateWithIdentity(ctx, u, identity); err != nil {
+		t.Fatalf("ユーザーの作成に失敗: %v", err)
+	}
+
+	session := &model.Session{
+		ID:        uuid.New().String(),
+		UserID:    u.ID,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+	if err := h.sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("セッションの作成に失敗: %v", err)
+	}
+
+	return &Client{UserID: u.ID, h: h, sessionID: session.ID}
+}
+
+// Response は API レスポンスのステータスコードと本文を保持する。
+type Response struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Do は body を JSON エンコードしてリクエストを送り、レスポンスを返す。body が nil の場合は本文を送らない。
+func (c *Client) Do(t *testing.T, method, path string, body any) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("リクエストボディのエンコードに失敗: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.h.Server.URL+path, reader)
+	if err != nil {
+		t.Fatalf("リクエストの生成に失敗: %v", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: c.sessionID})
+
+	resp, err := c.h.Server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s の送信に失敗: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%s %s のレスポンス読み込みに失敗: %v", method, path, err)
+	}
+	return &Response{StatusCode: resp.StatusCode, Body: data}
+}
+
+// MustStatus はステータスコードが want でない場合にレスポンス本文を添えてテストを失敗させる。
+func (r *Response) MustStatus(t *testing.T, want int) *Response {
+	t.Helper()
+	if r.StatusCode != want {
+		t.Fatalf("status = %d, want %d (body: %s)", r.StatusCode, want, r.Body)
+	}
+	return r
+}
+
+// Decode はレスポンス本文を JSON として v にデコードする。
+func (r *Response) Decode(t *testing.T, v any) {
+	t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		t.Fatalf("レスポンスのデコードに失敗: %v (body: %s)", err, r.Body)
+	}
+}
+
+// loopbackAllowedGuard はテスト用フィードサーバー（127.0.0.1）へ到達するための SSRF ガード。
+// http / https 以外のスキームのみを拒否し、宛先アドレスの検証は行わない。
+type loopbackAllowedGuard struct{}
+
+// ValidateURL は URL のスキームのみを検証する。
+func (loopbackAllowedGuard) ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	return nil
+}
+
+// NewSafeClient はタイムアウトのみを設定した HTTP クライアントを返す。
+func (loopbackAllowedGuard) NewSafeClient(timeout time.Duration, _ int64) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+var (
+	_ feed.SSRFValidator     = loopbackAllowedGuard{}
+	_ fetchpkg.SSRFValidator = loopbackAllowedGuard{}
+)
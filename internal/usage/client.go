@@ -0,0 +1,51 @@
+// Package usage はユーザーごとの API 利用量（リクエスト数・エラー数）の集計と参照を提供する。
+//
+// 利用量は Recorder が認証済みリクエストごとにメモリ上のカウンタへ加算し、一定間隔で
+// ユーザー×日×クライアント単位のカウンタテーブル（api_usage_daily）へまとめて加算する。
+// リクエストのレイテンシに DB 書き込みを載せないための構成で、保存に失敗した分は破棄する
+// （統計用途のため欠損を許容する）。
+package usage
+
+import "strings"
+
+const (
+	// ClientBrowser はブラウザ（User-Agent が "Mozilla/" で始まる）からのリクエストのクライアント名。
+	ClientBrowser = "browser"
+	// ClientUnknown は User-Agent が無いリクエストのクライアント名。
+	ClientUnknown = "unknown"
+	// ClientOther は製品名をクライアント名として扱えない User-Agent のクライアント名。
+	ClientOther = "other"
+
+	// maxClientNameLength はクライアント名として扱う製品名の最大長。
+	maxClientNameLength = 32
+)
+
+// ClientName は User-Agent からクライアント名を分類する。
+//
+// ブラウザは browser、User-Agent が無い場合は unknown にまとめ、それ以外は先頭の製品名
+// （"curl/8.0" の "curl" など）を小文字にして返す。製品名が長すぎる・英数字と "-._" 以外を
+// 含む場合は other とし、任意の文字列でクライアントの種類が際限なく増えないようにする。
+func ClientName(userAgent string) string {
+	ua := strings.TrimSpace(userAgent)
+	if ua == "" {
+		return ClientUnknown
+	}
+	if strings.HasPrefix(ua, "Mozilla/") {
+		return ClientBrowser
+	}
+
+	product := ua
+	if i := strings.IndexAny(ua, "/ ("); i >= 0 {
+		product = ua[:i]
+	}
+	product = strings.ToLower(product)
+	if product == "" || len(product) > maxClientNameLength {
+		return ClientOther
+	}
+	for _, r := range product {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '.' && r != '_' {
+			return ClientOther
+		}
+	}
+	return product
+}
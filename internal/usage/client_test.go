@@ -0,0 +1,30 @@
+package usage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClientName(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{name: "ブラウザのときbrowser", userAgent: "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36", want: ClientBrowser},
+		{name: "User-Agentが空のときunknown", userAgent: "  ", want: ClientUnknown},
+		{name: "製品名とバージョンのとき製品名を小文字で返す", userAgent: "Feedman-CLI/1.2.0", want: "feedman-cli"},
+		{name: "製品名のみのとき製品名を返す", userAgent: "python-requests", want: "python-requests"},
+		{name: "製品名に空白が続くとき空白の前までを返す", userAgent: "okhttp (android)", want: "okhttp"},
+		{name: "製品名に使えない文字を含むときother", userAgent: "ゆーざー/1.0", want: ClientOther},
+		{name: "製品名が長すぎるときother", userAgent: strings.Repeat("a", maxClientNameLength+1) + "/1.0", want: ClientOther},
+		{name: "先頭が区切り文字のときother", userAgent: "/1.0", want: ClientOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClientName(tt.userAgent); got != tt.want {
+				t.Errorf("ClientName(%q) = %q, want %q", tt.userAgent, got, tt.want)
+			}
+		})
+	}
+}
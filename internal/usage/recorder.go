@@ -0,0 +1,151 @@
+package usage
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	// DefaultFlushInterval はメモリ上のカウンタを保存する既定の間隔。
+	DefaultFlushInterval = time.Minute
+	// maxPendingCounters は保存前に保持するカウンタ（ユーザー×日×クライアント）の上限数。
+	// 保存の失敗が続いてもメモリを使い続けないよう、超過分のリクエストは集計しない。
+	maxPendingCounters = 10000
+	// flushTimeout はシャットダウン時の最終保存に許容する時間。
+	flushTimeout = 5 * time.Second
+)
+
+// counterKey はメモリ上のカウンタのキー。
+type counterKey struct {
+	userID string
+	date   time.Time
+	client string
+}
+
+// Recorder は認証済みリクエストの利用量をメモリ上で集計し、定期的にカウンタテーブルへ加算する。
+// RecordRequest はノンブロッキングで、保存は Run を実行する goroutine が行う。
+type Recorder struct {
+	repo          repository.APIUsageRepository
+	flushInterval time.Duration
+	logger        *slog.Logger
+	now           func() time.Time
+
+	mu         sync.Mutex
+	counters   map[counterKey]*model.APIUsage
+	dropped    bool
+	lastPruned time.Time
+}
+
+// NewRecorder は Recorder を生成する。flushInterval が 0 以下の場合は DefaultFlushInterval を用いる。
+func NewRecorder(repo repository.APIUsageRepository, flushInterval time.Duration, logger *slog.Logger) *Recorder {
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Recorder{
+		repo:          repo,
+		flushInterval: flushInterval,
+		logger:        logger,
+		now:           time.Now,
+		counters:      make(map[counterKey]*model.APIUsage),
+	}
+}
+
+// RecordRequest は 1 リクエスト分の利用量を加算する。status が 400 以上の場合はエラーとして数える。
+func (r *Recorder) RecordRequest(userID, userAgent string, status int) {
+	key := counterKey{userID: userID, date: model.UsageDate(r.now()), client: ClientName(userAgent)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.counters[key]
+	if !ok {
+		if len(r.counters) >= maxPendingCounters {
+			r.dropped = true
+			return
+		}
+		c = &model.APIUsage{UserID: key.userID, Date: key.date, Client: key.client}
+		r.counters[key] = c
+	}
+	c.RequestCount++
+	if status >= 400 {
+		c.ErrorCount++
+	}
+}
+
+// Run は flushInterval ごとにカウンタを保存する。
+// ctx がキャンセルされると未保存のカウンタを保存してから戻る。
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			r.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			r.flush(ctx)
+			r.prune(ctx)
+		}
+	}
+}
+
+// flush はメモリ上のカウンタを取り出して保存する。保存に失敗したカウンタは再試行せず破棄する。
+func (r *Recorder) flush(ctx context.Context) {
+	r.mu.Lock()
+	counters, dropped := r.counters, r.dropped
+	r.counters = make(map[counterKey]*model.APIUsage, len(counters))
+	r.dropped = false
+	r.mu.Unlock()
+
+	if dropped {
+		r.logger.Warn("API 利用量の未保存カウンタが上限に達したため一部のリクエストを集計しませんでした",
+			slog.Int("max_pending_counters", maxPendingCounters),
+		)
+	}
+	if len(counters) == 0 {
+		return
+	}
+
+	usages := make([]model.APIUsage, 0, len(counters))
+	for _, c := range counters {
+		usages = append(usages, *c)
+	}
+	if err := r.repo.AddUsage(ctx, usages); err != nil {
+		r.logger.Error("API 利用量の保存に失敗しました",
+			slog.Int("count", len(usages)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// prune は保持期間（model.APIUsageRetention）を過ぎたカウンタを 1 日 1 回削除する。
+func (r *Recorder) prune(ctx context.Context) {
+	today := model.UsageDate(r.now())
+	if !r.lastPruned.Before(today) {
+		return
+	}
+	r.lastPruned = today
+
+	deleted, err := r.repo.DeleteUsageBefore(ctx, today.Add(-model.APIUsageRetention))
+	if err != nil {
+		r.logger.Error("保持期間を過ぎた API 利用量の削除に失敗しました",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if deleted > 0 {
+		r.logger.Info("保持期間を過ぎた API 利用量を削除しました",
+			slog.Int64("deleted_count", deleted),
+		)
+	}
+}
@@ -0,0 +1,198 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockAPIUsageRepo は repository.APIUsageRepository のテスト用モック。
+type mockAPIUsageRepo struct {
+	mu        sync.Mutex
+	added     [][]model.APIUsage
+	addErr    error
+	deleted   []time.Time
+	listFn    func(ctx context.Context, userID string, since time.Time) ([]model.APIUsage, error)
+	listSince time.Time
+}
+
+func (m *mockAPIUsageRepo) AddUsage(_ context.Context, usages []model.APIUsage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.added = append(m.added, append([]model.APIUsage(nil), usages...))
+	return m.addErr
+}
+
+func (m *mockAPIUsageRepo) ListUsageByUser(ctx context.Context, userID string, since time.Time) ([]model.APIUsage, error) {
+	m.listSince = since
+	if m.listFn != nil {
+		return m.listFn(ctx, userID, since)
+	}
+	return nil, nil
+}
+
+func (m *mockAPIUsageRepo) DeleteUsageBefore(_ context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleted = append(m.deleted, before)
+	return 0, nil
+}
+
+// sortedUsages は AddUsage に渡された利用量をユーザー・日付・クライアント名の順に並べて返す。
+func sortedUsages(usages []model.APIUsage) []model.APIUsage {
+	sorted := append([]model.APIUsage(nil), usages...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Client < b.Client
+	})
+	return sorted
+}
+
+func TestRecorder(t *testing.T) {
+	now := time.Date(2026, 7, 3, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 7, 3, 0, 0, 0, 0, time.UTC)
+
+	newRecorder := func(repo *mockAPIUsageRepo) *Recorder {
+		r := NewRecorder(repo, time.Hour, nil)
+		r.now = func() time.Time { return now }
+		return r
+	}
+
+	t.Run("ユーザー・日・クライアントごとにリクエスト数とエラー数を集計して保存する", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{}
+		r := newRecorder(repo)
+		r.RecordRequest("user-1", "Mozilla/5.0", 200)
+		r.RecordRequest("user-1", "Mozilla/5.0", 404)
+		r.RecordRequest("user-1", "curl/8.0", 500)
+		r.RecordRequest("user-2", "Mozilla/5.0", 304)
+
+		// Act
+		r.flush(context.Background())
+
+		// Assert
+		if len(repo.added) != 1 {
+			t.Fatalf("AddUsage calls = %d, want 1", len(repo.added))
+		}
+		got := sortedUsages(repo.added[0])
+		want := []model.APIUsage{
+			{UserID: "user-1", Date: today, Client: ClientBrowser, RequestCount: 2, ErrorCount: 1},
+			{UserID: "user-1", Date: today, Client: "curl", RequestCount: 1, ErrorCount: 1},
+			{UserID: "user-2", Date: today, Client: ClientBrowser, RequestCount: 1, ErrorCount: 0},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("usages = %+v, want %+v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("usages[%d] = %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("保存後は集計をやり直し同じ分を二重に加算しない", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{}
+		r := newRecorder(repo)
+		r.RecordRequest("user-1", "", 200)
+		r.flush(context.Background())
+
+		// Act
+		r.flush(context.Background())
+		r.RecordRequest("user-1", "", 200)
+		r.flush(context.Background())
+
+		// Assert
+		if len(repo.added) != 2 {
+			t.Fatalf("AddUsage calls = %d, want 2", len(repo.added))
+		}
+		if got := repo.added[1]; len(got) != 1 || got[0].RequestCount != 1 || got[0].Client != ClientUnknown {
+			t.Errorf("2 回目の保存 = %+v", got)
+		}
+	})
+
+	t.Run("保存に失敗したとき再試行せずに破棄する", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{addErr: errors.New("db down")}
+		r := newRecorder(repo)
+		r.RecordRequest("user-1", "", 200)
+		r.flush(context.Background())
+		repo.addErr = nil
+
+		// Act
+		r.flush(context.Background())
+
+		// Assert
+		if len(repo.added) != 1 {
+			t.Errorf("AddUsage calls = %d, want 1", len(repo.added))
+		}
+	})
+
+	t.Run("未保存のカウンタが上限に達したとき新しいカウンタを作らない", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{}
+		r := newRecorder(repo)
+		for i := range maxPendingCounters {
+			r.counters[counterKey{userID: string(rune(i)), date: today, client: ClientBrowser}] = &model.APIUsage{}
+		}
+
+		// Act
+		r.RecordRequest("user-new", "", 200)
+
+		// Assert
+		if len(r.counters) != maxPendingCounters || !r.dropped {
+			t.Errorf("counters = %d, dropped = %v", len(r.counters), r.dropped)
+		}
+	})
+
+	t.Run("保持期間を過ぎたカウンタの削除は1日1回だけ行う", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{}
+		r := newRecorder(repo)
+
+		// Act
+		r.prune(context.Background())
+		r.prune(context.Background())
+
+		// Assert
+		if len(repo.deleted) != 1 {
+			t.Fatalf("DeleteUsageBefore calls = %d, want 1", len(repo.deleted))
+		}
+		if want := today.Add(-model.APIUsageRetention); !repo.deleted[0].Equal(want) {
+			t.Errorf("before = %v, want %v", repo.deleted[0], want)
+		}
+	})
+
+	t.Run("停止したとき未保存のカウンタを保存してから戻る", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{}
+		r := newRecorder(repo)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			r.Run(ctx)
+			close(done)
+		}()
+		r.RecordRequest("user-1", "", 200)
+
+		// Act
+		cancel()
+		<-done
+
+		// Assert
+		if len(repo.added) != 1 || repo.added[0][0].RequestCount != 1 {
+			t.Errorf("added = %+v", repo.added)
+		}
+	})
+}
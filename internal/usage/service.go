@@ -0,0 +1,70 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// DailyUsage は API 利用量の 1 日分。Clients はクライアント名の昇順。
+type DailyUsage struct {
+	Date         time.Time
+	RequestCount int64
+	ErrorCount   int64
+	Clients      []model.APIUsage
+}
+
+// UsageResult は直近 Days 日の API 利用量。Series は古い日から順に、利用の無い日も含めて Days 件。
+type UsageResult struct {
+	Days         int
+	Since        time.Time
+	RequestCount int64
+	ErrorCount   int64
+	Series       []DailyUsage
+}
+
+// Service は API 利用量の参照サービス。
+type Service struct {
+	repo repository.APIUsageRepository
+	now  func() time.Time
+}
+
+// NewService は Service を生成する。
+func NewService(repo repository.APIUsageRepository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Daily は当日（UTC）を含む直近 model.APIUsageDays 日の利用量を日別・クライアント別に返す。
+// Recorder の保存間隔の分だけ、直近のリクエストは反映が遅れる。
+func (s *Service) Daily(ctx context.Context, userID string) (*UsageResult, error) {
+	days := model.APIUsageDays
+	since := model.UsageDate(s.now()).AddDate(0, 0, -(days - 1))
+	series := make([]DailyUsage, days)
+	index := make(map[time.Time]int, days)
+	for i := range series {
+		date := since.AddDate(0, 0, i)
+		series[i] = DailyUsage{Date: date, Clients: []model.APIUsage{}}
+		index[date] = i
+	}
+
+	usages, err := s.repo.ListUsageByUser(ctx, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("API 利用量の取得に失敗しました: %w", err)
+	}
+	result := &UsageResult{Days: days, Since: since, Series: series}
+	for _, u := range usages {
+		i, ok := index[u.Date]
+		if !ok {
+			continue
+		}
+		series[i].RequestCount += u.RequestCount
+		series[i].ErrorCount += u.ErrorCount
+		series[i].Clients = append(series[i].Clients, u)
+		result.RequestCount += u.RequestCount
+		result.ErrorCount += u.ErrorCount
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestService_Daily(t *testing.T) {
+	now := time.Date(2026, 7, 3, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 7, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("当日を含む直近30日を利用の無い日も含めて古い順に返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{
+			listFn: func(context.Context, string, time.Time) ([]model.APIUsage, error) {
+				return []model.APIUsage{
+					{Date: today.AddDate(0, 0, -1), Client: ClientBrowser, RequestCount: 10, ErrorCount: 1},
+					{Date: today, Client: ClientBrowser, RequestCount: 5},
+					{Date: today, Client: "curl", RequestCount: 3, ErrorCount: 3},
+				}, nil
+			},
+		}
+		svc := NewService(repo)
+		svc.now = func() time.Time { return now }
+
+		// Act
+		got, err := svc.Daily(context.Background(), "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wantSince := today.AddDate(0, 0, -(model.APIUsageDays - 1))
+		if got.Days != model.APIUsageDays || !got.Since.Equal(wantSince) || !repo.listSince.Equal(wantSince) {
+			t.Errorf("days = %d, since = %v (repo %v), want %d, %v", got.Days, got.Since, repo.listSince, model.APIUsageDays, wantSince)
+		}
+		if len(got.Series) != model.APIUsageDays {
+			t.Fatalf("len(series) = %d, want %d", len(got.Series), model.APIUsageDays)
+		}
+		if first := got.Series[0]; !first.Date.Equal(wantSince) || first.RequestCount != 0 || first.Clients == nil {
+			t.Errorf("series[0] = %+v", first)
+		}
+		yesterday, last := got.Series[model.APIUsageDays-2], got.Series[model.APIUsageDays-1]
+		if yesterday.RequestCount != 10 || yesterday.ErrorCount != 1 {
+			t.Errorf("yesterday = %+v", yesterday)
+		}
+		if !last.Date.Equal(today) || last.RequestCount != 8 || last.ErrorCount != 3 || len(last.Clients) != 2 {
+			t.Errorf("today = %+v", last)
+		}
+		if got.RequestCount != 18 || got.ErrorCount != 4 {
+			t.Errorf("total = (%d, %d), want (18, 4)", got.RequestCount, got.ErrorCount)
+		}
+	})
+
+	t.Run("取得に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		repo := &mockAPIUsageRepo{
+			listFn: func(context.Context, string, time.Time) ([]model.APIUsage, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		svc := NewService(repo)
+
+		// Act
+		_, err := svc.Daily(context.Background(), "user-1")
+
+		// Assert
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
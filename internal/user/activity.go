@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	// DefaultActivityThrottle は同一ユーザーの最終アクティブ日時を更新する最小間隔の既定値。
+	// 認証済みリクエストごとに users を更新しないよう、この間隔内の 2 回目以降の記録は読み捨てる。
+	DefaultActivityThrottle = 15 * time.Minute
+)
+
+// ActivityTracker は認証済みリクエストのたびに呼ばれ、ユーザーの最終アクティブ日時を間引いて記録する。
+// 休眠から復帰したユーザーの購読フィードは記録時に次回フェッチを前倒しし、すぐに新着を取り込ませる。
+type ActivityTracker struct {
+	repo         repository.UserActivityRepository
+	throttle     time.Duration
+	dormantAfter time.Duration
+	logger       *slog.Logger
+	now          func() time.Time
+
+	mu         sync.Mutex
+	lastTouch  map[string]time.Time
+	lastPruned time.Time
+}
+
+// NewActivityTracker は ActivityTracker を生成する。
+// dormantAfter は休眠とみなす非アクティブ期間で、0 以下の場合は復帰時の前倒しを行わない。
+// throttle が 0 以下の場合は DefaultActivityThrottle を用いる。
+func NewActivityTracker(repo repository.UserActivityRepository, throttle, dormantAfter time.Duration, logger *slog.Logger) *ActivityTracker {
+	if throttle <= 0 {
+		throttle = DefaultActivityThrottle
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ActivityTracker{
+		repo:         repo,
+		throttle:     throttle,
+		dormantAfter: dormantAfter,
+		logger:       logger,
+		now:          time.Now,
+		lastTouch:    make(map[string]time.Time),
+	}
+}
+
+// TouchActivity はユーザーの最終アクティブ日時を記録する。
+// このプロセスで throttle 以内に記録済みのユーザーは何もしない。記録に失敗した場合は警告ログのみ出力し、
+// 次のリクエストで再度記録を試みる。リクエストの中断で記録が途切れないよう ctx のキャンセルは引き継がない。
+func (t *ActivityTracker) TouchActivity(ctx context.Context, userID string) {
+	now := t.now()
+	if !t.reserve(userID, now) {
+		return
+	}
+
+	dormantBefore := time.Time{}
+	if t.dormantAfter > 0 {
+		dormantBefore = now.Add(-t.dormantAfter)
+	}
+	reactivated, err := t.repo.TouchLastActive(context.WithoutCancel(ctx), userID, now, dormantBefore)
+	if err != nil {
+		t.release(userID)
+		t.logger.Warn("最終アクティブ日時の記録に失敗しました",
+			slog.String("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if reactivated {
+		t.logger.Info("休眠ユーザーが復帰したため購読フィードの次回フェッチを前倒ししました",
+			slog.String("user_id", userID),
+		)
+	}
+}
+
+// reserve は userID を now に記録済みとし、記録を行うべきかを返す。
+// 前回の記録から throttle が経過していない場合は false を返す。
+func (t *ActivityTracker) reserve(userID string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.lastTouch[userID]; ok && now.Sub(last) < t.throttle {
+		return false
+	}
+	t.lastTouch[userID] = now
+
+	// throttle を過ぎたエントリは間引きに使わないため、throttle ごとにまとめて捨てる
+	if now.Sub(t.lastPruned) >= t.throttle {
+		for id, last := range t.lastTouch {
+			if now.Sub(last) >= t.throttle {
+				delete(t.lastTouch, id)
+			}
+		}
+		t.lastPruned = now
+	}
+	return true
+}
+
+// release は記録に失敗した userID の記録済み状態を取り消す。
+func (t *ActivityTracker) release(userID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.lastTouch, userID)
+}
@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// touchCall は mockUserActivityRepo に記録された呼び出し 1 回分。
+type touchCall struct {
+	userID        string
+	now           time.Time
+	dormantBefore time.Time
+}
+
+// mockUserActivityRepo は repository.UserActivityRepository のモック実装。
+type mockUserActivityRepo struct {
+	calls       []touchCall
+	reactivated bool
+	err         error
+}
+
+func (m *mockUserActivityRepo) TouchLastActive(_ context.Context, userID string, now, dormantBefore time.Time) (bool, error) {
+	m.calls = append(m.calls, touchCall{userID: userID, now: now, dormantBefore: dormantBefore})
+	return m.reactivated, m.err
+}
+
+func TestActivityTracker_TouchActivity(t *testing.T) {
+	base := time.Date(2026, 7, 14, 9, 0, 0, 0, time.UTC)
+	newTracker := func(repo *mockUserActivityRepo, now *time.Time) *ActivityTracker {
+		tracker := NewActivityTracker(repo, 15*time.Minute, 90*24*time.Hour, nil)
+		tracker.now = func() time.Time { return *now }
+		return tracker
+	}
+
+	t.Run("初回は最終アクティブ日時と休眠判定の基準日時を渡して記録する", func(t *testing.T) {
+		// Arrange
+		repo := &mockUserActivityRepo{}
+		now := base
+		tracker := newTracker(repo, &now)
+
+		// Act
+		tracker.TouchActivity(context.Background(), "user-1")
+
+		// Assert
+		if len(repo.calls) != 1 {
+			t.Fatalf("calls = %d, want 1", len(repo.calls))
+		}
+		want := touchCall{userID: "user-1", now: base, dormantBefore: base.Add(-90 * 24 * time.Hour)}
+		if repo.calls[0] != want {
+			t.Errorf("call = %+v, want %+v", repo.calls[0], want)
+		}
+	})
+
+	t.Run("間引き間隔内の2回目は記録せず経過後に再度記録する", func(t *testing.T) {
+		// Arrange
+		repo := &mockUserActivityRepo{}
+		now := base
+		tracker := newTracker(repo, &now)
+
+		// Act
+		tracker.TouchActivity(context.Background(), "user-1")
+		now = base.Add(14 * time.Minute)
+		tracker.TouchActivity(context.Background(), "user-1")
+		tracker.TouchActivity(context.Background(), "user-2")
+		now = base.Add(15 * time.Minute)
+		tracker.TouchActivity(context.Background(), "user-1")
+
+		// Assert
+		if len(repo.calls) != 3 {
+			t.Fatalf("calls = %d, want 3", len(repo.calls))
+		}
+		if repo.calls[1].userID != "user-2" || repo.calls[2].userID != "user-1" {
+			t.Errorf("calls = %+v", repo.calls)
+		}
+	})
+
+	t.Run("記録に失敗したとき次のリクエストで再度記録する", func(t *testing.T) {
+		// Arrange
+		repo := &mockUserActivityRepo{err: errors.New("db error")}
+		now := base
+		tracker := newTracker(repo, &now)
+
+		// Act
+		tracker.TouchActivity(context.Background(), "user-1")
+		repo.err = nil
+		tracker.TouchActivity(context.Background(), "user-1")
+
+		// Assert
+		if len(repo.calls) != 2 {
+			t.Errorf("calls = %d, want 2", len(repo.calls))
+		}
+	})
+
+	t.Run("休眠とみなす期間が0のとき復帰判定の基準日時をゼロ値にする", func(t *testing.T) {
+		// Arrange
+		repo := &mockUserActivityRepo{}
+		tracker := NewActivityTracker(repo, 0, 0, nil)
+
+		// Act
+		tracker.TouchActivity(context.Background(), "user-1")
+
+		// Assert
+		if len(repo.calls) != 1 || !repo.calls[0].dormantBefore.IsZero() {
+			t.Errorf("calls = %+v, want one call with zero dormantBefore", repo.calls)
+		}
+	})
+}
@@ -45,6 +45,9 @@ func (m *mockSessionRepo) DeleteByID(ctx context.Context, id string) error {
 func (m *mockSessionRepo) DeleteByUserID(ctx context.Context, userID string) error {
 	return m.deleteByUserIDFn(ctx, userID)
 }
+func (m *mockSessionRepo) DeleteByIdentityID(ctx context.Context, userID, identityID string) error {
+	return nil
+}
 
 type mockSubRepo struct {
 	deleteByUserIDFn func(ctx context.Context, userID string) error
@@ -0,0 +1,181 @@
+// Package usersettings はユーザーごとの表示・通知設定のドメインロジックを提供する。
+//
+// 購読一覧の積読警告（too_many_unread）の閾値、記事本文リンクの開き方（新しいタブで開くか）、
+// 利用時間帯に合わせたプリフェッチのオプトイン、記事一覧の日付グルーピングに用いるタイムゾーンを扱う。
+// 設定は user_settings に保持し、未設定の場合は model.DefaultUnreadWarningThreshold /
+// model.DefaultOpenLinksInNewTab / model.DefaultPrefetchEnabled / model.DefaultTimezone を用いる。
+package usersettings
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	// 実行イメージに OS のタイムゾーンデータベースが無くても IANA 名を解決できるよう埋め込む。
+	_ "time/tzdata"
+
+	"github.com/hitoshi/feedman/internal/cache"
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// Service はユーザー設定のサービス層。
+type Service struct {
+	repo             repository.UserSettingsRepository
+	cacheInvalidator cache.UserInvalidator
+}
+
+// Option は NewService の任意設定を表す functional option。
+type Option func(*Service)
+
+// WithCacheInvalidator は閾値の更新時に当該ユーザーの購読一覧キャッシュ
+// （too_many_unread を含む）を無効化する invalidator を設定する。
+func WithCacheInvalidator(inv cache.UserInvalidator) Option {
+	return func(s *Service) {
+		s.cacheInvalidator = inv
+	}
+}
+
+// NewService は Service の新しいインスタンスを生成する。
+func NewService(repo repository.UserSettingsRepository, opts ...Option) *Service {
+	s := &Service{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetUnreadWarning は当該ユーザーの積読警告設定を返す。
+// 閾値が未設定の場合は既定値（model.DefaultUnreadWarningThreshold）を返す。
+func (s *Service) GetUnreadWarning(ctx context.Context, userID string) (*model.UnreadWarningSetting, error) {
+	threshold, err := s.repo.GetUnreadWarningThreshold(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("積読警告設定の取得に失敗しました: %w", err)
+	}
+	if threshold == nil {
+		return &model.UnreadWarningSetting{UserID: userID, Threshold: model.DefaultUnreadWarningThreshold}, nil
+	}
+	return &model.UnreadWarningSetting{UserID: userID, Threshold: *threshold}, nil
+}
+
+// UpdateUnreadWarning は当該ユーザーの積読警告の閾値を更新する。
+// 閾値は 0（警告無効）から model.MaxUnreadWarningThreshold の範囲で指定する。
+func (s *Service) UpdateUnreadWarning(ctx context.Context, userID string, threshold int) (*model.UnreadWarningSetting, error) {
+	if threshold < 0 || threshold > model.MaxUnreadWarningThreshold {
+		return nil, model.NewInvalidUnreadWarningThresholdError(threshold)
+	}
+
+	if err := s.repo.UpsertUnreadWarningThreshold(ctx, userID, threshold); err != nil {
+		return nil, fmt.Errorf("積読警告設定の更新に失敗しました: %w", err)
+	}
+	if s.cacheInvalidator != nil {
+		s.cacheInvalidator.InvalidateUser(ctx, userID)
+	}
+	return &model.UnreadWarningSetting{UserID: userID, Threshold: threshold}, nil
+}
+
+// GetLinkBehavior は当該ユーザーの記事本文リンクの開き方の設定を返す。
+// 未設定の場合は既定値（model.DefaultOpenLinksInNewTab）を返す。
+func (s *Service) GetLinkBehavior(ctx context.Context, userID string) (*model.LinkBehaviorSetting, error) {
+	v, err := s.repo.GetOpenLinksInNewTab(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("リンクの開き方の設定の取得に失敗しました: %w", err)
+	}
+	if v == nil {
+		return &model.LinkBehaviorSetting{UserID: userID, OpenInNewTab: model.DefaultOpenLinksInNewTab}, nil
+	}
+	return &model.LinkBehaviorSetting{UserID: userID, OpenInNewTab: *v}, nil
+}
+
+// UpdateLinkBehavior は当該ユーザーの記事本文リンクの開き方の設定を更新する。
+func (s *Service) UpdateLinkBehavior(ctx context.Context, userID string, openInNewTab bool) (*model.LinkBehaviorSetting, error) {
+	if err := s.repo.UpsertOpenLinksInNewTab(ctx, userID, openInNewTab); err != nil {
+		return nil, fmt.Errorf("リンクの開き方の設定の更新に失敗しました: %w", err)
+	}
+	return &model.LinkBehaviorSetting{UserID: userID, OpenInNewTab: openInNewTab}, nil
+}
+
+// OpenLinksInNewTab は当該ユーザーが記事本文のリンクを新しいタブで開く設定かを返す。
+// item.LinkPreference の実装として記事詳細の応答時に参照される。
+func (s *Service) OpenLinksInNewTab(ctx context.Context, userID string) (bool, error) {
+	setting, err := s.GetLinkBehavior(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return setting.OpenInNewTab, nil
+}
+
+// GetPrefetch は当該ユーザーの利用時間帯に合わせたプリフェッチの設定を返す。
+// 未設定の場合は既定値（model.DefaultPrefetchEnabled）を返す。
+func (s *Service) GetPrefetch(ctx context.Context, userID string) (*model.PrefetchSetting, error) {
+	v, err := s.repo.GetPrefetchEnabled(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("プリフェッチ設定の取得に失敗しました: %w", err)
+	}
+	if v == nil {
+		return &model.PrefetchSetting{UserID: userID, Enabled: model.DefaultPrefetchEnabled}, nil
+	}
+	return &model.PrefetchSetting{UserID: userID, Enabled: *v}, nil
+}
+
+// UpdatePrefetch は当該ユーザーの利用時間帯に合わせたプリフェッチの設定を更新する。
+// 有効にすると、worker は購読フィードの次回フェッチをこのユーザーがよく読む時間帯の直前に前倒しする。
+func (s *Service) UpdatePrefetch(ctx context.Context, userID string, enabled bool) (*model.PrefetchSetting, error) {
+	if err := s.repo.UpsertPrefetchEnabled(ctx, userID, enabled); err != nil {
+		return nil, fmt.Errorf("プリフェッチ設定の更新に失敗しました: %w", err)
+	}
+	return &model.PrefetchSetting{UserID: userID, Enabled: enabled}, nil
+}
+
+// GetTimezone は当該ユーザーのタイムゾーン設定を返す。
+// 未設定の場合は既定値（model.DefaultTimezone）を返す。
+func (s *Service) GetTimezone(ctx context.Context, userID string) (*model.TimezoneSetting, error) {
+	v, err := s.repo.GetTimezone(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("タイムゾーン設定の取得に失敗しました: %w", err)
+	}
+	if v == nil {
+		return &model.TimezoneSetting{UserID: userID, Timezone: model.DefaultTimezone}, nil
+	}
+	return &model.TimezoneSetting{UserID: userID, Timezone: *v}, nil
+}
+
+// UpdateTimezone は当該ユーザーのタイムゾーンを更新する。
+// IANA タイムゾーン名として解決できない値（空文字・"Local" を含む）は INVALID_TIMEZONE を返す。
+func (s *Service) UpdateTimezone(ctx context.Context, userID, timezone string) (*model.TimezoneSetting, error) {
+	if _, err := loadTimezone(timezone); err != nil {
+		return nil, model.NewInvalidTimezoneError(timezone)
+	}
+	if err := s.repo.UpsertTimezone(ctx, userID, timezone); err != nil {
+		return nil, fmt.Errorf("タイムゾーン設定の更新に失敗しました: %w", err)
+	}
+	return &model.TimezoneSetting{UserID: userID, Timezone: timezone}, nil
+}
+
+// Location は当該ユーザーのタイムゾーンを *time.Location として返す。
+// item.TimezoneResolver の実装として記事一覧の日付グルーピングで参照される。
+// 保存済みの値が解決できない場合（タイムゾーンデータベースからの削除など）は UTC として扱う。
+func (s *Service) Location(ctx context.Context, userID string) (*time.Location, error) {
+	setting, err := s.GetTimezone(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := loadTimezone(setting.Timezone)
+	if err != nil {
+		slog.Warn("保存済みのタイムゾーンを解決できないため UTC として扱います",
+			slog.String("user_id", userID),
+			slog.String("timezone", setting.Timezone),
+		)
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// loadTimezone は IANA タイムゾーン名を解決する。
+// time.LoadLocation が特別扱いする空文字（UTC）と "Local"（サーバーのローカル時刻）はユーザー設定として受け付けない。
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unsupported timezone: %q", name)
+	}
+	return time.LoadLocation(name)
+}
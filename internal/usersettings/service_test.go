@@ -0,0 +1,416 @@
+package usersettings
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// --- テスト用モック ---
+
+// mockUserSettingsRepo は UserSettingsRepository のモック。
+type mockUserSettingsRepo struct {
+	getThresholdFn    func(ctx context.Context, userID string) (*int, error)
+	upsertThresholdFn func(ctx context.Context, userID string, threshold int) error
+	upsertCalled      bool
+	upsertCalledWith  int
+
+	openLinksInNewTab *bool
+	upsertLinkErr     error
+
+	prefetchEnabled   *bool
+	upsertPrefetchErr error
+
+	timezone          *string
+	upsertTimezoneErr error
+}
+
+func (m *mockUserSettingsRepo) GetUnreadWarningThreshold(ctx context.Context, userID string) (*int, error) {
+	if m.getThresholdFn != nil {
+		return m.getThresholdFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockUserSettingsRepo) UpsertUnreadWarningThreshold(ctx context.Context, userID string, threshold int) error {
+	m.upsertCalled = true
+	m.upsertCalledWith = threshold
+	if m.upsertThresholdFn != nil {
+		return m.upsertThresholdFn(ctx, userID, threshold)
+	}
+	return nil
+}
+
+func (m *mockUserSettingsRepo) GetOpenLinksInNewTab(ctx context.Context, userID string) (*bool, error) {
+	return m.openLinksInNewTab, nil
+}
+
+func (m *mockUserSettingsRepo) UpsertOpenLinksInNewTab(ctx context.Context, userID string, openInNewTab bool) error {
+	if m.upsertLinkErr != nil {
+		return m.upsertLinkErr
+	}
+	m.openLinksInNewTab = &openInNewTab
+	return nil
+}
+
+func (m *mockUserSettingsRepo) GetPrefetchEnabled(ctx context.Context, userID string) (*bool, error) {
+	return m.prefetchEnabled, nil
+}
+
+func (m *mockUserSettingsRepo) UpsertPrefetchEnabled(ctx context.Context, userID string, enabled bool) error {
+	if m.upsertPrefetchErr != nil {
+		return m.upsertPrefetchErr
+	}
+	m.prefetchEnabled = &enabled
+	return nil
+}
+
+func (m *mockUserSettingsRepo) GetTimezone(ctx context.Context, userID string) (*string, error) {
+	return m.timezone, nil
+}
+
+func (m *mockUserSettingsRepo) UpsertTimezone(ctx context.Context, userID, timezone string) error {
+	if m.upsertTimezoneErr != nil {
+		return m.upsertTimezoneErr
+	}
+	m.timezone = &timezone
+	return nil
+}
+
+var _ repository.UserSettingsRepository = (*mockUserSettingsRepo)(nil)
+
+// mockInvalidator は cache.UserInvalidator のモック。
+type mockInvalidator struct {
+	invalidated []string
+}
+
+func (m *mockInvalidator) InvalidateUser(_ context.Context, userID string) {
+	m.invalidated = append(m.invalidated, userID)
+}
+
+// --- GetUnreadWarning テスト ---
+
+func TestGetUnreadWarning(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("閾値が未設定のとき既定値を返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{})
+
+		// Act
+		got, err := svc.GetUnreadWarning(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Threshold != model.DefaultUnreadWarningThreshold {
+			t.Errorf("Threshold = %d, want %d", got.Threshold, model.DefaultUnreadWarningThreshold)
+		}
+		if !got.Enabled() {
+			t.Error("既定設定では警告が有効であるべき")
+		}
+	})
+
+	t.Run("閾値が設定済みのときその値を返す", func(t *testing.T) {
+		// Arrange
+		threshold := 0
+		svc := NewService(&mockUserSettingsRepo{
+			getThresholdFn: func(ctx context.Context, userID string) (*int, error) { return &threshold, nil },
+		})
+
+		// Act
+		got, err := svc.GetUnreadWarning(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Threshold != 0 {
+			t.Errorf("Threshold = %d, want 0", got.Threshold)
+		}
+		if got.Enabled() {
+			t.Error("閾値 0 では警告が無効であるべき")
+		}
+	})
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{
+			getThresholdFn: func(ctx context.Context, userID string) (*int, error) { return nil, errors.New("db error") },
+		})
+
+		// Act
+		_, err := svc.GetUnreadWarning(ctx, "user-1")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// --- UpdateUnreadWarning テスト ---
+
+func TestUpdateUnreadWarning(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		threshold  int
+		wantReject bool
+	}{
+		{"負値のとき拒否", -1, true},
+		{"0のとき受理（警告無効）", 0, false},
+		{"中間値のとき受理", 300, false},
+		{"上限のとき受理", model.MaxUnreadWarningThreshold, false},
+		{"上限超過のとき拒否", model.MaxUnreadWarningThreshold + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := &mockUserSettingsRepo{}
+			svc := NewService(repo)
+
+			// Act
+			got, err := svc.UpdateUnreadWarning(ctx, "user-1", tt.threshold)
+
+			// Assert
+			if tt.wantReject {
+				var apiErr *model.APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidUnreadWarningThreshold {
+					t.Fatalf("expected INVALID_UNREAD_WARNING_THRESHOLD, got %v", err)
+				}
+				if repo.upsertCalled {
+					t.Error("検証エラー時は保存されるべきでない")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Threshold != tt.threshold {
+				t.Errorf("Threshold = %d, want %d", got.Threshold, tt.threshold)
+			}
+			if repo.upsertCalledWith != tt.threshold {
+				t.Errorf("保存された閾値 = %d, want %d", repo.upsertCalledWith, tt.threshold)
+			}
+		})
+	}
+
+	t.Run("リポジトリがエラーを返したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{
+			upsertThresholdFn: func(ctx context.Context, userID string, threshold int) error { return errors.New("db error") },
+		})
+
+		// Act
+		_, err := svc.UpdateUnreadWarning(ctx, "user-1", 100)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("更新に成功したとき購読一覧キャッシュを無効化する", func(t *testing.T) {
+		// Arrange
+		inv := &mockInvalidator{}
+		svc := NewService(&mockUserSettingsRepo{}, WithCacheInvalidator(inv))
+
+		// Act
+		if _, err := svc.UpdateUnreadWarning(ctx, "user-1", 100); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Assert
+		if len(inv.invalidated) != 1 || inv.invalidated[0] != "user-1" {
+			t.Errorf("invalidated = %v, want [user-1]", inv.invalidated)
+		}
+	})
+}
+
+func TestLinkBehavior(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("未設定のとき既定値（新しいタブで開く）を返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{})
+
+		// Act
+		got, err := svc.GetLinkBehavior(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.OpenInNewTab != model.DefaultOpenLinksInNewTab {
+			t.Errorf("OpenInNewTab = %v, want %v", got.OpenInNewTab, model.DefaultOpenLinksInNewTab)
+		}
+	})
+
+	t.Run("更新した設定がOpenLinksInNewTabに反映される", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{})
+
+		// Act
+		if _, err := svc.UpdateLinkBehavior(ctx, "user-1", false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := svc.OpenLinksInNewTab(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got {
+			t.Error("OpenLinksInNewTab = true, want false")
+		}
+	})
+
+	t.Run("保存に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{upsertLinkErr: errors.New("db error")})
+
+		// Act
+		_, err := svc.UpdateLinkBehavior(ctx, "user-1", false)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestPrefetch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("未設定のとき既定値（無効）を返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{})
+
+		// Act
+		got, err := svc.GetPrefetch(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Enabled != model.DefaultPrefetchEnabled {
+			t.Errorf("Enabled = %v, want %v", got.Enabled, model.DefaultPrefetchEnabled)
+		}
+	})
+
+	t.Run("有効に更新した設定が取得できる", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{})
+
+		// Act
+		if _, err := svc.UpdatePrefetch(ctx, "user-1", true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := svc.GetPrefetch(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !got.Enabled {
+			t.Error("Enabled = false, want true")
+		}
+	})
+
+	t.Run("保存に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{upsertPrefetchErr: errors.New("db error")})
+
+		// Act
+		_, err := svc.UpdatePrefetch(ctx, "user-1", true)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestTimezone(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("未設定のときUTCを返す", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{})
+
+		// Act
+		got, err := svc.GetTimezone(ctx, "user-1")
+		loc, locErr := svc.Location(ctx, "user-1")
+
+		// Assert
+		if err != nil || locErr != nil {
+			t.Fatalf("unexpected error: %v / %v", err, locErr)
+		}
+		if got.Timezone != model.DefaultTimezone || loc.String() != "UTC" {
+			t.Errorf("Timezone = %q, Location = %v, want UTC", got.Timezone, loc)
+		}
+	})
+
+	t.Run("IANA名に更新したタイムゾーンで日時を解決できる", func(t *testing.T) {
+		// Arrange
+		svc := NewService(&mockUserSettingsRepo{})
+
+		// Act
+		if _, err := svc.UpdateTimezone(ctx, "user-1", "Asia/Tokyo"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		loc, err := svc.Location(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := time.Date(2026, 7, 4, 15, 30, 0, 0, time.UTC).In(loc).Format("2006-01-02"); got != "2026-07-05" {
+			t.Errorf("date in %v = %s, want 2026-07-05", loc, got)
+		}
+	})
+
+	t.Run("解決できない名前のときINVALID_TIMEZONEを返し保存しない", func(t *testing.T) {
+		for _, tz := range []string{"", "Local", "Mars/Olympus"} {
+			// Arrange
+			repo := &mockUserSettingsRepo{}
+			svc := NewService(repo)
+
+			// Act
+			_, err := svc.UpdateTimezone(ctx, "user-1", tz)
+
+			// Assert
+			var apiErr *model.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeInvalidTimezone {
+				t.Errorf("timezone=%q: err = %v, want INVALID_TIMEZONE", tz, err)
+			}
+			if repo.timezone != nil {
+				t.Errorf("timezone=%q: 保存されるべきではない", tz)
+			}
+		}
+	})
+
+	t.Run("保存済みの値が解決できないときUTCとして扱う", func(t *testing.T) {
+		// Arrange
+		stored := "Removed/Zone"
+		svc := NewService(&mockUserSettingsRepo{timezone: &stored})
+
+		// Act
+		loc, err := svc.Location(ctx, "user-1")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loc != time.UTC {
+			t.Errorf("Location = %v, want UTC", loc)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 // Package cleanup は記事データの自動削除ジョブを提供する。
 // 保持期間（デフォルト180日）を超過した記事と関連するitem_statesを
 // 日次バッチで削除する。item_statesはCASCADE削除で自動的に処理される。
+// 全購読者が最大記事保持数（subscriptions.retention_override）を設定しているフィードは、
+// 保持期間の代わりに新しい記事から保持数（購読者間の最大値）を超えた分を削除する。
 package cleanup
 
 import (
@@ -35,8 +37,34 @@ func NewCleanupJob(db Executor, logger *slog.Logger) *CleanupJob {
 	}
 }
 
+// retentionQuery は保持期間と最大記事保持数の上書きを 1 文で適用する削除クエリ。
+// 保持期間は全フィードに適用し、保持数の上書きはそれに加えて件数の上限として適用する（どちらか一方でも超えれば削除）。
+// 上書きは全購読者が設定しているフィードにのみ適用し、保持数は購読者間の最大値を採用する。
+// 未設定の購読者が残るフィードで記事を減らすと、その購読者の期待する保持期間を破るため。
+const retentionQuery = `
+WITH retention_limits AS (
+	SELECT feed_id, MAX(retention_override) AS max_items
+	FROM subscriptions
+	GROUP BY feed_id
+	HAVING COUNT(*) = COUNT(retention_override)
+),
+ranked AS (
+	SELECT i.id, l.max_items,
+		ROW_NUMBER() OVER (
+			PARTITION BY i.feed_id
+			ORDER BY i.published_at DESC NULLS LAST, i.created_at DESC, i.id DESC
+		) AS rn
+	FROM items i
+	JOIN retention_limits l ON l.feed_id = i.feed_id
+)
+DELETE FROM items i
+WHERE i.created_at < now() - $1::interval
+	OR i.id IN (SELECT id FROM ranked WHERE rn > max_items)`
+
 // Run は保持期間を超過した記事を削除する。
 // created_atがRetentionDays日前より古い記事をDELETEする。
+// 最大記事保持数の上書きが適用されるフィードは、保持期間による削除に加えて
+// 公開日時（なければ取り込み日時）の新しい順に保持数を超えた記事もDELETEする。
 // item_statesはCASCADE削除により自動的に削除される。
 // 冪等: 削除対象がない場合でもエラーにならない。
 func (j *CleanupJob) Run(ctx context.Context) error {
@@ -44,7 +72,7 @@ func (j *CleanupJob) Run(ctx context.Context) error {
 
 	interval := fmt.Sprintf("%d days", j.RetentionDays)
 
-	query := `DELETE FROM items WHERE created_at < now() - $1::interval`
+	query := retentionQuery
 	result, err := j.db.ExecContext(ctx, query, interval)
 	if err != nil {
 		j.logger.Error("記事クリーンアップジョブの実行に失敗しました",
@@ -100,6 +100,34 @@ func TestCleanupJob_Run_ExecutesDeleteQuery(t *testing.T) {
 	}
 }
 
+func TestCleanupJob_Run_AppliesRetentionOverride(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	mock := &mockExecutor{
+		result: &fakeResult{rowsAffected: 0},
+	}
+	job := NewCleanupJob(mock, logger)
+
+	_ = job.Run(context.Background())
+
+	// 購読単位の最大記事保持数を参照し、購読者間の最大値を採用すること
+	if !strings.Contains(mock.query, "retention_override") {
+		t.Errorf("クエリに 'retention_override' が含まれていない: %s", mock.query)
+	}
+	if !strings.Contains(mock.query, "MAX(retention_override)") {
+		t.Errorf("クエリが購読者間の最大値を採用していない: %s", mock.query)
+	}
+	// 未設定の購読者がいるフィードには適用しないこと
+	if !strings.Contains(mock.query, "COUNT(*) = COUNT(retention_override)") {
+		t.Errorf("クエリが全購読者の設定を条件にしていない: %s", mock.query)
+	}
+	// 保持数の上書きがあるフィードも保持期間による削除の対象外にしないこと
+	if strings.Contains(mock.query, "NOT EXISTS") {
+		t.Errorf("クエリが保持数の上書きのあるフィードを保持期間の削除から除外している: %s", mock.query)
+	}
+}
+
 func TestCleanupJob_Run_UsesIntervalParameter(t *testing.T) {
 	var buf bytes.Buffer
 	logger := newTestLogger(&buf)
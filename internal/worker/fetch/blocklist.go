@@ -0,0 +1,64 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// blockedFeedMessage はフェッチ時にブロックリストとの一致を検出して停止したフィードに記録するエラーメッセージ。
+const blockedFeedMessage = "インスタンスの管理者によってブロックされたため、フェッチを停止しました"
+
+// BlocklistChecker はインスタンスのブロックリスト（モデレーション）との照合のインターフェース。
+// moderation.Service が実装し、一致した場合は FEED_BLOCKED の APIError を返す。
+type BlocklistChecker interface {
+	CheckURL(ctx context.Context, rawURL string) error
+}
+
+// WithBlocklist はフェッチの前にフィード URL をブロックリストと照合する checker を注入する。
+// 一致したフィードは HTTP リクエストを送らずに error_kind=blocked で停止する。
+// ブロックの追加時に遡及適用を選ばなかったフィードや、追加後に URL が変わったフィードもフェッチ時に停止できる。
+// 未指定時は照合しない。
+func WithBlocklist(checker BlocklistChecker) FetcherOption {
+	return func(f *Fetcher) {
+		f.blocklist = checker
+	}
+}
+
+// checkBlocklist はフィード URL がブロックリストに一致する場合にフィードを停止し、エラーを返す。
+// ブロックリストの取得に失敗した場合はフェッチを妨げないよう、ログのみ出力して nil を返す。
+func (f *Fetcher) checkBlocklist(ctx context.Context, feed *model.Feed) error {
+	if f.blocklist == nil {
+		return nil
+	}
+	err := f.blocklist.CheckURL(ctx, feed.FeedURL)
+	if err == nil {
+		return nil
+	}
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != model.ErrCodeFeedBlocked {
+		f.logger.Warn("ブロックリストとの照合に失敗しました",
+			slog.String("feed_id", feed.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+
+	f.logger.Info("ブロック対象のフィードのフェッチを停止しました",
+		slog.String("feed_id", feed.ID),
+		slog.String("feed_url", feed.FeedURL),
+	)
+	f.metrics.RecordFetchFailure(feed.ID, "blocked")
+	f.metrics.RecordFetchErrorKind(string(model.FetchErrorKindBlocked))
+	ApplyStopFeedWithKind(feed, model.FetchErrorKindBlocked, blockedFeedMessage)
+	if updateErr := f.feedRepo.UpdateFetchState(ctx, feed); updateErr != nil {
+		f.logger.Error("フィード状態の更新に失敗しました",
+			slog.String("feed_id", feed.ID),
+			slog.String("error", updateErr.Error()),
+		)
+	}
+	return fmt.Errorf("ブロック対象のフィード: %w", err)
+}
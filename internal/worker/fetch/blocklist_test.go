@@ -0,0 +1,81 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// stubBlocklist は BlocklistChecker のスタブ。常に err を返す。
+type stubBlocklist struct {
+	err error
+}
+
+func (s stubBlocklist) CheckURL(_ context.Context, _ string) error {
+	return s.err
+}
+
+func TestFetcher_Fetch_Blocklist(t *testing.T) {
+	cases := []struct {
+		name        string
+		checkErr    error
+		wantStopped bool
+	}{
+		{"ブロック対象のときリクエストを送らずblockedで停止する", model.NewFeedBlockedError("https://spam.example/feed.xml"), true},
+		{"ブロック対象でないとき通常どおりフェッチする", nil, false},
+		{"ブロックリストの取得に失敗したときフェッチを妨げない", errors.New("db down"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			var requests atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+			}))
+			defer server.Close()
+			var buf bytes.Buffer
+			f := NewFetcher(
+				&mockFeedRepo{updateFetchStateFunc: func(context.Context, *model.Feed) error { return nil }},
+				&mockSubRepo{minInterval: 60},
+				&mockUpsertService{},
+				&mockSSRFGuard{},
+				newTestLogger(&buf),
+				10*time.Second,
+				5*1024*1024,
+				WithBlocklist(stubBlocklist{err: tc.checkErr}),
+			)
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL + "/feed.xml", FetchStatus: model.FetchStatusActive}
+
+			// Act
+			err := f.Fetch(context.Background(), feed)
+
+			// Assert
+			if !tc.wantStopped {
+				if err != nil {
+					t.Fatalf("Fetch returned error: %v", err)
+				}
+				if requests.Load() != 1 {
+					t.Errorf("requests = %d, want 1", requests.Load())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ブロック対象のフェッチはエラーを返すべき")
+			}
+			if requests.Load() != 0 {
+				t.Errorf("ブロック対象にはリクエストを送るべきではない (requests=%d)", requests.Load())
+			}
+			if feed.FetchStatus != model.FetchStatusStopped || feed.ErrorKind != model.FetchErrorKindBlocked {
+				t.Errorf("status = %q, kind = %q, want stopped / blocked", feed.FetchStatus, feed.ErrorKind)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// Diagnoser はフィードを取得・パースし、取り込み時に問題となりうる点を診断する。
+// フェッチワーカーと同じパース・記事変換ロジックを使うが、DB には一切書き込まない。
+type Diagnoser struct {
+	ssrfGuard   SSRFValidator
+	timeout     time.Duration
+	maxBodySize int64
+	now         func() time.Time
+}
+
+// NewDiagnoser は Diagnoser の新しいインスタンスを生成する。
+// timeout・maxBodySize にはフェッチワーカーと同じ設定値を渡す想定。
+func NewDiagnoser(ssrfGuard SSRFValidator, timeout time.Duration, maxBodySize int64) *Diagnoser {
+	return &Diagnoser{
+		ssrfGuard:   ssrfGuard,
+		timeout:     timeout,
+		maxBodySize: maxBodySize,
+		now:         time.Now,
+	}
+}
+
+// Diagnose は URL または生 XML のどちらか一方を受け取り、パース結果を診断する。
+// URL が指定された場合は SSRF 検証を経てフェッチワーカーと同じ条件で取得する
+// （条件付きGETは行わない）。
+func (d *Diagnoser) Diagnose(ctx context.Context, rawURL, rawXML string) (*model.FeedDiagnosis, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	switch {
+	case rawURL == "" && strings.TrimSpace(rawXML) == "":
+		return nil, model.NewInvalidDebugParseInputError("url または xml が指定されていません")
+	case rawURL != "" && rawXML != "":
+		return nil, model.NewInvalidDebugParseInputError("url と xml は同時に指定できません")
+	}
+
+	if rawURL != "" {
+		body, err := d.fetch(ctx, rawURL)
+		if err != nil {
+			return nil, err
+		}
+		return d.parse(body)
+	}
+
+	if int64(len(rawXML)) > d.maxBodySize {
+		return nil, model.NewInvalidDebugParseInputError(
+			fmt.Sprintf("xml のサイズが上限（%d バイト）を超えています", d.maxBodySize))
+	}
+	return d.parse([]byte(rawXML))
+}
+
+// fetch は診断対象のフィードを取得する。
+// 取得できなかった場合はフィード登録時と同じ APIError（SSRF_BLOCKED / FETCH_FAILED）を返す。
+func (d *Diagnoser) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	if err := d.ssrfGuard.ValidateURL(rawURL); err != nil {
+		return nil, model.NewSSRFBlockedError()
+	}
+
+	client := d.ssrfGuard.NewSafeClient(d.timeout, d.maxBodySize)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, model.NewInvalidURLError(err.Error())
+	}
+	req.Header.Set("User-Agent", "Feedman/1.0 RSS Reader")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml, */*")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		switch ClassifyTransportError(err) {
+		case model.FetchErrorKindSSRF:
+			return nil, model.NewSSRFBlockedError()
+		case model.FetchErrorKindRedirect:
+			return nil, model.NewFeedRedirectLoopError(err.Error())
+		}
+		return nil, model.NewFetchFailedError(err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, model.NewFetchFailedError(fmt.Sprintf("HTTPステータス %d", resp.StatusCode))
+	}
+
+	body, err := readBodyWithLimit(resp.Body, d.maxBodySize)
+	if err != nil {
+		return nil, model.NewFetchFailedError(err.Error())
+	}
+	return body, nil
+}
+
+// parse は gofeed でパースし、フィード全体と記事ごとの警告を付与した診断結果を返す。
+// パースに失敗した場合は PARSE_FAILED に gofeed のエラー内容を Details として添えて返す。
+func (d *Diagnoser) parse(body []byte) (*model.FeedDiagnosis, error) {
+	parsedFeed, err := gofeed.NewParser().ParseString(string(body))
+	if err != nil {
+		apiErr := model.NewParseFailedError()
+		apiErr.Details = map[string]any{"parse_error": err.Error()}
+		return nil, apiErr
+	}
+
+	diag := &model.FeedDiagnosis{
+		FeedType:    parsedFeed.FeedType,
+		FeedVersion: parsedFeed.FeedVersion,
+		Title:       parsedFeed.Title,
+		SiteURL:     parsedFeed.Link,
+		Items:       []model.DiagnosedItem{},
+		Warnings:    []string{},
+	}
+	if parsedFeed.Title == "" {
+		diag.Warnings = append(diag.Warnings, "フィードのタイトルが空です")
+	}
+	if len(parsedFeed.Items) == 0 {
+		diag.Warnings = append(diag.Warnings, "記事が1件も検出されませんでした")
+	}
+
+	// ワーカーと同じ変換を適用する。nil の記事は変換時に除外されるため元記事も揃えておく。
+	sources := make([]*gofeed.Item, 0, len(parsedFeed.Items))
+	for _, item := range parsedFeed.Items {
+		if item != nil {
+			sources = append(sources, item)
+		}
+	}
+	parsedItems := convertGofeedItems(sources)
+
+	// 同一性キー（guid > link）の最終出現位置。取り込み時はバッチ内で後勝ちに集約される。
+	lastIndex := make(map[string]int, len(parsedItems))
+	for i, p := range parsedItems {
+		if key := diagnoseIdentityKey(p); key != "" {
+			lastIndex[key] = i
+		}
+	}
+
+	now := d.now()
+	for i, p := range parsedItems {
+		item := model.DiagnosedItem{
+			GuidOrID:        p.GuidOrID,
+			Title:           p.Title,
+			Link:            p.Link,
+			Author:          p.Author,
+			PublishedAt:     p.PublishedAt,
+			IsDateEstimated: p.PublishedAt == nil,
+			Warnings:        []string{},
+		}
+
+		switch {
+		case p.GuidOrID == "" && p.Link == "":
+			item.Warnings = append(item.Warnings, "GUIDとリンクがないため内容ハッシュで同一性を判定します")
+		case p.GuidOrID == "":
+			item.Warnings = append(item.Warnings, "GUIDがないためリンクで同一性を判定します")
+		}
+		if key := diagnoseIdentityKey(p); key != "" && lastIndex[key] != i {
+			item.Warnings = append(item.Warnings, "同一性キーが後続の記事と重複しているため取り込まれません")
+		}
+		if p.Title == "" {
+			item.Warnings = append(item.Warnings, "タイトルが空です")
+		}
+		if p.Link == "" {
+			item.Warnings = append(item.Warnings, "リンクがありません")
+		}
+
+		src := sources[i]
+		switch {
+		case p.PublishedAt == nil && (src.Published != "" || src.Updated != ""):
+			raw := src.Published
+			if raw == "" {
+				raw = src.Updated
+			}
+			item.Warnings = append(item.Warnings,
+				fmt.Sprintf("日付 %q を解釈できないため取り込み時刻で推定されます", raw))
+		case p.PublishedAt == nil:
+			item.Warnings = append(item.Warnings, "日付がないため取り込み時刻で推定されます")
+		case p.PublishedAt.After(now):
+			item.Warnings = append(item.Warnings, "公開日時が未来の日付です")
+		}
+
+		diag.Items = append(diag.Items, item)
+	}
+
+	return diag, nil
+}
+
+// diagnoseIdentityKey は記事の同一性判定に使われる代表キーを返す。
+// 取り込み時（item.ItemUpsertService）の guid_or_id > link の優先順位に合わせる。
+// どちらも空の場合は内容ハッシュで判定されるため空文字を返す。
+func diagnoseIdentityKey(p model.ParsedItem) string {
+	if p.GuidOrID != "" {
+		return "guid|" + p.GuidOrID
+	}
+	if p.Link != "" {
+		return "link|" + p.Link
+	}
+	return ""
+}
@@ -0,0 +1,240 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+const diagnoseTestRSS = `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel>
+    <title>Diag Feed</title>
+    <link>https://example.com/</link>
+    <item>
+      <title>Article 1</title>
+      <link>https://example.com/1</link>
+      <guid>guid-1</guid>
+      <pubDate>Wed, 01 Jan 2025 00:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title></title>
+      <link>https://example.com/2</link>
+      <pubDate>not a date</pubDate>
+    </item>
+    <item>
+      <title>No link</title>
+      <guid isPermaLink="false">guid-3</guid>
+    </item>
+    <item>
+      <title>Duplicate</title>
+      <link>https://example.com/1-dup</link>
+      <guid>guid-1</guid>
+      <pubDate>Fri, 01 Jan 2100 00:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+func newTestDiagnoser(guard SSRFValidator) *Diagnoser {
+	d := NewDiagnoser(guard, 10*time.Second, 1024*1024)
+	d.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return d
+}
+
+func hasWarning(warnings []string, substr string) bool {
+	for _, w := range warnings {
+		if strings.Contains(w, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDiagnoser_Diagnose_XML(t *testing.T) {
+	// Arrange
+	d := newTestDiagnoser(&mockSSRFGuard{})
+
+	// Act
+	diag, err := d.Diagnose(context.Background(), "", diagnoseTestRSS)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Diagnose() がエラーを返した: %v", err)
+	}
+	if diag.FeedType != "rss" || diag.FeedVersion != "2.0" {
+		t.Errorf("FeedType/FeedVersion = %q/%q, want rss/2.0", diag.FeedType, diag.FeedVersion)
+	}
+	if diag.Title != "Diag Feed" || diag.SiteURL != "https://example.com/" {
+		t.Errorf("Title/SiteURL = %q/%q", diag.Title, diag.SiteURL)
+	}
+	if len(diag.Items) != 4 {
+		t.Fatalf("Items の件数 = %d, want 4", len(diag.Items))
+	}
+
+	t.Run("重複するGUIDの先行記事には取り込まれない旨の警告が付く", func(t *testing.T) {
+		item := diag.Items[0]
+		if item.GuidOrID != "guid-1" || item.PublishedAt == nil || item.IsDateEstimated {
+			t.Errorf("item[0] = %+v", item)
+		}
+		if !hasWarning(item.Warnings, "重複") {
+			t.Errorf("重複警告がない: %v", item.Warnings)
+		}
+	})
+
+	t.Run("GUIDなし・タイトル空・解釈不能な日付のとき各警告が付き日付は推定扱いになる", func(t *testing.T) {
+		item := diag.Items[1]
+		if !item.IsDateEstimated || item.PublishedAt != nil {
+			t.Errorf("日付推定になるべき: %+v", item)
+		}
+		for _, want := range []string{"リンクで同一性", "タイトルが空", `"not a date"`} {
+			if !hasWarning(item.Warnings, want) {
+				t.Errorf("警告 %q がない: %v", want, item.Warnings)
+			}
+		}
+	})
+
+	t.Run("リンクと日付がないとき各警告が付く", func(t *testing.T) {
+		item := diag.Items[2]
+		for _, want := range []string{"リンクがありません", "日付がない"} {
+			if !hasWarning(item.Warnings, want) {
+				t.Errorf("警告 %q がない: %v", want, item.Warnings)
+			}
+		}
+	})
+
+	t.Run("未来の公開日時のとき警告が付き後勝ちの記事には重複警告が付かない", func(t *testing.T) {
+		item := diag.Items[3]
+		if !hasWarning(item.Warnings, "未来") {
+			t.Errorf("未来日付の警告がない: %v", item.Warnings)
+		}
+		if hasWarning(item.Warnings, "重複") {
+			t.Errorf("後勝ちの記事に重複警告が付いている: %v", item.Warnings)
+		}
+	})
+}
+
+func TestDiagnoser_Diagnose_NoItemsFeedWarnings(t *testing.T) {
+	// Arrange
+	d := newTestDiagnoser(&mockSSRFGuard{})
+	xml := `<?xml version="1.0"?><rss version="2.0"><channel></channel></rss>`
+
+	// Act
+	diag, err := d.Diagnose(context.Background(), "", xml)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Diagnose() がエラーを返した: %v", err)
+	}
+	if !hasWarning(diag.Warnings, "タイトルが空") || !hasWarning(diag.Warnings, "記事が1件も") {
+		t.Errorf("フィード単位の警告が不足している: %v", diag.Warnings)
+	}
+}
+
+func TestDiagnoser_Diagnose_URL(t *testing.T) {
+	t.Run("URLのとき取得したフィードを診断する", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/rss+xml")
+			fmt.Fprint(w, diagnoseTestRSS)
+		}))
+		defer server.Close()
+		d := newTestDiagnoser(&mockSSRFGuard{})
+
+		// Act
+		diag, err := d.Diagnose(context.Background(), server.URL, "")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Diagnose() がエラーを返した: %v", err)
+		}
+		if len(diag.Items) != 4 {
+			t.Errorf("Items の件数 = %d, want 4", len(diag.Items))
+		}
+	})
+
+	t.Run("HTTPステータスが2xx以外のときFETCH_FAILEDを返す", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		d := newTestDiagnoser(&mockSSRFGuard{})
+
+		// Act
+		_, err := d.Diagnose(context.Background(), server.URL, "")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeFetchFailed)
+	})
+
+	t.Run("SSRF検証に失敗したときSSRF_BLOCKEDを返す", func(t *testing.T) {
+		// Arrange
+		d := newTestDiagnoser(&mockSSRFGuard{validateErr: errors.New("private ip")})
+
+		// Act
+		_, err := d.Diagnose(context.Background(), "http://127.0.0.1/feed", "")
+
+		// Assert
+		assertAPIErrorCode(t, err, model.ErrCodeSSRFBlocked)
+	})
+}
+
+func TestDiagnoser_Diagnose_InvalidInput(t *testing.T) {
+	d := newTestDiagnoser(&mockSSRFGuard{})
+
+	cases := []struct {
+		name     string
+		url, xml string
+		wantCode string
+	}{
+		{name: "urlもxmlも空のときINVALID_DEBUG_PARSE_INPUTを返す", wantCode: model.ErrCodeInvalidDebugParseInput},
+		{name: "urlとxmlを同時に指定したときINVALID_DEBUG_PARSE_INPUTを返す", url: "https://example.com/feed", xml: diagnoseTestRSS, wantCode: model.ErrCodeInvalidDebugParseInput},
+		{name: "xmlがサイズ上限を超えるときINVALID_DEBUG_PARSE_INPUTを返す", xml: strings.Repeat("a", 1024*1024+1), wantCode: model.ErrCodeInvalidDebugParseInput},
+		{name: "xmlがフィードとして解釈できないときPARSE_FAILEDを返す", xml: "<html><body>not a feed</body></html>", wantCode: model.ErrCodeParseFailed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := d.Diagnose(context.Background(), tc.url, tc.xml)
+
+			// Assert
+			assertAPIErrorCode(t, err, tc.wantCode)
+		})
+	}
+}
+
+func TestDiagnoser_Diagnose_ParseFailedIncludesDetails(t *testing.T) {
+	// Arrange
+	d := newTestDiagnoser(&mockSSRFGuard{})
+
+	// Act
+	_, err := d.Diagnose(context.Background(), "", "<rss><channel><item>")
+
+	// Assert
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("APIError が返るべき: %v", err)
+	}
+	if _, ok := apiErr.Details["parse_error"]; !ok {
+		t.Errorf("Details に parse_error が含まれるべき: %v", apiErr.Details)
+	}
+}
+
+func assertAPIErrorCode(t *testing.T, err error, wantCode string) {
+	t.Helper()
+	var apiErr *model.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("APIError が返るべき: %v", err)
+	}
+	if apiErr.Code != wantCode {
+		t.Errorf("Code = %q, want %q", apiErr.Code, wantCode)
+	}
+}
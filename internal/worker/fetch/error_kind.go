@@ -0,0 +1,157 @@
+package fetch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// errBodyTooLarge はレスポンスボディが最大サイズを超過したことを表す。
+var errBodyTooLarge = errors.New("response body exceeds max size")
+
+// ClassifyTransportError はHTTPリクエスト実行・ボディ読み取り時のエラーを原因分類する。
+// 判定順序は SSRF → リダイレクト → サイズ超過 → DNS → タイムアウト → TLS → その他。
+// DNS のタイムアウトは名前解決の問題として dns に分類する。
+func ClassifyTransportError(err error) model.FetchErrorKind {
+	if err == nil {
+		return model.FetchErrorKindNone
+	}
+	if security.IsSSRFBlockedError(err) {
+		return model.FetchErrorKindSSRF
+	}
+	if security.IsRedirectError(err) {
+		return model.FetchErrorKindRedirect
+	}
+	if errors.Is(err, errBodyTooLarge) {
+		return model.FetchErrorKindTooLarge
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return model.FetchErrorKindDNS
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return model.FetchErrorKindTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return model.FetchErrorKindTimeout
+	}
+
+	if isTLSError(err) {
+		return model.FetchErrorKindTLS
+	}
+
+	return model.FetchErrorKindOther
+}
+
+// isTLSError は err がTLSハンドシェイク・証明書検証の失敗に起因するかを判定する。
+// crypto/tls のハンドシェイク失敗の一部（alert 等）は型付きエラーで返らないため、
+// 型判定に加えてエラーメッセージの "tls: " 接頭辞でも判定する。
+func isTLSError(err error) bool {
+	var (
+		recordErr    tls.RecordHeaderError
+		verifyErr    *tls.CertificateVerificationError
+		unknownAuth  x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		certInvalid  x509.CertificateInvalidError
+		alertErr     tls.AlertError
+		constraintEr x509.ConstraintViolationError
+	)
+	if errors.As(err, &recordErr) ||
+		errors.As(err, &verifyErr) ||
+		errors.As(err, &unknownAuth) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &certInvalid) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &constraintEr) {
+		return true
+	}
+	return strings.Contains(err.Error(), "tls: ")
+}
+
+// DescribeTLSError は TLS 起因のフェッチ失敗から詳細（種別・証明書の Subject / Issuer・有効期間）を抽出する。
+// TLS 以外の失敗の場合は nil を返す。now は有効期間の判定（期限切れか開始前か）に用いる。
+func DescribeTLSError(err error, now time.Time) *model.FetchErrorDetail {
+	if err == nil || ClassifyTransportError(err) != model.FetchErrorKindTLS {
+		return nil
+	}
+
+	detail := &model.FetchErrorDetail{TLSError: model.TLSErrorHandshake}
+
+	var (
+		verifyErr   *tls.CertificateVerificationError
+		certInvalid x509.CertificateInvalidError
+		unknownAuth x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+	)
+	var cert *x509.Certificate
+	if errors.As(err, &verifyErr) && len(verifyErr.UnverifiedCertificates) > 0 {
+		cert = verifyErr.UnverifiedCertificates[0]
+		detail.TLSError = model.TLSErrorCertInvalid
+	}
+
+	switch {
+	case errors.As(err, &certInvalid):
+		if cert == nil {
+			cert = certInvalid.Cert
+		}
+		detail.TLSError = model.TLSErrorCertInvalid
+		if certInvalid.Reason == x509.Expired {
+			detail.TLSError = model.TLSErrorCertExpired
+			if cert != nil && now.Before(cert.NotBefore) {
+				detail.TLSError = model.TLSErrorCertNotYetValid
+			}
+		}
+	case errors.As(err, &unknownAuth):
+		if cert == nil {
+			cert = unknownAuth.Cert
+		}
+		detail.TLSError = model.TLSErrorUnknownAuthority
+	case errors.As(err, &hostnameErr):
+		if cert == nil {
+			cert = hostnameErr.Certificate
+		}
+		detail.TLSError = model.TLSErrorHostnameMismatch
+	}
+
+	if cert != nil {
+		notBefore := cert.NotBefore.UTC()
+		notAfter := cert.NotAfter.UTC()
+		detail.CertSubject = certName(cert.Subject)
+		detail.CertIssuer = certName(cert.Issuer)
+		detail.CertDNSNames = cert.DNSNames
+		detail.CertNotBefore = &notBefore
+		detail.CertNotAfter = &notAfter
+	}
+	return detail
+}
+
+// certName は証明書の識別名を表示用に整形する。CommonName があればそれを優先する。
+func certName(name pkix.Name) string {
+	if name.CommonName != "" {
+		return name.CommonName
+	}
+	return name.String()
+}
+
+// ClassifyHTTPStatusKind はHTTPステータスコード（200 / 304 以外）を原因分類する。
+func ClassifyHTTPStatusKind(statusCode int) model.FetchErrorKind {
+	switch {
+	case statusCode >= 500:
+		return model.FetchErrorKindHTTP5xx
+	case statusCode >= 400:
+		return model.FetchErrorKindHTTP4xx
+	default:
+		return model.FetchErrorKindOther
+	}
+}
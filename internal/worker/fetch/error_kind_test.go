@@ -0,0 +1,215 @@
+package fetch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/doyensec/safeurl"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// timeoutError は Timeout() が true を返す net.Error のテスト用実装。
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClassifyTransportError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want model.FetchErrorKind
+	}{
+		{"nilのときNoneを返す", nil, model.FetchErrorKindNone},
+		{
+			"SSRFブロックのときssrfを返す",
+			&url.Error{Op: "Get", URL: "http://10.0.0.1/", Err: &safeurl.AllowedIPError{}},
+			model.FetchErrorKindSSRF,
+		},
+		{
+			"リダイレクトの循環のときredirectを返す",
+			&url.Error{Op: "Get", URL: "http://example.com/a", Err: fmt.Errorf("%w: http://example.com/b", security.ErrRedirectLoop)},
+			model.FetchErrorKindRedirect,
+		},
+		{
+			"リダイレクトの上限超過のときredirectを返す",
+			&url.Error{Op: "Get", URL: "http://example.com/6", Err: security.ErrTooManyRedirects},
+			model.FetchErrorKindRedirect,
+		},
+		{
+			"ボディサイズ超過のときtoo_largeを返す",
+			fmt.Errorf("%w: limit=10 bytes", errBodyTooLarge),
+			model.FetchErrorKindTooLarge,
+		},
+		{
+			"DNS解決失敗のときdnsを返す",
+			&url.Error{Op: "Get", URL: "http://nonexistent.invalid/", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "nonexistent.invalid", IsNotFound: true}}},
+			model.FetchErrorKindDNS,
+		},
+		{
+			"DNSタイムアウトのときdnsを返す",
+			&net.DNSError{Err: "timeout", Name: "example.com", IsTimeout: true},
+			model.FetchErrorKindDNS,
+		},
+		{
+			"コンテキストのデッドライン超過のときtimeoutを返す",
+			&url.Error{Op: "Get", URL: "http://example.com/", Err: context.DeadlineExceeded},
+			model.FetchErrorKindTimeout,
+		},
+		{
+			"net.ErrorのTimeoutのときtimeoutを返す",
+			&url.Error{Op: "Get", URL: "http://example.com/", Err: timeoutError{}},
+			model.FetchErrorKindTimeout,
+		},
+		{
+			"証明書検証失敗のときtlsを返す",
+			&url.Error{Op: "Get", URL: "https://example.com/", Err: x509.UnknownAuthorityError{}},
+			model.FetchErrorKindTLS,
+		},
+		{
+			"TLSハンドシェイク失敗メッセージのときtlsを返す",
+			errors.New("remote error: tls: handshake failure"),
+			model.FetchErrorKindTLS,
+		},
+		{
+			"分類できないエラーのときotherを返す",
+			errors.New("connection reset by peer"),
+			model.FetchErrorKindOther,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyTransportError(tt.err); got != tt.want {
+				t.Errorf("ClassifyTransportError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyHTTPStatusKind(t *testing.T) {
+	tests := []struct {
+		status int
+		want   model.FetchErrorKind
+	}{
+		{404, model.FetchErrorKindHTTP4xx},
+		{410, model.FetchErrorKindHTTP4xx},
+		{429, model.FetchErrorKindHTTP4xx},
+		{500, model.FetchErrorKindHTTP5xx},
+		{503, model.FetchErrorKindHTTP5xx},
+		{302, model.FetchErrorKindOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("ステータス%dのとき%sを返す", tt.status, tt.want), func(t *testing.T) {
+			if got := ClassifyHTTPStatusKind(tt.status); got != tt.want {
+				t.Errorf("ClassifyHTTPStatusKind(%d) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeTLSError(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "feed.example.com"},
+		Issuer:    pkix.Name{Organization: []string{"Example CA"}},
+		DNSNames:  []string{"feed.example.com"},
+		NotBefore: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	futureCert := &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "future.example.com"},
+		NotBefore: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2027, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://feed.example.com/", Err: err}
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		wantType    model.TLSErrorType
+		wantSubject string
+	}{
+		{
+			"証明書の期限切れのときcertificate_expiredを返す",
+			wrap(&tls.CertificateVerificationError{
+				UnverifiedCertificates: []*x509.Certificate{cert},
+				Err:                    x509.CertificateInvalidError{Cert: cert, Reason: x509.Expired},
+			}),
+			model.TLSErrorCertExpired,
+			"feed.example.com",
+		},
+		{
+			"有効期間開始前のときcertificate_not_yet_validを返す",
+			wrap(x509.CertificateInvalidError{Cert: futureCert, Reason: x509.Expired}),
+			model.TLSErrorCertNotYetValid,
+			"future.example.com",
+		},
+		{
+			"信頼できない認証局のときunknown_authorityを返す",
+			wrap(x509.UnknownAuthorityError{Cert: cert}),
+			model.TLSErrorUnknownAuthority,
+			"feed.example.com",
+		},
+		{
+			"ホスト名不一致のときhostname_mismatchを返す",
+			wrap(x509.HostnameError{Certificate: cert, Host: "other.example.com"}),
+			model.TLSErrorHostnameMismatch,
+			"feed.example.com",
+		},
+		{
+			"証明書を伴わないハンドシェイク失敗のときhandshakeを返す",
+			errors.New("remote error: tls: handshake failure"),
+			model.TLSErrorHandshake,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DescribeTLSError(tt.err, now)
+			if got == nil {
+				t.Fatal("DescribeTLSError() = nil, want detail")
+			}
+			if got.TLSError != tt.wantType {
+				t.Errorf("TLSError = %q, want %q", got.TLSError, tt.wantType)
+			}
+			if got.CertSubject != tt.wantSubject {
+				t.Errorf("CertSubject = %q, want %q", got.CertSubject, tt.wantSubject)
+			}
+			if tt.wantSubject != "" && (got.CertNotAfter == nil || got.CertNotBefore == nil) {
+				t.Errorf("certificate validity should be set: %+v", got)
+			}
+		})
+	}
+
+	t.Run("Issuerにコモンネームがないとき識別名全体を返す", func(t *testing.T) {
+		got := DescribeTLSError(wrap(x509.UnknownAuthorityError{Cert: cert}), now)
+		if got.CertIssuer != "O=Example CA" {
+			t.Errorf("CertIssuer = %q, want %q", got.CertIssuer, "O=Example CA")
+		}
+	})
+
+	t.Run("TLS以外の失敗のときnilを返す", func(t *testing.T) {
+		if got := DescribeTLSError(errors.New("connection reset by peer"), now); got != nil {
+			t.Errorf("DescribeTLSError() = %+v, want nil", got)
+		}
+		if got := DescribeTLSError(nil, now); got != nil {
+			t.Errorf("DescribeTLSError(nil) = %+v, want nil", got)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -21,6 +22,30 @@ type ItemUpserter interface {
 	UpsertItems(ctx context.Context, feedID string, items []model.ParsedItem) (int, int, error)
 }
 
+// ItemFilter は UPSERT の前段で取り込む記事を絞り込むインターフェース。
+// 購読単位の取り込みフィルタ（importfilter.Service）が実装する。
+type ItemFilter interface {
+	FilterItems(ctx context.Context, feedID string, items []model.ParsedItem) ([]model.ParsedItem, error)
+}
+
+// AttemptRecorder はフェッチ 1 回ごとの成否と所要時間を記録するインターフェース。
+// 管理者向け全体統計のフェッチ成功率・平均フェッチ時間の集計に用いる。
+type AttemptRecorder interface {
+	RecordFetchAttempt(ctx context.Context, attempt model.FetchAttempt) error
+}
+
+// FeedRediscoverer はサイトの URL から現在のフィード URL を探索するインターフェース。
+// feed.FeedDetector が実装する。
+type FeedRediscoverer interface {
+	DetectFeedURL(ctx context.Context, inputURL string) (string, error)
+}
+
+// NewItemNotifier は新着記事を取り込んだ直後に外部への転送キューへ積むインターフェース。
+// Slack / Discord 連携の配送キュー（repository.IntegrationDeliveryRepository）が実装する。
+type NewItemNotifier interface {
+	EnqueueNewItems(ctx context.Context, feedID string) (int, error)
+}
+
 // SSRFValidator はSSRF検証のインターフェース。
 type SSRFValidator interface {
 	ValidateURL(rawURL string) error
@@ -39,6 +64,39 @@ type Fetcher struct {
 	timeout     time.Duration
 	maxBodySize int64
 	metrics     metrics.MetricsCollector
+	itemFilter  ItemFilter
+	attempts    AttemptRecorder
+	maxItems    int
+
+	// rediscoverer / suggestionRepo はフィード URL 再検出の探索器と提案の保存先。未設定時は再検出しない。
+	rediscoverer   FeedRediscoverer
+	suggestionRepo repository.FeedURLSuggestionRepository
+
+	// activeHours は利用時間帯に合わせたプリフェッチで参照する購読者の利用時間帯。未設定時は前倒ししない。
+	// activeHoursCache はその集計結果のフィードごとのキャッシュ。
+	activeHours      repository.ActiveHourRepository
+	activeHoursCache *activeHoursCache
+
+	// notifier は新着記事の転送キュー。未設定時は転送しない。
+	notifier NewItemNotifier
+
+	// subscriberCounter / intervalPolicy は購読者数に応じたフェッチ間隔の延長に使う。未設定時は延長しない。
+	subscriberCounter repository.FeedSubscriberCounter
+	intervalPolicy    SubscriberIntervalPolicy
+
+	// activeSubscriberChecker / dormantPolicy は休眠ユーザーのみが購読するフィードのフェッチ間隔の延長に使う。
+	// 未設定時は延長しない。
+	activeSubscriberChecker repository.FeedActiveSubscriberChecker
+	dormantPolicy           DormantIntervalPolicy
+
+	// rawCaptures はデバッグ用のフェッチレスポンスの保存先。未設定時は保存しない。
+	rawCaptures RawCaptureStore
+
+	// blocklist はフェッチ前に照合するインスタンスのブロックリスト。未設定時は照合しない。
+	blocklist BlocklistChecker
+
+	// titleRecorder はフィードタイトルの変更を購読ごとの自動更新ポリシーに反映する先。未設定時は反映しない。
+	titleRecorder TitleChangeRecorder
 }
 
 // FetcherOption は NewFetcher の任意設定を表す functional option。
@@ -52,6 +110,49 @@ func WithMetrics(c metrics.MetricsCollector) FetcherOption {
 	}
 }
 
+// WithItemFilter は UPSERT の前段で記事を絞り込むフィルタを注入する。
+// 未指定時はパースしたすべての記事を取り込む。
+func WithItemFilter(filter ItemFilter) FetcherOption {
+	return func(f *Fetcher) {
+		f.itemFilter = filter
+	}
+}
+
+// WithAttemptRecorder はフェッチ結果の記録先を注入する。
+// 未指定時はフェッチ結果を記録しない。
+func WithAttemptRecorder(r AttemptRecorder) FetcherOption {
+	return func(f *Fetcher) {
+		f.attempts = r
+	}
+}
+
+// WithMaxItems は 1 回のフェッチで取り込む記事数の上限を設定する。
+// 上限を超えた場合は公開日時の新しい順に採用する。未指定または 0 以下のときは DefaultMaxItemsPerFetch を使う。
+func WithMaxItems(n int) FetcherOption {
+	return func(f *Fetcher) {
+		if n > 0 {
+			f.maxItems = n
+		}
+	}
+}
+
+// WithFeedRediscovery は 404/410 でフェッチを停止したとき、サイトから新しいフィード URL を再検出して
+// URL 張り替えの提案として保存する。未指定時は再検出しない。
+func WithFeedRediscovery(rediscoverer FeedRediscoverer, repo repository.FeedURLSuggestionRepository) FetcherOption {
+	return func(f *Fetcher) {
+		f.rediscoverer = rediscoverer
+		f.suggestionRepo = repo
+	}
+}
+
+// WithNewItemNotifier は新着記事を取り込んだ直後に転送キューへ積む notifier を注入する。
+// 未指定時は新着記事を転送しない。
+func WithNewItemNotifier(n NewItemNotifier) FetcherOption {
+	return func(f *Fetcher) {
+		f.notifier = n
+	}
+}
+
 // NewFetcher はFetcherの新しいインスタンスを生成する。
 // 既存の 7 引数 call site との後方互換のため、メトリクスコレクタは末尾の可変長
 // functional option（WithMetrics）として受け取る。opts 未指定時は no-op コレクタを既定値とする。
@@ -74,6 +175,7 @@ func NewFetcher(
 		timeout:     timeout,
 		maxBodySize: maxBodySize,
 		metrics:     metrics.NopCollector{},
+		maxItems:    DefaultMaxItemsPerFetch,
 	}
 	for _, opt := range opts {
 		opt(f)
@@ -81,16 +183,46 @@ func NewFetcher(
 	return f
 }
 
+// FetchStats はフィード 1 件のフェッチ結果の集計値。スケジューラのサイクル記録に用いる。
+type FetchStats struct {
+	// Succeeded は 304 または 200 で記事保存・状態更新まで完了したとき true。
+	Succeeded     bool
+	ItemsInserted int
+	ItemsUpdated  int
+}
+
 // Fetch はフィードをフェッチし、結果に応じてフィード状態を更新する。
 // FeedFetcherServiceインターフェースを実装する。
 func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
+	return f.fetch(ctx, feed, &FetchStats{})
+}
+
+// FetchWithStats は Fetch と同じ処理を行い、成否と新規/更新記事数を返す。
+// パース失敗など error を返さない失敗もあるため、成否は戻り値の Succeeded で判定する。
+func (f *Fetcher) FetchWithStats(ctx context.Context, feed *model.Feed) (FetchStats, error) {
+	var stats FetchStats
+	err := f.fetch(ctx, feed, &stats)
+	return stats, err
+}
+
+// fetch は Fetch / FetchWithStats の本体。結果の集計値を stats に書き込む。
+func (f *Fetcher) fetch(ctx context.Context, feed *model.Feed, stats *FetchStats) error {
 	start := time.Now()
+	// succeeded は成功数メトリクスを記録した経路でのみ true にする（304 と 200 の完了時）。
+	succeeded := false
 
 	// フェッチ完了時に所要時間をレイテンシメトリクスへ記録する（Requirement 2.5）。
 	defer func() {
-		f.metrics.RecordFetchLatency(time.Since(start))
+		elapsed := time.Since(start)
+		f.metrics.RecordFetchLatency(elapsed)
+		f.recordAttempt(ctx, feed.ID, succeeded, start, elapsed)
 	}()
 
+	// ブロックリストとの照合（一致したフィードはリクエストを送らずに停止する）
+	if err := f.checkBlocklist(ctx, feed); err != nil {
+		return err
+	}
+
 	// SSRF検証
 	if err := f.ssrfGuard.ValidateURL(feed.FeedURL); err != nil {
 		f.logger.Error("SSRF検証に失敗しました",
@@ -99,7 +231,8 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 			slog.String("error", err.Error()),
 		)
 		f.metrics.RecordFetchFailure(feed.ID, "ssrf_validation")
-		ApplyStopFeed(feed, fmt.Sprintf("SSRF検証失敗: %s", err.Error()))
+		f.metrics.RecordFetchErrorKind(string(model.FetchErrorKindSSRF))
+		ApplyStopFeedWithKind(feed, model.FetchErrorKindSSRF, fmt.Sprintf("SSRF検証失敗: %s", err.Error()))
 		if updateErr := f.feedRepo.UpdateFetchState(ctx, feed); updateErr != nil {
 			f.logger.Error("フィード状態の更新に失敗しました",
 				slog.String("feed_id", feed.ID),
@@ -119,25 +252,38 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 	req.Header.Set("User-Agent", "Feedman/1.0 RSS Reader")
 	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml, */*")
 
-	// 条件付きGET: ETag
-	if feed.ETag != "" {
-		req.Header.Set("If-None-Match", feed.ETag)
-	}
-	// 条件付きGET: Last-Modified
-	if feed.LastModified != "" {
-		req.Header.Set("If-Modified-Since", feed.LastModified)
+	// 条件付きGET。管理者がフィード単位で無効化している場合は送信せず、常に本文を取得する。
+	if !feed.IgnoreConditionalGet {
+		// 条件付きGET: ETag
+		if feed.ETag != "" {
+			req.Header.Set("If-None-Match", feed.ETag)
+		}
+		// 条件付きGET: Last-Modified
+		if feed.LastModified != "" {
+			req.Header.Set("If-Modified-Since", feed.LastModified)
+		}
 	}
 
 	// HTTPリクエスト実行
 	resp, err := client.Do(req)
 	if err != nil {
+		kind := ClassifyTransportError(err)
 		f.logger.Error("HTTPリクエストに失敗しました",
 			slog.String("feed_id", feed.ID),
 			slog.String("feed_url", feed.FeedURL),
+			slog.String("error_kind", string(kind)),
 			slog.String("error", err.Error()),
 		)
 		f.metrics.RecordFetchFailure(feed.ID, "http_request")
-		ApplyBackoff(feed, fmt.Sprintf("HTTPリクエスト失敗: %s", err.Error()))
+		f.metrics.RecordFetchErrorKind(string(kind))
+		if kind == model.FetchErrorKindSSRF {
+			// DNS解決後の宛先IPがブロック対象だった場合は事前検証失敗と同様に停止する
+			ApplyStopFeedWithKind(feed, kind, fmt.Sprintf("SSRF検証失敗: %s", err.Error()))
+		} else {
+			ApplyBackoffWithKind(feed, kind, fmt.Sprintf("HTTPリクエスト失敗: %s", err.Error()))
+			// TLS 失敗は error_message だけでは原因（証明書切れ等）が分からないため詳細を残す
+			feed.ErrorDetail = DescribeTLSError(err, time.Now())
+		}
 		if updateErr := f.feedRepo.UpdateFetchState(ctx, feed); updateErr != nil {
 			f.logger.Error("フィード状態の更新に失敗しました",
 				slog.String("feed_id", feed.ID),
@@ -148,7 +294,16 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 	}
 	defer resp.Body.Close()
 
+	// 管理者がフィード単位で有効にしている場合は、取り込み不具合の調査用にレスポンスを保存する。
+	// resp.Body.Close より先に実行されるため、パーサーが読まなかった本文も上限まで読み足して保存できる。
+	var capture *rawCapture
+	if feed.CaptureRawResponse && f.rawCaptures != nil {
+		capture = newRawCapture(feed, resp, time.Now())
+		defer f.saveRawCapture(ctx, capture, resp.Body)
+	}
+
 	duration := time.Since(start)
+	feed.HTTPVersion = resp.Proto
 
 	// HTTPレスポンスを受信したのでステータスコード別のレスポンス数を記録する（Requirement 2.4）。
 	f.metrics.RecordHTTPStatus(resp.StatusCode)
@@ -165,7 +320,7 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 			slog.Int("http_status", resp.StatusCode),
 			slog.Float64("duration_ms", float64(duration.Milliseconds())),
 		)
-		interval, err := f.getMinFetchInterval(ctx, feed.ID)
+		interval, err := f.getFetchInterval(ctx, feed.ID)
 		if err != nil {
 			f.logger.Error("最小フェッチ間隔の取得に失敗しました",
 				slog.String("feed_id", feed.ID),
@@ -173,9 +328,13 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 			)
 			interval = 60 // デフォルト60分
 		}
+		interval = effectiveFetchInterval(interval, feed)
 		// 304 は「変更なしで取得成功」として扱い成功数を増加させる（Requirement 2.1）。
 		f.metrics.RecordFetchSuccess(feed.ID)
+		succeeded = true
+		stats.Succeeded = true
 		ApplySuccess(feed, interval)
+		f.applyPrefetch(ctx, feed.ID, time.Now(), &feed.NextFetchAt)
 		f.recordLastSuccessfulFetch(ctx, feed.ID)
 		return f.feedRepo.UpdateFetchState(ctx, feed)
 
@@ -188,9 +347,18 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 			slog.Int("http_status", resp.StatusCode),
 			slog.String("reason", reason),
 		)
+		kind := ClassifyHTTPStatusKind(resp.StatusCode)
 		f.metrics.RecordFetchFailure(feed.ID, "http_stop")
-		ApplyStopFeed(feed, reason)
-		return f.feedRepo.UpdateFetchState(ctx, feed)
+		f.metrics.RecordFetchErrorKind(string(kind))
+		ApplyStopFeedWithKind(feed, kind, reason)
+		feed.ErrorDetail = &model.FetchErrorDetail{HTTPStatus: resp.StatusCode}
+		if err := f.feedRepo.UpdateFetchState(ctx, feed); err != nil {
+			return err
+		}
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+			f.suggestFeedURL(ctx, feed)
+		}
+		return nil
 
 	case FetchResultBackoff:
 		// 429/5xx: バックオフ
@@ -201,8 +369,11 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 			slog.Int("http_status", resp.StatusCode),
 			slog.Int("consecutive_errors", feed.ConsecutiveErrors+1),
 		)
+		kind := ClassifyHTTPStatusKind(resp.StatusCode)
 		f.metrics.RecordFetchFailure(feed.ID, "http_backoff")
-		ApplyBackoff(feed, reason)
+		f.metrics.RecordFetchErrorKind(string(kind))
+		ApplyBackoffWithKind(feed, kind, reason)
+		feed.ErrorDetail = &model.FetchErrorDetail{HTTPStatus: resp.StatusCode}
 		return f.feedRepo.UpdateFetchState(ctx, feed)
 
 	case FetchResultOK:
@@ -213,20 +384,31 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 			slog.String("feed_id", feed.ID),
 			slog.Int("http_status", resp.StatusCode),
 		)
+		kind := ClassifyHTTPStatusKind(resp.StatusCode)
 		f.metrics.RecordFetchFailure(feed.ID, "http_unexpected")
-		ApplyBackoff(feed, fmt.Sprintf("予期しないHTTPステータス: %d", resp.StatusCode))
+		f.metrics.RecordFetchErrorKind(string(kind))
+		ApplyBackoffWithKind(feed, kind, fmt.Sprintf("予期しないHTTPステータス: %d", resp.StatusCode))
+		feed.ErrorDetail = &model.FetchErrorDetail{HTTPStatus: resp.StatusCode}
 		return f.feedRepo.UpdateFetchState(ctx, feed)
 	}
 
-	// レスポンスボディを読み込み（最大サイズ制限付き）
-	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBodySize))
-	if err != nil {
+	// レスポンスボディを最大サイズ制限付きで読み進めながらパースする（ボディ全体をメモリに展開しない）
+	var src io.Reader = resp.Body
+	if capture != nil {
+		src = io.TeeReader(resp.Body, capture)
+	}
+	body := newLimitedBodyReader(src, f.maxBodySize)
+	parsedFeed, err := parseFeedStream(body)
+	if body.err != nil {
+		kind := ClassifyTransportError(body.err)
 		f.logger.Error("レスポンスボディの読み取りに失敗しました",
 			slog.String("feed_id", feed.ID),
-			slog.String("error", err.Error()),
+			slog.String("error_kind", string(kind)),
+			slog.String("error", body.err.Error()),
 		)
 		f.metrics.RecordFetchFailure(feed.ID, "body_read")
-		ApplyBackoff(feed, fmt.Sprintf("レスポンス読み取り失敗: %s", err.Error()))
+		f.metrics.RecordFetchErrorKind(string(kind))
+		ApplyBackoffWithKind(feed, kind, fmt.Sprintf("レスポンス読み取り失敗: %s", body.err.Error()))
 		return f.feedRepo.UpdateFetchState(ctx, feed)
 	}
 
@@ -238,9 +420,6 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 		feed.LastModified = lastMod
 	}
 
-	// gofeedでフィードをパース
-	parser := gofeed.NewParser()
-	parsedFeed, err := parser.ParseString(string(body))
 	if err != nil {
 		f.logger.Error("フィードのパースに失敗しました",
 			slog.String("feed_id", feed.ID),
@@ -250,6 +429,7 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 		// パース失敗はパース失敗数とフェッチ失敗数の両方を記録する（Requirement 2.3, 2.2）。
 		f.metrics.RecordParseFailure(feed.ID)
 		f.metrics.RecordFetchFailure(feed.ID, "parse")
+		f.metrics.RecordFetchErrorKind(string(model.FetchErrorKindParse))
 		ApplyParseFailure(feed, err.Error())
 		if updateErr := f.feedRepo.UpdateFetchState(ctx, feed); updateErr != nil {
 			f.logger.Error("フィード状態の更新に失敗しました",
@@ -260,7 +440,8 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 		return nil // パース失敗はフェッチエラーとしない（カウントして継続）
 	}
 
-	// フィードタイトルを更新
+	// フィードタイトルを更新（変更前のタイトルは購読ごとの自動更新ポリシーの反映に使う）
+	previousTitle := feed.Title
 	if parsedFeed.Title != "" {
 		feed.Title = parsedFeed.Title
 	}
@@ -269,17 +450,40 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 	}
 
 	// gofeedの記事をParsedItemに変換
+	// 本文中の相対 URL は記事の link（相対の場合はサイト URL、なければフィード URL）を基準に絶対化する
 	parsedItems := convertGofeedItems(parsedFeed.Items)
+	parsedFeed.Items = nil // 変換後は不要なため巨大フィードでのメモリ保持を避ける
+	if total := len(parsedItems); total > f.maxItems {
+		parsedItems = limitItemsByRecency(parsedItems, f.maxItems)
+		f.logger.Info("記事数が取り込み上限を超えたため新しい記事のみ採用します",
+			slog.String("feed_id", feed.ID),
+			slog.Int("items_total", total),
+			slog.Int("items_limit", f.maxItems),
+		)
+	}
+	baseURL := feed.SiteURL
+	if baseURL == "" {
+		baseURL = feed.FeedURL
+	}
+	for i := range parsedItems {
+		parsedItems[i].BaseURL = baseURL
+	}
+
+	// 取り込みフィルタに一致しない記事は保存しない
+	importItems := f.filterItems(ctx, feed.ID, parsedItems)
 
 	// ItemUpsertServiceで記事を保存
-	inserted, updated, err := f.upsertSvc.UpsertItems(ctx, feed.ID, parsedItems)
+	inserted, updated, err := f.upsertSvc.UpsertItems(ctx, feed.ID, importItems)
 	if err != nil {
 		f.logger.Error("記事のUPSERTに失敗しました",
 			slog.String("feed_id", feed.ID),
 			slog.String("error", err.Error()),
 		)
 		f.metrics.RecordFetchFailure(feed.ID, "upsert")
+		f.metrics.RecordFetchErrorKind(string(model.FetchErrorKindOther))
 		ApplyParseFailure(feed, fmt.Sprintf("記事UPSERT失敗: %s", err.Error()))
+		// UPSERT失敗はフィード側の問題ではないため分類は other とする
+		feed.ErrorKind = model.FetchErrorKindOther
 		if updateErr := f.feedRepo.UpdateFetchState(ctx, feed); updateErr != nil {
 			f.logger.Error("フィード状態の更新に失敗しました",
 				slog.String("feed_id", feed.ID),
@@ -289,8 +493,17 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 		return nil
 	}
 
-	// 最小フェッチ間隔を取得してnext_fetch_atを設定
-	interval, err := f.getMinFetchInterval(ctx, feed.ID)
+	// 新着記事があれば転送キューへ積む
+	if inserted > 0 {
+		f.enqueueNewItems(ctx, feed.ID)
+	}
+
+	// 記事の保存に成功したので言語・説明文・最終投稿日時と利用条件のメタ情報を更新
+	applyFeedMetadata(feed, parsedFeed, parsedItems, time.Now())
+	applyFeedUsageMetadata(feed, parsedFeed, resp.Header)
+
+	// 購読者の最小フェッチ間隔（購読者数に応じて延長、フィードの ttl を下限とする）からnext_fetch_atを設定
+	interval, err := f.getFetchInterval(ctx, feed.ID)
 	if err != nil {
 		f.logger.Error("最小フェッチ間隔の取得に失敗しました",
 			slog.String("feed_id", feed.ID),
@@ -298,8 +511,10 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 		)
 		interval = 60 // デフォルト60分
 	}
+	interval = effectiveFetchInterval(interval, feed)
 
 	ApplySuccess(feed, interval)
+	f.applyPrefetch(ctx, feed.ID, time.Now(), &feed.NextFetchAt)
 	f.recordLastSuccessfulFetch(ctx, feed.ID)
 
 	// フィード状態を更新
@@ -311,9 +526,14 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 		f.metrics.RecordFetchFailure(feed.ID, "update_state")
 		return updateErr
 	}
+	f.recordTitleChange(ctx, feed.ID, previousTitle, feed.Title)
 
 	// 200 で UPSERT・状態更新まで成功したのでフェッチ成功数を増加させる（Requirement 2.1）。
 	f.metrics.RecordFetchSuccess(feed.ID)
+	succeeded = true
+	stats.Succeeded = true
+	stats.ItemsInserted = inserted
+	stats.ItemsUpdated = updated
 
 	f.logger.Info("フィードフェッチが完了しました",
 		slog.String("feed_id", feed.ID),
@@ -322,12 +542,100 @@ func (f *Fetcher) Fetch(ctx context.Context, feed *model.Feed) error {
 		slog.Int("items_inserted", inserted),
 		slog.Int("items_updated", updated),
 		slog.Int("items_total", len(parsedItems)),
+		slog.Int("items_filtered", len(parsedItems)-len(importItems)),
 		slog.Float64("duration_ms", float64(duration.Milliseconds())),
 	)
 
 	return nil
 }
 
+// filterItems は取り込みフィルタで記事を絞り込む。
+// フィルタの取得・評価に失敗した場合は警告ログを出力し、記事を取りこぼさないよう全件を返す。
+func (f *Fetcher) filterItems(ctx context.Context, feedID string, items []model.ParsedItem) []model.ParsedItem {
+	if f.itemFilter == nil {
+		return items
+	}
+	filtered, err := f.itemFilter.FilterItems(ctx, feedID, items)
+	if err != nil {
+		f.logger.Warn("取り込みフィルタの適用に失敗したため全記事を取り込みます",
+			slog.String("feed_id", feedID),
+			slog.String("error", err.Error()),
+		)
+		return items
+	}
+	return filtered
+}
+
+// recordAttempt はフェッチ結果を記録する。記録に失敗しても警告ログのみ出力し、フェッチ結果には影響させない。
+func (f *Fetcher) recordAttempt(ctx context.Context, feedID string, succeeded bool, start time.Time, elapsed time.Duration) {
+	if f.attempts == nil {
+		return
+	}
+	attempt := model.FetchAttempt{FeedID: feedID, Succeeded: succeeded, Duration: elapsed, AttemptedAt: start}
+	if err := f.attempts.RecordFetchAttempt(ctx, attempt); err != nil {
+		f.logger.Warn("フェッチ結果の記録に失敗しました",
+			slog.String("feed_id", feedID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// suggestFeedURL は停止したフィードのサイトから現在のフィード URL を再検出し、
+// 元の URL と異なる URL が見つかれば張り替えの提案として保存する。
+// 見つからない場合は古い提案を消す。探索や保存に失敗しても警告ログのみ出力し、停止処理には影響させない。
+func (f *Fetcher) suggestFeedURL(ctx context.Context, feed *model.Feed) {
+	if f.rediscoverer == nil || f.suggestionRepo == nil {
+		return
+	}
+	target := rediscoveryTarget(feed)
+	if target == "" {
+		return
+	}
+
+	detectCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	found, err := f.rediscoverer.DetectFeedURL(detectCtx, target)
+	if err != nil {
+		f.logger.Info("フィード URL の再検出で候補が見つかりませんでした",
+			slog.String("feed_id", feed.ID),
+			slog.String("site_url", target),
+			slog.String("error", err.Error()),
+		)
+		found = ""
+	}
+	if found == feed.FeedURL {
+		found = ""
+	}
+
+	if err := f.suggestionRepo.SetSuggestedFeedURL(ctx, feed.ID, found); err != nil {
+		f.logger.Warn("フィード URL 張り替え提案の保存に失敗しました",
+			slog.String("feed_id", feed.ID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if found != "" {
+		f.logger.Info("停止したフィードの新しい URL 候補を検出しました",
+			slog.String("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+			slog.String("suggested_feed_url", found),
+		)
+	}
+}
+
+// rediscoveryTarget は再検出の起点とする URL を返す。
+// site_url が未設定の場合はフィード URL のオリジン（スキーム + ホスト）を使う。
+func rediscoveryTarget(feed *model.Feed) string {
+	if feed.SiteURL != "" {
+		return feed.SiteURL
+	}
+	u, err := url.Parse(feed.FeedURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host + "/"
+}
+
 // recordLastSuccessfulFetch は ApplySuccess 直後にフィードの最終成功時刻を更新する。
 // 更新失敗時は警告ログのみ出力し、フェッチ自体は成功扱いを維持する（手動フェッチ側の
 // クールダウン判定の起点を温存することを目的とし、Issue #115 Req 2.4 を満たす）。
@@ -340,6 +648,20 @@ func (f *Fetcher) recordLastSuccessfulFetch(ctx context.Context, feedID string)
 	}
 }
 
+// enqueueNewItems は新着記事を転送キューへ積む。
+// キューへの投入に失敗しても記事の取り込み自体は成功しているため、警告ログのみ出力する。
+func (f *Fetcher) enqueueNewItems(ctx context.Context, feedID string) {
+	if f.notifier == nil {
+		return
+	}
+	if _, err := f.notifier.EnqueueNewItems(ctx, feedID); err != nil {
+		f.logger.Warn("新着記事の転送キューへの投入に失敗しました",
+			slog.String("feed_id", feedID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
 // getMinFetchInterval はフィードの全購読者の中で最小のfetch_interval_minutesを取得する。
 func (f *Fetcher) getMinFetchInterval(ctx context.Context, feedID string) (int, error) {
 	interval, err := f.subRepo.MinFetchIntervalByFeedID(ctx, feedID)
@@ -359,10 +681,11 @@ func convertGofeedItems(items []*gofeed.Item) []model.ParsedItem {
 		}
 
 		parsed := model.ParsedItem{
-			Title:   item.Title,
-			Link:    item.Link,
-			Content: item.Content,
-			Summary: item.Description,
+			Title:        item.Title,
+			Link:         item.Link,
+			Content:      item.Content,
+			Summary:      item.Description,
+			ThumbnailURL: feedThumbnailURL(item),
 		}
 
 		// GUIDの設定: gofeedはGUIDをitem.GUIDに格納
@@ -404,3 +727,17 @@ func convertGofeedItems(items []*gofeed.Item) []model.ParsedItem {
 
 	return parsedItems
 }
+
+// readBodyWithLimit はレスポンスボディを最大 maxSize バイトまで読み込む。
+// maxSize を超えるボディは切り詰めず errBodyTooLarge を返す
+// （切り詰めたXMLはパース失敗として誤分類されるため）。
+func readBodyWithLimit(r io.Reader, maxSize int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("%w: limit=%d bytes", errBodyTooLarge, maxSize)
+	}
+	return body, nil
+}
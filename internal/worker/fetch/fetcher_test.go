@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -85,6 +86,7 @@ type mockMetricsCollector struct {
 	httpStatus    int
 	fetchLatency  int
 	itemsUpserted int
+	errorKinds    []string
 
 	lastStatusCode    int
 	lastItemsUpserted int
@@ -95,6 +97,10 @@ func (m *mockMetricsCollector) RecordFetchSuccess(_ string) { m.fetchSuccess++ }
 
 func (m *mockMetricsCollector) RecordFetchFailure(_, _ string) { m.fetchFailure++ }
 
+func (m *mockMetricsCollector) RecordFetchErrorKind(kind string) {
+	m.errorKinds = append(m.errorKinds, kind)
+}
+
 func (m *mockMetricsCollector) RecordParseFailure(_ string) { m.parseFailure++ }
 
 func (m *mockMetricsCollector) RecordHTTPStatus(statusCode int) {
@@ -326,6 +332,37 @@ func TestFetcher_Fetch_ConditionalGET_ETag(t *testing.T) {
 	}
 }
 
+// TestFetcher_Fetch_IgnoreConditionalGet は条件付き GET を無効化したフィードで
+// If-None-Match / If-Modified-Since を送信しないことを検証する。
+func TestFetcher_Fetch_IgnoreConditionalGet(t *testing.T) {
+	var receivedIfNoneMatch, receivedIfModifiedSince string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedIfNoneMatch = r.Header.Get("If-None-Match")
+		receivedIfModifiedSince = r.Header.Get("If-Modified-Since")
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	f := NewFetcher(
+		&mockFeedRepo{}, &mockSubRepo{minInterval: 60}, &mockUpsertService{}, &mockSSRFGuard{},
+		newTestLogger(&buf), 10*time.Second, 5*1024*1024,
+	)
+	feed := &model.Feed{
+		ID:                   "feed-1",
+		FeedURL:              server.URL,
+		ETag:                 `"etag-value"`,
+		LastModified:         "Wed, 21 Oct 2015 07:28:00 GMT",
+		IgnoreConditionalGet: true,
+	}
+
+	_ = f.Fetch(context.Background(), feed)
+
+	if receivedIfNoneMatch != "" || receivedIfModifiedSince != "" {
+		t.Errorf("条件付きヘッダー = (%q, %q), want 送信しない", receivedIfNoneMatch, receivedIfModifiedSince)
+	}
+}
+
 func TestFetcher_Fetch_ConditionalGET_LastModified(t *testing.T) {
 	var receivedIfModifiedSince string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -570,8 +607,8 @@ func TestFetcher_Fetch_NextFetchAtUsesMinInterval(t *testing.T) {
 
 	_ = f.Fetch(context.Background(), feed)
 
-	// NextFetchAtが約30分後であること
-	expectedTime := now.Add(30 * time.Minute)
+	// NextFetchAtが約30分後（フィード単位のジッター込み）であること
+	expectedTime := now.Add(30*time.Minute + fetchJitter(feed.ID, 30*time.Minute))
 	diff := feed.NextFetchAt.Sub(expectedTime)
 	if diff > 5*time.Second || diff < -5*time.Second {
 		t.Errorf("NextFetchAt が期待値から大幅にずれている: %v (期待: ~%v)", feed.NextFetchAt, expectedTime)
@@ -888,6 +925,61 @@ func TestFetcher_Fetch_PersistsTitleAndSiteURL(t *testing.T) {
 	}
 }
 
+// TestFetcher_Fetch_SetsBaseURLForItems は、本文中の相対 URL の絶対化のために
+// 記事へ基準 URL（サイト URL、なければフィード URL）が渡されることを検証する。
+func TestFetcher_Fetch_SetsBaseURLForItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		wantURL func(serverURL string) string
+	}{
+		{name: "サイトURLがあるときサイトURLを渡す", link: "<link>https://site.example.com/</link>", wantURL: func(string) string { return "https://site.example.com/" }},
+		{name: "サイトURLがないときフィードURLを渡す", link: "", wantURL: func(u string) string { return u }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/rss+xml")
+				fmt.Fprintf(w, `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel>
+    <title>Site</title>
+    %s
+    <item><guid>1</guid><title>Item</title><description>&lt;img src="/a.png"&gt;</description></item>
+  </channel>
+</rss>`, tt.link)
+			}))
+			defer server.Close()
+
+			var buf bytes.Buffer
+			upsert := &mockUpsertService{}
+			f := NewFetcher(
+				&mockFeedRepo{},
+				&mockSubRepo{minInterval: 60},
+				upsert,
+				&mockSSRFGuard{},
+				newTestLogger(&buf),
+				10*time.Second,
+				5*1024*1024,
+			)
+
+			// Act
+			if err := f.Fetch(context.Background(), &model.Feed{ID: "feed-1", FeedURL: server.URL}); err != nil {
+				t.Fatalf("Fetch() がエラーを返した: %v", err)
+			}
+
+			// Assert
+			if len(upsert.calledWith) != 1 {
+				t.Fatalf("UpsertItems に渡された記事数 = %d, want 1", len(upsert.calledWith))
+			}
+			if got, want := upsert.calledWith[0].BaseURL, tt.wantURL(server.URL); got != want {
+				t.Errorf("BaseURL = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 // TestFetcher_Fetch_EmptyParsedTitleDoesNotOverwrite は、パース済みタイトル・
 // サイト URL が空のとき、既存のタイトル・サイト URL が空値で上書きされず、
 // 永続化処理にも既存値が引き渡されることを検証する（Requirement 2.1 / 2.2）。
@@ -1416,3 +1508,762 @@ func TestFetcher_Fetch_LastSuccessfulFetchAtErrorDoesNotFailFetch(t *testing.T)
 		t.Errorf("UpdateLastSuccessfulFetchAt 呼び出し回数 = %d, want 1", feedRepo.lastSuccessfulFetchAtCalls)
 	}
 }
+
+// --- フェッチ失敗の原因分類（error_kind）のテスト ---
+
+func TestFetcher_Fetch_ErrorKind_BodyTooLarge(t *testing.T) {
+	// Arrange: 最大ボディサイズ（64バイト）を超えるレスポンスを返す
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, strings.Repeat("x", 128))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	mc := &mockMetricsCollector{}
+	f := NewFetcher(
+		&mockFeedRepo{updateFetchStateFunc: func(_ context.Context, _ *model.Feed) error { return nil }},
+		&mockSubRepo{minInterval: 60},
+		&mockUpsertService{},
+		&mockSSRFGuard{},
+		newTestLogger(&buf),
+		10*time.Second,
+		64,
+		WithMetrics(mc),
+	)
+	feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+	// Act
+	before := time.Now()
+	_ = f.Fetch(context.Background(), feed)
+
+	// Assert: パース失敗ではなくサイズ超過として分類され、長めのバックオフが適用される
+	if feed.ErrorKind != model.FetchErrorKindTooLarge {
+		t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindTooLarge)
+	}
+	if mc.parseFailure != 0 {
+		t.Errorf("RecordParseFailure 呼び出し回数 = %d, want 0", mc.parseFailure)
+	}
+	if feed.NextFetchAt.Before(before.Add(6 * time.Hour)) {
+		t.Errorf("サイズ超過時の NextFetchAt は6時間以上先であるべき: %v", feed.NextFetchAt)
+	}
+	if len(mc.errorKinds) != 1 || mc.errorKinds[0] != string(model.FetchErrorKindTooLarge) {
+		t.Errorf("RecordFetchErrorKind = %v, want [too_large]", mc.errorKinds)
+	}
+}
+
+func TestFetcher_Fetch_ErrorKind_BodyAtLimitIsAccepted(t *testing.T) {
+	// Arrange: ちょうど最大サイズのボディはサイズ超過として扱わない
+	body := `<?xml version="1.0"?><rss version="2.0"><channel><title>T</title></channel></rss>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	f := NewFetcher(
+		&mockFeedRepo{updateFetchStateFunc: func(_ context.Context, _ *model.Feed) error { return nil }},
+		&mockSubRepo{minInterval: 60},
+		&mockUpsertService{},
+		&mockSSRFGuard{},
+		newTestLogger(&buf),
+		10*time.Second,
+		int64(len(body)),
+	)
+	feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+	// Act
+	if err := f.Fetch(context.Background(), feed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Assert
+	if feed.ErrorKind != model.FetchErrorKindNone {
+		t.Errorf("ErrorKind = %q, want empty", feed.ErrorKind)
+	}
+	if feed.ConsecutiveErrors != 0 {
+		t.Errorf("ConsecutiveErrors = %d, want 0", feed.ConsecutiveErrors)
+	}
+}
+
+func TestFetcher_Fetch_ErrorKind_HTTPStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   model.FetchErrorKind
+	}{
+		{"404のときhttp_4xx", http.StatusNotFound, model.FetchErrorKindHTTP4xx},
+		{"429のときhttp_4xx", http.StatusTooManyRequests, model.FetchErrorKindHTTP4xx},
+		{"503のときhttp_5xx", http.StatusServiceUnavailable, model.FetchErrorKindHTTP5xx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			var buf bytes.Buffer
+			mc := &mockMetricsCollector{}
+			f := NewFetcher(
+				&mockFeedRepo{updateFetchStateFunc: func(_ context.Context, _ *model.Feed) error { return nil }},
+				&mockSubRepo{minInterval: 60},
+				&mockUpsertService{},
+				&mockSSRFGuard{},
+				newTestLogger(&buf),
+				10*time.Second,
+				5*1024*1024,
+				WithMetrics(mc),
+			)
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+			_ = f.Fetch(context.Background(), feed)
+
+			if feed.ErrorKind != tt.want {
+				t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, tt.want)
+			}
+			if len(mc.errorKinds) != 1 || mc.errorKinds[0] != string(tt.want) {
+				t.Errorf("RecordFetchErrorKind = %v, want [%s]", mc.errorKinds, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetcher_Fetch_ErrorKind_SSRFValidation(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFetcher(
+		&mockFeedRepo{updateFetchStateFunc: func(_ context.Context, _ *model.Feed) error { return nil }},
+		&mockSubRepo{minInterval: 60},
+		&mockUpsertService{},
+		&mockSSRFGuard{validateErr: fmt.Errorf("blocked IP address")},
+		newTestLogger(&buf),
+		10*time.Second,
+		5*1024*1024,
+	)
+	feed := &model.Feed{ID: "feed-1", FeedURL: "http://192.168.1.1/feed.xml", FetchStatus: model.FetchStatusActive}
+
+	_ = f.Fetch(context.Background(), feed)
+
+	if feed.ErrorKind != model.FetchErrorKindSSRF {
+		t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindSSRF)
+	}
+}
+
+func TestFetcher_Fetch_ErrorKind_ParseFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "not a feed")
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	f := NewFetcher(
+		&mockFeedRepo{updateFetchStateFunc: func(_ context.Context, _ *model.Feed) error { return nil }},
+		&mockSubRepo{minInterval: 60},
+		&mockUpsertService{},
+		&mockSSRFGuard{},
+		newTestLogger(&buf),
+		10*time.Second,
+		5*1024*1024,
+	)
+	feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+	_ = f.Fetch(context.Background(), feed)
+
+	if feed.ErrorKind != model.FetchErrorKindParse {
+		t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindParse)
+	}
+}
+
+func TestFetcher_Fetch_ErrorKind_ClearedOnSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	f := NewFetcher(
+		&mockFeedRepo{updateFetchStateFunc: func(_ context.Context, _ *model.Feed) error { return nil }},
+		&mockSubRepo{minInterval: 60},
+		&mockUpsertService{},
+		&mockSSRFGuard{},
+		newTestLogger(&buf),
+		10*time.Second,
+		5*1024*1024,
+	)
+	feed := &model.Feed{
+		ID:                "feed-1",
+		FeedURL:           server.URL,
+		FetchStatus:       model.FetchStatusActive,
+		ConsecutiveErrors: 2,
+		ErrorKind:         model.FetchErrorKindTimeout,
+	}
+
+	_ = f.Fetch(context.Background(), feed)
+
+	if feed.ErrorKind != model.FetchErrorKindNone {
+		t.Errorf("ErrorKind = %q, want empty", feed.ErrorKind)
+	}
+}
+
+func TestFetcher_Fetch_ErrorDetail(t *testing.T) {
+	newFetcher := func() *Fetcher {
+		var buf bytes.Buffer
+		return NewFetcher(
+			&mockFeedRepo{updateFetchStateFunc: func(_ context.Context, _ *model.Feed) error { return nil }},
+			&mockSubRepo{minInterval: 60},
+			&mockUpsertService{},
+			&mockSSRFGuard{},
+			newTestLogger(&buf),
+			10*time.Second,
+			5*1024*1024,
+		)
+	}
+
+	t.Run("TLS検証失敗のとき証明書情報をエラー詳細に記録する", func(t *testing.T) {
+		// Arrange: 自己署名証明書のサーバー（クライアントは信頼していない）
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+		// Act
+		_ = newFetcher().Fetch(context.Background(), feed)
+
+		// Assert
+		if feed.ErrorKind != model.FetchErrorKindTLS {
+			t.Fatalf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindTLS)
+		}
+		d := feed.ErrorDetail
+		if d == nil {
+			t.Fatal("ErrorDetail = nil, want TLS detail")
+		}
+		if d.TLSError != model.TLSErrorUnknownAuthority {
+			t.Errorf("TLSError = %q, want %q", d.TLSError, model.TLSErrorUnknownAuthority)
+		}
+		if d.CertNotAfter == nil || !d.CertNotAfter.Equal(server.Certificate().NotAfter) {
+			t.Errorf("CertNotAfter = %v, want %v", d.CertNotAfter, server.Certificate().NotAfter)
+		}
+		if feed.HTTPVersion != "" {
+			t.Errorf("HTTPVersion = %q, want empty（応答を受信していない）", feed.HTTPVersion)
+		}
+	})
+
+	t.Run("HTTPエラー応答のときステータスとHTTPバージョンを記録する", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+		// Act
+		_ = newFetcher().Fetch(context.Background(), feed)
+
+		// Assert
+		if feed.ErrorDetail == nil || feed.ErrorDetail.HTTPStatus != http.StatusServiceUnavailable {
+			t.Errorf("ErrorDetail = %+v, want HTTPStatus 503", feed.ErrorDetail)
+		}
+		if feed.HTTPVersion != "HTTP/1.1" {
+			t.Errorf("HTTPVersion = %q, want %q", feed.HTTPVersion, "HTTP/1.1")
+		}
+	})
+
+	t.Run("成功したときエラー詳細をクリアする", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotModified)
+		}))
+		defer server.Close()
+		feed := &model.Feed{
+			ID:          "feed-1",
+			FeedURL:     server.URL,
+			FetchStatus: model.FetchStatusActive,
+			ErrorKind:   model.FetchErrorKindTLS,
+			ErrorDetail: &model.FetchErrorDetail{TLSError: model.TLSErrorCertExpired},
+		}
+
+		// Act
+		_ = newFetcher().Fetch(context.Background(), feed)
+
+		// Assert
+		if feed.ErrorDetail != nil {
+			t.Errorf("ErrorDetail = %+v, want nil", feed.ErrorDetail)
+		}
+	})
+}
+
+// mockItemFilter は ItemFilter のテスト用モック。
+type mockItemFilter struct {
+	filterItemsFn func(ctx context.Context, feedID string, items []model.ParsedItem) ([]model.ParsedItem, error)
+}
+
+func (m *mockItemFilter) FilterItems(ctx context.Context, feedID string, items []model.ParsedItem) ([]model.ParsedItem, error) {
+	return m.filterItemsFn(ctx, feedID, items)
+}
+
+func TestFetcher_Fetch_ItemFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel>
+    <title>Test Feed</title>
+    <item><title>Go 1.25 released</title><guid>guid-1</guid></item>
+    <item><title>Weekly photo</title><guid>guid-2</guid></item>
+  </channel>
+</rss>`)
+	}))
+	defer server.Close()
+
+	newFetcher := func(upsertSvc *mockUpsertService, filter ItemFilter) *Fetcher {
+		var buf bytes.Buffer
+		return NewFetcher(
+			&mockFeedRepo{}, &mockSubRepo{minInterval: 60}, upsertSvc, &mockSSRFGuard{},
+			newTestLogger(&buf), 10*time.Second, 5*1024*1024,
+			WithItemFilter(filter),
+		)
+	}
+
+	t.Run("フィルタを設定したとき一致した記事のみUPSERTに渡す", func(t *testing.T) {
+		// Arrange
+		upsertSvc := &mockUpsertService{}
+		var gotFeedID string
+		filter := &mockItemFilter{
+			filterItemsFn: func(_ context.Context, feedID string, items []model.ParsedItem) ([]model.ParsedItem, error) {
+				gotFeedID = feedID
+				return items[:1], nil
+			},
+		}
+		f := newFetcher(upsertSvc, filter)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+		// Act
+		err := f.Fetch(context.Background(), feed)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Fetch() がエラーを返した: %v", err)
+		}
+		if gotFeedID != "feed-1" {
+			t.Errorf("feedID = %q, want %q", gotFeedID, "feed-1")
+		}
+		if len(upsertSvc.calledWith) != 1 || upsertSvc.calledWith[0].GuidOrID != "guid-1" {
+			t.Errorf("UpsertItems に渡された記事 = %+v, want guid-1 only", upsertSvc.calledWith)
+		}
+	})
+
+	t.Run("フィルタの適用に失敗したとき全記事をUPSERTに渡す", func(t *testing.T) {
+		// Arrange
+		upsertSvc := &mockUpsertService{}
+		filter := &mockItemFilter{
+			filterItemsFn: func(_ context.Context, _ string, _ []model.ParsedItem) ([]model.ParsedItem, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		f := newFetcher(upsertSvc, filter)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+		// Act
+		err := f.Fetch(context.Background(), feed)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Fetch() がエラーを返した: %v", err)
+		}
+		if len(upsertSvc.calledWith) != 2 {
+			t.Errorf("UpsertItems に渡された記事数 = %d, want 2", len(upsertSvc.calledWith))
+		}
+		if feed.FetchStatus != model.FetchStatusActive {
+			t.Errorf("FetchStatus = %q, want %q", feed.FetchStatus, model.FetchStatusActive)
+		}
+	})
+}
+
+// mockAttemptRecorder は AttemptRecorder のモック実装。
+type mockAttemptRecorder struct {
+	attempts []model.FetchAttempt
+	err      error
+}
+
+func (m *mockAttemptRecorder) RecordFetchAttempt(_ context.Context, attempt model.FetchAttempt) error {
+	m.attempts = append(m.attempts, attempt)
+	return m.err
+}
+
+func TestFetcher_Fetch_AttemptRecorder(t *testing.T) {
+	newServer := func(status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+			w.Header().Set("Content-Type", "application/rss+xml")
+			fmt.Fprint(w, `<?xml version="1.0"?><rss version="2.0"><channel><title>T</title><item><title>A</title><guid>g-1</guid></item></channel></rss>`)
+		}))
+	}
+	newFetcher := func(recorder AttemptRecorder) *Fetcher {
+		var buf bytes.Buffer
+		return NewFetcher(
+			&mockFeedRepo{}, &mockSubRepo{minInterval: 60}, &mockUpsertService{}, &mockSSRFGuard{},
+			newTestLogger(&buf), 10*time.Second, 5*1024*1024,
+			WithAttemptRecorder(recorder),
+		)
+	}
+
+	cases := []struct {
+		name          string
+		status        int
+		wantSucceeded bool
+	}{
+		{"200で取り込みまで完了したとき成功として記録する", http.StatusOK, true},
+		{"304のとき成功として記録する", http.StatusNotModified, true},
+		{"404で停止したとき失敗として記録する", http.StatusNotFound, false},
+		{"500のとき失敗として記録する", http.StatusInternalServerError, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			server := newServer(tc.status)
+			defer server.Close()
+			recorder := &mockAttemptRecorder{}
+			f := newFetcher(recorder)
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+			// Act
+			_ = f.Fetch(context.Background(), feed)
+
+			// Assert
+			if len(recorder.attempts) != 1 {
+				t.Fatalf("記録件数 = %d, want 1", len(recorder.attempts))
+			}
+			got := recorder.attempts[0]
+			if got.FeedID != "feed-1" || got.Succeeded != tc.wantSucceeded || got.AttemptedAt.IsZero() {
+				t.Errorf("attempt = %+v, want feed-1 succeeded=%v", got, tc.wantSucceeded)
+			}
+		})
+	}
+
+	t.Run("記録に失敗してもフェッチ結果に影響しない", func(t *testing.T) {
+		// Arrange
+		server := newServer(http.StatusOK)
+		defer server.Close()
+		f := newFetcher(&mockAttemptRecorder{err: errors.New("db error")})
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+		// Act
+		err := f.Fetch(context.Background(), feed)
+
+		// Assert
+		if err != nil {
+			t.Errorf("Fetch() がエラーを返した: %v", err)
+		}
+	})
+}
+
+func TestFetcher_FetchWithStats(t *testing.T) {
+	newServer := func(status int, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+			w.Header().Set("Content-Type", "application/rss+xml")
+			fmt.Fprint(w, body)
+		}))
+	}
+	const validRSS = `<?xml version="1.0"?><rss version="2.0"><channel><title>T</title><item><title>A</title><guid>g-1</guid></item></channel></rss>`
+
+	cases := []struct {
+		name         string
+		status       int
+		body         string
+		wantStats    FetchStats
+		wantFetchErr bool
+	}{
+		{"200で取り込みまで完了したとき成功と新規/更新記事数を返す", http.StatusOK, validRSS, FetchStats{Succeeded: true, ItemsInserted: 2, ItemsUpdated: 1}, false},
+		{"304のとき記事数0の成功を返す", http.StatusNotModified, "", FetchStats{Succeeded: true}, false},
+		{"パース失敗のときerrorを返さず失敗を返す", http.StatusOK, "not valid XML", FetchStats{}, false},
+		{"500のとき失敗を返す", http.StatusInternalServerError, "", FetchStats{}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			server := newServer(tc.status, tc.body)
+			defer server.Close()
+			var buf bytes.Buffer
+			f := NewFetcher(
+				&mockFeedRepo{}, &mockSubRepo{minInterval: 60}, &mockUpsertService{insertCount: 2, updateCount: 1}, &mockSSRFGuard{},
+				newTestLogger(&buf), 10*time.Second, 5*1024*1024,
+			)
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+			// Act
+			stats, err := f.FetchWithStats(context.Background(), feed)
+
+			// Assert
+			if (err != nil) != tc.wantFetchErr {
+				t.Fatalf("err = %v, wantFetchErr = %v", err, tc.wantFetchErr)
+			}
+			if stats != tc.wantStats {
+				t.Errorf("stats = %+v, want %+v", stats, tc.wantStats)
+			}
+		})
+	}
+}
+
+func TestFetcher_Fetch_MaxItemsKeepsNewest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel>
+    <title>Huge Feed</title>
+    <item><title>1</title><guid>guid-1</guid><pubDate>Mon, 05 Jan 2026 00:00:00 GMT</pubDate></item>
+    <item><title>2</title><guid>guid-2</guid><pubDate>Fri, 09 Jan 2026 00:00:00 GMT</pubDate></item>
+    <item><title>3</title><guid>guid-3</guid><pubDate>Thu, 01 Jan 2026 00:00:00 GMT</pubDate></item>
+    <item><title>4</title><guid>guid-4</guid><pubDate>Wed, 07 Jan 2026 00:00:00 GMT</pubDate></item>
+  </channel>
+</rss>`)
+	}))
+	defer server.Close()
+
+	upsertSvc := &mockUpsertService{insertCount: 2}
+	feedRepo := &mockFeedRepo{
+		updateFetchStateFunc: func(ctx context.Context, feed *model.Feed) error {
+			return nil
+		},
+	}
+
+	var buf bytes.Buffer
+	f := NewFetcher(
+		feedRepo,
+		&mockSubRepo{minInterval: 60},
+		upsertSvc,
+		&mockSSRFGuard{},
+		newTestLogger(&buf),
+		10*time.Second,
+		5*1024*1024,
+		WithMaxItems(2),
+	)
+
+	feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+	_ = f.Fetch(context.Background(), feed)
+
+	// 上限を超えた分は公開日時の新しい順に採用されること
+	if len(upsertSvc.calledWith) != 2 {
+		t.Fatalf("UpsertItemsに渡された記事数 = %d, want 2", len(upsertSvc.calledWith))
+	}
+	if upsertSvc.calledWith[0].GuidOrID != "guid-2" || upsertSvc.calledWith[1].GuidOrID != "guid-4" {
+		t.Errorf("採用された記事 = [%s %s], want [guid-2 guid-4]",
+			upsertSvc.calledWith[0].GuidOrID, upsertSvc.calledWith[1].GuidOrID)
+	}
+	if !strings.Contains(buf.String(), `"items_total":4`) {
+		t.Errorf("上限超過がログに記録されるべき: %s", buf.String())
+	}
+}
+
+// mockRediscoverer は FeedRediscoverer のモック実装。
+type mockRediscoverer struct {
+	found     string
+	err       error
+	gotTarget string
+	calls     int
+}
+
+func (m *mockRediscoverer) DetectFeedURL(_ context.Context, inputURL string) (string, error) {
+	m.calls++
+	m.gotTarget = inputURL
+	return m.found, m.err
+}
+
+// mockSuggestionRepo は repository.FeedURLSuggestionRepository のモック実装。
+type mockSuggestionRepo struct {
+	calls        int
+	gotFeedID    string
+	gotSuggested string
+}
+
+func (m *mockSuggestionRepo) SetSuggestedFeedURL(_ context.Context, feedID, suggestedURL string) error {
+	m.calls++
+	m.gotFeedID = feedID
+	m.gotSuggested = suggestedURL
+	return nil
+}
+
+func (m *mockSuggestionRepo) FindSuggestedFeedURL(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+func (m *mockSuggestionRepo) ApplySuggestedFeedURL(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
+func TestFetcher_Fetch_FeedRediscovery(t *testing.T) {
+	cases := []struct {
+		name          string
+		status        int
+		siteURL       string
+		found         string
+		detectErr     error
+		wantCalls     int
+		wantTarget    string
+		wantSuggested string
+	}{
+		{
+			name: "404で停止したときサイトから検出したURLを提案として保存する", status: http.StatusNotFound,
+			siteURL: "https://example.com/", found: "https://example.com/new-feed.xml",
+			wantCalls: 1, wantTarget: "https://example.com/", wantSuggested: "https://example.com/new-feed.xml",
+		},
+		{
+			name: "410で停止したとき再検出する", status: http.StatusGone,
+			siteURL: "https://example.com/", found: "https://example.com/new-feed.xml",
+			wantCalls: 1, wantTarget: "https://example.com/", wantSuggested: "https://example.com/new-feed.xml",
+		},
+		{
+			name: "候補が見つからないとき古い提案を消す", status: http.StatusNotFound,
+			siteURL: "https://example.com/", detectErr: errors.New("no feed"),
+			wantCalls: 1, wantTarget: "https://example.com/", wantSuggested: "",
+		},
+		{
+			name: "403で停止したとき再検出しない", status: http.StatusForbidden,
+			siteURL: "https://example.com/", found: "https://example.com/new-feed.xml",
+			wantCalls: 0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+			detector := &mockRediscoverer{found: tc.found, err: tc.detectErr}
+			repo := &mockSuggestionRepo{}
+			var buf bytes.Buffer
+			f := NewFetcher(
+				&mockFeedRepo{}, &mockSubRepo{minInterval: 60}, &mockUpsertService{}, &mockSSRFGuard{},
+				newTestLogger(&buf), 10*time.Second, 5*1024*1024,
+				WithFeedRediscovery(detector, repo),
+			)
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, SiteURL: tc.siteURL, FetchStatus: model.FetchStatusActive}
+
+			// Act
+			err := f.Fetch(context.Background(), feed)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Fetch() がエラーを返した: %v", err)
+			}
+			if detector.calls != tc.wantCalls || repo.calls != tc.wantCalls {
+				t.Fatalf("再検出 %d 回・保存 %d 回, want %d 回", detector.calls, repo.calls, tc.wantCalls)
+			}
+			if tc.wantCalls == 0 {
+				return
+			}
+			if detector.gotTarget != tc.wantTarget {
+				t.Errorf("再検出の起点 = %q, want %q", detector.gotTarget, tc.wantTarget)
+			}
+			if repo.gotFeedID != "feed-1" || repo.gotSuggested != tc.wantSuggested {
+				t.Errorf("保存 = (%q, %q), want (feed-1, %q)", repo.gotFeedID, repo.gotSuggested, tc.wantSuggested)
+			}
+		})
+	}
+
+	t.Run("検出したURLが元のフィードURLと同じとき提案しない", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		detector := &mockRediscoverer{found: server.URL}
+		repo := &mockSuggestionRepo{}
+		var buf bytes.Buffer
+		f := NewFetcher(
+			&mockFeedRepo{}, &mockSubRepo{minInterval: 60}, &mockUpsertService{}, &mockSSRFGuard{},
+			newTestLogger(&buf), 10*time.Second, 5*1024*1024,
+			WithFeedRediscovery(detector, repo),
+		)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+		// Act
+		_ = f.Fetch(context.Background(), feed)
+
+		// Assert
+		if detector.gotTarget != server.URL+"/" {
+			t.Errorf("site_url 未設定時の再検出の起点 = %q, want %q", detector.gotTarget, server.URL+"/")
+		}
+		if repo.calls != 1 || repo.gotSuggested != "" {
+			t.Errorf("保存 = %d 回 %q, want 1 回 空文字（提案なし）", repo.calls, repo.gotSuggested)
+		}
+	})
+}
+
+// mockNewItemNotifier は NewItemNotifier のテスト用モック。
+type mockNewItemNotifier struct {
+	feedIDs []string
+	err     error
+}
+
+func (m *mockNewItemNotifier) EnqueueNewItems(_ context.Context, feedID string) (int, error) {
+	m.feedIDs = append(m.feedIDs, feedID)
+	return 1, m.err
+}
+
+func TestFetcher_Fetch_EnqueuesNewItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel>
+    <title>Test Feed</title>
+    <item><title>Article 1</title><link>https://example.com/article1</link><guid>guid-1</guid></item>
+  </channel>
+</rss>`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		insertCount int
+		notifyErr   error
+		wantCalls   int
+	}{
+		{name: "新着記事があるとき転送キューへ積む", insertCount: 1, wantCalls: 1},
+		{name: "新着記事がないとき転送キューへ積まない", insertCount: 0, wantCalls: 0},
+		{name: "キューへの投入に失敗してもフェッチは成功する", insertCount: 1, notifyErr: errors.New("db error"), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var buf bytes.Buffer
+			notifier := &mockNewItemNotifier{err: tt.notifyErr}
+			f := NewFetcher(
+				&mockFeedRepo{updateFetchStateFunc: func(context.Context, *model.Feed) error { return nil }},
+				&mockSubRepo{minInterval: 60},
+				&mockUpsertService{insertCount: tt.insertCount, updateCount: 1 - tt.insertCount},
+				&mockSSRFGuard{},
+				newTestLogger(&buf),
+				10*time.Second,
+				5*1024*1024,
+				WithNewItemNotifier(notifier),
+			)
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+			// Act
+			err := f.Fetch(context.Background(), feed)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Fetch returned error: %v", err)
+			}
+			if len(notifier.feedIDs) != tt.wantCalls {
+				t.Errorf("EnqueueNewItems calls = %d, want %d", len(notifier.feedIDs), tt.wantCalls)
+			}
+			if feed.ConsecutiveErrors != 0 {
+				t.Errorf("ConsecutiveErrors = %d, want 0", feed.ConsecutiveErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package fetch
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// hostLimiter はホスト単位のフェッチの礼儀（politeness）を保証する。
+// 同一ホストへの同時接続を 1 本に制限し、直前のアクセス完了から minInterval が経過するまで
+// 次のアクセスを待たせる。大手ブログサービス等で 1 ホストに多数のフィードがある場合に、
+// 短時間の連続アクセスを避けるために使う。
+type hostLimiter struct {
+	minInterval time.Duration
+	now         func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*hostSlot
+}
+
+// hostSlot は 1 ホスト分の接続枠と最終アクセス時刻。
+// lastAccess は sem を保持している間のみ読み書きする。
+type hostSlot struct {
+	sem        chan struct{}
+	lastAccess time.Time
+	refs       int
+}
+
+// newHostLimiter は hostLimiter を生成する。minInterval が 0 以下の場合は同時接続数の制限のみ行う。
+func newHostLimiter(minInterval time.Duration) *hostLimiter {
+	return &hostLimiter{
+		minInterval: minInterval,
+		now:         time.Now,
+		hosts:       make(map[string]*hostSlot),
+	}
+}
+
+// acquire は host の接続枠を取得し、最小アクセス間隔が経過するまで待つ。
+// 戻り値の release はアクセス完了時に必ず呼び出すこと（完了時刻を次回の間隔の起点にする）。
+// 待機中に ctx がキャンセルされた場合は ctx.Err() を返す。
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	slot := l.ref(host)
+
+	select {
+	case slot.sem <- struct{}{}:
+	case <-ctx.Done():
+		l.unref(slot)
+		return nil, ctx.Err()
+	}
+
+	if !slot.lastAccess.IsZero() {
+		if wait := slot.lastAccess.Add(l.minInterval).Sub(l.now()); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				<-slot.sem
+				l.unref(slot)
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return func() {
+		slot.lastAccess = l.now()
+		<-slot.sem
+		l.unref(slot)
+	}, nil
+}
+
+// ref は host の枠を参照カウント付きで取得する（なければ作成する）。
+func (l *hostLimiter) ref(host string) *hostSlot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slot, ok := l.hosts[host]
+	if !ok {
+		slot = &hostSlot{sem: make(chan struct{}, 1)}
+		l.hosts[host] = slot
+	}
+	slot.refs++
+	return slot
+}
+
+// unref は参照カウントを減らし、参照がなく間隔の制約も切れた枠を破棄する。
+// 間隔内の枠は次のアクセスの待機に最終アクセス時刻が必要なため残し、後続の unref で破棄する。
+func (l *hostLimiter) unref(slot *hostSlot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slot.refs--
+	now := l.now()
+	for h, s := range l.hosts {
+		if s.refs == 0 && now.Sub(s.lastAccess) >= l.minInterval {
+			delete(l.hosts, h)
+		}
+	}
+}
+
+// feedHost はフィード URL から politeness 制御の単位となるホスト名を返す。
+// ポートは区別せず、大文字小文字を正規化する。解釈できない場合は空文字列を返す。
+func feedHost(feedURL string) string {
+	u, err := url.Parse(feedURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
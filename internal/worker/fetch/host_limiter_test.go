@@ -0,0 +1,126 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHostLimiter_Acquire(t *testing.T) {
+	t.Run("同一ホストの枠は解放されるまで取得できない", func(t *testing.T) {
+		// Arrange
+		l := newHostLimiter(0)
+		release, err := l.acquire(context.Background(), "example.com")
+		if err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		// Act
+		_, err = l.acquire(ctx, "example.com")
+
+		// Assert
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("2 本目の acquire() error = %v, want DeadlineExceeded", err)
+		}
+		release()
+	})
+
+	t.Run("別ホストの枠は同時に取得できる", func(t *testing.T) {
+		// Arrange
+		l := newHostLimiter(0)
+		release, err := l.acquire(context.Background(), "a.example.com")
+		if err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+		defer release()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// Act
+		releaseB, err := l.acquire(ctx, "b.example.com")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("別ホストの acquire() error = %v", err)
+		}
+		releaseB()
+	})
+
+	t.Run("直前のアクセス完了から最小間隔が経過するまで待つ", func(t *testing.T) {
+		// Arrange
+		const interval = 50 * time.Millisecond
+		l := newHostLimiter(interval)
+		release, err := l.acquire(context.Background(), "example.com")
+		if err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+		release()
+		released := time.Now()
+
+		// Act
+		release, err = l.acquire(context.Background(), "example.com")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+		if waited := time.Since(released); waited < interval {
+			t.Errorf("待機時間 = %v, want >= %v", waited, interval)
+		}
+		release()
+	})
+
+	t.Run("間隔待ちの間に ctx がキャンセルされたとき枠を解放してエラーを返す", func(t *testing.T) {
+		// Arrange
+		l := newHostLimiter(time.Hour)
+		release, _ := l.acquire(context.Background(), "example.com")
+		release()
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		// Act
+		_, err := l.acquire(ctx, "example.com")
+
+		// Assert
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("acquire() error = %v, want DeadlineExceeded", err)
+		}
+		if got := len(l.hosts["example.com"].sem); got != 0 {
+			t.Errorf("キャンセル後も枠が保持されている（使用中 = %d）", got)
+		}
+	})
+
+	t.Run("参照がなく間隔を過ぎたホストの枠は破棄される", func(t *testing.T) {
+		// Arrange
+		l := newHostLimiter(0)
+
+		// Act
+		release, _ := l.acquire(context.Background(), "example.com")
+		release()
+
+		// Assert
+		if len(l.hosts) != 0 {
+			t.Errorf("残っている枠 = %d, want 0", len(l.hosts))
+		}
+	})
+}
+
+func TestFeedHost(t *testing.T) {
+	tests := []struct {
+		feedURL string
+		want    string
+	}{
+		{"https://Blog.Example.com/feed.xml", "blog.example.com"},
+		{"https://blog.example.com:8443/a/rss", "blog.example.com"},
+		{"", ""},
+		{"://bad", ""},
+	}
+	for _, tt := range tests {
+		if got := feedHost(tt.feedURL); got != tt.want {
+			t.Errorf("feedHost(%q) = %q, want %q", tt.feedURL, got, tt.want)
+		}
+	}
+}
@@ -10,7 +10,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hitoshi/feedman/internal/dryrun"
+	"github.com/hitoshi/feedman/internal/item"
 	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
 )
 
 // TestIntegration_WorkerFetchFlow はワーカーフェッチフロー全体を検証する。
@@ -265,3 +269,67 @@ func TestIntegration_WorkerFetchFlow_ConditionalGET_304(t *testing.T) {
 		t.Error("expected next_fetch_at to be in the future")
 	}
 }
+
+// dryRunItemRepo は既存記事が無い状態を返す ItemRepository。書き込みは dryrun.ItemRepository が受け持つ。
+type dryRunItemRepo struct {
+	repository.ItemRepository
+}
+
+func (dryRunItemRepo) FindExistingForUpsert(context.Context, string, []string, []string, []string) (*repository.ExistingItems, error) {
+	return &repository.ExistingItems{}, nil
+}
+
+// TestIntegration_WorkerFetchFlow_DryRun はドライランのリポジトリで RunOnce を実行したとき、
+// 取得・パース・同一性判定までは行い、書き込みは Recorder への記録だけになることを検証する。
+func TestIntegration_WorkerFetchFlow_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel><title>Dry Run Feed</title><link>https://example.com</link>
+<item><title>Article 1</title><link>https://example.com/article/1</link><guid>guid-1</guid></item>
+<item><title>Article 2</title><link>https://example.com/article/2</link><guid>guid-2</guid></item>
+</channel></rss>`)
+	}))
+	defer server.Close()
+
+	testFeed := &model.Feed{
+		ID:          "feed-dry-run",
+		FeedURL:     server.URL + "/feed.xml",
+		FetchStatus: model.FetchStatusActive,
+		NextFetchAt: time.Now().Add(-1 * time.Minute),
+	}
+	feedRepo := &mockFeedRepo{
+		listDueForFetchFunc: func(ctx context.Context) ([]*model.Feed, error) {
+			return []*model.Feed{testFeed}, nil
+		},
+		updateFetchStateFunc: func(ctx context.Context, feed *model.Feed) error {
+			t.Error("ドライランでフェッチ状態が書き込まれた")
+			return nil
+		},
+	}
+
+	rec := dryrun.NewRecorder(slog.Default())
+	dryFeedRepo := dryrun.NewFeedRepository(feedRepo, rec)
+	upsertSvc := item.NewItemUpsertService(dryrun.NewItemRepository(dryRunItemRepo{}, rec), security.SharedContentSanitizer())
+	fetcher := NewFetcher(
+		dryFeedRepo, &mockSubRepo{minInterval: 60}, upsertSvc, &mockSSRFGuard{},
+		slog.Default(), 10*time.Second, 5*1024*1024,
+	)
+	scheduler := NewScheduler(dryFeedRepo, fetcher, slog.Default(), 2)
+
+	if err := scheduler.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce returned error: %v", err)
+	}
+
+	var ops []string
+	for _, w := range rec.Writes() {
+		ops = append(ops, w.Op)
+	}
+	want := []string{dryrun.OpCreateItem, dryrun.OpCreateItem, dryrun.OpUpdateLastSuccessfulFetchAt, dryrun.OpUpdateFetchState}
+	if fmt.Sprint(ops) != fmt.Sprint(want) {
+		t.Errorf("記録した書き込み = %v, want %v", ops, want)
+	}
+	if testFeed.Title != "Dry Run Feed" {
+		t.Errorf("feed title = %q, want %q", testFeed.Title, "Dry Run Feed")
+	}
+}
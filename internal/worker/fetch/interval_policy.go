@@ -0,0 +1,149 @@
+package fetch
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// maxExtendedIntervalMinutes は延長後のフェッチ間隔の上限（分）。
+// 購読設定で指定できる最大のフェッチ間隔（12 時間）と同じで、これを超えて間隔を空けない。
+const maxExtendedIntervalMinutes = 720
+
+// SubscriberIntervalPolicy は購読者数に応じてフェッチ間隔を延長するポリシー。
+// 購読者が多いフィードは購読者の設定した最小の間隔どおりに取得し、少ないフィードほど間隔を延ばして
+// 取得元と worker の負荷を下げる。
+type SubscriberIntervalPolicy struct {
+	// FullRateSubscribers はこの人数以上の購読者がいるフィードでは間隔を延長しない。1 以下で延長を無効にする。
+	FullRateSubscribers int
+	// MaxExtension は購読者 1 人のフィードに掛ける倍率。購読者が増えるにつれ FullRateSubscribers で 1 倍になるよう
+	// 線形に減らす。1 以下で延長を無効にする。
+	MaxExtension float64
+}
+
+// enabled はポリシーが有効かどうかを返す。
+func (p SubscriberIntervalPolicy) enabled() bool {
+	return p.FullRateSubscribers > 1 && p.MaxExtension > 1
+}
+
+// EffectiveInterval は購読者の設定した最小のフェッチ間隔 minInterval（分）と購読者数 subscribers から
+// 実効フェッチ間隔（分）を求める。延長後の間隔は maxExtendedIntervalMinutes を上限とし、
+// minInterval が既に上限以上の場合は minInterval をそのまま返す（購読者の設定より短くはしない）。
+func (p SubscriberIntervalPolicy) EffectiveInterval(minInterval, subscribers int) int {
+	if minInterval <= 0 || subscribers <= 0 || !p.enabled() || subscribers >= p.FullRateSubscribers {
+		return minInterval
+	}
+	// 購読者 1 人で MaxExtension 倍、FullRateSubscribers 人で 1 倍
+	ratio := float64(p.FullRateSubscribers-subscribers) / float64(p.FullRateSubscribers-1)
+	factor := 1 + (p.MaxExtension-1)*ratio
+	extended := int(math.Round(float64(minInterval) * factor))
+	return max(minInterval, min(extended, maxExtendedIntervalMinutes))
+}
+
+// WithSubscriberIntervalPolicy は購読者数に応じたフェッチ間隔の延長を有効にする。
+// 未指定時、または policy が延長しない設定（MaxExtension が 1 以下など）の場合は購読者数を数えず、
+// 購読者の設定した最小の間隔どおりに次回フェッチを設定する。
+func WithSubscriberIntervalPolicy(counter repository.FeedSubscriberCounter, policy SubscriberIntervalPolicy) FetcherOption {
+	return func(f *Fetcher) {
+		f.subscriberCounter = counter
+		f.intervalPolicy = policy
+	}
+}
+
+// DormantIntervalPolicy は休眠ユーザーのみが購読するフィードのフェッチ間隔を延長するポリシー。
+// 長期間ログインしていないユーザーのためにフィードを取得し続けないよう、アクティブな購読者が 1 人もいない
+// フィードは Interval まで間隔を空ける。休眠ユーザーが復帰したときは API サーバーが次回フェッチを前倒しする。
+type DormantIntervalPolicy struct {
+	// DormantAfter は最終アクティブ日時からこの期間が過ぎたユーザーを休眠中とみなす。0 以下で延長を無効にする。
+	DormantAfter time.Duration
+	// Interval は休眠ユーザーのみが購読するフィードのフェッチ間隔。0 以下で延長を無効にする。
+	// 購読者数に応じた延長と異なり 12 時間の上限は掛けない。
+	Interval time.Duration
+}
+
+// enabled はポリシーが有効かどうかを返す。
+func (p DormantIntervalPolicy) enabled() bool {
+	return p.DormantAfter > 0 && p.Interval > 0
+}
+
+// EffectiveInterval は実効フェッチ間隔 interval（分）に休眠時の延長を適用する。
+// アクティブな購読者がいる場合、または interval が既に延長後の間隔以上の場合は interval をそのまま返す。
+func (p DormantIntervalPolicy) EffectiveInterval(interval int, hasActiveSubscriber bool) int {
+	if !p.enabled() || hasActiveSubscriber {
+		return interval
+	}
+	return max(interval, int(p.Interval/time.Minute))
+}
+
+// WithDormantIntervalPolicy は休眠ユーザーのみが購読するフィードのフェッチ間隔の延長を有効にする。
+// 未指定時は購読者のアクティブ状況によらず間隔を決める。
+func WithDormantIntervalPolicy(checker repository.FeedActiveSubscriberChecker, policy DormantIntervalPolicy) FetcherOption {
+	return func(f *Fetcher) {
+		f.activeSubscriberChecker = checker
+		f.dormantPolicy = policy
+	}
+}
+
+// getFetchInterval はフィードの次回フェッチまでの間隔（分）を求める。
+// 購読者の設定した最小のフェッチ間隔に、購読者数に応じた延長と休眠ユーザーのみが購読する場合の延長を適用する。
+// 購読者数・アクティブな購読者の有無の取得に失敗した場合は警告ログのみ出力し、その延長を適用しない。
+func (f *Fetcher) getFetchInterval(ctx context.Context, feedID string) (int, error) {
+	interval, err := f.getMinFetchInterval(ctx, feedID)
+	if err != nil {
+		return interval, err
+	}
+	interval = f.applySubscriberIntervalPolicy(ctx, feedID, interval)
+	return f.applyDormantIntervalPolicy(ctx, feedID, interval, time.Now()), nil
+}
+
+// applySubscriberIntervalPolicy は購読者数に応じてフェッチ間隔 interval（分）を延長する。
+func (f *Fetcher) applySubscriberIntervalPolicy(ctx context.Context, feedID string, interval int) int {
+	if f.subscriberCounter == nil || !f.intervalPolicy.enabled() {
+		return interval
+	}
+	subscribers, err := f.subscriberCounter.CountSubscribersByFeedID(ctx, feedID)
+	if err != nil {
+		f.logger.Warn("購読者数の取得に失敗しました",
+			slog.String("feed_id", feedID),
+			slog.String("error", err.Error()),
+		)
+		return interval
+	}
+	effective := f.intervalPolicy.EffectiveInterval(interval, subscribers)
+	if effective != interval {
+		f.logger.Debug("購読者数に応じてフェッチ間隔を延長します",
+			slog.String("feed_id", feedID),
+			slog.Int("subscribers", subscribers),
+			slog.Int("min_interval_minutes", interval),
+			slog.Int("effective_interval_minutes", effective),
+		)
+	}
+	return effective
+}
+
+// applyDormantIntervalPolicy はフィードにアクティブな購読者がいない場合にフェッチ間隔 interval（分）を延長する。
+func (f *Fetcher) applyDormantIntervalPolicy(ctx context.Context, feedID string, interval int, now time.Time) int {
+	if f.activeSubscriberChecker == nil || !f.dormantPolicy.enabled() {
+		return interval
+	}
+	active, err := f.activeSubscriberChecker.HasActiveSubscriber(ctx, feedID, now.Add(-f.dormantPolicy.DormantAfter))
+	if err != nil {
+		f.logger.Warn("アクティブな購読者の有無の取得に失敗しました",
+			slog.String("feed_id", feedID),
+			slog.String("error", err.Error()),
+		)
+		return interval
+	}
+	effective := f.dormantPolicy.EffectiveInterval(interval, active)
+	if effective != interval {
+		f.logger.Debug("休眠ユーザーのみが購読するためフェッチ間隔を延長します",
+			slog.String("feed_id", feedID),
+			slog.Int("interval_minutes", interval),
+			slog.Int("effective_interval_minutes", effective),
+		)
+	}
+	return effective
+}
@@ -0,0 +1,265 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockSubscriberCounter は repository.FeedSubscriberCounter のモック。
+type mockSubscriberCounter struct {
+	count int
+	err   error
+	calls int
+}
+
+func (m *mockSubscriberCounter) CountSubscribersByFeedID(_ context.Context, _ string) (int, error) {
+	m.calls++
+	return m.count, m.err
+}
+
+var _ repository.FeedSubscriberCounter = (*mockSubscriberCounter)(nil)
+
+func TestSubscriberIntervalPolicy_EffectiveInterval(t *testing.T) {
+	policy := SubscriberIntervalPolicy{FullRateSubscribers: 50, MaxExtension: 2.0}
+
+	tests := []struct {
+		name        string
+		policy      SubscriberIntervalPolicy
+		minInterval int
+		subscribers int
+		want        int
+	}{
+		{name: "購読者が1人のとき最大倍率で延長する", policy: policy, minInterval: 30, subscribers: 1, want: 60},
+		{name: "購読者が増えるほど延長幅を線形に減らす", policy: SubscriberIntervalPolicy{FullRateSubscribers: 11, MaxExtension: 2.0}, minInterval: 60, subscribers: 6, want: 90},
+		{name: "購読者がしきい値以上のとき延長しない", policy: policy, minInterval: 30, subscribers: 50, want: 30},
+		{name: "延長後の間隔は12時間を上限とする", policy: policy, minInterval: 480, subscribers: 1, want: 720},
+		{name: "購読者の設定が上限のとき上限のまま返す", policy: policy, minInterval: 720, subscribers: 1, want: 720},
+		{name: "購読者がいないとき最小の間隔をそのまま返す", policy: policy, minInterval: 0, subscribers: 0, want: 0},
+		{name: "倍率が1のとき延長しない", policy: SubscriberIntervalPolicy{FullRateSubscribers: 50, MaxExtension: 1}, minInterval: 30, subscribers: 1, want: 30},
+		{name: "しきい値が1以下のとき延長しない", policy: SubscriberIntervalPolicy{FullRateSubscribers: 1, MaxExtension: 2}, minInterval: 30, subscribers: 1, want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.EffectiveInterval(tt.minInterval, tt.subscribers); got != tt.want {
+				t.Errorf("EffectiveInterval(%d, %d) = %d, want %d", tt.minInterval, tt.subscribers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetcher_Fetch_SubscriberIntervalPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel><title>Test</title></channel>
+</rss>`)
+	}))
+	defer server.Close()
+
+	extendPolicy := SubscriberIntervalPolicy{FullRateSubscribers: 50, MaxExtension: 2.0}
+	newFetcher := func(counter repository.FeedSubscriberCounter, policy SubscriberIntervalPolicy) *Fetcher {
+		var buf bytes.Buffer
+		return NewFetcher(
+			&mockFeedRepo{updateFetchStateFunc: func(context.Context, *model.Feed) error { return nil }},
+			// 購読者の設定した最小フェッチ間隔は 2 時間
+			&mockSubRepo{minInterval: 120},
+			&mockUpsertService{},
+			&mockSSRFGuard{},
+			newTestLogger(&buf),
+			10*time.Second,
+			5*1024*1024,
+			WithSubscriberIntervalPolicy(counter, policy),
+		)
+	}
+	// ApplySuccess のジッターは ±10% のため、期待する間隔の前後 15% に収まることを確認する
+	assertInterval := func(t *testing.T, feed *model.Feed, before time.Time, want time.Duration) {
+		t.Helper()
+		got := feed.NextFetchAt.Sub(before)
+		if got < want*85/100 || got > want*115/100 {
+			t.Errorf("NextFetchAt - now = %v, want about %v", got, want)
+		}
+	}
+
+	t.Run("購読者が1人のとき次回フェッチまでの間隔を延長する", func(t *testing.T) {
+		// Arrange
+		f := newFetcher(&mockSubscriberCounter{count: 1}, extendPolicy)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+		before := time.Now()
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		assertInterval(t, feed, before, 4*time.Hour)
+	})
+
+	t.Run("購読者数の取得に失敗したとき最小のフェッチ間隔どおりに設定する", func(t *testing.T) {
+		// Arrange
+		f := newFetcher(&mockSubscriberCounter{err: errors.New("db error")}, extendPolicy)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+		before := time.Now()
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		assertInterval(t, feed, before, 2*time.Hour)
+	})
+
+	t.Run("延長しない設定（既定の倍率1）のとき購読者数を数えず最小のフェッチ間隔どおりに設定する", func(t *testing.T) {
+		// Arrange
+		counter := &mockSubscriberCounter{count: 1}
+		f := newFetcher(counter, SubscriberIntervalPolicy{FullRateSubscribers: 50, MaxExtension: 1.0})
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+		before := time.Now()
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		assertInterval(t, feed, before, 2*time.Hour)
+		if counter.calls != 0 {
+			t.Errorf("CountSubscribersByFeedID calls = %d, want 0", counter.calls)
+		}
+	})
+}
+
+// mockActiveSubscriberChecker は repository.FeedActiveSubscriberChecker のモック。
+type mockActiveSubscriberChecker struct {
+	active   bool
+	err      error
+	gotSince time.Time
+}
+
+func (m *mockActiveSubscriberChecker) HasActiveSubscriber(_ context.Context, _ string, since time.Time) (bool, error) {
+	m.gotSince = since
+	return m.active, m.err
+}
+
+var _ repository.FeedActiveSubscriberChecker = (*mockActiveSubscriberChecker)(nil)
+
+func TestDormantIntervalPolicy_EffectiveInterval(t *testing.T) {
+	policy := DormantIntervalPolicy{DormantAfter: 90 * 24 * time.Hour, Interval: 24 * time.Hour}
+
+	tests := []struct {
+		name     string
+		policy   DormantIntervalPolicy
+		interval int
+		active   bool
+		want     int
+	}{
+		{name: "アクティブな購読者がいないとき延長後の間隔を返す", policy: policy, interval: 60, active: false, want: 1440},
+		{name: "アクティブな購読者がいるとき延長しない", policy: policy, interval: 60, active: true, want: 60},
+		{name: "延長後の間隔より長いとき元の間隔を返す", policy: DormantIntervalPolicy{DormantAfter: time.Hour, Interval: time.Hour}, interval: 120, active: false, want: 120},
+		{name: "間隔が0のとき延長しない", policy: DormantIntervalPolicy{DormantAfter: time.Hour}, interval: 60, active: false, want: 60},
+		{name: "休眠とみなす期間が0のとき延長しない", policy: DormantIntervalPolicy{Interval: 24 * time.Hour}, interval: 60, active: false, want: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.EffectiveInterval(tt.interval, tt.active); got != tt.want {
+				t.Errorf("EffectiveInterval(%d, %v) = %d, want %d", tt.interval, tt.active, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetcher_Fetch_DormantIntervalPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel><title>Test</title></channel>
+</rss>`)
+	}))
+	defer server.Close()
+
+	policy := DormantIntervalPolicy{DormantAfter: 90 * 24 * time.Hour, Interval: 24 * time.Hour}
+	newFetcher := func(checker repository.FeedActiveSubscriberChecker) *Fetcher {
+		var buf bytes.Buffer
+		return NewFetcher(
+			&mockFeedRepo{updateFetchStateFunc: func(context.Context, *model.Feed) error { return nil }},
+			// 購読者の設定した最小フェッチ間隔は 2 時間
+			&mockSubRepo{minInterval: 120},
+			&mockUpsertService{},
+			&mockSSRFGuard{},
+			newTestLogger(&buf),
+			10*time.Second,
+			5*1024*1024,
+			WithDormantIntervalPolicy(checker, policy),
+		)
+	}
+	assertInterval := func(t *testing.T, feed *model.Feed, before time.Time, want time.Duration) {
+		t.Helper()
+		got := feed.NextFetchAt.Sub(before)
+		if got < want*85/100 || got > want*115/100 {
+			t.Errorf("NextFetchAt - now = %v, want about %v", got, want)
+		}
+	}
+
+	t.Run("休眠ユーザーのみが購読するとき次回フェッチまでの間隔を延長する", func(t *testing.T) {
+		// Arrange
+		checker := &mockActiveSubscriberChecker{active: false}
+		f := newFetcher(checker)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+		before := time.Now()
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		assertInterval(t, feed, before, 24*time.Hour)
+		if wantSince := before.Add(-policy.DormantAfter); checker.gotSince.Before(wantSince) {
+			t.Errorf("since = %v, want at or after %v", checker.gotSince, wantSince)
+		}
+	})
+
+	t.Run("アクティブな購読者がいるとき最小のフェッチ間隔どおりに設定する", func(t *testing.T) {
+		// Arrange
+		f := newFetcher(&mockActiveSubscriberChecker{active: true})
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+		before := time.Now()
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		assertInterval(t, feed, before, 2*time.Hour)
+	})
+
+	t.Run("アクティブな購読者の有無の取得に失敗したとき延長しない", func(t *testing.T) {
+		// Arrange
+		f := newFetcher(&mockActiveSubscriberChecker{err: errors.New("db error")})
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+		before := time.Now()
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		assertInterval(t, feed, before, 2*time.Hour)
+	})
+}
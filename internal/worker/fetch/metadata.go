@@ -0,0 +1,146 @@
+package fetch
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+const (
+	// maxFeedLanguageLength は保存するフィード言語タグの最大長（BCP 47 の実用上限）。
+	maxFeedLanguageLength = 35
+	// maxFeedDescriptionRunes は保存するフィード説明文の最大文字数。
+	maxFeedDescriptionRunes = 1000
+	// maxFeedCopyrightRunes は保存する著作権表記の最大文字数。
+	maxFeedCopyrightRunes = 500
+	// maxFeedTTLMinutes は次回フェッチまでの間隔の下限として尊重する ttl の上限（分）。
+	// 極端に長い ttl で記事の取り込みが止まらないよう、購読設定の最大フェッチ間隔（12 時間）に揃える。
+	maxFeedTTLMinutes = maxExtendedIntervalMinutes
+	// maxFeedRobotsLength は保存するロボット向け指示の最大長。
+	maxFeedRobotsLength = 200
+)
+
+// applyFeedMetadata はパース済みフィードの channel 情報と記事の公開日時から
+// feed.Language / feed.Description / feed.LastPublishedAt を更新する。
+//
+// title / site_url と同様に、値が得られない項目は既存値を維持する。
+// LastPublishedAt は now より未来の日時を除いた記事の最新公開日時とし、既存値より新しい場合のみ進める
+// （フィードが古い記事を配信し直しても「最終投稿日時」が巻き戻らないようにするため）。
+func applyFeedMetadata(feed *model.Feed, parsed *gofeed.Feed, items []model.ParsedItem, now time.Time) {
+	if lang := strings.TrimSpace(parsed.Language); lang != "" && len(lang) <= maxFeedLanguageLength {
+		feed.Language = lang
+	}
+	if desc := normalizeFeedDescription(parsed.Description); desc != "" {
+		feed.Description = desc
+	}
+
+	var latest *time.Time
+	for _, item := range items {
+		if item.PublishedAt == nil || item.PublishedAt.After(now) {
+			continue
+		}
+		if latest == nil || item.PublishedAt.After(*latest) {
+			latest = item.PublishedAt
+		}
+	}
+	if latest != nil && (feed.LastPublishedAt == nil || latest.After(*feed.LastPublishedAt)) {
+		t := *latest
+		feed.LastPublishedAt = &t
+	}
+}
+
+// applyFeedUsageMetadata はパース済みフィードと応答ヘッダーから利用条件のメタ情報
+// （feed.Copyright / feed.TTLMinutes / feed.Robots）を更新する。
+//
+// 言語・説明文と異なり、値が得られない項目は空（ttl は 0）に戻す。
+// 配信元が転載条件や noindex の指示を取り下げた場合に、古い注意書きを表示し続けないようにするため。
+// robots は X-Robots-Tag ヘッダーと channel の <xhtml:meta name="robots"> の指示を出現順に重複なく連結する。
+func applyFeedUsageMetadata(feed *model.Feed, parsed *gofeed.Feed, header http.Header) {
+	feed.Copyright = truncateRunes(normalizeFeedDescription(parsed.Copyright), maxFeedCopyrightRunes)
+	feed.TTLMinutes = parseFeedTTL(parsed.Custom[feedCustomTTL])
+
+	directives := header.Values("X-Robots-Tag")
+	for _, exts := range parsed.Extensions {
+		for _, meta := range exts["meta"] {
+			if strings.EqualFold(meta.Attrs["name"], "robots") {
+				directives = append(directives, meta.Attrs["content"])
+			}
+		}
+	}
+	robots := normalizeRobotsDirectives(directives)
+	if len(robots) > maxFeedRobotsLength {
+		robots = ""
+	}
+	feed.Robots = robots
+}
+
+// parseFeedTTL は RSS の <ttl>（分）を解釈する。数値でないか 0 以下の場合は 0、
+// maxFeedTTLMinutes を超える場合は maxFeedTTLMinutes を返す。
+func parseFeedTTL(raw string) int {
+	ttl, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || ttl <= 0 {
+		return 0
+	}
+	return min(ttl, maxFeedTTLMinutes)
+}
+
+// normalizeRobotsDirectives はロボット向け指示の値（カンマ区切り）を小文字化し、出現順に重複を除いて ", " で連結する。
+// X-Robots-Tag の "googlebot: noindex" のようなユーザーエージェント指定付きの指示は、特定のクローラ向けのため含めない。
+func normalizeRobotsDirectives(values []string) string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, v := range values {
+		for _, d := range strings.Split(v, ",") {
+			d = strings.ToLower(strings.TrimSpace(d))
+			if d == "" || strings.Contains(d, ":") || seen[d] {
+				continue
+			}
+			seen[d] = true
+			out = append(out, d)
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+// effectiveFetchInterval は購読者の設定から求めたフェッチ間隔 interval（分）に、フィードの ttl を下限として適用する。
+func effectiveFetchInterval(interval int, feed *model.Feed) int {
+	return max(interval, feed.TTLMinutes)
+}
+
+// truncateRunes は s を最大 n 文字に切り詰める。
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) > n {
+		return string([]rune(s)[:n])
+	}
+	return s
+}
+
+// normalizeFeedDescription はフィード説明文をプレーンテキスト化する。
+// HTML タグを除去して空白を 1 つに畳み、maxFeedDescriptionRunes 文字で切り詰める。
+func normalizeFeedDescription(raw string) string {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(raw))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt == html.TextToken {
+			b.Write(z.Text())
+			b.WriteByte(' ')
+		}
+	}
+
+	text := strings.Join(strings.Fields(b.String()), " ")
+	if utf8.RuneCountInString(text) > maxFeedDescriptionRunes {
+		text = string([]rune(text)[:maxFeedDescriptionRunes])
+	}
+	return text
+}
@@ -0,0 +1,165 @@
+package fetch
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestApplyFeedMetadata(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(h int) *time.Time {
+		v := now.Add(time.Duration(h) * time.Hour)
+		return &v
+	}
+
+	t.Run("channel情報と記事の最新公開日時を反映する", func(t *testing.T) {
+		// Arrange
+		feed := &model.Feed{}
+		parsed := &gofeed.Feed{Language: " ja ", Description: "<p>技術&amp;日記</p>\n<p>毎日更新</p>"}
+		items := []model.ParsedItem{{PublishedAt: at(-3)}, {PublishedAt: at(-1)}, {PublishedAt: nil}}
+
+		// Act
+		applyFeedMetadata(feed, parsed, items, now)
+
+		// Assert
+		if feed.Language != "ja" {
+			t.Errorf("Language = %q, want %q", feed.Language, "ja")
+		}
+		if feed.Description != "技術&日記 毎日更新" {
+			t.Errorf("Description = %q, want %q", feed.Description, "技術&日記 毎日更新")
+		}
+		if feed.LastPublishedAt == nil || !feed.LastPublishedAt.Equal(*at(-1)) {
+			t.Errorf("LastPublishedAt = %v, want %v", feed.LastPublishedAt, at(-1))
+		}
+	})
+
+	t.Run("値が得られないとき既存値を維持する", func(t *testing.T) {
+		// Arrange
+		feed := &model.Feed{Language: "en", Description: "old", LastPublishedAt: at(-1)}
+		parsed := &gofeed.Feed{Language: strings.Repeat("x", maxFeedLanguageLength+1)}
+		items := []model.ParsedItem{{PublishedAt: at(-5)}, {PublishedAt: at(+2)}}
+
+		// Act
+		applyFeedMetadata(feed, parsed, items, now)
+
+		// Assert
+		if feed.Language != "en" || feed.Description != "old" {
+			t.Errorf("Language/Description = %q/%q, want %q/%q", feed.Language, feed.Description, "en", "old")
+		}
+		// 古い記事・未来日時の記事では巻き戻らない
+		if !feed.LastPublishedAt.Equal(*at(-1)) {
+			t.Errorf("LastPublishedAt = %v, want %v", feed.LastPublishedAt, at(-1))
+		}
+	})
+
+	t.Run("説明文が長いとき最大文字数で切り詰める", func(t *testing.T) {
+		// Arrange
+		feed := &model.Feed{}
+		parsed := &gofeed.Feed{Description: strings.Repeat("あ", maxFeedDescriptionRunes+10)}
+
+		// Act
+		applyFeedMetadata(feed, parsed, nil, now)
+
+		// Assert
+		if got := utf8.RuneCountInString(feed.Description); got != maxFeedDescriptionRunes {
+			t.Errorf("Description の文字数 = %d, want %d", got, maxFeedDescriptionRunes)
+		}
+	})
+}
+
+func TestApplyFeedUsageMetadata(t *testing.T) {
+	t.Run("RSSのcopyright・ttlとxhtml:metaのrobots指示を反映する", func(t *testing.T) {
+		// Arrange
+		parsed, err := parseFeedStream(strings.NewReader(`<?xml version="1.0"?>
+<rss version="2.0" xmlns:h="http://www.w3.org/1999/xhtml"><channel><title>t</title>
+<copyright>&lt;b&gt;無断転載禁止&lt;/b&gt;</copyright><ttl> 90 </ttl>
+<h:meta name="Robots" content="NoIndex, noarchive" />
+</channel></rss>`))
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		feed := &model.Feed{}
+		header := http.Header{}
+		header.Add("X-Robots-Tag", "noarchive, nosnippet")
+		header.Add("X-Robots-Tag", "googlebot: nofollow")
+
+		// Act
+		applyFeedUsageMetadata(feed, parsed, header)
+
+		// Assert
+		if feed.Copyright != "無断転載禁止" {
+			t.Errorf("Copyright = %q, want %q", feed.Copyright, "無断転載禁止")
+		}
+		if feed.TTLMinutes != 90 {
+			t.Errorf("TTLMinutes = %d, want 90", feed.TTLMinutes)
+		}
+		if feed.Robots != "noarchive, nosnippet, noindex" {
+			t.Errorf("Robots = %q, want %q", feed.Robots, "noarchive, nosnippet, noindex")
+		}
+		if !feed.NoIndex() {
+			t.Error("NoIndex() = false, want true")
+		}
+	})
+
+	t.Run("値が得られないとき既存値を空に戻す", func(t *testing.T) {
+		// Arrange
+		feed := &model.Feed{Copyright: "old", TTLMinutes: 60, Robots: "noindex"}
+		parsed := &gofeed.Feed{Custom: map[string]string{feedCustomTTL: "abc"}}
+
+		// Act
+		applyFeedUsageMetadata(feed, parsed, http.Header{})
+
+		// Assert
+		if feed.Copyright != "" || feed.TTLMinutes != 0 || feed.Robots != "" {
+			t.Errorf("Copyright/TTLMinutes/Robots = %q/%d/%q, want empty", feed.Copyright, feed.TTLMinutes, feed.Robots)
+		}
+		if feed.NoIndex() {
+			t.Error("NoIndex() = true, want false")
+		}
+	})
+
+	t.Run("ttlが上限を超えるとき上限に丸める", func(t *testing.T) {
+		// Arrange
+		feed := &model.Feed{}
+		parsed := &gofeed.Feed{Custom: map[string]string{feedCustomTTL: "100000"}}
+
+		// Act
+		applyFeedUsageMetadata(feed, parsed, http.Header{})
+
+		// Assert
+		if feed.TTLMinutes != maxFeedTTLMinutes {
+			t.Errorf("TTLMinutes = %d, want %d", feed.TTLMinutes, maxFeedTTLMinutes)
+		}
+	})
+}
+
+func TestEffectiveFetchInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval int
+		ttl      int
+		want     int
+	}{
+		{name: "ttlが購読者の間隔より長いときttlを下限とする", interval: 30, ttl: 120, want: 120},
+		{name: "ttlが購読者の間隔より短いとき購読者の間隔を使う", interval: 60, ttl: 15, want: 60},
+		{name: "ttlの指定がないとき購読者の間隔を使う", interval: 60, ttl: 0, want: 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := effectiveFetchInterval(tt.interval, &model.Feed{TTLMinutes: tt.ttl})
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("effectiveFetchInterval(%d, ttl=%d) = %d, want %d", tt.interval, tt.ttl, got, tt.want)
+			}
+		})
+	}
+}
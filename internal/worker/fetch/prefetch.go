@@ -0,0 +1,146 @@
+package fetch
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+const (
+	// prefetchLearningPeriod は利用時間帯の学習に使う閲覧履歴の期間（直近 4 週間）。
+	prefetchLearningPeriod = 28 * 24 * time.Hour
+	// prefetchMinActiveDays は利用時間帯とみなすのに必要な、その時間帯に閲覧のあった日数。
+	// 一度きりの夜更かしなどで前倒しが起きないよう、繰り返し現れる時間帯だけを採用する。
+	prefetchMinActiveDays = 3
+	// prefetchLeadTime は利用時間帯の開始（毎時 0 分）からどれだけ前にフェッチを済ませるか。
+	prefetchLeadTime = 20 * time.Minute
+	// prefetchMinGap は前倒し後の next_fetch_at と現在時刻の最小間隔。
+	// 直前にフェッチしたばかりのフィードを続けて取得しないようにする。
+	prefetchMinGap = 10 * time.Minute
+	// prefetchHoursCacheTTL は集計した利用時間帯をフィードごとに再利用する期間。
+	// 4 週間の閲覧履歴から求める時間帯は 1 日で大きく変わらないため、集計はフィードごとに 1 日 1 回に抑える。
+	prefetchHoursCacheTTL = 24 * time.Hour
+)
+
+// WithPrefetch は利用時間帯に合わせたプリフェッチを有効にする。
+// フェッチ成功時、プリフェッチを有効にした購読者の利用時間帯が次回フェッチまでの間にあれば、
+// next_fetch_at をその直前に前倒しする。未指定時は購読のフェッチ間隔どおりに次回フェッチを設定する。
+//
+// 購読者が複数いる場合は各購読者の利用時間帯の和集合を使う（誰かが読み始める前に新着を揃えるため）。
+// 前倒しは次回フェッチを早めるだけで、1 時間帯につき 1 回を超えてフェッチを増やさない。
+// 利用時間帯の集計結果はフィードごとに prefetchHoursCacheTTL の間再利用する。
+func WithPrefetch(repo repository.ActiveHourRepository) FetcherOption {
+	return func(f *Fetcher) {
+		f.activeHours = repo
+		f.activeHoursCache = &activeHoursCache{entries: make(map[string]cachedActiveHours)}
+	}
+}
+
+// cachedActiveHours は 1 フィード分の利用時間帯の集計結果。
+type cachedActiveHours struct {
+	hours     []int
+	expiresAt time.Time
+}
+
+// activeHoursCache はフィードごとの利用時間帯の集計結果を保持する。
+// 期限切れのエントリは prefetchHoursCacheTTL ごとにまとめて破棄する。
+type activeHoursCache struct {
+	mu        sync.Mutex
+	entries   map[string]cachedActiveHours
+	lastSweep time.Time
+}
+
+// get は期限内の集計結果を返す。
+func (c *activeHoursCache) get(feedID string, now time.Time) ([]int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[feedID]
+	if !ok || !now.Before(e.expiresAt) {
+		return nil, false
+	}
+	return e.hours, true
+}
+
+// put は集計結果を保存し、前回の掃除から prefetchHoursCacheTTL 以上経っていれば期限切れのエントリを破棄する。
+func (c *activeHoursCache) put(feedID string, hours []int, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.lastSweep) >= prefetchHoursCacheTTL {
+		for id, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[feedID] = cachedActiveHours{hours: hours, expiresAt: now.Add(prefetchHoursCacheTTL)}
+}
+
+// applyPrefetch は ApplySuccess 後の next_fetch_at を購読者の利用時間帯の直前に寄せる。
+// 利用時間帯の取得に失敗した場合は警告ログのみ出力し、ApplySuccess で設定した値を維持する。
+func (f *Fetcher) applyPrefetch(ctx context.Context, feedID string, now time.Time, next *time.Time) {
+	if f.activeHours == nil {
+		return
+	}
+	hours, err := f.feedActiveHours(ctx, feedID, now)
+	if err != nil {
+		f.logger.Warn("利用時間帯の取得に失敗しました",
+			slog.String("feed_id", feedID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	aligned := alignToActiveHours(feedID, now, *next, hours)
+	if aligned.Before(*next) {
+		f.logger.Debug("利用時間帯に合わせて次回フェッチを前倒しします",
+			slog.String("feed_id", feedID),
+			slog.Time("next_fetch_at", aligned),
+		)
+		*next = aligned
+	}
+}
+
+// feedActiveHours はフィードの購読者の利用時間帯を返す。期限内の集計結果があればそれを使う。
+// 取得に失敗した結果は保持せず、次のフェッチで再び集計する。
+func (f *Fetcher) feedActiveHours(ctx context.Context, feedID string, now time.Time) ([]int, error) {
+	if hours, ok := f.activeHoursCache.get(feedID, now); ok {
+		return hours, nil
+	}
+	hours, err := f.activeHours.ActiveHoursByFeed(ctx, feedID, now.Add(-prefetchLearningPeriod), prefetchMinActiveDays)
+	if err != nil {
+		return nil, err
+	}
+	f.activeHoursCache.put(feedID, hours, now)
+	return hours, nil
+}
+
+// alignToActiveHours は (now + prefetchMinGap, next) の範囲にある利用時間帯の直前の時刻のうち
+// 最も早いものを返す。該当する時刻がなければ next をそのまま返す。
+// hours は UTC の時（0〜23）で、各時間帯の開始から prefetchLeadTime 前を目標時刻とする。
+// 同じ時間帯を利用するフィードのフェッチが一斉に集中しないよう、目標時刻にはフィード単位のジッターを加える。
+func alignToActiveHours(feedID string, now, next time.Time, hours []int) time.Time {
+	if len(hours) == 0 || !next.After(now) {
+		return next
+	}
+	lead := prefetchLeadTime + fetchJitter(feedID, prefetchLeadTime)
+	earliest := now.Add(prefetchMinGap)
+
+	best := next
+	day := time.Date(now.UTC().Year(), now.UTC().Month(), now.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	// 前倒し先は next より前に限るため、next の翌日までを走査すれば十分
+	for ; !day.After(next.Add(24 * time.Hour)); day = day.Add(24 * time.Hour) {
+		for _, h := range hours {
+			if h < 0 || h > 23 {
+				continue
+			}
+			target := day.Add(time.Duration(h)*time.Hour - lead)
+			if target.After(earliest) && target.Before(best) {
+				best = target
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,244 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockActiveHourRepo は repository.ActiveHourRepository のモック。
+type mockActiveHourRepo struct {
+	hours []int
+	err   error
+	calls int
+}
+
+func (m *mockActiveHourRepo) ActiveHoursByFeed(_ context.Context, _ string, _ time.Time, _ int) ([]int, error) {
+	m.calls++
+	return m.hours, m.err
+}
+
+var _ repository.ActiveHourRepository = (*mockActiveHourRepo)(nil)
+
+func TestAlignToActiveHours(t *testing.T) {
+	const feedID = "feed-1"
+	lead := prefetchLeadTime + fetchJitter(feedID, prefetchLeadTime)
+	// 2026-06-01 06:00 UTC を基準時刻とする
+	now := time.Date(2026, 6, 1, 6, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 6, day, hour, 0, 0, 0, time.UTC).Add(-lead)
+	}
+
+	tests := []struct {
+		name  string
+		next  time.Time
+		hours []int
+		want  time.Time
+	}{
+		{
+			name:  "利用時間帯がないときnextをそのまま返す",
+			next:  now.Add(24 * time.Hour),
+			hours: nil,
+			want:  now.Add(24 * time.Hour),
+		},
+		{
+			name:  "次回フェッチまでに利用時間帯があるときその直前に前倒しする",
+			next:  now.Add(24 * time.Hour),
+			hours: []int{8},
+			want:  at(1, 8),
+		},
+		{
+			name:  "複数の利用時間帯があるとき最も早いものに前倒しする",
+			next:  now.Add(24 * time.Hour),
+			hours: []int{8, 21},
+			want:  at(1, 8),
+		},
+		{
+			name:  "当日の時間帯が過ぎているとき翌日の時間帯に前倒しする",
+			next:  now.Add(48 * time.Hour),
+			hours: []int{3},
+			want:  at(2, 3),
+		},
+		{
+			name:  "利用時間帯が次回フェッチより後のときnextをそのまま返す",
+			next:  now.Add(time.Hour),
+			hours: []int{8},
+			want:  now.Add(time.Hour),
+		},
+		{
+			name:  "当日の目標時刻を過ぎていて次回フェッチまでに翌日の時間帯がないときnextをそのまま返す",
+			next:  now.Add(3 * time.Hour),
+			hours: []int{6},
+			want:  now.Add(3 * time.Hour),
+		},
+		{
+			name:  "範囲外の時は無視する",
+			next:  now.Add(24 * time.Hour),
+			hours: []int{-1, 24},
+			want:  now.Add(24 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := alignToActiveHours(feedID, now, tt.next, tt.hours)
+
+			// Assert
+			if !got.Equal(tt.want) {
+				t.Errorf("alignToActiveHours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetcher_Fetch_Prefetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel><title>Test</title></channel>
+</rss>`)
+	}))
+	defer server.Close()
+
+	newFetcher := func(repo repository.ActiveHourRepository) *Fetcher {
+		var buf bytes.Buffer
+		return NewFetcher(
+			&mockFeedRepo{updateFetchStateFunc: func(context.Context, *model.Feed) error { return nil }},
+			// 最小フェッチ間隔は 1 日
+			&mockSubRepo{minInterval: 24 * 60},
+			&mockUpsertService{},
+			&mockSSRFGuard{},
+			newTestLogger(&buf),
+			10*time.Second,
+			5*1024*1024,
+			WithPrefetch(repo),
+		)
+	}
+
+	t.Run("利用時間帯があるときnext_fetch_atをその直前に前倒しする", func(t *testing.T) {
+		// Arrange
+		activeAt := time.Now().UTC().Add(3 * time.Hour).Truncate(time.Hour)
+		repo := &mockActiveHourRepo{hours: []int{activeAt.Hour()}}
+		f := newFetcher(repo)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		want := activeAt.Add(-(prefetchLeadTime + fetchJitter(feed.ID, prefetchLeadTime)))
+		if !feed.NextFetchAt.Equal(want) {
+			t.Errorf("NextFetchAt = %v, want %v", feed.NextFetchAt, want)
+		}
+	})
+
+	t.Run("利用時間帯の取得に失敗したときフェッチ間隔どおりに設定する", func(t *testing.T) {
+		// Arrange
+		repo := &mockActiveHourRepo{err: errors.New("db error")}
+		f := newFetcher(repo)
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+		now := time.Now()
+
+		// Act
+		if err := f.Fetch(context.Background(), feed); err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+
+		// Assert
+		if repo.calls != 1 {
+			t.Errorf("calls = %d, want 1", repo.calls)
+		}
+		if feed.NextFetchAt.Before(now.Add(20 * time.Hour)) {
+			t.Errorf("NextFetchAt = %v, want about 24h later", feed.NextFetchAt)
+		}
+	})
+
+	t.Run("同じフィードを続けてフェッチしたとき利用時間帯を集計し直さない", func(t *testing.T) {
+		// Arrange
+		repo := &mockActiveHourRepo{hours: []int{time.Now().UTC().Add(3 * time.Hour).Hour()}}
+		f := newFetcher(repo)
+
+		// Act
+		for _, id := range []string{"feed-1", "feed-1", "feed-2"} {
+			feed := &model.Feed{ID: id, FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+			if err := f.Fetch(context.Background(), feed); err != nil {
+				t.Fatalf("Fetch returned error: %v", err)
+			}
+		}
+
+		// Assert
+		if repo.calls != 2 {
+			t.Errorf("calls = %d, want 2 (フィードごとに 1 回)", repo.calls)
+		}
+	})
+
+	t.Run("利用時間帯の取得に失敗したとき結果を保持せず次のフェッチで集計し直す", func(t *testing.T) {
+		// Arrange
+		repo := &mockActiveHourRepo{err: errors.New("db error")}
+		f := newFetcher(repo)
+
+		// Act
+		for range 2 {
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, FetchStatus: model.FetchStatusActive}
+			if err := f.Fetch(context.Background(), feed); err != nil {
+				t.Fatalf("Fetch returned error: %v", err)
+			}
+		}
+
+		// Assert
+		if repo.calls != 2 {
+			t.Errorf("calls = %d, want 2", repo.calls)
+		}
+	})
+}
+
+func TestActiveHoursCache(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("期限を過ぎたとき集計結果を返さない", func(t *testing.T) {
+		// Arrange
+		c := &activeHoursCache{entries: make(map[string]cachedActiveHours)}
+		c.put("feed-1", []int{8}, now)
+
+		// Act
+		_, fresh := c.get("feed-1", now.Add(prefetchHoursCacheTTL-time.Minute))
+		_, expired := c.get("feed-1", now.Add(prefetchHoursCacheTTL))
+
+		// Assert
+		if !fresh {
+			t.Error("期限内の集計結果が返らない")
+		}
+		if expired {
+			t.Error("期限切れの集計結果が返った")
+		}
+	})
+
+	t.Run("保存のとき期限切れのエントリを破棄する", func(t *testing.T) {
+		// Arrange
+		c := &activeHoursCache{entries: make(map[string]cachedActiveHours)}
+		c.put("feed-1", []int{8}, now)
+
+		// Act
+		c.put("feed-2", []int{9}, now.Add(prefetchHoursCacheTTL))
+
+		// Assert
+		if _, ok := c.entries["feed-1"]; ok {
+			t.Error("期限切れのエントリが残っている")
+		}
+		if len(c.entries) != 1 {
+			t.Errorf("entries = %d, want 1", len(c.entries))
+		}
+	})
+}
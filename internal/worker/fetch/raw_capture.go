@@ -0,0 +1,87 @@
+package fetch
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// RawCaptureStore はデバッグ用に直近のフェッチレスポンスを保存するインターフェース。
+// repository.FeedRawCaptureRepository が実装する。
+type RawCaptureStore interface {
+	SaveRawCapture(ctx context.Context, capture *model.FeedRawCapture) error
+}
+
+// WithRawCaptureStore はフェッチレスポンスの保存先を注入する。
+// 保存するのは model.Feed.CaptureRawResponse が true のフィードのみで、未指定時はいずれのフィードも保存しない。
+func WithRawCaptureStore(store RawCaptureStore) FetcherOption {
+	return func(f *Fetcher) {
+		f.rawCaptures = store
+	}
+}
+
+// rawCapture はフェッチレスポンスのヘッダーとボディ先頭（model.MaxFeedRawCaptureBodyBytes まで）を記録する。
+// パーサーへ渡すボディを io.TeeReader で分岐して書き込み、上限を超えた分は切り捨てる。
+type rawCapture struct {
+	capture model.FeedRawCapture
+}
+
+// newRawCapture は resp のステータスとヘッダーを記録した rawCapture を生成する。
+// Set-Cookie は調査に不要で、ダウンロードした管理者へ渡すべきでないため記録しない。
+func newRawCapture(feed *model.Feed, resp *http.Response, fetchedAt time.Time) *rawCapture {
+	requestURL := feed.FeedURL
+	if resp.Request != nil && resp.Request.URL != nil {
+		// リダイレクトを追った場合は最終的にレスポンスを返した URL を記録する
+		requestURL = resp.Request.URL.String()
+	}
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	return &rawCapture{capture: model.FeedRawCapture{
+		FeedID:     feed.ID,
+		FetchedAt:  fetchedAt,
+		RequestURL: requestURL,
+		StatusCode: resp.StatusCode,
+		Proto:      resp.Proto,
+		Header:     header,
+		Body:       []byte{},
+	}}
+}
+
+// Write は io.Writer を実装する。上限を超えた分は記録せずに切り詰めたことだけを残し、常に全量を書き込んだものとして返す。
+func (c *rawCapture) Write(p []byte) (int, error) {
+	remaining := model.MaxFeedRawCaptureBodyBytes - len(c.capture.Body)
+	if len(p) > remaining {
+		c.capture.Body = append(c.capture.Body, p[:remaining]...)
+		c.capture.BodyTruncated = true
+		return len(p), nil
+	}
+	c.capture.Body = append(c.capture.Body, p...)
+	return len(p), nil
+}
+
+// fill はパーサーが読まなかったボディの残り（304 / エラー応答の本文やパース失敗時の未読部分）を上限まで読み足す。
+// 上限の直後に 1 バイトでも続きがあれば切り詰めたものとして記録する。読み取りエラーはそこまでの内容で打ち切る。
+func (c *rawCapture) fill(r io.Reader) {
+	if c.capture.BodyTruncated {
+		return
+	}
+	remaining := int64(model.MaxFeedRawCaptureBodyBytes - len(c.capture.Body))
+	_, _ = io.Copy(c, io.LimitReader(r, remaining+1))
+}
+
+// saveRawCapture はボディの残りを読み足してフェッチレスポンスを保存する。
+// 保存はデバッグ用の付随処理のため、失敗してもフェッチの結果には影響させず警告ログのみ出力する。
+func (f *Fetcher) saveRawCapture(ctx context.Context, capture *rawCapture, body io.Reader) {
+	capture.fill(body)
+	if err := f.rawCaptures.SaveRawCapture(ctx, &capture.capture); err != nil {
+		f.logger.Warn("フェッチレスポンスの保存に失敗しました",
+			slog.String("feed_id", capture.capture.FeedID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
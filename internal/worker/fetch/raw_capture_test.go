@@ -0,0 +1,132 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockRawCaptureStore は RawCaptureStore のテスト用モック。
+type mockRawCaptureStore struct {
+	saved []*model.FeedRawCapture
+}
+
+func (m *mockRawCaptureStore) SaveRawCapture(_ context.Context, capture *model.FeedRawCapture) error {
+	m.saved = append(m.saved, capture)
+	return nil
+}
+
+func TestFetcher_Fetch_RawCapture(t *testing.T) {
+	const rssBody = `<?xml version="1.0"?><rss version="2.0"><channel><title>t</title>` +
+		`<item><title>a</title><link>https://example.com/a</link></item></channel></rss>`
+
+	newFetcher := func(store *mockRawCaptureStore) *Fetcher {
+		var buf bytes.Buffer
+		return NewFetcher(
+			&mockFeedRepo{}, &mockSubRepo{minInterval: 60}, &mockUpsertService{}, &mockSSRFGuard{},
+			newTestLogger(&buf), 10*time.Second, 5*1024*1024,
+			WithRawCaptureStore(store),
+		)
+	}
+
+	t.Run("有効なフィードのとき200のヘッダーとボディを保存する", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/rss+xml")
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "secret"})
+			_, _ = w.Write([]byte(rssBody))
+		}))
+		defer server.Close()
+		store := &mockRawCaptureStore{}
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, CaptureRawResponse: true}
+
+		// Act
+		_ = newFetcher(store).Fetch(context.Background(), feed)
+
+		// Assert
+		if len(store.saved) != 1 {
+			t.Fatalf("saved = %d, want 1", len(store.saved))
+		}
+		got := store.saved[0]
+		if got.FeedID != "feed-1" || got.StatusCode != http.StatusOK || got.RequestURL != server.URL {
+			t.Errorf("capture = (%q, %d, %q)", got.FeedID, got.StatusCode, got.RequestURL)
+		}
+		if string(got.Body) != rssBody || got.BodyTruncated {
+			t.Errorf("body = %q (truncated=%v), want 全量", got.Body, got.BodyTruncated)
+		}
+		if got.Header.Get("Content-Type") != "application/rss+xml" {
+			t.Errorf("Content-Type = %q", got.Header.Get("Content-Type"))
+		}
+		if got.Header.Get("Set-Cookie") != "" {
+			t.Errorf("Set-Cookie = %q, want 保存しない", got.Header.Get("Set-Cookie"))
+		}
+	})
+
+	t.Run("エラー応答のときも本文を保存する", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("upstream error"))
+		}))
+		defer server.Close()
+		store := &mockRawCaptureStore{}
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, CaptureRawResponse: true}
+
+		// Act
+		_ = newFetcher(store).Fetch(context.Background(), feed)
+
+		// Assert
+		if len(store.saved) != 1 {
+			t.Fatalf("saved = %d, want 1", len(store.saved))
+		}
+		if got := store.saved[0]; got.StatusCode != http.StatusInternalServerError || string(got.Body) != "upstream error" {
+			t.Errorf("capture = (%d, %q)", got.StatusCode, got.Body)
+		}
+	})
+
+	t.Run("上限を超えるボディは先頭のみ保存し切り詰めを記録する", func(t *testing.T) {
+		// Arrange
+		padding := strings.Repeat(" ", model.MaxFeedRawCaptureBodyBytes)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(padding + rssBody))
+		}))
+		defer server.Close()
+		store := &mockRawCaptureStore{}
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, CaptureRawResponse: true}
+
+		// Act
+		_ = newFetcher(store).Fetch(context.Background(), feed)
+
+		// Assert
+		if len(store.saved) != 1 {
+			t.Fatalf("saved = %d, want 1", len(store.saved))
+		}
+		if got := store.saved[0]; len(got.Body) != model.MaxFeedRawCaptureBodyBytes || !got.BodyTruncated {
+			t.Errorf("body length = %d (truncated=%v), want %d (true)", len(got.Body), got.BodyTruncated, model.MaxFeedRawCaptureBodyBytes)
+		}
+	})
+
+	t.Run("無効なフィードのとき保存しない", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(rssBody))
+		}))
+		defer server.Close()
+		store := &mockRawCaptureStore{}
+		feed := &model.Feed{ID: "feed-1", FeedURL: server.URL}
+
+		// Act
+		_ = newFetcher(store).Fetch(context.Background(), feed)
+
+		// Assert
+		if len(store.saved) != 0 {
+			t.Errorf("saved = %d, want 0", len(store.saved))
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package fetch
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -30,6 +32,8 @@ const (
 	maxBackoff = 12 * time.Hour
 	// parseFailureThreshold はパース失敗によるフェッチ停止の閾値。
 	parseFailureThreshold = 10
+	// fetchJitterRatio は通常のフェッチ間隔に加えるジッターの最大比率（±10%）。
+	fetchJitterRatio = 0.1
 )
 
 // ClassifyHTTPStatus はHTTPステータスコードをフェッチ結果に分類する。
@@ -52,14 +56,54 @@ func ClassifyHTTPStatus(statusCode int) FetchResult {
 	}
 }
 
+// BackoffPolicy はエラー分類ごとの指数バックオフ設定。
+type BackoffPolicy struct {
+	// Initial は初回遅延。
+	Initial time.Duration
+	// Max は最大遅延。
+	Max time.Duration
+}
+
+// defaultBackoffPolicy は分類別ポリシーが定義されていないエラーに適用するバックオフ設定。
+var defaultBackoffPolicy = BackoffPolicy{Initial: initialBackoff, Max: maxBackoff}
+
+// backoffPolicies はエラー分類ごとのバックオフ設定。
+// DNS・TLS の失敗は短時間で回復しにくく、サイズ超過とリダイレクトの循環・上限超過はフィード側の構成が
+// 変わらない限り再発するため、一時的な障害より長い間隔で再試行する。
+var backoffPolicies = map[model.FetchErrorKind]BackoffPolicy{
+	model.FetchErrorKindDNS:      {Initial: 2 * time.Hour, Max: 24 * time.Hour},
+	model.FetchErrorKindTLS:      {Initial: 2 * time.Hour, Max: 24 * time.Hour},
+	model.FetchErrorKindTooLarge: {Initial: 6 * time.Hour, Max: 24 * time.Hour},
+	model.FetchErrorKindRedirect: {Initial: 6 * time.Hour, Max: 24 * time.Hour},
+}
+
+// BackoffPolicyFor はエラー分類に対応するバックオフ設定を返す。
+// 分類別の設定がない場合はデフォルト（初回30分、最大12時間）を返す。
+func BackoffPolicyFor(kind model.FetchErrorKind) BackoffPolicy {
+	if policy, ok := backoffPolicies[kind]; ok {
+		return policy
+	}
+	return defaultBackoffPolicy
+}
+
 // CalculateBackoff は連続エラー回数に基づいて指数バックオフ遅延を計算する。
 // 初回30分、2倍ずつ増加、最大12時間。
 func CalculateBackoff(consecutiveErrors int) time.Duration {
-	delay := initialBackoff
+	return calculateBackoffWithPolicy(defaultBackoffPolicy, consecutiveErrors)
+}
+
+// CalculateBackoffForKind はエラー分類別のバックオフ設定で指数バックオフ遅延を計算する。
+func CalculateBackoffForKind(kind model.FetchErrorKind, consecutiveErrors int) time.Duration {
+	return calculateBackoffWithPolicy(BackoffPolicyFor(kind), consecutiveErrors)
+}
+
+// calculateBackoffWithPolicy は指定のバックオフ設定で遅延を計算する。
+func calculateBackoffWithPolicy(policy BackoffPolicy, consecutiveErrors int) time.Duration {
+	delay := policy.Initial
 	for i := 0; i < consecutiveErrors; i++ {
 		delay *= 2
-		if delay > maxBackoff {
-			return maxBackoff
+		if delay > policy.Max {
+			return policy.Max
 		}
 	}
 	return delay
@@ -67,32 +111,68 @@ func CalculateBackoff(consecutiveErrors int) time.Duration {
 
 // ApplyStopFeed はフィードのフェッチを停止する。
 // fetch_statusをstoppedに設定し、エラーメッセージを記録する。
+// 前回失敗の詳細は残さないようクリアする（詳細がある場合は呼び出し側で設定する）。
 func ApplyStopFeed(feed *model.Feed, reason string) {
 	feed.FetchStatus = model.FetchStatusStopped
 	feed.ErrorMessage = reason
+	feed.ErrorDetail = nil
 	feed.UpdatedAt = time.Now()
 }
 
+// ApplyStopFeedWithKind はエラー分類を記録した上でフィードのフェッチを停止する。
+func ApplyStopFeedWithKind(feed *model.Feed, kind model.FetchErrorKind, reason string) {
+	feed.ErrorKind = kind
+	ApplyStopFeed(feed, reason)
+}
+
 // ApplyBackoff はフィードにバックオフ戦略を適用する。
 // 連続エラー回数をインクリメントし、指数バックオフでnext_fetch_atを設定する。
+// エラー分類は other として記録し、デフォルトのバックオフ設定を用いる。
 func ApplyBackoff(feed *model.Feed, reason string) {
+	ApplyBackoffWithKind(feed, model.FetchErrorKindOther, reason)
+}
+
+// ApplyBackoffWithKind はエラー分類別のバックオフ設定でフィードにバックオフ戦略を適用する。
+// エラー分類を記録し、連続エラー回数をインクリメントしてnext_fetch_atを設定する。
+// 前回失敗の詳細は残さないようクリアする（詳細がある場合は呼び出し側で設定する）。
+func ApplyBackoffWithKind(feed *model.Feed, kind model.FetchErrorKind, reason string) {
 	feed.ConsecutiveErrors++
 	feed.ErrorMessage = reason
-	delay := CalculateBackoff(feed.ConsecutiveErrors - 1)
+	feed.ErrorKind = kind
+	feed.ErrorDetail = nil
+	delay := CalculateBackoffForKind(kind, feed.ConsecutiveErrors-1)
 	feed.NextFetchAt = time.Now().Add(delay)
 	feed.UpdatedAt = time.Now()
 }
 
 // ApplySuccess はフェッチ成功時にフィードの状態をリセットする。
-// 連続エラー回数を0にリセットし、エラーメッセージをクリアする。
-// intervalMinutesに基づいてnext_fetch_atを設定する。
+// 連続エラー回数を0にリセットし、エラーメッセージ・エラー分類・エラー詳細をクリアする。
+// intervalMinutesにフィード単位のジッターを加えてnext_fetch_atを設定する。
 func ApplySuccess(feed *model.Feed, intervalMinutes int) {
+	interval := time.Duration(intervalMinutes) * time.Minute
 	feed.ConsecutiveErrors = 0
 	feed.ErrorMessage = ""
-	feed.NextFetchAt = time.Now().Add(time.Duration(intervalMinutes) * time.Minute)
+	feed.ErrorKind = model.FetchErrorKindNone
+	feed.ErrorDetail = nil
+	feed.NextFetchAt = time.Now().Add(interval + fetchJitter(feed.ID, interval))
 	feed.UpdatedAt = time.Now()
 }
 
+// fetchJitter はフェッチ間隔に加えるフィード単位のジッターを返す。
+// 同時刻に登録されたフィードの next_fetch_at が揃い、同じ時刻にフェッチが集中するのを避けるため、
+// フィードIDのハッシュから interval の ±fetchJitterRatio の範囲で決定的にずらす。
+// 同じフィードには毎回同じずれが掛かるため、フェッチ周期そのものは安定する。
+func fetchJitter(feedID string, interval time.Duration) time.Duration {
+	if feedID == "" || interval <= 0 {
+		return 0
+	}
+	// 末尾だけが異なるIDでも十分に散らばるよう、暗号学的ハッシュの先頭 8 バイトを使う
+	sum := sha256.Sum256([]byte(feedID))
+	// 上位 53 ビットを [0, 1) の一様値とみなし、[-1, 1) に写像する
+	u := float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+	return time.Duration((u*2 - 1) * fetchJitterRatio * float64(interval))
+}
+
 // CheckParseFailureThreshold はパース失敗回数が閾値に達しているかを確認する。
 func CheckParseFailureThreshold(feed *model.Feed) bool {
 	return feed.ConsecutiveErrors >= parseFailureThreshold
@@ -102,6 +182,8 @@ func CheckParseFailureThreshold(feed *model.Feed) bool {
 // 閾値に達した場合はフェッチを停止する。
 func ApplyParseFailure(feed *model.Feed, reason string) {
 	feed.ConsecutiveErrors++
+	feed.ErrorKind = model.FetchErrorKindParse
+	feed.ErrorDetail = nil
 	feed.ErrorMessage = fmt.Sprintf("パース失敗 (%d回連続): %s", feed.ConsecutiveErrors, reason)
 	feed.UpdatedAt = time.Now()
 
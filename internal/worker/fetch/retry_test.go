@@ -1,6 +1,7 @@
 package fetch
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -183,8 +184,9 @@ func TestApplySuccess(t *testing.T) {
 	if feed.ErrorMessage != "" {
 		t.Errorf("ErrorMessage = %q, want empty", feed.ErrorMessage)
 	}
-	// NextFetchAtが約60分後であること
-	expectedTime := time.Now().Add(time.Duration(interval) * time.Minute)
+	// NextFetchAtが約60分後（フィード単位のジッター込み）であること
+	wantInterval := time.Duration(interval) * time.Minute
+	expectedTime := time.Now().Add(wantInterval + fetchJitter(feed.ID, wantInterval))
 	diff := feed.NextFetchAt.Sub(expectedTime)
 	if diff > time.Second || diff < -time.Second {
 		t.Errorf("NextFetchAt が期待値から大幅にずれている: %v (期待: %v)", feed.NextFetchAt, expectedTime)
@@ -260,3 +262,171 @@ func TestApplyParseFailure_StopsAt10(t *testing.T) {
 		t.Error("ErrorMessage は設定されるべき")
 	}
 }
+
+// --- エラー分類別バックオフのテスト ---
+
+func TestBackoffPolicyFor(t *testing.T) {
+	tests := []struct {
+		kind        model.FetchErrorKind
+		wantInitial time.Duration
+		wantMax     time.Duration
+	}{
+		{model.FetchErrorKindDNS, 2 * time.Hour, 24 * time.Hour},
+		{model.FetchErrorKindTLS, 2 * time.Hour, 24 * time.Hour},
+		{model.FetchErrorKindTooLarge, 6 * time.Hour, 24 * time.Hour},
+		{model.FetchErrorKindTimeout, 30 * time.Minute, 12 * time.Hour},
+		{model.FetchErrorKindHTTP5xx, 30 * time.Minute, 12 * time.Hour},
+		{model.FetchErrorKindOther, 30 * time.Minute, 12 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind)+"のとき分類別の設定を返す", func(t *testing.T) {
+			got := BackoffPolicyFor(tt.kind)
+			if got.Initial != tt.wantInitial || got.Max != tt.wantMax {
+				t.Errorf("BackoffPolicyFor(%q) = %+v, want Initial=%v Max=%v", tt.kind, got, tt.wantInitial, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestCalculateBackoffForKind(t *testing.T) {
+	t.Run("DNSエラーの初回は2時間", func(t *testing.T) {
+		if got := CalculateBackoffForKind(model.FetchErrorKindDNS, 0); got != 2*time.Hour {
+			t.Errorf("got %v, want 2h", got)
+		}
+	})
+	t.Run("DNSエラーは24時間で頭打ちになる", func(t *testing.T) {
+		if got := CalculateBackoffForKind(model.FetchErrorKindDNS, 10); got != 24*time.Hour {
+			t.Errorf("got %v, want 24h", got)
+		}
+	})
+	t.Run("タイムアウトはデフォルトと同じ遅延になる", func(t *testing.T) {
+		for i := 0; i < 6; i++ {
+			if got, want := CalculateBackoffForKind(model.FetchErrorKindTimeout, i), CalculateBackoff(i); got != want {
+				t.Errorf("consecutiveErrors=%d: got %v, want %v", i, got, want)
+			}
+		}
+	})
+}
+
+func TestApplyBackoffWithKind(t *testing.T) {
+	feed := &model.Feed{ID: "feed-1", FetchStatus: model.FetchStatusActive}
+
+	before := time.Now()
+	ApplyBackoffWithKind(feed, model.FetchErrorKindTooLarge, "response too large")
+
+	if feed.ErrorKind != model.FetchErrorKindTooLarge {
+		t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindTooLarge)
+	}
+	if feed.ConsecutiveErrors != 1 {
+		t.Errorf("ConsecutiveErrors = %d, want 1", feed.ConsecutiveErrors)
+	}
+	if feed.NextFetchAt.Before(before.Add(6 * time.Hour)) {
+		t.Errorf("サイズ超過の初回バックオフは6時間以上であるべき: NextFetchAt = %v", feed.NextFetchAt)
+	}
+}
+
+func TestApplyBackoff_RecordsOtherKind(t *testing.T) {
+	feed := &model.Feed{ID: "feed-1", ErrorKind: model.FetchErrorKindDNS}
+
+	ApplyBackoff(feed, "error")
+
+	if feed.ErrorKind != model.FetchErrorKindOther {
+		t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindOther)
+	}
+}
+
+func TestApplyStopFeedWithKind(t *testing.T) {
+	feed := &model.Feed{ID: "feed-1", FetchStatus: model.FetchStatusActive}
+
+	ApplyStopFeedWithKind(feed, model.FetchErrorKindHTTP4xx, "404")
+
+	if feed.FetchStatus != model.FetchStatusStopped {
+		t.Errorf("FetchStatus = %q, want stopped", feed.FetchStatus)
+	}
+	if feed.ErrorKind != model.FetchErrorKindHTTP4xx {
+		t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindHTTP4xx)
+	}
+}
+
+func TestApplySuccess_ClearsErrorKind(t *testing.T) {
+	feed := &model.Feed{ID: "feed-1", ConsecutiveErrors: 3, ErrorKind: model.FetchErrorKindTimeout}
+
+	ApplySuccess(feed, 60)
+
+	if feed.ErrorKind != model.FetchErrorKindNone {
+		t.Errorf("ErrorKind = %q, want empty", feed.ErrorKind)
+	}
+}
+
+func TestApplyParseFailure_SetsParseKind(t *testing.T) {
+	feed := &model.Feed{ID: "feed-1", FetchStatus: model.FetchStatusActive}
+
+	ApplyParseFailure(feed, "invalid XML")
+
+	if feed.ErrorKind != model.FetchErrorKindParse {
+		t.Errorf("ErrorKind = %q, want %q", feed.ErrorKind, model.FetchErrorKindParse)
+	}
+}
+
+func TestFetchJitter(t *testing.T) {
+	interval := 60 * time.Minute
+
+	t.Run("同じフィードIDのとき毎回同じジッターを返す", func(t *testing.T) {
+		if a, b := fetchJitter("feed-1", interval), fetchJitter("feed-1", interval); a != b {
+			t.Errorf("fetchJitter is not deterministic: %v != %v", a, b)
+		}
+	})
+
+	t.Run("ジッターは間隔の±10%以内に収まる", func(t *testing.T) {
+		limit := time.Duration(float64(interval) * fetchJitterRatio)
+		for i := 0; i < 1000; i++ {
+			j := fetchJitter(fmt.Sprintf("feed-%d", i), interval)
+			if j < -limit || j > limit {
+				t.Fatalf("fetchJitter(feed-%d) = %v, want within ±%v", i, j, limit)
+			}
+		}
+	})
+
+	t.Run("フィードごとにずれが分散する", func(t *testing.T) {
+		// 1000 フィードのずれが前後どちらか一方に偏らないことを確認する
+		var early, late int
+		for i := 0; i < 1000; i++ {
+			if fetchJitter(fmt.Sprintf("feed-%d", i), interval) < 0 {
+				early++
+			} else {
+				late++
+			}
+		}
+		if early < 400 || late < 400 {
+			t.Errorf("jitter is skewed: early=%d late=%d", early, late)
+		}
+	})
+
+	t.Run("フィードIDが空または間隔が0以下のとき0を返す", func(t *testing.T) {
+		if j := fetchJitter("", interval); j != 0 {
+			t.Errorf("fetchJitter(\"\") = %v, want 0", j)
+		}
+		if j := fetchJitter("feed-1", 0); j != 0 {
+			t.Errorf("fetchJitter(interval=0) = %v, want 0", j)
+		}
+	})
+}
+
+func TestApplySuccess_SpreadsFeedsRegisteredTogether(t *testing.T) {
+	// Arrange: 同時刻にフェッチされた同じ間隔のフィード群
+	seen := make(map[time.Duration]bool)
+	base := time.Now()
+
+	// Act
+	for i := 0; i < 20; i++ {
+		feed := &model.Feed{ID: fmt.Sprintf("feed-%d", i)}
+		ApplySuccess(feed, 60)
+		seen[feed.NextFetchAt.Sub(base).Round(time.Second)] = true
+	}
+
+	// Assert: next_fetch_at がフィードごとにずれている
+	if len(seen) < 15 {
+		t.Errorf("next_fetch_at が揃いすぎている: distinct=%d, want >= 15", len(seen))
+	}
+}
@@ -18,14 +18,62 @@ type FeedFetcherService interface {
 	Fetch(ctx context.Context, feed *model.Feed) error
 }
 
+// FeedFetcherWithStats はフェッチ結果の集計値も返すフェッチャー。
+// Fetcher が実装する。未実装のフェッチャーでは error の有無で成否を判定し、記事数は 0 として数える。
+type FeedFetcherWithStats interface {
+	FetchWithStats(ctx context.Context, feed *model.Feed) (FetchStats, error)
+}
+
+// CycleRecorder はフェッチサイクルの結果を記録するインターフェース。
+type CycleRecorder interface {
+	RecordWorkerCycle(ctx context.Context, cycle model.WorkerCycle) error
+	DeleteWorkerCyclesBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WorkerCycleRetention はフェッチサイクル結果の保持期間。
+const WorkerCycleRetention = 7 * 24 * time.Hour
+
 // Scheduler はフィードフェッチのスケジューリングと並列制御を行う。
 // 5分間隔のティッカーでフェッチ対象フィードを取得し、
-// semaphoreパターンで最大並列数を制御しながらフェッチを実行する。
+// 最大並列数と同数のワーカーでホスト単位にフェッチを実行する。
+// 同一ホストのフィードは同時に 1 件ずつ、最小アクセス間隔を空けてフェッチする。
 type Scheduler struct {
 	feedRepo       repository.FeedRepository
 	fetcher        FeedFetcherService
 	logger         *slog.Logger
 	maxConcurrency int
+	cycles         CycleRecorder
+	hosts          *hostLimiter
+	hostDeadline   time.Duration
+}
+
+// SchedulerOption は NewScheduler の任意設定を表す functional option。
+type SchedulerOption func(*Scheduler)
+
+// WithCycleRecorder はサイクル結果の記録先を注入する。
+// 未指定時はサイクル結果を記録しない。
+func WithCycleRecorder(r CycleRecorder) SchedulerOption {
+	return func(s *Scheduler) {
+		s.cycles = r
+	}
+}
+
+// WithHostMinInterval は同一ホストへのフェッチの最小間隔（直前のフェッチ完了から次の開始まで）を設定する。
+// 未指定時は間隔を空けず、同一ホストへの同時接続を 1 本に制限するのみとする。
+func WithHostMinInterval(d time.Duration) SchedulerOption {
+	return func(s *Scheduler) {
+		s.hosts = newHostLimiter(d)
+	}
+}
+
+// WithHostDeadline は 1 サイクルで 1 ホストのフィードをフェッチし続ける時間の上限を設定する。
+// 上限を過ぎたホストの残りのフィードはフェッチせずに失敗として数え、次のサイクルに回す
+// （フィードの多いホストや応答の遅いホストがワーカーを占有し続けないようにするため）。
+// 実行中のフェッチは中断しない。未指定または 0 以下の場合は上限を設けない。
+func WithHostDeadline(d time.Duration) SchedulerOption {
+	return func(s *Scheduler) {
+		s.hostDeadline = d
+	}
 }
 
 // NewScheduler はSchedulerの新しいインスタンスを生成する。
@@ -35,16 +83,22 @@ func NewScheduler(
 	fetcher FeedFetcherService,
 	logger *slog.Logger,
 	maxConcurrency int,
+	opts ...SchedulerOption,
 ) *Scheduler {
 	if maxConcurrency <= 0 {
 		maxConcurrency = 10
 	}
-	return &Scheduler{
+	s := &Scheduler{
 		feedRepo:       feedRepo,
 		fetcher:        fetcher,
 		logger:         logger,
 		maxConcurrency: maxConcurrency,
+		hosts:          newHostLimiter(0),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Start は5分間隔のティッカーでスケジューラを起動する。
@@ -81,7 +135,10 @@ func (s *Scheduler) Start(ctx context.Context, interval time.Duration) {
 }
 
 // RunOnce はフェッチ対象フィードを1回取得し、並列でフェッチを実行する。
-// semaphoreパターンで最大並列数を制御する。
+// フィードをホストごとにまとめ、最大並列数と同数のワーカーがホスト単位で順に取り出して
+// そのホストのフィードを 1 件ずつフェッチする（起動する goroutine は最大並列数までに抑える）。
+// CycleRecorder が設定されている場合は、対象フィードが 0 件のサイクルも含めて結果を記録する
+// （「最近取り込みが動いていたか」を確認できるようにするため）。
 func (s *Scheduler) RunOnce(ctx context.Context) error {
 	start := time.Now()
 
@@ -91,8 +148,11 @@ func (s *Scheduler) RunOnce(ctx context.Context) error {
 		return err
 	}
 
+	cycle := model.WorkerCycle{StartedAt: start, FeedCount: len(feeds)}
+
 	if len(feeds) == 0 {
 		s.logger.Info("フェッチ対象のフィードはありません")
+		s.recordCycle(ctx, cycle)
 		return nil
 	}
 
@@ -100,35 +160,157 @@ func (s *Scheduler) RunOnce(ctx context.Context) error {
 		slog.Int("feed_count", len(feeds)),
 	)
 
-	// semaphoreパターンで並列数を制御
-	sem := make(chan struct{}, s.maxConcurrency)
-	var wg sync.WaitGroup
+	var mu sync.Mutex
+	record := func(stats FetchStats) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stats.Succeeded {
+			cycle.SucceededCount++
+		} else {
+			cycle.FailedCount++
+		}
+		cycle.ItemsInserted += stats.ItemsInserted
+		cycle.ItemsUpdated += stats.ItemsUpdated
+	}
 
-	for _, feed := range feeds {
+	// ワーカープールで並列数を制御
+	groups := groupFeedsByHost(feeds)
+	jobs := make(chan hostFeeds)
+	var wg sync.WaitGroup
+	for range min(s.maxConcurrency, len(groups)) {
 		wg.Add(1)
-		sem <- struct{}{} // semaphore取得（ブロック）
-
-		go func(f *model.Feed) {
+		go func() {
 			defer wg.Done()
-			defer func() { <-sem }() // semaphore解放
-
-			if err := s.fetcher.Fetch(ctx, f); err != nil {
-				s.logger.Error("フィードフェッチに失敗しました",
-					slog.String("feed_id", f.ID),
-					slog.String("feed_url", f.FeedURL),
-					slog.String("error", err.Error()),
-				)
+			for group := range jobs {
+				s.fetchHost(ctx, group, record)
 			}
-		}(feed)
+		}()
 	}
+	for _, group := range groups {
+		jobs <- group
+	}
+	close(jobs)
 
 	wg.Wait()
 
 	duration := time.Since(start)
 	s.logger.Info("フェッチサイクルが完了しました",
 		slog.Int("feed_count", len(feeds)),
+		slog.Int("succeeded_count", cycle.SucceededCount),
+		slog.Int("failed_count", cycle.FailedCount),
+		slog.Int("items_inserted", cycle.ItemsInserted),
+		slog.Int("items_updated", cycle.ItemsUpdated),
 		slog.Float64("duration_ms", float64(duration.Milliseconds())),
 	)
+	s.recordCycle(ctx, cycle)
 
 	return nil
 }
+
+// hostFeeds は同一ホストのフェッチ対象フィード。
+// ホストを特定できないフィード URL は 1 件ずつ別のグループにする（host は空文字列）。
+type hostFeeds struct {
+	host  string
+	feeds []*model.Feed
+}
+
+// groupFeedsByHost はフィードをホストごとにまとめる。
+// グループの順序・グループ内の順序は feeds での出現順（フェッチ予定時刻の早い順）を保つ。
+func groupFeedsByHost(feeds []*model.Feed) []hostFeeds {
+	var groups []hostFeeds
+	index := make(map[string]int)
+	for _, f := range feeds {
+		host := feedHost(f.FeedURL)
+		if host == "" {
+			groups = append(groups, hostFeeds{feeds: []*model.Feed{f}})
+			continue
+		}
+		if i, ok := index[host]; ok {
+			groups[i].feeds = append(groups[i].feeds, f)
+			continue
+		}
+		index[host] = len(groups)
+		groups = append(groups, hostFeeds{host: host, feeds: []*model.Feed{f}})
+	}
+	return groups
+}
+
+// fetchHost は 1 ホスト分のフィードを順にフェッチし、結果を record に渡す。
+// hostDeadline を過ぎた場合や ctx がキャンセルされた場合は、残りのフィードをフェッチせずに失敗として数える。
+func (s *Scheduler) fetchHost(ctx context.Context, group hostFeeds, record func(FetchStats)) {
+	waitCtx := ctx
+	if s.hostDeadline > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, s.hostDeadline)
+		defer cancel()
+	}
+
+	for i, f := range group.feeds {
+		release, err := s.acquireHost(waitCtx, group.host)
+		if err != nil {
+			s.logger.Warn("ホストの順番待ちを打ち切り、残りのフィードを次のサイクルに回します",
+				slog.String("host", group.host),
+				slog.Int("skipped_count", len(group.feeds)-i),
+				slog.String("error", err.Error()),
+			)
+			for range group.feeds[i:] {
+				record(FetchStats{})
+			}
+			return
+		}
+
+		// 持ち時間で実行中のフェッチを中断しないよう、フェッチには waitCtx ではなく ctx を渡す
+		stats, err := s.fetchWithStats(ctx, f)
+		release()
+		if err != nil {
+			s.logger.Error("フィードフェッチに失敗しました",
+				slog.String("feed_id", f.ID),
+				slog.String("feed_url", f.FeedURL),
+				slog.String("error", err.Error()),
+			)
+		}
+		record(stats)
+	}
+}
+
+// acquireHost はホスト単位の枠を取得し、最小アクセス間隔が経過するまで待つ。
+// host が空の場合はホスト単位の制御を行わない。ctx が終了している場合は枠を取得せずに ctx.Err() を返す。
+func (s *Scheduler) acquireHost(ctx context.Context, host string) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if host == "" {
+		return func() {}, nil
+	}
+	return s.hosts.acquire(ctx, host)
+}
+
+// fetchWithStats はフェッチャーが FeedFetcherWithStats を実装していればその集計値を返す。
+// 未実装の場合は error が nil のときを成功として扱う。
+func (s *Scheduler) fetchWithStats(ctx context.Context, feed *model.Feed) (FetchStats, error) {
+	if f, ok := s.fetcher.(FeedFetcherWithStats); ok {
+		return f.FetchWithStats(ctx, feed)
+	}
+	err := s.fetcher.Fetch(ctx, feed)
+	return FetchStats{Succeeded: err == nil}, err
+}
+
+// recordCycle はサイクル結果を記録し、保持期間を過ぎた古い結果を削除する。
+// 記録・削除に失敗しても警告ログのみ出力し、サイクルの成否には影響させない。
+func (s *Scheduler) recordCycle(ctx context.Context, cycle model.WorkerCycle) {
+	if s.cycles == nil {
+		return
+	}
+	cycle.FinishedAt = time.Now()
+	cycle.Duration = cycle.FinishedAt.Sub(cycle.StartedAt)
+	if err := s.cycles.RecordWorkerCycle(ctx, cycle); err != nil {
+		s.logger.Warn("フェッチサイクル結果の記録に失敗しました",
+			slog.String("error", err.Error()),
+		)
+	}
+	if _, err := s.cycles.DeleteWorkerCyclesBefore(ctx, cycle.StartedAt.Add(-WorkerCycleRetention)); err != nil {
+		s.logger.Warn("古いフェッチサイクル結果の削除に失敗しました",
+			slog.String("error", err.Error()),
+		)
+	}
+}
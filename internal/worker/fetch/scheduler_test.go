@@ -94,6 +94,10 @@ func (m *mockFeedRepo) UpdateLastSuccessfulFetchAt(ctx context.Context, feedID s
 	return nil
 }
 
+func (m *mockFeedRepo) ListBySiteHost(ctx context.Context, userID, host, excludeFeedID string, limit int) ([]*model.Feed, error) {
+	return nil, nil
+}
+
 // mockFetcher はFeedFetcherのテスト用モック。
 type mockFetcher struct {
 	fetchFunc func(ctx context.Context, feed *model.Feed) error
@@ -408,3 +412,296 @@ func TestScheduler_RunOnce_RespectsContext(t *testing.T) {
 		t.Fatal("キャンセル済みコンテキストではエラーが返るべき")
 	}
 }
+
+// --- フェッチサイクル結果の記録 ---
+
+// mockStatsFetcher は FeedFetcherWithStats を実装するフェッチャーのモック。
+type mockStatsFetcher struct {
+	mockFetcher
+	fetchWithStatsFunc func(ctx context.Context, feed *model.Feed) (FetchStats, error)
+}
+
+func (m *mockStatsFetcher) FetchWithStats(ctx context.Context, feed *model.Feed) (FetchStats, error) {
+	return m.fetchWithStatsFunc(ctx, feed)
+}
+
+// mockCycleRecorder は CycleRecorder のモック。
+type mockCycleRecorder struct {
+	recordErr     error
+	recorded      []model.WorkerCycle
+	deleteBefores []time.Time
+}
+
+func (m *mockCycleRecorder) RecordWorkerCycle(_ context.Context, cycle model.WorkerCycle) error {
+	m.recorded = append(m.recorded, cycle)
+	return m.recordErr
+}
+
+func (m *mockCycleRecorder) DeleteWorkerCyclesBefore(_ context.Context, before time.Time) (int64, error) {
+	m.deleteBefores = append(m.deleteBefores, before)
+	return 0, nil
+}
+
+func TestScheduler_RunOnce_RecordsCycle(t *testing.T) {
+	feeds := []*model.Feed{{ID: "feed-1"}, {ID: "feed-2"}, {ID: "feed-3"}}
+	repo := &mockFeedRepo{
+		listDueForFetchFunc: func(ctx context.Context) ([]*model.Feed, error) {
+			return feeds, nil
+		},
+	}
+
+	t.Run("FetchWithStatsの集計値を合算して記録する", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		fetcher := &mockStatsFetcher{
+			fetchWithStatsFunc: func(_ context.Context, feed *model.Feed) (FetchStats, error) {
+				switch feed.ID {
+				case "feed-1":
+					return FetchStats{Succeeded: true, ItemsInserted: 3, ItemsUpdated: 1}, nil
+				case "feed-2":
+					return FetchStats{Succeeded: true, ItemsInserted: 2}, nil
+				default:
+					// パース失敗のように error を返さない失敗
+					return FetchStats{}, nil
+				}
+			},
+		}
+		recorder := &mockCycleRecorder{}
+		s := NewScheduler(repo, fetcher, newTestLogger(&buf), 2, WithCycleRecorder(recorder))
+
+		// Act
+		if err := s.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce() がエラーを返した: %v", err)
+		}
+
+		// Assert
+		if len(recorder.recorded) != 1 {
+			t.Fatalf("記録回数 = %d, want 1", len(recorder.recorded))
+		}
+		got := recorder.recorded[0]
+		if got.FeedCount != 3 || got.SucceededCount != 2 || got.FailedCount != 1 {
+			t.Errorf("counts = (%d, %d, %d), want (3, 2, 1)", got.FeedCount, got.SucceededCount, got.FailedCount)
+		}
+		if got.ItemsInserted != 5 || got.ItemsUpdated != 1 {
+			t.Errorf("items = (%d, %d), want (5, 1)", got.ItemsInserted, got.ItemsUpdated)
+		}
+		if got.StartedAt.IsZero() || got.FinishedAt.Before(got.StartedAt) {
+			t.Errorf("StartedAt = %v, FinishedAt = %v, want 開始 <= 終了", got.StartedAt, got.FinishedAt)
+		}
+		if len(recorder.deleteBefores) != 1 || !recorder.deleteBefores[0].Equal(got.StartedAt.Add(-WorkerCycleRetention)) {
+			t.Errorf("deleteBefores = %v, want 開始時刻 - 保持期間", recorder.deleteBefores)
+		}
+	})
+
+	t.Run("FetchWithStats未実装のフェッチャーではerrorの有無で成否を数える", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		fetcher := &mockFetcher{
+			fetchFunc: func(_ context.Context, feed *model.Feed) error {
+				if feed.ID == "feed-2" {
+					return errors.New("fetch failed")
+				}
+				return nil
+			},
+		}
+		recorder := &mockCycleRecorder{}
+		s := NewScheduler(repo, fetcher, newTestLogger(&buf), 2, WithCycleRecorder(recorder))
+
+		// Act
+		if err := s.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce() がエラーを返した: %v", err)
+		}
+
+		// Assert
+		got := recorder.recorded[0]
+		if got.SucceededCount != 2 || got.FailedCount != 1 || got.ItemsInserted != 0 {
+			t.Errorf("cycle = %+v, want 成功 2 / 失敗 1 / 記事数 0", got)
+		}
+	})
+
+	t.Run("対象フィードが無いときも0件のサイクルとして記録する", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		emptyRepo := &mockFeedRepo{
+			listDueForFetchFunc: func(ctx context.Context) ([]*model.Feed, error) {
+				return nil, nil
+			},
+		}
+		recorder := &mockCycleRecorder{}
+		s := NewScheduler(emptyRepo, &mockFetcher{}, newTestLogger(&buf), 2, WithCycleRecorder(recorder))
+
+		// Act
+		if err := s.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce() がエラーを返した: %v", err)
+		}
+
+		// Assert
+		if len(recorder.recorded) != 1 || recorder.recorded[0].FeedCount != 0 {
+			t.Errorf("recorded = %+v, want FeedCount=0 の 1 件", recorder.recorded)
+		}
+	})
+
+	t.Run("記録に失敗してもRunOnceはエラーを返さず警告ログを出す", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		recorder := &mockCycleRecorder{recordErr: errors.New("db down")}
+		s := NewScheduler(repo, &mockFetcher{}, newTestLogger(&buf), 2, WithCycleRecorder(recorder))
+
+		// Act
+		err := s.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() がエラーを返した: %v", err)
+		}
+		if !strings.Contains(buf.String(), "フェッチサイクル結果の記録に失敗しました") {
+			t.Errorf("警告ログが出力されていない: %s", buf.String())
+		}
+	})
+}
+
+func TestScheduler_RunOnce_HostPoliteness(t *testing.T) {
+	// Arrange: 同一ホスト 3 件と別ホスト 1 件
+	feeds := []*model.Feed{
+		{ID: "a-1", FeedURL: "https://blog.example.com/a/feed.xml", FetchStatus: model.FetchStatusActive},
+		{ID: "a-2", FeedURL: "https://blog.example.com/b/feed.xml", FetchStatus: model.FetchStatusActive},
+		{ID: "a-3", FeedURL: "https://BLOG.example.com/c/feed.xml", FetchStatus: model.FetchStatusActive},
+		{ID: "b-1", FeedURL: "https://other.example.org/feed.xml", FetchStatus: model.FetchStatusActive},
+	}
+	repo := &mockFeedRepo{
+		listDueForFetchFunc: func(ctx context.Context) ([]*model.Feed, error) {
+			return feeds, nil
+		},
+	}
+
+	const interval = 30 * time.Millisecond
+	var mu sync.Mutex
+	var sameHostRunning, sameHostMax int
+	var sameHostStarts, sameHostEnds []time.Time
+	fetcher := &mockFetcher{
+		fetchFunc: func(ctx context.Context, feed *model.Feed) error {
+			if feed.ID == "b-1" {
+				return nil
+			}
+			mu.Lock()
+			sameHostRunning++
+			if sameHostRunning > sameHostMax {
+				sameHostMax = sameHostRunning
+			}
+			sameHostStarts = append(sameHostStarts, time.Now())
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			sameHostRunning--
+			sameHostEnds = append(sameHostEnds, time.Now())
+			mu.Unlock()
+			return nil
+		},
+	}
+
+	var buf bytes.Buffer
+	s := NewScheduler(repo, fetcher, newTestLogger(&buf), 10, WithHostMinInterval(interval))
+
+	// Act
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() がエラーを返した: %v", err)
+	}
+
+	// Assert
+	if sameHostMax != 1 {
+		t.Errorf("同一ホストへの最大同時フェッチ数 = %d, want 1", sameHostMax)
+	}
+	if len(sameHostStarts) != 3 {
+		t.Fatalf("同一ホストのフェッチ回数 = %d, want 3", len(sameHostStarts))
+	}
+	for i := 1; i < len(sameHostStarts); i++ {
+		if gap := sameHostStarts[i].Sub(sameHostEnds[i-1]); gap < interval {
+			t.Errorf("%d 件目の開始が直前の完了から %v 後, want >= %v", i+1, gap, interval)
+		}
+	}
+}
+
+func TestScheduler_RunOnce_HostDeadline(t *testing.T) {
+	// Arrange: 同一ホスト 3 件。最小間隔が持ち時間より長いため 2 件目以降は持ち時間内に始められない
+	feeds := []*model.Feed{
+		{ID: "a-1", FeedURL: "https://blog.example.com/a/feed.xml", FetchStatus: model.FetchStatusActive},
+		{ID: "a-2", FeedURL: "https://blog.example.com/b/feed.xml", FetchStatus: model.FetchStatusActive},
+		{ID: "a-3", FeedURL: "https://blog.example.com/c/feed.xml", FetchStatus: model.FetchStatusActive},
+		{ID: "b-1", FeedURL: "https://other.example.org/feed.xml", FetchStatus: model.FetchStatusActive},
+	}
+	repo := &mockFeedRepo{
+		listDueForFetchFunc: func(ctx context.Context) ([]*model.Feed, error) {
+			return feeds, nil
+		},
+	}
+	var mu sync.Mutex
+	var fetched []string
+	fetcher := &mockFetcher{
+		fetchFunc: func(ctx context.Context, feed *model.Feed) error {
+			mu.Lock()
+			defer mu.Unlock()
+			fetched = append(fetched, feed.ID)
+			return nil
+		},
+	}
+	recorder := &mockCycleRecorder{}
+	var buf bytes.Buffer
+	s := NewScheduler(repo, fetcher, newTestLogger(&buf), 10,
+		WithHostMinInterval(time.Second),
+		WithHostDeadline(20*time.Millisecond),
+		WithCycleRecorder(recorder),
+	)
+
+	// Act
+	start := time.Now()
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() がエラーを返した: %v", err)
+	}
+
+	// Assert
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("RunOnce() の所要時間 = %v, 持ち時間で打ち切られるべき", elapsed)
+	}
+	if len(fetched) != 2 {
+		t.Errorf("フェッチしたフィード = %v, want a-1 と b-1 のみ", fetched)
+	}
+	if len(recorder.recorded) != 1 {
+		t.Fatalf("記録回数 = %d, want 1", len(recorder.recorded))
+	}
+	if got := recorder.recorded[0]; got.SucceededCount != 2 || got.FailedCount != 2 {
+		t.Errorf("succeeded/failed = %d/%d, want 2/2", got.SucceededCount, got.FailedCount)
+	}
+	if !strings.Contains(buf.String(), "次のサイクルに回します") {
+		t.Errorf("持ち時間超過の警告ログが出力されていない: %s", buf.String())
+	}
+}
+
+func TestGroupFeedsByHost(t *testing.T) {
+	// Arrange
+	feeds := []*model.Feed{
+		{ID: "a-1", FeedURL: "https://blog.example.com/a/feed.xml"},
+		{ID: "b-1", FeedURL: "https://other.example.org/feed.xml"},
+		{ID: "x-1", FeedURL: "::invalid"},
+		{ID: "a-2", FeedURL: "https://BLOG.example.com:8443/b/feed.xml"},
+		{ID: "x-2", FeedURL: "::invalid"},
+	}
+
+	// Act
+	groups := groupFeedsByHost(feeds)
+
+	// Assert
+	var got []string
+	for _, g := range groups {
+		var ids []string
+		for _, f := range g.feeds {
+			ids = append(ids, f.ID)
+		}
+		got = append(got, g.host+"="+strings.Join(ids, ","))
+	}
+	want := []string{"blog.example.com=a-1,a-2", "other.example.org=b-1", "=x-1", "=x-2"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package fetch
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/atom"
+	"github.com/mmcdole/gofeed/rss"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// DefaultMaxItemsPerFetch は 1 回のフェッチで取り込む記事数の上限の既定値。
+const DefaultMaxItemsPerFetch = 500
+
+// feedSniffSize はフィード形式の判定のために先読みするバイト数。
+const feedSniffSize = 64 * 1024
+
+// parseFeedStream は r を先頭から順に読み進めながらフィードをパースする。
+//
+// gofeed.Parser.Parse は形式判定のためにボディ全体をメモリへ読み込み、さらに複製してからパースするため、
+// 巨大なフィードではボディの数倍のメモリを消費する。ここでは先頭 feedSniffSize バイトだけを先読みして
+// 形式を判定し、RSS / Atom はボディを XML プルパーサーへそのまま流し込む。
+// JSON Feed と、先読みの範囲でルート要素が見つからない場合は gofeed.Parser に委ねる。
+func parseFeedStream(r io.Reader) (*gofeed.Feed, error) {
+	br := bufio.NewReaderSize(r, feedSniffSize)
+	head, err := br.Peek(feedSniffSize)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+
+	switch detectFeedTypeHead(head) {
+	case gofeed.FeedTypeRSS:
+		rf, err := (&rss.Parser{}).Parse(br)
+		if err != nil {
+			return nil, err
+		}
+		return (&rssTranslator{}).Translate(rf)
+	case gofeed.FeedTypeAtom:
+		af, err := (&atom.Parser{}).Parse(br)
+		if err != nil {
+			return nil, err
+		}
+		return (&gofeed.DefaultAtomTranslator{}).Translate(af)
+	default:
+		p := gofeed.NewParser()
+		p.RSSTranslator = &rssTranslator{}
+		return p.Parse(br)
+	}
+}
+
+// feedCustomTTL は RSS の <ttl> を gofeed.Feed.Custom に引き継ぐキー。
+const feedCustomTTL = "ttl"
+
+// rssTranslator は gofeed.DefaultRSSTranslator に、汎用の gofeed.Feed に含まれない
+// channel の <ttl> を Custom[feedCustomTTL] として引き継ぐ処理を加えたトランスレータ。
+type rssTranslator struct {
+	gofeed.DefaultRSSTranslator
+}
+
+// Translate は gofeed.Translator を実装する。
+func (t *rssTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	result, err := t.DefaultRSSTranslator.Translate(feed)
+	if err != nil {
+		return nil, err
+	}
+	if rf, ok := feed.(*rss.Feed); ok && rf.TTL != "" {
+		if result.Custom == nil {
+			result.Custom = map[string]string{}
+		}
+		result.Custom[feedCustomTTL] = rf.TTL
+	}
+	return result, nil
+}
+
+// detectFeedTypeHead はボディ先頭の head から XML フィード（RSS / Atom）の形式を判定する。
+// XML 以外、またはルート要素が head に含まれない場合は gofeed.FeedTypeUnknown を返す。
+func detectFeedTypeHead(head []byte) gofeed.FeedType {
+	trimmed := bytes.TrimLeft(head, " \r\n\t\xef\xbb\xbf\xfe\xff\x00")
+	if len(trimmed) == 0 || trimmed[0] != '<' {
+		return gofeed.FeedTypeUnknown
+	}
+	return gofeed.DetectFeedType(bytes.NewReader(head))
+}
+
+// limitedBodyReader はレスポンスボディを最大 limit バイトまで読み進める io.Reader。
+// limit を超えるボディは切り詰めず errBodyTooLarge を返す（切り詰めたXMLはパース失敗として誤分類されるため）。
+// 読み取り中に発生したエラー（EOF を除く）は err に保持し、パーサーがエラーを包み直しても
+// ボディの読み取り失敗として分類できるようにする。
+type limitedBodyReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+	err       error
+}
+
+// newLimitedBodyReader は limitedBodyReader を生成する。
+func newLimitedBodyReader(r io.Reader, limit int64) *limitedBodyReader {
+	return &limitedBodyReader{r: r, limit: limit, remaining: limit}
+}
+
+// Read は io.Reader を実装する。
+func (l *limitedBodyReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if l.remaining <= 0 {
+		// 上限ちょうどで終わっているかを 1 バイト読んで確かめる
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			l.err = fmt.Errorf("%w: limit=%d bytes", errBodyTooLarge, l.limit)
+			return 0, l.err
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			l.err = err
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		l.err = err
+	}
+	return n, err
+}
+
+// limitItemsByRecency は items が max 件を超える場合に公開日時の新しい順で max 件を残す。
+// 公開日時のない記事は最も古いものとして扱い、公開日時が同じ記事はフィード内の出現順を保つ。
+// max 件以下の場合は items をそのまま返す。
+func limitItemsByRecency(items []model.ParsedItem, max int) []model.ParsedItem {
+	if max <= 0 || len(items) <= max {
+		return items
+	}
+	sorted := append([]model.ParsedItem(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].PublishedAt, sorted[j].PublishedAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.After(*b)
+	})
+	return sorted[:max]
+}
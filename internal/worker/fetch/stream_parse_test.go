@@ -0,0 +1,200 @@
+package fetch
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+func TestParseFeedStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantTitle string
+		wantItems int
+	}{
+		{
+			name: "RSSのときXMLプルパーサーでパースされること",
+			body: `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>RSS Feed</title>
+<item><title>A</title><guid>a</guid></item>
+<item><title>B</title><guid>b</guid></item>
+</channel></rss>`,
+			wantTitle: "RSS Feed",
+			wantItems: 2,
+		},
+		{
+			name: "Atomのときパースされること",
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Atom Feed</title>
+<entry><title>A</title><id>urn:a</id></entry>
+</feed>`,
+			wantTitle: "Atom Feed",
+			wantItems: 1,
+		},
+		{
+			name:      "JSON Feedのときパースされること",
+			body:      `{"version":"https://jsonfeed.org/version/1.1","title":"JSON Feed","items":[{"id":"1","title":"A"}]}`,
+			wantTitle: "JSON Feed",
+			wantItems: 1,
+		},
+		{
+			name: "BOMと先頭の空白があるときもRSSとしてパースされること",
+			body: "\xef\xbb\xbf\n  " + `<rss version="2.0"><channel><title>BOM Feed</title>
+<item><title>A</title><guid>a</guid></item></channel></rss>`,
+			wantTitle: "BOM Feed",
+			wantItems: 1,
+		},
+		{
+			name: "ルート要素が先読み範囲より後ろにあるときもパースされること",
+			body: `<?xml version="1.0"?><!--` + strings.Repeat("x", feedSniffSize) + `-->
+<rss version="2.0"><channel><title>Late Root</title>
+<item><title>A</title><guid>a</guid></item></channel></rss>`,
+			wantTitle: "Late Root",
+			wantItems: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			feed, err := parseFeedStream(strings.NewReader(tt.body))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("parseFeedStream() error = %v", err)
+			}
+			if feed.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", feed.Title, tt.wantTitle)
+			}
+			if len(feed.Items) != tt.wantItems {
+				t.Errorf("len(Items) = %d, want %d", len(feed.Items), tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestParseFeedStream_InvalidBody(t *testing.T) {
+	t.Run("フィードでない本文のときエラーを返すこと", func(t *testing.T) {
+		// Act
+		_, err := parseFeedStream(strings.NewReader("this is not a feed"))
+
+		// Assert
+		if err == nil {
+			t.Fatal("エラーが返されるべき")
+		}
+	})
+}
+
+func TestLimitedBodyReader(t *testing.T) {
+	t.Run("上限ちょうどのボディのときエラーにならないこと", func(t *testing.T) {
+		// Arrange
+		r := newLimitedBodyReader(strings.NewReader("0123456789"), 10)
+
+		// Act
+		b, err := io.ReadAll(r)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if string(b) != "0123456789" {
+			t.Errorf("body = %q", b)
+		}
+		if r.err != nil {
+			t.Errorf("err = %v, want nil", r.err)
+		}
+	})
+
+	t.Run("上限を超えるボディのときerrBodyTooLargeを保持すること", func(t *testing.T) {
+		// Arrange
+		r := newLimitedBodyReader(strings.NewReader("0123456789A"), 10)
+
+		// Act
+		_, err := io.ReadAll(r)
+
+		// Assert
+		if !errors.Is(err, errBodyTooLarge) {
+			t.Errorf("ReadAll() error = %v, want errBodyTooLarge", err)
+		}
+		if !errors.Is(r.err, errBodyTooLarge) {
+			t.Errorf("err = %v, want errBodyTooLarge", r.err)
+		}
+	})
+
+	t.Run("上限を超えるフィードをパースしたときもerrBodyTooLargeを保持すること", func(t *testing.T) {
+		// Arrange
+		body := `<rss version="2.0"><channel><title>T</title>` + strings.Repeat("<item><title>A</title></item>", 100) + `</channel></rss>`
+		r := newLimitedBodyReader(strings.NewReader(body), 100)
+
+		// Act
+		_, _ = parseFeedStream(r)
+
+		// Assert
+		if !errors.Is(r.err, errBodyTooLarge) {
+			t.Errorf("err = %v, want errBodyTooLarge", r.err)
+		}
+	})
+}
+
+func TestLimitItemsByRecency(t *testing.T) {
+	at := func(day int) *time.Time {
+		ts := time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)
+		return &ts
+	}
+
+	t.Run("上限以下のときそのまま返すこと", func(t *testing.T) {
+		// Arrange
+		items := []model.ParsedItem{{GuidOrID: "a"}, {GuidOrID: "b"}}
+
+		// Act
+		got := limitItemsByRecency(items, 2)
+
+		// Assert
+		if len(got) != 2 || got[0].GuidOrID != "a" || got[1].GuidOrID != "b" {
+			t.Errorf("got = %+v", got)
+		}
+	})
+
+	t.Run("上限を超えるとき公開日時の新しい順に上限件数を残すこと", func(t *testing.T) {
+		// Arrange
+		items := []model.ParsedItem{
+			{GuidOrID: "old", PublishedAt: at(1)},
+			{GuidOrID: "nodate"},
+			{GuidOrID: "newest", PublishedAt: at(5)},
+			{GuidOrID: "mid", PublishedAt: at(3)},
+		}
+
+		// Act
+		got := limitItemsByRecency(items, 2)
+
+		// Assert
+		if len(got) != 2 {
+			t.Fatalf("len = %d, want 2", len(got))
+		}
+		if got[0].GuidOrID != "newest" || got[1].GuidOrID != "mid" {
+			t.Errorf("got = [%s %s], want [newest mid]", got[0].GuidOrID, got[1].GuidOrID)
+		}
+	})
+
+	t.Run("公開日時のない記事は最も古いものとして扱うこと", func(t *testing.T) {
+		// Arrange
+		items := []model.ParsedItem{
+			{GuidOrID: "nodate-1"},
+			{GuidOrID: "dated", PublishedAt: at(1)},
+			{GuidOrID: "nodate-2"},
+		}
+
+		// Act
+		got := limitItemsByRecency(items, 2)
+
+		// Assert
+		if got[0].GuidOrID != "dated" || got[1].GuidOrID != "nodate-1" {
+			t.Errorf("got = [%s %s], want [dated nodate-1]", got[0].GuidOrID, got[1].GuidOrID)
+		}
+	})
+}
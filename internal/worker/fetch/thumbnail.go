@@ -0,0 +1,59 @@
+package fetch
+
+import (
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// feedThumbnailURL はフィードの記事要素が指定するサムネイル画像の URL を返す。
+// media:thumbnail（media:group・media:content 内のものを含む）、画像の media:content、
+// 画像の enclosure の順に最初に見つかったものを採用する。見つからない場合は空文字列。
+// URL の安全性の検証と本文の画像へのフォールバックは取り込み時（item パッケージ）に行う。
+func feedThumbnailURL(item *gofeed.Item) string {
+	if media, ok := item.Extensions["media"]; ok {
+		if u := mediaThumbnailURL(media); u != "" {
+			return u
+		}
+		for _, group := range media["group"] {
+			if u := mediaThumbnailURL(group.Children); u != "" {
+				return u
+			}
+		}
+		for _, content := range media["content"] {
+			if isImageMedia(content.Attrs) && content.Attrs["url"] != "" {
+				return content.Attrs["url"]
+			}
+		}
+	}
+	for _, enc := range item.Enclosures {
+		if enc != nil && strings.HasPrefix(strings.ToLower(enc.Type), "image/") && enc.URL != "" {
+			return enc.URL
+		}
+	}
+	return ""
+}
+
+// mediaThumbnailURL は media 名前空間の要素群から media:thumbnail の URL を返す。
+// 直下に無い場合は media:content の子要素の media:thumbnail を探す。
+func mediaThumbnailURL(elems map[string][]ext.Extension) string {
+	for _, thumb := range elems["thumbnail"] {
+		if u := thumb.Attrs["url"]; u != "" {
+			return u
+		}
+	}
+	for _, content := range elems["content"] {
+		for _, thumb := range content.Children["thumbnail"] {
+			if u := thumb.Attrs["url"]; u != "" {
+				return u
+			}
+		}
+	}
+	return ""
+}
+
+// isImageMedia は media:content の属性が画像を表すかを判定する。
+func isImageMedia(attrs map[string]string) bool {
+	return strings.EqualFold(attrs["medium"], "image") || strings.HasPrefix(strings.ToLower(attrs["type"]), "image/")
+}
@@ -0,0 +1,74 @@
+package fetch
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestFeedThumbnailURL(t *testing.T) {
+	tests := []struct {
+		name string
+		item string
+		want string
+	}{
+		{
+			name: "media:thumbnailがあるときそのURLを返す",
+			item: `<item><title>a</title>
+				<media:content url="https://example.com/content.jpg" medium="image"/>
+				<media:thumbnail url="https://example.com/thumb.jpg"/></item>`,
+			want: "https://example.com/thumb.jpg",
+		},
+		{
+			name: "media:group内のmedia:thumbnailを返す",
+			item: `<item><title>a</title><media:group>
+				<media:thumbnail url="https://example.com/group-thumb.jpg"/></media:group></item>`,
+			want: "https://example.com/group-thumb.jpg",
+		},
+		{
+			name: "media:content内のmedia:thumbnailを返す",
+			item: `<item><title>a</title><media:content url="https://example.com/video.mp4" medium="video">
+				<media:thumbnail url="https://example.com/video-thumb.jpg"/></media:content></item>`,
+			want: "https://example.com/video-thumb.jpg",
+		},
+		{
+			name: "media:thumbnailが無いとき画像のmedia:contentを返す",
+			item: `<item><title>a</title>
+				<media:content url="https://example.com/video.mp4" type="video/mp4"/>
+				<media:content url="https://example.com/content.png" type="image/png"/></item>`,
+			want: "https://example.com/content.png",
+		},
+		{
+			name: "mediaが無いとき画像のenclosureを返す",
+			item: `<item><title>a</title>
+				<enclosure url="https://example.com/podcast.mp3" type="audio/mpeg" length="1"/>
+				<enclosure url="https://example.com/enclosure.jpg" type="image/jpeg" length="1"/></item>`,
+			want: "https://example.com/enclosure.jpg",
+		},
+		{
+			name: "画像の指定が無いとき空文字列を返す",
+			item: `<item><title>a</title><description><![CDATA[<img src="https://example.com/body.jpg">]]></description></item>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			xml := `<?xml version="1.0"?><rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/"><channel><title>t</title>` +
+				tt.item + `</channel></rss>`
+			feed, err := gofeed.NewParser().ParseString(xml)
+			if err != nil {
+				t.Fatalf("failed to parse feed: %v", err)
+			}
+
+			// Act
+			got := feedThumbnailURL(feed.Items[0])
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("feedThumbnailURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
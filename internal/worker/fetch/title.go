@@ -0,0 +1,35 @@
+package fetch
+
+import (
+	"context"
+	"log/slog"
+)
+
+// TitleChangeRecorder はフィードタイトルの変更を購読ごとのタイトル自動更新ポリシーに反映するインターフェース。
+// repository.PostgresFeedTitleRepo が実装し、initial の購読は変更前のタイトルを表示し続け、
+// manual の購読は新しいタイトルを承認待ちとして保留する。
+type TitleChangeRecorder interface {
+	RecordFeedTitleChange(ctx context.Context, feedID, previousTitle, title string) error
+}
+
+// WithTitleChangeRecorder はフェッチでフィードタイトルが変わったときに購読ごとのポリシーへ反映する recorder を注入する。
+// 未指定時は反映しない（feeds.title の更新のみ行う）。
+func WithTitleChangeRecorder(r TitleChangeRecorder) FetcherOption {
+	return func(f *Fetcher) {
+		f.titleRecorder = r
+	}
+}
+
+// recordTitleChange はタイトルが変わった場合に recorder へ反映する。
+// 反映に失敗しても警告ログのみ出力し、フェッチ結果には影響させない。
+func (f *Fetcher) recordTitleChange(ctx context.Context, feedID, previousTitle, title string) {
+	if f.titleRecorder == nil || title == previousTitle {
+		return
+	}
+	if err := f.titleRecorder.RecordFeedTitleChange(ctx, feedID, previousTitle, title); err != nil {
+		f.logger.Warn("フィードタイトルの変更の反映に失敗しました",
+			slog.String("feed_id", feedID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,77 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+)
+
+// mockTitleChangeRecorder は TitleChangeRecorder のモック実装。
+type mockTitleChangeRecorder struct {
+	calls    int
+	previous string
+	title    string
+}
+
+func (m *mockTitleChangeRecorder) RecordFeedTitleChange(_ context.Context, _, previousTitle, title string) error {
+	m.calls++
+	m.previous = previousTitle
+	m.title = title
+	return nil
+}
+
+func TestFetcher_Fetch_TitleChangeRecorder(t *testing.T) {
+	cases := []struct {
+		name      string
+		current   string
+		parsed    string
+		wantCalls int
+	}{
+		{"タイトルが変わったとき変更前後のタイトルで反映する", "Old Title", "New Title", 1},
+		{"タイトルが変わらないとき反映しない", "Same Title", "Same Title", 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/rss+xml")
+				w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>` + tc.parsed + `</title></channel></rss>`))
+			}))
+			defer server.Close()
+			recorder := &mockTitleChangeRecorder{}
+			var buf bytes.Buffer
+			f := NewFetcher(
+				&mockFeedRepo{updateFetchStateFunc: func(context.Context, *model.Feed) error { return nil }},
+				&mockSubRepo{minInterval: 60},
+				&mockUpsertService{},
+				&mockSSRFGuard{},
+				newTestLogger(&buf),
+				10*time.Second,
+				5*1024*1024,
+				WithTitleChangeRecorder(recorder),
+			)
+			feed := &model.Feed{ID: "feed-1", FeedURL: server.URL, Title: tc.current, FetchStatus: model.FetchStatusActive}
+
+			// Act
+			if err := f.Fetch(context.Background(), feed); err != nil {
+				t.Fatalf("Fetch returned error: %v", err)
+			}
+
+			// Assert
+			if feed.Title != tc.parsed {
+				t.Errorf("feed.Title = %q, want %q（feeds.title はポリシーによらず更新する）", feed.Title, tc.parsed)
+			}
+			if recorder.calls != tc.wantCalls {
+				t.Fatalf("calls = %d, want %d", recorder.calls, tc.wantCalls)
+			}
+			if tc.wantCalls > 0 && (recorder.previous != tc.current || recorder.title != tc.parsed) {
+				t.Errorf("recorded (%q, %q), want (%q, %q)", recorder.previous, recorder.title, tc.current, tc.parsed)
+			}
+		})
+	}
+}
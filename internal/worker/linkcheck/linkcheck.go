@@ -0,0 +1,223 @@
+// Package linkcheck はスター記事のリンク切れチェックジョブを提供する。
+// 公開から一定期間が経過したスター記事の元記事 URL を低頻度で確認し、
+// 404 / 410 やドメイン失効を items.link_status に記録する。
+// リクエストは SSRF 防止の事前検証と safeurl ベースのクライアントを必ず経由する。
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+	"github.com/hitoshi/feedman/internal/security"
+)
+
+// Config はリンク切れチェックジョブの設定パラメータ。
+type Config struct {
+	// Interval はジョブの実行間隔（デフォルト: 24時間）。
+	Interval time.Duration
+	// MinAge はチェック対象とするスター記事の公開からの経過期間（デフォルト: 30日）。
+	MinAge time.Duration
+	// RecheckInterval は同じ記事を再チェックするまでの間隔（デフォルト: 30日）。
+	RecheckInterval time.Duration
+	// BatchSize は 1 サイクルでチェックする最大記事数（デフォルト: 50）。
+	BatchSize int
+	// RequestInterval はリクエスト間の最低間隔（デフォルト: 2秒）。
+	RequestInterval time.Duration
+	// RequestTimeout は 1 リクエストあたりのタイムアウト（デフォルト: 15秒）。
+	RequestTimeout time.Duration
+}
+
+// DefaultConfig はデフォルトのリンク切れチェックジョブ設定を返す。
+func DefaultConfig() Config {
+	return Config{
+		Interval:        24 * time.Hour,
+		MinAge:          30 * 24 * time.Hour,
+		RecheckInterval: 30 * 24 * time.Hour,
+		BatchSize:       50,
+		RequestInterval: 2 * time.Second,
+		RequestTimeout:  15 * time.Second,
+	}
+}
+
+// Job はスター記事のリンク切れチェックジョブ。
+type Job struct {
+	repo   repository.LinkCheckRepository
+	guard  security.SSRFGuardService
+	client *http.Client
+	logger *slog.Logger
+	config Config
+	now    func() time.Time
+}
+
+// NewJob は Job の新しいインスタンスを生成する。
+// HTTP クライアントは guard.NewSafeClient で生成し、プライベート IP 等への接続を遮断する。
+func NewJob(
+	repo repository.LinkCheckRepository,
+	guard security.SSRFGuardService,
+	logger *slog.Logger,
+	config Config,
+) *Job {
+	return &Job{
+		repo:   repo,
+		guard:  guard,
+		client: guard.NewSafeClient(config.RequestTimeout, 0),
+		logger: logger,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Start はジョブをティッカーで定期実行する。
+// コンテキストがキャンセルされるまで実行を継続する。
+func (j *Job) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	j.logger.Info("リンク切れチェックジョブを開始しました",
+		slog.Duration("interval", j.config.Interval),
+		slog.Int("batch_size", j.config.BatchSize),
+	)
+
+	// 起動直後に1回実行
+	if err := j.RunOnce(ctx); err != nil {
+		j.logger.Error("リンク切れチェックサイクルの実行に失敗しました",
+			slog.String("error", err.Error()),
+		)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("リンク切れチェックジョブを停止しました")
+			return
+		case <-ticker.C:
+			if err := j.RunOnce(ctx); err != nil {
+				j.logger.Error("リンク切れチェックサイクルの実行に失敗しました",
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+}
+
+// RunOnce は1回のチェックサイクルを実行する。
+// 対象記事を 1 件ずつ RequestInterval 間隔でチェックし、結果を記録する。
+// 個別記事の記録失敗はログに残して次の記事へ進む。
+func (j *Job) RunOnce(ctx context.Context) error {
+	start := j.now()
+
+	targets, err := j.repo.ListLinkCheckTargets(
+		ctx,
+		start.Add(-j.config.MinAge),
+		start.Add(-j.config.RecheckInterval),
+		j.config.BatchSize,
+	)
+	if err != nil {
+		return fmt.Errorf("リンク切れチェック対象の取得に失敗しました: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	var brokenCount, inconclusiveCount int
+	for i, target := range targets {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(j.config.RequestInterval):
+			}
+		}
+
+		status := j.checkLink(ctx, target.Link)
+		switch {
+		case status.IsBroken():
+			brokenCount++
+		case status == "":
+			inconclusiveCount++
+		}
+
+		if err := j.repo.RecordLinkCheck(ctx, target.ItemID, status, j.now()); err != nil {
+			j.logger.Warn("リンク切れチェック結果の記録に失敗しました",
+				slog.String("item_id", target.ItemID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	j.logger.Info("リンク切れチェックサイクルが完了しました",
+		slog.Int("checked", len(targets)),
+		slog.Int("broken", brokenCount),
+		slog.Int("inconclusive", inconclusiveCount),
+		slog.Float64("duration_ms", float64(j.now().Sub(start).Milliseconds())),
+	)
+	return nil
+}
+
+// checkLink は元記事 URL の到達可否を判定する。
+// HEAD を送り、405 / 501 の場合のみ GET で再確認する。
+// 判定できない場合（SSRF 防止による拒否・タイムアウト・5xx 等）は空文字列を返す。
+func (j *Job) checkLink(ctx context.Context, rawURL string) model.LinkStatus {
+	if err := j.guard.ValidateURL(rawURL); err != nil {
+		return ""
+	}
+
+	code, err := j.request(ctx, http.MethodHead, rawURL)
+	if err == nil && (code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented) {
+		code, err = j.request(ctx, http.MethodGet, rawURL)
+	}
+	if err != nil {
+		return classifyError(err)
+	}
+	return classifyStatusCode(code)
+}
+
+// request は method で rawURL にリクエストを送り、ステータスコードを返す。
+// 本文は読まずに閉じる。
+func (j *Job) request(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "Feedman/1.0 RSS Reader")
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// classifyStatusCode は最終応答のステータスコードから判定結果を返す。
+// 404 / 410 のみをリンク切れとし、403 / 429 / 5xx 等は一時的な可能性があるため判定を保留する。
+func classifyStatusCode(code int) model.LinkStatus {
+	switch {
+	case code == http.StatusNotFound || code == http.StatusGone:
+		return model.LinkStatusNotFound
+	case code >= 200 && code < 400:
+		return model.LinkStatusOK
+	default:
+		return ""
+	}
+}
+
+// classifyError はリクエスト失敗の原因から判定結果を返す。
+// 名前解決でホストが存在しないと確定した場合のみドメイン失効とし、それ以外は判定を保留する。
+func classifyError(err error) model.LinkStatus {
+	if security.IsSSRFBlockedError(err) {
+		return ""
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return model.LinkStatusDomainUnresolvable
+	}
+	return ""
+}
@@ -0,0 +1,199 @@
+package linkcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hitoshi/feedman/internal/model"
+	"github.com/hitoshi/feedman/internal/repository"
+)
+
+// mockLinkCheckRepo は repository.LinkCheckRepository のモック実装。
+type mockLinkCheckRepo struct {
+	targets         []repository.LinkCheckTarget
+	listErr         error
+	publishedBefore time.Time
+	checkedBefore   time.Time
+	limit           int
+	recorded        map[string]model.LinkStatus
+}
+
+func (m *mockLinkCheckRepo) ListLinkCheckTargets(_ context.Context, publishedBefore, checkedBefore time.Time, limit int) ([]repository.LinkCheckTarget, error) {
+	m.publishedBefore = publishedBefore
+	m.checkedBefore = checkedBefore
+	m.limit = limit
+	return m.targets, m.listErr
+}
+
+func (m *mockLinkCheckRepo) RecordLinkCheck(_ context.Context, itemID string, status model.LinkStatus, _ time.Time) error {
+	if m.recorded == nil {
+		m.recorded = make(map[string]model.LinkStatus)
+	}
+	m.recorded[itemID] = status
+	return nil
+}
+
+// mockGuard は security.SSRFGuardService のモック実装。
+// テスト用の httptest サーバー（ループバック）へ接続できるよう通常のクライアントを返す。
+type mockGuard struct {
+	validateErr error
+}
+
+func (g *mockGuard) NewSafeClient(timeout time.Duration, _ int64) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+func (g *mockGuard) ValidateURL(_ string) error {
+	return g.validateErr
+}
+
+func newTestJob(repo *mockLinkCheckRepo, guard *mockGuard) *Job {
+	cfg := DefaultConfig()
+	cfg.RequestInterval = 0
+	return NewJob(repo, guard, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), cfg)
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 6, 20, 12, 0, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusGone) })
+	mux.HandleFunc("/error", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) })
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	t.Run("応答に応じて判定結果を記録し一時的な失敗は判定を保留する", func(t *testing.T) {
+		// Arrange
+		repo := &mockLinkCheckRepo{targets: []repository.LinkCheckTarget{
+			{ItemID: "ok", Link: srv.URL + "/ok"},
+			{ItemID: "gone", Link: srv.URL + "/gone"},
+			{ItemID: "missing", Link: srv.URL + "/missing"},
+			{ItemID: "error", Link: srv.URL + "/error"},
+			{ItemID: "no-head", Link: srv.URL + "/no-head"},
+		}}
+		job := newTestJob(repo, &mockGuard{})
+		job.now = func() time.Time { return now }
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		want := map[string]model.LinkStatus{
+			"ok":      model.LinkStatusOK,
+			"gone":    model.LinkStatusNotFound,
+			"missing": model.LinkStatusNotFound,
+			"error":   "",
+			"no-head": model.LinkStatusNotFound,
+		}
+		for id, status := range want {
+			got, ok := repo.recorded[id]
+			if !ok {
+				t.Errorf("%s: 結果が記録されていない", id)
+				continue
+			}
+			if got != status {
+				t.Errorf("%s: status = %q, want %q", id, got, status)
+			}
+		}
+		if want := now.Add(-DefaultConfig().MinAge); !repo.publishedBefore.Equal(want) {
+			t.Errorf("publishedBefore = %v, want %v", repo.publishedBefore, want)
+		}
+		if want := now.Add(-DefaultConfig().RecheckInterval); !repo.checkedBefore.Equal(want) {
+			t.Errorf("checkedBefore = %v, want %v", repo.checkedBefore, want)
+		}
+		if repo.limit != DefaultConfig().BatchSize {
+			t.Errorf("limit = %d, want %d", repo.limit, DefaultConfig().BatchSize)
+		}
+	})
+
+	t.Run("SSRF 防止の事前検証で拒否された URL にはリクエストせず判定を保留する", func(t *testing.T) {
+		// Arrange
+		var requested bool
+		blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requested = true
+		}))
+		defer blocked.Close()
+		repo := &mockLinkCheckRepo{targets: []repository.LinkCheckTarget{
+			{ItemID: "blocked", Link: blocked.URL},
+		}}
+		job := newTestJob(repo, &mockGuard{validateErr: errors.New("blocked")})
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if requested {
+			t.Error("拒否された URL にリクエストが送信された")
+		}
+		if got := repo.recorded["blocked"]; got != "" {
+			t.Errorf("status = %q, want empty", got)
+		}
+	})
+
+	t.Run("対象記事の取得に失敗したときエラーを返す", func(t *testing.T) {
+		// Arrange
+		job := newTestJob(&mockLinkCheckRepo{listErr: errors.New("db error")}, &mockGuard{})
+
+		// Act
+		err := job.RunOnce(context.Background())
+
+		// Assert
+		if err == nil {
+			t.Fatal("RunOnce() error = nil, want error")
+		}
+	})
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want model.LinkStatus
+	}{
+		{
+			name: "ホストが存在しないときドメイン失効と判定する",
+			err:  &url.Error{Op: "Head", URL: "https://gone.example", Err: &net.DNSError{Err: "no such host", Name: "gone.example", IsNotFound: true}},
+			want: model.LinkStatusDomainUnresolvable,
+		},
+		{
+			name: "名前解決が一時的に失敗したとき判定を保留する",
+			err:  &url.Error{Op: "Head", URL: "https://example.com", Err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}},
+			want: "",
+		},
+		{
+			name: "タイムアウトのとき判定を保留する",
+			err:  context.DeadlineExceeded,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.want {
+				t.Errorf("classifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package periodic は起動直後と一定間隔ごとに処理を実行する worker ジョブの共通処理を提供する。
+package periodic
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Run は起動直後と interval ごとに run を実行する。ctx がキャンセルされるまでブロックする。
+// name はログに出すジョブ名（例: 「ハイライトの計算」）で、run が返したエラーはログに記録して次の実行を待つ。
+func Run(ctx context.Context, logger *slog.Logger, name string, interval time.Duration, run func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Info(name+"ジョブを開始しました",
+		slog.Duration("interval", interval),
+	)
+
+	runOnce := func() {
+		if err := run(ctx); err != nil {
+			logger.Error(name+"ジョブの実行に失敗しました",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	runOnce()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info(name + "ジョブを停止しました")
+			return
+		case <-ticker.C:
+			runOnce()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package periodic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("起動直後と間隔ごとに実行し、キャンセルされたとき戻る", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		run := func(context.Context) error {
+			if calls.Add(1) >= 3 {
+				cancel()
+			}
+			return nil
+		}
+
+		// Act
+		done := make(chan struct{})
+		go func() {
+			Run(ctx, logger, "テスト", time.Millisecond, run)
+			close(done)
+		}()
+
+		// Assert
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run() did not return after cancel")
+		}
+		if got := calls.Load(); got < 3 {
+			t.Errorf("calls = %d, want >= 3", got)
+		}
+	})
+
+	t.Run("実行に失敗しても次の間隔で再び実行するとき", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls atomic.Int32
+		run := func(context.Context) error {
+			if calls.Add(1) >= 2 {
+				cancel()
+			}
+			return errors.New("db down")
+		}
+
+		// Act
+		Run(ctx, logger, "テスト", time.Millisecond, run)
+
+		// Assert
+		if got := calls.Load(); got < 2 {
+			t.Errorf("calls = %d, want >= 2", got)
+		}
+	})
+
+	t.Run("間隔が経過する前でも起動直後に 1 回実行するとき", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		run := func(context.Context) error {
+			calls.Add(1)
+			cancel()
+			return nil
+		}
+
+		// Act
+		Run(ctx, logger, "テスト", time.Hour, run)
+
+		// Assert
+		if got := calls.Load(); got != 1 {
+			t.Errorf("calls = %d, want 1", got)
+		}
+	})
+}
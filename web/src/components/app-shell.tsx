@@ -1,6 +1,6 @@
 "use client";
 
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import { useAppState, useAppDispatch } from "@/contexts/app-state";
 import { useFeeds } from "@/hooks/use-feeds";
 import { FeedList } from "@/components/feed-list";
@@ -42,6 +42,15 @@ export function AppShell() {
     null
   );
 
+  /**
+   * `/subscribe?url=` から誘導された購読確認対象の URL（`?subscribe=` クエリ）。
+   * 初回描画時に 1 度だけ読み取り、リロードで再表示されないようクエリを URL から取り除く。
+   */
+  const [subscribeUrl] = useState(readSubscribeQuery);
+  useEffect(() => {
+    if (subscribeUrl) clearSubscribeQuery();
+  }, [subscribeUrl]);
+
   /** フィード選択ハンドラ */
   const handleSelectFeed = (feedId: string) => {
     dispatch({ type: "SELECT_FEED", feedId });
@@ -117,7 +126,10 @@ export function AppShell() {
             <span className="text-sm font-medium text-muted-foreground">
               フィード
             </span>
-            <FeedRegisterDialog onRegistered={handleFeedRegistered} />
+            <FeedRegisterDialog
+              onRegistered={handleFeedRegistered}
+              initialUrl={subscribeUrl}
+            />
           </div>
 
           {isFeedsLoading ? (
@@ -236,3 +248,24 @@ function ThemeToggle() {
     </Button>
   );
 }
+
+/**
+ * `?subscribe=` クエリから購読確認対象の URL を読み取る。
+ * 未指定またはサーバーサイド描画時は undefined を返す。
+ */
+function readSubscribeQuery(): string | undefined {
+  if (typeof window === "undefined") return undefined;
+  return new URLSearchParams(window.location.search).get("subscribe") ?? undefined;
+}
+
+/** `?subscribe=` クエリを履歴から取り除く（他のクエリ・ハッシュは保持する）。 */
+function clearSubscribeQuery() {
+  const params = new URLSearchParams(window.location.search);
+  params.delete("subscribe");
+  const query = params.toString();
+  window.history.replaceState(
+    null,
+    "",
+    `${window.location.pathname}${query ? `?${query}` : ""}${window.location.hash}`
+  );
+}
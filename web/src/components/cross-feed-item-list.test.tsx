@@ -85,6 +85,7 @@ const mockPage1: CrossFeedListResponse = {
       is_read: false,
       is_starred: false,
       hatebu_count: 0,
+      reading_time_minutes: 0,
     },
     {
       id: "item-2",
@@ -99,6 +100,7 @@ const mockPage1: CrossFeedListResponse = {
       is_read: false,
       is_starred: false,
       hatebu_count: 3,
+      reading_time_minutes: 0,
     },
   ],
   next_cursor: null,
@@ -149,6 +151,7 @@ function setupMockFetch(page: CrossFeedListResponse) {
           is_read: false,
           is_starred: false,
           hatebu_count: 0,
+          reading_time_minutes: 0,
           hatebu_fetched_at: null,
         }),
       });
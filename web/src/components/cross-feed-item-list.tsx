@@ -251,6 +251,7 @@ function toItemSummary(item: CrossFeedItem): ItemSummary {
     is_read: item.is_read,
     is_starred: item.is_starred,
     hatebu_count: item.hatebu_count,
+    reading_time_minutes: item.reading_time_minutes,
     hatebu_fetched_at: null,
   };
 }
@@ -48,6 +48,23 @@ describe("FeedRegisterDialog コンポーネント", () => {
     expect(screen.getByText("フィードを登録")).toBeInTheDocument();
   });
 
+  it("initialUrl を指定したときダイアログが開いた状態で URL が入力済みになりAPIは呼ばれないこと", () => {
+    render(
+      <FeedRegisterDialog
+        onRegistered={() => {}}
+        initialUrl="https://example.com/blog"
+      />,
+      { wrapper: createWrapper() }
+    );
+
+    expect(screen.getByText("フィードを登録")).toBeInTheDocument();
+    expect(screen.getByPlaceholderText("https://example.com")).toHaveValue(
+      "https://example.com/blog"
+    );
+    // 登録はユーザーの確認操作（登録ボタン）で行う
+    expect(mockFetch).not.toHaveBeenCalled();
+  });
+
   it("URL入力欄が1つ表示されること", async () => {
     const user = userEvent.setup();
     render(<FeedRegisterDialog onRegistered={() => {}} />, {
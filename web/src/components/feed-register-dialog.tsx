@@ -22,6 +22,11 @@ import { ApiError } from "@/lib/api";
 interface FeedRegisterDialogProps {
   /** フィード登録成功時のコールバック */
   onRegistered: (feed: FeedRegistrationResponse) => void;
+  /**
+   * 初期表示する URL。指定された場合はダイアログを開いた状態で URL を入力済みにする
+   * （`/subscribe?url=` 経由の購読確認画面）。登録はユーザーの確認操作で行う。
+   */
+  initialUrl?: string;
 }
 
 /** ダイアログの状態（登録成功時は即座にダイアログを閉じるため success phase は持たない） */
@@ -37,9 +42,12 @@ type DialogState =
  * 登録成功時はダイアログを自動で閉じ、左ペインのフィード一覧キャッシュを無効化する。
  * エラー表示（フィード未検出、購読上限到達、想定外エラー）を原因カテゴリと対処方法付きで表示する。
  */
-export function FeedRegisterDialog({ onRegistered }: FeedRegisterDialogProps) {
-  const [open, setOpen] = useState(false);
-  const [url, setUrl] = useState("");
+export function FeedRegisterDialog({
+  onRegistered,
+  initialUrl,
+}: FeedRegisterDialogProps) {
+  const [open, setOpen] = useState(Boolean(initialUrl));
+  const [url, setUrl] = useState(initialUrl ?? "");
   const [dialogState, setDialogState] = useState<DialogState>({
     phase: "input",
   });
@@ -38,6 +38,7 @@ const mockItem: ItemDetailType = {
   is_read: true,
   is_starred: false,
   hatebu_count: 42,
+  reading_time_minutes: 0,
   hatebu_fetched_at: "2026-02-27T09:00:00Z",
   content: "<p>これはテスト記事の<strong>本文</strong>です。</p>",
   summary: "テスト記事のサマリー",
@@ -49,6 +50,7 @@ const mockItemNoHatebu: ItemDetailType = {
   ...mockItem,
   id: "item-2",
   hatebu_count: 0,
+  reading_time_minutes: 0,
   hatebu_fetched_at: null,
 };
 
@@ -152,6 +154,7 @@ describe("ItemDetail コンポーネント", () => {
     const zeroItem: ItemDetailType = {
       ...mockItem,
       hatebu_count: 0,
+      reading_time_minutes: 0,
       hatebu_fetched_at: "2026-02-27T09:00:00Z",
     };
 
@@ -1,7 +1,7 @@
 "use client";
 
 import { useEffect, useLayoutEffect, useMemo, useRef, useState } from "react";
-import { ExternalLink, Star, Bookmark } from "lucide-react";
+import { ExternalLink, Star, Bookmark, Clock } from "lucide-react";
 import { Button } from "@/components/ui/button";
 import { cn } from "@/lib/utils";
 import { sanitizeContentHtml } from "@/lib/sanitize";
@@ -106,12 +106,23 @@ export function ItemDetail({
             </a>
           </h3>
 
-          {/* タイトル右側のメタ情報グループ（はてブ数 + スター切替）。
+          {/* タイトル右側のメタ情報グループ（読了時間 + はてブ数 + スター切替）。
              縮小せず常にタイトル右端に整列する（Req 1.3, 1.4, 1.5）。 */}
           <div
             data-testid="item-detail-meta-group"
             className="flex flex-shrink-0 items-center gap-1"
           >
+            {/* 読了時間の目安（本文が無く 0 分の場合は表示しない） */}
+            {item.reading_time_minutes > 0 && (
+              <span
+                data-testid="reading-time"
+                className="inline-flex items-center gap-1 text-sm text-muted-foreground px-1"
+              >
+                <Clock className="w-4 h-4" aria-hidden="true" />
+                {item.reading_time_minutes} 分で読める
+              </span>
+            )}
+
             {/* はてなブックマーク数（アイコン + 数値、ツールチップで意味を補足） */}
             <span
               data-testid="hatebu-count"
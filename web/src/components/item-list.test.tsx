@@ -53,6 +53,7 @@ const mockItemsResponse: ItemListResponse = {
       is_read: false,
       is_starred: false,
       hatebu_count: 10,
+      reading_time_minutes: 0,
       hatebu_fetched_at: "2026-02-27T09:00:00Z",
     },
     {
@@ -66,6 +67,7 @@ const mockItemsResponse: ItemListResponse = {
       is_read: true,
       is_starred: true,
       hatebu_count: 0,
+      reading_time_minutes: 0,
       hatebu_fetched_at: null,
     },
   ],
@@ -102,6 +104,7 @@ const mockItemDetail: ItemDetail = {
   is_read: false,
   is_starred: false,
   hatebu_count: 10,
+  reading_time_minutes: 0,
   hatebu_fetched_at: "2026-02-27T09:00:00Z",
   content: "<p>これは記事の本文です</p>",
   summary: "記事の要約",
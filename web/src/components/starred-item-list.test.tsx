@@ -93,6 +93,7 @@ const mockStarredPage1: StarredItemListResponse = {
       is_read: false,
       is_starred: true,
       hatebu_count: 10,
+      reading_time_minutes: 0,
       hatebu_fetched_at: "2026-02-27T09:00:00Z",
     },
     {
@@ -107,6 +108,7 @@ const mockStarredPage1: StarredItemListResponse = {
       is_read: false,
       is_starred: true,
       hatebu_count: 5,
+      reading_time_minutes: 0,
       hatebu_fetched_at: null,
     },
   ],
@@ -129,6 +131,7 @@ const mockStarredPage2: StarredItemListResponse = {
       is_read: false,
       is_starred: true,
       hatebu_count: 0,
+      reading_time_minutes: 0,
       hatebu_fetched_at: null,
     },
   ],
@@ -298,6 +301,7 @@ describe("StarredItemList コンポーネント", () => {
             is_read: false,
             is_starred: true,
             hatebu_count: 10,
+            reading_time_minutes: 0,
             hatebu_fetched_at: "2026-02-27T09:00:00Z",
             content: "<p>本文</p>",
             author: "",
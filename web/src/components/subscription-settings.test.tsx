@@ -269,4 +269,110 @@ describe("SubscriptionSettings コンポーネント", () => {
       );
     });
   });
+
+  it("URL の張り替え提案がない停止中フィードでは提案が表示されないこと", () => {
+    render(
+      <SubscriptionSettings
+        subscription={mockStoppedSubscription}
+        onUnsubscribed={() => {}}
+      />,
+      { wrapper: createWrapper() }
+    );
+
+    expect(
+      screen.queryByTestId("apply-suggested-url-button")
+    ).not.toBeInTheDocument();
+  });
+
+  it("張り替え提案のボタンをクリックすると提案 URL への変更 API が呼ばれること", async () => {
+    const user = userEvent.setup();
+
+    render(
+      <SubscriptionSettings
+        subscription={{
+          ...mockStoppedSubscription,
+          suggested_feed_url: "https://example.com/new-feed.xml",
+        }}
+        onUnsubscribed={() => {}}
+      />,
+      { wrapper: createWrapper() }
+    );
+
+    expect(
+      screen.getByText("https://example.com/new-feed.xml")
+    ).toBeInTheDocument();
+
+    await user.click(screen.getByTestId("apply-suggested-url-button"));
+
+    await waitFor(() => {
+      expect(mockFetch).toHaveBeenCalledWith(
+        "/api/subscriptions/sub-2/apply-suggested-url",
+        expect.objectContaining({ method: "POST" })
+      );
+    });
+  });
+
+  it("承認待ちのタイトルがない場合は承認ボタンが表示されないこと", () => {
+    render(
+      <SubscriptionSettings
+        subscription={mockActiveSubscription}
+        onUnsubscribed={() => {}}
+      />,
+      { wrapper: createWrapper() }
+    );
+
+    expect(screen.queryByTestId("pending-feed-title")).not.toBeInTheDocument();
+  });
+
+  it("承認待ちのタイトルを承認すると承認 API が呼ばれること", async () => {
+    const user = userEvent.setup();
+
+    render(
+      <SubscriptionSettings
+        subscription={{
+          ...mockActiveSubscription,
+          feed_title_update_policy: "manual",
+          pending_feed_title: "Renamed Blog",
+        }}
+        onUnsubscribed={() => {}}
+      />,
+      { wrapper: createWrapper() }
+    );
+
+    expect(screen.getByText("Renamed Blog")).toBeInTheDocument();
+
+    await user.click(screen.getByTestId("approve-title-button"));
+
+    await waitFor(() => {
+      expect(mockFetch).toHaveBeenCalledWith(
+        "/api/feeds/feed-1/title/approve",
+        expect.objectContaining({ method: "POST" })
+      );
+    });
+  });
+
+  it("承認待ちのタイトルを破棄すると破棄 API が呼ばれること", async () => {
+    const user = userEvent.setup();
+
+    render(
+      <SubscriptionSettings
+        subscription={{
+          ...mockActiveSubscription,
+          feed_title_update_policy: "manual",
+          pending_feed_title: "Renamed Blog",
+        }}
+        onUnsubscribed={() => {}}
+      />,
+      { wrapper: createWrapper() }
+    );
+
+    await user.click(screen.getByTestId("dismiss-title-button"));
+
+    await waitFor(() => {
+      expect(mockFetch).toHaveBeenCalledWith(
+        "/api/feeds/feed-1/title/dismiss",
+        expect.objectContaining({ method: "POST" })
+      );
+    });
+  });
 });
@@ -24,8 +24,12 @@ import {
   useUpdateFetchInterval,
   useUnsubscribe,
   useResumeFeed,
+  useApplySuggestedFeedURL,
+  useUpdateTitleUpdatePolicy,
+  useApprovePendingFeedTitle,
+  useDismissPendingFeedTitle,
 } from "@/hooks/use-subscriptions";
-import type { Subscription } from "@/types/feed";
+import type { Subscription, TitleUpdatePolicy } from "@/types/feed";
 
 /** SubscriptionSettings コンポーネントのプロパティ */
 interface SubscriptionSettingsProps {
@@ -69,11 +73,19 @@ const FETCH_INTERVAL_OPTIONS = [
   { value: 720, label: "12時間" },
 ];
 
+/** フィードタイトル自動更新ポリシーの選択肢 */
+const TITLE_UPDATE_POLICY_OPTIONS: { value: TitleUpdatePolicy; label: string }[] =
+  [
+    { value: "always", label: "常に追従" },
+    { value: "initial", label: "初回のみ" },
+    { value: "manual", label: "変更時に確認" },
+  ];
+
 /**
  * 購読設定と管理UIコンポーネント
  *
- * フェッチ間隔設定の変更、購読解除の確認ダイアログ、
- * 停止中フィードの再開ボタンを提供する。
+ * フェッチ間隔・フィードタイトル追従の設定変更、承認待ちタイトルの承認・破棄、
+ * 購読解除の確認ダイアログ、停止中フィードの再開ボタンを提供する。
  */
 export function SubscriptionSettings({
   subscription,
@@ -84,6 +96,10 @@ export function SubscriptionSettings({
   const updateInterval = useUpdateFetchInterval();
   const unsubscribe = useUnsubscribe();
   const resumeFeed = useResumeFeed();
+  const applySuggestedURL = useApplySuggestedFeedURL();
+  const updateTitlePolicy = useUpdateTitleUpdatePolicy();
+  const approveTitle = useApprovePendingFeedTitle();
+  const dismissTitle = useDismissPendingFeedTitle();
 
   /** フェッチ間隔変更ハンドラ */
   const handleIntervalChange = (value: string) => {
@@ -109,6 +125,19 @@ export function SubscriptionSettings({
     resumeFeed.mutate(subscription.feed_id);
   };
 
+  /** 提案 URL への張り替えハンドラ */
+  const handleApplySuggestedURL = () => {
+    applySuggestedURL.mutate(subscription.id);
+  };
+
+  /** フィードタイトル自動更新ポリシー変更ハンドラ */
+  const handleTitlePolicyChange = (value: string) => {
+    updateTitlePolicy.mutate({
+      feedId: subscription.feed_id,
+      policy: value as TitleUpdatePolicy,
+    });
+  };
+
   const isStopped =
     subscription.feed_status === "stopped" ||
     subscription.feed_status === "error";
@@ -128,6 +157,63 @@ export function SubscriptionSettings({
                 {subscription.error_message}
               </p>
             )}
+            {/* サイトから再検出したフィード URL の張り替え提案 */}
+            {subscription.suggested_feed_url && (
+              <div className="mt-2 space-y-1" data-testid="suggested-feed-url">
+                <p className="text-muted-foreground">
+                  新しいフィード URL が見つかりました:{" "}
+                  <span className="break-all">
+                    {subscription.suggested_feed_url}
+                  </span>
+                </p>
+                <Button
+                  variant="outline"
+                  size="sm"
+                  data-testid="apply-suggested-url-button"
+                  onClick={handleApplySuggestedURL}
+                  disabled={applySuggestedURL.isPending}
+                >
+                  {applySuggestedURL.isPending
+                    ? "変更中..."
+                    : "この URL に変更して再開"}
+                </Button>
+              </div>
+            )}
+          </div>
+        </div>
+      )}
+
+      {/* 承認待ちのフィードタイトル（ポリシー manual で変更を検知した場合） */}
+      {subscription.pending_feed_title && (
+        <div
+          className="rounded-md border p-3 text-sm space-y-2"
+          data-testid="pending-feed-title"
+        >
+          <p className="text-muted-foreground">
+            フィードのタイトルが変更されています:{" "}
+            <span className="font-medium text-foreground break-all">
+              {subscription.pending_feed_title}
+            </span>
+          </p>
+          <div className="flex items-center gap-2">
+            <Button
+              variant="outline"
+              size="sm"
+              data-testid="approve-title-button"
+              onClick={() => approveTitle.mutate(subscription.feed_id)}
+              disabled={approveTitle.isPending || dismissTitle.isPending}
+            >
+              このタイトルに変更
+            </Button>
+            <Button
+              variant="ghost"
+              size="sm"
+              data-testid="dismiss-title-button"
+              onClick={() => dismissTitle.mutate(subscription.feed_id)}
+              disabled={approveTitle.isPending || dismissTitle.isPending}
+            >
+              現在のタイトルを維持
+            </Button>
           </div>
         </div>
       )}
@@ -158,6 +244,32 @@ export function SubscriptionSettings({
         </Select>
       </div>
 
+      {/* フィードタイトル追従設定 */}
+      <div className="flex items-center gap-3">
+        <label className="text-sm font-medium whitespace-nowrap">
+          タイトル追従
+        </label>
+        <Select
+          value={subscription.feed_title_update_policy ?? "always"}
+          onValueChange={handleTitlePolicyChange}
+          disabled={updateTitlePolicy.isPending}
+        >
+          <SelectTrigger
+            data-testid="title-policy-select"
+            className="w-[180px]"
+          >
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            {TITLE_UPDATE_POLICY_OPTIONS.map((option) => (
+              <SelectItem key={option.value} value={option.value}>
+                {option.label}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+      </div>
+
       {/* アクションボタン */}
       <div className="flex items-center gap-2">
         {/* 停止中フィードの再開ボタン */}
@@ -63,6 +63,7 @@ const mockPage1: CrossFeedListResponse = {
       is_read: false,
       is_starred: false,
       hatebu_count: 0,
+      reading_time_minutes: 0,
     },
     {
       id: "item-2",
@@ -77,6 +78,7 @@ const mockPage1: CrossFeedListResponse = {
       is_read: false,
       is_starred: false,
       hatebu_count: 3,
+      reading_time_minutes: 0,
     },
   ],
   next_cursor: "2026-05-27T09:00:00Z:item-2",
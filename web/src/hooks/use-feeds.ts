@@ -2,7 +2,7 @@
 
 import { useQuery } from "@tanstack/react-query";
 import { apiClient } from "@/lib/api";
-import type { Subscription } from "@/types/feed";
+import type { RelatedFeed, Subscription } from "@/types/feed";
 
 /**
  * フィード一覧を取得するカスタムフック
@@ -16,3 +16,24 @@ export function useFeeds() {
     queryFn: () => apiClient.get<Subscription[]>("/api/subscriptions"),
   });
 }
+
+/**
+ * 同じサイトの関連フィードを取得するカスタムフック
+ *
+ * GET /api/feeds/:id/related を呼び出し、カテゴリ別 RSS などの別フィードと購読状況を返す。
+ * feedId が null の場合はクエリを無効化し、リクエストを送信しない。
+ *
+ * @param feedId - 基準となるフィードID（null の場合はクエリ無効化）
+ */
+export function useRelatedFeeds(feedId: string | null) {
+  return useQuery<RelatedFeed[]>({
+    queryKey: ["related-feeds", feedId],
+    queryFn: async () => {
+      const res = await apiClient.get<{ feeds: RelatedFeed[] }>(
+        `/api/feeds/${feedId}/related`,
+      );
+      return res.feeds;
+    },
+    enabled: feedId !== null,
+  });
+}
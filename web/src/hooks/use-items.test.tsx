@@ -39,6 +39,7 @@ const mockPage1: ItemListResponse = {
       is_read: false,
       is_starred: false,
       hatebu_count: 10,
+      reading_time_minutes: 0,
       hatebu_fetched_at: "2026-02-27T09:00:00Z",
     },
     {
@@ -52,6 +53,7 @@ const mockPage1: ItemListResponse = {
       is_read: true,
       is_starred: true,
       hatebu_count: 0,
+      reading_time_minutes: 0,
       hatebu_fetched_at: null,
     },
   ],
@@ -73,6 +75,7 @@ const mockPage2: ItemListResponse = {
       is_read: false,
       is_starred: false,
       hatebu_count: 5,
+      reading_time_minutes: 0,
       hatebu_fetched_at: "2026-02-26T00:00:00Z",
     },
   ],
@@ -242,6 +245,7 @@ const mockItemDetail: ItemDetail = {
   is_read: false,
   is_starred: false,
   hatebu_count: 10,
+  reading_time_minutes: 0,
   hatebu_fetched_at: "2026-02-27T09:00:00Z",
   content: "<p>記事本文</p>",
   summary: "記事の要約",
@@ -40,6 +40,7 @@ const mockStarredPage1: StarredItemListResponse = {
       is_read: false,
       is_starred: true,
       hatebu_count: 10,
+      reading_time_minutes: 0,
       hatebu_fetched_at: "2026-02-27T09:00:00Z",
     },
     {
@@ -54,6 +55,7 @@ const mockStarredPage1: StarredItemListResponse = {
       is_read: true,
       is_starred: true,
       hatebu_count: 0,
+      reading_time_minutes: 0,
       hatebu_fetched_at: null,
     },
   ],
@@ -76,6 +78,7 @@ const mockStarredPage2: StarredItemListResponse = {
       is_read: false,
       is_starred: true,
       hatebu_count: 5,
+      reading_time_minutes: 0,
       hatebu_fetched_at: "2026-02-26T00:00:00Z",
     },
   ],
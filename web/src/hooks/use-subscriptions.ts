@@ -2,6 +2,7 @@
 
 import { useMutation, useQueryClient } from "@tanstack/react-query";
 import { apiClient } from "@/lib/api";
+import type { TitleUpdatePolicy } from "@/types/feed";
 
 /** フェッチ間隔更新のパラメータ */
 interface UpdateFetchIntervalParams {
@@ -49,6 +50,24 @@ export function useUnsubscribe() {
   });
 }
 
+/**
+ * 購読解除を取り消すmutationフック
+ *
+ * POST /api/subscriptions/:id/restore を呼び出す。猶予期間内のみ成功し、期限切れは 410 を返す。
+ * 成功時にfeedsキャッシュを無効化する。
+ */
+export function useRestoreSubscription() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (subscriptionId: string) =>
+      apiClient.post(`/api/subscriptions/${subscriptionId}/restore`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["feeds"] });
+    },
+  });
+}
+
 /**
  * 停止中フィードのフェッチを再開するmutationフック
  *
@@ -65,3 +84,79 @@ export function useResumeFeed() {
     },
   });
 }
+
+/**
+ * 停止中フィードの URL を再検出した提案 URL に張り替えるmutationフック
+ *
+ * POST /api/subscriptions/:id/apply-suggested-url を呼び出す。張り替えと同時にフェッチを再開する。
+ * 成功時にfeedsキャッシュを無効化する。
+ */
+export function useApplySuggestedFeedURL() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (subscriptionId: string) =>
+      apiClient.post(`/api/subscriptions/${subscriptionId}/apply-suggested-url`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["feeds"] });
+    },
+  });
+}
+
+/** フィードタイトル自動更新ポリシー更新のパラメータ */
+interface UpdateTitleUpdatePolicyParams {
+  feedId: string;
+  policy: TitleUpdatePolicy;
+}
+
+/**
+ * フィードタイトルの自動更新ポリシーを更新するmutationフック
+ *
+ * PUT /api/feeds/:id/title-policy に { policy } を送信する。
+ * フィードは購読者間で共有されるため、ポリシーは同じフィードの全購読者に反映される。
+ */
+export function useUpdateTitleUpdatePolicy() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: ({ feedId, policy }: UpdateTitleUpdatePolicyParams) =>
+      apiClient.put(`/api/feeds/${feedId}/title-policy`, { policy }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["feeds"] });
+    },
+  });
+}
+
+/**
+ * 承認待ちのフィードタイトルを反映するmutationフック
+ *
+ * POST /api/feeds/:id/title/approve を呼び出す。
+ */
+export function useApprovePendingFeedTitle() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (feedId: string) =>
+      apiClient.post(`/api/feeds/${feedId}/title/approve`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["feeds"] });
+    },
+  });
+}
+
+/**
+ * 承認待ちのフィードタイトルを破棄するmutationフック
+ *
+ * POST /api/feeds/:id/title/dismiss を呼び出す。現在のタイトルは維持される。
+ */
+export function useDismissPendingFeedTitle() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (feedId: string) =>
+      apiClient.post(`/api/feeds/${feedId}/title/dismiss`),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["feeds"] });
+    },
+  });
+}
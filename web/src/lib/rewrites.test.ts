@@ -8,7 +8,7 @@ import {
 } from "./rewrites";
 
 describe("buildRewrites", () => {
-  it("base が与えられたとき /api/:path* と /auth/:path* の 2 ルールをプレフィックス保持で返すこと", () => {
+  it("base が与えられたとき /api/:path*・/auth/:path*・/subscribe の 3 ルールをプレフィックス保持で返すこと", () => {
     // Arrange
     const base = "http://api:8080";
 
@@ -19,6 +19,7 @@ describe("buildRewrites", () => {
     expect(rules).toEqual([
       { source: "/api/:path*", destination: "http://api:8080/api/:path*" },
       { source: "/auth/:path*", destination: "http://api:8080/auth/:path*" },
+      { source: "/subscribe", destination: "http://api:8080/subscribe" },
     ]);
   });
 
@@ -33,6 +34,7 @@ describe("buildRewrites", () => {
     expect(rules).toEqual([
       { source: "/api/:path*", destination: "http://api:8080/api/:path*" },
       { source: "/auth/:path*", destination: "http://api:8080/auth/:path*" },
+      { source: "/subscribe", destination: "http://api:8080/subscribe" },
     ]);
   });
 
@@ -46,6 +48,7 @@ describe("buildRewrites", () => {
     // Assert
     expect(rules[0].destination).toBe("https://example.com/api/:path*");
     expect(rules[1].destination).toBe("https://example.com/auth/:path*");
+    expect(rules[2].destination).toBe("https://example.com/subscribe");
   });
 });
 
@@ -70,9 +70,10 @@ export function resolveApiInternalUrlForRewrites(
 /**
  * 与えられた api 接続先 base から rewrites ルール配列を生成する純粋関数。
  * `/api/:path*` と `/auth/:path*` をプレフィックス保持で転送する（strip しない）。
+ * ブラウザ拡張・ブックマークレットの購読追加入口 `/subscribe` も API へ転送する。
  *
  * @param apiInternalUrl - 正規化済み（末尾スラッシュ除去済み）の内部 API 接続先 base
- * @returns `/api/:path*`・`/auth/:path*` の 2 ルール
+ * @returns `/api/:path*`・`/auth/:path*`・`/subscribe` の 3 ルール
  */
 export function buildRewrites(apiInternalUrl: string): Rewrite[] {
   const base = apiInternalUrl.replace(/\/+$/, "");
@@ -80,5 +81,6 @@ export function buildRewrites(apiInternalUrl: string): Rewrite[] {
   return [
     { source: "/api/:path*", destination: `${base}/api/:path*` },
     { source: "/auth/:path*", destination: `${base}/auth/:path*` },
+    { source: "/subscribe", destination: `${base}/subscribe` },
   ];
 }
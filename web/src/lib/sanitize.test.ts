@@ -114,6 +114,31 @@ describe("sanitizeContentHtml", () => {
     expect(result).toContain("記事");
   });
 
+  it("target=_blankを持つリンクが含まれるときtargetを保持しrelを強制付与すること", () => {
+    // Arrange
+    const input = '<a href="https://example.com/" target="_blank" rel="opener">記事</a>';
+
+    // Act
+    const result = sanitizeContentHtml(input);
+
+    // Assert
+    expect(result).toContain('target="_blank"');
+    expect(result).toContain('rel="noopener noreferrer"');
+    expect(result).not.toContain('rel="opener"');
+  });
+
+  it("target=_blank以外のtargetが含まれるとき当該属性を除去すること", () => {
+    // Arrange
+    const input = '<a href="https://example.com/" target="_top">記事</a>';
+
+    // Act
+    const result = sanitizeContentHtml(input);
+
+    // Assert
+    expect(result).not.toContain("target=");
+    expect(result).toContain('rel="noopener noreferrer"');
+  });
+
   // Req 3.2: 許可された URL 属性を持つ画像の保持
   it("https srcを持つ画像が含まれるとき当該画像を保持して描画すること", () => {
     // Arrange
@@ -9,7 +9,7 @@
  *
  * 許可タグ・許可属性はバックエンド bluemonday ポリシーと整合する範囲に維持する:
  *   - 許可タグ: p, br, a, ul, ol, li, blockquote, pre, code, strong, em, img
- *   - a タグ: href（target / rel は描画側で扱う）
+ *   - a タグ: href, target（`_blank` のみ）, rel（常に `noopener noreferrer` を強制）
  *   - img タグ: src（https スキームのみ）, alt
  *   - script, iframe, style 要素および on* イベント属性は除去
  *   - javascript: などの危険スキームは無効化
@@ -42,10 +42,32 @@ const ALLOWED_TAGS = [
 
 /**
  * フロント側サニタイズで保持を許可する属性の集合。
- * a タグの href、img タグの src / alt のみを許可する。
+ * a タグの href / target / rel、img タグの src / alt のみを許可する。
+ * target / rel はバックエンドがユーザー設定（リンクを新しいタブで開く）に応じて付与する。
  * on* イベントハンドラ属性は許可リストに含めないことで除去される。
  */
-const ALLOWED_ATTR = ["href", "src", "alt"] as const;
+const ALLOWED_ATTR = ["href", "target", "rel", "src", "alt"] as const;
+
+/**
+ * a タグに強制する rel 属性値。
+ * バックエンド `security.LinkRel` と揃え、遷移先からの window.opener 参照とリファラ送信を防ぐ。
+ */
+const LINK_REL = "noopener noreferrer";
+
+/**
+ * a タグの rel を LINK_REL に置き換え、target は `_blank` 以外を除去する DOMPurify フック。
+ *
+ * @param node - サニタイズ中の要素
+ */
+function enforceLinkAttributes(node: Element): void {
+  if (node.tagName !== "A") {
+    return;
+  }
+  node.setAttribute("rel", LINK_REL);
+  if (node.hasAttribute("target") && node.getAttribute("target") !== "_blank") {
+    node.removeAttribute("target");
+  }
+}
 
 /**
  * 許可する URI スキームの集合（正規表現）。
@@ -91,9 +113,15 @@ export function sanitizeContentHtml(rawHtml: string): string {
     return "";
   }
 
-  return DOMPurify.sanitize(rawHtml, {
-    ALLOWED_TAGS: [...ALLOWED_TAGS],
-    ALLOWED_ATTR: [...ALLOWED_ATTR],
-    ALLOWED_URI_REGEXP,
-  });
+  // フックは DOMPurify インスタンス全体に作用するため、本呼び出しの間だけ登録する
+  DOMPurify.addHook("afterSanitizeAttributes", enforceLinkAttributes);
+  try {
+    return DOMPurify.sanitize(rawHtml, {
+      ALLOWED_TAGS: [...ALLOWED_TAGS],
+      ALLOWED_ATTR: [...ALLOWED_ATTR],
+      ALLOWED_URI_REGEXP,
+    });
+  } finally {
+    DOMPurify.removeHook("afterSanitizeAttributes");
+  }
 }
@@ -11,6 +11,9 @@ export interface ApiErrorResponse {
   message: string;
   category: string; // auth, validation, feed, system
   action: string; // ユーザー向け対処方法
+  details?: Record<string, unknown>;
+  request_id?: string; // サポート問い合わせ時にサーバーログと照合するリクエスト ID
+  docs_url?: string; // エラーコードの解説ページ URL
 }
 
 /** フィード登録レスポンスの型 */
@@ -22,4 +25,18 @@ export interface FeedRegistrationResponse {
   favicon_url?: string | null;
   feed_status: string;
   created_at: string;
+  /** お試し購読として登録した場合の購読の期限（ISO 8601） */
+  subscription_expires_at?: string;
+  /**
+   * フィードの著作権・利用条件の表記（RSS の copyright / Atom の rights）。表記がない場合は空文字。
+   * copyright / ttl_minutes / robots / noindex はフィードの取得時に保存するため、新規フィードの登録直後は未取得
+   * （空文字 / null / false）で、最初の取得後に GET /api/feeds/{id} で反映される。
+   */
+  copyright?: string;
+  /** フィードが示すキャッシュ有効期間（RSS の ttl、分）。指定がない場合は null */
+  ttl_minutes?: number | null;
+  /** フィードのロボット向け指示（例: "noindex, noarchive"）。指定がない場合は空文字 */
+  robots?: string;
+  /** フィードが noindex（または none）を指示しているか */
+  noindex?: boolean;
 }
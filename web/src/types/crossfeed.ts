@@ -30,13 +30,15 @@ export interface CrossFeedItem {
   is_read: boolean;
   is_starred: boolean;
   hatebu_count: number;
+  /** 本文から推定した読了時間（分）。本文が無い場合は 0 */
+  reading_time_minutes: number;
 }
 
 /**
  * GET /api/items/cross-feed のレスポンス。
  *
  * カーソルベースページネーション（50 件/回、上限 200）。
- * `next_cursor` は `<RFC3339Nano>:<itemID>` 形式の文字列または null。
+ * `next_cursor` は不透明なカーソル文字列または null（中身を解釈せずそのまま `cursor` に渡す）。
  * `has_more` が false のとき次ページなしとして扱う。
  * `since_time` は当該レスポンスでサーバが採用した新着判定基準時刻（RFC3339）。
  */
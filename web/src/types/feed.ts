@@ -8,6 +8,15 @@
 /** フィードのフェッチステータス */
 export type FeedStatus = "active" | "stopped" | "error";
 
+/**
+ * フィードタイトルの自動更新ポリシー
+ *
+ * - always: フェッチのたびにフィードのタイトルへ追従する（既定）
+ * - initial: 初回取得時のみ設定し、以後は変更しない
+ * - manual: 変更を検知したら承認待ちにして、承認されるまで反映しない
+ */
+export type TitleUpdatePolicy = "always" | "initial" | "manual";
+
 /** 購読情報（フィード一覧表示用） */
 export interface Subscription {
   id: string;
@@ -20,9 +29,37 @@ export interface Subscription {
   feed_status: FeedStatus;
   error_message?: string | null;
   unread_count: number;
+  /** フィードの言語（RSS の language / Atom の xml:lang）。未取得時は空文字 */
+  feed_language?: string;
+  /** フィードの説明文（プレーンテキスト）。未取得時は空文字 */
+  feed_description?: string;
+  /** フィード内で観測した記事の最新公開日時（ISO 8601）。未取得時は null */
+  feed_last_published_at?: string | null;
+  /** お試し購読の期限（ISO 8601）。期限を過ぎると自動で購読解除される。通常の購読は null */
+  expires_at?: string | null;
+  /** 404/410 で停止したフィードについてサイトから再検出した新しいフィード URL。提案がない場合は null */
+  suggested_feed_url?: string | null;
+  /** フィードタイトルの自動更新ポリシー */
+  feed_title_update_policy?: TitleUpdatePolicy;
+  /** manual のときに検知した承認待ちの新しいタイトル。ない場合は null */
+  pending_feed_title?: string | null;
   created_at: string;
 }
 
+/** 同じサイト（ホスト）の関連フィード（GET /api/feeds/:id/related） */
+export interface RelatedFeed {
+  feed_id: string;
+  title: string;
+  feed_url: string;
+  site_url: string;
+  fetch_status: FeedStatus;
+  /** フィード内で観測した記事の最新公開日時（ISO 8601）。未取得時は null */
+  last_published_at: string | null;
+  subscribed: boolean;
+  /** 購読済みの場合の購読ID。未購読の場合は null */
+  subscription_id: string | null;
+}
+
 /**
  * 手動フェッチ API のエラーレスポンスボディ。
  *
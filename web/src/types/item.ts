@@ -20,7 +20,13 @@ export interface ItemSummary {
   is_date_estimated: boolean;
   is_read: boolean;
   is_starred: boolean;
+  /** 非表示（ミュート）にした記事か。include_hidden=true で取得した非表示の記事でのみ true が返る */
+  is_hidden?: boolean;
+  /** 評価（1〜5）。未評価の記事では省略される。評価 1 以上の記事は is_starred も true */
+  rating?: number;
   hatebu_count: number;
+  /** 本文から推定した読了時間（分）。本文が無い場合は 0 */
+  reading_time_minutes: number;
   /** はてなブックマーク取得日時（未取得時はnull） */
   hatebu_fetched_at: string | null;
 }
@@ -43,6 +49,9 @@ export interface ItemListResponse {
 export interface ItemStateRequest {
   is_read?: boolean | null;
   is_starred?: boolean | null;
+  is_hidden?: boolean | null;
+  /** 評価（0〜5）。0 で評価とスターを解除する */
+  rating?: number | null;
 }
 
 /**
@@ -113,8 +122,8 @@ export interface ItemSearchHit {
 /**
  * 検索 API（GET /api/items/search）のレスポンス。
  *
- * カーソルベースページネーション形式で、`next_cursor` は `<RFC3339Nano>|<uuid>` 形式の
- * 文字列または null。`has_more` が false のとき、または `next_cursor` が null / 空文字の
+ * カーソルベースページネーション形式で、`next_cursor` は不透明なカーソル文字列
+ * または null（中身を解釈せずそのまま `cursor` に渡す）。`has_more` が false のとき、または `next_cursor` が null / 空文字の
  * ときは次ページなしとして扱う（impl-notes Task 4 / 5 の判断と整合）。
  */
 export interface ItemSearchResponse {